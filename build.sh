@@ -127,12 +127,32 @@ function build_binary() {
     echo "Creating directory structure..."
     mkdir -p "$OUTPUT_DIR/repository/conf"
     
+    # The SQLite database driver needs cgo, which is only used when building for the host platform
+    local cgo_enabled=0
+    if [ "$GO_OS" = "$DEFAULT_OS" ] && [ "$GO_ARCH" = "$DEFAULT_ARCH" ]; then
+        cgo_enabled=${CGO_ENABLED:-1}
+    fi
+
     # Build the binary with version and build date
     echo "Compiling binary for $GO_OS/$GO_ARCH..."
     cd consent-server
-    GOOS=$GO_OS GOARCH=$GO_ARCH CGO_ENABLED=0 go build \
+    GOOS=$GO_OS GOARCH=$GO_ARCH CGO_ENABLED=$cgo_enabled go build \
         -ldflags "-X 'main.version=$VERSION' -X 'main.buildDate=$(date -u '+%Y-%m-%d %H:%M:%S UTC')'" \
         -o "../$OUTPUT_DIR/$output_binary" "./cmd/server"
+    # Legacy status migration tool
+    local migrate_binary="statusmigrate"
+    if [ "$GO_OS" = "windows" ]; then
+        migrate_binary="statusmigrate.exe"
+    fi
+    GOOS=$GO_OS GOARCH=$GO_ARCH CGO_ENABLED=$cgo_enabled go build \
+        -o "../$OUTPUT_DIR/$migrate_binary" "./cmd/statusmigrate"
+    # Command line administration tool
+    local ctl_binary="consentctl"
+    if [ "$GO_OS" = "windows" ]; then
+        ctl_binary="consentctl.exe"
+    fi
+    GOOS=$GO_OS GOARCH=$GO_ARCH CGO_ENABLED=$cgo_enabled go build \
+        -o "../$OUTPUT_DIR/$ctl_binary" "./cmd/consentctl"
     cd ..
     
     # Copy configuration
@@ -149,6 +169,7 @@ function build_binary() {
         echo "Copying database scripts..."
         mkdir -p "$OUTPUT_DIR/dbscripts"
         cp consent-server/dbscripts/*.sql "$OUTPUT_DIR/dbscripts/" 2>/dev/null || true
+        cp -r consent-server/dbscripts/migrations "$OUTPUT_DIR/dbscripts/" 2>/dev/null || true
     fi
     
     # Copy API specifications
@@ -245,6 +266,17 @@ function run_server() {
     echo "================================================================"
 }
 
+function generate_proto() {
+    echo "================================================================"
+    echo "Generating gRPC code from protobuf definitions..."
+    protoc -I api/proto \
+        --go_out=consent-server --go_opt=module=github.com/wso2/consent-management-api \
+        --go-grpc_out=consent-server --go-grpc_opt=module=github.com/wso2/consent-management-api \
+        api/proto/consent/v1/*.proto || exit 1
+    echo "✓ Generated code in consent-server/internal/grpcapi/pb"
+    echo "================================================================"
+}
+
 function test_unit() {
     echo "================================================================"
     echo "Running unit tests..."
@@ -254,6 +286,33 @@ function test_unit() {
     echo "================================================================"
 }
 
+function test_fuzz() {
+    echo "================================================================"
+    echo "Running fuzz tests..."
+    local fuzz_time="${FUZZ_TIME:-30s}"
+    cd consent-server || exit 1
+
+    # Each fuzz target has to be run on its own, go test accepts a single -fuzz pattern per package
+    local targets
+    targets=$(grep -rl --include='*_fuzz_test.go' '^func Fuzz' internal)
+    for file in $targets; do
+        local pkg
+        pkg="./$(dirname "$file")"
+        for target in $(grep -o '^func Fuzz[A-Za-z0-9_]*' "$file" | sed 's/^func //'); do
+            echo "Fuzzing $target in $pkg for $fuzz_time"
+            if ! go test "$pkg" -run='^$' -fuzz="^${target}$" -fuzztime="$fuzz_time"; then
+                echo "✗ Fuzz target $target failed"
+                cd "$SCRIPT_DIR" || exit 1
+                exit 1
+            fi
+        done
+    done
+
+    cd "$SCRIPT_DIR" || exit 1
+    echo "✓ Fuzz tests passed"
+    echo "================================================================"
+}
+
 function test_integration() {
     echo "================================================================"
     echo "Running integration tests..."
@@ -280,6 +339,25 @@ function test_integration() {
     echo "================================================================"
 }
 
+function test_conformance() {
+    echo "================================================================"
+    echo "Running conformance checks..."
+
+    local target="${1:-http://localhost:3000}"
+    cd tests/conformance || exit 1
+    go run . -url "$target" -report conformance-report.json
+    TEST_EXIT_CODE=$?
+    cd "$SCRIPT_DIR" || exit 1
+
+    if [ $TEST_EXIT_CODE -ne 0 ]; then
+        echo "✗ Conformance checks failed"
+        exit 1
+    fi
+
+    echo "✓ Conformance checks passed"
+    echo "================================================================"
+}
+
 function test_all() {
     test_unit
     test_integration
@@ -296,8 +374,11 @@ function show_help() {
     echo "  build            - Build the binary and prepare output directory"
     echo "  package          - Build and create distribution package (zip)"
     echo "  run              - Build and run the server"
+    echo "  proto            - Regenerate gRPC code from api/proto (requires protoc, protoc-gen-go and protoc-gen-go-grpc)"
     echo "  test_unit        - Run unit tests"
     echo "  test_integration - Run integration tests"
+    echo "  test_fuzz        - Run fuzz tests (duration per target set by FUZZ_TIME, default 30s)"
+    echo "  test_conformance - Run conformance checks against a running server (URL as second argument)"
     echo "  test             - Run all tests"
     echo "  help             - Show this help message"
     echo ""
@@ -336,12 +417,21 @@ case "$1" in
     run)
         run_server
         ;;
+    proto)
+        generate_proto
+        ;;
     test_unit)
         test_unit
         ;;
     test_integration)
         test_integration
         ;;
+    test_fuzz)
+        test_fuzz
+        ;;
+    test_conformance)
+        test_conformance "$2"
+        ;;
     test)
         test_all
         ;;
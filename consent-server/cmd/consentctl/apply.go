@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/wso2/consent-management-api/internal/bootstrap/model"
+)
+
+// applyBundle runs apply, reconciling the organizations and purposes of a bundle through the
+// admin API. The bundle is parsed first, so that a malformed file is reported before any request.
+func applyBundle(ctx context.Context, c *client, args []string) error {
+	flags := newFlagSet("apply", "")
+	file := flags.String("file", "", "YAML or JSON bundle, - for stdin (required)")
+	prune := flags.Bool("prune", false, "delete the organizations, purposes and schemas the bundle leaves out")
+	dryRun := flags.Bool("dry-run", false, "list the changes without making them")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *file == "" || flags.NArg() != 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	content, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+	bundle, err := model.Parse(content)
+	if err != nil {
+		return err
+	}
+
+	var result model.ApplyResult
+	query := url.Values{"dryRun": {strconv.FormatBool(*dryRun)}, "prune": {strconv.FormatBool(*prune)}}
+	if err := c.call(ctx, http.MethodPost, "/admin/bootstrap", query, bundle, &result); err != nil {
+		return err
+	}
+
+	for _, change := range result.Changes {
+		if change.Action == model.ActionUnchanged {
+			continue
+		}
+		name := change.OrgID
+		if change.Name != "" {
+			name += "/" + change.Name
+		}
+		if change.Action == model.ActionFailed {
+			fmt.Fprintf(c.out, "%s %s failed to be %s: %s\n", change.Kind, name, change.Planned, change.Error)
+			continue
+		}
+		fmt.Fprintf(c.out, "%s %s %s\n", change.Kind, name, change.Action)
+	}
+	summary := result.Summary
+	fmt.Fprintf(c.out, "%d created, %d updated, %d deleted, %d unchanged, %d failed\n",
+		summary.Created, summary.Updated, summary.Deleted, summary.Unchanged, summary.Failed)
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d changes failed", summary.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/apierror"
+)
+
+// client calls the consent management API, over the network or in process in offline mode
+type client struct {
+	baseURL  string
+	http     *http.Client
+	timeout  time.Duration
+	orgID    string
+	clientID string
+	username string
+	password string
+	// out receives the output of the commands
+	out io.Writer
+}
+
+// apiError is an error response of the API
+type apiError struct {
+	status  int
+	problem apierror.ProblemDetails
+}
+
+func (e *apiError) Error() string {
+	if e.problem.Code == "" {
+		return fmt.Sprintf("request failed with status %d", e.status)
+	}
+	message := fmt.Sprintf("%s %s", e.problem.Code, e.problem.Title)
+	if e.problem.Detail != "" {
+		message += ": " + e.problem.Detail
+	}
+	for _, violation := range e.problem.Violations {
+		message += fmt.Sprintf("\n  %s: %s", violation.Path, violation.Message)
+	}
+	return message
+}
+
+// do sends a request with the organization, client ID and credentials of the client. body is
+// encoded as JSON when it is not nil. Responses with an error status are returned as *apiError;
+// otherwise the caller closes the response body.
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+	target := c.baseURL + constants.APIBasePath + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	}
+	if c.orgID != "" {
+		req.Header.Set(constants.HeaderOrgID, c.orgID)
+	}
+	if c.clientID != "" {
+		req.Header.Set(constants.HeaderTPPClientID, c.clientID)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		apiErr := &apiError{status: resp.StatusCode}
+		json.NewDecoder(resp.Body).Decode(&apiErr.problem)
+		return nil, apiErr
+	}
+	return resp, nil
+}
+
+// call sends a request within the request timeout and decodes the JSON response into result,
+// unless result is nil
+func (c *client) call(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	resp, err := c.do(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
+
+// printJSON writes a value as indented JSON to the output of the client
+func (c *client) printJSON(value any) error {
+	encoder := json.NewEncoder(c.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// requireOrg reports a missing organization before a request is sent without one
+func (c *client) requireOrg() error {
+	if strings.TrimSpace(c.orgID) == "" {
+		return fmt.Errorf("an organization is required: pass -org-id or set %s", envOrgID)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/consentpurpose/model"
+	"github.com/wso2/consent-management-api/internal/system/error/codes"
+)
+
+// TestFindCommand checks that one and two word commands are found with the arguments that follow
+func TestFindCommand(t *testing.T) {
+	cmd, args := findCommand([]string{"consents", "revoke", "-by", "ops", "id-1"})
+	if cmd == nil || cmd.name != "consents revoke" || strings.Join(args, " ") != "-by ops id-1" {
+		t.Errorf("expected consents revoke with its arguments, got %v %v", cmd, args)
+	}
+	if cmd, _ := findCommand([]string{"migrate", "-dry-run"}); cmd == nil || cmd.name != "migrate" {
+		t.Errorf("expected migrate, got %v", cmd)
+	}
+	if cmd, _ := findCommand([]string{"consents"}); cmd != nil {
+		t.Errorf("expected no command for an incomplete name, got %s", cmd.name)
+	}
+}
+
+// TestClient_ReturnsProblemDetails checks that error responses are returned as API errors and that
+// the client sends the organization, client ID and credentials
+func TestClient_ReturnsProblemDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if r.Header.Get("org-id") != "org-1" || r.Header.Get("TPP-client-id") != "client-1" || user != "admin" || password != "secret" {
+			t.Errorf("unexpected request headers: %v", r.Header)
+		}
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"CSE-4004","title":"Resource Not Found","status":404,"detail":"Consent not found"}`))
+	}))
+	defer server.Close()
+
+	c := &client{baseURL: server.URL, http: server.Client(), orgID: "org-1", clientID: "client-1", username: "admin", password: "secret"}
+	err := c.call(context.Background(), http.MethodGet, "/consents/x", nil, nil, nil)
+
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || apiErr.status != http.StatusNotFound {
+		t.Fatalf("expected a 404 API error, got %v", err)
+	}
+	if err.Error() != "CSE-4004 Resource Not Found: Consent not found" {
+		t.Errorf("unexpected error message: %s", err)
+	}
+}
+
+// TestOfflineTransport_StreamsResponse checks that responses served in process are streamed, and
+// that an aborted handler fails the read of the body
+func TestOfflineTransport_StreamsResponse(t *testing.T) {
+	transport := &offlineTransport{handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("a,b\n"))
+		http.NewResponseController(w).Flush()
+		if r.URL.Query().Get("abort") == "true" {
+			panic(http.ErrAbortHandler)
+		}
+		w.Write([]byte("1,2\n"))
+	})}
+	c := &client{baseURL: offlineBaseURL, http: &http.Client{Transport: transport}}
+
+	resp, err := c.do(context.Background(), http.MethodGet, "/consents/export", nil, nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || string(body) != "a,b\n1,2\n" || resp.Header.Get("Content-Type") != "text/csv" {
+		t.Errorf("unexpected response %q %v (%v)", body, resp.Header, err)
+	}
+
+	resp, err = c.do(context.Background(), http.MethodGet, "/consents/export", map[string][]string{"abort": {"true"}}, nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.ReadAll(resp.Body); err == nil {
+		t.Error("expected reading an aborted response to fail")
+	}
+}
+
+// TestSeedPurposes_SkipsExisting checks that purposes that already exist do not fail a seed
+func TestSeedPurposes_SkipsExisting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []model.CreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil || len(requests) != 2 {
+			t.Errorf("unexpected bulk request: %v %v", requests, err)
+		}
+		json.NewEncoder(w).Encode(model.BulkCreateResponse{
+			Data: []model.BulkCreateItemResult{
+				{Index: 0, Name: "marketing", Status: model.BulkItemCreated},
+				{Index: 1, Name: "analytics", Status: model.BulkItemFailed,
+					Error: &model.BulkCreateItemError{Code: codes.ConflictError, Message: "purpose name 'analytics' already exists"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "purposes.json")
+	os.WriteFile(file, []byte(`[{"name":"marketing","type":"string"},{"name":"analytics","type":"string"}]`), 0o600)
+
+	var out bytes.Buffer
+	c := &client{baseURL: server.URL, http: server.Client(), orgID: "org-1", out: &out}
+	if err := seedPurposes(context.Background(), c, []string{"-file", file}); err != nil {
+		t.Fatalf("expected the seed to succeed, got %v", err)
+	}
+	if out.String() != "1 created, 1 already existed, 0 failed\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
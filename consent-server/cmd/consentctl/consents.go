@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+)
+
+// searchFlags are the search filters shared by consents list and consents export
+type searchFlags struct {
+	statuses  *string
+	types     *string
+	clientIDs *string
+	userIDs   *string
+	tags      *string
+	filter    *string
+	text      *string
+}
+
+func addSearchFlags(flags *flag.FlagSet) *searchFlags {
+	return &searchFlags{
+		statuses:  flags.String("status", "", "comma-separated consent statuses"),
+		types:     flags.String("type", "", "comma-separated consent types"),
+		clientIDs: flags.String("client", "", "comma-separated client IDs"),
+		userIDs:   flags.String("user", "", "comma-separated IDs of authorizing users"),
+		tags:      flags.String("tag", "", "comma-separated tags"),
+		filter:    flags.String("filter", "", "filter expression, such as 'status eq \"ACTIVE\" and createdTime gt 1727740800000'"),
+		text:      flags.String("q", "", "free-text search"),
+	}
+}
+
+// query returns the search query parameters of the flags that are set
+func (f *searchFlags) query() url.Values {
+	query := url.Values{}
+	for param, value := range map[string]string{
+		"consentStatuses": *f.statuses,
+		"consentTypes":    *f.types,
+		"clientIds":       *f.clientIDs,
+		"userIds":         *f.userIDs,
+		"tags":            *f.tags,
+		"filter":          *f.filter,
+		"q":               *f.text,
+	} {
+		if value != "" {
+			query.Set(param, value)
+		}
+	}
+	return query
+}
+
+// consentPage is a page of GET /consents
+type consentPage struct {
+	Data     []model.ConsentDetailResponse `json:"data"`
+	Metadata model.ConsentSearchMetadata   `json:"metadata"`
+}
+
+// listConsents runs consents list. Pages are fetched with cursor pagination, so that -all reads
+// every matching consent even while consents are created.
+func listConsents(ctx context.Context, c *client, args []string) error {
+	flags := newFlagSet("consents list", "")
+	search := addSearchFlags(flags)
+	limit := flags.Int("limit", 50, "consents per page")
+	all := flags.Bool("all", false, "list every matching consent instead of the first page")
+	output := flags.String("o", "table", "output format: table or json")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *output != "table" && *output != "json" {
+		return fmt.Errorf("unknown output format '%s'", *output)
+	}
+	if err := c.requireOrg(); err != nil {
+		return err
+	}
+
+	query := search.query()
+	query.Set("limit", strconv.Itoa(*limit))
+	query.Set("includeTotal", "false")
+	if *search.text == "" {
+		query.Set("cursor", "")
+	}
+
+	var consents []model.ConsentDetailResponse
+	for {
+		var page consentPage
+		if err := c.call(ctx, http.MethodGet, "/consents", query, nil, &page); err != nil {
+			return err
+		}
+		consents = append(consents, page.Data...)
+		if !*all || !page.Metadata.HasMore || page.Metadata.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", page.Metadata.NextCursor)
+	}
+
+	if *output == "json" {
+		return c.printJSON(consents)
+	}
+	table := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tTYPE\tSTATUS\tCLIENT\tCREATED")
+	for _, consent := range consents {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", consent.ID, consent.Type, consent.Status, consent.ClientID,
+			time.UnixMilli(consent.CreatedTime).UTC().Format(time.RFC3339))
+	}
+	return table.Flush()
+}
+
+// getConsent runs consents get
+func getConsent(ctx context.Context, c *client, args []string) error {
+	flags := newFlagSet("consents get", "<consent-id>")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+	if err := c.requireOrg(); err != nil {
+		return err
+	}
+
+	var consent json.RawMessage
+	if err := c.call(ctx, http.MethodGet, "/consents/"+url.PathEscape(flags.Arg(0)), nil, nil, &consent); err != nil {
+		return err
+	}
+	return c.printJSON(consent)
+}
+
+// revokeConsents runs consents revoke. Every consent is attempted; the command fails when any
+// revocation failed.
+func revokeConsents(ctx context.Context, c *client, args []string) error {
+	flags := newFlagSet("consents revoke", "<consent-id>...")
+	actionBy := flags.String("by", "", "user or system performing the revocation (required)")
+	reason := flags.String("reason", "", "reason of the revocation")
+	effectiveAt := flags.String("effective-at", "", "RFC 3339 time to schedule the revocation for, instead of revoking now")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 || *actionBy == "" {
+		flags.Usage()
+		return errUsage
+	}
+	if err := c.requireOrg(); err != nil {
+		return err
+	}
+
+	request := model.ConsentRevokeRequest{ActionBy: *actionBy, RevocationReason: *reason}
+	if *effectiveAt != "" {
+		at, err := time.Parse(time.RFC3339, *effectiveAt)
+		if err != nil {
+			return fmt.Errorf("invalid -effective-at: %w", err)
+		}
+		seconds := at.Unix()
+		request.EffectiveAt = &seconds
+	}
+	failed := 0
+	for _, consentID := range flags.Args() {
+		var revoked model.ConsentRevokeResponse
+		err := c.call(ctx, http.MethodPut, "/consents/"+url.PathEscape(consentID)+"/revoke", nil, request, &revoked)
+		if err != nil {
+			failed++
+			fmt.Fprintf(c.out, "%s\tfailed: %v\n", consentID, err)
+			continue
+		}
+		if scheduled := revoked.ScheduledRevocation; scheduled != nil {
+			fmt.Fprintf(c.out, "%s\tscheduled for %s\n", consentID, time.Unix(scheduled.EffectiveAt, 0).UTC().Format(time.RFC3339))
+			continue
+		}
+		fmt.Fprintf(c.out, "%s\trevoked\n", consentID)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d consents were not revoked", failed, flags.NArg())
+	}
+	return nil
+}
+
+// exportConsents runs consents export, streaming the export to stdout or a file
+func exportConsents(ctx context.Context, c *client, args []string) error {
+	flags := newFlagSet("consents export", "")
+	search := addSearchFlags(flags)
+	format := flags.String("format", "csv", "export format: csv or ndjson")
+	outPath := flags.String("out", "", "file to write the export to instead of stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := c.requireOrg(); err != nil {
+		return err
+	}
+
+	query := search.query()
+	query.Set("format", *format)
+	resp, err := c.do(ctx, http.MethodGet, "/consents/export", query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	out := c.out
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	// A failure part way through aborts the response, so a truncated export is reported as an error
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("export interrupted: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// Command consentctl administers the consent management API from the command line. It lists,
+// shows, revokes and exports consents, seeds purposes, configures organizations and migrates the
+// database schema.
+//
+// It calls the API of a running server by default. With -offline it loads deployment.yaml and
+// serves the same requests in process against the configured database, for pipelines that prepare
+// a database before any server is started.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// Environment variables read for the global flags, so that credentials stay out of the process list
+const (
+	envServer   = "CONSENTCTL_SERVER"
+	envOrgID    = "CONSENTCTL_ORG_ID"
+	envClientID = "CONSENTCTL_CLIENT_ID"
+	envUsername = "CONSENTCTL_USERNAME"
+	envPassword = "CONSENTCTL_PASSWORD"
+)
+
+// command is a consentctl subcommand, run with the arguments that follow its name
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, c *client, args []string) error
+}
+
+// commands are the subcommands, in the order the usage lists them
+var commands = []command{
+	{"consents list", "list the consents matching search filters", listConsents},
+	{"consents get", "show a consent", getConsent},
+	{"consents revoke", "revoke consents", revokeConsents},
+	{"consents export", "export the consents matching search filters as CSV or NDJSON", exportConsents},
+	{"purposes seed", "create purposes from a JSON file, skipping those that exist", seedPurposes},
+	{"orgs configure", "create or replace an organization from a JSON file", configureOrg},
+	{"apply", "reconcile organizations and purposes with a YAML or JSON bundle", applyBundle},
+	{"migrate", "apply the pending database schema migrations", migrate},
+}
+
+// errUsage reports invalid arguments; the usage of the command was already printed
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	// Logs of the offline mode must not mix with the output of the commands
+	log.SetOutput(os.Stderr)
+	log.GetLogger()
+	os.Exit(run(os.Args[1:]))
+}
+
+// run runs the command line and returns the exit status: 2 for invalid arguments, 1 when the
+// command failed
+func run(arguments []string) int {
+	flags := flag.NewFlagSet("consentctl", flag.ContinueOnError)
+	flags.Usage = func() { printUsage(flags) }
+	server := flags.String("server", envOr(envServer, "http://localhost:3000"), "base URL of the consent server")
+	orgID := flags.String("org-id", os.Getenv(envOrgID), "organization the requests are made for")
+	clientID := flags.String("client-id", os.Getenv(envClientID), "client ID sent with the requests")
+	username := flags.String("username", os.Getenv(envUsername), "basic auth username")
+	password := flags.String("password", "", "basic auth password, defaults to $"+envPassword)
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of each request except exports, 0 for none")
+	offline := flags.Bool("offline", false, "serve the requests in process against the configured database instead of calling a server")
+	configPath := flags.String("config", os.Getenv("CONFIG_PATH"), "path to deployment.yaml (with -offline)")
+	logLevel := flags.String("log-level", "error", "log level of the offline mode")
+	if err := flags.Parse(arguments); err != nil {
+		return 2
+	}
+
+	// Unlike the other flags, the password is not shown as the default in the usage
+	if *password == "" {
+		*password = os.Getenv(envPassword)
+	}
+
+	cmd, args := findCommand(flags.Args())
+	if cmd == nil {
+		printUsage(flags)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	c := &client{
+		baseURL:  strings.TrimSuffix(*server, "/"),
+		http:     &http.Client{},
+		timeout:  *timeout,
+		orgID:    *orgID,
+		clientID: *clientID,
+		username: *username,
+		password: *password,
+		out:      os.Stdout,
+	}
+	if *offline {
+		if err := log.SetLogLevel(*logLevel); err != nil {
+			fmt.Fprintln(os.Stderr, "consentctl:", err)
+			return 2
+		}
+		transport, err := newOfflineTransport(*configPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "consentctl:", err)
+			return 1
+		}
+		defer transport.Close()
+		c.baseURL = offlineBaseURL
+		c.http.Transport = transport
+	}
+
+	if err := cmd.run(ctx, c, args); err != nil {
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		fmt.Fprintf(os.Stderr, "consentctl %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+// findCommand returns the subcommand named by the leading arguments and the arguments after its name
+func findCommand(args []string) (*command, []string) {
+	for i := range commands {
+		words := strings.Fields(commands[i].name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == commands[i].name {
+			return &commands[i], args[len(words):]
+		}
+	}
+	return nil, nil
+}
+
+// newFlagSet creates the flag set of a subcommand, printing its usage to stderr on errors
+func newFlagSet(name, arguments string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: consentctl [global flags] %s [flags] %s\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags parses the arguments of a subcommand, turning flag errors into errUsage
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
+
+func printUsage(flags *flag.FlagSet) {
+	out := flags.Output()
+	fmt.Fprintln(out, "Usage: consentctl [global flags] <command> [flags] [arguments]")
+	fmt.Fprintln(out, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-18s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(out, "\nGlobal flags:")
+	flags.PrintDefaults()
+	fmt.Fprintf(out, "\nThe server, organization, client ID and credentials default to $%s, $%s, $%s, $%s and $%s.\n",
+		envServer, envOrgID, envClientID, envUsername, envPassword)
+}
+
+// envOr returns the value of an environment variable, or fallback when it is not set
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// readInput reads a file, or stdin when path is "-"
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
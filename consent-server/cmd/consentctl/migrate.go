@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/wso2/consent-management-api/internal/admin/model"
+)
+
+// migrate runs migrate, applying or listing the pending schema migrations through the admin API
+func migrate(ctx context.Context, c *client, args []string) error {
+	flags := newFlagSet("migrate", "")
+	dryRun := flags.Bool("dry-run", false, "list the pending migrations without applying them")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	var result model.MigrationResponse
+	query := url.Values{"dryRun": {strconv.FormatBool(*dryRun)}}
+	if err := c.call(ctx, http.MethodPost, "/admin/migrate", query, nil, &result); err != nil {
+		return err
+	}
+
+	verb := "applied"
+	if result.DryRun {
+		verb = "pending"
+	}
+	for _, migration := range result.Migrations {
+		fmt.Fprintf(c.out, "%d %s %s\n", migration.Version, migration.Name, verb)
+	}
+	fmt.Fprintf(c.out, "schema version %d, latest %d, %d migrations %s\n",
+		result.CurrentVersion, result.TargetVersion, len(result.Migrations), verb)
+	return nil
+}
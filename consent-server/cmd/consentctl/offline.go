@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/admin"
+	"github.com/wso2/consent-management-api/internal/attributeschema"
+	"github.com/wso2/consent-management-api/internal/authresource"
+	"github.com/wso2/consent-management-api/internal/bootstrap"
+	"github.com/wso2/consent-management-api/internal/consent"
+	"github.com/wso2/consent-management-api/internal/consentfile"
+	"github.com/wso2/consent-management-api/internal/consentimport"
+	"github.com/wso2/consent-management-api/internal/consentpurpose"
+	"github.com/wso2/consent-management-api/internal/eventoutbox"
+	"github.com/wso2/consent-management-api/internal/export"
+	"github.com/wso2/consent-management-api/internal/operationaudit"
+	"github.com/wso2/consent-management-api/internal/organization"
+	"github.com/wso2/consent-management-api/internal/resourceschema"
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/database"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/events"
+	"github.com/wso2/consent-management-api/internal/system/featureflag"
+	"github.com/wso2/consent-management-api/internal/system/kafka"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// offlineBaseURL is the base URL of requests served in process. The host is never resolved.
+const offlineBaseURL = "http://consentctl.offline"
+
+// offlineTransport serves the requests of the client with the handlers of the server, in process.
+// The commands work the same in both modes, since the same handlers validate and store the data.
+type offlineTransport struct {
+	handler  http.Handler
+	db       *database.DB
+	registry *stores.StoreRegistry
+}
+
+// newOfflineTransport loads the configuration, connects to the configured database and registers
+// the modules the commands use. Basic auth is disabled: whoever runs consentctl offline already
+// holds the database credentials. Background tasks are not started; the consent events of the
+// requests are delivered before Close returns.
+func newOfflineTransport(configPath string) (*offlineTransport, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.Security.BasicAuth.Enabled = false
+	cfg.Admin.BasicAuth.Enabled = false
+	// The bundle of the server is applied by the server; consentctl applies the bundle it is given
+	cfg.Bootstrap.File = ""
+
+	if err := featureflag.Initialize(cfg.FeatureFlags); err != nil {
+		return nil, fmt.Errorf("invalid feature flag configuration: %w", err)
+	}
+
+	db, err := database.Initialize(&cfg.Database.Consent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+	healthCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := db.HealthCheck(healthCtx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("database health check failed: %w", err)
+	}
+
+	provider.InitDBProvider(db)
+	dbClient, err := provider.GetDBProvider().GetConsentDBClient()
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to get database client: %w", err)
+	}
+
+	// Revoked consents must not stay valid in the validation cache of running servers
+	if validateCache := cfg.Cache.Validate; validateCache.Enabled {
+		if err := cache.InitValidateCache(validateCache); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create the consent validation cache: %w", err)
+		}
+	}
+	if kafkaCfg := cfg.Events.Kafka; kafkaCfg.Enabled {
+		publisher, err := kafka.NewEventPublisher(kafkaCfg)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create Kafka event publisher: %w", err)
+		}
+		events.SetPublisher(publisher)
+	}
+
+	registry := stores.NewStoreRegistry(
+		dbClient,
+		consent.NewConsentStore(dbClient),
+		authresource.NewAuthResourceStore(dbClient),
+		consentpurpose.NewConsentPurposeStore(dbClient),
+		export.NewExportJobStore(dbClient),
+		consentfile.NewConsentFileStore(dbClient),
+		consentimport.NewImportJobStore(dbClient),
+		attributeschema.NewAttributeSchemaStore(dbClient),
+		resourceschema.NewResourceSchemaStore(dbClient),
+		organization.NewOrganizationStore(dbClient),
+		operationaudit.NewOperationAuditStore(dbClient),
+		eventoutbox.NewEventOutboxStore(dbClient),
+	)
+
+	// The public and admin routes share one mux, in the order the server initializes the modules
+	mux := http.NewServeMux()
+	orgService := organization.Initialize(mux, registry)
+	operationaudit.Initialize(mux, mux, registry)
+	authresource.Initialize(mux, mux, registry)
+	purposeService := consentpurpose.Initialize(mux, registry)
+	consent.Initialize(mux, mux, registry)
+	admin.Initialize(mux, registry)
+	schemaService := attributeschema.Initialize(mux, registry)
+	if _, err := bootstrap.Initialize(mux, orgService, purposeService, schemaService); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &offlineTransport{
+		handler:  middleware.WrapWithCorrelationID(mux),
+		db:       db,
+		registry: registry,
+	}, nil
+}
+
+// RoundTrip serves a request with the handlers. The response is returned as soon as the handler
+// writes its header, and its body streams what the handler writes after that.
+func (t *offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil {
+		req.Body = http.NoBody
+	}
+	reader, writer := io.Pipe()
+	w := &pipeResponseWriter{
+		req:    req,
+		header: make(http.Header),
+		body:   writer,
+		ready:  make(chan *http.Response, 1),
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				writer.CloseWithError(fmt.Errorf("handler panicked: %v", r))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			writer.Close()
+		}()
+		t.handler.ServeHTTP(w, req)
+	}()
+
+	select {
+	case resp := <-w.ready:
+		resp.Body = reader
+		return resp, nil
+	case <-req.Context().Done():
+		reader.CloseWithError(req.Context().Err())
+		return nil, req.Context().Err()
+	}
+}
+
+// Close waits for the transactions of the requests, delivers their consent events and closes the
+// database
+func (t *offlineTransport) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	t.registry.Drain(ctx)
+	events.Flush(ctx)
+	cache.CloseValidateCache()
+	return t.db.Close()
+}
+
+// pipeResponseWriter sends the response of a handler through a pipe
+type pipeResponseWriter struct {
+	req    *http.Request
+	header http.Header
+	body   *io.PipeWriter
+	ready  chan *http.Response
+	once   sync.Once
+}
+
+func (w *pipeResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader hands the response to RoundTrip; only the first call has an effect
+func (w *pipeResponseWriter) WriteHeader(status int) {
+	w.once.Do(func() {
+		w.ready <- &http.Response{
+			Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode: status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     w.header.Clone(),
+			Request:    w.req,
+		}
+	})
+}
+
+func (w *pipeResponseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(p)
+}
+
+// Flush is a no-op: writes reach the reader as they happen
+func (w *pipeResponseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+}
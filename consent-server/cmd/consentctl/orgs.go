@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/wso2/consent-management-api/internal/organization/model"
+)
+
+// configureOrg runs orgs configure. The organization is created when it does not exist and
+// replaced otherwise, so the file is the whole configuration of the organization either way.
+func configureOrg(ctx context.Context, c *client, args []string) error {
+	flags := newFlagSet("orgs configure", "[org-id]")
+	file := flags.String("file", "", "JSON organization, as accepted by POST /orgs, - for stdin (required)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *file == "" || flags.NArg() > 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	content, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+	var request model.OrganizationRequest
+	if err := json.Unmarshal(content, &request); err != nil {
+		return fmt.Errorf("invalid organization file: %w", err)
+	}
+	// The argument, then the file, then the global organization name the organization
+	if flags.NArg() == 1 {
+		request.OrgID = flags.Arg(0)
+	}
+	if request.OrgID == "" {
+		request.OrgID = c.orgID
+	}
+	if request.OrgID == "" {
+		return fmt.Errorf("an organization ID is required: pass it as an argument or set orgId in the file")
+	}
+
+	path := "/orgs/" + url.PathEscape(request.OrgID)
+	var organization model.Organization
+	err = c.call(ctx, http.MethodGet, path, nil, nil, &organization)
+	var apiErr *apiError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.status == http.StatusNotFound:
+		if err := c.call(ctx, http.MethodPost, "/orgs", nil, request, &organization); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "organization %s created\n", organization.OrgID)
+	case err != nil:
+		return err
+	default:
+		if err := c.call(ctx, http.MethodPut, path, nil, request, &organization); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "organization %s updated\n", organization.OrgID)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/consentpurpose/model"
+	"github.com/wso2/consent-management-api/internal/system/error/codes"
+)
+
+// seedPurposes runs purposes seed. The purposes are created with the bulk endpoint, at most
+// model.BulkCreateMaxItems per request. Purposes whose name already exists are left unchanged,
+// so a pipeline can seed the same file on every run.
+func seedPurposes(ctx context.Context, c *client, args []string) error {
+	flags := newFlagSet("purposes seed", "")
+	file := flags.String("file", "", "JSON array of purposes to create, - for stdin (required)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *file == "" || flags.NArg() != 0 {
+		flags.Usage()
+		return errUsage
+	}
+	if err := c.requireOrg(); err != nil {
+		return err
+	}
+
+	content, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+	var purposes []model.CreateRequest
+	if err := json.Unmarshal(content, &purposes); err != nil {
+		return fmt.Errorf("invalid purposes file: %w", err)
+	}
+
+	created, existing, failed := 0, 0, 0
+	for start := 0; start < len(purposes); start += model.BulkCreateMaxItems {
+		end := min(start+model.BulkCreateMaxItems, len(purposes))
+		var response model.BulkCreateResponse
+		if err := c.call(ctx, http.MethodPost, "/consent-purposes/bulk", nil, purposes[start:end], &response); err != nil {
+			return err
+		}
+		for _, result := range response.Data {
+			switch {
+			case result.Status == model.BulkItemCreated:
+				created++
+			case result.Error != nil && result.Error.Code == codes.ConflictError:
+				existing++
+			default:
+				failed++
+				message := "not created"
+				if result.Error != nil {
+					message = result.Error.Message
+				}
+				fmt.Fprintf(c.out, "purpose %d (%s): %s\n", start+result.Index, result.Name, message)
+			}
+		}
+	}
+
+	fmt.Fprintf(c.out, "%d created, %d already existed, %d failed\n", created, existing, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d purposes were not created", failed)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// newAdminServer creates the HTTP server for the admin listener, with TLS and client certificate
+// verification configured from admin.tls. The returned reloader is nil when TLS is disabled.
+func newAdminServer(cfg *config.Config, handler http.Handler) (*http.Server, *tlsReloader, error) {
+	hostname := cfg.Admin.Hostname
+	if hostname == "" {
+		hostname = cfg.Server.Hostname
+	}
+
+	server := &http.Server{
+		Addr:           fmt.Sprintf("%s:%d", hostname, cfg.Admin.Port),
+		Handler:        handler,
+		ReadTimeout:    15 * time.Second,
+		WriteTimeout:   15 * time.Second,
+		IdleTimeout:    60 * time.Second,
+		MaxHeaderBytes: 1 << 20, // 1 MB
+	}
+
+	if !cfg.Admin.TLS.Enabled {
+		return server, nil, nil
+	}
+	reloader, err := newTLSReloader("admin", cfg.Admin.TLS)
+	if err != nil {
+		return nil, nil, err
+	}
+	server.TLSConfig = reloader.tlsConfig()
+	return server, reloader, nil
+}
+
+// startAdminServer serves the admin listener until it is shut down
+func startAdminServer(server *http.Server, tlsCfg config.TLSConfig) {
+	logger := log.GetLogger()
+	logger.Info("Starting admin server...",
+		log.String("addr", server.Addr),
+		log.Bool("tls", tlsCfg.Enabled),
+		log.Bool("mtls", tlsCfg.Enabled && tlsCfg.ClientCAFile != ""))
+
+	var err error
+	if tlsCfg.Enabled {
+		// The certificate comes from server.TLSConfig so it can be reloaded
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		logger.Fatal("Failed to start admin server", log.Error(err))
+	}
+}
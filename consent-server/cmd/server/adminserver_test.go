@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/database/dbtest"
+)
+
+// adminRoutes are a sample of the routes served by the admin listener only
+var adminRoutes = []struct{ method, path string }{
+	{"GET", "/api/v1/admin/feature-flags"},
+	{"PUT", "/api/v1/admin/logging/level"},
+	{"POST", "/api/v1/admin/consent-ownership-transfers"},
+	{"POST", "/api/v1/admin/consents/c1/status"},
+	{"GET", "/api/v1/admin/export-jobs"},
+	{"GET", "/api/v1/audit/operations"},
+	{"POST", "/api/v1/users/user-1/erase"},
+}
+
+// adminTestListener is the admin listener of a server with admin.enabled, with the roots trusting
+// its certificate and a client certificate it accepts
+type adminTestListener struct {
+	mux      *http.ServeMux
+	adminMux *http.ServeMux
+	server   *httptest.Server
+	roots    *x509.CertPool
+	client   tls.Certificate
+}
+
+// startAdminListener registers the services on separate public and admin muxes, as main does with
+// admin.enabled, and serves the admin mux over mutual TLS with its own basic auth users
+func startAdminListener(t *testing.T) *adminTestListener {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "test-ca", true, nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	certFile, keyFile := newTestCertificate(t, "localhost", false, ca).writeFiles(t, dir, "admin")
+	clientCAFile, _ := ca.writeFiles(t, dir, "client-ca")
+
+	cfg := &config.Config{
+		Security: config.SecurityConfig{BasicAuth: config.BasicAuthConfig{
+			Enabled: true,
+			Users:   []config.BasicAuthUser{{Username: "api", Password: "api"}},
+		}},
+		Admin: config.AdminConfig{
+			Enabled:   true,
+			TLS:       config.TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile, ClientCAFile: clientCAFile},
+			BasicAuth: config.BasicAuthConfig{Enabled: true, Users: []config.BasicAuthUser{{Username: "operator", Password: "operator"}}},
+		},
+	}
+	config.SetGlobal(cfg)
+	t.Cleanup(func() { config.SetGlobal(nil) })
+
+	mux := http.NewServeMux()
+	adminMux := http.NewServeMux()
+	registerServices(mux, adminMux, dbtest.NewSQLiteClient(t))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		unregisterServices(ctx)
+	})
+
+	adminServer, _, err := newAdminServer(cfg, adminMux)
+	if err != nil {
+		t.Fatalf("failed to configure the admin server: %v", err)
+	}
+	server := httptest.NewUnstartedServer(adminServer.Handler)
+	server.TLS = adminServer.TLSConfig
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return &adminTestListener{
+		mux:      mux,
+		adminMux: adminMux,
+		server:   server,
+		roots:    roots,
+		client:   newTestCertificate(t, "operator", false, ca).tlsCertificate(),
+	}
+}
+
+// get calls GET path on the admin listener, presenting the client certificate if any and
+// authenticating as username when set
+func (l *adminTestListener) get(path string, clientCert *tls.Certificate, username, password string) (*http.Response, error) {
+	tlsCfg := &tls.Config{RootCAs: l.roots, ServerName: "localhost"}
+	if clientCert != nil {
+		tlsCfg.Certificates = []tls.Certificate{*clientCert}
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+	defer client.CloseIdleConnections()
+
+	req, err := http.NewRequest(http.MethodGet, l.server.URL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// TestAdminListener_SeparatesAdminRoutes checks that with admin.enabled the admin routes are only
+// registered on the admin mux, and that the admin listener enforces its basic auth users and
+// client certificates
+func TestAdminListener_SeparatesAdminRoutes(t *testing.T) {
+	listener := startAdminListener(t)
+
+	t.Run("admin routes are absent from the public mux", func(t *testing.T) {
+		for _, route := range adminRoutes {
+			req := httptest.NewRequest(route.method, route.path, nil)
+			if _, pattern := listener.adminMux.Handler(req); pattern == "" {
+				t.Errorf("expected %s %s on the admin mux", route.method, route.path)
+			}
+			recorder := httptest.NewRecorder()
+			listener.mux.ServeHTTP(recorder, req)
+			if recorder.Code != http.StatusNotFound {
+				t.Errorf("expected %s %s to be absent from the public mux, got %d", route.method, route.path, recorder.Code)
+			}
+		}
+		// Public routes stay on the public mux
+		req := httptest.NewRequest("GET", "/api/v1/consents", nil)
+		if _, pattern := listener.mux.Handler(req); pattern == "" {
+			t.Error("expected the consent routes on the public mux")
+		}
+	})
+
+	t.Run("admin basic auth is enforced", func(t *testing.T) {
+		testCases := []struct {
+			name               string
+			username, password string
+			want               int
+		}{
+			{"admin user", "operator", "operator", http.StatusOK},
+			{"no credentials", "", "", http.StatusUnauthorized},
+			{"wrong password", "operator", "wrong", http.StatusUnauthorized},
+			{"security.basic_auth user", "api", "api", http.StatusUnauthorized},
+		}
+		for _, tc := range testCases {
+			resp, err := listener.get("/api/v1/admin/feature-flags", &listener.client, tc.username, tc.password)
+			if err != nil {
+				t.Fatalf("%s: request failed: %v", tc.name, err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Errorf("%s: expected %d, got %d", tc.name, tc.want, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("client certificates are verified", func(t *testing.T) {
+		if _, err := listener.get("/health", nil, "", ""); err == nil {
+			t.Error("expected a call without a client certificate to be rejected")
+		}
+		untrusted := newTestCertificate(t, "operator", false, newTestCertificate(t, "other-ca", true, nil)).tlsCertificate()
+		if _, err := listener.get("/health", &untrusted, "", ""); err == nil {
+			t.Error("expected a client certificate of another CA to be rejected")
+		}
+		resp, err := listener.get("/health", &listener.client, "", "")
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Errorf("expected the health check to be served with a trusted certificate, got %v, %v", resp, err)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
@@ -9,11 +10,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/wso2/consent-management-api/internal/openapi"
 	"github.com/wso2/consent-management-api/internal/system/config"
 	"github.com/wso2/consent-management-api/internal/system/database"
 	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/featureflag"
 	"github.com/wso2/consent-management-api/internal/system/log"
 	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
 )
 
 // Version information (set by build script)
@@ -23,6 +27,9 @@ var (
 )
 
 func main() {
+	devMode := flag.Bool("dev", false, "Use an in-memory SQLite database instead of the configured one")
+	flag.Parse()
+
 	// Initialize logger
 	logger := log.GetLogger()
 
@@ -40,6 +47,13 @@ func main() {
 
 	logger.Info("Configuration loaded successfully", log.String("config_path", configPath))
 
+	// Development mode runs without a database server. Data is lost when the server stops.
+	if *devMode {
+		cfg.Database.Consent.Type = config.DatabaseTypeSQLite
+		cfg.Database.Consent.Database = config.SQLiteInMemory
+		logger.Warn("Running in development mode with an in-memory SQLite database")
+	}
+
 	// Update log level from configuration
 	if cfg.Logging.Level != "" {
 		if err := log.SetLogLevel(cfg.Logging.Level); err != nil {
@@ -49,6 +63,16 @@ func main() {
 		}
 	}
 
+	// Load feature flag states
+	if err := featureflag.Initialize(cfg.FeatureFlags); err != nil {
+		logger.Fatal("Invalid feature flag configuration", log.Error(err))
+	}
+
+	// Start exporting traces when tracing is enabled
+	if err := tracing.Initialize(cfg.Tracing); err != nil {
+		logger.Fatal("Failed to initialize tracing", log.Error(err))
+	}
+
 	// Initialize database
 	db, err := database.Initialize(&cfg.Database.Consent)
 	if err != nil {
@@ -75,14 +99,23 @@ func main() {
 		logger.Fatal("Failed to get database client", log.Error(err))
 	}
 
-	// Create HTTP mux
+	// Create HTTP mux. Admin endpoints get their own mux when the admin listener is enabled.
 	mux := http.NewServeMux()
+	adminMux := mux
+	if cfg.Admin.Enabled {
+		adminMux = http.NewServeMux()
+	}
 
-	// Register all services
-	registerServices(mux, dbClient)
+	// Register the compiled-in consent interceptors, then all services
+	registerInterceptors()
+	registerServices(mux, adminMux, dbClient)
 
-	// Wrap with correlation ID middleware
-	httpHandler := middleware.WrapWithCorrelationID(mux)
+	// Wrap with request validation, body limit, request timeout, tracing, correlation ID and consent
+	// lock token middleware. The body limit and request validation pass the request to the mux
+	// unchanged, and the request timeout records the matched route itself, so tracing can still name
+	// spans after the matched route.
+	httpHandler := middleware.WrapWithConsentLockToken(middleware.WrapWithCorrelationID(middleware.WrapWithTracing(
+		middleware.WrapWithRequestTimeout(mux, middleware.WrapWithBodyLimit(mux, openapi.WrapWithRequestValidation(mux))))))
 
 	// Configure HTTP server
 	serverAddr := fmt.Sprintf("%s:%d", cfg.Server.Hostname, cfg.Server.Port)
@@ -95,18 +128,61 @@ func main() {
 		MaxHeaderBytes: 1 << 20, // 1 MB
 	}
 
+	// Certificates of TLS listeners are reloaded on SIGHUP
+	var tlsReloaders []*tlsReloader
+	tlsCfg := cfg.Server.TLS
+	if tlsCfg.Enabled {
+		reloader, err := newTLSReloader("server", tlsCfg)
+		if err != nil {
+			logger.Fatal("Failed to configure server TLS", log.Error(err))
+		}
+		server.TLSConfig = reloader.tlsConfig()
+		tlsReloaders = append(tlsReloaders, reloader)
+	}
+
 	// Start server in a goroutine
 	go func() {
 		logger.Info("Starting HTTP server...",
 			log.String("hostname", cfg.Server.Hostname),
 			log.Int("port", cfg.Server.Port),
-			log.String("addr", serverAddr))
+			log.String("addr", serverAddr),
+			log.Bool("tls", tlsCfg.Enabled),
+			log.Bool("mtls", tlsCfg.Enabled && tlsCfg.ClientCAFile != ""))
 
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if tlsCfg.Enabled {
+			// The certificate comes from server.TLSConfig so it can be reloaded
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			logger.Fatal("Failed to start server", log.Error(err))
 		}
 	}()
 
+	// Start the admin listener
+	var adminServer *http.Server
+	if cfg.Admin.Enabled {
+		var reloader *tlsReloader
+		adminServer, reloader, err = newAdminServer(cfg, middleware.WrapWithCorrelationID(middleware.WrapWithTracing(
+			middleware.WrapWithRequestTimeout(adminMux, middleware.WrapWithBodyLimit(adminMux, adminMux)))))
+		if err != nil {
+			logger.Fatal("Failed to configure admin server", log.Error(err))
+		}
+		if reloader != nil {
+			tlsReloaders = append(tlsReloaders, reloader)
+		}
+		go startAdminServer(adminServer, cfg.Admin.TLS)
+	}
+
+	if grpcTLSReloader != nil {
+		tlsReloaders = append(tlsReloaders, grpcTLSReloader)
+	}
+	if len(tlsReloaders) > 0 {
+		go reloadCertificatesOnHangup(tlsReloaders)
+	}
+
 	logger.Info("✓ Server is running", log.String("address", serverAddr))
 	logger.Info("Press Ctrl+C to stop the server")
 
@@ -115,18 +191,24 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	logger.Info("Shutting down server...")
+	logger.Info("Shutting down server...", log.String("timeout", cfg.Server.GetShutdownTimeout().String()))
 
-	// Graceful shutdown with timeout
-	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	// Graceful shutdown: stop accepting requests, wait for in-flight requests and transactions, then
+	// close the database. Everything shares one deadline.
+	ctx, cancel = context.WithTimeout(context.Background(), cfg.Server.GetShutdownTimeout())
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		logger.Fatal("Server forced to shutdown", log.Error(err))
+		logger.Error("Server forced to shutdown before in-flight requests finished", log.Error(err))
+	}
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			logger.Error("Admin server forced to shutdown", log.Error(err))
+		}
 	}
 
 	// Unregister services
-	unregisterServices()
+	unregisterServices(ctx)
 	logger.Info("Services unregistered")
 
 	// Close database connections
@@ -142,5 +224,36 @@ func main() {
 		logger.Error("Error closing database", log.Error(err))
 	}
 
+	// Export the remaining spans
+	if err := tracing.Shutdown(ctx); err != nil {
+		logger.Error("Error flushing traces", log.Error(err))
+	}
+
 	logger.Info("Server exited gracefully")
 }
+
+// registerInterceptors registers the consent interceptors compiled into this server. Deployments
+// that need in-process hooks implement interceptor.ConsentInterceptor, usually embedding
+// interceptor.Base, and register it here:
+//
+//	interceptor.Register(&myInterceptor{})
+func registerInterceptors() {
+}
+
+// reloadCertificatesOnHangup reloads the TLS certificates and client CAs from disk whenever the
+// process receives SIGHUP. A listener keeps its previous certificate when its files are invalid.
+func reloadCertificatesOnHangup(reloaders []*tlsReloader) {
+	logger := log.GetLogger()
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	for range hangup {
+		for _, reloader := range reloaders {
+			if err := reloader.reload(); err != nil {
+				logger.Error("Failed to reload TLS certificate, keeping the previous one", log.Error(err))
+				continue
+			}
+			logger.Info("TLS certificate reloaded", log.String("listener", reloader.name))
+		}
+	}
+}
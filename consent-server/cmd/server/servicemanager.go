@@ -1,55 +1,258 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"net/http"
 
+	"github.com/wso2/consent-management-api/internal/admin"
+	"github.com/wso2/consent-management-api/internal/attributeschema"
 	"github.com/wso2/consent-management-api/internal/authresource"
+	"github.com/wso2/consent-management-api/internal/bootstrap"
 	"github.com/wso2/consent-management-api/internal/consent"
+	"github.com/wso2/consent-management-api/internal/consentfile"
+	"github.com/wso2/consent-management-api/internal/consentimport"
 	"github.com/wso2/consent-management-api/internal/consentpurpose"
+	"github.com/wso2/consent-management-api/internal/consentsync"
+	"github.com/wso2/consent-management-api/internal/errorcatalog"
+	"github.com/wso2/consent-management-api/internal/eventoutbox"
+	"github.com/wso2/consent-management-api/internal/export"
+	"github.com/wso2/consent-management-api/internal/grpcapi"
+	"github.com/wso2/consent-management-api/internal/openapi"
+	"github.com/wso2/consent-management-api/internal/operationaudit"
+	"github.com/wso2/consent-management-api/internal/organization"
+	"github.com/wso2/consent-management-api/internal/resourceschema"
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/config"
 	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/events"
+	"github.com/wso2/consent-management-api/internal/system/kafka"
 	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/notification"
+	"github.com/wso2/consent-management-api/internal/system/scheduler"
 	"github.com/wso2/consent-management-api/internal/system/stores"
 )
 
-// registerServices registers all consent management services with the provided HTTP multiplexer.
+// grpcServer is the gRPC server started by registerServices, nil when gRPC is disabled
+var grpcServer *grpcapi.Server
+
+// grpcTLSReloader serves the certificate of the gRPC server, nil when gRPC TLS is disabled
+var grpcTLSReloader *tlsReloader
+
+// importService is the consent import service started by registerServices
+var importService consentimport.ImportService
+
+// storeRegistry is the store registry created by registerServices, drained on shutdown
+var storeRegistry *stores.StoreRegistry
+
+// registerServices registers all consent management services with the provided HTTP multiplexers.
+// Admin endpoints are registered on adminMux, which is the public mux unless the admin listener is enabled.
 func registerServices(
 	mux *http.ServeMux,
+	adminMux *http.ServeMux,
 	dbClient provider.DBClientInterface,
 ) {
 	logger := log.GetLogger()
 
+	// Purpose definitions are read on every consent create and validate, so they can be cached
+	purposeStore := consentpurpose.NewConsentPurposeStore(dbClient)
+	if purposeCache := config.Get().Cache.Purpose; purposeCache.Enabled {
+		purposeStore = consentpurpose.NewCachedConsentPurposeStore(purposeStore, purposeCache.TTL, purposeCache.MaxEntries)
+		logger.Info("Purpose definition cache enabled",
+			log.String("ttl", purposeCache.TTL.String()),
+			log.Int("max_entries", purposeCache.MaxEntries))
+	}
+
+	// Search totals are counted over every matching consent, so they can be cached across pages
+	consentStore := consent.NewConsentStore(dbClient)
+	if countCache := config.Get().Cache.SearchCount; countCache.Enabled {
+		consentStore = consent.NewSearchCountCachedStore(consentStore, countCache.TTL, countCache.MaxEntries)
+		logger.Info("Consent search count cache enabled",
+			log.String("ttl", countCache.TTL.String()),
+			log.Int("max_entries", countCache.MaxEntries))
+	}
+
+	// Consent validation data can be cached in Redis, shared by all server instances
+	if validateCache := config.Get().Cache.Validate; validateCache.Enabled {
+		if err := cache.InitValidateCache(validateCache); err != nil {
+			logger.Fatal("Failed to create the consent validation cache", log.Error(err))
+		}
+		logger.Info("Consent validation cache enabled",
+			log.String("address", validateCache.Redis.Address),
+			log.String("ttl", validateCache.TTL.String()))
+	}
+
+	// Consent events are published to Kafka when configured, and written to the log otherwise
+	if kafkaCfg := config.Get().Events.Kafka; kafkaCfg.Enabled {
+		publisher, err := kafka.NewEventPublisher(kafkaCfg)
+		if err != nil {
+			logger.Fatal("Failed to create Kafka event publisher", log.Error(err))
+		}
+		events.SetPublisher(publisher)
+		logger.Info("Kafka event publisher enabled",
+			log.String("topic", kafkaCfg.Topic),
+			log.Int("brokers", len(kafkaCfg.Brokers)))
+	}
+
+	// Expiring consents are notified to organization webhooks, and by email when configured
+	if notificationCfg := config.Get().Consent.ExpiryNotification; notificationCfg.Enabled {
+		notification.Register(notification.NewWebhookNotifier(notificationCfg.GetTimeout()))
+		if notificationCfg.Email.Enabled {
+			notification.Register(notification.NewEmailNotifier(notificationCfg.Email, notificationCfg.GetTimeout()))
+			logger.Info("Consent expiry emails enabled", log.String("smtp_host", notificationCfg.Email.Host))
+		}
+	}
+
 	// Create Store Registry with all stores
-	storeRegistry := stores.NewStoreRegistry(
+	storeRegistry = stores.NewStoreRegistry(
 		dbClient,
-		consent.NewConsentStore(dbClient),
+		consentStore,
 		authresource.NewAuthResourceStore(dbClient),
-		consentpurpose.NewConsentPurposeStore(dbClient),
+		purposeStore,
+		export.NewExportJobStore(dbClient),
+		consentfile.NewConsentFileStore(dbClient),
+		consentimport.NewImportJobStore(dbClient),
+		attributeschema.NewAttributeSchemaStore(dbClient),
+		resourceschema.NewResourceSchemaStore(dbClient),
+		organization.NewOrganizationStore(dbClient),
+		operationaudit.NewOperationAuditStore(dbClient),
+		eventoutbox.NewEventOutboxStore(dbClient),
 	)
 	logger.Info("Store Registry initialized with all stores")
 
+	// Organization overrides are loaded first so that every module sees the per-org consent configuration
+	orgService := organization.Initialize(adminMux, storeRegistry)
+	logger.Info("Organization module initialized")
+
+	// Operations on consents are audited from the first request, so the recorder is set first
+	operationaudit.Initialize(mux, adminMux, storeRegistry)
+	logger.Info("OperationAudit module initialized")
+
 	// Initialize all services with the registry
-	authresource.Initialize(mux, storeRegistry)
+	authResourceService := authresource.Initialize(mux, adminMux, storeRegistry)
 	logger.Info("AuthResource module initialized")
 
-	consentpurpose.Initialize(mux, storeRegistry)
+	purposeService := consentpurpose.Initialize(mux, storeRegistry)
 	logger.Info("ConsentPurpose module initialized")
 
-	consent.Initialize(mux, storeRegistry)
+	consentService := consent.Initialize(mux, adminMux, storeRegistry)
 	logger.Info("Consent module initialized")
 
+	if _, err := consentfile.Initialize(mux, storeRegistry); err != nil {
+		logger.Fatal("Failed to create the consent file storage", log.Error(err))
+	}
+	logger.Info("ConsentFile module initialized")
+
+	importService = consentimport.Initialize(mux, storeRegistry, consentService)
+	logger.Info("ConsentImport module initialized")
+
+	admin.Initialize(adminMux, storeRegistry)
+	logger.Info("Admin module initialized")
+
+	export.Initialize(adminMux, storeRegistry)
+	logger.Info("Export module initialized")
+
+	schemaService := attributeschema.Initialize(adminMux, storeRegistry)
+	logger.Info("AttributeSchema module initialized")
+
+	resourceschema.Initialize(adminMux, storeRegistry)
+	logger.Info("ResourceSchema module initialized")
+
+	// The configured bundle is applied before the server accepts requests
+	if _, err := bootstrap.Initialize(adminMux, orgService, purposeService, schemaService); err != nil {
+		logger.Fatal("Failed to apply the bootstrap bundle", log.Error(err))
+	}
+	logger.Info("Bootstrap module initialized")
+
+	// Consent state changes are pushed to the sync connectors with the events of the outbox
+	if err := consentsync.Initialize(storeRegistry); err != nil {
+		logger.Fatal("Failed to create consent sync connectors", log.Error(err))
+	}
+	logger.Info("ConsentSync module initialized")
+
+	eventoutbox.Initialize(storeRegistry)
+	logger.Info("EventOutbox module initialized")
+
+	// The error code catalog documents the problem types returned by both listeners
+	errorcatalog.Initialize(mux)
+	if adminMux != mux {
+		errorcatalog.Initialize(adminMux)
+	}
+	logger.Info("Error catalog initialized")
+
+	// The OpenAPI document is checked against the routes, so it is loaded once every route is registered
+	if err := openapi.Initialize(mux); err != nil {
+		logger.Fatal("Failed to load the OpenAPI document", log.Error(err))
+	}
+
+	// Start background tasks registered by the modules
+	scheduler.GetScheduler().Start()
+
+	// Serve the same services over gRPC when enabled
+	if cfg := config.Get(); cfg.GRPC.Enabled {
+		var grpcTLSConfig *tls.Config
+		if cfg.GRPC.TLS.Enabled {
+			reloader, err := newTLSReloader("gRPC", cfg.GRPC.TLS)
+			if err != nil {
+				logger.Fatal("Failed to configure gRPC TLS", log.Error(err))
+			}
+			grpcTLSReloader = reloader
+			grpcTLSConfig = reloader.tlsConfig()
+		}
+		grpcServer = grpcapi.NewServer(cfg, grpcapi.Services{
+			Consent:      consentService,
+			AuthResource: authResourceService,
+			Purpose:      purposeService,
+		}, grpcTLSConfig)
+		if err := grpcServer.Start(); err != nil {
+			logger.Fatal("Failed to start gRPC server", log.Error(err))
+		}
+		logger.Info("gRPC server initialized", log.Int("port", cfg.GRPC.Port), log.Bool("tls", cfg.GRPC.TLS.Enabled))
+	}
+
 	// TODO : refacter health check endpoint here.
 	// Register health check endpoint
-	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"healthy"}`))
-	})
+	mux.HandleFunc("GET /health", healthCheck)
+	if adminMux != mux {
+		adminMux.HandleFunc("GET /health", healthCheck)
+	}
 }
 
-// TODO : compare with tunder and see if we need to add anything below mwthod. if not needed we can remove it
-// unregisterServices performs cleanup of all services during shutdown.
-// Currently a placeholder for future service cleanup needs.
-func unregisterServices() {
-	// Future: Add any service-specific cleanup logic here
-	// e.g., closing connections, flushing caches, etc.
+// healthCheck handles GET /health
+func healthCheck(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"healthy"}`))
+}
+
+// unregisterServices performs cleanup of all services during shutdown. It must be called after
+// the HTTP servers stopped accepting requests; the database can be closed once it returns.
+func unregisterServices(ctx context.Context) {
+	logger := log.GetLogger()
+
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
+
+	// Stop background tasks before the database connections are closed
+	scheduler.GetScheduler().Stop()
+	if importService != nil {
+		importService.Stop()
+	}
+
+	// Wait for transactions started by requests and background tasks to commit
+	if storeRegistry != nil {
+		if err := storeRegistry.Drain(ctx); err != nil {
+			logger.Error("Shutdown deadline reached before all transactions finished", log.Error(err))
+		} else {
+			logger.Info("In-flight transactions finished")
+		}
+	}
+
+	// Deliver queued consent events
+	if err := events.Flush(ctx); err != nil {
+		logger.Error("Failed to flush consent events", log.Error(err))
+	}
+
+	cache.CloseValidateCache()
 }
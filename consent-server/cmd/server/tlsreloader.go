@@ -0,0 +1,82 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// tlsReloader serves the certificate and client CAs of a listener and reloads them from disk on
+// request, so certificates can be rotated without restarting the server
+type tlsReloader struct {
+	name string
+	cfg  config.TLSConfig
+
+	mu      sync.RWMutex
+	current *tls.Config
+}
+
+// newTLSReloader loads the certificate, key and client CA bundle of a listener
+func newTLSReloader(name string, cfg config.TLSConfig) (*tlsReloader, error) {
+	r := &tlsReloader{name: name, cfg: cfg}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload reads the files again. The previous certificate is kept when the files are invalid.
+func (r *tlsReloader) reload() error {
+	certificate, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load %s TLS certificate: %w", r.name, err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s client CA file: %w", r.name, err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("no certificates found in %s client CA file %s", r.name, r.cfg.ClientCAFile)
+		}
+	}
+
+	current := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+	}
+	if clientCAs != nil {
+		current.ClientCAs = clientCAs
+		current.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = current
+	return nil
+}
+
+// tlsConfig returns a TLS configuration that uses the most recently loaded certificate and client
+// CAs for every handshake. Client certificates are required when a client CA file is configured.
+func (r *tlsReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return &r.current.Certificates[0], nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.current, nil
+		},
+	}
+}
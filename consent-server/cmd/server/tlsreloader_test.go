@@ -0,0 +1,233 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// testCertificate is a certificate and its key, signed by a test CA or self-signed
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// newTestCertificate creates a certificate for the common name, signed by parent or self-signed
+// when parent is nil
+func newTestCertificate(t *testing.T, commonName string, isCA bool, parent *testCertificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate a key: %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("failed to generate a serial number: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              []string{commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create the certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse the certificate: %v", err)
+	}
+	return &testCertificate{cert: cert, key: key, der: der}
+}
+
+// writeFiles writes the certificate and key as PEM files and returns their paths
+func (c *testCertificate) writeFiles(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("failed to encode the key: %v", err)
+	}
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	writeFile(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}))
+	writeFile(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certFile, keyFile
+}
+
+// tlsCertificate returns the certificate for use by a TLS client
+func (c *testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+// startTLSServer serves an empty response over TLS configured by the reloader
+func startTLSServer(t *testing.T, reloader *tlsReloader) *httptest.Server {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = reloader.tlsConfig()
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// handshake connects to the server trusting roots, presenting the client certificate if any, and
+// returns the certificate the server presented
+func handshake(server *httptest.Server, roots *x509.CertPool, clientCert *tls.Certificate) (*x509.Certificate, error) {
+	tlsCfg := &tls.Config{RootCAs: roots, ServerName: "localhost"}
+	if clientCert != nil {
+		tlsCfg.Certificates = []tls.Certificate{*clientCert}
+	}
+	conn, err := tls.Dial("tcp", server.Listener.Addr().String(), tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// Client certificate failures surface on the first read under TLS 1.3
+	if _, err := conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n\r\n")); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return nil, err
+	}
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+// TestTLSReloader_ServesReloadedCertificate checks that a reload swaps the certificate served to new
+// connections and that invalid files keep the previous certificate
+func TestTLSReloader_ServesReloadedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "test-ca", true, nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	first := newTestCertificate(t, "localhost", false, ca)
+	certFile, keyFile := first.writeFiles(t, dir, "server")
+	reloader, err := newTLSReloader("server", config.TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("failed to load the certificate: %v", err)
+	}
+	server := startTLSServer(t, reloader)
+
+	served, err := handshake(server, roots, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if served.SerialNumber.Cmp(first.cert.SerialNumber) != 0 {
+		t.Fatalf("expected the initial certificate to be served")
+	}
+
+	second := newTestCertificate(t, "localhost", false, ca)
+	second.writeFiles(t, dir, "server")
+	if err := reloader.reload(); err != nil {
+		t.Fatalf("failed to reload the certificate: %v", err)
+	}
+	served, err = handshake(server, roots, nil)
+	if err != nil {
+		t.Fatalf("failed to connect after the reload: %v", err)
+	}
+	if served.SerialNumber.Cmp(second.cert.SerialNumber) != 0 {
+		t.Errorf("expected the reloaded certificate to be served")
+	}
+
+	writeFile(t, certFile, []byte("not a certificate"))
+	if err := reloader.reload(); err == nil {
+		t.Fatal("expected reloading an invalid certificate to fail")
+	}
+	served, err = handshake(server, roots, nil)
+	if err != nil {
+		t.Fatalf("failed to connect after the failed reload: %v", err)
+	}
+	if served.SerialNumber.Cmp(second.cert.SerialNumber) != 0 {
+		t.Errorf("expected the previous certificate to be kept after a failed reload")
+	}
+}
+
+// TestTLSReloader_RequiresClientCertificatesOfTheConfiguredCA checks mutual TLS
+func TestTLSReloader_RequiresClientCertificatesOfTheConfiguredCA(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "test-ca", true, nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	certFile, keyFile := newTestCertificate(t, "localhost", false, ca).writeFiles(t, dir, "server")
+	clientCAFile, _ := ca.writeFiles(t, dir, "client-ca")
+	reloader, err := newTLSReloader("server", config.TLSConfig{
+		Enabled: true, CertFile: certFile, KeyFile: keyFile, ClientCAFile: clientCAFile,
+	})
+	if err != nil {
+		t.Fatalf("failed to load the certificate: %v", err)
+	}
+	server := startTLSServer(t, reloader)
+
+	trusted := newTestCertificate(t, "gateway", false, ca).tlsCertificate()
+	untrusted := newTestCertificate(t, "gateway", false, newTestCertificate(t, "other-ca", true, nil)).tlsCertificate()
+
+	testCases := []struct {
+		name       string
+		clientCert *tls.Certificate
+		wantOK     bool
+	}{
+		{"client certificate of the CA", &trusted, true},
+		{"no client certificate", nil, false},
+		{"client certificate of another CA", &untrusted, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := handshake(server, roots, tc.clientCert)
+			if tc.wantOK && err != nil {
+				t.Errorf("expected the connection to be accepted, got %v", err)
+			}
+			if !tc.wantOK && err == nil {
+				t.Error("expected the connection to be rejected")
+			}
+		})
+	}
+}
+
+// TestNewTLSReloader_RejectsInvalidClientCAFile checks that a listener does not start with a client
+// CA file that holds no certificates
+func TestNewTLSReloader_RejectsInvalidClientCAFile(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := newTestCertificate(t, "localhost", false, nil).writeFiles(t, dir, "server")
+	clientCAFile := filepath.Join(dir, "client-ca.crt")
+	writeFile(t, clientCAFile, []byte("not a certificate"))
+
+	_, err := newTLSReloader("admin", config.TLSConfig{
+		Enabled: true, CertFile: certFile, KeyFile: keyFile, ClientCAFile: clientCAFile,
+	})
+	if err == nil {
+		t.Error("expected an invalid client CA file to be rejected")
+	}
+}
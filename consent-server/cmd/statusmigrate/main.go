@@ -0,0 +1,145 @@
+// Command statusmigrate rewrites legacy consent and authorization status names (for example
+// awaitingAuthorization and AUTHORIZED) to the status vocabulary configured in deployment.yaml.
+//
+// It runs in dry-run mode by default and only reports the affected rows. Pass -apply together with
+// -rollback-file to migrate; the rollback file receives one statement per migrated row and must not
+// already exist.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/database"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/statusmigration"
+)
+
+// mappingFlag collects repeated legacy=status flags
+type mappingFlag statusmigration.Mapping
+
+func (f mappingFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for legacy, status := range f {
+		pairs = append(pairs, legacy+"="+status)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f mappingFlag) Set(value string) error {
+	legacy, status, ok := strings.Cut(value, "=")
+	if !ok || legacy == "" || status == "" {
+		return fmt.Errorf("expected legacy=status, got %q", value)
+	}
+	f[legacy] = status
+	return nil
+}
+
+func main() {
+	configPath := flag.String("config", os.Getenv("CONFIG_PATH"), "path to deployment.yaml")
+	apply := flag.Bool("apply", false, "migrate the statuses instead of only reporting them")
+	rollbackFile := flag.String("rollback-file", "", "file to write rollback statements to (required with -apply)")
+	batchSize := flag.Int("batch-size", 500, "rows updated per transaction")
+	consentOverrides := mappingFlag{}
+	authOverrides := mappingFlag{}
+	flag.Var(consentOverrides, "consent-status", "additional or overriding consent status mapping as legacy=status (repeatable)")
+	flag.Var(authOverrides, "auth-status", "additional or overriding authorization status mapping as legacy=status (repeatable)")
+	flag.Parse()
+
+	if err := run(*configPath, *apply, *rollbackFile, *batchSize, consentOverrides, authOverrides); err != nil {
+		fmt.Fprintln(os.Stderr, "status migration failed:", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string, apply bool, rollbackFile string, batchSize int, consentOverrides, authOverrides mappingFlag) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Logging.Level != "" {
+		if err := log.SetLogLevel(cfg.Logging.Level); err != nil {
+			return fmt.Errorf("failed to set log level: %w", err)
+		}
+	}
+
+	opts := statusmigration.Options{
+		DryRun:          !apply,
+		BatchSize:       batchSize,
+		ConsentStatuses: statusmigration.DefaultConsentStatusMapping(&cfg.Consent),
+		AuthStatuses:    statusmigration.DefaultAuthStatusMapping(&cfg.Consent),
+	}
+	for legacy, status := range consentOverrides {
+		opts.ConsentStatuses[legacy] = status
+	}
+	for legacy, status := range authOverrides {
+		opts.AuthStatuses[legacy] = status
+	}
+
+	if apply {
+		if rollbackFile == "" {
+			return fmt.Errorf("-rollback-file is required with -apply")
+		}
+		// O_EXCL guards against overwriting the rollback script of a previous run
+		f, err := os.OpenFile(rollbackFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create rollback file: %w", err)
+		}
+		defer f.Close()
+		fmt.Fprintf(f, "-- Rollback of the legacy status migration started at %s\n",
+			time.Now().UTC().Format(time.RFC3339))
+		opts.Rollback = f
+	}
+
+	if err := statusmigration.Validate(&cfg.Consent, opts); err != nil {
+		return err
+	}
+
+	db, err := database.Initialize(&cfg.Database.Consent)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	healthCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := db.HealthCheck(healthCtx); err != nil {
+		return fmt.Errorf("database health check failed: %w", err)
+	}
+
+	provider.InitDBProvider(db)
+	dbClient, err := provider.GetDBProvider().GetConsentDBClient()
+	if err != nil {
+		return fmt.Errorf("failed to get database client: %w", err)
+	}
+
+	// Stop between batches on interrupt; committed batches are already in the rollback file
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	results, err := statusmigration.NewMigrator(dbClient, cfg.Database.Consent.GetType()).Run(ctx, opts)
+	printResults(results, opts.DryRun)
+	return err
+}
+
+func printResults(results []statusmigration.Result, dryRun bool) {
+	verb := "migrated"
+	if dryRun {
+		verb = "to migrate (dry run)"
+	}
+	if len(results) == 0 {
+		fmt.Println("No legacy statuses found")
+		return
+	}
+	for _, r := range results {
+		fmt.Printf("%s.%s: %d rows %s from '%s' to '%s'\n", r.Table, r.Column, r.Rows, verb, r.From, r.To)
+	}
+}
@@ -0,0 +1,447 @@
+-- Initial consent management schema (MySQL)
+-- Every statement is idempotent, so the migration can be applied to a database that was created
+-- with the schema script used before migrations were introduced.
+
+-- Main consent table
+CREATE TABLE IF NOT EXISTS CONSENT (
+  CONSENT_ID            VARCHAR(255) NOT NULL,
+  CREATED_TIME          BIGINT NOT NULL,
+  UPDATED_TIME          BIGINT NOT NULL,
+  CLIENT_ID             VARCHAR(255) NOT NULL,
+  CONSENT_TYPE          VARCHAR(64) NOT NULL,
+  CURRENT_STATUS        VARCHAR(64) NOT NULL,
+  CONSENT_FREQUENCY     INT DEFAULT NULL,
+  VALIDITY_TIME         BIGINT DEFAULT NULL,
+  RECURRING_INDICATOR   BOOLEAN DEFAULT NULL,
+  DATA_ACCESS_VALIDITY_DURATION BIGINT DEFAULT NULL,
+  PARENT_CONSENT_ID     VARCHAR(255) DEFAULT NULL,
+  VERSION               INT NOT NULL DEFAULT 1,
+  -- Set when the retention job removed the consent's personal data
+  ANONYMIZED_TIME       BIGINT DEFAULT NULL,
+  ORG_ID                VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ORG_ID),
+  INDEX idx_client_id (CLIENT_ID),
+  INDEX idx_consent_type (CONSENT_TYPE),
+  INDEX idx_current_status (CURRENT_STATUS),
+  INDEX idx_created_time (CREATED_TIME),
+  INDEX idx_validity_time (VALIDITY_TIME),
+  INDEX idx_status_updated_time (CURRENT_STATUS, UPDATED_TIME),
+  INDEX idx_parent_consent_id (PARENT_CONSENT_ID, ORG_ID),
+  INDEX idx_org_id (ORG_ID)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Authorization resource table
+CREATE TABLE IF NOT EXISTS CONSENT_AUTH_RESOURCE (
+  AUTH_ID           VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  AUTH_TYPE         VARCHAR(255) NOT NULL,
+  USER_ID           VARCHAR(255) DEFAULT NULL,
+  AUTH_STATUS       VARCHAR(255) NOT NULL,
+  UPDATED_TIME      BIGINT NOT NULL,
+  RESOURCES                TEXT DEFAULT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (AUTH_ID, ORG_ID),
+  INDEX idx_consent_id (CONSENT_ID),
+  INDEX idx_user_id (USER_ID),
+  INDEX idx_auth_status (AUTH_STATUS),
+  INDEX idx_auth_type (AUTH_TYPE),
+  CONSTRAINT FK_CONSENT_AUTH_RESOURCE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Status audit table for tracking consent status changes
+CREATE TABLE IF NOT EXISTS CONSENT_STATUS_AUDIT (
+  STATUS_AUDIT_ID   VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  CURRENT_STATUS    VARCHAR(64) NOT NULL,
+  ACTION_TIME       BIGINT NOT NULL,
+  REASON            TEXT DEFAULT NULL,
+  ACTION_BY         VARCHAR(255) DEFAULT NULL,
+  PREVIOUS_STATUS   VARCHAR(64) DEFAULT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (STATUS_AUDIT_ID, ORG_ID),
+  INDEX idx_consent_id (CONSENT_ID),
+  INDEX idx_action_time (ACTION_TIME),
+  INDEX idx_action_by (ACTION_BY, ACTION_TIME),
+  CONSTRAINT FK_CONSENT_STATUS_AUDIT
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Status audit table for tracking authorization resource status changes. Entries reference the
+-- consent rather than the auth resource so they outlive authorizations replaced by a consent update.
+CREATE TABLE IF NOT EXISTS AUTH_STATUS_AUDIT (
+  STATUS_AUDIT_ID   VARCHAR(255) NOT NULL,
+  AUTH_ID           VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  CURRENT_STATUS    VARCHAR(255) NOT NULL,
+  PREVIOUS_STATUS   VARCHAR(255) DEFAULT NULL,
+  ACTION_TIME       BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (STATUS_AUDIT_ID, ORG_ID),
+  INDEX idx_auth_status_audit_auth_id (AUTH_ID, CONSENT_ID),
+  CONSTRAINT FK_AUTH_STATUS_AUDIT
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Consent attributes table for key-value pairs
+CREATE TABLE IF NOT EXISTS CONSENT_ATTRIBUTE (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  ATT_KEY           VARCHAR(255) NOT NULL,
+  ATT_VALUE         TEXT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ATT_KEY, ORG_ID),
+  INDEX idx_att_key (ATT_KEY),
+  CONSTRAINT FK_CONSENT_ATTRIBUTE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Authorizer roles that must each approve a consent before it becomes active
+CREATE TABLE IF NOT EXISTS CONSENT_REQUIRED_AUTHORIZER (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  AUTHORIZER_ROLE   VARCHAR(255) NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, AUTHORIZER_ROLE, ORG_ID),
+  CONSTRAINT FK_CONSENT_REQUIRED_AUTHORIZER
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Consent history table holding a snapshot of every superseded consent version
+CREATE TABLE IF NOT EXISTS CONSENT_HISTORY (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  VERSION           INT NOT NULL,
+  SNAPSHOT          JSON NOT NULL,
+  AMENDED_TIME      BIGINT NOT NULL,
+  AMENDED_BY        VARCHAR(255) DEFAULT NULL,
+  REASON            TEXT DEFAULT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, VERSION, ORG_ID),
+  INDEX idx_history_amended_time (AMENDED_TIME),
+  CONSTRAINT FK_CONSENT_HISTORY
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Consent usage table counting validated accesses to a consent per frequency period
+CREATE TABLE IF NOT EXISTS CONSENT_USAGE (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  PERIOD_START      BIGINT NOT NULL,
+  ACCESS_COUNT      INT NOT NULL DEFAULT 0,
+  LAST_ACCESS_TIME  BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ORG_ID, PERIOD_START),
+  CONSTRAINT FK_CONSENT_USAGE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Consent locks taken while a user is authorizing a consent. Only callers presenting LOCK_TOKEN
+-- can change the consent until EXPIRY_TIME, after which the lock is replaced by the next one taken.
+CREATE TABLE IF NOT EXISTS CONSENT_LOCK (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  LOCK_TOKEN        VARCHAR(255) NOT NULL,
+  LOCKED_BY         VARCHAR(255) NOT NULL,
+  LOCKED_TIME       BIGINT NOT NULL,
+  EXPIRY_TIME       BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ORG_ID),
+  CONSTRAINT FK_CONSENT_LOCK
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- The modifiedResponse document of a consent, as last returned by the enrich hooks of its create
+-- or update. It is returned with the consent and left out of validate responses.
+CREATE TABLE IF NOT EXISTS CONSENT_MODIFIED_RESPONSE (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  DOCUMENT          JSON NOT NULL,
+  UPDATED_TIME      BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ORG_ID),
+  CONSTRAINT FK_CONSENT_MODIFIED_RESPONSE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Tags labelling consents, such as the migration batch of a consent. Tags are kept apart from
+-- consent attributes so that tagging does not conflict with attribute updates by clients.
+CREATE TABLE IF NOT EXISTS CONSENT_TAG (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  TAG               VARCHAR(64) NOT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, TAG, ORG_ID),
+  INDEX idx_tag (TAG, ORG_ID),
+  CONSTRAINT FK_CONSENT_TAG
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Free-text notes attached to consents by support agents and systems. Notes are kept apart from
+-- consent attributes and are only returned on the admin notes endpoint.
+CREATE TABLE IF NOT EXISTS CONSENT_NOTE (
+  NOTE_ID           VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  AUTHOR            VARCHAR(255) NOT NULL,
+  NOTE_TEXT         TEXT NOT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (NOTE_ID, ORG_ID),
+  INDEX idx_consent_id (CONSENT_ID, ORG_ID, CREATED_TIME),
+  CONSTRAINT FK_CONSENT_NOTE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Expiry notices sent for consents. VALIDITY_TIME is the validity time the notice was sent for, so
+-- a consent whose validity is extended is notified again before its new validity time.
+CREATE TABLE IF NOT EXISTS CONSENT_EXPIRY_NOTICE (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  VALIDITY_TIME     BIGINT NOT NULL,
+  NOTIFIED_TIME     BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ORG_ID),
+  CONSTRAINT FK_CONSENT_EXPIRY_NOTICE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Consent purpose table
+CREATE TABLE IF NOT EXISTS CONSENT_PURPOSE (
+  ID            VARCHAR(255) NOT NULL,
+  NAME          VARCHAR(255) NOT NULL,
+  DESCRIPTION   VARCHAR(1024) DEFAULT NULL,
+  TYPE          VARCHAR(64) NOT NULL DEFAULT 'string',
+  ORG_ID        VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (ID, ORG_ID),
+  UNIQUE KEY unique_name_per_org (NAME, ORG_ID),
+  INDEX idx_name (NAME),
+  INDEX idx_org_id (ORG_ID),
+  INDEX idx_type (TYPE)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Mapping table to link consent with purposes
+CREATE TABLE IF NOT EXISTS CONSENT_PURPOSE_MAPPING (
+  CONSENT_ID       VARCHAR(255) NOT NULL,
+  ORG_ID           VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PURPOSE_ID       VARCHAR(255) NOT NULL,
+  VALUE            JSON DEFAULT NULL,
+  IS_USER_APPROVED BOOLEAN DEFAULT FALSE,
+  IS_MANDATORY     BOOLEAN NOT NULL DEFAULT TRUE,
+  EXPIRES_AT       BIGINT DEFAULT NULL,
+  LAST_CONFIRMED_AT BIGINT DEFAULT NULL,
+  PRIMARY KEY (CONSENT_ID, ORG_ID, PURPOSE_ID),
+  INDEX idx_consent_id (CONSENT_ID),
+  INDEX idx_purpose_id (PURPOSE_ID),
+  INDEX idx_is_user_approved (IS_USER_APPROVED),
+  INDEX idx_is_mandatory (IS_MANDATORY),
+  INDEX idx_purpose_approved (PURPOSE_ID, IS_USER_APPROVED),
+  CONSTRAINT FK_CONSENT_PURPOSE_MAPPING_CONSENT
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE,
+  CONSTRAINT FK_CONSENT_PURPOSE_MAPPING_PURPOSE
+    FOREIGN KEY (PURPOSE_ID, ORG_ID)
+    REFERENCES CONSENT_PURPOSE (ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Attributes for consent purposes (key/value pairs scoped to purpose + org)
+CREATE TABLE IF NOT EXISTS CONSENT_PURPOSE_ATTRIBUTE (
+  PURPOSE_ID       VARCHAR(255) NOT NULL,
+  ATT_KEY          VARCHAR(255) NOT NULL,
+  ATT_VALUE        TEXT NOT NULL,
+  ORG_ID           VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (PURPOSE_ID, ATT_KEY, ORG_ID),
+  INDEX idx_att_key_purpose (ATT_KEY),
+  CONSTRAINT FK_CONSENT_PURPOSE_ATTRIBUTE_PURPOSE
+    FOREIGN KEY (PURPOSE_ID, ORG_ID)
+    REFERENCES CONSENT_PURPOSE (ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Scheduled consent export jobs
+CREATE TABLE IF NOT EXISTS EXPORT_JOB (
+  JOB_ID            VARCHAR(255) NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  NAME              VARCHAR(255) NOT NULL,
+  FILTER            JSON DEFAULT NULL,
+  FORMAT            VARCHAR(16) NOT NULL,
+  DESTINATION       JSON NOT NULL,
+  ENCRYPTION_KEY_ID VARCHAR(255) DEFAULT NULL,
+  INTERVAL_SECONDS  BIGINT NOT NULL,
+  ENABLED           BOOLEAN NOT NULL DEFAULT TRUE,
+  NEXT_RUN_TIME     BIGINT NOT NULL,
+  LAST_RUN_TIME     BIGINT DEFAULT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  UPDATED_TIME      BIGINT NOT NULL,
+  PRIMARY KEY (JOB_ID, ORG_ID),
+  UNIQUE KEY unique_export_job_name_per_org (NAME, ORG_ID),
+  INDEX idx_export_next_run (ENABLED, NEXT_RUN_TIME)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Delivery receipts recorded for every export job run
+CREATE TABLE IF NOT EXISTS EXPORT_DELIVERY_RECEIPT (
+  RECEIPT_ID        VARCHAR(255) NOT NULL,
+  JOB_ID            VARCHAR(255) NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  STATUS            VARCHAR(32) NOT NULL,
+  STARTED_TIME      BIGINT NOT NULL,
+  COMPLETED_TIME    BIGINT NOT NULL,
+  RECORD_COUNT      INT NOT NULL DEFAULT 0,
+  OBJECT_LOCATION   VARCHAR(1024) DEFAULT NULL,
+  CHECKSUM          VARCHAR(128) DEFAULT NULL,
+  ERROR_MESSAGE     TEXT DEFAULT NULL,
+  PRIMARY KEY (RECEIPT_ID, ORG_ID),
+  INDEX idx_receipt_job_id (JOB_ID, STARTED_TIME),
+  CONSTRAINT FK_EXPORT_DELIVERY_RECEIPT_JOB
+    FOREIGN KEY (JOB_ID, ORG_ID)
+    REFERENCES EXPORT_JOB (JOB_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Documents attached to consents. CONTENT holds the file when the database storage is
+-- configured; with object storage only STORAGE_LOCATION is set.
+CREATE TABLE IF NOT EXISTS CONSENT_FILE (
+  FILE_ID           VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  FILE_NAME         VARCHAR(255) NOT NULL,
+  CONTENT_TYPE      VARCHAR(255) NOT NULL,
+  FILE_SIZE         BIGINT NOT NULL,
+  CHECKSUM          VARCHAR(128) NOT NULL,
+  STORAGE_TYPE      VARCHAR(16) NOT NULL,
+  STORAGE_LOCATION  VARCHAR(1024) DEFAULT NULL,
+  CONTENT           LONGBLOB DEFAULT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  PRIMARY KEY (FILE_ID, ORG_ID),
+  INDEX idx_consent_file_consent (CONSENT_ID, ORG_ID, CREATED_TIME),
+  CONSTRAINT FK_CONSENT_FILE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Bulk consent import jobs. The counts are updated after every row, so PROCESSED_COUNT is the
+-- number of lines of the import file already handled.
+CREATE TABLE IF NOT EXISTS IMPORT_JOB (
+  JOB_ID            VARCHAR(255) NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  CLIENT_ID         VARCHAR(255) NOT NULL,
+  STATUS            VARCHAR(32) NOT NULL,
+  PROCESSED_COUNT   BIGINT NOT NULL DEFAULT 0,
+  SUCCEEDED_COUNT   BIGINT NOT NULL DEFAULT 0,
+  FAILED_COUNT      BIGINT NOT NULL DEFAULT 0,
+  ERROR_MESSAGE     TEXT DEFAULT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  UPDATED_TIME      BIGINT NOT NULL,
+  COMPLETED_TIME    BIGINT DEFAULT NULL,
+  PRIMARY KEY (JOB_ID, ORG_ID),
+  INDEX idx_import_job_status (STATUS)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Rows of an import file that could not be imported
+CREATE TABLE IF NOT EXISTS IMPORT_JOB_ERROR (
+  JOB_ID            VARCHAR(255) NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  LINE_NUMBER       BIGINT NOT NULL,
+  ERROR_CODE        VARCHAR(32) NOT NULL,
+  ERROR_MESSAGE     TEXT NOT NULL,
+  PRIMARY KEY (JOB_ID, ORG_ID, LINE_NUMBER),
+  CONSTRAINT FK_IMPORT_JOB_ERROR_JOB
+    FOREIGN KEY (JOB_ID, ORG_ID)
+    REFERENCES IMPORT_JOB (JOB_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Per-organization consent attribute schema. ATTRIBUTES holds the JSON list of allowed keys with
+-- their type, pattern and required consent types.
+CREATE TABLE IF NOT EXISTS ATTRIBUTE_SCHEMA (
+  ORG_ID            VARCHAR(255) NOT NULL,
+  ATTRIBUTES        JSON NOT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  UPDATED_TIME      BIGINT NOT NULL,
+  PRIMARY KEY (ORG_ID)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Per-organization JSON Schema for the RESOURCES of authorizations of one type. Authorization types
+-- without a schema accept any resources.
+CREATE TABLE IF NOT EXISTS AUTH_RESOURCE_SCHEMA (
+  AUTH_TYPE         VARCHAR(255) NOT NULL,
+  ORG_ID            VARCHAR(255) NOT NULL,
+  SCHEMA_JSON       JSON NOT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  UPDATED_TIME      BIGINT NOT NULL,
+  PRIMARY KEY (AUTH_TYPE, ORG_ID)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Organization metadata. STATUS_MAPPINGS holds the JSON consent status names the organization
+-- overrides, WEBHOOK_URLS the JSON list of webhook endpoints and ALLOWED_CONSENT_TYPES the JSON
+-- list of consent types it accepts; NULL columns fall back to the deployment configuration.
+CREATE TABLE IF NOT EXISTS ORGANIZATION (
+  ORG_ID            VARCHAR(255) NOT NULL,
+  NAME              VARCHAR(255) NOT NULL,
+  STATUS_MAPPINGS   JSON DEFAULT NULL,
+  WEBHOOK_URLS      JSON DEFAULT NULL,
+  RETENTION_DAYS    INT DEFAULT NULL,
+  DEFAULT_VALIDITY_SECONDS BIGINT DEFAULT NULL,
+  ALLOWED_CONSENT_TYPES    JSON DEFAULT NULL,
+  EXPIRY_NOTICE_DAYS       INT DEFAULT NULL,
+  TERMINAL_RETENTION_DAYS  INT DEFAULT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  UPDATED_TIME      BIGINT NOT NULL,
+  PRIMARY KEY (ORG_ID)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Operations callers performed on consents, with the outcome. Entries are kept when the consent
+-- is purged. DETAILS holds the JSON description of what the operation changed.
+CREATE TABLE IF NOT EXISTS CONSENT_OPERATION_AUDIT (
+  OPERATION_ID      VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) DEFAULT NULL,
+  ACTION            VARCHAR(64) NOT NULL,
+  ACTOR             VARCHAR(255) DEFAULT NULL,
+  CLIENT_ID         VARCHAR(255) DEFAULT NULL,
+  HTTP_METHOD       VARCHAR(16) NOT NULL,
+  ROUTE             VARCHAR(255) NOT NULL,
+  STATUS_CODE       INT NOT NULL,
+  OUTCOME           VARCHAR(16) NOT NULL,
+  DETAILS           JSON DEFAULT NULL,
+  ACTION_TIME       BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) NOT NULL,
+  PRIMARY KEY (OPERATION_ID),
+  INDEX idx_operation_audit_consent (CONSENT_ID, ORG_ID, ACTION_TIME),
+  INDEX idx_operation_audit_actor (ACTOR, ACTION_TIME)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Consent events written in the transaction of the consent change, so an event is recorded if and
+-- only if the change commits. The relay publishes them in CREATED_TIME order and deletes them once
+-- the transport acknowledged them. PAYLOAD holds the JSON encoded event.
+CREATE TABLE IF NOT EXISTS CONSENT_EVENT_OUTBOX (
+  EVENT_ID          VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  EVENT_TYPE        VARCHAR(64) NOT NULL,
+  PAYLOAD           JSON NOT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  ATTEMPTS          INT NOT NULL DEFAULT 0,
+  NEXT_ATTEMPT_TIME BIGINT NOT NULL,
+  LAST_ERROR        TEXT DEFAULT NULL,
+  ORG_ID            VARCHAR(255) NOT NULL,
+  PRIMARY KEY (EVENT_ID),
+  INDEX idx_event_outbox_created (CREATED_TIME, EVENT_ID)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
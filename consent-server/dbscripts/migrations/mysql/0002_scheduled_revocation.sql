@@ -0,0 +1,18 @@
+-- Scheduled consent revocations (MySQL)
+
+-- Revocations requested with a future effectiveAt. The scheduled revocation job applies
+-- REVOKE_REQUEST, the JSON revoke request, once EFFECTIVE_AT is reached and deletes the row.
+-- A consent has at most one scheduled revocation; scheduling another replaces it.
+CREATE TABLE IF NOT EXISTS CONSENT_SCHEDULED_REVOCATION (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  EFFECTIVE_AT      BIGINT NOT NULL,
+  REVOKE_REQUEST    TEXT NOT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ORG_ID),
+  INDEX idx_effective_at (EFFECTIVE_AT),
+  CONSTRAINT FK_CONSENT_SCHEDULED_REVOCATION
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,10 @@
+-- Delegated authorizations (MySQL)
+
+-- An authorization granted on behalf of another person, as under a power of attorney, records
+-- DELEGATOR_ID, the person the consent is given for, and DELEGATE_ID, the person who gave it.
+-- Both are set or both are NULL.
+ALTER TABLE CONSENT_AUTH_RESOURCE
+  ADD COLUMN DELEGATOR_ID VARCHAR(255) DEFAULT NULL,
+  ADD COLUMN DELEGATE_ID  VARCHAR(255) DEFAULT NULL,
+  ADD INDEX idx_delegator_id (DELEGATOR_ID),
+  ADD INDEX idx_delegate_id (DELEGATE_ID);
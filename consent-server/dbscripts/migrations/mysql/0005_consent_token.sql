@@ -0,0 +1,21 @@
+-- Token-consent bindings (MySQL)
+
+-- Access and refresh tokens issued for a consent, identified by their jti or a hash of the token,
+-- so that gateways can resolve a token to its consent. A token is bound to one consent. EXPIRES_AT
+-- is the expiry of the token in milliseconds, NULL when it is not known; expired bindings are
+-- removed by the token cleanup job, and all bindings of a consent are removed when it ends.
+CREATE TABLE IF NOT EXISTS CONSENT_TOKEN (
+  TOKEN_ID          VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  TOKEN_TYPE        VARCHAR(32) NOT NULL,
+  EXPIRES_AT        BIGINT DEFAULT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (TOKEN_ID, ORG_ID),
+  INDEX idx_consent_id (CONSENT_ID, ORG_ID),
+  INDEX idx_expires_at (EXPIRES_AT),
+  CONSTRAINT FK_CONSENT_TOKEN
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,11 @@
+-- Delegated authorizations (SQLite)
+-- SQLite variant of mysql/0003_auth_resource_delegation.sql. Keep the migrations of both databases in sync.
+
+-- An authorization granted on behalf of another person, as under a power of attorney, records
+-- DELEGATOR_ID, the person the consent is given for, and DELEGATE_ID, the person who gave it.
+-- Both are set or both are NULL.
+ALTER TABLE CONSENT_AUTH_RESOURCE ADD COLUMN DELEGATOR_ID VARCHAR(255) DEFAULT NULL;
+ALTER TABLE CONSENT_AUTH_RESOURCE ADD COLUMN DELEGATE_ID VARCHAR(255) DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_auth_resource_delegator_id ON CONSENT_AUTH_RESOURCE (DELEGATOR_ID);
+CREATE INDEX IF NOT EXISTS idx_auth_resource_delegate_id ON CONSENT_AUTH_RESOURCE (DELEGATE_ID);
@@ -0,0 +1,25 @@
+-- Consent evidence (SQLite)
+-- SQLite variant of mysql/0004_consent_evidence.sql. Keep the migrations of both databases in sync.
+
+-- Evidence of how a consent was given or changed: the channel it was collected through, the IP
+-- address and user agent of the person, the version of the consent UI and a SHA-256 checksum of the
+-- text that was presented. One row is recorded for each create or update that supplies evidence;
+-- CONSENT_VERSION is the version of the consent the evidence was captured for.
+CREATE TABLE IF NOT EXISTS CONSENT_EVIDENCE (
+  EVIDENCE_ID              VARCHAR(255) NOT NULL,
+  CONSENT_ID               VARCHAR(255) NOT NULL,
+  CONSENT_VERSION          INT NOT NULL,
+  CHANNEL                  VARCHAR(64) NOT NULL,
+  IP_ADDRESS               VARCHAR(45) DEFAULT NULL,
+  USER_AGENT               VARCHAR(512) DEFAULT NULL,
+  UI_VERSION               VARCHAR(64) DEFAULT NULL,
+  PRESENTED_TEXT_CHECKSUM  VARCHAR(64) DEFAULT NULL,
+  CAPTURED_TIME            BIGINT NOT NULL,
+  ORG_ID                   VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (EVIDENCE_ID, ORG_ID),
+  CONSTRAINT FK_CONSENT_EVIDENCE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_consent_evidence_consent_id ON CONSENT_EVIDENCE (CONSENT_ID, ORG_ID, CAPTURED_TIME);
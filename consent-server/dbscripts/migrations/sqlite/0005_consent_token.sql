@@ -0,0 +1,22 @@
+-- Token-consent bindings (SQLite)
+-- SQLite variant of mysql/0005_consent_token.sql. Keep the migrations of both databases in sync.
+
+-- Access and refresh tokens issued for a consent, identified by their jti or a hash of the token,
+-- so that gateways can resolve a token to its consent. A token is bound to one consent. EXPIRES_AT
+-- is the expiry of the token in milliseconds, NULL when it is not known; expired bindings are
+-- removed by the token cleanup job, and all bindings of a consent are removed when it ends.
+CREATE TABLE IF NOT EXISTS CONSENT_TOKEN (
+  TOKEN_ID          VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  TOKEN_TYPE        VARCHAR(32) NOT NULL,
+  EXPIRES_AT        BIGINT DEFAULT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (TOKEN_ID, ORG_ID),
+  CONSTRAINT FK_CONSENT_TOKEN
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_consent_token_consent_id ON CONSENT_TOKEN (CONSENT_ID, ORG_ID);
+CREATE INDEX IF NOT EXISTS idx_consent_token_expires_at ON CONSENT_TOKEN (EXPIRES_AT);
@@ -0,0 +1,20 @@
+-- Scheduled consent updates (SQLite)
+-- SQLite variant of mysql/0006_scheduled_update.sql. Keep the migrations of both databases in sync.
+
+-- Updates requested with a future effectiveAt. The scheduled update job applies UPDATE_REQUEST,
+-- the JSON update request, once EFFECTIVE_AT is reached and deletes the row.
+-- A consent has at most one scheduled update; scheduling another replaces it.
+CREATE TABLE IF NOT EXISTS CONSENT_SCHEDULED_UPDATE (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  EFFECTIVE_AT      BIGINT NOT NULL,
+  UPDATE_REQUEST    TEXT NOT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ORG_ID),
+  CONSTRAINT FK_CONSENT_SCHEDULED_UPDATE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_update_effective_at ON CONSENT_SCHEDULED_UPDATE (EFFECTIVE_AT);
@@ -0,0 +1,281 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/wso2/consent-management-api/internal/admin/model"
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// adminHandler handles HTTP requests for administrative operations
+type adminHandler struct {
+	service AdminService
+}
+
+// newAdminHandler creates a new admin handler
+func newAdminHandler(service AdminService) *adminHandler {
+	return &adminHandler{
+		service: service,
+	}
+}
+
+// listCaches handles GET /admin/caches
+func (h *adminHandler) listCaches(w http.ResponseWriter, r *http.Request) {
+	hotKeyCount, err := parseHotKeyCount(r)
+	if err != nil {
+		utils.SendError(w, r, err)
+		return
+	}
+
+	stats := h.service.ListCacheStats(r.Context(), hotKeyCount)
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(model.CacheStatsListResponse{Data: stats})
+}
+
+// getCache handles GET /admin/caches/{cacheName}
+func (h *adminHandler) getCache(w http.ResponseWriter, r *http.Request) {
+	hotKeyCount, err := parseHotKeyCount(r)
+	if err != nil {
+		utils.SendError(w, r, err)
+		return
+	}
+
+	stats, serviceErr := h.service.GetCacheStats(r.Context(), r.PathValue("cacheName"), hotKeyCount)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(stats)
+}
+
+// invalidateAllCaches handles DELETE /admin/caches
+func (h *adminHandler) invalidateAllCaches(w http.ResponseWriter, r *http.Request) {
+	h.invalidate(w, r, "")
+}
+
+// invalidateCache handles DELETE /admin/caches/{cacheName}
+func (h *adminHandler) invalidateCache(w http.ResponseWriter, r *http.Request) {
+	h.invalidate(w, r, r.PathValue("cacheName"))
+}
+
+// invalidate removes cache entries matching the consentId, purposeName and orgId query parameters
+func (h *adminHandler) invalidate(w http.ResponseWriter, r *http.Request, cacheName string) {
+	query := r.URL.Query()
+	filter := cache.InvalidationFilter{
+		OrgID:       query.Get("orgId"),
+		ConsentID:   query.Get("consentId"),
+		PurposeName: query.Get("purposeName"),
+	}
+
+	response, serviceErr := h.service.InvalidateCaches(r.Context(), cacheName, filter)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// getClock handles GET /admin/clock
+func (h *adminHandler) getClock(w http.ResponseWriter, r *http.Request) {
+	response := h.service.GetClock(r.Context())
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// updateClock handles PUT /admin/clock
+func (h *adminHandler) updateClock(w http.ResponseWriter, r *http.Request) {
+	var req model.ClockUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.UpdateClock(r.Context(), req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// resetClock handles DELETE /admin/clock
+func (h *adminHandler) resetClock(w http.ResponseWriter, r *http.Request) {
+	response, serviceErr := h.service.ResetClock(r.Context())
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// listFeatureFlags handles GET /admin/feature-flags
+func (h *adminHandler) listFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	response := h.service.ListFeatureFlags(r.Context(), r.URL.Query().Get("orgId"))
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// setFeatureFlag handles PUT /admin/feature-flags/{flagName}
+func (h *adminHandler) setFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	var req model.FeatureFlagUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.SetFeatureFlag(r.Context(), r.URL.Query().Get("orgId"), r.PathValue("flagName"), req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// clearFeatureFlag handles DELETE /admin/feature-flags/{flagName}
+func (h *adminHandler) clearFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	response, serviceErr := h.service.ClearFeatureFlag(r.Context(), r.URL.Query().Get("orgId"), r.PathValue("flagName"))
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// listExtensions handles GET /admin/extensions
+func (h *adminHandler) listExtensions(w http.ResponseWriter, r *http.Request) {
+	stats := h.service.ListExtensionStats(r.Context())
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(model.ExtensionStatsListResponse{Data: stats})
+}
+
+// getDatabasePool handles GET /admin/database/pool
+func (h *adminHandler) getDatabasePool(w http.ResponseWriter, r *http.Request) {
+	response := h.service.GetDatabasePoolStats(r.Context())
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// getLogLevels handles GET /admin/logging/level
+func (h *adminHandler) getLogLevels(w http.ResponseWriter, r *http.Request) {
+	response := h.service.GetLogLevels(r.Context())
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// setLogLevel handles PUT /admin/logging/level
+func (h *adminHandler) setLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req model.LogLevelUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.SetLogLevel(r.Context(), req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// setModuleLogLevel handles PUT /admin/logging/level/{module}
+func (h *adminHandler) setModuleLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req model.LogLevelUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.SetModuleLogLevel(r.Context(), r.PathValue("module"), req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// clearModuleLogLevel handles DELETE /admin/logging/level/{module}
+func (h *adminHandler) clearModuleLogLevel(w http.ResponseWriter, r *http.Request) {
+	response := h.service.ClearModuleLogLevel(r.Context(), r.PathValue("module"))
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// migrate handles POST /admin/migrate
+func (h *adminHandler) migrate(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if value := r.URL.Query().Get("dryRun"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "dryRun must be true or false"))
+			return
+		}
+		dryRun = parsed
+	}
+
+	response, serviceErr := h.service.Migrate(r.Context(), dryRun)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseHotKeyCount reads the optional hotKeys query parameter
+func parseHotKeyCount(r *http.Request) (int, *serviceerror.ServiceError) {
+	hotKeysStr := r.URL.Query().Get("hotKeys")
+	if hotKeysStr == "" {
+		return cache.DefaultHotKeyCount, nil
+	}
+	hotKeys, err := strconv.Atoi(hotKeysStr)
+	if err != nil || hotKeys < 0 || hotKeys > 100 {
+		return 0, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "hotKeys must be an integer between 0 and 100")
+	}
+	return hotKeys, nil
+}
@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/clock"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize sets up the admin module and registers routes
+func Initialize(mux *http.ServeMux, registry *stores.StoreRegistry) AdminService {
+	service := newAdminService(registry)
+	handler := newAdminHandler(service)
+
+	registerRoutes(mux, handler)
+
+	return service
+}
+
+// registerRoutes registers all admin routes. Admin routes are protected with admin basic auth.
+func registerRoutes(mux *http.ServeMux, handler *adminHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "X-Correlation-ID"},
+	}
+
+	// GET /api/v1/admin/caches - List cache statistics
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/caches",
+		middleware.WithAdminAuth(handler.listCaches), corsOpts))
+
+	// GET /api/v1/admin/caches/{cacheName} - Get statistics for a cache
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/caches/{cacheName}",
+		middleware.WithAdminAuth(handler.getCache), corsOpts))
+
+	// DELETE /api/v1/admin/caches - Invalidate entries across all caches
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/admin/caches",
+		middleware.WithAdminAuth(handler.invalidateAllCaches), corsOpts))
+
+	// DELETE /api/v1/admin/caches/{cacheName} - Invalidate entries in a cache
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/admin/caches/{cacheName}",
+		middleware.WithAdminAuth(handler.invalidateCache), corsOpts))
+
+	// GET /api/v1/admin/feature-flags - List feature flag states, optionally for an organization
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/feature-flags",
+		middleware.WithAdminAuth(handler.listFeatureFlags), corsOpts))
+
+	// PUT /api/v1/admin/feature-flags/{flagName} - Override a feature flag for an organization
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/admin/feature-flags/{flagName}",
+		middleware.WithAdminAuth(handler.setFeatureFlag), corsOpts))
+
+	// DELETE /api/v1/admin/feature-flags/{flagName} - Remove an organization's feature flag override
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/admin/feature-flags/{flagName}",
+		middleware.WithAdminAuth(handler.clearFeatureFlag), corsOpts))
+
+	// GET /api/v1/admin/extensions - List service extension hook metrics and circuit states
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/extensions",
+		middleware.WithAdminAuth(handler.listExtensions), corsOpts))
+
+	// GET /api/v1/admin/database/pool - Get the database connection pool settings and gauges
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/database/pool",
+		middleware.WithAdminAuth(handler.getDatabasePool), corsOpts))
+
+	// GET /api/v1/admin/logging/level - Get the server and module log levels
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/logging/level",
+		middleware.WithAdminAuth(handler.getLogLevels), corsOpts))
+
+	// PUT /api/v1/admin/logging/level - Change the server log level
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/admin/logging/level",
+		middleware.WithAdminAuth(handler.setLogLevel), corsOpts))
+
+	// PUT /api/v1/admin/logging/level/{module} - Change the log level of a module
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/admin/logging/level/{module}",
+		middleware.WithAdminAuth(handler.setModuleLogLevel), corsOpts))
+
+	// DELETE /api/v1/admin/logging/level/{module} - Return a module to the server log level
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/admin/logging/level/{module}",
+		middleware.WithAdminAuth(handler.clearModuleLogLevel), corsOpts))
+
+	// POST /api/v1/admin/migrate - Apply the pending schema migrations, or list them with dryRun=true
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/admin/migrate",
+		middleware.WithAdminAuth(handler.migrate), corsOpts))
+
+	// The clock API lets test environments control the server's notion of "now".
+	// It is only registered when explicitly enabled in the testing configuration.
+	cfg := config.Get()
+	if cfg == nil || !cfg.Testing.ClockControlEnabled {
+		return
+	}
+	clock.EnableControl()
+	log.GetLogger().Warn("Clock control is enabled, this must not be used in production")
+
+	// GET /api/v1/admin/clock - Get the server clock
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/clock",
+		middleware.WithAdminAuth(handler.getClock), corsOpts))
+
+	// PUT /api/v1/admin/clock - Freeze, shift or advance the server clock
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/admin/clock",
+		middleware.WithAdminAuth(handler.updateClock), corsOpts))
+
+	// DELETE /api/v1/admin/clock - Reset the server clock to system time
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/admin/clock",
+		middleware.WithAdminAuth(handler.resetClock), corsOpts))
+}
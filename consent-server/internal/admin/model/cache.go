@@ -0,0 +1,20 @@
+package model
+
+import "github.com/wso2/consent-management-api/internal/system/cache"
+
+// CacheStatsListResponse represents the response for listing cache statistics
+type CacheStatsListResponse struct {
+	Data []cache.Stats `json:"data"`
+}
+
+// CacheInvalidationResponse represents the result of a cache invalidation request
+type CacheInvalidationResponse struct {
+	Caches       []CacheInvalidationResult `json:"caches"`
+	TotalRemoved int                       `json:"totalRemoved"`
+}
+
+// CacheInvalidationResult holds the number of entries removed from a single cache
+type CacheInvalidationResult struct {
+	Name    string `json:"name"`
+	Removed int    `json:"removed"`
+}
@@ -0,0 +1,16 @@
+package model
+
+// ClockUpdateRequest represents a request to change the server clock.
+// Exactly one of the fields must be provided. All values are in milliseconds.
+type ClockUpdateRequest struct {
+	Time          *int64 `json:"time,omitempty"`          // Freeze the clock at this epoch time
+	OffsetMillis  *int64 `json:"offsetMillis,omitempty"`  // Keep the clock running, shifted from real time
+	AdvanceMillis *int64 `json:"advanceMillis,omitempty"` // Move the clock forward from its current value
+}
+
+// ClockResponse represents the current state of the server clock
+type ClockResponse struct {
+	Now          int64 `json:"now"`
+	Frozen       bool  `json:"frozen"`
+	OffsetMillis int64 `json:"offsetMillis"`
+}
@@ -0,0 +1,8 @@
+package model
+
+import "github.com/wso2/consent-management-api/internal/system/database"
+
+// DatabasePoolResponse represents the response for the database connection pool gauges
+type DatabasePoolResponse struct {
+	Consent database.PoolStats `json:"consent"`
+}
@@ -0,0 +1,8 @@
+package model
+
+import "github.com/wso2/consent-management-api/internal/system/extension"
+
+// ExtensionStatsListResponse represents the response for listing service extension hook metrics
+type ExtensionStatsListResponse struct {
+	Data []extension.HookStats `json:"data"`
+}
@@ -0,0 +1,20 @@
+package model
+
+// FeatureFlagResponse represents the effective state of a feature flag
+type FeatureFlagResponse struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Source  string `json:"source"` // default, config or override
+}
+
+// FeatureFlagListResponse represents the feature flags of an organization, or the configured
+// defaults when no organization is given
+type FeatureFlagListResponse struct {
+	OrgID string                `json:"orgId,omitempty"`
+	Data  []FeatureFlagResponse `json:"data"`
+}
+
+// FeatureFlagUpdateRequest represents a request to override a feature flag for an organization
+type FeatureFlagUpdateRequest struct {
+	Enabled *bool `json:"enabled"`
+}
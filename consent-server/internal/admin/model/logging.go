@@ -0,0 +1,13 @@
+package model
+
+// LogLevelResponse represents the level of the server log and of the modules that have their own
+// level. Modules are the component names logged with each entry, such as DBClient.
+type LogLevelResponse struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+// LogLevelUpdateRequest represents a request to change the level of the server log or of a module
+type LogLevelUpdateRequest struct {
+	Level string `json:"level"` // debug, info, warn or error
+}
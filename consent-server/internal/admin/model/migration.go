@@ -0,0 +1,18 @@
+package model
+
+// MigrationResponse represents the result of a schema migration run
+type MigrationResponse struct {
+	DryRun bool `json:"dryRun"`
+	// CurrentVersion is the schema version before the run, 0 for an empty database
+	CurrentVersion int `json:"currentVersion"`
+	// TargetVersion is the latest schema version the server ships
+	TargetVersion int `json:"targetVersion"`
+	// Migrations are the migrations applied, or pending on a dry run, in version order
+	Migrations []MigrationInfo `json:"migrations"`
+}
+
+// MigrationInfo identifies a schema migration
+type MigrationInfo struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+}
@@ -0,0 +1,349 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/admin/model"
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/clock"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/extension"
+	"github.com/wso2/consent-management-api/internal/system/featureflag"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// maxLogModuleLength is the longest module name a log level can be set for
+const maxLogModuleLength = 64
+
+// AdminService defines the exported service interface for administrative operations
+type AdminService interface {
+	ListCacheStats(ctx context.Context, hotKeyCount int) []cache.Stats
+	GetCacheStats(ctx context.Context, cacheName string, hotKeyCount int) (*cache.Stats, *serviceerror.ServiceError)
+	InvalidateCaches(ctx context.Context, cacheName string, filter cache.InvalidationFilter) (*model.CacheInvalidationResponse, *serviceerror.ServiceError)
+	GetClock(ctx context.Context) *model.ClockResponse
+	UpdateClock(ctx context.Context, req model.ClockUpdateRequest) (*model.ClockResponse, *serviceerror.ServiceError)
+	ResetClock(ctx context.Context) (*model.ClockResponse, *serviceerror.ServiceError)
+	ListFeatureFlags(ctx context.Context, orgID string) *model.FeatureFlagListResponse
+	SetFeatureFlag(ctx context.Context, orgID, flagName string, req model.FeatureFlagUpdateRequest) (*model.FeatureFlagResponse, *serviceerror.ServiceError)
+	ClearFeatureFlag(ctx context.Context, orgID, flagName string) (*model.FeatureFlagResponse, *serviceerror.ServiceError)
+	ListExtensionStats(ctx context.Context) []extension.HookStats
+	GetDatabasePoolStats(ctx context.Context) *model.DatabasePoolResponse
+	GetLogLevels(ctx context.Context) *model.LogLevelResponse
+	SetLogLevel(ctx context.Context, req model.LogLevelUpdateRequest) (*model.LogLevelResponse, *serviceerror.ServiceError)
+	SetModuleLogLevel(ctx context.Context, module string, req model.LogLevelUpdateRequest) (*model.LogLevelResponse, *serviceerror.ServiceError)
+	ClearModuleLogLevel(ctx context.Context, module string) *model.LogLevelResponse
+	Migrate(ctx context.Context, dryRun bool) (*model.MigrationResponse, *serviceerror.ServiceError)
+}
+
+// adminService implements the AdminService interface
+type adminService struct {
+	stores *stores.StoreRegistry
+	caches *cache.Manager
+}
+
+// newAdminService creates a new admin service
+func newAdminService(registry *stores.StoreRegistry) AdminService {
+	return &adminService{
+		stores: registry,
+		caches: cache.GetManager(),
+	}
+}
+
+// ListCacheStats returns statistics for all registered caches
+func (s *adminService) ListCacheStats(ctx context.Context, hotKeyCount int) []cache.Stats {
+	caches := s.caches.List()
+	stats := make([]cache.Stats, 0, len(caches))
+	for _, c := range caches {
+		stats = append(stats, c.Stats(hotKeyCount))
+	}
+	return stats
+}
+
+// GetCacheStats returns statistics for a single cache
+func (s *adminService) GetCacheStats(ctx context.Context, cacheName string, hotKeyCount int) (*cache.Stats, *serviceerror.ServiceError) {
+	c, ok := s.caches.Get(cacheName)
+	if !ok {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("cache '%s' not found", cacheName))
+	}
+	stats := c.Stats(hotKeyCount)
+	return &stats, nil
+}
+
+// InvalidateCaches removes entries matching the filter from the named cache, or from all
+// caches when cacheName is empty. An empty filter clears the selected caches entirely.
+func (s *adminService) InvalidateCaches(ctx context.Context, cacheName string, filter cache.InvalidationFilter) (*model.CacheInvalidationResponse, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	var targets []cache.ManagedCache
+	if cacheName != "" {
+		c, ok := s.caches.Get(cacheName)
+		if !ok {
+			return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("cache '%s' not found", cacheName))
+		}
+		targets = []cache.ManagedCache{c}
+	} else {
+		targets = s.caches.List()
+	}
+
+	response := &model.CacheInvalidationResponse{
+		Caches: make([]model.CacheInvalidationResult, 0, len(targets)),
+	}
+	for _, c := range targets {
+		removed := c.Invalidate(filter)
+		response.Caches = append(response.Caches, model.CacheInvalidationResult{
+			Name:    c.Name(),
+			Removed: removed,
+		})
+		response.TotalRemoved += removed
+	}
+
+	logger.Info("Cache invalidation completed",
+		log.String("cache_name", cacheName),
+		log.String("org_id", filter.OrgID),
+		log.String("consent_id", filter.ConsentID),
+		log.String("purpose_name", filter.PurposeName),
+		log.Int("removed", response.TotalRemoved))
+
+	return response, nil
+}
+
+// GetClock returns the current state of the server clock
+func (s *adminService) GetClock(ctx context.Context) *model.ClockResponse {
+	return toClockResponse(clock.GetState())
+}
+
+// UpdateClock freezes, shifts or advances the server clock
+func (s *adminService) UpdateClock(ctx context.Context, req model.ClockUpdateRequest) (*model.ClockResponse, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	provided := 0
+	for _, v := range []*int64{req.Time, req.OffsetMillis, req.AdvanceMillis} {
+		if v != nil {
+			provided++
+		}
+	}
+	if provided != 1 {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+			"exactly one of time, offsetMillis or advanceMillis must be provided")
+	}
+
+	var err error
+	switch {
+	case req.Time != nil:
+		err = clock.Freeze(time.UnixMilli(*req.Time))
+	case req.OffsetMillis != nil:
+		err = clock.SetOffset(time.Duration(*req.OffsetMillis) * time.Millisecond)
+	case req.AdvanceMillis != nil:
+		if *req.AdvanceMillis < 0 {
+			return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "advanceMillis must be non-negative")
+		}
+		err = clock.Advance(time.Duration(*req.AdvanceMillis) * time.Millisecond)
+	}
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
+
+	state := clock.GetState()
+	logger.Info("Server clock updated",
+		log.Any("now", state.Now.UnixMilli()),
+		log.Bool("frozen", state.Frozen))
+
+	return toClockResponse(state), nil
+}
+
+// ResetClock returns the server clock to the real system time
+func (s *adminService) ResetClock(ctx context.Context) (*model.ClockResponse, *serviceerror.ServiceError) {
+	if err := clock.Reset(); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
+	log.GetLogger().WithContext(ctx).Info("Server clock reset to system time")
+	return toClockResponse(clock.GetState()), nil
+}
+
+// ListFeatureFlags returns the effective state of every feature flag for an organization
+func (s *adminService) ListFeatureFlags(ctx context.Context, orgID string) *model.FeatureFlagListResponse {
+	states := featureflag.List(orgID)
+	response := &model.FeatureFlagListResponse{
+		OrgID: orgID,
+		Data:  make([]model.FeatureFlagResponse, 0, len(states)),
+	}
+	for _, state := range states {
+		response.Data = append(response.Data, toFeatureFlagResponse(state))
+	}
+	return response
+}
+
+// SetFeatureFlag overrides a feature flag for an organization. Overrides are held in memory and
+// are lost when the server restarts.
+func (s *adminService) SetFeatureFlag(ctx context.Context, orgID, flagName string, req model.FeatureFlagUpdateRequest) (*model.FeatureFlagResponse, *serviceerror.ServiceError) {
+	if orgID == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "orgId is required")
+	}
+	if req.Enabled == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "enabled is required")
+	}
+
+	flag := featureflag.Flag(flagName)
+	if err := featureflag.SetOverride(orgID, flag, *req.Enabled); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("feature flag '%s' not found", flagName))
+	}
+
+	log.GetLogger().WithContext(ctx).Info("Feature flag overridden",
+		log.String("org_id", orgID),
+		log.String("flag", flagName),
+		log.Bool("enabled", *req.Enabled))
+
+	response := toFeatureFlagResponse(featureflag.Get(orgID, flag))
+	return &response, nil
+}
+
+// ClearFeatureFlag removes the runtime override of a feature flag for an organization and returns
+// the state the flag falls back to
+func (s *adminService) ClearFeatureFlag(ctx context.Context, orgID, flagName string) (*model.FeatureFlagResponse, *serviceerror.ServiceError) {
+	if orgID == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "orgId is required")
+	}
+
+	flag := featureflag.Flag(flagName)
+	cleared, err := featureflag.ClearOverride(orgID, flag)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("feature flag '%s' not found", flagName))
+	}
+	if cleared {
+		log.GetLogger().WithContext(ctx).Info("Feature flag override cleared",
+			log.String("org_id", orgID),
+			log.String("flag", flagName))
+	}
+
+	response := toFeatureFlagResponse(featureflag.Get(orgID, flag))
+	return &response, nil
+}
+
+// ListExtensionStats returns the invocation metrics of the enabled service extension hooks
+func (s *adminService) ListExtensionStats(ctx context.Context) []extension.HookStats {
+	return extension.Stats()
+}
+
+// GetDatabasePoolStats returns the settings and gauges of the database connection pool
+func (s *adminService) GetDatabasePoolStats(ctx context.Context) *model.DatabasePoolResponse {
+	return &model.DatabasePoolResponse{Consent: provider.GetDBProvider().GetConsentPoolStats()}
+}
+
+// GetLogLevels returns the level of the server log and of the modules that have their own level
+func (s *adminService) GetLogLevels(ctx context.Context) *model.LogLevelResponse {
+	return &model.LogLevelResponse{
+		Level:   log.GetLogLevel(),
+		Modules: log.GetComponentLogLevels(),
+	}
+}
+
+// SetLogLevel changes the level of the server log. The level is held in memory and returns to the
+// configured level when the server restarts.
+func (s *adminService) SetLogLevel(ctx context.Context, req model.LogLevelUpdateRequest) (*model.LogLevelResponse, *serviceerror.ServiceError) {
+	if serviceErr := validateLogLevel(req.Level); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	previous := log.GetLogLevel()
+	if err := log.SetLogLevel(req.Level); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
+	log.GetLogger().WithContext(ctx).Warn("Log level changed",
+		log.String("previous_level", previous),
+		log.String("level", log.GetLogLevel()))
+
+	return s.GetLogLevels(ctx), nil
+}
+
+// SetModuleLogLevel sets the level of a module, overriding the server level for its log entries
+func (s *adminService) SetModuleLogLevel(ctx context.Context, module string, req model.LogLevelUpdateRequest) (*model.LogLevelResponse, *serviceerror.ServiceError) {
+	if module == "" || len(module) > maxLogModuleLength {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+			fmt.Sprintf("module must be 1 to %d characters", maxLogModuleLength))
+	}
+	if serviceErr := validateLogLevel(req.Level); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if err := log.SetComponentLogLevel(module, req.Level); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
+	log.GetLogger().WithContext(ctx).Warn("Module log level changed",
+		log.String("module", module),
+		log.String("level", strings.ToLower(req.Level)))
+
+	return s.GetLogLevels(ctx), nil
+}
+
+// ClearModuleLogLevel returns a module to the server level
+func (s *adminService) ClearModuleLogLevel(ctx context.Context, module string) *model.LogLevelResponse {
+	if log.ClearComponentLogLevel(module) {
+		log.GetLogger().WithContext(ctx).Warn("Module log level cleared", log.String("module", module))
+	}
+	return s.GetLogLevels(ctx)
+}
+
+// Migrate applies the pending schema migrations, or lists them on a dry run
+func (s *adminService) Migrate(ctx context.Context, dryRun bool) (*model.MigrationResponse, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	migrator, err := provider.GetDBProvider().GetConsentMigrator()
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	result, err := migrator.Migrate(ctx, dryRun)
+	if err != nil {
+		logger.Error("Schema migration failed", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	response := &model.MigrationResponse{
+		DryRun:         result.DryRun,
+		CurrentVersion: result.CurrentVersion,
+		TargetVersion:  result.TargetVersion,
+		Migrations:     make([]model.MigrationInfo, 0, len(result.Migrations)),
+	}
+	for _, migration := range result.Migrations {
+		response.Migrations = append(response.Migrations, model.MigrationInfo{Version: migration.Version, Name: migration.Name})
+	}
+	if !dryRun {
+		logger.Info("Schema migrations run",
+			log.Int("current_version", result.CurrentVersion),
+			log.Int("applied_migrations", len(result.Migrations)))
+	}
+	return response, nil
+}
+
+// validateLogLevel checks that a log level is one of the levels the API accepts
+func validateLogLevel(level string) *serviceerror.ServiceError {
+	switch strings.ToLower(level) {
+	case "debug", "info", "warn", "error":
+		return nil
+	case "":
+		return serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "level is required")
+	default:
+		return serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+			fmt.Sprintf("invalid level '%s': must be one of debug, info, warn or error", level))
+	}
+}
+
+func toFeatureFlagResponse(state featureflag.State) model.FeatureFlagResponse {
+	return model.FeatureFlagResponse{
+		Name:    string(state.Flag),
+		Enabled: state.Enabled,
+		Source:  string(state.Source),
+	}
+}
+
+func toClockResponse(state clock.State) *model.ClockResponse {
+	return &model.ClockResponse{
+		Now:          state.Now.UnixMilli(),
+		Frozen:       state.Frozen,
+		OffsetMillis: state.Offset.Milliseconds(),
+	}
+}
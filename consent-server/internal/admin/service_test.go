@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+)
+
+// TestInvalidateCaches checks that a named cache or every cache is invalidated with the filter,
+// and that unknown caches are not found
+func TestInvalidateCaches(t *testing.T) {
+	consents := cache.NewMemoryCache("admin-test-consents", 0, 0)
+	purposes := cache.NewMemoryCache("admin-test-purposes", 0, 0)
+	cache.GetManager().Register(consents)
+	cache.GetManager().Register(purposes)
+	consents.Set("org-1", "c1", 1, cache.ConsentTag("c1"))
+	consents.Set("org-1", "c2", 2, cache.ConsentTag("c2"))
+	purposes.Set("org-1", "p1", 3, cache.PurposeTag("marketing"))
+	purposes.Set("org-2", "p1", 4, cache.PurposeTag("marketing"))
+
+	service := &adminService{caches: cache.GetManager()}
+	ctx := context.Background()
+
+	response, serviceErr := service.InvalidateCaches(ctx, "admin-test-consents", cache.InvalidationFilter{ConsentID: "c1"})
+	if serviceErr != nil {
+		t.Fatalf("failed to invalidate the consent cache: %v", serviceErr)
+	}
+	if response.TotalRemoved != 1 || len(response.Caches) != 1 || response.Caches[0].Name != "admin-test-consents" {
+		t.Errorf("expected one entry removed from the consent cache, got %+v", response)
+	}
+
+	response, serviceErr = service.InvalidateCaches(ctx, "", cache.InvalidationFilter{OrgID: "org-1"})
+	if serviceErr != nil {
+		t.Fatalf("failed to invalidate every cache: %v", serviceErr)
+	}
+	if response.TotalRemoved != 2 {
+		t.Errorf("expected the remaining entries of org-1 to be removed, got %+v", response)
+	}
+	if stats, _ := service.GetCacheStats(ctx, "admin-test-purposes", cache.DefaultHotKeyCount); stats.Size != 1 {
+		t.Errorf("expected the entry of org-2 to be kept, got %+v", stats)
+	}
+
+	if _, serviceErr := service.InvalidateCaches(ctx, "unknown", cache.InvalidationFilter{}); serviceErr == nil ||
+		serviceErr.Code != serviceerror.ResourceNotFoundError.Code {
+		t.Errorf("expected an unknown cache to be not found, got %v", serviceErr)
+	}
+}
@@ -0,0 +1,79 @@
+package attributeschema
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/attributeschema/model"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// attributeSchemaHandler handles HTTP requests for consent attribute schemas
+type attributeSchemaHandler struct {
+	service AttributeSchemaService
+}
+
+// newAttributeSchemaHandler creates a new attribute schema handler
+func newAttributeSchemaHandler(service AttributeSchemaService) *attributeSchemaHandler {
+	return &attributeSchemaHandler{
+		service: service,
+	}
+}
+
+// getSchema handles GET /admin/attribute-schema
+func (h *attributeSchemaHandler) getSchema(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	schema, serviceErr := h.service.GetSchema(r.Context(), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, schema)
+}
+
+// putSchema handles PUT /admin/attribute-schema
+func (h *attributeSchemaHandler) putSchema(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.AttributeSchemaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid request body"))
+		return
+	}
+
+	schema, serviceErr := h.service.PutSchema(r.Context(), req, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, schema)
+}
+
+// deleteSchema handles DELETE /admin/attribute-schema
+func (h *attributeSchemaHandler) deleteSchema(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.DeleteSchema(r.Context(), orgID); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
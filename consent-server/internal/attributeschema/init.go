@@ -0,0 +1,40 @@
+package attributeschema
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize sets up the attribute schema module and registers routes
+func Initialize(mux *http.ServeMux, registry *stores.StoreRegistry) AttributeSchemaService {
+	service := newAttributeSchemaService(registry)
+	handler := newAttributeSchemaHandler(service)
+
+	registerRoutes(mux, handler)
+
+	return service
+}
+
+// registerRoutes registers the attribute schema admin routes. Admin routes are protected with admin basic auth.
+func registerRoutes(mux *http.ServeMux, handler *attributeSchemaHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "org-id", "X-Correlation-ID"},
+	}
+
+	// GET /api/v1/admin/attribute-schema - Get the organization's attribute schema
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/attribute-schema",
+		middleware.WithAdminAuth(handler.getSchema), corsOpts))
+
+	// PUT /api/v1/admin/attribute-schema - Create or replace the organization's attribute schema
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/admin/attribute-schema",
+		middleware.WithAdminAuth(handler.putSchema), corsOpts))
+
+	// DELETE /api/v1/admin/attribute-schema - Remove the organization's attribute schema
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/admin/attribute-schema",
+		middleware.WithAdminAuth(handler.deleteSchema), corsOpts))
+}
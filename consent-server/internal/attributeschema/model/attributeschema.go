@@ -0,0 +1,144 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Attribute value types
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeInteger = "integer"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+)
+
+// AttributeSchema represents the ATTRIBUTE_SCHEMA table. An organization has at most one schema,
+// which lists every consent attribute key the organization accepts.
+type AttributeSchema struct {
+	OrgID       string                `json:"orgId"`
+	Attributes  []AttributeDefinition `json:"attributes"`
+	CreatedTime int64                 `json:"createdTime"`
+	UpdatedTime int64                 `json:"updatedTime"`
+}
+
+// AttributeDefinition describes an allowed consent attribute key
+type AttributeDefinition struct {
+	Key                  string   `json:"key"`
+	Type                 string   `json:"type,omitempty"`
+	Pattern              string   `json:"pattern,omitempty"`
+	Required             bool     `json:"required,omitempty"`
+	RequiredConsentTypes []string `json:"requiredConsentTypes,omitempty"`
+}
+
+// AttributeSchemaRequest represents the request body for replacing an organization's attribute schema
+type AttributeSchemaRequest struct {
+	Attributes []AttributeDefinition `json:"attributes"`
+}
+
+// GetType returns the value type of the attribute, string when not set
+func (d AttributeDefinition) GetType() string {
+	if d.Type == "" {
+		return AttributeTypeString
+	}
+	return d.Type
+}
+
+// IsRequired reports whether the attribute must be present on consents of the given type
+func (d AttributeDefinition) IsRequired(consentType string) bool {
+	if d.Required {
+		return true
+	}
+	for _, t := range d.RequiredConsentTypes {
+		if t == consentType {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the schema definition itself is well formed
+func (r AttributeSchemaRequest) Validate() error {
+	seen := make(map[string]bool, len(r.Attributes))
+	for _, def := range r.Attributes {
+		if def.Key == "" {
+			return fmt.Errorf("attribute key is required")
+		}
+		if seen[def.Key] {
+			return fmt.Errorf("attribute '%s' is defined more than once", def.Key)
+		}
+		seen[def.Key] = true
+
+		switch def.GetType() {
+		case AttributeTypeString, AttributeTypeInteger, AttributeTypeNumber, AttributeTypeBoolean:
+		default:
+			return fmt.Errorf("attribute '%s' has unsupported type '%s'", def.Key, def.Type)
+		}
+		if def.Pattern != "" {
+			if _, err := regexp.Compile(def.Pattern); err != nil {
+				return fmt.Errorf("attribute '%s' has an invalid pattern: %v", def.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAttributes checks consent attributes against the schema: every key must be defined,
+// values must match the type and pattern of their definition, and the keys required for the
+// consent type must be present.
+func (s *AttributeSchema) ValidateAttributes(consentType string, attributes map[string]string) error {
+	definitions := make(map[string]AttributeDefinition, len(s.Attributes))
+	for _, def := range s.Attributes {
+		definitions[def.Key] = def
+	}
+
+	// Check keys in a stable order so the reported error does not change between requests
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		def, ok := definitions[key]
+		if !ok {
+			return fmt.Errorf("attribute '%s' is not defined in the attribute schema", key)
+		}
+		if err := validateValue(def, attributes[key]); err != nil {
+			return err
+		}
+	}
+
+	for _, def := range s.Attributes {
+		if _, ok := attributes[def.Key]; !ok && def.IsRequired(consentType) {
+			return fmt.Errorf("attribute '%s' is required for consent type '%s'", def.Key, consentType)
+		}
+	}
+	return nil
+}
+
+// validateValue checks a single attribute value against its definition
+func validateValue(def AttributeDefinition, value string) error {
+	var err error
+	switch def.GetType() {
+	case AttributeTypeInteger:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case AttributeTypeNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case AttributeTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	}
+	if err != nil {
+		return fmt.Errorf("attribute '%s' must be of type %s", def.Key, def.GetType())
+	}
+
+	if def.Pattern != "" {
+		matched, err := regexp.MatchString(def.Pattern, value)
+		if err != nil || !matched {
+			return fmt.Errorf("attribute '%s' does not match pattern '%s'", def.Key, def.Pattern)
+		}
+	}
+	return nil
+}
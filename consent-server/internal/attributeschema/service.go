@@ -0,0 +1,110 @@
+package attributeschema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wso2/consent-management-api/internal/attributeschema/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// AttributeSchemaService defines the exported service interface for consent attribute schemas
+type AttributeSchemaService interface {
+	GetSchema(ctx context.Context, orgID string) (*model.AttributeSchema, *serviceerror.ServiceError)
+	PutSchema(ctx context.Context, req model.AttributeSchemaRequest, orgID string) (*model.AttributeSchema, *serviceerror.ServiceError)
+	DeleteSchema(ctx context.Context, orgID string) *serviceerror.ServiceError
+}
+
+// attributeSchemaService implements the AttributeSchemaService interface
+type attributeSchemaService struct {
+	stores *stores.StoreRegistry
+}
+
+// newAttributeSchemaService creates a new attribute schema service
+func newAttributeSchemaService(registry *stores.StoreRegistry) AttributeSchemaService {
+	return &attributeSchemaService{
+		stores: registry,
+	}
+}
+
+// GetSchema retrieves the attribute schema of an organization
+func (s *attributeSchemaService) GetSchema(ctx context.Context, orgID string) (*model.AttributeSchema, *serviceerror.ServiceError) {
+	schema, err := s.stores.AttributeSchema.Get(ctx, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve attribute schema: %v", err))
+	}
+	if schema == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("no attribute schema defined for organization '%s'", orgID))
+	}
+	return schema, nil
+}
+
+// PutSchema creates or replaces the attribute schema of an organization. The schema applies to
+// consents created or updated afterwards; existing consent attributes are not re-validated.
+func (s *attributeSchemaService) PutSchema(ctx context.Context, req model.AttributeSchemaRequest, orgID string) (*model.AttributeSchema, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := req.Validate(); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
+
+	existing, err := s.stores.AttributeSchema.Get(ctx, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve attribute schema", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve attribute schema: %v", err))
+	}
+
+	now := utils.GetCurrentTimeMillis()
+	schema := &model.AttributeSchema{
+		OrgID:       orgID,
+		Attributes:  req.Attributes,
+		CreatedTime: now,
+		UpdatedTime: now,
+	}
+	if schema.Attributes == nil {
+		schema.Attributes = []model.AttributeDefinition{}
+	}
+
+	query := func(tx dbmodel.TxInterface) error {
+		return s.stores.AttributeSchema.Create(tx, schema)
+	}
+	if existing != nil {
+		schema.CreatedTime = existing.CreatedTime
+		query = func(tx dbmodel.TxInterface) error {
+			return s.stores.AttributeSchema.Update(tx, schema)
+		}
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{query}); err != nil {
+		logger.Error("Failed to store attribute schema", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to store attribute schema: %v", err))
+	}
+
+	logger.Info("Attribute schema stored",
+		log.String("org_id", orgID),
+		log.Int("attribute_count", len(schema.Attributes)))
+	return schema, nil
+}
+
+// DeleteSchema removes the attribute schema of an organization, after which consents accept any attributes again
+func (s *attributeSchemaService) DeleteSchema(ctx context.Context, orgID string) *serviceerror.ServiceError {
+	if _, serviceErr := s.GetSchema(ctx, orgID); serviceErr != nil {
+		return serviceErr
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.AttributeSchema.Delete(tx, orgID)
+		},
+	}); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to delete attribute schema: %v", err))
+	}
+
+	log.GetLogger().WithContext(ctx).Info("Attribute schema deleted", log.String("org_id", orgID))
+	return nil
+}
@@ -0,0 +1,116 @@
+package attributeschema
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/wso2/consent-management-api/internal/attributeschema/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// DBQuery objects for attribute schema operations
+var (
+	QueryCreateAttributeSchema = dbmodel.DBQuery{
+		ID:    "CREATE_ATTRIBUTE_SCHEMA",
+		Query: "INSERT INTO ATTRIBUTE_SCHEMA (ORG_ID, ATTRIBUTES, CREATED_TIME, UPDATED_TIME) VALUES (?, ?, ?, ?)",
+	}
+
+	QueryGetAttributeSchema = dbmodel.DBQuery{
+		ID:    "GET_ATTRIBUTE_SCHEMA",
+		Query: "SELECT ORG_ID, ATTRIBUTES, CREATED_TIME, UPDATED_TIME FROM ATTRIBUTE_SCHEMA WHERE ORG_ID = ?",
+	}
+
+	QueryUpdateAttributeSchema = dbmodel.DBQuery{
+		ID:    "UPDATE_ATTRIBUTE_SCHEMA",
+		Query: "UPDATE ATTRIBUTE_SCHEMA SET ATTRIBUTES = ?, UPDATED_TIME = ? WHERE ORG_ID = ?",
+	}
+
+	QueryDeleteAttributeSchema = dbmodel.DBQuery{
+		ID:    "DELETE_ATTRIBUTE_SCHEMA",
+		Query: "DELETE FROM ATTRIBUTE_SCHEMA WHERE ORG_ID = ?",
+	}
+)
+
+// store implements the interfaces.AttributeSchemaStore interface
+type store struct {
+	dbClient provider.DBClientInterface
+}
+
+// NewAttributeSchemaStore creates a new attribute schema store
+func NewAttributeSchemaStore(dbClient provider.DBClientInterface) interfaces.AttributeSchemaStore {
+	return &store{
+		dbClient: dbClient,
+	}
+}
+
+// Get retrieves the attribute schema of an organization, nil when none is defined
+func (s *store) Get(ctx context.Context, orgID string) (*model.AttributeSchema, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetAttributeSchema, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return mapToAttributeSchema(rows[0])
+}
+
+// Create stores the attribute schema of an organization within a transaction
+func (s *store) Create(tx dbmodel.TxInterface, schema *model.AttributeSchema) error {
+	attributes, err := json.Marshal(schema.Attributes)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(QueryCreateAttributeSchema.Query, schema.OrgID, string(attributes), schema.CreatedTime, schema.UpdatedTime)
+	return err
+}
+
+// Update replaces the attribute definitions of an organization's schema within a transaction
+func (s *store) Update(tx dbmodel.TxInterface, schema *model.AttributeSchema) error {
+	attributes, err := json.Marshal(schema.Attributes)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(QueryUpdateAttributeSchema.Query, string(attributes), schema.UpdatedTime, schema.OrgID)
+	return err
+}
+
+// Delete removes the attribute schema of an organization within a transaction
+func (s *store) Delete(tx dbmodel.TxInterface, orgID string) error {
+	_, err := tx.Exec(QueryDeleteAttributeSchema.Query, orgID)
+	return err
+}
+
+// mapToAttributeSchema converts a database row map to AttributeSchema
+// Note: DBClient normalizes column names to lowercase
+func mapToAttributeSchema(row map[string]interface{}) (*model.AttributeSchema, error) {
+	schema := &model.AttributeSchema{
+		OrgID:       getString(row, "org_id"),
+		CreatedTime: getInt64(row, "created_time"),
+		UpdatedTime: getInt64(row, "updated_time"),
+	}
+	if attributes := getString(row, "attributes"); attributes != "" {
+		if err := json.Unmarshal([]byte(attributes), &schema.Attributes); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+func getString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+func getInt64(row map[string]interface{}, key string) int64 {
+	if v, ok := row[key].(int64); ok {
+		return v
+	}
+	return 0
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/wso2/consent-management-api/internal/authresource/model"
 	"github.com/wso2/consent-management-api/internal/system/constants"
@@ -145,6 +147,67 @@ func (h *authResourceHandler) handleListByConsent(w http.ResponseWriter, r *http
 	json.NewEncoder(w).Encode(response)
 }
 
+// handleSearch handles GET /authorizations. Every filter is optional; orgId narrows the search to
+// one organization.
+func (h *authResourceHandler) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filters := model.AuthResourceSearchFilters{
+		OrgID:       strings.TrimSpace(query.Get("orgId")),
+		UserID:      strings.TrimSpace(query.Get("userId")),
+		DelegatorID: strings.TrimSpace(query.Get("delegatorId")),
+		DelegateID:  strings.TrimSpace(query.Get("delegateId")),
+		AuthType:    strings.TrimSpace(query.Get("type")),
+	}
+
+	// Parse status (comma-separated)
+	if statusesStr := query.Get("status"); statusesStr != "" {
+		for _, status := range strings.Split(statusesStr, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filters.Statuses = append(filters.Statuses, status)
+			}
+		}
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			filters.Limit = l
+		}
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			filters.Offset = o
+		}
+	}
+
+	response, serviceErr := h.service.SearchAuthResources(r.Context(), filters)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
+
+// handleHistory handles GET /consents/{consentId}/authorizations/{authorizationId}/history
+func (h *authResourceHandler) handleHistory(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(
+			serviceerror.InvalidRequestError,
+			"organization ID header is required",
+		))
+		return
+	}
+
+	response, serviceErr := h.service.GetAuthResourceHistory(r.Context(), r.PathValue("consentId"), r.PathValue("authorizationId"), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
+
 // handleUpdate handles PUT /consents/{consentId}/authorizations/{authorizationId}
 func (h *authResourceHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -192,3 +255,51 @@ func (h *authResourceHandler) handleUpdate(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+
+// handlePatch handles PATCH /consents/{consentId}/authorizations/{authorizationId}
+func (h *authResourceHandler) handlePatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract path parameters
+	consentID := r.PathValue("consentId")
+	authID := r.PathValue("authorizationId")
+	if consentID == "" || authID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(
+			serviceerror.InvalidRequestError,
+			"consent ID and auth ID are required",
+		))
+		return
+	}
+
+	// Extract organization ID from header
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(
+			serviceerror.InvalidRequestError,
+			"organization ID header is required",
+		))
+		return
+	}
+
+	// Parse request body
+	var request model.PatchRequest
+	if err := utils.DecodeJSONBody(r, &request); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(
+			serviceerror.InvalidRequestError,
+			fmt.Sprintf("invalid request body: %v", err),
+		))
+		return
+	}
+
+	// Call service
+	response, serviceErr := h.service.PatchAuthResource(ctx, consentID, authID, orgID, &request)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	// Send response
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -8,20 +8,21 @@ import (
 	"github.com/wso2/consent-management-api/internal/system/stores"
 )
 
-// Initialize sets up the auth resource module and registers routes
-func Initialize(mux *http.ServeMux, registry *stores.StoreRegistry) AuthResourceServiceInterface {
+// Initialize sets up the auth resource module and registers routes. The search across consents is
+// registered on adminMux.
+func Initialize(mux, adminMux *http.ServeMux, registry *stores.StoreRegistry) AuthResourceServiceInterface {
 	// Create service and handler using the registry
 	service := newAuthResourceService(registry)
 	handler := newAuthResourceHandler(service)
 
 	// Register routes
-	registerRoutes(mux, handler)
+	registerRoutes(mux, adminMux, handler)
 
 	return service
 }
 
 // registerRoutes registers all auth resource HTTP routes with CORS support
-func registerRoutes(mux *http.ServeMux, handler *authResourceHandler) {
+func registerRoutes(mux, adminMux *http.ServeMux, handler *authResourceHandler) {
 	// CORS configuration
 	corsOpts := middleware.CORSOptions{
 		AllowOrigin:      "*",
@@ -33,28 +34,55 @@ func registerRoutes(mux *http.ServeMux, handler *authResourceHandler) {
 	// Create authorization (POST /api/v1/consents/{consentId}/authorizations)
 	mux.HandleFunc(middleware.WithCORS(
 		"POST "+constants.APIBasePath+"/consents/{consentId}/authorizations",
-		handler.handleCreate,
+		middleware.WithScope(middleware.ScopeConsentsWrite, handler.handleCreate),
 		corsOpts,
 	))
 
 	// List authorizations by consent (GET /api/v1/consents/{consentId}/authorizations)
 	mux.HandleFunc(middleware.WithCORS(
 		"GET "+constants.APIBasePath+"/consents/{consentId}/authorizations",
-		handler.handleListByConsent,
+		middleware.WithScope(middleware.ScopeConsentsRead, handler.handleListByConsent),
 		corsOpts,
 	))
 
 	// Get single authorization (GET /api/v1/consents/{consentId}/authorizations/{authorizationId})
 	mux.HandleFunc(middleware.WithCORS(
 		"GET "+constants.APIBasePath+"/consents/{consentId}/authorizations/{authorizationId}",
-		handler.handleGet,
+		middleware.WithScope(middleware.ScopeConsentsRead, handler.handleGet),
+		corsOpts,
+	))
+
+	// Get authorization status history (GET /api/v1/consents/{consentId}/authorizations/{authorizationId}/history)
+	mux.HandleFunc(middleware.WithCORS(
+		"GET "+constants.APIBasePath+"/consents/{consentId}/authorizations/{authorizationId}/history",
+		middleware.WithScope(middleware.ScopeConsentsRead, handler.handleHistory),
 		corsOpts,
 	))
 
 	// Update authorization (PUT /api/v1/consents/{consentId}/authorizations/{authorizationId})
 	mux.HandleFunc(middleware.WithCORS(
 		"PUT "+constants.APIBasePath+"/consents/{consentId}/authorizations/{authorizationId}",
-		handler.handleUpdate,
+		middleware.WithScope(middleware.ScopeConsentsWrite, handler.handleUpdate),
+		corsOpts,
+	))
+
+	// Partially update authorization (PATCH /api/v1/consents/{consentId}/authorizations/{authorizationId})
+	mux.HandleFunc(middleware.WithCORS(
+		"PATCH "+constants.APIBasePath+"/consents/{consentId}/authorizations/{authorizationId}",
+		middleware.WithScope(middleware.ScopeConsentsWrite, handler.handlePatch),
 		corsOpts,
 	))
+
+	adminCorsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "X-Correlation-ID"},
+	}
+
+	// Search authorizations across consents (GET /api/v1/authorizations)
+	adminMux.HandleFunc(middleware.WithCORS(
+		"GET "+constants.APIBasePath+"/authorizations",
+		middleware.WithAdminAuth(handler.handleSearch),
+		adminCorsOpts,
+	))
 }
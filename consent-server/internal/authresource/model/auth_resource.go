@@ -11,21 +11,40 @@ type ConsentAuthResource struct {
 	Resources   *string     `db:"RESOURCES" json:"-"`
 	ResourceObj interface{} `db:"-" json:"resources,omitempty"`
 	OrgID       string      `db:"ORG_ID" json:"orgId"`
+	// DelegatorID and DelegateID are set on an authorization given on behalf of another person, as
+	// under a power of attorney: the delegator is the person the consent is given for and the
+	// delegate the person who gave it
+	DelegatorID *string `db:"DELEGATOR_ID" json:"delegatorId,omitempty"`
+	DelegateID  *string `db:"DELEGATE_ID" json:"delegateId,omitempty"`
 }
 
 // ConsentAuthResourceCreateRequest represents the request payload for creating an authorization resource
 type ConsentAuthResourceCreateRequest struct {
-	AuthType   string      `json:"type" binding:"required"`
-	UserID     *string     `json:"userId,omitempty"`
-	AuthStatus string      `json:"status" binding:"required"`
-	Resources  interface{} `json:"resources,omitempty"`
+	AuthType    string      `json:"type" binding:"required"`
+	UserID      *string     `json:"userId,omitempty"`
+	AuthStatus  string      `json:"status" binding:"required"`
+	Resources   interface{} `json:"resources,omitempty"`
+	DelegatorID *string     `json:"delegatorId,omitempty"`
+	DelegateID  *string     `json:"delegateId,omitempty"`
 }
 
 // ConsentAuthResourceUpdateRequest represents the request payload for updating an authorization resource
 type ConsentAuthResourceUpdateRequest struct {
-	AuthStatus string      `json:"status,omitempty"`
-	UserID     *string     `json:"userId,omitempty"`
-	Resources  interface{} `json:"resources,omitempty"`
+	AuthStatus  string      `json:"status,omitempty"`
+	UserID      *string     `json:"userId,omitempty"`
+	Resources   interface{} `json:"resources,omitempty"`
+	DelegatorID *string     `json:"delegatorId,omitempty"`
+	DelegateID  *string     `json:"delegateId,omitempty"`
+}
+
+// ConsentAuthResourcePatchRequest represents the request payload for partially updating an authorization resource.
+// Omitted fields are left unchanged. Resources are appended to the stored resources instead of replacing them.
+type ConsentAuthResourcePatchRequest struct {
+	AuthStatus  string      `json:"status,omitempty"`
+	UserID      *string     `json:"userId,omitempty"`
+	Resources   interface{} `json:"resources,omitempty"`
+	DelegatorID *string     `json:"delegatorId,omitempty"`
+	DelegateID  *string     `json:"delegateId,omitempty"`
 }
 
 // ConsentAuthResourceResponse represents the response for authorization resource operations
@@ -36,6 +55,8 @@ type ConsentAuthResourceResponse struct {
 	AuthStatus  string      `json:"status"`
 	UpdatedTime int64       `json:"updatedTime"`
 	Resources   interface{} `json:"resources,omitempty"`
+	DelegatorID *string     `json:"delegatorId,omitempty"`
+	DelegateID  *string     `json:"delegateId,omitempty"`
 }
 
 // ConsentAuthResourceListResponse represents the response for listing authorization resources
@@ -47,5 +68,58 @@ type ConsentAuthResourceListResponse struct {
 type AuthResource = ConsentAuthResource
 type CreateRequest = ConsentAuthResourceCreateRequest
 type UpdateRequest = ConsentAuthResourceUpdateRequest
+type PatchRequest = ConsentAuthResourcePatchRequest
 type Response = ConsentAuthResourceResponse
 type ListResponse = ConsentAuthResourceListResponse
+
+// AuthStatusAudit represents the AUTH_STATUS_AUDIT table, one entry per status change of an
+// authorization resource. PreviousStatus is nil for the status the resource was created with.
+type AuthStatusAudit struct {
+	StatusAuditID  string  `db:"STATUS_AUDIT_ID" json:"statusAuditId"`
+	AuthID         string  `db:"AUTH_ID" json:"authorizationId"`
+	ConsentID      string  `db:"CONSENT_ID" json:"consentId"`
+	CurrentStatus  string  `db:"CURRENT_STATUS" json:"currentStatus"`
+	PreviousStatus *string `db:"PREVIOUS_STATUS" json:"previousStatus,omitempty"`
+	ActionTime     int64   `db:"ACTION_TIME" json:"actionTime"`
+	OrgID          string  `db:"ORG_ID" json:"orgId"`
+}
+
+// AuthStatusAuditListResponse represents the status history of an authorization resource
+type AuthStatusAuditListResponse struct {
+	Data []AuthStatusAudit `json:"data"`
+}
+
+// AuthResourceSearchFilters represents the filters of an authorization search across consents
+type AuthResourceSearchFilters struct {
+	OrgID       string   // Empty searches every organization
+	UserID      string   // Authorizations bound to a user
+	DelegatorID string   // Authorizations given on behalf of a person
+	DelegateID  string   // Authorizations a person gave on behalf of another
+	Statuses    []string // e.g. ["APPROVED", "CREATED"]
+	AuthType    string   // e.g. "authorisation"
+	Limit       int
+	Offset      int
+}
+
+// AuthResourceSearchItem represents an authorization found by a search, with the consent and
+// organization it belongs to
+type AuthResourceSearchItem struct {
+	ConsentAuthResourceResponse
+	ConsentID string `json:"consentId"`
+	OrgID     string `json:"orgId"`
+}
+
+// AuthResourceSearchResponse represents a page of authorizations found by a search
+type AuthResourceSearchResponse struct {
+	Data     []AuthResourceSearchItem `json:"data"`
+	Metadata PaginationMetadata       `json:"metadata"`
+}
+
+// PaginationMetadata represents pagination metadata
+type PaginationMetadata struct {
+	Total   int  `json:"total"`
+	Limit   int  `json:"limit"`
+	Offset  int  `json:"offset"`
+	Count   int  `json:"count"`
+	HasMore bool `json:"hasMore"`
+}
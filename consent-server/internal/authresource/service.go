@@ -11,25 +11,38 @@ import (
 	"strings"
 
 	"github.com/wso2/consent-management-api/internal/authresource/model"
+	authvalidator "github.com/wso2/consent-management-api/internal/authresource/validator"
 	consentModel "github.com/wso2/consent-management-api/internal/consent/model"
 	"github.com/wso2/consent-management-api/internal/consent/validator"
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/consentlock"
 	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
 	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
 	"github.com/wso2/consent-management-api/internal/system/log"
 	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
 	"github.com/wso2/consent-management-api/internal/system/utils"
 )
 
+// Page sizes of authorization searches
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
 // AuthResourceServiceInterface defines the contract for auth resource business operations
 type AuthResourceServiceInterface interface {
 	CreateAuthResource(ctx context.Context, consentID, orgID string, request *model.CreateRequest) (*model.Response, *serviceerror.ServiceError)
 	GetAuthResource(ctx context.Context, authID, orgID string) (*model.Response, *serviceerror.ServiceError)
 	GetAuthResourcesByConsentID(ctx context.Context, consentID, orgID string) (*model.ListResponse, *serviceerror.ServiceError)
 	GetAuthResourcesByUserID(ctx context.Context, userID, orgID string) (*model.ListResponse, *serviceerror.ServiceError)
+	SearchAuthResources(ctx context.Context, filters model.AuthResourceSearchFilters) (*model.AuthResourceSearchResponse, *serviceerror.ServiceError)
 	UpdateAuthResource(ctx context.Context, authID, orgID string, request *model.UpdateRequest) (*model.Response, *serviceerror.ServiceError)
+	PatchAuthResource(ctx context.Context, consentID, authID, orgID string, request *model.PatchRequest) (*model.Response, *serviceerror.ServiceError)
 	DeleteAuthResource(ctx context.Context, authID, orgID string) *serviceerror.ServiceError
 	DeleteAuthResourcesByConsentID(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError
 	UpdateAllStatusByConsentID(ctx context.Context, consentID, orgID string, status string) *serviceerror.ServiceError
+	GetAuthResourceHistory(ctx context.Context, consentID, authID, orgID string) (*model.AuthStatusAuditListResponse, *serviceerror.ServiceError)
 }
 
 // authResourceService implements the AuthResourceServiceInterface
@@ -50,6 +63,9 @@ func (s *authResourceService) CreateAuthResource(
 	consentID, orgID string,
 	request *model.CreateRequest,
 ) (*model.Response, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "authresource.CreateAuthResource")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 
 	logger.Info("Creating authorization resource",
@@ -63,6 +79,17 @@ func (s *authResourceService) CreateAuthResource(
 		logger.Warn("Auth resource create request validation failed", log.String("error", err.Error()))
 		return nil, err
 	}
+	if err := s.ensureConsentExists(ctx, consentID, orgID); err != nil {
+		return nil, err
+	}
+	if err := s.checkConsentLock(ctx, consentID, orgID); err != nil {
+		return nil, err
+	}
+	if request.Resources != nil {
+		if err := s.enforceResourceSchema(ctx, request.AuthType, orgID, request.Resources); err != nil {
+			return nil, err
+		}
+	}
 
 	// Generate auth ID
 	authID := utils.GenerateUUID()
@@ -93,12 +120,14 @@ func (s *authResourceService) CreateAuthResource(
 		UpdatedTime: utils.GetCurrentTimeMillis(),
 		Resources:   resourcesJSON,
 		OrgID:       orgID,
+		DelegatorID: request.DelegatorID,
+		DelegateID:  request.DelegateID,
 	}
 
 	// Create auth resource and update consent status in a transaction
 	store := s.stores.AuthResource
 
-	err := s.stores.ExecuteTransaction([]func(tx dbmodel.TxInterface) error{
+	err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
 		func(tx dbmodel.TxInterface) error {
 			return store.Create(tx, authResource)
 		},
@@ -110,21 +139,29 @@ func (s *authResourceService) CreateAuthResource(
 			}
 
 			// Extract auth statuses
+			authTypes := make([]string, 0, len(allAuthResources))
 			authStatuses := make([]string, 0, len(allAuthResources))
 			for _, ar := range allAuthResources {
+				authTypes = append(authTypes, ar.AuthType)
 				authStatuses = append(authStatuses, ar.AuthStatus)
 			}
 
-			// Derive consent status based on all authorization statuses
-			// Use validator function to maintain consistency with consent creation logic
-			derivedConsentStatus := validator.EvaluateConsentStatusFromAuthStatuses(authStatuses)
-
 			// Get current consent to check if status changed - now with type safety!
 			currentConsent, err := s.stores.Consent.GetByID(ctx, consentID, orgID)
 			if err != nil {
 				return fmt.Errorf("failed to retrieve consent: %w", err)
 			}
 
+			// Derive consent status based on all authorization statuses
+			// Use validator function to maintain consistency with consent creation logic
+			derivedConsentStatus := validator.EvaluateConsentStatusFromAuthStatuses(orgID, currentConsent.ConsentType, authStatuses)
+			derivedConsentStatus, err = s.applyRequiredAuthorizers(ctx, consentID, orgID, derivedConsentStatus, authTypes, authStatuses)
+			if err != nil {
+				return err
+			}
+			derivedConsentStatus = validator.KeepAwaitingReauthorization(orgID, currentConsent.CurrentStatus, derivedConsentStatus)
+			derivedConsentStatus = validator.KeepSuspended(orgID, currentConsent.CurrentStatus, derivedConsentStatus)
+
 			// Check if status actually changed
 			if currentConsent.CurrentStatus == derivedConsentStatus {
 				// Status hasn't changed, skip update and audit
@@ -153,6 +190,10 @@ func (s *authResourceService) CreateAuthResource(
 			if err := s.stores.Consent.CreateStatusAudit(tx, audit); err != nil {
 				return err
 			}
+			// Tokens of a consent the authorization rejected no longer resolve to it
+			if validator.IsConsentEnded(orgID, derivedConsentStatus) {
+				return s.stores.Consent.DeleteTokensByConsentID(tx, consentID, orgID)
+			}
 			return nil
 		},
 	})
@@ -167,6 +208,7 @@ func (s *authResourceService) CreateAuthResource(
 			fmt.Sprintf("failed to create auth resource: %v", err),
 		)
 	}
+	cache.InvalidateConsentValidation(ctx, orgID, consentID)
 
 	logger.Info("Auth resource created successfully",
 		log.String("auth_id", authResource.AuthID),
@@ -182,6 +224,9 @@ func (s *authResourceService) GetAuthResource(
 	ctx context.Context,
 	authID, orgID string,
 ) (*model.Response, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "authresource.GetAuthResource")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Retrieving auth resource",
 		log.String("auth_id", authID),
@@ -216,6 +261,14 @@ func (s *authResourceService) GetAuthResource(
 		)
 	}
 
+	// Authorizations of a deleted consent are hidden with the consent
+	if err := s.ensureConsentExists(ctx, authResource.ConsentID, orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(
+			serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("auth resource not found: %s", authID),
+		)
+	}
+
 	logger.Debug("Auth resource retrieved successfully",
 		log.String("auth_id", authResource.AuthID),
 		log.String("auth_status", authResource.AuthStatus),
@@ -228,6 +281,9 @@ func (s *authResourceService) GetAuthResourcesByConsentID(
 	ctx context.Context,
 	consentID, orgID string,
 ) (*model.ListResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "authresource.GetAuthResourcesByConsentID")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Retrieving auth resources by consent ID",
 		log.String("consent_id", consentID),
@@ -239,6 +295,9 @@ func (s *authResourceService) GetAuthResourcesByConsentID(
 		logger.Warn("Validation failed for get auth resources by consent", log.String("error", err.Error()))
 		return nil, err
 	}
+	if err := s.ensureConsentExists(ctx, consentID, orgID); err != nil {
+		return nil, err
+	}
 
 	store := s.stores.AuthResource
 	authResources, err := store.GetByConsentID(ctx, consentID, orgID)
@@ -273,6 +332,9 @@ func (s *authResourceService) GetAuthResourcesByUserID(
 	ctx context.Context,
 	userID, orgID string,
 ) (*model.ListResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "authresource.GetAuthResourcesByUserID")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Retrieving auth resources by user ID",
 		log.String("user_id", userID),
@@ -320,12 +382,67 @@ func (s *authResourceService) GetAuthResourcesByUserID(
 	}, nil
 }
 
+// SearchAuthResources retrieves a page of authorizations across consents matching the filters, most
+// recently updated first
+func (s *authResourceService) SearchAuthResources(
+	ctx context.Context,
+	filters model.AuthResourceSearchFilters,
+) (*model.AuthResourceSearchResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "authresource.SearchAuthResources")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if filters.Limit <= 0 {
+		filters.Limit = defaultSearchLimit
+	}
+	if filters.Limit > maxSearchLimit {
+		filters.Limit = maxSearchLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	authResources, total, err := s.stores.AuthResource.Search(ctx, filters)
+	if err != nil {
+		logger.Error("Failed to search auth resources", log.Error(err))
+		return nil, serviceerror.CustomServiceError(
+			serviceerror.DatabaseError,
+			fmt.Sprintf("failed to search auth resources: %v", err),
+		)
+	}
+
+	// Initialize as empty slice to ensure JSON serialization returns [] instead of null
+	items := make([]model.AuthResourceSearchItem, 0, len(authResources))
+	for _, ar := range authResources {
+		items = append(items, model.AuthResourceSearchItem{
+			ConsentAuthResourceResponse: *s.buildResponse(&ar),
+			ConsentID:                   ar.ConsentID,
+			OrgID:                       ar.OrgID,
+		})
+	}
+
+	return &model.AuthResourceSearchResponse{
+		Data: items,
+		Metadata: model.PaginationMetadata{
+			Total:   total,
+			Limit:   filters.Limit,
+			Offset:  filters.Offset,
+			Count:   len(items),
+			HasMore: filters.Offset+len(items) < total,
+		},
+	}, nil
+}
+
 // UpdateAuthResource updates an existing authorization resource
 func (s *authResourceService) UpdateAuthResource(
 	ctx context.Context,
 	authID, orgID string,
 	request *model.UpdateRequest,
 ) (*model.Response, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "authresource.UpdateAuthResource")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Info("Updating auth resource",
 		log.String("auth_id", authID),
@@ -358,6 +475,16 @@ func (s *authResourceService) UpdateAuthResource(
 		)
 	}
 
+	if err := s.ensureConsentExists(ctx, existingAuthResource.ConsentID, orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(
+			serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("auth resource not found: %s", authID),
+		)
+	}
+	if err := s.checkConsentLock(ctx, existingAuthResource.ConsentID, orgID); err != nil {
+		return nil, err
+	}
+
 	// Update fields if provided
 	updatedAuthResource := *existingAuthResource
 	updatedAuthResource.UpdatedTime = utils.GetCurrentTimeMillis()
@@ -379,7 +506,19 @@ func (s *authResourceService) UpdateAuthResource(
 		updatedAuthResource.UserID = request.UserID
 	}
 
+	// The parties of a delegation are replaced together
+	if request.DelegatorID != nil || request.DelegateID != nil {
+		if err := authvalidator.ValidateDelegation(request.DelegatorID, request.DelegateID); err != nil {
+			return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+		}
+		updatedAuthResource.DelegatorID = request.DelegatorID
+		updatedAuthResource.DelegateID = request.DelegateID
+	}
+
 	if request.Resources != nil {
+		if err := s.enforceResourceSchema(ctx, existingAuthResource.AuthType, orgID, request.Resources); err != nil {
+			return nil, err
+		}
 		resourcesBytes, err := json.Marshal(request.Resources)
 		if err != nil {
 			return nil, serviceerror.CustomServiceError(
@@ -408,8 +547,10 @@ func (s *authResourceService) UpdateAuthResource(
 			}
 
 			// Extract auth statuses (including the updated one)
+			authTypes := make([]string, 0, len(allAuthResources))
 			authStatuses := make([]string, 0, len(allAuthResources))
 			for _, ar := range allAuthResources {
+				authTypes = append(authTypes, ar.AuthType)
 				if ar.AuthID == authID {
 					// Use the new status for this auth resource
 					authStatuses = append(authStatuses, updatedAuthResource.AuthStatus)
@@ -418,14 +559,6 @@ func (s *authResourceService) UpdateAuthResource(
 				}
 			}
 
-			// Derive consent status
-			derivedConsentStatus := validator.EvaluateConsentStatusFromAuthStatuses(authStatuses)
-			logger.Debug("Derived consent status from auth statuses",
-				log.String("consent_id", existingAuthResource.ConsentID),
-				log.String("derived_status", derivedConsentStatus),
-				log.Int("auth_count", len(authStatuses)),
-			)
-
 			// Get current consent to check if status changed using reflection
 			getByIDMethod := reflect.ValueOf(s.stores.Consent).MethodByName("GetByID")
 			getResults := getByIDMethod.Call([]reflect.Value{
@@ -441,12 +574,27 @@ func (s *authResourceService) UpdateAuthResource(
 			// Extract current status using JSON marshal/unmarshal
 			type consentWithStatus struct {
 				CurrentStatus string `json:"currentStatus"`
+				ConsentType   string `json:"consentType"`
 				OrgID         string `json:"orgId"`
 			}
 			currentConsentBytes, _ := json.Marshal(currentConsentInterface)
 			var currentConsent consentWithStatus
 			json.Unmarshal(currentConsentBytes, &currentConsent)
 
+			// Derive consent status
+			derivedConsentStatus := validator.EvaluateConsentStatusFromAuthStatuses(orgID, currentConsent.ConsentType, authStatuses)
+			logger.Debug("Derived consent status from auth statuses",
+				log.String("consent_id", existingAuthResource.ConsentID),
+				log.String("derived_status", derivedConsentStatus),
+				log.Int("auth_count", len(authStatuses)),
+			)
+			derivedConsentStatus, err = s.applyRequiredAuthorizers(ctx, existingAuthResource.ConsentID, orgID, derivedConsentStatus, authTypes, authStatuses)
+			if err != nil {
+				return err
+			}
+			derivedConsentStatus = validator.KeepAwaitingReauthorization(orgID, currentConsent.CurrentStatus, derivedConsentStatus)
+			derivedConsentStatus = validator.KeepSuspended(orgID, currentConsent.CurrentStatus, derivedConsentStatus)
+
 			// Only update if consent status actually changed
 			if currentConsent.CurrentStatus != derivedConsentStatus {
 				updatedTime := utils.GetCurrentTimeMillis()
@@ -505,6 +653,10 @@ func (s *authResourceService) UpdateAuthResource(
 				if !results[0].IsNil() {
 					return results[0].Interface().(error)
 				}
+				// Tokens of a consent the authorization rejected no longer resolve to it
+				if validator.IsConsentEnded(orgID, derivedConsentStatus) {
+					return s.stores.Consent.DeleteTokensByConsentID(tx, existingAuthResource.ConsentID, orgID)
+				}
 				return nil
 			}
 			return nil
@@ -512,7 +664,7 @@ func (s *authResourceService) UpdateAuthResource(
 	}
 
 	logger.Debug("Executing transaction for auth resource update")
-	err = s.stores.ExecuteTransaction(transactionSteps)
+	err = s.stores.ExecuteTransaction(ctx, transactionSteps)
 	if err != nil {
 		logger.Error("Transaction failed for auth resource update",
 			log.Error(err),
@@ -523,6 +675,7 @@ func (s *authResourceService) UpdateAuthResource(
 			fmt.Sprintf("failed to update auth resource: %v", err),
 		)
 	}
+	cache.InvalidateConsentValidation(ctx, orgID, updatedAuthResource.ConsentID)
 
 	logger.Info("Auth resource updated successfully",
 		log.String("auth_id", updatedAuthResource.AuthID),
@@ -533,11 +686,126 @@ func (s *authResourceService) UpdateAuthResource(
 	return s.buildResponse(&updatedAuthResource), nil
 }
 
+// PatchAuthResource partially updates an authorization resource. Only the provided fields are
+// changed and resources are appended to the stored resources. The update itself, including the
+// consent status re-derivation, is delegated to UpdateAuthResource.
+func (s *authResourceService) PatchAuthResource(
+	ctx context.Context,
+	consentID, authID, orgID string,
+	request *model.PatchRequest,
+) (*model.Response, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "authresource.PatchAuthResource")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Patching auth resource",
+		log.String("consent_id", consentID),
+		log.String("auth_id", authID),
+		log.String("org_id", orgID),
+	)
+
+	if err := s.validateAuthIDAndOrgID(authID, orgID); err != nil {
+		logger.Warn("Validation failed for patch auth resource", log.String("error", err.Error()))
+		return nil, err
+	}
+	if request.AuthStatus == "" && request.UserID == nil && request.Resources == nil &&
+		request.DelegatorID == nil && request.DelegateID == nil {
+		return nil, serviceerror.CustomServiceError(
+			serviceerror.ValidationError,
+			"at least one of status, userId, resources, delegatorId or delegateId must be provided",
+		)
+	}
+
+	existingAuthResource, err := s.stores.AuthResource.GetByID(ctx, authID, orgID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, serviceerror.CustomServiceError(
+				serviceerror.ResourceNotFoundError,
+				fmt.Sprintf("auth resource not found: %s", authID),
+			)
+		}
+		return nil, serviceerror.CustomServiceError(
+			serviceerror.DatabaseError,
+			fmt.Sprintf("failed to retrieve auth resource: %v", err),
+		)
+	}
+	if existingAuthResource.ConsentID != consentID {
+		logger.Warn("Auth resource does not belong to consent",
+			log.String("auth_id", authID),
+			log.String("consent_id", consentID),
+		)
+		return nil, serviceerror.CustomServiceError(
+			serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("auth resource not found: %s", authID),
+		)
+	}
+
+	update := &model.UpdateRequest{
+		AuthStatus:  request.AuthStatus,
+		UserID:      request.UserID,
+		DelegatorID: request.DelegatorID,
+		DelegateID:  request.DelegateID,
+	}
+	if request.Resources != nil {
+		merged, mergeErr := appendResources(existingAuthResource.Resources, request.Resources)
+		if mergeErr != nil {
+			logger.Warn("Failed to append auth resources", log.Error(mergeErr), log.String("auth_id", authID))
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, mergeErr.Error())
+		}
+		update.Resources = merged
+	}
+
+	return s.UpdateAuthResource(ctx, authID, orgID, update)
+}
+
+// appendResources appends patch resources to the stored resources JSON. Arrays are concatenated.
+// For objects, keys are added, and array values under an existing key are concatenated; other
+// values under an existing key are replaced.
+func appendResources(stored *string, patch interface{}) (interface{}, error) {
+	if stored == nil || *stored == "" || *stored == "null" {
+		return patch, nil
+	}
+
+	var existing interface{}
+	if err := json.Unmarshal([]byte(*stored), &existing); err != nil {
+		return nil, fmt.Errorf("stored resources are not valid JSON: %v", err)
+	}
+
+	switch current := existing.(type) {
+	case []interface{}:
+		items, ok := patch.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("resources must be an array to append to the stored resources")
+		}
+		return append(current, items...), nil
+	case map[string]interface{}:
+		fields, ok := patch.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("resources must be an object to append to the stored resources")
+		}
+		for key, value := range fields {
+			existingItems, existingIsArray := current[key].([]interface{})
+			newItems, newIsArray := value.([]interface{})
+			if existingIsArray && newIsArray {
+				current[key] = append(existingItems, newItems...)
+			} else {
+				current[key] = value
+			}
+		}
+		return current, nil
+	default:
+		return nil, fmt.Errorf("stored resources must be a JSON object or array to append to")
+	}
+}
+
 // DeleteAuthResource deletes an authorization resource
 func (s *authResourceService) DeleteAuthResource(
 	ctx context.Context,
 	authID, orgID string,
 ) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "authresource.DeleteAuthResource")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Info("Deleting auth resource",
 		log.String("auth_id", authID),
@@ -565,9 +833,12 @@ func (s *authResourceService) DeleteAuthResource(
 			fmt.Sprintf("failed to retrieve auth resource: %v", err),
 		)
 	}
+	if err := s.checkConsentLock(ctx, existingAuthResource.ConsentID, orgID); err != nil {
+		return err
+	}
 
 	// Delete auth resource and update consent status in transaction
-	err = s.stores.ExecuteTransaction([]func(tx dbmodel.TxInterface) error{
+	err = s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
 		func(tx dbmodel.TxInterface) error {
 			return store.Delete(tx, authID, orgID)
 		},
@@ -579,21 +850,15 @@ func (s *authResourceService) DeleteAuthResource(
 			}
 
 			// Filter out the deleted auth resource
+			authTypes := make([]string, 0, len(allAuthResources))
 			authStatuses := make([]string, 0, len(allAuthResources))
 			for _, ar := range allAuthResources {
 				if ar.AuthID != authID {
+					authTypes = append(authTypes, ar.AuthType)
 					authStatuses = append(authStatuses, ar.AuthStatus)
 				}
 			}
 
-			// Derive consent status from remaining auth resources
-			derivedConsentStatus := validator.EvaluateConsentStatusFromAuthStatuses(authStatuses)
-			logger.Debug("Derived consent status after deletion",
-				log.String("consent_id", existingAuthResource.ConsentID),
-				log.String("derived_status", derivedConsentStatus),
-				log.Int("remaining_auth_count", len(authStatuses)),
-			)
-
 			// Get current consent to check if status changed using reflection
 			getByIDMethod := reflect.ValueOf(s.stores.Consent).MethodByName("GetByID")
 			getResults := getByIDMethod.Call([]reflect.Value{
@@ -609,11 +874,26 @@ func (s *authResourceService) DeleteAuthResource(
 			// Extract current status using JSON marshal/unmarshal
 			type consentWithStatus struct {
 				CurrentStatus string `json:"currentStatus"`
+				ConsentType   string `json:"consentType"`
 			}
 			currentConsentBytes, _ := json.Marshal(currentConsentInterface)
 			var currentConsent consentWithStatus
 			json.Unmarshal(currentConsentBytes, &currentConsent)
 
+			// Derive consent status from remaining auth resources
+			derivedConsentStatus := validator.EvaluateConsentStatusFromAuthStatuses(orgID, currentConsent.ConsentType, authStatuses)
+			logger.Debug("Derived consent status after deletion",
+				log.String("consent_id", existingAuthResource.ConsentID),
+				log.String("derived_status", derivedConsentStatus),
+				log.Int("remaining_auth_count", len(authStatuses)),
+			)
+			derivedConsentStatus, err = s.applyRequiredAuthorizers(ctx, existingAuthResource.ConsentID, orgID, derivedConsentStatus, authTypes, authStatuses)
+			if err != nil {
+				return err
+			}
+			derivedConsentStatus = validator.KeepAwaitingReauthorization(orgID, currentConsent.CurrentStatus, derivedConsentStatus)
+			derivedConsentStatus = validator.KeepSuspended(orgID, currentConsent.CurrentStatus, derivedConsentStatus)
+
 			// Only update if consent status actually changed
 			if currentConsent.CurrentStatus != derivedConsentStatus {
 				updatedTime := utils.GetCurrentTimeMillis()
@@ -661,6 +941,10 @@ func (s *authResourceService) DeleteAuthResource(
 				if !results[0].IsNil() {
 					return results[0].Interface().(error)
 				}
+				// Tokens of a consent the authorization rejected no longer resolve to it
+				if validator.IsConsentEnded(orgID, derivedConsentStatus) {
+					return s.stores.Consent.DeleteTokensByConsentID(tx, existingAuthResource.ConsentID, orgID)
+				}
 				return nil
 			}
 			return nil
@@ -677,6 +961,7 @@ func (s *authResourceService) DeleteAuthResource(
 			fmt.Sprintf("failed to delete auth resource: %v", err),
 		)
 	}
+	cache.InvalidateConsentValidation(ctx, orgID, existingAuthResource.ConsentID)
 
 	logger.Info("Auth resource deleted successfully",
 		log.String("auth_id", authID),
@@ -690,6 +975,9 @@ func (s *authResourceService) DeleteAuthResourcesByConsentID(
 	ctx context.Context,
 	consentID, orgID string,
 ) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "authresource.DeleteAuthResourcesByConsentID")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Info("Deleting all auth resources for consent",
 		log.String("consent_id", consentID),
@@ -705,7 +993,7 @@ func (s *authResourceService) DeleteAuthResourcesByConsentID(
 	// Delete all auth resources for the consent
 	store := s.stores.AuthResource
 	logger.Debug("Executing transaction for auth resources deletion")
-	err := s.stores.ExecuteTransaction([]func(tx dbmodel.TxInterface) error{
+	err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
 		func(tx dbmodel.TxInterface) error {
 			return store.DeleteByConsentID(tx, consentID, orgID)
 		},
@@ -720,6 +1008,7 @@ func (s *authResourceService) DeleteAuthResourcesByConsentID(
 			fmt.Sprintf("failed to delete auth resources: %v", err),
 		)
 	}
+	cache.InvalidateConsentValidation(ctx, orgID, consentID)
 
 	logger.Info("Auth resources deleted successfully for consent",
 		log.String("consent_id", consentID),
@@ -733,6 +1022,9 @@ func (s *authResourceService) UpdateAllStatusByConsentID(
 	consentID, orgID string,
 	status string,
 ) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "authresource.UpdateAllStatusByConsentID")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Info("Updating all auth resource statuses for consent",
 		log.String("consent_id", consentID),
@@ -757,7 +1049,7 @@ func (s *authResourceService) UpdateAllStatusByConsentID(
 	store := s.stores.AuthResource
 	updatedTime := utils.GetCurrentTimeMillis()
 	logger.Debug("Executing transaction for auth statuses update")
-	err := s.stores.ExecuteTransaction([]func(tx dbmodel.TxInterface) error{
+	err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
 		func(tx dbmodel.TxInterface) error {
 			return store.UpdateAllStatusByConsentID(tx, consentID, orgID, status, updatedTime)
 		},
@@ -772,6 +1064,7 @@ func (s *authResourceService) UpdateAllStatusByConsentID(
 			fmt.Sprintf("failed to update auth resource statuses: %v", err),
 		)
 	}
+	cache.InvalidateConsentValidation(ctx, orgID, consentID)
 
 	logger.Info("Auth resource statuses updated successfully",
 		log.String("consent_id", consentID),
@@ -780,8 +1073,71 @@ func (s *authResourceService) UpdateAllStatusByConsentID(
 	return nil
 }
 
+// GetAuthResourceHistory retrieves the status changes of an authorization resource of a consent, oldest
+// first. The history of an authorization that was replaced by a consent update is still returned.
+func (s *authResourceService) GetAuthResourceHistory(
+	ctx context.Context,
+	consentID, authID, orgID string,
+) (*model.AuthStatusAuditListResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "authresource.GetAuthResourceHistory")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := s.validateConsentIDAndOrgID(consentID, orgID); err != nil {
+		return nil, err
+	}
+	if err := s.validateAuthIDAndOrgID(authID, orgID); err != nil {
+		return nil, err
+	}
+	if err := s.ensureConsentExists(ctx, consentID, orgID); err != nil {
+		return nil, err
+	}
+
+	audits, err := s.stores.AuthResource.GetStatusAudits(ctx, authID, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve auth resource status history",
+			log.Error(err),
+			log.String("auth_id", authID),
+		)
+		return nil, serviceerror.CustomServiceError(
+			serviceerror.DatabaseError,
+			fmt.Sprintf("failed to retrieve auth resource history: %v", err),
+		)
+	}
+	if len(audits) == 0 {
+		return nil, serviceerror.CustomServiceError(
+			serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("auth resource not found: %s", authID),
+		)
+	}
+
+	return &model.AuthStatusAuditListResponse{Data: audits}, nil
+}
+
 // Helper methods for validation
 
+// enforceResourceSchema rejects resources that do not conform to the organization's resources schema
+// for the authorization type. Authorization types without a schema accept any resources.
+func (s *authResourceService) enforceResourceSchema(ctx context.Context, authType, orgID string, resources interface{}) *serviceerror.ServiceError {
+	logger := log.GetLogger().WithContext(ctx)
+
+	schema, err := s.stores.ResourceSchema.Get(ctx, authType, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve resources schema", log.Error(err), log.String("auth_type", authType))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if schema == nil {
+		return nil
+	}
+
+	if err := schema.ValidateResources(resources); err != nil {
+		logger.Warn("Authorization resources rejected by the resources schema", log.Error(err))
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	return nil
+}
+
 func (s *authResourceService) validateCreateRequest(consentID, orgID string, request *model.CreateRequest) *serviceerror.ServiceError {
 	if err := s.validateConsentIDAndOrgID(consentID, orgID); err != nil {
 		return err
@@ -804,6 +1160,9 @@ func (s *authResourceService) validateCreateRequest(consentID, orgID string, req
 			"auth status is required",
 		)
 	}
+	if err := authvalidator.ValidateDelegation(request.DelegatorID, request.DelegateID); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
 	return nil
 }
 
@@ -839,6 +1198,57 @@ func (s *authResourceService) validateConsentIDAndOrgID(consentID, orgID string)
 	return s.validateOrgID(orgID)
 }
 
+// applyRequiredAuthorizers adjusts a consent status derived from the given authorizations for the
+// consent's required authorizers, which keep the consent from becoming active until each approved
+func (s *authResourceService) applyRequiredAuthorizers(
+	ctx context.Context,
+	consentID, orgID, derived string,
+	authTypes, authStatuses []string,
+) (string, error) {
+	requiredAuthorizers, err := s.stores.Consent.GetRequiredAuthorizers(ctx, consentID, orgID)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve required authorizers: %w", err)
+	}
+	pending := validator.PendingAuthorizers(orgID, requiredAuthorizers, authTypes, authStatuses)
+	return validator.ApplyRequiredAuthorizers(orgID, derived, pending, authStatuses), nil
+}
+
+// ensureConsentExists returns a not found error if the consent does not exist or is deleted
+func (s *authResourceService) ensureConsentExists(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError {
+	consent, err := s.stores.Consent.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		return serviceerror.CustomServiceError(
+			serviceerror.DatabaseError,
+			fmt.Sprintf("failed to retrieve consent: %v", err),
+		)
+	}
+	if consent == nil {
+		return serviceerror.CustomServiceError(
+			serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("consent not found: %s", consentID),
+		)
+	}
+	return nil
+}
+
+// checkConsentLock returns a locked error if another caller holds the lock of the consent
+func (s *authResourceService) checkConsentLock(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError {
+	lock, err := s.stores.Consent.GetLock(ctx, consentID, orgID)
+	if err != nil {
+		return serviceerror.CustomServiceError(
+			serviceerror.DatabaseError,
+			fmt.Sprintf("failed to retrieve consent lock: %v", err),
+		)
+	}
+	if lock.Blocks(consentlock.TokenFromContext(ctx), utils.GetCurrentTimeMillis()) {
+		return serviceerror.CustomServiceError(
+			serviceerror.ConsentLockedError,
+			fmt.Sprintf("consent is locked by another caller: %s", consentID),
+		)
+	}
+	return nil
+}
+
 func (s *authResourceService) validateOrgID(orgID string) *serviceerror.ServiceError {
 	if orgID == "" {
 		return serviceerror.CustomServiceError(
@@ -869,5 +1279,7 @@ func (s *authResourceService) buildResponse(authResource *model.AuthResource) *m
 		AuthStatus:  authResource.AuthStatus,
 		UpdatedTime: authResource.UpdatedTime,
 		Resources:   resources,
+		DelegatorID: authResource.DelegatorID,
+		DelegateID:  authResource.DelegateID,
 	}
 }
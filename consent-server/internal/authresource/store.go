@@ -3,33 +3,35 @@ package authresource
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/wso2/consent-management-api/internal/authresource/model"
 	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
 	"github.com/wso2/consent-management-api/internal/system/database/provider"
 	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+	"github.com/wso2/consent-management-api/internal/system/utils"
 )
 
 // DBQuery objects for all auth resource operations
 var (
 	QueryCreateAuthResource = dbmodel.DBQuery{
 		ID:    "CREATE_AUTH_RESOURCE",
-		Query: "INSERT INTO CONSENT_AUTH_RESOURCE (AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, RESOURCES, ORG_ID) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		Query: "INSERT INTO CONSENT_AUTH_RESOURCE (AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, RESOURCES, ORG_ID, DELEGATOR_ID, DELEGATE_ID) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 	}
 
 	QueryGetAuthResourceByID = dbmodel.DBQuery{
 		ID:    "GET_AUTH_RESOURCE_BY_ID",
-		Query: "SELECT AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, RESOURCES, ORG_ID FROM CONSENT_AUTH_RESOURCE WHERE AUTH_ID = ? AND ORG_ID = ?",
+		Query: "SELECT AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, RESOURCES, ORG_ID, DELEGATOR_ID, DELEGATE_ID FROM CONSENT_AUTH_RESOURCE WHERE AUTH_ID = ? AND ORG_ID = ?",
 	}
 
 	QueryGetAuthResourcesByConsentID = dbmodel.DBQuery{
 		ID:    "GET_AUTH_RESOURCES_BY_CONSENT_ID",
-		Query: "SELECT AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, RESOURCES, ORG_ID FROM CONSENT_AUTH_RESOURCE WHERE CONSENT_ID = ? AND ORG_ID = ?",
+		Query: "SELECT AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, RESOURCES, ORG_ID, DELEGATOR_ID, DELEGATE_ID FROM CONSENT_AUTH_RESOURCE WHERE CONSENT_ID = ? AND ORG_ID = ?",
 	}
 
 	QueryUpdateAuthResource = dbmodel.DBQuery{
 		ID:    "UPDATE_AUTH_RESOURCE",
-		Query: "UPDATE CONSENT_AUTH_RESOURCE SET AUTH_STATUS = ?, USER_ID = ?, RESOURCES = ?, UPDATED_TIME = ? WHERE AUTH_ID = ? AND ORG_ID = ?",
+		Query: "UPDATE CONSENT_AUTH_RESOURCE SET AUTH_STATUS = ?, USER_ID = ?, RESOURCES = ?, DELEGATOR_ID = ?, DELEGATE_ID = ?, UPDATED_TIME = ? WHERE AUTH_ID = ? AND ORG_ID = ?",
 	}
 
 	QueryUpdateAuthResourceStatus = dbmodel.DBQuery{
@@ -54,7 +56,7 @@ var (
 
 	QueryGetAuthResourcesByUserID = dbmodel.DBQuery{
 		ID:    "GET_AUTH_RESOURCES_BY_USER_ID",
-		Query: "SELECT AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, RESOURCES, ORG_ID FROM CONSENT_AUTH_RESOURCE WHERE USER_ID = ? AND ORG_ID = ?",
+		Query: "SELECT CONSENT_AUTH_RESOURCE.AUTH_ID, CONSENT_AUTH_RESOURCE.CONSENT_ID, CONSENT_AUTH_RESOURCE.AUTH_TYPE, CONSENT_AUTH_RESOURCE.USER_ID, CONSENT_AUTH_RESOURCE.AUTH_STATUS, CONSENT_AUTH_RESOURCE.UPDATED_TIME, CONSENT_AUTH_RESOURCE.RESOURCES, CONSENT_AUTH_RESOURCE.ORG_ID, CONSENT_AUTH_RESOURCE.DELEGATOR_ID, CONSENT_AUTH_RESOURCE.DELEGATE_ID FROM CONSENT_AUTH_RESOURCE INNER JOIN CONSENT ON CONSENT_AUTH_RESOURCE.CONSENT_ID = CONSENT.CONSENT_ID AND CONSENT_AUTH_RESOURCE.ORG_ID = CONSENT.ORG_ID WHERE CONSENT_AUTH_RESOURCE.USER_ID = ? AND CONSENT_AUTH_RESOURCE.ORG_ID = ? AND CONSENT.CURRENT_STATUS <> 'DELETED'",
 	}
 
 	QueryUpdateAllStatusByConsentID = dbmodel.DBQuery{
@@ -62,10 +64,40 @@ var (
 		Query: "UPDATE CONSENT_AUTH_RESOURCE SET AUTH_STATUS = ?, UPDATED_TIME = ? WHERE CONSENT_ID = ? AND ORG_ID = ?",
 	}
 
+	QueryReplaceUserID = dbmodel.DBQuery{
+		ID:    "REPLACE_AUTH_RESOURCE_USER_ID",
+		Query: "UPDATE CONSENT_AUTH_RESOURCE SET USER_ID = ?, UPDATED_TIME = ? WHERE USER_ID = ? AND ORG_ID = ?",
+	}
+
 	QueryGetAuthResourcesByConsentIDs = dbmodel.DBQuery{
 		ID:    "GET_AUTH_RESOURCES_BY_CONSENT_IDS",
 		Query: "", // Built dynamically
 	}
+
+	QueryCreateAuthStatusAudit = dbmodel.DBQuery{
+		ID:    "CREATE_AUTH_STATUS_AUDIT",
+		Query: "INSERT INTO AUTH_STATUS_AUDIT (STATUS_AUDIT_ID, AUTH_ID, CONSENT_ID, CURRENT_STATUS, PREVIOUS_STATUS, ACTION_TIME, ORG_ID) VALUES (?, ?, ?, ?, ?, ?, ?)",
+	}
+
+	// The stored status is the previous status; unchanged statuses are not audited
+	QueryCreateAuthStatusAuditFromCurrent = dbmodel.DBQuery{
+		ID: "CREATE_AUTH_STATUS_AUDIT_FROM_CURRENT",
+		Query: `INSERT INTO AUTH_STATUS_AUDIT (STATUS_AUDIT_ID, AUTH_ID, CONSENT_ID, CURRENT_STATUS, PREVIOUS_STATUS, ACTION_TIME, ORG_ID)
+				SELECT ?, AUTH_ID, CONSENT_ID, ?, AUTH_STATUS, ?, ORG_ID FROM CONSENT_AUTH_RESOURCE
+				WHERE AUTH_ID = ? AND ORG_ID = ? AND AUTH_STATUS <> ?`,
+	}
+
+	QueryGetAuthIDsWithOtherStatus = dbmodel.DBQuery{
+		ID:    "GET_AUTH_IDS_WITH_OTHER_STATUS",
+		Query: "SELECT AUTH_ID FROM CONSENT_AUTH_RESOURCE WHERE CONSENT_ID = ? AND ORG_ID = ? AND AUTH_STATUS <> ?",
+	}
+
+	QueryGetAuthStatusAudits = dbmodel.DBQuery{
+		ID: "GET_AUTH_STATUS_AUDITS",
+		Query: `SELECT STATUS_AUDIT_ID, AUTH_ID, CONSENT_ID, CURRENT_STATUS, PREVIOUS_STATUS, ACTION_TIME, ORG_ID
+				FROM AUTH_STATUS_AUDIT WHERE AUTH_ID = ? AND CONSENT_ID = ? AND ORG_ID = ?
+				ORDER BY ACTION_TIME, STATUS_AUDIT_ID`,
+	}
 )
 
 // store implements interfaces.AuthResourceStore
@@ -91,13 +123,20 @@ func (s *store) Create(tx dbmodel.TxInterface, authResource *model.AuthResource)
 		authResource.UpdatedTime,
 		authResource.Resources,
 		authResource.OrgID,
+		authResource.DelegatorID,
+		authResource.DelegateID,
 	)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(QueryCreateAuthStatusAudit.Query, utils.GenerateUUID(), authResource.AuthID, authResource.ConsentID,
+		authResource.AuthStatus, nil, authResource.UpdatedTime, authResource.OrgID)
 	return err
 }
 
 // GetByID retrieves an auth resource by ID
 func (s *store) GetByID(ctx context.Context, authID, orgID string) (*model.AuthResource, error) {
-	results, err := s.dbClient.Query(QueryGetAuthResourceByID, authID, orgID)
+	results, err := s.dbClient.QueryContext(ctx, QueryGetAuthResourceByID, authID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +148,7 @@ func (s *store) GetByID(ctx context.Context, authID, orgID string) (*model.AuthR
 
 // GetByConsentID retrieves all auth resources for a consent
 func (s *store) GetByConsentID(ctx context.Context, consentID, orgID string) ([]model.AuthResource, error) {
-	results, err := s.dbClient.Query(QueryGetAuthResourcesByConsentID, consentID, orgID)
+	results, err := s.dbClient.QueryContext(ctx, QueryGetAuthResourcesByConsentID, consentID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -121,12 +160,17 @@ func (s *store) GetByConsentID(ctx context.Context, consentID, orgID string) ([]
 	return authResources, nil
 }
 
-// Update updates an auth resource within a transaction
+// Update updates an auth resource within a transaction, auditing a status change
 func (s *store) Update(tx dbmodel.TxInterface, authResource *model.AuthResource) error {
+	if err := auditStatusChange(tx, authResource.AuthID, authResource.OrgID, authResource.AuthStatus, authResource.UpdatedTime); err != nil {
+		return err
+	}
 	_, err := tx.Exec(QueryUpdateAuthResource.Query,
 		authResource.AuthStatus,
 		authResource.UserID,
 		authResource.Resources,
+		authResource.DelegatorID,
+		authResource.DelegateID,
 		authResource.UpdatedTime,
 		authResource.AuthID,
 		authResource.OrgID,
@@ -134,8 +178,11 @@ func (s *store) Update(tx dbmodel.TxInterface, authResource *model.AuthResource)
 	return err
 }
 
-// UpdateStatus updates only the status of an auth resource within a transaction
+// UpdateStatus updates only the status of an auth resource within a transaction, auditing a status change
 func (s *store) UpdateStatus(tx dbmodel.TxInterface, authID, orgID, status string, updatedTime int64) error {
+	if err := auditStatusChange(tx, authID, orgID, status, updatedTime); err != nil {
+		return err
+	}
 	_, err := tx.Exec(QueryUpdateAuthResourceStatus.Query, status, updatedTime, authID, orgID)
 	return err
 }
@@ -154,7 +201,7 @@ func (s *store) DeleteByConsentID(tx dbmodel.TxInterface, consentID, orgID strin
 
 // Exists checks if an auth resource exists
 func (s *store) Exists(ctx context.Context, authID, orgID string) (bool, error) {
-	results, err := s.dbClient.Query(QueryCheckAuthResourceExists, authID, orgID)
+	results, err := s.dbClient.QueryContext(ctx, QueryCheckAuthResourceExists, authID, orgID)
 	if err != nil {
 		return false, err
 	}
@@ -170,7 +217,7 @@ func (s *store) Exists(ctx context.Context, authID, orgID string) (bool, error)
 
 // GetByUserID retrieves all auth resources for a user
 func (s *store) GetByUserID(ctx context.Context, userID, orgID string) ([]model.AuthResource, error) {
-	results, err := s.dbClient.Query(QueryGetAuthResourcesByUserID, userID, orgID)
+	results, err := s.dbClient.QueryContext(ctx, QueryGetAuthResourcesByUserID, userID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -182,9 +229,150 @@ func (s *store) GetByUserID(ctx context.Context, userID, orgID string) ([]model.
 	return authResources, nil
 }
 
-// UpdateAllStatusByConsentID updates status for all auth resources of a consent within a transaction
+// UpdateAllStatusByConsentID updates status for all auth resources of a consent within a transaction,
+// auditing each auth resource whose status changes
 func (s *store) UpdateAllStatusByConsentID(tx dbmodel.TxInterface, consentID, orgID, status string, updatedTime int64) error {
-	_, err := tx.Exec(QueryUpdateAllStatusByConsentID.Query, status, updatedTime, consentID, orgID)
+	rows, err := tx.Query(QueryGetAuthIDsWithOtherStatus.Query, consentID, orgID, status)
+	if err != nil {
+		return err
+	}
+	authIDs := make([]string, 0)
+	for rows.Next() {
+		var authID string
+		if err := rows.Scan(&authID); err != nil {
+			rows.Close()
+			return err
+		}
+		authIDs = append(authIDs, authID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, authID := range authIDs {
+		if err := auditStatusChange(tx, authID, orgID, status, updatedTime); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(QueryUpdateAllStatusByConsentID.Query, status, updatedTime, consentID, orgID)
+	return err
+}
+
+// GetStatusAudits retrieves the status changes of an auth resource, oldest first. Entries remain
+// after the auth resource is replaced or deleted, until the consent itself is deleted.
+func (s *store) GetStatusAudits(ctx context.Context, authID, consentID, orgID string) ([]model.AuthStatusAudit, error) {
+	results, err := s.dbClient.QueryContext(ctx, QueryGetAuthStatusAudits, authID, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]model.AuthStatusAudit, 0, len(results))
+	for _, row := range results {
+		audit := model.AuthStatusAudit{
+			StatusAuditID: getString(row, "status_audit_id"),
+			AuthID:        getString(row, "auth_id"),
+			ConsentID:     getString(row, "consent_id"),
+			CurrentStatus: getString(row, "current_status"),
+			OrgID:         getString(row, "org_id"),
+		}
+		if previousStatus := getString(row, "previous_status"); previousStatus != "" {
+			audit.PreviousStatus = &previousStatus
+		}
+		if actionTime, ok := row["action_time"].(int64); ok {
+			audit.ActionTime = actionTime
+		}
+		audits = append(audits, audit)
+	}
+	return audits, nil
+}
+
+// Search retrieves the auth resources matching the filters, most recently updated first, with the
+// total number of matching auth resources. Auth resources of deleted consents are not returned.
+func (s *store) Search(ctx context.Context, filters model.AuthResourceSearchFilters) ([]model.AuthResource, int, error) {
+	whereConditions := []string{"CONSENT.CURRENT_STATUS <> 'DELETED'"}
+	args := []interface{}{}
+
+	equalFilters := []struct {
+		condition string
+		value     string
+	}{
+		{"CONSENT_AUTH_RESOURCE.ORG_ID = ?", filters.OrgID},
+		{"CONSENT_AUTH_RESOURCE.USER_ID = ?", filters.UserID},
+		{"CONSENT_AUTH_RESOURCE.DELEGATOR_ID = ?", filters.DelegatorID},
+		{"CONSENT_AUTH_RESOURCE.DELEGATE_ID = ?", filters.DelegateID},
+		{"CONSENT_AUTH_RESOURCE.AUTH_TYPE = ?", filters.AuthType},
+	}
+	for _, equalFilter := range equalFilters {
+		if equalFilter.value != "" {
+			whereConditions = append(whereConditions, equalFilter.condition)
+			args = append(args, equalFilter.value)
+		}
+	}
+
+	if len(filters.Statuses) > 0 {
+		placeholders := make([]string, len(filters.Statuses))
+		for i, status := range filters.Statuses {
+			placeholders[i] = "?"
+			args = append(args, status)
+		}
+		whereConditions = append(whereConditions,
+			fmt.Sprintf("CONSENT_AUTH_RESOURCE.AUTH_STATUS IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	fromClause := " FROM CONSENT_AUTH_RESOURCE INNER JOIN CONSENT ON CONSENT_AUTH_RESOURCE.CONSENT_ID = CONSENT.CONSENT_ID" +
+		" AND CONSENT_AUTH_RESOURCE.ORG_ID = CONSENT.ORG_ID WHERE " + strings.Join(whereConditions, " AND ")
+
+	// Without an organization filter the search spans every organization
+	crossTenant := filters.OrgID == ""
+
+	countRows, err := s.dbClient.QueryContext(ctx, dbmodel.DBQuery{
+		ID:          "COUNT_AUTH_RESOURCE_SEARCH_RESULTS",
+		Query:       "SELECT COUNT(*) as count" + fromClause,
+		CrossTenant: crossTenant,
+	}, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	totalCount := 0
+	if len(countRows) > 0 {
+		if count, ok := countRows[0]["count"].(int64); ok {
+			totalCount = int(count)
+		}
+	}
+
+	// AUTH_ID breaks ties between auth resources updated at the same time so the order is stable
+	// across pages
+	selectQuery := "SELECT CONSENT_AUTH_RESOURCE.AUTH_ID, CONSENT_AUTH_RESOURCE.CONSENT_ID, CONSENT_AUTH_RESOURCE.AUTH_TYPE," +
+		" CONSENT_AUTH_RESOURCE.USER_ID, CONSENT_AUTH_RESOURCE.AUTH_STATUS, CONSENT_AUTH_RESOURCE.UPDATED_TIME," +
+		" CONSENT_AUTH_RESOURCE.RESOURCES, CONSENT_AUTH_RESOURCE.ORG_ID, CONSENT_AUTH_RESOURCE.DELEGATOR_ID," +
+		" CONSENT_AUTH_RESOURCE.DELEGATE_ID" + fromClause +
+		" ORDER BY CONSENT_AUTH_RESOURCE.UPDATED_TIME DESC, CONSENT_AUTH_RESOURCE.AUTH_ID LIMIT ? OFFSET ?"
+	args = append(args, filters.Limit, filters.Offset)
+
+	rows, err := s.dbClient.QueryContext(ctx, dbmodel.DBQuery{ID: "SEARCH_AUTH_RESOURCES", Query: selectQuery, CrossTenant: crossTenant}, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	authResources := make([]model.AuthResource, 0, len(rows))
+	for _, row := range rows {
+		authResources = append(authResources, *mapToAuthResource(row))
+	}
+	return authResources, totalCount, nil
+}
+
+// auditStatusChange records the change of an auth resource to status, before the auth resource is
+// updated. Nothing is recorded when the status does not change.
+func auditStatusChange(tx dbmodel.TxInterface, authID, orgID, status string, actionTime int64) error {
+	_, err := tx.Exec(QueryCreateAuthStatusAuditFromCurrent.Query, utils.GenerateUUID(), status, actionTime, authID, orgID, status)
+	return err
+}
+
+// ReplaceUserID binds every auth resource of a user in an organization to another user ID within a
+// transaction
+func (s *store) ReplaceUserID(tx dbmodel.TxInterface, userID, newUserID, orgID string, updatedTime int64) error {
+	_, err := tx.Exec(QueryReplaceUserID.Query, newUserID, updatedTime, userID, orgID)
 	return err
 }
 
@@ -209,10 +397,10 @@ func (s *store) GetByConsentIDs(ctx context.Context, consentIDs []string, orgID
 	// Build dynamic query
 	query := dbmodel.DBQuery{
 		ID:    QueryGetAuthResourcesByConsentIDs.ID,
-		Query: fmt.Sprintf("SELECT AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, RESOURCES, ORG_ID FROM CONSENT_AUTH_RESOURCE WHERE CONSENT_ID IN (%s) AND ORG_ID = ?", placeholders),
+		Query: fmt.Sprintf("SELECT AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, RESOURCES, ORG_ID, DELEGATOR_ID, DELEGATE_ID FROM CONSENT_AUTH_RESOURCE WHERE CONSENT_ID IN (%s) AND ORG_ID = ?", placeholders),
 	}
 
-	results, err := s.dbClient.Query(query, args...)
+	results, err := s.dbClient.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -278,5 +466,22 @@ func mapToAuthResource(row map[string]interface{}) *model.AuthResource {
 		authResource.OrgID = string(v)
 	}
 
+	if v := getString(row, "delegator_id"); v != "" {
+		authResource.DelegatorID = &v
+	}
+	if v := getString(row, "delegate_id"); v != "" {
+		authResource.DelegateID = &v
+	}
+
 	return authResource
 }
+
+// getString reads a string column that may be returned as string or []byte
+func getString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/wso2/consent-management-api/internal/authresource/model"
 	"github.com/wso2/consent-management-api/internal/system/config"
@@ -27,6 +28,24 @@ func ValidateAuthResourceCreateRequest(req model.ConsentAuthResourceCreateReques
 		return err
 	}
 
+	return ValidateDelegation(req.DelegatorID, req.DelegateID)
+}
+
+// ValidateDelegation validates the parties of a delegated authorization. Both the delegator and the
+// delegate must be captured, and they must be different people.
+func ValidateDelegation(delegatorID, delegateID *string) error {
+	if delegatorID == nil && delegateID == nil {
+		return nil
+	}
+	if delegatorID == nil || strings.TrimSpace(*delegatorID) == "" {
+		return fmt.Errorf("delegatorId is required for a delegated authorization")
+	}
+	if delegateID == nil || strings.TrimSpace(*delegateID) == "" {
+		return fmt.Errorf("delegateId is required for a delegated authorization")
+	}
+	if *delegatorID == *delegateID {
+		return fmt.Errorf("delegatorId and delegateId must be different")
+	}
 	return nil
 }
 
@@ -43,9 +62,13 @@ func ValidateAuthStatus(status string) error {
 // ValidateAuthResourceUpdateRequest validates auth resource update request
 func ValidateAuthResourceUpdateRequest(req model.ConsentAuthResourceUpdateRequest) error {
 	// At least one field must be provided
-	if req.AuthStatus == "" && req.UserID == nil && req.Resources == nil {
+	if req.AuthStatus == "" && req.UserID == nil && req.Resources == nil &&
+		req.DelegatorID == nil && req.DelegateID == nil {
 		return fmt.Errorf("at least one field must be provided for update")
 	}
+	if err := ValidateDelegation(req.DelegatorID, req.DelegateID); err != nil {
+		return err
+	}
 
 	// Validate status if provided
 	if req.AuthStatus != "" {
@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/wso2/consent-management-api/internal/bootstrap/model"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// bootstrapHandler handles HTTP requests for declarative bundles
+type bootstrapHandler struct {
+	service BootstrapService
+}
+
+// newBootstrapHandler creates a new bootstrap handler
+func newBootstrapHandler(service BootstrapService) *bootstrapHandler {
+	return &bootstrapHandler{
+		service: service,
+	}
+}
+
+// apply handles POST /admin/bootstrap
+func (h *bootstrapHandler) apply(w http.ResponseWriter, r *http.Request) {
+	var opts model.ApplyOptions
+	for name, target := range map[string]*bool{"dryRun": &opts.DryRun, "prune": &opts.Prune} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, name+" must be true or false"))
+			return
+		}
+		*target = parsed
+	}
+
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "failed to read the bundle"))
+		return
+	}
+	bundle, err := model.Parse(content)
+	if err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	result := h.service.Apply(r.Context(), bundle, opts)
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,83 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/wso2/consent-management-api/internal/attributeschema"
+	"github.com/wso2/consent-management-api/internal/bootstrap/model"
+	"github.com/wso2/consent-management-api/internal/consentpurpose"
+	"github.com/wso2/consent-management-api/internal/organization"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+)
+
+// Initialize sets up the bootstrap module and registers routes. When a bundle file is configured it
+// is applied before the server starts; an unreadable bundle or a failed change is returned as an
+// error, so that a server is not started with a partial configuration.
+func Initialize(
+	mux *http.ServeMux,
+	organizations organization.OrganizationService,
+	purposes consentpurpose.ConsentPurposeService,
+	schemas attributeschema.AttributeSchemaService,
+) (BootstrapService, error) {
+	service := newBootstrapService(organizations, purposes, schemas)
+	handler := newBootstrapHandler(service)
+
+	registerRoutes(mux, handler)
+
+	cfg := config.Get().Bootstrap
+	if cfg.File == "" {
+		return service, nil
+	}
+	if err := applyFile(service, cfg); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// applyFile applies the configured bundle file, logging every change it makes
+func applyFile(service BootstrapService, cfg config.BootstrapConfig) error {
+	content, err := os.ReadFile(cfg.File)
+	if err != nil {
+		return fmt.Errorf("failed to read bootstrap bundle: %w", err)
+	}
+	bundle, err := model.Parse(content)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cfg.File, err)
+	}
+
+	result := service.Apply(context.Background(), bundle, model.ApplyOptions{Prune: cfg.Prune})
+	logger := log.GetLogger()
+	for _, change := range result.Changes {
+		if change.Action == model.ActionUnchanged || change.Action == model.ActionFailed {
+			continue
+		}
+		logger.Info("Bootstrap change applied",
+			log.String("kind", change.Kind),
+			log.String("org_id", change.OrgID),
+			log.String("name", change.Name),
+			log.String("action", change.Action))
+	}
+	if result.Summary.Failed > 0 {
+		return fmt.Errorf("%d changes of bootstrap bundle %s failed", result.Summary.Failed, cfg.File)
+	}
+	return nil
+}
+
+// registerRoutes registers the bootstrap routes. They are protected with admin basic auth.
+func registerRoutes(mux *http.ServeMux, handler *bootstrapHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"POST", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "X-Correlation-ID"},
+	}
+
+	// POST /api/v1/admin/bootstrap - Apply a bundle of organizations and purposes, with dryRun and prune
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/admin/bootstrap",
+		middleware.WithAdminAuth(handler.apply), corsOpts))
+}
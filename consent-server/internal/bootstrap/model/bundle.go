@@ -0,0 +1,154 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	attributemodel "github.com/wso2/consent-management-api/internal/attributeschema/model"
+	purposemodel "github.com/wso2/consent-management-api/internal/consentpurpose/model"
+	orgmodel "github.com/wso2/consent-management-api/internal/organization/model"
+)
+
+// Bundle declares organizations together with their consent configuration. It is written in YAML
+// or JSON with the field names of the API request bodies.
+type Bundle struct {
+	Organizations []Organization `json:"organizations"`
+}
+
+// Organization is an organization of a bundle. Its settings, including the allowed consent types,
+// are those of POST /orgs; without a name the organization is not registered, and only its
+// attribute schema and purposes are applied.
+type Organization struct {
+	orgmodel.OrganizationRequest
+	// AttributeSchema lists the consent attributes the organization accepts. When it is left out the
+	// schema is not managed by the bundle, unless the bundle is pruned.
+	AttributeSchema []attributemodel.AttributeDefinition `json:"attributeSchema,omitempty"`
+	// Purposes are the consent purposes of the organization, identified by name
+	Purposes []purposemodel.CreateRequest `json:"purposes,omitempty"`
+}
+
+// Parse reads a bundle written in YAML or JSON. Unknown fields are rejected, so that a misspelt
+// setting is not silently left out of the reconciliation.
+func Parse(content []byte) (*Bundle, error) {
+	var raw any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	// The YAML document is converted to JSON to decode it with the JSON names of the API models
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	var bundle Bundle
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// Validate checks that organizations and the purposes of each organization are declared once.
+// The declarations themselves are validated when they are applied.
+func (b *Bundle) Validate() error {
+	orgIDs := make(map[string]bool)
+	for i, org := range b.Organizations {
+		if strings.TrimSpace(org.OrgID) == "" {
+			return fmt.Errorf("organizations[%d].orgId is required", i)
+		}
+		if orgIDs[org.OrgID] {
+			return fmt.Errorf("organization '%s' is declared more than once", org.OrgID)
+		}
+		orgIDs[org.OrgID] = true
+
+		names := make(map[string]bool)
+		for j, purpose := range org.Purposes {
+			if strings.TrimSpace(purpose.Name) == "" {
+				return fmt.Errorf("organizations[%d].purposes[%d].name is required", i, j)
+			}
+			if names[purpose.Name] {
+				return fmt.Errorf("purpose '%s' of organization '%s' is declared more than once", purpose.Name, org.OrgID)
+			}
+			names[purpose.Name] = true
+		}
+	}
+	return nil
+}
+
+// Kinds of resources reconciled from a bundle
+const (
+	KindOrganization    = "organization"
+	KindAttributeSchema = "attributeSchema"
+	KindPurpose         = "purpose"
+)
+
+// Actions taken on a resource to match the bundle
+const (
+	ActionCreated   = "created"
+	ActionUpdated   = "updated"
+	ActionDeleted   = "deleted"
+	ActionUnchanged = "unchanged"
+	ActionFailed    = "failed"
+)
+
+// ApplyOptions control how a bundle is applied
+type ApplyOptions struct {
+	// DryRun reports the changes without making them
+	DryRun bool
+	// Prune deletes what the bundle does not declare: registered organizations that are not in the
+	// bundle, and the purposes and attribute schema of a bundled organization that it leaves out
+	Prune bool
+}
+
+// Change is the action taken on one resource, or planned on a dry run
+type Change struct {
+	Kind   string `json:"kind"`
+	OrgID  string `json:"orgId"`
+	Name   string `json:"name,omitempty"` // Name of a purpose
+	Action string `json:"action"`
+	// Planned is the action a failed change attempted
+	Planned string `json:"planned,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ApplySummary counts the changes of an apply by action
+type ApplySummary struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Deleted   int `json:"deleted"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+// ApplyResult reports the changes made to match a bundle, in the order they were made
+type ApplyResult struct {
+	DryRun  bool         `json:"dryRun"`
+	Prune   bool         `json:"prune"`
+	Changes []Change     `json:"changes"`
+	Summary ApplySummary `json:"summary"`
+}
+
+// Add records a change and counts it in the summary
+func (r *ApplyResult) Add(change Change) {
+	r.Changes = append(r.Changes, change)
+	switch change.Action {
+	case ActionCreated:
+		r.Summary.Created++
+	case ActionUpdated:
+		r.Summary.Updated++
+	case ActionDeleted:
+		r.Summary.Deleted++
+	case ActionUnchanged:
+		r.Summary.Unchanged++
+	case ActionFailed:
+		r.Summary.Failed++
+	}
+}
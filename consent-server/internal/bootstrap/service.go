@@ -0,0 +1,310 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/wso2/consent-management-api/internal/attributeschema"
+	attributemodel "github.com/wso2/consent-management-api/internal/attributeschema/model"
+	"github.com/wso2/consent-management-api/internal/bootstrap/model"
+	"github.com/wso2/consent-management-api/internal/consentpurpose"
+	purposemodel "github.com/wso2/consent-management-api/internal/consentpurpose/model"
+	"github.com/wso2/consent-management-api/internal/organization"
+	orgmodel "github.com/wso2/consent-management-api/internal/organization/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// listPageSize is the page size used to read the registered organizations and purposes
+const listPageSize = 100
+
+// BootstrapService defines the exported service interface for declarative bundles
+type BootstrapService interface {
+	Apply(ctx context.Context, bundle *model.Bundle, opts model.ApplyOptions) *model.ApplyResult
+}
+
+// bootstrapService reconciles bundles through the services of the modules owning the resources,
+// so declared resources are validated exactly as API requests are
+type bootstrapService struct {
+	organizations organization.OrganizationService
+	purposes      consentpurpose.ConsentPurposeService
+	schemas       attributeschema.AttributeSchemaService
+}
+
+// newBootstrapService creates a new bootstrap service
+func newBootstrapService(
+	organizations organization.OrganizationService,
+	purposes consentpurpose.ConsentPurposeService,
+	schemas attributeschema.AttributeSchemaService,
+) BootstrapService {
+	return &bootstrapService{
+		organizations: organizations,
+		purposes:      purposes,
+		schemas:       schemas,
+	}
+}
+
+// Apply creates and updates the resources of a bundle until the database matches it. Failures do
+// not stop the apply: every resource is attempted and failures are reported in the result. The
+// purposes and schema of an organization that could not be created are not attempted. A dry run
+// compares the bundle with the database without validating the declarations.
+func (s *bootstrapService) Apply(ctx context.Context, bundle *model.Bundle, opts model.ApplyOptions) *model.ApplyResult {
+	result := &model.ApplyResult{DryRun: opts.DryRun, Prune: opts.Prune, Changes: []model.Change{}}
+
+	declared := make(map[string]bool, len(bundle.Organizations))
+	for _, org := range bundle.Organizations {
+		declared[org.OrgID] = true
+		if !s.applyOrganization(ctx, org, opts, result) {
+			continue
+		}
+		s.applyAttributeSchema(ctx, org, opts, result)
+		s.applyPurposes(ctx, org, opts, result)
+	}
+	if opts.Prune {
+		s.pruneOrganizations(ctx, declared, opts, result)
+	}
+
+	log.GetLogger().WithContext(ctx).Info("Bootstrap bundle applied",
+		log.Bool("dry_run", opts.DryRun),
+		log.Bool("prune", opts.Prune),
+		log.Int("created", result.Summary.Created),
+		log.Int("updated", result.Summary.Updated),
+		log.Int("deleted", result.Summary.Deleted),
+		log.Int("unchanged", result.Summary.Unchanged),
+		log.Int("failed", result.Summary.Failed))
+	return result
+}
+
+// applyOrganization creates or updates the organization record. It reports whether the purposes
+// and schema of the organization can be applied: organizations need no registration, so an entry
+// without a name only declares purposes and a schema.
+func (s *bootstrapService) applyOrganization(ctx context.Context, org model.Organization, opts model.ApplyOptions, result *model.ApplyResult) bool {
+	if org.Name == "" {
+		return true
+	}
+	change := model.Change{Kind: model.KindOrganization, OrgID: org.OrgID}
+
+	existing, serviceErr := s.organizations.GetOrganization(ctx, org.OrgID)
+	switch {
+	case serviceErr != nil && serviceErr.Code == serviceerror.ResourceNotFoundError.Code:
+		change.Action = model.ActionCreated
+		if !opts.DryRun {
+			_, serviceErr = s.organizations.CreateOrganization(ctx, org.OrganizationRequest)
+		}
+	case serviceErr != nil:
+	case sameJSON(org.OrganizationRequest, organizationRequest(existing)):
+		change.Action = model.ActionUnchanged
+	default:
+		change.Action = model.ActionUpdated
+		if !opts.DryRun {
+			_, serviceErr = s.organizations.UpdateOrganization(ctx, org.OrgID, org.OrganizationRequest)
+		}
+	}
+
+	s.record(ctx, result, change, serviceErr)
+	return serviceErr == nil
+}
+
+// applyAttributeSchema replaces the attribute schema of an organization when it differs from the
+// bundle, and deletes it on prune when the bundle declares none
+func (s *bootstrapService) applyAttributeSchema(ctx context.Context, org model.Organization, opts model.ApplyOptions, result *model.ApplyResult) {
+	if org.AttributeSchema == nil && !opts.Prune {
+		return
+	}
+	change := model.Change{Kind: model.KindAttributeSchema, OrgID: org.OrgID}
+
+	existing, serviceErr := s.schemas.GetSchema(ctx, org.OrgID)
+	notFound := serviceErr != nil && serviceErr.Code == serviceerror.ResourceNotFoundError.Code
+	if serviceErr != nil && !notFound {
+		s.record(ctx, result, change, serviceErr)
+		return
+	}
+	serviceErr = nil
+
+	request := attributemodel.AttributeSchemaRequest{Attributes: org.AttributeSchema}
+	switch {
+	case org.AttributeSchema == nil && notFound:
+		return
+	case org.AttributeSchema == nil:
+		change.Action = model.ActionDeleted
+		if !opts.DryRun {
+			serviceErr = s.schemas.DeleteSchema(ctx, org.OrgID)
+		}
+	case !notFound && sameJSON(request.Attributes, existing.Attributes):
+		change.Action = model.ActionUnchanged
+	default:
+		change.Action = model.ActionUpdated
+		if notFound {
+			change.Action = model.ActionCreated
+		}
+		if !opts.DryRun {
+			_, serviceErr = s.schemas.PutSchema(ctx, request, org.OrgID)
+		}
+	}
+	s.record(ctx, result, change, serviceErr)
+}
+
+// applyPurposes creates the purposes of an organization that do not exist, updates those that
+// differ from the bundle and, on prune, deletes the purposes the bundle leaves out. Purposes still
+// used by consents cannot be deleted and are reported as failed.
+func (s *bootstrapService) applyPurposes(ctx context.Context, org model.Organization, opts model.ApplyOptions, result *model.ApplyResult) {
+	existing, serviceErr := s.listPurposes(ctx, org.OrgID)
+	if serviceErr != nil {
+		s.record(ctx, result, model.Change{Kind: model.KindPurpose, OrgID: org.OrgID}, serviceErr)
+		return
+	}
+	byName := make(map[string]purposemodel.ConsentPurpose, len(existing))
+	for _, purpose := range existing {
+		byName[purpose.Name] = purpose
+	}
+
+	declared := make(map[string]bool, len(org.Purposes))
+	for _, purpose := range org.Purposes {
+		declared[purpose.Name] = true
+		change := model.Change{Kind: model.KindPurpose, OrgID: org.OrgID, Name: purpose.Name}
+		request := purposeUpdateRequest(purpose)
+
+		var serviceErr *serviceerror.ServiceError
+		current, exists := byName[purpose.Name]
+		switch {
+		case !exists:
+			change.Action = model.ActionCreated
+			if !opts.DryRun {
+				_, serviceErr = s.purposes.CreatePurpose(ctx, purpose, org.OrgID)
+			}
+		case sameJSON(request, purposeUpdateRequest(purposemodel.CreateRequest{
+			Name: current.Name, Description: stringValue(current.Description), Type: current.Type, Attributes: current.Attributes,
+		})):
+			change.Action = model.ActionUnchanged
+		default:
+			change.Action = model.ActionUpdated
+			if !opts.DryRun {
+				_, serviceErr = s.purposes.UpdatePurpose(ctx, current.ID, request, org.OrgID)
+			}
+		}
+		s.record(ctx, result, change, serviceErr)
+	}
+
+	if !opts.Prune {
+		return
+	}
+	for _, purpose := range existing {
+		if declared[purpose.Name] {
+			continue
+		}
+		change := model.Change{Kind: model.KindPurpose, OrgID: org.OrgID, Name: purpose.Name, Action: model.ActionDeleted}
+		var serviceErr *serviceerror.ServiceError
+		if !opts.DryRun {
+			serviceErr = s.purposes.DeletePurpose(ctx, purpose.ID, org.OrgID)
+		}
+		s.record(ctx, result, change, serviceErr)
+	}
+}
+
+// pruneOrganizations deletes the registered organizations the bundle does not declare. Their
+// consents and purposes are kept, as when an organization is deleted through the API.
+func (s *bootstrapService) pruneOrganizations(ctx context.Context, declared map[string]bool, opts model.ApplyOptions, result *model.ApplyResult) {
+	var undeclared []string
+	for offset := 0; ; offset += listPageSize {
+		page, serviceErr := s.organizations.ListOrganizations(ctx, listPageSize, offset)
+		if serviceErr != nil {
+			s.record(ctx, result, model.Change{Kind: model.KindOrganization, Action: model.ActionDeleted}, serviceErr)
+			return
+		}
+		for _, org := range page.Data {
+			if !declared[org.OrgID] {
+				undeclared = append(undeclared, org.OrgID)
+			}
+		}
+		if offset+len(page.Data) >= page.Metadata.Total || len(page.Data) == 0 {
+			break
+		}
+	}
+
+	// Deleting while paging would shift the pages, so organizations are deleted once listed
+	for _, orgID := range undeclared {
+		var serviceErr *serviceerror.ServiceError
+		if !opts.DryRun {
+			serviceErr = s.organizations.DeleteOrganization(ctx, orgID)
+		}
+		s.record(ctx, result, model.Change{Kind: model.KindOrganization, OrgID: orgID, Action: model.ActionDeleted}, serviceErr)
+	}
+}
+
+// listPurposes reads every purpose of an organization
+func (s *bootstrapService) listPurposes(ctx context.Context, orgID string) ([]purposemodel.ConsentPurpose, *serviceerror.ServiceError) {
+	var purposes []purposemodel.ConsentPurpose
+	for offset := 0; ; offset += listPageSize {
+		page, total, serviceErr := s.purposes.ListPurposes(ctx, purposemodel.PurposeSearchFilters{
+			OrgID:  orgID,
+			Limit:  listPageSize,
+			Offset: offset,
+		})
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+		purposes = append(purposes, page...)
+		if offset+len(page) >= total || len(page) == 0 {
+			return purposes, nil
+		}
+	}
+}
+
+// record adds a change to the result, as failed when serviceErr is set
+func (s *bootstrapService) record(ctx context.Context, result *model.ApplyResult, change model.Change, serviceErr *serviceerror.ServiceError) {
+	if serviceErr != nil {
+		change.Planned = change.Action
+		change.Action = model.ActionFailed
+		change.Error = serviceErr.Description
+		log.GetLogger().WithContext(ctx).Warn("Failed to apply bootstrap bundle entry",
+			log.String("kind", change.Kind),
+			log.String("org_id", change.OrgID),
+			log.String("name", change.Name),
+			log.String("action", change.Planned),
+			log.String("error", serviceErr.Description))
+	}
+	result.Add(change)
+}
+
+// organizationRequest converts a stored organization to the request that would recreate it
+func organizationRequest(org *orgmodel.Organization) orgmodel.OrganizationRequest {
+	return orgmodel.OrganizationRequest{
+		OrgID:                  org.OrgID,
+		Name:                   org.Name,
+		StatusMappings:         org.StatusMappings,
+		WebhookURLs:            org.WebhookURLs,
+		RetentionDays:          org.RetentionDays,
+		DefaultValiditySeconds: org.DefaultValiditySeconds,
+		AllowedConsentTypes:    org.AllowedConsentTypes,
+		ExpiryNoticeDays:       org.ExpiryNoticeDays,
+		TerminalRetentionDays:  org.TerminalRetentionDays,
+	}
+}
+
+// purposeUpdateRequest converts a declared purpose to the request replacing a stored one
+func purposeUpdateRequest(purpose purposemodel.CreateRequest) purposemodel.UpdateRequest {
+	request := purposemodel.UpdateRequest{
+		Name:       purpose.Name,
+		Type:       purpose.Type,
+		Attributes: purpose.Attributes,
+	}
+	if purpose.Description != "" {
+		request.Description = &purpose.Description
+	}
+	return request
+}
+
+// sameJSON compares two values by their JSON encoding, so that empty and missing lists are equal
+func sameJSON(a, b any) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,121 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/attributeschema"
+	attributemodel "github.com/wso2/consent-management-api/internal/attributeschema/model"
+	"github.com/wso2/consent-management-api/internal/bootstrap/model"
+	"github.com/wso2/consent-management-api/internal/consentpurpose"
+	purposemodel "github.com/wso2/consent-management-api/internal/consentpurpose/model"
+	"github.com/wso2/consent-management-api/internal/organization"
+	orgmodel "github.com/wso2/consent-management-api/internal/organization/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+)
+
+// fakeOrganizations keeps organizations in memory
+type fakeOrganizations struct {
+	organization.OrganizationService
+	orgs map[string]orgmodel.Organization
+}
+
+func (f *fakeOrganizations) GetOrganization(ctx context.Context, orgID string) (*orgmodel.Organization, *serviceerror.ServiceError) {
+	org, ok := f.orgs[orgID]
+	if !ok {
+		return nil, &serviceerror.ResourceNotFoundError
+	}
+	return &org, nil
+}
+
+func (f *fakeOrganizations) CreateOrganization(ctx context.Context, req orgmodel.OrganizationRequest) (*orgmodel.Organization, *serviceerror.ServiceError) {
+	f.orgs[req.OrgID] = orgmodel.Organization{OrgID: req.OrgID, Name: req.Name}
+	org := f.orgs[req.OrgID]
+	return &org, nil
+}
+
+func (f *fakeOrganizations) ListOrganizations(ctx context.Context, limit, offset int) (*orgmodel.OrganizationListResponse, *serviceerror.ServiceError) {
+	response := &orgmodel.OrganizationListResponse{Data: []orgmodel.Organization{}}
+	for _, org := range f.orgs {
+		response.Data = append(response.Data, org)
+	}
+	response.Metadata.Total = len(response.Data)
+	return response, nil
+}
+
+func (f *fakeOrganizations) DeleteOrganization(ctx context.Context, orgID string) *serviceerror.ServiceError {
+	delete(f.orgs, orgID)
+	return nil
+}
+
+// fakePurposes keeps the purposes of one organization in memory, by ID
+type fakePurposes struct {
+	consentpurpose.ConsentPurposeService
+	purposes map[string]purposemodel.ConsentPurpose
+}
+
+func (f *fakePurposes) ListPurposes(ctx context.Context, filters purposemodel.PurposeSearchFilters) ([]purposemodel.ConsentPurpose, int, *serviceerror.ServiceError) {
+	var purposes []purposemodel.ConsentPurpose
+	for _, purpose := range f.purposes {
+		purposes = append(purposes, purpose)
+	}
+	return purposes, len(purposes), nil
+}
+
+func (f *fakePurposes) CreatePurpose(ctx context.Context, req purposemodel.CreateRequest, orgID string) (*purposemodel.ConsentPurpose, *serviceerror.ServiceError) {
+	purpose := purposemodel.ConsentPurpose{ID: "id-" + req.Name, Name: req.Name, Type: req.Type, OrgID: orgID}
+	f.purposes[purpose.ID] = purpose
+	return &purpose, nil
+}
+
+func (f *fakePurposes) DeletePurpose(ctx context.Context, purposeID, orgID string) *serviceerror.ServiceError {
+	delete(f.purposes, purposeID)
+	return nil
+}
+
+// fakeSchemas reports that no organization has an attribute schema
+type fakeSchemas struct {
+	attributeschema.AttributeSchemaService
+}
+
+func (f *fakeSchemas) GetSchema(ctx context.Context, orgID string) (*attributemodel.AttributeSchema, *serviceerror.ServiceError) {
+	return nil, &serviceerror.ResourceNotFoundError
+}
+
+// TestApply_PrunesOnlyWhenAsked checks that an apply creates what the bundle declares and leaves
+// undeclared resources alone, that applying it again changes nothing, and that a pruned apply
+// deletes the undeclared organizations and purposes
+func TestApply_PrunesOnlyWhenAsked(t *testing.T) {
+	orgs := &fakeOrganizations{orgs: map[string]orgmodel.Organization{"legacy": {OrgID: "legacy", Name: "Legacy"}}}
+	purposes := &fakePurposes{purposes: map[string]purposemodel.ConsentPurpose{
+		"id-old": {ID: "id-old", Name: "old", Type: "string", OrgID: "acme"},
+	}}
+	service := newBootstrapService(orgs, purposes, &fakeSchemas{})
+
+	bundle := &model.Bundle{Organizations: []model.Organization{{
+		OrganizationRequest: orgmodel.OrganizationRequest{OrgID: "acme", Name: "Acme"},
+		Purposes:            []purposemodel.CreateRequest{{Name: "marketing", Type: "string"}},
+	}}}
+
+	result := service.Apply(context.Background(), bundle, model.ApplyOptions{})
+	if result.Summary != (model.ApplySummary{Created: 2}) {
+		t.Fatalf("expected the organization and purpose to be created, got %+v", result.Changes)
+	}
+	result = service.Apply(context.Background(), bundle, model.ApplyOptions{})
+	if result.Summary != (model.ApplySummary{Unchanged: 2}) {
+		t.Fatalf("expected a second apply to change nothing, got %+v", result.Changes)
+	}
+
+	result = service.Apply(context.Background(), bundle, model.ApplyOptions{Prune: true, DryRun: true})
+	if result.Summary != (model.ApplySummary{Deleted: 2, Unchanged: 2}) || len(orgs.orgs) != 2 || len(purposes.purposes) != 2 {
+		t.Fatalf("expected a dry run to plan two deletions and make none, got %+v", result.Changes)
+	}
+
+	result = service.Apply(context.Background(), bundle, model.ApplyOptions{Prune: true})
+	if result.Summary != (model.ApplySummary{Deleted: 2, Unchanged: 2}) {
+		t.Fatalf("expected the legacy organization and old purpose to be deleted, got %+v", result.Changes)
+	}
+	if _, ok := orgs.orgs["legacy"]; ok || len(purposes.purposes) != 1 || purposes.purposes["id-marketing"].Name != "marketing" {
+		t.Errorf("unexpected resources after prune: %v %v", orgs.orgs, purposes.purposes)
+	}
+}
@@ -0,0 +1,119 @@
+package consent
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+)
+
+// exportCSVHeader lists the columns written to CSV consent exports
+var exportCSVHeader = []string{
+	"consentId", "clientId", "consentType", "currentStatus", "createdTime", "updatedTime",
+	"validityTime", "frequency", "recurringIndicator", "dataAccessValidityDuration",
+	"purposes", "approvedPurposes", "userIds", "authorizationStatuses", "attributes",
+}
+
+// exportWriter serializes consent export records to a stream
+type exportWriter struct {
+	format string
+	out    io.Writer
+	csv    *csv.Writer
+}
+
+// newExportWriter creates a writer for the given export format. CSV exports start with a header row.
+func newExportWriter(format string, out io.Writer) (*exportWriter, error) {
+	w := &exportWriter{format: format, out: out}
+	switch format {
+	case model.ExportFormatCSV:
+		w.csv = csv.NewWriter(out)
+		if err := w.csv.Write(exportCSVHeader); err != nil {
+			return nil, err
+		}
+	case model.ExportFormatNDJSON:
+	default:
+		return nil, fmt.Errorf("unsupported export format '%s', must be one of: csv, ndjson", format)
+	}
+	return w, nil
+}
+
+// contentType returns the media type of the export
+func (w *exportWriter) contentType() string {
+	if w.format == model.ExportFormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Write writes a record. CSV output is buffered until Flush.
+func (w *exportWriter) Write(record model.ConsentExportRecord) error {
+	if w.format == model.ExportFormatNDJSON {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		_, err = w.out.Write(append(line, '\n'))
+		return err
+	}
+
+	attributes := ""
+	if len(record.Attributes) > 0 {
+		attrJSON, err := json.Marshal(record.Attributes)
+		if err != nil {
+			return err
+		}
+		attributes = string(attrJSON)
+	}
+
+	return w.csv.Write([]string{
+		record.ConsentID,
+		record.ClientID,
+		record.ConsentType,
+		record.CurrentStatus,
+		strconv.FormatInt(record.CreatedTime, 10),
+		strconv.FormatInt(record.UpdatedTime, 10),
+		formatInt64Ptr(record.ValidityTime),
+		formatIntPtr(record.Frequency),
+		formatBoolPtr(record.RecurringIndicator),
+		formatInt64Ptr(record.DataAccessValidityDuration),
+		strings.Join(record.Purposes, ";"),
+		strings.Join(record.ApprovedPurposes, ";"),
+		strings.Join(record.UserIDs, ";"),
+		strings.Join(record.AuthorizationStatuses, ";"),
+		attributes,
+	})
+}
+
+// Flush writes buffered CSV output
+func (w *exportWriter) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func formatInt64Ptr(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func formatIntPtr(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatBoolPtr(v *bool) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatBool(*v)
+}
@@ -0,0 +1,318 @@
+// Package filter parses the SCIM-style filter expressions accepted by the consent search
+// endpoints, such as status eq "ACTIVE" and (createdTime gt 1700000000000 or attributes.channel eq "web").
+// The parsed expression is translated into SQL by the consent store.
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxLength limits the length of a filter expression
+	MaxLength = 1024
+	// MaxComparisons limits the number of comparisons of a filter expression
+	MaxComparisons = 20
+)
+
+// Operator compares an attribute with a value
+type Operator string
+
+// Supported comparison operators
+const (
+	OperatorEq Operator = "eq"
+	OperatorNe Operator = "ne"
+	OperatorGt Operator = "gt"
+	OperatorLt Operator = "lt"
+)
+
+// Logical operators combining two expressions
+const (
+	LogicalAnd = "and"
+	LogicalOr  = "or"
+)
+
+// Filterable consent attributes
+const (
+	AttributeStatus      = "status"
+	AttributeType        = "type"
+	AttributeClientID    = "clientId"
+	AttributeCreatedTime = "createdTime"
+	// AttributeAttributes compares a consent attribute, named by the key following the prefix
+	AttributeAttributes = "attributes"
+)
+
+// Expression is a parsed filter, either a *Comparison or a *Logical
+type Expression interface {
+	expression()
+}
+
+// Comparison compares a consent attribute with a value. Value is an int64 for createdTime and a
+// string otherwise. Key is the consent attribute key of an attributes comparison.
+type Comparison struct {
+	Attribute string   `json:"attribute"`
+	Key       string   `json:"key,omitempty"`
+	Operator  Operator `json:"operator"`
+	Value     any      `json:"value"`
+}
+
+// Logical combines two expressions with and or or
+type Logical struct {
+	Op    string     `json:"op"`
+	Left  Expression `json:"left"`
+	Right Expression `json:"right"`
+}
+
+func (*Comparison) expression() {}
+func (*Logical) expression()    {}
+
+// Parse parses a filter expression. Operators, and, or and attribute names are case-insensitive;
+// attribute keys and values are not. and binds tighter than or, and parentheses group expressions.
+func Parse(expr string) (Expression, error) {
+	if len(expr) > MaxLength {
+		return nil, fmt.Errorf("filter must be at most %d characters", MaxLength)
+	}
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("filter is empty")
+	}
+
+	p := &parser{tokens: tokens}
+	result, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected '%s' at position %d of filter", p.tokens[p.pos].text, p.tokens[p.pos].offset+1)
+	}
+	return result, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenNumber
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	value  string // unquoted value of a string token
+	offset int
+}
+
+// tokenize splits a filter expression into words, quoted strings, numbers and parentheses
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", offset: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", offset: i})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(expr) && expr[end] != '"' {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d of filter", i+1)
+			}
+			var value string
+			if err := json.Unmarshal([]byte(expr[i:end+1]), &value); err != nil {
+				return nil, fmt.Errorf("invalid string at position %d of filter", i+1)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: expr[i : end+1], value: value, offset: i})
+			i = end + 1
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(expr) && expr[end] >= '0' && expr[end] <= '9' {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[i:end], offset: i})
+			i = end
+		case isWordByte(c):
+			end := i + 1
+			for end < len(expr) && isWordByte(expr[end]) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: expr[i:end], offset: i})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at position %d of filter", c, i+1)
+		}
+	}
+	return tokens, nil
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '.' || c == '_' || c == '-' || c == ':'
+}
+
+type parser struct {
+	tokens      []token
+	pos         int
+	comparisons int
+}
+
+// parseOr parses expressions joined by or
+func (p *parser) parseOr() (Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord(LogicalOr) {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: LogicalOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses expressions joined by and
+func (p *parser) parseAnd() (Expression, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord(LogicalAnd) {
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: LogicalAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseFactor parses a parenthesized expression or a comparison
+func (p *parser) parseFactor() (Expression, error) {
+	tok, err := p.next("an attribute or '('")
+	if err != nil {
+		return nil, err
+	}
+	if tok.kind == tokenLeftParen {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, err := p.next("')'")
+		if err != nil {
+			return nil, err
+		}
+		if closing.kind != tokenRightParen {
+			return nil, unexpected(closing, "')'")
+		}
+		return inner, nil
+	}
+	if tok.kind != tokenWord {
+		return nil, unexpected(tok, "an attribute")
+	}
+	return p.parseComparison(tok)
+}
+
+// parseComparison parses the operator and value following an attribute
+func (p *parser) parseComparison(attributeToken token) (Expression, error) {
+	p.comparisons++
+	if p.comparisons > MaxComparisons {
+		return nil, fmt.Errorf("filter must have at most %d comparisons", MaxComparisons)
+	}
+
+	comparison := &Comparison{}
+	numeric := false
+	name, key, hasKey := strings.Cut(attributeToken.text, ".")
+	switch {
+	case hasKey && strings.EqualFold(name, AttributeAttributes) && key != "":
+		comparison.Attribute, comparison.Key = AttributeAttributes, key
+	case !hasKey && strings.EqualFold(name, AttributeStatus):
+		comparison.Attribute = AttributeStatus
+	case !hasKey && strings.EqualFold(name, AttributeType):
+		comparison.Attribute = AttributeType
+	case !hasKey && strings.EqualFold(name, AttributeClientID):
+		comparison.Attribute = AttributeClientID
+	case !hasKey && strings.EqualFold(name, AttributeCreatedTime):
+		comparison.Attribute, numeric = AttributeCreatedTime, true
+	default:
+		return nil, fmt.Errorf("unsupported filter attribute '%s'", attributeToken.text)
+	}
+
+	opToken, err := p.next("an operator")
+	if err != nil {
+		return nil, err
+	}
+	comparison.Operator = Operator(strings.ToLower(opToken.text))
+	switch {
+	case opToken.kind != tokenWord:
+		return nil, unexpected(opToken, "an operator")
+	case comparison.Operator == OperatorEq || comparison.Operator == OperatorNe:
+	case (comparison.Operator == OperatorGt || comparison.Operator == OperatorLt) && numeric:
+	case comparison.Operator == OperatorGt || comparison.Operator == OperatorLt:
+		return nil, fmt.Errorf("operator '%s' is not supported for %s", opToken.text, attributeToken.text)
+	default:
+		return nil, fmt.Errorf("unsupported filter operator '%s'", opToken.text)
+	}
+
+	valueToken, err := p.next("a value")
+	if err != nil {
+		return nil, err
+	}
+	if numeric {
+		if valueToken.kind != tokenNumber {
+			return nil, fmt.Errorf("%s must be compared with a number", attributeToken.text)
+		}
+		value, err := strconv.ParseInt(valueToken.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at position %d of filter", valueToken.text, valueToken.offset+1)
+		}
+		comparison.Value = value
+	} else {
+		if valueToken.kind != tokenString {
+			return nil, fmt.Errorf("%s must be compared with a quoted string", attributeToken.text)
+		}
+		comparison.Value = valueToken.value
+	}
+	return comparison, nil
+}
+
+// next consumes the next token, failing with what was expected at the end of the expression
+func (p *parser) next(expected string) (token, error) {
+	if p.pos >= len(p.tokens) {
+		return token{}, fmt.Errorf("filter ended where %s was expected", expected)
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	return tok, nil
+}
+
+// acceptWord consumes the next token when it is the given case-insensitive word
+func (p *parser) acceptWord(word string) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenWord && strings.EqualFold(p.tokens[p.pos].text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func unexpected(tok token, expected string) error {
+	return fmt.Errorf("unexpected '%s' at position %d of filter, expected %s", tok.text, tok.offset+1, expected)
+}
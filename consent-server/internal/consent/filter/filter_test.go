@@ -0,0 +1,79 @@
+package filter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestParse_Precedence checks that and binds tighter than or and that parentheses group
+func TestParse_Precedence(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{
+			"and before or",
+			`status eq "ACTIVE" or type eq "accounts" and clientId ne "c1"`,
+			`{"op":"or","left":{"attribute":"status","operator":"eq","value":"ACTIVE"},` +
+				`"right":{"op":"and","left":{"attribute":"type","operator":"eq","value":"accounts"},` +
+				`"right":{"attribute":"clientId","operator":"ne","value":"c1"}}}`,
+		},
+		{
+			"parentheses",
+			`(status eq "ACTIVE" OR status eq "REVOKED") AND createdTime GT 1700000000000`,
+			`{"op":"and","left":{"op":"or","left":{"attribute":"status","operator":"eq","value":"ACTIVE"},` +
+				`"right":{"attribute":"status","operator":"eq","value":"REVOKED"}},` +
+				`"right":{"attribute":"createdTime","operator":"gt","value":1700000000000}}`,
+		},
+		{
+			"attribute key and escaped value",
+			`Attributes.channelId eq "we\"b"`,
+			`{"attribute":"attributes","key":"channelId","operator":"eq","value":"we\"b"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			got, _ := json.Marshal(expr)
+			if string(got) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestParse_Rejects checks that malformed and unsupported expressions are rejected
+func TestParse_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"empty", "  "},
+		{"unknown attribute", `userId eq "u1"`},
+		{"unknown operator", `status co "ACT"`},
+		{"ordering a string attribute", `clientId gt "c1"`},
+		{"unquoted string", `status eq ACTIVE`},
+		{"quoted number", `createdTime lt "1"`},
+		{"missing value", `status eq`},
+		{"unbalanced parentheses", `(status eq "ACTIVE"`},
+		{"trailing token", `status eq "ACTIVE")`},
+		{"dangling and", `status eq "ACTIVE" and`},
+		{"unterminated string", `status eq "ACTIVE`},
+		{"empty attribute key", `attributes. eq "x"`},
+		{"sql", `status eq "x"; DROP TABLE CONSENT`},
+		{"too long", `status eq "` + strings.Repeat("a", MaxLength) + `"`},
+		{"too many comparisons", strings.Repeat(`status eq "A" or `, MaxComparisons) + `status eq "A"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if expr, err := Parse(tt.expr); err == nil {
+				t.Errorf("expected an error, got %+v", expr)
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package consent
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/wso2/consent-management-api/internal/consent/filter"
 	"github.com/wso2/consent-management-api/internal/consent/model"
 	"github.com/wso2/consent-management-api/internal/system/constants"
 	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
@@ -69,17 +74,66 @@ func (h *consentHandler) getConsent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	consent, serviceErr := h.service.GetConsent(ctx, consentID, orgID)
+	// fields and include select the returned fields and the related data that is loaded;
+	// include=children returns the consent with its child consents as a tree
+	selection, err := model.ParseResponseSelection(r.URL.Query().Get("fields"), r.URL.Query().Get("include"),
+		model.ConsentAPIResponse{}, model.IncludeAuthorizations, model.IncludePurposes, model.IncludeAttributes,
+		model.IncludeTags, model.IncludeHistory, model.IncludeChildren)
+	if err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	consent, serviceErr := h.service.GetConsentIncluding(ctx, consentID, orgID, selection.Includes)
 	if serviceErr != nil {
 		utils.SendError(w, r, serviceErr)
 		return
 	}
 
-	apiResponse := consent.ToAPIResponse()
+	// Polling clients send the ETag back in If-None-Match and get a 304 until the consent changes
+	etag := consent.ETag(r.URL.Query().Get("fields") + "|" + r.URL.Query().Get("include"))
+	w.Header().Set(constants.HeaderETag, etag)
+	if utils.IfNoneMatch(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	apiResponse, err := selection.Apply(consent.ToAPIResponse())
+	if err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InternalServerError, err.Error()))
+		return
+	}
 	w.Header().Set(constants.HeaderContentType, "application/json")
 	json.NewEncoder(w).Encode(apiResponse)
 }
 
+// getConsentsBatch handles POST /consents/get-batch
+func (h *consentHandler) getConsentsBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Organization ID is required"))
+		return
+	}
+
+	var req model.ConsentBatchGetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.GetConsents(ctx, req.ConsentIDs, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
 // listConsents handles GET /consents
 func (h *consentHandler) listConsents(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -107,43 +161,192 @@ func (h *consentHandler) listConsents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Build search filters
+	filters, serviceErr := parseSearchFilters(r, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+	filters.Limit = limit
+	filters.Offset = offset
+
+	// Parse includeTotal (defaults to true); false skips the total count
+	if includeTotalStr := r.URL.Query().Get("includeTotal"); includeTotalStr != "" {
+		includeTotal, err := strconv.ParseBool(includeTotalStr)
+		if err != nil {
+			utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid includeTotal value"))
+			return
+		}
+		filters.SkipTotal = !includeTotal
+	}
+
+	// Cursor mode is selected by the cursor parameter, which is empty for the first page
+	if r.URL.Query().Has("cursor") {
+		if filters.SearchText != "" {
+			utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+				"cursor pagination cannot be combined with q; ranked results are paginated with offset"))
+			return
+		}
+		filters.CursorMode = true
+		if token := r.URL.Query().Get("cursor"); token != "" {
+			cursor, err := model.DecodeSearchCursor(token)
+			if err != nil {
+				utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid cursor"))
+				return
+			}
+			filters.Cursor = cursor
+		}
+	}
+
+	// fields and include select the returned fields and the related data loaded for the page
+	selection, err := model.ParseResponseSelection(r.URL.Query().Get("fields"), r.URL.Query().Get("include"),
+		model.ConsentDetailResponse{}, model.IncludeAuthorizations, model.IncludePurposes, model.IncludeAttributes,
+		model.IncludeTags, model.IncludeHistory)
+	if err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+	filters.Includes = &selection.Includes
+
+	// Use detailed search to include nested data
+	response, serviceErr := h.service.SearchConsentsDetailed(ctx, filters)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	data := make([]interface{}, len(response.Data))
+	for i := range response.Data {
+		if data[i], err = selection.Apply(response.Data[i]); err != nil {
+			utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InternalServerError, err.Error()))
+			return
+		}
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Data     []interface{}               `json:"data"`
+		Metadata model.ConsentSearchMetadata `json:"metadata"`
+	}{data, response.Metadata})
+}
+
+// exportConsents handles GET /consents/export. Every consent matching the search filters is
+// streamed as CSV or NDJSON, flushing after each page so the response is sent chunked.
+func (h *consentHandler) exportConsents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Organization ID is required"))
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = model.ExportFormatCSV
+	}
+	writer, err := newExportWriter(format, w)
+	if err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	filters, serviceErr := parseSearchFilters(r, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	// Large exports outlive the server write timeout
+	controller := http.NewResponseController(w)
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	started := false
+	serviceErr = h.service.ExportConsents(ctx, filters, func(records []model.ConsentExportRecord) error {
+		if !started {
+			w.Header().Set(constants.HeaderContentType, writer.contentType())
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"consents.%s\"", format))
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		for _, record := range records {
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		return controller.Flush()
+	})
+	if serviceErr != nil {
+		if !started {
+			utils.SendError(w, r, serviceErr)
+			return
+		}
+		// Part of the export was already sent. Abort the connection so the client does not take a
+		// truncated export for a complete one.
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// parseSearchFilters parses the consent search filters shared by the search and export endpoints
+func parseSearchFilters(r *http.Request, orgID string) (model.ConsentSearchFilters, *serviceerror.ServiceError) {
 	filters := model.ConsentSearchFilters{
-		OrgID:  orgID,
-		Limit:  limit,
-		Offset: offset,
+		OrgID: orgID,
 	}
 
-	// Parse consentTypes (comma-separated)
-	if consentTypesStr := r.URL.Query().Get("consentTypes"); consentTypesStr != "" {
-		filters.ConsentTypes = strings.Split(consentTypesStr, ",")
+	// Parse the comma-separated filters
+	listFilters := []struct {
+		param  string
+		target *[]string
+	}{
+		{"consentTypes", &filters.ConsentTypes},
+		{"consentStatuses", &filters.ConsentStatuses},
+		{"clientIds", &filters.ClientIDs},
+		{"userIds", &filters.UserIDs},
+		{"delegatorIds", &filters.DelegatorIDs},
+		{"delegateIds", &filters.DelegateIDs},
+		{"authTypes", &filters.AuthTypes},
+		{"authStatuses", &filters.AuthStatuses},
+		{"purposeNames", &filters.PurposeNames},
+		{"tags", &filters.Tags},
+	}
+	for _, listFilter := range listFilters {
+		valueStr := r.URL.Query().Get(listFilter.param)
+		if valueStr == "" {
+			continue
+		}
+		values := strings.Split(valueStr, ",")
 		// Trim whitespace
-		for i := range filters.ConsentTypes {
-			filters.ConsentTypes[i] = strings.TrimSpace(filters.ConsentTypes[i])
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
 		}
+		*listFilter.target = values
 	}
 
-	// Parse consentStatuses (comma-separated)
-	if statusesStr := r.URL.Query().Get("consentStatuses"); statusesStr != "" {
-		filters.ConsentStatuses = strings.Split(statusesStr, ",")
-		for i := range filters.ConsentStatuses {
-			filters.ConsentStatuses[i] = strings.TrimSpace(filters.ConsentStatuses[i])
+	// Parse free-text search
+	if q := strings.TrimSpace(r.URL.Query().Get("q")); q != "" {
+		if len(q) > maxSearchTextLength {
+			return filters, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+				fmt.Sprintf("q must be at most %d characters", maxSearchTextLength))
 		}
+		filters.SearchText = q
 	}
 
-	// Parse clientIds (comma-separated)
-	if clientIDsStr := r.URL.Query().Get("clientIds"); clientIDsStr != "" {
-		filters.ClientIDs = strings.Split(clientIDsStr, ",")
-		for i := range filters.ClientIDs {
-			filters.ClientIDs[i] = strings.TrimSpace(filters.ClientIDs[i])
-		}
+	// Parse attribute filters (repeated key or key:value)
+	attributes, serviceErr := parseAttributeFilters(r.URL.Query()["attribute"])
+	if serviceErr != nil {
+		return filters, serviceErr
 	}
+	filters.Attributes = attributes
 
-	// Parse userIds (comma-separated)
-	if userIDsStr := r.URL.Query().Get("userIds"); userIDsStr != "" {
-		filters.UserIDs = strings.Split(userIDsStr, ",")
-		for i := range filters.UserIDs {
-			filters.UserIDs[i] = strings.TrimSpace(filters.UserIDs[i])
+	// Parse the filter expression, e.g. status eq "ACTIVE" and attributes.channel eq "web"
+	if expr := strings.TrimSpace(r.URL.Query().Get("filter")); expr != "" {
+		parsed, err := filter.Parse(expr)
+		if err != nil {
+			return filters, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
 		}
+		filters.Filter = parsed
 	}
 
 	// Parse fromTime (Unix timestamp in milliseconds)
@@ -160,15 +363,50 @@ func (h *consentHandler) listConsents(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Use detailed search to include nested data
-	response, serviceErr := h.service.SearchConsentsDetailed(ctx, filters)
-	if serviceErr != nil {
-		utils.SendError(w, r, serviceErr)
-		return
+	// Parse date range and validity window filters (Unix timestamps in milliseconds)
+	timeFilters := []struct {
+		param  string
+		target **int64
+	}{
+		{"createdTimeFrom", &filters.CreatedTimeFrom},
+		{"createdTimeTo", &filters.CreatedTimeTo},
+		{"updatedTimeFrom", &filters.UpdatedTimeFrom},
+		{"updatedTimeTo", &filters.UpdatedTimeTo},
+		{"expiresAfter", &filters.ExpiresAfter},
+		{"expiresBefore", &filters.ExpiresBefore},
+	}
+	for _, timeFilter := range timeFilters {
+		valueStr := r.URL.Query().Get(timeFilter.param)
+		if valueStr == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(valueStr, 10, 64)
+		if err != nil || value < 0 {
+			return filters, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+				fmt.Sprintf("%s must be a Unix timestamp in milliseconds", timeFilter.param))
+		}
+		*timeFilter.target = &value
 	}
 
-	w.Header().Set(constants.HeaderContentType, "application/json")
-	json.NewEncoder(w).Encode(response)
+	return filters, nil
+}
+
+// parseAttributeFilters parses attribute parameters of the form key or key:value
+func parseAttributeFilters(values []string) ([]model.AttributeFilter, *serviceerror.ServiceError) {
+	if len(values) > maxAttributeFilters {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+			fmt.Sprintf("at most %d attributes can be searched", maxAttributeFilters))
+	}
+	attributes := make([]model.AttributeFilter, 0, len(values))
+	for _, attribute := range values {
+		key, value, _ := strings.Cut(attribute, ":")
+		if key == "" {
+			return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+				"attribute parameter must be of the form key or key:value")
+		}
+		attributes = append(attributes, model.AttributeFilter{Key: key, Value: value})
+	}
+	return attributes, nil
 }
 
 // updateConsent handles PUT /consents/{consentId}
@@ -199,9 +437,14 @@ func (h *consentHandler) updateConsent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A scheduled update is accepted, to be applied when it takes effect
+	status := http.StatusOK
+	if consent.ScheduledUpdate != nil {
+		status = http.StatusAccepted
+	}
 	apiResponse := consent.ToAPIResponse()
 	w.Header().Set(constants.HeaderContentType, "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(apiResponse)
 }
 
@@ -233,14 +476,20 @@ func (h *consentHandler) revokeConsent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A scheduled revocation is accepted, to be applied when it takes effect
+	status := http.StatusOK
+	if revokeResponse.ScheduledRevocation != nil {
+		status = http.StatusAccepted
+	}
 	w.Header().Set(constants.HeaderContentType, "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(revokeResponse)
 }
 
-// validateConsent handles POST /consents/validate
-func (h *consentHandler) validateConsent(w http.ResponseWriter, r *http.Request) {
+// getScheduledRevocation handles GET /consents/{consentId}/scheduled-revocation
+func (h *consentHandler) getScheduledRevocation(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	consentID := r.PathValue("consentId")
 	orgID := r.Header.Get(constants.HeaderOrgID)
 
 	if err := utils.ValidateOrgID(orgID); err != nil {
@@ -248,28 +497,77 @@ func (h *consentHandler) validateConsent(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var req model.ValidateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
 		return
 	}
 
-	// Call service to validate consent
-	response, serviceErr := h.service.ValidateConsent(ctx, req, orgID)
+	revocation, serviceErr := h.service.GetScheduledRevocation(ctx, consentID, orgID)
 	if serviceErr != nil {
 		utils.SendError(w, r, serviceErr)
 		return
 	}
 
-	// Always return HTTP 200, check isValid field in response
 	w.Header().Set(constants.HeaderContentType, "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(revocation)
 }
 
-// searchConsentsByAttribute handles GET /consents/attributes
-func (h *consentHandler) searchConsentsByAttribute(w http.ResponseWriter, r *http.Request) {
+// cancelScheduledRevocation handles DELETE /consents/{consentId}/scheduled-revocation
+func (h *consentHandler) cancelScheduledRevocation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.CancelScheduledRevocation(ctx, consentID, orgID); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getScheduledUpdate handles GET /consents/{consentId}/scheduled-update
+func (h *consentHandler) getScheduledUpdate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	update, serviceErr := h.service.GetScheduledUpdate(ctx, consentID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(update)
+}
+
+// cancelScheduledUpdate handles DELETE /consents/{consentId}/scheduled-update
+func (h *consentHandler) cancelScheduledUpdate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	consentID := r.PathValue("consentId")
 	orgID := r.Header.Get(constants.HeaderOrgID)
 
 	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
@@ -277,18 +575,1057 @@ func (h *consentHandler) searchConsentsByAttribute(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// Get query parameters
-	key := r.URL.Query().Get("key")
-	value := r.URL.Query().Get("value")
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
 
-	// Validate that key parameter is present
-	if key == "" {
-		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "key parameter is required"))
+	if serviceErr := h.service.CancelScheduledUpdate(ctx, consentID, orgID); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lockConsent handles POST /consents/{consentId}/lock
+func (h *consentHandler) lockConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	clientID := r.Header.Get(constants.HeaderTPPClientID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	lock, serviceErr := h.service.LockConsent(ctx, consentID, orgID, clientID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(lock)
+}
+
+// unlockConsent handles POST /consents/{consentId}/unlock
+func (h *consentHandler) unlockConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.UnlockConsent(ctx, consentID, orgID); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// addTags handles POST /consents/{consentId}/tags
+func (h *consentHandler) addTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.ConsentTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.AddTags(ctx, consentID, orgID, req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
 		return
 	}
 
-	// Call service to search consents by attribute
-	response, serviceErr := h.service.SearchConsentsByAttribute(ctx, key, value, orgID)
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// removeTag handles DELETE /consents/{consentId}/tags/{tag}
+func (h *consentHandler) removeTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.RemoveTag(ctx, consentID, orgID, r.PathValue("tag")); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// bindToken handles POST /consents/{consentId}/tokens. A new binding is answered with 201 and a
+// token already bound to the consent with 200.
+func (h *consentHandler) bindToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.ConsentTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, created, serviceErr := h.service.BindToken(ctx, consentID, orgID, req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// listTokens handles GET /consents/{consentId}/tokens
+func (h *consentHandler) listTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	response, serviceErr := h.service.ListTokens(ctx, consentID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// unbindToken handles DELETE /consents/{consentId}/tokens/{tokenId}
+func (h *consentHandler) unbindToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.UnbindToken(ctx, consentID, orgID, r.PathValue("tokenId")); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getTokenConsent handles GET /tokens/{tokenId}/consent
+func (h *consentHandler) getTokenConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	response, serviceErr := h.service.GetTokenConsent(ctx, r.PathValue("tokenId"), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// reauthorizeConsent handles POST /consents/{consentId}/reauthorize
+func (h *consentHandler) reauthorizeConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.ConsentReauthorizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.ReauthorizeConsent(ctx, consentID, orgID, req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// suspendConsent handles POST /consents/{consentId}/suspend
+func (h *consentHandler) suspendConsent(w http.ResponseWriter, r *http.Request) {
+	h.changeSuspension(w, r, h.service.SuspendConsent)
+}
+
+// resumeConsent handles POST /consents/{consentId}/resume
+func (h *consentHandler) resumeConsent(w http.ResponseWriter, r *http.Request) {
+	h.changeSuspension(w, r, h.service.ResumeConsent)
+}
+
+// changeSuspension decodes a suspend or resume request and applies it with change
+func (h *consentHandler) changeSuspension(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, consentID, orgID string, req model.ConsentSuspensionRequest) (*model.ConsentSuspensionResponse, *serviceerror.ServiceError)) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.ConsentSuspensionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := change(ctx, consentID, orgID, req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// deleteConsent handles DELETE /consents/{consentId}
+func (h *consentHandler) deleteConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	clientID := r.Header.Get(constants.HeaderTPPClientID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.DeleteConsent(ctx, consentID, orgID, clientID); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateConsent handles POST /consents/validate
+func (h *consentHandler) validateConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.ValidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	// Call service to validate consent
+	response, serviceErr := h.service.ValidateConsent(ctx, req, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	// Always return HTTP 200, check isValid field in response
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Limits of search parameters
+const (
+	// maxAttributeFilters limits the number of attributes of an attribute search
+	maxAttributeFilters = 10
+	// maxSearchTextLength limits the length of the free-text search
+	maxSearchTextLength = 256
+)
+
+// searchConsentsByAttribute handles GET /consents/attributes. Attributes are given as key and value
+// parameters and as repeated attribute=key:value parameters; a consent must have all of them.
+// expand=true returns full consents with pagination metadata instead of consent IDs.
+func (h *consentHandler) searchConsentsByAttribute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	query := r.URL.Query()
+
+	// Get query parameters
+	var attributes []model.AttributeFilter
+	if key := query.Get("key"); key != "" {
+		attributes = append(attributes, model.AttributeFilter{Key: key, Value: query.Get("value")})
+	} else if query.Get("value") != "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "value parameter requires a key parameter"))
+		return
+	}
+	attributeFilters, serviceErr := parseAttributeFilters(query["attribute"])
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+	attributes = append(attributes, attributeFilters...)
+
+	// Validate that at least one attribute is present
+	if len(attributes) == 0 {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "key parameter is required"))
+		return
+	}
+	if len(attributes) > maxAttributeFilters {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+			fmt.Sprintf("at most %d attributes can be searched", maxAttributeFilters)))
+		return
+	}
+
+	expand := false
+	if expandStr := query.Get("expand"); expandStr != "" {
+		var err error
+		if expand, err = strconv.ParseBool(expandStr); err != nil {
+			utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid expand value"))
+			return
+		}
+	}
+
+	if !expand {
+		// Call service to search consents by attribute
+		response, serviceErr := h.service.SearchConsentsByAttribute(ctx, attributes, orgID)
+		if serviceErr != nil {
+			utils.SendError(w, r, serviceErr)
+			return
+		}
+
+		w.Header().Set(constants.HeaderContentType, "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	// Expanded results are paginated like the consent list
+	filters := model.ConsentSearchFilters{
+		Attributes: attributes,
+		Limit:      10,
+		OrgID:      orgID,
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			filters.Limit = l
+		}
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			filters.Offset = o
+		}
+	}
+
+	response, serviceErr := h.service.SearchConsentsDetailed(ctx, filters)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
+
+// getRelationship handles GET /relationships?userId=&clientId=
+func (h *consentHandler) getRelationship(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	userID := strings.TrimSpace(r.URL.Query().Get("userId"))
+	clientID := strings.TrimSpace(r.URL.Query().Get("clientId"))
+	if userID == "" || clientID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "userId and clientId parameters are required"))
+		return
+	}
+
+	response, serviceErr := h.service.GetRelationship(ctx, userID, clientID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// listUserConsents handles GET /users/{userId}/consents
+func (h *consentHandler) listUserConsents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	userID := strings.TrimSpace(r.PathValue("userId"))
+	if userID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "userId is required"))
+		return
+	}
+
+	filters := model.ConsentSearchFilters{
+		OrgID: orgID,
+		Limit: 10,
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			filters.Limit = l
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			filters.Offset = o
+		}
+	}
+	if statusesStr := r.URL.Query().Get("consentStatuses"); statusesStr != "" {
+		for _, status := range strings.Split(statusesStr, ",") {
+			filters.ConsentStatuses = append(filters.ConsentStatuses, strings.TrimSpace(status))
+		}
+	}
+	if consentTypesStr := r.URL.Query().Get("consentTypes"); consentTypesStr != "" {
+		for _, consentType := range strings.Split(consentTypesStr, ",") {
+			filters.ConsentTypes = append(filters.ConsentTypes, strings.TrimSpace(consentType))
+		}
+	}
+
+	response, serviceErr := h.service.ListUserConsents(ctx, userID, filters)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// exportUserConsents handles GET /users/{userId}/consents/export. The format query parameter
+// selects a JSON bundle (default) or a printable HTML page.
+func (h *consentHandler) exportUserConsents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	userID := strings.TrimSpace(r.PathValue("userId"))
+	if userID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "userId is required"))
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = model.UserExportFormatJSON
+	}
+	if format != model.UserExportFormatJSON && format != model.UserExportFormatHTML {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+			fmt.Sprintf("unsupported export format '%s', must be one of: json, html", format)))
+		return
+	}
+
+	export, serviceErr := h.service.ExportUserConsents(ctx, userID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	var body bytes.Buffer
+	contentType := "application/json"
+	if format == model.UserExportFormatHTML {
+		contentType = "text/html; charset=utf-8"
+		if err := writeUserExportHTML(&body, export); err != nil {
+			utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to render export"))
+			return
+		}
+	} else if err := json.NewEncoder(&body).Encode(export); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to encode export"))
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"consents-export.%s\"", format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+// getUserPreferences handles GET /users/{userId}/preferences
+func (h *consentHandler) getUserPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	userID := strings.TrimSpace(r.PathValue("userId"))
+	if userID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "userId is required"))
+		return
+	}
+
+	response, serviceErr := h.service.GetUserPreferences(ctx, userID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// updateUserPreferences handles PUT /users/{userId}/preferences
+func (h *consentHandler) updateUserPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	userID := strings.TrimSpace(r.PathValue("userId"))
+	if userID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "userId is required"))
+		return
+	}
+
+	var req model.UserPreferencesUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.UpdateUserPreferences(ctx, userID, orgID, req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// amendConsent handles POST /consents/{consentId}/amendments
+func (h *consentHandler) amendConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.ConsentAmendmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	consent, serviceErr := h.service.AmendConsent(ctx, req, orgID, consentID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	apiResponse := consent.ToAPIResponse()
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(apiResponse)
+}
+
+// listConsentVersions handles GET /consents/{consentId}/versions
+func (h *consentHandler) listConsentVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	response, serviceErr := h.service.GetConsentVersions(ctx, consentID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// getConsentReceipt handles GET /consents/{consentId}/receipt
+func (h *consentHandler) getConsentReceipt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	response, serviceErr := h.service.GetConsentReceipt(ctx, consentID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"consent-receipt-%s.json\"", consentID))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// listConsentEvidence handles GET /consents/{consentId}/evidence
+func (h *consentHandler) listConsentEvidence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	response, serviceErr := h.service.ListEvidence(ctx, consentID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// getConsentUsage handles GET /consents/{consentId}/usage
+func (h *consentHandler) getConsentUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	response, serviceErr := h.service.GetConsentUsage(ctx, consentID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// getRequiredAuthorizers handles GET /consents/{consentId}/authorizers
+func (h *consentHandler) getRequiredAuthorizers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	response, serviceErr := h.service.GetRequiredAuthorizers(ctx, consentID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// getConsentVersion handles GET /consents/{consentId}/versions/{version}
+func (h *consentHandler) getConsentVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	version, err := strconv.Atoi(r.PathValue("version"))
+	if err != nil || version < 1 {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "version must be a positive integer"))
+		return
+	}
+
+	response, serviceErr := h.service.GetConsentVersion(ctx, consentID, orgID, version)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// overrideStatus handles POST /admin/consents/{consentId}/status
+func (h *consentHandler) overrideStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Organization ID is required"))
+		return
+	}
+
+	var req model.StatusOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.OverrideStatus(ctx, r.PathValue("consentId"), orgID, req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// transferOwnership handles POST /admin/consent-ownership-transfers
+func (h *consentHandler) transferOwnership(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Organization ID is required"))
+		return
+	}
+
+	var req model.OwnershipTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.TransferOwnership(ctx, req, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// eraseUser handles POST /users/{userId}/erase
+func (h *consentHandler) eraseUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Organization ID is required"))
+		return
+	}
+
+	userID := strings.TrimSpace(r.PathValue("userId"))
+	if userID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "userId is required"))
+		return
+	}
+
+	response, serviceErr := h.service.EraseUser(ctx, userID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// createNote handles POST /consents/{consentId}/notes
+func (h *consentHandler) createNote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Organization ID is required"))
+		return
+	}
+
+	var req model.ConsentNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Invalid request body"))
+		return
+	}
+
+	note, serviceErr := h.service.CreateNote(ctx, r.PathValue("consentId"), orgID, req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(note)
+}
+
+// listNotes handles GET /consents/{consentId}/notes
+func (h *consentHandler) listNotes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Organization ID is required"))
+		return
+	}
+
+	response, serviceErr := h.service.ListNotes(ctx, r.PathValue("consentId"), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// searchStatusAudit handles GET /audit. Every filter is optional; orgId narrows the search to one
+// organization.
+func (h *consentHandler) searchStatusAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	filters := model.StatusAuditSearchFilters{
+		OrgID:     strings.TrimSpace(query.Get("orgId")),
+		ConsentID: strings.TrimSpace(query.Get("consentId")),
+		ActionBy:  strings.TrimSpace(query.Get("actionBy")),
+	}
+
+	// Parse fromStatus and toStatus (comma-separated)
+	statusFilters := []struct {
+		param  string
+		target *[]string
+	}{
+		{"fromStatus", &filters.FromStatuses},
+		{"toStatus", &filters.ToStatuses},
+	}
+	for _, statusFilter := range statusFilters {
+		valueStr := query.Get(statusFilter.param)
+		if valueStr == "" {
+			continue
+		}
+		for _, status := range strings.Split(valueStr, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				*statusFilter.target = append(*statusFilter.target, status)
+			}
+		}
+	}
+
+	// Parse fromTime and toTime (Unix timestamps in milliseconds)
+	timeFilters := []struct {
+		param  string
+		target **int64
+	}{
+		{"fromTime", &filters.FromTime},
+		{"toTime", &filters.ToTime},
+	}
+	for _, timeFilter := range timeFilters {
+		valueStr := query.Get(timeFilter.param)
+		if valueStr == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(valueStr, 10, 64)
+		if err != nil || value < 0 {
+			utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+				fmt.Sprintf("%s must be a Unix timestamp in milliseconds", timeFilter.param)))
+			return
+		}
+		*timeFilter.target = &value
+	}
+
+	// Parse pagination parameters
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			filters.Limit = l
+		}
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			filters.Offset = o
+		}
+	}
+
+	response, serviceErr := h.service.SearchStatusAudit(ctx, filters)
 	if serviceErr != nil {
 		utils.SendError(w, r, serviceErr)
 		return
@@ -3,19 +3,62 @@ package consent
 import (
 	"net/http"
 
+	"github.com/wso2/consent-management-api/internal/system/audit"
+	"github.com/wso2/consent-management-api/internal/system/config"
 	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/log"
 	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/scheduler"
 	"github.com/wso2/consent-management-api/internal/system/stores"
 )
 
-// Initialize sets up the consent module and registers routes
-func Initialize(mux *http.ServeMux, registry *stores.StoreRegistry) ConsentService {
+// Initialize sets up the consent module and registers routes. Admin routes are registered on adminMux.
+func Initialize(mux, adminMux *http.ServeMux, registry *stores.StoreRegistry) ConsentService {
 	// Create service and handler using the registry
 	service := newConsentService(registry)
 	handler := newConsentHandler(service)
 
 	// Register routes with CORS middleware
 	registerRoutes(mux, handler)
+	registerAdminRoutes(adminMux, handler)
+
+	purgeCfg := config.Get().Consent.Purge
+	if purgeCfg.Enabled {
+		if err := scheduler.GetScheduler().Register("consent-purge", purgeCfg.Interval, service.PurgeDeletedConsents); err != nil {
+			log.GetLogger().Error("Failed to schedule consent purge", log.Error(err))
+		}
+	}
+
+	retentionCfg := config.Get().Consent.Retention
+	if retentionCfg.Enabled {
+		if err := scheduler.GetScheduler().Register("consent-retention", retentionCfg.Interval, service.ApplyRetention); err != nil {
+			log.GetLogger().Error("Failed to schedule consent retention", log.Error(err))
+		}
+	}
+
+	scheduledRevocationCfg := config.Get().Consent.ScheduledRevocation
+	if scheduledRevocationCfg.Enabled {
+		if err := scheduler.GetScheduler().Register("consent-scheduled-revocation", scheduledRevocationCfg.Interval, service.ApplyScheduledRevocations); err != nil {
+			log.GetLogger().Error("Failed to schedule scheduled consent revocations", log.Error(err))
+		}
+		if err := scheduler.GetScheduler().Register("consent-scheduled-update", scheduledRevocationCfg.Interval, service.ApplyScheduledUpdates); err != nil {
+			log.GetLogger().Error("Failed to schedule scheduled consent updates", log.Error(err))
+		}
+	}
+
+	tokenCleanupCfg := config.Get().Consent.TokenCleanup
+	if tokenCleanupCfg.Enabled {
+		if err := scheduler.GetScheduler().Register("consent-token-cleanup", tokenCleanupCfg.Interval, service.CleanupExpiredTokens); err != nil {
+			log.GetLogger().Error("Failed to schedule consent token cleanup", log.Error(err))
+		}
+	}
+
+	notificationCfg := config.Get().Consent.ExpiryNotification
+	if notificationCfg.Enabled {
+		if err := scheduler.GetScheduler().Register("consent-expiry-notification", notificationCfg.Interval, service.NotifyExpiringConsents); err != nil {
+			log.GetLogger().Error("Failed to schedule consent expiry notifications", log.Error(err))
+		}
+	}
 
 	return service
 }
@@ -30,23 +73,146 @@ func registerRoutes(mux *http.ServeMux, handler *consentHandler) {
 	}
 
 	// POST /api/v1/consents - Create consent
-	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents", handler.createConsent, corsOpts))
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents", middleware.WithOperationAudit(audit.ActionConsentCreate, middleware.WithScope(middleware.ScopeConsentsWrite, handler.createConsent)), corsOpts))
+
+	// GET /api/v1/consents/export - Stream consents matching search filters as CSV or NDJSON
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/export", middleware.WithScope(middleware.ScopeConsentsRead, handler.exportConsents), corsOpts))
 
 	// GET /api/v1/consents/{consentId} - Get consent by ID
-	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}", handler.getConsent, corsOpts))
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}", middleware.WithScope(middleware.ScopeConsentsRead, handler.getConsent), corsOpts))
 
 	// GET /api/v1/consents - List/search consents
-	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents", handler.listConsents, corsOpts))
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents", middleware.WithScope(middleware.ScopeConsentsRead, handler.listConsents), corsOpts))
 
 	// PUT /api/v1/consents/{consentId} - Update consent
-	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/consents/{consentId}", handler.updateConsent, corsOpts))
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/consents/{consentId}", middleware.WithOperationAudit(audit.ActionConsentUpdate, middleware.WithScope(middleware.ScopeConsentsWrite, handler.updateConsent)), corsOpts))
 
 	// PUT /api/v1/consents/{consentId}/revoke - Revoke consent
-	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/consents/{consentId}/revoke", handler.revokeConsent, corsOpts))
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/consents/{consentId}/revoke", middleware.WithOperationAudit(audit.ActionConsentRevoke, middleware.WithScope(middleware.ScopeConsentsRevoke, handler.revokeConsent)), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/scheduled-revocation - Get the revocation scheduled for a consent
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/scheduled-revocation", middleware.WithScope(middleware.ScopeConsentsRead, handler.getScheduledRevocation), corsOpts))
+
+	// DELETE /api/v1/consents/{consentId}/scheduled-revocation - Cancel the revocation scheduled for a consent
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/consents/{consentId}/scheduled-revocation", middleware.WithScope(middleware.ScopeConsentsRevoke, handler.cancelScheduledRevocation), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/scheduled-update - Get the update scheduled for a consent
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/scheduled-update", middleware.WithScope(middleware.ScopeConsentsRead, handler.getScheduledUpdate), corsOpts))
+
+	// DELETE /api/v1/consents/{consentId}/scheduled-update - Cancel the update scheduled for a consent
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/consents/{consentId}/scheduled-update", middleware.WithScope(middleware.ScopeConsentsWrite, handler.cancelScheduledUpdate), corsOpts))
+
+	// POST /api/v1/consents/{consentId}/reauthorize - Send consent back for re-authorization
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/reauthorize", middleware.WithOperationAudit(audit.ActionConsentReauthorize, middleware.WithScope(middleware.ScopeConsentsWrite, handler.reauthorizeConsent)), corsOpts))
+
+	// POST /api/v1/consents/{consentId}/suspend - Put a consent on hold until it is resumed
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/suspend", middleware.WithOperationAudit(audit.ActionConsentSuspend, middleware.WithScope(middleware.ScopeConsentsRevoke, handler.suspendConsent)), corsOpts))
+
+	// POST /api/v1/consents/{consentId}/resume - Lift the hold of a suspended consent
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/resume", middleware.WithOperationAudit(audit.ActionConsentResume, middleware.WithScope(middleware.ScopeConsentsRevoke, handler.resumeConsent)), corsOpts))
+
+	// POST /api/v1/consents/{consentId}/tokens - Bind an access or refresh token to a consent
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/tokens", middleware.WithOperationAudit(audit.ActionConsentTokenBind, middleware.WithScope(middleware.ScopeConsentsWrite, handler.bindToken)), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/tokens - List the tokens bound to a consent
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/tokens", middleware.WithScope(middleware.ScopeConsentsRead, handler.listTokens), corsOpts))
+
+	// DELETE /api/v1/consents/{consentId}/tokens/{tokenId} - Unbind a token, e.g. when it is revoked
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/consents/{consentId}/tokens/{tokenId}", middleware.WithOperationAudit(audit.ActionConsentTokenUnbind, middleware.WithScope(middleware.ScopeConsentsWrite, handler.unbindToken)), corsOpts))
+
+	// GET /api/v1/tokens/{tokenId}/consent - Resolve a token to the consent it is bound to
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/tokens/{tokenId}/consent", middleware.WithScope(middleware.ScopeConsentsRead, handler.getTokenConsent), corsOpts))
+
+	// POST /api/v1/consents/{consentId}/lock - Lock a consent while its user is authorizing it
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/lock", middleware.WithOperationAudit(audit.ActionConsentLock, middleware.WithScope(middleware.ScopeConsentsWrite, handler.lockConsent)), corsOpts))
+
+	// POST /api/v1/consents/{consentId}/unlock - Release the lock of a consent
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/unlock", middleware.WithOperationAudit(audit.ActionConsentUnlock, middleware.WithScope(middleware.ScopeConsentsWrite, handler.unlockConsent)), corsOpts))
+
+	// POST /api/v1/consents/{consentId}/tags - Add tags to a consent
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/tags", middleware.WithOperationAudit(audit.ActionConsentTag, middleware.WithScope(middleware.ScopeConsentsWrite, handler.addTags)), corsOpts))
+
+	// DELETE /api/v1/consents/{consentId}/tags/{tag} - Remove a tag from a consent
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/consents/{consentId}/tags/{tag}", middleware.WithOperationAudit(audit.ActionConsentUntag, middleware.WithScope(middleware.ScopeConsentsWrite, handler.removeTag)), corsOpts))
+
+	// DELETE /api/v1/consents/{consentId} - Soft delete consent
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/consents/{consentId}", middleware.WithOperationAudit(audit.ActionConsentDelete, middleware.WithScope(middleware.ScopeConsentsWrite, handler.deleteConsent)), corsOpts))
+
+	// POST /api/v1/consents/{consentId}/amendments - Amend consent, keeping the previous version
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/amendments", middleware.WithOperationAudit(audit.ActionConsentAmend, middleware.WithScope(middleware.ScopeConsentsWrite, handler.amendConsent)), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/versions - List superseded consent versions
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/versions", middleware.WithScope(middleware.ScopeConsentsRead, handler.listConsentVersions), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/versions/{version} - Get consent as of a version
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/versions/{version}", middleware.WithScope(middleware.ScopeConsentsRead, handler.getConsentVersion), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/receipt - Get a signed consent receipt
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/receipt", middleware.WithScope(middleware.ScopeConsentsRead, handler.getConsentReceipt), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/evidence - List the evidence of how a consent was collected
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/evidence", middleware.WithScope(middleware.ScopeConsentsRead, handler.listConsentEvidence), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/usage - Get consent usage in the current frequency period
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/usage", middleware.WithScope(middleware.ScopeConsentsRead, handler.getConsentUsage), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/authorizers - Get the required authorizers still to approve a consent
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/authorizers", middleware.WithScope(middleware.ScopeConsentsRead, handler.getRequiredAuthorizers), corsOpts))
 
 	// POST /api/v1/consents/validate - Validate consent
-	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/validate", handler.validateConsent, corsOpts))
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/validate", middleware.WithScope(middleware.ScopeConsentsRead, handler.validateConsent), corsOpts))
+
+	// POST /api/v1/consents/get-batch - Get several consents by ID
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/get-batch", middleware.WithScope(middleware.ScopeConsentsRead, handler.getConsentsBatch), corsOpts))
 
 	// GET /api/v1/consents/attributes - Search consents by attribute
-	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/attributes", handler.searchConsentsByAttribute, corsOpts))
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/attributes", middleware.WithScope(middleware.ScopeConsentsRead, handler.searchConsentsByAttribute), corsOpts))
+
+	// GET /api/v1/relationships - Summarize consents between a user and a client
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/relationships", middleware.WithScope(middleware.ScopeConsentsRead, handler.getRelationship), corsOpts))
+
+	// GET /api/v1/users/{userId}/consents - List the consents a user has authorized
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/users/{userId}/consents", middleware.WithScope(middleware.ScopeConsentsRead, handler.listUserConsents), corsOpts))
+
+	// GET /api/v1/users/{userId}/consents/export - Export a user's consents for a data subject access request
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/users/{userId}/consents/export", middleware.WithScope(middleware.ScopeConsentsRead, handler.exportUserConsents), corsOpts))
+
+	// GET /api/v1/users/{userId}/preferences - Show a user's choice for each purpose of their consents
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/users/{userId}/preferences", middleware.WithScope(middleware.ScopeConsentsRead, handler.getUserPreferences), corsOpts))
+
+	// PUT /api/v1/users/{userId}/preferences - Opt a user in to or out of purposes across their consents
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/users/{userId}/preferences", middleware.WithOperationAudit(audit.ActionUserPreferenceUpdate, middleware.WithScope(middleware.ScopeConsentsWrite, handler.updateUserPreferences)), corsOpts))
+}
+
+// registerAdminRoutes registers consent admin routes. Admin routes are protected with admin basic auth.
+func registerAdminRoutes(mux *http.ServeMux, handler *consentHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "POST", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "org-id", "X-Correlation-ID"},
+	}
+
+	// POST /api/v1/admin/consent-ownership-transfers - Move a user's consents to another user
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/admin/consent-ownership-transfers",
+		middleware.WithOperationAudit(audit.ActionOwnershipTransfer, middleware.WithAdminAuth(handler.transferOwnership)), corsOpts))
+
+	// POST /api/v1/users/{userId}/erase - Erase a user from the organization's consents
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/users/{userId}/erase",
+		middleware.WithOperationAudit(audit.ActionUserErase, middleware.WithAdminAuth(handler.eraseUser)), corsOpts))
+
+	// POST /api/v1/admin/consents/{consentId}/status - Force a consent into a status
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/admin/consents/{consentId}/status",
+		middleware.WithOperationAudit(audit.ActionStatusOverride, middleware.WithAdminAuth(handler.overrideStatus)), corsOpts))
+
+	// POST /api/v1/consents/{consentId}/notes - Add a note to a consent
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/notes",
+		middleware.WithAdminAuth(handler.createNote), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/notes - List the notes of a consent
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/notes",
+		middleware.WithAdminAuth(handler.listNotes), corsOpts))
+
+	// GET /api/v1/audit - Search consent status changes
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/audit",
+		middleware.WithAdminAuth(handler.searchStatusAudit), corsOpts))
 }
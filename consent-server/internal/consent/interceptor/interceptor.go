@@ -0,0 +1,135 @@
+// Package interceptor is the in-process counterpart of the service extension. Deployments compile
+// their own ConsentInterceptors into the server and register them in main.go, so enrichments that
+// cannot afford a network hop run in the request's goroutine. Interceptors run in the order they
+// are registered, before the service extension hooks of the same operation.
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/extension"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
+)
+
+// ErrFailed wraps errors, other than rejections, returned by an interceptor
+var ErrFailed = errors.New("consent interceptor failed")
+
+// ConsentInterceptor hooks into consent operations in-process. A hook rejects the operation by
+// returning an *extension.RejectedError, which fails it with the status the error asks for; any
+// other error fails the operation as an internal error. Hooks receive the same payloads as the
+// matching service extension hooks.
+type ConsentInterceptor interface {
+	// Name identifies the interceptor in logs and traces
+	Name() string
+	// PreCreate runs before a consent create request is validated and may change the request
+	PreCreate(ctx context.Context, orgID string, payload *model.ConsentCreateHookPayload) error
+	// PostCreate runs after a consent is created and may set payload.ModifiedResponse. The consent
+	// is already stored when it runs, so a rejection only fails the response.
+	PostCreate(ctx context.Context, orgID string, payload *model.ConsentEnrichHookPayload) error
+	// PreValidate runs before a consent is validated and may change the request. A rejection
+	// returns isValid false.
+	PreValidate(ctx context.Context, orgID string, payload *model.ConsentValidateHookPayload) error
+}
+
+// Base implements every hook of ConsentInterceptor as a no-op. Interceptors embed it to implement
+// only the hooks they need.
+type Base struct{}
+
+// PreCreate does nothing
+func (Base) PreCreate(ctx context.Context, orgID string, payload *model.ConsentCreateHookPayload) error {
+	return nil
+}
+
+// PostCreate does nothing
+func (Base) PostCreate(ctx context.Context, orgID string, payload *model.ConsentEnrichHookPayload) error {
+	return nil
+}
+
+// PreValidate does nothing
+func (Base) PreValidate(ctx context.Context, orgID string, payload *model.ConsentValidateHookPayload) error {
+	return nil
+}
+
+var (
+	mu           sync.RWMutex
+	interceptors []ConsentInterceptor
+)
+
+// Register adds an interceptor. Interceptors are registered at startup, before the server accepts
+// requests.
+func Register(interceptor ConsentInterceptor) {
+	mu.Lock()
+	defer mu.Unlock()
+	interceptors = append(interceptors, interceptor)
+	log.GetLogger().Info("Consent interceptor registered", log.String("interceptor", interceptor.Name()))
+}
+
+// Enabled reports whether any interceptor is registered
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return len(interceptors) > 0
+}
+
+// PreCreate runs the PreCreate hook of every interceptor, stopping at the first error
+func PreCreate(ctx context.Context, orgID string, payload *model.ConsentCreateHookPayload) error {
+	return run(ctx, "PreCreate", func(ctx context.Context, i ConsentInterceptor) error {
+		return i.PreCreate(ctx, orgID, payload)
+	})
+}
+
+// PostCreate runs the PostCreate hook of every interceptor, stopping at the first error
+func PostCreate(ctx context.Context, orgID string, payload *model.ConsentEnrichHookPayload) error {
+	return run(ctx, "PostCreate", func(ctx context.Context, i ConsentInterceptor) error {
+		return i.PostCreate(ctx, orgID, payload)
+	})
+}
+
+// PreValidate runs the PreValidate hook of every interceptor, stopping at the first error
+func PreValidate(ctx context.Context, orgID string, payload *model.ConsentValidateHookPayload) error {
+	return run(ctx, "PreValidate", func(ctx context.Context, i ConsentInterceptor) error {
+		return i.PreValidate(ctx, orgID, payload)
+	})
+}
+
+// run calls hook for each interceptor in registration order. Rejections are returned as they are;
+// other errors, and panics, are wrapped in ErrFailed.
+func run(ctx context.Context, hookName string, hook func(context.Context, ConsentInterceptor) error) error {
+	mu.RLock()
+	registered := interceptors
+	mu.RUnlock()
+
+	for _, i := range registered {
+		if err := runOne(ctx, i, hookName, hook); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runOne(ctx context.Context, i ConsentInterceptor, hookName string, hook func(context.Context, ConsentInterceptor) error) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "interceptor."+i.Name()+"."+hookName)
+	defer span.End()
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %s.%s panicked: %v", ErrFailed, i.Name(), hookName, recovered)
+		}
+		if err != nil {
+			span.SetError(err)
+		}
+	}()
+
+	if err = hook(ctx, i); err != nil {
+		var rejected *extension.RejectedError
+		if !errors.As(err, &rejected) {
+			err = fmt.Errorf("%w: %s.%s: %v", ErrFailed, i.Name(), hookName, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/extension"
+)
+
+// testInterceptor appends its name to the consent type on PreCreate and runs preValidate on PreValidate
+type testInterceptor struct {
+	Base
+	name        string
+	preValidate func() error
+}
+
+func (t *testInterceptor) Name() string { return t.name }
+
+func (t *testInterceptor) PreCreate(ctx context.Context, orgID string, payload *model.ConsentCreateHookPayload) error {
+	payload.ConsentRequest.Type += t.name
+	return nil
+}
+
+func (t *testInterceptor) PreValidate(ctx context.Context, orgID string, payload *model.ConsentValidateHookPayload) error {
+	if t.preValidate == nil {
+		return nil
+	}
+	return t.preValidate()
+}
+
+// withInterceptors replaces the registered interceptors for the duration of a test
+func withInterceptors(t *testing.T, registered ...ConsentInterceptor) {
+	previous := interceptors
+	interceptors = registered
+	t.Cleanup(func() { interceptors = previous })
+}
+
+// TestPreCreate_RunsInRegistrationOrder checks that each interceptor sees the changes of the ones
+// registered before it
+func TestPreCreate_RunsInRegistrationOrder(t *testing.T) {
+	withInterceptors(t, &testInterceptor{name: "-a"}, &testInterceptor{name: "-b"})
+
+	payload := &model.ConsentCreateHookPayload{ConsentRequest: model.ConsentAPIRequest{Type: "accounts"}}
+	if err := PreCreate(context.Background(), "org", payload); err != nil {
+		t.Fatalf("PreCreate() error = %v", err)
+	}
+	if payload.ConsentRequest.Type != "accounts-a-b" {
+		t.Errorf("type = %q, want %q", payload.ConsentRequest.Type, "accounts-a-b")
+	}
+}
+
+// TestPreValidate_Errors checks that rejections are returned as they are, that other errors and
+// panics are wrapped in ErrFailed, and that the first error stops the chain
+func TestPreValidate_Errors(t *testing.T) {
+	rejection := &extension.RejectedError{StatusCode: 403, Message: "denied"}
+	tests := map[string]struct {
+		preValidate  func() error
+		wantRejected bool
+	}{
+		"rejection": {preValidate: func() error { return rejection }, wantRejected: true},
+		"error":     {preValidate: func() error { return errors.New("lookup failed") }},
+		"panic":     {preValidate: func() error { panic("nil map") }},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			called := false
+			withInterceptors(t,
+				&testInterceptor{name: "first", preValidate: tt.preValidate},
+				&testInterceptor{name: "second", preValidate: func() error { called = true; return nil }})
+
+			err := PreValidate(context.Background(), "org", &model.ConsentValidateHookPayload{})
+			var rejected *extension.RejectedError
+			if got := errors.As(err, &rejected); got != tt.wantRejected {
+				t.Errorf("rejected = %v, want %v (error %v)", got, tt.wantRejected, err)
+			}
+			if !tt.wantRejected && !errors.Is(err, ErrFailed) {
+				t.Errorf("error = %v, want it to wrap ErrFailed", err)
+			}
+			if called {
+				t.Error("interceptor after the failing one was called")
+			}
+		})
+	}
+}
+
+// TestBase_DoesNothing checks that an interceptor embedding Base can leave hooks unimplemented
+func TestBase_DoesNothing(t *testing.T) {
+	withInterceptors(t, &testInterceptor{name: "only-pre-create"})
+
+	payload := &model.ConsentEnrichHookPayload{}
+	if err := PostCreate(context.Background(), "org", payload); err != nil {
+		t.Fatalf("PostCreate() error = %v", err)
+	}
+	if payload.ModifiedResponse != nil {
+		t.Errorf("modifiedResponse = %v, want nil", payload.ModifiedResponse)
+	}
+}
@@ -0,0 +1,23 @@
+package model
+
+// Statuses of a required authorizer role
+const (
+	AuthorizerStatusApproved = "APPROVED"
+	AuthorizerStatusPending  = "PENDING"
+)
+
+// RequiredAuthorizer is a role that must approve a multi-party consent before it becomes active.
+// AuthorizationIDs lists the consent's authorizations of the role's type.
+type RequiredAuthorizer struct {
+	Role             string   `json:"role"`
+	Status           string   `json:"status"`
+	AuthorizationIDs []string `json:"authorizationIds"`
+}
+
+// RequiredAuthorizersResponse reports which required authorizers of a consent have approved it
+type RequiredAuthorizersResponse struct {
+	ConsentID     string               `json:"consentId"`
+	ConsentStatus string               `json:"consentStatus"`
+	Authorizers   []RequiredAuthorizer `json:"authorizers"`
+	Pending       []string             `json:"pending"`
+}
@@ -0,0 +1,13 @@
+package model
+
+// ConsentBatchGetRequest represents the payload for retrieving several consents in one call
+type ConsentBatchGetRequest struct {
+	ConsentIDs []string `json:"consentIds"`
+}
+
+// ConsentBatchGetResponse holds the consents found for a batch retrieval, in request order, and
+// the requested IDs that did not match a consent of the organization
+type ConsentBatchGetResponse struct {
+	Data     []ConsentAPIResponse `json:"data"`
+	NotFound []string             `json:"notFound"`
+}
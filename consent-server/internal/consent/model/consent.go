@@ -1,28 +1,39 @@
 package model
 
 import (
+	"crypto/sha256"
 	"database/sql/driver"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	authmodel "github.com/wso2/consent-management-api/internal/authresource/model"
+	"github.com/wso2/consent-management-api/internal/consent/filter"
 	"github.com/wso2/consent-management-api/internal/system/config"
 )
 
+// DeletedConsentStatus is the system status of a soft-deleted consent. It is not configurable
+// because store queries match on it to hide deleted consents.
+const DeletedConsentStatus = "DELETED"
+
 // Consent represents the CONSENT table
 type Consent struct {
-	ConsentID                  string `db:"CONSENT_ID" json:"consentId"`
-	CreatedTime                int64  `db:"CREATED_TIME" json:"createdTime"`
-	UpdatedTime                int64  `db:"UPDATED_TIME" json:"updatedTime"`
-	ClientID                   string `db:"CLIENT_ID" json:"clientId"`
-	ConsentType                string `db:"CONSENT_TYPE" json:"consentType"`
-	CurrentStatus              string `db:"CURRENT_STATUS" json:"currentStatus"`
-	ConsentFrequency           *int   `db:"CONSENT_FREQUENCY" json:"consentFrequency,omitempty"`
-	ValidityTime               *int64 `db:"VALIDITY_TIME" json:"validityTime,omitempty"`
-	RecurringIndicator         *bool  `db:"RECURRING_INDICATOR" json:"recurringIndicator,omitempty"`
-	DataAccessValidityDuration *int64 `db:"DATA_ACCESS_VALIDITY_DURATION" json:"dataAccessValidityDuration,omitempty"`
-	OrgID                      string `db:"ORG_ID" json:"orgId"`
+	ConsentID                  string  `db:"CONSENT_ID" json:"consentId"`
+	CreatedTime                int64   `db:"CREATED_TIME" json:"createdTime"`
+	UpdatedTime                int64   `db:"UPDATED_TIME" json:"updatedTime"`
+	ClientID                   string  `db:"CLIENT_ID" json:"clientId"`
+	ConsentType                string  `db:"CONSENT_TYPE" json:"consentType"`
+	CurrentStatus              string  `db:"CURRENT_STATUS" json:"currentStatus"`
+	ConsentFrequency           *int    `db:"CONSENT_FREQUENCY" json:"consentFrequency,omitempty"`
+	ValidityTime               *int64  `db:"VALIDITY_TIME" json:"validityTime,omitempty"`
+	RecurringIndicator         *bool   `db:"RECURRING_INDICATOR" json:"recurringIndicator,omitempty"`
+	DataAccessValidityDuration *int64  `db:"DATA_ACCESS_VALIDITY_DURATION" json:"dataAccessValidityDuration,omitempty"`
+	ParentConsentID            *string `db:"PARENT_CONSENT_ID" json:"parentConsentId,omitempty"`
+	Version                    int     `db:"VERSION" json:"version"`
+	OrgID                      string  `db:"ORG_ID" json:"orgId"`
 }
 
 // JSON type for handling JSON fields in MySQL
@@ -88,13 +99,15 @@ func (j *JSON) UnmarshalJSON(data []byte) error {
 
 // ConsentPurposeItem represents a single consent purpose with name, value, and selection status
 type ConsentPurposeItem struct {
-	Name           string                 `json:"name"`
-	Value          interface{}            `json:"value,omitempty"`          // Can be string, object, or array - omitted when nil
-	IsUserApproved *bool                  `json:"isUserApproved,omitempty"` // Optional: defaults to false if not provided
-	IsMandatory    *bool                  `json:"isMandatory,omitempty"`    // Optional: defaults to true if not provided
-	Type           *string                `json:"type,omitempty"`           // Enriched from purpose definition (optional)
-	Description    *string                `json:"description,omitempty"`    // Enriched from purpose definition (optional)
-	Attributes     map[string]interface{} `json:"attributes,omitempty"`     // Enriched from purpose definition (optional)
+	Name            string                 `json:"name"`
+	Value           interface{}            `json:"value,omitempty"`           // Can be string, object, or array - omitted when nil
+	IsUserApproved  *bool                  `json:"isUserApproved,omitempty"`  // Optional: defaults to false if not provided
+	IsMandatory     *bool                  `json:"isMandatory,omitempty"`     // Optional: defaults to true if not provided
+	ExpiresAt       *int64                 `json:"expiresAt,omitempty"`       // Optional: time after which the purpose must be re-confirmed
+	LastConfirmedAt *int64                 `json:"lastConfirmedAt,omitempty"` // Optional: time the user last confirmed the purpose
+	Type            *string                `json:"type,omitempty"`            // Enriched from purpose definition (optional)
+	Description     *string                `json:"description,omitempty"`     // Enriched from purpose definition (optional)
+	Attributes      map[string]interface{} `json:"attributes,omitempty"`      // Enriched from purpose definition (optional)
 }
 
 // ConsentAPIRequest represents the API payload for creating a consent (external format)
@@ -105,9 +118,15 @@ type ConsentAPIRequest struct {
 	RecurringIndicator         *bool                     `json:"recurringIndicator,omitempty"`
 	Frequency                  *int                      `json:"frequency,omitempty"`
 	DataAccessValidityDuration *int64                    `json:"dataAccessValidityDuration,omitempty"`
+	ParentConsentID            *string                   `json:"parentConsentId,omitempty"` // Optional: consent that owns this one; set on create only
 	ConsentPurpose             []ConsentPurposeItem      `json:"consentPurpose,omitempty"`
 	Attributes                 map[string]string         `json:"attributes,omitempty"`
 	Authorizations             []AuthorizationAPIRequest `json:"authorizations"` // Remove omitempty to allow explicit empty array in updates
+	// RequiredAuthorizers are authorization types that must each have an approved authorization
+	// before the consent becomes active, e.g. both holders of a joint account; set on create only
+	RequiredAuthorizers []string `json:"requiredAuthorizers,omitempty"`
+	// Evidence records how the consent was collected
+	Evidence *ConsentEvidenceRequest `json:"evidence,omitempty"`
 }
 
 // AuthorizationAPIRequest represents the API payload for authorization resource (external format)
@@ -117,6 +136,21 @@ type AuthorizationAPIRequest struct {
 	Type      string      `json:"type" binding:"required"`
 	Status    string      `json:"status,omitempty"` // Optional: defaults to "approved" if not provided
 	Resources interface{} `json:"resources,omitempty"`
+	// DelegatorID and DelegateID are given together for an authorization given on behalf of
+	// another person, e.g. under a power of attorney
+	DelegatorID string `json:"delegatorId,omitempty"`
+	DelegateID  string `json:"delegateId,omitempty"`
+}
+
+// Delegation returns the delegator and delegate of the authorization, nil when they are not given
+func (req *AuthorizationAPIRequest) Delegation() (delegatorID, delegateID *string) {
+	if req.DelegatorID != "" {
+		delegatorID = &req.DelegatorID
+	}
+	if req.DelegateID != "" {
+		delegateID = &req.DelegateID
+	}
+	return delegatorID, delegateID
 }
 
 // ToAuthResourceCreateRequest converts API request format to internal format
@@ -134,11 +168,14 @@ func (req *AuthorizationAPIRequest) ToAuthResourceCreateRequest() *authmodel.Con
 		status = string(AuthStatusMappings.CreatedState) // Default to "created" state
 	}
 
+	delegatorID, delegateID := req.Delegation()
 	return &authmodel.ConsentAuthResourceCreateRequest{
-		AuthType:   req.Type,
-		UserID:     userID,
-		AuthStatus: status, // Store the status value in AuthStatus field
-		Resources:  req.Resources,
+		AuthType:    req.Type,
+		UserID:      userID,
+		AuthStatus:  status, // Store the status value in AuthStatus field
+		Resources:   req.Resources,
+		DelegatorID: delegatorID,
+		DelegateID:  delegateID,
 	}
 }
 
@@ -176,6 +213,14 @@ type ConsentAPIUpdateRequest struct {
 	ConsentPurpose             []ConsentPurposeItem      `json:"consentPurpose"`
 	Attributes                 map[string]string         `json:"attributes"`
 	Authorizations             []AuthorizationAPIRequest `json:"authorizations"`
+	// ScheduledRevocation schedules the revocation of the consent with the update. Its effectiveAt
+	// must be in the future.
+	ScheduledRevocation *ConsentRevokeRequest `json:"scheduledRevocation,omitempty"`
+	// EffectiveAt is the Unix time in seconds the update takes effect. A future time schedules the
+	// update instead of applying it; an absent or past time updates the consent now.
+	EffectiveAt *int64 `json:"effectiveAt,omitempty"`
+	// Evidence records how the change was collected, against the version of the consent it produced
+	Evidence *ConsentEvidenceRequest `json:"evidence,omitempty"`
 }
 
 // ConsentCreateRequest represents the internal request payload for creating a consent
@@ -187,8 +232,10 @@ type ConsentCreateRequest struct {
 	ValidityTime               *int64                                       `json:"validityTime,omitempty"`
 	RecurringIndicator         *bool                                        `json:"recurringIndicator,omitempty"`
 	DataAccessValidityDuration *int64                                       `json:"dataAccessValidityDuration,omitempty"`
+	ParentConsentID            *string                                      `json:"parentConsentId,omitempty"`
 	Attributes                 map[string]string                            `json:"attributes,omitempty"`
 	AuthResources              []authmodel.ConsentAuthResourceCreateRequest `json:"authResources,omitempty"`
+	RequiredAuthorizers        []string                                     `json:"requiredAuthorizers,omitempty"`
 }
 
 // ConsentUpdateRequest represents the request payload for updating a consent
@@ -217,9 +264,21 @@ type ConsentResponse struct {
 	ValidityTime               *int64                          `json:"validityTime,omitempty"`
 	RecurringIndicator         *bool                           `json:"recurringIndicator,omitempty"`
 	DataAccessValidityDuration *int64                          `json:"dataAccessValidityDuration,omitempty"`
+	ParentConsentID            *string                         `json:"parentConsentId,omitempty"`
+	Version                    int                             `json:"version"`
 	OrgID                      string                          `json:"orgId"`
 	Attributes                 map[string]string               `json:"attributes,omitempty"`
+	Tags                       []string                        `json:"tags,omitempty"`
 	AuthResources              []authmodel.ConsentAuthResource `json:"authResources,omitempty"`
+	// History lists the superseded versions, newest first, when they were requested
+	History []ConsentVersionSummary `json:"history,omitempty"`
+	// Children holds the child consents, with their own children, when the tree was requested
+	Children []ConsentResponse `json:"children,omitempty"`
+	// ModifiedResponse holds the additions of the service extension enrich hooks
+	ModifiedResponse interface{} `json:"modifiedResponse,omitempty"`
+	// ScheduledUpdate is set when the update was scheduled for a future effectiveAt, in which case
+	// the consent is returned as it is until then
+	ScheduledUpdate *ScheduledUpdateResponse `json:"scheduledUpdate,omitempty"`
 }
 
 // ConsentSearchParams represents search parameters for consent queries
@@ -244,10 +303,13 @@ type ConsentSearchResponse struct {
 
 // ConsentSearchMetadata represents pagination metadata
 type ConsentSearchMetadata struct {
-	Total  int `json:"total"`
-	Limit  int `json:"limit"`
-	Offset int `json:"offset"`
-	Count  int `json:"count"` // Number of results in current page
+	Total   *int `json:"total,omitempty"` // Omitted when the total was not requested
+	Limit   int  `json:"limit"`
+	Offset  int  `json:"offset"`
+	Count   int  `json:"count"`   // Number of results in current page
+	HasMore bool `json:"hasMore"` // Whether more results follow this page
+	// NextCursor is the token for the next page in cursor mode, omitted on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // ConsentSearchFilters represents search criteria for consents
@@ -256,28 +318,60 @@ type ConsentSearchFilters struct {
 	ConsentStatuses []string // e.g., ["active", "revoked"]
 	ClientIDs       []string // TPP client IDs
 	UserIDs         []string // End-user IDs
-	FromTime        *int64   // Unix timestamp - start of time window
-	ToTime          *int64   // Unix timestamp - end of time window
-	Limit           int
-	Offset          int
-	OrgID           string
+	// Authorization filters match the same authorization as UserIDs, e.g. an APPROVED authorization of a user
+	DelegatorIDs []string // Persons an authorization was given on behalf of
+	DelegateIDs  []string // Persons who gave an authorization on behalf of another
+	AuthTypes    []string
+	AuthStatuses []string
+	PurposeNames []string // Consents linked to any of the purposes
+	// SearchText matches part of the consent ID, client ID, a user ID or an attribute value. Outside
+	// cursor mode results are ranked, best match first.
+	SearchText string
+	FromTime   *int64 // Unix timestamp - start of time window
+	ToTime     *int64 // Unix timestamp - end of time window
+	// Date range and validity window filters (Unix timestamps in milliseconds, inclusive)
+	CreatedTimeFrom *int64
+	CreatedTimeTo   *int64
+	UpdatedTimeFrom *int64
+	UpdatedTimeTo   *int64
+	ExpiresAfter    *int64   // Only consents with an expiry (validity time) are matched
+	ExpiresBefore   *int64   // Only consents with an expiry (validity time) are matched
+	Tags            []string // Consents with any of the tags
+	// Attributes matches consents that have every listed attribute
+	Attributes []AttributeFilter
+	// Filter is a parsed filter expression, combined with the other filters
+	Filter filter.Expression
+	Limit  int
+	Offset int
+	// CursorMode enables keyset pagination. Offset is ignored and results start after Cursor,
+	// or at the first result when Cursor is nil.
+	CursorMode bool
+	Cursor     *SearchCursor
+	// SkipTotal skips the count query, which is the slowest part of a search on large organizations
+	SkipTotal bool
+	// Includes selects the related data loaded for detailed results; nil loads the default relations
+	Includes *ConsentIncludes
+	OrgID    string
 }
 
 // ConsentDetailResponse represents a detailed consent with related data
 type ConsentDetailResponse struct {
-	ID                         string                `json:"id"`
-	ConsentPurposes            []ConsentPurposeItem  `json:"consentPurpose"`
-	CreatedTime                int64                 `json:"createdTime"`
-	UpdatedTime                int64                 `json:"updatedTime"`
-	ClientID                   string                `json:"clientId"`
-	Type                       string                `json:"type"`
-	Status                     string                `json:"status"`
-	Frequency                  int                   `json:"frequency"`
-	ValidityTime               int64                 `json:"validityTime"`
-	RecurringIndicator         bool                  `json:"recurringIndicator"`
-	DataAccessValidityDuration int64                 `json:"dataAccessValidityDuration"`
-	Attributes                 map[string]string     `json:"attributes"`
-	Authorizations             []AuthorizationDetail `json:"authorizations"`
+	ID                         string                  `json:"id"`
+	ConsentPurposes            []ConsentPurposeItem    `json:"consentPurpose"`
+	CreatedTime                int64                   `json:"createdTime"`
+	UpdatedTime                int64                   `json:"updatedTime"`
+	ClientID                   string                  `json:"clientId"`
+	Type                       string                  `json:"type"`
+	Status                     string                  `json:"status"`
+	Frequency                  int                     `json:"frequency"`
+	ValidityTime               int64                   `json:"validityTime"`
+	RecurringIndicator         bool                    `json:"recurringIndicator"`
+	DataAccessValidityDuration int64                   `json:"dataAccessValidityDuration"`
+	Version                    int                     `json:"version"`
+	Attributes                 map[string]string       `json:"attributes"`
+	Tags                       []string                `json:"tags,omitempty"`
+	Authorizations             []AuthorizationDetail   `json:"authorizations"`
+	History                    []ConsentVersionSummary `json:"history,omitempty"` // Present with include=history
 }
 
 // AuthorizationDetail represents authorization resource details
@@ -288,6 +382,8 @@ type AuthorizationDetail struct {
 	Status      string      `json:"status"`
 	UpdatedTime int64       `json:"updatedTime"`
 	Resources   interface{} `json:"resources,omitempty"`
+	DelegatorID *string     `json:"delegatorId,omitempty"`
+	DelegateID  *string     `json:"delegateId,omitempty"`
 }
 
 // ConsentDetailSearchResponse wraps detailed consent search results
@@ -296,10 +392,31 @@ type ConsentDetailSearchResponse struct {
 	Metadata ConsentSearchMetadata   `json:"metadata"`
 }
 
-// ConsentRevokeRequest represents the request to revoke a consent
+// ConsentRevokeRequest represents the request to revoke a consent. Listing purposes or
+// authorizations revokes only those; the consent itself is revoked once none of its mandatory
+// purposes or authorizations is left.
 type ConsentRevokeRequest struct {
-	ActionBy         string `json:"actionBy" binding:"required"`
-	RevocationReason string `json:"revocationReason,omitempty"`
+	ActionBy         string   `json:"actionBy" binding:"required"`
+	RevocationReason string   `json:"revocationReason,omitempty"`
+	PurposeNames     []string `json:"purposeNames,omitempty"`
+	AuthorizationIDs []string `json:"authorizationIds,omitempty"`
+	// EffectiveAt is the Unix time in seconds the revocation takes effect. A future time schedules
+	// the revocation instead of applying it; an absent or past time revokes the consent now.
+	EffectiveAt *int64 `json:"effectiveAt,omitempty"`
+}
+
+// IsPartial reports whether the request revokes only some purposes or authorizations of the consent
+func (req *ConsentRevokeRequest) IsPartial() bool {
+	return len(req.PurposeNames) > 0 || len(req.AuthorizationIDs) > 0
+}
+
+// PartialRevocationReason is the status audit reason of a partial revocation, recorded as JSON so
+// that the revoked purposes and authorizations can be told apart from the free text reason
+type PartialRevocationReason struct {
+	RevocationReason string   `json:"revocationReason,omitempty"`
+	RevokedPurposes  []string `json:"revokedPurposes,omitempty"`
+	// RevokedAuthorizationIDs are the authorizations revoked by the request
+	RevokedAuthorizationIDs []string `json:"revokedAuthorizationIds,omitempty"`
 }
 
 // GetCreatedTime returns the created time as a time.Time
@@ -350,6 +467,21 @@ func (req *ConsentAPIRequest) ToConsentCreateRequest() (*ConsentCreateRequest, e
 		purposeNames[purposeName] = true
 	}
 
+	// Validate required authorizer roles
+	requiredAuthorizers := make(map[string]bool, len(req.RequiredAuthorizers))
+	for _, role := range req.RequiredAuthorizers {
+		if strings.TrimSpace(role) == "" {
+			return nil, fmt.Errorf("required authorizer roles cannot be empty")
+		}
+		if len(role) > 255 {
+			return nil, fmt.Errorf("required authorizer role too long (max 255 characters)")
+		}
+		if requiredAuthorizers[role] {
+			return nil, fmt.Errorf("duplicate required authorizer role found: %s", role)
+		}
+		requiredAuthorizers[role] = true
+	}
+
 	createReq := &ConsentCreateRequest{
 		ConsentPurpose:             consentPurposes,
 		ConsentType:                req.Type,
@@ -359,6 +491,8 @@ func (req *ConsentAPIRequest) ToConsentCreateRequest() (*ConsentCreateRequest, e
 		ConsentFrequency:           req.Frequency,
 		RecurringIndicator:         req.RecurringIndicator,
 		DataAccessValidityDuration: req.DataAccessValidityDuration,
+		ParentConsentID:            req.ParentConsentID,
+		RequiredAuthorizers:        req.RequiredAuthorizers,
 	}
 
 	// Map authorizations to auth resources
@@ -376,11 +510,14 @@ func (req *ConsentAPIRequest) ToConsentCreateRequest() (*ConsentCreateRequest, e
 				status = string(AuthStatusMappings.ApprovedState)
 			}
 
+			delegatorID, delegateID := auth.Delegation()
 			createReq.AuthResources[i] = authmodel.ConsentAuthResourceCreateRequest{
-				AuthType:   auth.Type,
-				UserID:     userID,
-				AuthStatus: status,
-				Resources:  auth.Resources,
+				AuthType:    auth.Type,
+				UserID:      userID,
+				AuthStatus:  status,
+				Resources:   auth.Resources,
+				DelegatorID: delegatorID,
+				DelegateID:  delegateID,
 			}
 		}
 	}
@@ -456,11 +593,14 @@ func (req *ConsentAPIUpdateRequest) ToConsentUpdateRequest() (*ConsentUpdateRequ
 				status = string(AuthStatusMappings.ApprovedState)
 			}
 
+			delegatorID, delegateID := auth.Delegation()
 			updateReq.AuthResources[i] = authmodel.ConsentAuthResourceCreateRequest{
-				AuthType:   auth.Type,
-				UserID:     userID,
-				AuthStatus: status, // Store the status value
-				Resources:  auth.Resources,
+				AuthType:    auth.Type,
+				UserID:      userID,
+				AuthStatus:  status, // Store the status value
+				Resources:   auth.Resources,
+				DelegatorID: delegatorID,
+				DelegateID:  delegateID,
 			}
 		}
 	}
@@ -481,9 +621,15 @@ type ConsentAPIResponse struct {
 	ValidityTime               *int64                     `json:"validityTime,omitempty"`
 	RecurringIndicator         *bool                      `json:"recurringIndicator,omitempty"`
 	DataAccessValidityDuration *int64                     `json:"dataAccessValidityDuration,omitempty"`
+	ParentConsentID            *string                    `json:"parentConsentId,omitempty"`
+	Version                    int                        `json:"version"`
 	Attributes                 map[string]string          `json:"attributes"`
+	Tags                       []string                   `json:"tags,omitempty"` // Present when the consent has tags
 	Authorizations             []AuthorizationAPIResponse `json:"authorizations"`
+	History                    []ConsentVersionSummary    `json:"history,omitempty"`          // Present in GET with include=history
+	Children                   []ConsentAPIResponse       `json:"children,omitempty"`         // Present in GET with include=children
 	ModifiedResponse           interface{}                `json:"modifiedResponse,omitempty"` // Present in GET/POST/PUT, excluded in validate
+	ScheduledUpdate            *ScheduledUpdateResponse   `json:"scheduledUpdate,omitempty"`  // Present in PUT with a future effectiveAt
 }
 
 // AuthorizationAPIResponse represents the API response format for authorization resource (external format)
@@ -494,6 +640,8 @@ type AuthorizationAPIResponse struct {
 	Status      string      `json:"status"`
 	UpdatedTime int64       `json:"updatedTime"`
 	Resources   interface{} `json:"resources"`
+	DelegatorID *string     `json:"delegatorId,omitempty"`
+	DelegateID  *string     `json:"delegateId,omitempty"`
 }
 
 // ToAPIResponse converts internal response format to API response format
@@ -522,7 +670,12 @@ func (resp *ConsentResponse) ToAPIResponse() *ConsentAPIResponse {
 		ValidityTime:               resp.ValidityTime,
 		RecurringIndicator:         resp.RecurringIndicator,
 		DataAccessValidityDuration: resp.DataAccessValidityDuration,
+		ParentConsentID:            resp.ParentConsentID,
+		Version:                    resp.Version,
 		Attributes:                 attributes,
+		Tags:                       resp.Tags,
+		History:                    resp.History,
+		ScheduledUpdate:            resp.ScheduledUpdate,
 		ModifiedResponse:           make(map[string]interface{}),
 		Authorizations:             make([]AuthorizationAPIResponse, 0),
 	}
@@ -550,13 +703,51 @@ func (resp *ConsentResponse) ToAPIResponse() *ConsentAPIResponse {
 				Status:      auth.AuthStatus,
 				UpdatedTime: auth.UpdatedTime,
 				Resources:   resources,
+				DelegatorID: auth.DelegatorID,
+				DelegateID:  auth.DelegateID,
 			}
 		}
 	}
 
+	if resp.Children != nil {
+		apiResp.Children = make([]ConsentAPIResponse, len(resp.Children))
+		for i := range resp.Children {
+			apiResp.Children[i] = *resp.Children[i].ToAPIResponse()
+		}
+	}
+
+	if resp.ModifiedResponse != nil {
+		apiResp.ModifiedResponse = resp.ModifiedResponse
+	}
+
 	return apiResp
 }
 
+// ETag returns a strong entity tag for the consent as returned with variant, the query parameters that
+// shape the response. It changes with the updated time and version of the consent, of its
+// authorizations and of its child consents.
+func (resp *ConsentResponse) ETag(variant string) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n", variant)
+	resp.writeETagState(hash)
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// writeETagState writes the state the consent's entity tag is computed from
+func (resp *ConsentResponse) writeETagState(hash io.Writer) {
+	fmt.Fprintf(hash, "%s:%d:%d\n", resp.ConsentID, resp.UpdatedTime, resp.Version)
+	// Tags change without updating the consent
+	for _, tag := range resp.Tags {
+		fmt.Fprintf(hash, "tag:%s\n", tag)
+	}
+	for _, auth := range resp.AuthResources {
+		fmt.Fprintf(hash, "auth:%s:%d\n", auth.AuthID, auth.UpdatedTime)
+	}
+	for i := range resp.Children {
+		resp.Children[i].writeETagState(hash)
+	}
+}
+
 // ValidateRequest represents the payload for validation API
 type ValidateRequest struct {
 	Headers         map[string]interface{} `json:"headers"`
@@ -565,7 +756,11 @@ type ValidateRequest struct {
 	ConsentID       string                 `json:"consentId"`
 	UserID          string                 `json:"userId"`
 	ClientID        string                 `json:"clientId"`
-	ResourceParams  struct {
+	// RequestedPurposes and RequestedResources ask for a grant/deny decision per purpose name and
+	// resource identifier in addition to the overall result
+	RequestedPurposes  []string `json:"requestedPurposes,omitempty"`
+	RequestedResources []string `json:"requestedResources,omitempty"`
+	ResourceParams     struct {
 		Resource   string `json:"resource"`
 		HTTPMethod string `json:"httpMethod"`
 		Context    string `json:"context"`
@@ -574,14 +769,52 @@ type ValidateRequest struct {
 
 // ValidateResponse represents the response for validation API
 type ValidateResponse struct {
-	IsValid            bool                        `json:"isValid"`
-	ModifiedPayload    interface{}                 `json:"modifiedPayload,omitempty"`
-	ErrorCode          int                         `json:"errorCode,omitempty"`
-	ErrorMessage       string                      `json:"errorMessage,omitempty"`
-	ErrorDescription   string                      `json:"errorDescription,omitempty"`
+	IsValid          bool        `json:"isValid"`
+	ModifiedPayload  interface{} `json:"modifiedPayload,omitempty"`
+	ErrorCode        int         `json:"errorCode,omitempty"`
+	ErrorMessage     string      `json:"errorMessage,omitempty"`
+	ErrorDescription string      `json:"errorDescription,omitempty"`
+	// StalePurposes lists the purposes whose expiresAt has passed and need re-confirmation. It is
+	// informational and does not affect isValid.
+	StalePurposes []string `json:"stalePurposes,omitempty"`
+	// PurposeDecisions and ResourceDecisions hold the decision for each requested purpose and
+	// resource. They are only set when the request asks for them.
+	PurposeDecisions  map[string]ValidateDecision `json:"purposeDecisions,omitempty"`
+	ResourceDecisions map[string]ValidateDecision `json:"resourceDecisions,omitempty"`
+	// DataAccessWindow is set for consents with a dataAccessValidityDuration
+	DataAccessWindow   *DataAccessWindow           `json:"dataAccessWindow,omitempty"`
 	ConsentInformation *ValidateConsentAPIResponse `json:"consentInformation,omitempty"`
 }
 
+// DataAccessWindow is the period in which data may be accessed under a consent. It starts at the
+// last authorization of the consent and lasts dataAccessValidityDuration seconds. Times are in
+// milliseconds.
+type DataAccessWindow struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// Decisions returned for requested purposes and resources
+const (
+	ValidateDecisionGrant = "GRANT"
+	ValidateDecisionDeny  = "DENY"
+)
+
+// Reasons for denying a requested purpose or resource
+const (
+	DenyReasonConsentInvalid        = "consent_invalid"
+	DenyReasonPurposeNotInConsent   = "purpose_not_in_consent"
+	DenyReasonPurposeNotApproved    = "purpose_not_approved"
+	DenyReasonPurposeReconfirmation = "purpose_reconfirmation_required"
+	DenyReasonResourceNotAuthorized = "resource_not_authorized"
+)
+
+// ValidateDecision is the grant or deny decision for a single requested purpose or resource
+type ValidateDecision struct {
+	Decision string `json:"decision"`
+	Reason   string `json:"reason,omitempty"`
+}
+
 // ValidateConsentAPIResponse represents consent information in validate response (excludes modifiedResponse)
 type ValidateConsentAPIResponse struct {
 	ID                         string                     `json:"id"`
@@ -626,4 +859,17 @@ type ConsentRevokeResponse struct {
 	ActionTime       int64  `json:"actionTime"`
 	ActionBy         string `json:"actionBy"`
 	RevocationReason string `json:"revocationReason,omitempty"`
+	// ConsentStatus is the status of the consent after the revocation. A partial revocation leaves
+	// the consent in its status while mandatory purposes and authorizations remain.
+	ConsentStatus string `json:"consentStatus"`
+	// RevokedPurposes and RevokedAuthorizationIDs list what a partial revocation revoked
+	RevokedPurposes         []string `json:"revokedPurposes,omitempty"`
+	RevokedAuthorizationIDs []string `json:"revokedAuthorizationIds,omitempty"`
+	// RevokedChildConsentIDs lists the descendant consents revoked with the consent
+	RevokedChildConsentIDs []string `json:"revokedChildConsentIds,omitempty"`
+	// ScheduledRevocation is set when the revocation was scheduled for a future effectiveAt, in
+	// which case nothing was revoked yet
+	ScheduledRevocation *ScheduledRevocationResponse `json:"scheduledRevocation,omitempty"`
+	// ModifiedResponse holds the additions of the enrich_consent_revoke_response extension hook
+	ModifiedResponse interface{} `json:"modifiedResponse,omitempty"`
 }
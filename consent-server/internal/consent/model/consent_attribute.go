@@ -26,6 +26,12 @@ type ConsentAttributeResponse struct {
 	OrgID      string            `json:"orgId"`
 }
 
+// AttributeFilter matches consents that have an attribute. An empty Value matches any value of the key.
+type AttributeFilter struct {
+	Key   string
+	Value string
+}
+
 // ConsentAttributeSearchResponse represents the response for attribute search
 type ConsentAttributeSearchResponse struct {
 	ConsentIDs []string `json:"consentIds"`
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// fuzzSeeds are malicious request bodies shared by the decoding fuzz targets
+var fuzzSeeds = []string{
+	`{"type":"accounts","authorizations":[{"type":"authorisation","status":"APPROVED"}]}`,
+	`{"type":"' OR '1'='1","attributes":{"k":"'; DROP TABLE CONSENT; --"}}`,
+	`{"type":"accounts","attributes":{"\"quoted\"":"\\\"\\u0027\\u0000"}}`,
+	`{"type":"\u202eстнuocca","consentPurpose":[{"name":"\ufeffmarketing","isMandatory":false}]}`,
+	`{"type":"accounts","consentPurpose":[{"name":"a","value":[[[[[[[[[[[[[[[[[[[[{}]]]]]]]]]]]]]]]]]]]}]}`,
+	`{"type":"accounts","consentPurpose":[{"name":"a"},{"name":"a"}]}`,
+	`{"type":"accounts","validityTime":-9223372036854775808,"frequency":-1}`,
+	`{"type":"accounts","authorizations":[{"type":"x","resources":{"a":{"b":{"c":{"d":{"e":"f"}}}}}}]}`,
+	`{"type":` + `"` + strings.Repeat("A", 4096) + `"}`,
+	`{"type":"accounts","authorizations":null,"consentPurpose":null}`,
+	`[]`,
+	`null`,
+}
+
+func setupFuzzConfig() {
+	config.SetGlobal(&config.Config{
+		Consent: config.ConsentConfig{
+			StatusMappings: config.ConsentStatusMappings{
+				ActiveStatus:   "ACTIVE",
+				ExpiredStatus:  "EXPIRED",
+				RevokedStatus:  "REVOKED",
+				CreatedStatus:  "CREATED",
+				RejectedStatus: "REJECTED",
+			},
+			AuthStatusMappings: config.AuthStatusMappings{
+				ApprovedState:      "APPROVED",
+				RejectedState:      "REJECTED",
+				CreatedState:       "CREATED",
+				SystemExpiredState: "SYS_EXPIRED",
+				SystemRevokedState: "SYS_REVOKED",
+			},
+		},
+	})
+}
+
+// FuzzConsentAPIRequest decodes arbitrary bodies as consent create requests and
+// converts them to the internal format.
+func FuzzConsentAPIRequest(f *testing.F) {
+	setupFuzzConfig()
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var req ConsentAPIRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			return
+		}
+
+		createReq, err := req.ToConsentCreateRequest()
+		if err != nil {
+			return
+		}
+		if createReq.ConsentType != req.Type {
+			t.Fatalf("consent type changed during conversion: %q -> %q", req.Type, createReq.ConsentType)
+		}
+		if len(createReq.AuthResources) != len(req.Authorizations) {
+			t.Fatalf("expected %d auth resources, got %d", len(req.Authorizations), len(createReq.AuthResources))
+		}
+
+		seen := make(map[string]bool)
+		for _, cp := range createReq.ConsentPurpose {
+			if seen[cp.Name] {
+				t.Fatalf("duplicate purpose %q accepted", cp.Name)
+			}
+			seen[cp.Name] = true
+			if *cp.IsMandatory && !*cp.IsUserApproved {
+				t.Fatalf("mandatory purpose %q accepted without user approval", cp.Name)
+			}
+		}
+	})
+}
+
+// FuzzConsentAPIUpdateRequest decodes arbitrary bodies as consent update requests and
+// converts them to the internal format.
+func FuzzConsentAPIUpdateRequest(f *testing.F) {
+	setupFuzzConfig()
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var req ConsentAPIUpdateRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			return
+		}
+
+		if _, err := req.ToConsentUpdateRequest(); err != nil {
+			return
+		}
+	})
+}
+
+// FuzzJSONScan feeds arbitrary database values through the JSON column scanner
+func FuzzJSONScan(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Add([]byte(`{"a":"\ud800"}`))
+	f.Add([]byte(strings.Repeat("[", 10000) + strings.Repeat("]", 10000)))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var j JSON
+		if err := j.Scan(data); err != nil {
+			return
+		}
+		if !json.Valid(j) {
+			t.Fatalf("scanner produced invalid JSON: %q", string(j))
+		}
+	})
+}
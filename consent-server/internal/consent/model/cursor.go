@@ -0,0 +1,35 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// SearchCursor is the position of a consent in the search order (created time, then consent ID, both descending)
+type SearchCursor struct {
+	CreatedTime int64  `json:"t"`
+	ConsentID   string `json:"id"`
+}
+
+// ErrInvalidCursor is returned when a cursor token cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeSearchCursor encodes a cursor as an opaque URL-safe token
+func EncodeSearchCursor(cursor SearchCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeSearchCursor decodes a token produced by EncodeSearchCursor
+func DecodeSearchCursor(token string) (*SearchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor SearchCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ConsentID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
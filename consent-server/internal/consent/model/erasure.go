@@ -0,0 +1,25 @@
+package model
+
+// UserErasureReport describes the user data removed by a right-to-erasure request. The user is only
+// named by the hash of the user ID, so the report can be kept as evidence without personal data.
+type UserErasureReport struct {
+	ErasureID  string          `json:"erasureId"`
+	OrgID      string          `json:"orgId"`
+	UserIDHash string          `json:"userIdHash"` // Hex encoded SHA-256 of the erased user ID
+	Pseudonym  string          `json:"pseudonym"`  // Replaces the user ID in authorizations and audits
+	ErasedTime int64           `json:"erasedTime"` // Unix timestamp in milliseconds
+	Consents   []ErasedConsent `json:"consents"`
+}
+
+// ErasedConsent describes a consent of the erased user
+type ErasedConsent struct {
+	ConsentID         string   `json:"consentId"`
+	AuthorizationIDs  []string `json:"authorizationIds"`  // Authorizations now held by the pseudonym
+	RemovedAttributes []string `json:"removedAttributes"` // Keys of the identifying attributes removed
+}
+
+// UserErasureResponse represents an erasure report with its signature
+type UserErasureResponse struct {
+	Report UserErasureReport `json:"report"`
+	JWS    string            `json:"jws,omitempty"` // Compact JWS over the report; empty when receipt signing is not configured
+}
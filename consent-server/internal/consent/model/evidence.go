@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Length limits of consent evidence, in characters
+const (
+	MaxEvidenceChannelLength   = 64
+	MaxEvidenceUserAgentLength = 512
+	MaxEvidenceUIVersionLength = 64
+)
+
+// presentedTextChecksumPattern matches a hex encoded SHA-256 digest
+var presentedTextChecksumPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// ConsentEvidenceRequest describes how a consent was collected. It is given with a create or update
+// and recorded against the version of the consent it produced, so that the consent can be shown to
+// have been given knowingly: through which channel, from which device, in which version of the
+// consent UI and on which text, identified by the SHA-256 checksum of the text presented.
+type ConsentEvidenceRequest struct {
+	Channel               string `json:"channel"`
+	IPAddress             string `json:"ipAddress,omitempty"`
+	UserAgent             string `json:"userAgent,omitempty"`
+	UIVersion             string `json:"uiVersion,omitempty"`
+	PresentedTextChecksum string `json:"presentedTextChecksum,omitempty"`
+}
+
+// Validate checks that the evidence names its channel and that its fields are well formed
+func (r ConsentEvidenceRequest) Validate() error {
+	if strings.TrimSpace(r.Channel) == "" {
+		return fmt.Errorf("channel is required")
+	}
+	if utf8.RuneCountInString(r.Channel) > MaxEvidenceChannelLength {
+		return fmt.Errorf("channel too long (max %d chars)", MaxEvidenceChannelLength)
+	}
+	if r.IPAddress != "" && net.ParseIP(r.IPAddress) == nil {
+		return fmt.Errorf("ipAddress must be an IPv4 or IPv6 address")
+	}
+	if utf8.RuneCountInString(r.UserAgent) > MaxEvidenceUserAgentLength {
+		return fmt.Errorf("userAgent too long (max %d chars)", MaxEvidenceUserAgentLength)
+	}
+	if utf8.RuneCountInString(r.UIVersion) > MaxEvidenceUIVersionLength {
+		return fmt.Errorf("uiVersion too long (max %d chars)", MaxEvidenceUIVersionLength)
+	}
+	if r.PresentedTextChecksum != "" && !presentedTextChecksumPattern.MatchString(r.PresentedTextChecksum) {
+		return fmt.Errorf("presentedTextChecksum must be a hex encoded SHA-256 digest")
+	}
+	return nil
+}
+
+// ConsentEvidence represents the CONSENT_EVIDENCE table, the evidence recorded for a version of a
+// consent. The IP address and user agent are removed when the consent is anonymized.
+type ConsentEvidence struct {
+	ID                    string  `json:"id"`
+	ConsentID             string  `json:"consentId"`
+	ConsentVersion        int     `json:"consentVersion"`
+	Channel               string  `json:"channel"`
+	IPAddress             *string `json:"ipAddress,omitempty"`
+	UserAgent             *string `json:"userAgent,omitempty"`
+	UIVersion             *string `json:"uiVersion,omitempty"`
+	PresentedTextChecksum *string `json:"presentedTextChecksum,omitempty"`
+	CapturedTime          int64   `json:"capturedTime"`
+	OrgID                 string  `json:"-"`
+}
+
+// NewConsentEvidence returns the evidence of a request, captured for a version of a consent
+func NewConsentEvidence(req ConsentEvidenceRequest, evidenceID, consentID, orgID string, consentVersion int, capturedTime int64) *ConsentEvidence {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	checksum := optional(strings.ToLower(req.PresentedTextChecksum))
+	return &ConsentEvidence{
+		ID:                    evidenceID,
+		ConsentID:             consentID,
+		ConsentVersion:        consentVersion,
+		Channel:               req.Channel,
+		IPAddress:             optional(req.IPAddress),
+		UserAgent:             optional(req.UserAgent),
+		UIVersion:             optional(req.UIVersion),
+		PresentedTextChecksum: checksum,
+		CapturedTime:          capturedTime,
+		OrgID:                 orgID,
+	}
+}
+
+// ConsentEvidenceListResponse represents the evidence of a consent, oldest first
+type ConsentEvidenceListResponse struct {
+	Data []ConsentEvidence `json:"data"`
+}
@@ -0,0 +1,30 @@
+package model
+
+// Consent export formats
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// ConsentExportRecord is a consent flattened into a single row for CSV and NDJSON exports
+type ConsentExportRecord struct {
+	ConsentID                  string `json:"consentId"`
+	ClientID                   string `json:"clientId"`
+	ConsentType                string `json:"consentType"`
+	CurrentStatus              string `json:"currentStatus"`
+	CreatedTime                int64  `json:"createdTime"`
+	UpdatedTime                int64  `json:"updatedTime"`
+	ValidityTime               *int64 `json:"validityTime,omitempty"`
+	Frequency                  *int   `json:"frequency,omitempty"`
+	RecurringIndicator         *bool  `json:"recurringIndicator,omitempty"`
+	DataAccessValidityDuration *int64 `json:"dataAccessValidityDuration,omitempty"`
+	// Purposes lists the names of all purposes of the consent and ApprovedPurposes the ones the
+	// user approved
+	Purposes         []string `json:"purposes"`
+	ApprovedPurposes []string `json:"approvedPurposes"`
+	UserIDs          []string `json:"userIds"`
+	// AuthorizationStatuses lists "<userId>:<status>" for each authorization, with the
+	// authorization ID in place of the user ID when no user is bound
+	AuthorizationStatuses []string          `json:"authorizationStatuses"`
+	Attributes            map[string]string `json:"attributes"`
+}
@@ -0,0 +1,50 @@
+package model
+
+// Payloads exchanged with the service extension hooks. Pre-process hooks may return the payload
+// with a rewritten request; enrich hooks may return the payload with a modified response.
+
+// ConsentCreateHookPayload is sent to the pre_process_consent_creation hook
+type ConsentCreateHookPayload struct {
+	ClientID       string            `json:"clientId"`
+	ConsentRequest ConsentAPIRequest `json:"consentRequest"`
+}
+
+// ConsentUpdateHookPayload is sent to the pre_process_consent_update hook
+type ConsentUpdateHookPayload struct {
+	ConsentID      string                  `json:"consentId"`
+	ConsentRequest ConsentAPIUpdateRequest `json:"consentRequest"`
+}
+
+// ConsentRevokeHookPayload is sent to the pre_process_consent_revoke hook
+type ConsentRevokeHookPayload struct {
+	ConsentID     string               `json:"consentId"`
+	RevokeRequest ConsentRevokeRequest `json:"revokeRequest"`
+}
+
+// ConsentValidateHookPayload is sent to the pre_process_consent_validation hook
+type ConsentValidateHookPayload struct {
+	ValidateRequest ValidateRequest `json:"validateRequest"`
+}
+
+// ConsentEnrichHookPayload is sent to the enrich hooks of consent create, update and revoke. The
+// extension returns the additions to the response in ModifiedResponse.
+type ConsentEnrichHookPayload struct {
+	Consent          *ConsentAPIResponse    `json:"consent,omitempty"`
+	Revocation       *ConsentRevokeResponse `json:"revocation,omitempty"`
+	ModifiedResponse interface{}            `json:"modifiedResponse,omitempty"`
+}
+
+// ValidateEnrichHookPayload is sent to the enrich_consent_validation_response hook. The extension
+// may change the validation result and set the modified payload returned to the caller.
+type ValidateEnrichHookPayload struct {
+	ValidateRequest  ValidateRequest  `json:"validateRequest"`
+	ValidateResponse ValidateResponse `json:"validateResponse"`
+}
+
+// ValidationPolicyHookPayload is sent to the evaluate_validation_policy hook for each extension
+// rule of a validation policy. The extension denies the validation by rejecting the call.
+type ValidationPolicyHookPayload struct {
+	Rule               string                      `json:"rule"`
+	ValidateRequest    ValidateRequest             `json:"validateRequest"`
+	ConsentInformation *ValidateConsentAPIResponse `json:"consentInformation"`
+}
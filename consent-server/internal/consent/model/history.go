@@ -0,0 +1,47 @@
+package model
+
+// ConsentHistory represents the CONSENT_HISTORY table. Each row is a snapshot of a consent
+// version that was superseded by an amendment.
+type ConsentHistory struct {
+	ConsentID   string  `db:"CONSENT_ID" json:"consentId"`
+	Version     int     `db:"VERSION" json:"version"`
+	Snapshot    string  `db:"SNAPSHOT" json:"snapshot"` // ConsentAPIResponse of the version, as JSON
+	AmendedTime int64   `db:"AMENDED_TIME" json:"amendedTime"`
+	AmendedBy   *string `db:"AMENDED_BY" json:"amendedBy,omitempty"`
+	Reason      *string `db:"REASON" json:"reason,omitempty"`
+	OrgID       string  `db:"ORG_ID" json:"orgId"`
+}
+
+// ConsentAmendmentRequest represents the API payload for amending a consent.
+// Consent fields follow the same semantics as ConsentAPIUpdateRequest.
+type ConsentAmendmentRequest struct {
+	ConsentAPIUpdateRequest
+	AmendedBy string `json:"amendedBy,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ConsentVersionSummary describes a superseded consent version
+type ConsentVersionSummary struct {
+	Version     int     `json:"version"`
+	AmendedTime int64   `json:"amendedTime"`
+	AmendedBy   *string `json:"amendedBy,omitempty"`
+	Reason      *string `json:"reason,omitempty"`
+}
+
+// ConsentVersionListResponse represents the response for listing consent versions
+type ConsentVersionListResponse struct {
+	ConsentID      string                  `json:"consentId"`
+	CurrentVersion int                     `json:"currentVersion"`
+	Data           []ConsentVersionSummary `json:"data"`
+}
+
+// ConsentVersionResponse represents a consent as it was at a given version.
+// AmendedTime is omitted for the current version.
+type ConsentVersionResponse struct {
+	Version     int                 `json:"version"`
+	Current     bool                `json:"current"`
+	AmendedTime *int64              `json:"amendedTime,omitempty"`
+	AmendedBy   *string             `json:"amendedBy,omitempty"`
+	Reason      *string             `json:"reason,omitempty"`
+	Consent     *ConsentAPIResponse `json:"consent"`
+}
@@ -0,0 +1,191 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Relations that can be requested with the include query parameter
+const (
+	IncludeAuthorizations = "authorizations"
+	IncludePurposes       = "purposes"
+	IncludeAttributes     = "attributes"
+	IncludeTags           = "tags"
+	IncludeHistory        = "history"
+	IncludeChildren       = "children"
+)
+
+// ConsentIncludes selects the related data loaded with a consent. Each relation costs a store query,
+// per consent on GET and per page on search.
+type ConsentIncludes struct {
+	Authorizations bool
+	Purposes       bool
+	Attributes     bool
+	Tags           bool
+	History        bool // Superseded versions of the consent
+	Children       bool // Child consents, recursively; GET only
+}
+
+// DefaultConsentIncludes returns the relations loaded when no include parameter is given
+func DefaultConsentIncludes() ConsentIncludes {
+	return ConsentIncludes{Authorizations: true, Purposes: true, Attributes: true, Tags: true}
+}
+
+// includeResponseKeys maps the relations to the response field holding them
+var includeResponseKeys = map[string]string{
+	IncludeAuthorizations: "authorizations",
+	IncludePurposes:       "consentPurpose",
+	IncludeAttributes:     "attributes",
+	IncludeTags:           "tags",
+	IncludeHistory:        "history",
+	IncludeChildren:       "children",
+}
+
+// ResponseSelection is the parsed fields and include query parameters of a consent read
+type ResponseSelection struct {
+	Includes ConsentIncludes
+	// keys are the top-level response fields that are returned; nil returns every field
+	keys map[string]bool
+}
+
+// ParseResponseSelection parses the comma-separated fields and include query parameters against
+// the JSON fields of response, a consent response struct. Listing any of authorizations, purposes,
+// attributes or tags in include loads only the listed ones; history and children are loaded in addition.
+// Relations that are not loaded, and fields not listed in fields, are left out of the response.
+// Relations left out by fields are not loaded either.
+func ParseResponseSelection(fields, include string, response interface{}, allowedIncludes ...string) (*ResponseSelection, error) {
+	selection := &ResponseSelection{Includes: DefaultConsentIncludes()}
+	known := jsonFieldNames(reflect.TypeOf(response))
+
+	if include != "" {
+		requested := make(map[string]bool)
+		for _, name := range strings.Split(include, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if _, ok := includeResponseKeys[name]; !ok || !slices.Contains(allowedIncludes, name) {
+				return nil, fmt.Errorf("unsupported include '%s'", name)
+			}
+			requested[name] = true
+		}
+		if requested[IncludeAuthorizations] || requested[IncludePurposes] || requested[IncludeAttributes] || requested[IncludeTags] {
+			selection.Includes.Authorizations = requested[IncludeAuthorizations]
+			selection.Includes.Purposes = requested[IncludePurposes]
+			selection.Includes.Attributes = requested[IncludeAttributes]
+			selection.Includes.Tags = requested[IncludeTags]
+		}
+		selection.Includes.History = requested[IncludeHistory]
+		selection.Includes.Children = requested[IncludeChildren]
+	}
+
+	if fields != "" {
+		selection.keys = map[string]bool{"id": true}
+		for _, name := range strings.Split(fields, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if !known[name] {
+				return nil, fmt.Errorf("unknown field '%s'", name)
+			}
+			selection.keys[name] = true
+		}
+	}
+
+	// Relations outside the selected fields are not loaded, and relations that are not loaded are
+	// not returned. History and children are omitted from responses when they are not loaded.
+	loaded := map[string]*bool{
+		IncludeAuthorizations: &selection.Includes.Authorizations,
+		IncludePurposes:       &selection.Includes.Purposes,
+		IncludeAttributes:     &selection.Includes.Attributes,
+		IncludeTags:           &selection.Includes.Tags,
+		IncludeHistory:        &selection.Includes.History,
+		IncludeChildren:       &selection.Includes.Children,
+	}
+	for relation, isLoaded := range loaded {
+		key := includeResponseKeys[relation]
+		if selection.keys != nil && !selection.keys[key] {
+			*isLoaded = false
+		}
+		if !*isLoaded && known[key] && relation != IncludeHistory && relation != IncludeChildren {
+			if selection.keys == nil {
+				selection.keys = make(map[string]bool, len(known))
+				for name := range known {
+					selection.keys[name] = true
+				}
+			}
+			delete(selection.keys, key)
+		}
+	}
+
+	return selection, nil
+}
+
+// Apply returns item with only the selected fields, or item itself when every field is selected.
+// Child consents are reduced to the same fields.
+func (s *ResponseSelection) Apply(item interface{}) (interface{}, error) {
+	if s.keys == nil {
+		return item, nil
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	return s.selectFields(data)
+}
+
+// selectFields reduces a JSON encoded consent to the selected fields
+func (s *ResponseSelection) selectFields(data json.RawMessage) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]json.RawMessage, len(s.keys))
+	for key, value := range all {
+		if !s.keys[key] {
+			continue
+		}
+		if key == includeResponseKeys[IncludeChildren] {
+			var children []json.RawMessage
+			if err := json.Unmarshal(value, &children); err != nil {
+				return nil, err
+			}
+			reduced := make([]map[string]json.RawMessage, len(children))
+			for i, child := range children {
+				selectedChild, err := s.selectFields(child)
+				if err != nil {
+					return nil, err
+				}
+				reduced[i] = selectedChild
+			}
+			encoded, err := json.Marshal(reduced)
+			if err != nil {
+				return nil, err
+			}
+			value = encoded
+		}
+		selected[key] = value
+	}
+	return selected, nil
+}
+
+// jsonFieldNames returns the JSON names of the fields of a struct type
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
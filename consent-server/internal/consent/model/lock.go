@@ -0,0 +1,22 @@
+package model
+
+// ConsentLock is a lease on a consent taken while its user is authorizing it. Until the lock expires
+// or is released, only callers presenting LockToken can change the consent or its authorizations.
+type ConsentLock struct {
+	ConsentID  string `json:"consentId"`
+	LockToken  string `json:"lockToken"`
+	LockedBy   string `json:"lockedBy"` // Client ID of the caller that took the lock
+	LockedTime int64  `json:"lockedTime"`
+	ExpiryTime int64  `json:"expiryTime"`
+	OrgID      string `json:"-"`
+}
+
+// IsActive reports whether the lock is still held at now, in milliseconds
+func (l *ConsentLock) IsActive(now int64) bool {
+	return l != nil && l.ExpiryTime > now
+}
+
+// Blocks reports whether the lock keeps a caller presenting token from changing the consent at now
+func (l *ConsentLock) Blocks(token string, now int64) bool {
+	return l.IsActive(now) && l.LockToken != token
+}
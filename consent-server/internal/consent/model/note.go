@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Length limits of consent notes, in characters
+const (
+	MaxNoteAuthorLength = 255
+	MaxNoteTextLength   = 4000
+)
+
+// ConsentNote represents the CONSENT_NOTE table. Notes are free text attached to a consent by support
+// agents and systems; they are kept apart from the consent's attributes and never returned with it.
+type ConsentNote struct {
+	ID          string `json:"id"`
+	ConsentID   string `json:"consentId"`
+	Author      string `json:"author"`
+	Text        string `json:"text"`
+	CreatedTime int64  `json:"createdTime"`
+	OrgID       string `json:"-"`
+}
+
+// ConsentNoteRequest represents the request body for adding a note to a consent
+type ConsentNoteRequest struct {
+	Author string `json:"author"`
+	Text   string `json:"text"`
+}
+
+// ConsentNoteListResponse represents the notes of a consent, oldest first
+type ConsentNoteListResponse struct {
+	Data []ConsentNote `json:"data"`
+}
+
+// Validate checks that the note has an author and text within the length limits
+func (r ConsentNoteRequest) Validate() error {
+	if strings.TrimSpace(r.Author) == "" {
+		return fmt.Errorf("author is required")
+	}
+	if utf8.RuneCountInString(r.Author) > MaxNoteAuthorLength {
+		return fmt.Errorf("author too long (max %d chars)", MaxNoteAuthorLength)
+	}
+	if strings.TrimSpace(r.Text) == "" {
+		return fmt.Errorf("text is required")
+	}
+	if utf8.RuneCountInString(r.Text) > MaxNoteTextLength {
+		return fmt.Errorf("text too long (max %d chars)", MaxNoteTextLength)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package model
+
+// OwnershipTransferRequest represents the admin payload for moving consents from one user to another
+type OwnershipTransferRequest struct {
+	FromUserID string   `json:"fromUserId"`
+	ToUserID   string   `json:"toUserId"`
+	ConsentIDs []string `json:"consentIds,omitempty"` // Limits the transfer to these consents; all of the user's consents otherwise
+	Reason     string   `json:"reason,omitempty"`
+	ActionBy   string   `json:"actionBy"`
+}
+
+// OwnershipTransferResponse represents the result of a consent ownership transfer
+type OwnershipTransferResponse struct {
+	FromUserID      string                   `json:"fromUserId"`
+	ToUserID        string                   `json:"toUserId"`
+	TransferredTime int64                    `json:"transferredTime"`
+	Transferred     []TransferredConsent     `json:"transferred"`
+	Skipped         []SkippedTransferConsent `json:"skipped"`
+}
+
+// TransferredConsent describes a consent whose authorizations were moved to the new user
+type TransferredConsent struct {
+	ConsentID        string   `json:"consentId"`
+	Version          int      `json:"version"` // Version after the transfer; the previous version is kept in the consent history
+	AuthorizationIDs []string `json:"authorizationIds"`
+}
+
+// SkippedTransferConsent describes a consent that was left with the original user
+type SkippedTransferConsent struct {
+	ConsentID string `json:"consentId"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+}
@@ -0,0 +1,45 @@
+package model
+
+// Preference states of a purpose across the consents of a user
+const (
+	PreferenceOptedIn  = "OPTED_IN"
+	PreferenceOptedOut = "OPTED_OUT"
+	PreferenceMixed    = "MIXED"
+)
+
+// UserPreferencesResponse lists a user's choice for each purpose of their consents
+type UserPreferencesResponse struct {
+	UserID      string              `json:"userId"`
+	Preferences []PurposePreference `json:"preferences"`
+}
+
+// PurposePreference is a user's choice for a purpose, aggregated over the consents linking it
+type PurposePreference struct {
+	PurposeName string `json:"purposeName"`
+	// State is OPTED_IN when every consent approves the purpose, OPTED_OUT when none does, and
+	// MIXED otherwise
+	State           string              `json:"state"`
+	IsMandatory     bool                `json:"isMandatory"`               // Mandatory in any of the consents
+	LastConfirmedAt *int64              `json:"lastConfirmedAt,omitempty"` // Latest confirmation in any of the consents
+	Consents        []PreferenceConsent `json:"consents"`
+}
+
+// PreferenceConsent is a consent linking a purpose, with the user's approval of the purpose in it
+type PreferenceConsent struct {
+	ConsentID   string `json:"consentId"`
+	ConsentType string `json:"consentType"`
+	OptedIn     bool   `json:"optedIn"`
+	IsMandatory bool   `json:"isMandatory"`
+}
+
+// UserPreferencesUpdateRequest changes a user's choice for some purposes. Purposes left out are
+// not changed.
+type UserPreferencesUpdateRequest struct {
+	Preferences []PreferenceUpdate `json:"preferences"`
+}
+
+// PreferenceUpdate opts a user in to or out of a purpose in every consent linking it
+type PreferenceUpdate struct {
+	PurposeName string `json:"purposeName"`
+	OptedIn     *bool  `json:"optedIn"`
+}
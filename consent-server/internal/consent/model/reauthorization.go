@@ -0,0 +1,25 @@
+package model
+
+// ConsentReauthorizationRequest represents the payload for sending a consent back to its users for
+// re-authorization
+type ConsentReauthorizationRequest struct {
+	// ValidityTime is the validity time the consent gets. Defaults to the organization's default
+	// validity from now.
+	ValidityTime *int64 `json:"validityTime,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	ActionBy     string `json:"actionBy"`
+}
+
+// ConsentReauthorizationResponse represents the result of a re-authorization request
+type ConsentReauthorizationResponse struct {
+	ConsentID      string `json:"consentId"`
+	PreviousStatus string `json:"previousStatus"`
+	Status         string `json:"status"`
+	// AuthorizationStatus is the status all authorizations of the consent were reset to
+	AuthorizationStatus  string `json:"authorizationStatus"`
+	ValidityTime         int64  `json:"validityTime"`
+	PreviousValidityTime *int64 `json:"previousValidityTime,omitempty"`
+	Reason               string `json:"reason"`
+	ActionBy             string `json:"actionBy"`
+	ActionTime           int64  `json:"actionTime"`
+}
@@ -0,0 +1,68 @@
+package model
+
+// ConsentReceiptVersion identifies the receipt format. Field names follow the Kantara Initiative
+// Consent Receipt Specification v1.1, the basis of ISO/IEC TS 27560.
+const ConsentReceiptVersion = "KI-CR-v1.1.0"
+
+// ConsentReceiptResponse is the API response for a consent receipt. JWS is the compact JSON Web
+// Signature whose payload is the receipt, so the receipt can be verified with the public key.
+type ConsentReceiptResponse struct {
+	Receipt ConsentReceipt `json:"receipt"`
+	JWS     string         `json:"jws"`
+}
+
+// ConsentReceipt is a record of the consent given by a PII principal, issued as evidence of consent
+type ConsentReceipt struct {
+	Version          string                 `json:"version"`
+	Jurisdiction     string                 `json:"jurisdiction,omitempty"`
+	ConsentTimestamp int64                  `json:"consentTimestamp"` // Seconds since epoch
+	CollectionMethod string                 `json:"collectionMethod"`
+	ConsentReceiptID string                 `json:"consentReceiptID"`
+	PublicKey        string                 `json:"publicKey"`
+	Language         string                 `json:"language,omitempty"`
+	PiiPrincipalID   string                 `json:"piiPrincipalId"`
+	PiiControllers   []ReceiptPiiController `json:"piiControllers"`
+	PolicyURL        string                 `json:"policyUrl,omitempty"`
+	Services         []ReceiptService       `json:"services"`
+	Sensitive        bool                   `json:"sensitive"`
+	SpiCat           []string               `json:"spiCat"`
+
+	// Details of the consent record the receipt was issued for
+	ConsentID      string `json:"consentId"`
+	ConsentVersion int    `json:"consentVersion"`
+	ConsentStatus  string `json:"consentStatus"`
+	IssuedAt       int64  `json:"issuedAt"`            // Seconds since epoch
+	ExpiresAt      *int64 `json:"expiresAt,omitempty"` // Seconds since epoch, omitted when the consent does not expire
+
+	// Evidence of how the consent was collected, oldest first
+	Evidence []ConsentEvidence `json:"evidence,omitempty"`
+}
+
+// ReceiptPiiController identifies the organization that controls the PII
+type ReceiptPiiController struct {
+	PiiController    string `json:"piiController"`
+	OnBehalf         bool   `json:"onBehalf"`
+	Contact          string `json:"contact"`
+	Address          string `json:"address,omitempty"`
+	Email            string `json:"email"`
+	Phone            string `json:"phone,omitempty"`
+	PiiControllerURL string `json:"piiControllerUrl,omitempty"`
+}
+
+// ReceiptService groups the purposes consented to for a service, the consent type
+type ReceiptService struct {
+	Service  string           `json:"service"`
+	Purposes []ReceiptPurpose `json:"purposes"`
+}
+
+// ReceiptPurpose describes a purpose of the consent
+type ReceiptPurpose struct {
+	Purpose              string   `json:"purpose"`
+	PurposeCategory      []string `json:"purposeCategory"`
+	ConsentType          string   `json:"consentType"` // EXPLICIT when approved by the user, otherwise IMPLICIT
+	PiiCategory          []string `json:"piiCategory"`
+	PrimaryPurpose       bool     `json:"primaryPurpose"`
+	Termination          string   `json:"termination"`
+	ThirdPartyDisclosure bool     `json:"thirdPartyDisclosure"`
+	ThirdPartyName       string   `json:"thirdPartyName,omitempty"`
+}
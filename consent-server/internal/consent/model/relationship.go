@@ -0,0 +1,12 @@
+package model
+
+// RelationshipResponse summarizes the data-sharing relationship between a user and a client
+type RelationshipResponse struct {
+	UserID           string                  `json:"userId"`
+	ClientID         string                  `json:"clientId"`
+	ActiveConsents   []ConsentDetailResponse `json:"activeConsents"`
+	ApprovedPurposes []string                `json:"approvedPurposes"`
+	EarliestExpiry   *int64                  `json:"earliestExpiry,omitempty"` // Omitted when no active consent has an expiry
+	LastActivityTime *int64                  `json:"lastActivityTime,omitempty"`
+	TotalConsents    int                     `json:"totalConsents"` // All consents between the user and client, in any status
+}
@@ -0,0 +1,36 @@
+package model
+
+// ScheduledRevocation is a revocation requested with a future effectiveAt. The scheduled
+// revocation job applies Request once EffectiveAt is reached.
+type ScheduledRevocation struct {
+	ConsentID   string
+	OrgID       string
+	EffectiveAt int64 // Unix timestamp in milliseconds
+	// Request is the revoke request applied, without its effectiveAt
+	Request     ConsentRevokeRequest
+	CreatedTime int64
+}
+
+// ScheduledRevocationResponse describes a scheduled revocation. Times are Unix timestamps in seconds.
+type ScheduledRevocationResponse struct {
+	ConsentID        string   `json:"consentId"`
+	EffectiveAt      int64    `json:"effectiveAt"`
+	ActionBy         string   `json:"actionBy"`
+	RevocationReason string   `json:"revocationReason,omitempty"`
+	PurposeNames     []string `json:"purposeNames,omitempty"`
+	AuthorizationIDs []string `json:"authorizationIds,omitempty"`
+	ScheduledTime    int64    `json:"scheduledTime"`
+}
+
+// ToResponse converts a scheduled revocation to its API representation
+func (r *ScheduledRevocation) ToResponse() *ScheduledRevocationResponse {
+	return &ScheduledRevocationResponse{
+		ConsentID:        r.ConsentID,
+		EffectiveAt:      r.EffectiveAt / 1000,
+		ActionBy:         r.Request.ActionBy,
+		RevocationReason: r.Request.RevocationReason,
+		PurposeNames:     r.Request.PurposeNames,
+		AuthorizationIDs: r.Request.AuthorizationIDs,
+		ScheduledTime:    r.CreatedTime / 1000,
+	}
+}
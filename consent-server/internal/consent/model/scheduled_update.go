@@ -0,0 +1,30 @@
+package model
+
+// ScheduledUpdate is a consent update requested with a future effectiveAt. The scheduled update
+// job applies Request once EffectiveAt is reached.
+type ScheduledUpdate struct {
+	ConsentID   string
+	OrgID       string
+	EffectiveAt int64 // Unix timestamp in milliseconds
+	// Request is the update request applied, without its effectiveAt
+	Request     ConsentAPIUpdateRequest
+	CreatedTime int64
+}
+
+// ScheduledUpdateResponse describes a scheduled update. Times are Unix timestamps in seconds.
+type ScheduledUpdateResponse struct {
+	ConsentID     string                  `json:"consentId"`
+	EffectiveAt   int64                   `json:"effectiveAt"`
+	Update        ConsentAPIUpdateRequest `json:"update"`
+	ScheduledTime int64                   `json:"scheduledTime"`
+}
+
+// ToResponse converts a scheduled update to its API representation
+func (u *ScheduledUpdate) ToResponse() *ScheduledUpdateResponse {
+	return &ScheduledUpdateResponse{
+		ConsentID:     u.ConsentID,
+		EffectiveAt:   u.EffectiveAt / 1000,
+		Update:        u.Request,
+		ScheduledTime: u.CreatedTime / 1000,
+	}
+}
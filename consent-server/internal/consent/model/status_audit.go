@@ -40,3 +40,36 @@ type ConsentStatusAuditResponse struct {
 type ConsentStatusAuditListResponse struct {
 	Data []ConsentStatusAuditResponse `json:"data"`
 }
+
+// StatusAuditSearchFilters represents the filters of a status audit search
+type StatusAuditSearchFilters struct {
+	OrgID        string   // Empty searches every organization
+	ConsentID    string   // Entries of a single consent
+	ActionBy     string   // Entries recorded for the user or system that made the change
+	FromStatuses []string // Status before the change, e.g. ["ACTIVE"]
+	ToStatuses   []string // Status after the change, e.g. ["REVOKED"]
+	FromTime     *int64   // Action time lower bound (Unix timestamp in milliseconds)
+	ToTime       *int64   // Action time upper bound (Unix timestamp in milliseconds)
+	Limit        int
+	Offset       int
+}
+
+// StatusAuditSearchResponse represents a page of status audit entries
+type StatusAuditSearchResponse struct {
+	Data     []ConsentStatusAuditResponse `json:"data"`
+	Metadata ConsentSearchMetadata        `json:"metadata"`
+}
+
+// ToResponse converts the audit entry to its API representation
+func (a *ConsentStatusAudit) ToResponse() ConsentStatusAuditResponse {
+	return ConsentStatusAuditResponse{
+		StatusAuditID:  a.StatusAuditID,
+		ConsentID:      a.ConsentID,
+		CurrentStatus:  a.CurrentStatus,
+		ActionTime:     a.ActionTime,
+		Reason:         a.Reason,
+		ActionBy:       a.ActionBy,
+		PreviousStatus: a.PreviousStatus,
+		OrgID:          a.OrgID,
+	}
+}
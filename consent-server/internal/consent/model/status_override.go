@@ -0,0 +1,21 @@
+package model
+
+// StatusOverrideRequest represents the admin payload for forcing a consent into a status
+type StatusOverrideRequest struct {
+	Status   string `json:"status"`
+	Reason   string `json:"reason"`
+	ActionBy string `json:"actionBy"`
+}
+
+// StatusOverrideResponse represents the result of a consent status override
+type StatusOverrideResponse struct {
+	ConsentID      string `json:"consentId"`
+	PreviousStatus string `json:"previousStatus"`
+	Status         string `json:"status"`
+	// AuthorizationStatus is the status given to all authorizations of the consent, omitted when
+	// they were left unchanged
+	AuthorizationStatus string `json:"authorizationStatus,omitempty"`
+	Reason              string `json:"reason"`
+	ActionBy            string `json:"actionBy"`
+	ActionTime          int64  `json:"actionTime"`
+}
@@ -0,0 +1,17 @@
+package model
+
+// ConsentSuspensionRequest represents the payload for suspending or resuming a consent
+type ConsentSuspensionRequest struct {
+	Reason   string `json:"reason,omitempty"`
+	ActionBy string `json:"actionBy"`
+}
+
+// ConsentSuspensionResponse represents the result of suspending or resuming a consent
+type ConsentSuspensionResponse struct {
+	ConsentID      string `json:"consentId"`
+	PreviousStatus string `json:"previousStatus"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason"`
+	ActionBy       string `json:"actionBy"`
+	ActionTime     int64  `json:"actionTime"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MaxConsentTags is the maximum number of tags a consent can have
+const MaxConsentTags = 50
+
+// tagPattern matches a valid tag: up to 64 letters, digits, dots, underscores, colons and hyphens,
+// starting with a letter or digit
+var tagPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:-]{0,63}$`)
+
+// ConsentTagsRequest represents the request body for adding tags to a consent
+type ConsentTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// ConsentTagsResponse represents the tags of a consent, in alphabetical order
+type ConsentTagsResponse struct {
+	ConsentID string   `json:"consentId"`
+	Tags      []string `json:"tags"`
+}
+
+// Validate checks that the request lists at least one tag and that every tag is valid
+func (r ConsentTagsRequest) Validate() error {
+	if len(r.Tags) == 0 {
+		return fmt.Errorf("tags must contain at least one tag")
+	}
+	if len(r.Tags) > MaxConsentTags {
+		return fmt.Errorf("tags must not contain more than %d tags", MaxConsentTags)
+	}
+	for _, tag := range r.Tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateTag checks that a tag is 1 to 64 letters, digits, dots, underscores, colons and hyphens,
+// starting with a letter or digit
+func ValidateTag(tag string) error {
+	if !tagPattern.MatchString(tag) {
+		return fmt.Errorf("invalid tag '%s': tags must be 1 to 64 letters, digits, '.', '_', ':' or '-', starting with a letter or digit", tag)
+	}
+	return nil
+}
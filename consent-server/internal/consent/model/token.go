@@ -0,0 +1,98 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Types of tokens bound to a consent, named as in the token_type_hint of OAuth token revocation
+const (
+	TokenTypeAccess  = "access_token"
+	TokenTypeRefresh = "refresh_token"
+)
+
+// tokenIDPattern matches a token identifier, a jti or a hash of the token: 1 to 255 letters, digits
+// and the characters used by base64 and base64url encodings, except '/', which cannot be used in a path
+var tokenIDPattern = regexp.MustCompile(`^[A-Za-z0-9._~:+=-]{1,255}$`)
+
+// ConsentToken represents the CONSENT_TOKEN table, a token issued for a consent
+type ConsentToken struct {
+	TokenID     string
+	ConsentID   string
+	TokenType   string
+	ExpiresAt   *int64 // Unix timestamp in milliseconds, nil when the expiry of the token is not known
+	CreatedTime int64
+	OrgID       string
+}
+
+// ConsentTokenRequest represents the request body for binding a token to a consent. ExpiresAt is the
+// expiry of the token as a Unix timestamp in seconds, like the exp claim of a JWT.
+type ConsentTokenRequest struct {
+	TokenID   string `json:"tokenId"`
+	TokenType string `json:"tokenType"`
+	ExpiresAt *int64 `json:"expiresAt,omitempty"`
+}
+
+// ConsentTokenResponse describes a token bound to a consent. Times are Unix timestamps in seconds.
+type ConsentTokenResponse struct {
+	TokenID     string `json:"tokenId"`
+	ConsentID   string `json:"consentId"`
+	TokenType   string `json:"tokenType"`
+	ExpiresAt   *int64 `json:"expiresAt,omitempty"`
+	CreatedTime int64  `json:"createdTime"`
+}
+
+// ConsentTokenListResponse represents the tokens bound to a consent, oldest first
+type ConsentTokenListResponse struct {
+	Data []ConsentTokenResponse `json:"data"`
+}
+
+// TokenConsentResponse represents the consent a token resolves to
+type TokenConsentResponse struct {
+	Token   ConsentTokenResponse `json:"token"`
+	Consent *ConsentAPIResponse  `json:"consent"`
+}
+
+// Validate checks the token ID, the token type and, when given, that the token has not expired.
+// now is in milliseconds.
+func (r ConsentTokenRequest) Validate(now int64) error {
+	if err := ValidateTokenID(r.TokenID); err != nil {
+		return err
+	}
+	if r.TokenType != TokenTypeAccess && r.TokenType != TokenTypeRefresh {
+		return fmt.Errorf("tokenType must be %s or %s", TokenTypeAccess, TokenTypeRefresh)
+	}
+	if r.ExpiresAt != nil && *r.ExpiresAt*1000 <= now {
+		return fmt.Errorf("expiresAt must be in the future")
+	}
+	return nil
+}
+
+// ValidateTokenID checks that a token ID is 1 to 255 letters, digits, '.', '_', '~', ':', '+', '='
+// or '-'
+func ValidateTokenID(tokenID string) error {
+	if !tokenIDPattern.MatchString(tokenID) {
+		return fmt.Errorf("invalid tokenId: token IDs must be 1 to 255 letters, digits, '.', '_', '~', ':', '+', '=' or '-'")
+	}
+	return nil
+}
+
+// IsExpired reports whether the token expired by now, in milliseconds
+func (t *ConsentToken) IsExpired(now int64) bool {
+	return t.ExpiresAt != nil && *t.ExpiresAt <= now
+}
+
+// ToResponse converts a token binding to its API representation
+func (t *ConsentToken) ToResponse() ConsentTokenResponse {
+	response := ConsentTokenResponse{
+		TokenID:     t.TokenID,
+		ConsentID:   t.ConsentID,
+		TokenType:   t.TokenType,
+		CreatedTime: t.CreatedTime / 1000,
+	}
+	if t.ExpiresAt != nil {
+		expiresAt := *t.ExpiresAt / 1000
+		response.ExpiresAt = &expiresAt
+	}
+	return response
+}
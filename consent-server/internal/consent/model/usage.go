@@ -0,0 +1,24 @@
+package model
+
+// ConsentUsage is the number of validated accesses to a consent within one frequency period
+type ConsentUsage struct {
+	ConsentID      string `db:"CONSENT_ID"`
+	OrgID          string `db:"ORG_ID"`
+	PeriodStart    int64  `db:"PERIOD_START"`
+	AccessCount    int    `db:"ACCESS_COUNT"`
+	LastAccessTime int64  `db:"LAST_ACCESS_TIME"`
+}
+
+// ConsentUsageResponse represents the usage of a consent in the current frequency period.
+// Recurring consents are counted per period; other consents are counted over their whole
+// lifetime, in which case the period starts at 0 and has no end.
+type ConsentUsageResponse struct {
+	ConsentID          string `json:"consentId"`
+	Frequency          *int   `json:"frequency,omitempty"`
+	RecurringIndicator bool   `json:"recurringIndicator"`
+	PeriodStart        int64  `json:"periodStart"`
+	PeriodEnd          *int64 `json:"periodEnd,omitempty"`
+	AccessCount        int    `json:"accessCount"`
+	Remaining          *int   `json:"remaining,omitempty"` // Omitted when the consent has no frequency limit
+	LastAccessTime     *int64 `json:"lastAccessTime,omitempty"`
+}
@@ -0,0 +1,18 @@
+package model
+
+// UserConsentResponse is a consent in which a user appears as an authorizer
+type UserConsentResponse struct {
+	ConsentDetailResponse
+	// UserApprovalStatus is the user's approval of the consent: rejected when any of the user's
+	// authorizations is rejected, approved when all are approved, and the pending status otherwise
+	UserApprovalStatus string `json:"userApprovalStatus"`
+	// UserAuthorizations are the consent's authorizations that belong to the user
+	UserAuthorizations []AuthorizationDetail `json:"userAuthorizations"`
+}
+
+// UserConsentListResponse lists the consents of a user
+type UserConsentListResponse struct {
+	UserID   string                `json:"userId"`
+	Data     []UserConsentResponse `json:"data"`
+	Metadata ConsentSearchMetadata `json:"metadata"`
+}
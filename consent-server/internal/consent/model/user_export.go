@@ -0,0 +1,22 @@
+package model
+
+// Data subject access request export formats
+const (
+	UserExportFormatJSON = "json"
+	UserExportFormatHTML = "html"
+)
+
+// UserConsentExport is the answer to a data subject access request: every consent a user appears
+// in, with its purposes, authorizations, status changes and superseded versions
+type UserConsentExport struct {
+	UserID        string                   `json:"userId"`
+	OrgID         string                   `json:"orgId"`
+	GeneratedTime int64                    `json:"generatedTime"` // Unix timestamp in milliseconds
+	Consents      []UserConsentExportEntry `json:"consents"`
+}
+
+// UserConsentExportEntry is a consent of an exported user. History lists the superseded versions.
+type UserConsentExportEntry struct {
+	UserConsentResponse
+	StatusHistory []ConsentStatusAudit `json:"statusHistory"` // Oldest first
+}
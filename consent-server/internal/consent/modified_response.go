@@ -0,0 +1,111 @@
+package consent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	resourceschemamodel "github.com/wso2/consent-management-api/internal/resourceschema/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// modifiedResponseSchema is the JSON Schema modifiedResponse documents are checked against. A
+// schema that fails to load rejects every document rather than accepting them unchecked.
+type modifiedResponseSchema struct {
+	schema  *resourceschemamodel.JSONSchema // nil when no schema is configured
+	loadErr error
+}
+
+// loadModifiedResponseSchema compiles the configured modifiedResponse schema file
+func loadModifiedResponseSchema() modifiedResponseSchema {
+	cfg := config.Get()
+	if cfg == nil || cfg.Consent.ModifiedResponse.SchemaFile == "" {
+		return modifiedResponseSchema{}
+	}
+	path := cfg.Consent.ModifiedResponse.SchemaFile
+	raw, err := os.ReadFile(path)
+	if err == nil {
+		var schema *resourceschemamodel.JSONSchema
+		if schema, err = resourceschemamodel.CompileJSONSchema(raw); err == nil {
+			log.GetLogger().Info("modifiedResponse schema loaded", log.String("schema_file", path))
+			return modifiedResponseSchema{schema: schema}
+		}
+	}
+	log.GetLogger().Error("Failed to load modifiedResponse schema, modifiedResponse documents are rejected",
+		log.String("schema_file", path), log.Error(err))
+	return modifiedResponseSchema{loadErr: fmt.Errorf("modifiedResponse schema is unavailable")}
+}
+
+// encodeModifiedResponse checks a modifiedResponse document and returns its JSON encoding. The
+// document must be a JSON object within the configured size that conforms to the configured schema.
+func (consentService *consentService) encodeModifiedResponse(document interface{}) (string, error) {
+	if consentService.modifiedResponseSchema.loadErr != nil {
+		return "", consentService.modifiedResponseSchema.loadErr
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return "", fmt.Errorf("modifiedResponse must be valid JSON: %v", err)
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return "", fmt.Errorf("modifiedResponse must be a JSON object")
+	}
+	if maxSize := config.Get().Consent.ModifiedResponse.GetMaxSize(); len(data) > maxSize {
+		return "", fmt.Errorf("modifiedResponse is %d bytes, larger than the limit of %d bytes", len(data), maxSize)
+	}
+
+	if schema := consentService.modifiedResponseSchema.schema; schema != nil {
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return "", fmt.Errorf("modifiedResponse must be valid JSON: %v", err)
+		}
+		if err := schema.Validate(value); err != nil {
+			return "", fmt.Errorf("modifiedResponse does not match the schema: %v", err)
+		}
+	}
+	return string(data), nil
+}
+
+// saveModifiedResponse checks and stores the modifiedResponse of a created or updated consent.
+// Responses without one keep the stored document.
+func (consentService *consentService) saveModifiedResponse(ctx context.Context, orgID string, response *model.ConsentResponse) *serviceerror.ServiceError {
+	if response.ModifiedResponse == nil {
+		return nil
+	}
+	logger := log.GetLogger().WithContext(ctx)
+
+	document, err := consentService.encodeModifiedResponse(response.ModifiedResponse)
+	if err != nil {
+		logger.Error("Invalid modifiedResponse returned for consent", log.Error(err),
+			log.String("consent_id", response.ConsentID))
+		return serviceerror.CustomServiceError(serviceerror.InternalServerError, err.Error())
+	}
+	if err := consentService.stores.Consent.SaveModifiedResponse(ctx, response.ConsentID, orgID, document,
+		utils.GetCurrentTimeMillis()); err != nil {
+		logger.Error("Failed to store modifiedResponse", log.Error(err), log.String("consent_id", response.ConsentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	return nil
+}
+
+// loadModifiedResponse sets the stored modifiedResponse of a consent on its response
+func (consentService *consentService) loadModifiedResponse(ctx context.Context, orgID string, response *model.ConsentResponse) error {
+	document, err := consentService.stores.Consent.GetModifiedResponse(ctx, response.ConsentID, orgID)
+	if err != nil || document == "" {
+		return err
+	}
+	var modified interface{}
+	if err := json.Unmarshal([]byte(document), &modified); err != nil {
+		return fmt.Errorf("stored modifiedResponse is not valid JSON: %w", err)
+	}
+	response.ModifiedResponse = modified
+	return nil
+}
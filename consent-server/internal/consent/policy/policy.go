@@ -0,0 +1,176 @@
+// Package policy evaluates the validation policy rules that organizations configure for consent
+// validation. Rules are either evaluated in-process or delegated to the service extension.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/extension"
+)
+
+// Violation describes the rule that denied a consent validation
+type Violation struct {
+	Rule        string
+	Description string
+}
+
+// Evaluate runs the validation policy rules of an organization against a validation request and
+// the consent being validated. It returns the first violation, or nil when every rule allows the
+// request. An error is returned when an extension rule could not be evaluated.
+func Evaluate(ctx context.Context, orgID string, req model.ValidateRequest, info *model.ValidateConsentAPIResponse) (*Violation, error) {
+	for _, rule := range config.Get().Consent.ValidationPolicy.GetRules(orgID) {
+		if !appliesTo(rule, info.Type) {
+			continue
+		}
+
+		var description string
+		switch rule.Type {
+		case config.PolicyRuleHTTPMethod:
+			description = checkHTTPMethod(rule, req)
+		case config.PolicyRuleResourcePath:
+			description = checkResourcePath(rule, req, info)
+		case config.PolicyRuleFrequency:
+			description = checkFrequency(rule, info)
+		case config.PolicyRuleExtension:
+			var err error
+			if description, err = checkExtension(ctx, orgID, rule, req, info); err != nil {
+				return nil, err
+			}
+		}
+		if description != "" {
+			return &Violation{Rule: rule.Name, Description: description}, nil
+		}
+	}
+	return nil, nil
+}
+
+// appliesTo reports whether a rule applies to consents of consentType
+func appliesTo(rule config.ValidationPolicyRule, consentType string) bool {
+	if len(rule.ConsentTypes) == 0 {
+		return true
+	}
+	for _, t := range rule.ConsentTypes {
+		if t == consentType {
+			return true
+		}
+	}
+	return false
+}
+
+func checkHTTPMethod(rule config.ValidationPolicyRule, req model.ValidateRequest) string {
+	method := req.ResourceParams.HTTPMethod
+	for _, allowed := range rule.HTTPMethods {
+		if strings.EqualFold(allowed, method) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("HTTP method '%s' is not allowed", method)
+}
+
+func checkResourcePath(rule config.ValidationPolicyRule, req model.ValidateRequest, info *model.ValidateConsentAPIResponse) string {
+	resource := req.ResourceParams.Resource
+	if resource == "" {
+		resource = req.ElectedResource
+	}
+	if i := strings.IndexByte(resource, '?'); i >= 0 {
+		resource = resource[:i]
+	}
+
+	values := authorizedValues(req.UserID, info)
+	for _, pattern := range rule.ResourcePatterns {
+		if matchPath(splitPath(pattern), splitPath(resource), values) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("resource '%s' is not covered by the consent", resource)
+}
+
+func checkFrequency(rule config.ValidationPolicyRule, info *model.ValidateConsentAPIResponse) string {
+	if info.Frequency != nil && *info.Frequency > rule.MaxFrequency {
+		return fmt.Sprintf("consent frequency %d exceeds the allowed maximum of %d", *info.Frequency, rule.MaxFrequency)
+	}
+	return ""
+}
+
+func checkExtension(ctx context.Context, orgID string, rule config.ValidationPolicyRule, req model.ValidateRequest, info *model.ValidateConsentAPIResponse) (string, error) {
+	payload := &model.ValidationPolicyHookPayload{Rule: rule.Name, ValidateRequest: req, ConsentInformation: info}
+	err := extension.Invoke(ctx, extension.EvaluateValidationPolicy, orgID, payload)
+	var rejected *extension.RejectedError
+	if errors.As(err, &rejected) {
+		return rejected.Error(), nil
+	}
+	return "", err
+}
+
+// wildcardKey matches a value stored under any key of the authorized resources
+const wildcardKey = "*"
+
+// authorizedValues collects the values in the resources of the approved authorizations of a user,
+// grouped by the key they are stored under. Every value is also listed under wildcardKey.
+func authorizedValues(userID string, info *model.ValidateConsentAPIResponse) map[string]map[string]bool {
+	values := map[string]map[string]bool{wildcardKey: {}}
+	approvedStatus := string(config.Get().Consent.GetApprovedAuthStatus())
+	for _, auth := range info.Authorizations {
+		if auth.Status != approvedStatus {
+			continue
+		}
+		if userID != "" && auth.UserID != nil && *auth.UserID != userID {
+			continue
+		}
+		collectValues("", auth.Resources, values)
+	}
+	return values
+}
+
+func collectValues(key string, value interface{}, values map[string]map[string]bool) {
+	switch v := value.(type) {
+	case string:
+		values[wildcardKey][v] = true
+		if key != "" {
+			if values[key] == nil {
+				values[key] = map[string]bool{}
+			}
+			values[key][v] = true
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectValues(key, item, values)
+		}
+	case map[string]interface{}:
+		for k, item := range v {
+			collectValues(k, item, values)
+		}
+	}
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// matchPath matches path segments against pattern segments. "*" matches any segment, a trailing
+// "**" matches the rest of the path and "{key}" matches an authorized value stored under key.
+func matchPath(pattern, path []string, values map[string]map[string]bool) bool {
+	for i, segment := range pattern {
+		if segment == "**" && i == len(pattern)-1 {
+			return true
+		}
+		if i >= len(path) {
+			return false
+		}
+		switch {
+		case segment == "*":
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			if !values[segment[1:len(segment)-1]][path[i]] {
+				return false
+			}
+		case segment != path[i]:
+			return false
+		}
+	}
+	return len(pattern) == len(path)
+}
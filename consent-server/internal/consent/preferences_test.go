@@ -0,0 +1,112 @@
+package consent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/authresource"
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/consentpurpose"
+	"github.com/wso2/consent-management-api/internal/eventoutbox"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/database/dbtest"
+	"github.com/wso2/consent-management-api/internal/system/featureflag"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// newPreferenceTestService returns a consent service over a test database holding consent c1 of
+// user-1 in org-1: created with an approved authorization, a mandatory purpose "terms" and an
+// optional purpose "news", neither approved. Mandatory purposes are enforced for org-1.
+func newPreferenceTestService(t *testing.T) *consentService {
+	config.SetGlobal(&config.Config{Consent: config.ConsentConfig{
+		StatusMappings: config.ConsentStatusMappings{
+			ActiveStatus:   "ACTIVE",
+			ExpiredStatus:  "EXPIRED",
+			RevokedStatus:  "REVOKED",
+			CreatedStatus:  "CREATED",
+			RejectedStatus: "REJECTED",
+		},
+		AuthStatusMappings: config.AuthStatusMappings{
+			ApprovedState: "APPROVED",
+			RejectedState: "REJECTED",
+			CreatedState:  "CREATED",
+		},
+	}})
+	t.Cleanup(func() { config.SetGlobal(nil) })
+	if err := featureflag.SetOverride("org-1", featureflag.PurposeEnforcement, true); err != nil {
+		t.Fatalf("failed to enable purpose enforcement: %v", err)
+	}
+	t.Cleanup(func() { _, _ = featureflag.ClearOverride("org-1", featureflag.PurposeEnforcement) })
+
+	dbClient := dbtest.NewSQLiteClient(t,
+		"INSERT INTO CONSENT (CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, ORG_ID) "+
+			"VALUES ('c1', 1000, 1000, 'client-1', 'accounts', 'CREATED', 'org-1')",
+		"INSERT INTO CONSENT_AUTH_RESOURCE (AUTH_ID, CONSENT_ID, AUTH_TYPE, USER_ID, AUTH_STATUS, UPDATED_TIME, ORG_ID) "+
+			"VALUES ('a1', 'c1', 'authorization', 'user-1', 'APPROVED', 1000, 'org-1')",
+		"INSERT INTO CONSENT_PURPOSE (ID, NAME, ORG_ID) VALUES ('p1', 'terms', 'org-1'), ('p2', 'news', 'org-1')",
+		"INSERT INTO CONSENT_PURPOSE_MAPPING (CONSENT_ID, ORG_ID, PURPOSE_ID, IS_USER_APPROVED, IS_MANDATORY) "+
+			"VALUES ('c1', 'org-1', 'p1', FALSE, TRUE), ('c1', 'org-1', 'p2', FALSE, FALSE)",
+	)
+	registry := stores.NewStoreRegistry(dbClient, NewConsentStore(dbClient), authresource.NewAuthResourceStore(dbClient),
+		consentpurpose.NewConsentPurposeStore(dbClient), nil, nil, nil, nil, nil, nil, nil, eventoutbox.NewEventOutboxStore(dbClient))
+	return &consentService{stores: registry}
+}
+
+// optIn opts user-1 in to the purpose
+func optIn(t *testing.T, service *consentService, purposeName string) {
+	t.Helper()
+	approve := true
+	_, serviceErr := service.UpdateUserPreferences(context.Background(), "user-1", "org-1", model.UserPreferencesUpdateRequest{
+		Preferences: []model.PreferenceUpdate{{PurposeName: purposeName, OptedIn: &approve}},
+	})
+	if serviceErr != nil {
+		t.Fatalf("failed to opt in to %s: %+v", purposeName, serviceErr)
+	}
+}
+
+// TestUpdateUserPreferences_VersionsTheConsentAndDerivesItsStatus checks that each changed consent
+// gets a new version and updated time with the previous version in its history, and that it is
+// only activated once its mandatory purposes are approved
+func TestUpdateUserPreferences_VersionsTheConsentAndDerivesItsStatus(t *testing.T) {
+	service := newPreferenceTestService(t)
+	ctx := context.Background()
+	consentStore := service.stores.Consent
+
+	optIn(t, service, "news")
+
+	consent, err := consentStore.GetByID(ctx, "c1", "org-1")
+	if err != nil || consent == nil {
+		t.Fatalf("failed to load the consent: %v", err)
+	}
+	if consent.Version != 2 || consent.UpdatedTime == 1000 {
+		t.Errorf("expected a new version and updated time, got version %d updated at %d", consent.Version, consent.UpdatedTime)
+	}
+	if consent.CurrentStatus != "CREATED" {
+		t.Errorf("expected the consent to wait for its mandatory purpose, got %s", consent.CurrentStatus)
+	}
+	history, err := consentStore.GetHistoryByConsentID(ctx, "c1", "org-1")
+	if err != nil || len(history) != 1 || history[0].Version != 1 {
+		t.Fatalf("expected version 1 in the history, got %+v, %v", history, err)
+	}
+
+	optIn(t, service, "terms")
+
+	consent, err = consentStore.GetByID(ctx, "c1", "org-1")
+	if err != nil || consent == nil {
+		t.Fatalf("failed to load the consent: %v", err)
+	}
+	if consent.Version != 3 || consent.CurrentStatus != "ACTIVE" {
+		t.Errorf("expected version 3 to be active once the mandatory purpose is approved, got version %d %s",
+			consent.Version, consent.CurrentStatus)
+	}
+	audits, err := consentStore.GetStatusAuditByConsentID(ctx, "c1", "org-1")
+	if err != nil || len(audits) != 1 || audits[0].CurrentStatus != "ACTIVE" || *audits[0].PreviousStatus != "CREATED" {
+		t.Errorf("expected the activation in the status audit, got %+v, %v", audits, err)
+	}
+
+	// Repeating an update changes nothing
+	optIn(t, service, "terms")
+	if consent, _ = consentStore.GetByID(ctx, "c1", "org-1"); consent.Version != 3 {
+		t.Errorf("expected an unchanged update to keep version 3, got %d", consent.Version)
+	}
+}
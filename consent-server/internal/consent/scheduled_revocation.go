@@ -0,0 +1,216 @@
+package consent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// newScheduledRevocation checks a revoke request with a future effectiveAt and returns the
+// scheduled revocation of the consent. now is in milliseconds.
+func newScheduledRevocation(existing *model.Consent, orgID string, req model.ConsentRevokeRequest, now int64) (*model.ScheduledRevocation, *serviceerror.ServiceError) {
+	if !config.Get().Consent.ScheduledRevocation.Enabled {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "scheduled revocation is not enabled")
+	}
+	if req.ActionBy == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "ActionBy is required")
+	}
+	if req.EffectiveAt == nil || *req.EffectiveAt*1000 <= now {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "effectiveAt must be in the future")
+	}
+	if existing.CurrentStatus == string(config.Get().Consent.ForOrg(orgID).GetRevokedConsentStatus()) {
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("Consent with ID '%s' is already revoked", existing.ConsentID))
+	}
+
+	revocation := &model.ScheduledRevocation{
+		ConsentID:   existing.ConsentID,
+		OrgID:       orgID,
+		EffectiveAt: *req.EffectiveAt * 1000,
+		Request:     req,
+		CreatedTime: now,
+	}
+	// The job applies the request as an immediate revocation
+	revocation.Request.EffectiveAt = nil
+	return revocation, nil
+}
+
+// scheduleRevocation stores a revocation that takes effect later, replacing the one the consent
+// had. The consent keeps its status until the scheduled revocation job applies it.
+func (consentService *consentService) scheduleRevocation(ctx context.Context, existing *model.Consent, orgID string, req model.ConsentRevokeRequest) (*model.ConsentRevokeResponse, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	currentTime := utils.GetCurrentTimeMillis()
+	revocation, serviceErr := newScheduledRevocation(existing, orgID, req, currentTime)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	store := consentService.stores.Consent
+	err := consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return store.SaveScheduledRevocation(tx, revocation)
+		},
+	})
+	if err != nil {
+		logger.Error("Failed to schedule consent revocation", log.Error(err), log.String("consent_id", existing.ConsentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	logger.Info("Consent revocation scheduled",
+		log.String("consent_id", existing.ConsentID),
+		log.Any("effective_at", revocation.EffectiveAt))
+
+	return &model.ConsentRevokeResponse{
+		ActionTime:          currentTime / 1000, // Convert milliseconds to seconds
+		ActionBy:            req.ActionBy,
+		RevocationReason:    req.RevocationReason,
+		ConsentStatus:       existing.CurrentStatus,
+		ScheduledRevocation: revocation.ToResponse(),
+	}, nil
+}
+
+// GetScheduledRevocation retrieves the revocation scheduled for a consent
+func (consentService *consentService) GetScheduledRevocation(ctx context.Context, consentID, orgID string) (*model.ScheduledRevocationResponse, *serviceerror.ServiceError) {
+	revocation, serviceErr := consentService.findScheduledRevocation(ctx, consentID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	return revocation.ToResponse(), nil
+}
+
+// CancelScheduledRevocation deletes the revocation scheduled for a consent before it takes effect
+func (consentService *consentService) CancelScheduledRevocation(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if _, serviceErr := consentService.findScheduledRevocation(ctx, consentID, orgID); serviceErr != nil {
+		return serviceErr
+	}
+	if serviceErr := consentService.checkConsentLock(ctx, consentID, orgID); serviceErr != nil {
+		return serviceErr
+	}
+
+	store := consentService.stores.Consent
+	err := consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return store.DeleteScheduledRevocation(tx, consentID, orgID)
+		},
+	})
+	if err != nil {
+		logger.Error("Failed to cancel scheduled revocation", log.Error(err), log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	logger.Info("Scheduled consent revocation cancelled", log.String("consent_id", consentID))
+	return nil
+}
+
+// findScheduledRevocation retrieves the scheduled revocation of a consent, failing when the
+// consent does not exist or has none
+func (consentService *consentService) findScheduledRevocation(ctx context.Context, consentID, orgID string) (*model.ScheduledRevocation, *serviceerror.ServiceError) {
+	store := consentService.stores.Consent
+	existing, err := store.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+
+	revocation, err := store.GetScheduledRevocation(ctx, consentID, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if revocation == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("Consent with ID '%s' has no scheduled revocation", consentID))
+	}
+	return revocation, nil
+}
+
+// claimScheduledRevocation returns the query that deletes the scheduled revocation a revocation
+// applies, or a query doing nothing when it applies none
+func (consentService *consentService) claimScheduledRevocation(claim *model.ScheduledRevocation) func(tx dbmodel.TxInterface) error {
+	return func(tx dbmodel.TxInterface) error {
+		if claim == nil {
+			return nil
+		}
+		return consentService.stores.Consent.ClaimScheduledRevocation(tx, claim)
+	}
+}
+
+// scheduledRevocationClaimedError reports a scheduled revocation that was applied, replaced or
+// cancelled while it was being applied
+func scheduledRevocationClaimedError(consentID string) *serviceerror.ServiceError {
+	return serviceerror.CustomServiceError(serviceerror.ConflictError,
+		fmt.Sprintf("Scheduled revocation of consent with ID '%s' has changed", consentID))
+}
+
+// ApplyScheduledRevocations applies the scheduled revocations that took effect. Each revocation is
+// deleted in the transaction that applies it, so that servers sharing the database apply it once
+// and a revocation whose server stopped or failed midway stays scheduled. Revocations that failed
+// on a database error or a consent lock are left to be retried on the next run; those the consent
+// no longer allows, such as a consent revoked in the meantime, are dropped.
+func (consentService *consentService) ApplyScheduledRevocations(ctx context.Context) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ApplyScheduledRevocations")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	store := consentService.stores.Consent
+
+	due, err := store.GetDueScheduledRevocations(ctx, utils.GetCurrentTimeMillis(),
+		config.Get().Consent.ScheduledRevocation.BatchSize)
+	if err != nil {
+		logger.Error("Failed to retrieve due scheduled revocations", log.Error(err))
+		return
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	applied, dropped, retried := 0, 0, 0
+	for i := range due {
+		revocation := &due[i]
+		_, serviceErr := consentService.revokeConsent(ctx, revocation.ConsentID, revocation.OrgID, revocation.Request, revocation)
+		switch {
+		case serviceErr == nil:
+			applied++
+		case serviceErr.Type == serviceerror.ServerErrorType || serviceErr.Code == serviceerror.ConsentLockedError.Code:
+			logger.Warn("Scheduled revocation failed, retrying on the next run",
+				log.String("consent_id", revocation.ConsentID),
+				log.String("org_id", revocation.OrgID),
+				log.String("error", serviceErr.Description))
+			retried++
+		default:
+			// The revocation is only dropped when it is still the one that was read
+			err := consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+				consentService.claimScheduledRevocation(revocation),
+			})
+			if errors.Is(err, ErrScheduledRevocationClaimed) {
+				continue
+			}
+			if err != nil {
+				logger.Error("Failed to drop scheduled revocation", log.Error(err), log.String("consent_id", revocation.ConsentID))
+				continue
+			}
+			logger.Warn("Scheduled revocation no longer applies to the consent, dropping it",
+				log.String("consent_id", revocation.ConsentID),
+				log.String("org_id", revocation.OrgID),
+				log.String("error", serviceErr.Description))
+			dropped++
+		}
+	}
+
+	logger.Info("Applied scheduled revocations",
+		log.Int("applied", applied),
+		log.Int("dropped", dropped),
+		log.Int("retried", retried))
+}
@@ -0,0 +1,151 @@
+package consent
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/authresource"
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/consentpurpose"
+	"github.com/wso2/consent-management-api/internal/eventoutbox"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/database/dbtest"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// failStatusAudit makes every status audit insert fail, so that revocations fail after their
+// scheduled revocation was deleted in the transaction
+const failStatusAudit = "CREATE TRIGGER FAIL_STATUS_AUDIT BEFORE INSERT ON CONSENT_STATUS_AUDIT " +
+	"BEGIN SELECT RAISE(ABORT, 'database unavailable'); END"
+
+// newScheduledRevocationTestService returns a consent service over a test database holding the
+// active consent c1 of org-1 with a revocation that took effect, and the database itself. Scheduled
+// revocations and updates are enabled.
+func newScheduledRevocationTestService(t *testing.T, statements ...string) (*consentService, *sql.DB) {
+	config.SetGlobal(&config.Config{Consent: config.ConsentConfig{
+		StatusMappings: config.ConsentStatusMappings{
+			ActiveStatus:   "ACTIVE",
+			ExpiredStatus:  "EXPIRED",
+			RevokedStatus:  "REVOKED",
+			CreatedStatus:  "CREATED",
+			RejectedStatus: "REJECTED",
+		},
+		AuthStatusMappings: config.AuthStatusMappings{
+			ApprovedState:      "APPROVED",
+			SystemRevokedState: "SYS_REVOKED",
+		},
+		ScheduledRevocation: config.ScheduledRevocationConfig{Enabled: true, BatchSize: 10},
+	}})
+	t.Cleanup(func() { config.SetGlobal(nil) })
+
+	db := dbtest.NewSQLiteDB(t, append([]string{
+		"INSERT INTO CONSENT (CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, ORG_ID) " +
+			"VALUES ('c1', 1000, 1000, 'client-1', 'accounts', 'ACTIVE', 'org-1')",
+		"INSERT INTO CONSENT_SCHEDULED_REVOCATION (CONSENT_ID, EFFECTIVE_AT, REVOKE_REQUEST, CREATED_TIME, ORG_ID) " +
+			"VALUES ('c1', 2000, '{\"actionBy\":\"user-1\"}', 1500, 'org-1')",
+	}, statements...)...)
+	dbClient := provider.NewDBClient(db, config.DatabaseTypeSQLite)
+	registry := stores.NewStoreRegistry(dbClient, NewConsentStore(dbClient), authresource.NewAuthResourceStore(dbClient),
+		consentpurpose.NewConsentPurposeStore(dbClient), nil, nil, nil, nil, nil, nil, nil, eventoutbox.NewEventOutboxStore(dbClient))
+	return &consentService{stores: registry}, db
+}
+
+// consentState returns the status of c1 and its scheduled revocation, if any
+func consentState(t *testing.T, service *consentService) (string, *model.ScheduledRevocation) {
+	t.Helper()
+	ctx := context.Background()
+	consent, err := service.stores.Consent.GetByID(ctx, "c1", "org-1")
+	if err != nil || consent == nil {
+		t.Fatalf("failed to load the consent: %v", err)
+	}
+	revocation, err := service.stores.Consent.GetScheduledRevocation(ctx, "c1", "org-1")
+	if err != nil {
+		t.Fatalf("failed to load the scheduled revocation: %v", err)
+	}
+	return consent.CurrentStatus, revocation
+}
+
+// TestApplyScheduledRevocations_RevokesAndRemovesTheRevocation checks that a due revocation is
+// applied and removed with it
+func TestApplyScheduledRevocations_RevokesAndRemovesTheRevocation(t *testing.T) {
+	service, _ := newScheduledRevocationTestService(t)
+
+	service.ApplyScheduledRevocations(context.Background())
+
+	status, revocation := consentState(t, service)
+	if status != "REVOKED" || revocation != nil {
+		t.Errorf("expected the consent to be revoked and the revocation removed, got %s, %+v", status, revocation)
+	}
+}
+
+// TestApplyScheduledRevocations_KeepsFailedRevocations checks that a revocation whose transaction
+// fails, as when the server stops before committing it, stays scheduled and is applied by the next
+// run
+func TestApplyScheduledRevocations_KeepsFailedRevocations(t *testing.T) {
+	service, db := newScheduledRevocationTestService(t, failStatusAudit)
+
+	service.ApplyScheduledRevocations(context.Background())
+
+	status, revocation := consentState(t, service)
+	if status != "ACTIVE" || revocation == nil || revocation.EffectiveAt != 2000 || revocation.CreatedTime != 1500 {
+		t.Fatalf("expected the consent to stay active with its revocation scheduled, got %s, %+v", status, revocation)
+	}
+
+	if _, err := db.Exec("DROP TRIGGER FAIL_STATUS_AUDIT"); err != nil {
+		t.Fatalf("failed to restore the database: %v", err)
+	}
+	service.ApplyScheduledRevocations(context.Background())
+
+	if status, revocation = consentState(t, service); status != "REVOKED" || revocation != nil {
+		t.Errorf("expected the next run to apply the revocation, got %s, %+v", status, revocation)
+	}
+}
+
+// TestApplyScheduledRevocations_AppliesARevocationOnce checks that a revocation read before it was
+// applied by another server, or replaced, is not applied again
+func TestApplyScheduledRevocations_AppliesARevocationOnce(t *testing.T) {
+	service, db := newScheduledRevocationTestService(t)
+	ctx := context.Background()
+	stale := &model.ScheduledRevocation{ConsentID: "c1", OrgID: "org-1", EffectiveAt: 2000, CreatedTime: 1000,
+		Request: model.ConsentRevokeRequest{ActionBy: "user-1"}}
+
+	_, serviceErr := service.revokeConsent(ctx, "c1", "org-1", stale.Request, stale)
+	if serviceErr == nil || serviceErr.Code != serviceerror.ConflictError.Code {
+		t.Fatalf("expected a replaced revocation to be rejected, got %+v", serviceErr)
+	}
+	status, revocation := consentState(t, service)
+	if status != "ACTIVE" || revocation == nil {
+		t.Fatalf("expected the consent and its current revocation to be left as they were, got %s, %+v", status, revocation)
+	}
+
+	// Another server applies the revocation after it was read
+	due, err := service.stores.Consent.GetDueScheduledRevocations(ctx, 2000, 10)
+	if err != nil || len(due) != 1 {
+		t.Fatalf("failed to read the due revocation: %+v, %v", due, err)
+	}
+	if _, serviceErr = service.RevokeConsent(ctx, "c1", "org-1", due[0].Request); serviceErr != nil {
+		t.Fatalf("failed to revoke the consent: %+v", serviceErr)
+	}
+	if _, err := db.Exec("UPDATE CONSENT SET CURRENT_STATUS = 'ACTIVE' WHERE CONSENT_ID = 'c1'"); err != nil {
+		t.Fatalf("failed to reactivate the consent: %v", err)
+	}
+	if _, serviceErr = service.revokeConsent(ctx, "c1", "org-1", due[0].Request, &due[0]); serviceErr == nil {
+		t.Error("expected a revocation applied by another server not to be applied again")
+	}
+}
+
+// TestApplyScheduledRevocations_DropsRevocationsThatNoLongerApply checks that the revocation of a
+// consent revoked in the meantime is dropped
+func TestApplyScheduledRevocations_DropsRevocationsThatNoLongerApply(t *testing.T) {
+	service, _ := newScheduledRevocationTestService(t,
+		"UPDATE CONSENT SET CURRENT_STATUS = 'REVOKED' WHERE CONSENT_ID = 'c1'")
+
+	service.ApplyScheduledRevocations(context.Background())
+
+	if _, revocation := consentState(t, service); revocation != nil {
+		t.Errorf("expected the revocation to be dropped, got %+v", revocation)
+	}
+}
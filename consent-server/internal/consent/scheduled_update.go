@@ -0,0 +1,195 @@
+package consent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// scheduleUpdate stores an update that takes effect later, replacing the one the consent had. The
+// consent is returned as it is; the scheduled update job applies the update, checking it again
+// against the consent it then finds.
+func (consentService *consentService) scheduleUpdate(ctx context.Context, existing *model.Consent, orgID string, req model.ConsentAPIUpdateRequest) (*model.ConsentResponse, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if !config.Get().Consent.ScheduledRevocation.Enabled {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "scheduled updates are not enabled")
+	}
+
+	currentTime := utils.GetCurrentTimeMillis()
+	update := &model.ScheduledUpdate{
+		ConsentID:   existing.ConsentID,
+		OrgID:       orgID,
+		EffectiveAt: *req.EffectiveAt * 1000,
+		Request:     req,
+		CreatedTime: currentTime,
+	}
+	// The job applies the request as an immediate update
+	update.Request.EffectiveAt = nil
+
+	store := consentService.stores.Consent
+	err := consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return store.SaveScheduledUpdate(tx, update)
+		},
+	})
+	if err != nil {
+		logger.Error("Failed to schedule consent update", log.Error(err), log.String("consent_id", existing.ConsentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	logger.Info("Consent update scheduled",
+		log.String("consent_id", existing.ConsentID),
+		log.Any("effective_at", update.EffectiveAt))
+
+	response, serviceErr := consentService.GetConsent(ctx, existing.ConsentID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	response.ScheduledUpdate = update.ToResponse()
+	return response, nil
+}
+
+// GetScheduledUpdate retrieves the update scheduled for a consent
+func (consentService *consentService) GetScheduledUpdate(ctx context.Context, consentID, orgID string) (*model.ScheduledUpdateResponse, *serviceerror.ServiceError) {
+	update, serviceErr := consentService.findScheduledUpdate(ctx, consentID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	return update.ToResponse(), nil
+}
+
+// CancelScheduledUpdate deletes the update scheduled for a consent before it takes effect
+func (consentService *consentService) CancelScheduledUpdate(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if _, serviceErr := consentService.findScheduledUpdate(ctx, consentID, orgID); serviceErr != nil {
+		return serviceErr
+	}
+	if serviceErr := consentService.checkConsentLock(ctx, consentID, orgID); serviceErr != nil {
+		return serviceErr
+	}
+
+	store := consentService.stores.Consent
+	err := consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return store.DeleteScheduledUpdate(tx, consentID, orgID)
+		},
+	})
+	if err != nil {
+		logger.Error("Failed to cancel scheduled update", log.Error(err), log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	logger.Info("Scheduled consent update cancelled", log.String("consent_id", consentID))
+	return nil
+}
+
+// findScheduledUpdate retrieves the scheduled update of a consent, failing when the consent does
+// not exist or has none
+func (consentService *consentService) findScheduledUpdate(ctx context.Context, consentID, orgID string) (*model.ScheduledUpdate, *serviceerror.ServiceError) {
+	store := consentService.stores.Consent
+	existing, err := store.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+
+	update, err := store.GetScheduledUpdate(ctx, consentID, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if update == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("Consent with ID '%s' has no scheduled update", consentID))
+	}
+	return update, nil
+}
+
+// claimScheduledUpdate returns the query that deletes the scheduled update an update applies, or
+// a query doing nothing when it applies none
+func (consentService *consentService) claimScheduledUpdate(claim *model.ScheduledUpdate) func(tx dbmodel.TxInterface) error {
+	return func(tx dbmodel.TxInterface) error {
+		if claim == nil {
+			return nil
+		}
+		return consentService.stores.Consent.ClaimScheduledUpdate(tx, claim)
+	}
+}
+
+// scheduledUpdateClaimedError reports a scheduled update that was applied, replaced or cancelled
+// while it was being applied
+func scheduledUpdateClaimedError(consentID string) *serviceerror.ServiceError {
+	return serviceerror.CustomServiceError(serviceerror.ConflictError,
+		fmt.Sprintf("Scheduled update of consent with ID '%s' has changed", consentID))
+}
+
+// ApplyScheduledUpdates applies the scheduled updates that took effect, as if they were requested
+// then. Like scheduled revocations, each update is deleted in the transaction that applies it.
+// Updates that failed on a database error or a consent lock are left to be retried on the next
+// run; those the consent no longer allows are dropped.
+func (consentService *consentService) ApplyScheduledUpdates(ctx context.Context) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ApplyScheduledUpdates")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	store := consentService.stores.Consent
+
+	due, err := store.GetDueScheduledUpdates(ctx, utils.GetCurrentTimeMillis(),
+		config.Get().Consent.ScheduledRevocation.BatchSize)
+	if err != nil {
+		logger.Error("Failed to retrieve due scheduled updates", log.Error(err))
+		return
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	applied, dropped, retried := 0, 0, 0
+	for i := range due {
+		update := &due[i]
+		_, serviceErr := consentService.updateConsent(ctx, update.Request, update.OrgID, update.ConsentID, nil, update)
+		switch {
+		case serviceErr == nil:
+			applied++
+		case serviceErr.Type == serviceerror.ServerErrorType || serviceErr.Code == serviceerror.ConsentLockedError.Code:
+			logger.Warn("Scheduled update failed, retrying on the next run",
+				log.String("consent_id", update.ConsentID),
+				log.String("org_id", update.OrgID),
+				log.String("error", serviceErr.Description))
+			retried++
+		default:
+			// The update is only dropped when it is still the one that was read
+			err := consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+				consentService.claimScheduledUpdate(update),
+			})
+			if errors.Is(err, ErrScheduledUpdateClaimed) {
+				continue
+			}
+			if err != nil {
+				logger.Error("Failed to drop scheduled update", log.Error(err), log.String("consent_id", update.ConsentID))
+				continue
+			}
+			logger.Warn("Scheduled update no longer applies to the consent, dropping it",
+				log.String("consent_id", update.ConsentID),
+				log.String("org_id", update.OrgID),
+				log.String("error", serviceErr.Description))
+			dropped++
+		}
+	}
+
+	logger.Info("Applied scheduled updates",
+		log.Int("applied", applied),
+		log.Int("dropped", dropped),
+		log.Int("retried", retried))
+}
@@ -0,0 +1,111 @@
+package consent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// scheduleValidityUpdate schedules an update of the validity time of c1 an hour from now
+func scheduleValidityUpdate(t *testing.T, service *consentService, validityTime int64) *model.ConsentResponse {
+	t.Helper()
+	effectiveAt := utils.GetCurrentTimeMillis()/1000 + 3600
+	response, serviceErr := service.UpdateConsent(context.Background(), model.ConsentAPIUpdateRequest{
+		ValidityTime: &validityTime,
+		EffectiveAt:  &effectiveAt,
+	}, "org-1", "c1")
+	if serviceErr != nil {
+		t.Fatalf("failed to schedule the update: %+v", serviceErr)
+	}
+	return response
+}
+
+// validityTime returns the validity time of c1
+func validityTime(t *testing.T, service *consentService) int64 {
+	t.Helper()
+	consent, err := service.stores.Consent.GetByID(context.Background(), "c1", "org-1")
+	if err != nil || consent == nil {
+		t.Fatalf("failed to load the consent: %v", err)
+	}
+	if consent.ValidityTime == nil {
+		return 0
+	}
+	return *consent.ValidityTime
+}
+
+// TestUpdateConsent_SchedulesUpdatesThatTakeEffectLater checks that an update with a future
+// effectiveAt leaves the consent as it is until the job applies it, and is removed once applied
+func TestUpdateConsent_SchedulesUpdatesThatTakeEffectLater(t *testing.T) {
+	service, db := newScheduledRevocationTestService(t)
+	ctx := context.Background()
+
+	response := scheduleValidityUpdate(t, service, 4000000000)
+	if response.ScheduledUpdate == nil || response.ScheduledUpdate.Update.EffectiveAt != nil {
+		t.Fatalf("expected the scheduled update without its effectiveAt, got %+v", response.ScheduledUpdate)
+	}
+	if got := validityTime(t, service); got != 0 {
+		t.Errorf("expected the consent to be left as it is, got validity time %d", got)
+	}
+
+	// Not due yet
+	service.ApplyScheduledUpdates(ctx)
+	if got := validityTime(t, service); got != 0 {
+		t.Errorf("expected the update to wait for its effectiveAt, got validity time %d", got)
+	}
+
+	if _, err := db.Exec("UPDATE CONSENT_SCHEDULED_UPDATE SET EFFECTIVE_AT = 2000"); err != nil {
+		t.Fatalf("failed to make the update due: %v", err)
+	}
+	service.ApplyScheduledUpdates(ctx)
+
+	if got := validityTime(t, service); got != 4000000000 {
+		t.Errorf("expected the update to be applied, got validity time %d", got)
+	}
+	if _, serviceErr := service.GetScheduledUpdate(ctx, "c1", "org-1"); serviceErr == nil ||
+		serviceErr.Code != serviceerror.ResourceNotFoundError.Code {
+		t.Errorf("expected the applied update to be removed, got %+v", serviceErr)
+	}
+}
+
+// TestApplyScheduledUpdates_KeepsFailedUpdates checks that an update whose transaction fails stays
+// scheduled for the next run
+func TestApplyScheduledUpdates_KeepsFailedUpdates(t *testing.T) {
+	service, db := newScheduledRevocationTestService(t,
+		"CREATE TRIGGER FAIL_CONSENT_UPDATE BEFORE UPDATE ON CONSENT BEGIN SELECT RAISE(ABORT, 'database unavailable'); END",
+		"INSERT INTO CONSENT_SCHEDULED_UPDATE (CONSENT_ID, EFFECTIVE_AT, UPDATE_REQUEST, CREATED_TIME, ORG_ID) "+
+			"VALUES ('c1', 2000, '{\"validityTime\":4000000000}', 1500, 'org-1')")
+	ctx := context.Background()
+
+	service.ApplyScheduledUpdates(ctx)
+
+	if _, serviceErr := service.GetScheduledUpdate(ctx, "c1", "org-1"); serviceErr != nil {
+		t.Fatalf("expected the failed update to stay scheduled, got %+v", serviceErr)
+	}
+
+	if _, err := db.Exec("DROP TRIGGER FAIL_CONSENT_UPDATE"); err != nil {
+		t.Fatalf("failed to restore the database: %v", err)
+	}
+	service.ApplyScheduledUpdates(ctx)
+
+	if got := validityTime(t, service); got != 4000000000 {
+		t.Errorf("expected the next run to apply the update, got validity time %d", got)
+	}
+}
+
+// TestUpdateConsent_ScheduledUpdatesAreDroppedOnRevocation checks that revoking a consent drops its
+// scheduled update
+func TestUpdateConsent_ScheduledUpdatesAreDroppedOnRevocation(t *testing.T) {
+	service, _ := newScheduledRevocationTestService(t)
+	ctx := context.Background()
+	scheduleValidityUpdate(t, service, 4000000000)
+
+	if _, serviceErr := service.RevokeConsent(ctx, "c1", "org-1", model.ConsentRevokeRequest{ActionBy: "user-1"}); serviceErr != nil {
+		t.Fatalf("failed to revoke the consent: %+v", serviceErr)
+	}
+	if _, serviceErr := service.GetScheduledUpdate(ctx, "c1", "org-1"); serviceErr == nil {
+		t.Error("expected the scheduled update to be dropped with the revocation")
+	}
+}
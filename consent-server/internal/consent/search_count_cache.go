@@ -0,0 +1,74 @@
+package consent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// SearchCountCacheName is the name of the consent search count cache in /api/v1/admin/caches
+const SearchCountCacheName = "consent-search-count"
+
+// searchCountKeyPrefix prefixes the filter signature of a cached count. Entries are scoped to the
+// organization by the cache.
+const searchCountKeyPrefix = "count:"
+
+// countCachedStore serves the totals of consent searches from an in-memory cache, keyed by the
+// search filters, so paging through a search runs the count query once per TTL instead of once
+// per page. Everything else is delegated to the wrapped store.
+type countCachedStore struct {
+	interfaces.ConsentStore
+	cache *cache.MemoryCache
+}
+
+// NewSearchCountCachedStore wraps a consent store with a search count cache and registers the
+// cache with the cache manager. Totals can lag behind consents created or deleted within the TTL.
+func NewSearchCountCachedStore(store interfaces.ConsentStore, ttl time.Duration, maxEntries int) interfaces.ConsentStore {
+	countCache := cache.NewMemoryCache(SearchCountCacheName, ttl, maxEntries)
+	cache.GetManager().Register(countCache)
+	return &countCachedStore{
+		ConsentStore: store,
+		cache:        countCache,
+	}
+}
+
+// Search retrieves consents based on filters with pagination. A cached total skips the count query.
+func (s *countCachedStore) Search(ctx context.Context, filters model.ConsentSearchFilters) ([]model.Consent, int, error) {
+	if filters.SkipTotal {
+		return s.ConsentStore.Search(ctx, filters)
+	}
+
+	key := searchCountKeyPrefix + searchCountSignature(filters)
+	if cached, ok := s.cache.Get(filters.OrgID, key); ok {
+		filters.SkipTotal = true
+		consents, _, err := s.ConsentStore.Search(ctx, filters)
+		return consents, cached.(int), err
+	}
+
+	consents, total, err := s.ConsentStore.Search(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.cache.Set(filters.OrgID, key, total)
+	return consents, total, nil
+}
+
+// searchCountSignature identifies the consents a search matches, leaving out the page and the
+// data loaded for it
+func searchCountSignature(filters model.ConsentSearchFilters) string {
+	filters.Limit, filters.Offset = 0, 0
+	filters.CursorMode, filters.Cursor = false, nil
+	filters.Includes = nil
+	filters.OrgID = ""
+
+	// The filters hold only strings, numbers, slices of them and filter expressions, which always marshal
+	data, _ := json.Marshal(filters)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,74 @@
+package consent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// countingSearchStore counts the searches that ran the count query
+type countingSearchStore struct {
+	interfaces.ConsentStore
+	counted int
+	total   int
+}
+
+func (s *countingSearchStore) Search(ctx context.Context, filters model.ConsentSearchFilters) ([]model.Consent, int, error) {
+	if filters.SkipTotal {
+		return []model.Consent{}, 0, nil
+	}
+	s.counted++
+	return []model.Consent{}, s.total, nil
+}
+
+// TestSearchCountCache_CountsOncePerFilters checks that paging through a search counts once, and
+// that other filters and organizations are counted separately
+func TestSearchCountCache_CountsOncePerFilters(t *testing.T) {
+	inner := &countingSearchStore{total: 42}
+	store := NewSearchCountCachedStore(inner, time.Minute, 100)
+	ctx := context.Background()
+
+	filters := model.ConsentSearchFilters{OrgID: "org-1", ConsentStatuses: []string{"ACTIVE"}, Limit: 10}
+	for offset := 0; offset < 30; offset += 10 {
+		filters.Offset = offset
+		_, total, err := store.Search(ctx, filters)
+		if err != nil {
+			t.Fatalf("search failed: %v", err)
+		}
+		if total != 42 {
+			t.Errorf("expected total 42 at offset %d, got %d", offset, total)
+		}
+	}
+	if inner.counted != 1 {
+		t.Errorf("expected one count across pages, got %d", inner.counted)
+	}
+
+	filters.ConsentStatuses = []string{"REVOKED"}
+	if _, _, err := store.Search(ctx, filters); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	filters.OrgID = "org-2"
+	if _, _, err := store.Search(ctx, filters); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if inner.counted != 3 {
+		t.Errorf("expected other filters and organizations to be counted, got %d counts", inner.counted)
+	}
+}
+
+// TestSearchCountCache_SkipTotalBypassesCache checks that searches without a total do not count
+func TestSearchCountCache_SkipTotalBypassesCache(t *testing.T) {
+	inner := &countingSearchStore{total: 42}
+	store := NewSearchCountCachedStore(inner, time.Minute, 100)
+
+	_, total, err := store.Search(context.Background(), model.ConsentSearchFilters{OrgID: "org-1", SkipTotal: true})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if total != 0 || inner.counted != 0 {
+		t.Errorf("expected no count, got total %d after %d counts", total, inner.counted)
+	}
+}
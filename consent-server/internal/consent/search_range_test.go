@@ -0,0 +1,100 @@
+package consent
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/database/dbtest"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+)
+
+// newRangeSearchStore returns a store holding consents of org-1 created, updated and expiring at
+// known times. c3 expires at a validity time in seconds, c4 never expires and c5 belongs to org-2.
+func newRangeSearchStore(t *testing.T) *store {
+	return &store{dbClient: dbtest.NewSQLiteClient(t,
+		"INSERT INTO CONSENT (CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, "+
+			"CURRENT_STATUS, VALIDITY_TIME, ORG_ID) VALUES "+
+			"('c1', 1000, 5000, 'client-1', 'accounts', 'ACTIVE', 2000000000000, 'org-1'), "+
+			"('c2', 2000, 6000, 'client-1', 'accounts', 'ACTIVE', 3000000000000, 'org-1'), "+
+			"('c3', 3000, 7000, 'client-1', 'accounts', 'ACTIVE', 2500000000, 'org-1'), "+
+			"('c4', 4000, 8000, 'client-1', 'accounts', 'ACTIVE', 0, 'org-1'), "+
+			"('c5', 2000, 6000, 'client-1', 'accounts', 'ACTIVE', 3000000000000, 'org-2')",
+	)}
+}
+
+// TestSearch_TimeRangeFilters checks the inclusive created, updated and expiry range filters, and
+// that expiry filters compare validity times stored in seconds in milliseconds
+func TestSearch_TimeRangeFilters(t *testing.T) {
+	s := newRangeSearchStore(t)
+	at := func(value int64) *int64 { return &value }
+
+	testCases := []struct {
+		name    string
+		filters model.ConsentSearchFilters
+		want    []string
+	}{
+		{"no range", model.ConsentSearchFilters{}, []string{"c1", "c2", "c3", "c4"}},
+		{"created from", model.ConsentSearchFilters{CreatedTimeFrom: at(2000)}, []string{"c2", "c3", "c4"}},
+		{"created window", model.ConsentSearchFilters{CreatedTimeFrom: at(2000), CreatedTimeTo: at(3000)}, []string{"c2", "c3"}},
+		{"updated to", model.ConsentSearchFilters{UpdatedTimeTo: at(6000)}, []string{"c1", "c2"}},
+		{"updated window", model.ConsentSearchFilters{UpdatedTimeFrom: at(6500), UpdatedTimeTo: at(7500)}, []string{"c3"}},
+		{"expires before", model.ConsentSearchFilters{ExpiresBefore: at(2600000000000)}, []string{"c1", "c3"}},
+		{"expires after", model.ConsentSearchFilters{ExpiresAfter: at(2600000000000)}, []string{"c2"}},
+		{"expiry window", model.ConsentSearchFilters{ExpiresAfter: at(2000000000000), ExpiresBefore: at(2500000000000)}, []string{"c1", "c3"}},
+		{"combined", model.ConsentSearchFilters{CreatedTimeFrom: at(2000), ExpiresBefore: at(2600000000000)}, []string{"c3"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filters.OrgID = "org-1"
+			tc.filters.Limit = 10
+			consents, total, err := s.Search(context.Background(), tc.filters)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			ids := make([]string, 0, len(consents))
+			for _, consent := range consents {
+				ids = append(ids, consent.ConsentID)
+			}
+			sort.Strings(ids)
+			if !reflect.DeepEqual(ids, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, ids)
+			}
+			if total != len(tc.want) {
+				t.Errorf("expected a total of %d, got %d", len(tc.want), total)
+			}
+		})
+	}
+}
+
+// TestSearchConsentsDetailed_RejectsInvertedRanges checks that a range whose lower bound is after
+// its upper bound is rejected before the store is queried
+func TestSearchConsentsDetailed_RejectsInvertedRanges(t *testing.T) {
+	at := func(value int64) *int64 { return &value }
+	service := &consentService{}
+
+	testCases := []struct {
+		name    string
+		filters model.ConsentSearchFilters
+	}{
+		{"created", model.ConsentSearchFilters{CreatedTimeFrom: at(2), CreatedTimeTo: at(1)}},
+		{"updated", model.ConsentSearchFilters{UpdatedTimeFrom: at(2), UpdatedTimeTo: at(1)}},
+		{"expiry", model.ConsentSearchFilters{ExpiresAfter: at(2), ExpiresBefore: at(1)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, serviceErr := service.SearchConsentsDetailed(context.Background(), tc.filters)
+			if serviceErr == nil || serviceErr.Code != serviceerror.ValidationError.Code {
+				t.Errorf("expected a validation error, got %+v", serviceErr)
+			}
+		})
+	}
+
+	if err := validateSearchRanges(model.ConsentSearchFilters{CreatedTimeFrom: at(1), CreatedTimeTo: at(1)}); err != nil {
+		t.Errorf("expected equal bounds to be accepted, got %v", err)
+	}
+}
@@ -2,18 +2,41 @@ package consent
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	authmodel "github.com/wso2/consent-management-api/internal/authresource/model"
+	"github.com/wso2/consent-management-api/internal/consent/interceptor"
 	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/consent/policy"
 	"github.com/wso2/consent-management-api/internal/consent/validator"
 	purposemodel "github.com/wso2/consent-management-api/internal/consentpurpose/model"
+	outboxmodel "github.com/wso2/consent-management-api/internal/eventoutbox/model"
+	resourceschemamodel "github.com/wso2/consent-management-api/internal/resourceschema/model"
+	opaudit "github.com/wso2/consent-management-api/internal/system/audit"
+	"github.com/wso2/consent-management-api/internal/system/cache"
 	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/consentlock"
 	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
 	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/events"
+	"github.com/wso2/consent-management-api/internal/system/extension"
+	"github.com/wso2/consent-management-api/internal/system/featureflag"
+	"github.com/wso2/consent-management-api/internal/system/idgen"
 	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/notification"
+	"github.com/wso2/consent-management-api/internal/system/signing"
 	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
 	"github.com/wso2/consent-management-api/internal/system/utils"
 )
 
@@ -21,29 +44,87 @@ import (
 type ConsentService interface {
 	CreateConsent(ctx context.Context, req model.ConsentAPIRequest, clientID, orgID string) (*model.ConsentResponse, *serviceerror.ServiceError)
 	GetConsent(ctx context.Context, consentID, orgID string) (*model.ConsentResponse, *serviceerror.ServiceError)
+	GetConsentIncluding(ctx context.Context, consentID, orgID string, includes model.ConsentIncludes) (*model.ConsentResponse, *serviceerror.ServiceError)
+	GetConsents(ctx context.Context, consentIDs []string, orgID string) (*model.ConsentBatchGetResponse, *serviceerror.ServiceError)
 	ListConsents(ctx context.Context, orgID string, limit, offset int) ([]model.ConsentResponse, int, *serviceerror.ServiceError)
 	SearchConsents(ctx context.Context, filters model.ConsentSearchFilters) ([]model.ConsentResponse, int, *serviceerror.ServiceError)
 	SearchConsentsDetailed(ctx context.Context, filters model.ConsentSearchFilters) (*model.ConsentDetailSearchResponse, *serviceerror.ServiceError)
+	ExportConsents(ctx context.Context, filters model.ConsentSearchFilters, emit func([]model.ConsentExportRecord) error) *serviceerror.ServiceError
 	UpdateConsent(ctx context.Context, req model.ConsentAPIUpdateRequest, orgID, consentID string) (*model.ConsentResponse, *serviceerror.ServiceError)
 	RevokeConsent(ctx context.Context, consentID, orgID string, req model.ConsentRevokeRequest) (*model.ConsentRevokeResponse, *serviceerror.ServiceError)
+	DeleteConsent(ctx context.Context, consentID, orgID, clientID string) *serviceerror.ServiceError
+	PurgeDeletedConsents(ctx context.Context)
+	ApplyRetention(ctx context.Context)
+	ApplyScheduledRevocations(ctx context.Context)
+	ApplyScheduledUpdates(ctx context.Context)
+	CleanupExpiredTokens(ctx context.Context)
+	GetScheduledRevocation(ctx context.Context, consentID, orgID string) (*model.ScheduledRevocationResponse, *serviceerror.ServiceError)
+	CancelScheduledRevocation(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError
+	GetScheduledUpdate(ctx context.Context, consentID, orgID string) (*model.ScheduledUpdateResponse, *serviceerror.ServiceError)
+	CancelScheduledUpdate(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError
+	NotifyExpiringConsents(ctx context.Context)
+	ReauthorizeConsent(ctx context.Context, consentID, orgID string, req model.ConsentReauthorizationRequest) (*model.ConsentReauthorizationResponse, *serviceerror.ServiceError)
+	SuspendConsent(ctx context.Context, consentID, orgID string, req model.ConsentSuspensionRequest) (*model.ConsentSuspensionResponse, *serviceerror.ServiceError)
+	ResumeConsent(ctx context.Context, consentID, orgID string, req model.ConsentSuspensionRequest) (*model.ConsentSuspensionResponse, *serviceerror.ServiceError)
 	ValidateConsent(ctx context.Context, req model.ValidateRequest, orgID string) (*model.ValidateResponse, *serviceerror.ServiceError)
-	SearchConsentsByAttribute(ctx context.Context, key, value, orgID string) (*model.ConsentAttributeSearchResponse, *serviceerror.ServiceError)
+	SearchConsentsByAttribute(ctx context.Context, attributes []model.AttributeFilter, orgID string) (*model.ConsentAttributeSearchResponse, *serviceerror.ServiceError)
+	AmendConsent(ctx context.Context, req model.ConsentAmendmentRequest, orgID, consentID string) (*model.ConsentResponse, *serviceerror.ServiceError)
+	GetConsentVersions(ctx context.Context, consentID, orgID string) (*model.ConsentVersionListResponse, *serviceerror.ServiceError)
+	GetConsentVersion(ctx context.Context, consentID, orgID string, version int) (*model.ConsentVersionResponse, *serviceerror.ServiceError)
+	GetRelationship(ctx context.Context, userID, clientID, orgID string) (*model.RelationshipResponse, *serviceerror.ServiceError)
+	ListUserConsents(ctx context.Context, userID string, filters model.ConsentSearchFilters) (*model.UserConsentListResponse, *serviceerror.ServiceError)
+	ExportUserConsents(ctx context.Context, userID, orgID string) (*model.UserConsentExport, *serviceerror.ServiceError)
+	GetConsentReceipt(ctx context.Context, consentID, orgID string) (*model.ConsentReceiptResponse, *serviceerror.ServiceError)
+	TransferOwnership(ctx context.Context, req model.OwnershipTransferRequest, orgID string) (*model.OwnershipTransferResponse, *serviceerror.ServiceError)
+	EraseUser(ctx context.Context, userID, orgID string) (*model.UserErasureResponse, *serviceerror.ServiceError)
+	GetUserPreferences(ctx context.Context, userID, orgID string) (*model.UserPreferencesResponse, *serviceerror.ServiceError)
+	UpdateUserPreferences(ctx context.Context, userID, orgID string, req model.UserPreferencesUpdateRequest) (*model.UserPreferencesResponse, *serviceerror.ServiceError)
+	OverrideStatus(ctx context.Context, consentID, orgID string, req model.StatusOverrideRequest) (*model.StatusOverrideResponse, *serviceerror.ServiceError)
+	GetConsentUsage(ctx context.Context, consentID, orgID string) (*model.ConsentUsageResponse, *serviceerror.ServiceError)
+	GetRequiredAuthorizers(ctx context.Context, consentID, orgID string) (*model.RequiredAuthorizersResponse, *serviceerror.ServiceError)
+	LockConsent(ctx context.Context, consentID, orgID, clientID string) (*model.ConsentLock, *serviceerror.ServiceError)
+	UnlockConsent(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError
+	SearchStatusAudit(ctx context.Context, filters model.StatusAuditSearchFilters) (*model.StatusAuditSearchResponse, *serviceerror.ServiceError)
+	AddTags(ctx context.Context, consentID, orgID string, req model.ConsentTagsRequest) (*model.ConsentTagsResponse, *serviceerror.ServiceError)
+	RemoveTag(ctx context.Context, consentID, orgID, tag string) *serviceerror.ServiceError
+	CreateNote(ctx context.Context, consentID, orgID string, req model.ConsentNoteRequest) (*model.ConsentNote, *serviceerror.ServiceError)
+	ListNotes(ctx context.Context, consentID, orgID string) (*model.ConsentNoteListResponse, *serviceerror.ServiceError)
+	ListEvidence(ctx context.Context, consentID, orgID string) (*model.ConsentEvidenceListResponse, *serviceerror.ServiceError)
+	BindToken(ctx context.Context, consentID, orgID string, req model.ConsentTokenRequest) (*model.ConsentTokenResponse, bool, *serviceerror.ServiceError)
+	ListTokens(ctx context.Context, consentID, orgID string) (*model.ConsentTokenListResponse, *serviceerror.ServiceError)
+	UnbindToken(ctx context.Context, consentID, orgID, tokenID string) *serviceerror.ServiceError
+	GetTokenConsent(ctx context.Context, tokenID, orgID string) (*model.TokenConsentResponse, *serviceerror.ServiceError)
 }
 
+// maxBatchGetConsentIDs is the maximum number of consent IDs accepted by GetConsents
+const maxBatchGetConsentIDs = 100
+
+// exportPageSize is the number of consents read per query while streaming an export
+const exportPageSize = 500
+
 // consentService implements the ConsentService interface
 type consentService struct {
-	stores *stores.StoreRegistry
+	stores                 *stores.StoreRegistry
+	receiptSigner          *signing.Signer // nil when consent receipts are not configured
+	consentIDs             idgen.Generator
+	modifiedResponseSchema modifiedResponseSchema
 }
 
 // newConsentService creates a new consent service
 func newConsentService(registry *stores.StoreRegistry) ConsentService {
 	return &consentService{
-		stores: registry,
+		stores:                 registry,
+		receiptSigner:          loadReceiptSigner(),
+		consentIDs:             idgen.NewConsentIDGenerator(config.Get().Consent.IDGeneration),
+		modifiedResponseSchema: loadModifiedResponseSchema(),
 	}
 }
 
 // CreateConsent creates a new consent with all related entities in a single transaction
 func (consentService *consentService) CreateConsent(ctx context.Context, req model.ConsentAPIRequest, clientID, orgID string) (*model.ConsentResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.CreateConsent")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 
 	logger.Info("Creating consent",
@@ -51,6 +132,25 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 		log.String("org_id", orgID),
 		log.String("consent_type", req.Type))
 
+	// Let the compiled-in interceptors, then the service extension, adjust or reject the request
+	// before it is validated
+	if interceptor.Enabled() {
+		payload := &model.ConsentCreateHookPayload{ClientID: clientID, ConsentRequest: req}
+		if err := interceptor.PreCreate(ctx, orgID, payload); err != nil {
+			logger.Warn("Consent creation rejected by consent interceptor", log.Error(err))
+			return nil, extensionServiceError(err)
+		}
+		req = payload.ConsentRequest
+	}
+	if extension.IsEnabled(extension.PreProcessConsentCreation) {
+		payload := &model.ConsentCreateHookPayload{ClientID: clientID, ConsentRequest: req}
+		if err := extension.Invoke(ctx, extension.PreProcessConsentCreation, orgID, payload); err != nil {
+			logger.Warn("Consent creation rejected by service extension", log.Error(err))
+			return nil, extensionServiceError(err)
+		}
+		req = payload.ConsentRequest
+	}
+
 	// Validate request
 	if err := utils.ValidateOrgID(orgID); err != nil {
 		logger.Warn("Invalid organization ID", log.Error(err), log.String("org_id", orgID))
@@ -62,10 +162,28 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 	}
 	if err := validator.ValidateConsentCreateRequest(req, clientID, orgID); err != nil {
 		logger.Warn("Consent create request validation failed", log.Error(err))
-		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+		return nil, serviceerror.ValidationErrorFrom(err)
+	}
+	consentCfg := config.Get().Consent.ForOrg(orgID)
+	if !consentCfg.IsConsentTypeAllowed(req.Type) {
+		logger.Warn("Consent type not allowed for organization", log.String("consent_type", req.Type))
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("consent type '%s' is not allowed for organization '%s'", req.Type, orgID))
+	}
+	if featureflag.IsEnabled(orgID, featureflag.StrictValidation) {
+		if err := validator.ValidateStrictConsentFields(req.ConsentPurpose, req.Attributes, req.ValidityTime); err != nil {
+			logger.Warn("Consent create request failed strict validation", log.Error(err))
+			return nil, serviceerror.ValidationErrorFrom(err)
+		}
+	}
+	if serviceErr := consentService.enforceAttributeSchema(ctx, req.Attributes, req.Type, "", orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+	if req.ParentConsentID != nil {
+		if serviceErr := consentService.validateParentConsent(ctx, *req.ParentConsentID, clientID, orgID); serviceErr != nil {
+			return nil, serviceErr
+		}
 	}
-
-	logger.Debug("Request validation successful")
 
 	logger.Debug("Request validation successful")
 
@@ -75,25 +193,52 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 		logger.Error("Failed to convert API request to internal format", log.Error(err))
 		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
 	}
+	if serviceErr := consentService.enforceResourceSchemas(ctx, createReq.AuthResources, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
 
-	// Extract auth statuses
+	// Extract auth types and statuses
+	authTypes := make([]string, 0, len(createReq.AuthResources))
 	authStatuses := make([]string, 0, len(createReq.AuthResources))
 	for _, ar := range createReq.AuthResources {
+		authTypes = append(authTypes, ar.AuthType)
 		authStatuses = append(authStatuses, ar.AuthStatus)
 	}
 
-	// Derive consent status from authorization states
-	consentStatus := validator.EvaluateConsentStatusFromAuthStatuses(authStatuses)
+	// Derive consent status from authorization states, holding it back until every required authorizer approved
+	consentStatus := validator.EvaluateConsentStatusFromAuthStatuses(orgID, createReq.ConsentType, authStatuses)
+	pending := validator.PendingAuthorizers(orgID, createReq.RequiredAuthorizers, authTypes, authStatuses)
+	consentStatus = validator.ApplyRequiredAuthorizers(orgID, consentStatus, pending, authStatuses)
 	logger.Debug("Consent status derived from authorizations",
 		log.String("consent_status", consentStatus),
 		log.Int("auth_count", len(authStatuses)))
 
+	if err := validator.ValidateStatusTransition(orgID, "", consentStatus); err != nil {
+		logger.Warn("Consent status rejected by the state machine", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	if consentStatus == string(consentCfg.GetActiveConsentStatus()) &&
+		featureflag.IsEnabled(orgID, featureflag.PurposeEnforcement) {
+		if unapproved := validator.UnapprovedMandatoryPurposes(req.ConsentPurpose); len(unapproved) > 0 {
+			logger.Warn("Mandatory purposes not approved by the user", log.Any("purposes", unapproved))
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+				fmt.Sprintf("mandatory purposes must be approved by the user: %v", unapproved))
+		}
+	}
+
 	// Generate IDs and timestamp
-	consentID := utils.GenerateUUID()
+	consentID := consentService.consentIDs.NewID(orgID)
 	currentTime := utils.GetCurrentTimeMillis()
 
 	logger.Debug("Generated consent ID", log.String("consent_id", consentID))
 
+	// Consents created without a validity time expire after the organization's default validity
+	if (createReq.ValidityTime == nil || *createReq.ValidityTime == 0) && consentCfg.DefaultValidity > 0 {
+		validityTime := currentTime/1000 + int64(consentCfg.DefaultValidity/time.Second)
+		createReq.ValidityTime = &validityTime
+	}
+
 	// Create consent entity
 	consent := &model.Consent{
 		ConsentID:                  consentID,
@@ -106,6 +251,8 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 		ValidityTime:               createReq.ValidityTime,
 		RecurringIndicator:         createReq.RecurringIndicator,
 		DataAccessValidityDuration: createReq.DataAccessValidityDuration,
+		ParentConsentID:            createReq.ParentConsentID,
+		Version:                    1,
 		OrgID:                      orgID,
 	}
 
@@ -139,6 +286,13 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 		})
 	}
 
+	if len(createReq.RequiredAuthorizers) > 0 {
+		logger.Debug("Adding required authorizers", log.Int("authorizer_count", len(createReq.RequiredAuthorizers)))
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return consentStore.CreateRequiredAuthorizers(tx, consentID, orgID, createReq.RequiredAuthorizers)
+		})
+	}
+
 	// Create audit record
 	auditID := utils.GenerateUUID()
 	actionBy := clientID // Client ID as the action initiator
@@ -184,6 +338,8 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 			userIDPtr = &authReq.UserID
 		}
 
+		delegatorID, delegateID := authReq.Delegation()
+
 		authResource := &authmodel.AuthResource{
 			AuthID:      authID,
 			ConsentID:   consentID,
@@ -193,6 +349,8 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 			UpdatedTime: currentTime,
 			Resources:   resourcesJSON,
 			OrgID:       orgID,
+			DelegatorID: delegatorID,
+			DelegateID:  delegateID,
 		}
 
 		queries = append(queries, func(tx dbmodel.TxInterface) error {
@@ -259,16 +417,39 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 				isMandatory = *purposeItem.IsMandatory
 			}
 
+			expiresAt := purposeItem.ExpiresAt
+			lastConfirmedAt := purposeItem.LastConfirmedAt
+
 			// Add to transaction queries
 			queries = append(queries, func(tx dbmodel.TxInterface) error {
-				return purposeStore.LinkPurposeToConsent(tx, consentID, purposeID, orgID, valueJSON, isUserApproved, isMandatory)
+				return purposeStore.LinkPurposeToConsent(tx, consentID, purposeID, orgID, valueJSON, isUserApproved, isMandatory, expiresAt, lastConfirmedAt)
 			})
 		}
 	}
 
+	// Record the evidence of how the consent was collected
+	var evidence *model.ConsentEvidence
+	if req.Evidence != nil {
+		evidence = model.NewConsentEvidence(*req.Evidence, utils.GenerateUUID(), consentID, orgID, consent.Version, currentTime)
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return consentStore.CreateEvidence(tx, evidence)
+		})
+	}
+
+	queries = append(queries, consentService.recordEvent(events.ConsentEvent{
+		ID:         utils.GenerateUUID(),
+		Type:       events.ConsentCreated,
+		Timestamp:  consent.CreatedTime,
+		OrgID:      orgID,
+		ConsentID:  consentID,
+		ClientID:   consent.ClientID,
+		Status:     consent.CurrentStatus,
+		Attributes: createReq.Attributes,
+	}))
+
 	// Execute all operations in a single transaction
 	logger.Debug("Executing transaction", log.Int("operation_count", len(queries)))
-	if err := consentService.stores.ExecuteTransaction(queries); err != nil {
+	if err := consentService.stores.ExecuteTransaction(ctx, queries); err != nil {
 		logger.Error("Failed to create consent in transaction",
 			log.Error(err),
 			log.String("consent_id", consentID))
@@ -277,6 +458,18 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 
 	logger.Info("Consent created successfully", log.String("consent_id", consentID))
 
+	// Record the created consent and what it was created with in the operation audit
+	opaudit.SetConsentID(ctx, consentID)
+	purposes := make([]string, 0, len(createReq.ConsentPurpose))
+	for _, purpose := range createReq.ConsentPurpose {
+		purposes = append(purposes, purpose.Name)
+	}
+	auditChanges(ctx, "attributes", "added", sortedKeys(createReq.Attributes))
+	auditChanges(ctx, "purposes", "linked", purposes)
+	if evidence != nil {
+		opaudit.AddDetail(ctx, "evidence", evidence)
+	}
+
 	// TODO : check consent expireation and handle accordingly.
 
 	// Retrieve related data after creation
@@ -301,11 +494,40 @@ func (consentService *consentService) CreateConsent(ctx context.Context, req mod
 		log.Int("purposes", len(purposeMappings)),
 		log.Int("attributes", len(attributesMap)))
 
+	if interceptor.Enabled() {
+		payload := &model.ConsentEnrichHookPayload{Consent: response.ToAPIResponse()}
+		if err := interceptor.PostCreate(ctx, orgID, payload); err != nil {
+			logger.Warn("Consent interceptor failed to enrich consent response", log.Error(err))
+			return nil, extensionServiceError(err)
+		}
+		if payload.ModifiedResponse != nil {
+			if _, err := consentService.encodeModifiedResponse(payload.ModifiedResponse); err != nil {
+				logger.Error("Consent interceptor returned an invalid modifiedResponse", log.Error(err))
+				return nil, serviceerror.CustomServiceError(serviceerror.InternalServerError, err.Error())
+			}
+		}
+		response.ModifiedResponse = payload.ModifiedResponse
+	}
+	if serviceErr := consentService.enrichConsentResponse(ctx, extension.EnrichConsentCreationResponse, orgID, response); serviceErr != nil {
+		return nil, serviceErr
+	}
+	if serviceErr := consentService.saveModifiedResponse(ctx, orgID, response); serviceErr != nil {
+		return nil, serviceErr
+	}
+
 	return response, nil
 }
 
 // GetConsent retrieves a consent by ID with all related data
 func (consentService *consentService) GetConsent(ctx context.Context, consentID, orgID string) (*model.ConsentResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetConsent")
+	defer span.End()
+
+	return consentService.getConsent(ctx, consentID, orgID, model.DefaultConsentIncludes())
+}
+
+// getConsent retrieves a consent with the selected related data
+func (consentService *consentService) getConsent(ctx context.Context, consentID, orgID string, includes model.ConsentIncludes) (*model.ConsentResponse, *serviceerror.ServiceError) {
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Retrieving consent",
 		log.String("consent_id", consentID),
@@ -333,10 +555,19 @@ func (consentService *consentService) GetConsent(ctx context.Context, consentID,
 
 	// TODO : check consent expireation and handle accordingly.
 
-	// Retrieve all related data
-	attributes, _ := consentStore.GetAttributesByConsentID(ctx, consentID, orgID)
-	authResources, _ := authResourceStore.GetByConsentID(ctx, consentID, orgID)
-	purposeMappings, _ := purposeStore.GetMappingsByConsentID(ctx, consentID, orgID)
+	// Retrieve the selected related data
+	var attributes []model.ConsentAttribute
+	var authResources []authmodel.AuthResource
+	var purposeMappings []purposemodel.ConsentPurposeMapping
+	if includes.Attributes {
+		attributes, _ = consentStore.GetAttributesByConsentID(ctx, consentID, orgID)
+	}
+	if includes.Authorizations {
+		authResources, _ = authResourceStore.GetByConsentID(ctx, consentID, orgID)
+	}
+	if includes.Purposes {
+		purposeMappings, _ = purposeStore.GetMappingsByConsentID(ctx, consentID, orgID)
+	}
 
 	// Convert attributes slice to map
 	attributesMap := make(map[string]string)
@@ -346,6 +577,25 @@ func (consentService *consentService) GetConsent(ctx context.Context, consentID,
 
 	// Build complete response with all related data
 	response := buildConsentResponse(consent, attributesMap, authResources, purposeMappings)
+	if includes.Tags {
+		if response.Tags, err = consentStore.GetTagsByConsentID(ctx, consentID, orgID); err != nil {
+			logger.Error("Failed to retrieve consent tags", log.Error(err), log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+	}
+	if err := consentService.loadModifiedResponse(ctx, orgID, response); err != nil {
+		logger.Error("Failed to retrieve modifiedResponse", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	if includes.History {
+		history, err := consentStore.GetHistoryByConsentID(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve consent history", log.Error(err), log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		response.History = versionSummaries(history)
+	}
 
 	logger.Debug("Consent retrieved successfully",
 		log.String("consent_id", consentID),
@@ -356,8 +606,242 @@ func (consentService *consentService) GetConsent(ctx context.Context, consentID,
 	return response, nil
 }
 
+// validateParentConsent checks that a new consent can be created under the given parent: the
+// parent must exist in the organization, belong to the same client and not be revoked or expired
+func (consentService *consentService) validateParentConsent(ctx context.Context, parentConsentID, clientID, orgID string) *serviceerror.ServiceError {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateConsentID(parentConsentID); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("invalid parentConsentId: %v", err))
+	}
+
+	parent, err := consentService.stores.Consent.GetByID(ctx, parentConsentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve parent consent", log.Error(err), log.String("parent_consent_id", parentConsentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if parent == nil || parent.ClientID != clientID {
+		logger.Warn("Parent consent not found", log.String("parent_consent_id", parentConsentID))
+		return serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("parent consent '%s' not found", parentConsentID))
+	}
+	if config.Get().Consent.ForOrg(orgID).IsTerminalStatus(config.ConsentStatus(parent.CurrentStatus)) {
+		logger.Warn("Parent consent is in a terminal status",
+			log.String("parent_consent_id", parentConsentID),
+			log.String("status", parent.CurrentStatus))
+		return serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("parent consent '%s' is %s", parentConsentID, parent.CurrentStatus))
+	}
+	return nil
+}
+
+// GetConsentIncluding retrieves a consent with the selected related data. With includes.Children
+// its child consents and, recursively, their children are attached with the same related data.
+func (consentService *consentService) GetConsentIncluding(ctx context.Context, consentID, orgID string, includes model.ConsentIncludes) (*model.ConsentResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetConsentIncluding")
+	defer span.End()
+
+	root, serviceErr := consentService.getConsent(ctx, consentID, orgID, includes)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	if !includes.Children {
+		return root, nil
+	}
+
+	// Children are attached level by level; visited guards against a malformed hierarchy
+	visited := map[string]bool{root.ConsentID: true}
+	level := []*model.ConsentResponse{root}
+	for len(level) > 0 {
+		var next []*model.ConsentResponse
+		for _, node := range level {
+			children, err := consentService.stores.Consent.GetChildConsents(ctx, node.ConsentID, orgID)
+			if err != nil {
+				log.GetLogger().WithContext(ctx).Error("Failed to retrieve child consents",
+					log.Error(err), log.String("consent_id", node.ConsentID))
+				return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+			}
+
+			node.Children = make([]model.ConsentResponse, 0, len(children))
+			for _, child := range children {
+				if visited[child.ConsentID] {
+					continue
+				}
+				visited[child.ConsentID] = true
+
+				childResp, serviceErr := consentService.getConsent(ctx, child.ConsentID, orgID, includes)
+				if serviceErr != nil {
+					return nil, serviceErr
+				}
+				node.Children = append(node.Children, *childResp)
+			}
+			for i := range node.Children {
+				next = append(next, &node.Children[i])
+			}
+		}
+		level = next
+	}
+
+	return root, nil
+}
+
+// allConsentRelations selects every relation loaded by loadConsentRelations
+var allConsentRelations = model.ConsentIncludes{Authorizations: true, Purposes: true, Attributes: true}
+
+// consentRelations holds the authorization resources, purpose mappings and attributes of a set of
+// consents, grouped by consent ID
+type consentRelations struct {
+	authResources   map[string][]authmodel.AuthResource
+	purposeMappings map[string][]purposemodel.ConsentPurposeMapping
+	attributes      map[string]map[string]string
+}
+
+// loadConsentRelations retrieves the authorization resources, purpose mappings and attributes
+// selected by includes with one query each, however many consents are given
+func (consentService *consentService) loadConsentRelations(ctx context.Context, consentIDs []string, orgID string, includes model.ConsentIncludes) (*consentRelations, error) {
+	relations := &consentRelations{
+		authResources:   make(map[string][]authmodel.AuthResource),
+		purposeMappings: make(map[string][]purposemodel.ConsentPurposeMapping),
+		attributes:      make(map[string]map[string]string),
+	}
+
+	if includes.Authorizations {
+		authResources, err := consentService.stores.AuthResource.GetByConsentIDs(ctx, consentIDs, orgID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get authorization resources: %w", err)
+		}
+		for _, auth := range authResources {
+			relations.authResources[auth.ConsentID] = append(relations.authResources[auth.ConsentID], auth)
+		}
+	}
+
+	if includes.Purposes {
+		purposeMappings, err := consentService.stores.ConsentPurpose.GetMappingsByConsentIDs(ctx, consentIDs, orgID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get purpose mappings: %w", err)
+		}
+		for _, mapping := range purposeMappings {
+			relations.purposeMappings[mapping.ConsentID] = append(relations.purposeMappings[mapping.ConsentID], mapping)
+		}
+	}
+
+	if includes.Attributes {
+		attributes, err := consentService.stores.Consent.GetAttributesByConsentIDs(ctx, consentIDs, orgID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get consent attributes: %w", err)
+		}
+		if attributes != nil {
+			relations.attributes = attributes
+		}
+	}
+	return relations, nil
+}
+
+// buildConsentResponse builds the response of a consent with its loaded relations
+func (relations *consentRelations) buildConsentResponse(consent *model.Consent) *model.ConsentResponse {
+	attributes := relations.attributes[consent.ConsentID]
+	if attributes == nil {
+		attributes = make(map[string]string)
+	}
+	return buildConsentResponse(consent, attributes, relations.authResources[consent.ConsentID],
+		relations.purposeMappings[consent.ConsentID])
+}
+
+// GetConsents retrieves up to maxBatchGetConsentIDs consents with all related data. Related data
+// is fetched with one query per table for the whole batch.
+func (consentService *consentService) GetConsents(ctx context.Context, consentIDs []string, orgID string) (*model.ConsentBatchGetResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetConsents")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Debug("Retrieving consents in batch",
+		log.String("org_id", orgID),
+		log.Int("requested", len(consentIDs)),
+	)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	// Drop duplicates, keeping the order of first appearance
+	ids := make([]string, 0, len(consentIDs))
+	seen := make(map[string]bool, len(consentIDs))
+	for _, id := range consentIDs {
+		if seen[id] {
+			continue
+		}
+		if err := utils.ValidateConsentID(id); err != nil {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "consentIds must contain at least one consent ID")
+	}
+	if len(ids) > maxBatchGetConsentIDs {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("consentIds must not contain more than %d consent IDs", maxBatchGetConsentIDs))
+	}
+
+	consentStore := consentService.stores.Consent
+	consents, err := consentStore.GetByIDs(ctx, ids, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consents", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	response := &model.ConsentBatchGetResponse{
+		Data:     make([]model.ConsentAPIResponse, 0, len(consents)),
+		NotFound: make([]string, 0),
+	}
+	if len(consents) == 0 {
+		response.NotFound = append(response.NotFound, ids...)
+		return response, nil
+	}
+
+	foundIDs := make([]string, len(consents))
+	consentsByID := make(map[string]*model.Consent, len(consents))
+	for i := range consents {
+		foundIDs[i] = consents[i].ConsentID
+		consentsByID[consents[i].ConsentID] = &consents[i]
+	}
+
+	relations, err := consentService.loadConsentRelations(ctx, foundIDs, orgID, allConsentRelations)
+	if err != nil {
+		logger.Error("Failed to get consent relations", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	tagsByConsent, err := consentStore.GetTagsByConsentIDs(ctx, foundIDs, orgID)
+	if err != nil {
+		logger.Error("Failed to get consent tags", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	for _, id := range ids {
+		consent, found := consentsByID[id]
+		if !found {
+			response.NotFound = append(response.NotFound, id)
+			continue
+		}
+		consentResponse := relations.buildConsentResponse(consent)
+		consentResponse.Tags = tagsByConsent[id]
+		response.Data = append(response.Data, *consentResponse.ToAPIResponse())
+	}
+
+	logger.Debug("Consents retrieved in batch",
+		log.Int("found", len(response.Data)),
+		log.Int("not_found", len(response.NotFound)),
+	)
+	return response, nil
+}
+
 // ListConsents retrieves paginated list of consents
 func (consentService *consentService) ListConsents(ctx context.Context, orgID string, limit, offset int) ([]model.ConsentResponse, int, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ListConsents")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Listing consents",
 		log.String("org_id", orgID),
@@ -396,6 +880,8 @@ func (consentService *consentService) ListConsents(ctx context.Context, orgID st
 			ValidityTime:               c.ValidityTime,
 			RecurringIndicator:         c.RecurringIndicator,
 			DataAccessValidityDuration: c.DataAccessValidityDuration,
+			ParentConsentID:            c.ParentConsentID,
+			Version:                    c.Version,
 			OrgID:                      c.OrgID,
 		})
 	}
@@ -409,6 +895,9 @@ func (consentService *consentService) ListConsents(ctx context.Context, orgID st
 
 // SearchConsents retrieves consents based on search filters with pagination
 func (consentService *consentService) SearchConsents(ctx context.Context, filters model.ConsentSearchFilters) ([]model.ConsentResponse, int, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.SearchConsents")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Searching consents",
 		log.String("org_id", filters.OrgID),
@@ -450,6 +939,8 @@ func (consentService *consentService) SearchConsents(ctx context.Context, filter
 			ValidityTime:               c.ValidityTime,
 			RecurringIndicator:         c.RecurringIndicator,
 			DataAccessValidityDuration: c.DataAccessValidityDuration,
+			ParentConsentID:            c.ParentConsentID,
+			Version:                    c.Version,
 			OrgID:                      c.OrgID,
 		})
 	}
@@ -461,8 +952,29 @@ func (consentService *consentService) SearchConsents(ctx context.Context, filter
 	return responses, total, nil
 }
 
+// validateSearchRanges checks that the lower bound of every time range filter is not after its upper bound
+func validateSearchRanges(filters model.ConsentSearchFilters) error {
+	ranges := []struct {
+		from, to         *int64
+		fromName, toName string
+	}{
+		{filters.CreatedTimeFrom, filters.CreatedTimeTo, "createdTimeFrom", "createdTimeTo"},
+		{filters.UpdatedTimeFrom, filters.UpdatedTimeTo, "updatedTimeFrom", "updatedTimeTo"},
+		{filters.ExpiresAfter, filters.ExpiresBefore, "expiresAfter", "expiresBefore"},
+	}
+	for _, r := range ranges {
+		if r.from != nil && r.to != nil && *r.from > *r.to {
+			return fmt.Errorf("%s must not be after %s", r.fromName, r.toName)
+		}
+	}
+	return nil
+}
+
 // SearchConsentsDetailed retrieves consents with nested authorization resources, purposes, and attributes
 func (consentService *consentService) SearchConsentsDetailed(ctx context.Context, filters model.ConsentSearchFilters) (*model.ConsentDetailSearchResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.SearchConsentsDetailed")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Info("Searching consents with detailed data",
 		log.String("org_id", filters.OrgID),
@@ -471,6 +983,11 @@ func (consentService *consentService) SearchConsentsDetailed(ctx context.Context
 		log.Int("statuses_count", len(filters.ConsentStatuses)),
 		log.Int("limit", filters.Limit))
 
+	if err := validateSearchRanges(filters); err != nil {
+		logger.Warn("Invalid search time range", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
 	// Validate pagination
 	if filters.Limit <= 0 {
 		filters.Limit = 10
@@ -479,23 +996,39 @@ func (consentService *consentService) SearchConsentsDetailed(ctx context.Context
 		filters.Offset = 0
 	}
 
-	// Step 1: Search consents
+	// Step 1: Search consents. One extra consent is fetched to detect whether another page follows.
+	if filters.CursorMode {
+		filters.Offset = 0
+	}
+	storeFilters := filters
+	storeFilters.Limit = filters.Limit + 1
 	consentStore := consentService.stores.Consent
-	consents, total, err := consentStore.Search(ctx, filters)
+	consents, total, err := consentStore.Search(ctx, storeFilters)
 	if err != nil {
 		logger.Error("Failed to search consents", log.Error(err))
 		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
 	}
 
+	metadata := model.ConsentSearchMetadata{
+		Limit:   filters.Limit,
+		Offset:  filters.Offset,
+		HasMore: len(consents) > filters.Limit,
+	}
+	if !filters.SkipTotal {
+		metadata.Total = &total
+	}
+	if metadata.HasMore {
+		consents = consents[:filters.Limit]
+		if filters.CursorMode {
+			last := consents[len(consents)-1]
+			metadata.NextCursor = model.EncodeSearchCursor(model.SearchCursor{CreatedTime: last.CreatedTime, ConsentID: last.ConsentID})
+		}
+	}
+
 	if len(consents) == 0 {
 		return &model.ConsentDetailSearchResponse{
-			Data: []model.ConsentDetailResponse{},
-			Metadata: model.ConsentSearchMetadata{
-				Total:  0,
-				Limit:  filters.Limit,
-				Offset: filters.Offset,
-				Count:  0,
-			},
+			Data:     []model.ConsentDetailResponse{},
+			Metadata: metadata,
 		}, nil
 	}
 
@@ -505,45 +1038,41 @@ func (consentService *consentService) SearchConsentsDetailed(ctx context.Context
 		consentIDs[i] = c.ConsentID
 	}
 
-	// Step 3: Batch fetch related data in parallel
-	authResourceStore := consentService.stores.AuthResource
-	purposeStore := consentService.stores.ConsentPurpose
-
-	authResources, err := authResourceStore.GetByConsentIDs(ctx, consentIDs, filters.OrgID)
-	if err != nil {
-		logger.Error("Failed to get authorization resources", log.Error(err))
-		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
-	}
-
-	purposeMappings, err := purposeStore.GetMappingsByConsentIDs(ctx, consentIDs, filters.OrgID)
-	if err != nil {
-		logger.Error("Failed to get purpose mappings", log.Error(err))
-		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	// Step 3: Batch fetch the selected related data
+	includes := model.DefaultConsentIncludes()
+	if filters.Includes != nil {
+		includes = *filters.Includes
 	}
-
-	attributesByConsent, err := consentStore.GetAttributesByConsentIDs(ctx, consentIDs, filters.OrgID)
+	relations, err := consentService.loadConsentRelations(ctx, consentIDs, filters.OrgID, includes)
 	if err != nil {
-		logger.Error("Failed to get consent attributes", log.Error(err))
+		logger.Error("Failed to get consent relations", log.Error(err))
 		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
 	}
 
-	// Step 4: Group by consent ID
-	authsByConsent := make(map[string][]authmodel.AuthResource)
-	for _, auth := range authResources {
-		authsByConsent[auth.ConsentID] = append(authsByConsent[auth.ConsentID], auth)
+	tagsByConsent := make(map[string][]string)
+	if includes.Tags {
+		tagsByConsent, err = consentStore.GetTagsByConsentIDs(ctx, consentIDs, filters.OrgID)
+		if err != nil {
+			logger.Error("Failed to get consent tags", log.Error(err))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
 	}
 
-	purposesByConsent := make(map[string][]purposemodel.ConsentPurposeMapping)
-	for _, mapping := range purposeMappings {
-		purposesByConsent[mapping.ConsentID] = append(purposesByConsent[mapping.ConsentID], mapping)
+	historyByConsent := make(map[string][]model.ConsentHistory)
+	if includes.History {
+		historyByConsent, err = consentStore.GetHistoryByConsentIDs(ctx, consentIDs, filters.OrgID)
+		if err != nil {
+			logger.Error("Failed to get consent history", log.Error(err))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
 	}
 
-	// Step 5: Assemble detailed responses
+	// Step 4: Assemble detailed responses
 	detailedResponses := make([]model.ConsentDetailResponse, 0, len(consents))
 	for _, consent := range consents {
 		// Build authorizations - initialize as empty slice
 		authorizations := make([]model.AuthorizationDetail, 0)
-		for _, auth := range authsByConsent[consent.ConsentID] {
+		for _, auth := range relations.authResources[consent.ConsentID] {
 			var resources interface{}
 			if auth.Resources != nil && *auth.Resources != "" {
 				_ = json.Unmarshal([]byte(*auth.Resources), &resources)
@@ -561,12 +1090,14 @@ func (consentService *consentService) SearchConsentsDetailed(ctx context.Context
 				Status:      auth.AuthStatus,
 				UpdatedTime: auth.UpdatedTime,
 				Resources:   resources,
+				DelegatorID: auth.DelegatorID,
+				DelegateID:  auth.DelegateID,
 			})
 		}
 
 		// Build consent purposes - initialize as empty slice
 		consentPurposes := make([]model.ConsentPurposeItem, 0)
-		for _, mapping := range purposesByConsent[consent.ConsentID] {
+		for _, mapping := range relations.purposeMappings[consent.ConsentID] {
 			var value interface{}
 			// mapping.Value is already interface{}, check if it's string and unmarshal
 			if mapping.Value != nil {
@@ -582,15 +1113,17 @@ func (consentService *consentService) SearchConsentsDetailed(ctx context.Context
 			isMandatory := mapping.IsMandatory
 
 			consentPurposes = append(consentPurposes, model.ConsentPurposeItem{
-				Name:           mapping.Name,
-				Value:          value,
-				IsUserApproved: &isUserApproved,
-				IsMandatory:    &isMandatory,
+				Name:            mapping.Name,
+				Value:           value,
+				IsUserApproved:  &isUserApproved,
+				IsMandatory:     &isMandatory,
+				ExpiresAt:       mapping.ExpiresAt,
+				LastConfirmedAt: mapping.LastConfirmedAt,
 			})
 		}
 
 		// Get attributes (already grouped by consent ID)
-		attributes := attributesByConsent[consent.ConsentID]
+		attributes := relations.attributes[consent.ConsentID]
 		if attributes == nil {
 			attributes = make(map[string]string)
 		}
@@ -613,7 +1146,7 @@ func (consentService *consentService) SearchConsentsDetailed(ctx context.Context
 			dataAccessValidityDuration = *consent.DataAccessValidityDuration
 		}
 
-		detailedResponses = append(detailedResponses, model.ConsentDetailResponse{
+		detail := model.ConsentDetailResponse{
 			ID:                         consent.ConsentID,
 			ConsentPurposes:            consentPurposes,
 			CreatedTime:                consent.CreatedTime,
@@ -625,71 +1158,287 @@ func (consentService *consentService) SearchConsentsDetailed(ctx context.Context
 			ValidityTime:               validityTime,
 			RecurringIndicator:         recurringIndicator,
 			DataAccessValidityDuration: dataAccessValidityDuration,
+			Version:                    consent.Version,
 			Attributes:                 attributes,
+			Tags:                       tagsByConsent[consent.ConsentID],
 			Authorizations:             authorizations,
-		})
+		}
+		if includes.History {
+			detail.History = versionSummaries(historyByConsent[consent.ConsentID])
+		}
+		detailedResponses = append(detailedResponses, detail)
 	}
 
 	logger.Info("Consents searched with details successfully",
 		log.Int("count", len(detailedResponses)),
 		log.Int("total", total))
 
+	metadata.Count = len(detailedResponses)
 	return &model.ConsentDetailSearchResponse{
-		Data: detailedResponses,
-		Metadata: model.ConsentSearchMetadata{
-			Total:  total,
-			Limit:  filters.Limit,
-			Offset: filters.Offset,
-			Count:  len(detailedResponses),
-		},
+		Data:     detailedResponses,
+		Metadata: metadata,
 	}, nil
 }
 
-// UpdateConsent updates an existing consent
-func (consentService *consentService) UpdateConsent(ctx context.Context, req model.ConsentAPIUpdateRequest, orgID, consentID string) (*model.ConsentResponse, *serviceerror.ServiceError) {
-	logger := log.GetLogger().WithContext(ctx)
-	logger.Info("Updating consent",
-		log.String("consent_id", consentID),
-		log.String("org_id", orgID))
+// ExportConsents passes every consent matching the filters to emit, one page at a time and newest
+// first. emit is called at least once, with an empty page when nothing matches. Pages are read with
+// keyset pagination, so consents created while the export runs do not shift later pages.
+func (consentService *consentService) ExportConsents(ctx context.Context, filters model.ConsentSearchFilters, emit func([]model.ConsentExportRecord) error) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "consent.ExportConsents")
+	defer span.End()
 
-	// Get stores
-	authResourceStore := consentService.stores.AuthResource
-	consentStore := consentService.stores.Consent
-	purposeStore := consentService.stores.ConsentPurpose
+	logger := log.GetLogger().WithContext(ctx)
 
-	// Validate request
-	if err := utils.ValidateOrgID(orgID); err != nil {
-		logger.Warn("Invalid organization ID", log.Error(err), log.String("org_id", orgID))
-		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	if err := validateSearchRanges(filters); err != nil {
+		logger.Warn("Invalid search time range", log.Error(err))
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
 	}
-	if err := validator.ValidateConsentUpdateRequest(req); err != nil {
-		logger.Warn("Consent update request validation failed", log.Error(err))
-		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+
+	filters.Limit = exportPageSize
+	filters.Offset = 0
+	filters.SkipTotal = true
+	filters.CursorMode = true
+	filters.Cursor = nil
+
+	exported := 0
+	for {
+		consents, _, err := consentService.stores.Consent.Search(ctx, filters)
+		if err != nil {
+			logger.Error("Failed to search consents for export", log.Error(err), log.Int("exported", exported))
+			return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+
+		records, serviceErr := consentService.buildExportRecords(ctx, consents, filters.OrgID)
+		if serviceErr != nil {
+			return serviceErr
+		}
+		if err := emit(records); err != nil {
+			logger.Warn("Consent export aborted", log.Error(err), log.Int("exported", exported))
+			return serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to write export")
+		}
+		exported += len(records)
+
+		if len(consents) < filters.Limit {
+			break
+		}
+		last := consents[len(consents)-1]
+		filters.Cursor = &model.SearchCursor{CreatedTime: last.CreatedTime, ConsentID: last.ConsentID}
 	}
 
-	// Convert to internal format
-	updateReq, convertErr := req.ToConsentUpdateRequest()
-	if convertErr != nil {
-		logger.Warn("Failed to convert update request", log.Error(convertErr))
-		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, convertErr.Error())
+	logger.Info("Consents exported", log.String("org_id", filters.OrgID), log.Int("count", exported))
+	return nil
+}
+
+// buildExportRecords flattens a page of consents with their purposes, authorizations and attributes
+func (consentService *consentService) buildExportRecords(ctx context.Context, consents []model.Consent, orgID string) ([]model.ConsentExportRecord, *serviceerror.ServiceError) {
+	if len(consents) == 0 {
+		return []model.ConsentExportRecord{}, nil
 	}
+	logger := log.GetLogger().WithContext(ctx)
 
-	logger.Debug("Request validation successful")
+	consentIDs := make([]string, len(consents))
+	for i, c := range consents {
+		consentIDs[i] = c.ConsentID
+	}
 
-	// Check if consent exists
-	existing, err := consentStore.GetByID(ctx, consentID, orgID)
+	authResources, err := consentService.stores.AuthResource.GetByConsentIDs(ctx, consentIDs, orgID)
 	if err != nil {
-		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		logger.Error("Failed to get authorization resources", log.Error(err))
 		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
 	}
-	if existing == nil {
-		logger.Warn("Consent not found", log.String("consent_id", consentID))
-		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	purposeMappings, err := consentService.stores.ConsentPurpose.GetMappingsByConsentIDs(ctx, consentIDs, orgID)
+	if err != nil {
+		logger.Error("Failed to get purpose mappings", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
 	}
-
-	currentTime := utils.GetCurrentTimeMillis()
+	attributesByConsent, err := consentService.stores.Consent.GetAttributesByConsentIDs(ctx, consentIDs, orgID)
+	if err != nil {
+		logger.Error("Failed to get consent attributes", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	records := make(map[string]*model.ConsentExportRecord, len(consents))
+	result := make([]model.ConsentExportRecord, len(consents))
+	for i, c := range consents {
+		attributes := attributesByConsent[c.ConsentID]
+		if attributes == nil {
+			attributes = map[string]string{}
+		}
+		result[i] = model.ConsentExportRecord{
+			ConsentID:                  c.ConsentID,
+			ClientID:                   c.ClientID,
+			ConsentType:                c.ConsentType,
+			CurrentStatus:              c.CurrentStatus,
+			CreatedTime:                c.CreatedTime,
+			UpdatedTime:                c.UpdatedTime,
+			ValidityTime:               c.ValidityTime,
+			Frequency:                  c.ConsentFrequency,
+			RecurringIndicator:         c.RecurringIndicator,
+			DataAccessValidityDuration: c.DataAccessValidityDuration,
+			Purposes:                   []string{},
+			ApprovedPurposes:           []string{},
+			UserIDs:                    []string{},
+			AuthorizationStatuses:      []string{},
+			Attributes:                 attributes,
+		}
+		records[c.ConsentID] = &result[i]
+	}
+
+	for _, mapping := range purposeMappings {
+		record := records[mapping.ConsentID]
+		if record == nil {
+			continue
+		}
+		record.Purposes = append(record.Purposes, mapping.Name)
+		if mapping.IsUserApproved {
+			record.ApprovedPurposes = append(record.ApprovedPurposes, mapping.Name)
+		}
+	}
+	for _, auth := range authResources {
+		record := records[auth.ConsentID]
+		if record == nil {
+			continue
+		}
+		holder := auth.AuthID
+		if auth.UserID != nil && *auth.UserID != "" {
+			holder = *auth.UserID
+			if !slices.Contains(record.UserIDs, holder) {
+				record.UserIDs = append(record.UserIDs, holder)
+			}
+		}
+		record.AuthorizationStatuses = append(record.AuthorizationStatuses, holder+":"+auth.AuthStatus)
+	}
+	return result, nil
+}
+
+// UpdateConsent updates an existing consent
+func (consentService *consentService) UpdateConsent(ctx context.Context, req model.ConsentAPIUpdateRequest, orgID, consentID string) (*model.ConsentResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.UpdateConsent")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Updating consent",
+		log.String("consent_id", consentID),
+		log.String("org_id", orgID))
+
+	return consentService.updateConsent(ctx, req, orgID, consentID, nil, nil)
+}
+
+// AmendConsent applies an update to a consent after snapshotting its current state into the
+// consent history and bumping its version
+func (consentService *consentService) AmendConsent(ctx context.Context, req model.ConsentAmendmentRequest, orgID, consentID string) (*model.ConsentResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.AmendConsent")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Amending consent",
+		log.String("consent_id", consentID),
+		log.String("org_id", orgID))
+
+	return consentService.updateConsent(ctx, req.ConsentAPIUpdateRequest, orgID, consentID, &req, nil)
+}
+
+// updateConsent applies an update to a consent. When amendment is set, the consent state before
+// the update is recorded as a new history entry in the same transaction. When claim is set, the
+// update applies that scheduled update: it is deleted in the update transaction, which is rolled
+// back when another server applied, replaced or cancelled it in the meantime.
+func (consentService *consentService) updateConsent(ctx context.Context, req model.ConsentAPIUpdateRequest, orgID, consentID string, amendment *model.ConsentAmendmentRequest, claim *model.ScheduledUpdate) (*model.ConsentResponse, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	// Get stores
+	authResourceStore := consentService.stores.AuthResource
+	consentStore := consentService.stores.Consent
+	purposeStore := consentService.stores.ConsentPurpose
+
+	// Let the service extension adjust or reject the request before it is validated
+	if extension.IsEnabled(extension.PreProcessConsentUpdate) {
+		payload := &model.ConsentUpdateHookPayload{ConsentID: consentID, ConsentRequest: req}
+		if err := extension.Invoke(ctx, extension.PreProcessConsentUpdate, orgID, payload); err != nil {
+			logger.Warn("Consent update rejected by service extension", log.Error(err))
+			return nil, extensionServiceError(err)
+		}
+		req = payload.ConsentRequest
+	}
+
+	// Validate request
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		logger.Warn("Invalid organization ID", log.Error(err), log.String("org_id", orgID))
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := validator.ValidateConsentUpdateRequest(req); err != nil {
+		logger.Warn("Consent update request validation failed", log.Error(err))
+		return nil, serviceerror.ValidationErrorFrom(err)
+	}
+	if amendment != nil && req.EffectiveAt != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			"effectiveAt is not supported on amendments, amendments take effect immediately")
+	}
+	if req.Type != "" && !config.Get().Consent.ForOrg(orgID).IsConsentTypeAllowed(req.Type) {
+		logger.Warn("Consent type not allowed for organization", log.String("consent_type", req.Type))
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("consent type '%s' is not allowed for organization '%s'", req.Type, orgID))
+	}
+	if featureflag.IsEnabled(orgID, featureflag.StrictValidation) {
+		if err := validator.ValidateStrictConsentFields(req.ConsentPurpose, req.Attributes, req.ValidityTime); err != nil {
+			logger.Warn("Consent update request failed strict validation", log.Error(err))
+			return nil, serviceerror.ValidationErrorFrom(err)
+		}
+	}
+
+	// Convert to internal format
+	updateReq, convertErr := req.ToConsentUpdateRequest()
+	if convertErr != nil {
+		logger.Warn("Failed to convert update request", log.Error(convertErr))
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, convertErr.Error())
+	}
+
+	logger.Debug("Request validation successful")
+
+	// Check if consent exists
+	existing, err := consentStore.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		logger.Warn("Consent not found", log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+	if serviceErr := consentService.checkConsentLock(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	// An update that takes effect later is stored for the scheduled update job
+	if req.EffectiveAt != nil && *req.EffectiveAt*1000 > utils.GetCurrentTimeMillis() {
+		return consentService.scheduleUpdate(ctx, existing, orgID, req)
+	}
+
+	// Attributes are checked when they are replaced or when the consent type changes
+	consentType := existing.ConsentType
+	if updateReq.ConsentType != "" {
+		consentType = updateReq.ConsentType
+	}
+	if req.Attributes != nil || consentType != existing.ConsentType {
+		if serviceErr := consentService.enforceAttributeSchema(ctx, req.Attributes, consentType, consentID, orgID); serviceErr != nil {
+			return nil, serviceErr
+		}
+	}
+
+	currentTime := utils.GetCurrentTimeMillis()
 	previousStatus := existing.CurrentStatus
 
+	// A revocation scheduled with the update is stored with it. Its purposes and authorizations
+	// are checked when it takes effect, as the update may change them.
+	var scheduledRevocation *model.ScheduledRevocation
+	if req.ScheduledRevocation != nil {
+		var serviceErr *serviceerror.ServiceError
+		scheduledRevocation, serviceErr = newScheduledRevocation(existing, orgID, *req.ScheduledRevocation, currentTime)
+		if serviceErr != nil {
+			logger.Warn("Invalid scheduled revocation", log.String("error", serviceErr.Description))
+			return nil, serviceErr
+		}
+	}
+
 	if req.DataAccessValidityDuration != nil {
 		// Validate that it's non-negative
 		if *req.DataAccessValidityDuration < 0 {
@@ -699,29 +1448,57 @@ func (consentService *consentService) UpdateConsent(ctx context.Context, req mod
 		updateReq.DataAccessValidityDuration = req.DataAccessValidityDuration
 	}
 
+	if updateReq.AuthResources != nil {
+		if serviceErr := consentService.enforceResourceSchemas(ctx, updateReq.AuthResources, orgID); serviceErr != nil {
+			return nil, serviceErr
+		}
+	}
+
 	// Derive new consent status from authorization states if auth resources are being updated
 	var newStatus string
 	var statusChanged bool
 	if updateReq.AuthResources != nil {
 
-		// Extract auth statuses
+		// Extract auth types and statuses
+		authTypes := make([]string, 0, len(updateReq.AuthResources))
 		authStatuses := make([]string, 0, len(updateReq.AuthResources))
 		for _, ar := range updateReq.AuthResources {
+			authTypes = append(authTypes, ar.AuthType)
 			authStatuses = append(authStatuses, ar.AuthStatus)
 		}
 
-		newStatus = validator.EvaluateConsentStatusFromAuthStatuses(authStatuses)
+		requiredAuthorizers, err := consentStore.GetRequiredAuthorizers(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve required authorizers", log.Error(err))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError,
+				fmt.Sprintf("failed to retrieve required authorizers: %v", err))
+		}
+		pending := validator.PendingAuthorizers(orgID, requiredAuthorizers, authTypes, authStatuses)
+		newStatus = validator.KeepSuspended(orgID, previousStatus, validator.KeepAwaitingReauthorization(orgID, previousStatus,
+			validator.ApplyRequiredAuthorizers(orgID,
+				validator.EvaluateConsentStatusFromAuthStatuses(orgID, consentType, authStatuses), pending, authStatuses)))
 		statusChanged = (newStatus != previousStatus)
 		if statusChanged {
 			logger.Debug("Consent status changed",
 				log.String("previous_status", previousStatus),
 				log.String("new_status", newStatus))
 		}
+		if err := validator.ValidateStatusTransition(orgID, previousStatus, newStatus); err != nil {
+			logger.Warn("Consent status transition rejected by the state machine", log.Error(err))
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+		}
 	} else {
 		newStatus = existing.CurrentStatus
 		statusChanged = false
 	}
 
+	if newStatus == string(config.Get().Consent.ForOrg(orgID).GetActiveConsentStatus()) &&
+		featureflag.IsEnabled(orgID, featureflag.PurposeEnforcement) {
+		if serviceErr := consentService.enforceMandatoryPurposes(ctx, req.ConsentPurpose, consentID, orgID, statusChanged); serviceErr != nil {
+			return nil, serviceErr
+		}
+	}
+
 	// Update consent fields
 	consent := &model.Consent{
 		ConsentID:                  consentID,
@@ -737,6 +1514,7 @@ func (consentService *consentService) UpdateConsent(ctx context.Context, req mod
 
 	// Build transactional operations
 	queries := []func(tx dbmodel.TxInterface) error{
+		consentService.claimScheduledUpdate(claim),
 		func(tx dbmodel.TxInterface) error {
 			return consentStore.Update(tx, consent)
 		},
@@ -745,6 +1523,7 @@ func (consentService *consentService) UpdateConsent(ctx context.Context, req mod
 	if statusChanged {
 
 		queries = []func(tx dbmodel.TxInterface) error{
+			consentService.claimScheduledUpdate(claim),
 			func(tx dbmodel.TxInterface) error {
 				return consentStore.UpdateStatus(tx, consentID, orgID, newStatus, currentTime)
 			},
@@ -830,6 +1609,8 @@ func (consentService *consentService) UpdateConsent(ctx context.Context, req mod
 					UpdatedTime: currentTime,
 					Resources:   resourcesJSON,
 					OrgID:       orgID,
+					DelegatorID: authReq.DelegatorID,
+					DelegateID:  authReq.DelegateID,
 				}
 
 				queries = append(queries, func(tx dbmodel.TxInterface) error {
@@ -900,22 +1681,139 @@ func (consentService *consentService) UpdateConsent(ctx context.Context, req mod
 					isMandatory = *purposeItem.IsMandatory
 				}
 
+				expiresAt := purposeItem.ExpiresAt
+				lastConfirmedAt := purposeItem.LastConfirmedAt
+
 				// Add to transaction queries
 				queries = append(queries, func(tx dbmodel.TxInterface) error {
-					return purposeStore.LinkPurposeToConsent(tx, consentID, purposeID, orgID, valueJSON, isUserApproved, isMandatory)
+					return purposeStore.LinkPurposeToConsent(tx, consentID, purposeID, orgID, valueJSON, isUserApproved, isMandatory, expiresAt, lastConfirmedAt)
 				})
 			}
 		}
 	}
 
+	// Read what the update replaces, so the operation audit can record what changed
+	var previousAttributes map[string]string
+	if updateReq.Attributes != nil && opaudit.FromContext(ctx) != nil {
+		stored, err := consentStore.GetAttributesByConsentID(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve consent attributes", log.Error(err), log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		previousAttributes = make(map[string]string, len(stored))
+		for _, attr := range stored {
+			previousAttributes[attr.AttKey] = attr.AttValue
+		}
+	}
+	var previousPurposes []string
+	if updateReq.ConsentPurpose != nil && opaudit.FromContext(ctx) != nil {
+		mappings, err := purposeStore.GetMappingsByConsentID(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve consent purposes", log.Error(err), log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		previousPurposes = make([]string, 0, len(mappings))
+		for _, mapping := range mappings {
+			previousPurposes = append(previousPurposes, mapping.Name)
+		}
+	}
+
+	// Snapshot the current version before it is replaced
+	if amendment != nil {
+		amendmentQueries, serviceErr := consentService.buildAmendmentQueries(ctx, existing, amendment, currentTime)
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+		queries = append(queries, amendmentQueries...)
+	}
+
+	if scheduledRevocation != nil {
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return consentStore.SaveScheduledRevocation(tx, scheduledRevocation)
+		})
+	}
+
+	// Evidence is recorded against the version the update produces; an amendment starts a new one
+	var evidence *model.ConsentEvidence
+	if req.Evidence != nil {
+		version := existing.Version
+		if amendment != nil {
+			version++
+		}
+		evidence = model.NewConsentEvidence(*req.Evidence, utils.GenerateUUID(), consentID, orgID, version, currentTime)
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return consentStore.CreateEvidence(tx, evidence)
+		})
+	}
+
+	// The event carries the attributes the consent has after the update
+	eventAttributes := updateReq.Attributes
+	if eventAttributes == nil {
+		stored, err := consentStore.GetAttributesByConsentID(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve consent attributes", log.Error(err), log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		eventAttributes = make(map[string]string, len(stored))
+		for _, attr := range stored {
+			eventAttributes[attr.AttKey] = attr.AttValue
+		}
+	}
+	// Tokens of a consent the update rejected no longer resolve to it
+	if validator.IsConsentEnded(orgID, newStatus) {
+		queries = append(queries, consentService.releaseTokens(consentID, orgID))
+	}
+	queries = append(queries, consentService.recordEvent(events.ConsentEvent{
+		ID:         utils.GenerateUUID(),
+		Type:       events.ConsentUpdated,
+		Timestamp:  currentTime,
+		OrgID:      orgID,
+		ConsentID:  consentID,
+		ClientID:   existing.ClientID,
+		Status:     newStatus,
+		Attributes: eventAttributes,
+	}))
+
 	// Execute transaction
 	logger.Debug("Executing update transaction", log.Int("operation_count", len(queries)))
-	if err := consentService.stores.ExecuteTransaction(queries); err != nil {
+	if err := consentService.stores.ExecuteTransaction(ctx, queries); err != nil {
+		if errors.Is(err, ErrScheduledUpdateClaimed) {
+			return nil, scheduledUpdateClaimedError(consentID)
+		}
+		if errors.Is(err, ErrConsentVersionConflict) {
+			logger.Warn("Consent was amended concurrently", log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+				fmt.Sprintf("Consent with ID '%s' was modified concurrently, retry the amendment", consentID))
+		}
 		logger.Error("Failed to update consent in transaction",
 			log.Error(err),
 			log.String("consent_id", consentID))
 		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
 	}
+	cache.InvalidateConsentValidation(ctx, orgID, consentID)
+
+	if statusChanged {
+		auditChanges(ctx, "status", "from", []string{previousStatus})
+		auditChanges(ctx, "status", "to", []string{newStatus})
+	}
+	if updateReq.Attributes != nil {
+		added, removed, changed := attributeDelta(previousAttributes, updateReq.Attributes)
+		auditChanges(ctx, "attributes", "added", added)
+		auditChanges(ctx, "attributes", "removed", removed)
+		auditChanges(ctx, "attributes", "changed", changed)
+	}
+	if updateReq.ConsentPurpose != nil {
+		purposes := make([]string, 0, len(updateReq.ConsentPurpose))
+		for _, purpose := range updateReq.ConsentPurpose {
+			purposes = append(purposes, purpose.Name)
+		}
+		linked, unlinked := purposeDelta(previousPurposes, purposes)
+		auditChanges(ctx, "purposes", "linked", linked)
+		auditChanges(ctx, "purposes", "unlinked", unlinked)
+	}
+	if evidence != nil {
+		opaudit.AddDetail(ctx, "evidence", evidence)
+	}
 
 	// Get updated consent
 	logger.Debug("Retrieving updated consent data")
@@ -937,6 +1835,7 @@ func (consentService *consentService) UpdateConsent(ctx context.Context, req mod
 
 	// Build complete response
 	response := buildConsentResponse(updated, attributesMap, authResources, purposeMappings)
+	response.Tags, _ = consentStore.GetTagsByConsentID(ctx, consentID, orgID)
 
 	logger.Info("Consent updated successfully",
 		log.String("consent_id", consentID),
@@ -945,17 +1844,222 @@ func (consentService *consentService) UpdateConsent(ctx context.Context, req mod
 		log.Int("purposes", len(purposeMappings)),
 		log.Int("attributes", len(attributesMap)))
 
+	// The stored modifiedResponse is returned, and passed to the enrich hook to change
+	if err := consentService.loadModifiedResponse(ctx, orgID, response); err != nil {
+		logger.Error("Failed to retrieve modifiedResponse", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	stored := response.ModifiedResponse
+	if serviceErr := consentService.enrichConsentResponse(ctx, extension.EnrichConsentUpdateResponse, orgID, response); serviceErr != nil {
+		return nil, serviceErr
+	}
+	if !reflect.DeepEqual(stored, response.ModifiedResponse) {
+		if serviceErr := consentService.saveModifiedResponse(ctx, orgID, response); serviceErr != nil {
+			return nil, serviceErr
+		}
+	}
+
 	return response, nil
 }
 
+// recordEvent returns a transaction query that writes a consent event to the event outbox, so the
+// event is published if and only if the change commits
+func (consentService *consentService) recordEvent(event events.ConsentEvent) func(tx dbmodel.TxInterface) error {
+	return func(tx dbmodel.TxInterface) error {
+		outboxEvent, err := outboxmodel.NewOutboxEvent(event)
+		if err != nil {
+			return err
+		}
+		return consentService.stores.EventOutbox.Create(tx, outboxEvent)
+	}
+}
+
+// auditChanges adds a list of changes to the operation audit detail named group, for example the
+// attribute keys an update added. Empty lists are left out. Attribute values are never recorded.
+func auditChanges(ctx context.Context, group, kind string, values []string) {
+	op := opaudit.FromContext(ctx)
+	if op == nil || len(values) == 0 {
+		return
+	}
+	changes, _ := op.Details()[group].(map[string][]string)
+	if changes == nil {
+		changes = make(map[string][]string)
+	}
+	changes[kind] = values
+	opaudit.AddDetail(ctx, group, changes)
+}
+
+// attributeDelta returns the attribute keys added, removed and given a new value when the
+// attributes before are replaced with after
+func attributeDelta(before, after map[string]string) (added, removed, changed []string) {
+	for _, key := range sortedKeys(after) {
+		previous, existed := before[key]
+		if !existed {
+			added = append(added, key)
+		} else if previous != after[key] {
+			changed = append(changed, key)
+		}
+	}
+	for _, key := range sortedKeys(before) {
+		if _, kept := after[key]; !kept {
+			removed = append(removed, key)
+		}
+	}
+	return added, removed, changed
+}
+
+// purposeDelta returns the purposes linked and unlinked when the purposes before are replaced
+// with after
+func purposeDelta(before, after []string) (linked, unlinked []string) {
+	for _, name := range after {
+		if !slices.Contains(before, name) {
+			linked = append(linked, name)
+		}
+	}
+	for _, name := range before {
+		if !slices.Contains(after, name) {
+			unlinked = append(unlinked, name)
+		}
+	}
+	return linked, unlinked
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// enforceMandatoryPurposes rejects an update that leaves an active consent with mandatory purposes
+// the user has not approved. Purposes in the request are always checked; the stored purposes are
+// only checked when the update activates the consent, so unrelated updates of consents created
+// before the flag was enabled are not blocked.
+func (consentService *consentService) enforceMandatoryPurposes(ctx context.Context, purposes []model.ConsentPurposeItem, consentID, orgID string, activating bool) *serviceerror.ServiceError {
+	logger := log.GetLogger().WithContext(ctx)
+
+	var unapproved []string
+	if purposes != nil {
+		unapproved = validator.UnapprovedMandatoryPurposes(purposes)
+	} else if activating {
+		mappings, err := consentService.stores.ConsentPurpose.GetMappingsByConsentID(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve consent purposes", log.Error(err), log.String("consent_id", consentID))
+			return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		for _, mapping := range mappings {
+			if mapping.IsMandatory && !mapping.IsUserApproved {
+				unapproved = append(unapproved, mapping.Name)
+			}
+		}
+	}
+
+	if len(unapproved) > 0 {
+		logger.Warn("Mandatory purposes not approved by the user", log.Any("purposes", unapproved))
+		return serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("mandatory purposes must be approved by the user: %v", unapproved))
+	}
+	return nil
+}
+
+// enforceAttributeSchema rejects consent attributes that do not conform to the organization's
+// attribute schema. Organizations without a schema accept any attributes. When attributes is nil
+// the stored attributes of the consent are checked, so changing the consent type cannot leave a
+// consent without the attributes its new type requires.
+func (consentService *consentService) enforceAttributeSchema(ctx context.Context, attributes map[string]string, consentType, consentID, orgID string) *serviceerror.ServiceError {
+	logger := log.GetLogger().WithContext(ctx)
+
+	schema, err := consentService.stores.AttributeSchema.Get(ctx, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve attribute schema", log.Error(err), log.String("org_id", orgID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if schema == nil {
+		return nil
+	}
+
+	if attributes == nil && consentID != "" {
+		stored, err := consentService.stores.Consent.GetAttributesByConsentID(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve consent attributes", log.Error(err), log.String("consent_id", consentID))
+			return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		attributes = make(map[string]string, len(stored))
+		for _, attr := range stored {
+			attributes[attr.AttKey] = attr.AttValue
+		}
+	}
+
+	if err := schema.ValidateAttributes(consentType, attributes); err != nil {
+		logger.Warn("Consent attributes rejected by the attribute schema", log.Error(err))
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	return nil
+}
+
+// enforceResourceSchemas rejects authorizations whose resources do not conform to the organization's
+// resources schema for their authorization type. Authorization types without a schema, and
+// authorizations without resources, are not checked.
+func (consentService *consentService) enforceResourceSchemas(ctx context.Context, authRequests []authmodel.ConsentAuthResourceCreateRequest, orgID string) *serviceerror.ServiceError {
+	logger := log.GetLogger().WithContext(ctx)
+
+	schemas := make(map[string]*resourceschemamodel.ResourceSchema)
+	for _, authReq := range authRequests {
+		if authReq.Resources == nil {
+			continue
+		}
+		schema, loaded := schemas[authReq.AuthType]
+		if !loaded {
+			var err error
+			schema, err = consentService.stores.ResourceSchema.Get(ctx, authReq.AuthType, orgID)
+			if err != nil {
+				logger.Error("Failed to retrieve resources schema", log.Error(err), log.String("auth_type", authReq.AuthType))
+				return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+			}
+			schemas[authReq.AuthType] = schema
+		}
+		if schema == nil {
+			continue
+		}
+		if err := schema.ValidateResources(authReq.Resources); err != nil {
+			logger.Warn("Authorization resources rejected by the resources schema", log.Error(err))
+			return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+		}
+	}
+	return nil
+}
+
 // RevokeConsent updates consent status and creates audit entry
 func (consentService *consentService) RevokeConsent(ctx context.Context, consentID, orgID string, req model.ConsentRevokeRequest) (*model.ConsentRevokeResponse, *serviceerror.ServiceError) {
+	return consentService.revokeConsent(ctx, consentID, orgID, req, nil)
+}
+
+// revokeConsent revokes a consent. When claim is set, the revocation applies that scheduled
+// revocation: it is deleted in the revoke transaction, which is rolled back when another server
+// applied, replaced or cancelled it in the meantime.
+func (consentService *consentService) revokeConsent(ctx context.Context, consentID, orgID string, req model.ConsentRevokeRequest, claim *model.ScheduledRevocation) (*model.ConsentRevokeResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.RevokeConsent")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Info("Revoking consent",
 		log.String("consent_id", consentID),
 		log.String("org_id", orgID),
 		log.String("action_by", req.ActionBy))
 
+	// Let the service extension adjust or reject the revocation
+	if extension.IsEnabled(extension.PreProcessConsentRevoke) {
+		payload := &model.ConsentRevokeHookPayload{ConsentID: consentID, RevokeRequest: req}
+		if err := extension.Invoke(ctx, extension.PreProcessConsentRevoke, orgID, payload); err != nil {
+			logger.Warn("Consent revocation rejected by service extension", log.Error(err))
+			return nil, extensionServiceError(err)
+		}
+		req = payload.RevokeRequest
+	}
+
 	// Validate action by
 	if req.ActionBy == "" {
 		logger.Warn("Validation failed: ActionBy is required")
@@ -964,7 +2068,9 @@ func (consentService *consentService) RevokeConsent(ctx context.Context, consent
 
 	logger.Debug("Request validation successful")
 
-	revokedStatusName := config.Get().Consent.GetRevokedConsentStatus()
+	consentCfg := config.Get().Consent.ForOrg(orgID)
+	revokedStatusName := consentCfg.GetRevokedConsentStatus()
+	systemRevokedAuthStatus := string(consentCfg.GetSystemRevokedAuthStatus())
 
 	// Check if consent exists
 	store := consentService.stores.Consent
@@ -977,6 +2083,9 @@ func (consentService *consentService) RevokeConsent(ctx context.Context, consent
 		logger.Warn("Consent not found", log.String("consent_id", consentID))
 		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
 	}
+	if serviceErr := consentService.checkConsentLock(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
 
 	// Check if consent is already revoked
 	if existing.CurrentStatus == string(revokedStatusName) {
@@ -986,11 +2095,54 @@ func (consentService *consentService) RevokeConsent(ctx context.Context, consent
 		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError, fmt.Sprintf("Consent with ID '%s' is already revoked", consentID))
 	}
 
+	// The purposes and authorizations of a partial revocation are checked before it is scheduled
+	var scope *revocationScope
+	if req.IsPartial() {
+		var serviceErr *serviceerror.ServiceError
+		scope, serviceErr = consentService.planPartialRevocation(ctx, existing, orgID, req)
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+	}
+
+	// A revocation that takes effect later is stored for the scheduled revocation job
+	if req.EffectiveAt != nil && *req.EffectiveAt*1000 > utils.GetCurrentTimeMillis() {
+		response, serviceErr := consentService.scheduleRevocation(ctx, existing, orgID, req)
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+		return consentService.enrichRevokeResponse(ctx, orgID, response)
+	}
+
+	// A partial revocation can revoke the consent, so it is checked against the state machine even
+	// when the consent remains
+	if err := validator.ValidateStatusTransition(orgID, existing.CurrentStatus, string(revokedStatusName)); err != nil {
+		logger.Warn("Consent revocation rejected by the state machine", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	// A partial revocation that leaves mandatory purposes and authorizations in place does not
+	// change the consent status
+	if scope != nil {
+		if scope.consentRemains {
+			response, serviceErr := consentService.revokeScope(ctx, existing, orgID, req, scope, claim)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			return consentService.enrichRevokeResponse(ctx, orgID, response)
+		}
+		logger.Info("Partial revocation leaves no mandatory purpose or authorization, revoking the consent",
+			log.String("consent_id", consentID))
+	}
+
 	currentTime := utils.GetCurrentTimeMillis()
 
 	// Create audit entry
 	auditID := utils.GenerateUUID()
 	reason := req.RevocationReason
+	if scope != nil {
+		reason = scope.reason
+	}
 	audit := &model.ConsentStatusAudit{
 		StatusAuditID:  auditID,
 		ConsentID:      consentID,
@@ -1002,31 +2154,116 @@ func (consentService *consentService) RevokeConsent(ctx context.Context, consent
 		OrgID:          orgID,
 	}
 
+	// Child consents are revoked with their parent
+	children, serviceErr := consentService.revocableDescendants(ctx, consentID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
 	// Get auth resource store for cascading status update
 	authResourceStore := consentService.stores.AuthResource
 
 	// Execute transaction - update consent status, all auth resource statuses, and create audit
-	logger.Debug("Executing revocation transaction")
-	err = consentService.stores.ExecuteTransaction([]func(tx dbmodel.TxInterface) error{
+	logger.Debug("Executing revocation transaction", log.Int("child_consents", len(children)))
+	queries := []func(tx dbmodel.TxInterface) error{
+		consentService.claimScheduledRevocation(claim),
 		func(tx dbmodel.TxInterface) error {
 			return store.UpdateStatus(tx, consentID, orgID, string(revokedStatusName), currentTime)
 		},
 		func(tx dbmodel.TxInterface) error {
-			// Update all authorization statuses to SYS_REVOKED when consent is revoked
-			return authResourceStore.UpdateAllStatusByConsentID(tx, consentID, orgID, "SYS_REVOKED", currentTime)
+			// Update all authorization statuses to the system revoked status when consent is revoked
+			return authResourceStore.UpdateAllStatusByConsentID(tx, consentID, orgID, systemRevokedAuthStatus, currentTime)
 		},
 		func(tx dbmodel.TxInterface) error {
 			return store.CreateStatusAudit(tx, audit)
 		},
-	})
-	if err != nil {
-		logger.Error("Failed to revoke consent in transaction",
-			log.Error(err),
-			log.String("consent_id", consentID))
-		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
-	}
-
-	logger.Info("Consent revoked successfully",
+		func(tx dbmodel.TxInterface) error {
+			return store.DeleteScheduledRevocation(tx, consentID, orgID)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return store.DeleteScheduledUpdate(tx, consentID, orgID)
+		},
+		consentService.releaseTokens(consentID, orgID),
+		consentService.recordEvent(events.ConsentEvent{
+			ID:             utils.GenerateUUID(),
+			Type:           events.ConsentRevoked,
+			Timestamp:      currentTime,
+			OrgID:          orgID,
+			ConsentID:      consentID,
+			ClientID:       existing.ClientID,
+			Status:         string(revokedStatusName),
+			PreviousStatus: existing.CurrentStatus,
+		}),
+	}
+	if scope != nil {
+		queries = append(queries, scope.purposeQueries(consentService, consentID, orgID, currentTime)...)
+	}
+
+	childIDs := make([]string, 0, len(children))
+	childReason := fmt.Sprintf("Parent consent %s revoked", consentID)
+	for _, child := range children {
+		childIDs = append(childIDs, child.ConsentID)
+		childAudit := &model.ConsentStatusAudit{
+			StatusAuditID:  utils.GenerateUUID(),
+			ConsentID:      child.ConsentID,
+			CurrentStatus:  string(revokedStatusName),
+			ActionTime:     currentTime,
+			Reason:         &childReason,
+			ActionBy:       &req.ActionBy,
+			PreviousStatus: &child.CurrentStatus,
+			OrgID:          orgID,
+		}
+		queries = append(queries,
+			func(tx dbmodel.TxInterface) error {
+				return store.UpdateStatus(tx, child.ConsentID, orgID, string(revokedStatusName), currentTime)
+			},
+			func(tx dbmodel.TxInterface) error {
+				return authResourceStore.UpdateAllStatusByConsentID(tx, child.ConsentID, orgID, systemRevokedAuthStatus, currentTime)
+			},
+			func(tx dbmodel.TxInterface) error {
+				return store.CreateStatusAudit(tx, childAudit)
+			},
+			func(tx dbmodel.TxInterface) error {
+				return store.DeleteScheduledRevocation(tx, child.ConsentID, orgID)
+			},
+			func(tx dbmodel.TxInterface) error {
+				return store.DeleteScheduledUpdate(tx, child.ConsentID, orgID)
+			},
+			consentService.releaseTokens(child.ConsentID, orgID),
+			consentService.recordEvent(events.ConsentEvent{
+				ID:             utils.GenerateUUID(),
+				Type:           events.ConsentRevoked,
+				Timestamp:      currentTime,
+				OrgID:          orgID,
+				ConsentID:      child.ConsentID,
+				ClientID:       child.ClientID,
+				Status:         string(revokedStatusName),
+				PreviousStatus: child.CurrentStatus,
+			}),
+		)
+	}
+
+	err = consentService.stores.ExecuteTransaction(ctx, queries)
+	if errors.Is(err, ErrScheduledRevocationClaimed) {
+		return nil, scheduledRevocationClaimedError(consentID)
+	}
+	if err != nil {
+		logger.Error("Failed to revoke consent in transaction",
+			log.Error(err),
+			log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	cache.InvalidateConsentValidation(ctx, orgID, consentID)
+	for _, childID := range childIDs {
+		cache.InvalidateConsentValidation(ctx, orgID, childID)
+	}
+	auditChanges(ctx, "children", "revoked", childIDs)
+	if scope != nil {
+		auditChanges(ctx, "purposes", "revoked", scope.purposeNames())
+		auditChanges(ctx, "authorizations", "revoked", scope.authorizationIDs())
+	}
+
+	logger.Info("Consent revoked successfully",
 		log.String("consent_id", consentID),
 		log.String("previous_status", existing.CurrentStatus),
 		log.String("new_status", string(revokedStatusName)))
@@ -1036,317 +2273,3228 @@ func (consentService *consentService) RevokeConsent(ctx context.Context, consent
 		ActionTime:       currentTime / 1000, // Convert milliseconds to seconds
 		ActionBy:         req.ActionBy,
 		RevocationReason: req.RevocationReason,
+		ConsentStatus:    string(revokedStatusName),
 	}
+	if scope != nil {
+		response.RevokedPurposes = scope.purposeNames()
+		response.RevokedAuthorizationIDs = scope.authorizationIDs()
+	}
+	if len(childIDs) > 0 {
+		response.RevokedChildConsentIDs = childIDs
+	}
+
+	return consentService.enrichRevokeResponse(ctx, orgID, response)
+}
 
+// enrichRevokeResponse passes a revoke response through the enrich_consent_revoke_response hook
+func (consentService *consentService) enrichRevokeResponse(ctx context.Context, orgID string, response *model.ConsentRevokeResponse) (*model.ConsentRevokeResponse, *serviceerror.ServiceError) {
+	if extension.IsEnabled(extension.EnrichConsentRevokeResponse) {
+		payload := &model.ConsentEnrichHookPayload{Revocation: response}
+		if err := extension.Invoke(ctx, extension.EnrichConsentRevokeResponse, orgID, payload); err != nil {
+			log.GetLogger().WithContext(ctx).Warn("Service extension failed to enrich revoke response", log.Error(err))
+			return nil, extensionServiceError(err)
+		}
+		response.ModifiedResponse = payload.ModifiedResponse
+	}
 	return response, nil
 }
 
-// ValidateConsent validates a consent for data access
-func (consentService *consentService) ValidateConsent(ctx context.Context, req model.ValidateRequest, orgID string) (*model.ValidateResponse, *serviceerror.ServiceError) {
-	logger := log.GetLogger().WithContext(ctx)
-	logger.Info("Validating consent",
-		log.String("consent_id", req.ConsentID),
-		log.String("org_id", orgID))
+// revocationScope holds the purposes and authorizations a partial revocation revokes
+type revocationScope struct {
+	purposes       []purposemodel.ConsentPurposeMapping
+	authorizations []authmodel.AuthResource
+	// consentRemains is set when mandatory purposes stay approved and authorizations stay in place
+	consentRemains bool
+	// reason is the status audit reason, a model.PartialRevocationReason document
+	reason string
+}
 
-	// Initialize response with invalid state
-	response := &model.ValidateResponse{
-		IsValid: false,
+// purposeNames returns the names of the revoked purposes
+func (scope *revocationScope) purposeNames() []string {
+	names := make([]string, 0, len(scope.purposes))
+	for _, purpose := range scope.purposes {
+		names = append(names, purpose.Name)
 	}
+	return names
+}
 
-	// Validate request
-	if req.ConsentID == "" {
-		logger.Warn("Validation failed: ConsentID is required")
-		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "ConsentID is required")
+// authorizationIDs returns the IDs of the revoked authorizations
+func (scope *revocationScope) authorizationIDs() []string {
+	ids := make([]string, 0, len(scope.authorizations))
+	for _, authorization := range scope.authorizations {
+		ids = append(ids, authorization.AuthID)
 	}
+	return ids
+}
 
-	logger.Debug("Request validation successful")
+// purposeQueries withdraws the approval of the revoked purposes
+func (scope *revocationScope) purposeQueries(consentService *consentService, consentID, orgID string, currentTime int64) []func(tx dbmodel.TxInterface) error {
+	purposeStore := consentService.stores.ConsentPurpose
+	queries := make([]func(tx dbmodel.TxInterface) error, 0, len(scope.purposes))
+	for _, purpose := range scope.purposes {
+		purposeID := purpose.PurposeID
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return purposeStore.UpdateMappingApproval(tx, consentID, purposeID, orgID, false, currentTime)
+		})
+	}
+	return queries
+}
 
-	// Get consent
-	consentStore := consentService.stores.Consent
-	consent, err := consentStore.GetByID(ctx, req.ConsentID, orgID)
+// planPartialRevocation resolves the purposes and authorizations named by a revoke request and
+// decides whether the consent remains: every mandatory purpose must stay approved, and at least one
+// authorization must stay unrevoked when the consent has any
+func (consentService *consentService) planPartialRevocation(ctx context.Context, existing *model.Consent, orgID string, req model.ConsentRevokeRequest) (*revocationScope, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+	consentCfg := config.Get().Consent.ForOrg(orgID)
+	if consentCfg.IsTerminalStatus(config.ConsentStatus(existing.CurrentStatus)) {
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("Consent with ID '%s' is %s and its purposes and authorizations cannot be revoked", existing.ConsentID, existing.CurrentStatus))
+	}
+
+	mappings, err := consentService.stores.ConsentPurpose.GetMappingsByConsentID(ctx, existing.ConsentID, orgID)
 	if err != nil {
-		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", req.ConsentID))
-		// return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
-		response.ErrorCode = 500
-		response.ErrorMessage = "database_error"
-		response.ErrorDescription = "Database error while retrieving consent"
+		logger.Error("Failed to retrieve consent purposes", log.Error(err), log.String("consent_id", existing.ConsentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
 	}
-	if consent == nil {
-		logger.Warn("Consent not found", log.String("consent_id", req.ConsentID))
-		// return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("Consent with ID '%s' not found", req.ConsentID))
-		response.ErrorCode = 404
-		response.ErrorMessage = "not_found"
-		response.ErrorDescription = "Consent not found"
-	} else {
-		// Check if consent is expired and update status accordingly (only if consent exists)
-		expiredStatusName := string(config.Get().Consent.GetExpiredConsentStatus())
-		if consent.ValidityTime != nil && validator.IsConsentExpired(*consent.ValidityTime) {
-			// Update consent status to expired if not already expired
-			if consent.CurrentStatus != expiredStatusName {
-				if err := consentService.expireConsent(ctx, consent, orgID); err != nil {
-					// Log error but continue with validation
-					// The consent object is already updated in-memory by expireConsent
-				} else {
-					// Re-fetch consent after expiring to get latest state
-					if updatedConsent, fetchErr := consentStore.GetByID(ctx, req.ConsentID, orgID); fetchErr == nil && updatedConsent != nil {
-						consent = updatedConsent
-					}
-					// If re-fetch fails, continue with in-memory consent object
-				}
-			}
-		}
+	authResources, err := consentService.stores.AuthResource.GetByConsentID(ctx, existing.ConsentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent authorizations", log.Error(err), log.String("consent_id", existing.ConsentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
 	}
 
-	// Check consent status - only active consents are valid
-	activeStatusName := string(config.Get().Consent.GetActiveConsentStatus())
-	if consent != nil && consent.CurrentStatus != activeStatusName && response.ErrorCode == 0 {
-		response.ErrorCode = 401
-		response.ErrorMessage = "invalid_consent_status"
-		response.ErrorDescription = fmt.Sprintf("Consent status is '%s', expected '%s'", consent.CurrentStatus, activeStatusName)
+	systemRevoked := string(consentCfg.GetSystemRevokedAuthStatus())
+	scope := &revocationScope{}
+	revokedPurposes := make(map[string]bool, len(req.PurposeNames))
+	for _, name := range req.PurposeNames {
+		if revokedPurposes[name] {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("purpose '%s' is listed more than once", name))
+		}
+		revokedPurposes[name] = true
+		index := slices.IndexFunc(mappings, func(mapping purposemodel.ConsentPurposeMapping) bool { return mapping.Name == name })
+		if index < 0 {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("purpose '%s' is not part of the consent", name))
+		}
+		if !mappings[index].IsUserApproved {
+			return nil, serviceerror.CustomServiceError(serviceerror.ConflictError, fmt.Sprintf("purpose '%s' is not approved", name))
+		}
+		scope.purposes = append(scope.purposes, mappings[index])
+	}
+	revokedAuths := make(map[string]bool, len(req.AuthorizationIDs))
+	for _, authID := range req.AuthorizationIDs {
+		if revokedAuths[authID] {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("authorization '%s' is listed more than once", authID))
+		}
+		revokedAuths[authID] = true
+		index := slices.IndexFunc(authResources, func(authResource authmodel.AuthResource) bool { return authResource.AuthID == authID })
+		if index < 0 {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("authorization '%s' is not part of the consent", authID))
+		}
+		if authResources[index].AuthStatus == systemRevoked {
+			return nil, serviceerror.CustomServiceError(serviceerror.ConflictError, fmt.Sprintf("authorization '%s' is already revoked", authID))
+		}
+		scope.authorizations = append(scope.authorizations, authResources[index])
 	}
 
-	// If no errors, mark as valid
-	if response.ErrorCode == 0 {
-		response.IsValid = true
-		logger.Info("Consent validation successful",
-			log.String("consent_id", req.ConsentID),
-			log.Bool("is_valid", true))
-	} else {
-		logger.Warn("Consent validation failed",
-			log.String("consent_id", req.ConsentID),
-			log.Bool("is_valid", false),
-			log.Int("error_code", response.ErrorCode),
-			log.String("error_message", response.ErrorMessage))
+	scope.consentRemains = true
+	for _, mapping := range mappings {
+		if mapping.IsMandatory && (!mapping.IsUserApproved || revokedPurposes[mapping.Name]) {
+			scope.consentRemains = false
+		}
+	}
+	if len(authResources) > 0 {
+		remaining := 0
+		for _, authResource := range authResources {
+			if !revokedAuths[authResource.AuthID] && authResource.AuthStatus != systemRevoked {
+				remaining++
+			}
+		}
+		if remaining == 0 {
+			scope.consentRemains = false
+		}
 	}
 
-	// Retrieve related data for consent information (only if consent exists)
-	if consent != nil {
-		authResourceStore := consentService.stores.AuthResource
-		purposeStore := consentService.stores.ConsentPurpose
+	reason, _ := json.Marshal(model.PartialRevocationReason{
+		RevocationReason:        req.RevocationReason,
+		RevokedPurposes:         scope.purposeNames(),
+		RevokedAuthorizationIDs: scope.authorizationIDs(),
+	})
+	scope.reason = string(reason)
+	return scope, nil
+}
 
-		attributes, _ := consentStore.GetAttributesByConsentID(ctx, consent.ConsentID, orgID)
-		authResources, _ := authResourceStore.GetByConsentID(ctx, consent.ConsentID, orgID)
-		purposeMappings, _ := purposeStore.GetMappingsByConsentID(ctx, consent.ConsentID, orgID)
+// revokeScope revokes the purposes and authorizations of a partial revocation that leaves the
+// consent in place. The consent keeps its status; the revocation is recorded in the status audit
+// with the consent status unchanged.
+func (consentService *consentService) revokeScope(ctx context.Context, existing *model.Consent, orgID string, req model.ConsentRevokeRequest, scope *revocationScope, claim *model.ScheduledRevocation) (*model.ConsentRevokeResponse, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+	consentStore := consentService.stores.Consent
+	authResourceStore := consentService.stores.AuthResource
+	systemRevoked := string(config.Get().Consent.ForOrg(orgID).GetSystemRevokedAuthStatus())
+	currentTime := utils.GetCurrentTimeMillis()
 
-		// Convert attributes slice to map
-		attributesMap := make(map[string]string)
-		for _, a := range attributes {
-			attributesMap[a.AttKey] = a.AttValue
-		}
+	attributes, err := consentStore.GetAttributesByConsentID(ctx, existing.ConsentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent attributes", log.Error(err), log.String("consent_id", existing.ConsentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	attributesMap := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		attributesMap[attribute.AttKey] = attribute.AttValue
+	}
 
-		// Build complete consent response
-		consentResponse := buildConsentResponse(consent, attributesMap, authResources, purposeMappings)
+	audit := &model.ConsentStatusAudit{
+		StatusAuditID:  utils.GenerateUUID(),
+		ConsentID:      existing.ConsentID,
+		CurrentStatus:  existing.CurrentStatus,
+		ActionTime:     currentTime,
+		Reason:         &scope.reason,
+		ActionBy:       &req.ActionBy,
+		PreviousStatus: &existing.CurrentStatus,
+		OrgID:          orgID,
+	}
+	queries := append([]func(tx dbmodel.TxInterface) error{consentService.claimScheduledRevocation(claim)},
+		scope.purposeQueries(consentService, existing.ConsentID, orgID, currentTime)...)
+	for _, authorization := range scope.authorizations {
+		authID := authorization.AuthID
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return authResourceStore.UpdateStatus(tx, authID, orgID, systemRevoked, currentTime)
+		})
+	}
+	queries = append(queries,
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.CreateStatusAudit(tx, audit)
+		},
+		consentService.recordEvent(events.ConsentEvent{
+			ID:         utils.GenerateUUID(),
+			Type:       events.ConsentUpdated,
+			Timestamp:  currentTime,
+			OrgID:      orgID,
+			ConsentID:  existing.ConsentID,
+			ClientID:   existing.ClientID,
+			Status:     existing.CurrentStatus,
+			Attributes: attributesMap,
+		}),
+	)
 
-		// Convert to API response and then to ValidateConsentAPIResponse (which excludes modifiedResponse)
-		apiResponse := consentService.EnrichedConsentAPIResponseWithPurposeDetails(ctx, consentResponse, orgID)
-		response.ConsentInformation = apiResponse.ToValidateConsentAPIResponse()
+	err = consentService.stores.ExecuteTransaction(ctx, queries)
+	if errors.Is(err, ErrScheduledRevocationClaimed) {
+		return nil, scheduledRevocationClaimedError(existing.ConsentID)
+	}
+	if err != nil {
+		logger.Error("Failed to revoke consent purposes and authorizations in transaction",
+			log.Error(err), log.String("consent_id", existing.ConsentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
 	}
+	cache.InvalidateConsentValidation(ctx, orgID, existing.ConsentID)
+	auditChanges(ctx, "purposes", "revoked", scope.purposeNames())
+	auditChanges(ctx, "authorizations", "revoked", scope.authorizationIDs())
+
+	logger.Info("Consent partially revoked",
+		log.String("consent_id", existing.ConsentID),
+		log.Int("purposes", len(scope.purposes)),
+		log.Int("authorizations", len(scope.authorizations)))
+
+	return &model.ConsentRevokeResponse{
+		ActionTime:              currentTime / 1000, // Convert milliseconds to seconds
+		ActionBy:                req.ActionBy,
+		RevocationReason:        req.RevocationReason,
+		ConsentStatus:           existing.CurrentStatus,
+		RevokedPurposes:         scope.purposeNames(),
+		RevokedAuthorizationIDs: scope.authorizationIDs(),
+	}, nil
+}
 
-	return response, nil
+// revocableDescendants collects the child consents of a consent and, recursively, their children
+// that are still to be revoked with it. Revoked, expired and rejected consents are left as they are
+// but their children are still collected.
+func (consentService *consentService) revocableDescendants(ctx context.Context, consentID, orgID string) ([]model.Consent, *serviceerror.ServiceError) {
+	consentCfg := config.Get().Consent.ForOrg(orgID)
+	rejectedStatus := string(consentCfg.GetRejectedConsentStatus())
+
+	var descendants []model.Consent
+	visited := map[string]bool{consentID: true}
+	pending := []string{consentID}
+	for len(pending) > 0 {
+		parentID := pending[0]
+		pending = pending[1:]
+
+		children, err := consentService.stores.Consent.GetChildConsents(ctx, parentID, orgID)
+		if err != nil {
+			log.GetLogger().WithContext(ctx).Error("Failed to retrieve child consents",
+				log.Error(err), log.String("consent_id", parentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		for _, child := range children {
+			if visited[child.ConsentID] {
+				continue
+			}
+			visited[child.ConsentID] = true
+			pending = append(pending, child.ConsentID)
+
+			if consentCfg.IsTerminalStatus(config.ConsentStatus(child.CurrentStatus)) || child.CurrentStatus == rejectedStatus {
+				continue
+			}
+			descendants = append(descendants, child)
+		}
+	}
+	return descendants, nil
 }
 
-// expireConsent updates consent and all related auth resources to expired status
-func (consentService *consentService) expireConsent(ctx context.Context, consent *model.Consent, orgID string) error {
+// ReauthorizeConsent sends a consent back to its users for re-authorization, as in the Open Banking
+// 90-day re-consent journey. The consent moves to the awaiting re-authorization status, all its
+// authorizations are reset to the created status and its validity time is extended; it becomes
+// active again once its authorizations are approved. The change is recorded in the status audit.
+// Like a status override it is not checked against the state machine: the statuses that can be
+// re-authorized are set by consent.reauthorization.from_statuses.
+func (consentService *consentService) ReauthorizeConsent(ctx context.Context, consentID, orgID string, req model.ConsentReauthorizationRequest) (*model.ConsentReauthorizationResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ReauthorizeConsent")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
-	logger.Debug("Expiring consent",
-		log.String("consent_id", consent.ConsentID),
-		log.String("org_id", orgID))
+	logger.Info("Reauthorizing consent",
+		log.String("consent_id", consentID),
+		log.String("org_id", orgID),
+		log.String("action_by", req.ActionBy))
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if strings.TrimSpace(req.ActionBy) == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "actionBy is required")
+	}
 
-	expiredStatusName := string(config.Get().Consent.GetExpiredConsentStatus())
+	consentCfg := config.Get().Consent.ForOrg(orgID)
 	currentTime := utils.GetCurrentTimeMillis()
 
-	// Create audit entry
-	auditID := utils.GenerateUUID()
-	reason := "Consent expired based on validityTime"
-	actionBy := "SYSTEM"
-	previousStatus := consent.CurrentStatus
+	// The validity time defaults to the organization's default validity, like on create
+	var validityTime int64
+	switch {
+	case req.ValidityTime != nil:
+		validityTime = *req.ValidityTime
+	case consentCfg.DefaultValidity > 0:
+		validityTime = currentTime/1000 + int64(consentCfg.DefaultValidity/time.Second)
+	default:
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			"validityTime is required when the organization has no default validity")
+	}
+	if validityTime <= 0 || validator.IsConsentExpired(validityTime) {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "validityTime must be in the future")
+	}
+	if maxValidity := consentCfg.Reauthorization.MaxValidity; maxValidity > 0 &&
+		validityMillis(validityTime) > currentTime+maxValidity.Milliseconds() {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("validityTime must not be more than %s from now", maxValidity))
+	}
+
+	consentStore := consentService.stores.Consent
+	existing, err := consentStore.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+	if serviceErr := consentService.checkConsentLock(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+	if !consentCfg.IsReauthorizable(config.ConsentStatus(existing.CurrentStatus)) {
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("Consent with ID '%s' in status '%s' cannot be re-authorized", consentID, existing.CurrentStatus))
+	}
+
+	awaitingStatus := string(consentCfg.GetAwaitingReauthorizationStatus())
+	authStatus := string(consentCfg.GetCreatedAuthStatus())
+	reason := req.Reason
+	if strings.TrimSpace(reason) == "" {
+		reason = "Consent sent for re-authorization"
+	}
+	actionBy := req.ActionBy
 	audit := &model.ConsentStatusAudit{
-		StatusAuditID:  auditID,
-		ConsentID:      consent.ConsentID,
-		CurrentStatus:  expiredStatusName,
+		StatusAuditID:  utils.GenerateUUID(),
+		ConsentID:      consentID,
+		CurrentStatus:  awaitingStatus,
 		ActionTime:     currentTime,
 		Reason:         &reason,
 		ActionBy:       &actionBy,
-		PreviousStatus: &previousStatus,
+		PreviousStatus: &existing.CurrentStatus,
 		OrgID:          orgID,
 	}
 
-	// Get stores for cascading status update
-	consentStore := consentService.stores.Consent
-	authResourceStore := consentService.stores.AuthResource
+	updated := *existing
+	updated.ValidityTime = &validityTime
+	updated.UpdatedTime = currentTime
 
-	// Execute transaction - update consent status, all auth resource statuses, and create audit
-	err := consentService.stores.ExecuteTransaction([]func(tx dbmodel.TxInterface) error{
+	err = consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
 		func(tx dbmodel.TxInterface) error {
-			return consentStore.UpdateStatus(tx, consent.ConsentID, orgID, expiredStatusName, currentTime)
+			return consentStore.Update(tx, &updated)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.UpdateStatus(tx, consentID, orgID, awaitingStatus, currentTime)
 		},
 		func(tx dbmodel.TxInterface) error {
-			// Update all authorization statuses to SYS_EXPIRED when consent expires
-			return authResourceStore.UpdateAllStatusByConsentID(tx, consent.ConsentID, orgID, "SYS_EXPIRED", currentTime)
+			return consentService.stores.AuthResource.UpdateAllStatusByConsentID(tx, consentID, orgID, authStatus, currentTime)
 		},
 		func(tx dbmodel.TxInterface) error {
 			return consentStore.CreateStatusAudit(tx, audit)
 		},
+		consentService.recordEvent(events.ConsentEvent{
+			ID:             utils.GenerateUUID(),
+			Type:           events.ConsentReauthorizationRequested,
+			Timestamp:      currentTime,
+			OrgID:          orgID,
+			ConsentID:      consentID,
+			ClientID:       existing.ClientID,
+			Status:         awaitingStatus,
+			PreviousStatus: existing.CurrentStatus,
+			ValidityTime:   validityTime,
+		}),
 	})
 	if err != nil {
-		logger.Error("Failed to expire consent in transaction",
-			log.Error(err),
-			log.String("consent_id", consent.ConsentID))
-		return err
+		logger.Error("Failed to reauthorize consent in transaction", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
 	}
+	cache.InvalidateConsentValidation(ctx, orgID, consentID)
 
-	// Update local consent object
-	consent.CurrentStatus = expiredStatusName
-	consent.UpdatedTime = currentTime
-
-	logger.Debug("Consent expired successfully",
-		log.String("consent_id", consent.ConsentID),
-		log.String("new_status", expiredStatusName))
+	auditChanges(ctx, "status", "from", []string{existing.CurrentStatus})
+	auditChanges(ctx, "status", "to", []string{awaitingStatus})
 
-	return nil
+	logger.Info("Consent sent for re-authorization",
+		log.String("consent_id", consentID),
+		log.String("previous_status", existing.CurrentStatus),
+		log.String("new_status", awaitingStatus))
+
+	return &model.ConsentReauthorizationResponse{
+		ConsentID:            consentID,
+		PreviousStatus:       existing.CurrentStatus,
+		Status:               awaitingStatus,
+		AuthorizationStatus:  authStatus,
+		ValidityTime:         validityTime,
+		PreviousValidityTime: existing.ValidityTime,
+		Reason:               reason,
+		ActionBy:             req.ActionBy,
+		ActionTime:           currentTime,
+	}, nil
 }
 
-func (consentService *consentService) EnrichedConsentAPIResponseWithPurposeDetails(ctx context.Context, consent *model.ConsentResponse, orgID string) *model.ConsentAPIResponse {
-	logger := log.GetLogger().WithContext(ctx)
-	logger.Debug("Enriching consent response with purpose details",
-		log.String("consent_id", consent.ConsentID),
-		log.String("org_id", orgID))
-
-	purposeStore := consentService.stores.ConsentPurpose
+// DeleteConsent soft deletes a consent. The consent is moved to the DELETED status, which hides
+// it from all reads, and is hard-deleted later by the purge job.
+func (consentService *consentService) DeleteConsent(ctx context.Context, consentID, orgID, clientID string) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "consent.DeleteConsent")
+	defer span.End()
 
-	if consent == nil {
-		logger.Debug("Consent is nil, returning nil")
-		return nil
-	}
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Deleting consent",
+		log.String("consent_id", consentID),
+		log.String("org_id", orgID),
+		log.String("client_id", clientID))
 
-	// Use ToAPIResponse to build the complete base response structure
-	apiResponse := consent.ToAPIResponse()
+	store := consentService.stores.Consent
+	existing, err := store.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		logger.Warn("Consent not found", log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+	if serviceErr := consentService.checkConsentLock(ctx, consentID, orgID); serviceErr != nil {
+		return serviceErr
+	}
 
-	// Enrich consent purposes with full purpose details (type, description, attributes)
-	if len(apiResponse.ConsentPurpose) > 0 {
-		enrichedPurposes := make([]model.ConsentPurposeItem, 0, len(apiResponse.ConsentPurpose))
+	currentTime := utils.GetCurrentTimeMillis()
+	reason := "Consent deleted"
+	audit := &model.ConsentStatusAudit{
+		StatusAuditID:  utils.GenerateUUID(),
+		ConsentID:      consentID,
+		CurrentStatus:  model.DeletedConsentStatus,
+		ActionTime:     currentTime,
+		Reason:         &reason,
+		ActionBy:       &clientID,
+		PreviousStatus: &existing.CurrentStatus,
+		OrgID:          orgID,
+	}
 
-		for _, cp := range apiResponse.ConsentPurpose {
-			// Convert base purpose to enriched purpose
-			enrichedPurpose := cp
+	err = consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return store.UpdateStatus(tx, consentID, orgID, model.DeletedConsentStatus, currentTime)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return store.CreateStatusAudit(tx, audit)
+		},
+		consentService.releaseTokens(consentID, orgID),
+	})
+	if err != nil {
+		logger.Error("Failed to delete consent in transaction",
+			log.Error(err),
+			log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	cache.InvalidateConsentValidation(ctx, orgID, consentID)
 
-			// Fetch full purpose details from consent purpose service
-			if cp.Name != "" {
-				purpose, err := purposeStore.GetByName(ctx, cp.Name, orgID)
-				if err == nil && purpose != nil {
-					// Enrich with type, description, and attributes from the purpose definition
-					enrichedPurpose.Type = &purpose.Type
-					enrichedPurpose.Description = purpose.Description
+	logger.Info("Consent deleted successfully",
+		log.String("consent_id", consentID),
+		log.String("previous_status", existing.CurrentStatus))
+	return nil
+}
 
-					attributes, _ := purposeStore.GetAttributesByPurposeID(ctx, purpose.ID, orgID)
+// PurgeDeletedConsents hard-deletes consents that were soft deleted more than the configured
+// retention period ago. Organizations may override the retention period, so consents are read
+// with the shortest one and skipped while their organization still retains them. Attributes,
+// authorizations, audits and history are removed by the database cascade. Each consent is
+// purged in its own transaction so a failure does not block the rest of the batch.
+func (consentService *consentService) PurgeDeletedConsents(ctx context.Context) {
+	ctx, span := tracing.StartSpan(ctx, "consent.PurgeDeletedConsents")
+	defer span.End()
 
-					// Convert []ConsentPurposeAttribute to map[string]interface{}
-					if len(attributes) > 0 {
-						attrs := make(map[string]interface{}, len(attributes))
-						for _, attr := range attributes {
-							attrs[attr.Key] = attr.Value
-						}
-						enrichedPurpose.Attributes = attrs
-					} else {
-						enrichedPurpose.Attributes = map[string]interface{}{}
-					}
-				} else {
-					// If we can't fetch the purpose, add empty values for enriched fields
-					emptyType := ""
-					emptyDesc := ""
-					enrichedPurpose.Type = &emptyType
-					enrichedPurpose.Description = &emptyDesc
-					enrichedPurpose.Attributes = map[string]interface{}{}
+	logger := log.GetLogger().WithContext(ctx)
+	consentCfg := &config.Get().Consent
+	purgeCfg := consentCfg.Purge
+
+	now := utils.GetCurrentTimeMillis()
+	deletedBefore := now - daysMillis(consentCfg.ShortestRetentionDays())
+
+	store := consentService.stores.Consent
+	consents, err := store.GetPurgeableConsents(ctx, deletedBefore, purgeCfg.BatchSize)
+	if err != nil {
+		logger.Error("Failed to retrieve soft-deleted consents for purge", log.Error(err))
+		return
+	}
+	if len(consents) == 0 {
+		return
+	}
+
+	purged, retained := 0, 0
+	for _, consent := range consents {
+		consentID, orgID := consent.ConsentID, consent.OrgID
+		if consent.UpdatedTime > now-daysMillis(consentCfg.ForOrg(orgID).Purge.RetentionDays) {
+			retained++
+			continue
+		}
+		err := consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+			func(tx dbmodel.TxInterface) error {
+				return store.Delete(tx, consentID, orgID)
+			},
+		})
+		if err != nil {
+			logger.Error("Failed to purge consent",
+				log.Error(err),
+				log.String("consent_id", consentID),
+				log.String("org_id", orgID))
+			continue
+		}
+		purged++
+	}
+
+	logger.Info("Purged soft-deleted consents",
+		log.Int("purged", purged),
+		log.Int("retained", retained),
+		log.Int("failed", len(consents)-purged-retained))
+}
+
+// daysMillis converts a period in days to milliseconds
+func daysMillis(days int) int64 {
+	return int64(days) * 24 * 60 * 60 * 1000
+}
+
+// retentionSummary collects the consents of an organization handled by a retention run
+type retentionSummary struct {
+	retentionDays int
+	purged        []string
+	failed        []string
+}
+
+// ApplyRetention deletes or anonymizes, as configured, revoked and expired consents whose
+// organization's retention period has ended. Consents are read with the shortest retention period
+// and skipped while their organization still retains them. Each consent is handled in its own
+// transaction, and each organization with handled consents gets an operation audit entry listing
+// them.
+func (consentService *consentService) ApplyRetention(ctx context.Context) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ApplyRetention")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	consentCfg := &config.Get().Consent
+	retentionCfg := consentCfg.Retention
+
+	now := utils.GetCurrentTimeMillis()
+	updatedBefore := now - daysMillis(consentCfg.ShortestTerminalRetentionDays())
+
+	store := consentService.stores.Consent
+	consents, err := store.GetRetentionExpiredConsents(ctx, consentCfg.TerminalStatusNames(), updatedBefore, retentionCfg.BatchSize)
+	if err != nil {
+		logger.Error("Failed to retrieve consents past their retention period", log.Error(err))
+		return
+	}
+	if len(consents) == 0 {
+		return
+	}
+
+	summaries := make(map[string]*retentionSummary)
+	orgIDs := make([]string, 0)
+	purged, retained, failed := 0, 0, 0
+	for _, consent := range consents {
+		consentID, orgID := consent.ConsentID, consent.OrgID
+		orgCfg := consentCfg.ForOrg(orgID)
+		if !orgCfg.IsTerminalStatus(config.ConsentStatus(consent.CurrentStatus)) ||
+			consent.UpdatedTime > now-daysMillis(orgCfg.Retention.RetentionDays) {
+			retained++
+			continue
+		}
+
+		summary, ok := summaries[orgID]
+		if !ok {
+			summary = &retentionSummary{retentionDays: orgCfg.Retention.RetentionDays}
+			summaries[orgID] = summary
+			orgIDs = append(orgIDs, orgID)
+		}
+
+		err := consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+			func(tx dbmodel.TxInterface) error {
+				if retentionCfg.Action == config.RetentionActionAnonymize {
+					return store.Anonymize(tx, consentID, orgID, now)
 				}
-			}
+				return store.Delete(tx, consentID, orgID)
+			},
+		})
+		if err != nil {
+			logger.Error("Failed to apply retention to consent",
+				log.Error(err),
+				log.String("consent_id", consentID),
+				log.String("org_id", orgID))
+			summary.failed = append(summary.failed, consentID)
+			failed++
+			continue
+		}
+		cache.InvalidateConsentValidation(ctx, orgID, consentID)
+		summary.purged = append(summary.purged, consentID)
+		purged++
+	}
 
-			enrichedPurposes = append(enrichedPurposes, enrichedPurpose)
+	for _, orgID := range orgIDs {
+		summary := summaries[orgID]
+		op := &opaudit.Operation{
+			Action:  opaudit.ActionConsentRetention,
+			OrgID:   orgID,
+			Actor:   "system",
+			Method:  "SCHEDULED",
+			Route:   "consent-retention",
+			Outcome: opaudit.OutcomeSuccess,
+			Time:    now,
 		}
+		if len(summary.failed) > 0 {
+			op.Outcome = opaudit.OutcomeFailure
+		}
+		opCtx := opaudit.WithOperation(ctx, op)
+		opaudit.AddDetail(opCtx, "action", retentionCfg.Action)
+		opaudit.AddDetail(opCtx, "retentionDays", summary.retentionDays)
+		opaudit.AddDetail(opCtx, "consentIds", summary.purged)
+		if len(summary.failed) > 0 {
+			opaudit.AddDetail(opCtx, "failedConsentIds", summary.failed)
+		}
+		opaudit.Record(ctx, op)
+	}
 
-		// Set enriched purposes
-		apiResponse.ConsentPurpose = enrichedPurposes
+	logger.Info("Applied consent retention",
+		log.String("action", retentionCfg.Action),
+		log.Int("purged", purged),
+		log.Int("retained", retained),
+		log.Int("failed", failed))
+}
+
+// NotifyExpiringConsents notifies active consents whose validity time falls within their
+// organization's expiry notice period. Each consent is notified once per validity time: the
+// registered notifiers are called, then the notice and a consent.expiring_soon event are recorded
+// in one transaction. A consent whose notifiers fail is retried on the next run. Consents are read
+// with the longest notice period and skipped while their organization's period has not started.
+func (consentService *consentService) NotifyExpiringConsents(ctx context.Context) {
+	ctx, span := tracing.StartSpan(ctx, "consent.NotifyExpiringConsents")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	consentCfg := &config.Get().Consent
+	noticeDays := consentCfg.LongestExpiryNoticeDays()
+	if noticeDays <= 0 {
+		return
 	}
 
-	logger.Debug("Consent response enriched successfully",
-		log.Int("purpose_count", len(apiResponse.ConsentPurpose)))
+	now := utils.GetCurrentTimeMillis()
+	store := consentService.stores.Consent
+	consents, err := store.GetExpiringConsents(ctx, now, now+daysMillis(noticeDays),
+		consentCfg.ActiveStatusNames(), consentCfg.ExpiryNotification.BatchSize)
+	if err != nil {
+		logger.Error("Failed to retrieve expiring consents", log.Error(err))
+		return
+	}
+	if len(consents) == 0 {
+		return
+	}
 
-	return apiResponse
+	notified, skipped := 0, 0
+	for _, consent := range consents {
+		orgCfg := consentCfg.ForOrg(consent.OrgID)
+		validityTime := *consent.ValidityTime
+		if consent.CurrentStatus != string(orgCfg.GetActiveConsentStatus()) ||
+			validityMillis(validityTime) > now+daysMillis(orgCfg.ExpiryNotification.NoticeDays) {
+			skipped++
+			continue
+		}
+		if err := consentService.notifyExpiringConsent(ctx, consent, now); err != nil {
+			logger.Error("Failed to notify expiring consent",
+				log.Error(err),
+				log.String("consent_id", consent.ConsentID),
+				log.String("org_id", consent.OrgID))
+			continue
+		}
+		notified++
+	}
+
+	logger.Info("Notified expiring consents",
+		log.Int("notified", notified),
+		log.Int("skipped", skipped),
+		log.Int("failed", len(consents)-notified-skipped))
 }
 
-// buildConsentResponse constructs a complete ConsentResponse with related data
-func buildConsentResponse(
-	consent *model.Consent,
-	attributes map[string]string,
-	authResources []authmodel.AuthResource,
-	purposeMappings []purposemodel.ConsentPurposeMapping,
-) *model.ConsentResponse {
-	// Convert purposeMappings to ConsentPurposeItem
-	purposes := make([]model.ConsentPurposeItem, len(purposeMappings))
-	for i, mapping := range purposeMappings {
-		purposes[i] = model.ConsentPurposeItem{
-			Name:           mapping.Name,
-			Value:          mapping.Value,
-			IsUserApproved: &mapping.IsUserApproved,
-			IsMandatory:    &mapping.IsMandatory,
+// notifyExpiringConsent calls the notifiers for a consent and records its notice and event
+func (consentService *consentService) notifyExpiringConsent(ctx context.Context, consent model.Consent, now int64) error {
+	consentID, orgID := consent.ConsentID, consent.OrgID
+	attributes, err := consentService.stores.Consent.GetAttributesByConsentIDs(ctx, []string{consentID}, orgID)
+	if err != nil {
+		return err
+	}
+	authResources, err := consentService.stores.AuthResource.GetByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		return err
+	}
+
+	userIDs := make([]string, 0, len(authResources))
+	for _, authResource := range authResources {
+		if authResource.UserID != nil && *authResource.UserID != "" && !slices.Contains(userIDs, *authResource.UserID) {
+			userIDs = append(userIDs, *authResource.UserID)
 		}
 	}
 
-	// AuthResource is already a type alias for ConsentAuthResource - use directly
-	authResourcesResp := authResources
+	event := events.ConsentEvent{
+		ID:           utils.GenerateUUID(),
+		Type:         events.ConsentExpiringSoon,
+		Timestamp:    now,
+		OrgID:        orgID,
+		ConsentID:    consentID,
+		ClientID:     consent.ClientID,
+		Status:       consent.CurrentStatus,
+		Attributes:   attributes[consentID],
+		ValidityTime: *consent.ValidityTime,
+	}
+	if err := notification.NotifyExpiry(ctx, notification.ExpiryNotice{
+		Event:       event,
+		ConsentType: consent.ConsentType,
+		UserIDs:     userIDs,
+	}); err != nil {
+		return err
+	}
 
-	return &model.ConsentResponse{
-		ConsentID:                  consent.ConsentID,
-		ConsentPurpose:             purposes,
-		CreatedTime:                consent.CreatedTime,
-		UpdatedTime:                consent.UpdatedTime,
-		ClientID:                   consent.ClientID,
-		ConsentType:                consent.ConsentType,
-		CurrentStatus:              consent.CurrentStatus,
-		ConsentFrequency:           consent.ConsentFrequency,
-		ValidityTime:               consent.ValidityTime,
-		RecurringIndicator:         consent.RecurringIndicator,
-		DataAccessValidityDuration: consent.DataAccessValidityDuration,
-		OrgID:                      consent.OrgID,
-		Attributes:                 attributes,
-		AuthResources:              authResourcesResp,
+	return consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return consentService.stores.Consent.RecordExpiryNotice(tx, consentID, orgID, *consent.ValidityTime, now)
+		},
+		consentService.recordEvent(event),
+	})
+}
+
+// validityMillis converts a consent validity time, stored in seconds or milliseconds, to
+// milliseconds. Values below 10^11 are seconds, as in validator.IsConsentExpired.
+func validityMillis(validityTime int64) int64 {
+	if validityTime < 100000000000 {
+		return validityTime * 1000
 	}
+	return validityTime
 }
 
-// SearchConsentsByAttribute searches for consents by attribute key and optionally value
-// If value is empty, it searches by key only
-func (consentService *consentService) SearchConsentsByAttribute(ctx context.Context, key, value, orgID string) (*model.ConsentAttributeSearchResponse, *serviceerror.ServiceError) {
+// ValidateConsent validates a consent for data access
+func (consentService *consentService) ValidateConsent(ctx context.Context, req model.ValidateRequest, orgID string) (*model.ValidateResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ValidateConsent")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
-	logger.Info("Searching consents by attribute",
-		log.String("key", key),
-		log.String("value", value),
+	logger.Info("Validating consent",
+		log.String("consent_id", req.ConsentID),
 		log.String("org_id", orgID))
 
-	consentStore := consentService.stores.Consent
+	// Initialize response with invalid state
+	response := &model.ValidateResponse{
+		IsValid: false,
+	}
 
-	var consentIDs []string
-	var err error
+	// Let the compiled-in interceptors, then the service extension, adjust the request or reject
+	// the validation
+	if interceptor.Enabled() {
+		payload := &model.ConsentValidateHookPayload{ValidateRequest: req}
+		if err := interceptor.PreValidate(ctx, orgID, payload); err != nil {
+			logger.Warn("Consent validation rejected by consent interceptor", log.Error(err))
+			setExtensionValidateError(response, err)
+			return response, nil
+		}
+		req = payload.ValidateRequest
+	}
+	if extension.IsEnabled(extension.PreProcessConsentValidation) {
+		payload := &model.ConsentValidateHookPayload{ValidateRequest: req}
+		if err := extension.Invoke(ctx, extension.PreProcessConsentValidation, orgID, payload); err != nil {
+			logger.Warn("Consent validation rejected by service extension", log.Error(err))
+			setExtensionValidateError(response, err)
+			return response, nil
+		}
+		req = payload.ValidateRequest
+	}
 
-	// If value is provided and not empty, search by key-value pair
-	// Otherwise, search by key only
-	if value != "" {
-		consentIDs, err = consentStore.FindConsentIDsByAttribute(ctx, key, value, orgID)
-	} else {
-		consentIDs, err = consentStore.FindConsentIDsByAttributeKey(ctx, key, orgID)
+	// Validate request
+	if req.ConsentID == "" {
+		logger.Warn("Validation failed: ConsentID is required")
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "ConsentID is required")
+	}
+
+	logger.Debug("Request validation successful")
+
+	// Serve the consent from the validate cache unless it is due to be expired
+	expiredStatusName := string(config.Get().Consent.ForOrg(orgID).GetExpiredConsentStatus())
+	snapshot := getValidateSnapshot(ctx, req.ConsentID, orgID)
+	if snapshot != nil && snapshot.Consent.ValidityTime != nil && validator.IsConsentExpired(*snapshot.Consent.ValidityTime) &&
+		snapshot.Consent.CurrentStatus != expiredStatusName {
+		snapshot = nil
 	}
 
+	// Get consent
+	consentStore := consentService.stores.Consent
+	var consent *model.Consent
+	var err error
+	if snapshot != nil {
+		logger.Debug("Consent served from validate cache", log.String("consent_id", req.ConsentID))
+		consent = &snapshot.Consent
+	} else {
+		consent, err = consentStore.GetByID(ctx, req.ConsentID, orgID)
+	}
 	if err != nil {
-		logger.Error("Failed to search consents by attribute",
-			log.Error(err),
-			log.String("key", key),
-			log.String("value", value))
-		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", req.ConsentID))
+		// return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		response.ErrorCode = 500
+		response.ErrorMessage = "database_error"
+		response.ErrorDescription = "Database error while retrieving consent"
+	}
+	if consent == nil {
+		logger.Warn("Consent not found", log.String("consent_id", req.ConsentID))
+		// return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("Consent with ID '%s' not found", req.ConsentID))
+		response.ErrorCode = 404
+		response.ErrorMessage = "not_found"
+		response.ErrorDescription = "Consent not found"
+	} else {
+		// Check if consent is expired and update status accordingly (only if consent exists)
+		if consent.ValidityTime != nil && validator.IsConsentExpired(*consent.ValidityTime) {
+			// Update consent status to expired if not already expired
+			if consent.CurrentStatus != expiredStatusName {
+				if err := consentService.expireConsent(ctx, consent, orgID); err != nil {
+					// Log error but continue with validation
+					// The consent object is already updated in-memory by expireConsent
+				} else {
+					// Re-fetch consent after expiring to get latest state
+					if updatedConsent, fetchErr := consentStore.GetByID(ctx, req.ConsentID, orgID); fetchErr == nil && updatedConsent != nil {
+						consent = updatedConsent
+					}
+					// If re-fetch fails, continue with in-memory consent object
+				}
+			}
+		}
 	}
 
-	logger.Info("Consents searched by attribute successfully",
-		log.Int("count", len(consentIDs)))
+	// Check consent status - only active consents are valid
+	activeStatusName := string(config.Get().Consent.ForOrg(orgID).GetActiveConsentStatus())
+	suspendedStatusName := string(config.Get().Consent.ForOrg(orgID).GetSuspendedConsentStatus())
+	if consent != nil && consent.CurrentStatus == suspendedStatusName && response.ErrorCode == 0 {
+		response.ErrorCode = 403
+		response.ErrorMessage = "consent_suspended"
+		response.ErrorDescription = "Consent is suspended until it is resumed"
+	}
+	if consent != nil && consent.CurrentStatus != activeStatusName && response.ErrorCode == 0 {
+		response.ErrorCode = 401
+		response.ErrorMessage = "invalid_consent_status"
+		response.ErrorDescription = fmt.Sprintf("Consent status is '%s', expected '%s'", consent.CurrentStatus, activeStatusName)
+	}
 
-	return &model.ConsentAttributeSearchResponse{
-		ConsentIDs: consentIDs,
-		Count:      len(consentIDs),
-	}, nil
+	// If no errors, mark as valid
+	if response.ErrorCode == 0 {
+		response.IsValid = true
+		logger.Info("Consent validation successful",
+			log.String("consent_id", req.ConsentID),
+			log.Bool("is_valid", true))
+	} else {
+		logger.Warn("Consent validation failed",
+			log.String("consent_id", req.ConsentID),
+			log.Bool("is_valid", false),
+			log.Int("error_code", response.ErrorCode),
+			log.String("error_message", response.ErrorMessage))
+	}
+
+	// Retrieve related data for consent information (only if consent exists)
+	if snapshot != nil {
+		response.ConsentInformation = snapshot.Information
+	} else if consent != nil {
+		relations, relationsErr := consentService.loadConsentRelations(ctx, []string{consent.ConsentID}, orgID, allConsentRelations)
+		if relationsErr != nil {
+			logger.Warn("Failed to retrieve consent relations for validation", log.Error(relationsErr))
+			relations = &consentRelations{}
+		}
+
+		// Build complete consent response
+		consentResponse := relations.buildConsentResponse(consent)
+
+		// Convert to API response and then to ValidateConsentAPIResponse (which excludes modifiedResponse)
+		apiResponse := consentService.EnrichedConsentAPIResponseWithPurposeDetails(ctx, consentResponse, orgID)
+		response.ConsentInformation = apiResponse.ToValidateConsentAPIResponse()
+
+		// Only complete reads are cached
+		if err == nil && relationsErr == nil {
+			setValidateSnapshot(ctx, orgID, &validateSnapshot{Consent: *consent, Information: response.ConsentInformation})
+		}
+	}
+
+	// Stale purposes are derived at request time so that cached snapshots age correctly
+	if response.ConsentInformation != nil {
+		response.StalePurposes = validator.StalePurposes(response.ConsentInformation.ConsentPurpose)
+	}
+
+	// Apply the organization's validation policy to consents that passed the built-in checks
+	if response.IsValid && response.ConsentInformation != nil {
+		violation, policyErr := policy.Evaluate(ctx, orgID, req, response.ConsentInformation)
+		if policyErr != nil {
+			logger.Error("Failed to evaluate validation policy", log.Error(policyErr))
+			response.IsValid = false
+			response.ErrorCode = 500
+			response.ErrorMessage = "extension_error"
+			response.ErrorDescription = "Service extension is unavailable"
+		} else if violation != nil {
+			logger.Warn("Consent validation denied by policy",
+				log.String("consent_id", req.ConsentID),
+				log.String("rule", violation.Rule))
+			response.IsValid = false
+			response.ErrorCode = 403
+			response.ErrorMessage = "policy_violation"
+			response.ErrorDescription = fmt.Sprintf("Validation policy rule '%s' denied the request: %s", violation.Rule, violation.Description)
+		}
+	}
+
+	// Enforce the data access window of consents with a dataAccessValidityDuration
+	if window := validator.GetDataAccessWindow(response.ConsentInformation, req.UserID); window != nil {
+		response.DataAccessWindow = window
+		if response.IsValid && utils.GetCurrentTimeMillis() > window.End {
+			logger.Warn("Consent data access window has lapsed",
+				log.String("consent_id", req.ConsentID),
+				log.Any("window_end", window.End))
+			response.IsValid = false
+			response.ErrorCode = 401
+			response.ErrorMessage = "data_access_window_lapsed"
+			response.ErrorDescription = fmt.Sprintf("Data access under the consent was allowed until %s",
+				time.UnixMilli(window.End).UTC().Format(time.RFC3339))
+		}
+	}
+
+	// Count the access and enforce the consent frequency
+	if response.IsValid {
+		consentService.recordConsentUsage(ctx, consent, orgID, response)
+	}
+
+	// Granular decisions for the purposes and resources the caller asked about
+	info := response.ConsentInformation
+	if len(req.RequestedPurposes) > 0 {
+		var purposes []model.ConsentPurposeItem
+		if info != nil {
+			purposes = info.ConsentPurpose
+		}
+		response.PurposeDecisions = validator.PurposeDecisions(req.RequestedPurposes, response.IsValid, purposes)
+	}
+	if len(req.RequestedResources) > 0 {
+		response.ResourceDecisions = validator.ResourceDecisions(req.RequestedResources, response.IsValid, req.UserID, info)
+	}
+
+	if extension.IsEnabled(extension.EnrichConsentValidationResponse) {
+		payload := &model.ValidateEnrichHookPayload{ValidateRequest: req, ValidateResponse: *response}
+		if err := extension.Invoke(ctx, extension.EnrichConsentValidationResponse, orgID, payload); err != nil {
+			logger.Warn("Service extension failed to enrich validation response", log.Error(err))
+			return nil, extensionServiceError(err)
+		}
+		response = &payload.ValidateResponse
+	}
+
+	return response, nil
+}
+
+// enrichConsentResponse passes a consent response through an enrich hook and stores the
+// additions returned by the extension in the response's modifiedResponse.
+func (consentService *consentService) enrichConsentResponse(ctx context.Context, hook extension.Hook, orgID string, response *model.ConsentResponse) *serviceerror.ServiceError {
+	if !extension.IsEnabled(hook) {
+		return nil
+	}
+	payload := &model.ConsentEnrichHookPayload{Consent: response.ToAPIResponse(), ModifiedResponse: response.ModifiedResponse}
+	if err := extension.Invoke(ctx, hook, orgID, payload); err != nil {
+		log.GetLogger().WithContext(ctx).Warn("Service extension failed to enrich consent response",
+			log.Error(err), log.String("hook", string(hook)))
+		return extensionServiceError(err)
+	}
+	if payload.ModifiedResponse != nil {
+		if _, err := consentService.encodeModifiedResponse(payload.ModifiedResponse); err != nil {
+			// An invalid document is an invalid extension response, so the hook's failure policy applies
+			log.GetLogger().WithContext(ctx).Warn("Service extension returned an invalid modifiedResponse",
+				log.Error(err), log.String("hook", string(hook)))
+			if !extension.FailsOpen(hook) {
+				return serviceerror.CustomServiceError(serviceerror.InternalServerError, err.Error())
+			}
+			return nil
+		}
+	}
+	response.ModifiedResponse = payload.ModifiedResponse
+	return nil
+}
+
+// extensionServiceError maps a service extension or consent interceptor failure to a service
+// error. Rejections keep the status the extension asked for; anything else means the extension
+// could not be reached or the interceptor failed.
+func extensionServiceError(err error) *serviceerror.ServiceError {
+	var rejected *extension.RejectedError
+	if !errors.As(err, &rejected) {
+		if errors.Is(err, interceptor.ErrFailed) {
+			return serviceerror.CustomServiceError(serviceerror.InternalServerError, "consent interceptor failed")
+		}
+		return serviceerror.CustomServiceError(serviceerror.InternalServerError, "service extension is unavailable")
+	}
+	switch rejected.StatusCode {
+	case 401:
+		return serviceerror.CustomServiceError(serviceerror.UnauthorizedError, rejected.Error())
+	case 404:
+		return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, rejected.Error())
+	case 409:
+		return serviceerror.CustomServiceError(serviceerror.ConflictError, rejected.Error())
+	default:
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, rejected.Error())
+	}
+}
+
+// setExtensionValidateError reports a validation rejected by a consent interceptor or the service
+// extension, or failed because either could not complete, in a validate response
+func setExtensionValidateError(response *model.ValidateResponse, err error) {
+	var rejected *extension.RejectedError
+	switch {
+	case errors.As(err, &rejected):
+		response.ErrorCode = rejected.StatusCode
+		response.ErrorMessage = "extension_rejected"
+		response.ErrorDescription = rejected.Error()
+	case errors.Is(err, interceptor.ErrFailed):
+		response.ErrorCode = 500
+		response.ErrorMessage = "extension_error"
+		response.ErrorDescription = "Consent interceptor failed"
+	default:
+		response.ErrorCode = 500
+		response.ErrorMessage = "extension_error"
+		response.ErrorDescription = "Service extension is unavailable"
+	}
+}
+
+// expireConsent updates consent and all related auth resources to expired status
+func (consentService *consentService) expireConsent(ctx context.Context, consent *model.Consent, orgID string) error {
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Debug("Expiring consent",
+		log.String("consent_id", consent.ConsentID),
+		log.String("org_id", orgID))
+
+	consentCfg := config.Get().Consent.ForOrg(orgID)
+	expiredStatusName := string(consentCfg.GetExpiredConsentStatus())
+	systemExpiredAuthStatus := string(consentCfg.GetSystemExpiredAuthStatus())
+	currentTime := utils.GetCurrentTimeMillis()
+
+	// Create audit entry
+	auditID := utils.GenerateUUID()
+	reason := "Consent expired based on validityTime"
+	actionBy := "SYSTEM"
+	previousStatus := consent.CurrentStatus
+	audit := &model.ConsentStatusAudit{
+		StatusAuditID:  auditID,
+		ConsentID:      consent.ConsentID,
+		CurrentStatus:  expiredStatusName,
+		ActionTime:     currentTime,
+		Reason:         &reason,
+		ActionBy:       &actionBy,
+		PreviousStatus: &previousStatus,
+		OrgID:          orgID,
+	}
+
+	// Get stores for cascading status update
+	consentStore := consentService.stores.Consent
+	authResourceStore := consentService.stores.AuthResource
+
+	// Execute transaction - update consent status, all auth resource statuses, and create audit
+	err := consentService.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.UpdateStatus(tx, consent.ConsentID, orgID, expiredStatusName, currentTime)
+		},
+		func(tx dbmodel.TxInterface) error {
+			// Update all authorization statuses to the system expired status when consent expires
+			return authResourceStore.UpdateAllStatusByConsentID(tx, consent.ConsentID, orgID, systemExpiredAuthStatus, currentTime)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.CreateStatusAudit(tx, audit)
+		},
+		consentService.releaseTokens(consent.ConsentID, orgID),
+		consentService.recordEvent(events.ConsentEvent{
+			ID:             utils.GenerateUUID(),
+			Type:           events.ConsentExpired,
+			Timestamp:      currentTime,
+			OrgID:          orgID,
+			ConsentID:      consent.ConsentID,
+			ClientID:       consent.ClientID,
+			Status:         expiredStatusName,
+			PreviousStatus: previousStatus,
+		}),
+	})
+	if err != nil {
+		logger.Error("Failed to expire consent in transaction",
+			log.Error(err),
+			log.String("consent_id", consent.ConsentID))
+		return err
+	}
+	cache.InvalidateConsentValidation(ctx, orgID, consent.ConsentID)
+
+	// Update local consent object
+	consent.CurrentStatus = expiredStatusName
+	consent.UpdatedTime = currentTime
+
+	logger.Debug("Consent expired successfully",
+		log.String("consent_id", consent.ConsentID),
+		log.String("new_status", expiredStatusName))
+
+	return nil
+}
+
+func (consentService *consentService) EnrichedConsentAPIResponseWithPurposeDetails(ctx context.Context, consent *model.ConsentResponse, orgID string) *model.ConsentAPIResponse {
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Debug("Enriching consent response with purpose details",
+		log.String("consent_id", consent.ConsentID),
+		log.String("org_id", orgID))
+
+	purposeStore := consentService.stores.ConsentPurpose
+
+	if consent == nil {
+		logger.Debug("Consent is nil, returning nil")
+		return nil
+	}
+
+	// Use ToAPIResponse to build the complete base response structure
+	apiResponse := consent.ToAPIResponse()
+
+	// Enrich consent purposes with full purpose details (type, description, attributes). Purpose
+	// definitions and their attributes are fetched in one query each for all purposes.
+	if len(apiResponse.ConsentPurpose) > 0 {
+		names := make([]string, 0, len(apiResponse.ConsentPurpose))
+		for _, cp := range apiResponse.ConsentPurpose {
+			if cp.Name != "" {
+				names = append(names, cp.Name)
+			}
+		}
+
+		purposesByName, err := purposeStore.GetByNames(ctx, names, orgID)
+		if err != nil {
+			logger.Warn("Failed to retrieve purpose definitions", log.Error(err))
+			purposesByName = nil
+		}
+
+		purposeIDs := make([]string, 0, len(purposesByName))
+		for _, purpose := range purposesByName {
+			purposeIDs = append(purposeIDs, purpose.ID)
+		}
+		attributesByPurpose, err := purposeStore.GetAttributesByPurposeIDs(ctx, purposeIDs, orgID)
+		if err != nil {
+			logger.Warn("Failed to retrieve purpose attributes", log.Error(err))
+			attributesByPurpose = nil
+		}
+
+		enrichedPurposes := make([]model.ConsentPurposeItem, 0, len(apiResponse.ConsentPurpose))
+
+		for _, cp := range apiResponse.ConsentPurpose {
+			// Convert base purpose to enriched purpose
+			enrichedPurpose := cp
+
+			if cp.Name != "" {
+				if purpose, found := purposesByName[cp.Name]; found {
+					// Enrich with type, description, and attributes from the purpose definition
+					enrichedPurpose.Type = &purpose.Type
+					enrichedPurpose.Description = purpose.Description
+
+					// Convert []ConsentPurposeAttribute to map[string]interface{}
+					attributes := attributesByPurpose[purpose.ID]
+					attrs := make(map[string]interface{}, len(attributes))
+					for _, attr := range attributes {
+						attrs[attr.Key] = attr.Value
+					}
+					enrichedPurpose.Attributes = attrs
+				} else {
+					// If we can't fetch the purpose, add empty values for enriched fields
+					emptyType := ""
+					emptyDesc := ""
+					enrichedPurpose.Type = &emptyType
+					enrichedPurpose.Description = &emptyDesc
+					enrichedPurpose.Attributes = map[string]interface{}{}
+				}
+			}
+
+			enrichedPurposes = append(enrichedPurposes, enrichedPurpose)
+		}
+
+		// Set enriched purposes
+		apiResponse.ConsentPurpose = enrichedPurposes
+	}
+
+	logger.Debug("Consent response enriched successfully",
+		log.Int("purpose_count", len(apiResponse.ConsentPurpose)))
+
+	return apiResponse
+}
+
+// buildConsentResponse constructs a complete ConsentResponse with related data
+func buildConsentResponse(
+	consent *model.Consent,
+	attributes map[string]string,
+	authResources []authmodel.AuthResource,
+	purposeMappings []purposemodel.ConsentPurposeMapping,
+) *model.ConsentResponse {
+	// Convert purposeMappings to ConsentPurposeItem
+	purposes := make([]model.ConsentPurposeItem, len(purposeMappings))
+	for i, mapping := range purposeMappings {
+		purposes[i] = model.ConsentPurposeItem{
+			Name:            mapping.Name,
+			Value:           mapping.Value,
+			IsUserApproved:  &mapping.IsUserApproved,
+			IsMandatory:     &mapping.IsMandatory,
+			ExpiresAt:       mapping.ExpiresAt,
+			LastConfirmedAt: mapping.LastConfirmedAt,
+		}
+	}
+
+	// AuthResource is already a type alias for ConsentAuthResource - use directly
+	authResourcesResp := authResources
+
+	return &model.ConsentResponse{
+		ConsentID:                  consent.ConsentID,
+		ConsentPurpose:             purposes,
+		CreatedTime:                consent.CreatedTime,
+		UpdatedTime:                consent.UpdatedTime,
+		ClientID:                   consent.ClientID,
+		ConsentType:                consent.ConsentType,
+		CurrentStatus:              consent.CurrentStatus,
+		ConsentFrequency:           consent.ConsentFrequency,
+		ValidityTime:               consent.ValidityTime,
+		RecurringIndicator:         consent.RecurringIndicator,
+		DataAccessValidityDuration: consent.DataAccessValidityDuration,
+		ParentConsentID:            consent.ParentConsentID,
+		Version:                    consent.Version,
+		OrgID:                      consent.OrgID,
+		Attributes:                 attributes,
+		AuthResources:              authResourcesResp,
+	}
+}
+
+// SearchConsentsByAttribute searches for the IDs of consents that have every listed attribute.
+// An attribute filter with an empty value matches any value of its key.
+func (consentService *consentService) SearchConsentsByAttribute(ctx context.Context, attributes []model.AttributeFilter, orgID string) (*model.ConsentAttributeSearchResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.SearchConsentsByAttribute")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Searching consents by attribute",
+		log.Int("attributes_count", len(attributes)),
+		log.String("org_id", orgID))
+
+	if len(attributes) == 0 {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "at least one attribute is required")
+	}
+
+	consentStore := consentService.stores.Consent
+
+	var consentIDs []string
+	var err error
+
+	// A single attribute uses the dedicated lookups; several attributes must all match
+	switch {
+	case len(attributes) > 1:
+		consentIDs, err = consentStore.FindConsentIDsByAttributes(ctx, attributes, orgID)
+	case attributes[0].Value != "":
+		consentIDs, err = consentStore.FindConsentIDsByAttribute(ctx, attributes[0].Key, attributes[0].Value, orgID)
+	default:
+		consentIDs, err = consentStore.FindConsentIDsByAttributeKey(ctx, attributes[0].Key, orgID)
+	}
+
+	if err != nil {
+		logger.Error("Failed to search consents by attribute",
+			log.Error(err),
+			log.Int("attributes_count", len(attributes)))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	logger.Info("Consents searched by attribute successfully",
+		log.Int("count", len(consentIDs)))
+
+	return &model.ConsentAttributeSearchResponse{
+		ConsentIDs: consentIDs,
+		Count:      len(consentIDs),
+	}, nil
+}
+
+// relationshipPageSize is the page size used when collecting all consents of a relationship
+const relationshipPageSize = 100
+
+// GetRelationship builds a summary of all consents a user has granted to a client
+func (consentService *consentService) GetRelationship(ctx context.Context, userID, clientID, orgID string) (*model.RelationshipResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetRelationship")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Building relationship summary",
+		log.String("user_id", userID),
+		log.String("client_id", clientID),
+		log.String("org_id", orgID))
+
+	filters := model.ConsentSearchFilters{
+		UserIDs:   []string{userID},
+		ClientIDs: []string{clientID},
+		Limit:     relationshipPageSize,
+		OrgID:     orgID,
+	}
+
+	// Collect every consent of the relationship so the summary is not skewed by pagination
+	consents := make([]model.ConsentDetailResponse, 0)
+	for {
+		page, serviceErr := consentService.SearchConsentsDetailed(ctx, filters)
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+		consents = append(consents, page.Data...)
+		if !page.Metadata.HasMore {
+			break
+		}
+		filters.Offset += len(page.Data)
+	}
+
+	activeStatus := string(config.Get().Consent.ForOrg(orgID).GetActiveConsentStatus())
+	approvedPurposes := make(map[string]struct{})
+	response := &model.RelationshipResponse{
+		UserID:           userID,
+		ClientID:         clientID,
+		ActiveConsents:   make([]model.ConsentDetailResponse, 0),
+		ApprovedPurposes: make([]string, 0),
+		TotalConsents:    len(consents),
+	}
+
+	for _, consent := range consents {
+		// Last activity covers consents in any status and their authorizations
+		lastActivity := consent.UpdatedTime
+		for _, auth := range consent.Authorizations {
+			if auth.UpdatedTime > lastActivity {
+				lastActivity = auth.UpdatedTime
+			}
+		}
+		if response.LastActivityTime == nil || lastActivity > *response.LastActivityTime {
+			response.LastActivityTime = &lastActivity
+		}
+
+		if consent.Status != activeStatus || validator.IsConsentExpired(consent.ValidityTime) {
+			continue
+		}
+		response.ActiveConsents = append(response.ActiveConsents, consent)
+
+		for _, purpose := range consent.ConsentPurposes {
+			if purpose.IsUserApproved != nil && *purpose.IsUserApproved {
+				approvedPurposes[purpose.Name] = struct{}{}
+			}
+		}
+
+		if consent.ValidityTime > 0 && (response.EarliestExpiry == nil || consent.ValidityTime < *response.EarliestExpiry) {
+			validityTime := consent.ValidityTime
+			response.EarliestExpiry = &validityTime
+		}
+	}
+
+	for name := range approvedPurposes {
+		response.ApprovedPurposes = append(response.ApprovedPurposes, name)
+	}
+	sort.Strings(response.ApprovedPurposes)
+
+	logger.Info("Relationship summary built",
+		log.Int("total_consents", response.TotalConsents),
+		log.Int("active_consents", len(response.ActiveConsents)),
+		log.Int("approved_purposes", len(response.ApprovedPurposes)))
+
+	return response, nil
+}
+
+// ListUserConsents lists the consents in which a user appears as an authorizer, with the user's
+// approval of each consent
+func (consentService *consentService) ListUserConsents(ctx context.Context, userID string, filters model.ConsentSearchFilters) (*model.UserConsentListResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ListUserConsents")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Listing user consents",
+		log.String("user_id", userID),
+		log.String("org_id", filters.OrgID))
+
+	filters.UserIDs = []string{userID}
+	page, serviceErr := consentService.SearchConsentsDetailed(ctx, filters)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	response := &model.UserConsentListResponse{
+		UserID:   userID,
+		Data:     make([]model.UserConsentResponse, 0, len(page.Data)),
+		Metadata: page.Metadata,
+	}
+	for _, consent := range page.Data {
+		userAuthorizations := make([]model.AuthorizationDetail, 0, 1)
+		for _, auth := range consent.Authorizations {
+			if auth.UserID == userID {
+				userAuthorizations = append(userAuthorizations, auth)
+			}
+		}
+		response.Data = append(response.Data, model.UserConsentResponse{
+			ConsentDetailResponse: consent,
+			UserApprovalStatus:    userApprovalStatus(userAuthorizations),
+			UserAuthorizations:    userAuthorizations,
+		})
+	}
+
+	logger.Info("User consents listed", log.Int("count", len(response.Data)))
+	return response, nil
+}
+
+// ExportUserConsents collects every consent of a user for a data subject access request, with the
+// purposes, authorizations, status changes and superseded versions of each
+func (consentService *consentService) ExportUserConsents(ctx context.Context, userID, orgID string) (*model.UserConsentExport, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ExportUserConsents")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Exporting user consents",
+		log.String("user_id", userID),
+		log.String("org_id", orgID))
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	export := &model.UserConsentExport{
+		UserID:        userID,
+		OrgID:         orgID,
+		GeneratedTime: utils.GetCurrentTimeMillis(),
+		Consents:      []model.UserConsentExportEntry{},
+	}
+
+	filters := model.ConsentSearchFilters{
+		OrgID:      orgID,
+		UserIDs:    []string{userID},
+		Limit:      exportPageSize,
+		SkipTotal:  true,
+		CursorMode: true,
+		Includes:   &model.ConsentIncludes{Authorizations: true, Purposes: true, Attributes: true, History: true},
+	}
+	for {
+		page, serviceErr := consentService.SearchConsentsDetailed(ctx, filters)
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+
+		purposeNames := []string{}
+		for _, consent := range page.Data {
+			for _, purpose := range consent.ConsentPurposes {
+				purposeNames = append(purposeNames, purpose.Name)
+			}
+		}
+		purposes, err := consentService.stores.ConsentPurpose.GetByNames(ctx, purposeNames, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve purposes for export", log.Error(err))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+
+		for _, consent := range page.Data {
+			audits, err := consentService.stores.Consent.GetStatusAuditByConsentID(ctx, consent.ID, orgID)
+			if err != nil {
+				logger.Error("Failed to retrieve status audits for export", log.Error(err), log.String("consent_id", consent.ID))
+				return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+			}
+			sort.SliceStable(audits, func(i, j int) bool { return audits[i].ActionTime < audits[j].ActionTime })
+
+			for i, item := range consent.ConsentPurposes {
+				if purpose, ok := purposes[item.Name]; ok {
+					purposeType := purpose.Type
+					consent.ConsentPurposes[i].Type = &purposeType
+					consent.ConsentPurposes[i].Description = purpose.Description
+				}
+			}
+
+			userAuthorizations := make([]model.AuthorizationDetail, 0, 1)
+			for _, auth := range consent.Authorizations {
+				if auth.UserID == userID {
+					userAuthorizations = append(userAuthorizations, auth)
+				}
+			}
+			export.Consents = append(export.Consents, model.UserConsentExportEntry{
+				UserConsentResponse: model.UserConsentResponse{
+					ConsentDetailResponse: consent,
+					UserApprovalStatus:    userApprovalStatus(userAuthorizations),
+					UserAuthorizations:    userAuthorizations,
+				},
+				StatusHistory: audits,
+			})
+		}
+
+		if !page.Metadata.HasMore {
+			break
+		}
+		last := page.Data[len(page.Data)-1]
+		filters.Cursor = &model.SearchCursor{CreatedTime: last.CreatedTime, ConsentID: last.ID}
+	}
+
+	logger.Info("User consents exported", log.Int("count", len(export.Consents)))
+	return export, nil
+}
+
+// userApprovalStatus summarizes a user's authorizations of a consent as a single authorization status
+func userApprovalStatus(authorizations []model.AuthorizationDetail) string {
+	consentConfig := config.Get().Consent
+	approved := string(consentConfig.GetApprovedAuthStatus())
+	rejected := string(consentConfig.GetRejectedAuthStatus())
+
+	pending := ""
+	for _, auth := range authorizations {
+		switch {
+		case strings.EqualFold(auth.Status, rejected):
+			return rejected
+		case !strings.EqualFold(auth.Status, approved) && pending == "":
+			pending = auth.Status
+		}
+	}
+	if pending != "" {
+		return pending
+	}
+	return approved
+}
+
+// buildAmendmentQueries snapshots the current state of a consent and returns the transactional
+// operations that store the snapshot and bump the consent version
+func (consentService *consentService) buildAmendmentQueries(ctx context.Context, existing *model.Consent, amendment *model.ConsentAmendmentRequest, currentTime int64) ([]func(tx dbmodel.TxInterface) error, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+	consentStore := consentService.stores.Consent
+	consentID := existing.ConsentID
+	orgID := existing.OrgID
+
+	if config.Get().Consent.ForOrg(orgID).IsTerminalStatus(config.ConsentStatus(existing.CurrentStatus)) {
+		logger.Warn("Cannot amend consent in terminal status",
+			log.String("consent_id", consentID),
+			log.String("status", existing.CurrentStatus))
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("Consent with ID '%s' is %s and cannot be amended", consentID, existing.CurrentStatus))
+	}
+
+	authResources, err := consentService.stores.AuthResource.GetByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to get authorization resources for snapshot", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	purposeMappings, err := consentService.stores.ConsentPurpose.GetMappingsByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to get purpose mappings for snapshot", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	attributes, err := consentStore.GetAttributesByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to get consent attributes for snapshot", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	attributesMap := make(map[string]string)
+	for _, a := range attributes {
+		attributesMap[a.AttKey] = a.AttValue
+	}
+
+	snapshot := buildConsentResponse(existing, attributesMap, authResources, purposeMappings).ToAPIResponse()
+	snapshot.ModifiedResponse = nil
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		logger.Error("Failed to marshal consent snapshot", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	history := &model.ConsentHistory{
+		ConsentID:   consentID,
+		Version:     existing.Version,
+		Snapshot:    string(snapshotJSON),
+		AmendedTime: currentTime,
+		OrgID:       orgID,
+	}
+	if amendment.AmendedBy != "" {
+		history.AmendedBy = &amendment.AmendedBy
+	}
+	if amendment.Reason != "" {
+		history.Reason = &amendment.Reason
+	}
+
+	logger.Debug("Consent snapshot created",
+		log.String("consent_id", consentID),
+		log.Int("version", existing.Version))
+
+	return []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.IncrementVersion(tx, consentID, orgID, existing.Version)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.CreateHistory(tx, history)
+		},
+	}, nil
+}
+
+// versionSummaries summarizes superseded consent versions, keeping their order
+func versionSummaries(history []model.ConsentHistory) []model.ConsentVersionSummary {
+	versions := make([]model.ConsentVersionSummary, 0, len(history))
+	for _, entry := range history {
+		versions = append(versions, model.ConsentVersionSummary{
+			Version:     entry.Version,
+			AmendedTime: entry.AmendedTime,
+			AmendedBy:   entry.AmendedBy,
+			Reason:      entry.Reason,
+		})
+	}
+	return versions
+}
+
+// GetConsentVersions lists the superseded versions of a consent, newest first
+func (consentService *consentService) GetConsentVersions(ctx context.Context, consentID, orgID string) (*model.ConsentVersionListResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetConsentVersions")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Listing consent versions",
+		log.String("consent_id", consentID),
+		log.String("org_id", orgID))
+
+	consentStore := consentService.stores.Consent
+	consent, err := consentStore.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if consent == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+
+	history, err := consentStore.GetHistoryByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent history", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	versions := versionSummaries(history)
+
+	return &model.ConsentVersionListResponse{
+		ConsentID:      consentID,
+		CurrentVersion: consent.Version,
+		Data:           versions,
+	}, nil
+}
+
+// GetConsentVersion returns a consent as it was at the given version. The current version is
+// served from the live consent, superseded versions from their history snapshot.
+func (consentService *consentService) GetConsentVersion(ctx context.Context, consentID, orgID string, version int) (*model.ConsentVersionResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetConsentVersion")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Retrieving consent version",
+		log.String("consent_id", consentID),
+		log.Int("version", version),
+		log.String("org_id", orgID))
+
+	current, serviceErr := consentService.GetConsent(ctx, consentID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if version == current.Version {
+		snapshot := current.ToAPIResponse()
+		snapshot.ModifiedResponse = nil
+		return &model.ConsentVersionResponse{
+			Version: version,
+			Current: true,
+			Consent: snapshot,
+		}, nil
+	}
+
+	entry, err := consentService.stores.Consent.GetHistoryByVersion(ctx, consentID, orgID, version)
+	if err != nil {
+		logger.Error("Failed to retrieve consent history", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if entry == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("Version %d of consent with ID '%s' not found", version, consentID))
+	}
+
+	var snapshot model.ConsentAPIResponse
+	if err := json.Unmarshal([]byte(entry.Snapshot), &snapshot); err != nil {
+		logger.Error("Failed to parse consent snapshot", log.Error(err), log.Int("version", version))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	amendedTime := entry.AmendedTime
+	return &model.ConsentVersionResponse{
+		Version:     entry.Version,
+		AmendedTime: &amendedTime,
+		AmendedBy:   entry.AmendedBy,
+		Reason:      entry.Reason,
+		Consent:     &snapshot,
+	}, nil
+}
+
+// loadReceiptSigner loads the key that signs consent receipts. Receipts are unavailable when no key
+// is configured or the key cannot be loaded.
+func loadReceiptSigner() *signing.Signer {
+	cfg := config.Get()
+	if cfg == nil || cfg.Consent.Receipt.SigningKeyFile == "" {
+		return nil
+	}
+	receiptCfg := cfg.Consent.Receipt
+	signer, err := signing.LoadSigner(receiptCfg.SigningKeyFile, receiptCfg.KeyID)
+	if err != nil {
+		log.GetLogger().Error("Failed to load consent receipt signing key, receipts are disabled", log.Error(err))
+		return nil
+	}
+	log.GetLogger().Info("Consent receipt signing enabled",
+		log.String("algorithm", signer.Algorithm()),
+		log.String("key_id", signer.KeyID()))
+	return signer
+}
+
+// usagePeriod returns the frequency period of a consent that contains now. Recurring consents are
+// counted per configured period; other consents over their whole lifetime, which starts at 0 and
+// has no end.
+func usagePeriod(consent *model.Consent, now int64) (int64, *int64) {
+	if consent.RecurringIndicator == nil || !*consent.RecurringIndicator {
+		return 0, nil
+	}
+	period := config.Get().Consent.GetUsagePeriod().Milliseconds()
+	start := now - now%period
+	end := start + period
+	return start, &end
+}
+
+// frequencyLimit returns the number of accesses a consent allows per period, or 0 when it has no limit
+func frequencyLimit(consent *model.Consent) int {
+	if consent.ConsentFrequency == nil || *consent.ConsentFrequency <= 0 {
+		return 0
+	}
+	return *consent.ConsentFrequency
+}
+
+// recordConsentUsage counts a validated access to a consent and fails the validation when the
+// consent frequency is exhausted for the current period
+func (consentService *consentService) recordConsentUsage(ctx context.Context, consent *model.Consent, orgID string, response *model.ValidateResponse) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	now := utils.GetCurrentTimeMillis()
+	periodStart, _ := usagePeriod(consent, now)
+	limit := frequencyLimit(consent)
+	storeLimit := limit
+	if storeLimit == 0 {
+		storeLimit = math.MaxInt32
+	}
+
+	counted, err := consentService.stores.Consent.RecordUsage(ctx, consent.ConsentID, orgID, periodStart, storeLimit, now)
+	if err != nil {
+		logger.Error("Failed to record consent usage", log.Error(err), log.String("consent_id", consent.ConsentID))
+		response.IsValid = false
+		response.ErrorCode = 500
+		response.ErrorMessage = "database_error"
+		response.ErrorDescription = "Database error while recording consent usage"
+		return
+	}
+	if !counted {
+		logger.Warn("Consent frequency limit exceeded",
+			log.String("consent_id", consent.ConsentID),
+			log.Int("frequency", limit))
+		response.IsValid = false
+		response.ErrorCode = 429
+		response.ErrorMessage = "frequency_limit_exceeded"
+		response.ErrorDescription = fmt.Sprintf("Consent allows %d accesses per frequency period and the limit has been reached", limit)
+	}
+}
+
+// GetConsentUsage returns how often a consent has been accessed in its current frequency period
+func (consentService *consentService) GetConsentUsage(ctx context.Context, consentID, orgID string) (*model.ConsentUsageResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetConsentUsage")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	consent, err := consentService.stores.Consent.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if consent == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+
+	periodStart, periodEnd := usagePeriod(consent, utils.GetCurrentTimeMillis())
+	usage, err := consentService.stores.Consent.GetUsage(ctx, consentID, orgID, periodStart)
+	if err != nil {
+		logger.Error("Failed to retrieve consent usage", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	response := &model.ConsentUsageResponse{
+		ConsentID:          consentID,
+		Frequency:          consent.ConsentFrequency,
+		RecurringIndicator: consent.RecurringIndicator != nil && *consent.RecurringIndicator,
+		PeriodStart:        periodStart,
+		PeriodEnd:          periodEnd,
+	}
+	if usage != nil {
+		response.AccessCount = usage.AccessCount
+		response.LastAccessTime = &usage.LastAccessTime
+	}
+	if limit := frequencyLimit(consent); limit > 0 {
+		remaining := max(limit-response.AccessCount, 0)
+		response.Remaining = &remaining
+	}
+	return response, nil
+}
+
+// GetRequiredAuthorizers reports the required authorizer roles of a consent and which of them
+// have yet to approve it. Consents created without required authorizers report none.
+func (consentService *consentService) GetRequiredAuthorizers(ctx context.Context, consentID, orgID string) (*model.RequiredAuthorizersResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetRequiredAuthorizers")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	consent, err := consentService.stores.Consent.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if consent == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+
+	requiredAuthorizers, err := consentService.stores.Consent.GetRequiredAuthorizers(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve required authorizers", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	authResources, err := consentService.stores.AuthResource.GetByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve authorizations", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	authTypes := make([]string, 0, len(authResources))
+	authStatuses := make([]string, 0, len(authResources))
+	for _, ar := range authResources {
+		authTypes = append(authTypes, ar.AuthType)
+		authStatuses = append(authStatuses, ar.AuthStatus)
+	}
+	pending := validator.PendingAuthorizers(orgID, requiredAuthorizers, authTypes, authStatuses)
+
+	response := &model.RequiredAuthorizersResponse{
+		ConsentID:     consentID,
+		ConsentStatus: consent.CurrentStatus,
+		Authorizers:   make([]model.RequiredAuthorizer, 0, len(requiredAuthorizers)),
+		Pending:       pending,
+	}
+	for _, role := range requiredAuthorizers {
+		authorizer := model.RequiredAuthorizer{
+			Role:             role,
+			Status:           model.AuthorizerStatusApproved,
+			AuthorizationIDs: []string{},
+		}
+		if slices.Contains(pending, role) {
+			authorizer.Status = model.AuthorizerStatusPending
+		}
+		for _, ar := range authResources {
+			if ar.AuthType == role {
+				authorizer.AuthorizationIDs = append(authorizer.AuthorizationIDs, ar.AuthID)
+			}
+		}
+		response.Authorizers = append(response.Authorizers, authorizer)
+	}
+	return response, nil
+}
+
+// LockConsent locks a consent for the caller while its user is authorizing it, so other callers
+// cannot change it until the lock is released or expires. A caller presenting the token of the
+// current lock renews it.
+func (consentService *consentService) LockConsent(ctx context.Context, consentID, orgID, clientID string) (*model.ConsentLock, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.LockConsent")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	existing, err := consentService.stores.Consent.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil || existing.CurrentStatus == model.DeletedConsentStatus {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+
+	// The holder of the current lock renews it with the same token; everyone else gets a new token
+	current, err := consentService.stores.Consent.GetLock(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent lock", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	currentTime := utils.GetCurrentTimeMillis()
+	lockToken := utils.GenerateUUID()
+	if current.IsActive(currentTime) && current.LockToken == consentlock.TokenFromContext(ctx) {
+		lockToken = current.LockToken
+	}
+	lock := &model.ConsentLock{
+		ConsentID:  consentID,
+		LockToken:  lockToken,
+		LockedBy:   clientID,
+		LockedTime: currentTime,
+		ExpiryTime: currentTime + config.Get().Consent.Lock.GetTTL().Milliseconds(),
+		OrgID:      orgID,
+	}
+	acquired, err := consentService.stores.Consent.AcquireLock(ctx, lock)
+	if err != nil {
+		logger.Error("Failed to lock consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if !acquired {
+		logger.Warn("Consent is locked by another caller", log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.ConsentLockedError,
+			fmt.Sprintf("Consent with ID '%s' is locked by another caller", consentID))
+	}
+
+	opaudit.AddDetail(ctx, "expiryTime", lock.ExpiryTime)
+
+	logger.Info("Consent locked",
+		log.String("consent_id", consentID),
+		log.String("client_id", clientID),
+		log.Any("expiry_time", lock.ExpiryTime))
+	return lock, nil
+}
+
+// UnlockConsent releases the lock of a consent. The caller must present the lock's token; releasing
+// a consent that is not locked succeeds.
+func (consentService *consentService) UnlockConsent(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "consent.UnlockConsent")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	existing, err := consentService.stores.Consent.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+	if serviceErr := consentService.checkConsentLock(ctx, consentID, orgID); serviceErr != nil {
+		return serviceErr
+	}
+
+	if _, err := consentService.stores.Consent.ReleaseLock(ctx, consentID, orgID, consentlock.TokenFromContext(ctx)); err != nil {
+		logger.Error("Failed to unlock consent", log.Error(err), log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	logger.Info("Consent unlocked", log.String("consent_id", consentID))
+	return nil
+}
+
+// checkConsentLock rejects changes to a consent while another caller holds its lock
+func (consentService *consentService) checkConsentLock(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError {
+	lock, err := consentService.stores.Consent.GetLock(ctx, consentID, orgID)
+	if err != nil {
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve consent lock: %v", err))
+	}
+	if lock.Blocks(consentlock.TokenFromContext(ctx), utils.GetCurrentTimeMillis()) {
+		return serviceerror.CustomServiceError(serviceerror.ConsentLockedError,
+			fmt.Sprintf("Consent with ID '%s' is locked by another caller", consentID))
+	}
+	return nil
+}
+
+// GetConsentReceipt issues a signed receipt for a consent, describing the purposes the user
+// consented to, the PII controller and when and how consent was collected
+func (consentService *consentService) GetConsentReceipt(ctx context.Context, consentID, orgID string) (*model.ConsentReceiptResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetConsentReceipt")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if consentService.receiptSigner == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Consent receipts are not enabled")
+	}
+
+	consent, serviceErr := consentService.GetConsent(ctx, consentID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	enriched := consentService.EnrichedConsentAPIResponseWithPurposeDetails(ctx, consent, orgID)
+
+	receiptCfg := config.Get().Consent.Receipt
+	controller := receiptCfg.GetController(orgID)
+
+	principalID := ""
+	for _, auth := range enriched.Authorizations {
+		if auth.UserID != nil && *auth.UserID != "" {
+			principalID = *auth.UserID
+			break
+		}
+	}
+
+	var expiresAt *int64
+	termination := "Until revoked by the PII principal"
+	if consent.ValidityTime != nil && *consent.ValidityTime > 0 {
+		expiry := toEpochSeconds(*consent.ValidityTime)
+		expiresAt = &expiry
+		termination = "Expires at " + time.Unix(expiry, 0).UTC().Format(time.RFC3339) + " unless revoked earlier"
+	}
+
+	purposes := make([]model.ReceiptPurpose, 0, len(enriched.ConsentPurpose))
+	for _, item := range enriched.ConsentPurpose {
+		purpose := model.ReceiptPurpose{
+			Purpose:              item.Name,
+			PurposeCategory:      []string{},
+			ConsentType:          "IMPLICIT",
+			PiiCategory:          receiptPiiCategories(item.Attributes),
+			PrimaryPurpose:       item.IsMandatory == nil || *item.IsMandatory,
+			Termination:          termination,
+			ThirdPartyDisclosure: consent.ClientID != "",
+			ThirdPartyName:       consent.ClientID,
+		}
+		if item.Description != nil && *item.Description != "" {
+			purpose.Purpose = *item.Description
+		}
+		if item.Type != nil && *item.Type != "" {
+			purpose.PurposeCategory = []string{*item.Type}
+		}
+		if item.IsUserApproved != nil && *item.IsUserApproved {
+			purpose.ConsentType = "EXPLICIT"
+		}
+		purposes = append(purposes, purpose)
+	}
+
+	evidence, err := consentService.stores.Consent.GetEvidenceByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent evidence", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	receipt := model.ConsentReceipt{
+		Version:          model.ConsentReceiptVersion,
+		Jurisdiction:     receiptCfg.Jurisdiction,
+		ConsentTimestamp: toEpochSeconds(consent.CreatedTime),
+		CollectionMethod: receiptCfg.CollectionMethod,
+		ConsentReceiptID: utils.GenerateUUID(),
+		PublicKey:        consentService.receiptSigner.PublicKeyPEM(),
+		Language:         receiptCfg.Language,
+		PiiPrincipalID:   principalID,
+		PiiControllers: []model.ReceiptPiiController{{
+			PiiController:    controller.Name,
+			Contact:          controller.Contact,
+			Address:          controller.Address,
+			Email:            controller.Email,
+			Phone:            controller.Phone,
+			PiiControllerURL: controller.URL,
+		}},
+		PolicyURL: receiptCfg.PolicyURL,
+		Services: []model.ReceiptService{{
+			Service:  consent.ConsentType,
+			Purposes: purposes,
+		}},
+		SpiCat:         []string{},
+		ConsentID:      consent.ConsentID,
+		ConsentVersion: consent.Version,
+		ConsentStatus:  consent.CurrentStatus,
+		IssuedAt:       toEpochSeconds(utils.GetCurrentTimeMillis()),
+		ExpiresAt:      expiresAt,
+		Evidence:       evidence,
+	}
+
+	payload, err := json.Marshal(receipt)
+	if err != nil {
+		logger.Error("Failed to encode consent receipt", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to encode consent receipt")
+	}
+	jws, err := consentService.receiptSigner.SignJWS(payload, "JSON")
+	if err != nil {
+		logger.Error("Failed to sign consent receipt", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to sign consent receipt")
+	}
+
+	logger.Info("Consent receipt issued",
+		log.String("consent_id", consentID),
+		log.String("receipt_id", receipt.ConsentReceiptID))
+
+	return &model.ConsentReceiptResponse{Receipt: receipt, JWS: jws}, nil
+}
+
+// receiptPiiCategories reads the PII categories of a purpose from its piiCategory attribute, given
+// either as a list or as a comma separated string
+func receiptPiiCategories(attributes map[string]interface{}) []string {
+	categories := []string{}
+	switch value := attributes["piiCategory"].(type) {
+	case string:
+		for _, category := range strings.Split(value, ",") {
+			if category = strings.TrimSpace(category); category != "" {
+				categories = append(categories, category)
+			}
+		}
+	case []interface{}:
+		for _, category := range value {
+			if str, ok := category.(string); ok && str != "" {
+				categories = append(categories, str)
+			}
+		}
+	}
+	return categories
+}
+
+// toEpochSeconds converts a timestamp stored in seconds or milliseconds to seconds
+func toEpochSeconds(timestamp int64) int64 {
+	if timestamp >= 100000000000 { // 10^11, see validator.IsConsentExpired
+		return timestamp / 1000
+	}
+	return timestamp
+}
+
+// TransferOwnership moves the user binding of a user's authorizations to another user. Each
+// consent is snapshotted into its history and versioned, a status audit records the transfer and
+// an ownership transfer event is emitted. Consents in a terminal status stay with the original
+// user so that their history is unchanged. All consents are transferred in a single transaction.
+func (consentService *consentService) TransferOwnership(ctx context.Context, req model.OwnershipTransferRequest, orgID string) (*model.OwnershipTransferResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.TransferOwnership")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Transferring consent ownership",
+		log.String("org_id", orgID),
+		log.String("from_user_id", req.FromUserID),
+		log.String("to_user_id", req.ToUserID))
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if req.FromUserID == "" || req.ToUserID == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "fromUserId and toUserId are required")
+	}
+	if req.FromUserID == req.ToUserID {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "fromUserId and toUserId must differ")
+	}
+	if req.ActionBy == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "actionBy is required")
+	}
+
+	consentCfg := config.Get().Consent.ForOrg(orgID)
+	policy := consentCfg.OwnershipTransfer.GetPolicy(orgID)
+	if policy == nil {
+		logger.Warn("Consent ownership transfer is not allowed for organization", log.String("org_id", orgID))
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+			fmt.Sprintf("Consent ownership transfer is not allowed for organization '%s'", orgID))
+	}
+	if policy.RequireReason && strings.TrimSpace(req.Reason) == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "reason is required for consent ownership transfers")
+	}
+
+	authResources, err := consentService.stores.AuthResource.GetByUserID(ctx, req.FromUserID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve authorizations of user", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	// Group the user's authorizations by consent, keeping the order in which consents appear
+	consentIDs := []string{}
+	authsByConsent := make(map[string][]authmodel.AuthResource)
+	for _, authResource := range authResources {
+		if _, seen := authsByConsent[authResource.ConsentID]; !seen {
+			consentIDs = append(consentIDs, authResource.ConsentID)
+		}
+		authsByConsent[authResource.ConsentID] = append(authsByConsent[authResource.ConsentID], authResource)
+	}
+	if len(req.ConsentIDs) > 0 {
+		for _, consentID := range req.ConsentIDs {
+			if _, ok := authsByConsent[consentID]; !ok {
+				return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+					fmt.Sprintf("Consent with ID '%s' has no authorizations for user '%s'", consentID, req.FromUserID))
+			}
+		}
+		consentIDs = req.ConsentIDs
+	}
+
+	currentTime := utils.GetCurrentTimeMillis()
+	reason := fmt.Sprintf("Ownership transferred from '%s' to '%s'", req.FromUserID, req.ToUserID)
+	if req.Reason != "" {
+		reason += ": " + req.Reason
+	}
+
+	response := &model.OwnershipTransferResponse{
+		FromUserID:      req.FromUserID,
+		ToUserID:        req.ToUserID,
+		TransferredTime: currentTime,
+		Transferred:     []model.TransferredConsent{},
+		Skipped:         []model.SkippedTransferConsent{},
+	}
+	transferredConsents := []*model.Consent{}
+
+	consentStore := consentService.stores.Consent
+	authResourceStore := consentService.stores.AuthResource
+	queries := []func(tx dbmodel.TxInterface) error{}
+	for _, consentID := range consentIDs {
+		existing, err := consentStore.GetByID(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		if existing == nil {
+			continue
+		}
+		if consentCfg.IsTerminalStatus(config.ConsentStatus(existing.CurrentStatus)) {
+			response.Skipped = append(response.Skipped, model.SkippedTransferConsent{
+				ConsentID: consentID,
+				Status:    existing.CurrentStatus,
+				Reason:    "consent is in a terminal status",
+			})
+			continue
+		}
+
+		// Keep the version before the transfer in the consent history
+		historyQueries, serviceErr := consentService.buildAmendmentQueries(ctx, existing,
+			&model.ConsentAmendmentRequest{AmendedBy: req.ActionBy, Reason: reason}, currentTime)
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+		queries = append(queries, historyQueries...)
+
+		authIDs := make([]string, 0, len(authsByConsent[consentID]))
+		for _, authResource := range authsByConsent[consentID] {
+			updated := authResource
+			updated.UserID = &req.ToUserID
+			updated.UpdatedTime = currentTime
+			queries = append(queries, func(tx dbmodel.TxInterface) error {
+				return authResourceStore.Update(tx, &updated)
+			})
+			authIDs = append(authIDs, authResource.AuthID)
+		}
+
+		status := existing.CurrentStatus
+		actionBy := req.ActionBy
+		auditReason := reason
+		audit := &model.ConsentStatusAudit{
+			StatusAuditID:  utils.GenerateUUID(),
+			ConsentID:      consentID,
+			CurrentStatus:  status,
+			ActionTime:     currentTime,
+			Reason:         &auditReason,
+			ActionBy:       &actionBy,
+			PreviousStatus: &status,
+			OrgID:          orgID,
+		}
+		queries = append(queries,
+			func(tx dbmodel.TxInterface) error {
+				return consentStore.UpdateStatus(tx, consentID, orgID, status, currentTime)
+			},
+			func(tx dbmodel.TxInterface) error {
+				return consentStore.CreateStatusAudit(tx, audit)
+			},
+			consentService.recordEvent(events.ConsentEvent{
+				ID:             utils.GenerateUUID(),
+				Type:           events.ConsentOwnershipTransferred,
+				Timestamp:      currentTime,
+				OrgID:          orgID,
+				ConsentID:      consentID,
+				ClientID:       existing.ClientID,
+				Status:         status,
+				UserID:         req.ToUserID,
+				PreviousUserID: req.FromUserID,
+			}))
+
+		response.Transferred = append(response.Transferred, model.TransferredConsent{
+			ConsentID:        consentID,
+			Version:          existing.Version + 1,
+			AuthorizationIDs: authIDs,
+		})
+		transferredConsents = append(transferredConsents, existing)
+	}
+
+	if len(queries) > 0 {
+		if err := consentService.stores.ExecuteTransaction(ctx, queries); err != nil {
+			if errors.Is(err, ErrConsentVersionConflict) {
+				return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+					"A consent was modified concurrently, retry the transfer")
+			}
+			logger.Error("Failed to transfer consent ownership", log.Error(err))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+	}
+
+	transferredIDs := make([]string, 0, len(transferredConsents))
+	for _, consent := range transferredConsents {
+		cache.InvalidateConsentValidation(ctx, orgID, consent.ConsentID)
+		transferredIDs = append(transferredIDs, consent.ConsentID)
+	}
+	opaudit.AddDetail(ctx, "fromUserId", req.FromUserID)
+	opaudit.AddDetail(ctx, "toUserId", req.ToUserID)
+	opaudit.AddDetail(ctx, "consentIds", transferredIDs)
+
+	logger.Info("Consent ownership transferred",
+		log.String("from_user_id", req.FromUserID),
+		log.String("to_user_id", req.ToUserID),
+		log.Int("transferred", len(response.Transferred)),
+		log.Int("skipped", len(response.Skipped)))
+
+	return response, nil
+}
+
+// EraseUser removes a user from the consents of an organization for a right-to-erasure request.
+// The user ID is replaced with a pseudonym in authorizations, status audits and history, and the
+// configured identifying attributes are removed from the user's consents and their history, as are
+// the IP addresses and user agents of their evidence, so the consents stay usable for reporting. The report is signed when receipt signing is configured.
+func (consentService *consentService) EraseUser(ctx context.Context, userID, orgID string) (*model.UserErasureResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.EraseUser")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if userID == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "userId is required")
+	}
+	if len(userID) > 255 {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "userId must not exceed 255 characters")
+	}
+
+	userIDHash := sha256.Sum256([]byte(userID))
+	report := model.UserErasureReport{
+		ErasureID:  utils.GenerateUUID(),
+		OrgID:      orgID,
+		UserIDHash: hex.EncodeToString(userIDHash[:]),
+		Pseudonym:  "erased-" + utils.GenerateUUID(),
+		ErasedTime: utils.GetCurrentTimeMillis(),
+		Consents:   []model.ErasedConsent{},
+	}
+	logger.Info("Erasing user from consents",
+		log.String("org_id", orgID),
+		log.String("erasure_id", report.ErasureID))
+
+	authResources, err := consentService.stores.AuthResource.GetByUserID(ctx, userID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve authorizations of user", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	// Group the user's authorizations by consent, keeping the order in which consents appear
+	consentIDs := []string{}
+	authIDsByConsent := make(map[string][]string)
+	for _, authResource := range authResources {
+		if _, seen := authIDsByConsent[authResource.ConsentID]; !seen {
+			consentIDs = append(consentIDs, authResource.ConsentID)
+		}
+		authIDsByConsent[authResource.ConsentID] = append(authIDsByConsent[authResource.ConsentID], authResource.AuthID)
+	}
+
+	consentStore := consentService.stores.Consent
+	attributesByConsent, err := consentStore.GetAttributesByConsentIDs(ctx, consentIDs, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent attributes", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	identifyingKeys := config.Get().Consent.ForOrg(orgID).Erasure.IdentifyingAttributes
+	queries := []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return consentService.stores.AuthResource.ReplaceUserID(tx, userID, report.Pseudonym, orgID, report.ErasedTime)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.PseudonymizeUser(tx, userID, report.Pseudonym, orgID)
+		},
+	}
+	for _, consentID := range consentIDs {
+		removed := []string{}
+		for _, key := range identifyingKeys {
+			if _, ok := attributesByConsent[consentID][key]; ok {
+				removed = append(removed, key)
+			}
+		}
+		if len(removed) > 0 {
+			queries = append(queries, func(tx dbmodel.TxInterface) error {
+				return consentStore.DeleteAttributesByKeys(tx, consentID, orgID, removed)
+			})
+		}
+
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return consentStore.AnonymizeEvidence(tx, consentID, orgID)
+		})
+
+		// Superseded versions keep the user ID and attributes in their snapshots
+		history, err := consentStore.GetHistoryByConsentID(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve consent history", log.Error(err), log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		for _, entry := range history {
+			version, err := consentStore.GetHistoryByVersion(ctx, consentID, orgID, entry.Version)
+			if err != nil {
+				logger.Error("Failed to retrieve consent version", log.Error(err), log.String("consent_id", consentID))
+				return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+			}
+			if version == nil {
+				continue
+			}
+			snapshot, err := eraseUserFromSnapshot(version.Snapshot, userID, report.Pseudonym, identifyingKeys)
+			if err != nil {
+				logger.Error("Failed to erase user from consent version", log.Error(err),
+					log.String("consent_id", consentID), log.Int("version", entry.Version))
+				return nil, serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to erase user from consent history")
+			}
+			versionNumber := entry.Version
+			queries = append(queries, func(tx dbmodel.TxInterface) error {
+				return consentStore.UpdateHistorySnapshot(tx, consentID, orgID, versionNumber, snapshot)
+			})
+		}
+
+		report.Consents = append(report.Consents, model.ErasedConsent{
+			ConsentID:         consentID,
+			AuthorizationIDs:  authIDsByConsent[consentID],
+			RemovedAttributes: removed,
+		})
+	}
+
+	if err := consentService.stores.ExecuteTransaction(ctx, queries); err != nil {
+		logger.Error("Failed to erase user", log.Error(err), log.String("erasure_id", report.ErasureID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	for _, consentID := range consentIDs {
+		cache.InvalidateConsentValidation(ctx, orgID, consentID)
+	}
+	opaudit.AddDetail(ctx, "erasureId", report.ErasureID)
+	opaudit.AddDetail(ctx, "consentIds", consentIDs)
+
+	response := &model.UserErasureResponse{Report: report}
+	if consentService.receiptSigner != nil {
+		payload, err := json.Marshal(report)
+		if err != nil {
+			logger.Error("Failed to encode erasure report", log.Error(err), log.String("erasure_id", report.ErasureID))
+			return nil, serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to encode erasure report")
+		}
+		if response.JWS, err = consentService.receiptSigner.SignJWS(payload, "JSON"); err != nil {
+			logger.Error("Failed to sign erasure report", log.Error(err), log.String("erasure_id", report.ErasureID))
+			return nil, serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to sign erasure report")
+		}
+	}
+
+	logger.Info("User erased from consents",
+		log.String("erasure_id", report.ErasureID),
+		log.Int("consents", len(report.Consents)))
+
+	return response, nil
+}
+
+// eraseUserFromSnapshot replaces the user ID in the authorizations of a consent version snapshot
+// with the pseudonym and removes the identifying attributes. Other fields are kept as stored.
+func eraseUserFromSnapshot(snapshot, userID, pseudonym string, identifyingKeys []string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(snapshot), &fields); err != nil {
+		return "", err
+	}
+
+	if raw, ok := fields["attributes"]; ok && len(identifyingKeys) > 0 {
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &attributes); err != nil {
+			return "", err
+		}
+		for _, key := range identifyingKeys {
+			delete(attributes, key)
+		}
+		encoded, err := json.Marshal(attributes)
+		if err != nil {
+			return "", err
+		}
+		fields["attributes"] = encoded
+	}
+
+	if raw, ok := fields["authorizations"]; ok {
+		var authorizations []map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &authorizations); err != nil {
+			return "", err
+		}
+		for _, authorization := range authorizations {
+			var authUserID string
+			if err := json.Unmarshal(authorization["userId"], &authUserID); err == nil && authUserID == userID {
+				authorization["userId"], _ = json.Marshal(pseudonym)
+			}
+		}
+		encoded, err := json.Marshal(authorizations)
+		if err != nil {
+			return "", err
+		}
+		fields["authorizations"] = encoded
+	}
+
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// GetUserPreferences aggregates the purposes of a user's consents into one choice per purpose.
+// Consents in a terminal status are left out, since their purposes can no longer change.
+func (consentService *consentService) GetUserPreferences(ctx context.Context, userID, orgID string) (*model.UserPreferencesResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetUserPreferences")
+	defer span.End()
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	consents, mappings, serviceErr := consentService.preferenceMappings(ctx, userID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	return buildUserPreferences(userID, consents, mappings), nil
+}
+
+// UpdateUserPreferences opts a user in to or out of purposes in every consent of the user linking
+// them. Each changed purpose mapping records the update time as its confirmation time. Each changed
+// consent gets a new version, keeping the previous one in its history, has its status derived
+// again and emits a consent.updated event. Mandatory purposes cannot be opted out of; the consent
+// has to be revoked instead.
+func (consentService *consentService) UpdateUserPreferences(ctx context.Context, userID, orgID string, req model.UserPreferencesUpdateRequest) (*model.UserPreferencesResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.UpdateUserPreferences")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if len(req.Preferences) == 0 {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "preferences must not be empty")
+	}
+	optedIn := make(map[string]bool, len(req.Preferences))
+	for _, preference := range req.Preferences {
+		if strings.TrimSpace(preference.PurposeName) == "" {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "purposeName is required")
+		}
+		if preference.OptedIn == nil {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+				fmt.Sprintf("optedIn is required for purpose '%s'", preference.PurposeName))
+		}
+		if _, duplicate := optedIn[preference.PurposeName]; duplicate {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+				fmt.Sprintf("purpose '%s' is listed more than once", preference.PurposeName))
+		}
+		optedIn[preference.PurposeName] = *preference.OptedIn
+	}
+
+	consents, mappings, serviceErr := consentService.preferenceMappings(ctx, userID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	linked := make(map[string]bool, len(mappings))
+	for _, mapping := range mappings {
+		linked[mapping.Name] = true
+		if approve, requested := optedIn[mapping.Name]; requested && !approve && mapping.IsMandatory {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+				fmt.Sprintf("purpose '%s' is mandatory for consent '%s' and cannot be opted out of; revoke the consent instead",
+					mapping.Name, mapping.ConsentID))
+		}
+	}
+	for _, preference := range req.Preferences {
+		if !linked[preference.PurposeName] {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+				fmt.Sprintf("purpose '%s' is not linked to any consent of the user", preference.PurposeName))
+		}
+	}
+
+	// Only mappings whose approval changes are written, so repeating an update changes nothing
+	consentStore := consentService.stores.Consent
+	purposeStore := consentService.stores.ConsentPurpose
+	currentTime := utils.GetCurrentTimeMillis()
+	queries := []func(tx dbmodel.TxInterface) error{}
+	changedConsentIDs := []string{}
+	var optedInNames, optedOutNames []string
+	for _, mapping := range mappings {
+		approve, requested := optedIn[mapping.Name]
+		if !requested || mapping.IsUserApproved == approve {
+			continue
+		}
+		if !slices.Contains(changedConsentIDs, mapping.ConsentID) {
+			changedConsentIDs = append(changedConsentIDs, mapping.ConsentID)
+		}
+		if approve && !slices.Contains(optedInNames, mapping.Name) {
+			optedInNames = append(optedInNames, mapping.Name)
+		} else if !approve && !slices.Contains(optedOutNames, mapping.Name) {
+			optedOutNames = append(optedOutNames, mapping.Name)
+		}
+		consentID, purposeID := mapping.ConsentID, mapping.PurposeID
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return purposeStore.UpdateMappingApproval(tx, consentID, purposeID, orgID, approve, currentTime)
+		})
+	}
+
+	if len(changedConsentIDs) > 0 {
+		for _, consentID := range changedConsentIDs {
+			if serviceErr := consentService.checkConsentLock(ctx, consentID, orgID); serviceErr != nil {
+				return nil, serviceErr
+			}
+		}
+
+		attributesByConsent, err := consentStore.GetAttributesByConsentIDs(ctx, changedConsentIDs, orgID)
+		if err != nil {
+			logger.Error("Failed to retrieve consent attributes", log.Error(err))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		for i := range consents {
+			consent := &consents[i]
+			if !slices.Contains(changedConsentIDs, consent.ConsentID) {
+				continue
+			}
+
+			// Keep the version before the update in the consent history
+			historyQueries, serviceErr := consentService.buildAmendmentQueries(ctx, consent,
+				&model.ConsentAmendmentRequest{AmendedBy: userID, Reason: "Purpose preferences updated by the user"}, currentTime)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			queries = append(queries, historyQueries...)
+
+			consentID, previousStatus := consent.ConsentID, consent.CurrentStatus
+			newStatus, serviceErr := consentService.derivePreferenceStatus(ctx, consent, mappings, optedIn)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			queries = append(queries, func(tx dbmodel.TxInterface) error {
+				return consentStore.UpdateStatus(tx, consentID, orgID, newStatus, currentTime)
+			})
+			if newStatus != previousStatus {
+				actionBy := userID
+				reason := "Consent status derived again after the user updated purpose preferences"
+				audit := &model.ConsentStatusAudit{
+					StatusAuditID:  utils.GenerateUUID(),
+					ConsentID:      consentID,
+					CurrentStatus:  newStatus,
+					ActionTime:     currentTime,
+					Reason:         &reason,
+					ActionBy:       &actionBy,
+					PreviousStatus: &previousStatus,
+					OrgID:          orgID,
+				}
+				queries = append(queries, func(tx dbmodel.TxInterface) error {
+					return consentStore.CreateStatusAudit(tx, audit)
+				})
+			}
+
+			queries = append(queries, consentService.recordEvent(events.ConsentEvent{
+				ID:         utils.GenerateUUID(),
+				Type:       events.ConsentUpdated,
+				Timestamp:  currentTime,
+				OrgID:      orgID,
+				ConsentID:  consentID,
+				ClientID:   consent.ClientID,
+				Status:     newStatus,
+				Attributes: attributesByConsent[consentID],
+			}))
+		}
+
+		if err := consentService.stores.ExecuteTransaction(ctx, queries); err != nil {
+			if errors.Is(err, ErrConsentVersionConflict) {
+				return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+					"A consent was modified concurrently, retry the update")
+			}
+			logger.Error("Failed to update user preferences", log.Error(err), log.String("org_id", orgID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+		for _, consentID := range changedConsentIDs {
+			cache.InvalidateConsentValidation(ctx, orgID, consentID)
+		}
+	}
+
+	auditChanges(ctx, "preferences", "optedIn", optedInNames)
+	auditChanges(ctx, "preferences", "optedOut", optedOutNames)
+	opaudit.AddDetail(ctx, "consentIds", changedConsentIDs)
+
+	logger.Info("User preferences updated",
+		log.String("org_id", orgID),
+		log.Int("purposes", len(req.Preferences)),
+		log.Int("consents", len(changedConsentIDs)))
+
+	consents, mappings, serviceErr = consentService.preferenceMappings(ctx, userID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	return buildUserPreferences(userID, consents, mappings), nil
+}
+
+// derivePreferenceStatus derives the status of a consent from its authorizations once its purpose
+// mappings carry the approvals of optedIn. With the purpose_enforcement flag, a consent is only
+// activated once the user approved every mandatory purpose; a consent that is already active keeps
+// its status, like an update that does not activate it. A status the state machine does not allow
+// to move to leaves the status unchanged.
+func (consentService *consentService) derivePreferenceStatus(ctx context.Context, consent *model.Consent,
+	mappings []purposemodel.ConsentPurposeMapping, optedIn map[string]bool) (string, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+	consentID, orgID := consent.ConsentID, consent.OrgID
+
+	authResources, err := consentService.stores.AuthResource.GetByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve authorizations", log.Error(err), log.String("consent_id", consentID))
+		return "", serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	authTypes := make([]string, 0, len(authResources))
+	authStatuses := make([]string, 0, len(authResources))
+	for _, authResource := range authResources {
+		authTypes = append(authTypes, authResource.AuthType)
+		authStatuses = append(authStatuses, authResource.AuthStatus)
+	}
+	requiredAuthorizers, err := consentService.stores.Consent.GetRequiredAuthorizers(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve required authorizers", log.Error(err), log.String("consent_id", consentID))
+		return "", serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	previousStatus := consent.CurrentStatus
+	pending := validator.PendingAuthorizers(orgID, requiredAuthorizers, authTypes, authStatuses)
+	derived := validator.KeepSuspended(orgID, previousStatus, validator.KeepAwaitingReauthorization(orgID, previousStatus,
+		validator.ApplyRequiredAuthorizers(orgID,
+			validator.EvaluateConsentStatusFromAuthStatuses(orgID, consent.ConsentType, authStatuses), pending, authStatuses)))
+	if derived == previousStatus {
+		return previousStatus, nil
+	}
+
+	if derived == string(config.Get().Consent.ForOrg(orgID).GetActiveConsentStatus()) &&
+		featureflag.IsEnabled(orgID, featureflag.PurposeEnforcement) {
+		for _, mapping := range mappings {
+			approved := mapping.IsUserApproved
+			if approve, requested := optedIn[mapping.Name]; requested {
+				approved = approve
+			}
+			if mapping.ConsentID == consentID && mapping.IsMandatory && !approved {
+				return previousStatus, nil
+			}
+		}
+	}
+	if err := validator.ValidateStatusTransition(orgID, previousStatus, derived); err != nil {
+		logger.Debug("Derived consent status not allowed by the state machine", log.Error(err),
+			log.String("consent_id", consentID))
+		return previousStatus, nil
+	}
+	return derived, nil
+}
+
+// preferenceMappings returns the consents of a user that are not in a terminal status, with the
+// purpose mappings of those consents
+func (consentService *consentService) preferenceMappings(ctx context.Context, userID, orgID string) ([]model.Consent, []purposemodel.ConsentPurposeMapping, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if userID == "" {
+		return nil, nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "userId is required")
+	}
+
+	authResources, err := consentService.stores.AuthResource.GetByUserID(ctx, userID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve authorizations of user", log.Error(err))
+		return nil, nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	consentIDs := []string{}
+	for _, authResource := range authResources {
+		if !slices.Contains(consentIDs, authResource.ConsentID) {
+			consentIDs = append(consentIDs, authResource.ConsentID)
+		}
+	}
+	if len(consentIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	stored, err := consentService.stores.Consent.GetByIDs(ctx, consentIDs, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consents of user", log.Error(err))
+		return nil, nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	orgConfig := config.Get().Consent.ForOrg(orgID)
+	consents := make([]model.Consent, 0, len(stored))
+	openIDs := make([]string, 0, len(stored))
+	for _, consent := range stored {
+		if orgConfig.IsTerminalStatus(config.ConsentStatus(consent.CurrentStatus)) {
+			continue
+		}
+		consents = append(consents, consent)
+		openIDs = append(openIDs, consent.ConsentID)
+	}
+	if len(openIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	mappings, err := consentService.stores.ConsentPurpose.GetMappingsByConsentIDs(ctx, openIDs, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve purpose mappings of user consents", log.Error(err))
+		return nil, nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	return consents, mappings, nil
+}
+
+// buildUserPreferences groups purpose mappings by purpose, in purpose name order
+func buildUserPreferences(userID string, consents []model.Consent, mappings []purposemodel.ConsentPurposeMapping) *model.UserPreferencesResponse {
+	consentTypes := make(map[string]string, len(consents))
+	for _, consent := range consents {
+		consentTypes[consent.ConsentID] = consent.ConsentType
+	}
+
+	byPurpose := make(map[string]*model.PurposePreference)
+	names := []string{}
+	for _, mapping := range mappings {
+		preference, ok := byPurpose[mapping.Name]
+		if !ok {
+			preference = &model.PurposePreference{PurposeName: mapping.Name, Consents: []model.PreferenceConsent{}}
+			byPurpose[mapping.Name] = preference
+			names = append(names, mapping.Name)
+		}
+		preference.IsMandatory = preference.IsMandatory || mapping.IsMandatory
+		if mapping.LastConfirmedAt != nil && (preference.LastConfirmedAt == nil || *mapping.LastConfirmedAt > *preference.LastConfirmedAt) {
+			preference.LastConfirmedAt = mapping.LastConfirmedAt
+		}
+		preference.Consents = append(preference.Consents, model.PreferenceConsent{
+			ConsentID:   mapping.ConsentID,
+			ConsentType: consentTypes[mapping.ConsentID],
+			OptedIn:     mapping.IsUserApproved,
+			IsMandatory: mapping.IsMandatory,
+		})
+	}
+	sort.Strings(names)
+
+	response := &model.UserPreferencesResponse{
+		UserID:      userID,
+		Preferences: make([]model.PurposePreference, 0, len(names)),
+	}
+	for _, name := range names {
+		preference := byPurpose[name]
+		sort.Slice(preference.Consents, func(i, j int) bool {
+			return preference.Consents[i].ConsentID < preference.Consents[j].ConsentID
+		})
+		optedIn := 0
+		for _, consent := range preference.Consents {
+			if consent.OptedIn {
+				optedIn++
+			}
+		}
+		switch optedIn {
+		case len(preference.Consents):
+			preference.State = model.PreferenceOptedIn
+		case 0:
+			preference.State = model.PreferenceOptedOut
+		default:
+			preference.State = model.PreferenceMixed
+		}
+		response.Preferences = append(response.Preferences, *preference)
+	}
+	return response
+}
+
+// OverrideStatus forces a consent into any configured status, bypassing the state machine. It is
+// meant for support teams fixing consents stuck in a wrong status. The change is audited with the
+// given reason and actor, and cascades to the consent's authorizations as configured.
+func (consentService *consentService) OverrideStatus(ctx context.Context, consentID, orgID string, req model.StatusOverrideRequest) (*model.StatusOverrideResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.OverrideStatus")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Overriding consent status",
+		log.String("consent_id", consentID),
+		log.String("org_id", orgID),
+		log.String("status", req.Status),
+		log.String("action_by", req.ActionBy))
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if strings.TrimSpace(req.Reason) == "" || strings.TrimSpace(req.ActionBy) == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "reason and actionBy are required")
+	}
+
+	consentCfg := config.Get().Consent.ForOrg(orgID)
+	if !consentCfg.IsStatusAllowed(config.ConsentStatus(req.Status)) {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("status must be one of %v", consentCfg.GetAllowedConsentStatuses()))
+	}
+
+	consentStore := consentService.stores.Consent
+	existing, err := consentStore.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+
+	currentTime := utils.GetCurrentTimeMillis()
+	reason := req.Reason
+	actionBy := req.ActionBy
+	audit := &model.ConsentStatusAudit{
+		StatusAuditID:  utils.GenerateUUID(),
+		ConsentID:      consentID,
+		CurrentStatus:  req.Status,
+		ActionTime:     currentTime,
+		Reason:         &reason,
+		ActionBy:       &actionBy,
+		PreviousStatus: &existing.CurrentStatus,
+		OrgID:          orgID,
+	}
+
+	queries := []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.UpdateStatus(tx, consentID, orgID, req.Status, currentTime)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.CreateStatusAudit(tx, audit)
+		},
+	}
+	authStatus, cascade := consentCfg.GetOverrideAuthStatus(req.Status)
+	if cascade {
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return consentService.stores.AuthResource.UpdateAllStatusByConsentID(tx, consentID, orgID, authStatus, currentTime)
+		})
+	}
+
+	if validator.IsConsentEnded(orgID, req.Status) {
+		queries = append(queries, consentService.releaseTokens(consentID, orgID))
+	}
+	queries = append(queries, consentService.recordEvent(events.ConsentEvent{
+		ID:             utils.GenerateUUID(),
+		Type:           events.ConsentStatusOverridden,
+		Timestamp:      currentTime,
+		OrgID:          orgID,
+		ConsentID:      consentID,
+		ClientID:       existing.ClientID,
+		Status:         req.Status,
+		PreviousStatus: existing.CurrentStatus,
+	}))
+
+	if err := consentService.stores.ExecuteTransaction(ctx, queries); err != nil {
+		logger.Error("Failed to override consent status", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	cache.InvalidateConsentValidation(ctx, orgID, consentID)
+
+	logger.Info("Consent status overridden",
+		log.String("consent_id", consentID),
+		log.String("previous_status", existing.CurrentStatus),
+		log.String("new_status", req.Status),
+		log.Bool("auth_cascade", cascade))
+
+	response := &model.StatusOverrideResponse{
+		ConsentID:      consentID,
+		PreviousStatus: existing.CurrentStatus,
+		Status:         req.Status,
+		Reason:         req.Reason,
+		ActionBy:       req.ActionBy,
+		ActionTime:     currentTime,
+	}
+	if cascade {
+		response.AuthorizationStatus = authStatus
+	}
+	return response, nil
+}
+
+// maxStatusAuditSearchLimit is the largest page of status audit entries returned by SearchStatusAudit
+const maxStatusAuditSearchLimit = 100
+
+// SearchStatusAudit lists the recorded consent status changes matching the filters, newest first.
+// It answers compliance questions such as which consents a user revoked in a period.
+func (consentService *consentService) SearchStatusAudit(ctx context.Context, filters model.StatusAuditSearchFilters) (*model.StatusAuditSearchResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.SearchStatusAudit")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Searching consent status audit",
+		log.String("org_id", filters.OrgID),
+		log.String("consent_id", filters.ConsentID),
+		log.String("action_by", filters.ActionBy),
+		log.Int("limit", filters.Limit))
+
+	if filters.FromTime != nil && filters.ToTime != nil && *filters.FromTime > *filters.ToTime {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "fromTime must not be after toTime")
+	}
+
+	// Validate pagination
+	if filters.Limit <= 0 {
+		filters.Limit = 20
+	}
+	if filters.Limit > maxStatusAuditSearchLimit {
+		filters.Limit = maxStatusAuditSearchLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	audits, total, err := consentService.stores.Consent.SearchStatusAudit(ctx, filters)
+	if err != nil {
+		logger.Error("Failed to search consent status audit", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	data := make([]model.ConsentStatusAuditResponse, 0, len(audits))
+	for i := range audits {
+		data = append(data, audits[i].ToResponse())
+	}
+
+	return &model.StatusAuditSearchResponse{
+		Data: data,
+		Metadata: model.ConsentSearchMetadata{
+			Total:   &total,
+			Limit:   filters.Limit,
+			Offset:  filters.Offset,
+			Count:   len(data),
+			HasMore: filters.Offset+len(data) < total,
+		},
+	}, nil
+}
+
+// AddTags labels a consent with tags, such as the migration batch it belongs to. Tags are kept apart
+// from the consent's attributes, so tagging never conflicts with client updates, and they do not change
+// the consent's version or updated time. Tags the consent already has are ignored.
+func (consentService *consentService) AddTags(ctx context.Context, consentID, orgID string, req model.ConsentTagsRequest) (*model.ConsentTagsResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.AddTags")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := req.Validate(); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	if serviceErr := consentService.checkTaggableConsent(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	consentStore := consentService.stores.Consent
+	existing, err := consentStore.GetTagsByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent tags", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	tags := make(map[string]bool, len(existing)+len(req.Tags))
+	for _, tag := range existing {
+		tags[tag] = true
+	}
+	var added []string
+	for _, tag := range req.Tags {
+		if !tags[tag] {
+			tags[tag] = true
+			added = append(added, tag)
+		}
+	}
+	if len(tags) > model.MaxConsentTags {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("a consent can have at most %d tags", model.MaxConsentTags))
+	}
+
+	if err := consentStore.AddTags(ctx, consentID, orgID, added, utils.GetCurrentTimeMillis()); err != nil {
+		logger.Error("Failed to add consent tags", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	auditChanges(ctx, "tags", "added", added)
+
+	current, err := consentStore.GetTagsByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent tags", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	logger.Info("Consent tags added",
+		log.String("consent_id", consentID),
+		log.Int("added", len(added)),
+		log.Int("tags", len(current)))
+	return &model.ConsentTagsResponse{ConsentID: consentID, Tags: current}, nil
+}
+
+// RemoveTag removes a tag from a consent
+func (consentService *consentService) RemoveTag(ctx context.Context, consentID, orgID, tag string) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "consent.RemoveTag")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := model.ValidateTag(tag); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	if serviceErr := consentService.checkTaggableConsent(ctx, consentID, orgID); serviceErr != nil {
+		return serviceErr
+	}
+
+	removed, err := consentService.stores.Consent.RemoveTag(ctx, consentID, orgID, tag)
+	if err != nil {
+		logger.Error("Failed to remove consent tag", log.Error(err), log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if !removed {
+		return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("Consent with ID '%s' has no tag '%s'", consentID, tag))
+	}
+	auditChanges(ctx, "tags", "removed", []string{tag})
+
+	logger.Info("Consent tag removed", log.String("consent_id", consentID), log.String("tag", tag))
+	return nil
+}
+
+// checkTaggableConsent returns a not found error unless the consent exists and is not deleted
+func (consentService *consentService) checkTaggableConsent(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError {
+	existing, err := consentService.stores.Consent.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		log.GetLogger().WithContext(ctx).Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil || existing.CurrentStatus == model.DeletedConsentStatus {
+		return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+	return nil
+}
+
+// CreateNote attaches a free-text note to a consent, such as a support agent recording that the
+// customer called to confirm a revocation. Notes cannot be changed once added.
+func (consentService *consentService) CreateNote(ctx context.Context, consentID, orgID string, req model.ConsentNoteRequest) (*model.ConsentNote, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.CreateNote")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := req.Validate(); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	if serviceErr := consentService.checkConsentExists(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	note := &model.ConsentNote{
+		ID:          utils.GenerateUUID(),
+		ConsentID:   consentID,
+		Author:      req.Author,
+		Text:        req.Text,
+		CreatedTime: utils.GetCurrentTimeMillis(),
+		OrgID:       orgID,
+	}
+	if err := consentService.stores.Consent.CreateNote(ctx, note); err != nil {
+		logger.Error("Failed to create consent note", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+
+	logger.Info("Consent note added",
+		log.String("consent_id", consentID),
+		log.String("note_id", note.ID),
+		log.String("author", note.Author))
+	return note, nil
+}
+
+// ListNotes returns the notes of a consent, oldest first
+func (consentService *consentService) ListNotes(ctx context.Context, consentID, orgID string) (*model.ConsentNoteListResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ListNotes")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	if serviceErr := consentService.checkConsentExists(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	notes, err := consentService.stores.Consent.GetNotesByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent notes", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	return &model.ConsentNoteListResponse{Data: notes}, nil
+}
+
+// ListEvidence returns the evidence of how a consent was collected, oldest first
+func (consentService *consentService) ListEvidence(ctx context.Context, consentID, orgID string) (*model.ConsentEvidenceListResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ListEvidence")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	if serviceErr := consentService.checkConsentExists(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	evidence, err := consentService.stores.Consent.GetEvidenceByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent evidence", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	return &model.ConsentEvidenceListResponse{Data: evidence}, nil
+}
+
+// checkConsentExists returns a not found error unless the consent exists. Notes and evidence may be
+// read on consents in any status, including deleted ones that have not been purged yet.
+func (consentService *consentService) checkConsentExists(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError {
+	existing, err := consentService.stores.Consent.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		log.GetLogger().WithContext(ctx).Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+	return nil
 }
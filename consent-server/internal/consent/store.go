@@ -2,35 +2,45 @@ package consent
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/wso2/consent-management-api/internal/consent/filter"
 	"github.com/wso2/consent-management-api/internal/consent/model"
 	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
 	"github.com/wso2/consent-management-api/internal/system/database/provider"
 	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
 )
 
-// DBQuery objects for consent operations
+// DBQuery objects for consent operations.
+// Soft-deleted consents keep the 'DELETED' status (model.DeletedConsentStatus) until they are
+// purged and are excluded from every read query.
 var (
 	QueryCreateConsent = dbmodel.DBQuery{
 		ID:    "CREATE_CONSENT",
-		Query: "INSERT INTO CONSENT (CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, ORG_ID) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		Query: "INSERT INTO CONSENT (CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, PARENT_CONSENT_ID, VERSION, ORG_ID) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 	}
 
 	QueryGetConsentByID = dbmodel.DBQuery{
 		ID:    "GET_CONSENT_BY_ID",
-		Query: "SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, ORG_ID FROM CONSENT WHERE CONSENT_ID = ? AND ORG_ID = ?",
+		Query: "SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, PARENT_CONSENT_ID, VERSION, ORG_ID FROM CONSENT WHERE CONSENT_ID = ? AND ORG_ID = ? AND CURRENT_STATUS <> 'DELETED'",
+	}
+
+	QueryGetConsentsByIDs = dbmodel.DBQuery{
+		ID:    "GET_CONSENTS_BY_IDS",
+		Query: "", // Built dynamically
 	}
 
 	QueryListConsents = dbmodel.DBQuery{
 		ID:    "LIST_CONSENTS",
-		Query: "SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, ORG_ID FROM CONSENT WHERE ORG_ID = ? ORDER BY CREATED_TIME DESC LIMIT ? OFFSET ?",
+		Query: "SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, PARENT_CONSENT_ID, VERSION, ORG_ID FROM CONSENT WHERE ORG_ID = ? AND CURRENT_STATUS <> 'DELETED' ORDER BY CREATED_TIME DESC LIMIT ? OFFSET ?",
 	}
 
 	QueryCountConsents = dbmodel.DBQuery{
 		ID:    "COUNT_CONSENTS",
-		Query: "SELECT COUNT(*) as count FROM CONSENT WHERE ORG_ID = ?",
+		Query: "SELECT COUNT(*) as count FROM CONSENT WHERE ORG_ID = ? AND CURRENT_STATUS <> 'DELETED'",
 	}
 
 	QueryUpdateConsent = dbmodel.DBQuery{
@@ -43,14 +53,137 @@ var (
 		Query: "UPDATE CONSENT SET CURRENT_STATUS = ?, UPDATED_TIME = ? WHERE CONSENT_ID = ? AND ORG_ID = ?",
 	}
 
+	QueryIncrementConsentVersion = dbmodel.DBQuery{
+		ID:    "INCREMENT_CONSENT_VERSION",
+		Query: "UPDATE CONSENT SET VERSION = VERSION + 1 WHERE CONSENT_ID = ? AND ORG_ID = ? AND VERSION = ?",
+	}
+
 	QueryDeleteConsent = dbmodel.DBQuery{
 		ID:    "DELETE_CONSENT",
 		Query: "DELETE FROM CONSENT WHERE CONSENT_ID = ? AND ORG_ID = ?",
 	}
 
+	// Deleted consents are purged across organizations
+	QueryGetPurgeableConsents = dbmodel.DBQuery{
+		ID:          "GET_PURGEABLE_CONSENTS",
+		CrossTenant: true,
+		Query:       "SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, PARENT_CONSENT_ID, VERSION, ORG_ID FROM CONSENT WHERE CURRENT_STATUS = 'DELETED' AND UPDATED_TIME <= ? ORDER BY UPDATED_TIME LIMIT ?",
+	}
+
+	// QueryGetRetentionExpiredConsents reads consents of all organizations in the given statuses that
+	// were last updated at or before a time and were not anonymized yet
+	QueryGetRetentionExpiredConsents = dbmodel.DBQuery{
+		ID:          "GET_RETENTION_EXPIRED_CONSENTS",
+		CrossTenant: true,
+		Query:       "", // Built dynamically
+	}
+
+	// The anonymize queries remove the personal data of a consent whose retention period ended and
+	// keep the consent, its authorizations and status audits for reporting
+	QueryAnonymizeConsent = dbmodel.DBQuery{
+		ID:    "ANONYMIZE_CONSENT",
+		Query: "UPDATE CONSENT SET ANONYMIZED_TIME = ?, UPDATED_TIME = ? WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryAnonymizeAuthResources = dbmodel.DBQuery{
+		ID:    "ANONYMIZE_CONSENT_AUTH_RESOURCES",
+		Query: "UPDATE CONSENT_AUTH_RESOURCE SET USER_ID = NULL, RESOURCES = NULL, DELEGATOR_ID = NULL, DELEGATE_ID = NULL WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryAnonymizeStatusAudits = dbmodel.DBQuery{
+		ID:    "ANONYMIZE_CONSENT_STATUS_AUDITS",
+		Query: "UPDATE CONSENT_STATUS_AUDIT SET ACTION_BY = NULL, REASON = NULL WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryAnonymizeEvidence = dbmodel.DBQuery{
+		ID:    "ANONYMIZE_CONSENT_EVIDENCE",
+		Query: "UPDATE CONSENT_EVIDENCE SET IP_ADDRESS = NULL, USER_AGENT = NULL WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteHistoryByConsentID = dbmodel.DBQuery{
+		ID:    "DELETE_HISTORY_BY_CONSENT_ID",
+		Query: "DELETE FROM CONSENT_HISTORY WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteFilesByConsentID = dbmodel.DBQuery{
+		ID:    "DELETE_FILES_BY_CONSENT_ID",
+		Query: "DELETE FROM CONSENT_FILE WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteNotesByConsentID = dbmodel.DBQuery{
+		ID:    "DELETE_NOTES_BY_CONSENT_ID",
+		Query: "DELETE FROM CONSENT_NOTE WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	// The pseudonymize queries replace a user ID with a pseudonym wherever the user acted on a
+	// consent of the organization. Reasons name users in quotes, as in ownership transfers.
+	QueryPseudonymizeStatusAuditActor = dbmodel.DBQuery{
+		ID:    "PSEUDONYMIZE_STATUS_AUDIT_ACTOR",
+		Query: "UPDATE CONSENT_STATUS_AUDIT SET ACTION_BY = ? WHERE ACTION_BY = ? AND ORG_ID = ?",
+	}
+
+	QueryPseudonymizeStatusAuditReason = dbmodel.DBQuery{
+		ID:    "PSEUDONYMIZE_STATUS_AUDIT_REASON",
+		Query: "UPDATE CONSENT_STATUS_AUDIT SET REASON = REPLACE(REASON, ?, ?) WHERE ORG_ID = ? AND INSTR(REASON, ?) > 0",
+	}
+
+	QueryPseudonymizeHistoryActor = dbmodel.DBQuery{
+		ID:    "PSEUDONYMIZE_HISTORY_ACTOR",
+		Query: "UPDATE CONSENT_HISTORY SET AMENDED_BY = ? WHERE AMENDED_BY = ? AND ORG_ID = ?",
+	}
+
+	QueryPseudonymizeAuthDelegator = dbmodel.DBQuery{
+		ID:    "PSEUDONYMIZE_AUTH_DELEGATOR",
+		Query: "UPDATE CONSENT_AUTH_RESOURCE SET DELEGATOR_ID = ? WHERE DELEGATOR_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryPseudonymizeAuthDelegate = dbmodel.DBQuery{
+		ID:    "PSEUDONYMIZE_AUTH_DELEGATE",
+		Query: "UPDATE CONSENT_AUTH_RESOURCE SET DELEGATE_ID = ? WHERE DELEGATE_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryPseudonymizeHistoryReason = dbmodel.DBQuery{
+		ID:    "PSEUDONYMIZE_HISTORY_REASON",
+		Query: "UPDATE CONSENT_HISTORY SET REASON = REPLACE(REASON, ?, ?) WHERE ORG_ID = ? AND INSTR(REASON, ?) > 0",
+	}
+
+	QueryUpdateHistorySnapshot = dbmodel.DBQuery{
+		ID:    "UPDATE_HISTORY_SNAPSHOT",
+		Query: "UPDATE CONSENT_HISTORY SET SNAPSHOT = ? WHERE CONSENT_ID = ? AND VERSION = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteAttributesByKeys = dbmodel.DBQuery{
+		ID:    "DELETE_ATTRIBUTES_BY_KEYS",
+		Query: "", // Built dynamically
+	}
+
+	// QueryGetExpiringConsents reads consents in the given statuses whose validity time falls in a
+	// window and that were not yet notified for that validity time. Validity times are stored in
+	// seconds or milliseconds, so the window is given in both units.
+	QueryGetExpiringConsents = dbmodel.DBQuery{
+		ID:          "GET_EXPIRING_CONSENTS",
+		CrossTenant: true,
+		Query:       "", // Built dynamically
+	}
+
+	QueryDeleteExpiryNotice = dbmodel.DBQuery{
+		ID:    "DELETE_CONSENT_EXPIRY_NOTICE",
+		Query: "DELETE FROM CONSENT_EXPIRY_NOTICE WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryCreateExpiryNotice = dbmodel.DBQuery{
+		ID:    "CREATE_CONSENT_EXPIRY_NOTICE",
+		Query: "INSERT INTO CONSENT_EXPIRY_NOTICE (CONSENT_ID, VALIDITY_TIME, NOTIFIED_TIME, ORG_ID) VALUES (?, ?, ?, ?)",
+	}
+
+	QueryGetChildConsents = dbmodel.DBQuery{
+		ID:    "GET_CHILD_CONSENTS",
+		Query: "SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, PARENT_CONSENT_ID, VERSION, ORG_ID FROM CONSENT WHERE PARENT_CONSENT_ID = ? AND ORG_ID = ? AND CURRENT_STATUS <> 'DELETED' ORDER BY CREATED_TIME, CONSENT_ID",
+	}
+
 	QueryGetConsentsByClientID = dbmodel.DBQuery{
 		ID:    "GET_CONSENTS_BY_CLIENT_ID",
-		Query: "SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, ORG_ID FROM CONSENT WHERE CLIENT_ID = ? AND ORG_ID = ?",
+		Query: "SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, PARENT_CONSENT_ID, VERSION, ORG_ID FROM CONSENT WHERE CLIENT_ID = ? AND ORG_ID = ? AND CURRENT_STATUS <> 'DELETED'",
 	}
 
 	// Attribute queries
@@ -59,6 +192,16 @@ var (
 		Query: "INSERT INTO CONSENT_ATTRIBUTE (CONSENT_ID, ATT_KEY, ATT_VALUE, ORG_ID) VALUES (?, ?, ?, ?)",
 	}
 
+	QueryCreateRequiredAuthorizer = dbmodel.DBQuery{
+		ID:    "CREATE_CONSENT_REQUIRED_AUTHORIZER",
+		Query: "INSERT INTO CONSENT_REQUIRED_AUTHORIZER (CONSENT_ID, AUTHORIZER_ROLE, ORG_ID) VALUES (?, ?, ?)",
+	}
+
+	QueryGetRequiredAuthorizers = dbmodel.DBQuery{
+		ID:    "GET_CONSENT_REQUIRED_AUTHORIZERS",
+		Query: "SELECT AUTHORIZER_ROLE FROM CONSENT_REQUIRED_AUTHORIZER WHERE CONSENT_ID = ? AND ORG_ID = ? ORDER BY AUTHORIZER_ROLE",
+	}
+
 	QueryGetAttributesByConsentID = dbmodel.DBQuery{
 		ID:    "GET_ATTRIBUTES_BY_CONSENT_ID",
 		Query: "SELECT CONSENT_ID, ATT_KEY, ATT_VALUE, ORG_ID FROM CONSENT_ATTRIBUTE WHERE CONSENT_ID = ? AND ORG_ID = ?",
@@ -71,12 +214,12 @@ var (
 
 	QueryFindConsentIDsByAttributeKey = dbmodel.DBQuery{
 		ID:    "FIND_CONSENT_IDS_BY_ATTRIBUTE_KEY",
-		Query: "SELECT DISTINCT CONSENT_ID FROM CONSENT_ATTRIBUTE WHERE ATT_KEY = ? AND ORG_ID = ? ORDER BY CONSENT_ID",
+		Query: "SELECT DISTINCT CONSENT_ATTRIBUTE.CONSENT_ID AS consent_id FROM CONSENT_ATTRIBUTE INNER JOIN CONSENT ON CONSENT_ATTRIBUTE.CONSENT_ID = CONSENT.CONSENT_ID AND CONSENT_ATTRIBUTE.ORG_ID = CONSENT.ORG_ID WHERE CONSENT_ATTRIBUTE.ATT_KEY = ? AND CONSENT_ATTRIBUTE.ORG_ID = ? AND CONSENT.CURRENT_STATUS <> 'DELETED' ORDER BY consent_id",
 	}
 
 	QueryFindConsentIDsByAttribute = dbmodel.DBQuery{
 		ID:    "FIND_CONSENT_IDS_BY_ATTRIBUTE",
-		Query: "SELECT DISTINCT CONSENT_ID FROM CONSENT_ATTRIBUTE WHERE ATT_KEY = ? AND ATT_VALUE = ? AND ORG_ID = ? ORDER BY CONSENT_ID",
+		Query: "SELECT DISTINCT CONSENT_ATTRIBUTE.CONSENT_ID AS consent_id FROM CONSENT_ATTRIBUTE INNER JOIN CONSENT ON CONSENT_ATTRIBUTE.CONSENT_ID = CONSENT.CONSENT_ID AND CONSENT_ATTRIBUTE.ORG_ID = CONSENT.ORG_ID WHERE CONSENT_ATTRIBUTE.ATT_KEY = ? AND CONSENT_ATTRIBUTE.ATT_VALUE = ? AND CONSENT_ATTRIBUTE.ORG_ID = ? AND CONSENT.CURRENT_STATUS <> 'DELETED' ORDER BY consent_id",
 	}
 
 	// Status audit queries
@@ -90,6 +233,215 @@ var (
 		Query: "SELECT STATUS_AUDIT_ID, CONSENT_ID, CURRENT_STATUS, ACTION_TIME, REASON, ACTION_BY, PREVIOUS_STATUS, ORG_ID FROM CONSENT_STATUS_AUDIT WHERE CONSENT_ID = ? AND ORG_ID = ? ORDER BY ACTION_TIME DESC",
 	}
 
+	// History queries
+	QueryCreateConsentHistory = dbmodel.DBQuery{
+		ID:    "CREATE_CONSENT_HISTORY",
+		Query: "INSERT INTO CONSENT_HISTORY (CONSENT_ID, VERSION, SNAPSHOT, AMENDED_TIME, AMENDED_BY, REASON, ORG_ID) VALUES (?, ?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryGetHistoryByConsentIDs = dbmodel.DBQuery{
+		ID:    "GET_HISTORY_BY_CONSENT_IDS",
+		Query: "", // Built dynamically
+	}
+
+	QueryGetHistoryByConsentID = dbmodel.DBQuery{
+		ID:    "GET_HISTORY_BY_CONSENT_ID",
+		Query: "SELECT CONSENT_ID, VERSION, AMENDED_TIME, AMENDED_BY, REASON, ORG_ID FROM CONSENT_HISTORY WHERE CONSENT_ID = ? AND ORG_ID = ? ORDER BY VERSION DESC",
+	}
+
+	QueryGetHistoryByVersion = dbmodel.DBQuery{
+		ID:    "GET_HISTORY_BY_VERSION",
+		Query: "SELECT CONSENT_ID, VERSION, SNAPSHOT, AMENDED_TIME, AMENDED_BY, REASON, ORG_ID FROM CONSENT_HISTORY WHERE CONSENT_ID = ? AND VERSION = ? AND ORG_ID = ?",
+	}
+
+	QueryIncrementConsentUsage = dbmodel.DBQuery{
+		ID:    "INCREMENT_CONSENT_USAGE",
+		Query: "UPDATE CONSENT_USAGE SET ACCESS_COUNT = ACCESS_COUNT + 1, LAST_ACCESS_TIME = ? WHERE CONSENT_ID = ? AND ORG_ID = ? AND PERIOD_START = ? AND ACCESS_COUNT < ?",
+	}
+
+	QueryCreateConsentUsage = dbmodel.DBQuery{
+		ID:          "CREATE_CONSENT_USAGE",
+		Query:       "INSERT IGNORE INTO CONSENT_USAGE (CONSENT_ID, ORG_ID, PERIOD_START, ACCESS_COUNT, LAST_ACCESS_TIME) VALUES (?, ?, ?, 1, ?)",
+		SQLiteQuery: "INSERT OR IGNORE INTO CONSENT_USAGE (CONSENT_ID, ORG_ID, PERIOD_START, ACCESS_COUNT, LAST_ACCESS_TIME) VALUES (?, ?, ?, 1, ?)",
+	}
+
+	QueryGetConsentUsage = dbmodel.DBQuery{
+		ID:    "GET_CONSENT_USAGE",
+		Query: "SELECT CONSENT_ID, ORG_ID, PERIOD_START, ACCESS_COUNT, LAST_ACCESS_TIME FROM CONSENT_USAGE WHERE CONSENT_ID = ? AND ORG_ID = ? AND PERIOD_START = ?",
+	}
+
+	QueryRenewConsentLock = dbmodel.DBQuery{
+		ID:    "RENEW_CONSENT_LOCK",
+		Query: "UPDATE CONSENT_LOCK SET LOCK_TOKEN = ?, LOCKED_BY = ?, LOCKED_TIME = ?, EXPIRY_TIME = ? WHERE CONSENT_ID = ? AND ORG_ID = ? AND (EXPIRY_TIME <= ? OR LOCK_TOKEN = ?)",
+	}
+
+	QueryCreateConsentLock = dbmodel.DBQuery{
+		ID:          "CREATE_CONSENT_LOCK",
+		Query:       "INSERT IGNORE INTO CONSENT_LOCK (CONSENT_ID, ORG_ID, LOCK_TOKEN, LOCKED_BY, LOCKED_TIME, EXPIRY_TIME) VALUES (?, ?, ?, ?, ?, ?)",
+		SQLiteQuery: "INSERT OR IGNORE INTO CONSENT_LOCK (CONSENT_ID, ORG_ID, LOCK_TOKEN, LOCKED_BY, LOCKED_TIME, EXPIRY_TIME) VALUES (?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryGetConsentLock = dbmodel.DBQuery{
+		ID:    "GET_CONSENT_LOCK",
+		Query: "SELECT CONSENT_ID, ORG_ID, LOCK_TOKEN, LOCKED_BY, LOCKED_TIME, EXPIRY_TIME FROM CONSENT_LOCK WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteConsentLock = dbmodel.DBQuery{
+		ID:    "DELETE_CONSENT_LOCK",
+		Query: "DELETE FROM CONSENT_LOCK WHERE CONSENT_ID = ? AND ORG_ID = ? AND LOCK_TOKEN = ?",
+	}
+
+	QueryUpdateModifiedResponse = dbmodel.DBQuery{
+		ID:    "UPDATE_MODIFIED_RESPONSE",
+		Query: "UPDATE CONSENT_MODIFIED_RESPONSE SET DOCUMENT = ?, UPDATED_TIME = ? WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryCreateModifiedResponse = dbmodel.DBQuery{
+		ID:          "CREATE_MODIFIED_RESPONSE",
+		Query:       "INSERT IGNORE INTO CONSENT_MODIFIED_RESPONSE (CONSENT_ID, ORG_ID, DOCUMENT, UPDATED_TIME) VALUES (?, ?, ?, ?)",
+		SQLiteQuery: "INSERT OR IGNORE INTO CONSENT_MODIFIED_RESPONSE (CONSENT_ID, ORG_ID, DOCUMENT, UPDATED_TIME) VALUES (?, ?, ?, ?)",
+	}
+
+	QueryGetModifiedResponse = dbmodel.DBQuery{
+		ID:    "GET_MODIFIED_RESPONSE",
+		Query: "SELECT DOCUMENT FROM CONSENT_MODIFIED_RESPONSE WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryCreateConsentTag = dbmodel.DBQuery{
+		ID:          "CREATE_CONSENT_TAG",
+		Query:       "INSERT IGNORE INTO CONSENT_TAG (CONSENT_ID, TAG, CREATED_TIME, ORG_ID) VALUES (?, ?, ?, ?)",
+		SQLiteQuery: "INSERT OR IGNORE INTO CONSENT_TAG (CONSENT_ID, TAG, CREATED_TIME, ORG_ID) VALUES (?, ?, ?, ?)",
+	}
+
+	QueryDeleteConsentTag = dbmodel.DBQuery{
+		ID:    "DELETE_CONSENT_TAG",
+		Query: "DELETE FROM CONSENT_TAG WHERE CONSENT_ID = ? AND TAG = ? AND ORG_ID = ?",
+	}
+
+	QueryGetTagsByConsentID = dbmodel.DBQuery{
+		ID:    "GET_TAGS_BY_CONSENT_ID",
+		Query: "SELECT TAG FROM CONSENT_TAG WHERE CONSENT_ID = ? AND ORG_ID = ? ORDER BY TAG",
+	}
+
+	QueryGetTagsByConsentIDs = dbmodel.DBQuery{
+		ID:    "GET_TAGS_BY_CONSENT_IDS",
+		Query: "", // Built dynamically
+	}
+
+	QueryCreateConsentNote = dbmodel.DBQuery{
+		ID:    "CREATE_CONSENT_NOTE",
+		Query: "INSERT INTO CONSENT_NOTE (NOTE_ID, CONSENT_ID, AUTHOR, NOTE_TEXT, CREATED_TIME, ORG_ID) VALUES (?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryGetNotesByConsentID = dbmodel.DBQuery{
+		ID:    "GET_NOTES_BY_CONSENT_ID",
+		Query: "SELECT NOTE_ID, AUTHOR, NOTE_TEXT, CREATED_TIME FROM CONSENT_NOTE WHERE CONSENT_ID = ? AND ORG_ID = ? ORDER BY CREATED_TIME ASC, NOTE_ID ASC",
+	}
+
+	QueryCreateConsentEvidence = dbmodel.DBQuery{
+		ID: "CREATE_CONSENT_EVIDENCE",
+		Query: "INSERT INTO CONSENT_EVIDENCE (EVIDENCE_ID, CONSENT_ID, CONSENT_VERSION, CHANNEL, IP_ADDRESS, USER_AGENT, UI_VERSION, " +
+			"PRESENTED_TEXT_CHECKSUM, CAPTURED_TIME, ORG_ID) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryGetEvidenceByConsentID = dbmodel.DBQuery{
+		ID: "GET_EVIDENCE_BY_CONSENT_ID",
+		Query: "SELECT EVIDENCE_ID, CONSENT_VERSION, CHANNEL, IP_ADDRESS, USER_AGENT, UI_VERSION, PRESENTED_TEXT_CHECKSUM, CAPTURED_TIME " +
+			"FROM CONSENT_EVIDENCE WHERE CONSENT_ID = ? AND ORG_ID = ? ORDER BY CAPTURED_TIME ASC, EVIDENCE_ID ASC",
+	}
+
+	QueryCreateConsentToken = dbmodel.DBQuery{
+		ID:    "CREATE_CONSENT_TOKEN",
+		Query: "INSERT INTO CONSENT_TOKEN (TOKEN_ID, CONSENT_ID, TOKEN_TYPE, EXPIRES_AT, CREATED_TIME, ORG_ID) VALUES (?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryGetConsentToken = dbmodel.DBQuery{
+		ID:    "GET_CONSENT_TOKEN",
+		Query: "SELECT TOKEN_ID, CONSENT_ID, TOKEN_TYPE, EXPIRES_AT, CREATED_TIME, ORG_ID FROM CONSENT_TOKEN WHERE TOKEN_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryGetTokensByConsentID = dbmodel.DBQuery{
+		ID: "GET_TOKENS_BY_CONSENT_ID",
+		Query: "SELECT TOKEN_ID, CONSENT_ID, TOKEN_TYPE, EXPIRES_AT, CREATED_TIME, ORG_ID FROM CONSENT_TOKEN " +
+			"WHERE CONSENT_ID = ? AND ORG_ID = ? AND (EXPIRES_AT IS NULL OR EXPIRES_AT > ?) ORDER BY CREATED_TIME ASC, TOKEN_ID ASC",
+	}
+
+	QueryDeleteConsentToken = dbmodel.DBQuery{
+		ID:    "DELETE_CONSENT_TOKEN",
+		Query: "DELETE FROM CONSENT_TOKEN WHERE TOKEN_ID = ? AND CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteTokensByConsentID = dbmodel.DBQuery{
+		ID:    "DELETE_TOKENS_BY_CONSENT_ID",
+		Query: "DELETE FROM CONSENT_TOKEN WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteExpiredTokens = dbmodel.DBQuery{
+		ID:          "DELETE_EXPIRED_CONSENT_TOKENS",
+		CrossTenant: true,
+		Query:       "DELETE FROM CONSENT_TOKEN WHERE EXPIRES_AT <= ?",
+	}
+
+	QueryDeleteScheduledRevocation = dbmodel.DBQuery{
+		ID:    "DELETE_SCHEDULED_REVOCATION",
+		Query: "DELETE FROM CONSENT_SCHEDULED_REVOCATION WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryCreateScheduledRevocation = dbmodel.DBQuery{
+		ID:    "CREATE_SCHEDULED_REVOCATION",
+		Query: "INSERT INTO CONSENT_SCHEDULED_REVOCATION (CONSENT_ID, EFFECTIVE_AT, REVOKE_REQUEST, CREATED_TIME, ORG_ID) VALUES (?, ?, ?, ?, ?)",
+	}
+
+	QueryGetScheduledRevocation = dbmodel.DBQuery{
+		ID:    "GET_SCHEDULED_REVOCATION",
+		Query: "SELECT CONSENT_ID, EFFECTIVE_AT, REVOKE_REQUEST, CREATED_TIME, ORG_ID FROM CONSENT_SCHEDULED_REVOCATION WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	// QueryGetDueScheduledRevocations reads the scheduled revocations of every organization that
+	// took effect by the given time, earliest first
+	QueryGetDueScheduledRevocations = dbmodel.DBQuery{
+		ID:          "GET_DUE_SCHEDULED_REVOCATIONS",
+		CrossTenant: true,
+		Query:       "SELECT CONSENT_ID, EFFECTIVE_AT, REVOKE_REQUEST, CREATED_TIME, ORG_ID FROM CONSENT_SCHEDULED_REVOCATION WHERE EFFECTIVE_AT <= ? ORDER BY EFFECTIVE_AT, CONSENT_ID LIMIT ?",
+	}
+
+	// QueryClaimScheduledRevocation deletes a scheduled revocation unless it was replaced or
+	// claimed by another server since it was read
+	QueryClaimScheduledRevocation = dbmodel.DBQuery{
+		ID:    "CLAIM_SCHEDULED_REVOCATION",
+		Query: "DELETE FROM CONSENT_SCHEDULED_REVOCATION WHERE CONSENT_ID = ? AND ORG_ID = ? AND EFFECTIVE_AT = ? AND CREATED_TIME = ?",
+	}
+
+	QueryDeleteScheduledUpdate = dbmodel.DBQuery{
+		ID:    "DELETE_SCHEDULED_UPDATE",
+		Query: "DELETE FROM CONSENT_SCHEDULED_UPDATE WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryCreateScheduledUpdate = dbmodel.DBQuery{
+		ID:    "CREATE_SCHEDULED_UPDATE",
+		Query: "INSERT INTO CONSENT_SCHEDULED_UPDATE (CONSENT_ID, EFFECTIVE_AT, UPDATE_REQUEST, CREATED_TIME, ORG_ID) VALUES (?, ?, ?, ?, ?)",
+	}
+
+	QueryGetScheduledUpdate = dbmodel.DBQuery{
+		ID:    "GET_SCHEDULED_UPDATE",
+		Query: "SELECT CONSENT_ID, EFFECTIVE_AT, UPDATE_REQUEST, CREATED_TIME, ORG_ID FROM CONSENT_SCHEDULED_UPDATE WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	// QueryGetDueScheduledUpdates reads the scheduled updates of every organization that took
+	// effect by the given time, earliest first
+	QueryGetDueScheduledUpdates = dbmodel.DBQuery{
+		ID:          "GET_DUE_SCHEDULED_UPDATES",
+		CrossTenant: true,
+		Query:       "SELECT CONSENT_ID, EFFECTIVE_AT, UPDATE_REQUEST, CREATED_TIME, ORG_ID FROM CONSENT_SCHEDULED_UPDATE WHERE EFFECTIVE_AT <= ? ORDER BY EFFECTIVE_AT, CONSENT_ID LIMIT ?",
+	}
+
+	// QueryClaimScheduledUpdate deletes a scheduled update unless it was replaced or claimed by
+	// another server since it was read
+	QueryClaimScheduledUpdate = dbmodel.DBQuery{
+		ID:    "CLAIM_SCHEDULED_UPDATE",
+		Query: "DELETE FROM CONSENT_SCHEDULED_UPDATE WHERE CONSENT_ID = ? AND ORG_ID = ? AND EFFECTIVE_AT = ? AND CREATED_TIME = ?",
+	}
+
 	QueryGetAttributesByConsentIDs = dbmodel.DBQuery{
 		ID:    "GET_ATTRIBUTES_BY_CONSENT_IDS",
 		Query: "", // Built dynamically
@@ -101,6 +453,22 @@ var (
 	}
 )
 
+// validityTimeMillisExpr normalizes VALIDITY_TIME to milliseconds. Validity times below 10^11 are
+// stored in seconds (see validator.IsConsentExpired).
+const validityTimeMillisExpr = "(CASE WHEN CONSENT.VALIDITY_TIME < 100000000000 THEN CONSENT.VALIDITY_TIME * 1000 ELSE CONSENT.VALIDITY_TIME END)"
+
+// ErrConsentVersionConflict is returned when a consent was amended concurrently and its version
+// no longer matches the version the amendment was based on
+var ErrConsentVersionConflict = errors.New("consent version has changed")
+
+// ErrScheduledRevocationClaimed is returned when a scheduled revocation was replaced, cancelled or
+// applied by another server since it was read
+var ErrScheduledRevocationClaimed = errors.New("scheduled revocation has changed")
+
+// ErrScheduledUpdateClaimed is returned when a scheduled update was replaced, cancelled or applied
+// by another server since it was read
+var ErrScheduledUpdateClaimed = errors.New("scheduled update has changed")
+
 // store implements the interfaces.ConsentStore interface
 type store struct {
 	dbClient provider.DBClientInterface
@@ -119,13 +487,13 @@ func (s *store) Create(tx dbmodel.TxInterface, consent *model.Consent) error {
 		consent.ConsentID, consent.CreatedTime, consent.UpdatedTime, consent.ClientID,
 		consent.ConsentType, consent.CurrentStatus, consent.ConsentFrequency,
 		consent.ValidityTime, consent.RecurringIndicator, consent.DataAccessValidityDuration,
-		consent.OrgID)
+		consent.ParentConsentID, consent.Version, consent.OrgID)
 	return err
 }
 
 // GetByID retrieves a consent by ID
 func (s *store) GetByID(ctx context.Context, consentID, orgID string) (*model.Consent, error) {
-	rows, err := s.dbClient.Query(QueryGetConsentByID, consentID, orgID)
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetConsentByID, consentID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -135,9 +503,47 @@ func (s *store) GetByID(ctx context.Context, consentID, orgID string) (*model.Co
 	return mapToConsent(rows[0]), nil
 }
 
+// GetByIDs retrieves the consents with the given IDs. IDs that do not exist are skipped.
+func (s *store) GetByIDs(ctx context.Context, consentIDs []string, orgID string) ([]model.Consent, error) {
+	if len(consentIDs) == 0 {
+		return []model.Consent{}, nil
+	}
+
+	// Build placeholders for IN clause
+	placeholders := ""
+	args := make([]interface{}, 0, len(consentIDs)+1)
+	for i, id := range consentIDs {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += "?"
+		args = append(args, id)
+	}
+	args = append(args, orgID)
+
+	query := dbmodel.DBQuery{
+		ID:    QueryGetConsentsByIDs.ID,
+		Query: fmt.Sprintf("SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY, VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, PARENT_CONSENT_ID, VERSION, ORG_ID FROM CONSENT WHERE CONSENT_ID IN (%s) AND ORG_ID = ? AND CURRENT_STATUS <> 'DELETED'", placeholders),
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	consents := make([]model.Consent, 0, len(rows))
+	for _, row := range rows {
+		consent := mapToConsent(row)
+		if consent != nil {
+			consents = append(consents, *consent)
+		}
+	}
+	return consents, nil
+}
+
 // List retrieves paginated consents
 func (s *store) List(ctx context.Context, orgID string, limit, offset int) ([]model.Consent, int, error) {
-	countRows, err := s.dbClient.Query(QueryCountConsents, orgID)
+	countRows, err := s.dbClient.QueryContext(ctx, QueryCountConsents, orgID)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -149,7 +555,7 @@ func (s *store) List(ctx context.Context, orgID string, limit, offset int) ([]mo
 		}
 	}
 
-	rows, err := s.dbClient.Query(QueryListConsents, orgID, limit, offset)
+	rows, err := s.dbClient.QueryContext(ctx, QueryListConsents, orgID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -168,7 +574,7 @@ func (s *store) List(ctx context.Context, orgID string, limit, offset int) ([]mo
 // Search retrieves consents based on filters with pagination
 func (s *store) Search(ctx context.Context, filters model.ConsentSearchFilters) ([]model.Consent, int, error) {
 	// Build WHERE clause dynamically
-	whereConditions := []string{"CONSENT.ORG_ID = ?"}
+	whereConditions := []string{"CONSENT.ORG_ID = ?", "CONSENT.CURRENT_STATUS <> 'DELETED'"}
 	args := []interface{}{filters.OrgID}
 	countArgs := []interface{}{filters.OrgID}
 
@@ -206,17 +612,45 @@ func (s *store) Search(ctx context.Context, filters model.ConsentSearchFilters)
 		whereConditions = append(whereConditions, fmt.Sprintf("CONSENT.CLIENT_ID IN (%s)", strings.Join(placeholders, ",")))
 	}
 
-	// Add userIds filter (via JOIN with CONSENT_AUTH_RESOURCE)
+	// Add userIds, delegatorIds, delegateIds, authTypes and authStatuses filters (via JOIN with
+	// CONSENT_AUTH_RESOURCE). They match the same authorization, so a consent matches when one
+	// authorization satisfies all of them.
 	joinClause := ""
-	if len(filters.UserIDs) > 0 {
-		placeholders := make([]string, len(filters.UserIDs))
-		for i, userID := range filters.UserIDs {
+	authFilters := []struct {
+		column string
+		values []string
+	}{
+		{"car.USER_ID", filters.UserIDs},
+		{"car.DELEGATOR_ID", filters.DelegatorIDs},
+		{"car.DELEGATE_ID", filters.DelegateIDs},
+		{"car.AUTH_TYPE", filters.AuthTypes},
+		{"car.AUTH_STATUS", filters.AuthStatuses},
+	}
+	for _, authFilter := range authFilters {
+		if len(authFilter.values) == 0 {
+			continue
+		}
+		placeholders := make([]string, len(authFilter.values))
+		for i, value := range authFilter.values {
 			placeholders[i] = "?"
-			args = append(args, userID)
-			countArgs = append(countArgs, userID)
+			args = append(args, value)
+			countArgs = append(countArgs, value)
 		}
 		joinClause = " INNER JOIN CONSENT_AUTH_RESOURCE car ON CONSENT.CONSENT_ID = car.CONSENT_ID AND CONSENT.ORG_ID = car.ORG_ID"
-		whereConditions = append(whereConditions, fmt.Sprintf("car.USER_ID IN (%s)", strings.Join(placeholders, ",")))
+		whereConditions = append(whereConditions, fmt.Sprintf("%s IN (%s)", authFilter.column, strings.Join(placeholders, ",")))
+	}
+
+	// Add purposeNames filter (consents linked to any of the purposes)
+	if len(filters.PurposeNames) > 0 {
+		placeholders := make([]string, len(filters.PurposeNames))
+		for i, name := range filters.PurposeNames {
+			placeholders[i] = "?"
+			args = append(args, name)
+			countArgs = append(countArgs, name)
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM CONSENT_PURPOSE_MAPPING cpm INNER JOIN CONSENT_PURPOSE cp ON cpm.PURPOSE_ID = cp.ID AND cpm.ORG_ID = cp.ORG_ID WHERE cpm.CONSENT_ID = CONSENT.CONSENT_ID AND cpm.ORG_ID = CONSENT.ORG_ID AND cp.NAME IN (%s))",
+			strings.Join(placeholders, ",")))
 	}
 
 	// Add time range filters (timestamps in milliseconds)
@@ -232,37 +666,133 @@ func (s *store) Search(ctx context.Context, filters model.ConsentSearchFilters)
 		countArgs = append(countArgs, *filters.ToTime)
 	}
 
-	whereClause := strings.Join(whereConditions, " AND ")
+	rangeFilters := []struct {
+		condition string
+		value     *int64
+	}{
+		{"CONSENT.CREATED_TIME >= ?", filters.CreatedTimeFrom},
+		{"CONSENT.CREATED_TIME <= ?", filters.CreatedTimeTo},
+		{"CONSENT.UPDATED_TIME >= ?", filters.UpdatedTimeFrom},
+		{"CONSENT.UPDATED_TIME <= ?", filters.UpdatedTimeTo},
+		{validityTimeMillisExpr + " >= ?", filters.ExpiresAfter},
+		{validityTimeMillisExpr + " <= ?", filters.ExpiresBefore},
+	}
+	for _, rangeFilter := range rangeFilters {
+		if rangeFilter.value != nil {
+			whereConditions = append(whereConditions, rangeFilter.condition)
+			args = append(args, *rangeFilter.value)
+			countArgs = append(countArgs, *rangeFilter.value)
+		}
+	}
 
-	// Build COUNT query
-	countQuery := fmt.Sprintf("SELECT COUNT(DISTINCT CONSENT.CONSENT_ID) as count FROM CONSENT%s WHERE %s",
-		joinClause, whereClause)
+	// Consents without an expiry never match an expiry filter
+	if filters.ExpiresAfter != nil || filters.ExpiresBefore != nil {
+		whereConditions = append(whereConditions, "CONSENT.VALIDITY_TIME > 0")
+	}
 
-	// Execute count query
-	countRows, err := s.dbClient.Query(dbmodel.DBQuery{ID: "COUNT_SEARCH_RESULTS", Query: countQuery}, countArgs...)
-	if err != nil {
-		return nil, 0, err
+	// Add tags filter (consents with any of the tags)
+	if len(filters.Tags) > 0 {
+		placeholders := make([]string, len(filters.Tags))
+		for i, tag := range filters.Tags {
+			placeholders[i] = "?"
+			args = append(args, tag)
+			countArgs = append(countArgs, tag)
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM CONSENT_TAG ct WHERE ct.CONSENT_ID = CONSENT.CONSENT_ID AND ct.ORG_ID = CONSENT.ORG_ID AND ct.TAG IN (%s))",
+			strings.Join(placeholders, ",")))
+	}
+
+	attributeConditions, attributeArgs := attributeFilterConditions(filters.Attributes)
+	whereConditions = append(whereConditions, attributeConditions...)
+	args = append(args, attributeArgs...)
+	countArgs = append(countArgs, attributeArgs...)
+
+	// Add the filter expression
+	if filters.Filter != nil {
+		aliases := 0
+		condition, filterArgs := filterCondition(filters.Filter, &aliases)
+		whereConditions = append(whereConditions, condition)
+		args = append(args, filterArgs...)
+		countArgs = append(countArgs, filterArgs...)
+	}
+
+	// Add free-text filter, ranked below unless results are read with a cursor
+	rankColumn := ""
+	var rankArgs []interface{}
+	if filters.SearchText != "" {
+		pattern := "%" + escapeLikePattern(filters.SearchText) + "%"
+		whereConditions = append(whereConditions, "(CONSENT.CONSENT_ID LIKE ? ESCAPE '!' OR CONSENT.CLIENT_ID LIKE ? ESCAPE '!'"+
+			" OR EXISTS (SELECT 1 FROM CONSENT_AUTH_RESOURCE qar WHERE qar.CONSENT_ID = CONSENT.CONSENT_ID AND qar.ORG_ID = CONSENT.ORG_ID AND qar.USER_ID LIKE ? ESCAPE '!')"+
+			" OR EXISTS (SELECT 1 FROM CONSENT_ATTRIBUTE qat WHERE qat.CONSENT_ID = CONSENT.CONSENT_ID AND qat.ORG_ID = CONSENT.ORG_ID AND qat.ATT_VALUE LIKE ? ESCAPE '!'))")
+		for i := 0; i < 4; i++ {
+			args = append(args, pattern)
+			countArgs = append(countArgs, pattern)
+		}
+
+		if !filters.CursorMode {
+			// An exact consent ID ranks first, then an exact client ID, user ID or attribute value,
+			// then partial matches
+			rankColumn = ", CASE WHEN CONSENT.CONSENT_ID = ? THEN 3" +
+				" WHEN CONSENT.CLIENT_ID = ?" +
+				" OR EXISTS (SELECT 1 FROM CONSENT_AUTH_RESOURCE rar WHERE rar.CONSENT_ID = CONSENT.CONSENT_ID AND rar.ORG_ID = CONSENT.ORG_ID AND rar.USER_ID = ?)" +
+				" OR EXISTS (SELECT 1 FROM CONSENT_ATTRIBUTE rat WHERE rat.CONSENT_ID = CONSENT.CONSENT_ID AND rat.ORG_ID = CONSENT.ORG_ID AND rat.ATT_VALUE = ?) THEN 2" +
+				" ELSE 1 END AS SEARCH_RANK"
+			rankArgs = []interface{}{filters.SearchText, filters.SearchText, filters.SearchText, filters.SearchText}
+		}
 	}
 
+	whereClause := strings.Join(whereConditions, " AND ")
+
+	// Build and execute the COUNT query unless the caller does not need the total
 	totalCount := 0
-	if len(countRows) > 0 {
-		if count, ok := countRows[0]["count"].(int64); ok {
-			totalCount = int(count)
+	if !filters.SkipTotal {
+		countQuery := fmt.Sprintf("SELECT COUNT(DISTINCT CONSENT.CONSENT_ID) as count FROM CONSENT%s WHERE %s",
+			joinClause, whereClause)
+
+		countRows, err := s.dbClient.QueryContext(ctx, dbmodel.DBQuery{ID: "COUNT_SEARCH_RESULTS", Query: countQuery}, countArgs...)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(countRows) > 0 {
+			if count, ok := countRows[0]["count"].(int64); ok {
+				totalCount = int(count)
+			}
 		}
 	}
 
-	// Build SELECT query with DISTINCT to handle JOIN duplicates
+	// In cursor mode, continue after the cursor position (keyset pagination). The count still
+	// covers every matching consent.
+	selectWhereClause := whereClause
+	offset := filters.Offset
+	if filters.CursorMode {
+		offset = 0
+		if filters.Cursor != nil {
+			selectWhereClause += " AND (CONSENT.CREATED_TIME < ? OR (CONSENT.CREATED_TIME = ? AND CONSENT.CONSENT_ID < ?))"
+			args = append(args, filters.Cursor.CreatedTime, filters.Cursor.CreatedTime, filters.Cursor.ConsentID)
+		}
+	}
+
+	// Build SELECT query with DISTINCT to handle JOIN duplicates. CONSENT_ID breaks ties between
+	// consents created at the same time so the order is stable across pages.
+	orderBy := "CONSENT.CREATED_TIME DESC, CONSENT.CONSENT_ID DESC"
+	if rankColumn != "" {
+		orderBy = "SEARCH_RANK DESC, " + orderBy
+	}
 	selectQuery := fmt.Sprintf(
-		"SELECT DISTINCT CONSENT.CONSENT_ID, CONSENT.CREATED_TIME, CONSENT.UPDATED_TIME, CONSENT.CLIENT_ID, CONSENT.CONSENT_TYPE, CONSENT.CURRENT_STATUS, CONSENT.CONSENT_FREQUENCY, CONSENT.VALIDITY_TIME, CONSENT.RECURRING_INDICATOR, CONSENT.DATA_ACCESS_VALIDITY_DURATION, CONSENT.ORG_ID FROM CONSENT%s WHERE %s ORDER BY CONSENT.CREATED_TIME DESC LIMIT ? OFFSET ?",
+		"SELECT DISTINCT CONSENT.CONSENT_ID, CONSENT.CREATED_TIME, CONSENT.UPDATED_TIME, CONSENT.CLIENT_ID, CONSENT.CONSENT_TYPE, CONSENT.CURRENT_STATUS, CONSENT.CONSENT_FREQUENCY, CONSENT.VALIDITY_TIME, CONSENT.RECURRING_INDICATOR, CONSENT.DATA_ACCESS_VALIDITY_DURATION, CONSENT.PARENT_CONSENT_ID, CONSENT.VERSION, CONSENT.ORG_ID%s FROM CONSENT%s WHERE %s ORDER BY %s LIMIT ? OFFSET ?",
+		rankColumn,
 		joinClause,
-		whereClause,
+		selectWhereClause,
+		orderBy,
 	)
 
-	// Add pagination parameters
-	args = append(args, filters.Limit, filters.Offset)
+	// The rank placeholders precede the WHERE clause; add pagination parameters
+	args = append(append(rankArgs, args...), filters.Limit, offset)
 
 	// Execute search query
-	rows, err := s.dbClient.Query(dbmodel.DBQuery{ID: "SEARCH_CONSENTS", Query: selectQuery}, args...)
+	rows, err := s.dbClient.QueryContext(ctx, dbmodel.DBQuery{ID: "SEARCH_CONSENTS", Query: selectQuery}, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -306,15 +836,36 @@ func (s *store) UpdateStatus(tx dbmodel.TxInterface, consentID, orgID, status st
 	return nil
 }
 
+// IncrementVersion bumps the consent version within a transaction, but only if the stored version
+// still equals expectedVersion. Returns ErrConsentVersionConflict otherwise.
+func (s *store) IncrementVersion(tx dbmodel.TxInterface, consentID, orgID string, expectedVersion int) error {
+	result, err := tx.Exec(QueryIncrementConsentVersion.Query, consentID, orgID, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrConsentVersionConflict
+	}
+
+	return nil
+}
+
 // Delete deletes a consent within a transaction
 func (s *store) Delete(tx dbmodel.TxInterface, consentID, orgID string) error {
 	_, err := tx.Exec(QueryDeleteConsent.Query, consentID, orgID)
 	return err
 }
 
-// GetByClientID retrieves consents by client ID
-func (s *store) GetByClientID(ctx context.Context, clientID, orgID string) ([]model.Consent, error) {
-	rows, err := s.dbClient.Query(QueryGetConsentsByClientID, clientID, orgID)
+// GetPurgeableConsents retrieves soft-deleted consents of all organizations that were deleted
+// at or before the given time, oldest first
+func (s *store) GetPurgeableConsents(ctx context.Context, deletedBefore int64, limit int) ([]model.Consent, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetPurgeableConsents, deletedBefore, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -330,158 +881,1195 @@ func (s *store) GetByClientID(ctx context.Context, clientID, orgID string) ([]mo
 	return consents, nil
 }
 
-// CreateAttributes creates multiple consent attributes within a transaction
-func (s *store) CreateAttributes(tx dbmodel.TxInterface, attributes []model.ConsentAttribute) error {
-	for _, attr := range attributes {
-		_, err := tx.Exec(QueryCreateAttribute.Query,
-			attr.ConsentID, attr.AttKey, attr.AttValue, attr.OrgID)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-// GetAttributesByConsentID retrieves attributes for a consent
-func (s *store) GetAttributesByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentAttribute, error) {
-	rows, err := s.dbClient.Query(QueryGetAttributesByConsentID, consentID, orgID)
-	if err != nil {
-		return nil, err
-	}
-
-	attributes := make([]model.ConsentAttribute, 0, len(rows))
-	for _, row := range rows {
-		attr := mapToConsentAttribute(row)
-		if attr != nil {
-			attributes = append(attributes, *attr)
-		}
-	}
-
-	return attributes, nil
-}
-
-// GetAttributesByConsentIDs retrieves attributes for multiple consents, grouped by consent ID
-func (s *store) GetAttributesByConsentIDs(ctx context.Context, consentIDs []string, orgID string) (map[string]map[string]string, error) {
-	if len(consentIDs) == 0 {
-		return make(map[string]map[string]string), nil
+// GetRetentionExpiredConsents retrieves consents of all organizations in one of the given statuses
+// that were last updated at or before the given time and were not anonymized, oldest first
+func (s *store) GetRetentionExpiredConsents(ctx context.Context, statuses []string, updatedBefore int64, limit int) ([]model.Consent, error) {
+	if len(statuses) == 0 {
+		return []model.Consent{}, nil
 	}
 
-	// Build placeholders for IN clause
 	placeholders := ""
-	args := make([]interface{}, 0, len(consentIDs)+1)
-	for i, id := range consentIDs {
+	args := make([]interface{}, 0, len(statuses)+2)
+	for i, status := range statuses {
 		if i > 0 {
 			placeholders += ", "
 		}
 		placeholders += "?"
-		args = append(args, id)
+		args = append(args, status)
 	}
-	args = append(args, orgID)
+	args = append(args, updatedBefore, limit)
 
-	// Build dynamic query
 	query := dbmodel.DBQuery{
-		ID:    QueryGetAttributesByConsentIDs.ID,
-		Query: fmt.Sprintf("SELECT CONSENT_ID, ATT_KEY, ATT_VALUE, ORG_ID FROM CONSENT_ATTRIBUTE WHERE CONSENT_ID IN (%s) AND ORG_ID = ?", placeholders),
+		ID:          QueryGetRetentionExpiredConsents.ID,
+		CrossTenant: QueryGetRetentionExpiredConsents.CrossTenant,
+		Query: fmt.Sprintf(`SELECT CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, CONSENT_FREQUENCY,
+				VALIDITY_TIME, RECURRING_INDICATOR, DATA_ACCESS_VALIDITY_DURATION, PARENT_CONSENT_ID, VERSION, ORG_ID
+			FROM CONSENT WHERE CURRENT_STATUS IN (%s) AND UPDATED_TIME <= ? AND ANONYMIZED_TIME IS NULL
+			ORDER BY UPDATED_TIME LIMIT ?`, placeholders),
 	}
 
-	rows, err := s.dbClient.Query(query, args...)
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Group attributes by consent ID
+	consents := make([]model.Consent, 0, len(rows))
+	for _, row := range rows {
+		consent := mapToConsent(row)
+		if consent != nil {
+			consents = append(consents, *consent)
+		}
+	}
+
+	return consents, nil
+}
+
+// Anonymize removes the personal data of a consent within a transaction: its attributes, history,
+// files and notes, the user IDs and resources of its authorizations, the actors and reasons of its
+// status audits and the IP addresses and user agents of its evidence. The consent is marked as
+// anonymized at the given time.
+func (s *store) Anonymize(tx dbmodel.TxInterface, consentID, orgID string, anonymizedTime int64) error {
+	if _, err := tx.Exec(QueryAnonymizeConsent.Query, anonymizedTime, anonymizedTime, consentID, orgID); err != nil {
+		return err
+	}
+	for _, query := range []dbmodel.DBQuery{
+		QueryDeleteAttributesByConsentID,
+		QueryDeleteHistoryByConsentID,
+		QueryDeleteFilesByConsentID,
+		QueryDeleteNotesByConsentID,
+		QueryAnonymizeAuthResources,
+		QueryAnonymizeStatusAudits,
+		QueryAnonymizeEvidence,
+	} {
+		if _, err := tx.Exec(query.Query, consentID, orgID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PseudonymizeUser replaces a user ID with a pseudonym in the status audits and history entries
+// of an organization within a transaction: as the actor, and where a reason names the user. The
+// user is also replaced as the delegator or delegate of authorizations.
+func (s *store) PseudonymizeUser(tx dbmodel.TxInterface, userID, pseudonym, orgID string) error {
+	for _, query := range []dbmodel.DBQuery{QueryPseudonymizeStatusAuditActor, QueryPseudonymizeHistoryActor,
+		QueryPseudonymizeAuthDelegator, QueryPseudonymizeAuthDelegate} {
+		if _, err := tx.Exec(query.Query, pseudonym, userID, orgID); err != nil {
+			return err
+		}
+	}
+	quotedUserID := "'" + userID + "'"
+	for _, query := range []dbmodel.DBQuery{QueryPseudonymizeStatusAuditReason, QueryPseudonymizeHistoryReason} {
+		if _, err := tx.Exec(query.Query, quotedUserID, "'"+pseudonym+"'", orgID, quotedUserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateHistorySnapshot replaces the snapshot of a superseded consent version within a transaction
+func (s *store) UpdateHistorySnapshot(tx dbmodel.TxInterface, consentID, orgID string, version int, snapshot string) error {
+	_, err := tx.Exec(QueryUpdateHistorySnapshot.Query, snapshot, consentID, version, orgID)
+	return err
+}
+
+// GetExpiringConsents retrieves consents of all organizations in one of the given statuses whose
+// validity time, in milliseconds, is within [from, to] and that have no expiry notice for it yet,
+// earliest validity time first
+func (s *store) GetExpiringConsents(ctx context.Context, from, to int64, statuses []string, limit int) ([]model.Consent, error) {
+	if len(statuses) == 0 {
+		return []model.Consent{}, nil
+	}
+
+	placeholders := make([]string, len(statuses))
+	args := make([]interface{}, 0, len(statuses)+5)
+	for i, status := range statuses {
+		placeholders[i] = "?"
+		args = append(args, status)
+	}
+	// validator.IsConsentExpired treats values below 10^11 as seconds
+	args = append(args, from/1000, to/1000, from, to, limit)
+
+	query := dbmodel.DBQuery{
+		ID:          QueryGetExpiringConsents.ID,
+		CrossTenant: QueryGetExpiringConsents.CrossTenant,
+		Query: fmt.Sprintf(`SELECT CONSENT.CONSENT_ID, CONSENT.CREATED_TIME, CONSENT.UPDATED_TIME, CONSENT.CLIENT_ID, CONSENT.CONSENT_TYPE,
+				CONSENT.CURRENT_STATUS, CONSENT.CONSENT_FREQUENCY, CONSENT.VALIDITY_TIME, CONSENT.RECURRING_INDICATOR,
+				CONSENT.DATA_ACCESS_VALIDITY_DURATION, CONSENT.PARENT_CONSENT_ID, CONSENT.VERSION, CONSENT.ORG_ID
+			FROM CONSENT LEFT JOIN CONSENT_EXPIRY_NOTICE ON CONSENT_EXPIRY_NOTICE.CONSENT_ID = CONSENT.CONSENT_ID
+				AND CONSENT_EXPIRY_NOTICE.ORG_ID = CONSENT.ORG_ID AND CONSENT_EXPIRY_NOTICE.VALIDITY_TIME = CONSENT.VALIDITY_TIME
+			WHERE CONSENT_EXPIRY_NOTICE.CONSENT_ID IS NULL AND CONSENT.CURRENT_STATUS IN (%s)
+				AND ((CONSENT.VALIDITY_TIME < 100000000000 AND CONSENT.VALIDITY_TIME BETWEEN ? AND ?)
+					OR (CONSENT.VALIDITY_TIME >= 100000000000 AND CONSENT.VALIDITY_TIME BETWEEN ? AND ?))
+			ORDER BY CONSENT.VALIDITY_TIME LIMIT ?`, strings.Join(placeholders, ", ")),
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	consents := make([]model.Consent, 0, len(rows))
+	for _, row := range rows {
+		consent := mapToConsent(row)
+		if consent != nil {
+			consents = append(consents, *consent)
+		}
+	}
+	return consents, nil
+}
+
+// RecordExpiryNotice records within a transaction that a consent was notified of its expiry at
+// the given validity time, replacing the notice of an earlier validity time
+func (s *store) RecordExpiryNotice(tx dbmodel.TxInterface, consentID, orgID string, validityTime, notifiedTime int64) error {
+	if _, err := tx.Exec(QueryDeleteExpiryNotice.Query, consentID, orgID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(QueryCreateExpiryNotice.Query, consentID, validityTime, notifiedTime, orgID)
+	return err
+}
+
+// GetByClientID retrieves consents by client ID
+func (s *store) GetByClientID(ctx context.Context, clientID, orgID string) ([]model.Consent, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetConsentsByClientID, clientID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	consents := make([]model.Consent, 0, len(rows))
+	for _, row := range rows {
+		consent := mapToConsent(row)
+		if consent != nil {
+			consents = append(consents, *consent)
+		}
+	}
+
+	return consents, nil
+}
+
+// GetChildConsents retrieves the consents whose parent is the given consent, oldest first
+func (s *store) GetChildConsents(ctx context.Context, parentConsentID, orgID string) ([]model.Consent, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetChildConsents, parentConsentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	consents := make([]model.Consent, 0, len(rows))
+	for _, row := range rows {
+		consent := mapToConsent(row)
+		if consent != nil {
+			consents = append(consents, *consent)
+		}
+	}
+
+	return consents, nil
+}
+
+// CreateAttributes creates multiple consent attributes within a transaction
+func (s *store) CreateAttributes(tx dbmodel.TxInterface, attributes []model.ConsentAttribute) error {
+	for _, attr := range attributes {
+		_, err := tx.Exec(QueryCreateAttribute.Query,
+			attr.ConsentID, attr.AttKey, attr.AttValue, attr.OrgID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAttributesByConsentID retrieves attributes for a consent
+func (s *store) GetAttributesByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentAttribute, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetAttributesByConsentID, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make([]model.ConsentAttribute, 0, len(rows))
+	for _, row := range rows {
+		attr := mapToConsentAttribute(row)
+		if attr != nil {
+			attributes = append(attributes, *attr)
+		}
+	}
+
+	return attributes, nil
+}
+
+// GetAttributesByConsentIDs retrieves attributes for multiple consents, grouped by consent ID
+func (s *store) GetAttributesByConsentIDs(ctx context.Context, consentIDs []string, orgID string) (map[string]map[string]string, error) {
+	if len(consentIDs) == 0 {
+		return make(map[string]map[string]string), nil
+	}
+
+	// Build placeholders for IN clause
+	placeholders := ""
+	args := make([]interface{}, 0, len(consentIDs)+1)
+	for i, id := range consentIDs {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += "?"
+		args = append(args, id)
+	}
+	args = append(args, orgID)
+
+	// Build dynamic query
+	query := dbmodel.DBQuery{
+		ID:    QueryGetAttributesByConsentIDs.ID,
+		Query: fmt.Sprintf("SELECT CONSENT_ID, ATT_KEY, ATT_VALUE, ORG_ID FROM CONSENT_ATTRIBUTE WHERE CONSENT_ID IN (%s) AND ORG_ID = ?", placeholders),
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group attributes by consent ID
 	result := make(map[string]map[string]string)
 	for _, row := range rows {
-		attr := mapToConsentAttribute(row)
-		if attr != nil {
-			if result[attr.ConsentID] == nil {
-				result[attr.ConsentID] = make(map[string]string)
-			}
-			result[attr.ConsentID][attr.AttKey] = attr.AttValue
-		}
+		attr := mapToConsentAttribute(row)
+		if attr != nil {
+			if result[attr.ConsentID] == nil {
+				result[attr.ConsentID] = make(map[string]string)
+			}
+			result[attr.ConsentID][attr.AttKey] = attr.AttValue
+		}
+	}
+
+	return result, nil
+}
+
+// CreateRequiredAuthorizers stores the authorizer roles that must approve a consent within a transaction
+func (s *store) CreateRequiredAuthorizers(tx dbmodel.TxInterface, consentID, orgID string, roles []string) error {
+	for _, role := range roles {
+		if _, err := tx.Exec(QueryCreateRequiredAuthorizer.Query, consentID, role, orgID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRequiredAuthorizers retrieves the authorizer roles that must approve a consent, in name order
+func (s *store) GetRequiredAuthorizers(ctx context.Context, consentID, orgID string) ([]string, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetRequiredAuthorizers, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if role, ok := row["authorizer_role"].(string); ok {
+			roles = append(roles, role)
+		} else if role, ok := row["authorizer_role"].([]byte); ok {
+			roles = append(roles, string(role))
+		}
+	}
+	return roles, nil
+}
+
+// DeleteAttributesByConsentID deletes all attributes for a consent within a transaction
+func (s *store) DeleteAttributesByConsentID(tx dbmodel.TxInterface, consentID, orgID string) error {
+	_, err := tx.Exec(QueryDeleteAttributesByConsentID.Query, consentID, orgID)
+	return err
+}
+
+// DeleteAttributesByKeys deletes the attributes of a consent with the given keys within a transaction
+func (s *store) DeleteAttributesByKeys(tx dbmodel.TxInterface, consentID, orgID string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	placeholders := ""
+	args := make([]interface{}, 0, len(keys)+2)
+	args = append(args, consentID, orgID)
+	for i, key := range keys {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += "?"
+		args = append(args, key)
+	}
+
+	_, err := tx.Exec(fmt.Sprintf("DELETE FROM CONSENT_ATTRIBUTE WHERE CONSENT_ID = ? AND ORG_ID = ? AND ATT_KEY IN (%s)", placeholders), args...)
+	return err
+}
+
+// FindConsentIDsByAttributeKey finds all consent IDs that have a specific attribute key
+func (s *store) FindConsentIDsByAttributeKey(ctx context.Context, key, orgID string) ([]string, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryFindConsentIDsByAttributeKey, key, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	consentIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		// Try lowercase first (normalized), then uppercase (raw)
+		if consentID, ok := row["consent_id"].(string); ok {
+			consentIDs = append(consentIDs, consentID)
+		} else if consentID, ok := row["consent_id"].([]byte); ok {
+			consentIDs = append(consentIDs, string(consentID))
+		} else if consentID, ok := row["CONSENT_ID"].(string); ok {
+			consentIDs = append(consentIDs, consentID)
+		} else if consentID, ok := row["CONSENT_ID"].([]byte); ok {
+			consentIDs = append(consentIDs, string(consentID))
+		}
+	}
+
+	return consentIDs, nil
+}
+
+// FindConsentIDsByAttribute finds all consent IDs that have a specific attribute key-value pair
+func (s *store) FindConsentIDsByAttribute(ctx context.Context, key, value, orgID string) ([]string, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryFindConsentIDsByAttribute, key, value, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	consentIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		// Try lowercase first (normalized), then uppercase (raw)
+		if consentID, ok := row["consent_id"].(string); ok {
+			consentIDs = append(consentIDs, consentID)
+		} else if consentID, ok := row["consent_id"].([]byte); ok {
+			consentIDs = append(consentIDs, string(consentID))
+		} else if consentID, ok := row["CONSENT_ID"].(string); ok {
+			consentIDs = append(consentIDs, consentID)
+		} else if consentID, ok := row["CONSENT_ID"].([]byte); ok {
+			consentIDs = append(consentIDs, string(consentID))
+		}
+	}
+
+	return consentIDs, nil
+}
+
+// FindConsentIDsByAttributes finds all consent IDs that have every listed attribute
+func (s *store) FindConsentIDsByAttributes(ctx context.Context, attributes []model.AttributeFilter, orgID string) ([]string, error) {
+	conditions, attributeArgs := attributeFilterConditions(attributes)
+	whereConditions := append([]string{"CONSENT.ORG_ID = ?", "CONSENT.CURRENT_STATUS <> 'DELETED'"}, conditions...)
+	args := append([]interface{}{orgID}, attributeArgs...)
+
+	query := dbmodel.DBQuery{
+		ID: "FIND_CONSENT_IDS_BY_ATTRIBUTES",
+		Query: "SELECT CONSENT.CONSENT_ID AS consent_id FROM CONSENT WHERE " +
+			strings.Join(whereConditions, " AND ") + " ORDER BY consent_id",
+	}
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	consentIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if consentID, ok := row["consent_id"].(string); ok {
+			consentIDs = append(consentIDs, consentID)
+		} else if consentID, ok := row["consent_id"].([]byte); ok {
+			consentIDs = append(consentIDs, string(consentID))
+		}
+	}
+
+	return consentIDs, nil
+}
+
+// escapeLikePattern escapes the LIKE wildcards of a search text with '!', the ESCAPE character of the
+// free-text search
+func escapeLikePattern(text string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(text)
+}
+
+// attributeFilterConditions builds one EXISTS condition per attribute filter, so a consent must
+// have every attribute to match. The conditions correlate with the outer CONSENT table.
+func attributeFilterConditions(attributes []model.AttributeFilter) ([]string, []interface{}) {
+	conditions := make([]string, 0, len(attributes))
+	args := make([]interface{}, 0, len(attributes)*2)
+	for i, attribute := range attributes {
+		alias := fmt.Sprintf("attr%d", i)
+		condition := fmt.Sprintf("EXISTS (SELECT 1 FROM CONSENT_ATTRIBUTE %[1]s WHERE %[1]s.CONSENT_ID = CONSENT.CONSENT_ID AND %[1]s.ORG_ID = CONSENT.ORG_ID AND %[1]s.ATT_KEY = ?", alias)
+		args = append(args, attribute.Key)
+		if attribute.Value != "" {
+			condition += fmt.Sprintf(" AND %s.ATT_VALUE = ?", alias)
+			args = append(args, attribute.Value)
+		}
+		conditions = append(conditions, condition+")")
+	}
+	return conditions, args
+}
+
+// filterColumns maps the consent fields of a filter expression to their columns
+var filterColumns = map[string]string{
+	filter.AttributeStatus:      "CONSENT.CURRENT_STATUS",
+	filter.AttributeType:        "CONSENT.CONSENT_TYPE",
+	filter.AttributeClientID:    "CONSENT.CLIENT_ID",
+	filter.AttributeCreatedTime: "CONSENT.CREATED_TIME",
+}
+
+// filterSQLOperators maps the comparison operators of a filter expression to SQL
+var filterSQLOperators = map[filter.Operator]string{
+	filter.OperatorEq: "=",
+	filter.OperatorNe: "<>",
+	filter.OperatorGt: ">",
+	filter.OperatorLt: "<",
+}
+
+// filterCondition translates a parsed filter expression into a parenthesized SQL condition.
+// aliases numbers the attribute subqueries so that each has its own alias.
+func filterCondition(expr filter.Expression, aliases *int) (string, []interface{}) {
+	switch e := expr.(type) {
+	case *filter.Logical:
+		left, leftArgs := filterCondition(e.Left, aliases)
+		right, rightArgs := filterCondition(e.Right, aliases)
+		return fmt.Sprintf("(%s %s %s)", left, strings.ToUpper(e.Op), right), append(leftArgs, rightArgs...)
+	case *filter.Comparison:
+		if e.Attribute == filter.AttributeAttributes {
+			alias := fmt.Sprintf("fattr%d", *aliases)
+			*aliases++
+			condition := fmt.Sprintf("EXISTS (SELECT 1 FROM CONSENT_ATTRIBUTE %[1]s WHERE %[1]s.CONSENT_ID = CONSENT.CONSENT_ID AND %[1]s.ORG_ID = CONSENT.ORG_ID AND %[1]s.ATT_KEY = ? AND %[1]s.ATT_VALUE = ?)", alias)
+			// ne matches consents without the attribute as well as those with another value
+			if e.Operator == filter.OperatorNe {
+				condition = "NOT " + condition
+			}
+			return "(" + condition + ")", []interface{}{e.Key, e.Value}
+		}
+		value := e.Value
+		if e.Attribute == filter.AttributeStatus {
+			value = strings.ToUpper(e.Value.(string))
+		}
+		return fmt.Sprintf("(%s %s ?)", filterColumns[e.Attribute], filterSQLOperators[e.Operator]), []interface{}{value}
+	}
+	// The parser only produces comparisons and logical expressions
+	return "(1 = 0)", nil
+}
+
+// CreateStatusAudit creates a status audit entry within a transaction
+func (s *store) CreateStatusAudit(tx dbmodel.TxInterface, audit *model.ConsentStatusAudit) error {
+	_, err := tx.Exec(QueryCreateStatusAudit.Query,
+		audit.StatusAuditID, audit.ConsentID, audit.CurrentStatus, audit.ActionTime,
+		audit.Reason, audit.ActionBy, audit.PreviousStatus, audit.OrgID)
+	return err
+}
+
+// GetStatusAuditByConsentID retrieves status audit history for a consent
+func (s *store) GetStatusAuditByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentStatusAudit, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetStatusAuditByConsentID, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]model.ConsentStatusAudit, 0, len(rows))
+	for _, row := range rows {
+		audit := mapToStatusAudit(row)
+		if audit != nil {
+			audits = append(audits, *audit)
+		}
+	}
+
+	return audits, nil
+}
+
+// SearchStatusAudit retrieves status audit entries matching the filters, newest first, with the
+// total number of matching entries
+func (s *store) SearchStatusAudit(ctx context.Context, filters model.StatusAuditSearchFilters) ([]model.ConsentStatusAudit, int, error) {
+	whereConditions := []string{}
+	args := []interface{}{}
+
+	equalFilters := []struct {
+		condition string
+		value     string
+	}{
+		{"ORG_ID = ?", filters.OrgID},
+		{"CONSENT_ID = ?", filters.ConsentID},
+		{"ACTION_BY = ?", filters.ActionBy},
+	}
+	for _, equalFilter := range equalFilters {
+		if equalFilter.value != "" {
+			whereConditions = append(whereConditions, equalFilter.condition)
+			args = append(args, equalFilter.value)
+		}
+	}
+
+	statusFilters := []struct {
+		column   string
+		statuses []string
+	}{
+		{"PREVIOUS_STATUS", filters.FromStatuses},
+		{"CURRENT_STATUS", filters.ToStatuses},
+	}
+	for _, statusFilter := range statusFilters {
+		if len(statusFilter.statuses) == 0 {
+			continue
+		}
+		placeholders := make([]string, len(statusFilter.statuses))
+		for i, status := range statusFilter.statuses {
+			placeholders[i] = "?"
+			args = append(args, strings.ToUpper(status))
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("%s IN (%s)", statusFilter.column, strings.Join(placeholders, ",")))
+	}
+
+	if filters.FromTime != nil {
+		whereConditions = append(whereConditions, "ACTION_TIME >= ?")
+		args = append(args, *filters.FromTime)
+	}
+	if filters.ToTime != nil {
+		whereConditions = append(whereConditions, "ACTION_TIME <= ?")
+		args = append(args, *filters.ToTime)
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	// Without an organization filter the search spans every organization
+	crossTenant := filters.OrgID == ""
+
+	countRows, err := s.dbClient.QueryContext(ctx, dbmodel.DBQuery{
+		ID:          "COUNT_STATUS_AUDIT_SEARCH_RESULTS",
+		Query:       "SELECT COUNT(*) as count FROM CONSENT_STATUS_AUDIT" + whereClause,
+		CrossTenant: crossTenant,
+	}, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	totalCount := 0
+	if len(countRows) > 0 {
+		if count, ok := countRows[0]["count"].(int64); ok {
+			totalCount = int(count)
+		}
+	}
+
+	// STATUS_AUDIT_ID breaks ties between entries recorded at the same time so the order is stable
+	// across pages
+	selectQuery := "SELECT STATUS_AUDIT_ID, CONSENT_ID, CURRENT_STATUS, ACTION_TIME, REASON, ACTION_BY, PREVIOUS_STATUS, ORG_ID FROM CONSENT_STATUS_AUDIT" +
+		whereClause + " ORDER BY ACTION_TIME DESC, STATUS_AUDIT_ID DESC LIMIT ? OFFSET ?"
+	args = append(args, filters.Limit, filters.Offset)
+
+	rows, err := s.dbClient.QueryContext(ctx, dbmodel.DBQuery{ID: "SEARCH_STATUS_AUDIT", Query: selectQuery, CrossTenant: crossTenant}, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	audits := make([]model.ConsentStatusAudit, 0, len(rows))
+	for _, row := range rows {
+		audit := mapToStatusAudit(row)
+		if audit != nil {
+			audits = append(audits, *audit)
+		}
+	}
+
+	return audits, totalCount, nil
+}
+
+// RecordUsage counts an access to a consent in the period starting at periodStart, unless the
+// period already has limit accesses. It reports whether the access was counted.
+func (s *store) RecordUsage(ctx context.Context, consentID, orgID string, periodStart int64, limit int, accessTime int64) (bool, error) {
+	updated, err := s.dbClient.ExecuteContext(ctx, QueryIncrementConsentUsage, accessTime, consentID, orgID, periodStart, limit)
+	if err != nil || updated > 0 {
+		return updated > 0, err
+	}
+
+	created, err := s.dbClient.ExecuteContext(ctx, QueryCreateConsentUsage, consentID, orgID, periodStart, accessTime)
+	if err != nil || created > 0 {
+		return created > 0, err
+	}
+
+	// The period exists, either at its limit or created by a concurrent access since the update
+	updated, err = s.dbClient.ExecuteContext(ctx, QueryIncrementConsentUsage, accessTime, consentID, orgID, periodStart, limit)
+	return updated > 0, err
+}
+
+// GetUsage retrieves the usage of a consent in the period starting at periodStart, or nil when
+// the consent was not accessed in that period
+func (s *store) GetUsage(ctx context.Context, consentID, orgID string, periodStart int64) (*model.ConsentUsage, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetConsentUsage, consentID, orgID, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	row := rows[0]
+	usage := &model.ConsentUsage{ConsentID: consentID, OrgID: orgID, PeriodStart: periodStart}
+	if count, ok := row["access_count"].(int64); ok {
+		usage.AccessCount = int(count)
+	}
+	if lastAccess, ok := row["last_access_time"].(int64); ok {
+		usage.LastAccessTime = lastAccess
+	}
+	return usage, nil
+}
+
+// AcquireLock takes the lock of a consent, unless another caller holds it. The lock replaces an
+// expired lock, and renews the lock when it has the token of the current one. It reports whether
+// the lock was taken.
+func (s *store) AcquireLock(ctx context.Context, lock *model.ConsentLock) (bool, error) {
+	updated, err := s.dbClient.ExecuteContext(ctx, QueryRenewConsentLock, lock.LockToken, lock.LockedBy, lock.LockedTime,
+		lock.ExpiryTime, lock.ConsentID, lock.OrgID, lock.LockedTime, lock.LockToken)
+	if err != nil || updated > 0 {
+		return updated > 0, err
+	}
+
+	// No lock exists, or it is held by another caller, or a concurrent request created one since the update
+	created, err := s.dbClient.ExecuteContext(ctx, QueryCreateConsentLock, lock.ConsentID, lock.OrgID, lock.LockToken,
+		lock.LockedBy, lock.LockedTime, lock.ExpiryTime)
+	return created > 0, err
+}
+
+// GetLock retrieves the lock of a consent, or nil when it has none. The lock may have expired.
+func (s *store) GetLock(ctx context.Context, consentID, orgID string) (*model.ConsentLock, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetConsentLock, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	row := rows[0]
+	lock := &model.ConsentLock{ConsentID: consentID, OrgID: orgID}
+	if token, ok := row["lock_token"].(string); ok {
+		lock.LockToken = token
+	} else if token, ok := row["lock_token"].([]byte); ok {
+		lock.LockToken = string(token)
+	}
+	if lockedBy, ok := row["locked_by"].(string); ok {
+		lock.LockedBy = lockedBy
+	} else if lockedBy, ok := row["locked_by"].([]byte); ok {
+		lock.LockedBy = string(lockedBy)
+	}
+	if lockedTime, ok := row["locked_time"].(int64); ok {
+		lock.LockedTime = lockedTime
+	}
+	if expiryTime, ok := row["expiry_time"].(int64); ok {
+		lock.ExpiryTime = expiryTime
+	}
+	return lock, nil
+}
+
+// ReleaseLock deletes the lock of a consent with the given token. It reports whether a lock was deleted.
+func (s *store) ReleaseLock(ctx context.Context, consentID, orgID, lockToken string) (bool, error) {
+	deleted, err := s.dbClient.ExecuteContext(ctx, QueryDeleteConsentLock, consentID, orgID, lockToken)
+	return deleted > 0, err
+}
+
+// SaveModifiedResponse stores the modifiedResponse document of a consent, replacing the previous one
+func (s *store) SaveModifiedResponse(ctx context.Context, consentID, orgID, document string, updatedTime int64) error {
+	updated, err := s.dbClient.ExecuteContext(ctx, QueryUpdateModifiedResponse, document, updatedTime, consentID, orgID)
+	if err != nil || updated > 0 {
+		return err
+	}
+
+	// No document exists, it is unchanged, or a concurrent request created one since the update
+	created, err := s.dbClient.ExecuteContext(ctx, QueryCreateModifiedResponse, consentID, orgID, document, updatedTime)
+	if err != nil || created > 0 {
+		return err
+	}
+	_, err = s.dbClient.ExecuteContext(ctx, QueryUpdateModifiedResponse, document, updatedTime, consentID, orgID)
+	return err
+}
+
+// GetModifiedResponse retrieves the modifiedResponse document of a consent, or an empty string
+// when it has none
+func (s *store) GetModifiedResponse(ctx context.Context, consentID, orgID string) (string, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetModifiedResponse, consentID, orgID)
+	if err != nil || len(rows) == 0 {
+		return "", err
+	}
+	switch document := rows[0]["document"].(type) {
+	case string:
+		return document, nil
+	case []byte:
+		return string(document), nil
+	}
+	return "", nil
+}
+
+// AddTags adds tags to a consent. Tags the consent already has are left as they are.
+func (s *store) AddTags(ctx context.Context, consentID, orgID string, tags []string, createdTime int64) error {
+	for _, tag := range tags {
+		if _, err := s.dbClient.ExecuteContext(ctx, QueryCreateConsentTag, consentID, tag, createdTime, orgID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveTag removes a tag from a consent. It reports whether the consent had the tag.
+func (s *store) RemoveTag(ctx context.Context, consentID, orgID, tag string) (bool, error) {
+	deleted, err := s.dbClient.ExecuteContext(ctx, QueryDeleteConsentTag, consentID, tag, orgID)
+	return deleted > 0, err
+}
+
+// GetTagsByConsentID retrieves the tags of a consent in alphabetical order
+func (s *store) GetTagsByConsentID(ctx context.Context, consentID, orgID string) ([]string, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetTagsByConsentID, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if tag, ok := row["tag"].(string); ok {
+			tags = append(tags, tag)
+		} else if tag, ok := row["tag"].([]byte); ok {
+			tags = append(tags, string(tag))
+		}
+	}
+	return tags, nil
+}
+
+// GetTagsByConsentIDs retrieves the tags of multiple consents in alphabetical order, grouped by consent ID
+func (s *store) GetTagsByConsentIDs(ctx context.Context, consentIDs []string, orgID string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if len(consentIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(consentIDs))
+	args := make([]interface{}, 0, len(consentIDs)+1)
+	for i, id := range consentIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	args = append(args, orgID)
+
+	query := dbmodel.DBQuery{
+		ID: QueryGetTagsByConsentIDs.ID,
+		Query: fmt.Sprintf("SELECT CONSENT_ID, TAG FROM CONSENT_TAG WHERE CONSENT_ID IN (%s) AND ORG_ID = ? ORDER BY CONSENT_ID, TAG",
+			strings.Join(placeholders, ", ")),
+	}
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		var consentID, tag string
+		if id, ok := row["consent_id"].(string); ok {
+			consentID = id
+		} else if id, ok := row["consent_id"].([]byte); ok {
+			consentID = string(id)
+		}
+		if t, ok := row["tag"].(string); ok {
+			tag = t
+		} else if t, ok := row["tag"].([]byte); ok {
+			tag = string(t)
+		}
+		result[consentID] = append(result[consentID], tag)
+	}
+	return result, nil
+}
+
+// CreateNote stores a note on a consent
+func (s *store) CreateNote(ctx context.Context, note *model.ConsentNote) error {
+	_, err := s.dbClient.ExecuteContext(ctx, QueryCreateConsentNote, note.ID, note.ConsentID, note.Author, note.Text,
+		note.CreatedTime, note.OrgID)
+	return err
+}
+
+// GetNotesByConsentID retrieves the notes of a consent, oldest first
+func (s *store) GetNotesByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentNote, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetNotesByConsentID, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]model.ConsentNote, 0, len(rows))
+	for _, row := range rows {
+		note := model.ConsentNote{ConsentID: consentID, OrgID: orgID}
+		if noteID, ok := row["note_id"].(string); ok {
+			note.ID = noteID
+		} else if noteID, ok := row["note_id"].([]byte); ok {
+			note.ID = string(noteID)
+		}
+		if author, ok := row["author"].(string); ok {
+			note.Author = author
+		} else if author, ok := row["author"].([]byte); ok {
+			note.Author = string(author)
+		}
+		if text, ok := row["note_text"].(string); ok {
+			note.Text = text
+		} else if text, ok := row["note_text"].([]byte); ok {
+			note.Text = string(text)
+		}
+		if createdTime, ok := row["created_time"].(int64); ok {
+			note.CreatedTime = createdTime
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// CreateEvidence stores the evidence of a consent within a transaction
+func (s *store) CreateEvidence(tx dbmodel.TxInterface, evidence *model.ConsentEvidence) error {
+	_, err := tx.Exec(QueryCreateConsentEvidence.Query, evidence.ID, evidence.ConsentID, evidence.ConsentVersion,
+		evidence.Channel, evidence.IPAddress, evidence.UserAgent, evidence.UIVersion, evidence.PresentedTextChecksum,
+		evidence.CapturedTime, evidence.OrgID)
+	return err
+}
+
+// AnonymizeEvidence removes the IP addresses and user agents from the evidence of a consent within
+// a transaction
+func (s *store) AnonymizeEvidence(tx dbmodel.TxInterface, consentID, orgID string) error {
+	_, err := tx.Exec(QueryAnonymizeEvidence.Query, consentID, orgID)
+	return err
+}
+
+// GetEvidenceByConsentID retrieves the evidence of a consent, oldest first
+func (s *store) GetEvidenceByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentEvidence, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetEvidenceByConsentID, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	evidence := make([]model.ConsentEvidence, 0, len(rows))
+	for _, row := range rows {
+		evidence = append(evidence, *mapToConsentEvidence(row, consentID, orgID))
+	}
+	return evidence, nil
+}
+
+// CreateToken binds a token to a consent
+func (s *store) CreateToken(ctx context.Context, token *model.ConsentToken) error {
+	_, err := s.dbClient.ExecuteContext(ctx, QueryCreateConsentToken, token.TokenID, token.ConsentID, token.TokenType,
+		token.ExpiresAt, token.CreatedTime, token.OrgID)
+	return err
+}
+
+// GetToken retrieves the binding of a token, nil when the token is not bound to a consent
+func (s *store) GetToken(ctx context.Context, tokenID, orgID string) (*model.ConsentToken, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetConsentToken, tokenID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return mapToConsentToken(rows[0]), nil
+}
+
+// GetTokensByConsentID retrieves the tokens bound to a consent that have not expired by now, in
+// milliseconds, oldest first
+func (s *store) GetTokensByConsentID(ctx context.Context, consentID, orgID string, now int64) ([]model.ConsentToken, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetTokensByConsentID, consentID, orgID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]model.ConsentToken, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, *mapToConsentToken(row))
 	}
+	return tokens, nil
+}
 
-	return result, nil
+// DeleteToken removes the binding of a token to a consent, reporting whether it was bound
+func (s *store) DeleteToken(ctx context.Context, tokenID, consentID, orgID string) (bool, error) {
+	deleted, err := s.dbClient.ExecuteContext(ctx, QueryDeleteConsentToken, tokenID, consentID, orgID)
+	return deleted > 0, err
 }
 
-// DeleteAttributesByConsentID deletes all attributes for a consent within a transaction
-func (s *store) DeleteAttributesByConsentID(tx dbmodel.TxInterface, consentID, orgID string) error {
-	_, err := tx.Exec(QueryDeleteAttributesByConsentID.Query, consentID, orgID)
+// DeleteTokensByConsentID removes the bindings of all tokens of a consent within a transaction
+func (s *store) DeleteTokensByConsentID(tx dbmodel.TxInterface, consentID, orgID string) error {
+	_, err := tx.Exec(QueryDeleteTokensByConsentID.Query, consentID, orgID)
 	return err
 }
 
-// FindConsentIDsByAttributeKey finds all consent IDs that have a specific attribute key
-func (s *store) FindConsentIDsByAttributeKey(ctx context.Context, key, orgID string) ([]string, error) {
-	rows, err := s.dbClient.Query(QueryFindConsentIDsByAttributeKey, key, orgID)
+// DeleteExpiredTokens removes the bindings of tokens that expired by now, in milliseconds, and
+// returns how many were removed
+func (s *store) DeleteExpiredTokens(ctx context.Context, now int64) (int64, error) {
+	return s.dbClient.ExecuteContext(ctx, QueryDeleteExpiredTokens, now)
+}
+
+// SaveScheduledRevocation stores the scheduled revocation of a consent within a transaction,
+// replacing the one it had
+func (s *store) SaveScheduledRevocation(tx dbmodel.TxInterface, revocation *model.ScheduledRevocation) error {
+	request, err := json.Marshal(revocation.Request)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(QueryDeleteScheduledRevocation.Query, revocation.ConsentID, revocation.OrgID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(QueryCreateScheduledRevocation.Query, revocation.ConsentID, revocation.EffectiveAt, string(request),
+		revocation.CreatedTime, revocation.OrgID)
+	return err
+}
+
+// DeleteScheduledRevocation deletes the scheduled revocation of a consent within a transaction
+func (s *store) DeleteScheduledRevocation(tx dbmodel.TxInterface, consentID, orgID string) error {
+	_, err := tx.Exec(QueryDeleteScheduledRevocation.Query, consentID, orgID)
+	return err
+}
+
+// GetScheduledRevocation retrieves the scheduled revocation of a consent, or nil when it has none
+func (s *store) GetScheduledRevocation(ctx context.Context, consentID, orgID string) (*model.ScheduledRevocation, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetScheduledRevocation, consentID, orgID)
 	if err != nil {
 		return nil, err
 	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return mapToScheduledRevocation(rows[0])
+}
 
-	consentIDs := make([]string, 0, len(rows))
+// GetDueScheduledRevocations retrieves up to limit scheduled revocations of any organization
+// whose effective time is at or before dueBy, in milliseconds
+func (s *store) GetDueScheduledRevocations(ctx context.Context, dueBy int64, limit int) ([]model.ScheduledRevocation, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetDueScheduledRevocations, dueBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	revocations := make([]model.ScheduledRevocation, 0, len(rows))
 	for _, row := range rows {
-		// Try lowercase first (normalized), then uppercase (raw)
-		if consentID, ok := row["consent_id"].(string); ok {
-			consentIDs = append(consentIDs, consentID)
-		} else if consentID, ok := row["consent_id"].([]byte); ok {
-			consentIDs = append(consentIDs, string(consentID))
-		} else if consentID, ok := row["CONSENT_ID"].(string); ok {
-			consentIDs = append(consentIDs, consentID)
-		} else if consentID, ok := row["CONSENT_ID"].([]byte); ok {
-			consentIDs = append(consentIDs, string(consentID))
+		revocation, err := mapToScheduledRevocation(row)
+		if err != nil {
+			return nil, err
 		}
+		revocations = append(revocations, *revocation)
 	}
+	return revocations, nil
+}
 
-	return consentIDs, nil
+// ClaimScheduledRevocation deletes a scheduled revocation read from the store within the
+// transaction that applies it, so that only one server applies it. Returns
+// ErrScheduledRevocationClaimed when the revocation was replaced, cancelled or claimed since.
+func (s *store) ClaimScheduledRevocation(tx dbmodel.TxInterface, revocation *model.ScheduledRevocation) error {
+	result, err := tx.Exec(QueryClaimScheduledRevocation.Query, revocation.ConsentID, revocation.OrgID,
+		revocation.EffectiveAt, revocation.CreatedTime)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrScheduledRevocationClaimed
+	}
+
+	return nil
 }
 
-// FindConsentIDsByAttribute finds all consent IDs that have a specific attribute key-value pair
-func (s *store) FindConsentIDsByAttribute(ctx context.Context, key, value, orgID string) ([]string, error) {
-	rows, err := s.dbClient.Query(QueryFindConsentIDsByAttribute, key, value, orgID)
+// mapToScheduledRevocation converts a CONSENT_SCHEDULED_REVOCATION row
+func mapToScheduledRevocation(row map[string]interface{}) (*model.ScheduledRevocation, error) {
+	revocation := &model.ScheduledRevocation{}
+	if consentID, ok := row["consent_id"].(string); ok {
+		revocation.ConsentID = consentID
+	} else if consentID, ok := row["consent_id"].([]byte); ok {
+		revocation.ConsentID = string(consentID)
+	}
+	if orgID, ok := row["org_id"].(string); ok {
+		revocation.OrgID = orgID
+	} else if orgID, ok := row["org_id"].([]byte); ok {
+		revocation.OrgID = string(orgID)
+	}
+	if effectiveAt, ok := row["effective_at"].(int64); ok {
+		revocation.EffectiveAt = effectiveAt
+	}
+	if createdTime, ok := row["created_time"].(int64); ok {
+		revocation.CreatedTime = createdTime
+	}
+
+	var request []byte
+	if value, ok := row["revoke_request"].(string); ok {
+		request = []byte(value)
+	} else if value, ok := row["revoke_request"].([]byte); ok {
+		request = value
+	}
+	if err := json.Unmarshal(request, &revocation.Request); err != nil {
+		return nil, fmt.Errorf("invalid revoke request of scheduled revocation of consent %s: %w", revocation.ConsentID, err)
+	}
+	return revocation, nil
+}
+
+// SaveScheduledUpdate stores the scheduled update of a consent within a transaction, replacing the
+// one it had
+func (s *store) SaveScheduledUpdate(tx dbmodel.TxInterface, update *model.ScheduledUpdate) error {
+	request, err := json.Marshal(update.Request)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(QueryDeleteScheduledUpdate.Query, update.ConsentID, update.OrgID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(QueryCreateScheduledUpdate.Query, update.ConsentID, update.EffectiveAt, string(request),
+		update.CreatedTime, update.OrgID)
+	return err
+}
+
+// DeleteScheduledUpdate deletes the scheduled update of a consent within a transaction
+func (s *store) DeleteScheduledUpdate(tx dbmodel.TxInterface, consentID, orgID string) error {
+	_, err := tx.Exec(QueryDeleteScheduledUpdate.Query, consentID, orgID)
+	return err
+}
+
+// GetScheduledUpdate retrieves the scheduled update of a consent, or nil when it has none
+func (s *store) GetScheduledUpdate(ctx context.Context, consentID, orgID string) (*model.ScheduledUpdate, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetScheduledUpdate, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return mapToScheduledUpdate(rows[0])
+}
+
+// GetDueScheduledUpdates retrieves up to limit scheduled updates of any organization whose
+// effective time is at or before dueBy, in milliseconds
+func (s *store) GetDueScheduledUpdates(ctx context.Context, dueBy int64, limit int) ([]model.ScheduledUpdate, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetDueScheduledUpdates, dueBy, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	consentIDs := make([]string, 0, len(rows))
+	updates := make([]model.ScheduledUpdate, 0, len(rows))
 	for _, row := range rows {
-		// Try lowercase first (normalized), then uppercase (raw)
-		if consentID, ok := row["consent_id"].(string); ok {
-			consentIDs = append(consentIDs, consentID)
-		} else if consentID, ok := row["consent_id"].([]byte); ok {
-			consentIDs = append(consentIDs, string(consentID))
-		} else if consentID, ok := row["CONSENT_ID"].(string); ok {
-			consentIDs = append(consentIDs, consentID)
-		} else if consentID, ok := row["CONSENT_ID"].([]byte); ok {
-			consentIDs = append(consentIDs, string(consentID))
+		update, err := mapToScheduledUpdate(row)
+		if err != nil {
+			return nil, err
 		}
+		updates = append(updates, *update)
+	}
+	return updates, nil
+}
+
+// ClaimScheduledUpdate deletes a scheduled update read from the store within the transaction that
+// applies it, so that only one server applies it. Returns ErrScheduledUpdateClaimed when the
+// update was replaced, cancelled or claimed since.
+func (s *store) ClaimScheduledUpdate(tx dbmodel.TxInterface, update *model.ScheduledUpdate) error {
+	result, err := tx.Exec(QueryClaimScheduledUpdate.Query, update.ConsentID, update.OrgID,
+		update.EffectiveAt, update.CreatedTime)
+	if err != nil {
+		return err
 	}
 
-	return consentIDs, nil
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrScheduledUpdateClaimed
+	}
+
+	return nil
 }
 
-// CreateStatusAudit creates a status audit entry within a transaction
-func (s *store) CreateStatusAudit(tx dbmodel.TxInterface, audit *model.ConsentStatusAudit) error {
-	_, err := tx.Exec(QueryCreateStatusAudit.Query,
-		audit.StatusAuditID, audit.ConsentID, audit.CurrentStatus, audit.ActionTime,
-		audit.Reason, audit.ActionBy, audit.PreviousStatus, audit.OrgID)
+// mapToScheduledUpdate converts a CONSENT_SCHEDULED_UPDATE row
+func mapToScheduledUpdate(row map[string]interface{}) (*model.ScheduledUpdate, error) {
+	update := &model.ScheduledUpdate{}
+	if consentID, ok := row["consent_id"].(string); ok {
+		update.ConsentID = consentID
+	} else if consentID, ok := row["consent_id"].([]byte); ok {
+		update.ConsentID = string(consentID)
+	}
+	if orgID, ok := row["org_id"].(string); ok {
+		update.OrgID = orgID
+	} else if orgID, ok := row["org_id"].([]byte); ok {
+		update.OrgID = string(orgID)
+	}
+	if effectiveAt, ok := row["effective_at"].(int64); ok {
+		update.EffectiveAt = effectiveAt
+	}
+	if createdTime, ok := row["created_time"].(int64); ok {
+		update.CreatedTime = createdTime
+	}
+
+	var request []byte
+	if value, ok := row["update_request"].(string); ok {
+		request = []byte(value)
+	} else if value, ok := row["update_request"].([]byte); ok {
+		request = value
+	}
+	if err := json.Unmarshal(request, &update.Request); err != nil {
+		return nil, fmt.Errorf("invalid update request of scheduled update of consent %s: %w", update.ConsentID, err)
+	}
+	return update, nil
+}
+
+// CreateHistory stores a snapshot of a superseded consent version within a transaction
+func (s *store) CreateHistory(tx dbmodel.TxInterface, history *model.ConsentHistory) error {
+	_, err := tx.Exec(QueryCreateConsentHistory.Query,
+		history.ConsentID, history.Version, history.Snapshot, history.AmendedTime,
+		history.AmendedBy, history.Reason, history.OrgID)
 	return err
 }
 
-// GetStatusAuditByConsentID retrieves status audit history for a consent
-func (s *store) GetStatusAuditByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentStatusAudit, error) {
-	rows, err := s.dbClient.Query(QueryGetStatusAuditByConsentID, consentID, orgID)
+// GetHistoryByConsentID retrieves the superseded versions of a consent, newest first.
+// Snapshots are not loaded.
+func (s *store) GetHistoryByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentHistory, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetHistoryByConsentID, consentID, orgID)
 	if err != nil {
 		return nil, err
 	}
 
-	audits := make([]model.ConsentStatusAudit, 0, len(rows))
+	history := make([]model.ConsentHistory, 0, len(rows))
 	for _, row := range rows {
-		audit := mapToStatusAudit(row)
-		if audit != nil {
-			audits = append(audits, *audit)
+		entry := mapToConsentHistory(row)
+		if entry != nil {
+			history = append(history, *entry)
 		}
 	}
 
-	return audits, nil
+	return history, nil
+}
+
+// GetHistoryByConsentIDs retrieves the superseded versions of several consents, grouped by consent ID
+// and newest first
+func (s *store) GetHistoryByConsentIDs(ctx context.Context, consentIDs []string, orgID string) (map[string][]model.ConsentHistory, error) {
+	if len(consentIDs) == 0 {
+		return make(map[string][]model.ConsentHistory), nil
+	}
+
+	// Build placeholders for IN clause
+	placeholders := ""
+	args := make([]interface{}, 0, len(consentIDs)+1)
+	for i, id := range consentIDs {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += "?"
+		args = append(args, id)
+	}
+	args = append(args, orgID)
+
+	query := dbmodel.DBQuery{
+		ID:    QueryGetHistoryByConsentIDs.ID,
+		Query: fmt.Sprintf("SELECT CONSENT_ID, VERSION, AMENDED_TIME, AMENDED_BY, REASON, ORG_ID FROM CONSENT_HISTORY WHERE CONSENT_ID IN (%s) AND ORG_ID = ? ORDER BY CONSENT_ID, VERSION DESC", placeholders),
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]model.ConsentHistory)
+	for _, row := range rows {
+		entry := mapToConsentHistory(row)
+		if entry != nil {
+			result[entry.ConsentID] = append(result[entry.ConsentID], *entry)
+		}
+	}
+
+	return result, nil
+}
+
+// GetHistoryByVersion retrieves the snapshot of a superseded consent version
+func (s *store) GetHistoryByVersion(ctx context.Context, consentID, orgID string, version int) (*model.ConsentHistory, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetHistoryByVersion, consentID, version, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return mapToConsentHistory(rows[0]), nil
 }
 
 // Mapper functions
@@ -548,6 +2136,17 @@ func mapToConsent(row map[string]interface{}) *model.Consent {
 		consent.DataAccessValidityDuration = &duration
 	}
 
+	if parentID, ok := row["parent_consent_id"].(string); ok {
+		consent.ParentConsentID = &parentID
+	} else if parentID, ok := row["parent_consent_id"].([]byte); ok {
+		parentIDStr := string(parentID)
+		consent.ParentConsentID = &parentIDStr
+	}
+
+	if version, ok := row["version"].(int64); ok {
+		consent.Version = int(version)
+	}
+
 	if orgID, ok := row["org_id"].(string); ok {
 		consent.OrgID = orgID
 	} else if orgID, ok := row["org_id"].([]byte); ok {
@@ -655,3 +2254,125 @@ func mapToStatusAudit(row map[string]interface{}) *model.ConsentStatusAudit {
 
 	return audit
 }
+
+// mapToConsentToken converts a CONSENT_TOKEN row
+// Note: DBClient normalizes column names to lowercase
+func mapToConsentToken(row map[string]interface{}) *model.ConsentToken {
+	token := &model.ConsentToken{}
+
+	if tokenID, ok := row["token_id"].(string); ok {
+		token.TokenID = tokenID
+	} else if tokenID, ok := row["token_id"].([]byte); ok {
+		token.TokenID = string(tokenID)
+	}
+	if consentID, ok := row["consent_id"].(string); ok {
+		token.ConsentID = consentID
+	} else if consentID, ok := row["consent_id"].([]byte); ok {
+		token.ConsentID = string(consentID)
+	}
+	if tokenType, ok := row["token_type"].(string); ok {
+		token.TokenType = tokenType
+	} else if tokenType, ok := row["token_type"].([]byte); ok {
+		token.TokenType = string(tokenType)
+	}
+	if expiresAt, ok := row["expires_at"].(int64); ok {
+		token.ExpiresAt = &expiresAt
+	}
+	if createdTime, ok := row["created_time"].(int64); ok {
+		token.CreatedTime = createdTime
+	}
+	if orgID, ok := row["org_id"].(string); ok {
+		token.OrgID = orgID
+	} else if orgID, ok := row["org_id"].([]byte); ok {
+		token.OrgID = string(orgID)
+	}
+
+	return token
+}
+
+// mapToConsentEvidence converts a CONSENT_EVIDENCE row of a consent
+// Note: DBClient normalizes column names to lowercase
+func mapToConsentEvidence(row map[string]interface{}, consentID, orgID string) *model.ConsentEvidence {
+	evidence := &model.ConsentEvidence{ConsentID: consentID, OrgID: orgID}
+
+	optional := func(key string) *string {
+		if value, ok := row[key].(string); ok {
+			return &value
+		} else if value, ok := row[key].([]byte); ok {
+			valueStr := string(value)
+			return &valueStr
+		}
+		return nil
+	}
+
+	if id := optional("evidence_id"); id != nil {
+		evidence.ID = *id
+	}
+	if version, ok := row["consent_version"].(int64); ok {
+		evidence.ConsentVersion = int(version)
+	}
+	if channel := optional("channel"); channel != nil {
+		evidence.Channel = *channel
+	}
+	evidence.IPAddress = optional("ip_address")
+	evidence.UserAgent = optional("user_agent")
+	evidence.UIVersion = optional("ui_version")
+	evidence.PresentedTextChecksum = optional("presented_text_checksum")
+	if capturedTime, ok := row["captured_time"].(int64); ok {
+		evidence.CapturedTime = capturedTime
+	}
+
+	return evidence
+}
+
+// mapToConsentHistory converts a database row map to ConsentHistory
+// Note: DBClient normalizes column names to lowercase
+func mapToConsentHistory(row map[string]interface{}) *model.ConsentHistory {
+	if row == nil {
+		return nil
+	}
+
+	history := &model.ConsentHistory{}
+
+	if consentID, ok := row["consent_id"].(string); ok {
+		history.ConsentID = consentID
+	} else if consentID, ok := row["consent_id"].([]byte); ok {
+		history.ConsentID = string(consentID)
+	}
+
+	if version, ok := row["version"].(int64); ok {
+		history.Version = int(version)
+	}
+
+	if snapshot, ok := row["snapshot"].(string); ok {
+		history.Snapshot = snapshot
+	} else if snapshot, ok := row["snapshot"].([]byte); ok {
+		history.Snapshot = string(snapshot)
+	}
+
+	if amendedTime, ok := row["amended_time"].(int64); ok {
+		history.AmendedTime = amendedTime
+	}
+
+	if amendedBy, ok := row["amended_by"].(string); ok {
+		history.AmendedBy = &amendedBy
+	} else if amendedBy, ok := row["amended_by"].([]byte); ok {
+		amendedByStr := string(amendedBy)
+		history.AmendedBy = &amendedByStr
+	}
+
+	if reason, ok := row["reason"].(string); ok {
+		history.Reason = &reason
+	} else if reason, ok := row["reason"].([]byte); ok {
+		reasonStr := string(reason)
+		history.Reason = &reasonStr
+	}
+
+	if orgID, ok := row["org_id"].(string); ok {
+		history.OrgID = orgID
+	} else if orgID, ok := row["org_id"].([]byte); ok {
+		history.OrgID = string(orgID)
+	}
+
+	return history
+}
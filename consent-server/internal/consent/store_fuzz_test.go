@@ -0,0 +1,236 @@
+package consent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/consent/filter"
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+)
+
+type capturedQuery struct {
+	sql  string
+	args []interface{}
+}
+
+// recordingDBClient captures the SQL and arguments sent to the database without executing them
+type recordingDBClient struct {
+	queries []capturedQuery
+}
+
+func (c *recordingDBClient) Query(query dbmodel.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	c.queries = append(c.queries, capturedQuery{sql: query.Query, args: args})
+	return []map[string]interface{}{}, nil
+}
+
+func (c *recordingDBClient) QueryContext(ctx context.Context, query dbmodel.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	return c.Query(query, args...)
+}
+
+func (c *recordingDBClient) Execute(query dbmodel.DBQuery, args ...interface{}) (int64, error) {
+	c.queries = append(c.queries, capturedQuery{sql: query.Query, args: args})
+	return 0, nil
+}
+
+func (c *recordingDBClient) ExecuteContext(ctx context.Context, query dbmodel.DBQuery, args ...interface{}) (int64, error) {
+	return c.Execute(query, args...)
+}
+
+func (c *recordingDBClient) BeginTxContext(ctx context.Context) (dbmodel.TxInterface, error) {
+	return c.BeginTx()
+}
+
+func (c *recordingDBClient) BeginTx() (dbmodel.TxInterface, error) {
+	return nil, nil
+}
+
+// splitFilter splits a comma-separated query parameter the same way the search handler does
+func splitFilter(value string) []string {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// neutralize replaces every filter value with a constant so that only the shape of the filters remains
+func neutralize(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i := range out {
+		out[i] = "x"
+	}
+	return out
+}
+
+// assertParameterized checks that user input only reaches the database as bound arguments
+func assertParameterized(t *testing.T, fuzzed, neutral []capturedQuery) {
+	t.Helper()
+	if len(fuzzed) != len(neutral) {
+		t.Fatalf("expected %d queries, got %d", len(neutral), len(fuzzed))
+	}
+	for i := range fuzzed {
+		if fuzzed[i].sql != neutral[i].sql {
+			t.Fatalf("filter values leaked into SQL text:\n%s\nexpected:\n%s", fuzzed[i].sql, neutral[i].sql)
+		}
+		if placeholders := strings.Count(fuzzed[i].sql, "?"); placeholders != len(fuzzed[i].args) {
+			t.Fatalf("query has %d placeholders but %d arguments: %s", placeholders, len(fuzzed[i].args), fuzzed[i].sql)
+		}
+	}
+}
+
+// FuzzSearchFilters drives the consent search filter-to-SQL path with arbitrary query
+// parameter values and checks that none of them are interpolated into the SQL text.
+func FuzzSearchFilters(f *testing.F) {
+	f.Add("accounts,payments", "active", "client-1", "user-1", "org-1", int64(0), int64(0))
+	f.Add("' OR '1'='1", "ACTIVE'); DROP TABLE CONSENT; --", "\" OR \"\"=\"", "?,?,?", "DEFAULT_ORG' --", int64(-1), int64(1<<62))
+	f.Add("\\', \\\", `", "%_%", "\x00\x1a", "ユーザー,ｕｓｅｒ", "\u202egro", int64(1), int64(-1))
+	f.Add(strings.Repeat("a,", 500), strings.Repeat("'", 1024), "", "", "", int64(0), int64(0))
+	f.Add("ſelect,İnto", "ﬁlter", "/* comment */", "-- ", "org;", int64(0), int64(0))
+
+	f.Fuzz(func(t *testing.T, types, statuses, clientIDs, userIDs, orgID string, fromTime, toTime int64) {
+		filters := model.ConsentSearchFilters{
+			ConsentTypes:    splitFilter(types),
+			ConsentStatuses: splitFilter(statuses),
+			ClientIDs:       splitFilter(clientIDs),
+			UserIDs:         splitFilter(userIDs),
+			AuthTypes:       splitFilter(types),
+			AuthStatuses:    splitFilter(statuses),
+			PurposeNames:    splitFilter(clientIDs),
+			Tags:            splitFilter(statuses),
+			SearchText:      userIDs,
+			OrgID:           orgID,
+			Limit:           10,
+			Offset:          0,
+		}
+		if fromTime != 0 {
+			filters.FromTime = &fromTime
+		}
+		if toTime != 0 {
+			filters.ToTime = &toTime
+		}
+
+		neutralFilters := filters
+		neutralFilters.ConsentTypes = neutralize(filters.ConsentTypes)
+		neutralFilters.ConsentStatuses = neutralize(filters.ConsentStatuses)
+		neutralFilters.ClientIDs = neutralize(filters.ClientIDs)
+		neutralFilters.UserIDs = neutralize(filters.UserIDs)
+		neutralFilters.AuthTypes = neutralize(filters.AuthTypes)
+		neutralFilters.AuthStatuses = neutralize(filters.AuthStatuses)
+		neutralFilters.PurposeNames = neutralize(filters.PurposeNames)
+		neutralFilters.Tags = neutralize(filters.Tags)
+		if filters.SearchText != "" {
+			neutralFilters.SearchText = "x"
+		}
+		neutralFilters.OrgID = "x"
+
+		fuzzedClient := &recordingDBClient{}
+		if _, _, err := NewConsentStore(fuzzedClient).Search(context.Background(), filters); err != nil {
+			t.Fatalf("search failed: %v", err)
+		}
+		neutralClient := &recordingDBClient{}
+		if _, _, err := NewConsentStore(neutralClient).Search(context.Background(), neutralFilters); err != nil {
+			t.Fatalf("search failed: %v", err)
+		}
+
+		assertParameterized(t, fuzzedClient.queries, neutralClient.queries)
+	})
+}
+
+// FuzzSearchFilterExpression drives filter expressions with arbitrary values through the search
+// and checks that the values are only bound as arguments
+func FuzzSearchFilterExpression(f *testing.F) {
+	f.Add("ACTIVE", "channel", "web")
+	f.Add("' OR '1'='1", "key'--", "value\" OR \"\"=\"")
+	f.Add("?", "?", "?")
+	f.Add(strings.Repeat("'", 512), "\u202e", "/* */")
+
+	f.Fuzz(func(t *testing.T, status, key, value string) {
+		search := func(status, key, value string) []capturedQuery {
+			expr := &filter.Logical{
+				Op:   filter.LogicalOr,
+				Left: &filter.Comparison{Attribute: filter.AttributeStatus, Operator: filter.OperatorEq, Value: status},
+				Right: &filter.Logical{
+					Op:    filter.LogicalAnd,
+					Left:  &filter.Comparison{Attribute: filter.AttributeAttributes, Key: key, Operator: filter.OperatorNe, Value: value},
+					Right: &filter.Comparison{Attribute: filter.AttributeCreatedTime, Operator: filter.OperatorGt, Value: int64(1)},
+				},
+			}
+			client := &recordingDBClient{}
+			filters := model.ConsentSearchFilters{Filter: expr, OrgID: "org-1", Limit: 10}
+			if _, _, err := NewConsentStore(client).Search(context.Background(), filters); err != nil {
+				t.Fatalf("search failed: %v", err)
+			}
+			return client.queries
+		}
+
+		assertParameterized(t, search(status, key, value), search("x", "x", "x"))
+	})
+}
+
+// FuzzAttributeQueries drives the attribute lookup queries with quote-laden keys and values
+func FuzzAttributeQueries(f *testing.F) {
+	f.Add("accountId", "12345", "c1,c2", "org-1")
+	f.Add("key'--", "value' OR '1'='1", "'),('", "org\\")
+	f.Add("\"", "\\\"\\'", "?", "?")
+	f.Add(strings.Repeat("k", 4096), strings.Repeat("\u202e", 1024), strings.Repeat("id,", 200), "")
+
+	f.Fuzz(func(t *testing.T, key, value, consentIDs, orgID string) {
+		ids := splitFilter(consentIDs)
+
+		fuzzedClient := &recordingDBClient{}
+		fuzzedStore := NewConsentStore(fuzzedClient)
+		neutralClient := &recordingDBClient{}
+		neutralStore := NewConsentStore(neutralClient)
+		ctx := context.Background()
+
+		if _, err := fuzzedStore.FindConsentIDsByAttribute(ctx, key, value, orgID); err != nil {
+			t.Fatalf("attribute lookup failed: %v", err)
+		}
+		if _, err := neutralStore.FindConsentIDsByAttribute(ctx, "x", "x", "x"); err != nil {
+			t.Fatalf("attribute lookup failed: %v", err)
+		}
+
+		if _, err := fuzzedStore.FindConsentIDsByAttributeKey(ctx, key, orgID); err != nil {
+			t.Fatalf("attribute key lookup failed: %v", err)
+		}
+		if _, err := neutralStore.FindConsentIDsByAttributeKey(ctx, "x", "x"); err != nil {
+			t.Fatalf("attribute key lookup failed: %v", err)
+		}
+
+		attributes := []model.AttributeFilter{{Key: key, Value: value}, {Key: key}}
+		neutralAttributes := []model.AttributeFilter{{Key: "x", Value: "x"}, {Key: "x"}}
+		if value == "" {
+			neutralAttributes[0].Value = ""
+		}
+		if _, err := fuzzedStore.FindConsentIDsByAttributes(ctx, attributes, orgID); err != nil {
+			t.Fatalf("multi-attribute lookup failed: %v", err)
+		}
+		if _, err := neutralStore.FindConsentIDsByAttributes(ctx, neutralAttributes, "x"); err != nil {
+			t.Fatalf("multi-attribute lookup failed: %v", err)
+		}
+
+		if _, err := fuzzedStore.GetAttributesByConsentIDs(ctx, ids, orgID); err != nil {
+			t.Fatalf("batch attribute lookup failed: %v", err)
+		}
+		if _, err := neutralStore.GetAttributesByConsentIDs(ctx, neutralize(ids), "x"); err != nil {
+			t.Fatalf("batch attribute lookup failed: %v", err)
+		}
+
+		if _, err := fuzzedStore.GetByIDs(ctx, ids, orgID); err != nil {
+			t.Fatalf("batch consent lookup failed: %v", err)
+		}
+		if _, err := neutralStore.GetByIDs(ctx, neutralize(ids), "x"); err != nil {
+			t.Fatalf("batch consent lookup failed: %v", err)
+		}
+
+		assertParameterized(t, fuzzedClient.queries, neutralClient.queries)
+	})
+}
@@ -0,0 +1,179 @@
+package consent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/consent/validator"
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/events"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// SuspendConsent puts a consent on hold, for example during a fraud investigation. The consent
+// moves to the suspended status, in which validation fails, until it is resumed. Revoked, expired
+// and rejected consents cannot be suspended, and the move is checked against the state machine.
+func (consentService *consentService) SuspendConsent(ctx context.Context, consentID, orgID string, req model.ConsentSuspensionRequest) (*model.ConsentSuspensionResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.SuspendConsent")
+	defer span.End()
+
+	existing, serviceErr := consentService.suspensionTarget(ctx, consentID, orgID, req)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	suspendedStatus := string(config.Get().Consent.ForOrg(orgID).GetSuspendedConsentStatus())
+	switch {
+	case existing.CurrentStatus == suspendedStatus:
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("Consent with ID '%s' is already suspended", consentID))
+	case validator.IsConsentEnded(orgID, existing.CurrentStatus):
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("Consent with ID '%s' in status '%s' cannot be suspended", consentID, existing.CurrentStatus))
+	}
+	if err := validator.ValidateStatusTransition(orgID, existing.CurrentStatus, suspendedStatus); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	return consentService.changeSuspension(ctx, existing, orgID, req, suspendedStatus, "Consent suspended", events.ConsentSuspended)
+}
+
+// ResumeConsent lifts the hold of a suspended consent. The consent returns to the status it had
+// when it was suspended, as recorded in its status audit, when the state machine allows it.
+func (consentService *consentService) ResumeConsent(ctx context.Context, consentID, orgID string, req model.ConsentSuspensionRequest) (*model.ConsentSuspensionResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ResumeConsent")
+	defer span.End()
+
+	existing, serviceErr := consentService.suspensionTarget(ctx, consentID, orgID, req)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	consentCfg := config.Get().Consent.ForOrg(orgID)
+	suspendedStatus := string(consentCfg.GetSuspendedConsentStatus())
+	if existing.CurrentStatus != suspendedStatus {
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("Consent with ID '%s' is not suspended", consentID))
+	}
+
+	audits, err := consentService.stores.Consent.GetStatusAuditByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	// Audits are ordered newest first. A consent suspended without an audit, such as through a
+	// status override, returns to the active status.
+	resumedStatus := string(consentCfg.GetActiveConsentStatus())
+	for _, audit := range audits {
+		if audit.CurrentStatus == suspendedStatus && audit.PreviousStatus != nil && *audit.PreviousStatus != suspendedStatus {
+			resumedStatus = *audit.PreviousStatus
+			break
+		}
+	}
+	if err := validator.ValidateStatusTransition(orgID, suspendedStatus, resumedStatus); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	return consentService.changeSuspension(ctx, existing, orgID, req, resumedStatus, "Consent resumed", events.ConsentResumed)
+}
+
+// suspensionTarget checks a suspend or resume request and retrieves the consent it changes
+func (consentService *consentService) suspensionTarget(ctx context.Context, consentID, orgID string, req model.ConsentSuspensionRequest) (*model.Consent, *serviceerror.ServiceError) {
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if strings.TrimSpace(req.ActionBy) == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "actionBy is required")
+	}
+
+	existing, err := consentService.stores.Consent.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		log.GetLogger().WithContext(ctx).Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+	if serviceErr := consentService.checkConsentLock(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+	return existing, nil
+}
+
+// changeSuspension moves a consent to the status of a suspend or resume, recording the change in
+// the status audit and emitting the event of the change. Authorizations are left unchanged.
+func (consentService *consentService) changeSuspension(ctx context.Context, existing *model.Consent, orgID string, req model.ConsentSuspensionRequest, newStatus, defaultReason string, eventType events.EventType) (*model.ConsentSuspensionResponse, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+	consentID := existing.ConsentID
+	currentTime := utils.GetCurrentTimeMillis()
+
+	reason := req.Reason
+	if strings.TrimSpace(reason) == "" {
+		reason = defaultReason
+	}
+	actionBy := req.ActionBy
+	audit := &model.ConsentStatusAudit{
+		StatusAuditID:  utils.GenerateUUID(),
+		ConsentID:      consentID,
+		CurrentStatus:  newStatus,
+		ActionTime:     currentTime,
+		Reason:         &reason,
+		ActionBy:       &actionBy,
+		PreviousStatus: &existing.CurrentStatus,
+		OrgID:          orgID,
+	}
+
+	consentStore := consentService.stores.Consent
+	queries := []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.UpdateStatus(tx, consentID, orgID, newStatus, currentTime)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return consentStore.CreateStatusAudit(tx, audit)
+		},
+	}
+	// Tokens of a suspended consent stop resolving to it. The authorization server binds new
+	// tokens once the consent is resumed.
+	if eventType == events.ConsentSuspended {
+		queries = append(queries, consentService.releaseTokens(consentID, orgID))
+	}
+	queries = append(queries, consentService.recordEvent(events.ConsentEvent{
+		ID:             utils.GenerateUUID(),
+		Type:           eventType,
+		Timestamp:      currentTime,
+		OrgID:          orgID,
+		ConsentID:      consentID,
+		ClientID:       existing.ClientID,
+		Status:         newStatus,
+		PreviousStatus: existing.CurrentStatus,
+	}))
+	err := consentService.stores.ExecuteTransaction(ctx, queries)
+	if err != nil {
+		logger.Error("Failed to change consent suspension in transaction", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	cache.InvalidateConsentValidation(ctx, orgID, consentID)
+
+	auditChanges(ctx, "status", "from", []string{existing.CurrentStatus})
+	auditChanges(ctx, "status", "to", []string{newStatus})
+
+	logger.Info("Consent suspension changed",
+		log.String("consent_id", consentID),
+		log.String("previous_status", existing.CurrentStatus),
+		log.String("new_status", newStatus))
+
+	return &model.ConsentSuspensionResponse{
+		ConsentID:      consentID,
+		PreviousStatus: existing.CurrentStatus,
+		Status:         newStatus,
+		Reason:         reason,
+		ActionBy:       req.ActionBy,
+		ActionTime:     currentTime,
+	}, nil
+}
@@ -0,0 +1,206 @@
+package consent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/consent/validator"
+	"github.com/wso2/consent-management-api/internal/system/database"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// BindToken binds an access or refresh token to a consent, so that the consent can be resolved from
+// the token. Binding a token again to the same consent returns the existing binding, reported by
+// created being false; a token bound to another consent is rejected until it expires.
+func (consentService *consentService) BindToken(ctx context.Context, consentID, orgID string, req model.ConsentTokenRequest) (*model.ConsentTokenResponse, bool, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.BindToken")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return nil, false, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	now := utils.GetCurrentTimeMillis()
+	if err := req.Validate(now); err != nil {
+		return nil, false, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	store := consentService.stores.Consent
+	existing, err := store.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, false, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if existing == nil {
+		return nil, false, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+	if validator.IsConsentEnded(orgID, existing.CurrentStatus) {
+		return nil, false, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("Consent with ID '%s' in status '%s' cannot be bound to tokens", consentID, existing.CurrentStatus))
+	}
+
+	bound, err := store.GetToken(ctx, req.TokenID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve token binding", log.Error(err), log.String("consent_id", consentID))
+		return nil, false, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if bound != nil {
+		if !bound.IsExpired(now) {
+			if bound.ConsentID != consentID {
+				return nil, false, serviceerror.CustomServiceError(serviceerror.ConflictError,
+					fmt.Sprintf("Token '%s' is bound to another consent", req.TokenID))
+			}
+			response := bound.ToResponse()
+			return &response, false, nil
+		}
+		// The token expired before the cleanup job removed it, so its ID may be reused
+		if _, err := store.DeleteToken(ctx, bound.TokenID, bound.ConsentID, orgID); err != nil {
+			logger.Error("Failed to remove expired token binding", log.Error(err), log.String("consent_id", bound.ConsentID))
+			return nil, false, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+		}
+	}
+
+	token := &model.ConsentToken{
+		TokenID:     req.TokenID,
+		ConsentID:   consentID,
+		TokenType:   req.TokenType,
+		CreatedTime: now,
+		OrgID:       orgID,
+	}
+	if req.ExpiresAt != nil {
+		expiresAt := *req.ExpiresAt * 1000
+		token.ExpiresAt = &expiresAt
+	}
+	if err := store.CreateToken(ctx, token); err != nil {
+		// Another request bound the token between the lookup and the insert
+		if database.IsDuplicateKey(err) {
+			return nil, false, serviceerror.CustomServiceError(serviceerror.ConflictError,
+				fmt.Sprintf("Token '%s' is already bound to a consent", req.TokenID))
+		}
+		logger.Error("Failed to bind token to consent", log.Error(err), log.String("consent_id", consentID))
+		return nil, false, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	auditChanges(ctx, "tokens", "bound", []string{req.TokenID})
+
+	logger.Info("Token bound to consent", log.String("consent_id", consentID), log.String("token_type", req.TokenType))
+	response := token.ToResponse()
+	return &response, true, nil
+}
+
+// ListTokens returns the tokens bound to a consent that have not expired, oldest first
+func (consentService *consentService) ListTokens(ctx context.Context, consentID, orgID string) (*model.ConsentTokenListResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.ListTokens")
+	defer span.End()
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if serviceErr := consentService.checkConsentExists(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	tokens, err := consentService.stores.Consent.GetTokensByConsentID(ctx, consentID, orgID, utils.GetCurrentTimeMillis())
+	if err != nil {
+		log.GetLogger().WithContext(ctx).Error("Failed to retrieve consent tokens", log.Error(err), log.String("consent_id", consentID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	response := &model.ConsentTokenListResponse{Data: make([]model.ConsentTokenResponse, 0, len(tokens))}
+	for i := range tokens {
+		response.Data = append(response.Data, tokens[i].ToResponse())
+	}
+	return response, nil
+}
+
+// UnbindToken removes the binding of a token to a consent, for example when the token is revoked
+func (consentService *consentService) UnbindToken(ctx context.Context, consentID, orgID, tokenID string) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "consent.UnbindToken")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := model.ValidateTokenID(tokenID); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	removed, err := consentService.stores.Consent.DeleteToken(ctx, tokenID, consentID, orgID)
+	if err != nil {
+		logger.Error("Failed to unbind token from consent", log.Error(err), log.String("consent_id", consentID))
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	if !removed {
+		return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("Token '%s' is not bound to consent with ID '%s'", tokenID, consentID))
+	}
+	auditChanges(ctx, "tokens", "unbound", []string{tokenID})
+
+	logger.Info("Token unbound from consent", log.String("consent_id", consentID))
+	return nil
+}
+
+// GetTokenConsent resolves a token to the consent it is bound to. Expired tokens and tokens of
+// consents that ended do not resolve.
+func (consentService *consentService) GetTokenConsent(ctx context.Context, tokenID, orgID string) (*model.TokenConsentResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consent.GetTokenConsent")
+	defer span.End()
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := model.ValidateTokenID(tokenID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	token, err := consentService.stores.Consent.GetToken(ctx, tokenID, orgID)
+	if err != nil {
+		log.GetLogger().WithContext(ctx).Error("Failed to retrieve token binding", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, err.Error())
+	}
+	notBound := serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+		fmt.Sprintf("Token '%s' is not bound to a consent", tokenID))
+	if token == nil || token.IsExpired(utils.GetCurrentTimeMillis()) {
+		return nil, notBound
+	}
+
+	consent, serviceErr := consentService.GetConsent(ctx, token.ConsentID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	if validator.IsConsentEnded(orgID, consent.CurrentStatus) {
+		return nil, notBound
+	}
+	return &model.TokenConsentResponse{Token: token.ToResponse(), Consent: consent.ToAPIResponse()}, nil
+}
+
+// CleanupExpiredTokens removes the bindings of tokens that expired
+func (consentService *consentService) CleanupExpiredTokens(ctx context.Context) {
+	ctx, span := tracing.StartSpan(ctx, "consent.CleanupExpiredTokens")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	removed, err := consentService.stores.Consent.DeleteExpiredTokens(ctx, utils.GetCurrentTimeMillis())
+	if err != nil {
+		logger.Error("Failed to remove expired token bindings", log.Error(err))
+		return
+	}
+	if removed > 0 {
+		logger.Info("Removed expired token bindings", log.Any("removed", removed))
+	}
+}
+
+// releaseTokens returns a transaction query removing the token bindings of a consent, so that its
+// tokens stop resolving to it. Paths that end or suspend a consent add it to their transaction.
+func (consentService *consentService) releaseTokens(consentID, orgID string) func(tx dbmodel.TxInterface) error {
+	return func(tx dbmodel.TxInterface) error {
+		return consentService.stores.Consent.DeleteTokensByConsentID(tx, consentID, orgID)
+	}
+}
@@ -0,0 +1,53 @@
+package consent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// racingTokenStore finds no binding for a token, then fails the insert as another request bound
+// the token in between
+type racingTokenStore struct {
+	interfaces.ConsentStore
+	consent model.Consent
+}
+
+func (s *racingTokenStore) GetByID(ctx context.Context, consentID, orgID string) (*model.Consent, error) {
+	return &s.consent, nil
+}
+
+func (s *racingTokenStore) GetToken(ctx context.Context, tokenID, orgID string) (*model.ConsentToken, error) {
+	return nil, nil
+}
+
+func (s *racingTokenStore) CreateToken(ctx context.Context, token *model.ConsentToken) error {
+	return sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintPrimaryKey}
+}
+
+// TestBindToken_ConcurrentBindConflicts checks that losing the race to bind a token answers with a
+// conflict rather than a database error
+func TestBindToken_ConcurrentBindConflicts(t *testing.T) {
+	const consentID = "3f2b8c1e-6d4a-4b7e-9a1c-2e5f8d9b0c3a"
+	config.SetGlobal(&config.Config{})
+	t.Cleanup(func() { config.SetGlobal(nil) })
+	service := &consentService{stores: &stores.StoreRegistry{Consent: &racingTokenStore{
+		consent: model.Consent{ConsentID: consentID, CurrentStatus: "ACTIVE", OrgID: "org-1"},
+	}}}
+
+	_, created, serviceErr := service.BindToken(context.Background(), consentID, "org-1",
+		model.ConsentTokenRequest{TokenID: "4b1d6f0e-jti", TokenType: "access_token"})
+	if serviceErr == nil || serviceErr.Code != serviceerror.ConflictError.Code {
+		t.Fatalf("expected a conflict, got %+v", serviceErr)
+	}
+	if created {
+		t.Error("expected no binding to be reported as created")
+	}
+}
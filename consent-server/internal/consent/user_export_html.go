@@ -0,0 +1,96 @@
+package consent
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+)
+
+// userExportHTML renders a user consent export as a self-contained page that can be handed to the
+// data subject as is, or printed to PDF from a browser
+var userExportHTML = template.Must(template.New("user-export").Funcs(template.FuncMap{
+	"time": formatExportTime,
+	"deref": func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	},
+	"json": func(value interface{}) string {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Consents of {{.UserID}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin: 0.5em 0 1.5em; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f3f3f3; }
+section { page-break-inside: avoid; margin-bottom: 2em; }
+</style>
+</head>
+<body>
+<h1>Consents of {{.UserID}}</h1>
+<p>Organization {{.OrgID}}, generated {{time .GeneratedTime}}. {{len .Consents}} consent(s).</p>
+{{range .Consents}}
+<section>
+<h2>Consent {{.ID}}</h2>
+<table>
+<tr><th>Type</th><td>{{.Type}}</td></tr>
+<tr><th>Client</th><td>{{.ClientID}}</td></tr>
+<tr><th>Status</th><td>{{.Status}}</td></tr>
+<tr><th>Your approval</th><td>{{.UserApprovalStatus}}</td></tr>
+<tr><th>Created</th><td>{{time .CreatedTime}}</td></tr>
+<tr><th>Last updated</th><td>{{time .UpdatedTime}}</td></tr>
+<tr><th>Valid until</th><td>{{if .ValidityTime}}{{time .ValidityTime}}{{else}}Until revoked{{end}}</td></tr>
+<tr><th>Version</th><td>{{.Version}}</td></tr>
+</table>
+<h3>Purposes</h3>
+{{if .ConsentPurposes}}<table>
+<tr><th>Purpose</th><th>Description</th><th>Approved</th><th>Value</th></tr>
+{{range .ConsentPurposes}}<tr><td>{{.Name}}</td><td>{{deref .Description}}</td><td>{{if .IsUserApproved}}{{.IsUserApproved}}{{else}}false{{end}}</td><td>{{if .Value}}{{json .Value}}{{end}}</td></tr>
+{{end}}</table>{{else}}<p>None</p>{{end}}
+<h3>Authorizations</h3>
+<table>
+<tr><th>ID</th><th>User</th><th>Type</th><th>Status</th><th>Updated</th></tr>
+{{range .Authorizations}}<tr><td>{{.ID}}</td><td>{{.UserID}}</td><td>{{.Type}}</td><td>{{.Status}}</td><td>{{time .UpdatedTime}}</td></tr>
+{{end}}</table>
+{{if .Attributes}}<h3>Attributes</h3>
+<table>
+{{range $key, $value := .Attributes}}<tr><th>{{$key}}</th><td>{{$value}}</td></tr>
+{{end}}</table>{{end}}
+<h3>Status history</h3>
+<table>
+<tr><th>Time</th><th>From</th><th>To</th><th>By</th><th>Reason</th></tr>
+{{range .StatusHistory}}<tr><td>{{time .ActionTime}}</td><td>{{deref .PreviousStatus}}</td><td>{{.CurrentStatus}}</td><td>{{deref .ActionBy}}</td><td>{{deref .Reason}}</td></tr>
+{{end}}</table>
+{{if .History}}<h3>Earlier versions</h3>
+<table>
+<tr><th>Version</th><th>Replaced</th><th>By</th><th>Reason</th></tr>
+{{range .History}}<tr><td>{{.Version}}</td><td>{{time .AmendedTime}}</td><td>{{deref .AmendedBy}}</td><td>{{deref .Reason}}</td></tr>
+{{end}}</table>{{end}}
+</section>
+{{end}}
+</body>
+</html>
+`))
+
+// writeUserExportHTML renders a user consent export as HTML
+func writeUserExportHTML(out io.Writer, export *model.UserConsentExport) error {
+	return userExportHTML.Execute(out, export)
+}
+
+// formatExportTime formats a timestamp stored in seconds or milliseconds as UTC RFC 3339
+func formatExportTime(timestamp int64) string {
+	return time.Unix(toEpochSeconds(timestamp), 0).UTC().Format(time.RFC3339)
+}
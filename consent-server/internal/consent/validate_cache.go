@@ -0,0 +1,56 @@
+package consent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// validateSnapshot is the request independent data ValidateConsent reads from the database. It is
+// kept in the validate cache so repeated validations of a consent skip the database.
+type validateSnapshot struct {
+	Consent     model.Consent                     `json:"consent"`
+	Information *model.ValidateConsentAPIResponse `json:"information"`
+}
+
+// getValidateSnapshot returns the cached validation data of a consent, or nil on a cache miss or
+// when the validate cache is disabled
+func getValidateSnapshot(ctx context.Context, consentID, orgID string) *validateSnapshot {
+	validateCache := cache.GetValidateCache()
+	if validateCache == nil {
+		return nil
+	}
+	value, found := validateCache.Get(ctx, orgID, consentID)
+	if !found {
+		return nil
+	}
+
+	// Keep numbers in resources and purpose values as they were stored
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var snapshot validateSnapshot
+	if err := decoder.Decode(&snapshot); err != nil || snapshot.Information == nil {
+		log.GetLogger().WithContext(ctx).Warn("Ignoring unreadable validate cache entry",
+			log.String("consent_id", consentID))
+		return nil
+	}
+	return &snapshot
+}
+
+// setValidateSnapshot caches the validation data of a consent
+func setValidateSnapshot(ctx context.Context, orgID string, snapshot *validateSnapshot) {
+	validateCache := cache.GetValidateCache()
+	if validateCache == nil {
+		return
+	}
+	value, err := json.Marshal(snapshot)
+	if err != nil {
+		log.GetLogger().WithContext(ctx).Warn("Failed to encode validate cache entry", log.Error(err))
+		return
+	}
+	validateCache.Set(ctx, orgID, snapshot.Consent.ConsentID, value)
+}
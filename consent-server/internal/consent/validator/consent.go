@@ -1,80 +1,336 @@
 package validator
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
-	"time"
 
 	authvalidator "github.com/wso2/consent-management-api/internal/authresource/validator"
 	"github.com/wso2/consent-management-api/internal/consent/model"
 	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/featureflag"
+	"github.com/wso2/consent-management-api/internal/system/utils"
 )
 
-// ValidateConsentCreateRequest validates consent creation request
+// ValidateConsentCreateRequest validates consent creation request. Every invalid field is reported
+// as a serviceerror.FieldViolations error.
 func ValidateConsentCreateRequest(req model.ConsentAPIRequest, clientID, orgID string) error {
+	var violations serviceerror.FieldViolations
+
 	// Required fields
 	if req.Type == "" {
-		return fmt.Errorf("type is required")
-	}
-	if len(req.Type) > 64 {
-		return fmt.Errorf("type cannot exceed 64 characters")
+		violations = append(violations, violation("type", "required", "type is required"))
+	} else if len(req.Type) > 64 {
+		violations = append(violations, violation("type", "maxLength", "type cannot exceed 64 characters"))
 	}
 	if clientID == "" {
-		return fmt.Errorf("clientID is required")
+		violations = append(violations, violation("clientID", "required", "clientID is required"))
 	}
 	if orgID == "" {
-		return fmt.Errorf("orgID is required")
+		violations = append(violations, violation("orgID", "required", "orgID is required"))
 	}
 
 	// Validate auth resources (Authorizations field)
 	for i, authReq := range req.Authorizations {
 		if authReq.Type == "" {
-			return fmt.Errorf("authorizations[%d].type is required", i)
+			violations = append(violations, violation(fmt.Sprintf("authorizations[%d].type", i), "required",
+				fmt.Sprintf("authorizations[%d].type is required", i)))
 		}
 		// Status is optional and defaults to "approved" in the ToAuthResourceCreateRequest method
 		if authReq.Status != "" {
 			if err := authvalidator.ValidateAuthStatus(authReq.Status); err != nil {
-				return fmt.Errorf("authorizations[%d]: %w", i, err)
+				violations = append(violations, violation(fmt.Sprintf("authorizations[%d].status", i), "enum",
+					fmt.Sprintf("authorizations[%d]: %v", i, err)))
 			}
 		}
 	}
+	violations = append(violations, validateDelegations(req.Authorizations)...)
+	violations = append(violations, validateEvidence(req.Evidence)...)
 
-	// Validate validity time if provided
-	if req.ValidityTime != nil && *req.ValidityTime < 0 {
-		return fmt.Errorf("validityTime must be non-negative")
-	}
-
-	// Validate frequency if provided
-	if req.Frequency != nil && *req.Frequency < 0 {
-		return fmt.Errorf("frequency must be non-negative")
-	}
-
-	return nil
+	violations = append(violations, validateNonNegative(req.ValidityTime, req.Frequency)...)
+	violations = append(violations, validatePurposeTimestamps(req.ConsentPurpose)...)
+	return violationsError(violations)
 }
 
-// ValidateConsentUpdateRequest validates consent update request (keeping for future use)
+// ValidateConsentUpdateRequest validates consent update request. Every invalid field is reported
+// as a serviceerror.FieldViolations error.
 func ValidateConsentUpdateRequest(req model.ConsentAPIUpdateRequest) error {
 	// At least one field must be provided (check if nil, not if empty)
 	// Empty arrays are valid - they indicate removal of all items
 	if req.Type == "" && req.Frequency == nil &&
 		req.ValidityTime == nil && req.RecurringIndicator == nil &&
-		req.Attributes == nil && req.Authorizations == nil && req.ConsentPurpose == nil {
-		return fmt.Errorf("at least one field must be provided for update")
+		req.Attributes == nil && req.Authorizations == nil && req.ConsentPurpose == nil &&
+		req.ScheduledRevocation == nil {
+		return serviceerror.FieldViolations{violation("", "minProperties", "at least one field must be provided for update")}
 	}
 
-	// Validate validity time if provided
-	if req.ValidityTime != nil && *req.ValidityTime < 0 {
-		return fmt.Errorf("validityTime must be non-negative")
+	var violations serviceerror.FieldViolations
+	violations = append(violations, validateDelegations(req.Authorizations)...)
+	violations = append(violations, validateEvidence(req.Evidence)...)
+	violations = append(violations, validateNonNegative(req.ValidityTime, req.Frequency)...)
+	violations = append(violations, validatePurposeTimestamps(req.ConsentPurpose)...)
+	// A scheduled update is applied later as an immediate update, so it cannot carry a revocation
+	// scheduled relative to the time it was requested
+	if req.EffectiveAt != nil && req.ScheduledRevocation != nil {
+		violations = append(violations, violation("scheduledRevocation", "scheduledUpdate",
+			"scheduledRevocation cannot be combined with effectiveAt, schedule the revocation separately"))
 	}
+	return violationsError(violations)
+}
 
-	// Validate frequency if provided
-	if req.Frequency != nil && *req.Frequency < 0 {
-		return fmt.Errorf("frequency must be non-negative")
+// validateDelegations checks that delegated authorizations capture both the delegator and the delegate
+func validateDelegations(authorizations []model.AuthorizationAPIRequest) serviceerror.FieldViolations {
+	var violations serviceerror.FieldViolations
+	for i := range authorizations {
+		delegatorID, delegateID := authorizations[i].Delegation()
+		if err := authvalidator.ValidateDelegation(delegatorID, delegateID); err != nil {
+			violations = append(violations, violation(fmt.Sprintf("authorizations[%d]", i), "delegation",
+				fmt.Sprintf("authorizations[%d]: %v", i, err)))
+		}
 	}
+	return violations
+}
 
+// validateEvidence checks the optional evidence of how the consent was collected
+func validateEvidence(evidence *model.ConsentEvidenceRequest) serviceerror.FieldViolations {
+	if evidence == nil {
+		return nil
+	}
+	if err := evidence.Validate(); err != nil {
+		return serviceerror.FieldViolations{violation("evidence", "evidence", fmt.Sprintf("evidence: %v", err))}
+	}
 	return nil
 }
 
+// validateNonNegative checks the optional validity time and frequency
+func validateNonNegative(validityTime *int64, frequency *int) serviceerror.FieldViolations {
+	var violations serviceerror.FieldViolations
+	if validityTime != nil && *validityTime < 0 {
+		violations = append(violations, violation("validityTime", "minimum", "validityTime must be non-negative"))
+	}
+	if frequency != nil && *frequency < 0 {
+		violations = append(violations, violation("frequency", "minimum", "frequency must be non-negative"))
+	}
+	return violations
+}
+
+// validatePurposeTimestamps checks the optional per-purpose expiry and confirmation times
+func validatePurposeTimestamps(purposes []model.ConsentPurposeItem) serviceerror.FieldViolations {
+	var violations serviceerror.FieldViolations
+	for i, purpose := range purposes {
+		if purpose.ExpiresAt != nil && *purpose.ExpiresAt < 0 {
+			violations = append(violations, violation(fmt.Sprintf("consentPurpose[%d].expiresAt", i), "minimum",
+				fmt.Sprintf("consentPurpose[%d].expiresAt must be non-negative", i)))
+		}
+		if purpose.LastConfirmedAt != nil && *purpose.LastConfirmedAt < 0 {
+			violations = append(violations, violation(fmt.Sprintf("consentPurpose[%d].lastConfirmedAt", i), "minimum",
+				fmt.Sprintf("consentPurpose[%d].lastConfirmedAt must be non-negative", i)))
+		}
+	}
+	return violations
+}
+
+// ValidateStrictConsentFields applies the additional request checks enabled by the
+// strict_validation feature flag: purpose names must be unique, attribute keys must not be blank
+// and a validity time must not already have passed.
+func ValidateStrictConsentFields(purposes []model.ConsentPurposeItem, attributes map[string]string, validityTime *int64) error {
+	var violations serviceerror.FieldViolations
+
+	seen := make(map[string]bool, len(purposes))
+	for i, purpose := range purposes {
+		if seen[purpose.Name] {
+			violations = append(violations, violation(fmt.Sprintf("consentPurpose[%d].name", i), "unique",
+				fmt.Sprintf("consentPurpose[%d]: duplicate purpose name '%s'", i, purpose.Name)))
+		}
+		seen[purpose.Name] = true
+	}
+
+	for key := range attributes {
+		if strings.TrimSpace(key) == "" {
+			violations = append(violations, violation("attributes", "propertyNames", "attribute keys cannot be blank"))
+			break
+		}
+	}
+
+	if validityTime != nil && IsConsentExpired(*validityTime) {
+		violations = append(violations, violation("validityTime", "future", "validityTime must be in the future"))
+	}
+
+	return violationsError(violations)
+}
+
+// violation builds a field violation
+func violation(path, constraint, message string) serviceerror.FieldViolation {
+	return serviceerror.FieldViolation{Path: path, Constraint: constraint, Message: message}
+}
+
+// violationsError returns the violations as an error, or nil when there are none
+func violationsError(violations serviceerror.FieldViolations) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return violations
+}
+
+// UnapprovedMandatoryPurposes returns the names of mandatory purposes that the user has not
+// approved. Purposes are mandatory unless isMandatory is explicitly false.
+func UnapprovedMandatoryPurposes(purposes []model.ConsentPurposeItem) []string {
+	unapproved := []string{}
+	for _, purpose := range purposes {
+		mandatory := purpose.IsMandatory == nil || *purpose.IsMandatory
+		approved := purpose.IsUserApproved != nil && *purpose.IsUserApproved
+		if mandatory && !approved {
+			unapproved = append(unapproved, purpose.Name)
+		}
+	}
+	return unapproved
+}
+
+// StalePurposes returns the names of purposes whose expiresAt has passed and which therefore
+// need to be re-confirmed by the user, independently of the overall consent validity.
+func StalePurposes(purposes []model.ConsentPurposeItem) []string {
+	var stale []string
+	for _, purpose := range purposes {
+		if purpose.ExpiresAt != nil && IsConsentExpired(*purpose.ExpiresAt) {
+			stale = append(stale, purpose.Name)
+		}
+	}
+	return stale
+}
+
+// GetDataAccessWindow returns the data access window of a consent, or nil when the consent has no
+// dataAccessValidityDuration. The window starts at the latest update of an approved authorization
+// of the user, or of the consent when it has none.
+func GetDataAccessWindow(info *model.ValidateConsentAPIResponse, userID string) *model.DataAccessWindow {
+	if info == nil || info.DataAccessValidityDuration == nil || *info.DataAccessValidityDuration <= 0 {
+		return nil
+	}
+
+	approvedStatus := string(config.Get().Consent.GetApprovedAuthStatus())
+	var lastAuthorized int64
+	for _, auth := range info.Authorizations {
+		if auth.Status != approvedStatus {
+			continue
+		}
+		if userID != "" && auth.UserID != nil && *auth.UserID != userID {
+			continue
+		}
+		lastAuthorized = max(lastAuthorized, auth.UpdatedTime)
+	}
+	if lastAuthorized == 0 {
+		lastAuthorized = info.UpdatedTime
+	}
+
+	return &model.DataAccessWindow{
+		Start: lastAuthorized,
+		End:   lastAuthorized + *info.DataAccessValidityDuration*1000,
+	}
+}
+
+// PurposeDecisions decides for each requested purpose name whether it is granted. A purpose is
+// granted when the consent is valid, contains the purpose, the user approved it and it does not
+// need re-confirmation.
+func PurposeDecisions(requested []string, consentValid bool, purposes []model.ConsentPurposeItem) map[string]model.ValidateDecision {
+	byName := make(map[string]model.ConsentPurposeItem, len(purposes))
+	for _, purpose := range purposes {
+		byName[purpose.Name] = purpose
+	}
+
+	decisions := make(map[string]model.ValidateDecision, len(requested))
+	for _, name := range requested {
+		purpose, found := byName[name]
+		switch {
+		case !consentValid:
+			decisions[name] = deny(model.DenyReasonConsentInvalid)
+		case !found:
+			decisions[name] = deny(model.DenyReasonPurposeNotInConsent)
+		case purpose.IsUserApproved == nil || !*purpose.IsUserApproved:
+			decisions[name] = deny(model.DenyReasonPurposeNotApproved)
+		case purpose.ExpiresAt != nil && IsConsentExpired(*purpose.ExpiresAt):
+			decisions[name] = deny(model.DenyReasonPurposeReconfirmation)
+		default:
+			decisions[name] = model.ValidateDecision{Decision: model.ValidateDecisionGrant}
+		}
+	}
+	return decisions
+}
+
+// ResourceDecisions decides for each requested resource identifier whether it is granted. A
+// resource is granted when the consent is valid and the identifier appears in the resources of an
+// approved authorization of the user, or in the value of an approved purpose that does not need
+// re-confirmation.
+func ResourceDecisions(requested []string, consentValid bool, userID string, info *model.ValidateConsentAPIResponse) map[string]model.ValidateDecision {
+	granted := make(map[string]bool)
+	if consentValid && info != nil {
+		approvedStatus := string(config.Get().Consent.GetApprovedAuthStatus())
+		for _, auth := range info.Authorizations {
+			if auth.Status != approvedStatus {
+				continue
+			}
+			if userID != "" && auth.UserID != nil && *auth.UserID != userID {
+				continue
+			}
+			collectResourceIDs(auth.Resources, granted)
+		}
+		for _, purpose := range info.ConsentPurpose {
+			if purpose.IsUserApproved == nil || !*purpose.IsUserApproved {
+				continue
+			}
+			if purpose.ExpiresAt != nil && IsConsentExpired(*purpose.ExpiresAt) {
+				continue
+			}
+			collectResourceIDs(decodePurposeValue(purpose.Value), granted)
+		}
+	}
+
+	decisions := make(map[string]model.ValidateDecision, len(requested))
+	for _, resource := range requested {
+		switch {
+		case !consentValid:
+			decisions[resource] = deny(model.DenyReasonConsentInvalid)
+		case !granted[resource]:
+			decisions[resource] = deny(model.DenyReasonResourceNotAuthorized)
+		default:
+			decisions[resource] = model.ValidateDecision{Decision: model.ValidateDecisionGrant}
+		}
+	}
+	return decisions
+}
+
+// collectResourceIDs adds every string found in a resources document to ids
+func collectResourceIDs(value interface{}, ids map[string]bool) {
+	switch v := value.(type) {
+	case string:
+		ids[v] = true
+	case []interface{}:
+		for _, item := range v {
+			collectResourceIDs(item, ids)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			collectResourceIDs(item, ids)
+		}
+	}
+}
+
+// decodePurposeValue decodes a purpose value that is still held as the JSON text it is stored as
+func decodePurposeValue(value interface{}) interface{} {
+	text, ok := value.(string)
+	if !ok {
+		return value
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(text), &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
+
+func deny(reason string) model.ValidateDecision {
+	return model.ValidateDecision{Decision: model.ValidateDecisionDeny, Reason: reason}
+}
+
 // ValidateConsentGetRequest validates consent retrieval request parameters
 func ValidateConsentGetRequest(consentID, orgID string) error {
 	if consentID == "" {
@@ -94,62 +350,186 @@ func ValidateConsentGetRequest(consentID, orgID string) error {
 
 // EvaluateConsentStatusFromAuthStatuses determines consent status from a list of auth status strings.
 // This is a helper function for authresource package to avoid import cycles.
-// Uses the same priority logic as EvaluateConsentStatus.
-func EvaluateConsentStatusFromAuthStatuses(authStatuses []string) string {
-	consentConfig := config.Get().Consent
+// Auth statuses are mapped to the status names of the organization and combined with the status
+// derivation strategy configured for the organization and consent type. Authorizations revoked by
+// a partial revocation do not take part while other authorizations remain.
+func EvaluateConsentStatusFromAuthStatuses(orgID, consentType string, authStatuses []string) string {
+	consentConfig := config.Get().Consent.ForOrg(orgID)
 
 	if len(authStatuses) == 0 {
 		// No auth resources - default to created status
 		return string(consentConfig.GetCreatedConsentStatus())
 	}
 
-	// Evaluate ALL auth statuses with priority logic
-	hasRejected := false
-	hasCreated := false
-	allApproved := true
+	systemRevoked := string(consentConfig.GetSystemRevokedAuthStatus())
+	remaining := make([]string, 0, len(authStatuses))
+	for _, authStatus := range authStatuses {
+		if authStatus != systemRevoked {
+			remaining = append(remaining, authStatus)
+		}
+	}
+	if len(remaining) > 0 {
+		authStatuses = remaining
+	}
 
+	mapped := make([]string, 0, len(authStatuses))
+	approved, rejected := 0, 0
 	for _, authStatus := range authStatuses {
-		// Map auth status to consent status first (case-insensitive comparison)
-		authStatusUpper := strings.ToUpper(authStatus)
-		var mappedConsentStatus string
-
-		// Check if auth status matches known auth states
-		if authStatusUpper == strings.ToUpper(string(consentConfig.GetApprovedAuthStatus())) || authStatus == "" {
-			// Approved or empty/missing status → active consent
-			mappedConsentStatus = string(consentConfig.GetActiveConsentStatus())
-		} else if authStatusUpper == strings.ToUpper(string(consentConfig.GetRejectedAuthStatus())) {
-			// Rejected auth → rejected consent
-			mappedConsentStatus = string(consentConfig.GetRejectedConsentStatus())
-		} else if authStatusUpper == strings.ToUpper(string(consentConfig.GetCreatedAuthStatus())) {
-			// Created auth → created consent
-			mappedConsentStatus = string(consentConfig.GetCreatedConsentStatus())
-		} else {
-			// Unknown status - treat as created
-			mappedConsentStatus = string(consentConfig.GetCreatedConsentStatus())
-		}
-
-		// Now check the mapped consent status
-		if mappedConsentStatus == string(consentConfig.GetRejectedConsentStatus()) {
-			hasRejected = true
-			allApproved = false
-		} else if mappedConsentStatus == string(consentConfig.GetCreatedConsentStatus()) {
-			hasCreated = true
-			allApproved = false
-		} else if mappedConsentStatus != string(consentConfig.GetActiveConsentStatus()) {
-			allApproved = false
-		}
-	}
-
-	// Priority: rejected > created > approved (active)
-	if hasRejected {
-		return string(consentConfig.GetRejectedConsentStatus())
-	} else if hasCreated {
-		return string(consentConfig.GetCreatedConsentStatus())
-	} else if allApproved {
-		return string(consentConfig.GetActiveConsentStatus())
-	} else {
-		return string(consentConfig.GetCreatedConsentStatus())
+		status := consentConfig.MapAuthStatus(authStatus)
+		switch status {
+		case string(consentConfig.GetActiveConsentStatus()):
+			approved++
+		case string(consentConfig.GetRejectedConsentStatus()):
+			rejected++
+		}
+		mapped = append(mapped, status)
+	}
+
+	active := string(consentConfig.GetActiveConsentStatus())
+	strategy, quorum := consentConfig.StatusDerivation.GetStrategy(orgID, consentType)
+	switch strategy {
+	case config.StatusDerivationAnyApprove:
+		if approved > 0 {
+			return active
+		}
+	case config.StatusDerivationQuorum:
+		if approved >= quorum {
+			return active
+		}
+		// While enough authorizations are left to reach the quorum, rejections do not decide the
+		// status; the consent waits for the remaining authorizations
+		if rejected == 0 || len(mapped)-rejected >= quorum {
+			pending := make([]string, 0, len(mapped))
+			for _, status := range mapped {
+				if status != active && status != string(consentConfig.GetRejectedConsentStatus()) {
+					pending = append(pending, status)
+				}
+			}
+			if len(pending) == 0 {
+				return string(consentConfig.GetCreatedConsentStatus())
+			}
+			mapped = pending
+		}
+	}
+
+	// Keep the status with the highest priority
+	derived := ""
+	for _, status := range mapped {
+		if derived == "" || statusPriority(consentConfig, status) < statusPriority(consentConfig, derived) {
+			derived = status
+		}
+	}
+	return derived
+}
+
+// PendingAuthorizers returns the required authorizer roles of a consent that have no approved
+// authorization. An authorization fills the role matching its type; authTypes and authStatuses
+// describe the consent's authorizations in the same order.
+func PendingAuthorizers(orgID string, requiredAuthorizers, authTypes, authStatuses []string) []string {
+	consentConfig := config.Get().Consent.ForOrg(orgID)
+
+	approved := make(map[string]bool, len(authTypes))
+	for i, authType := range authTypes {
+		if consentConfig.MapAuthStatus(authStatuses[i]) == string(consentConfig.GetActiveConsentStatus()) {
+			approved[authType] = true
+		}
+	}
+
+	pending := make([]string, 0, len(requiredAuthorizers))
+	for _, role := range requiredAuthorizers {
+		if !approved[role] {
+			pending = append(pending, role)
+		}
+	}
+	return pending
+}
+
+// ApplyRequiredAuthorizers returns the status a consent with pending required authorizers takes
+// for the status derived from its authorizations. Once any authorization is approved it is
+// partially authorized instead of active, unless it is rejected.
+func ApplyRequiredAuthorizers(orgID, derived string, pending, authStatuses []string) string {
+	if len(pending) == 0 {
+		return derived
+	}
+	consentConfig := config.Get().Consent.ForOrg(orgID)
+	if derived == string(consentConfig.GetRejectedConsentStatus()) {
+		return derived
+	}
+	for _, authStatus := range authStatuses {
+		if consentConfig.MapAuthStatus(authStatus) == string(consentConfig.GetActiveConsentStatus()) {
+			return string(consentConfig.GetPartiallyAuthorizedStatus())
+		}
+	}
+	return derived
+}
+
+// KeepAwaitingReauthorization returns the status a consent in currentStatus takes for a derived
+// status. Consents awaiting re-authorization keep that status while their authorizations derive
+// the created status, so they stay marked until the user approves or rejects the re-authorization.
+func KeepAwaitingReauthorization(orgID, currentStatus, derived string) string {
+	consentConfig := config.Get().Consent.ForOrg(orgID)
+	if currentStatus == string(consentConfig.GetAwaitingReauthorizationStatus()) &&
+		derived == string(consentConfig.GetCreatedConsentStatus()) {
+		return currentStatus
 	}
+	return derived
+}
+
+// KeepSuspended returns the status a consent in currentStatus takes for a derived status.
+// Suspended consents keep that status whatever their authorizations derive, until they are resumed.
+func KeepSuspended(orgID, currentStatus, derived string) string {
+	if currentStatus == string(config.Get().Consent.ForOrg(orgID).GetSuspendedConsentStatus()) {
+		return currentStatus
+	}
+	return derived
+}
+
+// IsConsentEnded reports whether a consent in status has ended: revoked, expired, rejected or deleted
+func IsConsentEnded(orgID, status string) bool {
+	consentConfig := config.Get().Consent.ForOrg(orgID)
+	consentStatus := config.ConsentStatus(status)
+	return consentConfig.IsTerminalStatus(consentStatus) || consentConfig.IsRejectedStatus(consentStatus) ||
+		status == model.DeletedConsentStatus
+}
+
+// statusPriority ranks derived consent statuses: rejected, then created, then the additional
+// state machine states in configured order, then active
+func statusPriority(consentConfig *config.ConsentConfig, status string) int {
+	switch status {
+	case string(consentConfig.GetRejectedConsentStatus()):
+		return 0
+	case string(consentConfig.GetCreatedConsentStatus()):
+		return 1
+	case string(consentConfig.GetActiveConsentStatus()):
+		return len(consentConfig.StateMachine.States) + 2
+	}
+	for i, state := range consentConfig.StateMachine.States {
+		if state == status {
+			return i + 2
+		}
+	}
+	// Statuses outside the state machine are treated like created
+	return 1
+}
+
+// ValidateStatusTransition checks a consent status change against the configured state machine.
+// An empty from status checks the status a consent is created in. Transitions are only enforced
+// for organizations with the status_machine feature flag.
+func ValidateStatusTransition(orgID, from, to string) error {
+	if from == to || !featureflag.IsEnabled(orgID, featureflag.StatusMachine) {
+		return nil
+	}
+	consentConfig := config.Get().Consent.ForOrg(orgID)
+	if from == "" {
+		if !consentConfig.IsInitialStateAllowed(to) {
+			return fmt.Errorf("consents cannot be created in status '%s'", to)
+		}
+		return nil
+	}
+	if !consentConfig.IsTransitionAllowed(from, to) {
+		return fmt.Errorf("consent status cannot change from '%s' to '%s'", from, to)
+	}
+	return nil
 }
 
 // IsExpired checks if a given validity time has expired
@@ -172,6 +552,6 @@ func IsConsentExpired(validityTime int64) bool {
 		validityTimeMillis = validityTime
 	}
 
-	currentTimeMillis := time.Now().UnixNano() / int64(time.Millisecond)
+	currentTimeMillis := utils.GetCurrentTimeMillis()
 	return currentTimeMillis > validityTimeMillis
 }
@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+func setupFuzzConfig() {
+	config.SetGlobal(&config.Config{
+		Consent: config.ConsentConfig{
+			StatusMappings: config.ConsentStatusMappings{
+				ActiveStatus:   "ACTIVE",
+				ExpiredStatus:  "EXPIRED",
+				RevokedStatus:  "REVOKED",
+				CreatedStatus:  "CREATED",
+				RejectedStatus: "REJECTED",
+			},
+			AuthStatusMappings: config.AuthStatusMappings{
+				ApprovedState:      "APPROVED",
+				RejectedState:      "REJECTED",
+				CreatedState:       "CREATED",
+				SystemExpiredState: "SYS_EXPIRED",
+				SystemRevokedState: "SYS_REVOKED",
+			},
+			StatusDerivation: config.StatusDerivationConfig{
+				Rules: []config.StatusDerivationRule{
+					{ConsentTypes: []string{"any-approve"}, Strategy: config.StatusDerivationAnyApprove},
+					{ConsentTypes: []string{"quorum"}, Strategy: config.StatusDerivationQuorum, Quorum: 2},
+				},
+			},
+		},
+	})
+}
+
+// FuzzValidateConsentCreateRequest runs decoded create requests through the validator
+// and checks that accepted requests satisfy the documented constraints.
+func FuzzValidateConsentCreateRequest(f *testing.F) {
+	setupFuzzConfig()
+	f.Add([]byte(`{"type":"accounts","authorizations":[{"type":"authorisation"}]}`), "client-1", "org-1")
+	f.Add([]byte(`{"type":"' OR 1=1 --","authorizations":[{"type":"x","status":"SYS_EXPIRED"}]}`), "client'--", "org\"1")
+	f.Add([]byte(`{"type":"`+strings.Repeat("é", 64)+`"}`), "c", "o")
+	f.Add([]byte(`{"type":"accounts","validityTime":-1}`), "\u0000", "\u202e")
+	f.Add([]byte(`{"type":"accounts","authorizations":[{"type":""}]}`), "client", "")
+
+	f.Fuzz(func(t *testing.T, data []byte, clientID, orgID string) {
+		var req model.ConsentAPIRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			return
+		}
+
+		if err := ValidateConsentCreateRequest(req, clientID, orgID); err != nil {
+			return
+		}
+		if req.Type == "" || len(req.Type) > 64 {
+			t.Fatalf("invalid type %q accepted", req.Type)
+		}
+		if clientID == "" || orgID == "" {
+			t.Fatalf("empty clientID or orgID accepted")
+		}
+		for i, auth := range req.Authorizations {
+			if auth.Type == "" {
+				t.Fatalf("authorizations[%d] accepted without type", i)
+			}
+			if auth.Status == "SYS_EXPIRED" || auth.Status == "SYS_REVOKED" {
+				t.Fatalf("authorizations[%d] accepted with system status %q", i, auth.Status)
+			}
+		}
+	})
+}
+
+// FuzzValidateConsentUpdateRequest runs decoded update requests through the validator
+func FuzzValidateConsentUpdateRequest(f *testing.F) {
+	setupFuzzConfig()
+	f.Add([]byte(`{"type":"accounts"}`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`{"authorizations":[],"attributes":{}}`))
+	f.Add([]byte(`{"frequency":-2147483648,"validityTime":9223372036854775807}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var req model.ConsentAPIUpdateRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			return
+		}
+
+		if err := ValidateConsentUpdateRequest(req); err != nil {
+			return
+		}
+		if req.ValidityTime != nil && *req.ValidityTime < 0 {
+			t.Fatalf("negative validityTime accepted")
+		}
+		if req.Frequency != nil && *req.Frequency < 0 {
+			t.Fatalf("negative frequency accepted")
+		}
+	})
+}
+
+// FuzzEvaluateConsentStatusFromAuthStatuses checks that arbitrary auth statuses always
+// resolve to one of the configured consent statuses, with every status derivation strategy.
+func FuzzEvaluateConsentStatusFromAuthStatuses(f *testing.F) {
+	setupFuzzConfig()
+	f.Add("APPROVED,approved,")
+	f.Add("REJECTED,APPROVED")
+	f.Add("ſystem,İ,ﬀ")
+	f.Add("created\x00,' OR ''='")
+
+	f.Fuzz(func(t *testing.T, statuses string) {
+		for _, consentType := range []string{"accounts", "any-approve", "quorum"} {
+			status := EvaluateConsentStatusFromAuthStatuses("fuzz-org", consentType, strings.Split(statuses, ","))
+			switch status {
+			case "ACTIVE", "CREATED", "REJECTED":
+			default:
+				t.Fatalf("unexpected consent status %q for %s auth statuses %q", status, consentType, statuses)
+			}
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package consentfile
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/wso2/consent-management-api/internal/consentfile/model"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// multipartOverhead is the room allowed for multipart boundaries, part headers and other form
+// fields on top of the file size limit
+const multipartOverhead = 64 << 10
+
+// filePartName is the multipart form field that carries the uploaded file
+const filePartName = "file"
+
+// consentFileHandler handles HTTP requests for consent file attachments
+type consentFileHandler struct {
+	service ConsentFileService
+}
+
+// newConsentFileHandler creates a new consent file handler
+func newConsentFileHandler(service ConsentFileService) *consentFileHandler {
+	return &consentFileHandler{
+		service: service,
+	}
+}
+
+// uploadFile handles POST /consents/{consentId}/files
+func (h *consentFileHandler) uploadFile(w http.ResponseWriter, r *http.Request) {
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if !validateRequest(w, r, consentID) {
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(constants.HeaderContentType))
+	if err != nil || mediaType != "multipart/form-data" {
+		utils.SendError(w, r, contentTypeRejected("request must be multipart/form-data with a 'file' part"))
+		return
+	}
+
+	limit := maxFileSize()
+	r.Body = http.MaxBytesReader(w, r.Body, limit+multipartOverhead)
+	upload, serviceErr := readFilePart(r, limit)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	file, serviceErr := h.service.UploadFile(r.Context(), consentID, orgID, *upload)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, file)
+}
+
+// listFiles handles GET /consents/{consentId}/files
+func (h *consentFileHandler) listFiles(w http.ResponseWriter, r *http.Request) {
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if !validateRequest(w, r, consentID) {
+		return
+	}
+
+	files, serviceErr := h.service.ListFiles(r.Context(), consentID, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, model.ConsentFileListResponse{Data: files})
+}
+
+// getFile handles GET /consents/{consentId}/files/{fileId} and responds with the file content
+func (h *consentFileHandler) getFile(w http.ResponseWriter, r *http.Request) {
+	consentID := r.PathValue("consentId")
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if !validateRequest(w, r, consentID) {
+		return
+	}
+
+	file, serviceErr := h.service.GetFile(r.Context(), consentID, r.PathValue("fileId"), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, file.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(file.Content)
+}
+
+// validateRequest checks the organization and client headers and the consent ID, and sends an
+// error response when they are invalid
+func validateRequest(w http.ResponseWriter, r *http.Request, consentID string) bool {
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return false
+	}
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return false
+	}
+	return true
+}
+
+// readFilePart reads the file part of a multipart request. At most one byte more than limit is
+// read, so oversized files are rejected without buffering them whole.
+func readFilePart(r *http.Request, limit int64) (*model.FileUpload, *serviceerror.ServiceError) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid multipart request")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+				"multipart request has no 'file' part")
+		}
+		if err != nil {
+			return nil, multipartReadError(err, limit)
+		}
+		if part.FormName() != filePartName {
+			continue
+		}
+
+		content, err := io.ReadAll(io.LimitReader(part, limit+1))
+		if err != nil {
+			return nil, multipartReadError(err, limit)
+		}
+		contentType := part.Header.Get(constants.HeaderContentType)
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		return &model.FileUpload{
+			FileName:    part.FileName(),
+			ContentType: contentType,
+			Content:     content,
+		}, nil
+	}
+}
+
+// multipartReadError maps a failure to read the request body to a service error
+func multipartReadError(err error, limit int64) *serviceerror.ServiceError {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return fileTooLarge(limit)
+	}
+	return serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid multipart request")
+}
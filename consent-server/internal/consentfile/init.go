@@ -0,0 +1,43 @@
+package consentfile
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/audit"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize sets up the consent file module and registers routes. It fails when the configured
+// content storage cannot be used.
+func Initialize(mux *http.ServeMux, registry *stores.StoreRegistry) (ConsentFileService, error) {
+	service, err := newConsentFileService(registry)
+	if err != nil {
+		return nil, err
+	}
+	handler := newConsentFileHandler(service)
+
+	registerRoutes(mux, handler)
+
+	return service, nil
+}
+
+// registerRoutes registers all consent file routes
+func registerRoutes(mux *http.ServeMux, handler *consentFileHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:      "*",
+		AllowMethods:     []string{"GET", "POST", "OPTIONS"},
+		AllowHeaders:     []string{"Content-Type", "Authorization", "X-Organization-ID", "X-Correlation-ID"},
+		AllowCredentials: true,
+	}
+
+	// POST /api/v1/consents/{consentId}/files - Attach a file to a consent
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/{consentId}/files", middleware.WithOperationAudit(audit.ActionFileUpload, middleware.WithScope(middleware.ScopeConsentsWrite, handler.uploadFile)), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/files - List the files of a consent
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/files", middleware.WithScope(middleware.ScopeConsentsRead, handler.listFiles), corsOpts))
+
+	// GET /api/v1/consents/{consentId}/files/{fileId} - Download a file
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/files/{fileId}", middleware.WithScope(middleware.ScopeConsentsRead, handler.getFile), corsOpts))
+}
@@ -0,0 +1,29 @@
+package model
+
+// ConsentFile represents the CONSENT_FILE table. Content is only loaded when a file is downloaded
+// and is empty for files kept in object storage.
+type ConsentFile struct {
+	FileID          string  `json:"id"`
+	ConsentID       string  `json:"consentId"`
+	OrgID           string  `json:"orgId"`
+	FileName        string  `json:"fileName"`
+	ContentType     string  `json:"contentType"`
+	Size            int64   `json:"size"`
+	Checksum        string  `json:"checksum"`
+	StorageType     string  `json:"-"`
+	StorageLocation *string `json:"-"`
+	Content         []byte  `json:"-"`
+	CreatedTime     int64   `json:"createdTime"`
+}
+
+// FileUpload is a file received for attachment to a consent
+type FileUpload struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// ConsentFileListResponse represents the response for listing the files of a consent
+type ConsentFileListResponse struct {
+	Data []ConsentFile `json:"data"`
+}
@@ -0,0 +1,285 @@
+package consentfile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/consentfile/model"
+	"github.com/wso2/consent-management-api/internal/system/audit"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/codes"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+const (
+	defaultMaxFileSize = 10 << 20
+	maxFileNameLength  = 255
+)
+
+// defaultAllowedContentTypes are accepted when consent.files.allowed_content_types is not set
+var defaultAllowedContentTypes = []string{"application/pdf", "image/png", "image/jpeg", "text/plain"}
+
+// signedContentTypes are the allowed types that http.DetectContentType recognizes from the file
+// signature. Files declared with one of them must carry that signature.
+var signedContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/zip": true,
+}
+
+// ConsentFileService defines the exported service interface for consent file attachments
+type ConsentFileService interface {
+	UploadFile(ctx context.Context, consentID, orgID string, upload model.FileUpload) (*model.ConsentFile, *serviceerror.ServiceError)
+	ListFiles(ctx context.Context, consentID, orgID string) ([]model.ConsentFile, *serviceerror.ServiceError)
+	GetFile(ctx context.Context, consentID, fileID, orgID string) (*model.ConsentFile, *serviceerror.ServiceError)
+}
+
+// consentFileService implements the ConsentFileService interface
+type consentFileService struct {
+	stores  *stores.StoreRegistry
+	storage contentStorage
+}
+
+// newConsentFileService creates a new consent file service using the configured content storage
+func newConsentFileService(registry *stores.StoreRegistry) (ConsentFileService, error) {
+	storage, err := newContentStorage(config.Get().Consent.Files.Storage)
+	if err != nil {
+		return nil, err
+	}
+	return &consentFileService{stores: registry, storage: storage}, nil
+}
+
+// UploadFile validates a file and attaches it to a consent
+func (s *consentFileService) UploadFile(ctx context.Context, consentID, orgID string, upload model.FileUpload) (*model.ConsentFile, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentfile.UploadFile")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	contentType, serviceErr := validateUpload(&upload)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	if serviceErr := s.checkConsentExists(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if maxFiles := config.Get().Consent.Files.MaxFilesPerConsent; maxFiles > 0 {
+		count, err := s.stores.ConsentFile.CountByConsentID(ctx, consentID, orgID)
+		if err != nil {
+			logger.Error("Failed to count consent files", log.Error(err), log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to count consent files: %v", err))
+		}
+		if count >= maxFiles {
+			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+				fmt.Sprintf("consent already has the maximum of %d files", maxFiles))
+		}
+	}
+
+	checksum := sha256.Sum256(upload.Content)
+	file := &model.ConsentFile{
+		FileID:      utils.GenerateUUID(),
+		ConsentID:   consentID,
+		OrgID:       orgID,
+		FileName:    upload.FileName,
+		ContentType: contentType,
+		Size:        int64(len(upload.Content)),
+		Checksum:    hex.EncodeToString(checksum[:]),
+		StorageType: config.FileStorageDatabase,
+		CreatedTime: utils.GetCurrentTimeMillis(),
+	}
+
+	if s.storage == nil {
+		file.Content = upload.Content
+	} else {
+		location, err := s.storage.Put(ctx, orgID+"/"+consentID+"/"+file.FileID, upload.Content, contentType)
+		if err != nil {
+			logger.Error("Failed to store consent file", log.Error(err), log.String("consent_id", consentID))
+			return nil, serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to store file")
+		}
+		file.StorageType = config.FileStorageS3
+		file.StorageLocation = &location
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.ConsentFile.Create(tx, file)
+		},
+	}); err != nil {
+		logger.Error("Failed to create consent file", log.Error(err), log.String("consent_id", consentID))
+		if file.StorageLocation != nil {
+			if deleteErr := s.storage.Delete(ctx, *file.StorageLocation); deleteErr != nil {
+				logger.Warn("Failed to remove stored file after a failed upload",
+					log.Error(deleteErr), log.String("location", *file.StorageLocation))
+			}
+		}
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to create consent file: %v", err))
+	}
+
+	logger.Info("Consent file attached",
+		log.String("consent_id", consentID),
+		log.String("file_id", file.FileID),
+		log.String("content_type", contentType),
+		log.Int("size", int(file.Size)),
+		log.String("storage_type", file.StorageType))
+
+	audit.AddDetail(ctx, "file", map[string]interface{}{
+		"id":          file.FileID,
+		"fileName":    file.FileName,
+		"contentType": contentType,
+		"size":        file.Size,
+		"checksum":    file.Checksum,
+	})
+
+	file.Content = nil
+	return file, nil
+}
+
+// ListFiles retrieves the files attached to a consent, without their content
+func (s *consentFileService) ListFiles(ctx context.Context, consentID, orgID string) ([]model.ConsentFile, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentfile.ListFiles")
+	defer span.End()
+
+	if serviceErr := s.checkConsentExists(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	files, err := s.stores.ConsentFile.ListByConsentID(ctx, consentID, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to list consent files: %v", err))
+	}
+	return files, nil
+}
+
+// GetFile retrieves a file attached to a consent together with its content
+func (s *consentFileService) GetFile(ctx context.Context, consentID, fileID, orgID string) (*model.ConsentFile, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentfile.GetFile")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if serviceErr := s.checkConsentExists(ctx, consentID, orgID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	file, err := s.stores.ConsentFile.GetByID(ctx, fileID, consentID, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve consent file: %v", err))
+	}
+	if file == nil {
+		return nil, consentFileNotFound(fileID)
+	}
+
+	if file.StorageType == config.FileStorageS3 {
+		if s.storage == nil || file.StorageLocation == nil {
+			logger.Error("Consent file is kept in object storage, which is not configured",
+				log.String("file_id", fileID))
+			return nil, serviceerror.CustomServiceError(serviceerror.InternalServerError, "file storage is not available")
+		}
+		content, err := s.storage.Get(ctx, *file.StorageLocation)
+		if err != nil {
+			logger.Error("Failed to read consent file from object storage",
+				log.Error(err), log.String("file_id", fileID))
+			return nil, serviceerror.CustomServiceError(serviceerror.InternalServerError, "failed to read file")
+		}
+		file.Content = content
+	}
+	return file, nil
+}
+
+// checkConsentExists returns a not found error when the consent does not exist or is deleted
+func (s *consentFileService) checkConsentExists(ctx context.Context, consentID, orgID string) *serviceerror.ServiceError {
+	consent, err := s.stores.Consent.GetByID(ctx, consentID, orgID)
+	if err != nil {
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve consent: %v", err))
+	}
+	if consent == nil {
+		return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("Consent with ID '%s' not found", consentID))
+	}
+	return nil
+}
+
+// maxFileSize returns the configured file size limit in bytes
+func maxFileSize() int64 {
+	if size := config.Get().Consent.Files.MaxSize; size > 0 {
+		return size
+	}
+	return defaultMaxFileSize
+}
+
+// validateUpload checks the name, size and content type of an upload and returns its normalized
+// content type
+func validateUpload(upload *model.FileUpload) (string, *serviceerror.ServiceError) {
+	upload.FileName = strings.TrimSpace(upload.FileName)
+	if upload.FileName == "" {
+		return "", serviceerror.CustomServiceError(serviceerror.ValidationError, "file name is required")
+	}
+	if len(upload.FileName) > maxFileNameLength {
+		return "", serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("file name must not exceed %d characters", maxFileNameLength))
+	}
+	if len(upload.Content) == 0 {
+		return "", serviceerror.CustomServiceError(serviceerror.ValidationError, "file is empty")
+	}
+	if limit := maxFileSize(); int64(len(upload.Content)) > limit {
+		return "", fileTooLarge(limit)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(upload.ContentType)
+	if err != nil {
+		return "", contentTypeRejected(fmt.Sprintf("invalid content type '%s'", upload.ContentType))
+	}
+	allowed := config.Get().Consent.Files.AllowedContentTypes
+	if len(allowed) == 0 {
+		allowed = defaultAllowedContentTypes
+	}
+	if !containsFold(allowed, mediaType) {
+		return "", contentTypeRejected(fmt.Sprintf("content type '%s' is not allowed, must be one of: %s",
+			mediaType, strings.Join(allowed, ", ")))
+	}
+	if signedContentTypes[mediaType] {
+		detected, _, _ := mime.ParseMediaType(http.DetectContentType(upload.Content))
+		if detected != mediaType {
+			return "", serviceerror.CustomServiceError(serviceerror.ValidationError,
+				fmt.Sprintf("file content does not match content type '%s'", mediaType))
+		}
+	}
+	return mime.FormatMediaType(mediaType, params), nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func consentFileNotFound(fileID string) *serviceerror.ServiceError {
+	return serviceerror.NewServiceError(codes.ConsentFileNotFound, serviceerror.ClientErrorType,
+		"Consent File Not Found", fmt.Sprintf("consent file '%s' not found", fileID))
+}
+
+func fileTooLarge(limit int64) *serviceerror.ServiceError {
+	return serviceerror.NewServiceError(codes.ConsentFileTooLarge, serviceerror.ClientErrorType,
+		"File Too Large", fmt.Sprintf("file exceeds the maximum size of %d bytes", limit))
+}
+
+func contentTypeRejected(description string) *serviceerror.ServiceError {
+	return serviceerror.NewServiceError(codes.ConsentFileTypeRejected, serviceerror.ClientErrorType,
+		"Unsupported Media Type", description)
+}
@@ -0,0 +1,72 @@
+package consentfile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/objectstorage"
+)
+
+const defaultObjectStorageTimeout = 30 * time.Second
+
+// contentStorage keeps file contents outside the database. Locations returned by Put are stored
+// with the file metadata and passed back to Get.
+type contentStorage interface {
+	Put(ctx context.Context, name string, data []byte, contentType string) (string, error)
+	Get(ctx context.Context, location string) ([]byte, error)
+	Delete(ctx context.Context, location string) error
+}
+
+// newContentStorage creates the configured content storage, or nil when contents are kept in the database
+func newContentStorage(cfg config.FileStorageConfig) (contentStorage, error) {
+	if cfg.Type != config.FileStorageS3 {
+		return nil, nil
+	}
+
+	endpoint := cfg.S3.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.S3.Region)
+	}
+	timeout := cfg.S3.Timeout
+	if timeout == 0 {
+		timeout = defaultObjectStorageTimeout
+	}
+	client, err := objectstorage.NewClient(endpoint, cfg.S3.Region, cfg.S3.Bucket,
+		cfg.S3.AccessKeyID, cfg.S3.SecretAccessKey, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &objectContentStorage{prefix: strings.Trim(cfg.S3.Path, "/"), client: client}, nil
+}
+
+// objectContentStorage keeps file contents in an S3 compatible bucket. Locations are object keys
+// within the configured bucket.
+type objectContentStorage struct {
+	prefix string
+	client *objectstorage.Client
+}
+
+// Put uploads a file under the configured path prefix and returns its object key
+func (s *objectContentStorage) Put(ctx context.Context, name string, data []byte, contentType string) (string, error) {
+	key := name
+	if s.prefix != "" {
+		key = s.prefix + "/" + name
+	}
+	if err := s.client.Put(ctx, key, data, contentType); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// Get downloads a file by its object key
+func (s *objectContentStorage) Get(ctx context.Context, location string) ([]byte, error) {
+	return s.client.Get(ctx, location)
+}
+
+// Delete removes a file by its object key
+func (s *objectContentStorage) Delete(ctx context.Context, location string) error {
+	return s.client.Delete(ctx, location)
+}
@@ -0,0 +1,140 @@
+package consentfile
+
+import (
+	"context"
+
+	"github.com/wso2/consent-management-api/internal/consentfile/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// DBQuery objects for consent file operations
+var (
+	QueryCreateConsentFile = dbmodel.DBQuery{
+		ID:    "CREATE_CONSENT_FILE",
+		Query: "INSERT INTO CONSENT_FILE (FILE_ID, CONSENT_ID, ORG_ID, FILE_NAME, CONTENT_TYPE, FILE_SIZE, CHECKSUM, STORAGE_TYPE, STORAGE_LOCATION, CONTENT, CREATED_TIME) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryGetConsentFileByID = dbmodel.DBQuery{
+		ID:    "GET_CONSENT_FILE_BY_ID",
+		Query: "SELECT FILE_ID, CONSENT_ID, ORG_ID, FILE_NAME, CONTENT_TYPE, FILE_SIZE, CHECKSUM, STORAGE_TYPE, STORAGE_LOCATION, CONTENT, CREATED_TIME FROM CONSENT_FILE WHERE FILE_ID = ? AND CONSENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryListConsentFiles = dbmodel.DBQuery{
+		ID:    "LIST_CONSENT_FILES",
+		Query: "SELECT FILE_ID, CONSENT_ID, ORG_ID, FILE_NAME, CONTENT_TYPE, FILE_SIZE, CHECKSUM, STORAGE_TYPE, STORAGE_LOCATION, CREATED_TIME FROM CONSENT_FILE WHERE CONSENT_ID = ? AND ORG_ID = ? ORDER BY CREATED_TIME, FILE_ID",
+	}
+
+	QueryCountConsentFiles = dbmodel.DBQuery{
+		ID:    "COUNT_CONSENT_FILES",
+		Query: "SELECT COUNT(*) as count FROM CONSENT_FILE WHERE CONSENT_ID = ? AND ORG_ID = ?",
+	}
+)
+
+// store implements the interfaces.ConsentFileStore interface
+type store struct {
+	dbClient provider.DBClientInterface
+}
+
+// NewConsentFileStore creates a new consent file store
+func NewConsentFileStore(dbClient provider.DBClientInterface) interfaces.ConsentFileStore {
+	return &store{
+		dbClient: dbClient,
+	}
+}
+
+// Create creates a consent file within a transaction
+func (s *store) Create(tx dbmodel.TxInterface, file *model.ConsentFile) error {
+	_, err := tx.Exec(QueryCreateConsentFile.Query,
+		file.FileID, file.ConsentID, file.OrgID, file.FileName, file.ContentType, file.Size, file.Checksum,
+		file.StorageType, file.StorageLocation, file.Content, file.CreatedTime)
+	return err
+}
+
+// GetByID retrieves a consent file including its content when it is kept in the database
+func (s *store) GetByID(ctx context.Context, fileID, consentID, orgID string) (*model.ConsentFile, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetConsentFileByID, fileID, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	file := mapToConsentFile(rows[0])
+	if content, ok := rows[0]["content"].([]byte); ok {
+		file.Content = content
+	} else if content, ok := rows[0]["content"].(string); ok {
+		file.Content = []byte(content)
+	}
+	return file, nil
+}
+
+// ListByConsentID retrieves the files of a consent without their content, oldest first
+func (s *store) ListByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentFile, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryListConsentFiles, consentID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]model.ConsentFile, 0, len(rows))
+	for _, row := range rows {
+		files = append(files, *mapToConsentFile(row))
+	}
+	return files, nil
+}
+
+// CountByConsentID counts the files of a consent
+func (s *store) CountByConsentID(ctx context.Context, consentID, orgID string) (int, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryCountConsentFiles, consentID, orgID)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) > 0 {
+		if count, ok := rows[0]["count"].(int64); ok {
+			return int(count), nil
+		}
+	}
+	return 0, nil
+}
+
+// mapToConsentFile converts a database row map to ConsentFile
+// Note: DBClient normalizes column names to lowercase
+func mapToConsentFile(row map[string]interface{}) *model.ConsentFile {
+	return &model.ConsentFile{
+		FileID:          getString(row, "file_id"),
+		ConsentID:       getString(row, "consent_id"),
+		OrgID:           getString(row, "org_id"),
+		FileName:        getString(row, "file_name"),
+		ContentType:     getString(row, "content_type"),
+		Size:            getInt64(row, "file_size"),
+		Checksum:        getString(row, "checksum"),
+		StorageType:     getString(row, "storage_type"),
+		StorageLocation: getStringPtr(row, "storage_location"),
+		CreatedTime:     getInt64(row, "created_time"),
+	}
+}
+
+func getString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+func getStringPtr(row map[string]interface{}, key string) *string {
+	if row[key] == nil {
+		return nil
+	}
+	v := getString(row, key)
+	return &v
+}
+
+func getInt64(row map[string]interface{}, key string) int64 {
+	if v, ok := row[key].(int64); ok {
+		return v
+	}
+	return 0
+}
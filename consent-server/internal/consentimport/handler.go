@@ -0,0 +1,94 @@
+package consentimport
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// importMediaTypes lists the content types an import file may be sent with
+var importMediaTypes = map[string]bool{
+	"application/x-ndjson": true,
+	"application/ndjson":   true,
+	"application/jsonl":    true,
+}
+
+// importHandler handles HTTP requests for bulk consent imports
+type importHandler struct {
+	service ImportService
+}
+
+// newImportHandler creates a new import handler
+func newImportHandler(service ImportService) *importHandler {
+	return &importHandler{
+		service: service,
+	}
+}
+
+// submitImport handles POST /consents/import. The request body is the NDJSON import file.
+func (h *importHandler) submitImport(w http.ResponseWriter, r *http.Request) {
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(constants.HeaderContentType))
+	if err != nil || !importMediaTypes[mediaType] {
+		utils.SendError(w, r, importFileTypeRejected("import file must be sent as application/x-ndjson"))
+		return
+	}
+
+	// Large import files take longer to upload than the server read timeout allows
+	_ = http.NewResponseController(w).SetReadDeadline(time.Time{})
+
+	job, serviceErr := h.service.SubmitImport(r.Context(), r.Body,
+		r.Header.Get(constants.HeaderTPPClientID), r.Header.Get(constants.HeaderOrgID))
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set("Location", constants.APIBasePath+"/jobs/"+job.JobID)
+	utils.JSONResponse(w, http.StatusAccepted, job)
+}
+
+// getJob handles GET /jobs/{jobId}. The row errors of the job are paginated with limit and offset.
+func (h *importHandler) getJob(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	limit, offset := parsePagination(r)
+	job, serviceErr := h.service.GetJob(r.Context(), r.PathValue("jobId"), orgID, limit, offset)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, job)
+}
+
+// parsePagination reads limit and offset query parameters, ignoring invalid values
+func parsePagination(r *http.Request) (int, int) {
+	limit := 20
+	offset := 0
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return limit, offset
+}
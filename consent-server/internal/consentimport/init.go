@@ -0,0 +1,40 @@
+package consentimport
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/consent"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize sets up the consent import module, registers routes and resumes import jobs that
+// were interrupted by a restart
+func Initialize(mux *http.ServeMux, registry *stores.StoreRegistry, consentService consent.ConsentService) ImportService {
+	service := newImportService(registry, consentService)
+	handler := newImportHandler(service)
+
+	registerRoutes(mux, handler)
+
+	service.ResumeJobs(context.Background())
+
+	return service
+}
+
+// registerRoutes registers all consent import routes
+func registerRoutes(mux *http.ServeMux, handler *importHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:      "*",
+		AllowMethods:     []string{"GET", "POST", "OPTIONS"},
+		AllowHeaders:     []string{"Content-Type", "Authorization", "X-Organization-ID", "X-Correlation-ID"},
+		AllowCredentials: true,
+	}
+
+	// POST /api/v1/consents/import - Submit an NDJSON file of consents to import
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consents/import", middleware.WithScope(middleware.ScopeConsentsWrite, handler.submitImport), corsOpts))
+
+	// GET /api/v1/jobs/{jobId} - Get an import job and its row errors
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/jobs/{jobId}", middleware.WithScope(middleware.ScopeConsentsRead, handler.getJob), corsOpts))
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	consentmodel "github.com/wso2/consent-management-api/internal/consent/model"
+)
+
+// Import job statuses
+const (
+	ImportStatusPending   = "PENDING"
+	ImportStatusRunning   = "RUNNING"
+	ImportStatusCompleted = "COMPLETED"
+	ImportStatusFailed    = "FAILED"
+)
+
+// ImportJobType is the job type reported for consent import jobs
+const ImportJobType = "CONSENT_IMPORT"
+
+// ImportJob represents the IMPORT_JOB table
+type ImportJob struct {
+	JobID          string  `json:"id"`
+	Type           string  `json:"type"`
+	OrgID          string  `json:"orgId"`
+	ClientID       string  `json:"clientId"`
+	Status         string  `json:"status"`
+	ProcessedCount int64   `json:"processedCount"`
+	SucceededCount int64   `json:"succeededCount"`
+	FailedCount    int64   `json:"failedCount"`
+	ErrorMessage   *string `json:"errorMessage,omitempty"`
+	CreatedTime    int64   `json:"createdTime"`
+	UpdatedTime    int64   `json:"updatedTime"`
+	CompletedTime  *int64  `json:"completedTime,omitempty"`
+}
+
+// ImportRowError represents the IMPORT_JOB_ERROR table, a line of an import file that could not
+// be imported
+type ImportRowError struct {
+	JobID      string `json:"-"`
+	OrgID      string `json:"-"`
+	LineNumber int64  `json:"line"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+// ImportRecord is a single line of an import file. It takes the consent create payload, with an
+// optional client ID for consents that belong to a client other than the one submitting the job.
+type ImportRecord struct {
+	consentmodel.ConsentAPIRequest
+	ClientID string `json:"clientId,omitempty"`
+}
+
+// ImportJobResponse represents the response for retrieving an import job with a page of its row
+// errors
+type ImportJobResponse struct {
+	ImportJob
+	Errors ImportErrorList `json:"errors"`
+}
+
+// ImportErrorList is a page of row errors of an import job
+type ImportErrorList struct {
+	Data     []ImportRowError   `json:"data"`
+	Metadata PaginationMetadata `json:"metadata"`
+}
+
+// PaginationMetadata represents pagination metadata
+type PaginationMetadata struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
@@ -0,0 +1,419 @@
+package consentimport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/consent"
+	"github.com/wso2/consent-management-api/internal/consentimport/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/codes"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// importFileExt is the extension of import files kept in the work directory
+const importFileExt = ".ndjson"
+
+// ImportService defines the exported service interface for bulk consent imports
+type ImportService interface {
+	SubmitImport(ctx context.Context, body io.Reader, clientID, orgID string) (*model.ImportJob, *serviceerror.ServiceError)
+	GetJob(ctx context.Context, jobID, orgID string, limit, offset int) (*model.ImportJobResponse, *serviceerror.ServiceError)
+	ResumeJobs(ctx context.Context)
+	Stop()
+}
+
+// importService implements the ImportService interface. Jobs are processed by a fixed number of
+// workers in this server; each job reads its file from the work directory.
+type importService struct {
+	stores         *stores.StoreRegistry
+	consentService consent.ConsentService
+	workDir        string
+
+	queue  chan *model.ImportJob
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newImportService creates a new import service and starts its workers
+func newImportService(registry *stores.StoreRegistry, consentService consent.ConsentService) ImportService {
+	importCfg := config.Get().Consent.Import
+
+	workDir := importCfg.WorkDir
+	if workDir == "" {
+		workDir = filepath.Join(os.TempDir(), "consent-imports")
+	}
+	workers := importCfg.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &importService{
+		stores:         registry,
+		consentService: consentService,
+		workDir:        workDir,
+		queue:          make(chan *model.ImportJob, 64),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	for i := 0; i < workers; i++ {
+		s.wg.Add(1)
+		go s.worker()
+	}
+	return s
+}
+
+// SubmitImport stores the import file in the work directory and queues a job to import it
+func (s *importService) SubmitImport(ctx context.Context, body io.Reader, clientID, orgID string) (*model.ImportJob, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+	if err := utils.ValidateClientID(clientID); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	now := utils.GetCurrentTimeMillis()
+	job := &model.ImportJob{
+		JobID:       utils.GenerateUUID(),
+		Type:        model.ImportJobType,
+		OrgID:       orgID,
+		ClientID:    clientID,
+		Status:      model.ImportStatusPending,
+		CreatedTime: now,
+		UpdatedTime: now,
+	}
+
+	if serviceErr := s.saveFile(job.JobID, body); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.ImportJob.Create(tx, job)
+		},
+	}); err != nil {
+		logger.Error("Failed to create import job", log.Error(err))
+		os.Remove(s.filePath(job.JobID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to create import job: %v", err))
+	}
+
+	logger.Info("Consent import job created", log.String("job_id", job.JobID), log.String("org_id", orgID))
+	s.enqueue(job)
+	return job, nil
+}
+
+// GetJob retrieves an import job with a page of its row errors
+func (s *importService) GetJob(ctx context.Context, jobID, orgID string, limit, offset int) (*model.ImportJobResponse, *serviceerror.ServiceError) {
+	job, err := s.stores.ImportJob.GetByID(ctx, jobID, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve import job: %v", err))
+	}
+	if job == nil {
+		return nil, importJobNotFound(jobID)
+	}
+
+	rowErrors, total, err := s.stores.ImportJob.ListErrors(ctx, jobID, orgID, limit, offset)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to list import errors: %v", err))
+	}
+
+	return &model.ImportJobResponse{
+		ImportJob: *job,
+		Errors: model.ImportErrorList{
+			Data: rowErrors,
+			Metadata: model.PaginationMetadata{
+				Total:  total,
+				Limit:  limit,
+				Offset: offset,
+				Count:  len(rowErrors),
+			},
+		},
+	}, nil
+}
+
+// ResumeJobs queues the unfinished jobs whose import file is in this server's work directory, so
+// jobs interrupted by a restart continue after the last processed line
+func (s *importService) ResumeJobs(ctx context.Context) {
+	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "ConsentImport"))
+
+	jobs, err := s.stores.ImportJob.GetUnfinished(ctx)
+	if err != nil {
+		logger.Error("Failed to load unfinished import jobs", log.Error(err))
+		return
+	}
+
+	for i := range jobs {
+		if _, err := os.Stat(s.filePath(jobs[i].JobID)); err != nil {
+			// The job was submitted to another server
+			continue
+		}
+		logger.Info("Resuming consent import job",
+			log.String("job_id", jobs[i].JobID),
+			log.Any("processed_count", jobs[i].ProcessedCount))
+		s.enqueue(&jobs[i])
+	}
+}
+
+// Stop stops the workers. A job in progress stops after its current line and is resumed from
+// there when the server starts again.
+func (s *importService) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// enqueue hands a job to the workers without blocking the caller
+func (s *importService) enqueue(job *model.ImportJob) {
+	select {
+	case s.queue <- job:
+	default:
+		go func() {
+			select {
+			case s.queue <- job:
+			case <-s.ctx.Done():
+			}
+		}()
+	}
+}
+
+// worker processes queued jobs until the service is stopped
+func (s *importService) worker() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case job := <-s.queue:
+			s.runJob(job)
+		}
+	}
+}
+
+// runJob imports the lines of a job's file that have not been processed yet. Each line is created
+// through the consent service, so it goes through the same validation as a create request. Lines
+// that are rejected are recorded as row errors; a server side failure stops the job.
+func (s *importService) runJob(job *model.ImportJob) {
+	// Lines are imported to completion even while the service is stopping; the stop is only
+	// checked between lines
+	ctx := context.WithoutCancel(s.ctx)
+	logger := log.GetLogger().With(
+		log.String(log.LoggerKeyComponentName, "ConsentImport"),
+		log.String("job_id", job.JobID),
+		log.String("org_id", job.OrgID))
+
+	file, err := os.Open(s.filePath(job.JobID))
+	if err != nil {
+		logger.Error("Failed to open import file", log.Error(err))
+		s.finishJob(ctx, job, model.ImportStatusFailed, "import file is no longer available")
+		return
+	}
+	defer file.Close()
+
+	if job.Status != model.ImportStatusRunning {
+		job.Status = model.ImportStatusRunning
+		job.UpdatedTime = utils.GetCurrentTimeMillis()
+		if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+			func(tx dbmodel.TxInterface) error {
+				return s.stores.ImportJob.UpdateStatus(tx, job)
+			},
+		}); err != nil {
+			logger.Error("Failed to start import job", log.Error(err))
+			return
+		}
+	}
+	logger.Info("Consent import job started", log.Any("processed_count", job.ProcessedCount))
+
+	reader := bufio.NewReader(file)
+	var lineNumber int64
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if len(line) == 0 && readErr != nil {
+			if readErr != io.EOF {
+				logger.Error("Failed to read import file", log.Error(readErr))
+				s.finishJob(ctx, job, model.ImportStatusFailed, fmt.Sprintf("failed to read import file: %v", readErr))
+				return
+			}
+			break
+		}
+		lineNumber++
+		if lineNumber <= job.ProcessedCount {
+			continue
+		}
+		if s.ctx.Err() != nil {
+			logger.Info("Consent import job paused", log.Any("processed_count", job.ProcessedCount))
+			return
+		}
+
+		line = bytes.TrimSpace(line)
+		var rowErr *serviceerror.ServiceError
+		if len(line) > 0 {
+			rowErr = s.importLine(ctx, job, line)
+		}
+		if rowErr != nil && rowErr.Type == serviceerror.ServerErrorType {
+			logger.Error("Consent import job stopped by a server error",
+				log.Any("line", lineNumber), log.String("error", rowErr.Error()))
+			s.finishJob(ctx, job, model.ImportStatusFailed, fmt.Sprintf("line %d: %s", lineNumber, rowErr.Description))
+			return
+		}
+
+		if err := s.recordLine(ctx, job, lineNumber, len(line) > 0, rowErr); err != nil {
+			logger.Error("Failed to record import progress", log.Error(err), log.Any("line", lineNumber))
+			s.finishJob(ctx, job, model.ImportStatusFailed, fmt.Sprintf("failed to record progress: %v", err))
+			return
+		}
+	}
+
+	s.finishJob(ctx, job, model.ImportStatusCompleted, "")
+	logger.Info("Consent import job completed",
+		log.Any("succeeded_count", job.SucceededCount),
+		log.Any("failed_count", job.FailedCount))
+}
+
+// importLine creates the consent described by one line of an import file
+func (s *importService) importLine(ctx context.Context, job *model.ImportJob, line []byte) *serviceerror.ServiceError {
+	var record model.ImportRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.InvalidRequestError, fmt.Sprintf("invalid JSON: %v", err))
+	}
+
+	clientID := record.ClientID
+	if clientID == "" {
+		clientID = job.ClientID
+	}
+	_, serviceErr := s.consentService.CreateConsent(ctx, record.ConsentAPIRequest, clientID, job.OrgID)
+	return serviceErr
+}
+
+// recordLine advances the job past a line, recording the row error when the line was rejected.
+// Blank lines are passed with counted set to false and only advance the job.
+func (s *importService) recordLine(ctx context.Context, job *model.ImportJob, lineNumber int64, counted bool, rowErr *serviceerror.ServiceError) error {
+	progress := *job
+	progress.ProcessedCount = lineNumber
+	progress.UpdatedTime = utils.GetCurrentTimeMillis()
+
+	queries := []func(tx dbmodel.TxInterface) error{}
+	if rowErr != nil {
+		progress.FailedCount++
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return s.stores.ImportJob.CreateError(tx, &model.ImportRowError{
+				JobID:      job.JobID,
+				OrgID:      job.OrgID,
+				LineNumber: lineNumber,
+				Code:       rowErr.Code,
+				Message:    rowErr.Description,
+			})
+		})
+	} else if counted {
+		progress.SucceededCount++
+	}
+	queries = append(queries, func(tx dbmodel.TxInterface) error {
+		return s.stores.ImportJob.UpdateProgress(tx, &progress)
+	})
+
+	if err := s.stores.ExecuteTransaction(ctx, queries); err != nil {
+		return err
+	}
+	*job = progress
+	return nil
+}
+
+// finishJob records the final status of a job and removes its import file
+func (s *importService) finishJob(ctx context.Context, job *model.ImportJob, status, errorMessage string) {
+	now := utils.GetCurrentTimeMillis()
+	job.Status = status
+	job.UpdatedTime = now
+	job.CompletedTime = &now
+	if errorMessage != "" {
+		job.ErrorMessage = &errorMessage
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.ImportJob.UpdateStatus(tx, job)
+		},
+	}); err != nil {
+		log.GetLogger().Error("Failed to record import job status", log.Error(err),
+			log.String("job_id", job.JobID), log.String("status", status))
+		return
+	}
+
+	if err := os.Remove(s.filePath(job.JobID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.GetLogger().Warn("Failed to remove import file", log.Error(err), log.String("job_id", job.JobID))
+	}
+}
+
+// saveFile writes an uploaded import file to the work directory. The file is written under a
+// temporary name and renamed once complete, so a partial upload is never picked up by a worker.
+func (s *importService) saveFile(jobID string, body io.Reader) *serviceerror.ServiceError {
+	if err := os.MkdirAll(s.workDir, 0o700); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.InternalServerError, fmt.Sprintf("failed to create import work directory: %v", err))
+	}
+
+	path := s.filePath(jobID)
+	partPath := path + ".part"
+	file, err := os.OpenFile(partPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return serviceerror.CustomServiceError(serviceerror.InternalServerError, fmt.Sprintf("failed to store import file: %v", err))
+	}
+
+	maxSize := config.Get().Consent.Import.MaxFileSize
+	if maxSize > 0 {
+		body = io.LimitReader(body, maxSize+1)
+	}
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && maxSize > 0 && written > maxSize {
+		os.Remove(partPath)
+		return importFileTooLarge(maxSize)
+	}
+	if err != nil {
+		os.Remove(partPath)
+		return serviceerror.CustomServiceError(serviceerror.InvalidRequestError, fmt.Sprintf("failed to read import file: %v", err))
+	}
+	if written == 0 {
+		os.Remove(partPath)
+		return serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "import file is empty")
+	}
+
+	if err := os.Rename(partPath, path); err != nil {
+		os.Remove(partPath)
+		return serviceerror.CustomServiceError(serviceerror.InternalServerError, fmt.Sprintf("failed to store import file: %v", err))
+	}
+	return nil
+}
+
+// filePath returns the location of a job's import file in the work directory
+func (s *importService) filePath(jobID string) string {
+	return filepath.Join(s.workDir, jobID+importFileExt)
+}
+
+func importJobNotFound(jobID string) *serviceerror.ServiceError {
+	return serviceerror.NewServiceError(codes.ImportJobNotFound, serviceerror.ClientErrorType,
+		"Import Job Not Found", fmt.Sprintf("import job '%s' not found", jobID))
+}
+
+func importFileTooLarge(limit int64) *serviceerror.ServiceError {
+	return serviceerror.NewServiceError(codes.ImportFileTooLarge, serviceerror.ClientErrorType,
+		"Import File Too Large", fmt.Sprintf("import file exceeds the maximum size of %d bytes", limit))
+}
+
+func importFileTypeRejected(description string) *serviceerror.ServiceError {
+	return serviceerror.NewServiceError(codes.ImportFileTypeRejected, serviceerror.ClientErrorType,
+		"Unsupported Media Type", description)
+}
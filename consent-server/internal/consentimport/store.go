@@ -0,0 +1,205 @@
+package consentimport
+
+import (
+	"context"
+
+	"github.com/wso2/consent-management-api/internal/consentimport/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// DBQuery objects for import job operations
+var (
+	QueryCreateImportJob = dbmodel.DBQuery{
+		ID:    "CREATE_IMPORT_JOB",
+		Query: "INSERT INTO IMPORT_JOB (JOB_ID, ORG_ID, CLIENT_ID, STATUS, PROCESSED_COUNT, SUCCEEDED_COUNT, FAILED_COUNT, CREATED_TIME, UPDATED_TIME) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryGetImportJobByID = dbmodel.DBQuery{
+		ID:    "GET_IMPORT_JOB_BY_ID",
+		Query: "SELECT JOB_ID, ORG_ID, CLIENT_ID, STATUS, PROCESSED_COUNT, SUCCEEDED_COUNT, FAILED_COUNT, ERROR_MESSAGE, CREATED_TIME, UPDATED_TIME, COMPLETED_TIME FROM IMPORT_JOB WHERE JOB_ID = ? AND ORG_ID = ?",
+	}
+
+	// Unfinished jobs of every organization are recovered at startup
+	QueryGetUnfinishedImportJobs = dbmodel.DBQuery{
+		ID:          "GET_UNFINISHED_IMPORT_JOBS",
+		CrossTenant: true,
+		Query:       "SELECT JOB_ID, ORG_ID, CLIENT_ID, STATUS, PROCESSED_COUNT, SUCCEEDED_COUNT, FAILED_COUNT, ERROR_MESSAGE, CREATED_TIME, UPDATED_TIME, COMPLETED_TIME FROM IMPORT_JOB WHERE STATUS IN (?, ?) ORDER BY CREATED_TIME",
+	}
+
+	QueryUpdateImportJobStatus = dbmodel.DBQuery{
+		ID:    "UPDATE_IMPORT_JOB_STATUS",
+		Query: "UPDATE IMPORT_JOB SET STATUS = ?, ERROR_MESSAGE = ?, UPDATED_TIME = ?, COMPLETED_TIME = ? WHERE JOB_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryUpdateImportJobProgress = dbmodel.DBQuery{
+		ID:    "UPDATE_IMPORT_JOB_PROGRESS",
+		Query: "UPDATE IMPORT_JOB SET PROCESSED_COUNT = ?, SUCCEEDED_COUNT = ?, FAILED_COUNT = ?, UPDATED_TIME = ? WHERE JOB_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryCreateImportJobError = dbmodel.DBQuery{
+		ID:    "CREATE_IMPORT_JOB_ERROR",
+		Query: "INSERT INTO IMPORT_JOB_ERROR (JOB_ID, ORG_ID, LINE_NUMBER, ERROR_CODE, ERROR_MESSAGE) VALUES (?, ?, ?, ?, ?)",
+	}
+
+	QueryListImportJobErrors = dbmodel.DBQuery{
+		ID:    "LIST_IMPORT_JOB_ERRORS",
+		Query: "SELECT JOB_ID, ORG_ID, LINE_NUMBER, ERROR_CODE, ERROR_MESSAGE FROM IMPORT_JOB_ERROR WHERE JOB_ID = ? AND ORG_ID = ? ORDER BY LINE_NUMBER LIMIT ? OFFSET ?",
+	}
+
+	QueryCountImportJobErrors = dbmodel.DBQuery{
+		ID:    "COUNT_IMPORT_JOB_ERRORS",
+		Query: "SELECT COUNT(*) as count FROM IMPORT_JOB_ERROR WHERE JOB_ID = ? AND ORG_ID = ?",
+	}
+)
+
+// store implements the interfaces.ImportJobStore interface
+type store struct {
+	dbClient provider.DBClientInterface
+}
+
+// NewImportJobStore creates a new import job store
+func NewImportJobStore(dbClient provider.DBClientInterface) interfaces.ImportJobStore {
+	return &store{
+		dbClient: dbClient,
+	}
+}
+
+// Create creates an import job within a transaction
+func (s *store) Create(tx dbmodel.TxInterface, job *model.ImportJob) error {
+	_, err := tx.Exec(QueryCreateImportJob.Query,
+		job.JobID, job.OrgID, job.ClientID, job.Status, job.ProcessedCount, job.SucceededCount, job.FailedCount,
+		job.CreatedTime, job.UpdatedTime)
+	return err
+}
+
+// GetByID retrieves an import job
+func (s *store) GetByID(ctx context.Context, jobID, orgID string) (*model.ImportJob, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetImportJobByID, jobID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return mapToImportJob(rows[0]), nil
+}
+
+// GetUnfinished retrieves the pending and running import jobs of all organizations, oldest first
+func (s *store) GetUnfinished(ctx context.Context) ([]model.ImportJob, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetUnfinishedImportJobs, model.ImportStatusPending, model.ImportStatusRunning)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]model.ImportJob, 0, len(rows))
+	for _, row := range rows {
+		jobs = append(jobs, *mapToImportJob(row))
+	}
+	return jobs, nil
+}
+
+// UpdateStatus updates the status of an import job within a transaction
+func (s *store) UpdateStatus(tx dbmodel.TxInterface, job *model.ImportJob) error {
+	_, err := tx.Exec(QueryUpdateImportJobStatus.Query,
+		job.Status, job.ErrorMessage, job.UpdatedTime, job.CompletedTime, job.JobID, job.OrgID)
+	return err
+}
+
+// UpdateProgress updates the row counts of an import job within a transaction
+func (s *store) UpdateProgress(tx dbmodel.TxInterface, job *model.ImportJob) error {
+	_, err := tx.Exec(QueryUpdateImportJobProgress.Query,
+		job.ProcessedCount, job.SucceededCount, job.FailedCount, job.UpdatedTime, job.JobID, job.OrgID)
+	return err
+}
+
+// CreateError records a row that could not be imported within a transaction
+func (s *store) CreateError(tx dbmodel.TxInterface, rowErr *model.ImportRowError) error {
+	_, err := tx.Exec(QueryCreateImportJobError.Query,
+		rowErr.JobID, rowErr.OrgID, rowErr.LineNumber, rowErr.Code, rowErr.Message)
+	return err
+}
+
+// ListErrors retrieves paginated row errors of an import job, in file order
+func (s *store) ListErrors(ctx context.Context, jobID, orgID string, limit, offset int) ([]model.ImportRowError, int, error) {
+	countRows, err := s.dbClient.QueryContext(ctx, QueryCountImportJobErrors, jobID, orgID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount := 0
+	if len(countRows) > 0 {
+		if count, ok := countRows[0]["count"].(int64); ok {
+			totalCount = int(count)
+		}
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, QueryListImportJobErrors, jobID, orgID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rowErrors := make([]model.ImportRowError, 0, len(rows))
+	for _, row := range rows {
+		rowErrors = append(rowErrors, model.ImportRowError{
+			JobID:      getString(row, "job_id"),
+			OrgID:      getString(row, "org_id"),
+			LineNumber: getInt64(row, "line_number"),
+			Code:       getString(row, "error_code"),
+			Message:    getString(row, "error_message"),
+		})
+	}
+	return rowErrors, totalCount, nil
+}
+
+// mapToImportJob converts a database row map to ImportJob
+// Note: DBClient normalizes column names to lowercase
+func mapToImportJob(row map[string]interface{}) *model.ImportJob {
+	return &model.ImportJob{
+		JobID:          getString(row, "job_id"),
+		Type:           model.ImportJobType,
+		OrgID:          getString(row, "org_id"),
+		ClientID:       getString(row, "client_id"),
+		Status:         getString(row, "status"),
+		ProcessedCount: getInt64(row, "processed_count"),
+		SucceededCount: getInt64(row, "succeeded_count"),
+		FailedCount:    getInt64(row, "failed_count"),
+		ErrorMessage:   getStringPtr(row, "error_message"),
+		CreatedTime:    getInt64(row, "created_time"),
+		UpdatedTime:    getInt64(row, "updated_time"),
+		CompletedTime:  getInt64Ptr(row, "completed_time"),
+	}
+}
+
+func getString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+func getStringPtr(row map[string]interface{}, key string) *string {
+	if row[key] == nil {
+		return nil
+	}
+	v := getString(row, key)
+	return &v
+}
+
+func getInt64(row map[string]interface{}, key string) int64 {
+	if v, ok := row[key].(int64); ok {
+		return v
+	}
+	return 0
+}
+
+func getInt64Ptr(row map[string]interface{}, key string) *int64 {
+	if row[key] == nil {
+		return nil
+	}
+	v := getInt64(row, key)
+	return &v
+}
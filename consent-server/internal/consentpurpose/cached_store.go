@@ -0,0 +1,179 @@
+package consentpurpose
+
+import (
+	"context"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/consentpurpose/model"
+	"github.com/wso2/consent-management-api/internal/system/cache"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// PurposeCacheName is the name of the purpose definition cache in /api/v1/admin/caches
+const PurposeCacheName = "consent-purpose"
+
+// Cache key prefixes. Entries are scoped to the organization by the cache.
+const (
+	purposeByIDKeyPrefix    = "id:"
+	purposeByNameKeyPrefix  = "name:"
+	purposeAttributesPrefix = "attributes:"
+)
+
+// purposeCacheInvalidator is implemented by purpose stores that cache purpose definitions
+type purposeCacheInvalidator interface {
+	InvalidateOrg(orgID string) int
+}
+
+// cachedStore serves purpose definitions and purpose attributes from an in-memory cache and
+// delegates everything else to the wrapped store. Purposes that do not exist are not cached.
+type cachedStore struct {
+	interfaces.ConsentPurposeStore
+	cache *cache.MemoryCache
+}
+
+// NewCachedConsentPurposeStore wraps a consent purpose store with a purpose definition cache and
+// registers the cache with the cache manager
+func NewCachedConsentPurposeStore(store interfaces.ConsentPurposeStore, ttl time.Duration, maxEntries int) interfaces.ConsentPurposeStore {
+	purposeCache := cache.NewMemoryCache(PurposeCacheName, ttl, maxEntries)
+	cache.GetManager().Register(purposeCache)
+	return &cachedStore{
+		ConsentPurposeStore: store,
+		cache:               purposeCache,
+	}
+}
+
+// InvalidateOrg removes all cached entries of an organization and returns the number removed
+func (s *cachedStore) InvalidateOrg(orgID string) int {
+	return s.cache.Invalidate(cache.InvalidationFilter{OrgID: orgID})
+}
+
+// GetByID retrieves a consent purpose by ID
+func (s *cachedStore) GetByID(ctx context.Context, purposeID, orgID string) (*model.ConsentPurpose, error) {
+	if cached, ok := s.cache.Get(orgID, purposeByIDKeyPrefix+purposeID); ok {
+		purpose := cached.(model.ConsentPurpose)
+		return &purpose, nil
+	}
+
+	purpose, err := s.ConsentPurposeStore.GetByID(ctx, purposeID, orgID)
+	if err != nil || purpose == nil {
+		return purpose, err
+	}
+	s.setPurpose(orgID, *purpose)
+	return purpose, nil
+}
+
+// GetByName retrieves a consent purpose by name
+func (s *cachedStore) GetByName(ctx context.Context, name, orgID string) (*model.ConsentPurpose, error) {
+	if cached, ok := s.cache.Get(orgID, purposeByNameKeyPrefix+name); ok {
+		purpose := cached.(model.ConsentPurpose)
+		return &purpose, nil
+	}
+
+	purpose, err := s.ConsentPurposeStore.GetByName(ctx, name, orgID)
+	if err != nil || purpose == nil {
+		return purpose, err
+	}
+	s.setPurpose(orgID, *purpose)
+	return purpose, nil
+}
+
+// GetByNames retrieves consent purposes by their names, keyed by name. Only the names that are
+// not cached are read from the wrapped store.
+func (s *cachedStore) GetByNames(ctx context.Context, names []string, orgID string) (map[string]model.ConsentPurpose, error) {
+	result := make(map[string]model.ConsentPurpose, len(names))
+	missing := make([]string, 0, len(names))
+	for _, name := range names {
+		if cached, ok := s.cache.Get(orgID, purposeByNameKeyPrefix+name); ok {
+			result[name] = cached.(model.ConsentPurpose)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	purposes, err := s.ConsentPurposeStore.GetByNames(ctx, missing, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for name, purpose := range purposes {
+		s.setPurpose(orgID, purpose)
+		result[name] = purpose
+	}
+	return result, nil
+}
+
+// GetIDsByNames retrieves purpose IDs by their names, served from the purpose definitions
+func (s *cachedStore) GetIDsByNames(ctx context.Context, names []string, orgID string) (map[string]string, error) {
+	purposes, err := s.GetByNames(ctx, names, orgID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string, len(purposes))
+	for name, purpose := range purposes {
+		ids[name] = purpose.ID
+	}
+	return ids, nil
+}
+
+// GetAttributesByPurposeID retrieves all attributes for a purpose
+func (s *cachedStore) GetAttributesByPurposeID(ctx context.Context, purposeID, orgID string) ([]model.ConsentPurposeAttribute, error) {
+	if cached, ok := s.cache.Get(orgID, purposeAttributesPrefix+purposeID); ok {
+		return copyAttributes(cached.([]model.ConsentPurposeAttribute)), nil
+	}
+
+	attributes, err := s.ConsentPurposeStore.GetAttributesByPurposeID(ctx, purposeID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(orgID, purposeAttributesPrefix+purposeID, copyAttributes(attributes))
+	return attributes, nil
+}
+
+// GetAttributesByPurposeIDs retrieves attributes for multiple purposes, grouped by purpose ID.
+// Only the purposes whose attributes are not cached are read from the wrapped store.
+func (s *cachedStore) GetAttributesByPurposeIDs(ctx context.Context, purposeIDs []string, orgID string) (map[string][]model.ConsentPurposeAttribute, error) {
+	result := make(map[string][]model.ConsentPurposeAttribute, len(purposeIDs))
+	missing := make([]string, 0, len(purposeIDs))
+	for _, purposeID := range purposeIDs {
+		if cached, ok := s.cache.Get(orgID, purposeAttributesPrefix+purposeID); ok {
+			if attributes := cached.([]model.ConsentPurposeAttribute); len(attributes) > 0 {
+				result[purposeID] = copyAttributes(attributes)
+			}
+		} else {
+			missing = append(missing, purposeID)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	attributesByPurpose, err := s.ConsentPurposeStore.GetAttributesByPurposeIDs(ctx, missing, orgID)
+	if err != nil {
+		return nil, err
+	}
+	// Purposes without attributes are cached too, as an empty list
+	for _, purposeID := range missing {
+		attributes := attributesByPurpose[purposeID]
+		s.cache.Set(orgID, purposeAttributesPrefix+purposeID, copyAttributes(attributes))
+		if len(attributes) > 0 {
+			result[purposeID] = attributes
+		}
+	}
+	return result, nil
+}
+
+// setPurpose caches a purpose definition under its ID and its name
+func (s *cachedStore) setPurpose(orgID string, purpose model.ConsentPurpose) {
+	tag := cache.PurposeTag(purpose.Name)
+	s.cache.Set(orgID, purposeByIDKeyPrefix+purpose.ID, purpose, tag)
+	s.cache.Set(orgID, purposeByNameKeyPrefix+purpose.Name, purpose, tag)
+}
+
+// copyAttributes returns a copy of attributes so cached lists are not shared with callers
+func copyAttributes(attributes []model.ConsentPurposeAttribute) []model.ConsentPurposeAttribute {
+	copied := make([]model.ConsentPurposeAttribute, len(attributes))
+	copy(copied, attributes)
+	return copied
+}
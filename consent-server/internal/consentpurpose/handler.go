@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/wso2/consent-management-api/internal/consentpurpose/model"
 	"github.com/wso2/consent-management-api/internal/system/constants"
 	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
 	"github.com/wso2/consent-management-api/internal/system/utils"
 )
 
@@ -78,6 +80,31 @@ func (h *consentPurposeHandler) createPurpose(w http.ResponseWriter, r *http.Req
 	json.NewEncoder(w).Encode(response)
 }
 
+// bulkCreatePurposes handles POST /consent-purposes/bulk
+// Valid purposes are created together and every item gets a result, so a whole catalog can be seeded in one request
+func (h *consentPurposeHandler) bulkCreatePurposes(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+
+	if err := utils.ValidateOrgIdAndClientIdIsPresent(r); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var requests []model.CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid request body"))
+		return
+	}
+
+	response, serviceErr := h.service.CreatePurposesInBulk(r.Context(), requests, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
+
 // getPurpose handles GET /consent-purposes/{purposeId}
 func (h *consentPurposeHandler) getPurpose(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -133,10 +160,22 @@ func (h *consentPurposeHandler) listPurposes(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	// Parse optional name filter
-	name := r.URL.Query().Get("name")
+	// Parse optional filters
+	query := r.URL.Query()
+	filters := model.PurposeSearchFilters{
+		Name:           query.Get("name"),
+		NamePrefix:     query.Get("namePrefix"),
+		AttributeKey:   query.Get("attributeKey"),
+		AttributeValue: query.Get("attributeValue"),
+		Limit:          limit,
+		Offset:         offset,
+		OrgID:          orgID,
+	}
+	if types := query.Get("types"); types != "" {
+		filters.Types = strings.Split(types, ",")
+	}
 
-	purposes, total, serviceErr := h.service.ListPurposes(ctx, orgID, limit, offset, name)
+	purposes, total, serviceErr := h.service.ListPurposes(ctx, filters)
 	if serviceErr != nil {
 		utils.SendError(w, r, serviceErr)
 		return
@@ -146,11 +185,12 @@ func (h *consentPurposeHandler) listPurposes(w http.ResponseWriter, r *http.Requ
 	purposeResponses := make([]model.Response, 0, len(purposes))
 	for _, p := range purposes {
 		purposeResponses = append(purposeResponses, model.Response{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Type:        p.Type,
-			Attributes:  p.Attributes,
+			ID:           p.ID,
+			Name:         p.Name,
+			Description:  p.Description,
+			Type:         p.Type,
+			Attributes:   p.Attributes,
+			ConsentCount: p.ConsentCount,
 		})
 	}
 
@@ -216,6 +256,12 @@ func (h *consentPurposeHandler) deletePurpose(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Forced deletion detaches the purpose from its consents and is restricted to admins
+	if r.URL.Query().Get("force") == "true" {
+		middleware.WithAdminAuth(h.forceDeletePurpose)(w, r)
+		return
+	}
+
 	if serviceErr := h.service.DeletePurpose(ctx, purposeID, orgID); serviceErr != nil {
 		utils.SendError(w, r, serviceErr)
 		return
@@ -224,6 +270,144 @@ func (h *consentPurposeHandler) deletePurpose(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// forceDeletePurpose handles DELETE /consent-purposes/{purposeId}?force=true
+func (h *consentPurposeHandler) forceDeletePurpose(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	actionBy := r.Header.Get(constants.HeaderTPPClientID)
+
+	response, serviceErr := h.service.ForceDeletePurpose(r.Context(), r.PathValue("purposeId"), orgID, actionBy)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.Header().Set(constants.HeaderContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// listPurposeAttributes handles GET /consent-purposes/{purposeId}/attributes
+func (h *consentPurposeHandler) listPurposeAttributes(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	attributes, serviceErr := h.service.ListPurposeAttributes(r.Context(), r.PathValue("purposeId"), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, model.PurposeAttributeListResponse{Data: attributes})
+}
+
+// listPurposeApprovals handles GET /consent-purposes/{purposeId}/approvals
+func (h *consentPurposeHandler) listPurposeApprovals(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	query := r.URL.Query()
+	filters := model.PurposeApprovalFilters{
+		PurposeID: r.PathValue("purposeId"),
+		OrgID:     orgID,
+		Status:    query.Get("status"),
+		Limit:     100,
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			filters.Limit = l
+		}
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			filters.Offset = o
+		}
+	}
+
+	approvals, total, serviceErr := h.service.ListPurposeApprovals(r.Context(), filters)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, model.PurposeApprovalListResponse{
+		Data: approvals,
+		Metadata: model.PaginationMetadata{
+			Total:  total,
+			Offset: filters.Offset,
+			Count:  len(approvals),
+			Limit:  filters.Limit,
+		},
+	})
+}
+
+// createPurposeAttribute handles POST /consent-purposes/{purposeId}/attributes
+func (h *consentPurposeHandler) createPurposeAttribute(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.PurposeAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid request body"))
+		return
+	}
+
+	attribute, serviceErr := h.service.CreatePurposeAttribute(r.Context(), r.PathValue("purposeId"), req, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, attribute)
+}
+
+// updatePurposeAttribute handles PUT /consent-purposes/{purposeId}/attributes/{key}
+func (h *consentPurposeHandler) updatePurposeAttribute(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.PurposeAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid request body"))
+		return
+	}
+
+	attribute, serviceErr := h.service.UpdatePurposeAttribute(r.Context(), r.PathValue("purposeId"), r.PathValue("key"), req, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, attribute)
+}
+
+// deletePurposeAttribute handles DELETE /consent-purposes/{purposeId}/attributes/{key}
+func (h *consentPurposeHandler) deletePurposeAttribute(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.DeletePurposeAttribute(r.Context(), r.PathValue("purposeId"), r.PathValue("key"), orgID); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // validatePurposes handles POST /consent-purposes/validate
 func (h *consentPurposeHandler) validatePurposes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -29,20 +29,38 @@ func registerRoutes(mux *http.ServeMux, handler *consentPurposeHandler) {
 	}
 
 	// POST /api/v1/consent-purposes - Create purpose
-	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consent-purposes", handler.createPurpose, corsOptions))
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consent-purposes", middleware.WithScope(middleware.ScopePurposesAdmin, handler.createPurpose), corsOptions))
+
+	// POST /api/v1/consent-purposes/bulk - Create purposes with per-item results
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consent-purposes/bulk", middleware.WithScope(middleware.ScopePurposesAdmin, handler.bulkCreatePurposes), corsOptions))
 
 	// GET /api/v1/consent-purposes/{purposeId} - Get purpose by ID
-	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consent-purposes/{purposeId}", handler.getPurpose, corsOptions))
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consent-purposes/{purposeId}", middleware.WithScope(middleware.ScopeConsentsRead, handler.getPurpose), corsOptions))
 
 	// GET /api/v1/consent-purposes - List purposes
-	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consent-purposes", handler.listPurposes, corsOptions))
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consent-purposes", middleware.WithScope(middleware.ScopeConsentsRead, handler.listPurposes), corsOptions))
 
 	// POST /api/v1/consent-purposes/validate - Validate purpose names
-	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consent-purposes/validate", handler.validatePurposes, corsOptions))
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consent-purposes/validate", middleware.WithScope(middleware.ScopeConsentsRead, handler.validatePurposes), corsOptions))
 
 	// PUT /api/v1/consent-purposes/{purposeId} - Update purpose
-	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/consent-purposes/{purposeId}", handler.updatePurpose, corsOptions))
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/consent-purposes/{purposeId}", middleware.WithScope(middleware.ScopePurposesAdmin, handler.updatePurpose), corsOptions))
 
 	// DELETE /api/v1/consent-purposes/{purposeId} - Delete purpose
-	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/consent-purposes/{purposeId}", handler.deletePurpose, corsOptions))
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/consent-purposes/{purposeId}", middleware.WithScope(middleware.ScopePurposesAdmin, handler.deletePurpose), corsOptions))
+
+	// GET /api/v1/consent-purposes/{purposeId}/attributes - List purpose attributes
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consent-purposes/{purposeId}/attributes", middleware.WithScope(middleware.ScopeConsentsRead, handler.listPurposeAttributes), corsOptions))
+
+	// GET /api/v1/consent-purposes/{purposeId}/approvals - List the users who approved the purpose
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consent-purposes/{purposeId}/approvals", middleware.WithScope(middleware.ScopeConsentsRead, handler.listPurposeApprovals), corsOptions))
+
+	// POST /api/v1/consent-purposes/{purposeId}/attributes - Add purpose attribute
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/consent-purposes/{purposeId}/attributes", middleware.WithScope(middleware.ScopePurposesAdmin, handler.createPurposeAttribute), corsOptions))
+
+	// PUT /api/v1/consent-purposes/{purposeId}/attributes/{key} - Update purpose attribute
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/consent-purposes/{purposeId}/attributes/{key}", middleware.WithScope(middleware.ScopePurposesAdmin, handler.updatePurposeAttribute), corsOptions))
+
+	// DELETE /api/v1/consent-purposes/{purposeId}/attributes/{key} - Delete purpose attribute
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/consent-purposes/{purposeId}/attributes/{key}", middleware.WithScope(middleware.ScopePurposesAdmin, handler.deletePurposeAttribute), corsOptions))
 }
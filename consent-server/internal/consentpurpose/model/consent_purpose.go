@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/wso2/consent-management-api/internal/consentpurpose/validators"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
 )
 
 // JSONValue represents a JSON value that can be stored in the database
@@ -58,6 +59,8 @@ type ConsentPurpose struct {
 	Type        string            `json:"type" db:"TYPE"`
 	Attributes  map[string]string `json:"attributes,omitempty" db:"-"`
 	OrgID       string            `json:"orgId" db:"ORG_ID"`
+	// ConsentCount is the number of consents linked to the purpose. It is only set when listing purposes.
+	ConsentCount *int `json:"consentCount,omitempty" db:"-"`
 }
 
 // ConsentPurposeMapping represents the CONSENT_PURPOSE_MAPPING table
@@ -68,7 +71,57 @@ type ConsentPurposeMapping struct {
 	Value          interface{} `db:"VALUE" json:"value,omitempty"`
 	IsUserApproved bool        `db:"IS_USER_APPROVED" json:"isUserApproved"`
 	IsMandatory    bool        `db:"IS_MANDATORY" json:"isMandatory"`
-	Name           string      `db:"-" json:"name"` // Purpose name for convenience (not in mapping table)
+	// ExpiresAt and LastConfirmedAt track re-confirmation of the purpose independently of the
+	// overall consent validity
+	ExpiresAt       *int64 `db:"EXPIRES_AT" json:"expiresAt,omitempty"`
+	LastConfirmedAt *int64 `db:"LAST_CONFIRMED_AT" json:"lastConfirmedAt,omitempty"`
+	Name            string `db:"-" json:"name"` // Purpose name for convenience (not in mapping table)
+}
+
+// LinkedConsent is a consent mapped to a purpose, with the consent's current status
+type LinkedConsent struct {
+	ConsentID     string `db:"CONSENT_ID"`
+	CurrentStatus string `db:"CURRENT_STATUS"`
+}
+
+// PurposeApproval is a user who approved a purpose, with the consent in which the approval was given.
+// A consent authorized by several users yields one approval per user.
+type PurposeApproval struct {
+	UserID        string `json:"userId"`
+	ConsentID     string `json:"consentId"`
+	ConsentType   string `json:"consentType"`
+	ConsentStatus string `json:"consentStatus"`
+	UpdatedTime   int64  `json:"updatedTime"`
+}
+
+// PurposeApprovalFilters selects the approvals of a purpose. Status, when set, limits the
+// approvals to consents in that status.
+type PurposeApprovalFilters struct {
+	PurposeID string
+	OrgID     string
+	Status    string
+	Limit     int
+	Offset    int
+}
+
+// PurposeApprovalListResponse represents a page of the approvals of a purpose
+type PurposeApprovalListResponse struct {
+	Data     []PurposeApproval  `json:"data"`
+	Metadata PaginationMetadata `json:"metadata"`
+}
+
+// PaginationMetadata describes a page of a list response
+type PaginationMetadata struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+	Limit  int `json:"limit"`
+}
+
+// PurposeDeleteResponse represents the response of a forced purpose deletion
+type PurposeDeleteResponse struct {
+	PurposeID        string `json:"purposeId"`
+	DetachedConsents int    `json:"detachedConsents"`
 }
 
 // ConsentPurposeCreateRequest represents the request to create a consent purpose
@@ -79,6 +132,45 @@ type ConsentPurposeCreateRequest struct {
 	Attributes  map[string]string `json:"attributes,omitempty"`
 }
 
+// BulkCreateMaxItems is the largest number of purposes accepted by one bulk create request
+const BulkCreateMaxItems = 1000
+
+// Outcomes of an item of a bulk create request
+const (
+	BulkItemCreated = "CREATED"
+	BulkItemFailed  = "FAILED"
+)
+
+// BulkCreateItemResult reports the outcome of one purpose of a bulk create request
+type BulkCreateItemResult struct {
+	Index   int                     `json:"index"`
+	Name    string                  `json:"name"`
+	Status  string                  `json:"status"`
+	Purpose *ConsentPurposeResponse `json:"purpose,omitempty"`
+	Error   *BulkCreateItemError    `json:"error,omitempty"`
+}
+
+// BulkCreateItemError explains why a purpose of a bulk create request was not created
+type BulkCreateItemError struct {
+	Code       string                       `json:"code"`
+	Message    string                       `json:"message"`
+	Violations serviceerror.FieldViolations `json:"violations,omitempty"`
+}
+
+// BulkCreateResponse represents the response of a bulk create request, with one result per item
+// in request order
+type BulkCreateResponse struct {
+	Data    []BulkCreateItemResult `json:"data"`
+	Summary BulkCreateSummary      `json:"summary"`
+}
+
+// BulkCreateSummary counts the outcomes of a bulk create request
+type BulkCreateSummary struct {
+	Total   int `json:"total"`
+	Created int `json:"created"`
+	Failed  int `json:"failed"`
+}
+
 // ConsentPurposeUpdateRequest represents the request to update a consent purpose
 // All fields are required - no partial updates allowed
 type ConsentPurposeUpdateRequest struct {
@@ -90,11 +182,24 @@ type ConsentPurposeUpdateRequest struct {
 
 // ConsentPurposeResponse represents the response for consent purpose operations
 type ConsentPurposeResponse struct {
-	ID          string            `json:"id"`
-	Name        string            `json:"name"`
-	Description *string           `json:"description,omitempty"`
-	Type        string            `json:"type"`
-	Attributes  map[string]string `json:"attributes,omitempty"`
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Description  *string           `json:"description,omitempty"`
+	Type         string            `json:"type"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	ConsentCount *int              `json:"consentCount,omitempty"`
+}
+
+// PurposeSearchFilters selects the purposes returned by a purpose list. Empty fields do not filter.
+type PurposeSearchFilters struct {
+	Name           string   // Substring of the purpose name
+	NamePrefix     string   // Start of the purpose name
+	Types          []string // Purpose types, e.g. ["string", "attribute"]
+	AttributeKey   string   // Purposes having an attribute with this key
+	AttributeValue string   // Value of the AttributeKey attribute, requires AttributeKey
+	Limit          int
+	Offset         int
+	OrgID          string
 }
 
 // ConsentPurposeListResponse represents a list of consent purposes
@@ -138,6 +243,24 @@ type ConsentPurposeAttribute struct {
 	OrgID     string `db:"ORG_ID"`
 }
 
+// PurposeAttribute is a single key/value attribute of a consent purpose
+type PurposeAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// PurposeAttributeRequest represents the request to create or update a purpose attribute. Key is
+// taken from the path on update.
+type PurposeAttributeRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// PurposeAttributeListResponse represents the attributes of a consent purpose, ordered by key
+type PurposeAttributeListResponse struct {
+	Data []PurposeAttribute `json:"data"`
+}
+
 // Type aliases for backward compatibility
 type Response = ConsentPurposeResponse
 type ListResponse = ConsentPurposeListResponse
@@ -3,13 +3,17 @@ package consentpurpose
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	consentModel "github.com/wso2/consent-management-api/internal/consent/model"
 	"github.com/wso2/consent-management-api/internal/consentpurpose/model"
 	"github.com/wso2/consent-management-api/internal/consentpurpose/validators"
+	"github.com/wso2/consent-management-api/internal/system/cache"
 	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
 	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
 	"github.com/wso2/consent-management-api/internal/system/log"
 	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
 	"github.com/wso2/consent-management-api/internal/system/utils"
 )
 
@@ -17,11 +21,18 @@ import (
 type ConsentPurposeService interface {
 	CreatePurpose(ctx context.Context, req model.CreateRequest, orgID string) (*model.ConsentPurpose, *serviceerror.ServiceError)
 	CreatePurposesInBatch(ctx context.Context, requests []model.CreateRequest, orgID string) ([]model.ConsentPurpose, *serviceerror.ServiceError)
+	CreatePurposesInBulk(ctx context.Context, requests []model.CreateRequest, orgID string) (*model.BulkCreateResponse, *serviceerror.ServiceError)
 	GetPurpose(ctx context.Context, purposeID, orgID string) (*model.ConsentPurpose, *serviceerror.ServiceError)
-	ListPurposes(ctx context.Context, orgID string, limit, offset int, name string) ([]model.ConsentPurpose, int, *serviceerror.ServiceError)
+	ListPurposes(ctx context.Context, filters model.PurposeSearchFilters) ([]model.ConsentPurpose, int, *serviceerror.ServiceError)
 	UpdatePurpose(ctx context.Context, purposeID string, req model.UpdateRequest, orgID string) (*model.ConsentPurpose, *serviceerror.ServiceError)
 	DeletePurpose(ctx context.Context, purposeID, orgID string) *serviceerror.ServiceError
+	ForceDeletePurpose(ctx context.Context, purposeID, orgID, actionBy string) (*model.PurposeDeleteResponse, *serviceerror.ServiceError)
 	ValidatePurposeNames(ctx context.Context, orgID string, purposeNames []string) ([]string, *serviceerror.ServiceError)
+	ListPurposeAttributes(ctx context.Context, purposeID, orgID string) ([]model.PurposeAttribute, *serviceerror.ServiceError)
+	CreatePurposeAttribute(ctx context.Context, purposeID string, req model.PurposeAttributeRequest, orgID string) (*model.PurposeAttribute, *serviceerror.ServiceError)
+	UpdatePurposeAttribute(ctx context.Context, purposeID, key string, req model.PurposeAttributeRequest, orgID string) (*model.PurposeAttribute, *serviceerror.ServiceError)
+	DeletePurposeAttribute(ctx context.Context, purposeID, key, orgID string) *serviceerror.ServiceError
+	ListPurposeApprovals(ctx context.Context, filters model.PurposeApprovalFilters) ([]model.PurposeApproval, int, *serviceerror.ServiceError)
 }
 
 // consentPurposeService implements the ConsentPurposeService interface
@@ -38,6 +49,9 @@ func newConsentPurposeService(registry *stores.StoreRegistry) ConsentPurposeServ
 
 // CreatePurpose creates a new consent purpose
 func (s *consentPurposeService) CreatePurpose(ctx context.Context, req model.CreateRequest, orgID string) (*model.ConsentPurpose, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.CreatePurpose")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 
 	logger.Info("Creating consent purpose",
@@ -46,9 +60,9 @@ func (s *consentPurposeService) CreatePurpose(ctx context.Context, req model.Cre
 		log.String("org_id", orgID))
 
 	// Validate request
-	if err := s.validateCreateRequest(req); err != nil {
-		logger.Warn("Consent purpose create request validation failed", log.String("error", err.Error()))
-		return nil, err
+	if violations := s.validateCreateRequest(req); len(violations) > 0 {
+		logger.Warn("Consent purpose create request validation failed", log.String("error", violations.Error()))
+		return nil, serviceerror.NewFieldValidationError(violations)
 	}
 
 	// Check if purpose name already exists
@@ -106,11 +120,12 @@ func (s *consentPurposeService) CreatePurpose(ctx context.Context, req model.Cre
 	}
 
 	logger.Debug("Executing transaction", log.Int("operation_count", len(queries)))
-	err := s.stores.ExecuteTransaction(queries)
+	err := s.stores.ExecuteTransaction(ctx, queries)
 	if err != nil {
 		logger.Error("Failed to create purpose in transaction", log.Error(err), log.String("purpose_id", purposeID))
 		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to create purpose: %v", err))
 	}
+	s.invalidatePurposeCache(ctx, orgID)
 
 	logger.Info("Consent purpose created successfully",
 		log.String("purpose_id", purposeID),
@@ -123,6 +138,9 @@ func (s *consentPurposeService) CreatePurpose(ctx context.Context, req model.Cre
 // CreatePurposesInBatch creates multiple consent purposes in a single transaction
 // Either all purposes are created or none (atomic operation)
 func (s *consentPurposeService) CreatePurposesInBatch(ctx context.Context, requests []model.CreateRequest, orgID string) ([]model.ConsentPurpose, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.CreatePurposesInBatch")
+	defer span.End()
+
 	// Validate inputs
 	if len(requests) == 0 {
 		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "at least one purpose must be provided")
@@ -130,22 +148,32 @@ func (s *consentPurposeService) CreatePurposesInBatch(ctx context.Context, reque
 
 	store := s.stores.ConsentPurpose
 
-	// Pre-validate all requests and check for duplicate names within the batch
+	// Pre-validate all requests and check for duplicate names within the batch. Every invalid field
+	// is reported, with paths prefixed by the index of the request.
+	var violations serviceerror.FieldViolations
 	namesSeen := make(map[string]bool)
 	for i, req := range requests {
-		// Validate request
-		if valErr := s.validateCreateRequest(req); valErr != nil {
-			// Return error with index information
-			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("invalid request at index %d: %v", i, valErr))
+		for _, violation := range s.validateCreateRequest(req) {
+			violation.Path = fmt.Sprintf("[%d].%s", i, violation.Path)
+			violations = append(violations, violation)
 		}
 
 		// Check for duplicate names within the batch
-		if namesSeen[req.Name] {
-			return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("duplicate purpose name '%s' in request batch at index %d", req.Name, i))
+		if req.Name != "" && namesSeen[req.Name] {
+			violations = append(violations, serviceerror.FieldViolation{
+				Path:       fmt.Sprintf("[%d].name", i),
+				Constraint: "unique",
+				Message:    fmt.Sprintf("duplicate purpose name '%s' in request batch", req.Name),
+			})
 		}
 		namesSeen[req.Name] = true
+	}
+	if len(violations) > 0 {
+		return nil, serviceerror.NewFieldValidationError(violations)
+	}
 
-		// Check if purpose name already exists in database
+	// Check if purpose names already exist in database
+	for i, req := range requests {
 		exists, dbErr := store.CheckNameExists(ctx, req.Name, orgID)
 		if dbErr != nil {
 			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to validate purpose name at index %d: %v", i, dbErr))
@@ -161,57 +189,169 @@ func (s *consentPurposeService) CreatePurposesInBatch(ctx context.Context, reque
 
 	// Create all purposes within the transaction
 	for _, req := range requests {
-		purposeID := utils.GenerateUUID()
-		desc := req.Description
-
-		purpose := &model.ConsentPurpose{
-			ID:          purposeID,
-			Name:        req.Name,
-			Description: &desc,
-			Type:        req.Type,
-			OrgID:       orgID,
-			Attributes:  req.Attributes,
+		purpose, purposeQueries := s.purposeCreateQueries(req, orgID)
+		queries = append(queries, purposeQueries...)
+		createdPurposes = append(createdPurposes, purpose)
+	}
+
+	// Execute all operations in a single transaction
+	if err := s.stores.ExecuteTransaction(ctx, queries); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to create purposes in batch: %v", err))
+	}
+	s.invalidatePurposeCache(ctx, orgID)
+
+	return createdPurposes, nil
+}
+
+// CreatePurposesInBulk creates the valid purposes of a bulk request in a single transaction and
+// reports the outcome of every item. Items that fail validation or whose name is taken are skipped;
+// an error is returned only when the request itself is invalid or the transaction fails.
+func (s *consentPurposeService) CreatePurposesInBulk(ctx context.Context, requests []model.CreateRequest, orgID string) (*model.BulkCreateResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.CreatePurposesInBulk")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+
+	if len(requests) == 0 {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "at least one purpose must be provided")
+	}
+	if len(requests) > model.BulkCreateMaxItems {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("at most %d purposes can be created in one bulk request", model.BulkCreateMaxItems))
+	}
+
+	results := make([]model.BulkCreateItemResult, len(requests))
+	fail := func(i int, serviceErr *serviceerror.ServiceError) {
+		results[i].Status = model.BulkItemFailed
+		results[i].Error = &model.BulkCreateItemError{
+			Code:       serviceErr.Code,
+			Message:    serviceErr.Description,
+			Violations: serviceErr.Violations,
 		}
+	}
 
-		// Add purpose creation to transaction
-		purposeCopy := *purpose // Create a copy for the closure
-		queries = append(queries, func(tx dbmodel.TxInterface) error {
-			return store.Create(tx, &purposeCopy)
-		})
+	// Validate every item; the first occurrence of a name wins within the request
+	namesSeen := make(map[string]bool, len(requests))
+	candidateNames := make([]string, 0, len(requests))
+	for i, req := range requests {
+		results[i].Index = i
+		results[i].Name = req.Name
+
+		if violations := s.validateCreateRequest(req); len(violations) > 0 {
+			fail(i, serviceerror.NewFieldValidationError(violations))
+		} else if namesSeen[req.Name] {
+			fail(i, serviceerror.NewFieldValidationError(serviceerror.FieldViolations{{
+				Path:       "name",
+				Constraint: "unique",
+				Message:    fmt.Sprintf("duplicate purpose name '%s' in request batch", req.Name),
+			}}))
+		} else {
+			candidateNames = append(candidateNames, req.Name)
+		}
+		namesSeen[req.Name] = true
+	}
 
-		// Add attributes if provided
-		if len(req.Attributes) > 0 {
-			attributes := make([]model.ConsentPurposeAttribute, 0, len(req.Attributes))
-			for key, value := range req.Attributes {
-				attr := model.ConsentPurposeAttribute{
-					PurposeID: purposeID,
-					Key:       key,
-					Value:     value,
-					OrgID:     orgID,
-				}
-				attributes = append(attributes, attr)
-			}
+	// Names that already exist are looked up with one query
+	existing := map[string]string{}
+	if len(candidateNames) > 0 {
+		var err error
+		existing, err = s.stores.ConsentPurpose.GetIDsByNames(ctx, candidateNames, orgID)
+		if err != nil {
+			logger.Error("Failed to check purpose names", log.Error(err), log.String("org_id", orgID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to check purpose names: %v", err))
+		}
+	}
 
-			// Capture attributes for this iteration
-			attrsCopy := attributes
-			queries = append(queries, func(tx dbmodel.TxInterface) error {
-				return store.CreateAttributes(tx, attrsCopy)
-			})
+	var queries []func(tx dbmodel.TxInterface) error
+	for i, req := range requests {
+		if results[i].Status == model.BulkItemFailed {
+			continue
+		}
+		if _, exists := existing[req.Name]; exists {
+			fail(i, serviceerror.CustomServiceError(serviceerror.ConflictError,
+				fmt.Sprintf("purpose name '%s' already exists for this organization", req.Name)))
+			continue
 		}
 
-		createdPurposes = append(createdPurposes, *purpose)
+		purpose, purposeQueries := s.purposeCreateQueries(req, orgID)
+		queries = append(queries, purposeQueries...)
+		results[i].Status = model.BulkItemCreated
+		results[i].Purpose = purpose.ToConsentPurposeResponse()
 	}
 
-	// Execute all operations in a single transaction
-	if err := s.stores.ExecuteTransaction(queries); err != nil {
-		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to create purposes in batch: %v", err))
+	response := &model.BulkCreateResponse{Data: results}
+	response.Summary.Total = len(requests)
+	for _, result := range results {
+		if result.Status == model.BulkItemCreated {
+			response.Summary.Created++
+		} else {
+			response.Summary.Failed++
+		}
 	}
 
-	return createdPurposes, nil
+	if len(queries) > 0 {
+		if err := s.stores.ExecuteTransaction(ctx, queries); err != nil {
+			logger.Error("Failed to create purposes in bulk", log.Error(err), log.String("org_id", orgID))
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to create purposes in bulk: %v", err))
+		}
+		s.invalidatePurposeCache(ctx, orgID)
+	}
+
+	logger.Info("Bulk purpose creation completed",
+		log.String("org_id", orgID),
+		log.Int("created", response.Summary.Created),
+		log.Int("failed", response.Summary.Failed))
+
+	return response, nil
+}
+
+// purposeCreateQueries builds a purpose from a create request together with the transaction
+// operations that store it and its attributes
+func (s *consentPurposeService) purposeCreateQueries(req model.CreateRequest, orgID string) (model.ConsentPurpose, []func(tx dbmodel.TxInterface) error) {
+	store := s.stores.ConsentPurpose
+	purposeID := utils.GenerateUUID()
+	desc := req.Description
+
+	purpose := model.ConsentPurpose{
+		ID:          purposeID,
+		Name:        req.Name,
+		Description: &desc,
+		Type:        req.Type,
+		OrgID:       orgID,
+		Attributes:  req.Attributes,
+	}
+
+	purposeCopy := purpose // Create a copy for the closure
+	queries := []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return store.Create(tx, &purposeCopy)
+		},
+	}
+
+	// Add attributes if provided
+	if len(req.Attributes) > 0 {
+		attributes := make([]model.ConsentPurposeAttribute, 0, len(req.Attributes))
+		for key, value := range req.Attributes {
+			attributes = append(attributes, model.ConsentPurposeAttribute{
+				PurposeID: purposeID,
+				Key:       key,
+				Value:     value,
+				OrgID:     orgID,
+			})
+		}
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return store.CreateAttributes(tx, attributes)
+		})
+	}
+
+	return purpose, queries
 }
 
 // GetPurpose retrieves a consent purpose by ID
 func (s *consentPurposeService) GetPurpose(ctx context.Context, purposeID, orgID string) (*model.ConsentPurpose, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.GetPurpose")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Retrieving consent purpose",
 		log.String("purpose_id", purposeID),
@@ -258,50 +398,69 @@ func (s *consentPurposeService) GetPurpose(ctx context.Context, purposeID, orgID
 	return purpose, nil
 }
 
-// ListPurposes retrieves paginated list of consent purposes with optional name filter
-func (s *consentPurposeService) ListPurposes(ctx context.Context, orgID string, limit, offset int, name string) ([]model.ConsentPurpose, int, *serviceerror.ServiceError) {
+// ListPurposes retrieves a paginated list of consent purposes matching the filters, with their
+// attributes and the number of consents linked to each purpose
+func (s *consentPurposeService) ListPurposes(ctx context.Context, filters model.PurposeSearchFilters) ([]model.ConsentPurpose, int, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.ListPurposes")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Listing consent purposes",
-		log.String("org_id", orgID),
-		log.Int("limit", limit),
-		log.Int("offset", offset),
-		log.String("name_filter", name),
+		log.String("org_id", filters.OrgID),
+		log.Int("limit", filters.Limit),
+		log.Int("offset", filters.Offset),
+		log.String("name_filter", filters.Name),
+		log.String("name_prefix", filters.NamePrefix),
+		log.Any("types", filters.Types),
+		log.String("attribute_key", filters.AttributeKey),
 	)
 
-	if limit <= 0 {
-		limit = 100
+	if filters.AttributeValue != "" && filters.AttributeKey == "" {
+		return nil, 0, serviceerror.CustomServiceError(serviceerror.ValidationError, "attributeValue requires attributeKey")
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 100
 	}
-	if offset < 0 {
-		offset = 0
+	if filters.Offset < 0 {
+		filters.Offset = 0
 	}
 
 	store := s.stores.ConsentPurpose
-	purposes, total, err := store.List(ctx, orgID, limit, offset, name)
+	purposes, total, err := store.List(ctx, filters)
 	if err != nil {
 		logger.Error("Failed to list purposes",
 			log.Error(err),
-			log.String("org_id", orgID),
+			log.String("org_id", filters.OrgID),
 		)
 		return nil, 0, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to list purposes: %v", err))
 	}
 
-	// Load attributes for each purpose
-	for i := range purposes {
-		attributes, attrErr := store.GetAttributesByPurposeID(ctx, purposes[i].ID, orgID)
-		if attrErr != nil {
-			logger.Error("Failed to load attributes for purpose",
-				log.Error(attrErr),
-				log.String("purpose_id", purposes[i].ID),
-			)
-			return nil, 0, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to load attributes: %v", attrErr))
-		}
+	purposeIDs := make([]string, 0, len(purposes))
+	for _, purpose := range purposes {
+		purposeIDs = append(purposeIDs, purpose.ID)
+	}
+
+	// Load attributes and linked consent counts for the whole page
+	attributes, err := store.GetAttributesByPurposeIDs(ctx, purposeIDs, filters.OrgID)
+	if err != nil {
+		logger.Error("Failed to load attributes for purposes", log.Error(err))
+		return nil, 0, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to load attributes: %v", err))
+	}
+	consentCounts, err := store.CountConsentsByPurposeIDs(ctx, purposeIDs, filters.OrgID)
+	if err != nil {
+		logger.Error("Failed to count consents linked to purposes", log.Error(err))
+		return nil, 0, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to count linked consents: %v", err))
+	}
 
+	for i := range purposes {
 		if purposes[i].Attributes == nil {
 			purposes[i].Attributes = make(map[string]string)
 		}
-		for _, attr := range attributes {
+		for _, attr := range attributes[purposes[i].ID] {
 			purposes[i].Attributes[attr.Key] = attr.Value
 		}
+		consentCount := consentCounts[purposes[i].ID]
+		purposes[i].ConsentCount = &consentCount
 	}
 
 	logger.Debug("Purposes listed successfully",
@@ -313,6 +472,9 @@ func (s *consentPurposeService) ListPurposes(ctx context.Context, orgID string,
 
 // UpdatePurpose updates an existing consent purpose
 func (s *consentPurposeService) UpdatePurpose(ctx context.Context, purposeID string, req model.UpdateRequest, orgID string) (*model.ConsentPurpose, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.UpdatePurpose")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Info("Updating consent purpose",
 		log.String("purpose_id", purposeID),
@@ -321,9 +483,9 @@ func (s *consentPurposeService) UpdatePurpose(ctx context.Context, purposeID str
 	)
 
 	// Validate request
-	if err := s.validateUpdateRequest(req); err != nil {
-		logger.Warn("Update purpose request validation failed", log.String("error", err.Error()))
-		return nil, err
+	if violations := s.validateUpdateRequest(req); len(violations) > 0 {
+		logger.Warn("Update purpose request validation failed", log.String("error", violations.Error()))
+		return nil, serviceerror.NewFieldValidationError(violations)
 	}
 
 	// Check if purpose exists
@@ -384,7 +546,7 @@ func (s *consentPurposeService) UpdatePurpose(ctx context.Context, purposeID str
 	logger.Debug("Executing transaction for purpose update",
 		log.Int("attributes_count", len(attributes)),
 	)
-	err = s.stores.ExecuteTransaction(queries)
+	err = s.stores.ExecuteTransaction(ctx, queries)
 	if err != nil {
 		logger.Error("Transaction failed for purpose update",
 			log.Error(err),
@@ -392,6 +554,7 @@ func (s *consentPurposeService) UpdatePurpose(ctx context.Context, purposeID str
 		)
 		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to update purpose: %v", err))
 	}
+	s.invalidatePurposeCache(ctx, orgID)
 
 	logger.Info("Purpose updated successfully",
 		log.String("purpose_id", purposeID),
@@ -400,32 +563,41 @@ func (s *consentPurposeService) UpdatePurpose(ctx context.Context, purposeID str
 	return purpose, nil
 }
 
-// DeletePurpose deletes a consent purpose
+// DeletePurpose deletes a consent purpose. Purposes still mapped to consents that are not deleted
+// are refused; mappings of soft-deleted consents are removed with the purpose.
 func (s *consentPurposeService) DeletePurpose(ctx context.Context, purposeID, orgID string) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.DeletePurpose")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Info("Deleting consent purpose",
 		log.String("purpose_id", purposeID),
 		log.String("org_id", orgID),
 	)
 
-	// Check if purpose exists
-	store := s.stores.ConsentPurpose
-	existing, err := store.GetByID(ctx, purposeID, orgID)
-	if err != nil {
-		logger.Error("Failed to retrieve purpose for deletion",
-			log.Error(err),
+	existing, linked, serviceErr := s.getPurposeForDeletion(ctx, purposeID, orgID)
+	if serviceErr != nil {
+		return serviceErr
+	}
+	inUse := 0
+	for _, consent := range linked {
+		if consent.CurrentStatus != consentModel.DeletedConsentStatus {
+			inUse++
+		}
+	}
+	if inUse > 0 {
+		logger.Warn("Purpose is linked to consents",
 			log.String("purpose_id", purposeID),
+			log.Int("linked_consents", inUse),
 		)
-		return serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve purpose: %v", err))
-	}
-	if existing == nil {
-		logger.Warn("Purpose not found for deletion", log.String("purpose_id", purposeID))
-		return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("purpose with ID '%s' not found", purposeID))
+		return serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("purpose '%s' is linked to %d consent(s); detach them or delete with force=true", existing.Name, inUse))
 	}
 
 	// Delete attributes and purpose in a transaction
 	logger.Debug("Executing transaction for purpose deletion")
-	err = s.stores.ExecuteTransaction([]func(tx dbmodel.TxInterface) error{
+	store := s.stores.ConsentPurpose
+	err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
 		func(tx dbmodel.TxInterface) error {
 			return store.DeleteAttributesByPurposeID(tx, purposeID, orgID)
 		},
@@ -440,6 +612,7 @@ func (s *consentPurposeService) DeletePurpose(ctx context.Context, purposeID, or
 		)
 		return serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to delete purpose: %v", err))
 	}
+	s.invalidatePurposeCache(ctx, orgID)
 
 	logger.Info("Purpose deleted successfully",
 		log.String("purpose_id", purposeID),
@@ -448,8 +621,114 @@ func (s *consentPurposeService) DeletePurpose(ctx context.Context, purposeID, or
 	return nil
 }
 
+// ForceDeletePurpose deletes a consent purpose and detaches it from every consent it is mapped
+// to. The detachment is recorded in the status audit of each consent, which keeps its status.
+func (s *consentPurposeService) ForceDeletePurpose(ctx context.Context, purposeID, orgID, actionBy string) (*model.PurposeDeleteResponse, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.ForceDeletePurpose")
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	logger.Info("Force deleting consent purpose",
+		log.String("purpose_id", purposeID),
+		log.String("org_id", orgID),
+		log.String("action_by", actionBy),
+	)
+
+	existing, linked, serviceErr := s.getPurposeForDeletion(ctx, purposeID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	currentTime := utils.GetCurrentTimeMillis()
+	reason := fmt.Sprintf("Purpose '%s' detached by forced purpose deletion", existing.Name)
+	store := s.stores.ConsentPurpose
+	queries := []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return store.DeleteMappingsByPurposeID(tx, purposeID, orgID)
+		},
+	}
+	for _, consent := range linked {
+		status := consent.CurrentStatus
+		audit := &consentModel.ConsentStatusAudit{
+			StatusAuditID:  utils.GenerateUUID(),
+			ConsentID:      consent.ConsentID,
+			CurrentStatus:  status,
+			ActionTime:     currentTime,
+			Reason:         &reason,
+			ActionBy:       &actionBy,
+			PreviousStatus: &status,
+			OrgID:          orgID,
+		}
+		queries = append(queries, func(tx dbmodel.TxInterface) error {
+			return s.stores.Consent.CreateStatusAudit(tx, audit)
+		})
+	}
+	queries = append(queries,
+		func(tx dbmodel.TxInterface) error {
+			return store.DeleteAttributesByPurposeID(tx, purposeID, orgID)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return store.Delete(tx, purposeID, orgID)
+		},
+	)
+
+	if err := s.stores.ExecuteTransaction(ctx, queries); err != nil {
+		logger.Error("Transaction failed for forced purpose deletion",
+			log.Error(err),
+			log.String("purpose_id", purposeID),
+		)
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to delete purpose: %v", err))
+	}
+	s.invalidatePurposeCache(ctx, orgID)
+	for _, consent := range linked {
+		cache.InvalidateConsentValidation(ctx, orgID, consent.ConsentID)
+	}
+
+	logger.Info("Purpose force deleted",
+		log.String("purpose_id", purposeID),
+		log.String("name", existing.Name),
+		log.Int("detached_consents", len(linked)),
+	)
+	return &model.PurposeDeleteResponse{
+		PurposeID:        purposeID,
+		DetachedConsents: len(linked),
+	}, nil
+}
+
+// getPurposeForDeletion loads a purpose that is about to be deleted, with the consents mapped to it
+func (s *consentPurposeService) getPurposeForDeletion(ctx context.Context, purposeID, orgID string) (*model.ConsentPurpose, []model.LinkedConsent, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	store := s.stores.ConsentPurpose
+	existing, err := store.GetByID(ctx, purposeID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve purpose for deletion",
+			log.Error(err),
+			log.String("purpose_id", purposeID),
+		)
+		return nil, nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve purpose: %v", err))
+	}
+	if existing == nil {
+		logger.Warn("Purpose not found for deletion", log.String("purpose_id", purposeID))
+		return nil, nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, fmt.Sprintf("purpose with ID '%s' not found", purposeID))
+	}
+
+	linked, err := store.GetLinkedConsents(ctx, purposeID, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve consents linked to purpose",
+			log.Error(err),
+			log.String("purpose_id", purposeID),
+		)
+		return nil, nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve linked consents: %v", err))
+	}
+	return existing, linked, nil
+}
+
 // ValidatePurposeNames validates a list of purpose names and returns only the valid ones
 func (s *consentPurposeService) ValidatePurposeNames(ctx context.Context, orgID string, purposeNames []string) ([]string, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.ValidatePurposeNames")
+	defer span.End()
+
 	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Validating purpose names",
 		log.String("org_id", orgID),
@@ -493,60 +772,226 @@ func (s *consentPurposeService) ValidatePurposeNames(ctx context.Context, orgID
 	return validNames, nil
 }
 
-// validateCreateRequest validates create request
-func (s *consentPurposeService) validateCreateRequest(req model.CreateRequest) *serviceerror.ServiceError {
-	if req.Name == "" {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, "purpose name is required")
+// ListPurposeAttributes retrieves the attributes of a consent purpose ordered by key
+func (s *consentPurposeService) ListPurposeAttributes(ctx context.Context, purposeID, orgID string) ([]model.PurposeAttribute, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.ListPurposeAttributes")
+	defer span.End()
+
+	purpose, serviceErr := s.GetPurpose(ctx, purposeID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	attributes := make([]model.PurposeAttribute, 0, len(purpose.Attributes))
+	for key, value := range purpose.Attributes {
+		attributes = append(attributes, model.PurposeAttribute{Key: key, Value: value})
 	}
-	if len(req.Name) > 255 {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, "purpose name must not exceed 255 characters")
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Key < attributes[j].Key
+	})
+	return attributes, nil
+}
+
+// ListPurposeApprovals lists the users who approved a purpose, with the consents in which they approved it
+func (s *consentPurposeService) ListPurposeApprovals(ctx context.Context, filters model.PurposeApprovalFilters) ([]model.PurposeApproval, int, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.ListPurposeApprovals")
+	defer span.End()
+
+	if _, serviceErr := s.GetPurpose(ctx, filters.PurposeID, filters.OrgID); serviceErr != nil {
+		return nil, 0, serviceErr
 	}
-	if len(req.Description) > 1024 {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, "purpose description must not exceed 1024 characters")
+	if filters.Limit <= 0 {
+		filters.Limit = 100
 	}
-	if req.Type == "" {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, "purpose type is required")
+	if filters.Offset < 0 {
+		filters.Offset = 0
 	}
 
-	// Validate purpose type using validators
-	handler, err := validators.GetHandler(req.Type)
+	approvals, total, err := s.stores.ConsentPurpose.ListApprovals(ctx, filters)
 	if err != nil {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("invalid purpose type: %s", req.Type))
+		log.GetLogger().WithContext(ctx).Error("Failed to list purpose approvals",
+			log.Error(err),
+			log.String("purpose_id", filters.PurposeID),
+		)
+		return nil, 0, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to list purpose approvals: %v", err))
 	}
+	return approvals, total, nil
+}
 
-	// Validate attributes using type handler
-	if validationErr := handler.ValidateAttributes(req.Attributes); validationErr != nil {
+// CreatePurposeAttribute adds an attribute to a consent purpose
+func (s *consentPurposeService) CreatePurposeAttribute(ctx context.Context, purposeID string, req model.PurposeAttributeRequest, orgID string) (*model.PurposeAttribute, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.CreatePurposeAttribute")
+	defer span.End()
+
+	if req.Key == "" {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "attribute key is required")
+	}
+	if len(req.Key) > 255 {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "attribute key must not exceed 255 characters")
+	}
+
+	purpose, serviceErr := s.GetPurpose(ctx, purposeID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	if _, exists := purpose.Attributes[req.Key]; exists {
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("attribute '%s' already exists on purpose '%s'", req.Key, purposeID))
+	}
+
+	purpose.Attributes[req.Key] = req.Value
+	attribute := model.ConsentPurposeAttribute{PurposeID: purposeID, Key: req.Key, Value: req.Value, OrgID: orgID}
+	store := s.stores.ConsentPurpose
+	if serviceErr := s.changePurposeAttributes(ctx, purpose, "create", func(tx dbmodel.TxInterface) error {
+		return store.CreateAttributes(tx, []model.ConsentPurposeAttribute{attribute})
+	}); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	return &model.PurposeAttribute{Key: req.Key, Value: req.Value}, nil
+}
+
+// UpdatePurposeAttribute changes the value of an existing attribute of a consent purpose
+func (s *consentPurposeService) UpdatePurposeAttribute(ctx context.Context, purposeID, key string, req model.PurposeAttributeRequest, orgID string) (*model.PurposeAttribute, *serviceerror.ServiceError) {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.UpdatePurposeAttribute")
+	defer span.End()
+
+	if req.Key != "" && req.Key != key {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "attribute key in the body does not match the path")
+	}
+
+	purpose, serviceErr := s.GetPurpose(ctx, purposeID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	if _, exists := purpose.Attributes[key]; !exists {
+		return nil, purposeAttributeNotFound(purposeID, key)
+	}
+
+	purpose.Attributes[key] = req.Value
+	attribute := model.ConsentPurposeAttribute{PurposeID: purposeID, Key: key, Value: req.Value, OrgID: orgID}
+	store := s.stores.ConsentPurpose
+	if serviceErr := s.changePurposeAttributes(ctx, purpose, "update", func(tx dbmodel.TxInterface) error {
+		return store.UpdateAttribute(tx, attribute)
+	}); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	return &model.PurposeAttribute{Key: key, Value: req.Value}, nil
+}
+
+// DeletePurposeAttribute removes an attribute from a consent purpose
+func (s *consentPurposeService) DeletePurposeAttribute(ctx context.Context, purposeID, key, orgID string) *serviceerror.ServiceError {
+	ctx, span := tracing.StartSpan(ctx, "consentpurpose.DeletePurposeAttribute")
+	defer span.End()
+
+	purpose, serviceErr := s.GetPurpose(ctx, purposeID, orgID)
+	if serviceErr != nil {
+		return serviceErr
+	}
+	if _, exists := purpose.Attributes[key]; !exists {
+		return purposeAttributeNotFound(purposeID, key)
+	}
+
+	delete(purpose.Attributes, key)
+	store := s.stores.ConsentPurpose
+	return s.changePurposeAttributes(ctx, purpose, "delete", func(tx dbmodel.TxInterface) error {
+		return store.DeleteAttribute(tx, purposeID, key, orgID)
+	})
+}
+
+// changePurposeAttributes checks the resulting attributes of a purpose against its type handler,
+// so a single attribute change cannot drop an attribute the type requires, and then applies the
+// change
+func (s *consentPurposeService) changePurposeAttributes(ctx context.Context, purpose *model.ConsentPurpose, action string, change func(tx dbmodel.TxInterface) error) *serviceerror.ServiceError {
+	logger := log.GetLogger().WithContext(ctx)
+
+	handler, err := validators.GetHandler(purpose.Type)
+	if err != nil {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("invalid purpose type: %s", purpose.Type))
+	}
+	if validationErr := handler.ValidateAttributes(purpose.Attributes); len(validationErr) > 0 {
 		return serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("attribute validation failed: %v", validationErr))
 	}
 
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{change}); err != nil {
+		logger.Error("Failed to "+action+" purpose attribute",
+			log.Error(err),
+			log.String("purpose_id", purpose.ID),
+		)
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to %s purpose attribute: %v", action, err))
+	}
+	s.invalidatePurposeCache(ctx, purpose.OrgID)
+
+	logger.Info("Purpose attributes changed",
+		log.String("purpose_id", purpose.ID),
+		log.String("action", action),
+		log.Int("attributes_count", len(purpose.Attributes)),
+	)
 	return nil
 }
 
-// validateUpdateRequest validates update request
-func (s *consentPurposeService) validateUpdateRequest(req model.UpdateRequest) *serviceerror.ServiceError {
-	if req.Name == "" {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, "purpose name is required")
+// purposeAttributeNotFound returns the error for an attribute a purpose does not have
+func purposeAttributeNotFound(purposeID, key string) *serviceerror.ServiceError {
+	return serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+		fmt.Sprintf("attribute '%s' not found on purpose '%s'", key, purposeID))
+}
+
+// validateCreateRequest reports every invalid field of a create request
+func (s *consentPurposeService) validateCreateRequest(req model.CreateRequest) serviceerror.FieldViolations {
+	return validatePurposeFields(req.Name, &req.Description, req.Type, req.Attributes)
+}
+
+// validateUpdateRequest reports every invalid field of an update request
+func (s *consentPurposeService) validateUpdateRequest(req model.UpdateRequest) serviceerror.FieldViolations {
+	return validatePurposeFields(req.Name, req.Description, req.Type, req.Attributes)
+}
+
+// validatePurposeFields checks the fields shared by create and update requests. Attributes are
+// validated by the handler of the purpose type.
+func validatePurposeFields(name string, description *string, purposeType string, attributes map[string]string) serviceerror.FieldViolations {
+	var violations serviceerror.FieldViolations
+	add := func(path, constraint, message string) {
+		violations = append(violations, serviceerror.FieldViolation{Path: path, Constraint: constraint, Message: message})
 	}
-	if len(req.Name) > 255 {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, "purpose name must not exceed 255 characters")
+
+	if name == "" {
+		add("name", "required", "purpose name is required")
+	} else if len(name) > 255 {
+		add("name", "maxLength", "purpose name must not exceed 255 characters")
 	}
-	if req.Description != nil && len(*req.Description) > 1024 {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, "purpose description must not exceed 1024 characters")
+	if description != nil && len(*description) > 1024 {
+		add("description", "maxLength", "purpose description must not exceed 1024 characters")
 	}
-	if req.Type == "" {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, "purpose type is required")
+	if purposeType == "" {
+		add("type", "required", "purpose type is required")
+		return violations
 	}
 
 	// Validate purpose type using validators
-	handler, err := validators.GetHandler(req.Type)
+	handler, err := validators.GetHandler(purposeType)
 	if err != nil {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("invalid purpose type: %s", req.Type))
+		add("type", "enum", fmt.Sprintf("invalid purpose type: %s", purposeType))
+		return violations
 	}
 
 	// Validate attributes using type handler
-	if validationErr := handler.ValidateAttributes(req.Attributes); validationErr != nil {
-		return serviceerror.CustomServiceError(serviceerror.ValidationError, fmt.Sprintf("attribute validation failed: %v", validationErr))
+	for _, attrErr := range handler.ValidateAttributes(attributes) {
+		add("attributes."+attrErr.Field, attrErr.Constraint, attrErr.Message)
 	}
 
-	return nil
+	return violations
+}
+
+// invalidatePurposeCache drops the cached purpose definitions of an organization once a change to
+// its purposes is committed. It is a no-op when the purpose cache is disabled.
+func (s *consentPurposeService) invalidatePurposeCache(ctx context.Context, orgID string) {
+	invalidator, ok := s.stores.ConsentPurpose.(purposeCacheInvalidator)
+	if !ok {
+		return
+	}
+	removed := invalidator.InvalidateOrg(orgID)
+	log.GetLogger().WithContext(ctx).Debug("Invalidated cached purpose definitions",
+		log.String("org_id", orgID),
+		log.Int("removed", removed))
 }
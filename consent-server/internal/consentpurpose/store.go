@@ -3,6 +3,7 @@ package consentpurpose
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/wso2/consent-management-api/internal/consentpurpose/model"
 	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
@@ -29,22 +30,21 @@ var (
 
 	QueryListPurposes = dbmodel.DBQuery{
 		ID:    "LIST_CONSENT_PURPOSES",
-		Query: "SELECT ID, NAME, DESCRIPTION, TYPE, ORG_ID FROM CONSENT_PURPOSE WHERE ORG_ID = ? ORDER BY NAME LIMIT ? OFFSET ?",
-	}
-
-	QueryListPurposesWithName = dbmodel.DBQuery{
-		ID:    "LIST_CONSENT_PURPOSES_WITH_NAME",
-		Query: "SELECT ID, NAME, DESCRIPTION, TYPE, ORG_ID FROM CONSENT_PURPOSE WHERE ORG_ID = ? AND NAME LIKE ? ORDER BY NAME LIMIT ? OFFSET ?",
+		Query: "SELECT cp.ID, cp.NAME, cp.DESCRIPTION, cp.TYPE, cp.ORG_ID FROM CONSENT_PURPOSE cp WHERE %s ORDER BY cp.NAME LIMIT ? OFFSET ?",
 	}
 
 	QueryCountPurposes = dbmodel.DBQuery{
 		ID:    "COUNT_CONSENT_PURPOSES",
-		Query: "SELECT COUNT(*) as count FROM CONSENT_PURPOSE WHERE ORG_ID = ?",
+		Query: "SELECT COUNT(*) as count FROM CONSENT_PURPOSE cp WHERE %s",
 	}
 
-	QueryCountPurposesWithName = dbmodel.DBQuery{
-		ID:    "COUNT_CONSENT_PURPOSES_WITH_NAME",
-		Query: "SELECT COUNT(*) as count FROM CONSENT_PURPOSE WHERE ORG_ID = ? AND NAME LIKE ?",
+	QueryCountConsentsByPurposeIDs = dbmodel.DBQuery{
+		ID: "COUNT_CONSENTS_BY_PURPOSE_IDS",
+		Query: `SELECT cpm.PURPOSE_ID, COUNT(*) as count
+				FROM CONSENT_PURPOSE_MAPPING cpm
+				INNER JOIN CONSENT c ON cpm.CONSENT_ID = c.CONSENT_ID AND cpm.ORG_ID = c.ORG_ID
+				WHERE cpm.ORG_ID = ? AND c.CURRENT_STATUS <> 'DELETED' AND cpm.PURPOSE_ID IN (%s)
+				GROUP BY cpm.PURPOSE_ID`,
 	}
 
 	QueryUpdatePurpose = dbmodel.DBQuery{
@@ -72,6 +72,16 @@ var (
 		Query: "SELECT PURPOSE_ID, ATT_KEY, ATT_VALUE, ORG_ID FROM CONSENT_PURPOSE_ATTRIBUTE WHERE PURPOSE_ID = ? AND ORG_ID = ?",
 	}
 
+	QueryUpdateAttribute = dbmodel.DBQuery{
+		ID:    "UPDATE_PURPOSE_ATTRIBUTE",
+		Query: "UPDATE CONSENT_PURPOSE_ATTRIBUTE SET ATT_VALUE = ? WHERE PURPOSE_ID = ? AND ATT_KEY = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteAttribute = dbmodel.DBQuery{
+		ID:    "DELETE_PURPOSE_ATTRIBUTE",
+		Query: "DELETE FROM CONSENT_PURPOSE_ATTRIBUTE WHERE PURPOSE_ID = ? AND ATT_KEY = ? AND ORG_ID = ?",
+	}
+
 	QueryDeleteAttributesByPurposeID = dbmodel.DBQuery{
 		ID:    "DELETE_ATTRIBUTES_BY_PURPOSE_ID",
 		Query: "DELETE FROM CONSENT_PURPOSE_ATTRIBUTE WHERE PURPOSE_ID = ? AND ORG_ID = ?",
@@ -87,12 +97,17 @@ var (
 
 	QueryLinkPurposeToConsent = dbmodel.DBQuery{
 		ID:    "LINK_PURPOSE_TO_CONSENT",
-		Query: "INSERT INTO CONSENT_PURPOSE_MAPPING (CONSENT_ID, PURPOSE_ID, ORG_ID, VALUE, IS_USER_APPROVED, IS_MANDATORY) VALUES (?, ?, ?, ?, ?, ?)",
+		Query: "INSERT INTO CONSENT_PURPOSE_MAPPING (CONSENT_ID, PURPOSE_ID, ORG_ID, VALUE, IS_USER_APPROVED, IS_MANDATORY, EXPIRES_AT, LAST_CONFIRMED_AT) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryUpdateMappingApproval = dbmodel.DBQuery{
+		ID:    "UPDATE_MAPPING_APPROVAL",
+		Query: "UPDATE CONSENT_PURPOSE_MAPPING SET IS_USER_APPROVED = ?, LAST_CONFIRMED_AT = ? WHERE CONSENT_ID = ? AND PURPOSE_ID = ? AND ORG_ID = ?",
 	}
 
 	QueryGetMappingsByConsentID = dbmodel.DBQuery{
 		ID: "GET_MAPPINGS_BY_CONSENT_ID",
-		Query: `SELECT cpm.CONSENT_ID, cpm.PURPOSE_ID, cpm.ORG_ID, cpm.VALUE, cpm.IS_USER_APPROVED, cpm.IS_MANDATORY, cp.NAME
+		Query: `SELECT cpm.CONSENT_ID, cpm.PURPOSE_ID, cpm.ORG_ID, cpm.VALUE, cpm.IS_USER_APPROVED, cpm.IS_MANDATORY, cpm.EXPIRES_AT, cpm.LAST_CONFIRMED_AT, cp.NAME
 				FROM CONSENT_PURPOSE_MAPPING cpm
 				INNER JOIN CONSENT_PURPOSE cp ON cpm.PURPOSE_ID = cp.ID
 				WHERE cpm.CONSENT_ID = ? AND cpm.ORG_ID = ?`,
@@ -103,11 +118,56 @@ var (
 		Query: "SELECT ID, NAME FROM CONSENT_PURPOSE WHERE ORG_ID = ? AND NAME IN (%s)",
 	}
 
+	QueryGetPurposesByNames = dbmodel.DBQuery{
+		ID:    "GET_CONSENT_PURPOSES_BY_NAMES",
+		Query: "SELECT ID, NAME, DESCRIPTION, TYPE, ORG_ID FROM CONSENT_PURPOSE WHERE ORG_ID = ? AND NAME IN (%s)",
+	}
+
+	QueryGetAttributesByPurposeIDs = dbmodel.DBQuery{
+		ID:    "GET_ATTRIBUTES_BY_PURPOSE_IDS",
+		Query: "SELECT PURPOSE_ID, ATT_KEY, ATT_VALUE, ORG_ID FROM CONSENT_PURPOSE_ATTRIBUTE WHERE ORG_ID = ? AND PURPOSE_ID IN (%s)",
+	}
+
 	QueryDeleteMappingsByConsentID = dbmodel.DBQuery{
 		ID:    "DELETE_MAPPINGS_BY_CONSENT_ID",
 		Query: "DELETE FROM CONSENT_PURPOSE_MAPPING WHERE CONSENT_ID = ? AND ORG_ID = ?",
 	}
 
+	QueryDeleteMappingsByPurposeID = dbmodel.DBQuery{
+		ID:    "DELETE_MAPPINGS_BY_PURPOSE_ID",
+		Query: "DELETE FROM CONSENT_PURPOSE_MAPPING WHERE PURPOSE_ID = ? AND ORG_ID = ?",
+	}
+
+	// Approvals are read from the purpose mappings joined with the users authorizing the consent
+	QueryListPurposeApprovals = dbmodel.DBQuery{
+		ID: "LIST_PURPOSE_APPROVALS",
+		Query: `SELECT DISTINCT ar.USER_ID, c.CONSENT_ID, c.CONSENT_TYPE, c.CURRENT_STATUS, c.UPDATED_TIME
+				FROM CONSENT_PURPOSE_MAPPING cpm
+				INNER JOIN CONSENT c ON cpm.CONSENT_ID = c.CONSENT_ID AND cpm.ORG_ID = c.ORG_ID
+				INNER JOIN CONSENT_AUTH_RESOURCE ar ON ar.CONSENT_ID = c.CONSENT_ID AND ar.ORG_ID = c.ORG_ID
+				WHERE %s
+				ORDER BY ar.USER_ID, c.CONSENT_ID LIMIT ? OFFSET ?`,
+	}
+
+	QueryCountPurposeApprovals = dbmodel.DBQuery{
+		ID: "COUNT_PURPOSE_APPROVALS",
+		Query: `SELECT COUNT(*) as count FROM (
+				SELECT DISTINCT ar.USER_ID, c.CONSENT_ID
+				FROM CONSENT_PURPOSE_MAPPING cpm
+				INNER JOIN CONSENT c ON cpm.CONSENT_ID = c.CONSENT_ID AND cpm.ORG_ID = c.ORG_ID
+				INNER JOIN CONSENT_AUTH_RESOURCE ar ON ar.CONSENT_ID = c.CONSENT_ID AND ar.ORG_ID = c.ORG_ID
+				WHERE %s) approvals`,
+	}
+
+	QueryGetLinkedConsents = dbmodel.DBQuery{
+		ID: "GET_LINKED_CONSENTS_BY_PURPOSE_ID",
+		Query: `SELECT cpm.CONSENT_ID, c.CURRENT_STATUS
+				FROM CONSENT_PURPOSE_MAPPING cpm
+				INNER JOIN CONSENT c ON cpm.CONSENT_ID = c.CONSENT_ID AND cpm.ORG_ID = c.ORG_ID
+				WHERE cpm.PURPOSE_ID = ? AND cpm.ORG_ID = ?
+				ORDER BY cpm.CONSENT_ID`,
+	}
+
 	QueryGetMappingsByConsentIDs = dbmodel.DBQuery{
 		ID:    "GET_MAPPINGS_BY_CONSENT_IDS",
 		Query: "", // Built dynamically
@@ -135,7 +195,7 @@ func (s *store) Create(tx dbmodel.TxInterface, purpose *model.ConsentPurpose) er
 
 // GetByID retrieves a consent purpose by ID
 func (s *store) GetByID(ctx context.Context, purposeID, orgID string) (*model.ConsentPurpose, error) {
-	rows, err := s.dbClient.Query(QueryGetPurposeByID, purposeID, orgID)
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetPurposeByID, purposeID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -147,7 +207,7 @@ func (s *store) GetByID(ctx context.Context, purposeID, orgID string) (*model.Co
 
 // GetByName retrieves a consent purpose by name
 func (s *store) GetByName(ctx context.Context, name, orgID string) (*model.ConsentPurpose, error) {
-	rows, err := s.dbClient.Query(QueryGetPurposeByName, name, orgID)
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetPurposeByName, name, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -157,40 +217,86 @@ func (s *store) GetByName(ctx context.Context, name, orgID string) (*model.Conse
 	return mapToConsentPurpose(rows[0]), nil
 }
 
-// List retrieves a paginated list of consent purposes
-func (s *store) List(ctx context.Context, orgID string, limit, offset int, name string) ([]model.ConsentPurpose, int, error) {
-	var countRows []map[string]interface{}
-	var rows []map[string]interface{}
-	var err error
+// GetByNames retrieves consent purposes by their names (batch lookup), keyed by name. Names that
+// do not match a purpose are left out.
+func (s *store) GetByNames(ctx context.Context, names []string, orgID string) (map[string]model.ConsentPurpose, error) {
+	if len(names) == 0 {
+		return make(map[string]model.ConsentPurpose), nil
+	}
 
-	// Use different queries based on whether name filter is provided
-	if name != "" {
-		// Add wildcards for partial match (case-insensitive search)
-		namePattern := "%" + name + "%"
+	placeholders, args := inClauseArgs(orgID, names)
+	query := dbmodel.DBQuery{
+		ID:    QueryGetPurposesByNames.ID,
+		Query: fmt.Sprintf(QueryGetPurposesByNames.Query, placeholders),
+	}
 
-		// Get total count with name filter
-		countRows, err = s.dbClient.Query(QueryCountPurposesWithName, orgID, namePattern)
-		if err != nil {
-			return nil, 0, err
-		}
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 
-		// Get paginated results with name filter
-		rows, err = s.dbClient.Query(QueryListPurposesWithName, orgID, namePattern, limit, offset)
-		if err != nil {
-			return nil, 0, err
+	result := make(map[string]model.ConsentPurpose, len(rows))
+	for _, row := range rows {
+		purpose := mapToConsentPurpose(row)
+		if purpose != nil {
+			result[purpose.Name] = *purpose
 		}
-	} else {
-		// Get total count without name filter
-		countRows, err = s.dbClient.Query(QueryCountPurposes, orgID)
-		if err != nil {
-			return nil, 0, err
+	}
+	return result, nil
+}
+
+// List retrieves a paginated list of consent purposes matching the filters
+func (s *store) List(ctx context.Context, filters model.PurposeSearchFilters) ([]model.ConsentPurpose, int, error) {
+	whereConditions := []string{"cp.ORG_ID = ?"}
+	args := []interface{}{filters.OrgID}
+
+	// Name filters are matched with LIKE, so wildcards in the filter are escaped
+	if filters.Name != "" {
+		whereConditions = append(whereConditions, "cp.NAME LIKE ? ESCAPE '!'")
+		args = append(args, "%"+escapeLike(filters.Name)+"%")
+	}
+	if filters.NamePrefix != "" {
+		whereConditions = append(whereConditions, "cp.NAME LIKE ? ESCAPE '!'")
+		args = append(args, escapeLike(filters.NamePrefix)+"%")
+	}
+
+	if len(filters.Types) > 0 {
+		placeholders := make([]string, len(filters.Types))
+		for i, purposeType := range filters.Types {
+			placeholders[i] = "?"
+			args = append(args, purposeType)
 		}
+		whereConditions = append(whereConditions, fmt.Sprintf("cp.TYPE IN (%s)", strings.Join(placeholders, ",")))
+	}
 
-		// Get paginated results without name filter
-		rows, err = s.dbClient.Query(QueryListPurposes, orgID, limit, offset)
-		if err != nil {
-			return nil, 0, err
+	if filters.AttributeKey != "" {
+		condition := "EXISTS (SELECT 1 FROM CONSENT_PURPOSE_ATTRIBUTE cpa WHERE cpa.PURPOSE_ID = cp.ID AND cpa.ORG_ID = cp.ORG_ID AND cpa.ATT_KEY = ?"
+		args = append(args, filters.AttributeKey)
+		if filters.AttributeValue != "" {
+			condition += " AND cpa.ATT_VALUE = ?"
+			args = append(args, filters.AttributeValue)
 		}
+		whereConditions = append(whereConditions, condition+")")
+	}
+
+	whereClause := strings.Join(whereConditions, " AND ")
+
+	countQuery := dbmodel.DBQuery{
+		ID:    QueryCountPurposes.ID,
+		Query: fmt.Sprintf(QueryCountPurposes.Query, whereClause),
+	}
+	countRows, err := s.dbClient.QueryContext(ctx, countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	listQuery := dbmodel.DBQuery{
+		ID:    QueryListPurposes.ID,
+		Query: fmt.Sprintf(QueryListPurposes.Query, whereClause),
+	}
+	rows, err := s.dbClient.QueryContext(ctx, listQuery, append(args, filters.Limit, filters.Offset)...)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	totalCount := 0
@@ -211,6 +317,41 @@ func (s *store) List(ctx context.Context, orgID string, limit, offset int, name
 	return purposes, totalCount, nil
 }
 
+// CountConsentsByPurposeIDs counts the consents linked to each purpose, excluding deleted consents.
+// Purposes without linked consents are absent from the result.
+func (s *store) CountConsentsByPurposeIDs(ctx context.Context, purposeIDs []string, orgID string) (map[string]int, error) {
+	counts := make(map[string]int, len(purposeIDs))
+	if len(purposeIDs) == 0 {
+		return counts, nil
+	}
+
+	placeholders, args := inClauseArgs(orgID, purposeIDs)
+	query := dbmodel.DBQuery{
+		ID:    QueryCountConsentsByPurposeIDs.ID,
+		Query: fmt.Sprintf(QueryCountConsentsByPurposeIDs.Query, placeholders),
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		purposeID, _ := row["purpose_id"].(string)
+		if b, ok := row["purpose_id"].([]byte); ok {
+			purposeID = string(b)
+		}
+		if count, ok := row["count"].(int64); ok {
+			counts[purposeID] = int(count)
+		}
+	}
+	return counts, nil
+}
+
+// escapeLike escapes the LIKE wildcards in value using '!' as the escape character
+func escapeLike(value string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(value)
+}
+
 // Update updates an existing consent purpose within a transaction
 func (s *store) Update(tx dbmodel.TxInterface, purpose *model.ConsentPurpose) error {
 	_, err := tx.Exec(QueryUpdatePurpose.Query,
@@ -226,7 +367,7 @@ func (s *store) Delete(tx dbmodel.TxInterface, purposeID, orgID string) error {
 
 // CheckNameExists checks if a purpose name already exists
 func (s *store) CheckNameExists(ctx context.Context, name, orgID string) (bool, error) {
-	rows, err := s.dbClient.Query(QueryCheckPurposeNameExists, name, orgID)
+	rows, err := s.dbClient.QueryContext(ctx, QueryCheckPurposeNameExists, name, orgID)
 	if err != nil {
 		return false, err
 	}
@@ -253,7 +394,7 @@ func (s *store) CreateAttributes(tx dbmodel.TxInterface, attributes []model.Cons
 
 // GetAttributesByPurposeID retrieves all attributes for a purpose
 func (s *store) GetAttributesByPurposeID(ctx context.Context, purposeID, orgID string) ([]model.ConsentPurposeAttribute, error) {
-	rows, err := s.dbClient.Query(QueryGetAttributesByPurposeID, purposeID, orgID)
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetAttributesByPurposeID, purposeID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -269,12 +410,64 @@ func (s *store) GetAttributesByPurposeID(ctx context.Context, purposeID, orgID s
 	return attributes, nil
 }
 
+// GetAttributesByPurposeIDs retrieves attributes for multiple purposes, grouped by purpose ID
+func (s *store) GetAttributesByPurposeIDs(ctx context.Context, purposeIDs []string, orgID string) (map[string][]model.ConsentPurposeAttribute, error) {
+	if len(purposeIDs) == 0 {
+		return make(map[string][]model.ConsentPurposeAttribute), nil
+	}
+
+	placeholders, args := inClauseArgs(orgID, purposeIDs)
+	query := dbmodel.DBQuery{
+		ID:    QueryGetAttributesByPurposeIDs.ID,
+		Query: fmt.Sprintf(QueryGetAttributesByPurposeIDs.Query, placeholders),
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]model.ConsentPurposeAttribute)
+	for _, row := range rows {
+		attr := mapToConsentPurposeAttribute(row)
+		if attr != nil {
+			result[attr.PurposeID] = append(result[attr.PurposeID], *attr)
+		}
+	}
+	return result, nil
+}
+
 // DeleteAttributesByPurposeID deletes all attributes for a purpose within a transaction
 func (s *store) DeleteAttributesByPurposeID(tx dbmodel.TxInterface, purposeID, orgID string) error {
 	_, err := tx.Exec(QueryDeleteAttributesByPurposeID.Query, purposeID, orgID)
 	return err
 }
 
+// UpdateAttribute changes the value of a single purpose attribute within a transaction
+func (s *store) UpdateAttribute(tx dbmodel.TxInterface, attribute model.ConsentPurposeAttribute) error {
+	_, err := tx.Exec(QueryUpdateAttribute.Query, attribute.Value, attribute.PurposeID, attribute.Key, attribute.OrgID)
+	return err
+}
+
+// DeleteAttribute deletes a single purpose attribute within a transaction
+func (s *store) DeleteAttribute(tx dbmodel.TxInterface, purposeID, key, orgID string) error {
+	_, err := tx.Exec(QueryDeleteAttribute.Query, purposeID, key, orgID)
+	return err
+}
+
+// inClauseArgs builds the placeholders of an IN clause for values, returning them with the query
+// arguments: orgID followed by values
+func inClauseArgs(orgID string, values []string) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, 0, len(values)+1)
+	args = append(args, orgID)
+	for i, value := range values {
+		placeholders[i] = "?"
+		args = append(args, value)
+	}
+	return strings.Join(placeholders, ", "), args
+}
+
 // mapToConsentPurpose maps a database row to ConsentPurpose model
 // Note: DBClient normalizes column names to lowercase
 func mapToConsentPurpose(row map[string]interface{}) *model.ConsentPurpose {
@@ -384,15 +577,22 @@ func mapToConsentPurposeAttribute(row map[string]interface{}) *model.ConsentPurp
 }
 
 // LinkPurposeToConsent links a purpose to a consent within a transaction
-func (s *store) LinkPurposeToConsent(tx dbmodel.TxInterface, consentID, purposeID, orgID string, value *string, isUserApproved, isMandatory bool) error {
+func (s *store) LinkPurposeToConsent(tx dbmodel.TxInterface, consentID, purposeID, orgID string, value *string, isUserApproved, isMandatory bool, expiresAt, lastConfirmedAt *int64) error {
 	_, err := tx.Exec(QueryLinkPurposeToConsent.Query,
-		consentID, purposeID, orgID, value, isUserApproved, isMandatory)
+		consentID, purposeID, orgID, value, isUserApproved, isMandatory, expiresAt, lastConfirmedAt)
+	return err
+}
+
+// UpdateMappingApproval records the user's approval of a purpose linked to a consent, and the time
+// the user confirmed it, within a transaction
+func (s *store) UpdateMappingApproval(tx dbmodel.TxInterface, consentID, purposeID, orgID string, isUserApproved bool, confirmedAt int64) error {
+	_, err := tx.Exec(QueryUpdateMappingApproval.Query, isUserApproved, confirmedAt, consentID, purposeID, orgID)
 	return err
 }
 
 // GetPurposesByConsentID retrieves all purposes linked to a consent
 func (s *store) GetPurposesByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentPurpose, error) {
-	rows, err := s.dbClient.Query(QueryGetPurposesByConsentID, consentID, orgID)
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetPurposesByConsentID, consentID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -410,7 +610,7 @@ func (s *store) GetPurposesByConsentID(ctx context.Context, consentID, orgID str
 
 // GetMappingsByConsentID retrieves all purpose mappings for a consent with their values
 func (s *store) GetMappingsByConsentID(ctx context.Context, consentID, orgID string) ([]model.ConsentPurposeMapping, error) {
-	rows, err := s.dbClient.Query(QueryGetMappingsByConsentID, consentID, orgID)
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetMappingsByConsentID, consentID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -447,13 +647,13 @@ func (s *store) GetMappingsByConsentIDs(ctx context.Context, consentIDs []string
 	// Build dynamic query
 	query := dbmodel.DBQuery{
 		ID: QueryGetMappingsByConsentIDs.ID,
-		Query: fmt.Sprintf(`SELECT cpm.CONSENT_ID, cpm.PURPOSE_ID, cpm.ORG_ID, cpm.VALUE, cpm.IS_USER_APPROVED, cpm.IS_MANDATORY, cp.NAME
+		Query: fmt.Sprintf(`SELECT cpm.CONSENT_ID, cpm.PURPOSE_ID, cpm.ORG_ID, cpm.VALUE, cpm.IS_USER_APPROVED, cpm.IS_MANDATORY, cpm.EXPIRES_AT, cpm.LAST_CONFIRMED_AT, cp.NAME
 				FROM CONSENT_PURPOSE_MAPPING cpm
 				INNER JOIN CONSENT_PURPOSE cp ON cpm.PURPOSE_ID = cp.ID
 				WHERE cpm.CONSENT_ID IN (%s) AND cpm.ORG_ID = ?`, placeholders),
 	}
 
-	rows, err := s.dbClient.Query(query, args...)
+	rows, err := s.dbClient.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -497,7 +697,7 @@ func (s *store) GetIDsByNames(ctx context.Context, names []string, orgID string)
 		Query: query,
 	}
 
-	rows, err := s.dbClient.Query(formattedQuery, args...)
+	rows, err := s.dbClient.QueryContext(ctx, formattedQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -579,6 +779,14 @@ func mapToConsentPurposeMapping(row map[string]interface{}) *model.ConsentPurpos
 		mapping.IsMandatory = isMandatory != 0
 	}
 
+	if expiresAt, ok := row["expires_at"].(int64); ok {
+		mapping.ExpiresAt = &expiresAt
+	}
+
+	if lastConfirmedAt, ok := row["last_confirmed_at"].(int64); ok {
+		mapping.LastConfirmedAt = &lastConfirmedAt
+	}
+
 	if name, ok := row["name"].(string); ok {
 		mapping.Name = name
 	} else if name, ok := row["name"].([]byte); ok {
@@ -588,6 +796,97 @@ func mapToConsentPurposeMapping(row map[string]interface{}) *model.ConsentPurpos
 	return mapping
 }
 
+// GetLinkedConsents retrieves the consents mapped to a purpose, including soft-deleted consents
+func (s *store) GetLinkedConsents(ctx context.Context, purposeID, orgID string) ([]model.LinkedConsent, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetLinkedConsents, purposeID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	linked := make([]model.LinkedConsent, 0, len(rows))
+	for _, row := range rows {
+		var consent model.LinkedConsent
+		if consentID, ok := row["consent_id"].(string); ok {
+			consent.ConsentID = consentID
+		} else if consentID, ok := row["consent_id"].([]byte); ok {
+			consent.ConsentID = string(consentID)
+		}
+		if status, ok := row["current_status"].(string); ok {
+			consent.CurrentStatus = status
+		} else if status, ok := row["current_status"].([]byte); ok {
+			consent.CurrentStatus = string(status)
+		}
+		linked = append(linked, consent)
+	}
+	return linked, nil
+}
+
+// ListApprovals retrieves the users who approved a purpose, one entry per user and consent, ordered
+// by user ID. Deleted consents and authorizations without a user are left out.
+func (s *store) ListApprovals(ctx context.Context, filters model.PurposeApprovalFilters) ([]model.PurposeApproval, int, error) {
+	whereClause := "cpm.PURPOSE_ID = ? AND cpm.ORG_ID = ? AND cpm.IS_USER_APPROVED = ? AND c.CURRENT_STATUS <> 'DELETED' AND ar.USER_ID IS NOT NULL"
+	args := []interface{}{filters.PurposeID, filters.OrgID, true}
+	if filters.Status != "" {
+		whereClause += " AND c.CURRENT_STATUS = ?"
+		args = append(args, filters.Status)
+	}
+
+	countQuery := dbmodel.DBQuery{
+		ID:    QueryCountPurposeApprovals.ID,
+		Query: fmt.Sprintf(QueryCountPurposeApprovals.Query, whereClause),
+	}
+	countRows, err := s.dbClient.QueryContext(ctx, countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := 0
+	if len(countRows) > 0 {
+		if count, ok := countRows[0]["count"].(int64); ok {
+			total = int(count)
+		}
+	}
+
+	listQuery := dbmodel.DBQuery{
+		ID:    QueryListPurposeApprovals.ID,
+		Query: fmt.Sprintf(QueryListPurposeApprovals.Query, whereClause),
+	}
+	rows, err := s.dbClient.QueryContext(ctx, listQuery, append(args, filters.Limit, filters.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	approvals := make([]model.PurposeApproval, 0, len(rows))
+	for _, row := range rows {
+		approval := model.PurposeApproval{
+			UserID:        rowString(row, "user_id"),
+			ConsentID:     rowString(row, "consent_id"),
+			ConsentType:   rowString(row, "consent_type"),
+			ConsentStatus: rowString(row, "current_status"),
+		}
+		if updatedTime, ok := row["updated_time"].(int64); ok {
+			approval.UpdatedTime = updatedTime
+		}
+		approvals = append(approvals, approval)
+	}
+	return approvals, total, nil
+}
+
+// rowString reads a string column that the driver may return as bytes
+func rowString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+// DeleteMappingsByPurposeID detaches a purpose from all its consents within a transaction
+func (s *store) DeleteMappingsByPurposeID(tx dbmodel.TxInterface, purposeID, orgID string) error {
+	_, err := tx.Exec(QueryDeleteMappingsByPurposeID.Query, purposeID, orgID)
+	return err
+}
+
 // DeleteMappingsByConsentID deletes all consent purpose mappings for a consent within a transaction
 func (s *store) DeleteMappingsByConsentID(tx dbmodel.TxInterface, consentID, orgID string) error {
 	_, err := tx.Exec(QueryDeleteMappingsByConsentID.Query, consentID, orgID)
@@ -0,0 +1,101 @@
+package consentpurpose
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/system/database/dbtest"
+)
+
+// newTestStore returns a store holding purposes of org-1 and a purpose of the same name in org-2
+func newTestStore(t *testing.T) *store {
+	return &store{dbClient: dbtest.NewSQLiteClient(t,
+		"INSERT INTO CONSENT_PURPOSE (ID, NAME, DESCRIPTION, TYPE, ORG_ID) VALUES "+
+			"('p1', 'marketing', 'Marketing emails', 'string', 'org-1'), "+
+			"('p2', 'analytics', NULL, 'json', 'org-1'), "+
+			"('p3', 'marketing', NULL, 'string', 'org-2')",
+		"INSERT INTO CONSENT_PURPOSE_ATTRIBUTE (PURPOSE_ID, ATT_KEY, ATT_VALUE, ORG_ID) VALUES "+
+			"('p1', 'channel', 'email', 'org-1'), ('p1', 'region', 'EU', 'org-1'), ('p3', 'channel', 'sms', 'org-2')",
+	)}
+}
+
+// TestGetByNames_KeysPurposesOfTheOrganizationByName checks that purposes are looked up in one
+// query, that unknown names and other organizations are left out, and that no names query nothing
+func TestGetByNames_KeysPurposesOfTheOrganizationByName(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	purposes, err := s.GetByNames(ctx, []string{"marketing", "analytics", "unknown"}, "org-1")
+	if err != nil {
+		t.Fatalf("GetByNames failed: %v", err)
+	}
+	if len(purposes) != 2 {
+		t.Fatalf("expected the two purposes of org-1, got %+v", purposes)
+	}
+	if marketing := purposes["marketing"]; marketing.ID != "p1" || marketing.Type != "string" ||
+		marketing.Description == nil || *marketing.Description != "Marketing emails" {
+		t.Errorf("unexpected marketing purpose %+v", marketing)
+	}
+	if analytics := purposes["analytics"]; analytics.ID != "p2" || analytics.Type != "json" {
+		t.Errorf("unexpected analytics purpose %+v", analytics)
+	}
+
+	// A nil client fails the test if the store queries for no names
+	empty, err := (&store{}).GetByNames(ctx, nil, "org-1")
+	if err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("expected an empty map for no names, got %+v, %v", empty, err)
+	}
+}
+
+// TestGetAttributesByPurposeIDs_GroupsByPurpose checks that attributes of several purposes are
+// grouped by purpose ID, scoped to the organization
+func TestGetAttributesByPurposeIDs_GroupsByPurpose(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	attributes, err := s.GetAttributesByPurposeIDs(ctx, []string{"p1", "p2", "p3"}, "org-1")
+	if err != nil {
+		t.Fatalf("GetAttributesByPurposeIDs failed: %v", err)
+	}
+	if len(attributes) != 1 || len(attributes["p1"]) != 2 {
+		t.Fatalf("expected the two attributes of p1 only, got %+v", attributes)
+	}
+	values := map[string]string{}
+	for _, attribute := range attributes["p1"] {
+		values[attribute.Key] = attribute.Value
+	}
+	if !reflect.DeepEqual(values, map[string]string{"channel": "email", "region": "EU"}) {
+		t.Errorf("unexpected attributes of p1 %+v", values)
+	}
+
+	empty, err := (&store{}).GetAttributesByPurposeIDs(ctx, []string{}, "org-1")
+	if err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("expected an empty map for no purpose IDs, got %+v, %v", empty, err)
+	}
+}
+
+// TestInClauseArgs checks the placeholders and the arguments, the organization first
+func TestInClauseArgs(t *testing.T) {
+	tests := []struct {
+		name             string
+		values           []string
+		wantPlaceholders string
+		wantArgs         []interface{}
+	}{
+		{"empty", nil, "", []interface{}{"org-1"}},
+		{"single", []string{"a"}, "?", []interface{}{"org-1", "a"}},
+		{"several", []string{"a", "b", "c"}, "?, ?, ?", []interface{}{"org-1", "a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placeholders, args := inClauseArgs("org-1", tt.values)
+			if placeholders != tt.wantPlaceholders {
+				t.Errorf("expected placeholders %q, got %q", tt.wantPlaceholders, placeholders)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("expected arguments %v, got %v", tt.wantArgs, args)
+			}
+		})
+	}
+}
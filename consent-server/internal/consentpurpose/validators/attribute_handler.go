@@ -17,16 +17,18 @@ func (h *AttributePurposeTypeHandler) ValidateAttributes(attributes map[string]s
 	// resourcePath is MANDATORY
 	if path, exists := attributes["resourcePath"]; !exists || path == "" {
 		errors = append(errors, ValidationError{
-			Field:   "resourcePath",
-			Message: "resourcePath is required for attribute type",
+			Field:      "resourcePath",
+			Constraint: "required",
+			Message:    "resourcePath is required for attribute type",
 		})
 	}
 
 	// jsonPath is MANDATORY
 	if path, exists := attributes["jsonPath"]; !exists || path == "" {
 		errors = append(errors, ValidationError{
-			Field:   "jsonPath",
-			Message: "jsonPath is required for attribute type",
+			Field:      "jsonPath",
+			Constraint: "required",
+			Message:    "jsonPath is required for attribute type",
 		})
 	}
 
@@ -4,8 +4,9 @@ import "encoding/json"
 
 // ValidationError represents a single validation error for an attribute
 type ValidationError struct {
-	Field   string `json:"field"`
-	Message string `json:"message"`
+	Field      string `json:"field"`
+	Constraint string `json:"constraint"`
+	Message    string `json:"message"`
 }
 
 // PurposeAttributeSpec defines metadata about an attribute for a purpose type
@@ -18,8 +18,9 @@ func (h *JsonSchemaPurposeTypeHandler) ValidateAttributes(attributes map[string]
 	schema, exists := attributes["validationSchema"]
 	if !exists || schema == "" {
 		errors = append(errors, ValidationError{
-			Field:   "validationSchema",
-			Message: "validationSchema is required for json-schema type",
+			Field:      "validationSchema",
+			Constraint: "required",
+			Message:    "validationSchema is required for json-schema type",
 		})
 		return errors
 	}
@@ -27,8 +28,9 @@ func (h *JsonSchemaPurposeTypeHandler) ValidateAttributes(attributes map[string]
 	// Validate that validationSchema is valid JSON
 	if !isValidJSON(schema) {
 		errors = append(errors, ValidationError{
-			Field:   "validationSchema",
-			Message: "validationSchema must be valid JSON",
+			Field:      "validationSchema",
+			Constraint: "format",
+			Message:    "validationSchema must be valid JSON",
 		})
 	}
 
@@ -0,0 +1,90 @@
+package consentsync
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/consentsync/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/events"
+)
+
+// Connector pushes consent state to an external consent management or marketing platform. Sync
+// returns an error when the state could not be delivered; the event is then retried, so
+// connectors may see a state twice and must apply it idempotently.
+type Connector interface {
+	Name() string
+	Sync(ctx context.Context, state model.ConsentState) error
+}
+
+// connectorFilter limits a connector to some organizations and event types
+type connectorFilter struct {
+	orgs       []string
+	eventTypes []string
+}
+
+// matches reports whether an event is synced to the connector
+func (f connectorFilter) matches(event events.ConsentEvent) bool {
+	if len(f.orgs) > 0 && !slices.Contains(f.orgs, event.OrgID) {
+		return false
+	}
+	if len(f.eventTypes) == 0 {
+		return event.Type != events.ConsentExpiringSoon
+	}
+	return slices.Contains(f.eventTypes, string(event.Type))
+}
+
+// route is a connector with the events it receives
+type route struct {
+	connector Connector
+	filter    connectorFilter
+}
+
+var (
+	customMu sync.RWMutex
+	custom   []Connector
+)
+
+// Register adds a connector that receives the state changes of every organization, next to the
+// connectors configured under events.sync. It must be called before the server starts.
+func Register(c Connector) {
+	customMu.Lock()
+	defer customMu.Unlock()
+	custom = append(custom, c)
+}
+
+// newConnector creates a configured connector
+func newConnector(cfg config.SyncConnectorConfig) (Connector, error) {
+	switch cfg.Type {
+	case config.SyncConnectorREST:
+		return newRESTConnector(cfg), nil
+	case config.SyncConnectorTokenRevocation:
+		return newTokenRevocationConnector(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported sync connector type '%s'", cfg.Type)
+	}
+}
+
+// loadRoutes creates the configured connectors followed by the registered ones
+func loadRoutes(cfg config.ConsentSyncConfig) ([]route, error) {
+	routes := make([]route, 0, len(cfg.Connectors))
+	for _, connectorCfg := range cfg.Connectors {
+		connector, err := newConnector(connectorCfg)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route{
+			connector: connector,
+			filter:    connectorFilter{orgs: connectorCfg.Orgs, eventTypes: connectorCfg.EventTypes},
+		})
+	}
+
+	customMu.RLock()
+	defer customMu.RUnlock()
+	for _, connector := range custom {
+		routes = append(routes, route{connector: connector})
+	}
+	return routes, nil
+}
@@ -0,0 +1,27 @@
+package consentsync
+
+import (
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/events"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize wraps the event publisher so that consent state changes are also pushed to the sync
+// connectors. It must be called once the event transport is set. Nothing changes when no
+// connector is configured or registered.
+func Initialize(registry *stores.StoreRegistry) error {
+	routes, err := loadRoutes(config.Get().Events.Sync)
+	if err != nil {
+		return err
+	}
+	if len(routes) == 0 {
+		return nil
+	}
+
+	events.SetPublisher(newSyncPublisher(events.GetPublisher(), routes, registry))
+	for _, r := range routes {
+		log.GetLogger().Info("Consent sync connector enabled", log.String("connector", r.connector.Name()))
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package model
+
+// ConsentState is the state of a consent sent to sync connectors. It is read when the event is
+// delivered, so a connector always receives the latest state, even for an older event.
+type ConsentState struct {
+	EventID     string `json:"eventId"` // Repeated when a delivery is retried
+	EventType   string `json:"eventType"`
+	Timestamp   int64  `json:"timestamp"` // Time of the event, in Unix milliseconds
+	OrgID       string `json:"orgId"`
+	ConsentID   string `json:"consentId"`
+	ConsentType string `json:"consentType"`
+	ClientID    string `json:"clientId"`
+	Status      string `json:"status"`
+	// Active is true when the consent is in the organization's active status
+	Active bool `json:"active"`
+	// UserIDs are the users who authorize the consent
+	UserIDs []string `json:"userIds"`
+	// PreviousUserID is the user the consent was transferred from, on ownership transfer events
+	PreviousUserID string         `json:"previousUserId,omitempty"`
+	Purposes       []PurposeState `json:"purposes"`
+}
+
+// PurposeState is a purpose of a synced consent
+type PurposeState struct {
+	Name           string `json:"name"`
+	IsUserApproved bool   `json:"isUserApproved"`
+	IsMandatory    bool   `json:"isMandatory"`
+	// OptedIn is true when the user approved the purpose and the consent is active, that is when
+	// the purpose may be acted on. Platforms keeping suppression lists suppress the other users.
+	OptedIn bool `json:"optedIn"`
+}
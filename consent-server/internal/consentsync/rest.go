@@ -0,0 +1,80 @@
+package consentsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/consentsync/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+)
+
+// Sync request headers naming the event the state was read for
+const (
+	headerEventID   = "X-Consent-Event-ID"
+	headerEventType = "X-Consent-Event-Type"
+)
+
+// restConnector sends the consent state as JSON to an HTTP endpoint
+type restConnector struct {
+	name    string
+	url     string
+	method  string
+	headers map[string]string
+	client  *http.Client
+}
+
+// newRESTConnector creates a connector for an HTTP endpoint
+func newRESTConnector(cfg config.SyncConnectorConfig) *restConnector {
+	method := cfg.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	return &restConnector{
+		name:    cfg.Name,
+		url:     cfg.URL,
+		method:  method,
+		headers: cfg.Headers,
+		client:  &http.Client{Timeout: cfg.GetTimeout()},
+	}
+}
+
+// Name returns the configured connector name
+func (c *restConnector) Name() string {
+	return c.name
+}
+
+// Sync sends the state and expects a 2xx response
+func (c *restConnector) Sync(ctx context.Context, state model.ConsentState) error {
+	body, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, c.method, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set(constants.HeaderContentType, "application/json")
+	req.Header.Set(headerEventID, state.EventID)
+	req.Header.Set(headerEventType, state.EventType)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package consentsync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/consentsync/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+)
+
+// tokenRevocationConnector asks an authorization server to revoke the tokens issued for a consent
+// once the consent is revoked or expired, so that refresh tokens do not outlive the consent
+type tokenRevocationConnector struct {
+	name           string
+	url            string
+	consentIDParam string
+	headers        map[string]string
+	client         *http.Client
+}
+
+// newTokenRevocationConnector creates a connector for the token revocation endpoint of an
+// authorization server
+func newTokenRevocationConnector(cfg config.SyncConnectorConfig) *tokenRevocationConnector {
+	return &tokenRevocationConnector{
+		name:           cfg.Name,
+		url:            cfg.URL,
+		consentIDParam: cfg.GetConsentIDParam(),
+		headers:        cfg.Headers,
+		client:         &http.Client{Timeout: cfg.GetTimeout()},
+	}
+}
+
+// Name returns the configured connector name
+func (c *tokenRevocationConnector) Name() string {
+	return c.name
+}
+
+// Sync posts the consent ID as a form parameter when the consent is revoked or expired, and
+// expects a 2xx response. Consents in other statuses are skipped. Revoking the tokens of a consent
+// twice revokes nothing the second time, so retried events are harmless.
+func (c *tokenRevocationConnector) Sync(ctx context.Context, state model.ConsentState) error {
+	if !config.Get().Consent.ForOrg(state.OrgID).IsTerminalStatus(config.ConsentStatus(state.Status)) {
+		return nil
+	}
+
+	form := url.Values{c.consentIDParam: {state.ConsentID}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set(constants.HeaderContentType, "application/x-www-form-urlencoded")
+	req.Header.Set(headerEventID, state.EventID)
+	req.Header.Set(headerEventType, state.EventType)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package consentsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/consentsync/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/events"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// maxPartialDeliveries bounds the events remembered as delivered to some destinations only. Past
+// it, a retried event is sent again to every destination.
+const maxPartialDeliveries = 10000
+
+// primaryDestination names the wrapped publisher among the destinations of an event
+const primaryDestination = ""
+
+// syncPublisher publishes events with the wrapped publisher, then pushes the state of the consent
+// to the connectors matching the event. It implements events.Publisher, so the outbox relay
+// retries an event until every destination acknowledged it.
+type syncPublisher struct {
+	next      events.Publisher
+	routes    []route
+	readState func(ctx context.Context, event events.ConsentEvent) (*model.ConsentState, error)
+
+	mu sync.Mutex
+	// delivered holds the destinations that acknowledged an event some other destination failed,
+	// so a retry only goes to the destinations that failed
+	delivered map[string]map[string]bool
+}
+
+// newSyncPublisher creates a publisher that reads consent state from the stores
+func newSyncPublisher(next events.Publisher, routes []route, registry *stores.StoreRegistry) *syncPublisher {
+	return &syncPublisher{
+		next:      next,
+		routes:    routes,
+		readState: stateReader(registry),
+		delivered: make(map[string]map[string]bool),
+	}
+}
+
+// Publish delivers an event to the wrapped publisher and to every matching connector. Connectors
+// are only called once the wrapped publisher acknowledged the event, and a failing connector does
+// not keep the others from receiving it.
+func (p *syncPublisher) Publish(ctx context.Context, event events.ConsentEvent) error {
+	logger := log.GetLogger().WithContext(ctx).With(log.String(log.LoggerKeyComponentName, "ConsentSync"))
+
+	if !p.wasDelivered(event.ID, primaryDestination) {
+		if err := p.next.Publish(ctx, event); err != nil {
+			return err
+		}
+		p.markDelivered(event.ID, primaryDestination)
+	}
+
+	var state *model.ConsentState
+	var errs []error
+	for _, r := range p.routes {
+		name := r.connector.Name()
+		if !r.filter.matches(event) || p.wasDelivered(event.ID, name) {
+			continue
+		}
+		if state == nil {
+			var err error
+			if state, err = p.readState(ctx, event); err != nil {
+				errs = append(errs, fmt.Errorf("failed to read consent state: %w", err))
+				break
+			}
+			if state == nil {
+				// The consent was deleted since, so there is no state left to sync
+				logger.Debug("Consent of event no longer exists, skipping sync",
+					log.String("event_id", event.ID),
+					log.String("consent_id", event.ConsentID))
+				break
+			}
+		}
+
+		if err := r.connector.Sync(ctx, *state); err != nil {
+			logger.Warn("Failed to sync consent state",
+				log.Error(err),
+				log.String("connector", name),
+				log.String("event_id", event.ID),
+				log.String("consent_id", event.ConsentID))
+			errs = append(errs, fmt.Errorf("sync connector %s: %w", name, err))
+			continue
+		}
+		p.markDelivered(event.ID, name)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	p.forget(event.ID)
+	return nil
+}
+
+// Flush delivers the events queued by the wrapped publisher, if it queues events
+func (p *syncPublisher) Flush(ctx context.Context) error {
+	if flusher, ok := p.next.(events.Flusher); ok {
+		return flusher.Flush(ctx)
+	}
+	return nil
+}
+
+// wasDelivered reports whether a destination acknowledged an event that is being retried
+func (p *syncPublisher) wasDelivered(eventID, destination string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.delivered[eventID][destination]
+}
+
+// markDelivered remembers that a destination acknowledged an event
+func (p *syncPublisher) markDelivered(eventID, destination string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	destinations, ok := p.delivered[eventID]
+	if !ok {
+		if len(p.delivered) >= maxPartialDeliveries {
+			return
+		}
+		destinations = make(map[string]bool)
+		p.delivered[eventID] = destinations
+	}
+	destinations[destination] = true
+}
+
+// forget drops an event every destination acknowledged
+func (p *syncPublisher) forget(eventID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.delivered, eventID)
+}
+
+// stateReader returns a function reading the current state of the consent of an event. It returns
+// nil when the consent no longer exists.
+func stateReader(registry *stores.StoreRegistry) func(ctx context.Context, event events.ConsentEvent) (*model.ConsentState, error) {
+	return func(ctx context.Context, event events.ConsentEvent) (*model.ConsentState, error) {
+		consent, err := registry.Consent.GetByID(ctx, event.ConsentID, event.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if consent == nil {
+			return nil, nil
+		}
+		authResources, err := registry.AuthResource.GetByConsentID(ctx, event.ConsentID, event.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		mappings, err := registry.ConsentPurpose.GetMappingsByConsentID(ctx, event.ConsentID, event.OrgID)
+		if err != nil {
+			return nil, err
+		}
+
+		active := consent.CurrentStatus == string(config.Get().Consent.ForOrg(event.OrgID).GetActiveConsentStatus())
+		state := &model.ConsentState{
+			EventID:        event.ID,
+			EventType:      string(event.Type),
+			Timestamp:      event.Timestamp,
+			OrgID:          event.OrgID,
+			ConsentID:      event.ConsentID,
+			ConsentType:    consent.ConsentType,
+			ClientID:       consent.ClientID,
+			Status:         consent.CurrentStatus,
+			Active:         active,
+			UserIDs:        []string{},
+			PreviousUserID: event.PreviousUserID,
+			Purposes:       make([]model.PurposeState, 0, len(mappings)),
+		}
+		for _, authResource := range authResources {
+			if authResource.UserID != nil && *authResource.UserID != "" && !slices.Contains(state.UserIDs, *authResource.UserID) {
+				state.UserIDs = append(state.UserIDs, *authResource.UserID)
+			}
+		}
+		for _, mapping := range mappings {
+			state.Purposes = append(state.Purposes, model.PurposeState{
+				Name:           mapping.Name,
+				IsUserApproved: mapping.IsUserApproved,
+				IsMandatory:    mapping.IsMandatory,
+				OptedIn:        mapping.IsUserApproved && active,
+			})
+		}
+		return state, nil
+	}
+}
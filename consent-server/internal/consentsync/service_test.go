@@ -0,0 +1,192 @@
+package consentsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/consentsync/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/events"
+)
+
+// recordingPublisher counts the events it is given
+type recordingPublisher struct {
+	published int
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event events.ConsentEvent) error {
+	p.published++
+	return nil
+}
+
+// fakeConnector counts its syncs and fails while failures is positive
+type fakeConnector struct {
+	name     string
+	failures int
+	synced   []model.ConsentState
+}
+
+func (c *fakeConnector) Name() string { return c.name }
+
+func (c *fakeConnector) Sync(ctx context.Context, state model.ConsentState) error {
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("unavailable")
+	}
+	c.synced = append(c.synced, state)
+	return nil
+}
+
+func newTestPublisher(next events.Publisher, routes ...route) *syncPublisher {
+	return &syncPublisher{
+		next:   next,
+		routes: routes,
+		readState: func(ctx context.Context, event events.ConsentEvent) (*model.ConsentState, error) {
+			return &model.ConsentState{EventID: event.ID, ConsentID: event.ConsentID}, nil
+		},
+		delivered: make(map[string]map[string]bool),
+	}
+}
+
+// TestPublish_RetriesOnlyFailedDestinations checks that a retried event is not sent again to the
+// destinations that already acknowledged it
+func TestPublish_RetriesOnlyFailedDestinations(t *testing.T) {
+	next := &recordingPublisher{}
+	healthy := &fakeConnector{name: "healthy"}
+	flaky := &fakeConnector{name: "flaky", failures: 1}
+	publisher := newTestPublisher(next, route{connector: healthy}, route{connector: flaky})
+
+	event := events.ConsentEvent{ID: "event-1", Type: events.ConsentUpdated, OrgID: "org-1", ConsentID: "c-1"}
+	if err := publisher.Publish(context.Background(), event); err == nil {
+		t.Fatal("expected the failing connector to fail the delivery")
+	}
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+
+	if next.published != 1 || len(healthy.synced) != 1 || len(flaky.synced) != 1 {
+		t.Errorf("expected one delivery per destination, got %d, %d and %d",
+			next.published, len(healthy.synced), len(flaky.synced))
+	}
+	if len(publisher.delivered) != 0 {
+		t.Errorf("expected the delivered event to be forgotten, %d remembered", len(publisher.delivered))
+	}
+}
+
+// TestConnectorFilter_MatchesOrgsAndEventTypes checks the organization and event type filters
+func TestConnectorFilter_MatchesOrgsAndEventTypes(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter connectorFilter
+		event  events.ConsentEvent
+		want   bool
+	}{
+		{"all events by default", connectorFilter{}, events.ConsentEvent{Type: events.ConsentRevoked}, true},
+		{"no expiry notices by default", connectorFilter{}, events.ConsentEvent{Type: events.ConsentExpiringSoon}, false},
+		{"other organization", connectorFilter{orgs: []string{"org-1"}}, events.ConsentEvent{Type: events.ConsentRevoked, OrgID: "org-2"}, false},
+		{"listed event type", connectorFilter{eventTypes: []string{"consent.expiring_soon"}}, events.ConsentEvent{Type: events.ConsentExpiringSoon}, true},
+		{"unlisted event type", connectorFilter{eventTypes: []string{"consent.revoked"}}, events.ConsentEvent{Type: events.ConsentCreated}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(tt.event); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestRESTConnector_SendsState checks the request of the rest connector
+func TestRESTConnector_SendsState(t *testing.T) {
+	var received model.ConsentState
+	var header http.Header
+	var method string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, header = r.Method, r.Header
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	connector := newRESTConnector(config.SyncConnectorConfig{
+		Name:    "cmp",
+		Type:    config.SyncConnectorREST,
+		URL:     server.URL,
+		Method:  http.MethodPut,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+	state := model.ConsentState{EventID: "event-1", EventType: "consent.revoked", ConsentID: "c-1",
+		Purposes: []model.PurposeState{{Name: "marketing"}}}
+	if err := connector.Sync(context.Background(), state); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+
+	if method != http.MethodPut || header.Get("Authorization") != "Bearer token" || header.Get(headerEventID) != "event-1" {
+		t.Errorf("unexpected request: %s with headers %v", method, header)
+	}
+	if received.ConsentID != "c-1" || len(received.Purposes) != 1 {
+		t.Errorf("unexpected state received: %+v", received)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	if err := connector.Sync(context.Background(), state); err == nil {
+		t.Error("expected a 503 response to fail the sync")
+	}
+}
+
+// TestTokenRevocationConnector_RevokesEndedConsents checks that the tokens of revoked consents are
+// revoked by consent ID, and that consents still in use are skipped
+func TestTokenRevocationConnector_RevokesEndedConsents(t *testing.T) {
+	config.SetGlobal(&config.Config{Consent: config.ConsentConfig{StatusMappings: config.ConsentStatusMappings{
+		ActiveStatus:  "ACTIVE",
+		ExpiredStatus: "EXPIRED",
+		RevokedStatus: "REVOKED",
+	}}})
+	defer config.SetGlobal(nil)
+
+	var revoked []string
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		revoked = append(revoked, r.PostFormValue("consentId"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	connector := newTokenRevocationConnector(config.SyncConnectorConfig{
+		Name:           "identity-server",
+		Type:           config.SyncConnectorTokenRevocation,
+		URL:            server.URL,
+		ConsentIDParam: "consentId",
+		Headers:        map[string]string{"Authorization": "Basic YWRtaW46YWRtaW4="},
+	})
+	for _, state := range []model.ConsentState{
+		{EventID: "event-1", EventType: "consent.updated", ConsentID: "c-1", Status: "ACTIVE"},
+		{EventID: "event-2", EventType: "consent.revoked", ConsentID: "c-2", Status: "REVOKED"},
+		{EventID: "event-3", EventType: "consent.expired", ConsentID: "c-3", Status: "EXPIRED"},
+	} {
+		if err := connector.Sync(context.Background(), state); err != nil {
+			t.Fatalf("sync of %s failed: %v", state.ConsentID, err)
+		}
+	}
+
+	if len(revoked) != 2 || revoked[0] != "c-2" || revoked[1] != "c-3" {
+		t.Errorf("expected the tokens of c-2 and c-3 to be revoked, got %v", revoked)
+	}
+	if header.Get("Authorization") != "Basic YWRtaW46YWRtaW4=" || header.Get(headerEventID) != "event-3" {
+		t.Errorf("unexpected request headers %v", header)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	if err := connector.Sync(context.Background(), model.ConsentState{ConsentID: "c-2", Status: "REVOKED"}); err == nil {
+		t.Error("expected a 502 response to fail the revocation")
+	}
+}
@@ -0,0 +1,34 @@
+package errorcatalog
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/error/codes"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// errorCatalogResponse is the response body of GET /errors
+type errorCatalogResponse struct {
+	Data []codes.Definition `json:"data"`
+}
+
+// listErrorCodes handles GET /errors
+func listErrorCodes(w http.ResponseWriter, r *http.Request) {
+	utils.JSONResponse(w, http.StatusOK, errorCatalogResponse{Data: codes.Catalog()})
+}
+
+// getErrorCode handles GET /errors/{code}, which is the type URI of problem responses
+func getErrorCode(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+
+	definition, ok := codes.Lookup(code)
+	if !ok {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("error code '%s' not found", code)))
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, definition)
+}
@@ -0,0 +1,24 @@
+package errorcatalog
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+)
+
+// Initialize registers the error code catalog routes. The catalog documents the API, so it is not
+// protected by authentication.
+func Initialize(mux *http.ServeMux) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "X-Correlation-ID"},
+	}
+
+	// GET /api/v1/errors - List error codes
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.ErrorCatalogPath, listErrorCodes, corsOpts))
+
+	// GET /api/v1/errors/{code} - Get an error code
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.ErrorCatalogPath+"/{code}", getErrorCode, corsOpts))
+}
@@ -0,0 +1,20 @@
+package eventoutbox
+
+import (
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/scheduler"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize sets up the event outbox module and schedules the relay that publishes its events
+func Initialize(registry *stores.StoreRegistry) EventOutboxService {
+	service := newEventOutboxService(registry)
+
+	outboxCfg := config.Get().Events.Outbox
+	if err := scheduler.GetScheduler().Register("event-outbox-relay", outboxCfg.GetPollInterval(), service.RelayPendingEvents); err != nil {
+		log.GetLogger().Error("Failed to schedule the event outbox relay", log.Error(err))
+	}
+
+	return service
+}
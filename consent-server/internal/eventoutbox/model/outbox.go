@@ -0,0 +1,47 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/wso2/consent-management-api/internal/system/events"
+)
+
+// OutboxEvent represents the CONSENT_EVENT_OUTBOX table. It holds a consent event, written in the
+// transaction of the consent change, until the relay has published it.
+type OutboxEvent struct {
+	EventID   string
+	ConsentID string
+	EventType string
+	// Payload is the event encoded as JSON. Attributes are filtered when the event is published.
+	Payload     []byte
+	CreatedTime int64
+	// Attempts counts the failed attempts to publish the event
+	Attempts        int
+	NextAttemptTime int64
+	LastError       string
+	OrgID           string
+}
+
+// NewOutboxEvent creates the outbox entry of an event, due for publishing right away
+func NewOutboxEvent(event events.ConsentEvent) (*OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		EventID:         event.ID,
+		ConsentID:       event.ConsentID,
+		EventType:       string(event.Type),
+		Payload:         payload,
+		CreatedTime:     event.Timestamp,
+		NextAttemptTime: event.Timestamp,
+		OrgID:           event.OrgID,
+	}, nil
+}
+
+// ConsentEvent decodes the event held by the entry
+func (e *OutboxEvent) ConsentEvent() (events.ConsentEvent, error) {
+	var event events.ConsentEvent
+	err := json.Unmarshal(e.Payload, &event)
+	return event, err
+}
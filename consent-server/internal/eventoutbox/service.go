@@ -0,0 +1,114 @@
+package eventoutbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/events"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// claimLease is how long a claimed event is hidden from the relays of other server instances. An
+// event whose relay stopped before recording the outcome is published again once it expires.
+const claimLease = 5 * time.Minute
+
+// EventOutboxService defines the exported service interface for the event outbox
+type EventOutboxService interface {
+	RelayPendingEvents(ctx context.Context)
+}
+
+// eventOutboxService implements EventOutboxService
+type eventOutboxService struct {
+	stores *stores.StoreRegistry
+}
+
+// newEventOutboxService creates a new event outbox service
+func newEventOutboxService(registry *stores.StoreRegistry) *eventOutboxService {
+	return &eventOutboxService{
+		stores: registry,
+	}
+}
+
+// RelayPendingEvents publishes the due events of the outbox, oldest first, and removes them once
+// the publisher acknowledged them. An event that fails is retried with a growing delay, and the
+// later events of its consent wait for it, so the events of a consent are published in order.
+func (s *eventOutboxService) RelayPendingEvents(ctx context.Context) {
+	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "EventOutbox"))
+	outboxCfg := &config.Get().Events.Outbox
+	store := s.stores.EventOutbox
+
+	pending, err := store.GetPending(ctx, outboxCfg.GetBatchSize())
+	if err != nil {
+		logger.Error("Failed to load pending consent events", log.Error(err))
+		return
+	}
+
+	now := utils.GetCurrentTimeMillis()
+	// Consents with an earlier event that is not published yet
+	held := make(map[string]bool)
+	published := 0
+	for i := range pending {
+		outboxEvent := &pending[i]
+		consentKey := outboxEvent.OrgID + "/" + outboxEvent.ConsentID
+		if held[consentKey] || outboxEvent.NextAttemptTime > now {
+			held[consentKey] = true
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		claimed, err := store.Claim(ctx, outboxEvent.EventID, outboxEvent.OrgID, outboxEvent.NextAttemptTime,
+			now+claimLease.Milliseconds())
+		if err != nil || !claimed {
+			if err != nil {
+				logger.Error("Failed to claim consent event", log.Error(err), log.String("event_id", outboxEvent.EventID))
+			}
+			// Another node is publishing the event, or it is unknown whether it will be
+			held[consentKey] = true
+			continue
+		}
+
+		event, err := outboxEvent.ConsentEvent()
+		if err == nil {
+			err = events.Publish(ctx, event)
+		}
+		if err != nil {
+			attempts := outboxEvent.Attempts + 1
+			nextAttemptTime := now + retryDelay(outboxCfg, attempts).Milliseconds()
+			if recordErr := store.RecordFailure(ctx, outboxEvent.EventID, outboxEvent.OrgID, attempts, nextAttemptTime, err.Error()); recordErr != nil {
+				logger.Error("Failed to record consent event failure", log.Error(recordErr), log.String("event_id", outboxEvent.EventID))
+			}
+			logger.Warn("Failed to publish consent event, retrying later",
+				log.Error(err),
+				log.String("event_id", outboxEvent.EventID),
+				log.String("event_type", outboxEvent.EventType),
+				log.Int("attempts", attempts))
+			// The publisher is shared, so the remaining events wait for the next poll
+			break
+		}
+
+		if err := store.Delete(ctx, outboxEvent.EventID, outboxEvent.OrgID); err != nil {
+			// The event is published again once its claim expires
+			logger.Error("Failed to remove published consent event", log.Error(err), log.String("event_id", outboxEvent.EventID))
+		}
+		published++
+	}
+
+	if published > 0 {
+		logger.Debug("Published consent events", log.Int("published", published), log.Int("pending", len(pending)-published))
+	}
+}
+
+// retryDelay returns the delay before the next attempt to publish an event that failed attempts
+// times. It doubles with every failure, starting from the poll interval.
+func retryDelay(cfg *config.EventOutboxConfig, attempts int) time.Duration {
+	delay, maxBackoff := cfg.GetPollInterval(), cfg.GetMaxBackoff()
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,220 @@
+package eventoutbox
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/eventoutbox/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/database/dbtest"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/events"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// recordingPublisher records the IDs of the events it publishes and fails the events in failing
+type recordingPublisher struct {
+	published []string
+	failing   map[string]bool
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event events.ConsentEvent) error {
+	if p.failing[event.ID] {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, event.ID)
+	return nil
+}
+
+// newTestOutbox returns an outbox service over a test database, publishing to a recording publisher
+func newTestOutbox(t *testing.T) (*eventOutboxService, *recordingPublisher) {
+	config.SetGlobal(&config.Config{Events: config.EventsConfig{Outbox: config.EventOutboxConfig{
+		PollInterval: time.Second,
+		MaxBackoff:   time.Minute,
+	}}})
+	t.Cleanup(func() { config.SetGlobal(nil) })
+
+	publisher := &recordingPublisher{failing: make(map[string]bool)}
+	previous := events.GetPublisher()
+	events.SetPublisher(publisher)
+	t.Cleanup(func() { events.SetPublisher(previous) })
+
+	dbClient := dbtest.NewSQLiteClient(t)
+	registry := stores.NewStoreRegistry(dbClient, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		NewEventOutboxStore(dbClient))
+	return newEventOutboxService(registry), publisher
+}
+
+// addEvent records an event of the consent in the outbox, created offset milliseconds from now
+func addEvent(t *testing.T, s *eventOutboxService, eventID, consentID string, offset int64) {
+	t.Helper()
+	timestamp := utils.GetCurrentTimeMillis() + offset
+	outboxEvent, err := model.NewOutboxEvent(events.ConsentEvent{
+		ID: eventID, Type: events.ConsentRevoked, Timestamp: timestamp, OrgID: "org-1", ConsentID: consentID,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode the event: %v", err)
+	}
+	err = s.stores.ExecuteTransaction(context.Background(), []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error { return s.stores.EventOutbox.Create(tx, outboxEvent) },
+	})
+	if err != nil {
+		t.Fatalf("failed to record the event: %v", err)
+	}
+}
+
+// pendingEvents returns the events left in the outbox, keyed by ID
+func pendingEvents(t *testing.T, s *eventOutboxService) map[string]model.OutboxEvent {
+	t.Helper()
+	pending, err := s.stores.EventOutbox.GetPending(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("failed to load the outbox: %v", err)
+	}
+	byID := make(map[string]model.OutboxEvent)
+	for _, outboxEvent := range pending {
+		byID[outboxEvent.EventID] = outboxEvent
+	}
+	return byID
+}
+
+// TestRelayPendingEvents_PublishesAndRemovesEventsInOrder checks that due events are published
+// oldest first and removed once published
+func TestRelayPendingEvents_PublishesAndRemovesEventsInOrder(t *testing.T) {
+	s, publisher := newTestOutbox(t)
+	addEvent(t, s, "e2", "c1", -1000)
+	addEvent(t, s, "e1", "c1", -2000)
+	addEvent(t, s, "e3", "c2", -500)
+
+	s.RelayPendingEvents(context.Background())
+
+	if want := []string{"e1", "e2", "e3"}; !reflect.DeepEqual(publisher.published, want) {
+		t.Errorf("expected %v to be published, got %v", want, publisher.published)
+	}
+	if pending := pendingEvents(t, s); len(pending) != 0 {
+		t.Errorf("expected the published events to be removed, got %+v", pending)
+	}
+}
+
+// TestRelayPendingEvents_RetriesFailedEventsLater checks that an event that fails to publish stays
+// in the outbox with its failure recorded, and that the remaining events wait for the next poll
+func TestRelayPendingEvents_RetriesFailedEventsLater(t *testing.T) {
+	s, publisher := newTestOutbox(t)
+	addEvent(t, s, "e1", "c1", -2000)
+	addEvent(t, s, "e2", "c2", -1000)
+	publisher.failing["e1"] = true
+
+	before := utils.GetCurrentTimeMillis()
+	s.RelayPendingEvents(context.Background())
+
+	if len(publisher.published) != 0 {
+		t.Fatalf("expected nothing to be published while the publisher fails, got %v", publisher.published)
+	}
+	pending := pendingEvents(t, s)
+	failed := pending["e1"]
+	if failed.Attempts != 1 || failed.LastError != "broker unavailable" {
+		t.Errorf("expected the failure to be recorded, got %+v", failed)
+	}
+	if failed.NextAttemptTime < before+time.Second.Milliseconds() {
+		t.Errorf("expected the next attempt to be delayed by the poll interval, got %d", failed.NextAttemptTime-before)
+	}
+	if _, ok := pending["e2"]; !ok {
+		t.Error("expected the event after the failed one to be kept for the next poll")
+	}
+
+	s.RelayPendingEvents(context.Background())
+	if want := []string{"e2"}; !reflect.DeepEqual(publisher.published, want) {
+		t.Errorf("expected only the event of the other consent to be published while e1 waits, got %v", publisher.published)
+	}
+}
+
+// TestRelayPendingEvents_HoldsLaterEventsOfTheConsent checks that an event that is not due holds
+// back the later events of its consent, but not those of other consents
+func TestRelayPendingEvents_HoldsLaterEventsOfTheConsent(t *testing.T) {
+	s, publisher := newTestOutbox(t)
+	addEvent(t, s, "e1", "c1", -3000)
+	addEvent(t, s, "e2", "c1", -2000)
+	addEvent(t, s, "e3", "c2", -1000)
+
+	pending := pendingEvents(t, s)
+	claimed, err := s.stores.EventOutbox.Claim(context.Background(), "e1", "org-1",
+		pending["e1"].NextAttemptTime, utils.GetCurrentTimeMillis()+time.Minute.Milliseconds())
+	if err != nil || !claimed {
+		t.Fatalf("failed to claim the event: %v", err)
+	}
+
+	s.RelayPendingEvents(context.Background())
+
+	if want := []string{"e3"}; !reflect.DeepEqual(publisher.published, want) {
+		t.Errorf("expected %v to be published, got %v", want, publisher.published)
+	}
+	if _, ok := pendingEvents(t, s)["e2"]; !ok {
+		t.Error("expected the later event of the consent to wait for the earlier one")
+	}
+}
+
+// TestClaim_OnlyOneRelayClaimsAnEvent checks that a claim based on a stale next attempt time fails
+func TestClaim_OnlyOneRelayClaimsAnEvent(t *testing.T) {
+	s, _ := newTestOutbox(t)
+	addEvent(t, s, "e1", "c1", -1000)
+	nextAttemptTime := pendingEvents(t, s)["e1"].NextAttemptTime
+	ctx := context.Background()
+
+	claimed, err := s.stores.EventOutbox.Claim(ctx, "e1", "org-1", nextAttemptTime, nextAttemptTime+1000)
+	if err != nil || !claimed {
+		t.Fatalf("expected the first claim to succeed, got %v, %v", claimed, err)
+	}
+	claimed, err = s.stores.EventOutbox.Claim(ctx, "e1", "org-1", nextAttemptTime, nextAttemptTime+2000)
+	if err != nil || claimed {
+		t.Errorf("expected the second claim to fail, got %v, %v", claimed, err)
+	}
+}
+
+// TestCreate_RolledBackWithTheConsentChange checks that the event of a consent change that is rolled
+// back never reaches the outbox
+func TestCreate_RolledBackWithTheConsentChange(t *testing.T) {
+	s, _ := newTestOutbox(t)
+	outboxEvent, err := model.NewOutboxEvent(events.ConsentEvent{
+		ID: "e1", Type: events.ConsentRevoked, Timestamp: utils.GetCurrentTimeMillis(), OrgID: "org-1", ConsentID: "c1",
+	})
+	if err != nil {
+		t.Fatalf("failed to encode the event: %v", err)
+	}
+
+	errUpdate := errors.New("failed to update consent")
+	err = s.stores.ExecuteTransaction(context.Background(), []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error { return s.stores.EventOutbox.Create(tx, outboxEvent) },
+		func(tx dbmodel.TxInterface) error { return errUpdate },
+	})
+	if !errors.Is(err, errUpdate) {
+		t.Fatalf("expected the transaction to fail, got %v", err)
+	}
+	if pending := pendingEvents(t, s); len(pending) != 0 {
+		t.Errorf("expected the event to be rolled back, got %+v", pending)
+	}
+}
+
+// TestRetryDelay checks that the delay doubles from the poll interval up to the maximum backoff
+func TestRetryDelay(t *testing.T) {
+	cfg := &config.EventOutboxConfig{PollInterval: time.Second, MaxBackoff: 10 * time.Second}
+
+	testCases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{20, 10 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		if got := retryDelay(cfg, tc.attempts); got != tc.want {
+			t.Errorf("attempt %d: expected %v, got %v", tc.attempts, tc.want, got)
+		}
+	}
+}
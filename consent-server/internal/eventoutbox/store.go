@@ -0,0 +1,128 @@
+package eventoutbox
+
+import (
+	"context"
+
+	"github.com/wso2/consent-management-api/internal/eventoutbox/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// DBQuery objects for event outbox operations
+var (
+	QueryCreateOutboxEvent = dbmodel.DBQuery{
+		ID: "CREATE_OUTBOX_EVENT",
+		Query: `INSERT INTO CONSENT_EVENT_OUTBOX (EVENT_ID, CONSENT_ID, EVENT_TYPE, PAYLOAD, CREATED_TIME, ATTEMPTS, NEXT_ATTEMPT_TIME, ORG_ID)
+				VALUES (?, ?, ?, ?, ?, 0, ?, ?)`,
+	}
+
+	// Pending events are relayed across organizations, oldest first
+	QueryGetPendingOutboxEvents = dbmodel.DBQuery{
+		ID:          "GET_PENDING_OUTBOX_EVENTS",
+		CrossTenant: true,
+		Query:       "SELECT EVENT_ID, CONSENT_ID, EVENT_TYPE, PAYLOAD, CREATED_TIME, ATTEMPTS, NEXT_ATTEMPT_TIME, LAST_ERROR, ORG_ID FROM CONSENT_EVENT_OUTBOX ORDER BY CREATED_TIME, EVENT_ID LIMIT ?",
+	}
+
+	QueryClaimOutboxEvent = dbmodel.DBQuery{
+		ID:    "CLAIM_OUTBOX_EVENT",
+		Query: "UPDATE CONSENT_EVENT_OUTBOX SET NEXT_ATTEMPT_TIME = ? WHERE EVENT_ID = ? AND ORG_ID = ? AND NEXT_ATTEMPT_TIME = ?",
+	}
+
+	QueryRecordOutboxFailure = dbmodel.DBQuery{
+		ID:    "RECORD_OUTBOX_EVENT_FAILURE",
+		Query: "UPDATE CONSENT_EVENT_OUTBOX SET ATTEMPTS = ?, NEXT_ATTEMPT_TIME = ?, LAST_ERROR = ? WHERE EVENT_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteOutboxEvent = dbmodel.DBQuery{
+		ID:    "DELETE_OUTBOX_EVENT",
+		Query: "DELETE FROM CONSENT_EVENT_OUTBOX WHERE EVENT_ID = ? AND ORG_ID = ?",
+	}
+)
+
+// store implements the interfaces.EventOutboxStore interface
+type store struct {
+	dbClient provider.DBClientInterface
+}
+
+// NewEventOutboxStore creates a new event outbox store
+func NewEventOutboxStore(dbClient provider.DBClientInterface) interfaces.EventOutboxStore {
+	return &store{
+		dbClient: dbClient,
+	}
+}
+
+// Create records an event within the transaction of the consent change it describes
+func (s *store) Create(tx dbmodel.TxInterface, event *model.OutboxEvent) error {
+	_, err := tx.Exec(QueryCreateOutboxEvent.Query, event.EventID, event.ConsentID, event.EventType,
+		string(event.Payload), event.CreatedTime, event.NextAttemptTime, event.OrgID)
+	return err
+}
+
+// GetPending retrieves the oldest events of all organizations, including events that are not due
+// yet, so the relay can keep the events of a consent in order
+func (s *store) GetPending(ctx context.Context, limit int) ([]model.OutboxEvent, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetPendingOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	outboxEvents := make([]model.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		outboxEvents = append(outboxEvents, mapToOutboxEvent(row))
+	}
+	return outboxEvents, nil
+}
+
+// Claim moves the next attempt of an event to claimUntil, so other server instances skip it while
+// it is published. It reports false when another instance claimed the event first.
+func (s *store) Claim(ctx context.Context, eventID, orgID string, nextAttemptTime, claimUntil int64) (bool, error) {
+	rows, err := s.dbClient.ExecuteContext(ctx, QueryClaimOutboxEvent, claimUntil, eventID, orgID, nextAttemptTime)
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// RecordFailure records a failed attempt to publish an event and when to try again
+func (s *store) RecordFailure(ctx context.Context, eventID, orgID string, attempts int, nextAttemptTime int64, lastError string) error {
+	_, err := s.dbClient.ExecuteContext(ctx, QueryRecordOutboxFailure, attempts, nextAttemptTime, lastError, eventID, orgID)
+	return err
+}
+
+// Delete removes a published event
+func (s *store) Delete(ctx context.Context, eventID, orgID string) error {
+	_, err := s.dbClient.ExecuteContext(ctx, QueryDeleteOutboxEvent, eventID, orgID)
+	return err
+}
+
+// mapToOutboxEvent converts a database row map to OutboxEvent
+// Note: DBClient normalizes column names to lowercase
+func mapToOutboxEvent(row map[string]interface{}) model.OutboxEvent {
+	return model.OutboxEvent{
+		EventID:         getString(row, "event_id"),
+		ConsentID:       getString(row, "consent_id"),
+		EventType:       getString(row, "event_type"),
+		Payload:         []byte(getString(row, "payload")),
+		CreatedTime:     getInt64(row, "created_time"),
+		Attempts:        int(getInt64(row, "attempts")),
+		NextAttemptTime: getInt64(row, "next_attempt_time"),
+		LastError:       getString(row, "last_error"),
+		OrgID:           getString(row, "org_id"),
+	}
+}
+
+func getString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+func getInt64(row map[string]interface{}, key string) int64 {
+	if v, ok := row[key].(int64); ok {
+		return v
+	}
+	return 0
+}
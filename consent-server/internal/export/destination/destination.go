@@ -0,0 +1,81 @@
+// Package destination delivers export files to remote storage.
+package destination
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/export/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// Supported destination types
+const (
+	TypeS3   = "s3"
+	TypeGCS  = "gcs"
+	TypeSFTP = "sftp"
+)
+
+// Destination delivers an export file and returns the location it was written to. The file is
+// read from data until EOF while the export is being built, so a read error aborts the delivery.
+type Destination interface {
+	Deliver(ctx context.Context, objectName string, data io.Reader) (string, error)
+}
+
+// Validate checks that a destination definition has the fields required by its type
+func Validate(dest model.Destination) error {
+	if dest.CredentialID == "" {
+		return fmt.Errorf("destination credentialId is required")
+	}
+	switch dest.Type {
+	case TypeS3:
+		if dest.Bucket == "" || dest.Region == "" {
+			return fmt.Errorf("s3 destination requires bucket and region")
+		}
+	case TypeGCS:
+		if dest.Bucket == "" {
+			return fmt.Errorf("gcs destination requires bucket")
+		}
+	case TypeSFTP:
+		return validateSFTP(dest)
+	default:
+		return fmt.Errorf("unsupported destination type '%s', must be one of: s3, gcs, sftp", dest.Type)
+	}
+	return nil
+}
+
+// New creates the destination for a job using the credential referenced by the job
+func New(dest model.Destination, cred config.ExportCredential) (Destination, error) {
+	if err := Validate(dest); err != nil {
+		return nil, err
+	}
+
+	switch dest.Type {
+	case TypeS3:
+		endpoint := dest.Endpoint
+		if endpoint == "" {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", dest.Region)
+		}
+		return newObjectStorageDestination("s3", endpoint, dest.Region, dest.Bucket, dest.Path, cred)
+	case TypeGCS:
+		// Cloud Storage accepts requests signed with HMAC keys through its XML API
+		endpoint := dest.Endpoint
+		if endpoint == "" {
+			endpoint = "https://storage.googleapis.com"
+		}
+		return newObjectStorageDestination("gs", endpoint, "auto", dest.Bucket, dest.Path, cred)
+	default:
+		return newSFTPDestination(dest, cred), nil
+	}
+}
+
+// joinPath joins a path prefix and an object name with a single separator
+func joinPath(prefix, name string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return name
+	}
+	return prefix + "/" + name
+}
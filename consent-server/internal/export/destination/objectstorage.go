@@ -0,0 +1,35 @@
+package destination
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/objectstorage"
+)
+
+// objectStorageDestination uploads export files to an S3 compatible object store
+type objectStorageDestination struct {
+	scheme string
+	prefix string
+	client *objectstorage.Client
+}
+
+func newObjectStorageDestination(scheme, endpoint, region, bucket, prefix string, cred config.ExportCredential) (*objectStorageDestination, error) {
+	client, err := objectstorage.NewClient(endpoint, region, bucket, cred.AccessKeyID, cred.SecretAccessKey, 5*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	return &objectStorageDestination{scheme: scheme, prefix: prefix, client: client}, nil
+}
+
+// Deliver streams the data to a single object
+func (d *objectStorageDestination) Deliver(ctx context.Context, objectName string, data io.Reader) (string, error) {
+	key := joinPath(d.prefix, objectName)
+	if err := d.client.Upload(ctx, key, data, ""); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s://%s/%s", d.scheme, d.client.Bucket(), key), nil
+}
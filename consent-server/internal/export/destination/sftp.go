@@ -0,0 +1,137 @@
+package destination
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/wso2/consent-management-api/internal/export/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+var (
+	// sftpHostPattern matches DNS host names. IP addresses are checked separately.
+	sftpHostPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?$`)
+	// sftpUsernamePattern matches portable user names, which cannot start with '-' and so cannot
+	// be taken for an sftp option
+	sftpUsernamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+)
+
+// sftpDestination uploads export files with the system sftp client in batch mode.
+// Host keys are always verified against the configured known_hosts file.
+type sftpDestination struct {
+	host       string
+	port       int
+	username   string
+	path       string
+	credential config.ExportCredential
+}
+
+func newSFTPDestination(dest model.Destination, cred config.ExportCredential) *sftpDestination {
+	port := dest.Port
+	if port == 0 {
+		port = 22
+	}
+	return &sftpDestination{
+		host:       dest.Host,
+		port:       port,
+		username:   dest.Username,
+		path:       dest.Path,
+		credential: cred,
+	}
+}
+
+// validateSFTP checks the fields of an sftp destination that end up in the sftp command line or
+// batch file
+func validateSFTP(dest model.Destination) error {
+	if dest.Host == "" || dest.Username == "" {
+		return fmt.Errorf("sftp destination requires host and username")
+	}
+	if net.ParseIP(dest.Host) == nil && !sftpHostPattern.MatchString(dest.Host) {
+		return fmt.Errorf("invalid sftp host: %q", dest.Host)
+	}
+	if !sftpUsernamePattern.MatchString(dest.Username) {
+		return fmt.Errorf("invalid sftp username: %q", dest.Username)
+	}
+	if dest.Port < 0 || dest.Port > 65535 {
+		return fmt.Errorf("invalid sftp port: %d", dest.Port)
+	}
+	if strings.IndexFunc(dest.Path, unicode.IsControl) >= 0 {
+		return fmt.Errorf("sftp path must not contain control characters")
+	}
+	return nil
+}
+
+// Deliver copies the data to a temporary file and uploads it with sftp
+func (d *sftpDestination) Deliver(ctx context.Context, objectName string, data io.Reader) (string, error) {
+	if d.credential.PrivateKeyFile == "" || d.credential.KnownHostsFile == "" {
+		return "", fmt.Errorf("sftp credential requires private_key_file and known_hosts_file")
+	}
+
+	tmpFile, err := os.CreateTemp("", "consent-export-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary export file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := io.Copy(tmpFile, data); err != nil {
+		tmpFile.Close()
+		return "", fmt.Errorf("failed to write temporary export file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary export file: %w", err)
+	}
+
+	remotePath := joinPath(d.path, objectName)
+	if strings.HasPrefix(d.path, "/") {
+		remotePath = "/" + remotePath
+	}
+
+	cmd, err := d.command(ctx, tmpFile.Name(), remotePath)
+	if err != nil {
+		return "", err
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("sftp upload failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return fmt.Sprintf("sftp://%s@%s:%d/%s", d.username, d.host, d.port, strings.TrimPrefix(remotePath, "/")), nil
+}
+
+// command builds the sftp invocation that uploads a local file to a remote path. The destination
+// is validated again as jobs stored before validation was tightened may still hold unsafe values,
+// and "--" ends the options so the destination is never read as one.
+func (d *sftpDestination) command(ctx context.Context, localPath, remotePath string) (*exec.Cmd, error) {
+	if err := validateSFTP(model.Destination{Host: d.host, Username: d.username, Port: d.port, Path: remotePath}); err != nil {
+		return nil, err
+	}
+	if strings.IndexFunc(localPath, unicode.IsControl) >= 0 {
+		return nil, fmt.Errorf("sftp local path must not contain control characters")
+	}
+
+	cmd := exec.CommandContext(ctx, "sftp",
+		"-b", "-",
+		"-P", strconv.Itoa(d.port),
+		"-i", d.credential.PrivateKeyFile,
+		"-o", "BatchMode=yes",
+		"-o", "StrictHostKeyChecking=yes",
+		"-o", "UserKnownHostsFile="+d.credential.KnownHostsFile,
+		"--",
+		d.username+"@"+d.host,
+	)
+	cmd.Stdin = strings.NewReader("put " + quoteSFTPArg(localPath) + " " + quoteSFTPArg(remotePath) + "\n")
+	return cmd, nil
+}
+
+// quoteSFTPArg quotes an argument of an sftp batch command. Within double quotes sftp only treats
+// backslash and double quote specially, and both are escaped with a backslash.
+func quoteSFTPArg(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
@@ -0,0 +1,82 @@
+package destination
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/export/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// TestValidate_SFTP checks that hosts, usernames and paths that could be read as sftp options or
+// batch commands are rejected
+func TestValidate_SFTP(t *testing.T) {
+	valid := model.Destination{Type: TypeSFTP, CredentialID: "sftp-1", Host: "sftp.example.com",
+		Username: "export_user", Path: "/exports/consents"}
+	if err := Validate(valid); err != nil {
+		t.Fatalf("expected a valid destination, got %v", err)
+	}
+
+	for _, host := range []string{"10.0.0.5", "::1", "localhost"} {
+		dest := valid
+		dest.Host = host
+		if err := Validate(dest); err != nil {
+			t.Errorf("expected host %q to be valid, got %v", host, err)
+		}
+	}
+
+	for name, mutate := range map[string]func(*model.Destination){
+		"missing host":           func(d *model.Destination) { d.Host = "" },
+		"option as host":         func(d *model.Destination) { d.Host = "-oProxyCommand=sh" },
+		"host with user":         func(d *model.Destination) { d.Host = "root@sftp.example.com" },
+		"host with space":        func(d *model.Destination) { d.Host = "sftp.example.com -v" },
+		"option as username":     func(d *model.Destination) { d.Username = "-oProxyCommand=sh" },
+		"username with host":     func(d *model.Destination) { d.Username = "root@evil.example.com" },
+		"port out of range":      func(d *model.Destination) { d.Port = 70000 },
+		"path with newline":      func(d *model.Destination) { d.Path = "/exports\n!sh" },
+		"path with carriage ret": func(d *model.Destination) { d.Path = "/exports\r" },
+	} {
+		dest := valid
+		mutate(&dest)
+		if err := Validate(dest); err == nil {
+			t.Errorf("%s: expected the destination to be rejected", name)
+		}
+	}
+}
+
+// TestSFTPCommand checks the sftp arguments and the quoting of the batch command
+func TestSFTPCommand(t *testing.T) {
+	d := newSFTPDestination(model.Destination{Host: "sftp.example.com", Username: "export_user"},
+		config.ExportCredential{PrivateKeyFile: "/keys/id_ed25519", KnownHostsFile: "/keys/known_hosts"})
+
+	cmd, err := d.command(context.Background(), "/tmp/consent-export-1", `/exports/a "b" \c*.jsonl`)
+	if err != nil {
+		t.Fatalf("failed to build the command: %v", err)
+	}
+
+	expectedArgs := []string{"sftp", "-b", "-", "-P", "22", "-i", "/keys/id_ed25519",
+		"-o", "BatchMode=yes", "-o", "StrictHostKeyChecking=yes", "-o", "UserKnownHostsFile=/keys/known_hosts",
+		"--", "export_user@sftp.example.com"}
+	if !slices.Equal(cmd.Args, expectedArgs) {
+		t.Errorf("expected arguments %q, got %q", expectedArgs, cmd.Args)
+	}
+
+	batch, _ := io.ReadAll(cmd.Stdin)
+	expectedBatch := `put "/tmp/consent-export-1" "/exports/a \"b\" \\c*.jsonl"` + "\n"
+	if string(batch) != expectedBatch {
+		t.Errorf("expected batch %q, got %q", expectedBatch, batch)
+	}
+
+	if _, err := d.command(context.Background(), "/tmp/consent-export-1", "/exports/a.jsonl\nrm /"); err == nil ||
+		!strings.Contains(err.Error(), "control characters") {
+		t.Errorf("expected a remote path with a newline to be rejected, got %v", err)
+	}
+
+	d.username = "-oProxyCommand=sh"
+	if _, err := d.command(context.Background(), "/tmp/consent-export-1", "/exports/a.jsonl"); err == nil {
+		t.Error("expected an unsafe username stored before validation to be rejected")
+	}
+}
@@ -0,0 +1,190 @@
+package export
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/wso2/consent-management-api/internal/export/model"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// exportHandler handles HTTP requests for scheduled export jobs
+type exportHandler struct {
+	service ExportService
+}
+
+// newExportHandler creates a new export handler
+func newExportHandler(service ExportService) *exportHandler {
+	return &exportHandler{
+		service: service,
+	}
+}
+
+// createJob handles POST /admin/export-jobs
+func (h *exportHandler) createJob(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.ExportJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid request body"))
+		return
+	}
+
+	job, serviceErr := h.service.CreateJob(r.Context(), req, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, job)
+}
+
+// getJob handles GET /admin/export-jobs/{jobId}
+func (h *exportHandler) getJob(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	job, serviceErr := h.service.GetJob(r.Context(), r.PathValue("jobId"), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, job)
+}
+
+// listJobs handles GET /admin/export-jobs
+func (h *exportHandler) listJobs(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	limit, offset := parsePagination(r)
+	jobs, total, serviceErr := h.service.ListJobs(r.Context(), orgID, limit, offset)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, model.ExportJobListResponse{
+		Data: jobs,
+		Metadata: model.PaginationMetadata{
+			Total:  total,
+			Limit:  limit,
+			Offset: offset,
+			Count:  len(jobs),
+		},
+	})
+}
+
+// updateJob handles PUT /admin/export-jobs/{jobId}
+func (h *exportHandler) updateJob(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.ExportJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid request body"))
+		return
+	}
+
+	job, serviceErr := h.service.UpdateJob(r.Context(), r.PathValue("jobId"), req, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, job)
+}
+
+// deleteJob handles DELETE /admin/export-jobs/{jobId}
+func (h *exportHandler) deleteJob(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.DeleteJob(r.Context(), r.PathValue("jobId"), orgID); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// runJob handles POST /admin/export-jobs/{jobId}/run
+func (h *exportHandler) runJob(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	receipt, serviceErr := h.service.RunJob(r.Context(), r.PathValue("jobId"), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, receipt)
+}
+
+// listReceipts handles GET /admin/export-jobs/{jobId}/receipts
+func (h *exportHandler) listReceipts(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	limit, offset := parsePagination(r)
+	receipts, total, serviceErr := h.service.ListReceipts(r.Context(), r.PathValue("jobId"), orgID, limit, offset)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, model.DeliveryReceiptListResponse{
+		Data: receipts,
+		Metadata: model.PaginationMetadata{
+			Total:  total,
+			Limit:  limit,
+			Offset: offset,
+			Count:  len(receipts),
+		},
+	})
+}
+
+// parsePagination reads limit and offset query parameters, ignoring invalid values
+func parsePagination(r *http.Request) (int, int) {
+	limit := 20
+	offset := 0
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return limit, offset
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/scheduler"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize sets up the export module, registers routes and schedules due export jobs
+func Initialize(mux *http.ServeMux, registry *stores.StoreRegistry) ExportService {
+	service := newExportService(registry)
+	handler := newExportHandler(service)
+
+	registerRoutes(mux, handler)
+
+	exportCfg := config.Get().Export
+	if exportCfg.Enabled {
+		if err := scheduler.GetScheduler().Register("consent-export", exportCfg.PollInterval, service.RunDueJobs); err != nil {
+			log.GetLogger().Error("Failed to schedule consent export jobs", log.Error(err))
+		}
+	}
+
+	return service
+}
+
+// registerRoutes registers all export job admin routes. Admin routes are protected with admin basic auth.
+func registerRoutes(mux *http.ServeMux, handler *exportHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "org-id", "X-Correlation-ID"},
+	}
+
+	// POST /api/v1/admin/export-jobs - Create export job
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/admin/export-jobs",
+		middleware.WithAdminAuth(handler.createJob), corsOpts))
+
+	// GET /api/v1/admin/export-jobs - List export jobs
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/export-jobs",
+		middleware.WithAdminAuth(handler.listJobs), corsOpts))
+
+	// GET /api/v1/admin/export-jobs/{jobId} - Get export job
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/export-jobs/{jobId}",
+		middleware.WithAdminAuth(handler.getJob), corsOpts))
+
+	// PUT /api/v1/admin/export-jobs/{jobId} - Update export job
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/admin/export-jobs/{jobId}",
+		middleware.WithAdminAuth(handler.updateJob), corsOpts))
+
+	// DELETE /api/v1/admin/export-jobs/{jobId} - Delete export job
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/admin/export-jobs/{jobId}",
+		middleware.WithAdminAuth(handler.deleteJob), corsOpts))
+
+	// POST /api/v1/admin/export-jobs/{jobId}/run - Run export job now
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/admin/export-jobs/{jobId}/run",
+		middleware.WithAdminAuth(handler.runJob), corsOpts))
+
+	// GET /api/v1/admin/export-jobs/{jobId}/receipts - List delivery receipts
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/export-jobs/{jobId}/receipts",
+		middleware.WithAdminAuth(handler.listReceipts), corsOpts))
+}
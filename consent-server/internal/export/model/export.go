@@ -0,0 +1,135 @@
+package model
+
+import (
+	consentmodel "github.com/wso2/consent-management-api/internal/consent/model"
+)
+
+// Export file formats
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// Delivery receipt statuses
+const (
+	ReceiptStatusSuccess = "SUCCESS"
+	ReceiptStatusFailed  = "FAILED"
+)
+
+// ExportJob represents the EXPORT_JOB table
+type ExportJob struct {
+	JobID           string      `json:"id"`
+	OrgID           string      `json:"orgId"`
+	Name            string      `json:"name"`
+	Filter          Filter      `json:"filter"`
+	Format          string      `json:"format"`
+	Destination     Destination `json:"destination"`
+	EncryptionKeyID *string     `json:"encryptionKeyId,omitempty"`
+	IntervalSeconds int64       `json:"intervalSeconds"`
+	Enabled         bool        `json:"enabled"`
+	NextRunTime     int64       `json:"nextRunTime"`
+	LastRunTime     *int64      `json:"lastRunTime,omitempty"`
+	CreatedTime     int64       `json:"createdTime"`
+	UpdatedTime     int64       `json:"updatedTime"`
+}
+
+// Filter selects the consents included in an export
+type Filter struct {
+	ConsentTypes    []string `json:"consentTypes,omitempty"`
+	ConsentStatuses []string `json:"consentStatuses,omitempty"`
+	ClientIDs       []string `json:"clientIds,omitempty"`
+	UserIDs         []string `json:"userIds,omitempty"`
+	FromTime        *int64   `json:"fromTime,omitempty"`
+	ToTime          *int64   `json:"toTime,omitempty"`
+}
+
+// ToSearchFilters converts the export filter to consent search filters for the given page
+func (f Filter) ToSearchFilters(orgID string, limit, offset int) consentmodel.ConsentSearchFilters {
+	return consentmodel.ConsentSearchFilters{
+		ConsentTypes:    f.ConsentTypes,
+		ConsentStatuses: f.ConsentStatuses,
+		ClientIDs:       f.ClientIDs,
+		UserIDs:         f.UserIDs,
+		FromTime:        f.FromTime,
+		ToTime:          f.ToTime,
+		Limit:           limit,
+		Offset:          offset,
+		OrgID:           orgID,
+	}
+}
+
+// Destination describes where an export file is delivered.
+// Secrets are not stored with the job; CredentialID refers to an entry in the export configuration.
+type Destination struct {
+	Type         string `json:"type"`
+	Bucket       string `json:"bucket,omitempty"`
+	Region       string `json:"region,omitempty"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	Host         string `json:"host,omitempty"`
+	Port         int    `json:"port,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Path         string `json:"path,omitempty"`
+	CredentialID string `json:"credentialId"`
+}
+
+// DeliveryReceipt represents the EXPORT_DELIVERY_RECEIPT table
+type DeliveryReceipt struct {
+	ReceiptID      string  `json:"id"`
+	JobID          string  `json:"jobId"`
+	OrgID          string  `json:"orgId"`
+	Status         string  `json:"status"`
+	StartedTime    int64   `json:"startedTime"`
+	CompletedTime  int64   `json:"completedTime"`
+	RecordCount    int     `json:"recordCount"`
+	ObjectLocation *string `json:"objectLocation,omitempty"`
+	Checksum       *string `json:"checksum,omitempty"`
+	ErrorMessage   *string `json:"errorMessage,omitempty"`
+}
+
+// ExportJobRequest represents the API payload for creating or updating an export job
+type ExportJobRequest struct {
+	Name            string      `json:"name"`
+	Filter          Filter      `json:"filter"`
+	Format          string      `json:"format"`
+	Destination     Destination `json:"destination"`
+	EncryptionKeyID *string     `json:"encryptionKeyId,omitempty"`
+	IntervalSeconds int64       `json:"intervalSeconds"`
+	StartTime       *int64      `json:"startTime,omitempty"`
+	Enabled         *bool       `json:"enabled,omitempty"`
+}
+
+// ExportJobListResponse represents the response for listing export jobs
+type ExportJobListResponse struct {
+	Data     []ExportJob        `json:"data"`
+	Metadata PaginationMetadata `json:"metadata"`
+}
+
+// DeliveryReceiptListResponse represents the response for listing delivery receipts
+type DeliveryReceiptListResponse struct {
+	Data     []DeliveryReceipt  `json:"data"`
+	Metadata PaginationMetadata `json:"metadata"`
+}
+
+// PaginationMetadata represents pagination metadata
+type PaginationMetadata struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
+
+// ExportRecord is a single consent row written to an export file
+type ExportRecord struct {
+	consentmodel.Consent
+	Attributes     map[string]string     `json:"attributes,omitempty"`
+	Authorizations []ExportAuthorization `json:"authorizations,omitempty"`
+}
+
+// ExportAuthorization is an authorization resource written to an export file
+type ExportAuthorization struct {
+	AuthID      string `json:"authId"`
+	AuthType    string `json:"authType"`
+	UserID      string `json:"userId,omitempty"`
+	AuthStatus  string `json:"authStatus"`
+	UpdatedTime int64  `json:"updatedTime"`
+}
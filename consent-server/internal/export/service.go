@@ -0,0 +1,490 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/export/destination"
+	"github.com/wso2/consent-management-api/internal/export/model"
+	"github.com/wso2/consent-management-api/internal/system/clock"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/codes"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// errExportNotRead stops building an export the destination no longer reads
+var errExportNotRead = errors.New("export delivery stopped")
+
+const (
+	minIntervalSeconds = 60
+	defaultBatchSize   = 500
+	dueJobsPerPoll     = 50
+)
+
+// ExportService defines the exported service interface for scheduled consent exports
+type ExportService interface {
+	CreateJob(ctx context.Context, req model.ExportJobRequest, orgID string) (*model.ExportJob, *serviceerror.ServiceError)
+	GetJob(ctx context.Context, jobID, orgID string) (*model.ExportJob, *serviceerror.ServiceError)
+	ListJobs(ctx context.Context, orgID string, limit, offset int) ([]model.ExportJob, int, *serviceerror.ServiceError)
+	UpdateJob(ctx context.Context, jobID string, req model.ExportJobRequest, orgID string) (*model.ExportJob, *serviceerror.ServiceError)
+	DeleteJob(ctx context.Context, jobID, orgID string) *serviceerror.ServiceError
+	RunJob(ctx context.Context, jobID, orgID string) (*model.DeliveryReceipt, *serviceerror.ServiceError)
+	ListReceipts(ctx context.Context, jobID, orgID string, limit, offset int) ([]model.DeliveryReceipt, int, *serviceerror.ServiceError)
+	RunDueJobs(ctx context.Context)
+}
+
+// exportService implements the ExportService interface
+type exportService struct {
+	stores *stores.StoreRegistry
+}
+
+// newExportService creates a new export service
+func newExportService(registry *stores.StoreRegistry) ExportService {
+	return &exportService{
+		stores: registry,
+	}
+}
+
+// CreateJob creates a new scheduled export job
+func (s *exportService) CreateJob(ctx context.Context, req model.ExportJobRequest, orgID string) (*model.ExportJob, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := validateJobRequest(req); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.stores.ExportJob.CheckNameExists(ctx, req.Name, orgID)
+	if err != nil {
+		logger.Error("Failed to check export job name existence", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to check name existence: %v", err))
+	}
+	if exists {
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError, fmt.Sprintf("export job with name '%s' already exists", req.Name))
+	}
+
+	now := utils.GetCurrentTimeMillis()
+	job := &model.ExportJob{
+		JobID:           utils.GenerateUUID(),
+		OrgID:           orgID,
+		Name:            req.Name,
+		Filter:          req.Filter,
+		Format:          req.Format,
+		Destination:     req.Destination,
+		EncryptionKeyID: req.EncryptionKeyID,
+		IntervalSeconds: req.IntervalSeconds,
+		Enabled:         req.Enabled == nil || *req.Enabled,
+		NextRunTime:     initialRunTime(req, now),
+		CreatedTime:     now,
+		UpdatedTime:     now,
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.ExportJob.Create(tx, job)
+		},
+	}); err != nil {
+		logger.Error("Failed to create export job", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to create export job: %v", err))
+	}
+
+	logger.Info("Export job created",
+		log.String("job_id", job.JobID),
+		log.String("org_id", orgID),
+		log.String("destination_type", job.Destination.Type))
+	return job, nil
+}
+
+// GetJob retrieves an export job
+func (s *exportService) GetJob(ctx context.Context, jobID, orgID string) (*model.ExportJob, *serviceerror.ServiceError) {
+	job, err := s.stores.ExportJob.GetByID(ctx, jobID, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve export job: %v", err))
+	}
+	if job == nil {
+		return nil, exportJobNotFound(jobID)
+	}
+	return job, nil
+}
+
+// ListJobs retrieves paginated export jobs for an organization
+func (s *exportService) ListJobs(ctx context.Context, orgID string, limit, offset int) ([]model.ExportJob, int, *serviceerror.ServiceError) {
+	jobs, total, err := s.stores.ExportJob.List(ctx, orgID, limit, offset)
+	if err != nil {
+		return nil, 0, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to list export jobs: %v", err))
+	}
+	return jobs, total, nil
+}
+
+// UpdateJob replaces the definition of an export job
+func (s *exportService) UpdateJob(ctx context.Context, jobID string, req model.ExportJobRequest, orgID string) (*model.ExportJob, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := validateJobRequest(req); err != nil {
+		return nil, err
+	}
+
+	job, serviceErr := s.GetJob(ctx, jobID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if req.Name != job.Name {
+		exists, err := s.stores.ExportJob.CheckNameExists(ctx, req.Name, orgID)
+		if err != nil {
+			return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to check name existence: %v", err))
+		}
+		if exists {
+			return nil, serviceerror.CustomServiceError(serviceerror.ConflictError, fmt.Sprintf("export job with name '%s' already exists", req.Name))
+		}
+	}
+
+	now := utils.GetCurrentTimeMillis()
+	if req.StartTime != nil || req.IntervalSeconds != job.IntervalSeconds {
+		job.NextRunTime = initialRunTime(req, now)
+	}
+	job.Name = req.Name
+	job.Filter = req.Filter
+	job.Format = req.Format
+	job.Destination = req.Destination
+	job.EncryptionKeyID = req.EncryptionKeyID
+	job.IntervalSeconds = req.IntervalSeconds
+	if req.Enabled != nil {
+		job.Enabled = *req.Enabled
+	}
+	job.UpdatedTime = now
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.ExportJob.Update(tx, job)
+		},
+	}); err != nil {
+		logger.Error("Failed to update export job", log.Error(err), log.String("job_id", jobID))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to update export job: %v", err))
+	}
+
+	logger.Info("Export job updated", log.String("job_id", jobID), log.String("org_id", orgID))
+	return job, nil
+}
+
+// DeleteJob deletes an export job along with its delivery receipts
+func (s *exportService) DeleteJob(ctx context.Context, jobID, orgID string) *serviceerror.ServiceError {
+	if _, serviceErr := s.GetJob(ctx, jobID, orgID); serviceErr != nil {
+		return serviceErr
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.ExportJob.Delete(tx, jobID, orgID)
+		},
+	}); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to delete export job: %v", err))
+	}
+
+	log.GetLogger().WithContext(ctx).Info("Export job deleted", log.String("job_id", jobID), log.String("org_id", orgID))
+	return nil
+}
+
+// RunJob executes an export job immediately without changing its schedule
+func (s *exportService) RunJob(ctx context.Context, jobID, orgID string) (*model.DeliveryReceipt, *serviceerror.ServiceError) {
+	job, serviceErr := s.GetJob(ctx, jobID, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	receipt, err := s.executeJob(ctx, job)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to record delivery receipt: %v", err))
+	}
+	return receipt, nil
+}
+
+// ListReceipts retrieves paginated delivery receipts for a job
+func (s *exportService) ListReceipts(ctx context.Context, jobID, orgID string, limit, offset int) ([]model.DeliveryReceipt, int, *serviceerror.ServiceError) {
+	if _, serviceErr := s.GetJob(ctx, jobID, orgID); serviceErr != nil {
+		return nil, 0, serviceErr
+	}
+
+	receipts, total, err := s.stores.ExportJob.ListReceipts(ctx, jobID, orgID, limit, offset)
+	if err != nil {
+		return nil, 0, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to list delivery receipts: %v", err))
+	}
+	return receipts, total, nil
+}
+
+// RunDueJobs executes every enabled job whose next run time has passed. It is invoked by the scheduler.
+func (s *exportService) RunDueJobs(ctx context.Context) {
+	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "ExportScheduler"))
+
+	now := utils.GetCurrentTimeMillis()
+	jobs, err := s.stores.ExportJob.GetDueJobs(ctx, now, dueJobsPerPoll)
+	if err != nil {
+		logger.Error("Failed to load due export jobs", log.Error(err))
+		return
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		claimed, err := s.stores.ExportJob.ClaimRun(ctx, job.JobID, job.OrgID, job.NextRunTime, nextRunTime(job, now))
+		if err != nil {
+			logger.Error("Failed to claim export job run", log.Error(err), log.String("job_id", job.JobID))
+			continue
+		}
+		if !claimed {
+			// Another node already picked up this run
+			continue
+		}
+
+		if _, err := s.executeJob(ctx, job); err != nil {
+			logger.Error("Failed to record export delivery receipt", log.Error(err), log.String("job_id", job.JobID))
+		}
+	}
+}
+
+// executeJob builds the export file, delivers it and records a delivery receipt.
+// Export and delivery failures are captured in the receipt; only a failure to persist
+// the receipt is returned as an error.
+func (s *exportService) executeJob(ctx context.Context, job *model.ExportJob) (*model.DeliveryReceipt, error) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	started := clock.Now().UTC()
+	receipt := &model.DeliveryReceipt{
+		ReceiptID:   utils.GenerateUUID(),
+		JobID:       job.JobID,
+		OrgID:       job.OrgID,
+		StartedTime: utils.TimeToMillis(started),
+	}
+
+	location, checksum, count, err := s.deliver(ctx, job, started)
+	receipt.RecordCount = count
+	receipt.CompletedTime = utils.GetCurrentTimeMillis()
+	if err != nil {
+		errMsg := err.Error()
+		receipt.Status = model.ReceiptStatusFailed
+		receipt.ErrorMessage = &errMsg
+		logger.Error("Export job delivery failed", log.Error(err), log.String("job_id", job.JobID), log.String("org_id", job.OrgID))
+	} else {
+		receipt.Status = model.ReceiptStatusSuccess
+		receipt.ObjectLocation = &location
+		receipt.Checksum = &checksum
+		logger.Info("Export job delivered",
+			log.String("job_id", job.JobID),
+			log.String("org_id", job.OrgID),
+			log.String("location", location),
+			log.Int("record_count", count))
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.ExportJob.CreateReceipt(tx, receipt)
+		},
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.ExportJob.UpdateLastRunTime(tx, job.JobID, job.OrgID, receipt.StartedTime)
+		},
+	}); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// deliver exports the consents selected by the job and streams the file to its destination as it
+// is built. Returns the delivered location, the SHA-256 checksum of the delivered bytes and the
+// record count.
+func (s *exportService) deliver(ctx context.Context, job *model.ExportJob, started time.Time) (string, string, int, error) {
+	exportCfg := config.Get().Export
+
+	cred, ok := exportCfg.Credentials[job.Destination.CredentialID]
+	if !ok {
+		return "", "", 0, fmt.Errorf("credential '%s' is not configured", job.Destination.CredentialID)
+	}
+	dest, err := destination.New(job.Destination, cred)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	reader, writer := io.Pipe()
+	hash := sha256.New()
+	var out io.WriteCloser = nopCloser{io.MultiWriter(writer, hash)}
+
+	objectName := fmt.Sprintf("consent-export-%s-%s.%s", job.JobID, started.Format("20060102T150405Z"), job.Format)
+	if job.EncryptionKeyID != nil && *job.EncryptionKeyID != "" {
+		key, ok := exportCfg.EncryptionKeys[*job.EncryptionKeyID]
+		if !ok {
+			return "", "", 0, fmt.Errorf("encryption key '%s' is not configured", *job.EncryptionKeyID)
+		}
+		if out, err = newSealWriter(key, out); err != nil {
+			return "", "", 0, fmt.Errorf("failed to encrypt export: %w", err)
+		}
+		objectName += ".enc"
+	}
+
+	type buildResult struct {
+		count int
+		err   error
+	}
+	built := make(chan buildResult, 1)
+	go func() {
+		count, err := s.buildExport(ctx, job, exportCfg.BatchSize, out)
+		if err == nil {
+			err = out.Close()
+		}
+		// A failed build fails the delivery reading the export
+		writer.CloseWithError(err)
+		built <- buildResult{count: count, err: err}
+	}()
+
+	location, err := dest.Deliver(ctx, objectName, reader)
+	// Stops the build when the destination gave up before reading the whole export
+	reader.CloseWithError(errExportNotRead)
+	result := <-built
+
+	if result.err != nil && !errors.Is(result.err, errExportNotRead) {
+		return "", "", result.count, fmt.Errorf("failed to build export: %w", result.err)
+	}
+	if err != nil {
+		return "", "", result.count, err
+	}
+	if result.err != nil {
+		return "", "", result.count, fmt.Errorf("destination did not read the whole export")
+	}
+	return location, hex.EncodeToString(hash.Sum(nil)), result.count, nil
+}
+
+// buildExport pages through the consents matching the job filter and serializes them to out.
+// Returns the number of records written.
+func (s *exportService) buildExport(ctx context.Context, job *model.ExportJob, batchSize int, out io.Writer) (int, error) {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	writer, err := newRecordWriter(job.Format, out)
+	if err != nil {
+		return 0, err
+	}
+
+	for offset := 0; ; offset += batchSize {
+		consents, total, err := s.stores.Consent.Search(ctx, job.Filter.ToSearchFilters(job.OrgID, batchSize, offset))
+		if err != nil {
+			return writer.count, err
+		}
+		if len(consents) == 0 {
+			break
+		}
+
+		consentIDs := make([]string, 0, len(consents))
+		for _, c := range consents {
+			consentIDs = append(consentIDs, c.ConsentID)
+		}
+
+		attributes, err := s.stores.Consent.GetAttributesByConsentIDs(ctx, consentIDs, job.OrgID)
+		if err != nil {
+			return writer.count, err
+		}
+		authResources, err := s.stores.AuthResource.GetByConsentIDs(ctx, consentIDs, job.OrgID)
+		if err != nil {
+			return writer.count, err
+		}
+		authsByConsent := make(map[string][]model.ExportAuthorization)
+		for _, ar := range authResources {
+			auth := model.ExportAuthorization{
+				AuthID:      ar.AuthID,
+				AuthType:    ar.AuthType,
+				AuthStatus:  ar.AuthStatus,
+				UpdatedTime: ar.UpdatedTime,
+			}
+			if ar.UserID != nil {
+				auth.UserID = *ar.UserID
+			}
+			authsByConsent[ar.ConsentID] = append(authsByConsent[ar.ConsentID], auth)
+		}
+
+		for _, c := range consents {
+			if err := writer.Write(model.ExportRecord{
+				Consent:        c,
+				Attributes:     attributes[c.ConsentID],
+				Authorizations: authsByConsent[c.ConsentID],
+			}); err != nil {
+				return writer.count, err
+			}
+		}
+
+		if offset+len(consents) >= total {
+			break
+		}
+	}
+
+	return writer.count, writer.Flush()
+}
+
+// nopCloser adds a Close that does nothing to a writer
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// validateJobRequest validates an export job create or update request
+func validateJobRequest(req model.ExportJobRequest) *serviceerror.ServiceError {
+	if req.Name == "" {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, "name is required")
+	}
+	if len(req.Name) > 255 {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, "name must not exceed 255 characters")
+	}
+	if req.Format != model.FormatCSV && req.Format != model.FormatNDJSON {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, "format must be one of: csv, ndjson")
+	}
+	if req.IntervalSeconds < minIntervalSeconds {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("intervalSeconds must be at least %d", minIntervalSeconds))
+	}
+	if err := destination.Validate(req.Destination); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError, err.Error())
+	}
+
+	exportCfg := config.Get().Export
+	if _, ok := exportCfg.Credentials[req.Destination.CredentialID]; !ok {
+		return serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("credential '%s' is not configured", req.Destination.CredentialID))
+	}
+	if req.EncryptionKeyID != nil && *req.EncryptionKeyID != "" {
+		if _, ok := exportCfg.EncryptionKeys[*req.EncryptionKeyID]; !ok {
+			return serviceerror.CustomServiceError(serviceerror.ValidationError,
+				fmt.Sprintf("encryption key '%s' is not configured", *req.EncryptionKeyID))
+		}
+	}
+	return nil
+}
+
+// initialRunTime returns the first run time for a job, honouring an explicit start time
+func initialRunTime(req model.ExportJobRequest, now int64) int64 {
+	if req.StartTime != nil {
+		return *req.StartTime
+	}
+	return now + req.IntervalSeconds*1000
+}
+
+// nextRunTime advances the schedule past now, skipping runs missed while the server was down
+func nextRunTime(job *model.ExportJob, now int64) int64 {
+	interval := job.IntervalSeconds * 1000
+	next := job.NextRunTime + interval
+	if next <= now {
+		next += ((now-next)/interval + 1) * interval
+	}
+	return next
+}
+
+func exportJobNotFound(jobID string) *serviceerror.ServiceError {
+	return serviceerror.NewServiceError(codes.ExportJobNotFound, serviceerror.ClientErrorType,
+		"Export Job Not Found", fmt.Sprintf("export job '%s' not found", jobID))
+}
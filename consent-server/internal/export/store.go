@@ -0,0 +1,344 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/wso2/consent-management-api/internal/export/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// DBQuery objects for export job operations
+var (
+	QueryCreateExportJob = dbmodel.DBQuery{
+		ID:    "CREATE_EXPORT_JOB",
+		Query: "INSERT INTO EXPORT_JOB (JOB_ID, ORG_ID, NAME, FILTER, FORMAT, DESTINATION, ENCRYPTION_KEY_ID, INTERVAL_SECONDS, ENABLED, NEXT_RUN_TIME, LAST_RUN_TIME, CREATED_TIME, UPDATED_TIME) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryGetExportJobByID = dbmodel.DBQuery{
+		ID:    "GET_EXPORT_JOB_BY_ID",
+		Query: "SELECT JOB_ID, ORG_ID, NAME, FILTER, FORMAT, DESTINATION, ENCRYPTION_KEY_ID, INTERVAL_SECONDS, ENABLED, NEXT_RUN_TIME, LAST_RUN_TIME, CREATED_TIME, UPDATED_TIME FROM EXPORT_JOB WHERE JOB_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryListExportJobs = dbmodel.DBQuery{
+		ID:    "LIST_EXPORT_JOBS",
+		Query: "SELECT JOB_ID, ORG_ID, NAME, FILTER, FORMAT, DESTINATION, ENCRYPTION_KEY_ID, INTERVAL_SECONDS, ENABLED, NEXT_RUN_TIME, LAST_RUN_TIME, CREATED_TIME, UPDATED_TIME FROM EXPORT_JOB WHERE ORG_ID = ? ORDER BY NAME LIMIT ? OFFSET ?",
+	}
+
+	QueryCountExportJobs = dbmodel.DBQuery{
+		ID:    "COUNT_EXPORT_JOBS",
+		Query: "SELECT COUNT(*) as count FROM EXPORT_JOB WHERE ORG_ID = ?",
+	}
+
+	QueryCheckExportJobNameExists = dbmodel.DBQuery{
+		ID:    "CHECK_EXPORT_JOB_NAME_EXISTS",
+		Query: "SELECT COUNT(*) as count FROM EXPORT_JOB WHERE NAME = ? AND ORG_ID = ?",
+	}
+
+	// Due jobs are polled across organizations
+	QueryGetDueExportJobs = dbmodel.DBQuery{
+		ID:          "GET_DUE_EXPORT_JOBS",
+		CrossTenant: true,
+		Query:       "SELECT JOB_ID, ORG_ID, NAME, FILTER, FORMAT, DESTINATION, ENCRYPTION_KEY_ID, INTERVAL_SECONDS, ENABLED, NEXT_RUN_TIME, LAST_RUN_TIME, CREATED_TIME, UPDATED_TIME FROM EXPORT_JOB WHERE ENABLED = TRUE AND NEXT_RUN_TIME <= ? ORDER BY NEXT_RUN_TIME LIMIT ?",
+	}
+
+	QueryClaimExportJobRun = dbmodel.DBQuery{
+		ID:    "CLAIM_EXPORT_JOB_RUN",
+		Query: "UPDATE EXPORT_JOB SET NEXT_RUN_TIME = ? WHERE JOB_ID = ? AND ORG_ID = ? AND NEXT_RUN_TIME = ?",
+	}
+
+	QueryUpdateExportJob = dbmodel.DBQuery{
+		ID:    "UPDATE_EXPORT_JOB",
+		Query: "UPDATE EXPORT_JOB SET NAME = ?, FILTER = ?, FORMAT = ?, DESTINATION = ?, ENCRYPTION_KEY_ID = ?, INTERVAL_SECONDS = ?, ENABLED = ?, NEXT_RUN_TIME = ?, UPDATED_TIME = ? WHERE JOB_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryUpdateExportJobLastRunTime = dbmodel.DBQuery{
+		ID:    "UPDATE_EXPORT_JOB_LAST_RUN_TIME",
+		Query: "UPDATE EXPORT_JOB SET LAST_RUN_TIME = ? WHERE JOB_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteExportJob = dbmodel.DBQuery{
+		ID:    "DELETE_EXPORT_JOB",
+		Query: "DELETE FROM EXPORT_JOB WHERE JOB_ID = ? AND ORG_ID = ?",
+	}
+
+	QueryCreateDeliveryReceipt = dbmodel.DBQuery{
+		ID:    "CREATE_EXPORT_DELIVERY_RECEIPT",
+		Query: "INSERT INTO EXPORT_DELIVERY_RECEIPT (RECEIPT_ID, JOB_ID, ORG_ID, STATUS, STARTED_TIME, COMPLETED_TIME, RECORD_COUNT, OBJECT_LOCATION, CHECKSUM, ERROR_MESSAGE) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+	}
+
+	QueryListDeliveryReceipts = dbmodel.DBQuery{
+		ID:    "LIST_EXPORT_DELIVERY_RECEIPTS",
+		Query: "SELECT RECEIPT_ID, JOB_ID, ORG_ID, STATUS, STARTED_TIME, COMPLETED_TIME, RECORD_COUNT, OBJECT_LOCATION, CHECKSUM, ERROR_MESSAGE FROM EXPORT_DELIVERY_RECEIPT WHERE JOB_ID = ? AND ORG_ID = ? ORDER BY STARTED_TIME DESC LIMIT ? OFFSET ?",
+	}
+
+	QueryCountDeliveryReceipts = dbmodel.DBQuery{
+		ID:    "COUNT_EXPORT_DELIVERY_RECEIPTS",
+		Query: "SELECT COUNT(*) as count FROM EXPORT_DELIVERY_RECEIPT WHERE JOB_ID = ? AND ORG_ID = ?",
+	}
+)
+
+// store implements the interfaces.ExportJobStore interface
+type store struct {
+	dbClient provider.DBClientInterface
+}
+
+// NewExportJobStore creates a new export job store
+func NewExportJobStore(dbClient provider.DBClientInterface) interfaces.ExportJobStore {
+	return &store{
+		dbClient: dbClient,
+	}
+}
+
+// Create creates a new export job within a transaction
+func (s *store) Create(tx dbmodel.TxInterface, job *model.ExportJob) error {
+	filter, destination, err := marshalJobColumns(job)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(QueryCreateExportJob.Query,
+		job.JobID, job.OrgID, job.Name, filter, job.Format, destination, job.EncryptionKeyID,
+		job.IntervalSeconds, job.Enabled, job.NextRunTime, job.LastRunTime, job.CreatedTime, job.UpdatedTime)
+	return err
+}
+
+// GetByID retrieves an export job by ID
+func (s *store) GetByID(ctx context.Context, jobID, orgID string) (*model.ExportJob, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetExportJobByID, jobID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return mapToExportJob(rows[0])
+}
+
+// List retrieves paginated export jobs for an organization
+func (s *store) List(ctx context.Context, orgID string, limit, offset int) ([]model.ExportJob, int, error) {
+	countRows, err := s.dbClient.QueryContext(ctx, QueryCountExportJobs, orgID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount := 0
+	if len(countRows) > 0 {
+		if count, ok := countRows[0]["count"].(int64); ok {
+			totalCount = int(count)
+		}
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, QueryListExportJobs, orgID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs, err := mapToExportJobs(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return jobs, totalCount, nil
+}
+
+// CheckNameExists checks whether an export job with the given name exists in the organization
+func (s *store) CheckNameExists(ctx context.Context, name, orgID string) (bool, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryCheckExportJobNameExists, name, orgID)
+	if err != nil {
+		return false, err
+	}
+	if len(rows) > 0 {
+		if count, ok := rows[0]["count"].(int64); ok {
+			return count > 0, nil
+		}
+	}
+	return false, nil
+}
+
+// GetDueJobs retrieves enabled export jobs across all organizations whose next run time has passed
+func (s *store) GetDueJobs(ctx context.Context, now int64, limit int) ([]model.ExportJob, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetDueExportJobs, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return mapToExportJobs(rows)
+}
+
+// ClaimRun moves the next run time of a job forward only if it still matches the expected value.
+// Returns true when this caller won the claim, which prevents concurrent server nodes from
+// running the same job twice.
+func (s *store) ClaimRun(ctx context.Context, jobID, orgID string, expectedNextRun, nextRun int64) (bool, error) {
+	rowsAffected, err := s.dbClient.ExecuteContext(ctx, QueryClaimExportJobRun, nextRun, jobID, orgID, expectedNextRun)
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// Update updates an export job within a transaction
+func (s *store) Update(tx dbmodel.TxInterface, job *model.ExportJob) error {
+	filter, destination, err := marshalJobColumns(job)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(QueryUpdateExportJob.Query,
+		job.Name, filter, job.Format, destination, job.EncryptionKeyID, job.IntervalSeconds,
+		job.Enabled, job.NextRunTime, job.UpdatedTime, job.JobID, job.OrgID)
+	return err
+}
+
+// UpdateLastRunTime records the time of the latest run within a transaction
+func (s *store) UpdateLastRunTime(tx dbmodel.TxInterface, jobID, orgID string, lastRunTime int64) error {
+	_, err := tx.Exec(QueryUpdateExportJobLastRunTime.Query, lastRunTime, jobID, orgID)
+	return err
+}
+
+// Delete deletes an export job and its receipts within a transaction
+func (s *store) Delete(tx dbmodel.TxInterface, jobID, orgID string) error {
+	_, err := tx.Exec(QueryDeleteExportJob.Query, jobID, orgID)
+	return err
+}
+
+// CreateReceipt creates a delivery receipt within a transaction
+func (s *store) CreateReceipt(tx dbmodel.TxInterface, receipt *model.DeliveryReceipt) error {
+	_, err := tx.Exec(QueryCreateDeliveryReceipt.Query,
+		receipt.ReceiptID, receipt.JobID, receipt.OrgID, receipt.Status, receipt.StartedTime,
+		receipt.CompletedTime, receipt.RecordCount, receipt.ObjectLocation, receipt.Checksum, receipt.ErrorMessage)
+	return err
+}
+
+// ListReceipts retrieves paginated delivery receipts for a job, newest first
+func (s *store) ListReceipts(ctx context.Context, jobID, orgID string, limit, offset int) ([]model.DeliveryReceipt, int, error) {
+	countRows, err := s.dbClient.QueryContext(ctx, QueryCountDeliveryReceipts, jobID, orgID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount := 0
+	if len(countRows) > 0 {
+		if count, ok := countRows[0]["count"].(int64); ok {
+			totalCount = int(count)
+		}
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, QueryListDeliveryReceipts, jobID, orgID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	receipts := make([]model.DeliveryReceipt, 0, len(rows))
+	for _, row := range rows {
+		receipts = append(receipts, *mapToDeliveryReceipt(row))
+	}
+	return receipts, totalCount, nil
+}
+
+// marshalJobColumns serializes the JSON columns of an export job
+func marshalJobColumns(job *model.ExportJob) (string, string, error) {
+	filter, err := json.Marshal(job.Filter)
+	if err != nil {
+		return "", "", err
+	}
+	destination, err := json.Marshal(job.Destination)
+	if err != nil {
+		return "", "", err
+	}
+	return string(filter), string(destination), nil
+}
+
+// Mapper functions
+
+func mapToExportJobs(rows []map[string]interface{}) ([]model.ExportJob, error) {
+	jobs := make([]model.ExportJob, 0, len(rows))
+	for _, row := range rows {
+		job, err := mapToExportJob(row)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, nil
+}
+
+// mapToExportJob converts a database row map to ExportJob
+// Note: DBClient normalizes column names to lowercase
+func mapToExportJob(row map[string]interface{}) (*model.ExportJob, error) {
+	job := &model.ExportJob{
+		JobID:           getString(row, "job_id"),
+		OrgID:           getString(row, "org_id"),
+		Name:            getString(row, "name"),
+		Format:          getString(row, "format"),
+		EncryptionKeyID: getStringPtr(row, "encryption_key_id"),
+		IntervalSeconds: getInt64(row, "interval_seconds"),
+		NextRunTime:     getInt64(row, "next_run_time"),
+		LastRunTime:     getInt64Ptr(row, "last_run_time"),
+		CreatedTime:     getInt64(row, "created_time"),
+		UpdatedTime:     getInt64(row, "updated_time"),
+	}
+
+	if enabled, ok := row["enabled"].(bool); ok {
+		job.Enabled = enabled
+	} else if enabled, ok := row["enabled"].(int64); ok {
+		job.Enabled = enabled != 0
+	}
+
+	if filter := getString(row, "filter"); filter != "" {
+		if err := json.Unmarshal([]byte(filter), &job.Filter); err != nil {
+			return nil, err
+		}
+	}
+	if destination := getString(row, "destination"); destination != "" {
+		if err := json.Unmarshal([]byte(destination), &job.Destination); err != nil {
+			return nil, err
+		}
+	}
+	return job, nil
+}
+
+// mapToDeliveryReceipt converts a database row map to DeliveryReceipt
+func mapToDeliveryReceipt(row map[string]interface{}) *model.DeliveryReceipt {
+	return &model.DeliveryReceipt{
+		ReceiptID:      getString(row, "receipt_id"),
+		JobID:          getString(row, "job_id"),
+		OrgID:          getString(row, "org_id"),
+		Status:         getString(row, "status"),
+		StartedTime:    getInt64(row, "started_time"),
+		CompletedTime:  getInt64(row, "completed_time"),
+		RecordCount:    int(getInt64(row, "record_count")),
+		ObjectLocation: getStringPtr(row, "object_location"),
+		Checksum:       getStringPtr(row, "checksum"),
+		ErrorMessage:   getStringPtr(row, "error_message"),
+	}
+}
+
+func getString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+func getStringPtr(row map[string]interface{}, key string) *string {
+	if row[key] == nil {
+		return nil
+	}
+	v := getString(row, key)
+	return &v
+}
+
+func getInt64(row map[string]interface{}, key string) int64 {
+	if v, ok := row[key].(int64); ok {
+		return v
+	}
+	return 0
+}
+
+func getInt64Ptr(row map[string]interface{}, key string) *int64 {
+	if v, ok := row[key].(int64); ok {
+		return &v
+	}
+	return nil
+}
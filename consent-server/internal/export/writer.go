@@ -0,0 +1,172 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/export/model"
+)
+
+// csvHeader lists the columns written to CSV exports
+var csvHeader = []string{
+	"consentId", "clientId", "consentType", "currentStatus", "createdTime", "updatedTime",
+	"validityTime", "frequency", "recurringIndicator", "dataAccessValidityDuration",
+	"userIds", "attributes",
+}
+
+// recordWriter serializes export records to an output stream
+type recordWriter struct {
+	format string
+	out    *bufio.Writer
+	csv    *csv.Writer
+	count  int
+}
+
+// newRecordWriter creates a writer for the given export format
+func newRecordWriter(format string, out io.Writer) (*recordWriter, error) {
+	w := &recordWriter{format: format, out: bufio.NewWriter(out)}
+	switch format {
+	case model.FormatCSV:
+		w.csv = csv.NewWriter(w.out)
+		if err := w.csv.Write(csvHeader); err != nil {
+			return nil, err
+		}
+	case model.FormatNDJSON:
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+	return w, nil
+}
+
+// Write appends a record to the export
+func (w *recordWriter) Write(record model.ExportRecord) error {
+	w.count++
+	if w.format == model.FormatNDJSON {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if _, err := w.out.Write(line); err != nil {
+			return err
+		}
+		return w.out.WriteByte('\n')
+	}
+
+	attributes := ""
+	if len(record.Attributes) > 0 {
+		attrJSON, err := json.Marshal(record.Attributes)
+		if err != nil {
+			return err
+		}
+		attributes = string(attrJSON)
+	}
+
+	userIDs := make([]string, 0, len(record.Authorizations))
+	for _, auth := range record.Authorizations {
+		if auth.UserID != "" {
+			userIDs = append(userIDs, auth.UserID)
+		}
+	}
+
+	return w.csv.Write([]string{
+		record.ConsentID,
+		record.ClientID,
+		record.ConsentType,
+		record.CurrentStatus,
+		strconv.FormatInt(record.CreatedTime, 10),
+		strconv.FormatInt(record.UpdatedTime, 10),
+		formatInt64Ptr(record.ValidityTime),
+		formatIntPtr(record.ConsentFrequency),
+		formatBoolPtr(record.RecurringIndicator),
+		formatInt64Ptr(record.DataAccessValidityDuration),
+		strings.Join(userIDs, ";"),
+		attributes,
+	})
+}
+
+// Flush writes pending output to the stream
+func (w *recordWriter) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return w.out.Flush()
+}
+
+// sealWriter encrypts an export with AES-256-GCM. The output is the random nonce followed by the
+// sealed data, written when the writer is closed: a GCM message is authenticated as a whole, so
+// the export is held in memory until then.
+type sealWriter struct {
+	aead cipher.AEAD
+	out  io.Writer
+	buf  bytes.Buffer
+}
+
+// newSealWriter creates a writer that encrypts to out with a base64 encoded 32 byte key
+func newSealWriter(encodedKey string, out io.Writer) (*sealWriter, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key encoding: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &sealWriter{aead: aead, out: out}, nil
+}
+
+// Write adds data to the export
+func (w *sealWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Close encrypts the export and writes it out
+func (w *sealWriter) Close() error {
+	nonce := make([]byte, w.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	_, err := w.out.Write(w.aead.Seal(nonce, nonce, w.buf.Bytes(), nil))
+	return err
+}
+
+func formatInt64Ptr(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func formatIntPtr(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatBoolPtr(v *bool) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatBool(*v)
+}
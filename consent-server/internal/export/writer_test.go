@@ -0,0 +1,61 @@
+package export
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/export/model"
+)
+
+// TestSealWriter_EncryptsTheStreamedExport checks that an export written through the seal writer
+// decrypts to the records written
+func TestSealWriter_EncryptsTheStreamedExport(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	var out bytes.Buffer
+	seal, err := newSealWriter(base64.StdEncoding.EncodeToString(key), &out)
+	if err != nil {
+		t.Fatalf("failed to create the seal writer: %v", err)
+	}
+	writer, err := newRecordWriter(model.FormatCSV, seal)
+	if err != nil {
+		t.Fatalf("failed to create the record writer: %v", err)
+	}
+	var record model.ExportRecord
+	record.ConsentID = "c1"
+	if err := writer.Write(record); err != nil {
+		t.Fatalf("failed to write the record: %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("failed to flush the export: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatal("expected nothing to be written before the export is sealed")
+	}
+	if err := seal.Close(); err != nil {
+		t.Fatalf("failed to seal the export: %v", err)
+	}
+
+	block, _ := aes.NewCipher(key)
+	aead, _ := cipher.NewGCM(block)
+	sealed := out.Bytes()
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		t.Fatalf("failed to decrypt the export: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(plain)), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], "c1,") {
+		t.Errorf("expected the header and the record, got %q", plain)
+	}
+}
+
+// TestNewSealWriter_RejectsInvalidKeys checks that keys must be base64 encoded 32 byte keys
+func TestNewSealWriter_RejectsInvalidKeys(t *testing.T) {
+	for _, key := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, err := newSealWriter(key, &bytes.Buffer{}); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+	}
+}
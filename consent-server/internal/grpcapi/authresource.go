@@ -0,0 +1,135 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/wso2/consent-management-api/internal/authresource"
+	"github.com/wso2/consent-management-api/internal/authresource/model"
+	"github.com/wso2/consent-management-api/internal/grpcapi/pb/consentv1"
+)
+
+// authResourceServer implements the AuthResourceService gRPC API on top of the auth resource service
+type authResourceServer struct {
+	consentv1.UnimplementedAuthResourceServiceServer
+	service authresource.AuthResourceServiceInterface
+}
+
+// newAuthResourceServer creates a new auth resource gRPC server
+func newAuthResourceServer(service authresource.AuthResourceServiceInterface) *authResourceServer {
+	return &authResourceServer{
+		service: service,
+	}
+}
+
+// CreateAuthResource creates an authorization resource for a consent
+func (s *authResourceServer) CreateAuthResource(ctx context.Context, req *consentv1.CreateAuthResourceRequest) (*consentv1.Authorization, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetConsentId() == "" {
+		return nil, invalidArgument("consent ID is required")
+	}
+	if req.GetAuthorization() == nil {
+		return nil, invalidArgument("authorization is required")
+	}
+
+	input := req.GetAuthorization()
+	createReq := &model.CreateRequest{
+		AuthType:   input.GetType(),
+		AuthStatus: input.GetStatus(),
+		Resources:  fromValue(input.GetResources()),
+	}
+	if input.GetUserId() != "" {
+		userID := input.GetUserId()
+		createReq.UserID = &userID
+	}
+
+	resp, serviceErr := s.service.CreateAuthResource(ctx, req.GetConsentId(), orgID, createReq)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+	return toAuthorization(resp)
+}
+
+// GetAuthResource returns an authorization resource by ID
+func (s *authResourceServer) GetAuthResource(ctx context.Context, req *consentv1.GetAuthResourceRequest) (*consentv1.Authorization, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetConsentId() == "" || req.GetAuthorizationId() == "" {
+		return nil, invalidArgument("consent ID and auth ID are required")
+	}
+
+	resp, serviceErr := s.service.GetAuthResource(ctx, req.GetAuthorizationId(), orgID)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+	return toAuthorization(resp)
+}
+
+// ListAuthResources returns all authorization resources of a consent
+func (s *authResourceServer) ListAuthResources(ctx context.Context, req *consentv1.ListAuthResourcesRequest) (*consentv1.ListAuthResourcesResponse, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetConsentId() == "" {
+		return nil, invalidArgument("consent ID is required")
+	}
+
+	listResp, serviceErr := s.service.GetAuthResourcesByConsentID(ctx, req.GetConsentId(), orgID)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+
+	data := make([]*consentv1.Authorization, 0, len(listResp.Data))
+	for i := range listResp.Data {
+		authorization, err := toAuthorization(&listResp.Data[i])
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, authorization)
+	}
+	return &consentv1.ListAuthResourcesResponse{Data: data}, nil
+}
+
+// UpdateAuthResource updates an authorization resource
+func (s *authResourceServer) UpdateAuthResource(ctx context.Context, req *consentv1.UpdateAuthResourceRequest) (*consentv1.Authorization, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetConsentId() == "" || req.GetAuthorizationId() == "" {
+		return nil, invalidArgument("consent ID and auth ID are required")
+	}
+
+	updateReq := &model.UpdateRequest{
+		AuthStatus: req.GetStatus(),
+		UserID:     req.UserId,
+		Resources:  fromValue(req.GetResources()),
+	}
+
+	resp, serviceErr := s.service.UpdateAuthResource(ctx, req.GetAuthorizationId(), orgID, updateReq)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+	return toAuthorization(resp)
+}
+
+// toAuthorization converts an auth resource response to its gRPC representation
+func toAuthorization(resp *model.Response) (*consentv1.Authorization, error) {
+	resources, err := toValue(resp.Resources)
+	if err != nil {
+		return nil, conversionError(err)
+	}
+	return &consentv1.Authorization{
+		Id:          resp.AuthID,
+		UserId:      resp.UserID,
+		Type:        resp.AuthType,
+		Status:      resp.AuthStatus,
+		UpdatedTime: resp.UpdatedTime,
+		Resources:   resources,
+	}, nil
+}
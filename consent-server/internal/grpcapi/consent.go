@@ -0,0 +1,418 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/wso2/consent-management-api/internal/consent"
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/grpcapi/pb/consentv1"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+const (
+	defaultConsentSearchLimit = 10
+)
+
+// consentServer implements the ConsentService gRPC API on top of the consent service
+type consentServer struct {
+	consentv1.UnimplementedConsentServiceServer
+	service consent.ConsentService
+}
+
+// newConsentServer creates a new consent gRPC server
+func newConsentServer(service consent.ConsentService) *consentServer {
+	return &consentServer{
+		service: service,
+	}
+}
+
+// CreateConsent creates a consent for the calling client
+func (s *consentServer) CreateConsent(ctx context.Context, req *consentv1.CreateConsentRequest) (*consentv1.Consent, error) {
+	orgID, clientID, err := requireOrgAndClientID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apiReq := model.ConsentAPIRequest{
+		Type:                       req.GetType(),
+		ValidityTime:               req.ValidityTime,
+		RecurringIndicator:         req.RecurringIndicator,
+		Frequency:                  int32PtrToIntPtr(req.Frequency),
+		DataAccessValidityDuration: req.DataAccessValidityDuration,
+		ConsentPurpose:             fromPurposeItems(req.GetConsentPurpose()),
+		Attributes:                 req.GetAttributes(),
+		Authorizations:             fromAuthorizationInputs(req.GetAuthorizations()),
+	}
+
+	consentResp, serviceErr := s.service.CreateConsent(ctx, apiReq, clientID, orgID)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+	return toConsent(consentResp.ToAPIResponse())
+}
+
+// GetConsent returns a consent by ID
+func (s *consentServer) GetConsent(ctx context.Context, req *consentv1.GetConsentRequest) (*consentv1.Consent, error) {
+	orgID, _, err := requireOrgAndClientID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateConsentID(req.GetConsentId()); err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+
+	consentResp, serviceErr := s.service.GetConsent(ctx, req.GetConsentId(), orgID)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+	return toConsent(consentResp.ToAPIResponse())
+}
+
+// SearchConsents returns a page of consents matching the filters
+func (s *consentServer) SearchConsents(ctx context.Context, req *consentv1.SearchConsentsRequest) (*consentv1.SearchConsentsResponse, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := defaultConsentSearchLimit
+	if req.GetLimit() > 0 {
+		limit = int(req.GetLimit())
+	}
+	offset := 0
+	if req.GetOffset() > 0 {
+		offset = int(req.GetOffset())
+	}
+
+	filters := model.ConsentSearchFilters{
+		ConsentTypes:    req.GetConsentTypes(),
+		ConsentStatuses: req.GetConsentStatuses(),
+		ClientIDs:       req.GetClientIds(),
+		UserIDs:         req.GetUserIds(),
+		FromTime:        req.FromTime,
+		ToTime:          req.ToTime,
+		Limit:           limit,
+		Offset:          offset,
+		OrgID:           orgID,
+	}
+	if req.Cursor != nil {
+		filters.CursorMode = true
+		if token := req.GetCursor(); token != "" {
+			cursor, err := model.DecodeSearchCursor(token)
+			if err != nil {
+				return nil, invalidArgument("invalid cursor")
+			}
+			filters.Cursor = cursor
+		}
+	}
+	if req.IncludeTotal != nil && !req.GetIncludeTotal() {
+		filters.SkipTotal = true
+	}
+
+	searchResp, serviceErr := s.service.SearchConsentsDetailed(ctx, filters)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+
+	data := make([]*consentv1.Consent, 0, len(searchResp.Data))
+	for i := range searchResp.Data {
+		c, err := toConsentFromDetail(&searchResp.Data[i])
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, c)
+	}
+
+	metadata := &consentv1.PaginationMetadata{
+		Limit:      int32(searchResp.Metadata.Limit),
+		Offset:     int32(searchResp.Metadata.Offset),
+		Count:      int32(searchResp.Metadata.Count),
+		NextCursor: searchResp.Metadata.NextCursor,
+		HasMore:    searchResp.Metadata.HasMore,
+	}
+	if searchResp.Metadata.Total != nil {
+		metadata.Total = int32(*searchResp.Metadata.Total)
+	}
+
+	return &consentv1.SearchConsentsResponse{
+		Data:     data,
+		Metadata: metadata,
+	}, nil
+}
+
+// UpdateConsent updates a consent. List fields that are not set are left unchanged.
+func (s *consentServer) UpdateConsent(ctx context.Context, req *consentv1.UpdateConsentRequest) (*consentv1.Consent, error) {
+	orgID, _, err := requireOrgAndClientID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateConsentID(req.GetConsentId()); err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+
+	apiReq := model.ConsentAPIUpdateRequest{
+		Type:                       req.GetType(),
+		ValidityTime:               req.ValidityTime,
+		RecurringIndicator:         req.RecurringIndicator,
+		Frequency:                  int32PtrToIntPtr(req.Frequency),
+		DataAccessValidityDuration: req.DataAccessValidityDuration,
+	}
+	if req.ConsentPurpose != nil {
+		apiReq.ConsentPurpose = fromPurposeItems(req.ConsentPurpose.GetItems())
+		if apiReq.ConsentPurpose == nil {
+			apiReq.ConsentPurpose = []model.ConsentPurposeItem{}
+		}
+	}
+	if req.Attributes != nil {
+		apiReq.Attributes = req.Attributes.GetItems()
+		if apiReq.Attributes == nil {
+			apiReq.Attributes = map[string]string{}
+		}
+	}
+	if req.Authorizations != nil {
+		apiReq.Authorizations = fromAuthorizationInputs(req.Authorizations.GetItems())
+		if apiReq.Authorizations == nil {
+			apiReq.Authorizations = []model.AuthorizationAPIRequest{}
+		}
+	}
+
+	consentResp, serviceErr := s.service.UpdateConsent(ctx, apiReq, orgID, req.GetConsentId())
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+	return toConsent(consentResp.ToAPIResponse())
+}
+
+// RevokeConsent revokes a consent
+func (s *consentServer) RevokeConsent(ctx context.Context, req *consentv1.RevokeConsentRequest) (*consentv1.RevokeConsentResponse, error) {
+	orgID, _, err := requireOrgAndClientID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateConsentID(req.GetConsentId()); err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+
+	revokeReq := model.ConsentRevokeRequest{
+		ActionBy:         req.GetActionBy(),
+		RevocationReason: req.GetRevocationReason(),
+	}
+	revokeResp, serviceErr := s.service.RevokeConsent(ctx, req.GetConsentId(), orgID, revokeReq)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+
+	return &consentv1.RevokeConsentResponse{
+		ActionTime:       revokeResp.ActionTime,
+		ActionBy:         revokeResp.ActionBy,
+		RevocationReason: revokeResp.RevocationReason,
+	}, nil
+}
+
+// ValidateConsent checks whether a consent allows the requested access
+func (s *consentServer) ValidateConsent(ctx context.Context, req *consentv1.ValidateConsentRequest) (*consentv1.ValidateConsentResponse, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	validateReq := model.ValidateRequest{
+		Headers:         fromStruct(req.GetHeaders()),
+		Payload:         fromStruct(req.GetPayload()),
+		ElectedResource: req.GetElectedResource(),
+		ConsentID:       req.GetConsentId(),
+		UserID:          req.GetUserId(),
+		ClientID:        req.GetClientId(),
+	}
+	if params := req.GetResourceParams(); params != nil {
+		validateReq.ResourceParams.Resource = params.GetResource()
+		validateReq.ResourceParams.HTTPMethod = params.GetHttpMethod()
+		validateReq.ResourceParams.Context = params.GetContext()
+	}
+
+	validateResp, serviceErr := s.service.ValidateConsent(ctx, validateReq, orgID)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+
+	modifiedPayload, convErr := toValue(validateResp.ModifiedPayload)
+	if convErr != nil {
+		return nil, conversionError(convErr)
+	}
+	resp := &consentv1.ValidateConsentResponse{
+		IsValid:          validateResp.IsValid,
+		ModifiedPayload:  modifiedPayload,
+		ErrorCode:        int32(validateResp.ErrorCode),
+		ErrorMessage:     validateResp.ErrorMessage,
+		ErrorDescription: validateResp.ErrorDescription,
+	}
+	if info := validateResp.ConsentInformation; info != nil {
+		resp.ConsentInformation, err = toConsent(&model.ConsentAPIResponse{
+			ID:                         info.ID,
+			ConsentPurpose:             info.ConsentPurpose,
+			CreatedTime:                info.CreatedTime,
+			UpdatedTime:                info.UpdatedTime,
+			ClientID:                   info.ClientID,
+			Type:                       info.Type,
+			Status:                     info.Status,
+			Frequency:                  info.Frequency,
+			ValidityTime:               info.ValidityTime,
+			RecurringIndicator:         info.RecurringIndicator,
+			DataAccessValidityDuration: info.DataAccessValidityDuration,
+			Attributes:                 info.Attributes,
+			Authorizations:             info.Authorizations,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// fromPurposeItems converts gRPC purpose items to the API model
+func fromPurposeItems(items []*consentv1.ConsentPurposeItem) []model.ConsentPurposeItem {
+	if len(items) == 0 {
+		return nil
+	}
+	purposes := make([]model.ConsentPurposeItem, 0, len(items))
+	for _, item := range items {
+		purposes = append(purposes, model.ConsentPurposeItem{
+			Name:           item.GetName(),
+			Value:          fromValue(item.GetValue()),
+			IsUserApproved: item.IsUserApproved,
+			IsMandatory:    item.IsMandatory,
+		})
+	}
+	return purposes
+}
+
+// fromAuthorizationInputs converts gRPC authorization inputs to the API model
+func fromAuthorizationInputs(inputs []*consentv1.AuthorizationInput) []model.AuthorizationAPIRequest {
+	if len(inputs) == 0 {
+		return nil
+	}
+	authorizations := make([]model.AuthorizationAPIRequest, 0, len(inputs))
+	for _, input := range inputs {
+		authorizations = append(authorizations, model.AuthorizationAPIRequest{
+			UserID:    input.GetUserId(),
+			Type:      input.GetType(),
+			Status:    input.GetStatus(),
+			Resources: fromValue(input.GetResources()),
+		})
+	}
+	return authorizations
+}
+
+// toConsent converts an API consent response to its gRPC representation
+func toConsent(resp *model.ConsentAPIResponse) (*consentv1.Consent, error) {
+	purposes, err := toPurposeItems(resp.ConsentPurpose)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizations := make([]*consentv1.Authorization, 0, len(resp.Authorizations))
+	for _, auth := range resp.Authorizations {
+		resources, err := toValue(auth.Resources)
+		if err != nil {
+			return nil, conversionError(err)
+		}
+		authorizations = append(authorizations, &consentv1.Authorization{
+			Id:          auth.ID,
+			UserId:      auth.UserID,
+			Type:        auth.Type,
+			Status:      auth.Status,
+			UpdatedTime: auth.UpdatedTime,
+			Resources:   resources,
+		})
+	}
+
+	return &consentv1.Consent{
+		Id:                         resp.ID,
+		ConsentPurpose:             purposes,
+		CreatedTime:                resp.CreatedTime,
+		UpdatedTime:                resp.UpdatedTime,
+		ClientId:                   resp.ClientID,
+		Type:                       resp.Type,
+		Status:                     resp.Status,
+		Frequency:                  intPtrToInt32Ptr(resp.Frequency),
+		ValidityTime:               resp.ValidityTime,
+		RecurringIndicator:         resp.RecurringIndicator,
+		DataAccessValidityDuration: resp.DataAccessValidityDuration,
+		Attributes:                 resp.Attributes,
+		Authorizations:             authorizations,
+	}, nil
+}
+
+// toConsentFromDetail converts a detailed search result to its gRPC representation
+func toConsentFromDetail(detail *model.ConsentDetailResponse) (*consentv1.Consent, error) {
+	purposes, err := toPurposeItems(detail.ConsentPurposes)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizations := make([]*consentv1.Authorization, 0, len(detail.Authorizations))
+	for _, auth := range detail.Authorizations {
+		resources, err := toValue(auth.Resources)
+		if err != nil {
+			return nil, conversionError(err)
+		}
+		authorization := &consentv1.Authorization{
+			Id:          auth.ID,
+			Type:        auth.Type,
+			Status:      auth.Status,
+			UpdatedTime: auth.UpdatedTime,
+			Resources:   resources,
+		}
+		if auth.UserID != "" {
+			userID := auth.UserID
+			authorization.UserId = &userID
+		}
+		authorizations = append(authorizations, authorization)
+	}
+
+	frequency := int32(detail.Frequency)
+	validityTime := detail.ValidityTime
+	recurringIndicator := detail.RecurringIndicator
+	dataAccessValidityDuration := detail.DataAccessValidityDuration
+
+	return &consentv1.Consent{
+		Id:                         detail.ID,
+		ConsentPurpose:             purposes,
+		CreatedTime:                detail.CreatedTime,
+		UpdatedTime:                detail.UpdatedTime,
+		ClientId:                   detail.ClientID,
+		Type:                       detail.Type,
+		Status:                     detail.Status,
+		Frequency:                  &frequency,
+		ValidityTime:               &validityTime,
+		RecurringIndicator:         &recurringIndicator,
+		DataAccessValidityDuration: &dataAccessValidityDuration,
+		Attributes:                 detail.Attributes,
+		Authorizations:             authorizations,
+	}, nil
+}
+
+// toPurposeItems converts API purpose items to their gRPC representation
+func toPurposeItems(items []model.ConsentPurposeItem) ([]*consentv1.ConsentPurposeItem, error) {
+	purposes := make([]*consentv1.ConsentPurposeItem, 0, len(items))
+	for _, item := range items {
+		value, err := toValue(item.Value)
+		if err != nil {
+			return nil, conversionError(err)
+		}
+		attributes, err := toStruct(item.Attributes)
+		if err != nil {
+			return nil, conversionError(err)
+		}
+		purposes = append(purposes, &consentv1.ConsentPurposeItem{
+			Name:           item.Name,
+			Value:          value,
+			IsUserApproved: item.IsUserApproved,
+			IsMandatory:    item.IsMandatory,
+			Type:           item.Type,
+			Description:    item.Description,
+			Attributes:     attributes,
+		})
+	}
+	return purposes, nil
+}
@@ -0,0 +1,74 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// toValue converts a decoded JSON value to a protobuf Value. Values that cannot be represented
+// directly are round-tripped through JSON first.
+func toValue(v interface{}) (*structpb.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	value, err := structpb.NewValue(v)
+	if err == nil {
+		return value, nil
+	}
+
+	raw, marshalErr := json.Marshal(v)
+	if marshalErr != nil {
+		return nil, fmt.Errorf("failed to convert value: %w", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to convert value: %w", err)
+	}
+	return structpb.NewValue(decoded)
+}
+
+// fromValue converts a protobuf Value to its JSON representation, or nil when not set
+func fromValue(v *structpb.Value) interface{} {
+	if v == nil {
+		return nil
+	}
+	return v.AsInterface()
+}
+
+// toStruct converts a JSON object to a protobuf Struct
+func toStruct(m map[string]interface{}) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	value, err := toValue(m)
+	if err != nil {
+		return nil, err
+	}
+	return value.GetStructValue(), nil
+}
+
+// fromStruct converts a protobuf Struct to a JSON object, or nil when not set
+func fromStruct(s *structpb.Struct) map[string]interface{} {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
+
+func int32PtrToIntPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+func intPtrToInt32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
@@ -0,0 +1,63 @@
+package grpcapi
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	errcodes "github.com/wso2/consent-management-api/internal/system/error/codes"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+)
+
+const errorDomain = "consent-management"
+
+// toStatusError converts a service error to a gRPC status error. The service error code is
+// returned as the ErrorInfo reason so clients can branch on the same codes as the HTTP API.
+func toStatusError(err *serviceerror.ServiceError) error {
+	st := status.New(mapErrorToStatusCode(err), err.Message)
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: err.Code,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"description": err.Description,
+		},
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// invalidArgument returns an InvalidRequest error for a malformed gRPC request
+func invalidArgument(message string) error {
+	return toStatusError(serviceerror.CustomServiceError(serviceerror.InvalidRequestError, message))
+}
+
+// mapErrorToStatusCode maps service errors to gRPC codes, mirroring the HTTP status mapping
+func mapErrorToStatusCode(err *serviceerror.ServiceError) codes.Code {
+	if err.Type == serviceerror.ServerErrorType {
+		return codes.Internal
+	}
+
+	switch err.Code {
+	case errcodes.ResourceNotFound, errcodes.ConsentNotFound, errcodes.PurposeNotFound,
+		errcodes.AuthResourceNotFound, errcodes.ExportJobNotFound, errcodes.ConsentFileNotFound,
+		errcodes.ImportJobNotFound:
+		return codes.NotFound
+	case errcodes.ConflictError, errcodes.PurposeInUse:
+		return codes.AlreadyExists
+	case errcodes.Unauthorized:
+		return codes.Unauthenticated
+	case errcodes.Forbidden:
+		return codes.PermissionDenied
+	case errcodes.ConsentLocked:
+		return codes.FailedPrecondition
+	default:
+		return codes.InvalidArgument
+	}
+}
+
+// conversionError reports a response that could not be represented in protobuf
+func conversionError(err error) error {
+	return status.Errorf(codes.Internal, "failed to build response: %v", err)
+}
@@ -0,0 +1,86 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
+)
+
+// correlationIDInterceptor propagates the correlation ID from request metadata, or generates one,
+// and returns it in the response header the same way the HTTP correlation ID middleware does.
+func correlationIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	correlationID := ""
+	for _, key := range []string{constants.CorrelationIDHeaderName, "X-Request-ID", "X-Trace-ID"} {
+		if id := metadataValue(ctx, key); id != "" {
+			correlationID = id
+			break
+		}
+	}
+	if correlationID == "" {
+		correlationID = uuid.New().String()
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(constants.CorrelationIDHeaderName), correlationID))
+	ctx = context.WithValue(ctx, log.ContextKeyTraceID, correlationID)
+
+	return handler(ctx, req)
+}
+
+// tracingInterceptor records every call as a server span, continuing the caller's trace when the
+// request metadata carries a W3C traceparent value
+func tracingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if sc, ok := tracing.ParseTraceparent(metadataValue(ctx, tracing.TraceparentHeader)); ok {
+		ctx = tracing.ContextWithRemoteSpanContext(ctx, sc)
+	}
+	ctx, span := tracing.Start(ctx, info.FullMethod, tracing.SpanKindServer)
+	defer span.End()
+	span.SetAttribute("rpc.system", "grpc")
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		code := status.Code(err)
+		span.SetAttribute("rpc.grpc.status_code", int(code))
+		if code == codes.Internal || code == codes.Unavailable || code == codes.Unknown {
+			span.SetError(err)
+		}
+	}
+	return resp, err
+}
+
+// recoveryInterceptor converts panics in handlers to internal errors so a single request cannot crash the server
+func recoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.GetLogger().WithContext(ctx).Error("Panic while handling gRPC request",
+				log.String("method", info.FullMethod), log.Any("panic", r))
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// metadataValue returns the first value of the incoming metadata key. Keys are case-insensitive.
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,30 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// requireOrgID reads and validates the organization ID from the "org-id" request metadata
+func requireOrgID(ctx context.Context) (string, error) {
+	orgID := metadataValue(ctx, constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		return "", invalidArgument(err.Error())
+	}
+	return orgID, nil
+}
+
+// requireOrgAndClientID reads and validates the organization and client IDs from the request metadata
+func requireOrgAndClientID(ctx context.Context) (string, string, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	clientID := metadataValue(ctx, constants.HeaderTPPClientID)
+	if err := utils.ValidateClientID(clientID); err != nil {
+		return "", "", invalidArgument(err.Error())
+	}
+	return orgID, clientID, nil
+}
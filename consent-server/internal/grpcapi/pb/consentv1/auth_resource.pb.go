@@ -0,0 +1,579 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: consent/v1/auth_resource.proto
+
+package consentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Authorization is an authorization resource attached to a consent
+type Authorization struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	UpdatedTime   int64                  `protobuf:"varint,5,opt,name=updated_time,json=updatedTime,proto3" json:"updated_time,omitempty"`
+	Resources     *structpb.Value        `protobuf:"bytes,6,opt,name=resources,proto3" json:"resources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Authorization) Reset() {
+	*x = Authorization{}
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Authorization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Authorization) ProtoMessage() {}
+
+func (x *Authorization) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Authorization.ProtoReflect.Descriptor instead.
+func (*Authorization) Descriptor() ([]byte, []int) {
+	return file_consent_v1_auth_resource_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Authorization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Authorization) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *Authorization) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Authorization) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Authorization) GetUpdatedTime() int64 {
+	if x != nil {
+		return x.UpdatedTime
+	}
+	return 0
+}
+
+func (x *Authorization) GetResources() *structpb.Value {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+// AuthorizationInput describes an authorization resource to create
+type AuthorizationInput struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type   string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Defaults to the configured approved state when empty
+	Status        string          `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Resources     *structpb.Value `protobuf:"bytes,4,opt,name=resources,proto3" json:"resources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizationInput) Reset() {
+	*x = AuthorizationInput{}
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizationInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizationInput) ProtoMessage() {}
+
+func (x *AuthorizationInput) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizationInput.ProtoReflect.Descriptor instead.
+func (*AuthorizationInput) Descriptor() ([]byte, []int) {
+	return file_consent_v1_auth_resource_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuthorizationInput) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuthorizationInput) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AuthorizationInput) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AuthorizationInput) GetResources() *structpb.Value {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+type CreateAuthResourceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId     string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	Authorization *AuthorizationInput    `protobuf:"bytes,2,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuthResourceRequest) Reset() {
+	*x = CreateAuthResourceRequest{}
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuthResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuthResourceRequest) ProtoMessage() {}
+
+func (x *CreateAuthResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuthResourceRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuthResourceRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_auth_resource_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateAuthResourceRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *CreateAuthResourceRequest) GetAuthorization() *AuthorizationInput {
+	if x != nil {
+		return x.Authorization
+	}
+	return nil
+}
+
+type GetAuthResourceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId       string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	AuthorizationId string                 `protobuf:"bytes,2,opt,name=authorization_id,json=authorizationId,proto3" json:"authorization_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetAuthResourceRequest) Reset() {
+	*x = GetAuthResourceRequest{}
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthResourceRequest) ProtoMessage() {}
+
+func (x *GetAuthResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthResourceRequest.ProtoReflect.Descriptor instead.
+func (*GetAuthResourceRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_auth_resource_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAuthResourceRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *GetAuthResourceRequest) GetAuthorizationId() string {
+	if x != nil {
+		return x.AuthorizationId
+	}
+	return ""
+}
+
+type ListAuthResourcesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId     string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthResourcesRequest) Reset() {
+	*x = ListAuthResourcesRequest{}
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthResourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthResourcesRequest) ProtoMessage() {}
+
+func (x *ListAuthResourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthResourcesRequest.ProtoReflect.Descriptor instead.
+func (*ListAuthResourcesRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_auth_resource_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAuthResourcesRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+type ListAuthResourcesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Authorization       `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthResourcesResponse) Reset() {
+	*x = ListAuthResourcesResponse{}
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthResourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthResourcesResponse) ProtoMessage() {}
+
+func (x *ListAuthResourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthResourcesResponse.ProtoReflect.Descriptor instead.
+func (*ListAuthResourcesResponse) Descriptor() ([]byte, []int) {
+	return file_consent_v1_auth_resource_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAuthResourcesResponse) GetData() []*Authorization {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UpdateAuthResourceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId       string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	AuthorizationId string                 `protobuf:"bytes,2,opt,name=authorization_id,json=authorizationId,proto3" json:"authorization_id,omitempty"`
+	Status          string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	UserId          *string                `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	Resources       *structpb.Value        `protobuf:"bytes,5,opt,name=resources,proto3" json:"resources,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateAuthResourceRequest) Reset() {
+	*x = UpdateAuthResourceRequest{}
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuthResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuthResourceRequest) ProtoMessage() {}
+
+func (x *UpdateAuthResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_auth_resource_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuthResourceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAuthResourceRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_auth_resource_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateAuthResourceRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *UpdateAuthResourceRequest) GetAuthorizationId() string {
+	if x != nil {
+		return x.AuthorizationId
+	}
+	return ""
+}
+
+func (x *UpdateAuthResourceRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateAuthResourceRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateAuthResourceRequest) GetResources() *structpb.Value {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+var File_consent_v1_auth_resource_proto protoreflect.FileDescriptor
+
+const file_consent_v1_auth_resource_proto_rawDesc = "" +
+	"\n" +
+	"\x1econsent/v1/auth_resource.proto\x12\x0fwso2.consent.v1\x1a\x1cgoogle/protobuf/struct.proto\"\xce\x01\n" +
+	"\rAuthorization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12!\n" +
+	"\fupdated_time\x18\x05 \x01(\x03R\vupdatedTime\x124\n" +
+	"\tresources\x18\x06 \x01(\v2\x16.google.protobuf.ValueR\tresourcesB\n" +
+	"\n" +
+	"\b_user_id\"\x8f\x01\n" +
+	"\x12AuthorizationInput\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x124\n" +
+	"\tresources\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\tresources\"\x85\x01\n" +
+	"\x19CreateAuthResourceRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\x12I\n" +
+	"\rauthorization\x18\x02 \x01(\v2#.wso2.consent.v1.AuthorizationInputR\rauthorization\"b\n" +
+	"\x16GetAuthResourceRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\x12)\n" +
+	"\x10authorization_id\x18\x02 \x01(\tR\x0fauthorizationId\"9\n" +
+	"\x18ListAuthResourcesRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\"O\n" +
+	"\x19ListAuthResourcesResponse\x122\n" +
+	"\x04data\x18\x01 \x03(\v2\x1e.wso2.consent.v1.AuthorizationR\x04data\"\xdd\x01\n" +
+	"\x19UpdateAuthResourceRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\x12)\n" +
+	"\x10authorization_id\x18\x02 \x01(\tR\x0fauthorizationId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1c\n" +
+	"\auser_id\x18\x04 \x01(\tH\x00R\x06userId\x88\x01\x01\x124\n" +
+	"\tresources\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\tresourcesB\n" +
+	"\n" +
+	"\b_user_id2\xa1\x03\n" +
+	"\x13AuthResourceService\x12`\n" +
+	"\x12CreateAuthResource\x12*.wso2.consent.v1.CreateAuthResourceRequest\x1a\x1e.wso2.consent.v1.Authorization\x12Z\n" +
+	"\x0fGetAuthResource\x12'.wso2.consent.v1.GetAuthResourceRequest\x1a\x1e.wso2.consent.v1.Authorization\x12j\n" +
+	"\x11ListAuthResources\x12).wso2.consent.v1.ListAuthResourcesRequest\x1a*.wso2.consent.v1.ListAuthResourcesResponse\x12`\n" +
+	"\x12UpdateAuthResource\x12*.wso2.consent.v1.UpdateAuthResourceRequest\x1a\x1e.wso2.consent.v1.AuthorizationBPZNgithub.com/wso2/consent-management-api/internal/grpcapi/pb/consentv1;consentv1b\x06proto3"
+
+var (
+	file_consent_v1_auth_resource_proto_rawDescOnce sync.Once
+	file_consent_v1_auth_resource_proto_rawDescData []byte
+)
+
+func file_consent_v1_auth_resource_proto_rawDescGZIP() []byte {
+	file_consent_v1_auth_resource_proto_rawDescOnce.Do(func() {
+		file_consent_v1_auth_resource_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_consent_v1_auth_resource_proto_rawDesc), len(file_consent_v1_auth_resource_proto_rawDesc)))
+	})
+	return file_consent_v1_auth_resource_proto_rawDescData
+}
+
+var file_consent_v1_auth_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_consent_v1_auth_resource_proto_goTypes = []any{
+	(*Authorization)(nil),             // 0: wso2.consent.v1.Authorization
+	(*AuthorizationInput)(nil),        // 1: wso2.consent.v1.AuthorizationInput
+	(*CreateAuthResourceRequest)(nil), // 2: wso2.consent.v1.CreateAuthResourceRequest
+	(*GetAuthResourceRequest)(nil),    // 3: wso2.consent.v1.GetAuthResourceRequest
+	(*ListAuthResourcesRequest)(nil),  // 4: wso2.consent.v1.ListAuthResourcesRequest
+	(*ListAuthResourcesResponse)(nil), // 5: wso2.consent.v1.ListAuthResourcesResponse
+	(*UpdateAuthResourceRequest)(nil), // 6: wso2.consent.v1.UpdateAuthResourceRequest
+	(*structpb.Value)(nil),            // 7: google.protobuf.Value
+}
+var file_consent_v1_auth_resource_proto_depIdxs = []int32{
+	7, // 0: wso2.consent.v1.Authorization.resources:type_name -> google.protobuf.Value
+	7, // 1: wso2.consent.v1.AuthorizationInput.resources:type_name -> google.protobuf.Value
+	1, // 2: wso2.consent.v1.CreateAuthResourceRequest.authorization:type_name -> wso2.consent.v1.AuthorizationInput
+	0, // 3: wso2.consent.v1.ListAuthResourcesResponse.data:type_name -> wso2.consent.v1.Authorization
+	7, // 4: wso2.consent.v1.UpdateAuthResourceRequest.resources:type_name -> google.protobuf.Value
+	2, // 5: wso2.consent.v1.AuthResourceService.CreateAuthResource:input_type -> wso2.consent.v1.CreateAuthResourceRequest
+	3, // 6: wso2.consent.v1.AuthResourceService.GetAuthResource:input_type -> wso2.consent.v1.GetAuthResourceRequest
+	4, // 7: wso2.consent.v1.AuthResourceService.ListAuthResources:input_type -> wso2.consent.v1.ListAuthResourcesRequest
+	6, // 8: wso2.consent.v1.AuthResourceService.UpdateAuthResource:input_type -> wso2.consent.v1.UpdateAuthResourceRequest
+	0, // 9: wso2.consent.v1.AuthResourceService.CreateAuthResource:output_type -> wso2.consent.v1.Authorization
+	0, // 10: wso2.consent.v1.AuthResourceService.GetAuthResource:output_type -> wso2.consent.v1.Authorization
+	5, // 11: wso2.consent.v1.AuthResourceService.ListAuthResources:output_type -> wso2.consent.v1.ListAuthResourcesResponse
+	0, // 12: wso2.consent.v1.AuthResourceService.UpdateAuthResource:output_type -> wso2.consent.v1.Authorization
+	9, // [9:13] is the sub-list for method output_type
+	5, // [5:9] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_consent_v1_auth_resource_proto_init() }
+func file_consent_v1_auth_resource_proto_init() {
+	if File_consent_v1_auth_resource_proto != nil {
+		return
+	}
+	file_consent_v1_auth_resource_proto_msgTypes[0].OneofWrappers = []any{}
+	file_consent_v1_auth_resource_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_consent_v1_auth_resource_proto_rawDesc), len(file_consent_v1_auth_resource_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_consent_v1_auth_resource_proto_goTypes,
+		DependencyIndexes: file_consent_v1_auth_resource_proto_depIdxs,
+		MessageInfos:      file_consent_v1_auth_resource_proto_msgTypes,
+	}.Build()
+	File_consent_v1_auth_resource_proto = out.File
+	file_consent_v1_auth_resource_proto_goTypes = nil
+	file_consent_v1_auth_resource_proto_depIdxs = nil
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: consent/v1/auth_resource.proto
+
+package consentv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AuthResourceService_CreateAuthResource_FullMethodName = "/wso2.consent.v1.AuthResourceService/CreateAuthResource"
+	AuthResourceService_GetAuthResource_FullMethodName    = "/wso2.consent.v1.AuthResourceService/GetAuthResource"
+	AuthResourceService_ListAuthResources_FullMethodName  = "/wso2.consent.v1.AuthResourceService/ListAuthResources"
+	AuthResourceService_UpdateAuthResource_FullMethodName = "/wso2.consent.v1.AuthResourceService/UpdateAuthResource"
+)
+
+// AuthResourceServiceClient is the client API for AuthResourceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AuthResourceService manages the authorization resources of a consent.
+// The organization is read from the "org-id" request metadata.
+type AuthResourceServiceClient interface {
+	CreateAuthResource(ctx context.Context, in *CreateAuthResourceRequest, opts ...grpc.CallOption) (*Authorization, error)
+	GetAuthResource(ctx context.Context, in *GetAuthResourceRequest, opts ...grpc.CallOption) (*Authorization, error)
+	ListAuthResources(ctx context.Context, in *ListAuthResourcesRequest, opts ...grpc.CallOption) (*ListAuthResourcesResponse, error)
+	UpdateAuthResource(ctx context.Context, in *UpdateAuthResourceRequest, opts ...grpc.CallOption) (*Authorization, error)
+}
+
+type authResourceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthResourceServiceClient(cc grpc.ClientConnInterface) AuthResourceServiceClient {
+	return &authResourceServiceClient{cc}
+}
+
+func (c *authResourceServiceClient) CreateAuthResource(ctx context.Context, in *CreateAuthResourceRequest, opts ...grpc.CallOption) (*Authorization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Authorization)
+	err := c.cc.Invoke(ctx, AuthResourceService_CreateAuthResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authResourceServiceClient) GetAuthResource(ctx context.Context, in *GetAuthResourceRequest, opts ...grpc.CallOption) (*Authorization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Authorization)
+	err := c.cc.Invoke(ctx, AuthResourceService_GetAuthResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authResourceServiceClient) ListAuthResources(ctx context.Context, in *ListAuthResourcesRequest, opts ...grpc.CallOption) (*ListAuthResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuthResourcesResponse)
+	err := c.cc.Invoke(ctx, AuthResourceService_ListAuthResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authResourceServiceClient) UpdateAuthResource(ctx context.Context, in *UpdateAuthResourceRequest, opts ...grpc.CallOption) (*Authorization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Authorization)
+	err := c.cc.Invoke(ctx, AuthResourceService_UpdateAuthResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthResourceServiceServer is the server API for AuthResourceService service.
+// All implementations must embed UnimplementedAuthResourceServiceServer
+// for forward compatibility.
+//
+// AuthResourceService manages the authorization resources of a consent.
+// The organization is read from the "org-id" request metadata.
+type AuthResourceServiceServer interface {
+	CreateAuthResource(context.Context, *CreateAuthResourceRequest) (*Authorization, error)
+	GetAuthResource(context.Context, *GetAuthResourceRequest) (*Authorization, error)
+	ListAuthResources(context.Context, *ListAuthResourcesRequest) (*ListAuthResourcesResponse, error)
+	UpdateAuthResource(context.Context, *UpdateAuthResourceRequest) (*Authorization, error)
+	mustEmbedUnimplementedAuthResourceServiceServer()
+}
+
+// UnimplementedAuthResourceServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthResourceServiceServer struct{}
+
+func (UnimplementedAuthResourceServiceServer) CreateAuthResource(context.Context, *CreateAuthResourceRequest) (*Authorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAuthResource not implemented")
+}
+func (UnimplementedAuthResourceServiceServer) GetAuthResource(context.Context, *GetAuthResourceRequest) (*Authorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthResource not implemented")
+}
+func (UnimplementedAuthResourceServiceServer) ListAuthResources(context.Context, *ListAuthResourcesRequest) (*ListAuthResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuthResources not implemented")
+}
+func (UnimplementedAuthResourceServiceServer) UpdateAuthResource(context.Context, *UpdateAuthResourceRequest) (*Authorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAuthResource not implemented")
+}
+func (UnimplementedAuthResourceServiceServer) mustEmbedUnimplementedAuthResourceServiceServer() {}
+func (UnimplementedAuthResourceServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeAuthResourceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthResourceServiceServer will
+// result in compilation errors.
+type UnsafeAuthResourceServiceServer interface {
+	mustEmbedUnimplementedAuthResourceServiceServer()
+}
+
+func RegisterAuthResourceServiceServer(s grpc.ServiceRegistrar, srv AuthResourceServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuthResourceServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthResourceService_ServiceDesc, srv)
+}
+
+func _AuthResourceService_CreateAuthResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuthResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthResourceServiceServer).CreateAuthResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthResourceService_CreateAuthResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthResourceServiceServer).CreateAuthResource(ctx, req.(*CreateAuthResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthResourceService_GetAuthResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthResourceServiceServer).GetAuthResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthResourceService_GetAuthResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthResourceServiceServer).GetAuthResource(ctx, req.(*GetAuthResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthResourceService_ListAuthResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuthResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthResourceServiceServer).ListAuthResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthResourceService_ListAuthResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthResourceServiceServer).ListAuthResources(ctx, req.(*ListAuthResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthResourceService_UpdateAuthResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAuthResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthResourceServiceServer).UpdateAuthResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthResourceService_UpdateAuthResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthResourceServiceServer).UpdateAuthResource(ctx, req.(*UpdateAuthResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthResourceService_ServiceDesc is the grpc.ServiceDesc for AuthResourceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthResourceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wso2.consent.v1.AuthResourceService",
+	HandlerType: (*AuthResourceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAuthResource",
+			Handler:    _AuthResourceService_CreateAuthResource_Handler,
+		},
+		{
+			MethodName: "GetAuthResource",
+			Handler:    _AuthResourceService_GetAuthResource_Handler,
+		},
+		{
+			MethodName: "ListAuthResources",
+			Handler:    _AuthResourceService_ListAuthResources_Handler,
+		},
+		{
+			MethodName: "UpdateAuthResource",
+			Handler:    _AuthResourceService_UpdateAuthResource_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consent/v1/auth_resource.proto",
+}
@@ -0,0 +1,1455 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: consent/v1/consent.proto
+
+package consentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConsentPurposeItem is a purpose selected in a consent
+type ConsentPurposeItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value *structpb.Value        `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Defaults to false when not set
+	IsUserApproved *bool `protobuf:"varint,3,opt,name=is_user_approved,json=isUserApproved,proto3,oneof" json:"is_user_approved,omitempty"`
+	// Defaults to true when not set
+	IsMandatory   *bool            `protobuf:"varint,4,opt,name=is_mandatory,json=isMandatory,proto3,oneof" json:"is_mandatory,omitempty"`
+	Type          *string          `protobuf:"bytes,5,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	Description   *string          `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Attributes    *structpb.Struct `protobuf:"bytes,7,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentPurposeItem) Reset() {
+	*x = ConsentPurposeItem{}
+	mi := &file_consent_v1_consent_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentPurposeItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentPurposeItem) ProtoMessage() {}
+
+func (x *ConsentPurposeItem) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentPurposeItem.ProtoReflect.Descriptor instead.
+func (*ConsentPurposeItem) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConsentPurposeItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConsentPurposeItem) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ConsentPurposeItem) GetIsUserApproved() bool {
+	if x != nil && x.IsUserApproved != nil {
+		return *x.IsUserApproved
+	}
+	return false
+}
+
+func (x *ConsentPurposeItem) GetIsMandatory() bool {
+	if x != nil && x.IsMandatory != nil {
+		return *x.IsMandatory
+	}
+	return false
+}
+
+func (x *ConsentPurposeItem) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *ConsentPurposeItem) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ConsentPurposeItem) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type Consent struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Id                         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConsentPurpose             []*ConsentPurposeItem  `protobuf:"bytes,2,rep,name=consent_purpose,json=consentPurpose,proto3" json:"consent_purpose,omitempty"`
+	CreatedTime                int64                  `protobuf:"varint,3,opt,name=created_time,json=createdTime,proto3" json:"created_time,omitempty"`
+	UpdatedTime                int64                  `protobuf:"varint,4,opt,name=updated_time,json=updatedTime,proto3" json:"updated_time,omitempty"`
+	ClientId                   string                 `protobuf:"bytes,5,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Type                       string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	Status                     string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Frequency                  *int32                 `protobuf:"varint,8,opt,name=frequency,proto3,oneof" json:"frequency,omitempty"`
+	ValidityTime               *int64                 `protobuf:"varint,9,opt,name=validity_time,json=validityTime,proto3,oneof" json:"validity_time,omitempty"`
+	RecurringIndicator         *bool                  `protobuf:"varint,10,opt,name=recurring_indicator,json=recurringIndicator,proto3,oneof" json:"recurring_indicator,omitempty"`
+	DataAccessValidityDuration *int64                 `protobuf:"varint,11,opt,name=data_access_validity_duration,json=dataAccessValidityDuration,proto3,oneof" json:"data_access_validity_duration,omitempty"`
+	Attributes                 map[string]string      `protobuf:"bytes,12,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Authorizations             []*Authorization       `protobuf:"bytes,13,rep,name=authorizations,proto3" json:"authorizations,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *Consent) Reset() {
+	*x = Consent{}
+	mi := &file_consent_v1_consent_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Consent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Consent) ProtoMessage() {}
+
+func (x *Consent) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Consent.ProtoReflect.Descriptor instead.
+func (*Consent) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Consent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Consent) GetConsentPurpose() []*ConsentPurposeItem {
+	if x != nil {
+		return x.ConsentPurpose
+	}
+	return nil
+}
+
+func (x *Consent) GetCreatedTime() int64 {
+	if x != nil {
+		return x.CreatedTime
+	}
+	return 0
+}
+
+func (x *Consent) GetUpdatedTime() int64 {
+	if x != nil {
+		return x.UpdatedTime
+	}
+	return 0
+}
+
+func (x *Consent) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Consent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Consent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Consent) GetFrequency() int32 {
+	if x != nil && x.Frequency != nil {
+		return *x.Frequency
+	}
+	return 0
+}
+
+func (x *Consent) GetValidityTime() int64 {
+	if x != nil && x.ValidityTime != nil {
+		return *x.ValidityTime
+	}
+	return 0
+}
+
+func (x *Consent) GetRecurringIndicator() bool {
+	if x != nil && x.RecurringIndicator != nil {
+		return *x.RecurringIndicator
+	}
+	return false
+}
+
+func (x *Consent) GetDataAccessValidityDuration() int64 {
+	if x != nil && x.DataAccessValidityDuration != nil {
+		return *x.DataAccessValidityDuration
+	}
+	return 0
+}
+
+func (x *Consent) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Consent) GetAuthorizations() []*Authorization {
+	if x != nil {
+		return x.Authorizations
+	}
+	return nil
+}
+
+type CreateConsentRequest struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Type                       string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	ValidityTime               *int64                 `protobuf:"varint,2,opt,name=validity_time,json=validityTime,proto3,oneof" json:"validity_time,omitempty"`
+	RecurringIndicator         *bool                  `protobuf:"varint,3,opt,name=recurring_indicator,json=recurringIndicator,proto3,oneof" json:"recurring_indicator,omitempty"`
+	Frequency                  *int32                 `protobuf:"varint,4,opt,name=frequency,proto3,oneof" json:"frequency,omitempty"`
+	DataAccessValidityDuration *int64                 `protobuf:"varint,5,opt,name=data_access_validity_duration,json=dataAccessValidityDuration,proto3,oneof" json:"data_access_validity_duration,omitempty"`
+	ConsentPurpose             []*ConsentPurposeItem  `protobuf:"bytes,6,rep,name=consent_purpose,json=consentPurpose,proto3" json:"consent_purpose,omitempty"`
+	Attributes                 map[string]string      `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Authorizations             []*AuthorizationInput  `protobuf:"bytes,8,rep,name=authorizations,proto3" json:"authorizations,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *CreateConsentRequest) Reset() {
+	*x = CreateConsentRequest{}
+	mi := &file_consent_v1_consent_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConsentRequest) ProtoMessage() {}
+
+func (x *CreateConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConsentRequest.ProtoReflect.Descriptor instead.
+func (*CreateConsentRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateConsentRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateConsentRequest) GetValidityTime() int64 {
+	if x != nil && x.ValidityTime != nil {
+		return *x.ValidityTime
+	}
+	return 0
+}
+
+func (x *CreateConsentRequest) GetRecurringIndicator() bool {
+	if x != nil && x.RecurringIndicator != nil {
+		return *x.RecurringIndicator
+	}
+	return false
+}
+
+func (x *CreateConsentRequest) GetFrequency() int32 {
+	if x != nil && x.Frequency != nil {
+		return *x.Frequency
+	}
+	return 0
+}
+
+func (x *CreateConsentRequest) GetDataAccessValidityDuration() int64 {
+	if x != nil && x.DataAccessValidityDuration != nil {
+		return *x.DataAccessValidityDuration
+	}
+	return 0
+}
+
+func (x *CreateConsentRequest) GetConsentPurpose() []*ConsentPurposeItem {
+	if x != nil {
+		return x.ConsentPurpose
+	}
+	return nil
+}
+
+func (x *CreateConsentRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *CreateConsentRequest) GetAuthorizations() []*AuthorizationInput {
+	if x != nil {
+		return x.Authorizations
+	}
+	return nil
+}
+
+type GetConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId     string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsentRequest) Reset() {
+	*x = GetConsentRequest{}
+	mi := &file_consent_v1_consent_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsentRequest) ProtoMessage() {}
+
+func (x *GetConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsentRequest.ProtoReflect.Descriptor instead.
+func (*GetConsentRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetConsentRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+type SearchConsentsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConsentTypes    []string               `protobuf:"bytes,1,rep,name=consent_types,json=consentTypes,proto3" json:"consent_types,omitempty"`
+	ConsentStatuses []string               `protobuf:"bytes,2,rep,name=consent_statuses,json=consentStatuses,proto3" json:"consent_statuses,omitempty"`
+	ClientIds       []string               `protobuf:"bytes,3,rep,name=client_ids,json=clientIds,proto3" json:"client_ids,omitempty"`
+	UserIds         []string               `protobuf:"bytes,4,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	FromTime        *int64                 `protobuf:"varint,5,opt,name=from_time,json=fromTime,proto3,oneof" json:"from_time,omitempty"`
+	ToTime          *int64                 `protobuf:"varint,6,opt,name=to_time,json=toTime,proto3,oneof" json:"to_time,omitempty"`
+	// Defaults to 10 when not set
+	Limit  int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Enables cursor pagination when set; empty for the first page, then metadata.next_cursor.
+	// offset is ignored in cursor mode.
+	Cursor *string `protobuf:"bytes,9,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	// Defaults to true. When false the total count is skipped and only metadata.has_more is reported.
+	IncludeTotal  *bool `protobuf:"varint,10,opt,name=include_total,json=includeTotal,proto3,oneof" json:"include_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchConsentsRequest) Reset() {
+	*x = SearchConsentsRequest{}
+	mi := &file_consent_v1_consent_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchConsentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchConsentsRequest) ProtoMessage() {}
+
+func (x *SearchConsentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchConsentsRequest.ProtoReflect.Descriptor instead.
+func (*SearchConsentsRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchConsentsRequest) GetConsentTypes() []string {
+	if x != nil {
+		return x.ConsentTypes
+	}
+	return nil
+}
+
+func (x *SearchConsentsRequest) GetConsentStatuses() []string {
+	if x != nil {
+		return x.ConsentStatuses
+	}
+	return nil
+}
+
+func (x *SearchConsentsRequest) GetClientIds() []string {
+	if x != nil {
+		return x.ClientIds
+	}
+	return nil
+}
+
+func (x *SearchConsentsRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *SearchConsentsRequest) GetFromTime() int64 {
+	if x != nil && x.FromTime != nil {
+		return *x.FromTime
+	}
+	return 0
+}
+
+func (x *SearchConsentsRequest) GetToTime() int64 {
+	if x != nil && x.ToTime != nil {
+		return *x.ToTime
+	}
+	return 0
+}
+
+func (x *SearchConsentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchConsentsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchConsentsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchConsentsRequest) GetIncludeTotal() bool {
+	if x != nil && x.IncludeTotal != nil {
+		return *x.IncludeTotal
+	}
+	return false
+}
+
+type SearchConsentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Consent             `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Metadata      *PaginationMetadata    `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchConsentsResponse) Reset() {
+	*x = SearchConsentsResponse{}
+	mi := &file_consent_v1_consent_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchConsentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchConsentsResponse) ProtoMessage() {}
+
+func (x *SearchConsentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchConsentsResponse.ProtoReflect.Descriptor instead.
+func (*SearchConsentsResponse) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchConsentsResponse) GetData() []*Consent {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SearchConsentsResponse) GetMetadata() *PaginationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// The list wrappers below distinguish "not provided" from "provided but empty".
+// An empty list removes all existing items, an unset field leaves them unchanged.
+type ConsentPurposeList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ConsentPurposeItem  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentPurposeList) Reset() {
+	*x = ConsentPurposeList{}
+	mi := &file_consent_v1_consent_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentPurposeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentPurposeList) ProtoMessage() {}
+
+func (x *ConsentPurposeList) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentPurposeList.ProtoReflect.Descriptor instead.
+func (*ConsentPurposeList) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConsentPurposeList) GetItems() []*ConsentPurposeItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type AttributeMap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         map[string]string      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeMap) Reset() {
+	*x = AttributeMap{}
+	mi := &file_consent_v1_consent_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeMap) ProtoMessage() {}
+
+func (x *AttributeMap) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeMap.ProtoReflect.Descriptor instead.
+func (*AttributeMap) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AttributeMap) GetItems() map[string]string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type AuthorizationInputList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*AuthorizationInput  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizationInputList) Reset() {
+	*x = AuthorizationInputList{}
+	mi := &file_consent_v1_consent_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizationInputList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizationInputList) ProtoMessage() {}
+
+func (x *AuthorizationInputList) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizationInputList.ProtoReflect.Descriptor instead.
+func (*AuthorizationInputList) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AuthorizationInputList) GetItems() []*AuthorizationInput {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type UpdateConsentRequest struct {
+	state                      protoimpl.MessageState  `protogen:"open.v1"`
+	ConsentId                  string                  `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	Type                       string                  `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ValidityTime               *int64                  `protobuf:"varint,3,opt,name=validity_time,json=validityTime,proto3,oneof" json:"validity_time,omitempty"`
+	RecurringIndicator         *bool                   `protobuf:"varint,4,opt,name=recurring_indicator,json=recurringIndicator,proto3,oneof" json:"recurring_indicator,omitempty"`
+	Frequency                  *int32                  `protobuf:"varint,5,opt,name=frequency,proto3,oneof" json:"frequency,omitempty"`
+	DataAccessValidityDuration *int64                  `protobuf:"varint,6,opt,name=data_access_validity_duration,json=dataAccessValidityDuration,proto3,oneof" json:"data_access_validity_duration,omitempty"`
+	ConsentPurpose             *ConsentPurposeList     `protobuf:"bytes,7,opt,name=consent_purpose,json=consentPurpose,proto3" json:"consent_purpose,omitempty"`
+	Attributes                 *AttributeMap           `protobuf:"bytes,8,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	Authorizations             *AuthorizationInputList `protobuf:"bytes,9,opt,name=authorizations,proto3" json:"authorizations,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *UpdateConsentRequest) Reset() {
+	*x = UpdateConsentRequest{}
+	mi := &file_consent_v1_consent_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConsentRequest) ProtoMessage() {}
+
+func (x *UpdateConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConsentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateConsentRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateConsentRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *UpdateConsentRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateConsentRequest) GetValidityTime() int64 {
+	if x != nil && x.ValidityTime != nil {
+		return *x.ValidityTime
+	}
+	return 0
+}
+
+func (x *UpdateConsentRequest) GetRecurringIndicator() bool {
+	if x != nil && x.RecurringIndicator != nil {
+		return *x.RecurringIndicator
+	}
+	return false
+}
+
+func (x *UpdateConsentRequest) GetFrequency() int32 {
+	if x != nil && x.Frequency != nil {
+		return *x.Frequency
+	}
+	return 0
+}
+
+func (x *UpdateConsentRequest) GetDataAccessValidityDuration() int64 {
+	if x != nil && x.DataAccessValidityDuration != nil {
+		return *x.DataAccessValidityDuration
+	}
+	return 0
+}
+
+func (x *UpdateConsentRequest) GetConsentPurpose() *ConsentPurposeList {
+	if x != nil {
+		return x.ConsentPurpose
+	}
+	return nil
+}
+
+func (x *UpdateConsentRequest) GetAttributes() *AttributeMap {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *UpdateConsentRequest) GetAuthorizations() *AuthorizationInputList {
+	if x != nil {
+		return x.Authorizations
+	}
+	return nil
+}
+
+type RevokeConsentRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId        string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	ActionBy         string                 `protobuf:"bytes,2,opt,name=action_by,json=actionBy,proto3" json:"action_by,omitempty"`
+	RevocationReason string                 `protobuf:"bytes,3,opt,name=revocation_reason,json=revocationReason,proto3" json:"revocation_reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RevokeConsentRequest) Reset() {
+	*x = RevokeConsentRequest{}
+	mi := &file_consent_v1_consent_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeConsentRequest) ProtoMessage() {}
+
+func (x *RevokeConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeConsentRequest.ProtoReflect.Descriptor instead.
+func (*RevokeConsentRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RevokeConsentRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *RevokeConsentRequest) GetActionBy() string {
+	if x != nil {
+		return x.ActionBy
+	}
+	return ""
+}
+
+func (x *RevokeConsentRequest) GetRevocationReason() string {
+	if x != nil {
+		return x.RevocationReason
+	}
+	return ""
+}
+
+type RevokeConsentResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ActionTime       int64                  `protobuf:"varint,1,opt,name=action_time,json=actionTime,proto3" json:"action_time,omitempty"`
+	ActionBy         string                 `protobuf:"bytes,2,opt,name=action_by,json=actionBy,proto3" json:"action_by,omitempty"`
+	RevocationReason string                 `protobuf:"bytes,3,opt,name=revocation_reason,json=revocationReason,proto3" json:"revocation_reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RevokeConsentResponse) Reset() {
+	*x = RevokeConsentResponse{}
+	mi := &file_consent_v1_consent_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeConsentResponse) ProtoMessage() {}
+
+func (x *RevokeConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeConsentResponse.ProtoReflect.Descriptor instead.
+func (*RevokeConsentResponse) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RevokeConsentResponse) GetActionTime() int64 {
+	if x != nil {
+		return x.ActionTime
+	}
+	return 0
+}
+
+func (x *RevokeConsentResponse) GetActionBy() string {
+	if x != nil {
+		return x.ActionBy
+	}
+	return ""
+}
+
+func (x *RevokeConsentResponse) GetRevocationReason() string {
+	if x != nil {
+		return x.RevocationReason
+	}
+	return ""
+}
+
+type ResourceParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      string                 `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	HttpMethod    string                 `protobuf:"bytes,2,opt,name=http_method,json=httpMethod,proto3" json:"http_method,omitempty"`
+	Context       string                 `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceParams) Reset() {
+	*x = ResourceParams{}
+	mi := &file_consent_v1_consent_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceParams) ProtoMessage() {}
+
+func (x *ResourceParams) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceParams.ProtoReflect.Descriptor instead.
+func (*ResourceParams) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResourceParams) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *ResourceParams) GetHttpMethod() string {
+	if x != nil {
+		return x.HttpMethod
+	}
+	return ""
+}
+
+func (x *ResourceParams) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+type ValidateConsentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConsentId       string                 `protobuf:"bytes,1,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ClientId        string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ElectedResource string                 `protobuf:"bytes,4,opt,name=elected_resource,json=electedResource,proto3" json:"elected_resource,omitempty"`
+	Headers         *structpb.Struct       `protobuf:"bytes,5,opt,name=headers,proto3" json:"headers,omitempty"`
+	Payload         *structpb.Struct       `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	ResourceParams  *ResourceParams        `protobuf:"bytes,7,opt,name=resource_params,json=resourceParams,proto3" json:"resource_params,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ValidateConsentRequest) Reset() {
+	*x = ValidateConsentRequest{}
+	mi := &file_consent_v1_consent_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateConsentRequest) ProtoMessage() {}
+
+func (x *ValidateConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateConsentRequest.ProtoReflect.Descriptor instead.
+func (*ValidateConsentRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ValidateConsentRequest) GetConsentId() string {
+	if x != nil {
+		return x.ConsentId
+	}
+	return ""
+}
+
+func (x *ValidateConsentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ValidateConsentRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ValidateConsentRequest) GetElectedResource() string {
+	if x != nil {
+		return x.ElectedResource
+	}
+	return ""
+}
+
+func (x *ValidateConsentRequest) GetHeaders() *structpb.Struct {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *ValidateConsentRequest) GetPayload() *structpb.Struct {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ValidateConsentRequest) GetResourceParams() *ResourceParams {
+	if x != nil {
+		return x.ResourceParams
+	}
+	return nil
+}
+
+type ValidateConsentResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	IsValid            bool                   `protobuf:"varint,1,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	ModifiedPayload    *structpb.Value        `protobuf:"bytes,2,opt,name=modified_payload,json=modifiedPayload,proto3" json:"modified_payload,omitempty"`
+	ErrorCode          int32                  `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorMessage       string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ErrorDescription   string                 `protobuf:"bytes,5,opt,name=error_description,json=errorDescription,proto3" json:"error_description,omitempty"`
+	ConsentInformation *Consent               `protobuf:"bytes,6,opt,name=consent_information,json=consentInformation,proto3" json:"consent_information,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ValidateConsentResponse) Reset() {
+	*x = ValidateConsentResponse{}
+	mi := &file_consent_v1_consent_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateConsentResponse) ProtoMessage() {}
+
+func (x *ValidateConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_consent_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateConsentResponse.ProtoReflect.Descriptor instead.
+func (*ValidateConsentResponse) Descriptor() ([]byte, []int) {
+	return file_consent_v1_consent_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ValidateConsentResponse) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *ValidateConsentResponse) GetModifiedPayload() *structpb.Value {
+	if x != nil {
+		return x.ModifiedPayload
+	}
+	return nil
+}
+
+func (x *ValidateConsentResponse) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *ValidateConsentResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ValidateConsentResponse) GetErrorDescription() string {
+	if x != nil {
+		return x.ErrorDescription
+	}
+	return ""
+}
+
+func (x *ValidateConsentResponse) GetConsentInformation() *Consent {
+	if x != nil {
+		return x.ConsentInformation
+	}
+	return nil
+}
+
+var File_consent_v1_consent_proto protoreflect.FileDescriptor
+
+const file_consent_v1_consent_proto_rawDesc = "" +
+	"\n" +
+	"\x18consent/v1/consent.proto\x12\x0fwso2.consent.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1econsent/v1/auth_resource.proto\x1a\x18consent/v1/purpose.proto\"\xe5\x02\n" +
+	"\x12ConsentPurposeItem\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value\x12-\n" +
+	"\x10is_user_approved\x18\x03 \x01(\bH\x00R\x0eisUserApproved\x88\x01\x01\x12&\n" +
+	"\fis_mandatory\x18\x04 \x01(\bH\x01R\visMandatory\x88\x01\x01\x12\x17\n" +
+	"\x04type\x18\x05 \x01(\tH\x02R\x04type\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x03R\vdescription\x88\x01\x01\x127\n" +
+	"\n" +
+	"attributes\x18\a \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributesB\x13\n" +
+	"\x11_is_user_approvedB\x0f\n" +
+	"\r_is_mandatoryB\a\n" +
+	"\x05_typeB\x0e\n" +
+	"\f_description\"\xec\x05\n" +
+	"\aConsent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12L\n" +
+	"\x0fconsent_purpose\x18\x02 \x03(\v2#.wso2.consent.v1.ConsentPurposeItemR\x0econsentPurpose\x12!\n" +
+	"\fcreated_time\x18\x03 \x01(\x03R\vcreatedTime\x12!\n" +
+	"\fupdated_time\x18\x04 \x01(\x03R\vupdatedTime\x12\x1b\n" +
+	"\tclient_id\x18\x05 \x01(\tR\bclientId\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12!\n" +
+	"\tfrequency\x18\b \x01(\x05H\x00R\tfrequency\x88\x01\x01\x12(\n" +
+	"\rvalidity_time\x18\t \x01(\x03H\x01R\fvalidityTime\x88\x01\x01\x124\n" +
+	"\x13recurring_indicator\x18\n" +
+	" \x01(\bH\x02R\x12recurringIndicator\x88\x01\x01\x12F\n" +
+	"\x1ddata_access_validity_duration\x18\v \x01(\x03H\x03R\x1adataAccessValidityDuration\x88\x01\x01\x12H\n" +
+	"\n" +
+	"attributes\x18\f \x03(\v2(.wso2.consent.v1.Consent.AttributesEntryR\n" +
+	"attributes\x12F\n" +
+	"\x0eauthorizations\x18\r \x03(\v2\x1e.wso2.consent.v1.AuthorizationR\x0eauthorizations\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_frequencyB\x10\n" +
+	"\x0e_validity_timeB\x16\n" +
+	"\x14_recurring_indicatorB \n" +
+	"\x1e_data_access_validity_duration\"\x80\x05\n" +
+	"\x14CreateConsentRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12(\n" +
+	"\rvalidity_time\x18\x02 \x01(\x03H\x00R\fvalidityTime\x88\x01\x01\x124\n" +
+	"\x13recurring_indicator\x18\x03 \x01(\bH\x01R\x12recurringIndicator\x88\x01\x01\x12!\n" +
+	"\tfrequency\x18\x04 \x01(\x05H\x02R\tfrequency\x88\x01\x01\x12F\n" +
+	"\x1ddata_access_validity_duration\x18\x05 \x01(\x03H\x03R\x1adataAccessValidityDuration\x88\x01\x01\x12L\n" +
+	"\x0fconsent_purpose\x18\x06 \x03(\v2#.wso2.consent.v1.ConsentPurposeItemR\x0econsentPurpose\x12U\n" +
+	"\n" +
+	"attributes\x18\a \x03(\v25.wso2.consent.v1.CreateConsentRequest.AttributesEntryR\n" +
+	"attributes\x12K\n" +
+	"\x0eauthorizations\x18\b \x03(\v2#.wso2.consent.v1.AuthorizationInputR\x0eauthorizations\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x10\n" +
+	"\x0e_validity_timeB\x16\n" +
+	"\x14_recurring_indicatorB\f\n" +
+	"\n" +
+	"_frequencyB \n" +
+	"\x1e_data_access_validity_duration\"2\n" +
+	"\x11GetConsentRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\"\x8d\x03\n" +
+	"\x15SearchConsentsRequest\x12#\n" +
+	"\rconsent_types\x18\x01 \x03(\tR\fconsentTypes\x12)\n" +
+	"\x10consent_statuses\x18\x02 \x03(\tR\x0fconsentStatuses\x12\x1d\n" +
+	"\n" +
+	"client_ids\x18\x03 \x03(\tR\tclientIds\x12\x19\n" +
+	"\buser_ids\x18\x04 \x03(\tR\auserIds\x12 \n" +
+	"\tfrom_time\x18\x05 \x01(\x03H\x00R\bfromTime\x88\x01\x01\x12\x1c\n" +
+	"\ato_time\x18\x06 \x01(\x03H\x01R\x06toTime\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\b \x01(\x05R\x06offset\x12\x1b\n" +
+	"\x06cursor\x18\t \x01(\tH\x02R\x06cursor\x88\x01\x01\x12(\n" +
+	"\rinclude_total\x18\n" +
+	" \x01(\bH\x03R\fincludeTotal\x88\x01\x01B\f\n" +
+	"\n" +
+	"_from_timeB\n" +
+	"\n" +
+	"\b_to_timeB\t\n" +
+	"\a_cursorB\x10\n" +
+	"\x0e_include_total\"\x87\x01\n" +
+	"\x16SearchConsentsResponse\x12,\n" +
+	"\x04data\x18\x01 \x03(\v2\x18.wso2.consent.v1.ConsentR\x04data\x12?\n" +
+	"\bmetadata\x18\x02 \x01(\v2#.wso2.consent.v1.PaginationMetadataR\bmetadata\"O\n" +
+	"\x12ConsentPurposeList\x129\n" +
+	"\x05items\x18\x01 \x03(\v2#.wso2.consent.v1.ConsentPurposeItemR\x05items\"\x88\x01\n" +
+	"\fAttributeMap\x12>\n" +
+	"\x05items\x18\x01 \x03(\v2(.wso2.consent.v1.AttributeMap.ItemsEntryR\x05items\x1a8\n" +
+	"\n" +
+	"ItemsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"S\n" +
+	"\x16AuthorizationInputList\x129\n" +
+	"\x05items\x18\x01 \x03(\v2#.wso2.consent.v1.AuthorizationInputR\x05items\"\xcc\x04\n" +
+	"\x14UpdateConsentRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12(\n" +
+	"\rvalidity_time\x18\x03 \x01(\x03H\x00R\fvalidityTime\x88\x01\x01\x124\n" +
+	"\x13recurring_indicator\x18\x04 \x01(\bH\x01R\x12recurringIndicator\x88\x01\x01\x12!\n" +
+	"\tfrequency\x18\x05 \x01(\x05H\x02R\tfrequency\x88\x01\x01\x12F\n" +
+	"\x1ddata_access_validity_duration\x18\x06 \x01(\x03H\x03R\x1adataAccessValidityDuration\x88\x01\x01\x12L\n" +
+	"\x0fconsent_purpose\x18\a \x01(\v2#.wso2.consent.v1.ConsentPurposeListR\x0econsentPurpose\x12=\n" +
+	"\n" +
+	"attributes\x18\b \x01(\v2\x1d.wso2.consent.v1.AttributeMapR\n" +
+	"attributes\x12O\n" +
+	"\x0eauthorizations\x18\t \x01(\v2'.wso2.consent.v1.AuthorizationInputListR\x0eauthorizationsB\x10\n" +
+	"\x0e_validity_timeB\x16\n" +
+	"\x14_recurring_indicatorB\f\n" +
+	"\n" +
+	"_frequencyB \n" +
+	"\x1e_data_access_validity_duration\"\x7f\n" +
+	"\x14RevokeConsentRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\x12\x1b\n" +
+	"\taction_by\x18\x02 \x01(\tR\bactionBy\x12+\n" +
+	"\x11revocation_reason\x18\x03 \x01(\tR\x10revocationReason\"\x82\x01\n" +
+	"\x15RevokeConsentResponse\x12\x1f\n" +
+	"\vaction_time\x18\x01 \x01(\x03R\n" +
+	"actionTime\x12\x1b\n" +
+	"\taction_by\x18\x02 \x01(\tR\bactionBy\x12+\n" +
+	"\x11revocation_reason\x18\x03 \x01(\tR\x10revocationReason\"g\n" +
+	"\x0eResourceParams\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x1f\n" +
+	"\vhttp_method\x18\x02 \x01(\tR\n" +
+	"httpMethod\x12\x18\n" +
+	"\acontext\x18\x03 \x01(\tR\acontext\"\xc8\x02\n" +
+	"\x16ValidateConsentRequest\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x01 \x01(\tR\tconsentId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12)\n" +
+	"\x10elected_resource\x18\x04 \x01(\tR\x0felectedResource\x121\n" +
+	"\aheaders\x18\x05 \x01(\v2\x17.google.protobuf.StructR\aheaders\x121\n" +
+	"\apayload\x18\x06 \x01(\v2\x17.google.protobuf.StructR\apayload\x12H\n" +
+	"\x0fresource_params\x18\a \x01(\v2\x1f.wso2.consent.v1.ResourceParamsR\x0eresourceParams\"\xb3\x02\n" +
+	"\x17ValidateConsentResponse\x12\x19\n" +
+	"\bis_valid\x18\x01 \x01(\bR\aisValid\x12A\n" +
+	"\x10modified_payload\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x0fmodifiedPayload\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x05R\terrorCode\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12+\n" +
+	"\x11error_description\x18\x05 \x01(\tR\x10errorDescription\x12I\n" +
+	"\x13consent_information\x18\x06 \x01(\v2\x18.wso2.consent.v1.ConsentR\x12consentInformation2\xa9\x04\n" +
+	"\x0eConsentService\x12P\n" +
+	"\rCreateConsent\x12%.wso2.consent.v1.CreateConsentRequest\x1a\x18.wso2.consent.v1.Consent\x12J\n" +
+	"\n" +
+	"GetConsent\x12\".wso2.consent.v1.GetConsentRequest\x1a\x18.wso2.consent.v1.Consent\x12a\n" +
+	"\x0eSearchConsents\x12&.wso2.consent.v1.SearchConsentsRequest\x1a'.wso2.consent.v1.SearchConsentsResponse\x12P\n" +
+	"\rUpdateConsent\x12%.wso2.consent.v1.UpdateConsentRequest\x1a\x18.wso2.consent.v1.Consent\x12^\n" +
+	"\rRevokeConsent\x12%.wso2.consent.v1.RevokeConsentRequest\x1a&.wso2.consent.v1.RevokeConsentResponse\x12d\n" +
+	"\x0fValidateConsent\x12'.wso2.consent.v1.ValidateConsentRequest\x1a(.wso2.consent.v1.ValidateConsentResponseBPZNgithub.com/wso2/consent-management-api/internal/grpcapi/pb/consentv1;consentv1b\x06proto3"
+
+var (
+	file_consent_v1_consent_proto_rawDescOnce sync.Once
+	file_consent_v1_consent_proto_rawDescData []byte
+)
+
+func file_consent_v1_consent_proto_rawDescGZIP() []byte {
+	file_consent_v1_consent_proto_rawDescOnce.Do(func() {
+		file_consent_v1_consent_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_consent_v1_consent_proto_rawDesc), len(file_consent_v1_consent_proto_rawDesc)))
+	})
+	return file_consent_v1_consent_proto_rawDescData
+}
+
+var file_consent_v1_consent_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_consent_v1_consent_proto_goTypes = []any{
+	(*ConsentPurposeItem)(nil),      // 0: wso2.consent.v1.ConsentPurposeItem
+	(*Consent)(nil),                 // 1: wso2.consent.v1.Consent
+	(*CreateConsentRequest)(nil),    // 2: wso2.consent.v1.CreateConsentRequest
+	(*GetConsentRequest)(nil),       // 3: wso2.consent.v1.GetConsentRequest
+	(*SearchConsentsRequest)(nil),   // 4: wso2.consent.v1.SearchConsentsRequest
+	(*SearchConsentsResponse)(nil),  // 5: wso2.consent.v1.SearchConsentsResponse
+	(*ConsentPurposeList)(nil),      // 6: wso2.consent.v1.ConsentPurposeList
+	(*AttributeMap)(nil),            // 7: wso2.consent.v1.AttributeMap
+	(*AuthorizationInputList)(nil),  // 8: wso2.consent.v1.AuthorizationInputList
+	(*UpdateConsentRequest)(nil),    // 9: wso2.consent.v1.UpdateConsentRequest
+	(*RevokeConsentRequest)(nil),    // 10: wso2.consent.v1.RevokeConsentRequest
+	(*RevokeConsentResponse)(nil),   // 11: wso2.consent.v1.RevokeConsentResponse
+	(*ResourceParams)(nil),          // 12: wso2.consent.v1.ResourceParams
+	(*ValidateConsentRequest)(nil),  // 13: wso2.consent.v1.ValidateConsentRequest
+	(*ValidateConsentResponse)(nil), // 14: wso2.consent.v1.ValidateConsentResponse
+	nil,                             // 15: wso2.consent.v1.Consent.AttributesEntry
+	nil,                             // 16: wso2.consent.v1.CreateConsentRequest.AttributesEntry
+	nil,                             // 17: wso2.consent.v1.AttributeMap.ItemsEntry
+	(*structpb.Value)(nil),          // 18: google.protobuf.Value
+	(*structpb.Struct)(nil),         // 19: google.protobuf.Struct
+	(*Authorization)(nil),           // 20: wso2.consent.v1.Authorization
+	(*AuthorizationInput)(nil),      // 21: wso2.consent.v1.AuthorizationInput
+	(*PaginationMetadata)(nil),      // 22: wso2.consent.v1.PaginationMetadata
+}
+var file_consent_v1_consent_proto_depIdxs = []int32{
+	18, // 0: wso2.consent.v1.ConsentPurposeItem.value:type_name -> google.protobuf.Value
+	19, // 1: wso2.consent.v1.ConsentPurposeItem.attributes:type_name -> google.protobuf.Struct
+	0,  // 2: wso2.consent.v1.Consent.consent_purpose:type_name -> wso2.consent.v1.ConsentPurposeItem
+	15, // 3: wso2.consent.v1.Consent.attributes:type_name -> wso2.consent.v1.Consent.AttributesEntry
+	20, // 4: wso2.consent.v1.Consent.authorizations:type_name -> wso2.consent.v1.Authorization
+	0,  // 5: wso2.consent.v1.CreateConsentRequest.consent_purpose:type_name -> wso2.consent.v1.ConsentPurposeItem
+	16, // 6: wso2.consent.v1.CreateConsentRequest.attributes:type_name -> wso2.consent.v1.CreateConsentRequest.AttributesEntry
+	21, // 7: wso2.consent.v1.CreateConsentRequest.authorizations:type_name -> wso2.consent.v1.AuthorizationInput
+	1,  // 8: wso2.consent.v1.SearchConsentsResponse.data:type_name -> wso2.consent.v1.Consent
+	22, // 9: wso2.consent.v1.SearchConsentsResponse.metadata:type_name -> wso2.consent.v1.PaginationMetadata
+	0,  // 10: wso2.consent.v1.ConsentPurposeList.items:type_name -> wso2.consent.v1.ConsentPurposeItem
+	17, // 11: wso2.consent.v1.AttributeMap.items:type_name -> wso2.consent.v1.AttributeMap.ItemsEntry
+	21, // 12: wso2.consent.v1.AuthorizationInputList.items:type_name -> wso2.consent.v1.AuthorizationInput
+	6,  // 13: wso2.consent.v1.UpdateConsentRequest.consent_purpose:type_name -> wso2.consent.v1.ConsentPurposeList
+	7,  // 14: wso2.consent.v1.UpdateConsentRequest.attributes:type_name -> wso2.consent.v1.AttributeMap
+	8,  // 15: wso2.consent.v1.UpdateConsentRequest.authorizations:type_name -> wso2.consent.v1.AuthorizationInputList
+	19, // 16: wso2.consent.v1.ValidateConsentRequest.headers:type_name -> google.protobuf.Struct
+	19, // 17: wso2.consent.v1.ValidateConsentRequest.payload:type_name -> google.protobuf.Struct
+	12, // 18: wso2.consent.v1.ValidateConsentRequest.resource_params:type_name -> wso2.consent.v1.ResourceParams
+	18, // 19: wso2.consent.v1.ValidateConsentResponse.modified_payload:type_name -> google.protobuf.Value
+	1,  // 20: wso2.consent.v1.ValidateConsentResponse.consent_information:type_name -> wso2.consent.v1.Consent
+	2,  // 21: wso2.consent.v1.ConsentService.CreateConsent:input_type -> wso2.consent.v1.CreateConsentRequest
+	3,  // 22: wso2.consent.v1.ConsentService.GetConsent:input_type -> wso2.consent.v1.GetConsentRequest
+	4,  // 23: wso2.consent.v1.ConsentService.SearchConsents:input_type -> wso2.consent.v1.SearchConsentsRequest
+	9,  // 24: wso2.consent.v1.ConsentService.UpdateConsent:input_type -> wso2.consent.v1.UpdateConsentRequest
+	10, // 25: wso2.consent.v1.ConsentService.RevokeConsent:input_type -> wso2.consent.v1.RevokeConsentRequest
+	13, // 26: wso2.consent.v1.ConsentService.ValidateConsent:input_type -> wso2.consent.v1.ValidateConsentRequest
+	1,  // 27: wso2.consent.v1.ConsentService.CreateConsent:output_type -> wso2.consent.v1.Consent
+	1,  // 28: wso2.consent.v1.ConsentService.GetConsent:output_type -> wso2.consent.v1.Consent
+	5,  // 29: wso2.consent.v1.ConsentService.SearchConsents:output_type -> wso2.consent.v1.SearchConsentsResponse
+	1,  // 30: wso2.consent.v1.ConsentService.UpdateConsent:output_type -> wso2.consent.v1.Consent
+	11, // 31: wso2.consent.v1.ConsentService.RevokeConsent:output_type -> wso2.consent.v1.RevokeConsentResponse
+	14, // 32: wso2.consent.v1.ConsentService.ValidateConsent:output_type -> wso2.consent.v1.ValidateConsentResponse
+	27, // [27:33] is the sub-list for method output_type
+	21, // [21:27] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_consent_v1_consent_proto_init() }
+func file_consent_v1_consent_proto_init() {
+	if File_consent_v1_consent_proto != nil {
+		return
+	}
+	file_consent_v1_auth_resource_proto_init()
+	file_consent_v1_purpose_proto_init()
+	file_consent_v1_consent_proto_msgTypes[0].OneofWrappers = []any{}
+	file_consent_v1_consent_proto_msgTypes[1].OneofWrappers = []any{}
+	file_consent_v1_consent_proto_msgTypes[2].OneofWrappers = []any{}
+	file_consent_v1_consent_proto_msgTypes[4].OneofWrappers = []any{}
+	file_consent_v1_consent_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_consent_v1_consent_proto_rawDesc), len(file_consent_v1_consent_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_consent_v1_consent_proto_goTypes,
+		DependencyIndexes: file_consent_v1_consent_proto_depIdxs,
+		MessageInfos:      file_consent_v1_consent_proto_msgTypes,
+	}.Build()
+	File_consent_v1_consent_proto = out.File
+	file_consent_v1_consent_proto_goTypes = nil
+	file_consent_v1_consent_proto_depIdxs = nil
+}
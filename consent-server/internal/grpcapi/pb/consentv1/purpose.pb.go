@@ -0,0 +1,790 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: consent/v1/purpose.proto
+
+package consentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Purpose is a consent purpose definition
+type Purpose struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Purpose) Reset() {
+	*x = Purpose{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Purpose) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Purpose) ProtoMessage() {}
+
+func (x *Purpose) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Purpose.ProtoReflect.Descriptor instead.
+func (*Purpose) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Purpose) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Purpose) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Purpose) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Purpose) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Purpose) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type PurposeInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurposeInput) Reset() {
+	*x = PurposeInput{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurposeInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurposeInput) ProtoMessage() {}
+
+func (x *PurposeInput) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurposeInput.ProtoReflect.Descriptor instead.
+func (*PurposeInput) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PurposeInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PurposeInput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PurposeInput) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PurposeInput) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type CreatePurposesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purposes      []*PurposeInput        `protobuf:"bytes,1,rep,name=purposes,proto3" json:"purposes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePurposesRequest) Reset() {
+	*x = CreatePurposesRequest{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePurposesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePurposesRequest) ProtoMessage() {}
+
+func (x *CreatePurposesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePurposesRequest.ProtoReflect.Descriptor instead.
+func (*CreatePurposesRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreatePurposesRequest) GetPurposes() []*PurposeInput {
+	if x != nil {
+		return x.Purposes
+	}
+	return nil
+}
+
+type CreatePurposesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Purpose             `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePurposesResponse) Reset() {
+	*x = CreatePurposesResponse{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePurposesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePurposesResponse) ProtoMessage() {}
+
+func (x *CreatePurposesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePurposesResponse.ProtoReflect.Descriptor instead.
+func (*CreatePurposesResponse) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreatePurposesResponse) GetData() []*Purpose {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetPurposeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurposeId     string                 `protobuf:"bytes,1,opt,name=purpose_id,json=purposeId,proto3" json:"purpose_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPurposeRequest) Reset() {
+	*x = GetPurposeRequest{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPurposeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPurposeRequest) ProtoMessage() {}
+
+func (x *GetPurposeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPurposeRequest.ProtoReflect.Descriptor instead.
+func (*GetPurposeRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPurposeRequest) GetPurposeId() string {
+	if x != nil {
+		return x.PurposeId
+	}
+	return ""
+}
+
+type ListPurposesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 100 when not set, maximum 100
+	Limit  int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Optional exact name filter
+	Name          string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPurposesRequest) Reset() {
+	*x = ListPurposesRequest{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPurposesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPurposesRequest) ProtoMessage() {}
+
+func (x *ListPurposesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPurposesRequest.ProtoReflect.Descriptor instead.
+func (*ListPurposesRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListPurposesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPurposesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListPurposesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListPurposesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Purpose             `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Metadata      *PaginationMetadata    `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPurposesResponse) Reset() {
+	*x = ListPurposesResponse{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPurposesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPurposesResponse) ProtoMessage() {}
+
+func (x *ListPurposesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPurposesResponse.ProtoReflect.Descriptor instead.
+func (*ListPurposesResponse) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListPurposesResponse) GetData() []*Purpose {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListPurposesResponse) GetMetadata() *PaginationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type UpdatePurposeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurposeId     string                 `protobuf:"bytes,1,opt,name=purpose_id,json=purposeId,proto3" json:"purpose_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePurposeRequest) Reset() {
+	*x = UpdatePurposeRequest{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePurposeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePurposeRequest) ProtoMessage() {}
+
+func (x *UpdatePurposeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePurposeRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePurposeRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdatePurposeRequest) GetPurposeId() string {
+	if x != nil {
+		return x.PurposeId
+	}
+	return ""
+}
+
+func (x *UpdatePurposeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePurposeRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdatePurposeRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdatePurposeRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type DeletePurposeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurposeId     string                 `protobuf:"bytes,1,opt,name=purpose_id,json=purposeId,proto3" json:"purpose_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePurposeRequest) Reset() {
+	*x = DeletePurposeRequest{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePurposeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePurposeRequest) ProtoMessage() {}
+
+func (x *DeletePurposeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePurposeRequest.ProtoReflect.Descriptor instead.
+func (*DeletePurposeRequest) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeletePurposeRequest) GetPurposeId() string {
+	if x != nil {
+		return x.PurposeId
+	}
+	return ""
+}
+
+// PaginationMetadata describes a page of list results
+type PaginationMetadata struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Total  int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Count  int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	// Token for the next page in cursor mode, empty on the last page
+	NextCursor string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// Whether more results follow this page
+	HasMore       bool `protobuf:"varint,6,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaginationMetadata) Reset() {
+	*x = PaginationMetadata{}
+	mi := &file_consent_v1_purpose_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaginationMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaginationMetadata) ProtoMessage() {}
+
+func (x *PaginationMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_consent_v1_purpose_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaginationMetadata.ProtoReflect.Descriptor instead.
+func (*PaginationMetadata) Descriptor() ([]byte, []int) {
+	return file_consent_v1_purpose_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PaginationMetadata) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PaginationMetadata) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PaginationMetadata) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PaginationMetadata) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PaginationMetadata) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *PaginationMetadata) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+var File_consent_v1_purpose_proto protoreflect.FileDescriptor
+
+const file_consent_v1_purpose_proto_rawDesc = "" +
+	"\n" +
+	"\x18consent/v1/purpose.proto\x12\x0fwso2.consent.v1\x1a\x1bgoogle/protobuf/empty.proto\"\x81\x02\n" +
+	"\aPurpose\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12H\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v2(.wso2.consent.v1.Purpose.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_description\"\xe6\x01\n" +
+	"\fPurposeInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12M\n" +
+	"\n" +
+	"attributes\x18\x04 \x03(\v2-.wso2.consent.v1.PurposeInput.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"R\n" +
+	"\x15CreatePurposesRequest\x129\n" +
+	"\bpurposes\x18\x01 \x03(\v2\x1d.wso2.consent.v1.PurposeInputR\bpurposes\"F\n" +
+	"\x16CreatePurposesResponse\x12,\n" +
+	"\x04data\x18\x01 \x03(\v2\x18.wso2.consent.v1.PurposeR\x04data\"2\n" +
+	"\x11GetPurposeRequest\x12\x1d\n" +
+	"\n" +
+	"purpose_id\x18\x01 \x01(\tR\tpurposeId\"W\n" +
+	"\x13ListPurposesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\x85\x01\n" +
+	"\x14ListPurposesResponse\x12,\n" +
+	"\x04data\x18\x01 \x03(\v2\x18.wso2.consent.v1.PurposeR\x04data\x12?\n" +
+	"\bmetadata\x18\x02 \x01(\v2#.wso2.consent.v1.PaginationMetadataR\bmetadata\"\xaa\x02\n" +
+	"\x14UpdatePurposeRequest\x12\x1d\n" +
+	"\n" +
+	"purpose_id\x18\x01 \x01(\tR\tpurposeId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12U\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v25.wso2.consent.v1.UpdatePurposeRequest.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_description\"5\n" +
+	"\x14DeletePurposeRequest\x12\x1d\n" +
+	"\n" +
+	"purpose_id\x18\x01 \x01(\tR\tpurposeId\"\xaa\x01\n" +
+	"\x12PaginationMetadata\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x06 \x01(\bR\ahasMore2\xbe\x03\n" +
+	"\x0ePurposeService\x12a\n" +
+	"\x0eCreatePurposes\x12&.wso2.consent.v1.CreatePurposesRequest\x1a'.wso2.consent.v1.CreatePurposesResponse\x12J\n" +
+	"\n" +
+	"GetPurpose\x12\".wso2.consent.v1.GetPurposeRequest\x1a\x18.wso2.consent.v1.Purpose\x12[\n" +
+	"\fListPurposes\x12$.wso2.consent.v1.ListPurposesRequest\x1a%.wso2.consent.v1.ListPurposesResponse\x12P\n" +
+	"\rUpdatePurpose\x12%.wso2.consent.v1.UpdatePurposeRequest\x1a\x18.wso2.consent.v1.Purpose\x12N\n" +
+	"\rDeletePurpose\x12%.wso2.consent.v1.DeletePurposeRequest\x1a\x16.google.protobuf.EmptyBPZNgithub.com/wso2/consent-management-api/internal/grpcapi/pb/consentv1;consentv1b\x06proto3"
+
+var (
+	file_consent_v1_purpose_proto_rawDescOnce sync.Once
+	file_consent_v1_purpose_proto_rawDescData []byte
+)
+
+func file_consent_v1_purpose_proto_rawDescGZIP() []byte {
+	file_consent_v1_purpose_proto_rawDescOnce.Do(func() {
+		file_consent_v1_purpose_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_consent_v1_purpose_proto_rawDesc), len(file_consent_v1_purpose_proto_rawDesc)))
+	})
+	return file_consent_v1_purpose_proto_rawDescData
+}
+
+var file_consent_v1_purpose_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_consent_v1_purpose_proto_goTypes = []any{
+	(*Purpose)(nil),                // 0: wso2.consent.v1.Purpose
+	(*PurposeInput)(nil),           // 1: wso2.consent.v1.PurposeInput
+	(*CreatePurposesRequest)(nil),  // 2: wso2.consent.v1.CreatePurposesRequest
+	(*CreatePurposesResponse)(nil), // 3: wso2.consent.v1.CreatePurposesResponse
+	(*GetPurposeRequest)(nil),      // 4: wso2.consent.v1.GetPurposeRequest
+	(*ListPurposesRequest)(nil),    // 5: wso2.consent.v1.ListPurposesRequest
+	(*ListPurposesResponse)(nil),   // 6: wso2.consent.v1.ListPurposesResponse
+	(*UpdatePurposeRequest)(nil),   // 7: wso2.consent.v1.UpdatePurposeRequest
+	(*DeletePurposeRequest)(nil),   // 8: wso2.consent.v1.DeletePurposeRequest
+	(*PaginationMetadata)(nil),     // 9: wso2.consent.v1.PaginationMetadata
+	nil,                            // 10: wso2.consent.v1.Purpose.AttributesEntry
+	nil,                            // 11: wso2.consent.v1.PurposeInput.AttributesEntry
+	nil,                            // 12: wso2.consent.v1.UpdatePurposeRequest.AttributesEntry
+	(*emptypb.Empty)(nil),          // 13: google.protobuf.Empty
+}
+var file_consent_v1_purpose_proto_depIdxs = []int32{
+	10, // 0: wso2.consent.v1.Purpose.attributes:type_name -> wso2.consent.v1.Purpose.AttributesEntry
+	11, // 1: wso2.consent.v1.PurposeInput.attributes:type_name -> wso2.consent.v1.PurposeInput.AttributesEntry
+	1,  // 2: wso2.consent.v1.CreatePurposesRequest.purposes:type_name -> wso2.consent.v1.PurposeInput
+	0,  // 3: wso2.consent.v1.CreatePurposesResponse.data:type_name -> wso2.consent.v1.Purpose
+	0,  // 4: wso2.consent.v1.ListPurposesResponse.data:type_name -> wso2.consent.v1.Purpose
+	9,  // 5: wso2.consent.v1.ListPurposesResponse.metadata:type_name -> wso2.consent.v1.PaginationMetadata
+	12, // 6: wso2.consent.v1.UpdatePurposeRequest.attributes:type_name -> wso2.consent.v1.UpdatePurposeRequest.AttributesEntry
+	2,  // 7: wso2.consent.v1.PurposeService.CreatePurposes:input_type -> wso2.consent.v1.CreatePurposesRequest
+	4,  // 8: wso2.consent.v1.PurposeService.GetPurpose:input_type -> wso2.consent.v1.GetPurposeRequest
+	5,  // 9: wso2.consent.v1.PurposeService.ListPurposes:input_type -> wso2.consent.v1.ListPurposesRequest
+	7,  // 10: wso2.consent.v1.PurposeService.UpdatePurpose:input_type -> wso2.consent.v1.UpdatePurposeRequest
+	8,  // 11: wso2.consent.v1.PurposeService.DeletePurpose:input_type -> wso2.consent.v1.DeletePurposeRequest
+	3,  // 12: wso2.consent.v1.PurposeService.CreatePurposes:output_type -> wso2.consent.v1.CreatePurposesResponse
+	0,  // 13: wso2.consent.v1.PurposeService.GetPurpose:output_type -> wso2.consent.v1.Purpose
+	6,  // 14: wso2.consent.v1.PurposeService.ListPurposes:output_type -> wso2.consent.v1.ListPurposesResponse
+	0,  // 15: wso2.consent.v1.PurposeService.UpdatePurpose:output_type -> wso2.consent.v1.Purpose
+	13, // 16: wso2.consent.v1.PurposeService.DeletePurpose:output_type -> google.protobuf.Empty
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_consent_v1_purpose_proto_init() }
+func file_consent_v1_purpose_proto_init() {
+	if File_consent_v1_purpose_proto != nil {
+		return
+	}
+	file_consent_v1_purpose_proto_msgTypes[0].OneofWrappers = []any{}
+	file_consent_v1_purpose_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_consent_v1_purpose_proto_rawDesc), len(file_consent_v1_purpose_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_consent_v1_purpose_proto_goTypes,
+		DependencyIndexes: file_consent_v1_purpose_proto_depIdxs,
+		MessageInfos:      file_consent_v1_purpose_proto_msgTypes,
+	}.Build()
+	File_consent_v1_purpose_proto = out.File
+	file_consent_v1_purpose_proto_goTypes = nil
+	file_consent_v1_purpose_proto_depIdxs = nil
+}
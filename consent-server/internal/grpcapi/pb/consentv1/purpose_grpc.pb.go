@@ -0,0 +1,298 @@
+// Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+//
+// WSO2 LLC. licenses this file to you under the Apache License,
+// Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: consent/v1/purpose.proto
+
+package consentv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PurposeService_CreatePurposes_FullMethodName = "/wso2.consent.v1.PurposeService/CreatePurposes"
+	PurposeService_GetPurpose_FullMethodName     = "/wso2.consent.v1.PurposeService/GetPurpose"
+	PurposeService_ListPurposes_FullMethodName   = "/wso2.consent.v1.PurposeService/ListPurposes"
+	PurposeService_UpdatePurpose_FullMethodName  = "/wso2.consent.v1.PurposeService/UpdatePurpose"
+	PurposeService_DeletePurpose_FullMethodName  = "/wso2.consent.v1.PurposeService/DeletePurpose"
+)
+
+// PurposeServiceClient is the client API for PurposeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PurposeService manages consent purpose definitions.
+// The organization is read from the "org-id" request metadata.
+type PurposeServiceClient interface {
+	// CreatePurposes creates all purposes in a single transaction
+	CreatePurposes(ctx context.Context, in *CreatePurposesRequest, opts ...grpc.CallOption) (*CreatePurposesResponse, error)
+	GetPurpose(ctx context.Context, in *GetPurposeRequest, opts ...grpc.CallOption) (*Purpose, error)
+	ListPurposes(ctx context.Context, in *ListPurposesRequest, opts ...grpc.CallOption) (*ListPurposesResponse, error)
+	UpdatePurpose(ctx context.Context, in *UpdatePurposeRequest, opts ...grpc.CallOption) (*Purpose, error)
+	DeletePurpose(ctx context.Context, in *DeletePurposeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type purposeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPurposeServiceClient(cc grpc.ClientConnInterface) PurposeServiceClient {
+	return &purposeServiceClient{cc}
+}
+
+func (c *purposeServiceClient) CreatePurposes(ctx context.Context, in *CreatePurposesRequest, opts ...grpc.CallOption) (*CreatePurposesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePurposesResponse)
+	err := c.cc.Invoke(ctx, PurposeService_CreatePurposes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purposeServiceClient) GetPurpose(ctx context.Context, in *GetPurposeRequest, opts ...grpc.CallOption) (*Purpose, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Purpose)
+	err := c.cc.Invoke(ctx, PurposeService_GetPurpose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purposeServiceClient) ListPurposes(ctx context.Context, in *ListPurposesRequest, opts ...grpc.CallOption) (*ListPurposesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPurposesResponse)
+	err := c.cc.Invoke(ctx, PurposeService_ListPurposes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purposeServiceClient) UpdatePurpose(ctx context.Context, in *UpdatePurposeRequest, opts ...grpc.CallOption) (*Purpose, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Purpose)
+	err := c.cc.Invoke(ctx, PurposeService_UpdatePurpose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purposeServiceClient) DeletePurpose(ctx context.Context, in *DeletePurposeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PurposeService_DeletePurpose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PurposeServiceServer is the server API for PurposeService service.
+// All implementations must embed UnimplementedPurposeServiceServer
+// for forward compatibility.
+//
+// PurposeService manages consent purpose definitions.
+// The organization is read from the "org-id" request metadata.
+type PurposeServiceServer interface {
+	// CreatePurposes creates all purposes in a single transaction
+	CreatePurposes(context.Context, *CreatePurposesRequest) (*CreatePurposesResponse, error)
+	GetPurpose(context.Context, *GetPurposeRequest) (*Purpose, error)
+	ListPurposes(context.Context, *ListPurposesRequest) (*ListPurposesResponse, error)
+	UpdatePurpose(context.Context, *UpdatePurposeRequest) (*Purpose, error)
+	DeletePurpose(context.Context, *DeletePurposeRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPurposeServiceServer()
+}
+
+// UnimplementedPurposeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPurposeServiceServer struct{}
+
+func (UnimplementedPurposeServiceServer) CreatePurposes(context.Context, *CreatePurposesRequest) (*CreatePurposesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePurposes not implemented")
+}
+func (UnimplementedPurposeServiceServer) GetPurpose(context.Context, *GetPurposeRequest) (*Purpose, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPurpose not implemented")
+}
+func (UnimplementedPurposeServiceServer) ListPurposes(context.Context, *ListPurposesRequest) (*ListPurposesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPurposes not implemented")
+}
+func (UnimplementedPurposeServiceServer) UpdatePurpose(context.Context, *UpdatePurposeRequest) (*Purpose, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePurpose not implemented")
+}
+func (UnimplementedPurposeServiceServer) DeletePurpose(context.Context, *DeletePurposeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePurpose not implemented")
+}
+func (UnimplementedPurposeServiceServer) mustEmbedUnimplementedPurposeServiceServer() {}
+func (UnimplementedPurposeServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePurposeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PurposeServiceServer will
+// result in compilation errors.
+type UnsafePurposeServiceServer interface {
+	mustEmbedUnimplementedPurposeServiceServer()
+}
+
+func RegisterPurposeServiceServer(s grpc.ServiceRegistrar, srv PurposeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPurposeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PurposeService_ServiceDesc, srv)
+}
+
+func _PurposeService_CreatePurposes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePurposesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurposeServiceServer).CreatePurposes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurposeService_CreatePurposes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurposeServiceServer).CreatePurposes(ctx, req.(*CreatePurposesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurposeService_GetPurpose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPurposeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurposeServiceServer).GetPurpose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurposeService_GetPurpose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurposeServiceServer).GetPurpose(ctx, req.(*GetPurposeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurposeService_ListPurposes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPurposesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurposeServiceServer).ListPurposes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurposeService_ListPurposes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurposeServiceServer).ListPurposes(ctx, req.(*ListPurposesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurposeService_UpdatePurpose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePurposeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurposeServiceServer).UpdatePurpose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurposeService_UpdatePurpose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurposeServiceServer).UpdatePurpose(ctx, req.(*UpdatePurposeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PurposeService_DeletePurpose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePurposeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurposeServiceServer).DeletePurpose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PurposeService_DeletePurpose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurposeServiceServer).DeletePurpose(ctx, req.(*DeletePurposeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PurposeService_ServiceDesc is the grpc.ServiceDesc for PurposeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PurposeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wso2.consent.v1.PurposeService",
+	HandlerType: (*PurposeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePurposes",
+			Handler:    _PurposeService_CreatePurposes_Handler,
+		},
+		{
+			MethodName: "GetPurpose",
+			Handler:    _PurposeService_GetPurpose_Handler,
+		},
+		{
+			MethodName: "ListPurposes",
+			Handler:    _PurposeService_ListPurposes_Handler,
+		},
+		{
+			MethodName: "UpdatePurpose",
+			Handler:    _PurposeService_UpdatePurpose_Handler,
+		},
+		{
+			MethodName: "DeletePurpose",
+			Handler:    _PurposeService_DeletePurpose_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consent/v1/purpose.proto",
+}
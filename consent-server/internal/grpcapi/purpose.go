@@ -0,0 +1,161 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/wso2/consent-management-api/internal/consentpurpose"
+	"github.com/wso2/consent-management-api/internal/consentpurpose/model"
+	"github.com/wso2/consent-management-api/internal/grpcapi/pb/consentv1"
+)
+
+const (
+	defaultPurposeListLimit = 100
+	maxPurposeListLimit     = 100
+)
+
+// purposeServer implements the PurposeService gRPC API on top of the consent purpose service
+type purposeServer struct {
+	consentv1.UnimplementedPurposeServiceServer
+	service consentpurpose.ConsentPurposeService
+}
+
+// newPurposeServer creates a new purpose gRPC server
+func newPurposeServer(service consentpurpose.ConsentPurposeService) *purposeServer {
+	return &purposeServer{
+		service: service,
+	}
+}
+
+// CreatePurposes creates all purposes in a single transaction
+func (s *purposeServer) CreatePurposes(ctx context.Context, req *consentv1.CreatePurposesRequest) (*consentv1.CreatePurposesResponse, error) {
+	orgID, _, err := requireOrgAndClientID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetPurposes()) == 0 {
+		return nil, invalidArgument("at least one purpose must be provided")
+	}
+
+	requests := make([]model.CreateRequest, 0, len(req.GetPurposes()))
+	for _, p := range req.GetPurposes() {
+		requests = append(requests, model.CreateRequest{
+			Name:        p.GetName(),
+			Description: p.GetDescription(),
+			Type:        p.GetType(),
+			Attributes:  p.GetAttributes(),
+		})
+	}
+
+	purposes, serviceErr := s.service.CreatePurposesInBatch(ctx, requests, orgID)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+
+	data := make([]*consentv1.Purpose, 0, len(purposes))
+	for i := range purposes {
+		data = append(data, toPurpose(&purposes[i]))
+	}
+	return &consentv1.CreatePurposesResponse{Data: data}, nil
+}
+
+// GetPurpose returns a purpose by ID
+func (s *purposeServer) GetPurpose(ctx context.Context, req *consentv1.GetPurposeRequest) (*consentv1.Purpose, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	purpose, serviceErr := s.service.GetPurpose(ctx, req.GetPurposeId(), orgID)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+	return toPurpose(purpose), nil
+}
+
+// ListPurposes returns a page of purposes, optionally filtered by name
+func (s *purposeServer) ListPurposes(ctx context.Context, req *consentv1.ListPurposesRequest) (*consentv1.ListPurposesResponse, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := defaultPurposeListLimit
+	if req.GetLimit() > 0 && req.GetLimit() <= maxPurposeListLimit {
+		limit = int(req.GetLimit())
+	}
+	offset := 0
+	if req.GetOffset() > 0 {
+		offset = int(req.GetOffset())
+	}
+
+	purposes, total, serviceErr := s.service.ListPurposes(ctx, model.PurposeSearchFilters{
+		Name:   req.GetName(),
+		Limit:  limit,
+		Offset: offset,
+		OrgID:  orgID,
+	})
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+
+	data := make([]*consentv1.Purpose, 0, len(purposes))
+	for i := range purposes {
+		data = append(data, toPurpose(&purposes[i]))
+	}
+	return &consentv1.ListPurposesResponse{
+		Data: data,
+		Metadata: &consentv1.PaginationMetadata{
+			Total:  int32(total),
+			Limit:  int32(limit),
+			Offset: int32(offset),
+			Count:  int32(len(data)),
+		},
+	}, nil
+}
+
+// UpdatePurpose replaces a purpose definition
+func (s *purposeServer) UpdatePurpose(ctx context.Context, req *consentv1.UpdatePurposeRequest) (*consentv1.Purpose, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updateReq := model.UpdateRequest{
+		Name:        req.GetName(),
+		Description: req.Description,
+		Type:        req.GetType(),
+		Attributes:  req.GetAttributes(),
+	}
+
+	purpose, serviceErr := s.service.UpdatePurpose(ctx, req.GetPurposeId(), updateReq, orgID)
+	if serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+	return toPurpose(purpose), nil
+}
+
+// DeletePurpose deletes a purpose
+func (s *purposeServer) DeletePurpose(ctx context.Context, req *consentv1.DeletePurposeRequest) (*emptypb.Empty, error) {
+	orgID, err := requireOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if serviceErr := s.service.DeletePurpose(ctx, req.GetPurposeId(), orgID); serviceErr != nil {
+		return nil, toStatusError(serviceErr)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// toPurpose converts a purpose to its gRPC representation
+func toPurpose(p *model.ConsentPurpose) *consentv1.Purpose {
+	return &consentv1.Purpose{
+		Id:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Type:        p.Type,
+		Attributes:  p.Attributes,
+	}
+}
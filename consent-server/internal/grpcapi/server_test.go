@@ -0,0 +1,183 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/wso2/consent-management-api/internal/consent"
+	"github.com/wso2/consent-management-api/internal/consent/model"
+	"github.com/wso2/consent-management-api/internal/grpcapi/pb/consentv1"
+	"github.com/wso2/consent-management-api/internal/system/audit"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+)
+
+const (
+	testOrgID     = "org-1"
+	testConsentID = "3f2b8c1e-6d4a-4b7e-9a1c-2e5f8d9b0c3a"
+)
+
+// stubConsentService serves a single consent. Methods the tests do not call are left to the
+// embedded interface and panic when called.
+type stubConsentService struct {
+	consent.ConsentService
+}
+
+func (s *stubConsentService) GetConsent(ctx context.Context, consentID, orgID string) (*model.ConsentResponse, *serviceerror.ServiceError) {
+	if consentID != testConsentID || orgID != testOrgID {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError, "consent not found")
+	}
+	return &model.ConsentResponse{ConsentID: consentID, ClientID: "client-1", ConsentType: "accounts",
+		CurrentStatus: "ACTIVE", OrgID: orgID}, nil
+}
+
+// recordingAuditor keeps the operations it records
+type recordingAuditor struct {
+	mu         sync.Mutex
+	operations []*audit.Operation
+}
+
+func (r *recordingAuditor) Record(ctx context.Context, op *audit.Operation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operations = append(r.operations, op)
+	return nil
+}
+
+// setupAuthConfig enables basic auth with a user granted every scope and a user granted only
+// consents:read
+func setupAuthConfig(t *testing.T) {
+	config.SetGlobal(&config.Config{Security: config.SecurityConfig{BasicAuth: config.BasicAuthConfig{
+		Enabled: true,
+		Users: []config.BasicAuthUser{
+			{Username: "admin", Password: "admin"},
+			{Username: "reader", Password: "reader", Scopes: []string{middleware.ScopeConsentsRead}},
+		},
+	}}})
+	t.Cleanup(func() { config.SetGlobal(nil) })
+}
+
+// newTestClient serves the consent service with the server's interceptors over an in-memory
+// connection
+func newTestClient(t *testing.T, service consent.ConsentService) consentv1.ConsentServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(&config.Config{}, Services{Consent: service}, nil)
+	go func() { _ = server.grpcServer.Serve(listener) }()
+	t.Cleanup(server.grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return consentv1.NewConsentServiceClient(conn)
+}
+
+// callContext returns a context carrying the organization, client and, when username is set,
+// basic auth credentials
+func callContext(username, password string) context.Context {
+	pairs := []string{"org-id", testOrgID, "tpp-client-id", "client-1"}
+	if username != "" {
+		pairs = append(pairs, "authorization",
+			"Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	}
+	return metadata.NewOutgoingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+// TestGetConsent_RequiresBasicAuth checks that calls are authenticated against security.basic_auth
+// and that authenticated calls reach the service
+func TestGetConsent_RequiresBasicAuth(t *testing.T) {
+	setupAuthConfig(t)
+	client := newTestClient(t, &stubConsentService{})
+	req := &consentv1.GetConsentRequest{ConsentId: testConsentID}
+
+	for name, ctx := range map[string]context.Context{
+		"no credentials":    callContext("", ""),
+		"wrong credentials": callContext("admin", "wrong"),
+	} {
+		if _, err := client.GetConsent(ctx, req); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s: expected Unauthenticated, got %v", name, err)
+		}
+	}
+
+	resp, err := client.GetConsent(callContext("admin", "admin"), req)
+	if err != nil {
+		t.Fatalf("expected the authenticated call to succeed, got %v", err)
+	}
+	if resp.GetId() != testConsentID || resp.GetStatus() != "ACTIVE" || resp.GetClientId() != "client-1" {
+		t.Errorf("unexpected consent %+v", resp)
+	}
+
+	_, err = client.GetConsent(callContext("admin", "admin"), &consentv1.GetConsentRequest{ConsentId: "not-a-consent-id"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a malformed consent ID, got %v", err)
+	}
+}
+
+// TestAuthInterceptor_EnforcesRouteScopes checks that the scope of the mirrored HTTP route is
+// required when security.authorization is enabled, and that rejected audited calls are recorded
+func TestAuthInterceptor_EnforcesRouteScopes(t *testing.T) {
+	setupAuthConfig(t)
+	config.Get().Security.Authorization.Enabled = true
+	auditor := &recordingAuditor{}
+	audit.SetRecorder(auditor)
+	t.Cleanup(func() { audit.SetRecorder(nil) })
+
+	client := newTestClient(t, &stubConsentService{})
+
+	if _, err := client.GetConsent(callContext("reader", "reader"), &consentv1.GetConsentRequest{ConsentId: testConsentID}); err != nil {
+		t.Errorf("expected consents:read to allow GetConsent, got %v", err)
+	}
+	_, err := client.RevokeConsent(callContext("reader", "reader"),
+		&consentv1.RevokeConsentRequest{ConsentId: testConsentID, ActionBy: "reader"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied without consents:revoke, got %v", err)
+	}
+
+	auditor.mu.Lock()
+	defer auditor.mu.Unlock()
+	if len(auditor.operations) != 1 {
+		t.Fatalf("expected the revoke to be audited once, got %d operations", len(auditor.operations))
+	}
+	op := auditor.operations[0]
+	if op.Action != audit.ActionConsentRevoke || op.Actor != "reader" || op.ConsentID != testConsentID ||
+		op.Outcome != audit.OutcomeFailure || op.StatusCode != 403 || op.Route != "PUT /consents/{consentId}/revoke" {
+		t.Errorf("unexpected audited operation %+v", op)
+	}
+}
+
+// TestMethodRoutes_CoverEveryMethod checks that every served method is authorized as a route, as
+// methods without a route are refused
+func TestMethodRoutes_CoverEveryMethod(t *testing.T) {
+	for _, desc := range []grpc.ServiceDesc{
+		consentv1.ConsentService_ServiceDesc,
+		consentv1.AuthResourceService_ServiceDesc,
+		consentv1.PurposeService_ServiceDesc,
+	} {
+		for _, method := range desc.Methods {
+			fullMethod := "/" + desc.ServiceName + "/" + method.MethodName
+			if _, ok := methodRoutes[fullMethod]; !ok {
+				t.Errorf("method %s has no route", fullMethod)
+			}
+		}
+	}
+
+	_, err := authInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/unknown.Service/Call"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected unknown methods to be refused, got %v", err)
+	}
+}
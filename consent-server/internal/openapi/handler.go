@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/featureflag"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// swaggerUIPage renders the OpenAPI document with Swagger UI, loaded from a CDN
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Consent Management API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+// getDocument handles GET /openapi.json
+func getDocument(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	w.Write(current.Load().json)
+}
+
+// getDocs handles GET /docs
+func getDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(constants.HeaderContentType, "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(swaggerUIPage))
+}
+
+// WrapWithRequestValidation validates the requests of organizations with the request_validation
+// feature flag against the OpenAPI document before the mux routes them. Requests that do not match
+// the documented parameters or JSON body are rejected with 400. Routes that are not documented,
+// such as the admin endpoints, are passed on unchecked.
+func WrapWithRequestValidation(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spec := current.Load()
+		if spec == nil || !featureflag.IsEnabled(r.Header.Get(constants.HeaderOrgID), featureflag.RequestValidation) {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		_, pattern := mux.Handler(r)
+		if err := spec.ValidateRequest(r, pattern); err != nil {
+			log.GetLogger().WithContext(r.Context()).Debug("Request does not match the OpenAPI document",
+				log.String("route", pattern),
+				log.Error(err))
+			utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+				"request does not match the API specification: "+err.Error()))
+			return
+		}
+
+		// The mux records the matched route on this request, so it is not copied
+		mux.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,83 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync/atomic"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+)
+
+// current is the loaded OpenAPI document, nil when none was found
+var current atomic.Pointer[Spec]
+
+// Initialize loads the OpenAPI document and registers the routes serving it, together with a
+// Swagger UI. Like the error catalog, the document describes the API, so it is not protected by
+// authentication. It must be called once every route is registered: documented operations without
+// a route are logged. When no document is found, nothing is served and requests are not validated.
+func Initialize(mux *http.ServeMux) error {
+	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "OpenAPI"))
+
+	var spec *Spec
+	var specPath string
+	for _, path := range config.Get().Server.GetOpenAPISpecPaths() {
+		loaded, err := Load(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load OpenAPI document %s: %w", path, err)
+		}
+		spec, specPath = loaded, path
+		break
+	}
+	if spec == nil {
+		logger.Warn("OpenAPI document not found, it is not served and requests are not validated",
+			log.Any("paths", config.Get().Server.GetOpenAPISpecPaths()))
+		return nil
+	}
+	if missing := spec.unresolvedRefs(); len(missing) > 0 {
+		return fmt.Errorf("OpenAPI document %s has unresolved references: %v", specPath, missing)
+	}
+	current.Store(spec)
+
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "X-Correlation-ID"},
+	}
+
+	// GET /api/v1/openapi.json - Get the OpenAPI document
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.OpenAPIPath, getDocument, corsOpts))
+
+	// GET /api/v1/docs - Browse the OpenAPI document with Swagger UI
+	mux.HandleFunc("GET "+constants.APIDocsPath, getDocs)
+
+	for _, route := range spec.unroutedOperations(mux) {
+		logger.Warn("Documented operation has no route", log.String("operation", route))
+	}
+
+	logger.Info("OpenAPI document loaded", log.String("path", specPath), log.Int("operations", len(spec.operations)))
+	return nil
+}
+
+// unroutedOperations lists the documented operations the mux has no route for
+func (s *Spec) unroutedOperations(mux *http.ServeMux) []string {
+	var unrouted []string
+	for _, op := range s.operations {
+		path := constants.APIBasePath + pathParamPattern.ReplaceAllString(op.path, "x")
+		req := httptest.NewRequest(op.method, path, nil)
+		if _, pattern := mux.Handler(req); pattern == "" {
+			unrouted = append(unrouted, op.method+" "+op.path)
+		}
+	}
+	sort.Strings(unrouted)
+	return unrouted
+}
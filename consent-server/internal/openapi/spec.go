@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+)
+
+// pathParamPattern matches the path parameters of a route, such as {consentId} or {path...}
+var pathParamPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// operationMethods are the HTTP methods an OpenAPI path item can document
+var operationMethods = []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+	http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace}
+
+// Spec is a loaded OpenAPI document
+type Spec struct {
+	document map[string]any
+	// json is the document served at the OpenAPI path
+	json []byte
+	// operations are the documented operations by route key
+	operations map[string]*operation
+}
+
+// operation is a documented operation with the parameters of its path item
+type operation struct {
+	method      string
+	path        string
+	parameters  []map[string]any
+	requestBody map[string]any
+}
+
+// Load reads an OpenAPI 3 document in YAML or JSON
+func Load(path string) (*Spec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(content)
+}
+
+// Parse parses an OpenAPI 3 document in YAML or JSON
+func Parse(content []byte) (*Spec, error) {
+	var raw any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	document, ok := normalize(raw).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid OpenAPI document: not an object")
+	}
+	if version, _ := document["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version '%v'", document["openapi"])
+	}
+
+	spec := &Spec{document: document, operations: make(map[string]*operation)}
+	var err error
+	if spec.json, err = json.Marshal(document); err != nil {
+		return nil, err
+	}
+
+	paths, _ := document["paths"].(map[string]any)
+	for path, rawItem := range paths {
+		item, _ := spec.resolve(rawItem).(map[string]any)
+		for _, method := range operationMethods {
+			rawOp, ok := item[strings.ToLower(method)].(map[string]any)
+			if !ok {
+				continue
+			}
+			op := &operation{method: method, path: path}
+			op.parameters = spec.mergeParameters(item["parameters"], rawOp["parameters"])
+			op.requestBody, _ = spec.resolve(rawOp["requestBody"]).(map[string]any)
+			spec.operations[routeKey(method, path)] = op
+		}
+	}
+	return spec, nil
+}
+
+// mergeParameters combines the parameters of a path item and of one of its operations. An
+// operation parameter replaces the path item parameter with the same name and location.
+func (s *Spec) mergeParameters(pathParams, opParams any) []map[string]any {
+	var merged []map[string]any
+	index := make(map[string]int)
+	for _, list := range []any{pathParams, opParams} {
+		params, _ := list.([]any)
+		for _, rawParam := range params {
+			param, ok := s.resolve(rawParam).(map[string]any)
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%v:%v", param["in"], param["name"])
+			if i, exists := index[key]; exists {
+				merged[i] = param
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, param)
+		}
+	}
+	return merged
+}
+
+// resolve follows a local $ref, returning the referenced node or the node itself
+func (s *Spec) resolve(node any) any {
+	for depth := 0; depth < 32; depth++ {
+		object, ok := node.(map[string]any)
+		if !ok {
+			return node
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return node
+		}
+		var target any = s.document
+		for _, token := range strings.Split(ref[2:], "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			parent, ok := target.(map[string]any)
+			if !ok {
+				return nil
+			}
+			target = parent[token]
+		}
+		node = target
+	}
+	return nil
+}
+
+// unresolvedRefs lists the local $refs of the document that point to nothing
+func (s *Spec) unresolvedRefs() []string {
+	var missing []string
+	var walk func(node any)
+	walk = func(node any) {
+		switch n := node.(type) {
+		case map[string]any:
+			if ref, ok := n["$ref"].(string); ok && strings.HasPrefix(ref, "#/") && s.resolve(n) == nil {
+				missing = append(missing, ref)
+			}
+			for _, child := range n {
+				walk(child)
+			}
+		case []any:
+			for _, child := range n {
+				walk(child)
+			}
+		}
+	}
+	walk(s.document)
+	return missing
+}
+
+// operationFor returns the documented operation of a mux route pattern, nil when it is not documented
+func (s *Spec) operationFor(pattern string) *operation {
+	method, path, found := strings.Cut(pattern, " ")
+	if !found || !strings.HasPrefix(path, constants.APIBasePath+"/") {
+		return nil
+	}
+	return s.operations[routeKey(method, strings.TrimPrefix(path, constants.APIBasePath))]
+}
+
+// routeKey identifies an operation by method and path, ignoring the names of path parameters
+func routeKey(method, path string) string {
+	path = strings.TrimSuffix(path, "{$}")
+	return method + " " + pathParamPattern.ReplaceAllString(path, "{}")
+}
+
+// normalize converts the maps decoded from YAML to maps keyed by strings, so the document can be
+// encoded as JSON. Response codes and other non-string keys are converted to their text.
+func normalize(node any) any {
+	switch n := node.(type) {
+	case map[string]any:
+		for key, value := range n {
+			n[key] = normalize(value)
+		}
+		return n
+	case map[any]any:
+		converted := make(map[string]any, len(n))
+		for key, value := range n {
+			converted[fmt.Sprint(key)] = normalize(value)
+		}
+		return converted
+	case []any:
+		for i := range n {
+			n[i] = normalize(n[i])
+		}
+		return n
+	default:
+		return n
+	}
+}
@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// repositorySpec is the OpenAPI document of the API, relative to this package
+const repositorySpec = "../../../api/consent-management-API.yaml"
+
+// TestLoad_RepositoryDocument checks that the document of the API loads and that every reference
+// in it resolves
+func TestLoad_RepositoryDocument(t *testing.T) {
+	spec, err := Load(repositorySpec)
+	if err != nil {
+		t.Fatalf("failed to load the OpenAPI document: %v", err)
+	}
+	if missing := spec.unresolvedRefs(); len(missing) > 0 {
+		t.Errorf("unresolved references: %v", missing)
+	}
+	if spec.operationFor("POST /api/v1/consents") == nil || spec.operationFor("GET /api/v1/consents/{id}") == nil {
+		t.Error("expected the consent operations to be documented")
+	}
+}
+
+const testSpec = `
+openapi: 3.1.0
+info: {title: test, version: v1}
+paths:
+  /items/{itemId}:
+    parameters:
+      - {name: itemId, in: path, required: true, schema: {type: string, maxLength: 5}}
+    put:
+      parameters:
+        - {name: org-id, in: header, required: true, schema: {type: string}}
+        - {name: limit, in: query, schema: {type: integer, minimum: 1}}
+        - {name: tag, in: query, schema: {type: array, maxItems: 2, items: {type: string}}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Item'}
+      responses:
+        "200": {description: ok}
+components:
+  schemas:
+    Item:
+      type: object
+      required: [name]
+      additionalProperties: false
+      properties:
+        name: {type: string, minLength: 1}
+        status: {type: string, enum: [ACTIVE, REVOKED]}
+        expiry: {type: [integer, "null"]}
+        labels: {type: object, additionalProperties: {type: string}}
+`
+
+// TestValidateRequest checks parameters, headers and bodies against a document
+func TestValidateRequest(t *testing.T) {
+	spec, err := Parse([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	const pattern = "PUT /api/v1/items/{id}"
+
+	tests := []struct {
+		name    string
+		target  string
+		orgID   string
+		body    string
+		wantErr string
+	}{
+		{"valid", "/api/v1/items/a%2Fb?limit=2&tag=x&tag=y", "org-1", `{"name":"n","status":"ACTIVE","expiry":null,"labels":{"k":"v"}}`, ""},
+		{"missing header", "/api/v1/items/a", "", `{"name":"n"}`, "header parameter 'org-id' is required"},
+		{"long path parameter", "/api/v1/items/abcdef", "org-1", `{"name":"n"}`, "path parameter 'itemId' must be at most 5 characters"},
+		{"query type", "/api/v1/items/a?limit=many", "org-1", `{"name":"n"}`, "query parameter 'limit' must be an integer"},
+		{"query minimum", "/api/v1/items/a?limit=0", "org-1", `{"name":"n"}`, "query parameter 'limit' must be at least 1"},
+		{"repeated query", "/api/v1/items/a?tag=x&tag=y&tag=z", "org-1", `{"name":"n"}`, "must have at most 2 items"},
+		{"missing body", "/api/v1/items/a", "org-1", ``, "request body is required"},
+		{"invalid JSON", "/api/v1/items/a", "org-1", `{"name":`, "request body is not valid JSON"},
+		{"missing property", "/api/v1/items/a", "org-1", `{"status":"ACTIVE"}`, "body.name is required"},
+		{"unknown property", "/api/v1/items/a", "org-1", `{"name":"n","other":1}`, "body.other is not a known property"},
+		{"enum", "/api/v1/items/a", "org-1", `{"name":"n","status":"PAUSED"}`, "body.status must be one of"},
+		{"null", "/api/v1/items/a", "org-1", `{"name":null}`, "body.name must not be null"},
+		{"map values", "/api/v1/items/a", "org-1", `{"name":"n","labels":{"k":1}}`, "body.labels.k must be of type string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.orgID != "" {
+				req.Header.Set("org-id", tt.orgID)
+			}
+
+			err := spec.ValidateRequest(req, pattern)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected the request to be valid, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestValidateRequest_RestoresBody checks that the handler reads the validated body as sent
+func TestValidateRequest_RestoresBody(t *testing.T) {
+	spec, err := Parse([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/items/a", strings.NewReader(`{"name":"n"}`))
+	req.Header.Set("org-id", "org-1")
+	if err := spec.ValidateRequest(req, "PUT /api/v1/items/{id}"); err != nil {
+		t.Fatalf("expected the request to be valid, got %v", err)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil || string(body) != `{"name":"n"}` {
+		t.Errorf("expected the body to be restored, got %q (%v)", body, err)
+	}
+}
+
+// TestUnroutedOperations checks that documented operations without a route are reported
+func TestUnroutedOperations(t *testing.T) {
+	spec, err := Parse([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	if unrouted := spec.unroutedOperations(mux); len(unrouted) != 1 || unrouted[0] != "PUT /items/{itemId}" {
+		t.Errorf("expected the operation to be unrouted, got %v", unrouted)
+	}
+	mux.HandleFunc("PUT /api/v1/items/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	if unrouted := spec.unroutedOperations(mux); len(unrouted) != 0 {
+		t.Errorf("expected every operation to be routed, got %v", unrouted)
+	}
+}
@@ -0,0 +1,417 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+)
+
+// maxSchemaDepth bounds the nesting of schemas followed while validating one value
+const maxSchemaDepth = 64
+
+// patterns caches the compiled pattern keywords of the document
+var patterns sync.Map
+
+// ValidateRequest checks a request against the operation documented for its mux route pattern. The
+// parameters, headers and JSON body are validated; bodies of other media types are left to the
+// handler. A nil error is returned for routes that are not documented. A validated JSON body is
+// put back on the request, so the handler reads it as sent.
+func (s *Spec) ValidateRequest(r *http.Request, pattern string) error {
+	op := s.operationFor(pattern)
+	if op == nil {
+		return nil
+	}
+
+	query := r.URL.Query()
+	pathValues := matchPath(constants.APIBasePath+op.path, r.URL.EscapedPath())
+	for _, param := range op.parameters {
+		name, _ := param["name"].(string)
+		required, _ := param["required"].(bool)
+		schema := param["schema"]
+
+		var values []string
+		switch param["in"] {
+		case "path":
+			values = []string{pathValues[name]}
+		case "query":
+			values = query[name]
+			if explode, ok := param["explode"].(bool); ok && !explode && len(values) == 1 {
+				values = strings.Split(values[0], ",")
+			}
+		case "header":
+			values = r.Header.Values(name)
+		default:
+			continue
+		}
+
+		location := fmt.Sprintf("%s parameter '%s'", param["in"], name)
+		if len(values) == 0 || (len(values) == 1 && values[0] == "" && param["in"] != "path") {
+			if required {
+				return fmt.Errorf("%s is required", location)
+			}
+			continue
+		}
+		value, err := s.parameterValue(schema, values)
+		if err != nil {
+			return fmt.Errorf("%s %s", location, err.Error())
+		}
+		if err := s.validate(schema, value, location, 0); err != nil {
+			return err
+		}
+	}
+
+	return s.validateBody(r, op)
+}
+
+// matchPath returns the path parameters of a request path by the names the document gives them,
+// which may differ from the names of the mux route. The mux only sets path values on the request
+// once it routes the request.
+func matchPath(documentPath, escapedPath string) map[string]string {
+	values := make(map[string]string)
+	patternSegments := strings.Split(strings.Trim(documentPath, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(escapedPath, "/"), "/")
+	for i, segment := range patternSegments {
+		if i >= len(pathSegments) {
+			break
+		}
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := strings.Trim(segment, "{}")
+		raw := pathSegments[i]
+		if rest, ok := strings.CutSuffix(name, "..."); ok {
+			name, raw = rest, strings.Join(pathSegments[i:], "/")
+		}
+		if value, err := url.PathUnescape(raw); err == nil {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+// validateBody checks the JSON body of a request against the schema of the operation
+func (s *Spec) validateBody(r *http.Request, op *operation) error {
+	if op.requestBody == nil {
+		return nil
+	}
+	content, _ := op.requestBody["content"].(map[string]any)
+	media, _ := content[constants.ContentTypeJSON].(map[string]any)
+	if media == nil {
+		return nil
+	}
+	if contentType := r.Header.Get(constants.HeaderContentType); contentType != "" {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != constants.ContentTypeJSON {
+			return nil
+		}
+	}
+
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			// The handler reports a body that could not be read, such as one over the size limit
+			r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+			return nil
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if required, _ := op.requestBody["required"].(bool); required {
+			return fmt.Errorf("request body is required")
+		}
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("request body is not valid JSON")
+	}
+	return s.validate(media["schema"], value, "body", 0)
+}
+
+// parameterValue converts the text of a parameter to the type its schema documents
+func (s *Spec) parameterValue(rawSchema any, values []string) (any, error) {
+	schema, _ := s.resolve(rawSchema).(map[string]any)
+	if slices.Contains(schemaTypes(schema), "array") {
+		items := make([]any, 0, len(values))
+		for _, value := range values {
+			item, err := s.parameterValue(schema["items"], []string{value})
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	value := values[0]
+	types := schemaTypes(schema)
+	switch {
+	case slices.Contains(types, "integer"):
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("must be an integer")
+		}
+		return json.Number(value), nil
+	case slices.Contains(types, "number"):
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("must be a number")
+		}
+		return json.Number(value), nil
+	case slices.Contains(types, "boolean"):
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("must be a boolean")
+		}
+		return b, nil
+	}
+	return value, nil
+}
+
+// validate checks a value decoded from JSON against a schema. The supported keywords are type,
+// nullable, enum, const, properties, required, additionalProperties, items, minItems, maxItems,
+// minLength, maxLength, pattern, minimum, maximum, allOf, anyOf and oneOf. oneOf is checked like
+// anyOf, so overlapping alternatives are not rejected. Other keywords, such as format, are ignored.
+func (s *Spec) validate(rawSchema, value any, location string, depth int) error {
+	if depth > maxSchemaDepth {
+		return nil
+	}
+	schema, ok := s.resolve(rawSchema).(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	for _, sub := range asList(schema["allOf"]) {
+		if err := s.validate(sub, value, location, depth+1); err != nil {
+			return err
+		}
+	}
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		alternatives := asList(schema[keyword])
+		if len(alternatives) == 0 {
+			continue
+		}
+		matched := false
+		for _, sub := range alternatives {
+			if s.validate(sub, value, location, depth+1) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s does not match any of the allowed schemas", location)
+		}
+	}
+
+	if value == nil {
+		types := schemaTypes(schema)
+		if len(types) > 0 && !slices.Contains(types, "null") {
+			return fmt.Errorf("%s must not be null", location)
+		}
+		return nil
+	}
+	if err := checkType(schema, value, location); err != nil {
+		return err
+	}
+
+	if enum := asList(schema["enum"]); len(enum) > 0 && !slices.ContainsFunc(enum, func(e any) bool { return sameValue(e, value) }) {
+		return fmt.Errorf("%s must be one of %v", location, enum)
+	}
+	if constant, ok := schema["const"]; ok && !sameValue(constant, value) {
+		return fmt.Errorf("%s must be %v", location, constant)
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if limit, ok := asNumber(schema["minLength"]); ok && float64(length) < limit {
+			return fmt.Errorf("%s must be at least %v characters", location, limit)
+		}
+		if limit, ok := asNumber(schema["maxLength"]); ok && float64(length) > limit {
+			return fmt.Errorf("%s must be at most %v characters", location, limit)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re := compilePattern(pattern); re != nil && !re.MatchString(v) {
+				return fmt.Errorf("%s must match the pattern %s", location, pattern)
+			}
+		}
+	case json.Number:
+		number, _ := v.Float64()
+		if limit, ok := asNumber(schema["minimum"]); ok && number < limit {
+			return fmt.Errorf("%s must be at least %v", location, limit)
+		}
+		if limit, ok := asNumber(schema["maximum"]); ok && number > limit {
+			return fmt.Errorf("%s must be at most %v", location, limit)
+		}
+	case []any:
+		if limit, ok := asNumber(schema["minItems"]); ok && float64(len(v)) < limit {
+			return fmt.Errorf("%s must have at least %v items", location, limit)
+		}
+		if limit, ok := asNumber(schema["maxItems"]); ok && float64(len(v)) > limit {
+			return fmt.Errorf("%s must have at most %v items", location, limit)
+		}
+		for i, item := range v {
+			if err := s.validate(schema["items"], item, fmt.Sprintf("%s[%d]", location, i), depth+1); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, name := range asList(schema["required"]) {
+			if _, ok := v[fmt.Sprint(name)]; !ok {
+				return fmt.Errorf("%s.%v is required", location, name)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for _, name := range sortedKeys(v) {
+			propertyLocation := location + "." + name
+			if property, ok := properties[name]; ok {
+				if err := s.validate(property, v[name], propertyLocation, depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					return fmt.Errorf("%s is not a known property", propertyLocation)
+				}
+			case map[string]any:
+				if err := s.validate(additional, v[name], propertyLocation, depth+1); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkType checks a non-null value against the type keyword of a schema
+func checkType(schema map[string]any, value any, location string) error {
+	types := schemaTypes(schema)
+	if len(types) == 0 {
+		return nil
+	}
+	for _, t := range types {
+		switch v := value.(type) {
+		case string:
+			if t == "string" {
+				return nil
+			}
+		case bool:
+			if t == "boolean" {
+				return nil
+			}
+		case json.Number:
+			if t == "number" {
+				return nil
+			}
+			if f, err := v.Float64(); t == "integer" && err == nil && f == math.Trunc(f) {
+				return nil
+			}
+		case []any:
+			if t == "array" {
+				return nil
+			}
+		case map[string]any:
+			if t == "object" {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s must be of type %s", location, strings.Join(types, " or "))
+}
+
+// schemaTypes returns the types a schema allows, from the type keyword of OpenAPI 3.1 or of 3.0
+// with nullable
+func schemaTypes(schema map[string]any) []string {
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			types = append(types, fmt.Sprint(item))
+		}
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable && len(types) > 0 {
+		types = append(types, "null")
+	}
+	return types
+}
+
+// compilePattern compiles a pattern keyword once. Patterns Go cannot compile are not checked.
+func compilePattern(pattern string) *regexp.Regexp {
+	if cached, ok := patterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	patterns.Store(pattern, re)
+	return re
+}
+
+// sameValue compares an enum or const value of the document with a decoded value
+func sameValue(expected, value any) bool {
+	if number, ok := value.(json.Number); ok {
+		want, ok := asNumber(expected)
+		got, err := number.Float64()
+		return ok && err == nil && want == got
+	}
+	return fmt.Sprint(expected) == fmt.Sprint(value)
+}
+
+// asNumber converts a numeric keyword of the document to a float
+func asNumber(value any) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// asList returns a list keyword of the document, nil when it is absent
+func asList(value any) []any {
+	list, _ := value.([]any)
+	return list
+}
+
+// sortedKeys returns the keys of an object in order, so the first error reported is stable
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// errReader returns the error the body failed with once the part read before it is consumed
+type errReader struct {
+	err error
+}
+
+func (e errReader) Read([]byte) (int, error) {
+	return 0, e.err
+}
@@ -0,0 +1,127 @@
+package operationaudit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/operationaudit/model"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// operationAuditHandler handles HTTP requests for the operation audit trail
+type operationAuditHandler struct {
+	service OperationAuditService
+}
+
+// newOperationAuditHandler creates a new operation audit handler
+func newOperationAuditHandler(service OperationAuditService) *operationAuditHandler {
+	return &operationAuditHandler{
+		service: service,
+	}
+}
+
+// listConsentOperations handles GET /consents/{consentId}/operations
+func (h *operationAuditHandler) listConsentOperations(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if orgID == "" {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "Organization ID is required"))
+		return
+	}
+	consentID := r.PathValue("consentId")
+	if err := utils.ValidateConsentID(consentID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	filters, serviceErr := parseSearchFilters(r)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+	filters.OrgID = orgID
+	filters.ConsentID = consentID
+
+	response, serviceErr := h.service.SearchOperations(r.Context(), filters)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
+
+// searchOperations handles GET /audit/operations. Every filter is optional; orgId narrows the
+// search to one organization.
+func (h *operationAuditHandler) searchOperations(w http.ResponseWriter, r *http.Request) {
+	filters, serviceErr := parseSearchFilters(r)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+	filters.OrgID = strings.TrimSpace(r.URL.Query().Get("orgId"))
+	filters.ConsentID = strings.TrimSpace(r.URL.Query().Get("consentId"))
+
+	response, serviceErr := h.service.SearchOperations(r.Context(), filters)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
+
+// parseSearchFilters reads the actor, action, outcome, time range and pagination query parameters
+func parseSearchFilters(r *http.Request) (model.OperationSearchFilters, *serviceerror.ServiceError) {
+	query := r.URL.Query()
+	filters := model.OperationSearchFilters{
+		Actor:   strings.TrimSpace(query.Get("actor")),
+		Outcome: strings.ToUpper(strings.TrimSpace(query.Get("outcome"))),
+	}
+
+	// Parse action (comma-separated)
+	if actionsStr := query.Get("action"); actionsStr != "" {
+		for _, action := range strings.Split(actionsStr, ",") {
+			if action = strings.TrimSpace(action); action != "" {
+				filters.Actions = append(filters.Actions, action)
+			}
+		}
+	}
+
+	// Parse fromTime and toTime (Unix timestamps in milliseconds)
+	timeFilters := []struct {
+		param  string
+		target **int64
+	}{
+		{"fromTime", &filters.FromTime},
+		{"toTime", &filters.ToTime},
+	}
+	for _, timeFilter := range timeFilters {
+		valueStr := query.Get(timeFilter.param)
+		if valueStr == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(valueStr, 10, 64)
+		if err != nil || value < 0 {
+			return filters, serviceerror.CustomServiceError(serviceerror.InvalidRequestError,
+				fmt.Sprintf("%s must be a Unix timestamp in milliseconds", timeFilter.param))
+		}
+		*timeFilter.target = &value
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			filters.Limit = l
+		}
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			filters.Offset = o
+		}
+	}
+
+	return filters, nil
+}
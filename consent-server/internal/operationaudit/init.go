@@ -0,0 +1,46 @@
+package operationaudit
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/audit"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize sets up the operation audit module, records audited operations in the operation
+// audit store and registers routes. The search across consents is registered on adminMux.
+func Initialize(mux, adminMux *http.ServeMux, registry *stores.StoreRegistry) OperationAuditService {
+	service := newOperationAuditService(registry)
+	handler := newOperationAuditHandler(service)
+
+	audit.SetRecorder(service)
+
+	registerRoutes(mux, adminMux, handler)
+
+	return service
+}
+
+// registerRoutes registers the operation audit routes
+func registerRoutes(mux, adminMux *http.ServeMux, handler *operationAuditHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:      "*",
+		AllowMethods:     []string{"GET", "OPTIONS"},
+		AllowHeaders:     []string{"Content-Type", "Authorization", "X-Organization-ID", "X-Correlation-ID"},
+		AllowCredentials: true,
+	}
+
+	// GET /api/v1/consents/{consentId}/operations - List the audited operations on a consent
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/consents/{consentId}/operations", middleware.WithScope(middleware.ScopeConsentsRead, handler.listConsentOperations), corsOpts))
+
+	adminCorsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "X-Correlation-ID"},
+	}
+
+	// GET /api/v1/audit/operations - Search audited operations, for example by actor
+	adminMux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/audit/operations",
+		middleware.WithAdminAuth(handler.searchOperations), adminCorsOpts))
+}
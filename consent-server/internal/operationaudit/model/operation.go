@@ -0,0 +1,49 @@
+package model
+
+import "encoding/json"
+
+// OperationAudit represents the CONSENT_OPERATION_AUDIT table. It records a call to an audited
+// consent endpoint, who made it and with what outcome.
+type OperationAudit struct {
+	OperationID string `json:"operationId"`
+	ConsentID   string `json:"consentId,omitempty"` // Empty when a create failed before the consent existed
+	Action      string `json:"action"`
+	Actor       string `json:"actor,omitempty"`
+	ClientID    string `json:"clientId,omitempty"`
+	Method      string `json:"method"`
+	Route       string `json:"route"`
+	StatusCode  int    `json:"statusCode"`
+	Outcome     string `json:"outcome"`
+	// Details describes what the operation changed, for example the attribute keys it added
+	Details    json.RawMessage `json:"details,omitempty"`
+	ActionTime int64           `json:"actionTime"`
+	OrgID      string          `json:"orgId"`
+}
+
+// OperationSearchFilters represents the filters of an operation audit search
+type OperationSearchFilters struct {
+	OrgID     string   // Empty searches every organization
+	ConsentID string   // Operations on a single consent
+	Actor     string   // Operations performed by a user or client
+	Actions   []string // e.g. ["consent.update"]
+	Outcome   string   // SUCCESS or FAILURE
+	FromTime  *int64   // Action time lower bound (Unix timestamp in milliseconds)
+	ToTime    *int64   // Action time upper bound (Unix timestamp in milliseconds)
+	Limit     int
+	Offset    int
+}
+
+// OperationListResponse represents a page of audited operations
+type OperationListResponse struct {
+	Data     []OperationAudit   `json:"data"`
+	Metadata PaginationMetadata `json:"metadata"`
+}
+
+// PaginationMetadata represents pagination metadata
+type PaginationMetadata struct {
+	Total   int  `json:"total"`
+	Limit   int  `json:"limit"`
+	Offset  int  `json:"offset"`
+	Count   int  `json:"count"`
+	HasMore bool `json:"hasMore"`
+}
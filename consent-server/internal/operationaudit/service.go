@@ -0,0 +1,102 @@
+package operationaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wso2/consent-management-api/internal/operationaudit/model"
+	"github.com/wso2/consent-management-api/internal/system/audit"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// Page sizes of operation searches
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// OperationAuditService defines the exported service interface for the operation audit trail
+type OperationAuditService interface {
+	SearchOperations(ctx context.Context, filters model.OperationSearchFilters) (*model.OperationListResponse, *serviceerror.ServiceError)
+}
+
+// operationAuditService implements OperationAuditService and records audited operations
+type operationAuditService struct {
+	stores *stores.StoreRegistry
+}
+
+// newOperationAuditService creates a new operation audit service
+func newOperationAuditService(registry *stores.StoreRegistry) *operationAuditService {
+	return &operationAuditService{
+		stores: registry,
+	}
+}
+
+// Record stores an audited operation. It implements audit.Recorder.
+func (s *operationAuditService) Record(ctx context.Context, op *audit.Operation) error {
+	operation := &model.OperationAudit{
+		OperationID: utils.GenerateUUID(),
+		ConsentID:   op.ConsentID,
+		Action:      string(op.Action),
+		Actor:       op.Actor,
+		ClientID:    op.ClientID,
+		Method:      op.Method,
+		Route:       op.Route,
+		StatusCode:  op.StatusCode,
+		Outcome:     op.Outcome,
+		ActionTime:  op.Time,
+		OrgID:       op.OrgID,
+	}
+	if details := op.Details(); details != nil {
+		data, err := json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("failed to encode operation details: %w", err)
+		}
+		operation.Details = data
+	}
+	return s.stores.OperationAudit.Create(ctx, operation)
+}
+
+// SearchOperations retrieves a page of audited operations matching the filters, newest first
+func (s *operationAuditService) SearchOperations(ctx context.Context, filters model.OperationSearchFilters) (*model.OperationListResponse, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if filters.FromTime != nil && filters.ToTime != nil && *filters.FromTime > *filters.ToTime {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError, "fromTime must not be after toTime")
+	}
+	if filters.Outcome != "" && filters.Outcome != audit.OutcomeSuccess && filters.Outcome != audit.OutcomeFailure {
+		return nil, serviceerror.CustomServiceError(serviceerror.ValidationError,
+			fmt.Sprintf("outcome must be %s or %s", audit.OutcomeSuccess, audit.OutcomeFailure))
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = defaultSearchLimit
+	}
+	if filters.Limit > maxSearchLimit {
+		filters.Limit = maxSearchLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	operations, total, err := s.stores.OperationAudit.Search(ctx, filters)
+	if err != nil {
+		logger.Error("Failed to search audited operations", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to search operations: %v", err))
+	}
+
+	return &model.OperationListResponse{
+		Data: operations,
+		Metadata: model.PaginationMetadata{
+			Total:   total,
+			Limit:   filters.Limit,
+			Offset:  filters.Offset,
+			Count:   len(operations),
+			HasMore: filters.Offset+len(operations) < total,
+		},
+	}, nil
+}
@@ -0,0 +1,174 @@
+package operationaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/operationaudit/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// DBQuery objects for operation audit operations
+var (
+	QueryCreateOperation = dbmodel.DBQuery{
+		ID: "CREATE_OPERATION_AUDIT",
+		Query: `INSERT INTO CONSENT_OPERATION_AUDIT (OPERATION_ID, CONSENT_ID, ACTION, ACTOR, CLIENT_ID, HTTP_METHOD, ROUTE, STATUS_CODE, OUTCOME, DETAILS, ACTION_TIME, ORG_ID)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+	}
+)
+
+// operationColumns are the columns selected for an operation
+const operationColumns = "OPERATION_ID, CONSENT_ID, ACTION, ACTOR, CLIENT_ID, HTTP_METHOD, ROUTE, STATUS_CODE, OUTCOME, DETAILS, ACTION_TIME, ORG_ID"
+
+// store implements the interfaces.OperationAuditStore interface
+type store struct {
+	dbClient provider.DBClientInterface
+}
+
+// NewOperationAuditStore creates a new operation audit store
+func NewOperationAuditStore(dbClient provider.DBClientInterface) interfaces.OperationAuditStore {
+	return &store{
+		dbClient: dbClient,
+	}
+}
+
+// Create stores an audited operation. It runs outside the transaction of the operation, so failed
+// operations are recorded too.
+func (s *store) Create(ctx context.Context, operation *model.OperationAudit) error {
+	_, err := s.dbClient.ExecuteContext(ctx, QueryCreateOperation,
+		operation.OperationID, nullable(operation.ConsentID), operation.Action, nullable(operation.Actor),
+		nullable(operation.ClientID), operation.Method, operation.Route, operation.StatusCode, operation.Outcome,
+		nullable(string(operation.Details)), operation.ActionTime, operation.OrgID)
+	return err
+}
+
+// Search retrieves the operations matching the filters, newest first, with the total number of
+// matching operations
+func (s *store) Search(ctx context.Context, filters model.OperationSearchFilters) ([]model.OperationAudit, int, error) {
+	whereConditions := []string{}
+	args := []interface{}{}
+
+	equalFilters := []struct {
+		condition string
+		value     string
+	}{
+		{"ORG_ID = ?", filters.OrgID},
+		{"CONSENT_ID = ?", filters.ConsentID},
+		{"ACTOR = ?", filters.Actor},
+		{"OUTCOME = ?", filters.Outcome},
+	}
+	for _, equalFilter := range equalFilters {
+		if equalFilter.value != "" {
+			whereConditions = append(whereConditions, equalFilter.condition)
+			args = append(args, equalFilter.value)
+		}
+	}
+
+	if len(filters.Actions) > 0 {
+		placeholders := make([]string, len(filters.Actions))
+		for i, action := range filters.Actions {
+			placeholders[i] = "?"
+			args = append(args, action)
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("ACTION IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	if filters.FromTime != nil {
+		whereConditions = append(whereConditions, "ACTION_TIME >= ?")
+		args = append(args, *filters.FromTime)
+	}
+	if filters.ToTime != nil {
+		whereConditions = append(whereConditions, "ACTION_TIME <= ?")
+		args = append(args, *filters.ToTime)
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	// Without an organization filter the search spans every organization
+	crossTenant := filters.OrgID == ""
+
+	countRows, err := s.dbClient.QueryContext(ctx, dbmodel.DBQuery{
+		ID:          "COUNT_OPERATION_AUDIT_SEARCH_RESULTS",
+		Query:       "SELECT COUNT(*) as count FROM CONSENT_OPERATION_AUDIT" + whereClause,
+		CrossTenant: crossTenant,
+	}, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	totalCount := 0
+	if len(countRows) > 0 {
+		if count, ok := countRows[0]["count"].(int64); ok {
+			totalCount = int(count)
+		}
+	}
+
+	// OPERATION_ID breaks ties between operations recorded at the same time so the order is stable
+	// across pages
+	selectQuery := "SELECT " + operationColumns + " FROM CONSENT_OPERATION_AUDIT" + whereClause +
+		" ORDER BY ACTION_TIME DESC, OPERATION_ID DESC LIMIT ? OFFSET ?"
+	args = append(args, filters.Limit, filters.Offset)
+
+	rows, err := s.dbClient.QueryContext(ctx, dbmodel.DBQuery{ID: "SEARCH_OPERATION_AUDIT", Query: selectQuery, CrossTenant: crossTenant}, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	operations := make([]model.OperationAudit, 0, len(rows))
+	for _, row := range rows {
+		operations = append(operations, mapToOperation(row))
+	}
+	return operations, totalCount, nil
+}
+
+// nullable stores empty strings as NULL
+func nullable(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// mapToOperation converts a database row map to OperationAudit
+// Note: DBClient normalizes column names to lowercase
+func mapToOperation(row map[string]interface{}) model.OperationAudit {
+	operation := model.OperationAudit{
+		OperationID: getString(row, "operation_id"),
+		ConsentID:   getString(row, "consent_id"),
+		Action:      getString(row, "action"),
+		Actor:       getString(row, "actor"),
+		ClientID:    getString(row, "client_id"),
+		Method:      getString(row, "http_method"),
+		Route:       getString(row, "route"),
+		StatusCode:  int(getInt64(row, "status_code")),
+		Outcome:     getString(row, "outcome"),
+		ActionTime:  getInt64(row, "action_time"),
+		OrgID:       getString(row, "org_id"),
+	}
+	if details := getString(row, "details"); details != "" && json.Valid([]byte(details)) {
+		operation.Details = json.RawMessage(details)
+	}
+	return operation
+}
+
+func getString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+func getInt64(row map[string]interface{}, key string) int64 {
+	if v, ok := row[key].(int64); ok {
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,127 @@
+package organization
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/wso2/consent-management-api/internal/organization/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// organizationHandler handles HTTP requests for organizations
+type organizationHandler struct {
+	service OrganizationService
+}
+
+// newOrganizationHandler creates a new organization handler
+func newOrganizationHandler(service OrganizationService) *organizationHandler {
+	return &organizationHandler{
+		service: service,
+	}
+}
+
+// createOrganization handles POST /orgs
+func (h *organizationHandler) createOrganization(w http.ResponseWriter, r *http.Request) {
+	var req model.OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid request body"))
+		return
+	}
+
+	organization, serviceErr := h.service.CreateOrganization(r.Context(), req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, organization)
+}
+
+// listOrganizations handles GET /orgs
+func (h *organizationHandler) listOrganizations(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+
+	response, serviceErr := h.service.ListOrganizations(r.Context(), limit, offset)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, response)
+}
+
+// getOrganization handles GET /orgs/{orgId}
+func (h *organizationHandler) getOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID := r.PathValue("orgId")
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	organization, serviceErr := h.service.GetOrganization(r.Context(), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, organization)
+}
+
+// updateOrganization handles PUT /orgs/{orgId}
+func (h *organizationHandler) updateOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID := r.PathValue("orgId")
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid request body"))
+		return
+	}
+
+	organization, serviceErr := h.service.UpdateOrganization(r.Context(), orgID, req)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, organization)
+}
+
+// deleteOrganization handles DELETE /orgs/{orgId}
+func (h *organizationHandler) deleteOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID := r.PathValue("orgId")
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.DeleteOrganization(r.Context(), orgID); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parsePagination reads limit (default 20, max 100) and offset query parameters
+func parsePagination(r *http.Request) (int, int) {
+	limit := 20
+	offset := 0
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return limit, offset
+}
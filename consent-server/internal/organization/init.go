@@ -0,0 +1,62 @@
+package organization
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/scheduler"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize sets up the organization module, loads the stored per-org consent overrides and registers routes
+func Initialize(mux *http.ServeMux, registry *stores.StoreRegistry) OrganizationService {
+	service := newOrganizationService(registry)
+	handler := newOrganizationHandler(service)
+
+	if err := service.loadOverrides(context.Background()); err != nil {
+		log.GetLogger().Error("Failed to load organization overrides, using the deployment configuration", log.Error(err))
+	}
+	if interval := config.Get().Cache.Organization.RefreshInterval; interval > 0 {
+		if err := scheduler.GetScheduler().Register("organization-overrides-refresh", interval, service.refreshOverrides); err != nil {
+			log.GetLogger().Error("Failed to schedule organization overrides refresh", log.Error(err))
+		}
+	}
+
+	registerRoutes(mux, handler)
+
+	return service
+}
+
+// registerRoutes registers the organization routes. They manage deployment-wide settings, so they are
+// protected with admin basic auth.
+func registerRoutes(mux *http.ServeMux, handler *organizationHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "X-Correlation-ID"},
+	}
+
+	// POST /api/v1/orgs - Create an organization
+	mux.HandleFunc(middleware.WithCORS("POST "+constants.APIBasePath+"/orgs",
+		middleware.WithAdminAuth(handler.createOrganization), corsOpts))
+
+	// GET /api/v1/orgs - List organizations
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/orgs",
+		middleware.WithAdminAuth(handler.listOrganizations), corsOpts))
+
+	// GET /api/v1/orgs/{orgId} - Get an organization
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/orgs/{orgId}",
+		middleware.WithAdminAuth(handler.getOrganization), corsOpts))
+
+	// PUT /api/v1/orgs/{orgId} - Replace an organization
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/orgs/{orgId}",
+		middleware.WithAdminAuth(handler.updateOrganization), corsOpts))
+
+	// DELETE /api/v1/orgs/{orgId} - Delete an organization
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/orgs/{orgId}",
+		middleware.WithAdminAuth(handler.deleteOrganization), corsOpts))
+}
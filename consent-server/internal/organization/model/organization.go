@@ -0,0 +1,190 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// deletedConsentStatus is the status soft-deleted consents are stored with, which organizations
+// cannot reuse
+const deletedConsentStatus = "DELETED"
+
+// Organization represents the ORGANIZATION table. It holds the metadata of an organization and the
+// consent settings it overrides; settings that are not set fall back to the deployment configuration.
+type Organization struct {
+	OrgID          string          `json:"orgId"`
+	Name           string          `json:"name"`
+	StatusMappings *StatusMappings `json:"statusMappings,omitempty"`
+	WebhookURLs    []string        `json:"webhookUrls,omitempty"`
+	// RetentionDays is how long the organization's deleted consents are kept before they are purged
+	RetentionDays *int `json:"retentionDays,omitempty"`
+	// DefaultValiditySeconds is the validity of new consents created without a validity time
+	DefaultValiditySeconds *int64   `json:"defaultValiditySeconds,omitempty"`
+	AllowedConsentTypes    []string `json:"allowedConsentTypes,omitempty"`
+	// ExpiryNoticeDays is how many days before their validity time consents are reported as expiring soon
+	ExpiryNoticeDays *int `json:"expiryNoticeDays,omitempty"`
+	// TerminalRetentionDays is how long the organization's revoked and expired consents are kept
+	TerminalRetentionDays *int  `json:"terminalRetentionDays,omitempty"`
+	CreatedTime           int64 `json:"createdTime"`
+	UpdatedTime           int64 `json:"updatedTime"`
+}
+
+// StatusMappings are the consent status names of an organization. Empty names keep the status
+// name of the deployment configuration.
+type StatusMappings struct {
+	CreatedStatus  string `json:"createdStatus,omitempty"`
+	ActiveStatus   string `json:"activeStatus,omitempty"`
+	RejectedStatus string `json:"rejectedStatus,omitempty"`
+	RevokedStatus  string `json:"revokedStatus,omitempty"`
+	ExpiredStatus  string `json:"expiredStatus,omitempty"`
+	// AwaitingReauthorizationStatus is the status of consents sent back for re-authorization
+	AwaitingReauthorizationStatus string `json:"awaitingReauthorizationStatus,omitempty"`
+	// PartiallyAuthorizedStatus is the status of consents waiting for required authorizers
+	PartiallyAuthorizedStatus string `json:"partiallyAuthorizedStatus,omitempty"`
+	// SuspendedStatus is the status of consents put on hold
+	SuspendedStatus string `json:"suspendedStatus,omitempty"`
+}
+
+// OrganizationRequest represents the request body for creating or replacing an organization.
+// OrgID is taken from the path on update.
+type OrganizationRequest struct {
+	OrgID          string          `json:"orgId"`
+	Name           string          `json:"name"`
+	StatusMappings *StatusMappings `json:"statusMappings,omitempty"`
+	WebhookURLs    []string        `json:"webhookUrls,omitempty"`
+	RetentionDays  *int            `json:"retentionDays,omitempty"`
+	// DefaultValiditySeconds is the validity of new consents created without a validity time
+	DefaultValiditySeconds *int64   `json:"defaultValiditySeconds,omitempty"`
+	AllowedConsentTypes    []string `json:"allowedConsentTypes,omitempty"`
+	ExpiryNoticeDays       *int     `json:"expiryNoticeDays,omitempty"`
+	TerminalRetentionDays  *int     `json:"terminalRetentionDays,omitempty"`
+}
+
+// OrganizationListResponse represents the response for listing organizations
+type OrganizationListResponse struct {
+	Data     []Organization     `json:"data"`
+	Metadata PaginationMetadata `json:"metadata"`
+}
+
+// PaginationMetadata represents pagination metadata
+type PaginationMetadata struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
+
+// Validate checks the request against the deployment consent configuration
+func (r OrganizationRequest) Validate(consentConfig *config.ConsentConfig) error {
+	if strings.TrimSpace(r.OrgID) == "" {
+		return fmt.Errorf("orgId is required")
+	}
+	if len(r.OrgID) > 255 {
+		return fmt.Errorf("orgId must not exceed 255 characters")
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(r.Name) > 255 {
+		return fmt.Errorf("name must not exceed 255 characters")
+	}
+	if r.RetentionDays != nil && *r.RetentionDays < 0 {
+		return fmt.Errorf("retentionDays must not be negative")
+	}
+	if r.DefaultValiditySeconds != nil && *r.DefaultValiditySeconds < 0 {
+		return fmt.Errorf("defaultValiditySeconds must not be negative")
+	}
+	if r.ExpiryNoticeDays != nil && *r.ExpiryNoticeDays < 0 {
+		return fmt.Errorf("expiryNoticeDays must not be negative")
+	}
+	if r.TerminalRetentionDays != nil && *r.TerminalRetentionDays < 0 {
+		return fmt.Errorf("terminalRetentionDays must not be negative")
+	}
+	seenTypes := make(map[string]bool, len(r.AllowedConsentTypes))
+	for _, consentType := range r.AllowedConsentTypes {
+		if strings.TrimSpace(consentType) == "" || len(consentType) > 64 {
+			return fmt.Errorf("allowed consent types must be between 1 and 64 characters")
+		}
+		if seenTypes[consentType] {
+			return fmt.Errorf("allowed consent type '%s' is listed more than once", consentType)
+		}
+		seenTypes[consentType] = true
+	}
+	for _, webhookURL := range r.WebhookURLs {
+		parsed, err := url.Parse(webhookURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("webhook URL '%s' must be an absolute http or https URL", webhookURL)
+		}
+	}
+	if r.StatusMappings != nil {
+		return r.StatusMappings.validate(consentConfig)
+	}
+	return nil
+}
+
+// validate checks that the effective status names of the organization are distinct
+func (m StatusMappings) validate(consentConfig *config.ConsentConfig) error {
+	effective := []struct {
+		field, name, fallback string
+	}{
+		{"createdStatus", m.CreatedStatus, consentConfig.StatusMappings.CreatedStatus},
+		{"activeStatus", m.ActiveStatus, consentConfig.StatusMappings.ActiveStatus},
+		{"rejectedStatus", m.RejectedStatus, consentConfig.StatusMappings.RejectedStatus},
+		{"revokedStatus", m.RevokedStatus, consentConfig.StatusMappings.RevokedStatus},
+		{"expiredStatus", m.ExpiredStatus, consentConfig.StatusMappings.ExpiredStatus},
+		{"awaitingReauthorizationStatus", m.AwaitingReauthorizationStatus, string(consentConfig.GetAwaitingReauthorizationStatus())},
+		{"partiallyAuthorizedStatus", m.PartiallyAuthorizedStatus, string(consentConfig.GetPartiallyAuthorizedStatus())},
+		{"suspendedStatus", m.SuspendedStatus, string(consentConfig.GetSuspendedConsentStatus())},
+	}
+
+	seen := make(map[string]string, len(effective))
+	for _, status := range effective {
+		name := status.name
+		if name == "" {
+			name = status.fallback
+		}
+		if len(name) > 64 {
+			return fmt.Errorf("%s must not exceed 64 characters", status.field)
+		}
+		if strings.EqualFold(name, deletedConsentStatus) {
+			return fmt.Errorf("%s cannot be '%s'", status.field, deletedConsentStatus)
+		}
+		if other, ok := seen[name]; ok {
+			return fmt.Errorf("%s and %s cannot both be '%s'", other, status.field, name)
+		}
+		seen[name] = status.field
+	}
+	return nil
+}
+
+// ToOverrides converts the organization to the consent configuration overrides it defines
+func (o *Organization) ToOverrides() config.OrgOverrides {
+	overrides := config.OrgOverrides{
+		RetentionDays:         o.RetentionDays,
+		WebhookURLs:           o.WebhookURLs,
+		AllowedConsentTypes:   o.AllowedConsentTypes,
+		ExpiryNoticeDays:      o.ExpiryNoticeDays,
+		TerminalRetentionDays: o.TerminalRetentionDays,
+	}
+	if o.DefaultValiditySeconds != nil {
+		defaultValidity := time.Duration(*o.DefaultValiditySeconds) * time.Second
+		overrides.DefaultValidity = &defaultValidity
+	}
+	if o.StatusMappings != nil {
+		overrides.StatusMappings = config.ConsentStatusMappings{
+			ActiveStatus:                  o.StatusMappings.ActiveStatus,
+			ExpiredStatus:                 o.StatusMappings.ExpiredStatus,
+			RevokedStatus:                 o.StatusMappings.RevokedStatus,
+			CreatedStatus:                 o.StatusMappings.CreatedStatus,
+			RejectedStatus:                o.StatusMappings.RejectedStatus,
+			AwaitingReauthorizationStatus: o.StatusMappings.AwaitingReauthorizationStatus,
+			PartiallyAuthorizedStatus:     o.StatusMappings.PartiallyAuthorizedStatus,
+			SuspendedStatus:               o.StatusMappings.SuspendedStatus,
+		}
+	}
+	return overrides
+}
@@ -0,0 +1,207 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wso2/consent-management-api/internal/organization/model"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// loadOverridesPageSize is the number of organizations read per query when loading overrides
+const loadOverridesPageSize = 100
+
+// OrganizationService defines the exported service interface for organizations
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, req model.OrganizationRequest) (*model.Organization, *serviceerror.ServiceError)
+	GetOrganization(ctx context.Context, orgID string) (*model.Organization, *serviceerror.ServiceError)
+	ListOrganizations(ctx context.Context, limit, offset int) (*model.OrganizationListResponse, *serviceerror.ServiceError)
+	UpdateOrganization(ctx context.Context, orgID string, req model.OrganizationRequest) (*model.Organization, *serviceerror.ServiceError)
+	DeleteOrganization(ctx context.Context, orgID string) *serviceerror.ServiceError
+}
+
+// organizationService implements the OrganizationService interface
+type organizationService struct {
+	stores *stores.StoreRegistry
+}
+
+// newOrganizationService creates a new organization service
+func newOrganizationService(registry *stores.StoreRegistry) *organizationService {
+	return &organizationService{
+		stores: registry,
+	}
+}
+
+// loadOverrides replaces the in-memory consent configuration overrides with those of the stored
+// organizations. The overrides are kept unchanged when the organizations cannot be read.
+func (s *organizationService) loadOverrides(ctx context.Context) error {
+	overrides := make(map[string]config.OrgOverrides)
+	for offset := 0; ; offset += loadOverridesPageSize {
+		organizations, total, err := s.stores.Organization.List(ctx, loadOverridesPageSize, offset)
+		if err != nil {
+			return err
+		}
+		for i := range organizations {
+			overrides[organizations[i].OrgID] = organizations[i].ToOverrides()
+		}
+		if len(organizations) == 0 || offset+len(organizations) >= total {
+			break
+		}
+	}
+	config.ReplaceOrgOverrides(overrides)
+	return nil
+}
+
+// refreshOverrides reloads the overrides on a schedule, so changes made through other server
+// instances show up
+func (s *organizationService) refreshOverrides(ctx context.Context) {
+	if err := s.loadOverrides(ctx); err != nil {
+		log.GetLogger().WithContext(ctx).Error("Failed to refresh organization overrides", log.Error(err))
+	}
+}
+
+// CreateOrganization stores a new organization and applies its consent configuration overrides
+func (s *organizationService) CreateOrganization(ctx context.Context, req model.OrganizationRequest) (*model.Organization, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := req.Validate(&config.Get().Consent); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
+
+	existing, err := s.stores.Organization.Get(ctx, req.OrgID)
+	if err != nil {
+		logger.Error("Failed to retrieve organization", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve organization: %v", err))
+	}
+	if existing != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ConflictError,
+			fmt.Sprintf("organization '%s' already exists", req.OrgID))
+	}
+
+	now := utils.GetCurrentTimeMillis()
+	organization := &model.Organization{
+		OrgID:                  req.OrgID,
+		Name:                   req.Name,
+		StatusMappings:         req.StatusMappings,
+		WebhookURLs:            req.WebhookURLs,
+		RetentionDays:          req.RetentionDays,
+		DefaultValiditySeconds: req.DefaultValiditySeconds,
+		AllowedConsentTypes:    req.AllowedConsentTypes,
+		ExpiryNoticeDays:       req.ExpiryNoticeDays,
+		TerminalRetentionDays:  req.TerminalRetentionDays,
+		CreatedTime:            now,
+		UpdatedTime:            now,
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.Organization.Create(tx, organization)
+		},
+	}); err != nil {
+		logger.Error("Failed to create organization", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to create organization: %v", err))
+	}
+	config.SetOrgOverrides(organization.OrgID, organization.ToOverrides())
+
+	logger.Info("Organization created", log.String("org_id", organization.OrgID))
+	return organization, nil
+}
+
+// GetOrganization retrieves an organization
+func (s *organizationService) GetOrganization(ctx context.Context, orgID string) (*model.Organization, *serviceerror.ServiceError) {
+	organization, err := s.stores.Organization.Get(ctx, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve organization: %v", err))
+	}
+	if organization == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("organization '%s' not found", orgID))
+	}
+	return organization, nil
+}
+
+// ListOrganizations retrieves a page of organizations
+func (s *organizationService) ListOrganizations(ctx context.Context, limit, offset int) (*model.OrganizationListResponse, *serviceerror.ServiceError) {
+	organizations, total, err := s.stores.Organization.List(ctx, limit, offset)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to list organizations: %v", err))
+	}
+
+	return &model.OrganizationListResponse{
+		Data: organizations,
+		Metadata: model.PaginationMetadata{
+			Total:  total,
+			Limit:  limit,
+			Offset: offset,
+			Count:  len(organizations),
+		},
+	}, nil
+}
+
+// UpdateOrganization replaces the metadata of an organization. Status name changes apply to consents
+// transitioned afterwards; stored consent statuses are not renamed.
+func (s *organizationService) UpdateOrganization(ctx context.Context, orgID string, req model.OrganizationRequest) (*model.Organization, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	req.OrgID = orgID
+	if err := req.Validate(&config.Get().Consent); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
+
+	existing, serviceErr := s.GetOrganization(ctx, orgID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	organization := &model.Organization{
+		OrgID:                  orgID,
+		Name:                   req.Name,
+		StatusMappings:         req.StatusMappings,
+		WebhookURLs:            req.WebhookURLs,
+		RetentionDays:          req.RetentionDays,
+		DefaultValiditySeconds: req.DefaultValiditySeconds,
+		AllowedConsentTypes:    req.AllowedConsentTypes,
+		ExpiryNoticeDays:       req.ExpiryNoticeDays,
+		TerminalRetentionDays:  req.TerminalRetentionDays,
+		CreatedTime:            existing.CreatedTime,
+		UpdatedTime:            utils.GetCurrentTimeMillis(),
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.Organization.Update(tx, organization)
+		},
+	}); err != nil {
+		logger.Error("Failed to update organization", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to update organization: %v", err))
+	}
+	config.SetOrgOverrides(orgID, organization.ToOverrides())
+
+	logger.Info("Organization updated", log.String("org_id", orgID))
+	return organization, nil
+}
+
+// DeleteOrganization removes an organization, after which its consents use the deployment configuration.
+// The organization's consents and purposes are kept.
+func (s *organizationService) DeleteOrganization(ctx context.Context, orgID string) *serviceerror.ServiceError {
+	if _, serviceErr := s.GetOrganization(ctx, orgID); serviceErr != nil {
+		return serviceErr
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.Organization.Delete(tx, orgID)
+		},
+	}); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to delete organization: %v", err))
+	}
+	config.ClearOrgOverrides(orgID)
+
+	log.GetLogger().WithContext(ctx).Info("Organization deleted", log.String("org_id", orgID))
+	return nil
+}
@@ -0,0 +1,222 @@
+package organization
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/wso2/consent-management-api/internal/organization/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// DBQuery objects for organization operations
+var (
+	QueryCreateOrganization = dbmodel.DBQuery{
+		ID: "CREATE_ORGANIZATION",
+		Query: `INSERT INTO ORGANIZATION (ORG_ID, NAME, STATUS_MAPPINGS, WEBHOOK_URLS, RETENTION_DAYS, DEFAULT_VALIDITY_SECONDS, ALLOWED_CONSENT_TYPES, EXPIRY_NOTICE_DAYS, TERMINAL_RETENTION_DAYS, CREATED_TIME, UPDATED_TIME)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+	}
+
+	QueryGetOrganization = dbmodel.DBQuery{
+		ID:    "GET_ORGANIZATION",
+		Query: "SELECT ORG_ID, NAME, STATUS_MAPPINGS, WEBHOOK_URLS, RETENTION_DAYS, DEFAULT_VALIDITY_SECONDS, ALLOWED_CONSENT_TYPES, EXPIRY_NOTICE_DAYS, TERMINAL_RETENTION_DAYS, CREATED_TIME, UPDATED_TIME FROM ORGANIZATION WHERE ORG_ID = ?",
+	}
+
+	QueryListOrganizations = dbmodel.DBQuery{
+		ID:    "LIST_ORGANIZATIONS",
+		Query: "SELECT ORG_ID, NAME, STATUS_MAPPINGS, WEBHOOK_URLS, RETENTION_DAYS, DEFAULT_VALIDITY_SECONDS, ALLOWED_CONSENT_TYPES, EXPIRY_NOTICE_DAYS, TERMINAL_RETENTION_DAYS, CREATED_TIME, UPDATED_TIME FROM ORGANIZATION ORDER BY ORG_ID LIMIT ? OFFSET ?",
+	}
+
+	QueryCountOrganizations = dbmodel.DBQuery{
+		ID:    "COUNT_ORGANIZATIONS",
+		Query: "SELECT COUNT(*) as count FROM ORGANIZATION",
+	}
+
+	QueryUpdateOrganization = dbmodel.DBQuery{
+		ID: "UPDATE_ORGANIZATION",
+		Query: `UPDATE ORGANIZATION SET NAME = ?, STATUS_MAPPINGS = ?, WEBHOOK_URLS = ?, RETENTION_DAYS = ?, DEFAULT_VALIDITY_SECONDS = ?,
+				ALLOWED_CONSENT_TYPES = ?, EXPIRY_NOTICE_DAYS = ?, TERMINAL_RETENTION_DAYS = ?, UPDATED_TIME = ? WHERE ORG_ID = ?`,
+	}
+
+	QueryDeleteOrganization = dbmodel.DBQuery{
+		ID:    "DELETE_ORGANIZATION",
+		Query: "DELETE FROM ORGANIZATION WHERE ORG_ID = ?",
+	}
+)
+
+// store implements the interfaces.OrganizationStore interface
+type store struct {
+	dbClient provider.DBClientInterface
+}
+
+// NewOrganizationStore creates a new organization store
+func NewOrganizationStore(dbClient provider.DBClientInterface) interfaces.OrganizationStore {
+	return &store{
+		dbClient: dbClient,
+	}
+}
+
+// Get retrieves an organization, nil when it does not exist
+func (s *store) Get(ctx context.Context, orgID string) (*model.Organization, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetOrganization, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return mapToOrganization(rows[0])
+}
+
+// List retrieves a page of organizations ordered by ID, with the total number of organizations
+func (s *store) List(ctx context.Context, limit, offset int) ([]model.Organization, int, error) {
+	countRows, err := s.dbClient.QueryContext(ctx, QueryCountOrganizations)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount := 0
+	if len(countRows) > 0 {
+		if count, ok := countRows[0]["count"].(int64); ok {
+			totalCount = int(count)
+		}
+	}
+
+	rows, err := s.dbClient.QueryContext(ctx, QueryListOrganizations, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	organizations := make([]model.Organization, 0, len(rows))
+	for _, row := range rows {
+		organization, err := mapToOrganization(row)
+		if err != nil {
+			return nil, 0, err
+		}
+		organizations = append(organizations, *organization)
+	}
+	return organizations, totalCount, nil
+}
+
+// Create stores an organization within a transaction
+func (s *store) Create(tx dbmodel.TxInterface, organization *model.Organization) error {
+	statusMappings, webhookURLs, allowedConsentTypes, err := marshalSettings(organization)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(QueryCreateOrganization.Query, organization.OrgID, organization.Name, statusMappings, webhookURLs,
+		organization.RetentionDays, organization.DefaultValiditySeconds, allowedConsentTypes, organization.ExpiryNoticeDays,
+		organization.TerminalRetentionDays, organization.CreatedTime, organization.UpdatedTime)
+	return err
+}
+
+// Update replaces the metadata of an organization within a transaction
+func (s *store) Update(tx dbmodel.TxInterface, organization *model.Organization) error {
+	statusMappings, webhookURLs, allowedConsentTypes, err := marshalSettings(organization)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(QueryUpdateOrganization.Query, organization.Name, statusMappings, webhookURLs,
+		organization.RetentionDays, organization.DefaultValiditySeconds, allowedConsentTypes, organization.ExpiryNoticeDays,
+		organization.TerminalRetentionDays, organization.UpdatedTime, organization.OrgID)
+	return err
+}
+
+// Delete removes an organization within a transaction. Consents and purposes of the organization are kept.
+func (s *store) Delete(tx dbmodel.TxInterface, orgID string) error {
+	_, err := tx.Exec(QueryDeleteOrganization.Query, orgID)
+	return err
+}
+
+// marshalSettings encodes the JSON columns of an organization, nil for settings that are not set
+func marshalSettings(organization *model.Organization) (interface{}, interface{}, interface{}, error) {
+	var statusMappings interface{}
+	if organization.StatusMappings != nil {
+		data, err := json.Marshal(organization.StatusMappings)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		statusMappings = string(data)
+	}
+	webhookURLs, err := marshalList(organization.WebhookURLs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	allowedConsentTypes, err := marshalList(organization.AllowedConsentTypes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return statusMappings, webhookURLs, allowedConsentTypes, nil
+}
+
+// marshalList encodes a list column, nil for an empty list
+func marshalList(values []string) (interface{}, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// mapToOrganization converts a database row map to Organization
+// Note: DBClient normalizes column names to lowercase
+func mapToOrganization(row map[string]interface{}) (*model.Organization, error) {
+	organization := &model.Organization{
+		OrgID:       getString(row, "org_id"),
+		Name:        getString(row, "name"),
+		CreatedTime: getInt64(row, "created_time"),
+		UpdatedTime: getInt64(row, "updated_time"),
+	}
+	if statusMappings := getString(row, "status_mappings"); statusMappings != "" {
+		organization.StatusMappings = &model.StatusMappings{}
+		if err := json.Unmarshal([]byte(statusMappings), organization.StatusMappings); err != nil {
+			return nil, err
+		}
+	}
+	if webhookURLs := getString(row, "webhook_urls"); webhookURLs != "" {
+		if err := json.Unmarshal([]byte(webhookURLs), &organization.WebhookURLs); err != nil {
+			return nil, err
+		}
+	}
+	if allowedConsentTypes := getString(row, "allowed_consent_types"); allowedConsentTypes != "" {
+		if err := json.Unmarshal([]byte(allowedConsentTypes), &organization.AllowedConsentTypes); err != nil {
+			return nil, err
+		}
+	}
+	if retentionDays, ok := row["retention_days"].(int64); ok {
+		days := int(retentionDays)
+		organization.RetentionDays = &days
+	}
+	if defaultValiditySeconds, ok := row["default_validity_seconds"].(int64); ok {
+		organization.DefaultValiditySeconds = &defaultValiditySeconds
+	}
+	if expiryNoticeDays, ok := row["expiry_notice_days"].(int64); ok {
+		days := int(expiryNoticeDays)
+		organization.ExpiryNoticeDays = &days
+	}
+	if terminalRetentionDays, ok := row["terminal_retention_days"].(int64); ok {
+		days := int(terminalRetentionDays)
+		organization.TerminalRetentionDays = &days
+	}
+	return organization, nil
+}
+
+func getString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+func getInt64(row map[string]interface{}, key string) int64 {
+	if v, ok := row[key].(int64); ok {
+		return v
+	}
+	return 0
+}
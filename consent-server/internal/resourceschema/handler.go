@@ -0,0 +1,96 @@
+package resourceschema
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/resourceschema/model"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// resourceSchemaHandler handles HTTP requests for authorization resources schemas
+type resourceSchemaHandler struct {
+	service ResourceSchemaService
+}
+
+// newResourceSchemaHandler creates a new resources schema handler
+func newResourceSchemaHandler(service ResourceSchemaService) *resourceSchemaHandler {
+	return &resourceSchemaHandler{
+		service: service,
+	}
+}
+
+// listSchemas handles GET /admin/auth-resource-schemas
+func (h *resourceSchemaHandler) listSchemas(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	schemas, serviceErr := h.service.ListSchemas(r.Context(), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, schemas)
+}
+
+// getSchema handles GET /admin/auth-resource-schemas/{authType}
+func (h *resourceSchemaHandler) getSchema(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	schema, serviceErr := h.service.GetSchema(r.Context(), r.PathValue("authType"), orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, schema)
+}
+
+// putSchema handles PUT /admin/auth-resource-schemas/{authType}
+func (h *resourceSchemaHandler) putSchema(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	var req model.ResourceSchemaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, "invalid request body"))
+		return
+	}
+
+	schema, serviceErr := h.service.PutSchema(r.Context(), r.PathValue("authType"), req, orgID)
+	if serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, schema)
+}
+
+// deleteSchema handles DELETE /admin/auth-resource-schemas/{authType}
+func (h *resourceSchemaHandler) deleteSchema(w http.ResponseWriter, r *http.Request) {
+	orgID := r.Header.Get(constants.HeaderOrgID)
+	if err := utils.ValidateOrgID(orgID); err != nil {
+		utils.SendError(w, r, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error()))
+		return
+	}
+
+	if serviceErr := h.service.DeleteSchema(r.Context(), r.PathValue("authType"), orgID); serviceErr != nil {
+		utils.SendError(w, r, serviceErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,44 @@
+package resourceschema
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/middleware"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+)
+
+// Initialize sets up the authorization resources schema module and registers routes
+func Initialize(mux *http.ServeMux, registry *stores.StoreRegistry) ResourceSchemaService {
+	service := newResourceSchemaService(registry)
+	handler := newResourceSchemaHandler(service)
+
+	registerRoutes(mux, handler)
+
+	return service
+}
+
+// registerRoutes registers the resources schema admin routes. Admin routes are protected with admin basic auth.
+func registerRoutes(mux *http.ServeMux, handler *resourceSchemaHandler) {
+	corsOpts := middleware.CORSOptions{
+		AllowOrigin:  "*",
+		AllowMethods: []string{"GET", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{"Content-Type", "Authorization", "org-id", "X-Correlation-ID"},
+	}
+
+	// GET /api/v1/admin/auth-resource-schemas - List the organization's resources schemas
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/auth-resource-schemas",
+		middleware.WithAdminAuth(handler.listSchemas), corsOpts))
+
+	// GET /api/v1/admin/auth-resource-schemas/{authType} - Get the resources schema of an authorization type
+	mux.HandleFunc(middleware.WithCORS("GET "+constants.APIBasePath+"/admin/auth-resource-schemas/{authType}",
+		middleware.WithAdminAuth(handler.getSchema), corsOpts))
+
+	// PUT /api/v1/admin/auth-resource-schemas/{authType} - Create or replace the resources schema of an authorization type
+	mux.HandleFunc(middleware.WithCORS("PUT "+constants.APIBasePath+"/admin/auth-resource-schemas/{authType}",
+		middleware.WithAdminAuth(handler.putSchema), corsOpts))
+
+	// DELETE /api/v1/admin/auth-resource-schemas/{authType} - Remove the resources schema of an authorization type
+	mux.HandleFunc(middleware.WithCORS("DELETE "+constants.APIBasePath+"/admin/auth-resource-schemas/{authType}",
+		middleware.WithAdminAuth(handler.deleteSchema), corsOpts))
+}
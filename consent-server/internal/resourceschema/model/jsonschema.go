@@ -0,0 +1,411 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// JSON Schema value types
+const (
+	JSONTypeObject  = "object"
+	JSONTypeArray   = "array"
+	JSONTypeString  = "string"
+	JSONTypeNumber  = "number"
+	JSONTypeInteger = "integer"
+	JSONTypeBoolean = "boolean"
+	JSONTypeNull    = "null"
+)
+
+// annotationKeywords are accepted in schemas but have no effect on validation
+var annotationKeywords = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+	"default":     true,
+	"examples":    true,
+}
+
+// JSONSchema is a compiled JSON Schema. The supported keywords are type, enum, const, properties,
+// required, additionalProperties, items, minItems, maxItems, minLength, maxLength, pattern, minimum
+// and maximum. Schemas using any other validation keyword are rejected when compiled, so that a
+// schema is never silently enforced more loosely than it reads.
+type JSONSchema struct {
+	always *bool // set for the boolean schemas true and false
+
+	types                []string
+	enum                 []interface{}
+	constValue           interface{}
+	hasConst             bool
+	properties           map[string]*JSONSchema
+	required             []string
+	additionalProperties *JSONSchema
+	items                *JSONSchema
+	minItems             *int
+	maxItems             *int
+	minLength            *int
+	maxLength            *int
+	pattern              *regexp.Regexp
+	minimum              *float64
+	maximum              *float64
+}
+
+// CompileJSONSchema parses and checks a JSON Schema document
+func CompileJSONSchema(raw json.RawMessage) (*JSONSchema, error) {
+	var node interface{}
+	if err := decodeJSON(raw, &node); err != nil {
+		return nil, fmt.Errorf("schema must be valid JSON: %v", err)
+	}
+	return compileNode(node, "$")
+}
+
+// Validate checks a value decoded from JSON against the schema. Numbers must be decoded as json.Number.
+func (s *JSONSchema) Validate(value interface{}) error {
+	return s.validate(value, "$")
+}
+
+// decodeJSON decodes data keeping numbers as json.Number
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// compileNode compiles a schema node found at path
+func compileNode(node interface{}, path string) (*JSONSchema, error) {
+	if b, ok := node.(bool); ok {
+		return &JSONSchema{always: &b}, nil
+	}
+	object, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema at %s must be an object or a boolean", path)
+	}
+
+	// Compile keywords in a stable order so the reported error does not change between requests
+	keywords := make([]string, 0, len(object))
+	for keyword := range object {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	schema := &JSONSchema{}
+	for _, keyword := range keywords {
+		value := object[keyword]
+		var err error
+		switch keyword {
+		case "type":
+			schema.types, err = compileTypes(value, path)
+		case "enum":
+			values, ok := value.([]interface{})
+			if !ok || len(values) == 0 {
+				err = fmt.Errorf("enum at %s must be a non-empty array", path)
+			}
+			schema.enum = values
+		case "const":
+			schema.constValue = value
+			schema.hasConst = true
+		case "properties":
+			properties, ok := value.(map[string]interface{})
+			if !ok {
+				err = fmt.Errorf("properties at %s must be an object", path)
+				break
+			}
+			schema.properties = make(map[string]*JSONSchema, len(properties))
+			for name, property := range properties {
+				if schema.properties[name], err = compileNode(property, path+"."+name); err != nil {
+					break
+				}
+			}
+		case "required":
+			schema.required, err = compileStrings(value, "required", path)
+		case "additionalProperties":
+			schema.additionalProperties, err = compileNode(value, path+".additionalProperties")
+		case "items":
+			schema.items, err = compileNode(value, path+"[]")
+		case "minItems":
+			schema.minItems, err = compileCount(value, keyword, path)
+		case "maxItems":
+			schema.maxItems, err = compileCount(value, keyword, path)
+		case "minLength":
+			schema.minLength, err = compileCount(value, keyword, path)
+		case "maxLength":
+			schema.maxLength, err = compileCount(value, keyword, path)
+		case "pattern":
+			pattern, ok := value.(string)
+			if !ok {
+				err = fmt.Errorf("pattern at %s must be a string", path)
+				break
+			}
+			if schema.pattern, err = regexp.Compile(pattern); err != nil {
+				err = fmt.Errorf("pattern at %s is invalid: %v", path, err)
+			}
+		case "minimum":
+			schema.minimum, err = compileNumber(value, keyword, path)
+		case "maximum":
+			schema.maximum, err = compileNumber(value, keyword, path)
+		default:
+			if !annotationKeywords[keyword] {
+				err = fmt.Errorf("unsupported keyword '%s' at %s", keyword, path)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+func compileTypes(value interface{}, path string) ([]string, error) {
+	var types []string
+	if single, ok := value.(string); ok {
+		types = []string{single}
+	} else {
+		var err error
+		if types, err = compileStrings(value, "type", path); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range types {
+		switch t {
+		case JSONTypeObject, JSONTypeArray, JSONTypeString, JSONTypeNumber, JSONTypeInteger, JSONTypeBoolean, JSONTypeNull:
+		default:
+			return nil, fmt.Errorf("type at %s has unsupported value '%s'", path, t)
+		}
+	}
+	return types, nil
+}
+
+func compileStrings(value interface{}, keyword, path string) ([]string, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s at %s must be an array of strings", keyword, path)
+	}
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s at %s must be an array of strings", keyword, path)
+		}
+		strs = append(strs, s)
+	}
+	return strs, nil
+}
+
+func compileCount(value interface{}, keyword, path string) (*int, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("%s at %s must be a non-negative integer", keyword, path)
+	}
+	count, err := strconv.Atoi(number.String())
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("%s at %s must be a non-negative integer", keyword, path)
+	}
+	return &count, nil
+}
+
+func compileNumber(value interface{}, keyword, path string) (*float64, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("%s at %s must be a number", keyword, path)
+	}
+	f, err := number.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("%s at %s must be a number", keyword, path)
+	}
+	return &f, nil
+}
+
+// validate checks value, found at path, against the schema
+func (s *JSONSchema) validate(value interface{}, path string) error {
+	if s.always != nil {
+		if !*s.always {
+			return fmt.Errorf("%s is not allowed", path)
+		}
+		return nil
+	}
+
+	if len(s.types) > 0 {
+		matched := false
+		for _, t := range s.types {
+			if hasJSONType(value, t) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s must be of type %s", path, strings.Join(s.types, " or "))
+		}
+	}
+
+	if s.hasConst && !jsonEqual(value, s.constValue) {
+		return fmt.Errorf("%s must be equal to the schema constant", path)
+	}
+	if s.enum != nil {
+		matched := false
+		for _, allowed := range s.enum {
+			if jsonEqual(value, allowed) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s must be one of the schema enum values", path)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return s.validateObject(v, path)
+	case []interface{}:
+		return s.validateArray(v, path)
+	case string:
+		return s.validateString(v, path)
+	case json.Number:
+		return s.validateNumber(v, path)
+	}
+	return nil
+}
+
+func (s *JSONSchema) validateObject(object map[string]interface{}, path string) error {
+	for _, name := range s.required {
+		if _, ok := object[name]; !ok {
+			return fmt.Errorf("%s.%s is required", path, name)
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if property, ok := s.properties[name]; ok {
+			if err := property.validate(object[name], path+"."+name); err != nil {
+				return err
+			}
+		} else if s.additionalProperties != nil {
+			if err := s.additionalProperties.validate(object[name], path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *JSONSchema) validateArray(array []interface{}, path string) error {
+	if s.minItems != nil && len(array) < *s.minItems {
+		return fmt.Errorf("%s must have at least %d items", path, *s.minItems)
+	}
+	if s.maxItems != nil && len(array) > *s.maxItems {
+		return fmt.Errorf("%s must have at most %d items", path, *s.maxItems)
+	}
+	if s.items != nil {
+		for i, item := range array {
+			if err := s.items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *JSONSchema) validateString(str, path string) error {
+	length := utf8.RuneCountInString(str)
+	if s.minLength != nil && length < *s.minLength {
+		return fmt.Errorf("%s must be at least %d characters", path, *s.minLength)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		return fmt.Errorf("%s must be at most %d characters", path, *s.maxLength)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return fmt.Errorf("%s does not match pattern '%s'", path, s.pattern.String())
+	}
+	return nil
+}
+
+func (s *JSONSchema) validateNumber(number json.Number, path string) error {
+	f, err := number.Float64()
+	if err != nil {
+		return fmt.Errorf("%s must be a number", path)
+	}
+	if s.minimum != nil && f < *s.minimum {
+		return fmt.Errorf("%s must be at least %v", path, *s.minimum)
+	}
+	if s.maximum != nil && f > *s.maximum {
+		return fmt.Errorf("%s must be at most %v", path, *s.maximum)
+	}
+	return nil
+}
+
+// hasJSONType reports whether a decoded JSON value is of the given JSON Schema type
+func hasJSONType(value interface{}, t string) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return t == JSONTypeObject
+	case []interface{}:
+		return t == JSONTypeArray
+	case string:
+		return t == JSONTypeString
+	case bool:
+		return t == JSONTypeBoolean
+	case nil:
+		return t == JSONTypeNull
+	case json.Number:
+		if t == JSONTypeNumber {
+			return true
+		}
+		if t == JSONTypeInteger {
+			f, err := v.Float64()
+			return err == nil && f == math.Trunc(f)
+		}
+	}
+	return false
+}
+
+// jsonEqual compares two decoded JSON values, comparing numbers by value
+func jsonEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		af, errA := av.Float64()
+		bf, errB := bv.Float64()
+		return errA == nil && errB == nil && af == bf
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ResourceSchema represents the AUTH_RESOURCE_SCHEMA table. An organization has at most one schema
+// per authorization type; the resources of authorizations of that type must conform to it.
+type ResourceSchema struct {
+	OrgID       string          `json:"orgId"`
+	AuthType    string          `json:"authType"`
+	Schema      json.RawMessage `json:"schema"`
+	CreatedTime int64           `json:"createdTime"`
+	UpdatedTime int64           `json:"updatedTime"`
+}
+
+// ResourceSchemaRequest represents the request body for replacing the resources schema of an authorization type
+type ResourceSchemaRequest struct {
+	Schema json.RawMessage `json:"schema"`
+}
+
+// ResourceSchemaListResponse represents the resources schemas of an organization
+type ResourceSchemaListResponse struct {
+	Data []ResourceSchema `json:"data"`
+}
+
+// Validate checks that the request holds a JSON Schema this server can enforce
+func (r ResourceSchemaRequest) Validate() error {
+	if len(bytes.TrimSpace(r.Schema)) == 0 || bytes.Equal(bytes.TrimSpace(r.Schema), []byte("null")) {
+		return fmt.Errorf("schema is required")
+	}
+	_, err := CompileJSONSchema(r.Schema)
+	return err
+}
+
+// ValidateResources checks authorization resources against the schema. The resources are
+// compared in their JSON form, so any value that marshals to JSON can be given.
+func (s *ResourceSchema) ValidateResources(resources interface{}) error {
+	schema, err := CompileJSONSchema(s.Schema)
+	if err != nil {
+		return fmt.Errorf("resources schema for authorization type '%s' is invalid: %v", s.AuthType, err)
+	}
+
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return fmt.Errorf("resources must be valid JSON: %v", err)
+	}
+	var value interface{}
+	if err := decodeJSON(data, &value); err != nil {
+		return fmt.Errorf("resources must be valid JSON: %v", err)
+	}
+
+	if err := schema.Validate(value); err != nil {
+		return fmt.Errorf("resources do not match the schema for authorization type '%s': %v", s.AuthType, err)
+	}
+	return nil
+}
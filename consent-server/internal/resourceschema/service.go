@@ -0,0 +1,123 @@
+package resourceschema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wso2/consent-management-api/internal/resourceschema/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/stores"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// ResourceSchemaService defines the exported service interface for authorization resources schemas
+type ResourceSchemaService interface {
+	ListSchemas(ctx context.Context, orgID string) (*model.ResourceSchemaListResponse, *serviceerror.ServiceError)
+	GetSchema(ctx context.Context, authType, orgID string) (*model.ResourceSchema, *serviceerror.ServiceError)
+	PutSchema(ctx context.Context, authType string, req model.ResourceSchemaRequest, orgID string) (*model.ResourceSchema, *serviceerror.ServiceError)
+	DeleteSchema(ctx context.Context, authType, orgID string) *serviceerror.ServiceError
+}
+
+// resourceSchemaService implements the ResourceSchemaService interface
+type resourceSchemaService struct {
+	stores *stores.StoreRegistry
+}
+
+// newResourceSchemaService creates a new resources schema service
+func newResourceSchemaService(registry *stores.StoreRegistry) ResourceSchemaService {
+	return &resourceSchemaService{
+		stores: registry,
+	}
+}
+
+// ListSchemas retrieves the resources schemas of an organization
+func (s *resourceSchemaService) ListSchemas(ctx context.Context, orgID string) (*model.ResourceSchemaListResponse, *serviceerror.ServiceError) {
+	schemas, err := s.stores.ResourceSchema.List(ctx, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve resources schemas: %v", err))
+	}
+	return &model.ResourceSchemaListResponse{Data: schemas}, nil
+}
+
+// GetSchema retrieves the resources schema of an authorization type
+func (s *resourceSchemaService) GetSchema(ctx context.Context, authType, orgID string) (*model.ResourceSchema, *serviceerror.ServiceError) {
+	schema, err := s.stores.ResourceSchema.Get(ctx, authType, orgID)
+	if err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve resources schema: %v", err))
+	}
+	if schema == nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.ResourceNotFoundError,
+			fmt.Sprintf("no resources schema defined for authorization type '%s'", authType))
+	}
+	return schema, nil
+}
+
+// PutSchema creates or replaces the resources schema of an authorization type. The schema applies to
+// authorizations created or updated afterwards; existing resources are not re-validated.
+func (s *resourceSchemaService) PutSchema(ctx context.Context, authType string, req model.ResourceSchemaRequest, orgID string) (*model.ResourceSchema, *serviceerror.ServiceError) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	if err := utils.ValidateRequired("authType", authType); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
+	if err := req.Validate(); err != nil {
+		return nil, serviceerror.CustomServiceError(serviceerror.InvalidRequestError, err.Error())
+	}
+
+	existing, err := s.stores.ResourceSchema.Get(ctx, authType, orgID)
+	if err != nil {
+		logger.Error("Failed to retrieve resources schema", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to retrieve resources schema: %v", err))
+	}
+
+	now := utils.GetCurrentTimeMillis()
+	schema := &model.ResourceSchema{
+		OrgID:       orgID,
+		AuthType:    authType,
+		Schema:      req.Schema,
+		CreatedTime: now,
+		UpdatedTime: now,
+	}
+
+	query := func(tx dbmodel.TxInterface) error {
+		return s.stores.ResourceSchema.Create(tx, schema)
+	}
+	if existing != nil {
+		schema.CreatedTime = existing.CreatedTime
+		query = func(tx dbmodel.TxInterface) error {
+			return s.stores.ResourceSchema.Update(tx, schema)
+		}
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{query}); err != nil {
+		logger.Error("Failed to store resources schema", log.Error(err))
+		return nil, serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to store resources schema: %v", err))
+	}
+
+	logger.Info("Resources schema stored",
+		log.String("org_id", orgID),
+		log.String("auth_type", authType))
+	return schema, nil
+}
+
+// DeleteSchema removes the resources schema of an authorization type, after which its resources are freeform again
+func (s *resourceSchemaService) DeleteSchema(ctx context.Context, authType, orgID string) *serviceerror.ServiceError {
+	if _, serviceErr := s.GetSchema(ctx, authType, orgID); serviceErr != nil {
+		return serviceErr
+	}
+
+	if err := s.stores.ExecuteTransaction(ctx, []func(tx dbmodel.TxInterface) error{
+		func(tx dbmodel.TxInterface) error {
+			return s.stores.ResourceSchema.Delete(tx, authType, orgID)
+		},
+	}); err != nil {
+		return serviceerror.CustomServiceError(serviceerror.DatabaseError, fmt.Sprintf("failed to delete resources schema: %v", err))
+	}
+
+	log.GetLogger().WithContext(ctx).Info("Resources schema deleted",
+		log.String("org_id", orgID),
+		log.String("auth_type", authType))
+	return nil
+}
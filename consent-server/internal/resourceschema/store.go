@@ -0,0 +1,122 @@
+package resourceschema
+
+import (
+	"context"
+
+	"github.com/wso2/consent-management-api/internal/resourceschema/model"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+)
+
+// DBQuery objects for authorization resources schema operations
+var (
+	QueryCreateResourceSchema = dbmodel.DBQuery{
+		ID:    "CREATE_AUTH_RESOURCE_SCHEMA",
+		Query: "INSERT INTO AUTH_RESOURCE_SCHEMA (AUTH_TYPE, ORG_ID, SCHEMA_JSON, CREATED_TIME, UPDATED_TIME) VALUES (?, ?, ?, ?, ?)",
+	}
+
+	QueryGetResourceSchema = dbmodel.DBQuery{
+		ID:    "GET_AUTH_RESOURCE_SCHEMA",
+		Query: "SELECT AUTH_TYPE, ORG_ID, SCHEMA_JSON, CREATED_TIME, UPDATED_TIME FROM AUTH_RESOURCE_SCHEMA WHERE AUTH_TYPE = ? AND ORG_ID = ?",
+	}
+
+	QueryListResourceSchemas = dbmodel.DBQuery{
+		ID:    "LIST_AUTH_RESOURCE_SCHEMAS",
+		Query: "SELECT AUTH_TYPE, ORG_ID, SCHEMA_JSON, CREATED_TIME, UPDATED_TIME FROM AUTH_RESOURCE_SCHEMA WHERE ORG_ID = ? ORDER BY AUTH_TYPE",
+	}
+
+	QueryUpdateResourceSchema = dbmodel.DBQuery{
+		ID:    "UPDATE_AUTH_RESOURCE_SCHEMA",
+		Query: "UPDATE AUTH_RESOURCE_SCHEMA SET SCHEMA_JSON = ?, UPDATED_TIME = ? WHERE AUTH_TYPE = ? AND ORG_ID = ?",
+	}
+
+	QueryDeleteResourceSchema = dbmodel.DBQuery{
+		ID:    "DELETE_AUTH_RESOURCE_SCHEMA",
+		Query: "DELETE FROM AUTH_RESOURCE_SCHEMA WHERE AUTH_TYPE = ? AND ORG_ID = ?",
+	}
+)
+
+// store implements the interfaces.ResourceSchemaStore interface
+type store struct {
+	dbClient provider.DBClientInterface
+}
+
+// NewResourceSchemaStore creates a new authorization resources schema store
+func NewResourceSchemaStore(dbClient provider.DBClientInterface) interfaces.ResourceSchemaStore {
+	return &store{
+		dbClient: dbClient,
+	}
+}
+
+// Get retrieves the resources schema of an authorization type, nil when none is defined
+func (s *store) Get(ctx context.Context, authType, orgID string) (*model.ResourceSchema, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryGetResourceSchema, authType, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return mapToResourceSchema(rows[0]), nil
+}
+
+// List retrieves the resources schemas of an organization ordered by authorization type
+func (s *store) List(ctx context.Context, orgID string) ([]model.ResourceSchema, error) {
+	rows, err := s.dbClient.QueryContext(ctx, QueryListResourceSchemas, orgID)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make([]model.ResourceSchema, 0, len(rows))
+	for _, row := range rows {
+		schemas = append(schemas, *mapToResourceSchema(row))
+	}
+	return schemas, nil
+}
+
+// Create stores the resources schema of an authorization type within a transaction
+func (s *store) Create(tx dbmodel.TxInterface, schema *model.ResourceSchema) error {
+	_, err := tx.Exec(QueryCreateResourceSchema.Query, schema.AuthType, schema.OrgID, string(schema.Schema),
+		schema.CreatedTime, schema.UpdatedTime)
+	return err
+}
+
+// Update replaces the resources schema of an authorization type within a transaction
+func (s *store) Update(tx dbmodel.TxInterface, schema *model.ResourceSchema) error {
+	_, err := tx.Exec(QueryUpdateResourceSchema.Query, string(schema.Schema), schema.UpdatedTime, schema.AuthType, schema.OrgID)
+	return err
+}
+
+// Delete removes the resources schema of an authorization type within a transaction
+func (s *store) Delete(tx dbmodel.TxInterface, authType, orgID string) error {
+	_, err := tx.Exec(QueryDeleteResourceSchema.Query, authType, orgID)
+	return err
+}
+
+// mapToResourceSchema converts a database row map to ResourceSchema
+// Note: DBClient normalizes column names to lowercase
+func mapToResourceSchema(row map[string]interface{}) *model.ResourceSchema {
+	return &model.ResourceSchema{
+		AuthType:    getString(row, "auth_type"),
+		OrgID:       getString(row, "org_id"),
+		Schema:      []byte(getString(row, "schema_json")),
+		CreatedTime: getInt64(row, "created_time"),
+		UpdatedTime: getInt64(row, "updated_time"),
+	}
+}
+
+func getString(row map[string]interface{}, key string) string {
+	if v, ok := row[key].(string); ok {
+		return v
+	} else if v, ok := row[key].([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+func getInt64(row map[string]interface{}, key string) int64 {
+	if v, ok := row[key].(int64); ok {
+		return v
+	}
+	return 0
+}
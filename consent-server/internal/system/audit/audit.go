@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package audit records the operations callers perform on consents. The HTTP middleware starts an
+// operation for each audited request and records it with its outcome once the handler returns;
+// services add the consent ID and the details of what changed through the request context.
+package audit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// Action identifies an audited operation
+type Action string
+
+// Audited operations
+const (
+	ActionConsentCreate      Action = "consent.create"
+	ActionConsentUpdate      Action = "consent.update"
+	ActionConsentAmend       Action = "consent.amend"
+	ActionConsentRevoke      Action = "consent.revoke"
+	ActionConsentDelete      Action = "consent.delete"
+	ActionConsentReauthorize Action = "consent.reauthorize"
+	ActionStatusOverride     Action = "consent.status_override"
+	ActionFileUpload         Action = "consent.file_upload"
+	ActionConsentTag         Action = "consent.tag"
+	ActionConsentUntag       Action = "consent.untag"
+	ActionConsentSuspend     Action = "consent.suspend"
+	ActionConsentResume      Action = "consent.resume"
+	ActionConsentTokenBind   Action = "consent.token_bind"
+	ActionConsentTokenUnbind Action = "consent.token_unbind"
+	ActionConsentLock        Action = "consent.lock"
+	ActionConsentUnlock      Action = "consent.unlock"
+	// ActionConsentRetention is recorded by the retention job for each organization it purged
+	// consents of, with the IDs of the purged consents
+	ActionConsentRetention Action = "consent.retention_purge"
+	// ActionUserErase is recorded for right-to-erasure requests, with the erasure ID and the
+	// consents of the erased user
+	ActionUserErase Action = "user.erase"
+	// ActionUserPreferenceUpdate is recorded for preference center updates, with the purposes the
+	// user opted in to and out of and the consents changed
+	ActionUserPreferenceUpdate Action = "user.preference_update"
+	// ActionOwnershipTransfer is recorded for consent ownership transfers, with the users and the
+	// consents transferred
+	ActionOwnershipTransfer Action = "user.ownership_transfer"
+)
+
+// Operation outcomes
+const (
+	OutcomeSuccess = "SUCCESS"
+	OutcomeFailure = "FAILURE"
+)
+
+// Operation describes a call to an audited endpoint
+type Operation struct {
+	Action     Action
+	OrgID      string
+	ConsentID  string
+	Actor      string // Authenticated user, or the client ID for unauthenticated callers
+	ClientID   string
+	Method     string
+	Route      string
+	StatusCode int
+	Outcome    string
+	Time       int64 // Unix timestamp in milliseconds
+
+	mu      sync.Mutex
+	details map[string]interface{}
+}
+
+// Details returns a copy of the details added to the operation
+func (o *Operation) Details() map[string]interface{} {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.details) == 0 {
+		return nil
+	}
+	details := make(map[string]interface{}, len(o.details))
+	for key, value := range o.details {
+		details[key] = value
+	}
+	return details
+}
+
+// Recorder stores audited operations
+type Recorder interface {
+	Record(ctx context.Context, op *Operation) error
+}
+
+type contextKey struct{}
+
+var (
+	recorderMu sync.RWMutex
+	recorder   Recorder
+)
+
+// SetRecorder replaces the recorder operations are stored with. Operations are dropped until a
+// recorder is set.
+func SetRecorder(r Recorder) {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	recorder = r
+}
+
+// WithOperation returns a context carrying the operation, so services can add to it
+func WithOperation(ctx context.Context, op *Operation) context.Context {
+	return context.WithValue(ctx, contextKey{}, op)
+}
+
+// FromContext returns the operation of the request, nil when the request is not audited
+func FromContext(ctx context.Context) *Operation {
+	op, _ := ctx.Value(contextKey{}).(*Operation)
+	return op
+}
+
+// SetConsentID sets the consent of the operation, for operations that create the consent
+func SetConsentID(ctx context.Context, consentID string) {
+	if op := FromContext(ctx); op != nil {
+		op.mu.Lock()
+		defer op.mu.Unlock()
+		op.ConsentID = consentID
+	}
+}
+
+// AddDetail records what the operation changed, for example the attribute keys it added. Values
+// must be JSON serializable.
+func AddDetail(ctx context.Context, key string, value interface{}) {
+	if op := FromContext(ctx); op != nil {
+		op.mu.Lock()
+		defer op.mu.Unlock()
+		if op.details == nil {
+			op.details = make(map[string]interface{})
+		}
+		op.details[key] = value
+	}
+}
+
+// Record stores the operation with the configured recorder. Failures are logged and do not fail
+// the request that was audited.
+func Record(ctx context.Context, op *Operation) {
+	recorderMu.RLock()
+	r := recorder
+	recorderMu.RUnlock()
+	if r == nil {
+		return
+	}
+
+	// Operations are recorded even when the request timed out or the client went away
+	if err := r.Record(context.WithoutCancel(ctx), op); err != nil {
+		log.GetLogger().WithContext(ctx).Error("Failed to record audited operation",
+			log.Error(err),
+			log.String("action", string(op.Action)),
+			log.String("consent_id", op.ConsentID))
+	}
+}
@@ -0,0 +1,313 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package cache provides server-side caches that can be inspected and invalidated at runtime.
+package cache
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultHotKeyCount is the number of hottest keys reported in cache stats by default
+	DefaultHotKeyCount = 10
+
+	tagConsentPrefix = "consent:"
+	tagPurposePrefix = "purpose:"
+)
+
+// ConsentTag returns the tag used to associate a cache entry with a consent ID
+func ConsentTag(consentID string) string {
+	return tagConsentPrefix + consentID
+}
+
+// PurposeTag returns the tag used to associate a cache entry with a purpose name
+func PurposeTag(purposeName string) string {
+	return tagPurposePrefix + purposeName
+}
+
+// ManagedCache is implemented by every cache that can be inspected and invalidated
+// through the administrative API.
+type ManagedCache interface {
+	Name() string
+	Stats(hotKeyCount int) Stats
+	Invalidate(filter InvalidationFilter) int
+	Clear() int
+}
+
+// InvalidationFilter selects cache entries to invalidate.
+// Entries must match every non-empty field to be removed.
+type InvalidationFilter struct {
+	OrgID       string
+	ConsentID   string
+	PurposeName string
+}
+
+// IsEmpty returns true when no filter criteria are set
+func (f InvalidationFilter) IsEmpty() bool {
+	return f.OrgID == "" && f.ConsentID == "" && f.PurposeName == ""
+}
+
+// Stats holds runtime statistics for a cache
+type Stats struct {
+	Name       string        `json:"name"`
+	Size       int           `json:"size"`
+	MaxEntries int           `json:"maxEntries"`
+	TTLSeconds int64         `json:"ttlSeconds"`
+	Hits       uint64        `json:"hits"`
+	Misses     uint64        `json:"misses"`
+	Evictions  uint64        `json:"evictions"`
+	HitRate    float64       `json:"hitRate"`
+	HotKeys    []HotKeyStats `json:"hotKeys"`
+}
+
+// HotKeyStats holds the hit count of a single cache key
+type HotKeyStats struct {
+	Key   string `json:"key"`
+	OrgID string `json:"orgId"`
+	Hits  uint64 `json:"hits"`
+}
+
+type entry struct {
+	key        string
+	value      interface{}
+	orgID      string
+	tags       []string
+	expiresAt  time.Time
+	lastAccess time.Time
+	hits       uint64
+}
+
+// MemoryCache is a concurrency-safe in-memory cache with TTL expiry, a bounded size
+// and per-entry tags used for targeted invalidation.
+type MemoryCache struct {
+	name       string
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*entry
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// NewMemoryCache creates a new in-memory cache. A zero ttl disables expiry and a
+// zero maxEntries leaves the cache unbounded.
+func NewMemoryCache(name string, ttl time.Duration, maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		name:       name,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*entry),
+	}
+}
+
+// Name returns the cache name
+func (c *MemoryCache) Name() string {
+	return c.name
+}
+
+// Get returns the cached value for the key within the given organization
+func (c *MemoryCache) Get(orgID, key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[entryKey(orgID, key)]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	now := time.Now()
+	if c.isExpired(e, now) {
+		delete(c.entries, entryKey(orgID, key))
+		c.evictions.Add(1)
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	e.hits++
+	e.lastAccess = now
+	c.hits.Add(1)
+	return e.value, true
+}
+
+// Set stores a value for the key within the given organization. Tags (see ConsentTag
+// and PurposeTag) allow the entry to be invalidated by related resource identifiers.
+func (c *MemoryCache) Set(orgID, key string, value interface{}, tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	k := entryKey(orgID, key)
+	if _, exists := c.entries[k]; !exists && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evictOne(now)
+	}
+
+	e := &entry{
+		key:        key,
+		value:      value,
+		orgID:      orgID,
+		tags:       tags,
+		lastAccess: now,
+	}
+	if c.ttl > 0 {
+		e.expiresAt = now.Add(c.ttl)
+	}
+	c.entries[k] = e
+}
+
+// Delete removes a single key within the given organization
+func (c *MemoryCache) Delete(orgID, key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := entryKey(orgID, key)
+	if _, ok := c.entries[k]; !ok {
+		return false
+	}
+	delete(c.entries, k)
+	return true
+}
+
+// Invalidate removes all entries matching the filter and returns the number removed.
+// An empty filter clears the whole cache.
+func (c *MemoryCache) Invalidate(filter InvalidationFilter) int {
+	if filter.IsEmpty() {
+		return c.Clear()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for k, e := range c.entries {
+		if matches(e, filter) {
+			delete(c.entries, k)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Clear removes all entries and returns the number removed
+func (c *MemoryCache) Clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := len(c.entries)
+	c.entries = make(map[string]*entry)
+	return removed
+}
+
+// Stats returns a snapshot of the cache statistics including the hottest keys
+func (c *MemoryCache) Stats(hotKeyCount int) Stats {
+	c.mu.Lock()
+	hotKeys := make([]HotKeyStats, 0, len(c.entries))
+	for _, e := range c.entries {
+		hotKeys = append(hotKeys, HotKeyStats{
+			Key:   e.key,
+			OrgID: e.orgID,
+			Hits:  e.hits,
+		})
+	}
+	size := len(c.entries)
+	c.mu.Unlock()
+
+	sort.Slice(hotKeys, func(i, j int) bool {
+		return hotKeys[i].Hits > hotKeys[j].Hits
+	})
+	if hotKeyCount >= 0 && len(hotKeys) > hotKeyCount {
+		hotKeys = hotKeys[:hotKeyCount]
+	}
+
+	hits := c.hits.Load()
+	misses := c.misses.Load()
+	hitRate := 0.0
+	if hits+misses > 0 {
+		hitRate = float64(hits) / float64(hits+misses)
+	}
+
+	return Stats{
+		Name:       c.name,
+		Size:       size,
+		MaxEntries: c.maxEntries,
+		TTLSeconds: int64(c.ttl.Seconds()),
+		Hits:       hits,
+		Misses:     misses,
+		Evictions:  c.evictions.Load(),
+		HitRate:    hitRate,
+		HotKeys:    hotKeys,
+	}
+}
+
+// evictOne removes an expired entry if one exists, otherwise the least recently used entry.
+// Caller must hold the lock.
+func (c *MemoryCache) evictOne(now time.Time) {
+	var oldestKey string
+	var oldestAccess time.Time
+	for k, e := range c.entries {
+		if c.isExpired(e, now) {
+			oldestKey = k
+			break
+		}
+		if oldestKey == "" || e.lastAccess.Before(oldestAccess) {
+			oldestKey = k
+			oldestAccess = e.lastAccess
+		}
+	}
+	if oldestKey != "" {
+		delete(c.entries, oldestKey)
+		c.evictions.Add(1)
+	}
+}
+
+func (c *MemoryCache) isExpired(e *entry, now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+func matches(e *entry, filter InvalidationFilter) bool {
+	if filter.OrgID != "" && e.orgID != filter.OrgID {
+		return false
+	}
+	if filter.ConsentID != "" && !hasTag(e, ConsentTag(filter.ConsentID)) {
+		return false
+	}
+	if filter.PurposeName != "" && !hasTag(e, PurposeTag(filter.PurposeName)) {
+		return false
+	}
+	return true
+}
+
+func hasTag(e *entry, tag string) bool {
+	for _, t := range e.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func entryKey(orgID, key string) string {
+	return orgID + "|" + key
+}
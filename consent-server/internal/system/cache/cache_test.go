@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMemoryCache_GetSet checks that entries are scoped to their organization and counted as hits
+// and misses
+func TestMemoryCache_GetSet(t *testing.T) {
+	c := NewMemoryCache("test", 0, 0)
+	c.Set("org-1", "key", "value")
+
+	if value, ok := c.Get("org-1", "key"); !ok || value != "value" {
+		t.Errorf("expected the cached value, got %v, %v", value, ok)
+	}
+	if _, ok := c.Get("org-2", "key"); ok {
+		t.Error("expected entries of another organization to be missed")
+	}
+	if !c.Delete("org-1", "key") || c.Delete("org-1", "key") {
+		t.Error("expected only the first delete to remove the entry")
+	}
+	if _, ok := c.Get("org-1", "key"); ok {
+		t.Error("expected a deleted entry to be missed")
+	}
+
+	stats := c.Stats(DefaultHotKeyCount)
+	if stats.Hits != 1 || stats.Misses != 2 || stats.HitRate != 1.0/3 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+// TestMemoryCache_ExpiresEntries checks that expired entries are missed and counted as evictions
+func TestMemoryCache_ExpiresEntries(t *testing.T) {
+	c := NewMemoryCache("test", time.Millisecond, 0)
+	c.Set("org-1", "key", "value")
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("org-1", "key"); ok {
+		t.Error("expected an expired entry to be missed")
+	}
+	if stats := c.Stats(0); stats.Size != 0 || stats.Evictions != 1 {
+		t.Errorf("expected the expired entry to be evicted, got %+v", stats)
+	}
+}
+
+// TestMemoryCache_EvictsLeastRecentlyUsed checks that a full cache evicts the entry read least
+// recently, and that replacing an entry evicts nothing
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache("test", 0, 2)
+	c.Set("org-1", "a", 1)
+	c.Set("org-1", "b", 2)
+	c.Set("org-1", "b", 3)
+	if stats := c.Stats(0); stats.Size != 2 || stats.Evictions != 0 {
+		t.Fatalf("expected replacing an entry to evict nothing, got %+v", stats)
+	}
+
+	time.Sleep(time.Millisecond)
+	c.Get("org-1", "a")
+	time.Sleep(time.Millisecond)
+	c.Set("org-1", "c", 4)
+	if _, ok := c.Get("org-1", "b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get("org-1", key); !ok {
+			t.Errorf("expected %s to be kept", key)
+		}
+	}
+}
+
+// TestMemoryCache_Invalidate checks that entries must match every field of the filter, and that an
+// empty filter clears the cache
+func TestMemoryCache_Invalidate(t *testing.T) {
+	c := NewMemoryCache("test", 0, 0)
+	c.Set("org-1", "consent-1", 1, ConsentTag("c1"))
+	c.Set("org-2", "consent-1", 2, ConsentTag("c1"))
+	c.Set("org-1", "purpose-1", 3, PurposeTag("marketing"))
+	c.Set("org-1", "other", 4)
+
+	if removed := c.Invalidate(InvalidationFilter{OrgID: "org-1", ConsentID: "c1"}); removed != 1 {
+		t.Errorf("expected the consent entry of org-1 to be removed, removed %d", removed)
+	}
+	if _, ok := c.Get("org-2", "consent-1"); !ok {
+		t.Error("expected the consent entry of org-2 to be kept")
+	}
+	if removed := c.Invalidate(InvalidationFilter{PurposeName: "marketing"}); removed != 1 {
+		t.Errorf("expected the purpose entry to be removed, removed %d", removed)
+	}
+	if removed := c.Invalidate(InvalidationFilter{}); removed != 2 {
+		t.Errorf("expected an empty filter to clear the remaining 2 entries, removed %d", removed)
+	}
+}
+
+// TestMemoryCache_StatsReportsHottestKeys checks that hot keys are ordered by hits and limited
+func TestMemoryCache_StatsReportsHottestKeys(t *testing.T) {
+	c := NewMemoryCache("test", time.Minute, 10)
+	for key, reads := range map[string]int{"cold": 1, "warm": 2, "hot": 3} {
+		c.Set("org-1", key, key)
+		for i := 0; i < reads; i++ {
+			c.Get("org-1", key)
+		}
+	}
+
+	stats := c.Stats(2)
+	if stats.Name != "test" || stats.Size != 3 || stats.MaxEntries != 10 || stats.TTLSeconds != 60 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if len(stats.HotKeys) != 2 || stats.HotKeys[0].Key != "hot" || stats.HotKeys[0].Hits != 3 ||
+		stats.HotKeys[1].Key != "warm" || stats.HotKeys[1].OrgID != "org-1" {
+		t.Errorf("unexpected hot keys %+v", stats.HotKeys)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cache
+
+import (
+	"sort"
+	"sync"
+)
+
+// Manager keeps track of all managed caches in the server
+type Manager struct {
+	mu     sync.RWMutex
+	caches map[string]ManagedCache
+}
+
+var (
+	manager     *Manager
+	managerOnce sync.Once
+)
+
+// GetManager returns the singleton cache manager
+func GetManager() *Manager {
+	managerOnce.Do(func() {
+		manager = &Manager{
+			caches: make(map[string]ManagedCache),
+		}
+	})
+	return manager
+}
+
+// Register adds a cache to the manager, replacing any cache with the same name
+func (m *Manager) Register(c ManagedCache) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.caches[c.Name()] = c
+}
+
+// Get returns the cache with the given name
+func (m *Manager) Get(name string) (ManagedCache, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.caches[name]
+	return c, ok
+}
+
+// List returns all registered caches sorted by name
+func (m *Manager) List() []ManagedCache {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	caches := make([]ManagedCache, 0, len(m.caches))
+	for _, c := range m.caches {
+		caches = append(caches, c)
+	}
+	sort.Slice(caches, func(i, j int) bool {
+		return caches[i].Name() < caches[j].Name()
+	})
+	return caches
+}
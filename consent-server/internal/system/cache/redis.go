@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+const (
+	defaultRedisTimeout  = time.Second
+	defaultRedisPoolSize = 10
+)
+
+// newRedisClient creates a Redis client from configuration. Connections are opened on first use.
+// A command that fails on a connection the server closed is retried once on a new connection.
+func newRedisClient(cfg config.RedisConfig) (*redis.Client, error) {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultRedisTimeout
+	}
+	poolSize := cfg.PoolSize
+	if poolSize == 0 {
+		poolSize = defaultRedisPoolSize
+	}
+
+	options := &redis.Options{
+		Addr:         cfg.Address,
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+		PoolSize:     poolSize,
+		MaxRetries:   1,
+		// A cache that cannot be reached is skipped, so requests do not wait for dial retries
+		DialerRetries: 1,
+	}
+	if cfg.TLS.Enabled {
+		options.TLSConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: cfg.TLS.InsecureSkipVerify,
+		}
+		if cfg.TLS.CAFile != "" {
+			pem, err := os.ReadFile(cfg.TLS.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read redis CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("redis CA file %s contains no certificates", cfg.TLS.CAFile)
+			}
+			options.TLSConfig.RootCAs = pool
+		}
+	}
+	redis.SetLogger(redisLogger{})
+	return redis.NewClient(options), nil
+}
+
+// redisLogger writes the messages of the Redis client, such as failed dials, to the server log.
+// Failed commands are logged by the caches themselves.
+type redisLogger struct{}
+
+func (redisLogger) Printf(ctx context.Context, format string, v ...interface{}) {
+	log.GetLogger().WithContext(ctx).Debug(fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// TestNewRedisClient_ConfiguresTLS checks that TLS connections verify the server with the
+// configured CA bundle, and that an unusable bundle is reported
+func TestNewRedisClient_ConfiguresTLS(t *testing.T) {
+	client, err := newRedisClient(config.RedisConfig{Address: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	if client.Options().TLSConfig != nil {
+		t.Error("expected plain connections when TLS is disabled")
+	}
+	_ = client.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("failed to write the CA file: %v", err)
+	}
+	cfg := config.RedisConfig{Address: "localhost:6379", TLS: config.RedisTLSConfig{Enabled: true, CAFile: caFile}}
+	if _, err := newRedisClient(cfg); err == nil {
+		t.Error("expected a CA file without certificates to be rejected")
+	}
+
+	cfg.TLS.CAFile = ""
+	client, err = newRedisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create the TLS client: %v", err)
+	}
+	defer client.Close()
+	if tlsConfig := client.Options().TLSConfig; tlsConfig == nil || tlsConfig.InsecureSkipVerify {
+		t.Errorf("expected verified TLS connections, got %+v", tlsConfig)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+const (
+	defaultRedisKeyPrefix = "consent-mgt:"
+	validateKeyPrefix     = "validate:"
+)
+
+// RedisCache stores organization scoped values in Redis with a fixed TTL. Redis failures are
+// logged and treated as cache misses, so callers fall back to the database.
+type RedisCache struct {
+	client    *redis.Client
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewRedisCache creates a cache over a Redis client. Keys are stored as
+// <keyPrefix><orgID>:<key>.
+func NewRedisCache(client *redis.Client, keyPrefix string, ttl time.Duration) *RedisCache {
+	return &RedisCache{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+	}
+}
+
+// Get returns the cached value for the key within the given organization
+func (c *RedisCache) Get(ctx context.Context, orgID, key string) ([]byte, bool) {
+	value, err := c.client.Get(ctx, c.redisKey(orgID, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false
+	}
+	if err != nil {
+		log.GetLogger().WithContext(ctx).Warn("Failed to read from Redis cache", log.Error(err))
+		return nil, false
+	}
+	return value, true
+}
+
+// Set stores a value for the key within the given organization
+func (c *RedisCache) Set(ctx context.Context, orgID, key string, value []byte) {
+	if err := c.client.Set(ctx, c.redisKey(orgID, key), value, c.ttl).Err(); err != nil {
+		log.GetLogger().WithContext(ctx).Warn("Failed to write to Redis cache", log.Error(err))
+	}
+}
+
+// Delete removes the key within the given organization
+func (c *RedisCache) Delete(ctx context.Context, orgID, key string) {
+	if err := c.client.Del(ctx, c.redisKey(orgID, key)).Err(); err != nil {
+		log.GetLogger().WithContext(ctx).Warn("Failed to delete from Redis cache",
+			log.Error(err), log.String("key", key))
+	}
+}
+
+func (c *RedisCache) redisKey(orgID, key string) string {
+	return c.keyPrefix + orgID + ":" + key
+}
+
+// validateCache is the cache of consent validation data, nil when it is disabled
+var validateCache *RedisCache
+
+// InitValidateCache sets up the consent validation cache from configuration
+func InitValidateCache(cfg config.ValidateCacheConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	client, err := newRedisClient(cfg.Redis)
+	if err != nil {
+		return err
+	}
+	keyPrefix := cfg.Redis.KeyPrefix
+	if keyPrefix == "" {
+		keyPrefix = defaultRedisKeyPrefix
+	}
+	validateCache = NewRedisCache(client, keyPrefix+validateKeyPrefix, cfg.TTL)
+	return nil
+}
+
+// GetValidateCache returns the consent validation cache, or nil when it is disabled
+func GetValidateCache() *RedisCache {
+	return validateCache
+}
+
+// InvalidateConsentValidation drops the cached validation data of a consent. Call it after every
+// committed change to a consent or its authorizations.
+func InvalidateConsentValidation(ctx context.Context, orgID, consentID string) {
+	if validateCache != nil {
+		validateCache.Delete(ctx, orgID, consentID)
+	}
+}
+
+// CloseValidateCache closes the connections of the consent validation cache
+func CloseValidateCache() {
+	if validateCache != nil {
+		_ = validateCache.client.Close()
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package clock provides the server's notion of the current time. In test environments the
+// clock can be frozen or shifted so that time dependent behaviour can be tested deterministically.
+package clock
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrControlDisabled is returned when the clock is modified without clock control being enabled
+var ErrControlDisabled = errors.New("clock control is not enabled")
+
+// State describes the current state of the clock
+type State struct {
+	Now    time.Time
+	Frozen bool
+	Offset time.Duration
+}
+
+var (
+	mu       sync.RWMutex
+	enabled  bool
+	frozen   bool
+	frozenAt time.Time
+	offset   time.Duration
+)
+
+// Now returns the current time as seen by the server
+func Now() time.Time {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if frozen {
+		return frozenAt
+	}
+	return time.Now().Add(offset)
+}
+
+// NowMillis returns the current time as seen by the server in milliseconds since epoch
+func NowMillis() int64 {
+	return Now().UnixMilli()
+}
+
+// EnableControl allows the clock to be modified. It must only be called in test environments.
+func EnableControl() {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = true
+}
+
+// IsControlEnabled returns true when the clock can be modified
+func IsControlEnabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return enabled
+}
+
+// Freeze stops the clock at the given time
+func Freeze(t time.Time) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !enabled {
+		return ErrControlDisabled
+	}
+	frozen = true
+	frozenAt = t
+	offset = 0
+	return nil
+}
+
+// SetOffset lets the clock keep running, shifted from the real time by the given offset
+func SetOffset(d time.Duration) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !enabled {
+		return ErrControlDisabled
+	}
+	frozen = false
+	offset = d
+	return nil
+}
+
+// Advance moves the clock forward by the given duration, keeping it frozen if it was frozen
+func Advance(d time.Duration) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !enabled {
+		return ErrControlDisabled
+	}
+	if frozen {
+		frozenAt = frozenAt.Add(d)
+	} else {
+		offset += d
+	}
+	return nil
+}
+
+// Reset returns the clock to the real system time
+func Reset() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !enabled {
+		return ErrControlDisabled
+	}
+	frozen = false
+	frozenAt = time.Time{}
+	offset = 0
+	return nil
+}
+
+// GetState returns a snapshot of the clock
+func GetState() State {
+	now := Now()
+
+	mu.RLock()
+	defer mu.RUnlock()
+	return State{
+		Now:    now,
+		Frozen: frozen,
+		Offset: offset,
+	}
+}
@@ -2,21 +2,32 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/utils"
 )
 
 // Config holds all configuration for the application
 type Config struct {
 	Server           ServerConfig           `mapstructure:"server"`
+	GRPC             GRPCConfig             `mapstructure:"grpc"`
+	Admin            AdminConfig            `mapstructure:"admin"`
 	Database         DatabasesConfig        `mapstructure:"database"`
 	ServiceExtension ServiceExtensionConfig `mapstructure:"service_extension"`
 	Logging          LoggingConfig          `mapstructure:"logging"`
 	Consent          ConsentConfig          `mapstructure:"consent"`
 	Security         SecurityConfig         `mapstructure:"security"`
 	CORS             CORSConfig             `mapstructure:"cors"`
+	Export           ExportConfig           `mapstructure:"export"`
+	Events           EventsConfig           `mapstructure:"events"`
+	Tracing          TracingConfig          `mapstructure:"tracing"`
+	FeatureFlags     FeatureFlagsConfig     `mapstructure:"feature_flags"`
+	Cache            CacheConfig            `mapstructure:"cache"`
+	Bootstrap        BootstrapConfig        `mapstructure:"bootstrap"`
+	Testing          TestingConfig          `mapstructure:"testing"`
 }
 
 // ServerConfig holds HTTP server configuration
@@ -26,6 +37,101 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"readTimeout"`
 	WriteTimeout time.Duration `mapstructure:"writeTimeout"`
 	IdleTimeout  time.Duration `mapstructure:"idleTimeout"`
+	// ShutdownTimeout bounds how long shutdown waits for in-flight requests and transactions
+	ShutdownTimeout time.Duration `mapstructure:"shutdownTimeout"`
+	// TLS serves the API over HTTPS, optionally requiring client certificates
+	TLS TLSConfig `mapstructure:"tls"`
+	// MaxBodySize is the largest request body accepted, in bytes. 0 disables the limit.
+	MaxBodySize int64 `mapstructure:"max_body_size"`
+	// RouteBodyLimits override MaxBodySize for individual routes
+	RouteBodyLimits []RouteBodyLimit `mapstructure:"route_body_limits"`
+	// RequestTimeout bounds how long a request is handled before it is cancelled. 0 disables the timeout.
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+	// RouteTimeouts override RequestTimeout for individual routes
+	RouteTimeouts []RouteTimeout `mapstructure:"route_timeouts"`
+	// OpenAPISpecPath is the OpenAPI document served by the API and used for request validation.
+	// Empty looks for api/consent-management-API.yaml in the working directory and its parent.
+	OpenAPISpecPath string `mapstructure:"openapi_spec_path"`
+}
+
+// RouteBodyLimit sets the largest request body accepted by one route
+type RouteBodyLimit struct {
+	// Route is the route pattern, such as "POST /api/v1/consent-purposes"
+	Route string `mapstructure:"route"`
+	// MaxBodySize is the limit in bytes. 0 disables the limit for the route.
+	MaxBodySize int64 `mapstructure:"max_body_size"`
+}
+
+// GetMaxBodySize returns the request body limit of a route pattern, 0 when the route is not limited
+func (c *ServerConfig) GetMaxBodySize(route string) int64 {
+	for _, limit := range c.RouteBodyLimits {
+		if limit.Route == route {
+			return limit.MaxBodySize
+		}
+	}
+	return c.MaxBodySize
+}
+
+// RouteTimeout sets how long one route handles a request before it is cancelled
+type RouteTimeout struct {
+	// Route is the route pattern, such as "GET /api/v1/consents"
+	Route string `mapstructure:"route"`
+	// Timeout bounds the request. 0 disables the timeout for the route.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// GetRequestTimeout returns the request timeout of a route pattern, 0 when the route has no timeout
+func (c *ServerConfig) GetRequestTimeout(route string) time.Duration {
+	for _, timeout := range c.RouteTimeouts {
+		if timeout.Route == route {
+			return timeout.Timeout
+		}
+	}
+	return c.RequestTimeout
+}
+
+// GetOpenAPISpecPaths returns the paths the OpenAPI document is looked up at, in order
+func (c *ServerConfig) GetOpenAPISpecPaths() []string {
+	if c.OpenAPISpecPath != "" {
+		return []string{c.OpenAPISpecPath}
+	}
+	return []string{"api/consent-management-API.yaml", "../api/consent-management-API.yaml"}
+}
+
+// GetShutdownTimeout returns the shutdown deadline, 30 seconds when not configured
+func (c *ServerConfig) GetShutdownTimeout() time.Duration {
+	if c.ShutdownTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return c.ShutdownTimeout
+}
+
+// GRPCConfig holds gRPC server configuration. The gRPC server listens on the server hostname.
+type GRPCConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	Port    int  `mapstructure:"port"`
+	// TLS serves gRPC over TLS, optionally requiring client certificates
+	TLS TLSConfig `mapstructure:"tls"`
+}
+
+// AdminConfig holds configuration for the separate admin listener. When enabled, admin and
+// maintenance endpoints are served only on this listener and not on the public server port.
+type AdminConfig struct {
+	Enabled  bool      `mapstructure:"enabled"`
+	Hostname string    `mapstructure:"hostname"`
+	Port     int       `mapstructure:"port"`
+	TLS      TLSConfig `mapstructure:"tls"`
+	// BasicAuth replaces security.basic_auth for admin endpoints when enabled
+	BasicAuth BasicAuthConfig `mapstructure:"basic_auth"`
+}
+
+// TLSConfig holds TLS configuration for a listener
+type TLSConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	// ClientCAFile enables mutual TLS. Clients must present a certificate signed by one of its CAs.
+	ClientCAFile string `mapstructure:"client_ca_file"`
 }
 
 // DatabasesConfig holds all database configurations
@@ -33,37 +139,283 @@ type DatabasesConfig struct {
 	Consent DatabaseConfig `mapstructure:"consent"`
 }
 
+// Supported database types
+const (
+	DatabaseTypeMySQL  = "mysql"
+	DatabaseTypeSQLite = "sqlite"
+)
+
+// SQLiteInMemory is the database name of a SQLite database held in memory
+const SQLiteInMemory = ":memory:"
+
 // DatabaseConfig holds individual database configuration
 type DatabaseConfig struct {
-	Type            string        `mapstructure:"type"`
-	Hostname        string        `mapstructure:"hostname"`
-	Port            int           `mapstructure:"port"`
-	User            string        `mapstructure:"user"`
-	Password        string        `mapstructure:"password"`
-	Database        string        `mapstructure:"database"`
-	MaxOpenConns    int           `mapstructure:"max_open_conns"`
-	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	// Type is mysql (default) or sqlite. For sqlite, Database is the database file path or
+	// ":memory:", and the schema migrations are always applied on start up.
+	Type     string `mapstructure:"type"`
+	Hostname string `mapstructure:"hostname"`
+	Port     int    `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+	Database string `mapstructure:"database"`
+	// MaxOpenConns caps the connections the server opens to the database. Defaults to 25.
+	MaxOpenConns int `mapstructure:"max_open_conns"`
+	// MaxIdleConns is the number of connections kept open while unused. Defaults to 5, and never
+	// exceeds MaxOpenConns.
+	MaxIdleConns int `mapstructure:"max_idle_conns"`
+	// ConnMaxLifetime closes connections after they were open this long. Defaults to 5m.
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	// ConnMaxIdleTime closes connections that were unused this long. Defaults to 1m.
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+	// MigrateOnStartup applies the pending schema migrations when the server starts. Otherwise they
+	// are applied with POST /api/v1/admin/migrate.
+	MigrateOnStartup bool `mapstructure:"migrate_on_startup"`
+	// QueryTimeout bounds each statement, within the deadline of the request it runs for. Zero
+	// leaves statements bounded by the request only.
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
+	// SlowQueryThreshold logs statements that run longer, with the consent and organization they
+	// were bound to. Defaults to 1s.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+	// TransactionRetry runs transactions again that lost a deadlock or serialization conflict
+	TransactionRetry TransactionRetryConfig `mapstructure:"transaction_retry"`
+}
+
+// TransactionRetryConfig holds how transactions rolled back by the database over a conflict with a
+// concurrent transaction are retried
+type TransactionRetryConfig struct {
+	// MaxRetries is the number of times a transaction is run again. Defaults to 3; 0 disables retries.
+	MaxRetries *int `mapstructure:"max_retries"`
+	// Backoff is the wait before the first retry, doubled for each further retry up to MaxBackoff
+	// and randomized so conflicting transactions do not retry in step. Defaults to 20ms.
+	Backoff time.Duration `mapstructure:"backoff"`
+	// MaxBackoff caps the wait between retries. Defaults to 500ms.
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+}
+
+// GetMaxRetries returns the number of times a conflicting transaction is retried, defaulting to 3
+func (c *TransactionRetryConfig) GetMaxRetries() int {
+	if c.MaxRetries == nil {
+		return 3
+	}
+	return *c.MaxRetries
+}
+
+// GetBackoff returns the wait before the first retry, defaulting to 20 milliseconds
+func (c *TransactionRetryConfig) GetBackoff() time.Duration {
+	if c.Backoff <= 0 {
+		return 20 * time.Millisecond
+	}
+	return c.Backoff
+}
+
+// GetMaxBackoff returns the longest wait between retries, defaulting to 500 milliseconds
+func (c *TransactionRetryConfig) GetMaxBackoff() time.Duration {
+	if c.MaxBackoff <= 0 {
+		return 500 * time.Millisecond
+	}
+	return c.MaxBackoff
 }
 
 // ServiceExtensionConfig holds extension service configuration
 type ServiceExtensionConfig struct {
-	Enabled       bool               `mapstructure:"enabled"`
-	BaseURL       string             `mapstructure:"base_url"`
-	Timeout       time.Duration      `mapstructure:"timeout"`
-	RetryAttempts int                `mapstructure:"retry_attempts"`
-	Endpoints     ExtensionEndpoints `mapstructure:"endpoints"`
+	Enabled       bool          `mapstructure:"enabled"`
+	BaseURL       string        `mapstructure:"base_url"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+	RetryAttempts int           `mapstructure:"retry_attempts"`
+	// RetryBackoff is the wait before the first retry, doubled for each further retry up to
+	// RetryMaxBackoff. Defaults to 100ms.
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+	// RetryMaxBackoff caps the wait between retries. Defaults to 2s.
+	RetryMaxBackoff time.Duration      `mapstructure:"retry_max_backoff"`
+	Endpoints       ExtensionEndpoints `mapstructure:"endpoints"`
+	// CircuitBreaker stops calling a hook that keeps failing. Disabled by default.
+	CircuitBreaker ExtensionCircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	// AsyncMaxInFlight limits the calls of async hooks that run at once; further calls are dropped.
+	// Defaults to 100.
+	AsyncMaxInFlight int `mapstructure:"async_max_in_flight"`
+	// Auth authenticates the calls to the extension, so it can be hosted outside the trusted network
+	Auth ExtensionAuthConfig `mapstructure:"auth"`
+	// Hooks overrides the timeout, failure policy, retries, circuit breaker, mode and authentication
+	// of individual hooks, keyed by endpoint name
+	Hooks map[string]ExtensionHookConfig `mapstructure:"hooks"`
+}
+
+// ExtensionCircuitBreakerConfig holds the circuit breaker of extension hooks. After
+// FailureThreshold consecutive failed calls the circuit opens and calls fail at once, under the
+// hook's failure policy, for OpenDuration. A single trial call then decides whether it closes again.
+type ExtensionCircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit; 0 disables it
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// OpenDuration is how long the circuit stays open. Defaults to 30s.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+}
+
+// GetOpenDuration returns how long the circuit stays open, defaulting to 30 seconds
+func (c ExtensionCircuitBreakerConfig) GetOpenDuration() time.Duration {
+	if c.OpenDuration <= 0 {
+		return 30 * time.Second
+	}
+	return c.OpenDuration
+}
+
+// ExtensionAuthConfig holds how calls to the service extension are authenticated. Either or both
+// of mutual TLS and OAuth2 can be used.
+type ExtensionAuthConfig struct {
+	TLS    ExtensionTLSConfig    `mapstructure:"tls"`
+	OAuth2 ExtensionOAuth2Config `mapstructure:"oauth2"`
+}
+
+// ExtensionTLSConfig holds the TLS settings of calls to the service extension. Setting a client
+// certificate enables mutual TLS.
+type ExtensionTLSConfig struct {
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	// CAFile verifies the extension's certificate instead of the system roots
+	CAFile string `mapstructure:"ca_file"`
+}
+
+// ExtensionOAuth2Config holds the OAuth2 client credentials the server gets access tokens for the
+// service extension with. OAuth2 is disabled when no token URL is set.
+type ExtensionOAuth2Config struct {
+	TokenURL     string   `mapstructure:"token_url"`
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	Scopes       []string `mapstructure:"scopes"`
+}
+
+// GetRetryAttempts returns the number of retries of a failed call to a hook
+func (e *ServiceExtensionConfig) GetRetryAttempts(hook string) int {
+	if hookRetries := e.Hooks[hook].RetryAttempts; hookRetries != nil {
+		return *hookRetries
+	}
+	return e.RetryAttempts
+}
+
+// GetRetryBackoff returns the wait before the first retry, defaulting to 100 milliseconds
+func (e *ServiceExtensionConfig) GetRetryBackoff() time.Duration {
+	if e.RetryBackoff <= 0 {
+		return 100 * time.Millisecond
+	}
+	return e.RetryBackoff
+}
+
+// GetRetryMaxBackoff returns the longest wait between retries, defaulting to 2 seconds
+func (e *ServiceExtensionConfig) GetRetryMaxBackoff() time.Duration {
+	if e.RetryMaxBackoff <= 0 {
+		return 2 * time.Second
+	}
+	return e.RetryMaxBackoff
+}
+
+// GetCircuitBreaker returns the circuit breaker of a hook: the hook's own when it sets one, and the
+// service extension's otherwise
+func (e *ServiceExtensionConfig) GetCircuitBreaker(hook string) ExtensionCircuitBreakerConfig {
+	if hookBreaker := e.Hooks[hook].CircuitBreaker; hookBreaker != nil {
+		return *hookBreaker
+	}
+	return e.CircuitBreaker
+}
+
+// GetAsyncMaxInFlight returns the limit of async hook calls running at once, defaulting to 100
+func (e *ServiceExtensionConfig) GetAsyncMaxInFlight() int {
+	if e.AsyncMaxInFlight <= 0 {
+		return 100
+	}
+	return e.AsyncMaxInFlight
+}
+
+// IsAsync reports whether a hook is called in the background without waiting for its response
+func (e *ServiceExtensionConfig) IsAsync(hook string) bool {
+	return e.Hooks[hook].Mode == ExtensionModeAsync
+}
+
+// GetAuth returns the authentication of calls to a hook: the hook's own when it sets one, and the
+// service extension's otherwise
+func (e *ServiceExtensionConfig) GetAuth(hook string) ExtensionAuthConfig {
+	if hookAuth := e.Hooks[hook].Auth; hookAuth != nil {
+		return *hookAuth
+	}
+	return e.Auth
 }
 
 // ExtensionEndpoints holds all extension service endpoint paths
 type ExtensionEndpoints struct {
-	PreProcessConsentCreation     string `mapstructure:"pre_process_consent_creation"`
-	EnrichConsentCreationResponse string `mapstructure:"enrich_consent_creation_response"`
-	PreProcessConsentRetrieval    string `mapstructure:"pre_process_consent_retrieval"`
-	PreProcessConsentUpdate       string `mapstructure:"pre_process_consent_update"`
-	EnrichConsentUpdateResponse   string `mapstructure:"enrich_consent_update_response"`
-	PreProcessConsentRevoke       string `mapstructure:"pre_process_consent_revoke"`
-	MapAcceleratorErrorResponse   string `mapstructure:"map_accelerator_error_response"`
+	PreProcessConsentCreation       string `mapstructure:"pre_process_consent_creation"`
+	EnrichConsentCreationResponse   string `mapstructure:"enrich_consent_creation_response"`
+	PreProcessConsentRetrieval      string `mapstructure:"pre_process_consent_retrieval"`
+	PreProcessConsentUpdate         string `mapstructure:"pre_process_consent_update"`
+	EnrichConsentUpdateResponse     string `mapstructure:"enrich_consent_update_response"`
+	PreProcessConsentRevoke         string `mapstructure:"pre_process_consent_revoke"`
+	EnrichConsentRevokeResponse     string `mapstructure:"enrich_consent_revoke_response"`
+	PreProcessConsentValidation     string `mapstructure:"pre_process_consent_validation"`
+	EnrichConsentValidationResponse string `mapstructure:"enrich_consent_validation_response"`
+	EvaluateValidationPolicy        string `mapstructure:"evaluate_validation_policy"`
+	MapAcceleratorErrorResponse     string `mapstructure:"map_accelerator_error_response"`
+}
+
+// Extension hook failure policies
+const (
+	// ExtensionFailOpen continues the operation without the hook when the extension cannot be reached
+	ExtensionFailOpen = "fail_open"
+	// ExtensionFailClosed fails the operation when the extension cannot be reached
+	ExtensionFailClosed = "fail_closed"
+)
+
+// Extension hook modes
+const (
+	// ExtensionModeSync waits for the hook's response, which can reject or change the operation
+	ExtensionModeSync = "sync"
+	// ExtensionModeAsync calls the hook in the background and ignores its response
+	ExtensionModeAsync = "async"
+)
+
+// ExtensionHookConfig holds the settings of a single extension hook
+type ExtensionHookConfig struct {
+	// Timeout of each call to the hook. Defaults to the service extension timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// FailurePolicy is fail_open or fail_closed. Pre-process and policy hooks fail closed and
+	// enrich hooks fail open by default.
+	FailurePolicy string `mapstructure:"failure_policy"`
+	// RetryAttempts replaces the service extension's retry count for the hook
+	RetryAttempts *int `mapstructure:"retry_attempts"`
+	// CircuitBreaker replaces the service extension's circuit breaker for the hook
+	CircuitBreaker *ExtensionCircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	// Mode is sync or async. Async hooks are fire-and-forget: the operation does not wait for them
+	// and their response is ignored. Defaults to sync.
+	Mode string `mapstructure:"mode"`
+	// Auth replaces the service extension's authentication for the hook, for hooks served by
+	// another host
+	Auth *ExtensionAuthConfig `mapstructure:"auth"`
+}
+
+// GetEndpoint returns the endpoint path configured for a hook, or an empty string when the hook is
+// not configured
+func (e *ExtensionEndpoints) GetEndpoint(hook string) string {
+	switch hook {
+	case "pre_process_consent_creation":
+		return e.PreProcessConsentCreation
+	case "enrich_consent_creation_response":
+		return e.EnrichConsentCreationResponse
+	case "pre_process_consent_retrieval":
+		return e.PreProcessConsentRetrieval
+	case "pre_process_consent_update":
+		return e.PreProcessConsentUpdate
+	case "enrich_consent_update_response":
+		return e.EnrichConsentUpdateResponse
+	case "pre_process_consent_revoke":
+		return e.PreProcessConsentRevoke
+	case "enrich_consent_revoke_response":
+		return e.EnrichConsentRevokeResponse
+	case "pre_process_consent_validation":
+		return e.PreProcessConsentValidation
+	case "enrich_consent_validation_response":
+		return e.EnrichConsentValidationResponse
+	case "evaluate_validation_policy":
+		return e.EvaluateValidationPolicy
+	case "map_accelerator_error_response":
+		return e.MapAcceleratorErrorResponse
+	}
+	return ""
 }
 
 // LoggingConfig holds logging configuration
@@ -81,8 +433,164 @@ type AuthStatus string
 
 // ConsentConfig holds consent-related configuration
 type ConsentConfig struct {
-	StatusMappings     ConsentStatusMappings `mapstructure:"status_mappings"`
-	AuthStatusMappings AuthStatusMappings    `mapstructure:"auth_status_mappings"`
+	StatusMappings      ConsentStatusMappings     `mapstructure:"status_mappings"`
+	AuthStatusMappings  AuthStatusMappings        `mapstructure:"auth_status_mappings"`
+	Purge               ConsentPurgeConfig        `mapstructure:"purge"`
+	Retention           ConsentRetentionConfig    `mapstructure:"retention"`
+	ScheduledRevocation ScheduledRevocationConfig `mapstructure:"scheduled_revocation"`
+	TokenCleanup        TokenCleanupConfig        `mapstructure:"token_cleanup"`
+	Receipt             ConsentReceiptConfig      `mapstructure:"receipt"`
+	OwnershipTransfer   OwnershipTransferConfig   `mapstructure:"ownership_transfer"`
+	Erasure             UserErasureConfig         `mapstructure:"erasure"`
+	StateMachine        StateMachineConfig        `mapstructure:"state_machine"`
+	Files               ConsentFilesConfig        `mapstructure:"files"`
+	Import              ConsentImportConfig       `mapstructure:"import"`
+	StatusOverride      StatusOverrideConfig      `mapstructure:"status_override"`
+	ValidationPolicy    ValidationPolicyConfig    `mapstructure:"validation_policy"`
+	Usage               ConsentUsageConfig        `mapstructure:"usage"`
+	ExpiryNotification  ExpiryNotificationConfig  `mapstructure:"expiry_notification"`
+	Reauthorization     ReauthorizationConfig     `mapstructure:"reauthorization"`
+	IDGeneration        ConsentIDConfig           `mapstructure:"id_generation"`
+	StatusDerivation    StatusDerivationConfig    `mapstructure:"status_derivation"`
+	Lock                ConsentLockConfig         `mapstructure:"lock"`
+	ModifiedResponse    ModifiedResponseConfig    `mapstructure:"modified_response"`
+	// DefaultValidity sets the validity time of consents created without one. Zero creates
+	// consents that do not expire.
+	DefaultValidity time.Duration `mapstructure:"default_validity"`
+	// AllowedTypes restricts the consent types that can be created. Empty allows any type.
+	AllowedTypes []string `mapstructure:"allowed_types"`
+}
+
+// ConsentPurgeConfig holds configuration for the job that hard-deletes soft-deleted consents
+type ConsentPurgeConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	// RetentionDays is how long a soft-deleted consent is kept before it is purged
+	RetentionDays int `mapstructure:"retention_days"`
+	BatchSize     int `mapstructure:"batch_size"`
+}
+
+// Actions taken on consents whose retention period ended
+const (
+	RetentionActionDelete    = "delete"
+	RetentionActionAnonymize = "anonymize"
+)
+
+// ConsentRetentionConfig holds configuration for the job that removes revoked and expired consents
+// once their retention period ends, so they are not stored longer than needed
+type ConsentRetentionConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	// RetentionDays is how long a consent is kept after it was revoked or expired
+	RetentionDays int `mapstructure:"retention_days"`
+	// Action is RetentionActionDelete to delete the consent, or RetentionActionAnonymize to keep it
+	// without its attributes, user IDs, resources, history and files
+	Action    string `mapstructure:"action"`
+	BatchSize int    `mapstructure:"batch_size"`
+}
+
+// ScheduledRevocationConfig holds configuration for revocations and updates requested with a
+// future effectiveAt, and for the jobs that apply them once they take effect
+type ScheduledRevocationConfig struct {
+	// Enabled accepts scheduled revocations and updates and runs the jobs. While disabled,
+	// revocations and updates with a future effectiveAt are rejected.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is how often the job runs, which bounds how late a revocation is applied
+	Interval  time.Duration `mapstructure:"interval"`
+	BatchSize int           `mapstructure:"batch_size"`
+}
+
+// TokenCleanupConfig holds configuration for the job that removes the bindings of expired tokens
+// to consents
+type TokenCleanupConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// ExpiryNotificationConfig holds configuration for the job that notifies consents nearing their
+// validity time, so users can be asked to re-authorize before access breaks
+type ExpiryNotificationConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	// NoticeDays is how many days before its validity time a consent is reported as expiring soon.
+	// Zero disables notices.
+	NoticeDays int `mapstructure:"notice_days"`
+	BatchSize  int `mapstructure:"batch_size"`
+	// Timeout bounds each webhook call and email delivery
+	Timeout time.Duration           `mapstructure:"timeout"`
+	Email   ExpiryNotificationEmail `mapstructure:"email"`
+}
+
+// ExpiryNotificationEmail holds the SMTP settings of expiry notification emails. Emails are sent
+// to the address stored in the consent attribute RecipientAttribute; consents without it get none.
+type ExpiryNotificationEmail struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	Host               string `mapstructure:"host"`
+	Port               int    `mapstructure:"port"`
+	Username           string `mapstructure:"username"`
+	Password           string `mapstructure:"password"`
+	From               string `mapstructure:"from"`
+	RecipientAttribute string `mapstructure:"recipient_attribute"`
+}
+
+// GetTimeout returns the timeout of expiry notification webhook calls and emails
+func (c *ExpiryNotificationConfig) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 10 * time.Second
+	}
+	return c.Timeout
+}
+
+// ReauthorizationConfig holds configuration for sending consents back to their users for
+// re-authorization, as in the Open Banking 90-day re-consent journey
+type ReauthorizationConfig struct {
+	// FromStatuses lists the consent statuses that can be re-authorized. Defaults to the active
+	// and expired statuses.
+	FromStatuses []string `mapstructure:"from_statuses"`
+	// MaxValidity caps the validity time a re-authorization sets, counted from the request. Zero
+	// means no limit.
+	MaxValidity time.Duration `mapstructure:"max_validity"`
+}
+
+// IsReauthorizable reports whether a consent in status can be sent back for re-authorization
+func (c *ConsentConfig) IsReauthorizable(status ConsentStatus) bool {
+	if len(c.Reauthorization.FromStatuses) == 0 {
+		return c.IsActiveStatus(status) || c.IsExpiredStatus(status)
+	}
+	return containsString(c.Reauthorization.FromStatuses, string(status))
+}
+
+// ConsentLockConfig holds configuration for the locks that keep a consent from being changed by
+// other callers while its user is authorizing it
+type ConsentLockConfig struct {
+	// TTL is how long a lock is held unless it is renewed or released
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// GetTTL returns how long consent locks are held
+func (c *ConsentLockConfig) GetTTL() time.Duration {
+	if c.TTL <= 0 {
+		return 5 * time.Minute
+	}
+	return c.TTL
+}
+
+// ModifiedResponseConfig holds the checks applied to the modifiedResponse documents returned by
+// consent interceptors and the service extension enrich hooks before they are stored
+type ModifiedResponseConfig struct {
+	// MaxSize is the largest document accepted, in bytes of JSON. Defaults to 64 KiB.
+	MaxSize int `mapstructure:"max_size"`
+	// SchemaFile is a JSON Schema file documents must conform to. Documents are only required to be
+	// JSON objects when it is not set.
+	SchemaFile string `mapstructure:"schema_file"`
+}
+
+// GetMaxSize returns the largest modifiedResponse document accepted, in bytes
+func (c *ModifiedResponseConfig) GetMaxSize() int {
+	if c.MaxSize <= 0 {
+		return 64 * 1024
+	}
+	return c.MaxSize
 }
 
 // ConsentStatusMappings holds the mapping of specific consent lifecycle states
@@ -92,6 +600,15 @@ type ConsentStatusMappings struct {
 	RevokedStatus  string `mapstructure:"revoked_status"`
 	CreatedStatus  string `mapstructure:"created_status"`
 	RejectedStatus string `mapstructure:"rejected_status"`
+	// AwaitingReauthorizationStatus is the status of consents sent back for re-authorization.
+	// Defaults to AWAITING_REAUTHORIZATION.
+	AwaitingReauthorizationStatus string `mapstructure:"awaiting_reauthorization_status"`
+	// PartiallyAuthorizedStatus is the status of consents with required authorizers while some of
+	// them have not approved. Defaults to PARTIALLY_AUTHORIZED.
+	PartiallyAuthorizedStatus string `mapstructure:"partially_authorized_status"`
+	// SuspendedStatus is the status of consents put on hold through the suspend operation.
+	// Defaults to SUSPENDED.
+	SuspendedStatus string `mapstructure:"suspended_status"`
 }
 
 // AuthStatusMappings holds the mapping of authorization resource lifecycle states
@@ -108,6 +625,30 @@ func (c *ConsentConfig) GetActiveConsentStatus() ConsentStatus {
 	return ConsentStatus(c.StatusMappings.ActiveStatus)
 }
 
+// GetAwaitingReauthorizationStatus returns the typed status of consents awaiting re-authorization
+func (c *ConsentConfig) GetAwaitingReauthorizationStatus() ConsentStatus {
+	if c.StatusMappings.AwaitingReauthorizationStatus == "" {
+		return "AWAITING_REAUTHORIZATION"
+	}
+	return ConsentStatus(c.StatusMappings.AwaitingReauthorizationStatus)
+}
+
+// GetPartiallyAuthorizedStatus returns the typed status of consents waiting for required authorizers
+func (c *ConsentConfig) GetPartiallyAuthorizedStatus() ConsentStatus {
+	if c.StatusMappings.PartiallyAuthorizedStatus == "" {
+		return "PARTIALLY_AUTHORIZED"
+	}
+	return ConsentStatus(c.StatusMappings.PartiallyAuthorizedStatus)
+}
+
+// GetSuspendedConsentStatus returns the typed status of suspended consents
+func (c *ConsentConfig) GetSuspendedConsentStatus() ConsentStatus {
+	if c.StatusMappings.SuspendedStatus == "" {
+		return "SUSPENDED"
+	}
+	return ConsentStatus(c.StatusMappings.SuspendedStatus)
+}
+
 // GetExpiredConsentStatus returns the typed expired status from config
 func (c *ConsentConfig) GetExpiredConsentStatus() ConsentStatus {
 	return ConsentStatus(c.StatusMappings.ExpiredStatus)
@@ -128,6 +669,11 @@ func (c *ConsentConfig) GetRejectedConsentStatus() ConsentStatus {
 	return ConsentStatus(c.StatusMappings.RejectedStatus)
 }
 
+// IsConsentTypeAllowed reports whether consents of the given type can be created
+func (c *ConsentConfig) IsConsentTypeAllowed(consentType string) bool {
+	return len(c.AllowedTypes) == 0 || containsString(c.AllowedTypes, consentType)
+}
+
 // GetApprovedAuthStatus returns the typed approved auth status from config
 func (c *ConsentConfig) GetApprovedAuthStatus() AuthStatus {
 	return AuthStatus(c.AuthStatusMappings.ApprovedState)
@@ -153,31 +699,691 @@ func (c *ConsentConfig) GetSystemRevokedAuthStatus() AuthStatus {
 	return AuthStatus(c.AuthStatusMappings.SystemRevokedState)
 }
 
-// SecurityConfig holds security configuration
-type SecurityConfig struct {
-	BasicAuth BasicAuthConfig `mapstructure:"basic_auth"`
+// SecurityConfig holds security configuration
+type SecurityConfig struct {
+	BasicAuth     BasicAuthConfig     `mapstructure:"basic_auth"`
+	Authorization AuthorizationConfig `mapstructure:"authorization"`
+}
+
+// AuthorizationConfig holds scope-based route authorization configuration
+type AuthorizationConfig struct {
+	// Enabled requires callers to authenticate as a security.basic_auth user granted the scope
+	// each route requires
+	Enabled bool `mapstructure:"enabled"`
+	// OrgIDs limits authorization to these organizations, for rolling it out gradually. Routes of
+	// every organization are authorized when empty.
+	OrgIDs []string `mapstructure:"org_ids"`
+	// RouteScopes overrides the scope a route requires. Keys are "METHOD /path" patterns relative
+	// to the API base path, e.g. "PUT /consents/{consentId}/revoke".
+	RouteScopes map[string]string `mapstructure:"route_scopes"`
+}
+
+// BasicAuthConfig holds basic authentication configuration
+type BasicAuthConfig struct {
+	Enabled bool            `mapstructure:"enabled"`
+	Users   []BasicAuthUser `mapstructure:"users"`
+}
+
+// BasicAuthUser represents a basic auth user
+type BasicAuthUser struct {
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// Scopes granted to the user when routes are authorized. A user without scopes is granted all.
+	Scopes []string `mapstructure:"scopes"`
+}
+
+// CORSConfig holds CORS configuration
+type CORSConfig struct {
+	Enabled          bool     `mapstructure:"enabled"`
+	AllowedOrigins   []string `mapstructure:"allowed_origins"`
+	AllowedMethods   []string `mapstructure:"allowed_methods"`
+	AllowedHeaders   []string `mapstructure:"allowed_headers"`
+	AllowCredentials bool     `mapstructure:"allow_credentials"`
+	MaxAge           int      `mapstructure:"max_age"`
+}
+
+// ExportConfig holds configuration for scheduled consent export delivery
+type ExportConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	// EncryptionKeys maps a key ID to a base64 encoded AES-256 key
+	EncryptionKeys map[string]string `mapstructure:"encryption_keys"`
+	// Credentials maps a credential ID to the secrets used to reach a delivery destination
+	Credentials map[string]ExportCredential `mapstructure:"credentials"`
+}
+
+// ExportCredential holds the secrets for an export delivery destination
+type ExportCredential struct {
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	PrivateKeyFile  string `mapstructure:"private_key_file"`
+	KnownHostsFile  string `mapstructure:"known_hosts_file"`
+}
+
+// EventsConfig holds configuration for consent events emitted to external systems
+type EventsConfig struct {
+	// AttributePropagation lists, per organization, the consent attributes that may be included in
+	// emitted events. Attributes of organizations that are not listed are never propagated.
+	AttributePropagation []AttributePropagationRule `mapstructure:"attribute_propagation"`
+	// Outbox holds the events written with consent changes until the relay publishes them
+	Outbox EventOutboxConfig `mapstructure:"outbox"`
+	// Kafka publishes consent events to a Kafka topic instead of the server log
+	Kafka KafkaConfig `mapstructure:"kafka"`
+	// Sync pushes consent state changes to external consent management and marketing platforms
+	Sync ConsentSyncConfig `mapstructure:"sync"`
+}
+
+// EventOutboxConfig holds configuration for the relay that publishes the events of the event outbox
+type EventOutboxConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	// MaxBackoff caps the delay between attempts to publish an event that keeps failing
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+}
+
+// GetPollInterval returns how often the outbox is polled, 1 second when not configured
+func (c *EventOutboxConfig) GetPollInterval() time.Duration {
+	if c.PollInterval <= 0 {
+		return time.Second
+	}
+	return c.PollInterval
+}
+
+// GetBatchSize returns the number of events read per poll, 100 when not configured
+func (c *EventOutboxConfig) GetBatchSize() int {
+	if c.BatchSize <= 0 {
+		return 100
+	}
+	return c.BatchSize
+}
+
+// GetMaxBackoff returns the longest delay between publish attempts, 5 minutes when not configured
+func (c *EventOutboxConfig) GetMaxBackoff() time.Duration {
+	if c.MaxBackoff <= 0 {
+		return 5 * time.Minute
+	}
+	return c.MaxBackoff
+}
+
+// Kafka acknowledgement modes
+const (
+	KafkaAcksAll    = "all"    // Wait for every in-sync replica
+	KafkaAcksLeader = "leader" // Wait for the partition leader only
+)
+
+// Kafka SASL mechanisms
+const (
+	KafkaSASLPlain       = "PLAIN"
+	KafkaSASLScramSHA256 = "SCRAM-SHA-256"
+	KafkaSASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+// KafkaConfig holds configuration for publishing consent events to Kafka. Events are keyed by
+// consent ID, so the events of a consent stay in order.
+type KafkaConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Brokers are the bootstrap brokers (host:port) used to discover the cluster
+	Brokers  []string `mapstructure:"brokers"`
+	Topic    string   `mapstructure:"topic"`
+	ClientID string   `mapstructure:"client_id"`
+	// Acks is all (default) or leader
+	Acks    string          `mapstructure:"acks"`
+	Timeout time.Duration   `mapstructure:"timeout"`
+	SASL    KafkaSASLConfig `mapstructure:"sasl"`
+	TLS     KafkaTLSConfig  `mapstructure:"tls"`
+}
+
+// KafkaSASLConfig holds the SASL credentials of the Kafka client. SASL is disabled when no
+// mechanism is set.
+type KafkaSASLConfig struct {
+	// Mechanism is PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512
+	Mechanism string `mapstructure:"mechanism"`
+	Username  string `mapstructure:"username"`
+	Password  string `mapstructure:"password"`
+}
+
+// KafkaTLSConfig holds the TLS settings of broker connections
+type KafkaTLSConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// CAFile is a PEM bundle used to verify brokers instead of the system roots
+	CAFile             string `mapstructure:"ca_file"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+// Consent sync connector types
+const (
+	SyncConnectorREST = "rest" // Sends the consent state as JSON to an HTTP endpoint
+	// Asks an authorization server to revoke the tokens of revoked and expired consents
+	SyncConnectorTokenRevocation = "token_revocation"
+)
+
+// ConsentSyncConfig lists the connectors that keep external platforms, such as the suppression
+// lists of a marketing platform or the tokens of an authorization server, in sync with consent
+// state. Connectors receive the events of the event outbox, so a change is synced if and only if
+// it committed.
+type ConsentSyncConfig struct {
+	Connectors []SyncConnectorConfig `mapstructure:"connectors"`
+}
+
+// SyncConnectorConfig configures a consent sync connector
+type SyncConnectorConfig struct {
+	// Name identifies the connector in logs
+	Name string `mapstructure:"name"`
+	Type string `mapstructure:"type"`
+	// URL is the endpoint of a rest or token_revocation connector
+	URL string `mapstructure:"url"`
+	// Method is POST (default) or PUT; token_revocation connectors only POST
+	Method string `mapstructure:"method"`
+	// ConsentIDParam is the form parameter a token_revocation connector sends the consent ID in,
+	// consent_id when not configured
+	ConsentIDParam string `mapstructure:"consent_id_param"`
+	// Headers are sent with every request, e.g. for authentication
+	Headers map[string]string `mapstructure:"headers"`
+	Timeout time.Duration     `mapstructure:"timeout"`
+	// Orgs limits the connector to the consents of these organizations; all when empty
+	Orgs []string `mapstructure:"orgs"`
+	// EventTypes limits the connector to these event types; all but consent.expiring_soon when empty
+	EventTypes []string `mapstructure:"event_types"`
+}
+
+// GetTimeout returns how long a sync request may take, 10 seconds when not configured
+func (c *SyncConnectorConfig) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 10 * time.Second
+	}
+	return c.Timeout
+}
+
+// GetConsentIDParam returns the form parameter token revocation requests carry the consent ID in
+func (c *SyncConnectorConfig) GetConsentIDParam() string {
+	if c.ConsentIDParam == "" {
+		return "consent_id"
+	}
+	return c.ConsentIDParam
+}
+
+// AttributePropagationRule whitelists consent attribute keys for the events of an organization
+type AttributePropagationRule struct {
+	OrgID      string   `mapstructure:"org_id"`
+	Attributes []string `mapstructure:"attributes"`
+}
+
+// TracingConfig holds configuration for exporting traces to an OpenTelemetry collector
+type TracingConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	ServiceName string `mapstructure:"service_name"`
+	// Endpoint is the base URL of the collector's OTLP/HTTP receiver, e.g. http://localhost:4318
+	Endpoint string `mapstructure:"endpoint"`
+	// Headers are sent with every export request, e.g. for collector authentication
+	Headers map[string]string `mapstructure:"headers"`
+	// SampleRatio is the fraction of new traces that are recorded. Requests that continue a trace
+	// follow the sampling decision of the caller.
+	SampleRatio    float64       `mapstructure:"sample_ratio"`
+	BatchSize      int           `mapstructure:"batch_size"`
+	ExportInterval time.Duration `mapstructure:"export_interval"`
+	Timeout        time.Duration `mapstructure:"timeout"`
+}
+
+// ConsentReceiptConfig holds configuration for signed consent receipts
+type ConsentReceiptConfig struct {
+	// SigningKeyFile is a PEM encoded EC, RSA or Ed25519 private key. Receipts are only issued
+	// when it is set.
+	SigningKeyFile   string `mapstructure:"signing_key_file"`
+	KeyID            string `mapstructure:"key_id"`
+	Jurisdiction     string `mapstructure:"jurisdiction"`
+	Language         string `mapstructure:"language"`
+	CollectionMethod string `mapstructure:"collection_method"`
+	PolicyURL        string `mapstructure:"policy_url"`
+	// Controller identifies the PII controller on receipts. OrgControllers replace it for the
+	// listed organizations.
+	Controller     ReceiptController   `mapstructure:"controller"`
+	OrgControllers []ReceiptController `mapstructure:"org_controllers"`
+}
+
+// ReceiptController describes the PII controller named on consent receipts
+type ReceiptController struct {
+	OrgID   string `mapstructure:"org_id"`
+	Name    string `mapstructure:"name"`
+	Contact string `mapstructure:"contact"`
+	Email   string `mapstructure:"email"`
+	Phone   string `mapstructure:"phone"`
+	Address string `mapstructure:"address"`
+	URL     string `mapstructure:"url"`
+}
+
+// GetController returns the PII controller configured for an organization
+func (c *ConsentReceiptConfig) GetController(orgID string) ReceiptController {
+	for _, controller := range c.OrgControllers {
+		if controller.OrgID == orgID {
+			return controller
+		}
+	}
+	return c.Controller
+}
+
+// OwnershipTransferConfig lists the organizations that allow consents to be transferred between
+// users. Transfers are refused for organizations without a policy.
+type OwnershipTransferConfig struct {
+	Orgs []OwnershipTransferPolicy `mapstructure:"orgs"`
+}
+
+// OwnershipTransferPolicy is the consent ownership transfer policy of an organization
+type OwnershipTransferPolicy struct {
+	OrgID         string `mapstructure:"org_id"`
+	RequireReason bool   `mapstructure:"require_reason"`
+}
+
+// GetPolicy returns the ownership transfer policy of an organization, or nil when transfers are
+// not allowed for it
+func (c *OwnershipTransferConfig) GetPolicy(orgID string) *OwnershipTransferPolicy {
+	for i := range c.Orgs {
+		if c.Orgs[i].OrgID == orgID {
+			return &c.Orgs[i]
+		}
+	}
+	return nil
+}
+
+// UserErasureConfig holds configuration for right-to-erasure requests
+type UserErasureConfig struct {
+	// IdentifyingAttributes lists the consent attribute keys that identify a user, such as email.
+	// They are removed from the consents of an erased user.
+	IdentifyingAttributes []string `mapstructure:"identifying_attributes"`
+}
+
+// Consent ID generation strategies
+const (
+	IDStrategyUUID = "uuid"
+	IDStrategyULID = "ulid"
+)
+
+// ConsentIDConfig holds configuration for generating consent IDs
+type ConsentIDConfig struct {
+	// Strategy is uuid (the default) or ulid. ULIDs sort by creation time.
+	Strategy string `mapstructure:"strategy"`
+	// Prefix is prepended to generated IDs, for example "CONSENT-". OrgPrefixes replace it for the
+	// listed organizations.
+	Prefix      string        `mapstructure:"prefix"`
+	OrgPrefixes []OrgIDPrefix `mapstructure:"org_prefixes"`
+}
+
+// OrgIDPrefix is the consent ID prefix of an organization
+type OrgIDPrefix struct {
+	OrgID  string `mapstructure:"org_id"`
+	Prefix string `mapstructure:"prefix"`
+}
+
+// GetPrefix returns the consent ID prefix of an organization
+func (c *ConsentIDConfig) GetPrefix(orgID string) string {
+	for _, orgPrefix := range c.OrgPrefixes {
+		if orgPrefix.OrgID == orgID {
+			return orgPrefix.Prefix
+		}
+	}
+	return c.Prefix
+}
+
+// Strategies for deriving a consent's status from the statuses of its authorizations
+const (
+	// StatusDerivationAllApprove derives the status with the highest precedence: any rejected
+	// authorization rejects the consent, and it is only active once every authorization is approved
+	StatusDerivationAllApprove = "all_approve"
+	// StatusDerivationAnyApprove activates the consent once any authorization is approved
+	StatusDerivationAnyApprove = "any_approve"
+	// StatusDerivationQuorum activates the consent once quorum authorizations are approved, and
+	// rejects it once too many are rejected for the quorum to be reached
+	StatusDerivationQuorum = "quorum"
+)
+
+// StatusDerivationConfig selects how a consent's status is derived from its authorizations. The
+// first rule matching the consent's organization and type applies; Strategy and Quorum apply to
+// consents no rule matches.
+type StatusDerivationConfig struct {
+	// Strategy is all_approve (the default), any_approve or quorum
+	Strategy string                 `mapstructure:"strategy"`
+	Quorum   int                    `mapstructure:"quorum"`
+	Rules    []StatusDerivationRule `mapstructure:"rules"`
+}
+
+// StatusDerivationRule sets the status derivation strategy of an organization's consents, or of
+// consents of some types. Empty OrgID matches every organization and empty ConsentTypes every type.
+type StatusDerivationRule struct {
+	OrgID        string   `mapstructure:"org_id"`
+	ConsentTypes []string `mapstructure:"consent_types"`
+	Strategy     string   `mapstructure:"strategy"`
+	// Quorum is the number of approved authorizations a quorum strategy requires
+	Quorum int `mapstructure:"quorum"`
+}
+
+// GetStrategy returns the status derivation strategy of consents of a type in an organization,
+// with the quorum it requires
+func (c *StatusDerivationConfig) GetStrategy(orgID, consentType string) (string, int) {
+	for _, rule := range c.Rules {
+		if (rule.OrgID == "" || rule.OrgID == orgID) &&
+			(len(rule.ConsentTypes) == 0 || containsString(rule.ConsentTypes, consentType)) {
+			return rule.Strategy, rule.Quorum
+		}
+	}
+	if c.Strategy == "" {
+		return StatusDerivationAllApprove, 0
+	}
+	return c.Strategy, c.Quorum
+}
+
+// StatusOverrideConfig holds configuration for admin consent status overrides
+type StatusOverrideConfig struct {
+	// AuthStatusCascades set the status given to every authorization of a consent that is forced
+	// into a consent status. Authorizations keep their status for consent statuses without a
+	// cascade. When empty, revoked and expired consents cascade to the system revoked and system
+	// expired authorization statuses.
+	AuthStatusCascades []StatusCascade `mapstructure:"auth_status_cascades"`
+}
+
+// StatusCascade maps a consent status to the authorization status it cascades to
+type StatusCascade struct {
+	ConsentStatus string `mapstructure:"consent_status"`
+	AuthStatus    string `mapstructure:"auth_status"`
+}
+
+// GetOverrideAuthStatus returns the authorization status a consent status override cascades to,
+// or false when authorizations are left unchanged
+func (c *ConsentConfig) GetOverrideAuthStatus(consentStatus string) (string, bool) {
+	if len(c.StatusOverride.AuthStatusCascades) == 0 {
+		switch ConsentStatus(consentStatus) {
+		case c.GetRevokedConsentStatus():
+			return string(c.GetSystemRevokedAuthStatus()), true
+		case c.GetExpiredConsentStatus():
+			return string(c.GetSystemExpiredAuthStatus()), true
+		}
+		return "", false
+	}
+	for _, cascade := range c.StatusOverride.AuthStatusCascades {
+		if cascade.ConsentStatus == consentStatus {
+			return cascade.AuthStatus, true
+		}
+	}
+	return "", false
+}
+
+// ConsentUsageConfig holds configuration for counting consent accesses against the consent frequency
+type ConsentUsageConfig struct {
+	// Period is the frequency period of recurring consents. Periods are aligned to the Unix
+	// epoch, so the default of 24h resets at midnight UTC.
+	Period time.Duration `mapstructure:"period"`
+}
+
+// GetUsagePeriod returns the frequency period of recurring consents
+func (c *ConsentConfig) GetUsagePeriod() time.Duration {
+	if c.Usage.Period <= 0 {
+		return 24 * time.Hour
+	}
+	return c.Usage.Period
+}
+
+// Validation policy rule types
+const (
+	// PolicyRuleHTTPMethod allows only the listed HTTP methods
+	PolicyRuleHTTPMethod = "http_method"
+	// PolicyRuleResourcePath requires the requested resource to match one of the resource patterns
+	PolicyRuleResourcePath = "resource_path"
+	// PolicyRuleFrequency denies consents whose frequency exceeds max_frequency
+	PolicyRuleFrequency = "frequency"
+	// PolicyRuleExtension delegates the decision to the evaluate_validation_policy extension hook
+	PolicyRuleExtension = "extension"
+)
+
+// ValidationPolicyConfig lists the policy rules each organization applies when a consent is
+// validated. Organizations without a policy only get the built-in checks.
+type ValidationPolicyConfig struct {
+	Orgs []OrgValidationPolicy `mapstructure:"orgs"`
+}
+
+// OrgValidationPolicy is the validation policy of an organization. Rules are evaluated in order
+// and the first rule that denies the request fails the validation.
+type OrgValidationPolicy struct {
+	OrgID string                 `mapstructure:"org_id"`
+	Rules []ValidationPolicyRule `mapstructure:"rules"`
+}
+
+// ValidationPolicyRule is a single validation policy rule
+type ValidationPolicyRule struct {
+	Name string `mapstructure:"name"`
+	Type string `mapstructure:"type"`
+	// ConsentTypes limits the rule to consents of these types. The rule applies to every consent
+	// when empty.
+	ConsentTypes []string `mapstructure:"consent_types"`
+	// HTTPMethods are the methods allowed by an http_method rule
+	HTTPMethods []string `mapstructure:"http_methods"`
+	// ResourcePatterns are the paths allowed by a resource_path rule. A "*" segment matches any
+	// segment, a trailing "**" matches the rest of the path and a "{key}" segment must equal a
+	// value stored under key in the resources of an approved authorization of the user. "{*}"
+	// accepts a value stored under any key.
+	ResourcePatterns []string `mapstructure:"resource_patterns"`
+	// MaxFrequency is the highest consent frequency allowed by a frequency rule
+	MaxFrequency int `mapstructure:"max_frequency"`
+}
+
+// GetRules returns the validation policy rules of an organization
+func (c *ValidationPolicyConfig) GetRules(orgID string) []ValidationPolicyRule {
+	for i := range c.Orgs {
+		if c.Orgs[i].OrgID == orgID {
+			return c.Orgs[i].Rules
+		}
+	}
+	return nil
+}
+
+// StateMachineConfig describes the consent statuses and the transitions allowed between them.
+// Transitions are only enforced for organizations with the status_machine feature flag.
+type StateMachineConfig struct {
+	// States lists consent statuses in addition to those in status_mappings, in the order they take
+	// precedence when deriving a consent's status from its authorizations
+	States []string `mapstructure:"states"`
+	// InitialStates are the statuses a consent may be created in. Any status is allowed when empty.
+	InitialStates []string `mapstructure:"initial_states"`
+	// Transitions lists the allowed status changes. Any change between known statuses is allowed
+	// when empty.
+	Transitions []StatusTransition `mapstructure:"transitions"`
+	// AuthStatusMappings map authorization statuses to the consent status they derive, in addition
+	// to the approved, rejected and created authorization statuses
+	AuthStatusMappings []AuthStatusMapping `mapstructure:"auth_status_mappings"`
+}
+
+// ConsentFilesConfig holds configuration for documents attached to consents
+type ConsentFilesConfig struct {
+	// MaxSize is the largest file accepted, in bytes
+	MaxSize int64 `mapstructure:"max_size"`
+	// MaxFilesPerConsent caps the number of files attached to a consent. Zero means no limit.
+	MaxFilesPerConsent int `mapstructure:"max_files_per_consent"`
+	// AllowedContentTypes lists the media types files may be uploaded with
+	AllowedContentTypes []string          `mapstructure:"allowed_content_types"`
+	Storage             FileStorageConfig `mapstructure:"storage"`
+}
+
+// ConsentImportConfig holds configuration for bulk consent import jobs
+type ConsentImportConfig struct {
+	// WorkDir is where uploaded import files are kept until their job completes. Defaults to a
+	// directory under the system temporary directory.
+	WorkDir string `mapstructure:"work_dir"`
+	// MaxFileSize is the largest import file accepted, in bytes. Zero means no limit.
+	MaxFileSize int64 `mapstructure:"max_file_size"`
+	// Workers is the number of import jobs processed at the same time. Defaults to 1.
+	Workers int `mapstructure:"workers"`
+}
+
+// Supported consent file storage types
+const (
+	FileStorageDatabase = "database"
+	FileStorageS3       = "s3"
+)
+
+// FileStorageConfig selects where file contents are kept. The database keeps them in the
+// CONSENT_FILE table; s3 keeps them in an S3 compatible object store and only the metadata in the
+// database.
+type FileStorageConfig struct {
+	Type string          `mapstructure:"type"`
+	S3   S3StorageConfig `mapstructure:"s3"`
+}
+
+// S3StorageConfig holds the settings of an S3 compatible object store
+type S3StorageConfig struct {
+	// Endpoint defaults to the Amazon S3 endpoint of the region
+	Endpoint        string        `mapstructure:"endpoint"`
+	Region          string        `mapstructure:"region"`
+	Bucket          string        `mapstructure:"bucket"`
+	Path            string        `mapstructure:"path"`
+	AccessKeyID     string        `mapstructure:"access_key_id"`
+	SecretAccessKey string        `mapstructure:"secret_access_key"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+}
+
+// StatusTransition lists the statuses a consent may move to from a status
+type StatusTransition struct {
+	From string   `mapstructure:"from"`
+	To   []string `mapstructure:"to"`
+}
+
+// AuthStatusMapping maps an authorization status to a consent status
+type AuthStatusMapping struct {
+	AuthStatus    string `mapstructure:"auth_status"`
+	ConsentStatus string `mapstructure:"consent_status"`
+}
+
+// IsInitialStateAllowed reports whether a consent may be created in status
+func (c *ConsentConfig) IsInitialStateAllowed(status string) bool {
+	if len(c.StateMachine.InitialStates) == 0 {
+		return c.IsStatusAllowed(ConsentStatus(status))
+	}
+	return containsString(c.StateMachine.InitialStates, status)
+}
+
+// IsTransitionAllowed reports whether a consent may move from one status to another
+func (c *ConsentConfig) IsTransitionAllowed(from, to string) bool {
+	if len(c.StateMachine.Transitions) == 0 {
+		return c.IsStatusAllowed(ConsentStatus(from)) && c.IsStatusAllowed(ConsentStatus(to))
+	}
+	for _, transition := range c.StateMachine.Transitions {
+		if transition.From == from {
+			return containsString(transition.To, to)
+		}
+	}
+	return false
+}
+
+// MapAuthStatus returns the consent status an authorization status derives. Authorization
+// statuses are compared case-insensitively, a missing status counts as approved and unknown
+// statuses derive the created status.
+func (c *ConsentConfig) MapAuthStatus(authStatus string) string {
+	for _, mapping := range c.StateMachine.AuthStatusMappings {
+		if strings.EqualFold(mapping.AuthStatus, authStatus) {
+			return mapping.ConsentStatus
+		}
+	}
+	switch {
+	case authStatus == "" || strings.EqualFold(authStatus, c.AuthStatusMappings.ApprovedState):
+		return c.StatusMappings.ActiveStatus
+	case strings.EqualFold(authStatus, c.AuthStatusMappings.RejectedState):
+		return c.StatusMappings.RejectedStatus
+	default:
+		return c.StatusMappings.CreatedStatus
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// FeatureFlagsConfig holds the state of feature flags. Flags that are not listed are disabled.
+type FeatureFlagsConfig struct {
+	Defaults map[string]bool   `mapstructure:"defaults"`
+	Orgs     []OrgFeatureFlags `mapstructure:"orgs"`
+}
+
+// OrgFeatureFlags overrides the default state of feature flags for an organization
+type OrgFeatureFlags struct {
+	OrgID string          `mapstructure:"org_id"`
+	Flags map[string]bool `mapstructure:"flags"`
+}
+
+// CacheConfig holds configuration for the in-memory caches. Cache statistics are served from
+// /api/v1/admin/caches.
+type CacheConfig struct {
+	// Purpose caches consent purpose definitions and their attributes
+	Purpose CacheSettings `mapstructure:"purpose"`
+	// SearchCount caches the totals of consent searches per organization and filters. Totals can
+	// lag behind consents created or deleted within the TTL, so it must be short and not zero.
+	SearchCount CacheSettings `mapstructure:"search_count"`
+	// Validate caches the consent data read by consent validation in Redis
+	Validate ValidateCacheConfig `mapstructure:"validate"`
+	// Organization refreshes the in-memory organization overrides
+	Organization OrganizationCacheConfig `mapstructure:"organization"`
+}
+
+// OrganizationCacheConfig holds configuration for the in-memory copy of the organization overrides.
+// Overrides are updated as soon as an organization changes through this server; the refresh picks
+// up changes made through other server instances.
+type OrganizationCacheConfig struct {
+	// RefreshInterval is how often the overrides are reloaded from the database. Zero disables the refresh.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
 }
 
-// BasicAuthConfig holds basic authentication configuration
-type BasicAuthConfig struct {
-	Enabled bool            `mapstructure:"enabled"`
-	Users   []BasicAuthUser `mapstructure:"users"`
+// ValidateCacheConfig holds configuration for the Redis cache of consent validation data. Entries
+// are keyed by organization and consent ID and dropped when the consent or its authorizations change.
+type ValidateCacheConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// TTL bounds how long changes made outside the API, such as purpose updates, take to show up
+	TTL   time.Duration `mapstructure:"ttl"`
+	Redis RedisConfig   `mapstructure:"redis"`
 }
 
-// BasicAuthUser represents a basic auth user
-type BasicAuthUser struct {
+// RedisConfig holds the connection settings of a Redis server
+type RedisConfig struct {
+	Address  string `mapstructure:"address"`
 	Username string `mapstructure:"username"`
 	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+	// KeyPrefix is prepended to every key, so several deployments can share a Redis server
+	KeyPrefix string         `mapstructure:"key_prefix"`
+	Timeout   time.Duration  `mapstructure:"timeout"`
+	PoolSize  int            `mapstructure:"pool_size"`
+	TLS       RedisTLSConfig `mapstructure:"tls"`
 }
 
-// CORSConfig holds CORS configuration
-type CORSConfig struct {
-	Enabled          bool     `mapstructure:"enabled"`
-	AllowedOrigins   []string `mapstructure:"allowed_origins"`
-	AllowedMethods   []string `mapstructure:"allowed_methods"`
-	AllowedHeaders   []string `mapstructure:"allowed_headers"`
-	AllowCredentials bool     `mapstructure:"allow_credentials"`
-	MaxAge           int      `mapstructure:"max_age"`
+// RedisTLSConfig holds the TLS settings of Redis connections
+type RedisTLSConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// CAFile is a PEM bundle used to verify the server instead of the system roots
+	CAFile             string `mapstructure:"ca_file"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+// CacheSettings holds the settings of a single cache
+type CacheSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+	// TTL is how long an entry is served from the cache. Zero keeps entries until they are invalidated.
+	TTL time.Duration `mapstructure:"ttl"`
+	// MaxEntries bounds the cache size per server. Zero leaves the cache unbounded.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+// BootstrapConfig holds the declarative bundle of organizations and purposes applied at startup
+type BootstrapConfig struct {
+	// File is the YAML bundle reconciled into the database when the server starts. Empty disables it.
+	File string `mapstructure:"file"`
+	// Prune deletes the organizations, purposes and attribute schemas the bundle does not declare
+	Prune bool `mapstructure:"prune"`
+}
+
+// TestingConfig holds options that must only be enabled in test environments
+type TestingConfig struct {
+	// ClockControlEnabled exposes the admin clock API that allows tests to set the server's notion of "now"
+	ClockControlEnabled bool `mapstructure:"clock_control_enabled"`
 }
 
 var globalConfig *Config
@@ -244,7 +1450,68 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("invalid server port: %d", config.Server.Port)
 	}
 
-	if config.Database.Consent.Hostname == "" {
+	if config.GRPC.Enabled {
+		if config.GRPC.Port <= 0 || config.GRPC.Port > 65535 {
+			return fmt.Errorf("invalid gRPC port: %d", config.GRPC.Port)
+		}
+		if config.GRPC.Port == config.Server.Port {
+			return fmt.Errorf("gRPC port must differ from the HTTP server port")
+		}
+	}
+
+	if config.Server.MaxBodySize < 0 {
+		return fmt.Errorf("server max_body_size must not be negative")
+	}
+	for _, limit := range config.Server.RouteBodyLimits {
+		if limit.Route == "" || limit.MaxBodySize < 0 {
+			return fmt.Errorf("server route_body_limits entries need a route and a non-negative max_body_size")
+		}
+	}
+	if config.Server.RequestTimeout < 0 {
+		return fmt.Errorf("server request_timeout must not be negative")
+	}
+	for _, timeout := range config.Server.RouteTimeouts {
+		if timeout.Route == "" || timeout.Timeout < 0 {
+			return fmt.Errorf("server route_timeouts entries need a route and a non-negative timeout")
+		}
+	}
+
+	if config.Server.TLS.Enabled && (config.Server.TLS.CertFile == "" || config.Server.TLS.KeyFile == "") {
+		return fmt.Errorf("server TLS certificate and key files are required when server TLS is enabled")
+	}
+
+	if config.GRPC.Enabled && config.GRPC.TLS.Enabled && (config.GRPC.TLS.CertFile == "" || config.GRPC.TLS.KeyFile == "") {
+		return fmt.Errorf("gRPC TLS certificate and key files are required when gRPC TLS is enabled")
+	}
+
+	if config.Admin.Enabled {
+		if config.Admin.Port <= 0 || config.Admin.Port > 65535 {
+			return fmt.Errorf("invalid admin port: %d", config.Admin.Port)
+		}
+		if config.Admin.Port == config.Server.Port || (config.GRPC.Enabled && config.Admin.Port == config.GRPC.Port) {
+			return fmt.Errorf("admin port must differ from the HTTP server and gRPC ports")
+		}
+		if config.Admin.TLS.Enabled && (config.Admin.TLS.CertFile == "" || config.Admin.TLS.KeyFile == "") {
+			return fmt.Errorf("admin TLS certificate and key files are required when admin TLS is enabled")
+		}
+		if config.Admin.BasicAuth.Enabled && len(config.Admin.BasicAuth.Users) == 0 {
+			return fmt.Errorf("at least one admin basic auth user is required when admin basic auth is enabled")
+		}
+	}
+
+	for route, scope := range config.Security.Authorization.RouteScopes {
+		if len(strings.Fields(route)) != 2 || strings.TrimSpace(scope) == "" {
+			return fmt.Errorf("invalid route scope '%s: %s', expected \"METHOD /path\" mapped to a scope", route, scope)
+		}
+	}
+
+	switch strings.ToLower(config.Database.Consent.Type) {
+	case "", DatabaseTypeMySQL, DatabaseTypeSQLite, "sqlite3":
+	default:
+		return fmt.Errorf("unsupported database type '%s', expected mysql or sqlite", config.Database.Consent.Type)
+	}
+
+	if !config.Database.Consent.IsSQLite() && config.Database.Consent.Hostname == "" {
 		return fmt.Errorf("database hostname is required")
 	}
 
@@ -252,10 +1519,272 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("database name is required")
 	}
 
+	if pool := config.Database.Consent; pool.MaxOpenConns < 0 || pool.MaxIdleConns < 0 ||
+		pool.ConnMaxLifetime < 0 || pool.ConnMaxIdleTime < 0 {
+		return fmt.Errorf("database connection pool settings must not be negative")
+	}
+
+	if config.Database.Consent.QueryTimeout < 0 || config.Database.Consent.SlowQueryThreshold < 0 {
+		return fmt.Errorf("database query_timeout and slow_query_threshold must not be negative")
+	}
+
+	if retry := config.Database.Consent.TransactionRetry; retry.GetMaxRetries() < 0 ||
+		retry.Backoff < 0 || retry.MaxBackoff < 0 {
+		return fmt.Errorf("database transaction retry settings must not be negative")
+	}
+
+	if pool := config.Database.Consent; pool.MaxIdleConns > pool.GetMaxOpenConns() {
+		return fmt.Errorf("database max_idle_conns (%d) must not exceed max_open_conns (%d)",
+			pool.MaxIdleConns, pool.GetMaxOpenConns())
+	}
+
 	if config.ServiceExtension.Enabled && config.ServiceExtension.BaseURL == "" {
 		return fmt.Errorf("service extension base URL is required when extension is enabled")
 	}
 
+	for hook, hookConfig := range config.ServiceExtension.Hooks {
+		if config.ServiceExtension.Endpoints.GetEndpoint(hook) == "" {
+			return fmt.Errorf("service extension hook '%s' has no configured endpoint", hook)
+		}
+		if hookConfig.FailurePolicy != "" && hookConfig.FailurePolicy != ExtensionFailOpen &&
+			hookConfig.FailurePolicy != ExtensionFailClosed {
+			return fmt.Errorf("service extension hook '%s' has invalid failure policy '%s'", hook, hookConfig.FailurePolicy)
+		}
+		if hookConfig.Timeout < 0 {
+			return fmt.Errorf("service extension hook '%s' timeout must not be negative", hook)
+		}
+		if hookConfig.Mode != "" && hookConfig.Mode != ExtensionModeSync && hookConfig.Mode != ExtensionModeAsync {
+			return fmt.Errorf("service extension hook '%s' has invalid mode '%s'", hook, hookConfig.Mode)
+		}
+		if hookConfig.RetryAttempts != nil && *hookConfig.RetryAttempts < 0 {
+			return fmt.Errorf("service extension hook '%s' retry_attempts must not be negative", hook)
+		}
+		if hookConfig.CircuitBreaker != nil && hookConfig.CircuitBreaker.FailureThreshold < 0 {
+			return fmt.Errorf("service extension hook '%s' circuit_breaker failure_threshold must not be negative", hook)
+		}
+		if hookConfig.Auth != nil {
+			if err := validateExtensionAuth(*hookConfig.Auth); err != nil {
+				return fmt.Errorf("service extension hook '%s': %w", hook, err)
+			}
+		}
+	}
+	if err := validateExtensionAuth(config.ServiceExtension.Auth); err != nil {
+		return fmt.Errorf("service extension: %w", err)
+	}
+	if config.ServiceExtension.RetryAttempts < 0 {
+		return fmt.Errorf("service extension retry_attempts must not be negative")
+	}
+	if config.ServiceExtension.CircuitBreaker.FailureThreshold < 0 {
+		return fmt.Errorf("service extension circuit_breaker failure_threshold must not be negative")
+	}
+
+	if config.Export.Enabled && config.Export.PollInterval <= 0 {
+		return fmt.Errorf("export poll interval must be positive when export is enabled")
+	}
+
+	if config.Cache.Purpose.Enabled {
+		if config.Cache.Purpose.TTL < 0 {
+			return fmt.Errorf("purpose cache ttl must not be negative")
+		}
+		if config.Cache.Purpose.MaxEntries < 0 {
+			return fmt.Errorf("purpose cache max entries must not be negative")
+		}
+	}
+
+	if config.Cache.SearchCount.Enabled {
+		if config.Cache.SearchCount.TTL <= 0 {
+			return fmt.Errorf("search count cache ttl must be positive")
+		}
+		if config.Cache.SearchCount.MaxEntries < 0 {
+			return fmt.Errorf("search count cache max entries must not be negative")
+		}
+	}
+
+	if config.Cache.Validate.Enabled {
+		if config.Cache.Validate.TTL <= 0 {
+			return fmt.Errorf("validate cache ttl must be positive when the validate cache is enabled")
+		}
+		if config.Cache.Validate.Redis.Address == "" {
+			return fmt.Errorf("validate cache redis address is required when the validate cache is enabled")
+		}
+		if config.Cache.Validate.Redis.DB < 0 || config.Cache.Validate.Redis.Timeout < 0 || config.Cache.Validate.Redis.PoolSize < 0 {
+			return fmt.Errorf("validate cache redis db, timeout and pool size must not be negative")
+		}
+	}
+
+	if config.Cache.Organization.RefreshInterval < 0 {
+		return fmt.Errorf("organization cache refresh interval must not be negative")
+	}
+
+	if config.Consent.DefaultValidity < 0 {
+		return fmt.Errorf("consent default validity must not be negative")
+	}
+
+	if config.Consent.Purge.Enabled {
+		if config.Consent.Purge.Interval <= 0 {
+			return fmt.Errorf("consent purge interval must be positive when purge is enabled")
+		}
+		if config.Consent.Purge.RetentionDays < 0 {
+			return fmt.Errorf("consent purge retention days must not be negative")
+		}
+		if config.Consent.Purge.BatchSize <= 0 {
+			return fmt.Errorf("consent purge batch size must be positive when purge is enabled")
+		}
+	}
+
+	if retention := config.Consent.Retention; retention.Enabled {
+		if retention.Interval <= 0 {
+			return fmt.Errorf("consent retention interval must be positive when retention is enabled")
+		}
+		if retention.RetentionDays < 0 {
+			return fmt.Errorf("consent retention days must not be negative")
+		}
+		if retention.Action != RetentionActionDelete && retention.Action != RetentionActionAnonymize {
+			return fmt.Errorf("consent retention action must be %s or %s", RetentionActionDelete, RetentionActionAnonymize)
+		}
+		if retention.BatchSize <= 0 {
+			return fmt.Errorf("consent retention batch size must be positive when retention is enabled")
+		}
+	}
+
+	if scheduled := config.Consent.ScheduledRevocation; scheduled.Enabled {
+		if scheduled.Interval <= 0 {
+			return fmt.Errorf("consent scheduled revocation interval must be positive when scheduled revocation is enabled")
+		}
+		if scheduled.BatchSize <= 0 {
+			return fmt.Errorf("consent scheduled revocation batch size must be positive when scheduled revocation is enabled")
+		}
+	}
+
+	if cleanup := config.Consent.TokenCleanup; cleanup.Enabled && cleanup.Interval <= 0 {
+		return fmt.Errorf("consent token cleanup interval must be positive when token cleanup is enabled")
+	}
+
+	if notification := config.Consent.ExpiryNotification; notification.Enabled {
+		if notification.Interval <= 0 {
+			return fmt.Errorf("consent expiry notification interval must be positive when notifications are enabled")
+		}
+		if notification.NoticeDays < 0 {
+			return fmt.Errorf("consent expiry notification notice days must not be negative")
+		}
+		if notification.BatchSize <= 0 {
+			return fmt.Errorf("consent expiry notification batch size must be positive when notifications are enabled")
+		}
+		if email := notification.Email; email.Enabled {
+			if email.Host == "" || email.Port <= 0 || email.From == "" || email.RecipientAttribute == "" {
+				return fmt.Errorf("consent expiry notification email host, port, from and recipient_attribute are required when email is enabled")
+			}
+		}
+	}
+
+	for i, rule := range config.Events.AttributePropagation {
+		if rule.OrgID == "" {
+			return fmt.Errorf("events attribute propagation rule %d is missing org_id", i)
+		}
+	}
+
+	if kafka := config.Events.Kafka; kafka.Enabled {
+		if len(kafka.Brokers) == 0 || kafka.Topic == "" {
+			return fmt.Errorf("events kafka brokers and topic are required when kafka is enabled")
+		}
+		if kafka.Acks != "" && kafka.Acks != KafkaAcksAll && kafka.Acks != KafkaAcksLeader {
+			return fmt.Errorf("events kafka acks must be %s or %s", KafkaAcksAll, KafkaAcksLeader)
+		}
+		if kafka.Timeout <= 0 {
+			return fmt.Errorf("events kafka timeout must be positive when kafka is enabled")
+		}
+		switch kafka.SASL.Mechanism {
+		case "":
+		case KafkaSASLPlain, KafkaSASLScramSHA256, KafkaSASLScramSHA512:
+			if kafka.SASL.Username == "" {
+				return fmt.Errorf("events kafka sasl username is required when a sasl mechanism is set")
+			}
+		default:
+			return fmt.Errorf("unsupported events kafka sasl mechanism '%s'", kafka.SASL.Mechanism)
+		}
+	}
+
+	if err := validateConsentSync(&config.Events.Sync); err != nil {
+		return err
+	}
+
+	for i, controller := range config.Consent.Receipt.OrgControllers {
+		if controller.OrgID == "" {
+			return fmt.Errorf("consent receipt controller %d is missing org_id", i)
+		}
+	}
+
+	if err := validateConsentIDConfig(&config.Consent.IDGeneration); err != nil {
+		return err
+	}
+
+	if err := validateStatusDerivation(&config.Consent.StatusDerivation); err != nil {
+		return err
+	}
+
+	for i, policy := range config.Consent.OwnershipTransfer.Orgs {
+		if policy.OrgID == "" {
+			return fmt.Errorf("consent ownership transfer policy %d is missing org_id", i)
+		}
+	}
+
+	if err := validateStateMachine(&config.Consent); err != nil {
+		return err
+	}
+
+	if config.Consent.Files.MaxSize < 0 || config.Consent.Files.MaxFilesPerConsent < 0 {
+		return fmt.Errorf("consent files max size and max files per consent must not be negative")
+	}
+	switch config.Consent.Files.Storage.Type {
+	case "", FileStorageDatabase:
+	case FileStorageS3:
+		if config.Consent.Files.Storage.S3.Bucket == "" || config.Consent.Files.Storage.S3.Region == "" {
+			return fmt.Errorf("consent files s3 storage requires bucket and region")
+		}
+	default:
+		return fmt.Errorf("unsupported consent files storage type '%s', must be one of: database, s3",
+			config.Consent.Files.Storage.Type)
+	}
+
+	for i, cascade := range config.Consent.StatusOverride.AuthStatusCascades {
+		if !config.Consent.IsStatusAllowed(ConsentStatus(cascade.ConsentStatus)) || cascade.AuthStatus == "" {
+			return fmt.Errorf("consent status override cascade %d must map a known consent status to an authorization status", i)
+		}
+	}
+
+	if config.Consent.Usage.Period < 0 {
+		return fmt.Errorf("consent usage period must not be negative")
+	}
+
+	if err := validateValidationPolicy(config); err != nil {
+		return err
+	}
+
+	if config.Consent.Import.MaxFileSize < 0 || config.Consent.Import.Workers < 0 {
+		return fmt.Errorf("consent import max file size and workers must not be negative")
+	}
+
+	for i, org := range config.FeatureFlags.Orgs {
+		if org.OrgID == "" {
+			return fmt.Errorf("feature flag override %d is missing org_id", i)
+		}
+	}
+
+	if config.Tracing.Enabled {
+		if config.Tracing.Endpoint == "" {
+			return fmt.Errorf("tracing endpoint is required when tracing is enabled")
+		}
+		if config.Tracing.ServiceName == "" {
+			return fmt.Errorf("tracing service name is required when tracing is enabled")
+		}
+		if config.Tracing.SampleRatio < 0 || config.Tracing.SampleRatio > 1 {
+			return fmt.Errorf("tracing sample ratio must be between 0 and 1")
+		}
+		if config.Tracing.BatchSize <= 0 || config.Tracing.ExportInterval <= 0 || config.Tracing.Timeout <= 0 {
+			return fmt.Errorf("tracing batch size, export interval and timeout must be positive when tracing is enabled")
+		}
+	}
+
 	// Validate consent status mappings
 	if config.Consent.StatusMappings.ActiveStatus == "" {
 		return fmt.Errorf("consent active status mapping is required")
@@ -273,6 +1802,10 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("consent rejected status mapping is required")
 	}
 
+	if config.Consent.Reauthorization.MaxValidity < 0 {
+		return fmt.Errorf("consent reauthorization max validity must not be negative")
+	}
+
 	// Validate auth status mappings
 	if config.Consent.AuthStatusMappings.ApprovedState == "" {
 		return fmt.Errorf("auth approved status mapping is required")
@@ -303,8 +1836,79 @@ func SetGlobal(cfg *Config) {
 	globalConfig = cfg
 }
 
+// GetType returns the database type, mysql unless configured otherwise
+func (d *DatabaseConfig) GetType() string {
+	switch strings.ToLower(d.Type) {
+	case DatabaseTypeSQLite, "sqlite3":
+		return DatabaseTypeSQLite
+	default:
+		return DatabaseTypeMySQL
+	}
+}
+
+// IsSQLite reports whether the database is SQLite
+func (d *DatabaseConfig) IsSQLite() bool {
+	return d.GetType() == DatabaseTypeSQLite
+}
+
+// GetMaxOpenConns returns the maximum number of open connections, defaulting to 25
+func (d *DatabaseConfig) GetMaxOpenConns() int {
+	if d.MaxOpenConns <= 0 {
+		return 25
+	}
+	return d.MaxOpenConns
+}
+
+// GetMaxIdleConns returns the number of idle connections kept open, defaulting to 5 and capped at
+// the maximum number of open connections
+func (d *DatabaseConfig) GetMaxIdleConns() int {
+	idle := d.MaxIdleConns
+	if idle <= 0 {
+		idle = 5
+	}
+	return min(idle, d.GetMaxOpenConns())
+}
+
+// GetConnMaxLifetime returns how long a connection is reused, defaulting to 5 minutes
+func (d *DatabaseConfig) GetConnMaxLifetime() time.Duration {
+	if d.ConnMaxLifetime <= 0 {
+		return 5 * time.Minute
+	}
+	return d.ConnMaxLifetime
+}
+
+// GetConnMaxIdleTime returns how long an unused connection is kept open, defaulting to 1 minute
+func (d *DatabaseConfig) GetConnMaxIdleTime() time.Duration {
+	if d.ConnMaxIdleTime <= 0 {
+		return time.Minute
+	}
+	return d.ConnMaxIdleTime
+}
+
+// GetSlowQueryThreshold returns the duration above which statements are logged as slow,
+// defaulting to 1 second
+func (d *DatabaseConfig) GetSlowQueryThreshold() time.Duration {
+	if d.SlowQueryThreshold <= 0 {
+		return time.Second
+	}
+	return d.SlowQueryThreshold
+}
+
+// GetDriverName returns the name of the SQL driver for the database type
+func (d *DatabaseConfig) GetDriverName() string {
+	if d.IsSQLite() {
+		return "sqlite3"
+	}
+	return "mysql"
+}
+
 // GetDSN returns the database connection string
 func (d *DatabaseConfig) GetDSN() string {
+	if d.IsSQLite() {
+		// Transactions take the write lock when they begin so concurrent writers wait for each
+		// other instead of failing to upgrade a read lock
+		return "file:" + d.Database + "?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate"
+	}
 	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&multiStatements=true",
 		d.User,
 		d.Password,
@@ -331,12 +1935,53 @@ func (s *SecurityConfig) IsBasicAuthEnabled() bool {
 
 // ValidateUser validates basic auth credentials
 func (s *SecurityConfig) ValidateUser(username, password string) bool {
-	for _, user := range s.BasicAuth.Users {
-		if user.Username == username && user.Password == password {
-			return true
+	return s.BasicAuth.ValidateUser(username, password)
+}
+
+// ValidateUser validates credentials against the configured users
+func (b *BasicAuthConfig) ValidateUser(username, password string) bool {
+	return b.FindUser(username, password) != nil
+}
+
+// FindUser returns the configured user matching the credentials, or nil
+func (b *BasicAuthConfig) FindUser(username, password string) *BasicAuthUser {
+	for i := range b.Users {
+		if b.Users[i].Username == username && b.Users[i].Password == password {
+			return &b.Users[i]
 		}
 	}
-	return false
+	return nil
+}
+
+// HasScope reports whether the user is granted scope
+func (u *BasicAuthUser) HasScope(scope string) bool {
+	return len(u.Scopes) == 0 || containsString(u.Scopes, scope)
+}
+
+// AppliesTo reports whether the routes of an organization are authorized
+func (a *AuthorizationConfig) AppliesTo(orgID string) bool {
+	return a.Enabled && (len(a.OrgIDs) == 0 || containsString(a.OrgIDs, orgID))
+}
+
+// GetRouteScope returns the scope required by route, a "METHOD /path" pattern relative to the API
+// base path, or defaultScope when the route is not overridden. Route keys are matched ignoring
+// case as the configuration loader lowercases map keys.
+func (a *AuthorizationConfig) GetRouteScope(route, defaultScope string) string {
+	for key, scope := range a.RouteScopes {
+		if strings.EqualFold(key, route) {
+			return scope
+		}
+	}
+	return defaultScope
+}
+
+// AdminBasicAuth returns the basic auth configuration protecting admin endpoints. The admin
+// listener's own users are used when it is enabled with basic auth, security.basic_auth otherwise.
+func (c *Config) AdminBasicAuth() *BasicAuthConfig {
+	if c.Admin.Enabled && c.Admin.BasicAuth.Enabled {
+		return &c.Admin.BasicAuth
+	}
+	return &c.Security.BasicAuth
 }
 
 // IsStatusAllowed checks if a given status is a valid consent status
@@ -345,7 +1990,11 @@ func (c *ConsentConfig) IsStatusAllowed(status ConsentStatus) bool {
 		status == c.GetExpiredConsentStatus() ||
 		status == c.GetRevokedConsentStatus() ||
 		status == c.GetCreatedConsentStatus() ||
-		status == c.GetRejectedConsentStatus()
+		status == c.GetRejectedConsentStatus() ||
+		status == c.GetAwaitingReauthorizationStatus() ||
+		status == c.GetPartiallyAuthorizedStatus() ||
+		status == c.GetSuspendedConsentStatus() ||
+		containsString(c.StateMachine.States, string(status))
 }
 
 // IsActiveStatus checks if the given status represents an active consent
@@ -380,13 +2029,20 @@ func (c *ConsentConfig) IsTerminalStatus(status ConsentStatus) bool {
 
 // GetAllowedConsentStatuses returns a list of all valid consent statuses
 func (c *ConsentConfig) GetAllowedConsentStatuses() []ConsentStatus {
-	return []ConsentStatus{
+	statuses := []ConsentStatus{
 		c.GetCreatedConsentStatus(),
 		c.GetActiveConsentStatus(),
 		c.GetRejectedConsentStatus(),
 		c.GetRevokedConsentStatus(),
 		c.GetExpiredConsentStatus(),
+		c.GetAwaitingReauthorizationStatus(),
+		c.GetPartiallyAuthorizedStatus(),
+		c.GetSuspendedConsentStatus(),
+	}
+	for _, state := range c.StateMachine.States {
+		statuses = append(statuses, ConsentStatus(state))
 	}
+	return statuses
 }
 
 // IsAuthStatusAllowed checks if a given status is a valid authorization status
@@ -408,3 +2064,185 @@ func (c *ConsentConfig) GetAllowedAuthStatuses() []AuthStatus {
 		c.GetSystemRevokedAuthStatus(),
 	}
 }
+
+// validateValidationPolicy checks that every validation policy rule is complete
+func validateValidationPolicy(config *Config) error {
+	for i, org := range config.Consent.ValidationPolicy.Orgs {
+		if org.OrgID == "" {
+			return fmt.Errorf("consent validation policy %d is missing org_id", i)
+		}
+		for j, rule := range org.Rules {
+			if rule.Name == "" {
+				return fmt.Errorf("consent validation policy rule %d of org '%s' is missing name", j, org.OrgID)
+			}
+			switch rule.Type {
+			case PolicyRuleHTTPMethod:
+				if len(rule.HTTPMethods) == 0 {
+					return fmt.Errorf("consent validation policy rule '%s' requires http_methods", rule.Name)
+				}
+			case PolicyRuleResourcePath:
+				if len(rule.ResourcePatterns) == 0 {
+					return fmt.Errorf("consent validation policy rule '%s' requires resource_patterns", rule.Name)
+				}
+			case PolicyRuleFrequency:
+				if rule.MaxFrequency <= 0 {
+					return fmt.Errorf("consent validation policy rule '%s' requires a positive max_frequency", rule.Name)
+				}
+			case PolicyRuleExtension:
+				if !config.ServiceExtension.Enabled || config.ServiceExtension.Endpoints.EvaluateValidationPolicy == "" {
+					return fmt.Errorf("consent validation policy rule '%s' requires the service extension with an evaluate_validation_policy endpoint", rule.Name)
+				}
+			default:
+				return fmt.Errorf("consent validation policy rule '%s' has unsupported type '%s', must be one of: %s, %s, %s, %s",
+					rule.Name, rule.Type, PolicyRuleHTTPMethod, PolicyRuleResourcePath, PolicyRuleFrequency, PolicyRuleExtension)
+			}
+		}
+	}
+	return nil
+}
+
+// maxConsentIDPrefixLength keeps prefixed IDs within the 100 characters accepted as consent IDs
+const maxConsentIDPrefixLength = 32
+
+// validateConsentSync checks that every sync connector has a unique name and a supported type
+func validateConsentSync(cfg *ConsentSyncConfig) error {
+	names := make(map[string]bool, len(cfg.Connectors))
+	for i, connector := range cfg.Connectors {
+		if connector.Name == "" {
+			return fmt.Errorf("events sync connector %d is missing name", i)
+		}
+		if names[connector.Name] {
+			return fmt.Errorf("events sync connector '%s' is configured more than once", connector.Name)
+		}
+		names[connector.Name] = true
+
+		if connector.Timeout < 0 {
+			return fmt.Errorf("events sync connector '%s' timeout must not be negative", connector.Name)
+		}
+		switch connector.Type {
+		case SyncConnectorREST:
+			if !strings.HasPrefix(connector.URL, "http://") && !strings.HasPrefix(connector.URL, "https://") {
+				return fmt.Errorf("events sync connector '%s' requires an http or https url", connector.Name)
+			}
+			if connector.Method != "" && connector.Method != "POST" && connector.Method != "PUT" {
+				return fmt.Errorf("events sync connector '%s' method must be POST or PUT", connector.Name)
+			}
+		case SyncConnectorTokenRevocation:
+			if !strings.HasPrefix(connector.URL, "http://") && !strings.HasPrefix(connector.URL, "https://") {
+				return fmt.Errorf("events sync connector '%s' requires an http or https url", connector.Name)
+			}
+			if connector.Method != "" && connector.Method != "POST" {
+				return fmt.Errorf("events sync connector '%s' method must be POST", connector.Name)
+			}
+		default:
+			return fmt.Errorf("unsupported events sync connector type '%s'", connector.Type)
+		}
+	}
+	return nil
+}
+
+// validateConsentIDConfig checks the consent ID strategy and that prefixes only use letters,
+// digits, '_', '.' and '-', so that prefixed IDs are safe in URLs and logs
+func validateConsentIDConfig(cfg *ConsentIDConfig) error {
+	switch cfg.Strategy {
+	case "", IDStrategyUUID, IDStrategyULID:
+	default:
+		return fmt.Errorf("unsupported consent id_generation strategy '%s', must be one of: %s, %s",
+			cfg.Strategy, IDStrategyUUID, IDStrategyULID)
+	}
+
+	prefixes := []string{cfg.Prefix}
+	for i, orgPrefix := range cfg.OrgPrefixes {
+		if orgPrefix.OrgID == "" {
+			return fmt.Errorf("consent id_generation org prefix %d is missing org_id", i)
+		}
+		prefixes = append(prefixes, orgPrefix.Prefix)
+	}
+	for _, prefix := range prefixes {
+		if !utils.IsValidIDPrefix(prefix) || len(prefix) > maxConsentIDPrefixLength {
+			return fmt.Errorf("invalid consent id_generation prefix '%s': use at most %d letters, digits, '_', '.' or '-'",
+				prefix, maxConsentIDPrefixLength)
+		}
+	}
+	return nil
+}
+
+// validateExtensionAuth checks the authentication settings of service extension calls
+func validateExtensionAuth(auth ExtensionAuthConfig) error {
+	if (auth.TLS.CertFile == "") != (auth.TLS.KeyFile == "") {
+		return fmt.Errorf("auth tls cert_file and key_file must be set together")
+	}
+	oauth2 := auth.OAuth2
+	if oauth2.TokenURL == "" {
+		if oauth2.ClientID != "" || oauth2.ClientSecret != "" || len(oauth2.Scopes) > 0 {
+			return fmt.Errorf("auth oauth2 token_url is required when client credentials are set")
+		}
+		return nil
+	}
+	if oauth2.ClientID == "" || oauth2.ClientSecret == "" {
+		return fmt.Errorf("auth oauth2 client_id and client_secret are required")
+	}
+	return nil
+}
+
+// validateStatusDerivation checks the status derivation strategies and that quorum strategies
+// have a quorum
+func validateStatusDerivation(cfg *StatusDerivationConfig) error {
+	validateStrategy := func(name, strategy string, quorum int) error {
+		switch strategy {
+		case StatusDerivationAllApprove, StatusDerivationAnyApprove:
+		case StatusDerivationQuorum:
+			if quorum < 1 {
+				return fmt.Errorf("consent status_derivation %s requires a positive quorum", name)
+			}
+		default:
+			return fmt.Errorf("unsupported consent status_derivation strategy '%s' for %s, must be one of: %s, %s, %s",
+				strategy, name, StatusDerivationAllApprove, StatusDerivationAnyApprove, StatusDerivationQuorum)
+		}
+		return nil
+	}
+
+	if cfg.Strategy != "" {
+		if err := validateStrategy("the default strategy", cfg.Strategy, cfg.Quorum); err != nil {
+			return err
+		}
+	}
+	for i, rule := range cfg.Rules {
+		if rule.OrgID == "" && len(rule.ConsentTypes) == 0 {
+			return fmt.Errorf("consent status_derivation rule %d requires org_id or consent_types", i)
+		}
+		if err := validateStrategy(fmt.Sprintf("rule %d", i), rule.Strategy, rule.Quorum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStateMachine checks that the state machine only refers to known consent statuses
+func validateStateMachine(consent *ConsentConfig) error {
+	stateMachine := consent.StateMachine
+	for _, state := range stateMachine.InitialStates {
+		if !consent.IsStatusAllowed(ConsentStatus(state)) {
+			return fmt.Errorf("consent state machine initial state '%s' is not a known consent status", state)
+		}
+	}
+	for i, transition := range stateMachine.Transitions {
+		if !consent.IsStatusAllowed(ConsentStatus(transition.From)) {
+			return fmt.Errorf("consent state machine transition %d is from unknown status '%s'", i, transition.From)
+		}
+		for _, to := range transition.To {
+			if !consent.IsStatusAllowed(ConsentStatus(to)) {
+				return fmt.Errorf("consent state machine transition %d is to unknown status '%s'", i, to)
+			}
+		}
+	}
+	for i, mapping := range stateMachine.AuthStatusMappings {
+		if mapping.AuthStatus == "" {
+			return fmt.Errorf("consent state machine auth status mapping %d is missing auth_status", i)
+		}
+		if !consent.IsStatusAllowed(ConsentStatus(mapping.ConsentStatus)) {
+			return fmt.Errorf("consent state machine auth status mapping %d maps to unknown status '%s'", i, mapping.ConsentStatus)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// OrgOverrides holds the consent settings an organization overrides. They are managed through the
+// organizations API and stored in the database; empty fields keep the deployment configuration.
+type OrgOverrides struct {
+	StatusMappings ConsentStatusMappings
+	// RetentionDays replaces consent.purge.retention_days for the organization's deleted consents
+	RetentionDays *int
+	WebhookURLs   []string
+	// DefaultValidity replaces consent.default_validity for the organization's new consents
+	DefaultValidity *time.Duration
+	// AllowedConsentTypes replaces consent.allowed_types when not empty
+	AllowedConsentTypes []string
+	// ExpiryNoticeDays replaces consent.expiry_notification.notice_days for the organization's consents
+	ExpiryNoticeDays *int
+	// TerminalRetentionDays replaces consent.retention.retention_days for the organization's revoked
+	// and expired consents
+	TerminalRetentionDays *int
+}
+
+var (
+	orgOverridesMu sync.RWMutex
+	orgOverrides   = map[string]OrgOverrides{}
+)
+
+// SetOrgOverrides replaces the overrides of an organization
+func SetOrgOverrides(orgID string, overrides OrgOverrides) {
+	orgOverridesMu.Lock()
+	defer orgOverridesMu.Unlock()
+	orgOverrides[orgID] = overrides
+}
+
+// ClearOrgOverrides removes the overrides of an organization, which then uses the deployment configuration
+func ClearOrgOverrides(orgID string) {
+	orgOverridesMu.Lock()
+	defer orgOverridesMu.Unlock()
+	delete(orgOverrides, orgID)
+}
+
+// ReplaceOrgOverrides replaces the overrides of all organizations, for example after reloading
+// them from the database
+func ReplaceOrgOverrides(overrides map[string]OrgOverrides) {
+	orgOverridesMu.Lock()
+	defer orgOverridesMu.Unlock()
+	orgOverrides = overrides
+}
+
+// GetOrgOverrides returns the overrides of an organization and whether it has any
+func GetOrgOverrides(orgID string) (OrgOverrides, bool) {
+	orgOverridesMu.RLock()
+	defer orgOverridesMu.RUnlock()
+	overrides, ok := orgOverrides[orgID]
+	return overrides, ok
+}
+
+// ShortestRetentionDays returns the shortest deleted consent retention of the deployment and all
+// organization overrides
+func (c *ConsentConfig) ShortestRetentionDays() int {
+	orgOverridesMu.RLock()
+	defer orgOverridesMu.RUnlock()
+
+	shortest := c.Purge.RetentionDays
+	for _, overrides := range orgOverrides {
+		if overrides.RetentionDays != nil && *overrides.RetentionDays < shortest {
+			shortest = *overrides.RetentionDays
+		}
+	}
+	return shortest
+}
+
+// ShortestTerminalRetentionDays returns the shortest revoked and expired consent retention of the
+// deployment and all organization overrides
+func (c *ConsentConfig) ShortestTerminalRetentionDays() int {
+	orgOverridesMu.RLock()
+	defer orgOverridesMu.RUnlock()
+
+	shortest := c.Retention.RetentionDays
+	for _, overrides := range orgOverrides {
+		if overrides.TerminalRetentionDays != nil && *overrides.TerminalRetentionDays < shortest {
+			shortest = *overrides.TerminalRetentionDays
+		}
+	}
+	return shortest
+}
+
+// LongestExpiryNoticeDays returns the longest expiry notice of the deployment and all organization
+// overrides
+func (c *ConsentConfig) LongestExpiryNoticeDays() int {
+	orgOverridesMu.RLock()
+	defer orgOverridesMu.RUnlock()
+
+	longest := c.ExpiryNotification.NoticeDays
+	for _, overrides := range orgOverrides {
+		if overrides.ExpiryNoticeDays != nil && *overrides.ExpiryNoticeDays > longest {
+			longest = *overrides.ExpiryNoticeDays
+		}
+	}
+	return longest
+}
+
+// ActiveStatusNames returns the active consent status names of the deployment and all
+// organization overrides
+func (c *ConsentConfig) ActiveStatusNames() []string {
+	orgOverridesMu.RLock()
+	defer orgOverridesMu.RUnlock()
+
+	names := []string{string(c.GetActiveConsentStatus())}
+	for _, overrides := range orgOverrides {
+		name := overrides.StatusMappings.ActiveStatus
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// TerminalStatusNames returns the revoked and expired consent status names of the deployment and
+// all organization overrides
+func (c *ConsentConfig) TerminalStatusNames() []string {
+	orgOverridesMu.RLock()
+	defer orgOverridesMu.RUnlock()
+
+	names := []string{string(c.GetRevokedConsentStatus()), string(c.GetExpiredConsentStatus())}
+	for _, overrides := range orgOverrides {
+		for _, name := range []string{overrides.StatusMappings.RevokedStatus, overrides.StatusMappings.ExpiredStatus} {
+			if name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// ForOrg returns the consent configuration of an organization: the deployment configuration with
+// the organization's overrides applied. Use it instead of the deployment configuration wherever
+// the organization is known.
+func (c *ConsentConfig) ForOrg(orgID string) *ConsentConfig {
+	overrides, ok := GetOrgOverrides(orgID)
+	if !ok {
+		return c
+	}
+
+	orgConfig := *c
+	mappings := overrides.StatusMappings
+	if mappings.ActiveStatus != "" {
+		orgConfig.StatusMappings.ActiveStatus = mappings.ActiveStatus
+	}
+	if mappings.ExpiredStatus != "" {
+		orgConfig.StatusMappings.ExpiredStatus = mappings.ExpiredStatus
+	}
+	if mappings.RevokedStatus != "" {
+		orgConfig.StatusMappings.RevokedStatus = mappings.RevokedStatus
+	}
+	if mappings.CreatedStatus != "" {
+		orgConfig.StatusMappings.CreatedStatus = mappings.CreatedStatus
+	}
+	if mappings.RejectedStatus != "" {
+		orgConfig.StatusMappings.RejectedStatus = mappings.RejectedStatus
+	}
+	if mappings.AwaitingReauthorizationStatus != "" {
+		orgConfig.StatusMappings.AwaitingReauthorizationStatus = mappings.AwaitingReauthorizationStatus
+	}
+	if mappings.PartiallyAuthorizedStatus != "" {
+		orgConfig.StatusMappings.PartiallyAuthorizedStatus = mappings.PartiallyAuthorizedStatus
+	}
+	if mappings.SuspendedStatus != "" {
+		orgConfig.StatusMappings.SuspendedStatus = mappings.SuspendedStatus
+	}
+	if overrides.RetentionDays != nil {
+		orgConfig.Purge.RetentionDays = *overrides.RetentionDays
+	}
+	if overrides.DefaultValidity != nil {
+		orgConfig.DefaultValidity = *overrides.DefaultValidity
+	}
+	if len(overrides.AllowedConsentTypes) > 0 {
+		orgConfig.AllowedTypes = overrides.AllowedConsentTypes
+	}
+	if overrides.ExpiryNoticeDays != nil {
+		orgConfig.ExpiryNotification.NoticeDays = *overrides.ExpiryNoticeDays
+	}
+	if overrides.TerminalRetentionDays != nil {
+		orgConfig.Retention.RetentionDays = *overrides.TerminalRetentionDays
+	}
+	return &orgConfig
+}
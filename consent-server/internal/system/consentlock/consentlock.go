@@ -0,0 +1,19 @@
+// Package consentlock carries the consent lock token a caller presents with a request to the
+// services that check consent locks. Callers holding a consent's lock send its token to change the
+// consent while it is locked; everyone else is turned away until the lock is released or expires.
+package consentlock
+
+import "context"
+
+type contextKey struct{}
+
+// WithToken returns a context carrying the lock token presented with a request
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, contextKey{}, token)
+}
+
+// TokenFromContext returns the lock token presented with the request, empty when there is none
+func TokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(contextKey{}).(string)
+	return token
+}
@@ -6,10 +6,23 @@ const (
 	CorrelationIDHeaderName = "X-Correlation-ID"
 	HeaderOrgID             = "org-id"
 	HeaderTPPClientID       = "TPP-client-id"
+	HeaderETag              = "ETag"
+	HeaderIfNoneMatch       = "If-None-Match"
+	HeaderConsentLockToken  = "Consent-Lock-Token"
+	HeaderAuthorization     = "Authorization"
 
 	// Content Types
-	ContentTypeJSON = "application/json"
+	ContentTypeJSON        = "application/json"
+	ContentTypeProblemJSON = "application/problem+json"
 
 	// API Base Path
 	APIBasePath = "/api/v1"
+
+	// ErrorCatalogPath serves the error code catalog; the type of a problem response is this path
+	// followed by the error code
+	ErrorCatalogPath = APIBasePath + "/errors"
+
+	// OpenAPIPath serves the OpenAPI document of the API as JSON, and APIDocsPath a Swagger UI for it
+	OpenAPIPath = APIBasePath + "/openapi.json"
+	APIDocsPath = APIBasePath + "/docs"
 )
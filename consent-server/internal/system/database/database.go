@@ -21,39 +21,92 @@ package database
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/database/migration"
 	"github.com/wso2/consent-management-api/internal/system/log"
 )
 
 // DB holds the database connection.
 type DB struct {
 	*sqlx.DB
+	// Type is the database type queries are selected for, mysql or sqlite
+	Type string
+	// tempFile is the file backing an in-memory SQLite database, removed on close
+	tempFile string
+	// maxIdleConns, connMaxLifetime and connMaxIdleTime are the pool settings, which sql.DBStats
+	// does not report
+	maxIdleConns    int
+	connMaxLifetime time.Duration
+	connMaxIdleTime time.Duration
+}
+
+// PoolStats holds the settings of the connection pool and its gauges
+type PoolStats struct {
+	MaxOpenConnections    int   `json:"maxOpenConnections"`
+	MaxIdleConnections    int   `json:"maxIdleConnections"`
+	ConnMaxLifetimeMillis int64 `json:"connMaxLifetimeMillis"`
+	ConnMaxIdleTimeMillis int64 `json:"connMaxIdleTimeMillis"`
+	// OpenConnections counts the connections in use and idle
+	OpenConnections int `json:"openConnections"`
+	InUse           int `json:"inUse"`
+	Idle            int `json:"idle"`
+	// WaitCount and WaitDurationMillis count the waits for a free connection since the server
+	// started; a growing WaitCount means the pool is too small for the load
+	WaitCount          int64 `json:"waitCount"`
+	WaitDurationMillis int64 `json:"waitDurationMillis"`
+	MaxIdleClosed      int64 `json:"maxIdleClosed"`
+	MaxIdleTimeClosed  int64 `json:"maxIdleTimeClosed"`
+	MaxLifetimeClosed  int64 `json:"maxLifetimeClosed"`
 }
 
 // Initialize creates and initializes the database connection.
 func Initialize(cfg *config.DatabaseConfig) (*DB, error) {
 	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "Database"))
+
+	// An in-memory SQLite database is backed by a temporary file. A real in-memory database is
+	// private to one connection, which would serialize every query behind open transactions.
+	var tempFile string
+	if cfg.IsSQLite() && cfg.Database == config.SQLiteInMemory {
+		file, err := os.CreateTemp("", "consent-*.db")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create in-memory database file: %w", err)
+		}
+		_ = file.Close()
+		tempFile = file.Name()
+		fileCfg := *cfg
+		fileCfg.Database = tempFile
+		cfg = &fileCfg
+	}
 	dsn := cfg.GetDSN()
 
 	logger.Info("Connecting to database...",
+		log.String("type", cfg.GetType()),
 		log.String("hostname", cfg.Hostname),
 		log.Int("port", cfg.Port),
 		log.String("database", cfg.Database))
 
 	// Open database connection
-	db, err := sqlx.Open("mysql", dsn)
+	db, err := sqlx.Open(cfg.GetDriverName(), dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	// Set connection pool settings
-	db.SetMaxOpenConns(cfg.MaxOpenConns)
-	db.SetMaxIdleConns(cfg.MaxIdleConns)
-	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetMaxOpenConns(cfg.GetMaxOpenConns())
+	db.SetMaxIdleConns(cfg.GetMaxIdleConns())
+	db.SetConnMaxLifetime(cfg.GetConnMaxLifetime())
+	db.SetConnMaxIdleTime(cfg.GetConnMaxIdleTime())
+	logger.Debug("Database connection pool configured",
+		log.Int("max_open_conns", cfg.GetMaxOpenConns()),
+		log.Int("max_idle_conns", cfg.GetMaxIdleConns()),
+		log.String("conn_max_lifetime", cfg.GetConnMaxLifetime().String()),
+		log.String("conn_max_idle_time", cfg.GetConnMaxIdleTime().String()))
 
 	// Test the connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -63,9 +116,50 @@ func Initialize(cfg *config.DatabaseConfig) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	// SQLite databases are always brought up to date, since they are created by the server
+	if cfg.IsSQLite() || cfg.MigrateOnStartup {
+		migrator, err := migration.NewMigrator(db.DB, cfg.GetType())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load schema migrations: %w", err)
+		}
+		result, err := migrator.Migrate(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate database schema: %w", err)
+		}
+		logger.Info("Database schema is up to date",
+			log.Int("version", result.TargetVersion),
+			log.Int("applied_migrations", len(result.Migrations)))
+	}
+
 	logger.Info("Successfully connected to database")
 
-	return &DB{DB: db}, nil
+	return &DB{
+		DB:              db,
+		Type:            cfg.GetType(),
+		tempFile:        tempFile,
+		maxIdleConns:    cfg.GetMaxIdleConns(),
+		connMaxLifetime: cfg.GetConnMaxLifetime(),
+		connMaxIdleTime: cfg.GetConnMaxIdleTime(),
+	}, nil
+}
+
+// PoolStats returns the settings and current gauges of the connection pool.
+func (db *DB) PoolStats() PoolStats {
+	stats := db.DB.Stats()
+	return PoolStats{
+		MaxOpenConnections:    stats.MaxOpenConnections,
+		MaxIdleConnections:    db.maxIdleConns,
+		ConnMaxLifetimeMillis: db.connMaxLifetime.Milliseconds(),
+		ConnMaxIdleTimeMillis: db.connMaxIdleTime.Milliseconds(),
+		OpenConnections:       stats.OpenConnections,
+		InUse:                 stats.InUse,
+		Idle:                  stats.Idle,
+		WaitCount:             stats.WaitCount,
+		WaitDurationMillis:    stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:         stats.MaxIdleClosed,
+		MaxIdleTimeClosed:     stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:     stats.MaxLifetimeClosed,
+	}
 }
 
 // Close closes the database connection.
@@ -73,7 +167,13 @@ func (db *DB) Close() error {
 	if db.DB != nil {
 		logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "Database"))
 		logger.Info("Closing database connection...")
-		return db.DB.Close()
+		err := db.DB.Close()
+		if db.tempFile != "" {
+			for _, suffix := range []string{"", "-wal", "-shm"} {
+				_ = os.Remove(db.tempFile + suffix)
+			}
+		}
+		return err
 	}
 	return nil
 }
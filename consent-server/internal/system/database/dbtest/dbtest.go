@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package dbtest provides databases with the server schema for store and service tests. It must
+// only be imported from tests.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	// SQLite driver for the test databases
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/database/migration"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+)
+
+// NewSQLiteDB returns an SQLite database in a temporary directory, migrated to the latest schema
+// and seeded with the given statements. The database is closed when the test ends.
+func NewSQLiteDB(t testing.TB, statements ...string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "consent.db"))
+	if err != nil {
+		t.Fatalf("failed to open the test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := migration.NewMigrator(db, config.DatabaseTypeSQLite)
+	if err != nil {
+		t.Fatalf("failed to create the migrator: %v", err)
+	}
+	if _, err := migrator.Migrate(context.Background(), false); err != nil {
+		t.Fatalf("failed to create the schema: %v", err)
+	}
+
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to prepare the test database: %v", err)
+		}
+	}
+	return db
+}
+
+// NewSQLiteClient returns a database client over NewSQLiteDB
+func NewSQLiteClient(t testing.TB, statements ...string) provider.DBClientInterface {
+	t.Helper()
+	return provider.NewDBClient(NewSQLiteDB(t, statements...), config.DatabaseTypeSQLite)
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package database
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mattn/go-sqlite3"
+)
+
+// MySQL errors raised when a transaction loses a conflict with a concurrent transaction
+const (
+	mysqlErrDeadlock           = 1213
+	mysqlSQLStateSerialization = "40001"
+)
+
+// mysqlErrDuplicateEntry is the MySQL error raised when an insert violates a primary or unique key
+const mysqlErrDuplicateEntry = 1062
+
+// IsRetryable reports whether err is a transient conflict between concurrent transactions, a
+// deadlock or serialization failure on MySQL or a locked database on SQLite. The database rolled
+// the transaction back, so running it again from the start can succeed.
+func IsRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrDeadlock || string(mysqlErr.SQLState[:]) == mysqlSQLStateSerialization
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+	return false
+}
+
+// IsDuplicateKey reports whether err is a primary or unique key violation, as raised when a
+// concurrent transaction inserted the same key first
+func IsDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrDuplicateEntry
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey || sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+	return false
+}
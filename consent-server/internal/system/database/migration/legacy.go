@@ -0,0 +1,136 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// legacyColumn is a column that 0001_initial_schema has but the tables created by the schema
+// scripts of earlier releases may lack. Its definitions are those of 0001 for each database type.
+type legacyColumn struct {
+	table  string
+	column string
+	mysql  string
+	sqlite string
+}
+
+// legacyIndex is an index of 0001_initial_schema the schema scripts of earlier releases may lack.
+// Only MySQL needs them: the SQLite migration creates its indexes with CREATE INDEX IF NOT EXISTS.
+type legacyIndex struct {
+	table   string
+	name    string
+	columns string
+}
+
+// legacyColumns are the columns added to the schema scripts after their tables were first released
+var legacyColumns = []legacyColumn{
+	{"CONSENT", "PARENT_CONSENT_ID", "VARCHAR(255) DEFAULT NULL", "VARCHAR(255) DEFAULT NULL"},
+	{"CONSENT", "VERSION", "INT NOT NULL DEFAULT 1", "INT NOT NULL DEFAULT 1"},
+	{"CONSENT", "ANONYMIZED_TIME", "BIGINT DEFAULT NULL", "BIGINT DEFAULT NULL"},
+	{"CONSENT_PURPOSE_MAPPING", "EXPIRES_AT", "BIGINT DEFAULT NULL", "BIGINT DEFAULT NULL"},
+	{"CONSENT_PURPOSE_MAPPING", "LAST_CONFIRMED_AT", "BIGINT DEFAULT NULL", "BIGINT DEFAULT NULL"},
+	{"ORGANIZATION", "DEFAULT_VALIDITY_SECONDS", "BIGINT DEFAULT NULL", "BIGINT DEFAULT NULL"},
+	{"ORGANIZATION", "ALLOWED_CONSENT_TYPES", "JSON DEFAULT NULL", "TEXT DEFAULT NULL"},
+	{"ORGANIZATION", "EXPIRY_NOTICE_DAYS", "INT DEFAULT NULL", "INT DEFAULT NULL"},
+	{"ORGANIZATION", "TERMINAL_RETENTION_DAYS", "INT DEFAULT NULL", "INT DEFAULT NULL"},
+}
+
+// legacyIndexes are the MySQL indexes added to the schema scripts after their tables were first
+// released
+var legacyIndexes = []legacyIndex{
+	{"CONSENT", "idx_validity_time", "VALIDITY_TIME"},
+	{"CONSENT", "idx_status_updated_time", "CURRENT_STATUS, UPDATED_TIME"},
+	{"CONSENT", "idx_parent_consent_id", "PARENT_CONSENT_ID, ORG_ID"},
+	{"CONSENT_AUTH_RESOURCE", "idx_auth_type", "AUTH_TYPE"},
+	{"CONSENT_STATUS_AUDIT", "idx_action_by", "ACTION_BY, ACTION_TIME"},
+}
+
+// upgradeLegacySchema brings the tables of a database created with the schema scripts of earlier
+// releases up to 0001_initial_schema, whose CREATE TABLE IF NOT EXISTS statements leave existing
+// tables as they are. It only adds what is missing, so a run that failed part way can be repeated.
+func (m *Migrator) upgradeLegacySchema(ctx context.Context, conn *sql.Conn) error {
+	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "Migration"))
+
+	for _, column := range legacyColumns {
+		exists, err := m.count(ctx, conn, tableExistsQuery(m.dbType), column.table)
+		if err != nil {
+			return fmt.Errorf("failed to look up the %s table: %w", column.table, err)
+		}
+		if exists == 0 {
+			// 0001 creates the table with the column
+			continue
+		}
+		exists, err = m.count(ctx, conn, columnExistsQuery(m.dbType), column.table, column.column)
+		if err != nil {
+			return fmt.Errorf("failed to look up column %s.%s: %w", column.table, column.column, err)
+		}
+		if exists > 0 {
+			continue
+		}
+
+		definition := column.mysql
+		if m.dbType == config.DatabaseTypeSQLite {
+			definition = column.sqlite
+		}
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", column.table, column.column, definition)
+		if _, err := conn.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", column.table, column.column, err)
+		}
+		logger.Info("Added a column to a table created by an earlier schema script",
+			log.String("table", column.table), log.String("column", column.column))
+	}
+
+	if m.dbType == config.DatabaseTypeSQLite {
+		return nil
+	}
+	for _, index := range legacyIndexes {
+		exists, err := m.count(ctx, conn, tableExistsQuery(m.dbType), index.table)
+		if err != nil {
+			return fmt.Errorf("failed to look up the %s table: %w", index.table, err)
+		}
+		if exists == 0 {
+			continue
+		}
+		exists, err = m.count(ctx, conn,
+			"SELECT COUNT(*) FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?",
+			index.table, index.name)
+		if err != nil {
+			return fmt.Errorf("failed to look up index %s: %w", index.name, err)
+		}
+		if exists > 0 {
+			continue
+		}
+		query := fmt.Sprintf("CREATE INDEX %s ON %s (%s)", index.name, index.table, index.columns)
+		if _, err := conn.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to create index %s: %w", index.name, err)
+		}
+		logger.Info("Added an index to a table created by an earlier schema script",
+			log.String("table", index.table), log.String("index", index.name))
+	}
+	return nil
+}
+
+// count runs a COUNT(*) query
+func (m *Migrator) count(ctx context.Context, conn *sql.Conn, query string, args ...any) (int, error) {
+	var count int
+	err := conn.QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+func tableExistsQuery(dbType string) string {
+	if dbType == config.DatabaseTypeSQLite {
+		return "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+	}
+	return "SELECT COUNT(*) FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?"
+}
+
+func columnExistsQuery(dbType string) string {
+	if dbType == config.DatabaseTypeSQLite {
+		return "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?"
+	}
+	return "SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?"
+}
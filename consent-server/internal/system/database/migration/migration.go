@@ -0,0 +1,296 @@
+// Package migration applies the versioned schema migrations embedded in the server binary and
+// records the applied versions in the SCHEMA_VERSION table.
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wso2/consent-management-api/dbscripts"
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// migrationLockName is the MySQL named lock held while migrating, so server instances starting
+// together do not apply the same migration twice
+const migrationLockName = "consent_schema_migration"
+
+// migrationLockTimeoutSeconds bounds how long a server waits for another instance's migration
+const migrationLockTimeoutSeconds = 300
+
+// migrationFilePattern matches migration file names such as 0002_add_consent_tags.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_]+)\.sql$`)
+
+// Migration is a schema change, identified by its version
+type Migration struct {
+	Version  int
+	Name     string
+	SQL      string
+	Checksum string
+}
+
+// AppliedMigration is a migration recorded in the SCHEMA_VERSION table
+type AppliedMigration struct {
+	Version     int
+	Name        string
+	Checksum    string
+	AppliedTime int64
+}
+
+// Result reports the migrations a run applied, or would apply on a dry run
+type Result struct {
+	DryRun bool
+	// CurrentVersion is the schema version before the run, 0 for an empty database
+	CurrentVersion int
+	// TargetVersion is the latest version embedded in the server
+	TargetVersion int
+	// Migrations are the migrations applied, or pending on a dry run, in version order
+	Migrations []Migration
+}
+
+// Migrator applies the migrations of one database type to a database
+type Migrator struct {
+	db         *sql.DB
+	dbType     string
+	migrations []Migration
+}
+
+// NewMigrator loads the migrations embedded for the database type
+func NewMigrator(db *sql.DB, dbType string) (*Migrator, error) {
+	migrations, err := Load(dbType)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dbType: dbType, migrations: migrations}, nil
+}
+
+// Load returns the migrations embedded for a database type, in version order
+func Load(dbType string) ([]Migration, error) {
+	dir := path.Join("migrations", dbType)
+	entries, err := fs.ReadDir(dbscripts.Migrations, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for database type '%s': %w", dbType, err)
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	versions := make(map[int]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file '%s' must be named <version>_<description>.sql", entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration file '%s' has an invalid version", entry.Name())
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("migrations '%s' and '%s' have the same version", other, entry.Name())
+		}
+		versions[version] = entry.Name()
+
+		content, err := fs.ReadFile(dbscripts.Migrations, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration '%s': %w", entry.Name(), err)
+		}
+		sum := sha256.Sum256(content)
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     match[2],
+			SQL:      string(content),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrate applies the pending migrations in version order. A dry run only reports them. Applied
+// migrations that were changed since are reported as an error, and nothing is applied.
+//
+// The migrations run to completion even when ctx is cancelled, since MySQL cannot roll back a
+// partly applied schema change.
+func (m *Migrator) Migrate(ctx context.Context, dryRun bool) (*Result, error) {
+	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "Migration"))
+	ctx = context.WithoutCancel(ctx)
+
+	// One connection holds the migration lock for the whole run
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a database connection: %w", err)
+	}
+	defer conn.Close()
+
+	if !dryRun {
+		unlock, err := m.lock(ctx, conn)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+		if _, err := conn.ExecContext(ctx, createVersionTableQuery(m.dbType)); err != nil {
+			return nil, fmt.Errorf("failed to create the SCHEMA_VERSION table: %w", err)
+		}
+	}
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{DryRun: dryRun, Migrations: []Migration{}}
+	if len(m.migrations) > 0 {
+		result.TargetVersion = m.migrations[len(m.migrations)-1].Version
+	}
+	for version := range applied {
+		result.CurrentVersion = max(result.CurrentVersion, version)
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		record, ok := applied[migration.Version]
+		if !ok {
+			pending = append(pending, migration)
+			continue
+		}
+		if record.Checksum != migration.Checksum {
+			return nil, fmt.Errorf("migration %d (%s) was changed after it was applied; add a new migration instead",
+				migration.Version, migration.Name)
+		}
+	}
+
+	if dryRun {
+		result.Migrations = append(result.Migrations, pending...)
+		return result, nil
+	}
+
+	// A database without applied migrations may have been created with the schema scripts of
+	// earlier releases
+	if len(applied) == 0 {
+		if err := m.upgradeLegacySchema(ctx, conn); err != nil {
+			return result, err
+		}
+	}
+
+	for _, migration := range pending {
+		start := time.Now()
+		if err := m.apply(ctx, conn, migration); err != nil {
+			return result, fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+		result.Migrations = append(result.Migrations, migration)
+		logger.Info("Applied schema migration",
+			log.Int("version", migration.Version),
+			log.String("name", migration.Name),
+			log.String("duration", time.Since(start).String()))
+	}
+
+	if len(pending) == 0 {
+		logger.Debug("Database schema is up to date", log.Int("version", result.CurrentVersion))
+	}
+	return result, nil
+}
+
+// applied reads the SCHEMA_VERSION table, which is empty until the first migration runs
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int]AppliedMigration, error) {
+	applied := make(map[int]AppliedMigration)
+
+	count, err := m.count(ctx, conn, tableExistsQuery(m.dbType), "SCHEMA_VERSION")
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the SCHEMA_VERSION table: %w", err)
+	}
+	if count == 0 {
+		return applied, nil
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT VERSION, NAME, CHECKSUM, APPLIED_TIME FROM SCHEMA_VERSION")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the SCHEMA_VERSION table: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record AppliedMigration
+		if err := rows.Scan(&record.Version, &record.Name, &record.Checksum, &record.AppliedTime); err != nil {
+			return nil, fmt.Errorf("failed to read the SCHEMA_VERSION table: %w", err)
+		}
+		applied[record.Version] = record
+	}
+	return applied, rows.Err()
+}
+
+// apply runs a migration and records it. SQLite applies both in one transaction; MySQL commits
+// each schema statement as it runs, so a failed migration can leave part of its changes behind.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	const recordQuery = "INSERT INTO SCHEMA_VERSION (VERSION, NAME, CHECKSUM, APPLIED_TIME) VALUES (?, ?, ?, ?)"
+	appliedTime := time.Now().UnixMilli()
+
+	if m.dbType != config.DatabaseTypeSQLite {
+		if _, err := conn.ExecContext(ctx, migration.SQL); err != nil {
+			return err
+		}
+		_, err := conn.ExecContext(ctx, recordQuery, migration.Version, migration.Name, migration.Checksum, appliedTime)
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, migration.SQL); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, recordQuery, migration.Version, migration.Name, migration.Checksum, appliedTime); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// lock keeps other server instances from migrating the database at the same time. SQLite
+// migrations take the database write lock in their transactions instead.
+func (m *Migrator) lock(ctx context.Context, conn *sql.Conn) (func(), error) {
+	if m.dbType == config.DatabaseTypeSQLite {
+		return func() {}, nil
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", migrationLockName, migrationLockTimeoutSeconds).Scan(&acquired); err != nil {
+		return nil, fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return nil, fmt.Errorf("timed out waiting for another server instance to finish migrating")
+	}
+	return func() {
+		_, _ = conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", migrationLockName)
+	}, nil
+}
+
+func createVersionTableQuery(dbType string) string {
+	if dbType == config.DatabaseTypeSQLite {
+		return `CREATE TABLE IF NOT EXISTS SCHEMA_VERSION (
+  VERSION       INT NOT NULL PRIMARY KEY,
+  NAME          VARCHAR(255) NOT NULL,
+  CHECKSUM      VARCHAR(64) NOT NULL,
+  APPLIED_TIME  BIGINT NOT NULL
+)`
+	}
+	return `CREATE TABLE IF NOT EXISTS SCHEMA_VERSION (
+  VERSION       INT NOT NULL,
+  NAME          VARCHAR(255) NOT NULL,
+  CHECKSUM      VARCHAR(64) NOT NULL,
+  APPLIED_TIME  BIGINT NOT NULL,
+  PRIMARY KEY (VERSION)
+) ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`
+}
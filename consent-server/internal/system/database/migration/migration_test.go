@@ -0,0 +1,234 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "consent.db"))
+	if err != nil {
+		t.Fatalf("failed to open the test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// TestLoad_OrdersEmbeddedMigrations checks that every database type embeds the same versions
+func TestLoad_OrdersEmbeddedMigrations(t *testing.T) {
+	mysql, err := Load(config.DatabaseTypeMySQL)
+	if err != nil {
+		t.Fatalf("failed to load mysql migrations: %v", err)
+	}
+	sqlite, err := Load(config.DatabaseTypeSQLite)
+	if err != nil {
+		t.Fatalf("failed to load sqlite migrations: %v", err)
+	}
+	if len(mysql) == 0 || len(mysql) != len(sqlite) {
+		t.Fatalf("expected the same migrations for both databases, got %d and %d", len(mysql), len(sqlite))
+	}
+	for i := range mysql {
+		if mysql[i].Version != sqlite[i].Version || mysql[i].Name != sqlite[i].Name {
+			t.Errorf("migration %d differs: %d_%s and %d_%s", i,
+				mysql[i].Version, mysql[i].Name, sqlite[i].Version, sqlite[i].Name)
+		}
+		if i > 0 && mysql[i].Version <= mysql[i-1].Version {
+			t.Errorf("migrations are not in version order at %d", mysql[i].Version)
+		}
+	}
+}
+
+// TestMigrate_AppliesPendingMigrationsOnce checks dry runs, a first run and a repeated run
+func TestMigrate_AppliesPendingMigrationsOnce(t *testing.T) {
+	db := openTestDB(t)
+	migrator, err := NewMigrator(db, config.DatabaseTypeSQLite)
+	if err != nil {
+		t.Fatalf("failed to create the migrator: %v", err)
+	}
+	ctx := context.Background()
+
+	dryRun, err := migrator.Migrate(ctx, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if dryRun.CurrentVersion != 0 || len(dryRun.Migrations) == 0 {
+		t.Fatalf("expected pending migrations on an empty database, got version %d with %d pending",
+			dryRun.CurrentVersion, len(dryRun.Migrations))
+	}
+	var tables int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table'").Scan(&tables); err != nil {
+		t.Fatalf("failed to count tables: %v", err)
+	}
+	if tables != 0 {
+		t.Fatalf("expected a dry run to leave the database empty, found %d tables", tables)
+	}
+
+	applied, err := migrator.Migrate(ctx, false)
+	if err != nil {
+		t.Fatalf("migration failed: %v", err)
+	}
+	if len(applied.Migrations) != len(dryRun.Migrations) {
+		t.Errorf("expected %d migrations applied, got %d", len(dryRun.Migrations), len(applied.Migrations))
+	}
+	if _, err := db.Exec("SELECT COUNT(*) FROM CONSENT"); err != nil {
+		t.Errorf("expected the CONSENT table to exist: %v", err)
+	}
+
+	again, err := migrator.Migrate(ctx, false)
+	if err != nil {
+		t.Fatalf("repeated migration failed: %v", err)
+	}
+	if len(again.Migrations) != 0 || again.CurrentVersion != again.TargetVersion {
+		t.Errorf("expected nothing to apply, got %d migrations at version %d of %d",
+			len(again.Migrations), again.CurrentVersion, again.TargetVersion)
+	}
+}
+
+// TestMigrate_RejectsChangedMigrations checks that an applied migration edited afterwards stops the run
+func TestMigrate_RejectsChangedMigrations(t *testing.T) {
+	db := openTestDB(t)
+	migrator, err := NewMigrator(db, config.DatabaseTypeSQLite)
+	if err != nil {
+		t.Fatalf("failed to create the migrator: %v", err)
+	}
+	if _, err := migrator.Migrate(context.Background(), false); err != nil {
+		t.Fatalf("migration failed: %v", err)
+	}
+
+	migrator.migrations[0].Checksum = "changed"
+	if _, err := migrator.Migrate(context.Background(), true); err == nil {
+		t.Error("expected a changed migration to be rejected")
+	}
+}
+
+// TestMigrate_UpgradesSchemaScriptDatabases checks that a database created with the first SQLite
+// schema script the server shipped is brought up to date, keeping its data
+func TestMigrate_UpgradesSchemaScriptDatabases(t *testing.T) {
+	db := openTestDB(t)
+	script, err := os.ReadFile(filepath.Join("testdata", "db_schema_sqlite.sql"))
+	if err != nil {
+		t.Fatalf("failed to read the schema script: %v", err)
+	}
+	for _, statement := range []string{
+		string(script),
+		"INSERT INTO CONSENT (CONSENT_ID, CREATED_TIME, UPDATED_TIME, CLIENT_ID, CONSENT_TYPE, CURRENT_STATUS, ORG_ID) " +
+			"VALUES ('c1', 1000, 1000, 'client-1', 'accounts', 'ACTIVE', 'org-1')",
+		"INSERT INTO CONSENT_PURPOSE (ID, NAME, ORG_ID) VALUES ('p1', 'marketing', 'org-1')",
+		"INSERT INTO CONSENT_PURPOSE_MAPPING (CONSENT_ID, ORG_ID, PURPOSE_ID, IS_USER_APPROVED) VALUES ('c1', 'org-1', 'p1', TRUE)",
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to create the schema script database: %v", err)
+		}
+	}
+
+	migrator, err := NewMigrator(db, config.DatabaseTypeSQLite)
+	if err != nil {
+		t.Fatalf("failed to create the migrator: %v", err)
+	}
+	result, err := migrator.Migrate(context.Background(), false)
+	if err != nil {
+		t.Fatalf("migration failed: %v", err)
+	}
+	if result.CurrentVersion != 0 || len(result.Migrations) != len(migrator.migrations) {
+		t.Errorf("expected every migration to be applied, got %d of %d from version %d",
+			len(result.Migrations), len(migrator.migrations), result.CurrentVersion)
+	}
+
+	var version int
+	var parentID, anonymizedTime, expiresAt, lastConfirmedAt sql.NullInt64
+	if err := db.QueryRow("SELECT c.VERSION, c.PARENT_CONSENT_ID, c.ANONYMIZED_TIME, m.EXPIRES_AT, m.LAST_CONFIRMED_AT "+
+		"FROM CONSENT c JOIN CONSENT_PURPOSE_MAPPING m ON m.CONSENT_ID = c.CONSENT_ID AND m.ORG_ID = c.ORG_ID "+
+		"WHERE c.CONSENT_ID = 'c1'").Scan(&version, &parentID, &anonymizedTime, &expiresAt, &lastConfirmedAt); err != nil {
+		t.Fatalf("expected the upgraded tables to keep their rows: %v", err)
+	}
+	if version != 1 || parentID.Valid || anonymizedTime.Valid || expiresAt.Valid || lastConfirmedAt.Valid {
+		t.Errorf("expected the added columns to hold their defaults, got version %d", version)
+	}
+	for _, column := range legacyColumns {
+		var count int
+		if err := db.QueryRow(columnExistsQuery(config.DatabaseTypeSQLite), column.table, column.column).Scan(&count); err != nil || count != 1 {
+			t.Errorf("expected column %s.%s to exist: %v", column.table, column.column, err)
+		}
+	}
+	var indexes int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_consent_parent_consent_id'").Scan(&indexes); err != nil || indexes != 1 {
+		t.Errorf("expected the indexes of the initial migration to be created: %v", err)
+	}
+}
+
+// TestLegacySchema_CoversMySQLSchemaScript checks that upgrading a database created with the MySQL
+// schema script the server first shipped adds every column and index the initial migration gives
+// the tables of that script
+func TestLegacySchema_CoversMySQLSchemaScript(t *testing.T) {
+	script, err := os.ReadFile(filepath.Join("testdata", "db_schema_mysql.sql"))
+	if err != nil {
+		t.Fatalf("failed to read the schema script: %v", err)
+	}
+	migrations, err := Load(config.DatabaseTypeMySQL)
+	if err != nil {
+		t.Fatalf("failed to load mysql migrations: %v", err)
+	}
+	before := mysqlTables(string(script))
+	after := mysqlTables(migrations[0].SQL)
+
+	added := make(map[string]bool)
+	for _, column := range legacyColumns {
+		added[column.table+"."+column.column] = true
+	}
+	for _, index := range legacyIndexes {
+		added[index.table+"."+index.name] = true
+	}
+	for table, existing := range before {
+		for name := range after[table] {
+			if !existing[name] && !added[table+"."+name] {
+				t.Errorf("upgrading a schema script database does not add %s.%s", table, name)
+			}
+		}
+	}
+}
+
+var (
+	createTablePattern = regexp.MustCompile(`(?i)^CREATE TABLE (?:IF NOT EXISTS )?(\w+)`)
+	indexPattern       = regexp.MustCompile(`(?i)^(?:UNIQUE )?(?:INDEX|KEY) (\w+)`)
+	columnPattern      = regexp.MustCompile(`^([A-Z_]+)\s`)
+)
+
+// mysqlTables returns the columns and inline indexes of the tables a MySQL script creates
+func mysqlTables(script string) map[string]map[string]bool {
+	tables := make(map[string]map[string]bool)
+	var table map[string]bool
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if match := createTablePattern.FindStringSubmatch(line); match != nil {
+			table = make(map[string]bool)
+			tables[match[1]] = table
+			continue
+		}
+		if table == nil {
+			continue
+		}
+		if strings.HasPrefix(line, ")") {
+			table = nil
+			continue
+		}
+		if match := indexPattern.FindStringSubmatch(line); match != nil {
+			table[match[1]] = true
+		} else if match := columnPattern.FindStringSubmatch(line); match != nil {
+			switch match[1] {
+			case "PRIMARY", "CONSTRAINT", "FOREIGN", "REFERENCES", "ON", "UNIQUE":
+			default:
+				table[match[1]] = true
+			}
+		}
+	}
+	return tables
+}
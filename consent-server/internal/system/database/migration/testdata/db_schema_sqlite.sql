@@ -0,0 +1,191 @@
+-- Consent Management API Database Schema (SQLite)
+-- Version: 1.0.0
+-- Description: SQLite variant of db_schema_mysql.sql for local development and tests.
+-- Keep both files in sync. The server applies this schema on start up when the
+-- sqlite database type is configured, so every statement must be idempotent.
+
+PRAGMA foreign_keys = ON;
+
+-- Main consent table
+CREATE TABLE IF NOT EXISTS CONSENT (
+  CONSENT_ID            VARCHAR(255) NOT NULL,
+  CREATED_TIME          BIGINT NOT NULL,
+  UPDATED_TIME          BIGINT NOT NULL,
+  CLIENT_ID             VARCHAR(255) NOT NULL,
+  CONSENT_TYPE          VARCHAR(64) NOT NULL,
+  CURRENT_STATUS        VARCHAR(64) NOT NULL,
+  CONSENT_FREQUENCY     INT DEFAULT NULL,
+  VALIDITY_TIME         BIGINT DEFAULT NULL,
+  RECURRING_INDICATOR   BOOLEAN DEFAULT NULL,
+  DATA_ACCESS_VALIDITY_DURATION BIGINT DEFAULT NULL,
+  VERSION               INT NOT NULL DEFAULT 1,
+  ORG_ID                VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ORG_ID)
+);
+CREATE INDEX IF NOT EXISTS idx_consent_client_id ON CONSENT (CLIENT_ID);
+CREATE INDEX IF NOT EXISTS idx_consent_consent_type ON CONSENT (CONSENT_TYPE);
+CREATE INDEX IF NOT EXISTS idx_consent_current_status ON CONSENT (CURRENT_STATUS);
+CREATE INDEX IF NOT EXISTS idx_consent_created_time ON CONSENT (CREATED_TIME);
+CREATE INDEX IF NOT EXISTS idx_consent_org_id ON CONSENT (ORG_ID);
+
+-- Authorization resource table
+CREATE TABLE IF NOT EXISTS CONSENT_AUTH_RESOURCE (
+  AUTH_ID           VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  AUTH_TYPE         VARCHAR(255) NOT NULL,
+  USER_ID           VARCHAR(255) DEFAULT NULL,
+  AUTH_STATUS       VARCHAR(255) NOT NULL,
+  UPDATED_TIME      BIGINT NOT NULL,
+  RESOURCES         TEXT DEFAULT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (AUTH_ID, ORG_ID),
+  CONSTRAINT FK_CONSENT_AUTH_RESOURCE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_auth_resource_consent_id ON CONSENT_AUTH_RESOURCE (CONSENT_ID);
+CREATE INDEX IF NOT EXISTS idx_auth_resource_user_id ON CONSENT_AUTH_RESOURCE (USER_ID);
+CREATE INDEX IF NOT EXISTS idx_auth_resource_auth_status ON CONSENT_AUTH_RESOURCE (AUTH_STATUS);
+
+-- Status audit table for tracking consent status changes
+CREATE TABLE IF NOT EXISTS CONSENT_STATUS_AUDIT (
+  STATUS_AUDIT_ID   VARCHAR(255) NOT NULL,
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  CURRENT_STATUS    VARCHAR(64) NOT NULL,
+  ACTION_TIME       BIGINT NOT NULL,
+  REASON            TEXT DEFAULT NULL,
+  ACTION_BY         VARCHAR(255) DEFAULT NULL,
+  PREVIOUS_STATUS   VARCHAR(64) DEFAULT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (STATUS_AUDIT_ID, ORG_ID),
+  CONSTRAINT FK_CONSENT_STATUS_AUDIT
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_status_audit_consent_id ON CONSENT_STATUS_AUDIT (CONSENT_ID);
+CREATE INDEX IF NOT EXISTS idx_status_audit_action_time ON CONSENT_STATUS_AUDIT (ACTION_TIME);
+
+-- Consent attributes table for key-value pairs
+CREATE TABLE IF NOT EXISTS CONSENT_ATTRIBUTE (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  ATT_KEY           VARCHAR(255) NOT NULL,
+  ATT_VALUE         TEXT NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, ATT_KEY, ORG_ID),
+  CONSTRAINT FK_CONSENT_ATTRIBUTE
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_attribute_att_key ON CONSENT_ATTRIBUTE (ATT_KEY);
+
+-- Consent history table holding a snapshot of every superseded consent version
+CREATE TABLE IF NOT EXISTS CONSENT_HISTORY (
+  CONSENT_ID        VARCHAR(255) NOT NULL,
+  VERSION           INT NOT NULL,
+  SNAPSHOT          TEXT NOT NULL,
+  AMENDED_TIME      BIGINT NOT NULL,
+  AMENDED_BY        VARCHAR(255) DEFAULT NULL,
+  REASON            TEXT DEFAULT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (CONSENT_ID, VERSION, ORG_ID),
+  CONSTRAINT FK_CONSENT_HISTORY
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_history_amended_time ON CONSENT_HISTORY (AMENDED_TIME);
+
+-- Consent purpose table
+CREATE TABLE IF NOT EXISTS CONSENT_PURPOSE (
+  ID            VARCHAR(255) NOT NULL,
+  NAME          VARCHAR(255) NOT NULL,
+  DESCRIPTION   VARCHAR(1024) DEFAULT NULL,
+  TYPE          VARCHAR(64) NOT NULL DEFAULT 'string',
+  ORG_ID        VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (ID, ORG_ID),
+  CONSTRAINT unique_name_per_org UNIQUE (NAME, ORG_ID)
+);
+CREATE INDEX IF NOT EXISTS idx_purpose_name ON CONSENT_PURPOSE (NAME);
+CREATE INDEX IF NOT EXISTS idx_purpose_org_id ON CONSENT_PURPOSE (ORG_ID);
+CREATE INDEX IF NOT EXISTS idx_purpose_type ON CONSENT_PURPOSE (TYPE);
+
+-- Mapping table to link consent with purposes
+CREATE TABLE IF NOT EXISTS CONSENT_PURPOSE_MAPPING (
+  CONSENT_ID       VARCHAR(255) NOT NULL,
+  ORG_ID           VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PURPOSE_ID       VARCHAR(255) NOT NULL,
+  VALUE            TEXT DEFAULT NULL,
+  IS_USER_APPROVED BOOLEAN DEFAULT FALSE,
+  IS_MANDATORY     BOOLEAN NOT NULL DEFAULT TRUE,
+  PRIMARY KEY (CONSENT_ID, ORG_ID, PURPOSE_ID),
+  CONSTRAINT FK_CONSENT_PURPOSE_MAPPING_CONSENT
+    FOREIGN KEY (CONSENT_ID, ORG_ID)
+    REFERENCES CONSENT (CONSENT_ID, ORG_ID)
+    ON DELETE CASCADE,
+  CONSTRAINT FK_CONSENT_PURPOSE_MAPPING_PURPOSE
+    FOREIGN KEY (PURPOSE_ID, ORG_ID)
+    REFERENCES CONSENT_PURPOSE (ID, ORG_ID)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_mapping_consent_id ON CONSENT_PURPOSE_MAPPING (CONSENT_ID);
+CREATE INDEX IF NOT EXISTS idx_mapping_purpose_id ON CONSENT_PURPOSE_MAPPING (PURPOSE_ID);
+CREATE INDEX IF NOT EXISTS idx_mapping_is_user_approved ON CONSENT_PURPOSE_MAPPING (IS_USER_APPROVED);
+CREATE INDEX IF NOT EXISTS idx_mapping_is_mandatory ON CONSENT_PURPOSE_MAPPING (IS_MANDATORY);
+CREATE INDEX IF NOT EXISTS idx_mapping_purpose_approved ON CONSENT_PURPOSE_MAPPING (PURPOSE_ID, IS_USER_APPROVED);
+
+-- Attributes for consent purposes (key/value pairs scoped to purpose + org)
+CREATE TABLE IF NOT EXISTS CONSENT_PURPOSE_ATTRIBUTE (
+  PURPOSE_ID       VARCHAR(255) NOT NULL,
+  ATT_KEY          VARCHAR(255) NOT NULL,
+  ATT_VALUE        TEXT NOT NULL,
+  ORG_ID           VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  PRIMARY KEY (PURPOSE_ID, ATT_KEY, ORG_ID),
+  CONSTRAINT FK_CONSENT_PURPOSE_ATTRIBUTE_PURPOSE
+    FOREIGN KEY (PURPOSE_ID, ORG_ID)
+    REFERENCES CONSENT_PURPOSE (ID, ORG_ID)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_att_key_purpose ON CONSENT_PURPOSE_ATTRIBUTE (ATT_KEY);
+
+-- Scheduled consent export jobs
+CREATE TABLE IF NOT EXISTS EXPORT_JOB (
+  JOB_ID            VARCHAR(255) NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  NAME              VARCHAR(255) NOT NULL,
+  FILTER            TEXT DEFAULT NULL,
+  FORMAT            VARCHAR(16) NOT NULL,
+  DESTINATION       TEXT NOT NULL,
+  ENCRYPTION_KEY_ID VARCHAR(255) DEFAULT NULL,
+  INTERVAL_SECONDS  BIGINT NOT NULL,
+  ENABLED           BOOLEAN NOT NULL DEFAULT TRUE,
+  NEXT_RUN_TIME     BIGINT NOT NULL,
+  LAST_RUN_TIME     BIGINT DEFAULT NULL,
+  CREATED_TIME      BIGINT NOT NULL,
+  UPDATED_TIME      BIGINT NOT NULL,
+  PRIMARY KEY (JOB_ID, ORG_ID),
+  CONSTRAINT unique_export_job_name_per_org UNIQUE (NAME, ORG_ID)
+);
+CREATE INDEX IF NOT EXISTS idx_export_next_run ON EXPORT_JOB (ENABLED, NEXT_RUN_TIME);
+
+-- Delivery receipts recorded for every export job run
+CREATE TABLE IF NOT EXISTS EXPORT_DELIVERY_RECEIPT (
+  RECEIPT_ID        VARCHAR(255) NOT NULL,
+  JOB_ID            VARCHAR(255) NOT NULL,
+  ORG_ID            VARCHAR(255) DEFAULT 'DEFAULT_ORG',
+  STATUS            VARCHAR(32) NOT NULL,
+  STARTED_TIME      BIGINT NOT NULL,
+  COMPLETED_TIME    BIGINT NOT NULL,
+  RECORD_COUNT      INT NOT NULL DEFAULT 0,
+  OBJECT_LOCATION   VARCHAR(1024) DEFAULT NULL,
+  CHECKSUM          VARCHAR(128) DEFAULT NULL,
+  ERROR_MESSAGE     TEXT DEFAULT NULL,
+  PRIMARY KEY (RECEIPT_ID, ORG_ID),
+  CONSTRAINT FK_EXPORT_DELIVERY_RECEIPT_JOB
+    FOREIGN KEY (JOB_ID, ORG_ID)
+    REFERENCES EXPORT_JOB (JOB_ID, ORG_ID)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_receipt_job_id ON EXPORT_DELIVERY_RECEIPT (JOB_ID, STARTED_TIME);
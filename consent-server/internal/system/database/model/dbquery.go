@@ -37,6 +37,9 @@ type DBQuery struct {
 	PostgresQuery string `json:"postgres_query,omitempty"`
 	// SQLiteQuery is the SQLite-specific query variant.
 	SQLiteQuery string `json:"sqlite_query,omitempty"`
+	// CrossTenant marks a query that intentionally spans organizations, such as an admin search
+	// or a background job. Such queries are exempt from the tenancy guard.
+	CrossTenant bool `json:"cross_tenant,omitempty"`
 }
 
 // GetID returns the unique identifier for the query.
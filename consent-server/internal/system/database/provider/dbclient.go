@@ -20,7 +20,9 @@
 package provider
 
 import (
+	"context"
 	"strings"
+	"time"
 
 	"github.com/wso2/consent-management-api/internal/system/database/model"
 	"github.com/wso2/consent-management-api/internal/system/log"
@@ -30,10 +32,16 @@ import (
 type DBClientInterface interface {
 	// Query executes a sql query that returns rows, typically a SELECT, and returns the result as a slice of maps.
 	Query(query model.DBQuery, args ...interface{}) ([]map[string]interface{}, error)
+	// QueryContext is Query aborted when the context is cancelled, such as when a request times out.
+	QueryContext(ctx context.Context, query model.DBQuery, args ...interface{}) ([]map[string]interface{}, error)
 	// Execute executes a sql query without returning data in any rows, and returns number of rows affected.
 	Execute(query model.DBQuery, args ...interface{}) (int64, error)
+	// ExecuteContext is Execute aborted when the context is cancelled.
+	ExecuteContext(ctx context.Context, query model.DBQuery, args ...interface{}) (int64, error)
 	// BeginTx starts a new database transaction.
 	BeginTx() (model.TxInterface, error)
+	// BeginTxContext starts a transaction that is rolled back when the context is cancelled.
+	BeginTxContext(ctx context.Context) (model.TxInterface, error)
 }
 
 // DBClient is the implementation of DBClientInterface.
@@ -52,11 +60,21 @@ func NewDBClient(db model.DBInterface, dbType string) DBClientInterface {
 
 // Query executes a sql query that returns rows, typically a SELECT, and returns the result as a slice of maps.
 func (client *DBClient) Query(query model.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	return client.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext executes a sql query that returns rows, aborting it when the context is cancelled or
+// the query timeout passes.
+func (client *DBClient) QueryContext(ctx context.Context, query model.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
 	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "DBClient"))
 	logger.Debug("Executing query", log.String("query_id", query.GetID()))
 
 	sqlQuery := query.GetQuery(client.dbType)
-	rows, err := client.db.Query(sqlQuery, args...)
+	statementCtx, cancel := statementContext(ctx)
+	defer cancel()
+	defer observeStatement(ctx, query.GetID(), sqlQuery, args, time.Now())
+
+	rows, err := client.db.QueryContext(statementCtx, sqlQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -101,11 +119,21 @@ func (client *DBClient) Query(query model.DBQuery, args ...interface{}) ([]map[s
 
 // Execute executes a sql query without returning data in any rows, and returns number of rows affected.
 func (client *DBClient) Execute(query model.DBQuery, args ...interface{}) (int64, error) {
+	return client.ExecuteContext(context.Background(), query, args...)
+}
+
+// ExecuteContext executes a sql query without returning rows, aborting it when the context is
+// cancelled or the query timeout passes.
+func (client *DBClient) ExecuteContext(ctx context.Context, query model.DBQuery, args ...interface{}) (int64, error) {
 	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "DBClient"))
 	logger.Debug("Executing query", log.String("query_id", query.GetID()))
 
 	sqlQuery := query.GetQuery(client.dbType)
-	res, err := client.db.Exec(sqlQuery, args...)
+	statementCtx, cancel := statementContext(ctx)
+	defer cancel()
+	defer observeStatement(ctx, query.GetID(), sqlQuery, args, time.Now())
+
+	res, err := client.db.ExecContext(statementCtx, sqlQuery, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -126,3 +154,13 @@ func (client *DBClient) BeginTx() (model.TxInterface, error) {
 	}
 	return model.NewTx(tx), nil
 }
+
+// BeginTxContext starts a database transaction that is rolled back when the context is cancelled.
+// Its statements are bounded by the query timeout and observed by the slow query log.
+func (client *DBClient) BeginTxContext(ctx context.Context) (model.TxInterface, error) {
+	tx, err := client.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &contextTx{tx: tx, ctx: ctx}, nil
+}
@@ -24,12 +24,15 @@ import (
 	"sync"
 
 	"github.com/wso2/consent-management-api/internal/system/database"
+	"github.com/wso2/consent-management-api/internal/system/database/migration"
 	"github.com/wso2/consent-management-api/internal/system/log"
 )
 
 // DBProviderInterface defines the interface for getting database clients.
 type DBProviderInterface interface {
 	GetConsentDBClient() (DBClientInterface, error)
+	GetConsentMigrator() (*migration.Migrator, error)
+	GetConsentPoolStats() database.PoolStats
 }
 
 // DBProviderCloser is a separate interface for closing the provider.
@@ -99,6 +102,16 @@ func (d *dbProvider) GetConsentDBClient() (DBClientInterface, error) {
 	return d.consentClient, nil
 }
 
+// GetConsentMigrator returns the schema migrator of the consent datasource.
+func (d *dbProvider) GetConsentMigrator() (*migration.Migrator, error) {
+	return migration.NewMigrator(d.db.DB.DB, d.db.Type)
+}
+
+// GetConsentPoolStats returns the connection pool gauges of the consent datasource.
+func (d *dbProvider) GetConsentPoolStats() database.PoolStats {
+	return d.db.PoolStats()
+}
+
 // initializeClient initializes the database client.
 func (d *dbProvider) initializeClient() {
 	d.consentMutex.Lock()
@@ -111,7 +124,7 @@ func (d *dbProvider) initializeClient() {
 		return
 	}
 
-	d.consentClient = NewDBClient(d.db.DB, "mysql")
+	d.consentClient = NewTenantGuardClient(NewDBClient(d.db.DB, d.db.Type))
 	logger.Debug("Consent DB client initialized")
 }
 
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// txQueryID labels statements run in a transaction, which are passed as plain SQL
+const txQueryID = "tx"
+
+// maxLoggedStatementLength truncates the transaction statements written to the slow query log
+const maxLoggedStatementLength = 120
+
+var (
+	// valuesPattern captures the value list of an INSERT
+	valuesPattern = regexp.MustCompile(`\bVALUES\s*\(([^)]*)\)`)
+	// consentPredicatePattern matches a consent predicate with a bound value, like orgPredicatePattern
+	consentPredicatePattern = regexp.MustCompile(`\b(?:\w+\.)?CONSENT_ID\s*(?:=|IN\s*\()\s*\?`)
+)
+
+// statementContext bounds a statement by the configured query timeout, on top of the deadline of
+// the context it runs for
+func statementContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	cfg := config.Get()
+	if cfg == nil || cfg.Database.Consent.QueryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, cfg.Database.Consent.QueryTimeout)
+}
+
+// observeStatement logs a statement that ran longer than the slow query threshold, with the
+// consent and organization it was bound to, so the queries that stall can be found
+func observeStatement(ctx context.Context, queryID, query string, args []interface{}, start time.Time) {
+	threshold := time.Second
+	if cfg := config.Get(); cfg != nil {
+		threshold = cfg.Database.Consent.GetSlowQueryThreshold()
+	}
+	elapsed := time.Since(start)
+	if elapsed < threshold {
+		return
+	}
+
+	statement := strings.ToUpper(strings.Join(strings.Fields(query), " "))
+	fields := []log.Field{
+		log.String("query_id", queryID),
+		log.Int("duration_ms", int(elapsed.Milliseconds())),
+		log.String("consent_id", boundValue(statement, "CONSENT_ID", consentPredicatePattern, args)),
+		log.String("org_id", boundValue(statement, "ORG_ID", orgPredicatePattern, args)),
+	}
+	if queryID == txQueryID {
+		if len(statement) > maxLoggedStatementLength {
+			statement = statement[:maxLoggedStatementLength] + "..."
+		}
+		fields = append(fields, log.String("statement", statement))
+	}
+	logger := log.GetLogger().WithContext(ctx).With(log.String(log.LoggerKeyComponentName, "DBClient"))
+	logger.Warn("Slow query", fields...)
+}
+
+// boundValue returns the argument bound to column in the statement, which must be upper case with
+// single spaces, or an empty string when the column is not bound to a placeholder
+func boundValue(statement, column string, predicate *regexp.Regexp, args []interface{}) string {
+	index := -1
+	if columns := insertColumnsPattern.FindStringSubmatch(statement); columns != nil {
+		values := valuesPattern.FindStringSubmatch(statement)
+		if values == nil {
+			return ""
+		}
+		names, placeholders := strings.Split(columns[1], ","), strings.Split(values[1], ",")
+		for i, name := range names {
+			if strings.TrimSpace(name) == column && i < len(placeholders) && strings.TrimSpace(placeholders[i]) == "?" {
+				index = strings.Count(strings.Join(placeholders[:i], ","), "?")
+				break
+			}
+		}
+	} else if loc := predicate.FindStringIndex(statement); loc != nil {
+		index = strings.Count(statement[:loc[1]-1], "?")
+	}
+
+	if index < 0 || index >= len(args) {
+		return ""
+	}
+	switch value := args[index].(type) {
+	case []byte:
+		return string(value)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// contextTx runs the statements of a transaction under the context the transaction was started
+// with, each bounded by the query timeout and observed by the slow query log
+type contextTx struct {
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+// Exec runs a statement in the transaction.
+func (t *contextTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := statementContext(t.ctx)
+	defer cancel()
+	defer observeStatement(t.ctx, txQueryID, query, args, time.Now())
+	return t.tx.ExecContext(ctx, query, args...)
+}
+
+// Query runs a statement that returns rows in the transaction. The rows are read after it returns,
+// so the statement is bounded by the transaction's context only.
+func (t *contextTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer observeStatement(t.ctx, txQueryID, query, args, time.Now())
+	return t.tx.QueryContext(t.ctx, query, args...)
+}
+
+// Commit commits the transaction.
+func (t *contextTx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback rolls the transaction back.
+func (t *contextTx) Rollback() error {
+	return t.tx.Rollback()
+}
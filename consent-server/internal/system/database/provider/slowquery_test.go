@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestBoundValue_FindsConsentAndOrganization checks that the slow query log finds the consent and
+// organization a statement is bound to
+func TestBoundValue_FindsConsentAndOrganization(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		args      []interface{}
+		consentID string
+		orgID     string
+	}{
+		{
+			name:      "select with both predicates",
+			query:     "SELECT * FROM CONSENT WHERE CONSENT_ID = ? AND ORG_ID = ?",
+			args:      []interface{}{"c-1", "org-1"},
+			consentID: "c-1",
+			orgID:     "org-1",
+		},
+		{
+			name:      "predicates through aliases after other placeholders",
+			query:     "SELECT * FROM CONSENT c JOIN CONSENT_ATTRIBUTE ca ON c.CONSENT_ID = ca.CONSENT_ID WHERE ca.ATT_KEY = ? AND c.CONSENT_ID IN (?, ?) AND c.ORG_ID = ?",
+			args:      []interface{}{"key", "c-1", "c-2", []byte("org-1")},
+			consentID: "c-1",
+			orgID:     "org-1",
+		},
+		{
+			name:      "insert with a literal value",
+			query:     "INSERT INTO CONSENT_TAG (CONSENT_ID, TAG, CREATED_TIME, ORG_ID) VALUES (?, ?, 0, ?)",
+			args:      []interface{}{"c-1", "tag", "org-1"},
+			consentID: "c-1",
+			orgID:     "org-1",
+		},
+		{
+			name:  "organization only",
+			query: "UPDATE CONSENT SET CURRENT_STATUS = ? WHERE UPDATED_TIME < ? AND ORG_ID = ?",
+			args:  []interface{}{"EXPIRED", 100, "org-1"},
+			orgID: "org-1",
+		},
+		{
+			name:  "missing arguments",
+			query: "SELECT * FROM CONSENT WHERE CONSENT_ID = ? AND ORG_ID = ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement := strings.ToUpper(strings.Join(strings.Fields(tt.query), " "))
+			if got := boundValue(statement, "CONSENT_ID", consentPredicatePattern, tt.args); got != tt.consentID {
+				t.Errorf("expected consent '%s', got '%s'", tt.consentID, got)
+			}
+			if got := boundValue(statement, "ORG_ID", orgPredicatePattern, tt.args); got != tt.orgID {
+				t.Errorf("expected organization '%s', got '%s'", tt.orgID, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// ErrMissingTenantFilter is returned when a statement on a tenant table does not bind an organization.
+var ErrMissingTenantFilter = errors.New("query is not scoped to an organization")
+
+// globalTables are the tables that are not owned by an organization
+var globalTables = map[string]bool{
+	"ORGANIZATION": true,
+}
+
+var (
+	// tablePattern captures the tables a statement reads or writes
+	tablePattern = regexp.MustCompile(`\b(?:FROM|JOIN|INTO|UPDATE)\s+([A-Z_][A-Z0-9_]*)`)
+	// insertColumnsPattern captures the column list of an INSERT
+	insertColumnsPattern = regexp.MustCompile(`^INSERT\s+(?:IGNORE\s+|OR\s+\w+\s+)?INTO\s+\w+\s*\(([^)]*)\)`)
+	// orgPredicatePattern matches an organization predicate with a bound value, e.g. "c.ORG_ID = ?"
+	orgPredicatePattern = regexp.MustCompile(`\b(?:\w+\.)?ORG_ID\s*(?:=|IN\s*\()\s*\?`)
+)
+
+// tenantGuardClient wraps a DBClientInterface and rejects statements on tenant tables that are not
+// scoped to an organization, so a query that forgets its ORG_ID filter fails fast instead of
+// reading or changing the data of another organization.
+type tenantGuardClient struct {
+	client DBClientInterface
+}
+
+// NewTenantGuardClient wraps the client with the tenancy guard. Queries marked CrossTenant bypass it.
+func NewTenantGuardClient(client DBClientInterface) DBClientInterface {
+	return &tenantGuardClient{
+		client: client,
+	}
+}
+
+// Query checks the tenancy of the query and runs it on the wrapped client.
+func (g *tenantGuardClient) Query(query model.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	if err := checkDBQueryTenancy(query, args); err != nil {
+		return nil, err
+	}
+	return g.client.Query(query, args...)
+}
+
+// QueryContext checks the tenancy of the query and runs it on the wrapped client.
+func (g *tenantGuardClient) QueryContext(ctx context.Context, query model.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	if err := checkDBQueryTenancy(query, args); err != nil {
+		return nil, err
+	}
+	return g.client.QueryContext(ctx, query, args...)
+}
+
+// Execute checks the tenancy of the query and runs it on the wrapped client.
+func (g *tenantGuardClient) Execute(query model.DBQuery, args ...interface{}) (int64, error) {
+	if err := checkDBQueryTenancy(query, args); err != nil {
+		return 0, err
+	}
+	return g.client.Execute(query, args...)
+}
+
+// ExecuteContext checks the tenancy of the query and runs it on the wrapped client.
+func (g *tenantGuardClient) ExecuteContext(ctx context.Context, query model.DBQuery, args ...interface{}) (int64, error) {
+	if err := checkDBQueryTenancy(query, args); err != nil {
+		return 0, err
+	}
+	return g.client.ExecuteContext(ctx, query, args...)
+}
+
+// BeginTx starts a transaction whose statements are checked by the tenancy guard.
+func (g *tenantGuardClient) BeginTx() (model.TxInterface, error) {
+	tx, err := g.client.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	return &tenantGuardTx{tx: tx}, nil
+}
+
+// BeginTxContext starts a transaction whose statements are checked by the tenancy guard.
+func (g *tenantGuardClient) BeginTxContext(ctx context.Context) (model.TxInterface, error) {
+	tx, err := g.client.BeginTxContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tenantGuardTx{tx: tx}, nil
+}
+
+// tenantGuardTx applies the tenancy guard to the statements of a transaction
+type tenantGuardTx struct {
+	tx model.TxInterface
+}
+
+// Exec checks the tenancy of the statement and runs it in the transaction.
+func (t *tenantGuardTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if err := checkTenancy("tx", query, args); err != nil {
+		return nil, err
+	}
+	return t.tx.Exec(query, args...)
+}
+
+// Query checks the tenancy of the statement and runs it in the transaction.
+func (t *tenantGuardTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if err := checkTenancy("tx", query, args); err != nil {
+		return nil, err
+	}
+	return t.tx.Query(query, args...)
+}
+
+// Commit commits the transaction.
+func (t *tenantGuardTx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback rolls the transaction back.
+func (t *tenantGuardTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// checkDBQueryTenancy checks every database-specific variant of the query
+func checkDBQueryTenancy(query model.DBQuery, args []interface{}) error {
+	if query.CrossTenant {
+		return nil
+	}
+	for _, variant := range []string{query.Query, query.PostgresQuery, query.SQLiteQuery} {
+		if variant == "" {
+			continue
+		}
+		if err := checkTenancy(query.ID, variant, args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTenancy verifies that a statement on a tenant table is scoped to an organization. An INSERT
+// must set ORG_ID; a SELECT, UPDATE or DELETE must filter on ORG_ID with a non-empty bound value.
+// Other statements, such as DDL, are not checked.
+func checkTenancy(queryID, query string, args []interface{}) error {
+	statement := strings.ToUpper(strings.Join(strings.Fields(query), " "))
+
+	if !touchesTenantTable(statement) {
+		return nil
+	}
+
+	var err error
+	switch {
+	case strings.HasPrefix(statement, "INSERT"):
+		err = checkInsertTenancy(statement)
+	case strings.HasPrefix(statement, "SELECT"), strings.HasPrefix(statement, "UPDATE"),
+		strings.HasPrefix(statement, "DELETE"):
+		err = checkPredicateTenancy(statement, args)
+	}
+
+	if err != nil {
+		logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "TenantGuard"))
+		logger.Error("Rejected query that is not scoped to an organization",
+			log.String("query_id", queryID), log.Error(err))
+		return fmt.Errorf("%s: %w", queryID, err)
+	}
+	return nil
+}
+
+// touchesTenantTable reports whether the statement references a table owned by an organization
+func touchesTenantTable(statement string) bool {
+	for _, match := range tablePattern.FindAllStringSubmatch(statement, -1) {
+		if !globalTables[match[1]] {
+			return true
+		}
+	}
+	return false
+}
+
+// checkInsertTenancy verifies that an INSERT sets ORG_ID
+func checkInsertTenancy(statement string) error {
+	match := insertColumnsPattern.FindStringSubmatch(statement)
+	if match == nil {
+		return fmt.Errorf("%w: INSERT must name its columns", ErrMissingTenantFilter)
+	}
+	for _, column := range strings.Split(match[1], ",") {
+		if strings.TrimSpace(column) == "ORG_ID" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: INSERT does not set ORG_ID", ErrMissingTenantFilter)
+}
+
+// checkPredicateTenancy verifies that the top-level WHERE clause requires an ORG_ID predicate and
+// that the bound value is a non-empty organization. The predicate must be one of the top-level AND
+// conjuncts of the clause: a predicate inside parentheses, in a subquery or next to a top-level OR
+// does not restrict every row the statement touches. A statement that only reads a derived table,
+// such as a count over a subquery, is checked through the subquery.
+func checkPredicateTenancy(statement string, args []interface{}) error {
+	whereStart, whereEnd := topLevelWhere(statement)
+	if whereStart < 0 {
+		if derivedStart, derivedEnd := derivedTable(statement); derivedStart >= 0 {
+			skipped := strings.Count(statement[:derivedStart], "?")
+			if skipped > len(args) {
+				skipped = len(args)
+			}
+			return checkPredicateTenancy(statement[derivedStart:derivedEnd], args[skipped:])
+		}
+		return fmt.Errorf("%w: statement has no WHERE clause", ErrMissingTenantFilter)
+	}
+
+	clause := statement[whereStart:whereEnd]
+	if len(splitTopLevel(clause, " OR ")) > 1 {
+		return fmt.Errorf("%w: WHERE clause combines conditions with a top-level OR", ErrMissingTenantFilter)
+	}
+
+	for _, conjunct := range splitTopLevel(clause, " AND ") {
+		loc := orgPredicatePattern.FindStringIndex(clause[conjunct.start:conjunct.end])
+		if loc == nil || loc[0] != 0 {
+			continue
+		}
+		// The predicate's placeholder is the last "?" of the match
+		argIndex := strings.Count(statement[:whereStart+conjunct.start+loc[1]], "?") - 1
+		if argIndex >= len(args) {
+			return fmt.Errorf("%w: ORG_ID is not bound", ErrMissingTenantFilter)
+		}
+		if !isOrganization(args[argIndex]) {
+			return fmt.Errorf("%w: ORG_ID is bound to an empty value", ErrMissingTenantFilter)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: WHERE clause does not filter on ORG_ID at the top level", ErrMissingTenantFilter)
+}
+
+// clauseEnds are the keywords that end a top-level WHERE clause
+var clauseEnds = []string{" GROUP BY ", " HAVING ", " ORDER BY ", " LIMIT ", " OFFSET ", " UNION ", " FOR UPDATE",
+	" RETURNING "}
+
+// topLevelWhere returns the bounds of the conditions of the top-level WHERE clause of a normalized
+// statement, or -1 when it has none. WHERE clauses of subqueries are skipped.
+func topLevelWhere(statement string) (int, int) {
+	start := -1
+	for _, i := range topLevelOffsets(statement) {
+		rest := statement[i:]
+		if start < 0 {
+			if strings.HasPrefix(rest, " WHERE ") {
+				start = i + len(" WHERE ")
+			}
+			continue
+		}
+		for _, end := range clauseEnds {
+			if strings.HasPrefix(rest, end) {
+				return start, i
+			}
+		}
+	}
+	if start < 0 {
+		return -1, -1
+	}
+	return start, len(statement)
+}
+
+// derivedTable returns the bounds of the subquery of a statement that reads a single derived
+// table, as in "SELECT COUNT(*) FROM (SELECT ...) t", or -1 when the statement reads anything else
+func derivedTable(statement string) (int, int) {
+	offsets := topLevelOffsets(statement)
+	for _, i := range offsets {
+		if strings.HasPrefix(statement[i:], " JOIN ") {
+			return -1, -1
+		}
+	}
+	for _, i := range offsets {
+		if !strings.HasPrefix(statement[i:], " FROM (") {
+			continue
+		}
+		start := i + len(" FROM (")
+		depth := 1
+		for end := start; end < len(statement); end++ {
+			switch statement[end] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					return start, end
+				}
+			}
+		}
+	}
+	return -1, -1
+}
+
+// span is a part of a clause
+type span struct {
+	start, end int
+}
+
+// splitTopLevel splits a clause at a separator that is neither inside parentheses nor in a string
+// literal
+func splitTopLevel(clause, separator string) []span {
+	spans := make([]span, 0, 1)
+	start := 0
+	for _, i := range topLevelOffsets(clause) {
+		if i >= start && strings.HasPrefix(clause[i:], separator) {
+			spans = append(spans, span{start: start, end: i})
+			start = i + len(separator)
+		}
+	}
+	return append(spans, span{start: start, end: len(clause)})
+}
+
+// topLevelOffsets returns the offsets of a statement that are neither inside parentheses nor in a
+// string literal
+func topLevelOffsets(statement string) []int {
+	offsets := make([]int, 0, len(statement))
+	depth := 0
+	inLiteral := false
+	for i := 0; i < len(statement); i++ {
+		switch c := statement[i]; {
+		case c == '\'':
+			inLiteral = !inLiteral
+		case inLiteral:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0:
+			offsets = append(offsets, i)
+		}
+	}
+	return offsets
+}
+
+// isOrganization reports whether a bound value identifies an organization
+func isOrganization(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v) != ""
+	case []byte:
+		return len(strings.TrimSpace(string(v))) > 0
+	case *string:
+		return v != nil && strings.TrimSpace(*v) != ""
+	default:
+		return false
+	}
+}
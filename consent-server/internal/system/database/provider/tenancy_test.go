@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/system/database/model"
+)
+
+// recordingDBClient counts the queries that reach the database without executing them
+type recordingDBClient struct {
+	executed int
+}
+
+func (c *recordingDBClient) Query(query model.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	c.executed++
+	return []map[string]interface{}{}, nil
+}
+
+func (c *recordingDBClient) QueryContext(ctx context.Context, query model.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	return c.Query(query, args...)
+}
+
+func (c *recordingDBClient) Execute(query model.DBQuery, args ...interface{}) (int64, error) {
+	c.executed++
+	return 0, nil
+}
+
+func (c *recordingDBClient) ExecuteContext(ctx context.Context, query model.DBQuery, args ...interface{}) (int64, error) {
+	return c.Execute(query, args...)
+}
+
+func (c *recordingDBClient) BeginTxContext(ctx context.Context) (model.TxInterface, error) {
+	return c.BeginTx()
+}
+
+func (c *recordingDBClient) BeginTx() (model.TxInterface, error) {
+	return nil, nil
+}
+
+// TestTenantGuard_RejectsUnscopedQueries checks that statements on tenant tables only reach the
+// database when they are bound to an organization
+func TestTenantGuard_RejectsUnscopedQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   model.DBQuery
+		args    []interface{}
+		allowed bool
+	}{
+		{
+			name:    "select scoped to an organization",
+			query:   model.DBQuery{ID: "GET", Query: "SELECT * FROM CONSENT WHERE CONSENT_ID = ? AND ORG_ID = ?"},
+			args:    []interface{}{"c-1", "org-1"},
+			allowed: true,
+		},
+		{
+			name:    "select scoped through an alias",
+			query:   model.DBQuery{ID: "JOIN", Query: "SELECT * FROM CONSENT c JOIN CONSENT_ATTRIBUTE ca ON c.CONSENT_ID = ca.CONSENT_ID WHERE c.ORG_ID = ? AND ca.ATT_KEY = ?"},
+			args:    []interface{}{"org-1", "key"},
+			allowed: true,
+		},
+		{
+			name:  "select without an organization filter",
+			query: model.DBQuery{ID: "GET_BY_ID", Query: "SELECT * FROM CONSENT WHERE CONSENT_ID = ?"},
+			args:  []interface{}{"c-1"},
+		},
+		{
+			name:  "select without a WHERE clause",
+			query: model.DBQuery{ID: "GET_ALL", Query: "SELECT * FROM CONSENT"},
+		},
+		{
+			name:  "organization bound to an empty value",
+			query: model.DBQuery{ID: "GET", Query: "SELECT * FROM CONSENT WHERE CONSENT_ID = ? AND ORG_ID = ?"},
+			args:  []interface{}{"c-1", ""},
+		},
+		{
+			name:  "organization filter with too few arguments",
+			query: model.DBQuery{ID: "GET", Query: "SELECT * FROM CONSENT WHERE ORG_ID = ?"},
+		},
+		{
+			name:  "organization filter only in the SQLite variant",
+			query: model.DBQuery{ID: "GET", Query: "SELECT * FROM CONSENT WHERE CONSENT_ID = ?", SQLiteQuery: "SELECT * FROM CONSENT WHERE CONSENT_ID = ? AND ORG_ID = ?"},
+			args:  []interface{}{"c-1", "org-1"},
+		},
+		{
+			name:  "update without an organization filter",
+			query: model.DBQuery{ID: "UPDATE", Query: "UPDATE CONSENT SET CURRENT_STATUS = ? WHERE CONSENT_ID = ?"},
+			args:  []interface{}{"REVOKED", "c-1"},
+		},
+		{
+			name:  "delete without an organization filter",
+			query: model.DBQuery{ID: "DELETE", Query: "DELETE FROM CONSENT_ATTRIBUTE WHERE CONSENT_ID = ?"},
+			args:  []interface{}{"c-1"},
+		},
+		{
+			name:    "organization filter next to a parenthesized OR",
+			query:   model.DBQuery{ID: "LOCK", Query: "UPDATE CONSENT_LOCK SET LOCK_TOKEN = ? WHERE CONSENT_ID = ? AND ORG_ID = ? AND (EXPIRY_TIME <= ? OR LOCK_TOKEN = ?) ORDER BY CONSENT_ID"},
+			args:    []interface{}{"t-1", "c-1", "org-1", int64(1), "t-0"},
+			allowed: true,
+		},
+		{
+			name:    "organization filter after a subquery",
+			query:   model.DBQuery{ID: "SUBQUERY", Query: "SELECT * FROM CONSENT WHERE CONSENT_ID IN (SELECT CONSENT_ID FROM CONSENT_TAG WHERE TAG = ? OR TAG = ?) AND ORG_ID = ?"},
+			args:    []interface{}{"a", "b", "org-1"},
+			allowed: true,
+		},
+		{
+			name:    "count over a scoped derived table",
+			query:   model.DBQuery{ID: "COUNT", Query: "SELECT COUNT(*) as count FROM (SELECT DISTINCT USER_ID FROM CONSENT_AUTH_RESOURCE WHERE ORG_ID = ? AND CONSENT_ID = ?) approvals"},
+			args:    []interface{}{"org-1", "c-1"},
+			allowed: true,
+		},
+		{
+			name:  "count over a derived table with a top-level OR",
+			query: model.DBQuery{ID: "COUNT", Query: "SELECT COUNT(*) as count FROM (SELECT USER_ID FROM CONSENT_AUTH_RESOURCE WHERE ORG_ID = ? OR CONSENT_ID = ?) approvals"},
+			args:  []interface{}{"org-1", "c-1"},
+		},
+		{
+			name:  "organization filter under a top-level OR",
+			query: model.DBQuery{ID: "OR", Query: "SELECT * FROM CONSENT WHERE ORG_ID = ? OR CONSENT_ID = ?"},
+			args:  []interface{}{"org-1", "c-1"},
+		},
+		{
+			name:  "organization filter before a trailing OR",
+			query: model.DBQuery{ID: "OR", Query: "DELETE FROM CONSENT WHERE CONSENT_ID = ? AND ORG_ID = ? OR 1 = 1"},
+			args:  []interface{}{"c-1", "org-1"},
+		},
+		{
+			name:  "organization filter inside a parenthesized OR",
+			query: model.DBQuery{ID: "OR", Query: "SELECT * FROM CONSENT WHERE (ORG_ID = ? OR CLIENT_ID = ?) AND CONSENT_ID = ?"},
+			args:  []interface{}{"org-1", "client-1", "c-1"},
+		},
+		{
+			name:  "organization filter only in a subquery",
+			query: model.DBQuery{ID: "SUBQUERY", Query: "SELECT * FROM CONSENT WHERE CONSENT_ID IN (SELECT CONSENT_ID FROM CONSENT_TAG WHERE ORG_ID = ?)"},
+			args:  []interface{}{"org-1"},
+		},
+		{
+			name:  "organization filter only in a string literal",
+			query: model.DBQuery{ID: "LITERAL", Query: "SELECT * FROM CONSENT WHERE CLIENT_ID = 'x AND ORG_ID = ?' AND CONSENT_ID = ?"},
+			args:  []interface{}{"c-1"},
+		},
+		{
+			name:    "insert setting the organization",
+			query:   model.DBQuery{ID: "INSERT", Query: "INSERT OR IGNORE INTO CONSENT_USAGE (CONSENT_ID, ORG_ID) VALUES (?, ?)"},
+			args:    []interface{}{"c-1", "org-1"},
+			allowed: true,
+		},
+		{
+			name:  "insert without the organization",
+			query: model.DBQuery{ID: "INSERT", Query: "INSERT INTO CONSENT_ATTRIBUTE (CONSENT_ID, ATT_KEY) VALUES (?, ?)"},
+			args:  []interface{}{"c-1", "key"},
+		},
+		{
+			name:    "global table",
+			query:   model.DBQuery{ID: "LIST_ORGS", Query: "SELECT * FROM ORGANIZATION"},
+			allowed: true,
+		},
+		{
+			name:    "cross-tenant query",
+			query:   model.DBQuery{ID: "PURGE", Query: "SELECT * FROM CONSENT WHERE CURRENT_STATUS = 'DELETED'", CrossTenant: true},
+			allowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &recordingDBClient{}
+			guard := NewTenantGuardClient(inner)
+
+			_, err := guard.Query(tt.query, tt.args...)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("expected query to be allowed, got %v", err)
+				}
+				if inner.executed != 1 {
+					t.Fatalf("expected query to reach the database")
+				}
+				return
+			}
+			if !errors.Is(err, ErrMissingTenantFilter) {
+				t.Fatalf("expected ErrMissingTenantFilter, got %v", err)
+			}
+			if inner.executed != 0 {
+				t.Fatalf("rejected query reached the database")
+			}
+		})
+	}
+}
@@ -18,22 +18,36 @@
 
 package apierror
 
-// ErrorResponse represents the error response returned by the API.
-// It follows the format specified in the API contract with specific error codes,
-// human-readable messages, detailed descriptions, and trace IDs for debugging.
-type ErrorResponse struct {
-	Code        string `json:"code"`                  // Specific error code (e.g., "CSE-4040")
-	Message     string `json:"message"`               // Human-readable error message
-	Description string `json:"description,omitempty"` // Detailed error description with context
-	TraceID     string `json:"traceId"`               // Correlation ID for request tracking
+// ProblemDetails is the RFC 7807 (application/problem+json) body of an error response. Besides the
+// standard members it carries the machine-readable error code and the trace ID of the request.
+type ProblemDetails struct {
+	Type     string `json:"type"`               // URI of the error code in the error code catalog
+	Title    string `json:"title"`              // Human-readable summary of the error
+	Status   int    `json:"status"`             // HTTP status code
+	Detail   string `json:"detail,omitempty"`   // Explanation specific to this occurrence
+	Instance string `json:"instance,omitempty"` // Request path the error occurred on
+	Code     string `json:"code"`               // Specific error code (e.g., "CSE-4040")
+	TraceID  string `json:"traceId"`            // Correlation ID for request tracking
+
+	Violations []Violation `json:"violations,omitempty"` // Invalid fields of the request, for validation errors
+}
+
+// Violation is one invalid field of a request in a problem response
+type Violation struct {
+	Path       string `json:"path"`
+	Constraint string `json:"constraint"`
+	Message    string `json:"message"`
 }
 
-// NewErrorResponse creates a new ErrorResponse with the provided details.
-func NewErrorResponse(code, message, description, traceID string) *ErrorResponse {
-	return &ErrorResponse{
-		Code:        code,
-		Message:     message,
-		Description: description,
-		TraceID:     traceID,
+// NewProblemDetails creates a new ProblemDetails with the provided details.
+func NewProblemDetails(typeURI, title string, status int, detail, instance, code, traceID string) *ProblemDetails {
+	return &ProblemDetails{
+		Type:     typeURI,
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     code,
+		TraceID:  traceID,
 	}
 }
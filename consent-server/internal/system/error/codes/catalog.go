@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package codes
+
+import "net/http"
+
+// Definition documents an error code: the HTTP status it is returned with and when it occurs
+type Definition struct {
+	Code        string `json:"code"`
+	Title       string `json:"title"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+// catalog lists every error code the API returns, in code order within each group
+var catalog = []Definition{
+	// General errors
+	{InvalidRequest, "Invalid Request", http.StatusBadRequest, "The request is malformed or a parameter is invalid"},
+	{ValidationError, "Validation Error", http.StatusBadRequest, "The request is well formed but violates a validation rule"},
+	{ResourceNotFound, "Resource Not Found", http.StatusNotFound, "The requested resource does not exist"},
+	{ConflictError, "Conflict", http.StatusConflict, "The request conflicts with the current state of the resource"},
+	{Unauthorized, "Unauthorized", http.StatusUnauthorized, "Credentials are missing or invalid"},
+	{Forbidden, "Forbidden", http.StatusForbidden, "The caller is not permitted to perform the operation"},
+	{InternalServerError, "Internal Server Error", http.StatusInternalServerError, "An unexpected error occurred"},
+	{DatabaseError, "Database Error", http.StatusInternalServerError, "The database could not complete the operation"},
+
+	// Consent-specific errors
+	{ConsentNotFound, "Consent Not Found", http.StatusNotFound, "The consent does not exist in the organization"},
+	{ConsentValidationFailed, "Consent Validation Failed", http.StatusBadRequest, "The consent could not be validated"},
+	{ConsentAttributeInvalid, "Invalid Consent Attribute", http.StatusBadRequest, "A consent attribute is invalid"},
+	{ConsentStatusInvalid, "Invalid Consent Status", http.StatusBadRequest, "The consent status or status transition is invalid"},
+	{ConsentLocked, "Consent Locked", http.StatusLocked, "The consent is locked by a caller authorizing it and cannot be changed"},
+	{ConsentCreationFailed, "Consent Creation Failed", http.StatusInternalServerError, "The consent could not be created"},
+	{ConsentUpdateFailed, "Consent Update Failed", http.StatusInternalServerError, "The consent could not be updated"},
+	{ConsentRevokeFailed, "Consent Revoke Failed", http.StatusInternalServerError, "The consent could not be revoked"},
+	{ConsentExpireFailed, "Consent Expire Failed", http.StatusInternalServerError, "The consent could not be expired"},
+
+	// Purpose-specific errors
+	{PurposeNotFound, "Purpose Not Found", http.StatusNotFound, "The consent purpose does not exist in the organization"},
+	{PurposeValidationFailed, "Purpose Validation Failed", http.StatusBadRequest, "The consent purpose is invalid"},
+	{PurposeInUse, "Purpose In Use", http.StatusConflict, "The consent purpose is linked to consents and cannot be deleted"},
+	{PurposeCreationFailed, "Purpose Creation Failed", http.StatusInternalServerError, "The consent purpose could not be created"},
+	{PurposeUpdateFailed, "Purpose Update Failed", http.StatusInternalServerError, "The consent purpose could not be updated"},
+	{PurposeDeleteFailed, "Purpose Delete Failed", http.StatusInternalServerError, "The consent purpose could not be deleted"},
+
+	// Auth Resource-specific errors
+	{AuthResourceNotFound, "Authorization Not Found", http.StatusNotFound, "The authorization resource does not exist"},
+	{AuthResourceValidationFailed, "Authorization Validation Failed", http.StatusBadRequest, "The authorization resource is invalid"},
+	{AuthResourceCreationFailed, "Authorization Creation Failed", http.StatusInternalServerError, "The authorization resource could not be created"},
+	{AuthResourceUpdateFailed, "Authorization Update Failed", http.StatusInternalServerError, "The authorization resource could not be updated"},
+	{AuthResourceDeleteFailed, "Authorization Delete Failed", http.StatusInternalServerError, "The authorization resource could not be deleted"},
+
+	// Export-specific errors
+	{ExportJobNotFound, "Export Job Not Found", http.StatusNotFound, "The consent export job does not exist"},
+
+	// Consent file-specific errors
+	{ConsentFileNotFound, "Consent File Not Found", http.StatusNotFound, "The consent file does not exist"},
+	{ConsentFileTooLarge, "File Too Large", http.StatusRequestEntityTooLarge, "The uploaded consent file exceeds the configured size limit"},
+	{ConsentFileTypeRejected, "Unsupported Media Type", http.StatusUnsupportedMediaType, "The consent file type is not allowed"},
+
+	// Consent import-specific errors
+	{ImportJobNotFound, "Import Job Not Found", http.StatusNotFound, "The consent import job does not exist"},
+	{ImportFileTooLarge, "Import File Too Large", http.StatusRequestEntityTooLarge, "The import file exceeds the configured size limit"},
+	{ImportFileTypeRejected, "Unsupported Media Type", http.StatusUnsupportedMediaType, "The import file is not sent as JSON Lines"},
+
+	// Request-specific errors
+	{RequestBodyTooLarge, "Request Body Too Large", http.StatusRequestEntityTooLarge, "The request body exceeds the configured size limit of the endpoint"},
+	{RequestTimeout, "Request Timeout", http.StatusGatewayTimeout, "The request was not handled within the configured timeout of the endpoint"},
+}
+
+// Catalog returns the definitions of all error codes
+func Catalog() []Definition {
+	definitions := make([]Definition, len(catalog))
+	copy(definitions, catalog)
+	return definitions
+}
+
+// Lookup returns the definition of an error code
+func Lookup(code string) (Definition, bool) {
+	for _, definition := range catalog {
+		if definition.Code == code {
+			return definition, true
+		}
+	}
+	return Definition{}, false
+}
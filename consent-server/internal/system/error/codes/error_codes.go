@@ -25,6 +25,8 @@ const (
 	DatabaseError       = "CSE-5001"
 	InvalidRequest      = "CSE-4000"
 	ValidationError     = "CSE-4001"
+	Unauthorized        = "CSE-4010"
+	Forbidden           = "CSE-4030"
 	ResourceNotFound    = "CSE-4004"
 	ConflictError       = "CSE-4009"
 
@@ -37,6 +39,7 @@ const (
 	ConsentExpireFailed     = "CSE-5013"
 	ConsentAttributeInvalid = "CSE-4042"
 	ConsentStatusInvalid    = "CSE-4043"
+	ConsentLocked           = "CSE-4230"
 
 	// Purpose-specific errors
 	PurposeNotFound         = "CSE-4050"
@@ -52,4 +55,21 @@ const (
 	AuthResourceUpdateFailed     = "CSE-5031"
 	AuthResourceDeleteFailed     = "CSE-5032"
 	AuthResourceValidationFailed = "CSE-4061"
+
+	// Export-specific errors
+	ExportJobNotFound = "CSE-4070"
+
+	// Consent file-specific errors
+	ConsentFileNotFound     = "CSE-4080"
+	ConsentFileTooLarge     = "CSE-4130"
+	ConsentFileTypeRejected = "CSE-4150"
+
+	// Consent import-specific errors
+	ImportJobNotFound      = "CSE-4090"
+	ImportFileTooLarge     = "CSE-4131"
+	ImportFileTypeRejected = "CSE-4151"
+
+	// Request-specific errors
+	RequestBodyTooLarge = "CSE-4132"
+	RequestTimeout      = "CSE-5040"
 )
@@ -19,6 +19,9 @@
 package serviceerror
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/wso2/consent-management-api/internal/system/error/codes"
 )
 
@@ -36,6 +39,31 @@ type ServiceError struct {
 	Type        ServiceErrorType `json:"type"`        // Error type (client_error or server_error)
 	Message     string           `json:"message"`     // Human-readable error message
 	Description string           `json:"description"` // Detailed error description
+	Violations  FieldViolations  `json:"violations,omitempty"`
+}
+
+// FieldViolation describes one invalid field of a request
+type FieldViolation struct {
+	Path       string `json:"path"`       // Location of the field in the request body (e.g., "[3].attributes.jsonPath")
+	Constraint string `json:"constraint"` // Violated constraint (e.g., "required", "maxLength")
+	Message    string `json:"message"`    // Human-readable explanation
+}
+
+// FieldViolations is an error listing every invalid field of a request, so clients can point at
+// each offending field instead of parsing one message
+type FieldViolations []FieldViolation
+
+// Error joins the violations as "path: message" pairs.
+func (v FieldViolations) Error() string {
+	messages := make([]string, 0, len(v))
+	for _, violation := range v {
+		if violation.Path == "" {
+			messages = append(messages, violation.Message)
+			continue
+		}
+		messages = append(messages, violation.Path+": "+violation.Message)
+	}
+	return strings.Join(messages, "; ")
 }
 
 // Predefined service errors for common scenarios
@@ -75,12 +103,33 @@ var (
 		Description: "The request conflicts with the current state of the resource",
 	}
 
+	UnauthorizedError = ServiceError{
+		Type:        ClientErrorType,
+		Code:        codes.Unauthorized,
+		Message:     "Unauthorized",
+		Description: "Authentication is required to access this resource",
+	}
+
+	ForbiddenError = ServiceError{
+		Type:        ClientErrorType,
+		Code:        codes.Forbidden,
+		Message:     "Forbidden",
+		Description: "The caller is not permitted to access this resource",
+	}
+
 	ValidationError = ServiceError{
 		Type:        ClientErrorType,
 		Code:        codes.ValidationError,
 		Message:     "Validation Error",
 		Description: "Request validation failed",
 	}
+
+	ConsentLockedError = ServiceError{
+		Type:        ClientErrorType,
+		Code:        codes.ConsentLocked,
+		Message:     "Consent Locked",
+		Description: "The consent is locked by another caller",
+	}
 )
 
 // NewServiceError creates a new ServiceError with the specified details.
@@ -104,6 +153,27 @@ func CustomServiceError(baseError ServiceError, description string) *ServiceErro
 	}
 }
 
+// NewFieldValidationError creates a validation error listing the invalid fields of a request.
+func NewFieldValidationError(violations FieldViolations) *ServiceError {
+	return &ServiceError{
+		Type:        ValidationError.Type,
+		Code:        ValidationError.Code,
+		Message:     ValidationError.Message,
+		Description: violations.Error(),
+		Violations:  violations,
+	}
+}
+
+// ValidationErrorFrom converts a validator error into a validation error. The field violations are
+// kept when the error carries them.
+func ValidationErrorFrom(err error) *ServiceError {
+	var violations FieldViolations
+	if errors.As(err, &violations) {
+		return NewFieldValidationError(violations)
+	}
+	return CustomServiceError(ValidationError, err.Error())
+}
+
 // Error implements the error interface.
 func (e *ServiceError) Error() string {
 	return e.Message + ": " + e.Description
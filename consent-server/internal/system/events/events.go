@@ -0,0 +1,210 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package events defines the consent lifecycle events emitted to external systems and the
+// serializer every transport must use. The serializer is the single place where consent
+// attributes are filtered, so sensitive attributes cannot leak through any transport.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// SchemaVersion is the version of the ConsentEvent JSON schema (api/consent-event.v1.schema.json).
+// It changes only when a field is removed or changes meaning; new optional fields keep the version.
+const SchemaVersion = "1"
+
+// EventType identifies a consent lifecycle event
+type EventType string
+
+// Consent lifecycle event types
+const (
+	ConsentCreated EventType = "consent.created"
+	ConsentUpdated EventType = "consent.updated"
+	ConsentRevoked EventType = "consent.revoked"
+	ConsentExpired EventType = "consent.expired"
+	// ConsentOwnershipTransferred is emitted when the user binding of a consent moves to another user
+	ConsentOwnershipTransferred EventType = "consent.ownership_transferred"
+	// ConsentStatusOverridden is emitted when an admin forces a consent into a status
+	ConsentStatusOverridden EventType = "consent.status_overridden"
+	// ConsentExpiringSoon is emitted once per validity time when a consent enters its organization's
+	// expiry notice period
+	ConsentExpiringSoon EventType = "consent.expiring_soon"
+	// ConsentReauthorizationRequested is emitted when a consent is sent back to its users for
+	// re-authorization
+	ConsentReauthorizationRequested EventType = "consent.reauthorization_requested"
+	// ConsentSuspended is emitted when a consent is put on hold, and ConsentResumed when the hold
+	// is lifted
+	ConsentSuspended EventType = "consent.suspended"
+	ConsentResumed   EventType = "consent.resumed"
+)
+
+// ConsentEvent is the payload emitted for a consent lifecycle change
+type ConsentEvent struct {
+	ID         string            `json:"id"`
+	Type       EventType         `json:"type"`
+	Timestamp  int64             `json:"timestamp"`
+	OrgID      string            `json:"orgId"`
+	ConsentID  string            `json:"consentId"`
+	ClientID   string            `json:"clientId"`
+	Status     string            `json:"status"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// UserID and PreviousUserID are set on ownership transfer events
+	UserID         string `json:"userId,omitempty"`
+	PreviousUserID string `json:"previousUserId,omitempty"`
+	// PreviousStatus is set on status override, revocation, expiry and re-authorization events
+	PreviousStatus string `json:"previousStatus,omitempty"`
+	// ValidityTime is set on expiring soon and re-authorization events, as stored on the consent
+	ValidityTime int64 `json:"validityTime,omitempty"`
+}
+
+// Publisher delivers consent events to an external system. Implementations must encode events
+// with Serialize, and Publish must only return once the event is delivered, since the outbox relay
+// removes the event then.
+type Publisher interface {
+	Publish(ctx context.Context, event ConsentEvent) error
+}
+
+// Flusher is implemented by publishers that queue events. Flush delivers the queued events and is
+// called on shutdown, before the database is closed.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// logPublisher writes events to the server log. It is used until a transport is configured.
+type logPublisher struct{}
+
+// Publish logs the serialized event
+func (logPublisher) Publish(ctx context.Context, event ConsentEvent) error {
+	data, err := Serialize(event)
+	if err != nil {
+		return err
+	}
+	log.GetLogger().WithContext(ctx).Info("Consent event",
+		log.String("event_type", string(event.Type)),
+		log.String("event", string(data)))
+	return nil
+}
+
+// AttributePolicy decides which consent attributes of an organization may be propagated
+type AttributePolicy interface {
+	AllowedAttributes(orgID string) []string
+}
+
+// configAttributePolicy reads the attribute whitelist from the events configuration
+type configAttributePolicy struct{}
+
+// AllowedAttributes returns the whitelisted attribute keys of an organization
+func (configAttributePolicy) AllowedAttributes(orgID string) []string {
+	cfg := config.Get()
+	if cfg == nil {
+		return nil
+	}
+	for _, rule := range cfg.Events.AttributePropagation {
+		if rule.OrgID == orgID {
+			return rule.Attributes
+		}
+	}
+	return nil
+}
+
+var (
+	policyMu sync.RWMutex
+	policy   AttributePolicy = configAttributePolicy{}
+
+	publisherMu sync.RWMutex
+	publisher   Publisher = logPublisher{}
+)
+
+// SetPublisher replaces the publisher events are delivered to
+func SetPublisher(p Publisher) {
+	publisherMu.Lock()
+	defer publisherMu.Unlock()
+	publisher = p
+}
+
+// GetPublisher returns the publisher events are delivered to, so it can be wrapped
+func GetPublisher() Publisher {
+	publisherMu.RLock()
+	defer publisherMu.RUnlock()
+	return publisher
+}
+
+// Publish delivers an event with the configured publisher and returns once it is delivered.
+// Consent changes do not call it directly: they record their events in the event outbox within
+// their transaction, and the outbox relay publishes them, retrying failed deliveries.
+func Publish(ctx context.Context, event ConsentEvent) error {
+	publisherMu.RLock()
+	p := publisher
+	publisherMu.RUnlock()
+
+	return p.Publish(ctx, event)
+}
+
+// Flush delivers the events queued by the configured publisher, if it queues events
+func Flush(ctx context.Context) error {
+	publisherMu.RLock()
+	p := publisher
+	publisherMu.RUnlock()
+
+	if flusher, ok := p.(Flusher); ok {
+		return flusher.Flush(ctx)
+	}
+	return nil
+}
+
+// SetAttributePolicy replaces the policy used to filter event attributes
+func SetAttributePolicy(p AttributePolicy) {
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	policy = p
+}
+
+// FilterAttributes returns the attributes of an organization that may be propagated. Attributes
+// that are not whitelisted are dropped (default deny).
+func FilterAttributes(orgID string, attributes map[string]string) map[string]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	policyMu.RLock()
+	allowed := policy.AllowedAttributes(orgID)
+	policyMu.RUnlock()
+
+	filtered := make(map[string]string)
+	for _, key := range allowed {
+		if value, ok := attributes[key]; ok {
+			filtered[key] = value
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+	return filtered
+}
+
+// Serialize encodes an event as JSON after filtering its attributes for the event's organization
+func Serialize(event ConsentEvent) ([]byte, error) {
+	event.Attributes = FilterAttributes(event.OrgID, event.Attributes)
+	return json.Marshal(event)
+}
@@ -0,0 +1,169 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// setAttributePropagation configures the whitelist read by the default attribute policy
+func setAttributePropagation(t *testing.T, rules ...config.AttributePropagationRule) {
+	t.Helper()
+	config.SetGlobal(&config.Config{Events: config.EventsConfig{AttributePropagation: rules}})
+	t.Cleanup(func() { config.SetGlobal(nil) })
+}
+
+// TestFilterAttributes_KeepsOnlyWhitelistedAttributesOfTheOrganization checks the default deny filtering
+func TestFilterAttributes_KeepsOnlyWhitelistedAttributesOfTheOrganization(t *testing.T) {
+	setAttributePropagation(t,
+		config.AttributePropagationRule{OrgID: "org-1", Attributes: []string{"channel", "region"}},
+		config.AttributePropagationRule{OrgID: "org-2", Attributes: []string{"ssn"}},
+	)
+	attributes := map[string]string{"channel": "web", "ssn": "123-45-6789"}
+
+	testCases := []struct {
+		name       string
+		orgID      string
+		attributes map[string]string
+		want       map[string]string
+	}{
+		{"whitelisted keys are kept", "org-1", attributes, map[string]string{"channel": "web"}},
+		{"whitelist is scoped to the organization", "org-2", attributes, map[string]string{"ssn": "123-45-6789"}},
+		{"unlisted organization gets nothing", "org-3", attributes, nil},
+		{"no whitelisted key present", "org-1", map[string]string{"ssn": "123-45-6789"}, nil},
+		{"no attributes", "org-1", nil, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FilterAttributes(tc.orgID, tc.attributes)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+// TestFilterAttributes_DropsEverythingWithoutConfig checks that attributes are never propagated
+// before the configuration is loaded
+func TestFilterAttributes_DropsEverythingWithoutConfig(t *testing.T) {
+	config.SetGlobal(nil)
+
+	if got := FilterAttributes("org-1", map[string]string{"channel": "web"}); got != nil {
+		t.Errorf("expected no attributes without configuration, got %v", got)
+	}
+}
+
+// staticPolicy whitelists the same attributes for every organization
+type staticPolicy []string
+
+func (p staticPolicy) AllowedAttributes(string) []string {
+	return p
+}
+
+// TestSetAttributePolicy_ReplacesTheConfiguredWhitelist checks that a custom policy takes over
+func TestSetAttributePolicy_ReplacesTheConfiguredWhitelist(t *testing.T) {
+	setAttributePropagation(t, config.AttributePropagationRule{OrgID: "org-1", Attributes: []string{"channel"}})
+	SetAttributePolicy(staticPolicy{"region"})
+	t.Cleanup(func() { SetAttributePolicy(configAttributePolicy{}) })
+
+	got := FilterAttributes("org-1", map[string]string{"channel": "web", "region": "eu"})
+	if want := map[string]string{"region": "eu"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// TestSerialize_FiltersAttributesBeforeEncoding checks that the payload only carries whitelisted
+// attributes and that the caller's event is left untouched
+func TestSerialize_FiltersAttributesBeforeEncoding(t *testing.T) {
+	setAttributePropagation(t, config.AttributePropagationRule{OrgID: "org-1", Attributes: []string{"channel"}})
+	event := ConsentEvent{
+		ID:         "evt-1",
+		Type:       ConsentCreated,
+		OrgID:      "org-1",
+		ConsentID:  "c1",
+		Status:     "ACTIVE",
+		Attributes: map[string]string{"channel": "web", "ssn": "123-45-6789"},
+	}
+
+	payload, err := Serialize(event)
+	if err != nil {
+		t.Fatalf("failed to serialize the event: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatalf("failed to decode the payload: %v", err)
+	}
+	if decoded["type"] != string(ConsentCreated) || decoded["consentId"] != "c1" {
+		t.Errorf("unexpected payload %s", payload)
+	}
+	if want := map[string]interface{}{"channel": "web"}; !reflect.DeepEqual(decoded["attributes"], want) {
+		t.Errorf("expected attributes %v, got %v", want, decoded["attributes"])
+	}
+	if len(event.Attributes) != 2 {
+		t.Errorf("expected the caller's attributes to be left untouched, got %v", event.Attributes)
+	}
+
+	event.Attributes = map[string]string{"ssn": "123-45-6789"}
+	payload, err = Serialize(event)
+	if err != nil {
+		t.Fatalf("failed to serialize the event: %v", err)
+	}
+	decoded = nil
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatalf("failed to decode the payload: %v", err)
+	}
+	if _, ok := decoded["attributes"]; ok {
+		t.Errorf("expected attributes to be omitted when none are whitelisted, got %s", payload)
+	}
+}
+
+// queuingPublisher queues published events until they are flushed
+type queuingPublisher struct {
+	queued    []ConsentEvent
+	delivered []ConsentEvent
+}
+
+func (p *queuingPublisher) Publish(ctx context.Context, event ConsentEvent) error {
+	p.queued = append(p.queued, event)
+	return nil
+}
+
+func (p *queuingPublisher) Flush(ctx context.Context) error {
+	p.delivered = append(p.delivered, p.queued...)
+	p.queued = nil
+	return nil
+}
+
+// TestFlush_DeliversQueuedEvents checks that shutdown delivers the events a queuing publisher holds,
+// and that publishers which deliver at once need no flush
+func TestFlush_DeliversQueuedEvents(t *testing.T) {
+	previous := GetPublisher()
+	t.Cleanup(func() { SetPublisher(previous) })
+	ctx := context.Background()
+
+	SetPublisher(logPublisher{})
+	if err := Flush(ctx); err != nil {
+		t.Errorf("expected flushing a publisher that does not queue to succeed, got %v", err)
+	}
+
+	queuing := &queuingPublisher{}
+	SetPublisher(queuing)
+	if err := Publish(ctx, ConsentEvent{ID: "evt-1", Type: ConsentRevoked, ConsentID: "c1"}); err != nil {
+		t.Fatalf("failed to publish the event: %v", err)
+	}
+	if len(queuing.delivered) != 0 {
+		t.Fatalf("expected the event to be queued until flushed, got %+v", queuing.delivered)
+	}
+
+	if err := Flush(ctx); err != nil {
+		t.Fatalf("failed to flush the events: %v", err)
+	}
+	if len(queuing.delivered) != 1 || queuing.delivered[0].ID != "evt-1" || len(queuing.queued) != 0 {
+		t.Errorf("expected the queued event to be delivered, got %+v", queuing)
+	}
+}
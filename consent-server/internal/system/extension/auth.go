@@ -0,0 +1,170 @@
+package extension
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+)
+
+// tokenRefreshMargin is how long before it expires a cached access token is replaced, so a token
+// does not expire while a call is in flight
+const tokenRefreshMargin = 30 * time.Second
+
+// defaultTokenLifetime is how long a token is cached when the token endpoint does not say
+const defaultTokenLifetime = 5 * time.Minute
+
+var (
+	clientsMu sync.Mutex
+	// clients holds an HTTP client per TLS configuration, so connections are reused across calls
+	clients = map[config.ExtensionTLSConfig]*http.Client{}
+
+	tokensMu sync.Mutex
+	// tokens holds the token source of each OAuth2 client, keyed by tokenKey
+	tokens = map[string]*tokenSource{}
+)
+
+// clientFor returns the HTTP client for calls with the TLS settings of auth
+func clientFor(auth config.ExtensionAuthConfig) (*http.Client, error) {
+	if auth.TLS == (config.ExtensionTLSConfig{}) {
+		return httpClient, nil
+	}
+
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if client, ok := clients[auth.TLS]; ok {
+		return client, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if auth.TLS.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(auth.TLS.CertFile, auth.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load extension client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	if auth.TLS.CAFile != "" {
+		pem, err := os.ReadFile(auth.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read extension CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("extension CA file %s contains no certificates", auth.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client := &http.Client{Transport: transport}
+	clients[auth.TLS] = client
+	return client, nil
+}
+
+// tokenSource gets OAuth2 access tokens with the client credentials grant and caches them until
+// shortly before they expire
+type tokenSource struct {
+	cfg    config.ExtensionOAuth2Config
+	client *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// tokenResponse is the body returned by an OAuth2 token endpoint
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// tokenSourceFor returns the token source of the OAuth2 client of auth, nil when OAuth2 is not configured
+func tokenSourceFor(auth config.ExtensionAuthConfig, client *http.Client) *tokenSource {
+	if auth.OAuth2.TokenURL == "" {
+		return nil
+	}
+	key := strings.Join([]string{auth.OAuth2.TokenURL, auth.OAuth2.ClientID, auth.OAuth2.ClientSecret,
+		strings.Join(auth.OAuth2.Scopes, " "), auth.TLS.CertFile, auth.TLS.KeyFile, auth.TLS.CAFile}, "\x00")
+
+	tokensMu.Lock()
+	defer tokensMu.Unlock()
+	source, ok := tokens[key]
+	if !ok {
+		source = &tokenSource{cfg: auth.OAuth2, client: client}
+		tokens[key] = source
+	}
+	return source
+}
+
+// Token returns a cached access token, getting a new one when there is none or it is about to expire
+func (s *tokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Add(tokenRefreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(constants.HeaderContentType, "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(s.cfg.ClientID), url.QueryEscape(s.cfg.ClientSecret))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get extension access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to get extension access token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint responded with status %d", resp.StatusCode)
+	}
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("invalid token endpoint response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("token endpoint returned no access token")
+	}
+
+	lifetime := defaultTokenLifetime
+	if token.ExpiresIn > 0 {
+		lifetime = time.Duration(token.ExpiresIn) * time.Second
+	}
+	s.token = token.AccessToken
+	s.expiresAt = time.Now().Add(lifetime)
+	return s.token, nil
+}
+
+// Invalidate drops the cached token if it is token, so the next call gets a new one
+func (s *tokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == token {
+		s.token = ""
+	}
+}
@@ -0,0 +1,401 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package extension calls the service extension, an external service that deployers use to add
+// their own validation and enrichment to consent operations. Pre-process hooks run before an
+// operation and can reject it or rewrite its request; enrich hooks run after it and can add to its
+// response.
+package extension
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/log"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// Hook identifies an extension point. Hook names match the keys of service_extension.endpoints.
+type Hook string
+
+const (
+	PreProcessConsentCreation       Hook = "pre_process_consent_creation"
+	EnrichConsentCreationResponse   Hook = "enrich_consent_creation_response"
+	PreProcessConsentUpdate         Hook = "pre_process_consent_update"
+	EnrichConsentUpdateResponse     Hook = "enrich_consent_update_response"
+	PreProcessConsentRevoke         Hook = "pre_process_consent_revoke"
+	EnrichConsentRevokeResponse     Hook = "enrich_consent_revoke_response"
+	PreProcessConsentValidation     Hook = "pre_process_consent_validation"
+	EnrichConsentValidationResponse Hook = "enrich_consent_validation_response"
+	EvaluateValidationPolicy        Hook = "evaluate_validation_policy"
+)
+
+// hooks lists every extension point, in the order metrics are reported
+var hooks = []Hook{
+	PreProcessConsentCreation,
+	EnrichConsentCreationResponse,
+	PreProcessConsentUpdate,
+	EnrichConsentUpdateResponse,
+	PreProcessConsentRevoke,
+	EnrichConsentRevokeResponse,
+	PreProcessConsentValidation,
+	EnrichConsentValidationResponse,
+	EvaluateValidationPolicy,
+}
+
+// Extension response statuses
+const (
+	statusSuccess = "SUCCESS"
+	statusError   = "ERROR"
+)
+
+// ErrUnavailable is returned when a hook that fails closed cannot be completed
+var ErrUnavailable = errors.New("service extension unavailable")
+
+// RejectedError is returned when the extension rejects an operation
+type RejectedError struct {
+	// StatusCode is the HTTP status the extension asks the operation to fail with
+	StatusCode  int
+	Message     string
+	Description string
+}
+
+func (e *RejectedError) Error() string {
+	if e.Description != "" {
+		return e.Message + ": " + e.Description
+	}
+	return e.Message
+}
+
+// request is the body sent to a hook
+type request struct {
+	RequestID string      `json:"requestId"`
+	Hook      Hook        `json:"hook"`
+	OrgID     string      `json:"orgId"`
+	Data      interface{} `json:"data"`
+}
+
+// response is the body returned by a hook. Data, when present, replaces the payload sent to the hook.
+type response struct {
+	ResponseID       string          `json:"responseId"`
+	Status           string          `json:"status"`
+	Data             json.RawMessage `json:"data,omitempty"`
+	ErrorCode        int             `json:"errorCode,omitempty"`
+	ErrorMessage     string          `json:"errorMessage,omitempty"`
+	ErrorDescription string          `json:"errorDescription,omitempty"`
+}
+
+var httpClient = &http.Client{}
+
+// IsEnabled reports whether the service extension is enabled and has an endpoint for hook
+func IsEnabled(hook Hook) bool {
+	cfg := config.Get().ServiceExtension
+	return cfg.Enabled && cfg.Endpoints.GetEndpoint(string(hook)) != ""
+}
+
+// Invoke calls hook with payload, which must be a pointer. When the extension returns data, it
+// replaces the value payload points to. A *RejectedError is returned when the extension rejects
+// the operation. When the extension cannot be reached or answers with an invalid response, hooks
+// that fail closed return an error wrapping ErrUnavailable and hooks that fail open leave payload
+// unchanged and return nil. Invoke does nothing for hooks that are not enabled.
+//
+// Async hooks are called in the background with a copy of payload; Invoke returns nil at once and
+// the hook's response is ignored.
+func Invoke(ctx context.Context, hook Hook, orgID string, payload interface{}) error {
+	if !IsEnabled(hook) {
+		return nil
+	}
+	target := reflect.ValueOf(payload)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("extension payload for %s must be a non-nil pointer", hook)
+	}
+
+	cfg := config.Get().ServiceExtension
+	if cfg.IsAsync(string(hook)) {
+		invokeAsync(ctx, &cfg, hook, orgID, payload)
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "extension."+string(hook), tracing.SpanKindClient)
+	defer span.End()
+
+	logger := log.GetLogger().WithContext(ctx)
+	hookConfig := cfg.Hooks[string(hook)]
+	state := stateFor(hook)
+	state.calls.Add(1)
+
+	resp, err := call(ctx, &cfg, hook, orgID, payload)
+	if err == nil {
+		err = apply(resp, target)
+	}
+	if err != nil {
+		var rejected *RejectedError
+		if errors.As(err, &rejected) {
+			state.rejected.Add(1)
+			logger.Info("Service extension rejected the operation",
+				log.String("hook", string(hook)),
+				log.Int("status_code", rejected.StatusCode),
+				log.String("message", rejected.Message))
+			return err
+		}
+
+		state.failed.Add(1)
+		span.SetError(err)
+		if failsOpen(hook, hookConfig) {
+			logger.Warn("Service extension call failed, continuing without the hook",
+				log.String("hook", string(hook)), log.Error(err))
+			return nil
+		}
+		logger.Error("Service extension call failed", log.String("hook", string(hook)), log.Error(err))
+		return fmt.Errorf("%w: %s: %v", ErrUnavailable, hook, err)
+	}
+	state.succeeded.Add(1)
+	return nil
+}
+
+// invokeAsync calls hook in the background. The payload is encoded before Invoke returns, so the
+// caller may go on changing it. Calls beyond the async in-flight limit are dropped.
+func invokeAsync(ctx context.Context, cfg *config.ServiceExtensionConfig, hook Hook, orgID string, payload interface{}) {
+	logger := log.GetLogger().WithContext(ctx)
+	state := stateFor(hook)
+	state.calls.Add(1)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		state.failed.Add(1)
+		logger.Warn("Failed to encode async service extension payload", log.String("hook", string(hook)), log.Error(err))
+		return
+	}
+	if !acquireAsyncSlot(cfg) {
+		state.asyncDropped.Add(1)
+		logger.Warn("Too many async service extension calls in flight, dropping the call", log.String("hook", string(hook)))
+		return
+	}
+
+	// The call outlives the operation, so it must not be cancelled with the operation's context
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer releaseAsyncSlot()
+
+		ctx, span := tracing.Start(ctx, "extension."+string(hook), tracing.SpanKindClient)
+		defer span.End()
+
+		resp, err := call(ctx, cfg, hook, orgID, json.RawMessage(data))
+		switch {
+		case err != nil:
+			state.failed.Add(1)
+			span.SetError(err)
+			logger.Warn("Async service extension call failed", log.String("hook", string(hook)), log.Error(err))
+		case resp.Status == statusError:
+			state.rejected.Add(1)
+			logger.Info("Async service extension rejected the operation, ignoring the response",
+				log.String("hook", string(hook)), log.String("message", resp.ErrorMessage))
+		default:
+			state.succeeded.Add(1)
+		}
+	}()
+}
+
+// call sends payload to hook, retrying failed attempts up to the configured retry count with an
+// exponential backoff. Calls are refused while the hook's circuit is open.
+func call(ctx context.Context, cfg *config.ServiceExtensionConfig, hook Hook, orgID string, payload interface{}) (*response, error) {
+	state := stateFor(hook)
+	breaker := cfg.GetCircuitBreaker(string(hook))
+	if !state.allow(breaker) {
+		state.shortCircuited.Add(1)
+		return nil, errCircuitOpen
+	}
+
+	start := time.Now()
+	resp, err := callWithRetries(ctx, cfg, hook, orgID, payload)
+	state.recordCall(breaker, time.Since(start), err)
+	return resp, err
+}
+
+// callWithRetries sends payload to hook, retrying failed attempts
+func callWithRetries(ctx context.Context, cfg *config.ServiceExtensionConfig, hook Hook, orgID string, payload interface{}) (*response, error) {
+	body, err := json.Marshal(request{
+		RequestID: utils.GenerateUUID(),
+		Hook:      hook,
+		OrgID:     orgID,
+		Data:      payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode extension request: %w", err)
+	}
+
+	url := strings.TrimRight(cfg.BaseURL, "/") + cfg.Endpoints.GetEndpoint(string(hook))
+	timeout := cfg.Timeout
+	if hookTimeout := cfg.Hooks[string(hook)].Timeout; hookTimeout > 0 {
+		timeout = hookTimeout
+	}
+
+	auth := cfg.GetAuth(string(hook))
+	client, err := clientFor(auth)
+	if err != nil {
+		return nil, err
+	}
+	tokens := tokenSourceFor(auth, client)
+
+	var lastErr error
+	for attempt := 0; attempt <= cfg.GetRetryAttempts(string(hook)); attempt++ {
+		if attempt > 0 {
+			stateFor(hook).retries.Add(1)
+			select {
+			case <-time.After(backoff(cfg, attempt)):
+			case <-ctx.Done():
+				return nil, lastErr
+			}
+		}
+		resp, err := callOnce(ctx, client, tokens, url, body, timeout)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// callOnce sends body to url once. With OAuth2 configured, a 401 response drops the cached access
+// token and the call is repeated with a new one.
+func callOnce(ctx context.Context, client *http.Client, tokens *tokenSource, url string, body []byte,
+	timeout time.Duration) (*response, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	httpResp, token, err := send(ctx, client, tokens, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode == http.StatusUnauthorized && tokens != nil {
+		httpResp.Body.Close()
+		tokens.Invalidate(token)
+		if httpResp, _, err = send(ctx, client, tokens, url, body); err != nil {
+			return nil, err
+		}
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("extension responded with status %d", httpResp.StatusCode)
+	}
+
+	var resp response
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("invalid extension response: %w", err)
+	}
+	return &resp, nil
+}
+
+// send posts body to url, with a bearer token from tokens when set, and returns the response and the token used
+func send(ctx context.Context, client *http.Client, tokens *tokenSource, url string, body []byte) (*http.Response, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set(constants.HeaderContentType, "application/json")
+	if traceID, ok := ctx.Value(log.ContextKeyTraceID).(string); ok {
+		req.Header.Set(constants.CorrelationIDHeaderName, traceID)
+	}
+	tracing.Inject(ctx, req.Header)
+
+	var token string
+	if tokens != nil {
+		if token, err = tokens.Token(ctx); err != nil {
+			return nil, "", err
+		}
+		req.Header.Set(constants.HeaderAuthorization, "Bearer "+token)
+	}
+
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	return httpResp, token, nil
+}
+
+// apply applies an extension response to the payload target points to
+func apply(resp *response, target reflect.Value) error {
+	switch resp.Status {
+	case statusError:
+		rejected := &RejectedError{
+			StatusCode:  resp.ErrorCode,
+			Message:     resp.ErrorMessage,
+			Description: resp.ErrorDescription,
+		}
+		if rejected.StatusCode < 400 || rejected.StatusCode > 499 {
+			rejected.StatusCode = http.StatusBadRequest
+		}
+		if rejected.Message == "" {
+			rejected.Message = "Rejected by service extension"
+		}
+		return rejected
+	case statusSuccess:
+	default:
+		return fmt.Errorf("invalid extension response status '%s'", resp.Status)
+	}
+
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return nil
+	}
+	replacement := reflect.New(target.Elem().Type())
+	if err := json.Unmarshal(resp.Data, replacement.Interface()); err != nil {
+		return fmt.Errorf("invalid extension response data: %w", err)
+	}
+	target.Elem().Set(replacement.Elem())
+	return nil
+}
+
+// FailsOpen reports whether an operation continues without hook when the extension cannot be
+// reached or answers with an invalid response
+func FailsOpen(hook Hook) bool {
+	return failsOpen(hook, config.Get().ServiceExtension.Hooks[string(hook)])
+}
+
+// failsOpen reports whether hook continues without the extension when it cannot be reached.
+// Pre-process and policy hooks fail closed unless configured otherwise.
+func failsOpen(hook Hook, hookConfig config.ExtensionHookConfig) bool {
+	switch hookConfig.FailurePolicy {
+	case config.ExtensionFailOpen:
+		return true
+	case config.ExtensionFailClosed:
+		return false
+	}
+	return !strings.HasPrefix(string(hook), "pre_process_") && hook != EvaluateValidationPolicy
+}
@@ -0,0 +1,198 @@
+package extension
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// errCircuitOpen is returned for calls to a hook whose circuit is open
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// HookStats holds the invocation metrics of a hook since the server started
+type HookStats struct {
+	Hook         string `json:"hook"`
+	Mode         string `json:"mode"`
+	CircuitState string `json:"circuitState"`
+	// Calls counts invocations, including those short-circuited or dropped
+	Calls     int64 `json:"calls"`
+	Succeeded int64 `json:"succeeded"`
+	Rejected  int64 `json:"rejected"`
+	// Failed counts invocations that did not complete, whatever the failure policy did with them
+	Failed         int64 `json:"failed"`
+	Retries        int64 `json:"retries"`
+	ShortCircuited int64 `json:"shortCircuited"`
+	AsyncDropped   int64 `json:"asyncDropped"`
+	// Latencies cover invocations that reached the extension, retries included
+	AverageLatencyMillis float64 `json:"averageLatencyMillis"`
+	MaxLatencyMillis     int64   `json:"maxLatencyMillis"`
+}
+
+// hookState holds the circuit breaker and metrics of a hook
+type hookState struct {
+	calls          atomic.Int64
+	succeeded      atomic.Int64
+	rejected       atomic.Int64
+	failed         atomic.Int64
+	retries        atomic.Int64
+	shortCircuited atomic.Int64
+	asyncDropped   atomic.Int64
+	sent           atomic.Int64
+	latencyTotal   atomic.Int64 // milliseconds
+	latencyMax     atomic.Int64 // milliseconds
+
+	mu       sync.Mutex
+	circuit  string
+	failures int // consecutive failed calls
+	openedAt time.Time
+}
+
+var (
+	hookStatesMu sync.Mutex
+	hookStates   = map[Hook]*hookState{}
+)
+
+// stateFor returns the state of hook, creating it on first use
+func stateFor(hook Hook) *hookState {
+	hookStatesMu.Lock()
+	defer hookStatesMu.Unlock()
+	state, ok := hookStates[hook]
+	if !ok {
+		state = &hookState{circuit: CircuitClosed}
+		hookStates[hook] = state
+	}
+	return state
+}
+
+// allow reports whether a call may be sent. An open circuit lets a single trial call through once
+// its open duration has passed; other calls are refused until the trial completes.
+func (s *hookState) allow(breaker config.ExtensionCircuitBreakerConfig) bool {
+	if breaker.FailureThreshold <= 0 {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch s.circuit {
+	case CircuitOpen:
+		if time.Since(s.openedAt) < breaker.GetOpenDuration() {
+			return false
+		}
+		s.circuit = CircuitHalfOpen
+		return true
+	case CircuitHalfOpen:
+		return false
+	}
+	return true
+}
+
+// recordCall records the outcome of a call that was sent. A success closes the circuit; a failed
+// trial, or reaching the failure threshold, opens it.
+func (s *hookState) recordCall(breaker config.ExtensionCircuitBreakerConfig, latency time.Duration, err error) {
+	millis := latency.Milliseconds()
+	s.sent.Add(1)
+	s.latencyTotal.Add(millis)
+	for {
+		current := s.latencyMax.Load()
+		if millis <= current || s.latencyMax.CompareAndSwap(current, millis) {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil || breaker.FailureThreshold <= 0 {
+		s.circuit = CircuitClosed
+		s.failures = 0
+		return
+	}
+	s.failures++
+	if s.circuit == CircuitHalfOpen || s.failures >= breaker.FailureThreshold {
+		s.circuit = CircuitOpen
+		s.openedAt = time.Now()
+	}
+}
+
+// stats returns the metrics of the hook
+func (s *hookState) stats(hook Hook, mode string) HookStats {
+	s.mu.Lock()
+	circuit := s.circuit
+	s.mu.Unlock()
+
+	stats := HookStats{
+		Hook:             string(hook),
+		Mode:             mode,
+		CircuitState:     circuit,
+		Calls:            s.calls.Load(),
+		Succeeded:        s.succeeded.Load(),
+		Rejected:         s.rejected.Load(),
+		Failed:           s.failed.Load(),
+		Retries:          s.retries.Load(),
+		ShortCircuited:   s.shortCircuited.Load(),
+		AsyncDropped:     s.asyncDropped.Load(),
+		MaxLatencyMillis: s.latencyMax.Load(),
+	}
+	if sent := s.sent.Load(); sent > 0 {
+		stats.AverageLatencyMillis = float64(s.latencyTotal.Load()) / float64(sent)
+	}
+	return stats
+}
+
+// Stats returns the invocation metrics of the enabled hooks
+func Stats() []HookStats {
+	cfg := config.Get().ServiceExtension
+	stats := make([]HookStats, 0, len(hooks))
+	for _, hook := range hooks {
+		if !IsEnabled(hook) {
+			continue
+		}
+		mode := config.ExtensionModeSync
+		if cfg.IsAsync(string(hook)) {
+			mode = config.ExtensionModeAsync
+		}
+		stats = append(stats, stateFor(hook).stats(hook, mode))
+	}
+	return stats
+}
+
+// backoff returns the wait before retry number attempt, counted from 1
+func backoff(cfg *config.ServiceExtensionConfig, attempt int) time.Duration {
+	wait := cfg.GetRetryBackoff()
+	for i := 1; i < attempt && wait < cfg.GetRetryMaxBackoff(); i++ {
+		wait *= 2
+	}
+	return min(wait, cfg.GetRetryMaxBackoff())
+}
+
+var (
+	asyncSlotsOnce sync.Once
+	// asyncSlots bounds the async hook calls running at once
+	asyncSlots chan struct{}
+)
+
+// acquireAsyncSlot reserves a slot for an async call, reporting false when all are taken
+func acquireAsyncSlot(cfg *config.ServiceExtensionConfig) bool {
+	asyncSlotsOnce.Do(func() {
+		asyncSlots = make(chan struct{}, cfg.GetAsyncMaxInFlight())
+	})
+	select {
+	case asyncSlots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// releaseAsyncSlot frees a slot reserved by acquireAsyncSlot
+func releaseAsyncSlot() {
+	<-asyncSlots
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package featureflag decides whether optional behaviours are enabled for an organization.
+// Flag states come from the feature_flags section of the configuration and can be overridden
+// per organization at runtime through the admin API, so a behaviour can be rolled out tenant by
+// tenant and rolled back without redeploying.
+package featureflag
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// Flag is the name of a feature flag
+type Flag string
+
+const (
+	// StrictValidation applies additional checks to consent create and update requests
+	StrictValidation Flag = "strict_validation"
+	// PurposeEnforcement requires mandatory purposes to be approved by the user before a
+	// consent becomes active
+	PurposeEnforcement Flag = "purpose_enforcement"
+	// StatusMachine enforces the configured consent status transitions
+	StatusMachine Flag = "status_machine"
+	// RequestValidation rejects requests that do not match the OpenAPI document of the API
+	RequestValidation Flag = "request_validation"
+)
+
+// Source describes where the state of a flag came from
+type Source string
+
+const (
+	SourceDefault  Source = "default"  // Built in default, the flag is disabled
+	SourceConfig   Source = "config"   // feature_flags section of the configuration
+	SourceOverride Source = "override" // Runtime override set through the admin API
+)
+
+// ErrUnknownFlag is returned for flag names that are not defined
+var ErrUnknownFlag = errors.New("unknown feature flag")
+
+// State is the effective state of a flag for an organization
+type State struct {
+	Flag    Flag
+	Enabled bool
+	Source  Source
+}
+
+var knownFlags = []Flag{StrictValidation, PurposeEnforcement, StatusMachine, RequestValidation}
+
+var (
+	mu        sync.RWMutex
+	defaults  = map[Flag]bool{}
+	orgConfig = map[string]map[Flag]bool{}
+	overrides = map[string]map[Flag]bool{}
+)
+
+// Initialize loads flag states from the configuration. Runtime overrides are cleared.
+func Initialize(cfg config.FeatureFlagsConfig) error {
+	newDefaults, err := toFlags(cfg.Defaults)
+	if err != nil {
+		return err
+	}
+	newOrgConfig := make(map[string]map[Flag]bool, len(cfg.Orgs))
+	for _, org := range cfg.Orgs {
+		flags, err := toFlags(org.Flags)
+		if err != nil {
+			return err
+		}
+		if newOrgConfig[org.OrgID] == nil {
+			newOrgConfig[org.OrgID] = map[Flag]bool{}
+		}
+		for flag, enabled := range flags {
+			newOrgConfig[org.OrgID][flag] = enabled
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	defaults = newDefaults
+	orgConfig = newOrgConfig
+	overrides = map[string]map[Flag]bool{}
+	return nil
+}
+
+// IsEnabled reports whether a flag is enabled for an organization
+func IsEnabled(orgID string, flag Flag) bool {
+	return Get(orgID, flag).Enabled
+}
+
+// Get returns the effective state of a flag for an organization. A runtime override wins over
+// the organization's configuration, which wins over the configured default.
+func Get(orgID string, flag Flag) State {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if enabled, ok := overrides[orgID][flag]; ok {
+		return State{Flag: flag, Enabled: enabled, Source: SourceOverride}
+	}
+	if enabled, ok := orgConfig[orgID][flag]; ok {
+		return State{Flag: flag, Enabled: enabled, Source: SourceConfig}
+	}
+	if enabled, ok := defaults[flag]; ok {
+		return State{Flag: flag, Enabled: enabled, Source: SourceConfig}
+	}
+	return State{Flag: flag, Enabled: false, Source: SourceDefault}
+}
+
+// List returns the effective state of every flag for an organization, ordered by flag name.
+// An empty orgID returns the configured defaults.
+func List(orgID string) []State {
+	states := make([]State, 0, len(knownFlags))
+	for _, flag := range knownFlags {
+		states = append(states, Get(orgID, flag))
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Flag < states[j].Flag })
+	return states
+}
+
+// SetOverride overrides a flag for an organization until it is cleared or the server restarts
+func SetOverride(orgID string, flag Flag, enabled bool) error {
+	if !IsKnown(flag) {
+		return ErrUnknownFlag
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if overrides[orgID] == nil {
+		overrides[orgID] = map[Flag]bool{}
+	}
+	overrides[orgID][flag] = enabled
+	return nil
+}
+
+// ClearOverride removes the runtime override of a flag for an organization. It reports whether
+// an override was present.
+func ClearOverride(orgID string, flag Flag) (bool, error) {
+	if !IsKnown(flag) {
+		return false, ErrUnknownFlag
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := overrides[orgID][flag]; !ok {
+		return false, nil
+	}
+	delete(overrides[orgID], flag)
+	if len(overrides[orgID]) == 0 {
+		delete(overrides, orgID)
+	}
+	return true, nil
+}
+
+// IsKnown reports whether flag is a defined feature flag
+func IsKnown(flag Flag) bool {
+	for _, known := range knownFlags {
+		if known == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func toFlags(values map[string]bool) (map[Flag]bool, error) {
+	flags := make(map[Flag]bool, len(values))
+	for name, enabled := range values {
+		flag := Flag(name)
+		if name == "scope_authorization" {
+			// Moved out of the feature flags so that the admin API cannot turn authorization off
+			return nil, fmt.Errorf("%w: %s, configure security.authorization.enabled instead", ErrUnknownFlag, name)
+		}
+		if !IsKnown(flag) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownFlag, name)
+		}
+		flags[flag] = enabled
+	}
+	return flags, nil
+}
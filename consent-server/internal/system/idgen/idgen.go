@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package idgen generates resource IDs with the configured strategy.
+package idgen
+
+import (
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// Generator creates IDs for an organization
+type Generator interface {
+	NewID(orgID string) string
+}
+
+// generator prefixes the IDs of a strategy with the prefix of the organization
+type generator struct {
+	cfg      config.ConsentIDConfig
+	generate func() string
+}
+
+// NewConsentIDGenerator creates the generator of consent IDs described by the configuration.
+// Unknown strategies fall back to UUIDs; the configuration is validated at startup.
+func NewConsentIDGenerator(cfg config.ConsentIDConfig) Generator {
+	g := &generator{cfg: cfg, generate: utils.GenerateUUID}
+	if cfg.Strategy == config.IDStrategyULID {
+		g.generate = utils.GenerateULID
+	}
+	return g
+}
+
+// NewID returns a new ID for the organization
+func (g *generator) NewID(orgID string) string {
+	return g.cfg.GetPrefix(orgID) + g.generate()
+}
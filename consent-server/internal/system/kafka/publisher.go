@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/events"
+)
+
+const (
+	// contentType describes the encoding of event values
+	contentType     = "application/json"
+	defaultClientID = "consent-server"
+)
+
+// EventPublisher publishes consent events to a Kafka topic. It implements events.Publisher.
+type EventPublisher struct {
+	client *kgo.Client
+}
+
+// NewEventPublisher creates a publisher. Brokers are contacted on the first event.
+func NewEventPublisher(cfg config.KafkaConfig) (*EventPublisher, error) {
+	opts, err := clientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	return &EventPublisher{client: client}, nil
+}
+
+// clientOptions returns the options of a client that produces to the configured topic. Records
+// are assigned to partitions with the murmur2 hash of their key, like the Java client does, and
+// each publish is given up after the configured timeout.
+func clientOptions(cfg config.KafkaConfig) ([]kgo.Opt, error) {
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = defaultClientID
+	}
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.Brokers...),
+		kgo.DefaultProduceTopic(cfg.Topic),
+		kgo.ClientID(clientID),
+		kgo.RecordPartitioner(kgo.StickyKeyPartitioner(nil)),
+		kgo.DialTimeout(cfg.Timeout),
+		kgo.ProduceRequestTimeout(cfg.Timeout),
+		kgo.RecordDeliveryTimeout(cfg.Timeout),
+	}
+	if cfg.Acks == config.KafkaAcksLeader {
+		// Idempotent writes require acknowledgements from every in-sync replica
+		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()), kgo.DisableIdempotentWrite())
+	} else {
+		opts = append(opts, kgo.RequiredAcks(kgo.AllISRAcks()))
+	}
+
+	switch cfg.SASL.Mechanism {
+	case "":
+	case config.KafkaSASLPlain:
+		auth := plain.Auth{User: cfg.SASL.Username, Pass: cfg.SASL.Password}
+		opts = append(opts, kgo.SASL(auth.AsMechanism()))
+	case config.KafkaSASLScramSHA256:
+		auth := scram.Auth{User: cfg.SASL.Username, Pass: cfg.SASL.Password}
+		opts = append(opts, kgo.SASL(auth.AsSha256Mechanism()))
+	case config.KafkaSASLScramSHA512:
+		auth := scram.Auth{User: cfg.SASL.Username, Pass: cfg.SASL.Password}
+		opts = append(opts, kgo.SASL(auth.AsSha512Mechanism()))
+	default:
+		return nil, fmt.Errorf("kafka: unsupported SASL mechanism %s", cfg.SASL.Mechanism)
+	}
+
+	if cfg.TLS.Enabled {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: cfg.TLS.InsecureSkipVerify,
+		}
+		if cfg.TLS.CAFile != "" {
+			pem, err := os.ReadFile(cfg.TLS.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read kafka CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("kafka CA file %s contains no certificates", cfg.TLS.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+	return opts, nil
+}
+
+// Publish sends an event and waits for the brokers to acknowledge it. The event is keyed by
+// consent ID, so the events of a consent are delivered in order, and its headers carry the schema
+// version and event type.
+func (p *EventPublisher) Publish(ctx context.Context, event events.ConsentEvent) error {
+	record, err := newRecord(event)
+	if err != nil {
+		return err
+	}
+	return p.client.ProduceSync(ctx, record).FirstErr()
+}
+
+// newRecord encodes an event as a record of the default topic
+func newRecord(event events.ConsentEvent) (*kgo.Record, error) {
+	value, err := events.Serialize(event)
+	if err != nil {
+		return nil, err
+	}
+	return &kgo.Record{
+		Key:   []byte(event.ConsentID),
+		Value: value,
+		Headers: []kgo.RecordHeader{
+			{Key: "schema-version", Value: []byte(events.SchemaVersion)},
+			{Key: "event-type", Value: []byte(event.Type)},
+			{Key: "content-type", Value: []byte(contentType)},
+		},
+		Timestamp: time.UnixMilli(event.Timestamp),
+	}, nil
+}
@@ -0,0 +1,86 @@
+package kafka
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/events"
+)
+
+// TestPartitioner checks that keys are assigned to the partitions of the Java client, which hashes
+// them with murmur2, so consent events land where other producers would put the same key
+func TestPartitioner(t *testing.T) {
+	hashes := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	}
+	partitioner := kgo.StickyKeyPartitioner(nil).ForTopic("consent-events")
+	for key, hash := range hashes {
+		want := int(uint32(hash)&0x7fffffff) % 12
+		if got := partitioner.Partition(&kgo.Record{Key: []byte(key)}, 12); got != want {
+			t.Errorf("partition of %q = %d, want %d", key, got, want)
+		}
+	}
+}
+
+// TestClientOptions checks the configurations a client can and cannot be created with
+func TestClientOptions(t *testing.T) {
+	base := config.KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "consent-events", Timeout: time.Second}
+
+	for _, mechanism := range []string{"", config.KafkaSASLPlain, config.KafkaSASLScramSHA256, config.KafkaSASLScramSHA512} {
+		cfg := base
+		cfg.Acks = config.KafkaAcksLeader
+		cfg.SASL = config.KafkaSASLConfig{Mechanism: mechanism, Username: "user", Password: "secret"}
+		publisher, err := NewEventPublisher(cfg)
+		if err != nil {
+			t.Fatalf("expected SASL mechanism %q to be accepted, got %v", mechanism, err)
+		}
+		publisher.client.Close()
+	}
+
+	cfg := base
+	cfg.SASL.Mechanism = "GSSAPI"
+	if _, err := clientOptions(cfg); err == nil {
+		t.Error("expected an unsupported SASL mechanism to be rejected")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg = base
+	cfg.TLS = config.KafkaTLSConfig{Enabled: true, CAFile: caFile}
+	if _, err := clientOptions(cfg); err == nil {
+		t.Error("expected a CA file without certificates to be rejected")
+	}
+}
+
+// TestNewRecord checks that events are keyed by consent ID and describe themselves in headers
+func TestNewRecord(t *testing.T) {
+	record, err := newRecord(events.ConsentEvent{
+		ID: "e-1", Type: "consent.created", Timestamp: 1700000000000, OrgID: "org", ConsentID: "c-1",
+	})
+	if err != nil {
+		t.Fatalf("newRecord() error = %v", err)
+	}
+	if string(record.Key) != "c-1" || !record.Timestamp.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("unexpected key %q or timestamp %v", record.Key, record.Timestamp)
+	}
+	headers := map[string]string{}
+	for _, header := range record.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	if headers["schema-version"] != events.SchemaVersion || headers["event-type"] != "consent.created" ||
+		headers["content-type"] != contentType {
+		t.Errorf("unexpected headers %v", headers)
+	}
+}
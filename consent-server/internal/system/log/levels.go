@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// levels holds the level of the server log and the levels set for individual components. Both can
+// be changed at runtime, and the change applies to loggers that were already created.
+type levels struct {
+	root       slog.LevelVar
+	mu         sync.RWMutex
+	components map[string]slog.Level
+}
+
+var logLevels = &levels{components: make(map[string]slog.Level)}
+
+// levelOf returns the level a component logs at, the server level unless the component has its own
+func (l *levels) levelOf(component string) slog.Level {
+	if component != "" {
+		l.mu.RLock()
+		level, ok := l.components[component]
+		l.mu.RUnlock()
+		if ok {
+			return level
+		}
+	}
+	return l.root.Level()
+}
+
+// levelHandler filters records by the level of the component the logger was created for, which is
+// taken from the component field added with Logger.With
+type levelHandler struct {
+	handler   slog.Handler
+	component string
+}
+
+// Enabled reports whether the component logs records of the level
+func (h *levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= logLevels.levelOf(h.component)
+}
+
+// Handle writes the record with the wrapped handler
+func (h *levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler with the attributes, for the component they name if any
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	component := h.component
+	for _, attr := range attrs {
+		if attr.Key == LoggerKeyComponentName {
+			component = attr.Value.String()
+		}
+	}
+	return &levelHandler{handler: h.handler.WithAttrs(attrs), component: component}
+}
+
+// WithGroup returns a handler that nests later attributes in the group
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{handler: h.handler.WithGroup(name), component: h.component}
+}
+
+// GetLogLevel returns the level of the server log
+func GetLogLevel() string {
+	return levelName(logLevels.root.Level())
+}
+
+// SetComponentLogLevel sets the level of a component, such as DBClient, overriding the server level
+func SetComponentLogLevel(component, logLevel string) error {
+	level, err := parseLogLevel(logLevel)
+	if err != nil {
+		return err
+	}
+	logLevels.mu.Lock()
+	defer logLevels.mu.Unlock()
+	logLevels.components[component] = level
+	return nil
+}
+
+// ClearComponentLogLevel returns a component to the server level. It reports whether the component
+// had its own level.
+func ClearComponentLogLevel(component string) bool {
+	logLevels.mu.Lock()
+	defer logLevels.mu.Unlock()
+	_, ok := logLevels.components[component]
+	delete(logLevels.components, component)
+	return ok
+}
+
+// GetComponentLogLevels returns the components that have their own level, with their levels
+func GetComponentLogLevels() map[string]string {
+	logLevels.mu.RLock()
+	defer logLevels.mu.RUnlock()
+	components := make(map[string]string, len(logLevels.components))
+	for component, level := range logLevels.components {
+		components[component] = levelName(level)
+	}
+	return components
+}
+
+// levelName returns the lower case name of a level, such as info or debug-2
+func levelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
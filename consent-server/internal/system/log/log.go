@@ -22,7 +22,9 @@ package log
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
+	"math"
 	"os"
 	"strings"
 	"sync"
@@ -38,6 +40,8 @@ const (
 var (
 	logger *Logger
 	once   sync.Once
+	// output receives the log records
+	output io.Writer = os.Stdout
 )
 
 // Logger is a wrapper around the slog logger.
@@ -56,7 +60,7 @@ func GetLogger() *Logger {
 	return logger
 }
 
-// SetLogLevel updates the log level dynamically.
+// SetLogLevel updates the log level dynamically, including for loggers that were already created.
 // This should be called after configuration is loaded.
 func SetLogLevel(logLevel string) error {
 	if logger == nil {
@@ -68,17 +72,17 @@ func SetLogLevel(logLevel string) error {
 		return errors.New("error parsing log level: " + err.Error())
 	}
 
-	handlerOptions := &slog.HandlerOptions{
-		Level: level,
-	}
+	logLevels.root.Set(level)
+	return nil
+}
 
-	logHandler := slog.NewTextHandler(os.Stdout, handlerOptions)
-	if logHandler == nil {
-		return errors.New("failed to create log handler")
+// SetOutput sends log records to w instead of stdout. It must be called before the logger is first
+// used, since loggers derived with With keep writing to the output they were created with.
+func SetOutput(w io.Writer) {
+	output = w
+	if logger != nil {
+		logger.internal = newSlogLogger()
 	}
-
-	logger.internal = slog.New(logHandler)
-	return nil
 }
 
 // initLogger initializes the slog logger.
@@ -94,22 +98,24 @@ func initLogger() error {
 		return errors.New("error parsing log level: " + err.Error())
 	}
 
-	handlerOptions := &slog.HandlerOptions{
-		Level: level,
-	}
-
-	logHandler := slog.NewTextHandler(os.Stdout, handlerOptions)
-	if logHandler == nil {
-		return errors.New("failed to create log handler")
-	}
+	logLevels.root.Set(level)
 
 	logger = &Logger{
-		internal: slog.New(logHandler),
+		internal: newSlogLogger(),
 	}
 
 	return nil
 }
 
+// newSlogLogger creates the slog logger writing to the log output. The text handler writes every
+// record; levelHandler filters them by the current levels.
+func newSlogLogger() *slog.Logger {
+	handlerOptions := &slog.HandlerOptions{
+		Level: slog.Level(math.MinInt),
+	}
+	return slog.New(&levelHandler{handler: slog.NewTextHandler(output, handlerOptions)})
+}
+
 // With creates a new logger instance with additional fields.
 func (l *Logger) With(fields ...Field) *Logger {
 	return &Logger{
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/featureflag"
+)
+
+// setAuthorizationConfig configures a reader granted consents:read and the given authorization
+func setAuthorizationConfig(t *testing.T, authorization config.AuthorizationConfig) {
+	config.SetGlobal(&config.Config{Security: config.SecurityConfig{
+		BasicAuth: config.BasicAuthConfig{Enabled: true, Users: []config.BasicAuthUser{
+			{Username: "reader", Password: "reader", Scopes: []string{ScopeConsentsRead}},
+		}},
+		Authorization: authorization,
+	}})
+	t.Cleanup(func() { config.SetGlobal(nil) })
+}
+
+// TestAuthorizeScope_FollowsTheDeploymentConfig checks that routes are authorized for the
+// organizations security.authorization applies to
+func TestAuthorizeScope_FollowsTheDeploymentConfig(t *testing.T) {
+	const route = "PUT /consents/{consentId}/revoke"
+
+	testCases := []struct {
+		name          string
+		authorization config.AuthorizationConfig
+		orgID         string
+		wantCode      string
+	}{
+		{"disabled", config.AuthorizationConfig{}, "org-1", ""},
+		{"enabled for every organization", config.AuthorizationConfig{Enabled: true}, "org-1", serviceerror.ForbiddenError.Code},
+		{"enabled for the organization", config.AuthorizationConfig{Enabled: true, OrgIDs: []string{"org-1"}}, "org-1", serviceerror.ForbiddenError.Code},
+		{"enabled for other organizations", config.AuthorizationConfig{Enabled: true, OrgIDs: []string{"org-2"}}, "org-1", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setAuthorizationConfig(t, tc.authorization)
+			serviceErr := AuthorizeScope(context.Background(), tc.orgID, route, ScopeConsentsRevoke, "reader", "reader", true)
+			code := ""
+			if serviceErr != nil {
+				code = serviceErr.Code
+			}
+			if code != tc.wantCode {
+				t.Errorf("expected %q, got %+v", tc.wantCode, serviceErr)
+			}
+		})
+	}
+}
+
+// TestAuthorizeScope_CannotBeDisabledAtRuntime checks that authorization is not a feature flag the
+// admin API can override
+func TestAuthorizeScope_CannotBeDisabledAtRuntime(t *testing.T) {
+	setAuthorizationConfig(t, config.AuthorizationConfig{Enabled: true})
+
+	if err := featureflag.SetOverride("org-1", "scope_authorization", false); !errors.Is(err, featureflag.ErrUnknownFlag) {
+		t.Errorf("expected scope_authorization to be an unknown flag, got %v", err)
+	}
+	serviceErr := AuthorizeScope(context.Background(), "org-1", "GET /consents", ScopeConsentsRead, "", "", false)
+	if serviceErr == nil || serviceErr.Code != serviceerror.UnauthorizedError.Code {
+		t.Errorf("expected anonymous calls to be rejected, got %+v", serviceErr)
+	}
+	if err := featureflag.Initialize(config.FeatureFlagsConfig{Defaults: map[string]bool{"scope_authorization": false}}); err == nil {
+		t.Error("expected scope_authorization in the feature flag configuration to be rejected")
+	}
+}
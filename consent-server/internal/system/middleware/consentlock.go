@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/consentlock"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+)
+
+// WrapWithConsentLockToken passes the consent lock token sent in the Consent-Lock-Token header on
+// to the services, so the caller holding a consent's lock can change the consent while it is locked
+func WrapWithConsentLockToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get(constants.HeaderConsentLockToken); token != "" {
+			r = r.WithContext(consentlock.WithToken(r.Context(), token))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/wso2/consent-management-api/internal/system/audit"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// WithOperationAudit records every call to a route as an audited operation with the caller and
+// the outcome. It should wrap the route's authorization so rejected calls are audited too.
+func WithOperationAudit(action audit.Action, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		clientID := r.Header.Get(constants.HeaderTPPClientID)
+		actor, _, ok := r.BasicAuth()
+		if !ok || actor == "" {
+			actor = clientID
+		}
+
+		op := &audit.Operation{
+			Action:    action,
+			OrgID:     r.Header.Get(constants.HeaderOrgID),
+			ConsentID: r.PathValue("consentId"),
+			Actor:     actor,
+			ClientID:  clientID,
+			Method:    r.Method,
+			Route:     routeKey(r.Pattern),
+			Time:      utils.GetCurrentTimeMillis(),
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		handler(recorder, r.WithContext(audit.WithOperation(r.Context(), op)))
+
+		op.StatusCode = recorder.status
+		op.Outcome = audit.OutcomeSuccess
+		if recorder.status >= http.StatusBadRequest {
+			op.Outcome = audit.OutcomeFailure
+		}
+		audit.Record(r.Context(), op)
+	}
+}
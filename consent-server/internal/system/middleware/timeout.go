@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/error/codes"
+	"github.com/wso2/consent-management-api/internal/system/error/serviceerror"
+	"github.com/wso2/consent-management-api/internal/system/utils"
+)
+
+// WrapWithRequestTimeout bounds how long next handles a request, using the timeout configured for
+// the route matched by mux. The request context is cancelled at the deadline, so store queries made
+// for the request abort. A request whose response has not started by then is answered with 504;
+// a response already being streamed is left to the handler, which sees the cancelled context.
+func WrapWithRequestTimeout(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		timeout := config.Get().Server.GetRequestTimeout(pattern)
+		if timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Tracing names spans after the route recorded on the request it passed on, while the mux
+		// records it on the copy made here
+		r.Pattern = pattern
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+
+		tw := &timeoutWriter{w: w, header: make(http.Header), ctx: ctx}
+		done := make(chan struct{})
+		panicked := make(chan interface{}, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- p
+				}
+			}()
+			next.ServeHTTP(tw, r)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case p := <-panicked:
+			panic(p)
+		case <-ctx.Done():
+		}
+
+		tw.mu.Lock()
+		if tw.wroteHeader {
+			// The response already started, so it can only end when the handler returns
+			tw.mu.Unlock()
+			select {
+			case <-done:
+			case p := <-panicked:
+				panic(p)
+			}
+			return
+		}
+		if ctx.Err() == nil {
+			// The handler returned in time without writing a response
+			tw.mu.Unlock()
+			return
+		}
+		tw.timedOut = true
+		tw.mu.Unlock()
+
+		utils.SendError(w, r, serviceerror.NewServiceError(codes.RequestTimeout, serviceerror.ServerErrorType,
+			"Request Timeout", fmt.Sprintf("request was not handled within %s", timeout)))
+	})
+}
+
+// timeoutWriter holds back the handler's headers until it writes the response, so a timeout can
+// still answer the request. Responses the handler starts after the deadline, such as the error of
+// a cancelled store query, are dropped in favour of the timeout response.
+type timeoutWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+	ctx    context.Context
+
+	mu          sync.Mutex
+	wroteHeader bool
+	timedOut    bool
+}
+
+// Header returns the headers the handler sets for its response
+func (t *timeoutWriter) Header() http.Header {
+	return t.header
+}
+
+// WriteHeader sends the handler's headers and status code unless the request timed out
+func (t *timeoutWriter) WriteHeader(code int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.writeHeaderLocked(code)
+}
+
+// Write sends the response body, or fails once the request timed out
+func (t *timeoutWriter) Write(b []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.writeHeaderLocked(http.StatusOK)
+	if t.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return t.w.Write(b)
+}
+
+// FlushError flushes the response streamed so far, for http.ResponseController
+func (t *timeoutWriter) FlushError() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.writeHeaderLocked(http.StatusOK)
+	if t.timedOut {
+		return http.ErrHandlerTimeout
+	}
+	return http.NewResponseController(t.w).Flush()
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController
+func (t *timeoutWriter) Unwrap() http.ResponseWriter {
+	return t.w
+}
+
+func (t *timeoutWriter) writeHeaderLocked(code int) {
+	if t.timedOut || t.wroteHeader {
+		return
+	}
+	if t.ctx.Err() != nil {
+		t.timedOut = true
+		return
+	}
+	t.wroteHeader = true
+	for key, values := range t.header {
+		t.w.Header()[key] = values
+	}
+	t.w.WriteHeader(code)
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/error/codes"
+)
+
+// newTimeoutTestServer serves a slow route that waits for its request to be cancelled, a fast route
+// and a slow route whose timeout is disabled
+func newTimeoutTestServer(t *testing.T) (http.Handler, chan error) {
+	config.SetGlobal(&config.Config{Server: config.ServerConfig{
+		RequestTimeout: 50 * time.Millisecond,
+		RouteTimeouts:  []config.RouteTimeout{{Route: "GET /unbounded", Timeout: 0}},
+	}})
+	t.Cleanup(func() { config.SetGlobal(nil) })
+
+	cancelled := make(chan error, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		cancelled <- r.Context().Err()
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /fast", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Handled", "true")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("done"))
+	})
+	mux.HandleFunc("GET /unbounded", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return WrapWithRequestTimeout(mux, mux), cancelled
+}
+
+// TestRequestTimeout_CancelsSlowRequests checks that a request running past its timeout is
+// answered with 504 and that the handler sees its context cancelled
+func TestRequestTimeout_CancelsSlowRequests(t *testing.T) {
+	handler, cancelled := newTimeoutTestServer(t)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	if recorder.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected status 504, got %d", recorder.Code)
+	}
+	var body struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("expected a JSON error body: %v", err)
+	}
+	if body.Code != codes.RequestTimeout {
+		t.Errorf("expected error code %s, got %s", codes.RequestTimeout, body.Code)
+	}
+
+	select {
+	case err := <-cancelled:
+		if err == nil {
+			t.Error("expected the request context to be cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler did not see its request cancelled")
+	}
+}
+
+// TestRequestTimeout_PassesFastResponses checks that responses written in time reach the client
+// unchanged
+func TestRequestTimeout_PassesFastResponses(t *testing.T) {
+	handler, _ := newTimeoutTestServer(t)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/fast", nil))
+
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", recorder.Code)
+	}
+	if recorder.Header().Get("X-Handled") != "true" {
+		t.Error("expected the handler's headers in the response")
+	}
+	if recorder.Body.String() != "done" {
+		t.Errorf("expected body 'done', got '%s'", recorder.Body.String())
+	}
+}
+
+// TestRequestTimeout_RouteOverrideDisablesTimeout checks that a route with a zero timeout runs
+// without a deadline
+func TestRequestTimeout_RouteOverrideDisablesTimeout(t *testing.T) {
+	handler, _ := newTimeoutTestServer(t)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/unbounded", nil))
+
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+// emailNotifier emails expiry notices over SMTP to the address stored in a consent attribute
+type emailNotifier struct {
+	cfg     config.ExpiryNotificationEmail
+	timeout time.Duration
+}
+
+// NewEmailNotifier creates a notifier that emails expiry notices. Consents without the recipient
+// attribute are skipped.
+func NewEmailNotifier(cfg config.ExpiryNotificationEmail, timeout time.Duration) Notifier {
+	return &emailNotifier{cfg: cfg, timeout: timeout}
+}
+
+// NotifyExpiry emails the notice to the consent's recipient address
+func (n *emailNotifier) NotifyExpiry(ctx context.Context, notice ExpiryNotice) error {
+	recipient := notice.Event.Attributes[n.cfg.RecipientAttribute]
+	if recipient == "" {
+		return nil
+	}
+	// Parsing rejects line breaks, so attribute values cannot inject headers
+	to, err := mail.ParseAddress(recipient)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address in attribute '%s': %w", n.cfg.RecipientAttribute, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+	return n.send(ctx, to.Address, expiryMessage(n.cfg.From, to.String(), notice))
+}
+
+// send delivers a message to one recipient, upgrading the connection with STARTTLS when the
+// server offers it
+func (n *emailNotifier) send(ctx context.Context, to string, message []byte) error {
+	addr := net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port))
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+	if n.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(n.cfg.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// expiryMessage builds the email of an expiry notice
+func expiryMessage(from, to string, notice ExpiryNotice) []byte {
+	expiry := validityTimeToTime(notice.Event.ValidityTime).UTC().Format("2 January 2006 15:04 MST")
+
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: Your consent expires on " + expiry + "\r\n")
+	b.WriteString("Date: " + time.Now().UTC().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&b, "The consent %s you granted to %s expires on %s.\r\n", notice.Event.ConsentID, notice.Event.ClientID, expiry)
+	b.WriteString("Renew it before then to keep the access it grants.\r\n")
+	return []byte(b.String())
+}
+
+// validityTimeToTime converts a consent validity time, stored in seconds or milliseconds, to a time.
+// Values below 10^11 are seconds, as in validator.IsConsentExpired.
+func validityTimeToTime(validityTime int64) time.Time {
+	if validityTime < 100000000000 {
+		return time.Unix(validityTime, 0)
+	}
+	return time.UnixMilli(validityTime)
+}
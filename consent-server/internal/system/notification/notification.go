@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package notification tells the people and systems acting on consents that a consent is about to
+// expire, so users can be asked to re-authorize before access breaks. Notifiers are pluggable:
+// deployments register their own with Register next to the built-in webhook and email notifiers.
+package notification
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/wso2/consent-management-api/internal/system/events"
+)
+
+// ExpiryNotice describes a consent entering its expiry notice period
+type ExpiryNotice struct {
+	// Event is the consent.expiring_soon event of the notice. Its attributes are not filtered;
+	// notifiers that send the event on must encode it with events.Serialize.
+	Event       events.ConsentEvent
+	ConsentType string
+	// UserIDs are the users who authorized the consent
+	UserIDs []string
+}
+
+// Notifier delivers expiry notices. NotifyExpiry returns an error when the notice could not be
+// delivered; the notice is then retried on the next run, so notifiers may see a notice twice.
+type Notifier interface {
+	NotifyExpiry(ctx context.Context, notice ExpiryNotice) error
+}
+
+var (
+	notifiersMu sync.RWMutex
+	notifiers   []Notifier
+)
+
+// Register adds a notifier that receives every expiry notice
+func Register(n Notifier) {
+	notifiersMu.Lock()
+	defer notifiersMu.Unlock()
+	notifiers = append(notifiers, n)
+}
+
+// NotifyExpiry delivers a notice to every registered notifier and returns their errors joined
+func NotifyExpiry(ctx context.Context, notice ExpiryNotice) error {
+	notifiersMu.RLock()
+	registered := notifiers
+	notifiersMu.RUnlock()
+
+	var errs []error
+	for _, n := range registered {
+		if err := n.NotifyExpiry(ctx, notice); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/constants"
+	"github.com/wso2/consent-management-api/internal/system/events"
+)
+
+// Webhook request headers naming the event carried in the body
+const (
+	headerEventType     = "X-Consent-Event-Type"
+	headerSchemaVersion = "X-Consent-Event-Schema-Version"
+)
+
+// webhookNotifier posts the expiring soon event to the webhook URLs of the consent's organization
+type webhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a notifier that posts expiry notices to organization webhook URLs.
+// Organizations without webhook URLs are skipped.
+func NewWebhookNotifier(timeout time.Duration) Notifier {
+	return &webhookNotifier{client: &http.Client{Timeout: timeout}}
+}
+
+// NotifyExpiry posts the serialized event to each webhook URL of the organization
+func (n *webhookNotifier) NotifyExpiry(ctx context.Context, notice ExpiryNotice) error {
+	overrides, _ := config.GetOrgOverrides(notice.Event.OrgID)
+	if len(overrides.WebhookURLs) == 0 {
+		return nil
+	}
+
+	body, err := events.Serialize(notice.Event)
+	if err != nil {
+		return err
+	}
+	for _, url := range overrides.WebhookURLs {
+		if err := n.post(ctx, url, notice.Event.Type, body); err != nil {
+			return fmt.Errorf("webhook %s: %w", url, err)
+		}
+	}
+	return nil
+}
+
+// post sends an event to a webhook URL and expects a 2xx response
+func (n *webhookNotifier) post(ctx context.Context, url string, eventType events.EventType, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(constants.HeaderContentType, "application/json")
+	req.Header.Set(headerEventType, string(eventType))
+	req.Header.Set(headerSchemaVersion, events.SchemaVersion)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
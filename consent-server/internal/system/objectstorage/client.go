@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package objectstorage reads and writes the objects of a bucket in an S3 compatible object store
+// such as Amazon S3, Google Cloud Storage (with HMAC keys) or MinIO, through the MinIO client.
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// uploadPartSize is the size of the parts of a streamed upload. Object stores allow 10000 parts,
+// so streamed objects are limited to about 160 GiB.
+const uploadPartSize = 16 << 20
+
+// ErrObjectNotFound is returned by Get when the object does not exist
+var ErrObjectNotFound = errors.New("object not found")
+
+// Client reads and writes the objects of a single bucket
+type Client struct {
+	bucket  string
+	timeout time.Duration
+	client  *minio.Client
+}
+
+// NewClient creates a client for a bucket. The endpoint is a URL such as https://s3.amazonaws.com,
+// and objects are addressed path style as <endpoint>/<bucket>/<key>. Each operation is bounded
+// by the timeout.
+func NewClient(endpoint, region, bucket, accessKeyID, secretAccessKey string, timeout time.Duration) (*Client, error) {
+	endpointURL, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || endpointURL.Host == "" || (endpointURL.Scheme != "https" && endpointURL.Scheme != "http") {
+		return nil, fmt.Errorf("invalid object storage endpoint '%s'", endpoint)
+	}
+	if endpointURL.Path != "" {
+		return nil, fmt.Errorf("object storage endpoint '%s' must not have a path", endpoint)
+	}
+
+	client, err := minio.New(endpointURL.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure:       endpointURL.Scheme == "https",
+		Region:       region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the object storage client: %w", err)
+	}
+	return &Client{bucket: bucket, timeout: timeout, client: client}, nil
+}
+
+// Bucket returns the name of the bucket
+func (c *Client) Bucket() string {
+	return c.bucket
+}
+
+// Put uploads data as a single object
+func (c *Client) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := c.client.PutObject(ctx, c.bucket, key, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: contentType}); err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+	return nil
+}
+
+// Upload streams data of unknown length to an object. The data is sent in parts of
+// uploadPartSize, so only one part is held in memory at a time.
+func (c *Client) Upload(ctx context.Context, key string, data io.Reader, contentType string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := c.client.PutObject(ctx, c.bucket, key, data, -1,
+		minio.PutObjectOptions{ContentType: contentType, PartSize: uploadPartSize}); err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+	return nil
+}
+
+// Get downloads an object
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	object, err := c.client.GetObject(ctx, c.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+	return data, nil
+}
+
+// Delete removes an object. Deleting an object that does not exist succeeds.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if err := c.client.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{}); err != nil && !isNotFound(err) {
+		return fmt.Errorf("delete failed: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// isNotFound reports whether an error is the object store's answer for a missing object
+func isNotFound(err error) bool {
+	return minio.ToErrorResponse(err).Code == minio.NoSuchKey
+}
@@ -0,0 +1,156 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeObjectStore serves the object requests of one bucket from memory, including multipart
+// uploads, which are assembled in part number order
+type fakeObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	parts   map[string]map[int][]byte
+}
+
+func (s *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access-key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	query := r.URL.Query()
+	switch r.Method {
+	case http.MethodPost:
+		if query.Has("uploads") {
+			s.parts[r.URL.Path] = map[int][]byte{}
+			_, _ = io.WriteString(w, "<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>")
+			return
+		}
+		var data []byte
+		for number := 1; number <= len(s.parts[r.URL.Path]); number++ {
+			data = append(data, s.parts[r.URL.Path][number]...)
+		}
+		s.objects[r.URL.Path] = data
+		_, _ = io.WriteString(w, `<CompleteMultipartUploadResult><Bucket>exports</Bucket><ETag>"etag"</ETag></CompleteMultipartUploadResult>`)
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		if strings.HasPrefix(r.Header.Get("x-amz-content-sha256"), "STREAMING-") {
+			data = decodeChunked(data)
+		}
+		w.Header().Set("ETag", `"etag"`)
+		if query.Has("partNumber") {
+			number, _ := strconv.Atoi(query.Get("partNumber"))
+			s.parts[r.URL.Path][number] = data
+			return
+		}
+		s.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, "<Error><Code>NoSuchKey</Code><Message>missing</Message></Error>")
+			return
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"etag"`)
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// decodeChunked returns the payload of a body sent with aws-chunked encoding, which signs each
+// chunk as <size>;chunk-signature=<signature>\r\n<data>\r\n
+func decodeChunked(body []byte) []byte {
+	var data []byte
+	for len(body) > 0 {
+		header, rest, _ := strings.Cut(string(body), "\r\n")
+		size, err := strconv.ParseInt(strings.SplitN(header, ";", 2)[0], 16, 64)
+		if err != nil || size == 0 || int64(len(rest)) < size {
+			break
+		}
+		data = append(data, rest[:size]...)
+		body = []byte(strings.TrimPrefix(rest[size:], "\r\n"))
+	}
+	return data
+}
+
+// TestClient_PutsGetsAndDeletesObjects checks that objects are addressed path style in the bucket
+// with signed requests
+func TestClient_PutsGetsAndDeletesObjects(t *testing.T) {
+	store := &fakeObjectStore{objects: map[string][]byte{}, parts: map[string]map[int][]byte{}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "us-east-1", "consents", "access-key", "secret-key", time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := client.Put(ctx, "files/a b.txt", []byte("content"), "text/plain"); err != nil {
+		t.Fatalf("upload failed: %v", err)
+	}
+	if _, ok := store.objects["/consents/files/a b.txt"]; !ok {
+		t.Fatalf("expected the object to be stored under the bucket path, got %v", store.objects)
+	}
+	data, err := client.Get(ctx, "files/a b.txt")
+	if err != nil || string(data) != "content" {
+		t.Fatalf("expected the uploaded content, got %q, %v", data, err)
+	}
+
+	if err := client.Delete(ctx, "files/a b.txt"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := client.Get(ctx, "files/a b.txt"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("expected a deleted object not to be found, got %v", err)
+	}
+}
+
+// TestClient_UploadsStreams checks that data of unknown length is uploaded as it is read
+func TestClient_UploadsStreams(t *testing.T) {
+	store := &fakeObjectStore{objects: map[string][]byte{}, parts: map[string]map[int][]byte{}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "us-east-1", "exports", "access-key", "secret-key", time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		for i := 0; i < 3; i++ {
+			_, _ = io.WriteString(writer, "line\n")
+		}
+		_ = writer.Close()
+	}()
+
+	if err := client.Upload(context.Background(), "export.ndjson", reader, ""); err != nil {
+		t.Fatalf("upload failed: %v", err)
+	}
+	if data := string(store.objects["/exports/export.ndjson"]); data != "line\nline\nline\n" {
+		t.Errorf("expected the streamed content, got %q", data)
+	}
+}
+
+// TestNewClient_RejectsInvalidEndpoints checks that endpoints must be http or https URLs without a path
+func TestNewClient_RejectsInvalidEndpoints(t *testing.T) {
+	for _, endpoint := range []string{"s3.amazonaws.com", "ftp://s3.amazonaws.com", "https://s3.amazonaws.com/bucket"} {
+		if _, err := NewClient(endpoint, "us-east-1", "consents", "key", "secret", time.Minute); err == nil {
+			t.Errorf("expected endpoint %q to be rejected", endpoint)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package scheduler runs periodic background tasks for the server.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// TaskFunc is the function executed on every tick of a scheduled task
+type TaskFunc func(ctx context.Context)
+
+type task struct {
+	name     string
+	interval time.Duration
+	run      TaskFunc
+}
+
+// Scheduler runs registered tasks at fixed intervals until stopped
+type Scheduler struct {
+	mu      sync.Mutex
+	tasks   []task
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+var (
+	instance *Scheduler
+	once     sync.Once
+)
+
+// GetScheduler returns the singleton scheduler
+func GetScheduler() *Scheduler {
+	once.Do(func() {
+		instance = &Scheduler{}
+	})
+	return instance
+}
+
+// Register adds a task that runs every interval. Tasks registered after Start begin immediately.
+func (s *Scheduler) Register(name string, interval time.Duration, run TaskFunc) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval %s for task %s", interval, name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := task{name: name, interval: interval, run: run}
+	s.tasks = append(s.tasks, t)
+	if s.started {
+		s.startTask(t)
+	}
+	return nil
+}
+
+// Start begins running all registered tasks
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.started = true
+	for _, t := range s.tasks {
+		s.startTask(t)
+	}
+
+	log.GetLogger().Info("Scheduler started", log.Int("task_count", len(s.tasks)))
+}
+
+// Stop cancels all running tasks and waits for in-flight executions to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.cancel()
+	s.started = false
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	log.GetLogger().Info("Scheduler stopped")
+}
+
+// startTask launches the goroutine for a task. Caller must hold the lock.
+func (s *Scheduler) startTask(t task) {
+	ctx := s.ctx
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				runSafely(ctx, t)
+			}
+		}
+	}()
+}
+
+// runSafely executes a task and recovers from panics so that one failing run
+// does not stop the task from being scheduled again.
+func runSafely(ctx context.Context, t task) {
+	logger := log.GetLogger().With(log.String(log.LoggerKeyComponentName, "Scheduler"))
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("Scheduled task panicked", log.String("task", t.name), log.Any("panic", r))
+		}
+	}()
+
+	logger.Debug("Running scheduled task", log.String("task", t.name))
+	t.run(ctx)
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package signing produces JSON Web Signatures (RFC 7515) over documents issued by the server,
+// such as consent receipts, using a private key loaded from a PEM file.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Signer signs payloads as compact JWS using a single private key
+type Signer struct {
+	key          crypto.Signer
+	algorithm    string
+	keyID        string
+	publicKeyPEM string
+}
+
+// LoadSigner reads a PEM encoded EC (P-256 or P-384), RSA or Ed25519 private key. The signature
+// algorithm is chosen from the key type.
+func LoadSigner(keyFile, keyID string) (*Signer, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("signing key file does not contain a PEM block")
+	}
+
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var algorithm string
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			algorithm = "ES256"
+		case elliptic.P384():
+			algorithm = "ES384"
+		default:
+			return nil, errors.New("unsupported EC curve, use P-256 or P-384")
+		}
+	case *rsa.PrivateKey:
+		algorithm = "RS256"
+	case ed25519.PrivateKey:
+		algorithm = "EdDSA"
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	return &Signer{
+		key:          key,
+		algorithm:    algorithm,
+		keyID:        keyID,
+		publicKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER})),
+	}, nil
+}
+
+// Algorithm returns the JWS algorithm name, e.g. ES256
+func (s *Signer) Algorithm() string {
+	return s.algorithm
+}
+
+// KeyID returns the key identifier placed in the JWS header
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// PublicKeyPEM returns the PEM encoded public key that verifies the signatures
+func (s *Signer) PublicKeyPEM() string {
+	return s.publicKeyPEM
+}
+
+// SignJWS returns the compact serialization of a JWS over payload
+func (s *Signer) SignJWS(payload []byte, contentType string) (string, error) {
+	header := map[string]string{"alg": s.algorithm}
+	if s.keyID != "" {
+		header["kid"] = s.keyID
+	}
+	if contentType != "" {
+		header["cty"] = contentType
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encode(headerJSON) + "." + encode(payload)
+	signature, err := s.sign([]byte(signingInput))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign payload: %w", err)
+	}
+	return signingInput + "." + encode(signature), nil
+}
+
+func (s *Signer) sign(input []byte) ([]byte, error) {
+	switch k := s.key.(type) {
+	case *ecdsa.PrivateKey:
+		var digest []byte
+		if s.algorithm == "ES384" {
+			sum := sha512.Sum384(input)
+			digest = sum[:]
+		} else {
+			sum := sha256.Sum256(input)
+			digest = sum[:]
+		}
+		r, sv, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			return nil, err
+		}
+		// JWS uses the fixed length R || S encoding rather than ASN.1
+		size := (k.Curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		sv.FillBytes(signature[size:])
+		return signature, nil
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256(input)
+		return rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, input), nil
+	}
+	return nil, fmt.Errorf("unsupported signing key type %T", s.key)
+}
+
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("failed to parse signing key, expected a PKCS#8, EC or PKCS#1 private key")
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
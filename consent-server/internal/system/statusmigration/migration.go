@@ -0,0 +1,357 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package statusmigration rewrites legacy consent and authorization status names to the configured
+// status vocabulary. It is a one-time utility for adopting the configurable status mappings on an
+// existing dataset and is run through the statusmigrate command, never by the server.
+package statusmigration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// Mapping maps a legacy status name to the status name that replaces it
+type Mapping map[string]string
+
+// target is a status column rewritten by the migration
+type target struct {
+	table      string
+	column     string
+	keyColumns []string
+	authStatus bool // Column holds authorization statuses instead of consent statuses
+}
+
+// targets lists every column that stores a consent or authorization status
+var targets = []target{
+	{table: "CONSENT", column: "CURRENT_STATUS", keyColumns: []string{"CONSENT_ID", "ORG_ID"}},
+	{table: "CONSENT_STATUS_AUDIT", column: "CURRENT_STATUS", keyColumns: []string{"STATUS_AUDIT_ID", "ORG_ID"}},
+	{table: "CONSENT_STATUS_AUDIT", column: "PREVIOUS_STATUS", keyColumns: []string{"STATUS_AUDIT_ID", "ORG_ID"}},
+	{table: "CONSENT_AUTH_RESOURCE", column: "AUTH_STATUS", keyColumns: []string{"AUTH_ID", "ORG_ID"}, authStatus: true},
+}
+
+// DefaultConsentStatusMapping returns the legacy consent status names and their configured replacements
+func DefaultConsentStatusMapping(cfg *config.ConsentConfig) Mapping {
+	return Mapping{
+		"awaitingAuthorization": string(cfg.GetCreatedConsentStatus()),
+		"awaitingAuthorisation": string(cfg.GetCreatedConsentStatus()),
+		"AUTHORIZED":            string(cfg.GetActiveConsentStatus()),
+		"authorized":            string(cfg.GetActiveConsentStatus()),
+		"authorised":            string(cfg.GetActiveConsentStatus()),
+		"rejected":              string(cfg.GetRejectedConsentStatus()),
+		"revoked":               string(cfg.GetRevokedConsentStatus()),
+		"expired":               string(cfg.GetExpiredConsentStatus()),
+	}
+}
+
+// DefaultAuthStatusMapping returns the legacy authorization status names and their configured replacements
+func DefaultAuthStatusMapping(cfg *config.ConsentConfig) Mapping {
+	return Mapping{
+		"awaitingAuthorization": string(cfg.GetCreatedAuthStatus()),
+		"awaitingAuthorisation": string(cfg.GetCreatedAuthStatus()),
+		"AUTHORIZED":            string(cfg.GetApprovedAuthStatus()),
+		"authorized":            string(cfg.GetApprovedAuthStatus()),
+		"authorised":            string(cfg.GetApprovedAuthStatus()),
+		"rejected":              string(cfg.GetRejectedAuthStatus()),
+	}
+}
+
+// Options controls a migration run
+type Options struct {
+	// DryRun only counts the rows that would be migrated
+	DryRun bool
+	// BatchSize is the number of rows updated per transaction
+	BatchSize       int
+	ConsentStatuses Mapping
+	AuthStatuses    Mapping
+	// Rollback receives an SQL statement per migrated row that restores its legacy status.
+	// Required unless DryRun is set.
+	Rollback io.Writer
+}
+
+// Result is the number of rows of a column that held, or were migrated from, a legacy status
+type Result struct {
+	Table  string
+	Column string
+	From   string
+	To     string
+	Rows   int
+}
+
+// Migrator rewrites legacy status names in the consent database
+type Migrator struct {
+	dbClient provider.DBClientInterface
+	dbType   string
+}
+
+// NewMigrator creates a new status migrator for a database of the given type
+func NewMigrator(dbClient provider.DBClientInterface, dbType string) *Migrator {
+	return &Migrator{dbClient: dbClient, dbType: (&config.DatabaseConfig{Type: dbType}).GetType()}
+}
+
+// Validate checks that every legacy status maps to a status of the configured vocabulary
+func Validate(cfg *config.ConsentConfig, opts Options) error {
+	if opts.BatchSize <= 0 {
+		return fmt.Errorf("batch size must be positive")
+	}
+	if !opts.DryRun && opts.Rollback == nil {
+		return fmt.Errorf("a rollback script destination is required to apply the migration")
+	}
+	for legacy, status := range opts.ConsentStatuses {
+		if !cfg.IsStatusAllowed(config.ConsentStatus(status)) {
+			return fmt.Errorf("legacy consent status '%s' maps to '%s', which is not a configured consent status", legacy, status)
+		}
+	}
+	for legacy, status := range opts.AuthStatuses {
+		if !cfg.IsAuthStatusAllowed(config.AuthStatus(status)) {
+			return fmt.Errorf("legacy authorization status '%s' maps to '%s', which is not a configured authorization status", legacy, status)
+		}
+	}
+	return nil
+}
+
+// Run migrates every legacy status of the mappings. In dry-run mode it only counts the affected rows.
+// Each batch is committed in its own transaction and its rollback statements are written once it
+// is committed, so an interrupted run can be resumed or rolled back.
+func (m *Migrator) Run(ctx context.Context, opts Options) ([]Result, error) {
+	logger := log.GetLogger().WithContext(ctx)
+	results := make([]Result, 0)
+
+	for _, t := range targets {
+		mapping := opts.ConsentStatuses
+		if t.authStatus {
+			mapping = opts.AuthStatuses
+		}
+
+		for _, legacy := range sortedKeys(mapping) {
+			status := mapping[legacy]
+			if legacy == status {
+				continue
+			}
+
+			var rows int
+			var err error
+			if opts.DryRun {
+				rows, err = m.count(t, legacy)
+			} else {
+				rows, err = m.migrate(ctx, t, legacy, status, opts)
+			}
+			if err != nil {
+				return results, fmt.Errorf("failed to migrate %s.%s from '%s': %w", t.table, t.column, legacy, err)
+			}
+			if rows == 0 {
+				continue
+			}
+
+			results = append(results, Result{Table: t.table, Column: t.column, From: legacy, To: status, Rows: rows})
+			logger.Info("Migrated legacy status",
+				log.String("table", t.table),
+				log.String("column", t.column),
+				log.String("from", legacy),
+				log.String("to", status),
+				log.Int("rows", rows),
+				log.Bool("dry_run", opts.DryRun))
+		}
+	}
+
+	return results, nil
+}
+
+// statusQuery builds a query matching a legacy status case-sensitively, so 'rejected' does not
+// match 'REJECTED'. The format receives the table and the status condition. MySQL compares with
+// the case-insensitive collation of the column unless BINARY is given; SQLite compares bytes.
+func statusQuery(id, format string, t target) dbmodel.DBQuery {
+	return dbmodel.DBQuery{
+		ID:          id,
+		Query:       fmt.Sprintf(format, t.table, fmt.Sprintf("BINARY %s = ?", t.column)),
+		SQLiteQuery: fmt.Sprintf(format, t.table, fmt.Sprintf("%s = ? COLLATE BINARY", t.column)),
+		CrossTenant: true,
+	}
+}
+
+// count returns the number of rows of a column that hold a legacy status.
+// The migration spans every organization.
+func (m *Migrator) count(t target, legacy string) (int, error) {
+	query := statusQuery("COUNT_LEGACY_STATUS", "SELECT COUNT(*) as count FROM %s WHERE %s", t)
+	rows, err := m.dbClient.Query(query, legacy)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	count, _ := rows[0]["count"].(int64)
+	return int(count), nil
+}
+
+// migrate rewrites a legacy status of a column in batches and returns the number of migrated rows
+func (m *Migrator) migrate(ctx context.Context, t target, legacy, status string, opts Options) (int, error) {
+	logger := log.GetLogger().WithContext(ctx)
+
+	selectQuery := statusQuery("GET_LEGACY_STATUS_KEYS",
+		"SELECT "+strings.Join(t.keyColumns, ", ")+" FROM %s WHERE %s LIMIT ?", t)
+	keyConditions := make([]string, len(t.keyColumns))
+	for i, column := range t.keyColumns {
+		keyConditions[i] = column + " = ?"
+	}
+	updateQuery := statusQuery("UPDATE_LEGACY_STATUS",
+		"UPDATE %s SET "+t.column+" = ? WHERE "+strings.Join(keyConditions, " AND ")+" AND %s", t)
+
+	migrated := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return migrated, err
+		}
+
+		rows, err := m.dbClient.Query(selectQuery, legacy, opts.BatchSize)
+		if err != nil {
+			return migrated, err
+		}
+		if len(rows) == 0 {
+			return migrated, nil
+		}
+
+		keys := make([][]interface{}, 0, len(rows))
+		for _, row := range rows {
+			key := make([]interface{}, len(t.keyColumns))
+			for i, column := range t.keyColumns {
+				key[i] = columnValue(row, column)
+			}
+			keys = append(keys, key)
+		}
+
+		updated, err := m.updateBatch(updateQuery.GetQuery(m.dbType), keys, legacy, status)
+		if err != nil {
+			return migrated, err
+		}
+		// Rows the select matched but the update did not would be selected again forever
+		if len(updated) == 0 {
+			return migrated, fmt.Errorf("no row of a batch of %d was updated, stopping to avoid selecting them again", len(keys))
+		}
+		if err := m.writeRollback(opts.Rollback, t, updated, legacy, status); err != nil {
+			return migrated, fmt.Errorf("batch committed but rollback script could not be written: %w", err)
+		}
+
+		migrated += len(updated)
+		logger.Info("Migrated batch",
+			log.String("table", t.table),
+			log.String("column", t.column),
+			log.String("from", legacy),
+			log.Int("batch", len(updated)),
+			log.Int("migrated", migrated))
+
+		if len(rows) < opts.BatchSize {
+			return migrated, nil
+		}
+	}
+}
+
+// updateBatch rewrites the status of a batch of rows in a single transaction and returns the keys
+// of the rows it changed. Rows changed since they were selected are skipped.
+func (m *Migrator) updateBatch(updateQuery string, keys [][]interface{}, legacy, status string) ([][]interface{}, error) {
+	tx, err := m.dbClient.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+
+	updated := make([][]interface{}, 0, len(keys))
+	for _, key := range keys {
+		args := append([]interface{}{status}, key...)
+		args = append(args, legacy)
+		result, err := tx.Exec(updateQuery, args...)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if rows > 0 {
+			updated = append(updated, key)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// writeRollback writes statements restoring the legacy status of a migrated batch
+func (m *Migrator) writeRollback(w io.Writer, t target, keys [][]interface{}, legacy, status string) error {
+	statusCondition := fmt.Sprintf("BINARY %s = %s", t.column, m.quote(status))
+	if m.dbType == config.DatabaseTypeSQLite {
+		statusCondition = fmt.Sprintf("%s = %s COLLATE BINARY", t.column, m.quote(status))
+	}
+	for _, key := range keys {
+		conditions := make([]string, 0, len(t.keyColumns)+1)
+		for i, column := range t.keyColumns {
+			conditions = append(conditions, fmt.Sprintf("%s = %s", column, m.quote(fmt.Sprint(key[i]))))
+		}
+		conditions = append(conditions, statusCondition)
+		_, err := fmt.Fprintf(w, "UPDATE %s SET %s = %s WHERE %s;\n",
+			t.table, t.column, m.quote(legacy), strings.Join(conditions, " AND "))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quote returns a string literal of the database. MySQL also treats backslashes as escapes,
+// SQLite does not.
+func (m *Migrator) quote(value string) string {
+	if m.dbType != config.DatabaseTypeSQLite {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+	}
+	value = strings.ReplaceAll(value, "'", "''")
+	return "'" + value + "'"
+}
+
+// columnValue reads a column from a result row. Column names are normalized to lowercase by the
+// DB client and values may be returned as strings or byte slices.
+func columnValue(row map[string]interface{}, column string) interface{} {
+	value, ok := row[strings.ToLower(column)]
+	if !ok {
+		value = row[column]
+	}
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return value
+}
+
+// sortedKeys returns the legacy statuses of a mapping in a stable order
+func sortedKeys(mapping Mapping) []string {
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,159 @@
+package statusmigration
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"maps"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+	"github.com/wso2/consent-management-api/internal/system/database/provider"
+)
+
+// openTestDB creates the status columns of the migration targets in an SQLite database
+func openTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "consent.db"))
+	if err != nil {
+		t.Fatalf("failed to open the test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, statement := range []string{
+		"CREATE TABLE CONSENT (CONSENT_ID TEXT, ORG_ID TEXT, CURRENT_STATUS TEXT)",
+		"CREATE TABLE CONSENT_STATUS_AUDIT (STATUS_AUDIT_ID TEXT, ORG_ID TEXT, CURRENT_STATUS TEXT, PREVIOUS_STATUS TEXT)",
+		"CREATE TABLE CONSENT_AUTH_RESOURCE (AUTH_ID TEXT, ORG_ID TEXT, AUTH_STATUS TEXT)",
+		"INSERT INTO CONSENT VALUES ('c1', 'org-1', 'rejected'), ('c2', 'org-2', 'REJECTED'), ('c3', 'org-1', 'authorised'), ('c4''s', 'org-1', 'rejected')",
+		"INSERT INTO CONSENT_AUTH_RESOURCE VALUES ('a1', 'org-1', 'authorised')",
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to prepare the test database: %v", err)
+		}
+	}
+	return db
+}
+
+// statuses returns the consent statuses by consent ID
+func statuses(t *testing.T, db *sql.DB) map[string]string {
+	rows, err := db.Query("SELECT CONSENT_ID, CURRENT_STATUS FROM CONSENT")
+	if err != nil {
+		t.Fatalf("failed to read the consents: %v", err)
+	}
+	defer rows.Close()
+	result := map[string]string{}
+	for rows.Next() {
+		var id, status string
+		if err := rows.Scan(&id, &status); err != nil {
+			t.Fatalf("failed to read a consent: %v", err)
+		}
+		result[id] = status
+	}
+	return result
+}
+
+// TestRun_SQLite_MigratesAndRollsBack checks that legacy statuses are matched case-sensitively,
+// migrated in batches, and restored by the rollback script
+func TestRun_SQLite_MigratesAndRollsBack(t *testing.T) {
+	db := openTestDB(t)
+	migrator := NewMigrator(provider.NewDBClient(db, config.DatabaseTypeSQLite), config.DatabaseTypeSQLite)
+	opts := Options{
+		DryRun:          true,
+		BatchSize:       1,
+		ConsentStatuses: Mapping{"rejected": "REJECTED", "authorised": "ACTIVE"},
+		AuthStatuses:    Mapping{"authorised": "APPROVED"},
+	}
+
+	results, err := migrator.Run(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if len(results) != 3 || results[1].From != "rejected" || results[1].Rows != 2 {
+		t.Fatalf("expected the two lowercase rejected consents to be counted, got %+v", results)
+	}
+
+	rollback := &bytes.Buffer{}
+	opts.DryRun = false
+	opts.Rollback = rollback
+	if _, err := migrator.Run(context.Background(), opts); err != nil {
+		t.Fatalf("migration failed: %v", err)
+	}
+	expected := map[string]string{"c1": "REJECTED", "c2": "REJECTED", "c3": "ACTIVE", "c4's": "REJECTED"}
+	if got := statuses(t, db); !maps.Equal(got, expected) {
+		t.Fatalf("expected %v after the migration, got %v", expected, got)
+	}
+	if strings.Contains(rollback.String(), "BINARY CURRENT_STATUS") {
+		t.Errorf("expected SQLite rollback statements, got %s", rollback.String())
+	}
+
+	if _, err := db.Exec(rollback.String()); err != nil {
+		t.Fatalf("failed to run the rollback script: %v", err)
+	}
+	expected = map[string]string{"c1": "rejected", "c2": "REJECTED", "c3": "authorised", "c4's": "rejected"}
+	if got := statuses(t, db); !maps.Equal(got, expected) {
+		t.Errorf("expected %v after the rollback, got %v", expected, got)
+	}
+}
+
+// TestWriteRollback_MySQL checks the MySQL rollback statement and its escaping
+func TestWriteRollback_MySQL(t *testing.T) {
+	migrator := NewMigrator(nil, config.DatabaseTypeMySQL)
+	rollback := &bytes.Buffer{}
+	err := migrator.writeRollback(rollback, targets[0], [][]interface{}{{`c'1\`, "org-1"}}, "rejected", "REJECTED")
+	if err != nil {
+		t.Fatalf("failed to write the rollback: %v", err)
+	}
+	expected := `UPDATE CONSENT SET CURRENT_STATUS = 'rejected' WHERE CONSENT_ID = 'c''1\\' AND ORG_ID = 'org-1' AND BINARY CURRENT_STATUS = 'REJECTED';` + "\n"
+	if rollback.String() != expected {
+		t.Errorf("expected %q, got %q", expected, rollback.String())
+	}
+}
+
+// stuckDBClient selects the same row forever and updates none, as when the select and the update
+// disagree on a match
+type stuckDBClient struct {
+	provider.DBClientInterface
+}
+
+func (c *stuckDBClient) Query(query dbmodel.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{{"consent_id": "c1", "org_id": "org-1"}}, nil
+}
+
+func (c *stuckDBClient) BeginTx() (dbmodel.TxInterface, error) {
+	return &stuckTx{}, nil
+}
+
+type stuckTx struct {
+	dbmodel.TxInterface
+}
+
+func (tx *stuckTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (tx *stuckTx) Commit() error { return nil }
+
+func (tx *stuckTx) Rollback() error { return nil }
+
+// TestRun_StopsWithoutProgress checks that a batch in which no row is updated stops the migration
+// instead of selecting the same rows again
+func TestRun_StopsWithoutProgress(t *testing.T) {
+	migrator := NewMigrator(&stuckDBClient{}, config.DatabaseTypeMySQL)
+	rollback := &bytes.Buffer{}
+	_, err := migrator.Run(context.Background(), Options{
+		BatchSize:       1,
+		ConsentStatuses: Mapping{"rejected": "REJECTED"},
+		Rollback:        rollback,
+	})
+	if err == nil || !strings.Contains(err.Error(), "no row of a batch") {
+		t.Fatalf("expected the migration to stop without progress, got %v", err)
+	}
+	if rollback.Len() != 0 {
+		t.Errorf("expected no rollback statements, got %s", rollback.String())
+	}
+}
@@ -3,30 +3,89 @@ package interfaces
 import (
 	"context"
 
+	attributeSchemaModel "github.com/wso2/consent-management-api/internal/attributeschema/model"
 	authResourceModel "github.com/wso2/consent-management-api/internal/authresource/model"
 	consentModel "github.com/wso2/consent-management-api/internal/consent/model"
+	consentFileModel "github.com/wso2/consent-management-api/internal/consentfile/model"
+	consentImportModel "github.com/wso2/consent-management-api/internal/consentimport/model"
 	consentPurposeModel "github.com/wso2/consent-management-api/internal/consentpurpose/model"
+	eventOutboxModel "github.com/wso2/consent-management-api/internal/eventoutbox/model"
+	exportModel "github.com/wso2/consent-management-api/internal/export/model"
+	operationAuditModel "github.com/wso2/consent-management-api/internal/operationaudit/model"
+	organizationModel "github.com/wso2/consent-management-api/internal/organization/model"
+	resourceSchemaModel "github.com/wso2/consent-management-api/internal/resourceschema/model"
 	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
 )
 
 // ConsentStore defines the interface for consent data operations
 type ConsentStore interface {
 	GetByID(ctx context.Context, consentID, orgID string) (*consentModel.Consent, error)
+	GetByIDs(ctx context.Context, consentIDs []string, orgID string) ([]consentModel.Consent, error)
 	List(ctx context.Context, orgID string, limit, offset int) ([]consentModel.Consent, int, error)
 	Search(ctx context.Context, filters consentModel.ConsentSearchFilters) ([]consentModel.Consent, int, error)
 	GetByClientID(ctx context.Context, clientID, orgID string) ([]consentModel.Consent, error)
+	GetChildConsents(ctx context.Context, parentConsentID, orgID string) ([]consentModel.Consent, error)
+	GetPurgeableConsents(ctx context.Context, deletedBefore int64, limit int) ([]consentModel.Consent, error)
+	GetExpiringConsents(ctx context.Context, from, to int64, statuses []string, limit int) ([]consentModel.Consent, error)
+	GetRetentionExpiredConsents(ctx context.Context, statuses []string, updatedBefore int64, limit int) ([]consentModel.Consent, error)
 	GetAttributesByConsentID(ctx context.Context, consentID, orgID string) ([]consentModel.ConsentAttribute, error)
 	GetAttributesByConsentIDs(ctx context.Context, consentIDs []string, orgID string) (map[string]map[string]string, error)
+	GetRequiredAuthorizers(ctx context.Context, consentID, orgID string) ([]string, error)
 	GetStatusAuditByConsentID(ctx context.Context, consentID, orgID string) ([]consentModel.ConsentStatusAudit, error)
+	SearchStatusAudit(ctx context.Context, filters consentModel.StatusAuditSearchFilters) ([]consentModel.ConsentStatusAudit, int, error)
+	GetHistoryByConsentID(ctx context.Context, consentID, orgID string) ([]consentModel.ConsentHistory, error)
+	GetHistoryByConsentIDs(ctx context.Context, consentIDs []string, orgID string) (map[string][]consentModel.ConsentHistory, error)
+	GetHistoryByVersion(ctx context.Context, consentID, orgID string, version int) (*consentModel.ConsentHistory, error)
 	FindConsentIDsByAttributeKey(ctx context.Context, key, orgID string) ([]string, error)
 	FindConsentIDsByAttribute(ctx context.Context, key, value, orgID string) ([]string, error)
+	FindConsentIDsByAttributes(ctx context.Context, attributes []consentModel.AttributeFilter, orgID string) ([]string, error)
+	GetUsage(ctx context.Context, consentID, orgID string, periodStart int64) (*consentModel.ConsentUsage, error)
+	RecordUsage(ctx context.Context, consentID, orgID string, periodStart int64, limit int, accessTime int64) (bool, error)
+	AcquireLock(ctx context.Context, lock *consentModel.ConsentLock) (bool, error)
+	GetLock(ctx context.Context, consentID, orgID string) (*consentModel.ConsentLock, error)
+	ReleaseLock(ctx context.Context, consentID, orgID, lockToken string) (bool, error)
+	SaveModifiedResponse(ctx context.Context, consentID, orgID, document string, updatedTime int64) error
+	GetModifiedResponse(ctx context.Context, consentID, orgID string) (string, error)
+	AddTags(ctx context.Context, consentID, orgID string, tags []string, createdTime int64) error
+	RemoveTag(ctx context.Context, consentID, orgID, tag string) (bool, error)
+	GetTagsByConsentID(ctx context.Context, consentID, orgID string) ([]string, error)
+	GetTagsByConsentIDs(ctx context.Context, consentIDs []string, orgID string) (map[string][]string, error)
+	CreateNote(ctx context.Context, note *consentModel.ConsentNote) error
+	GetNotesByConsentID(ctx context.Context, consentID, orgID string) ([]consentModel.ConsentNote, error)
+	GetEvidenceByConsentID(ctx context.Context, consentID, orgID string) ([]consentModel.ConsentEvidence, error)
+	CreateToken(ctx context.Context, token *consentModel.ConsentToken) error
+	GetToken(ctx context.Context, tokenID, orgID string) (*consentModel.ConsentToken, error)
+	GetTokensByConsentID(ctx context.Context, consentID, orgID string, now int64) ([]consentModel.ConsentToken, error)
+	DeleteToken(ctx context.Context, tokenID, consentID, orgID string) (bool, error)
+	DeleteExpiredTokens(ctx context.Context, now int64) (int64, error)
+	GetScheduledRevocation(ctx context.Context, consentID, orgID string) (*consentModel.ScheduledRevocation, error)
+	GetDueScheduledRevocations(ctx context.Context, dueBy int64, limit int) ([]consentModel.ScheduledRevocation, error)
+	GetScheduledUpdate(ctx context.Context, consentID, orgID string) (*consentModel.ScheduledUpdate, error)
+	GetDueScheduledUpdates(ctx context.Context, dueBy int64, limit int) ([]consentModel.ScheduledUpdate, error)
 	Create(tx dbmodel.TxInterface, consent *consentModel.Consent) error
 	Update(tx dbmodel.TxInterface, consent *consentModel.Consent) error
 	UpdateStatus(tx dbmodel.TxInterface, consentID, orgID, status string, updatedTime int64) error
+	IncrementVersion(tx dbmodel.TxInterface, consentID, orgID string, expectedVersion int) error
 	Delete(tx dbmodel.TxInterface, consentID, orgID string) error
 	CreateAttributes(tx dbmodel.TxInterface, attributes []consentModel.ConsentAttribute) error
 	DeleteAttributesByConsentID(tx dbmodel.TxInterface, consentID, orgID string) error
+	DeleteAttributesByKeys(tx dbmodel.TxInterface, consentID, orgID string, keys []string) error
+	CreateRequiredAuthorizers(tx dbmodel.TxInterface, consentID, orgID string, roles []string) error
 	CreateStatusAudit(tx dbmodel.TxInterface, audit *consentModel.ConsentStatusAudit) error
+	CreateHistory(tx dbmodel.TxInterface, history *consentModel.ConsentHistory) error
+	CreateEvidence(tx dbmodel.TxInterface, evidence *consentModel.ConsentEvidence) error
+	AnonymizeEvidence(tx dbmodel.TxInterface, consentID, orgID string) error
+	DeleteTokensByConsentID(tx dbmodel.TxInterface, consentID, orgID string) error
+	UpdateHistorySnapshot(tx dbmodel.TxInterface, consentID, orgID string, version int, snapshot string) error
+	RecordExpiryNotice(tx dbmodel.TxInterface, consentID, orgID string, validityTime, notifiedTime int64) error
+	Anonymize(tx dbmodel.TxInterface, consentID, orgID string, anonymizedTime int64) error
+	PseudonymizeUser(tx dbmodel.TxInterface, userID, pseudonym, orgID string) error
+	SaveScheduledRevocation(tx dbmodel.TxInterface, revocation *consentModel.ScheduledRevocation) error
+	DeleteScheduledRevocation(tx dbmodel.TxInterface, consentID, orgID string) error
+	ClaimScheduledRevocation(tx dbmodel.TxInterface, revocation *consentModel.ScheduledRevocation) error
+	SaveScheduledUpdate(tx dbmodel.TxInterface, update *consentModel.ScheduledUpdate) error
+	DeleteScheduledUpdate(tx dbmodel.TxInterface, consentID, orgID string) error
+	ClaimScheduledUpdate(tx dbmodel.TxInterface, update *consentModel.ScheduledUpdate) error
 }
 
 // AuthResourceStore defines the interface for authorization resource data operations
@@ -36,30 +95,117 @@ type AuthResourceStore interface {
 	GetByConsentIDs(ctx context.Context, consentIDs []string, orgID string) ([]authResourceModel.AuthResource, error)
 	Exists(ctx context.Context, authID, orgID string) (bool, error)
 	GetByUserID(ctx context.Context, userID, orgID string) ([]authResourceModel.AuthResource, error)
+	Search(ctx context.Context, filters authResourceModel.AuthResourceSearchFilters) ([]authResourceModel.AuthResource, int, error)
 	Create(tx dbmodel.TxInterface, authResource *authResourceModel.AuthResource) error
 	Update(tx dbmodel.TxInterface, authResource *authResourceModel.AuthResource) error
 	UpdateStatus(tx dbmodel.TxInterface, authID, orgID, status string, updatedTime int64) error
 	Delete(tx dbmodel.TxInterface, authID, orgID string) error
 	DeleteByConsentID(tx dbmodel.TxInterface, consentID, orgID string) error
 	UpdateAllStatusByConsentID(tx dbmodel.TxInterface, consentID, orgID, status string, updatedTime int64) error
+	ReplaceUserID(tx dbmodel.TxInterface, userID, newUserID, orgID string, updatedTime int64) error
+	GetStatusAudits(ctx context.Context, authID, consentID, orgID string) ([]authResourceModel.AuthStatusAudit, error)
 }
 
 // ConsentPurposeStore defines the interface for consent purpose data operations
 type ConsentPurposeStore interface {
 	GetByID(ctx context.Context, purposeID, orgID string) (*consentPurposeModel.ConsentPurpose, error)
 	GetByName(ctx context.Context, name, orgID string) (*consentPurposeModel.ConsentPurpose, error)
-	List(ctx context.Context, orgID string, limit, offset int, name string) ([]consentPurposeModel.ConsentPurpose, int, error)
+	GetByNames(ctx context.Context, names []string, orgID string) (map[string]consentPurposeModel.ConsentPurpose, error)
+	List(ctx context.Context, filters consentPurposeModel.PurposeSearchFilters) ([]consentPurposeModel.ConsentPurpose, int, error)
+	CountConsentsByPurposeIDs(ctx context.Context, purposeIDs []string, orgID string) (map[string]int, error)
 	CheckNameExists(ctx context.Context, name, orgID string) (bool, error)
 	GetAttributesByPurposeID(ctx context.Context, purposeID, orgID string) ([]consentPurposeModel.ConsentPurposeAttribute, error)
+	GetAttributesByPurposeIDs(ctx context.Context, purposeIDs []string, orgID string) (map[string][]consentPurposeModel.ConsentPurposeAttribute, error)
 	GetPurposesByConsentID(ctx context.Context, consentID, orgID string) ([]consentPurposeModel.ConsentPurpose, error)
 	GetMappingsByConsentID(ctx context.Context, consentID, orgID string) ([]consentPurposeModel.ConsentPurposeMapping, error)
 	GetMappingsByConsentIDs(ctx context.Context, consentIDs []string, orgID string) ([]consentPurposeModel.ConsentPurposeMapping, error)
 	GetIDsByNames(ctx context.Context, names []string, orgID string) (map[string]string, error)
+	GetLinkedConsents(ctx context.Context, purposeID, orgID string) ([]consentPurposeModel.LinkedConsent, error)
+	ListApprovals(ctx context.Context, filters consentPurposeModel.PurposeApprovalFilters) ([]consentPurposeModel.PurposeApproval, int, error)
 	Create(tx dbmodel.TxInterface, purpose *consentPurposeModel.ConsentPurpose) error
 	Update(tx dbmodel.TxInterface, purpose *consentPurposeModel.ConsentPurpose) error
 	Delete(tx dbmodel.TxInterface, purposeID, orgID string) error
 	CreateAttributes(tx dbmodel.TxInterface, attributes []consentPurposeModel.ConsentPurposeAttribute) error
 	DeleteAttributesByPurposeID(tx dbmodel.TxInterface, purposeID, orgID string) error
-	LinkPurposeToConsent(tx dbmodel.TxInterface, consentID, purposeID, orgID string, value *string, isUserApproved, isMandatory bool) error
+	UpdateAttribute(tx dbmodel.TxInterface, attribute consentPurposeModel.ConsentPurposeAttribute) error
+	DeleteAttribute(tx dbmodel.TxInterface, purposeID, key, orgID string) error
+	LinkPurposeToConsent(tx dbmodel.TxInterface, consentID, purposeID, orgID string, value *string, isUserApproved, isMandatory bool, expiresAt, lastConfirmedAt *int64) error
+	UpdateMappingApproval(tx dbmodel.TxInterface, consentID, purposeID, orgID string, isUserApproved bool, confirmedAt int64) error
 	DeleteMappingsByConsentID(tx dbmodel.TxInterface, consentID, orgID string) error
+	DeleteMappingsByPurposeID(tx dbmodel.TxInterface, purposeID, orgID string) error
+}
+
+// ExportJobStore defines the interface for scheduled export job data operations
+type ExportJobStore interface {
+	GetByID(ctx context.Context, jobID, orgID string) (*exportModel.ExportJob, error)
+	List(ctx context.Context, orgID string, limit, offset int) ([]exportModel.ExportJob, int, error)
+	CheckNameExists(ctx context.Context, name, orgID string) (bool, error)
+	GetDueJobs(ctx context.Context, now int64, limit int) ([]exportModel.ExportJob, error)
+	ClaimRun(ctx context.Context, jobID, orgID string, expectedNextRun, nextRun int64) (bool, error)
+	ListReceipts(ctx context.Context, jobID, orgID string, limit, offset int) ([]exportModel.DeliveryReceipt, int, error)
+	Create(tx dbmodel.TxInterface, job *exportModel.ExportJob) error
+	Update(tx dbmodel.TxInterface, job *exportModel.ExportJob) error
+	UpdateLastRunTime(tx dbmodel.TxInterface, jobID, orgID string, lastRunTime int64) error
+	Delete(tx dbmodel.TxInterface, jobID, orgID string) error
+	CreateReceipt(tx dbmodel.TxInterface, receipt *exportModel.DeliveryReceipt) error
+}
+
+// ConsentFileStore defines the interface for consent file attachment data operations
+type ConsentFileStore interface {
+	GetByID(ctx context.Context, fileID, consentID, orgID string) (*consentFileModel.ConsentFile, error)
+	ListByConsentID(ctx context.Context, consentID, orgID string) ([]consentFileModel.ConsentFile, error)
+	CountByConsentID(ctx context.Context, consentID, orgID string) (int, error)
+	Create(tx dbmodel.TxInterface, file *consentFileModel.ConsentFile) error
+}
+
+// ImportJobStore defines the interface for bulk consent import job data operations
+type ImportJobStore interface {
+	GetByID(ctx context.Context, jobID, orgID string) (*consentImportModel.ImportJob, error)
+	GetUnfinished(ctx context.Context) ([]consentImportModel.ImportJob, error)
+	ListErrors(ctx context.Context, jobID, orgID string, limit, offset int) ([]consentImportModel.ImportRowError, int, error)
+	Create(tx dbmodel.TxInterface, job *consentImportModel.ImportJob) error
+	UpdateStatus(tx dbmodel.TxInterface, job *consentImportModel.ImportJob) error
+	UpdateProgress(tx dbmodel.TxInterface, job *consentImportModel.ImportJob) error
+	CreateError(tx dbmodel.TxInterface, rowErr *consentImportModel.ImportRowError) error
+}
+
+// AttributeSchemaStore defines the interface for per-organization consent attribute schema operations
+type AttributeSchemaStore interface {
+	Get(ctx context.Context, orgID string) (*attributeSchemaModel.AttributeSchema, error)
+	Create(tx dbmodel.TxInterface, schema *attributeSchemaModel.AttributeSchema) error
+	Update(tx dbmodel.TxInterface, schema *attributeSchemaModel.AttributeSchema) error
+	Delete(tx dbmodel.TxInterface, orgID string) error
+}
+
+// ResourceSchemaStore defines the interface for per-authorization-type resources schema operations
+type ResourceSchemaStore interface {
+	Get(ctx context.Context, authType, orgID string) (*resourceSchemaModel.ResourceSchema, error)
+	List(ctx context.Context, orgID string) ([]resourceSchemaModel.ResourceSchema, error)
+	Create(tx dbmodel.TxInterface, schema *resourceSchemaModel.ResourceSchema) error
+	Update(tx dbmodel.TxInterface, schema *resourceSchemaModel.ResourceSchema) error
+	Delete(tx dbmodel.TxInterface, authType, orgID string) error
+}
+
+// OrganizationStore defines the interface for organization metadata operations
+type OrganizationStore interface {
+	Get(ctx context.Context, orgID string) (*organizationModel.Organization, error)
+	List(ctx context.Context, limit, offset int) ([]organizationModel.Organization, int, error)
+	Create(tx dbmodel.TxInterface, organization *organizationModel.Organization) error
+	Update(tx dbmodel.TxInterface, organization *organizationModel.Organization) error
+	Delete(tx dbmodel.TxInterface, orgID string) error
+}
+
+// OperationAuditStore defines the interface for the audit trail of consent operations
+type OperationAuditStore interface {
+	Create(ctx context.Context, operation *operationAuditModel.OperationAudit) error
+	Search(ctx context.Context, filters operationAuditModel.OperationSearchFilters) ([]operationAuditModel.OperationAudit, int, error)
+}
+
+// EventOutboxStore defines the interface for the consent events waiting to be published
+type EventOutboxStore interface {
+	Create(tx dbmodel.TxInterface, event *eventOutboxModel.OutboxEvent) error
+	GetPending(ctx context.Context, limit int) ([]eventOutboxModel.OutboxEvent, error)
+	Claim(ctx context.Context, eventID, orgID string, nextAttemptTime, claimUntil int64) (bool, error)
+	RecordFailure(ctx context.Context, eventID, orgID string, attempts int, nextAttemptTime int64, lastError string) error
+	Delete(ctx context.Context, eventID, orgID string) error
 }
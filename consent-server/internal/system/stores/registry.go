@@ -1,20 +1,47 @@
 package stores
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/database"
 	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
 	"github.com/wso2/consent-management-api/internal/system/database/provider"
 	"github.com/wso2/consent-management-api/internal/system/log"
 	"github.com/wso2/consent-management-api/internal/system/stores/interfaces"
+	"github.com/wso2/consent-management-api/internal/system/tracing"
 )
 
+// ErrDraining is returned for transactions started after shutdown began draining the registry
+var ErrDraining = errors.New("server is shutting down")
+
 // StoreRegistry holds references to all stores in the application
 type StoreRegistry struct {
 	dbClient provider.DBClientInterface
 
+	// Running transactions are counted so shutdown can wait for them before closing the database
+	txMu     sync.Mutex
+	txActive int
+	draining bool
+	txIdle   chan struct{}
+
 	// Store instances with typed interfaces
-	Consent        interfaces.ConsentStore
-	AuthResource   interfaces.AuthResourceStore
-	ConsentPurpose interfaces.ConsentPurposeStore
+	Consent         interfaces.ConsentStore
+	AuthResource    interfaces.AuthResourceStore
+	ConsentPurpose  interfaces.ConsentPurposeStore
+	ExportJob       interfaces.ExportJobStore
+	ConsentFile     interfaces.ConsentFileStore
+	ImportJob       interfaces.ImportJobStore
+	AttributeSchema interfaces.AttributeSchemaStore
+	ResourceSchema  interfaces.ResourceSchemaStore
+	Organization    interfaces.OrganizationStore
+	OperationAudit  interfaces.OperationAuditStore
+	EventOutbox     interfaces.EventOutboxStore
 }
 
 // NewStoreRegistry creates a new store registry with all initialized stores
@@ -23,21 +50,78 @@ func NewStoreRegistry(
 	consentStore interfaces.ConsentStore,
 	authResourceStore interfaces.AuthResourceStore,
 	consentPurposeStore interfaces.ConsentPurposeStore,
+	exportJobStore interfaces.ExportJobStore,
+	consentFileStore interfaces.ConsentFileStore,
+	importJobStore interfaces.ImportJobStore,
+	attributeSchemaStore interfaces.AttributeSchemaStore,
+	resourceSchemaStore interfaces.ResourceSchemaStore,
+	organizationStore interfaces.OrganizationStore,
+	operationAuditStore interfaces.OperationAuditStore,
+	eventOutboxStore interfaces.EventOutboxStore,
 ) *StoreRegistry {
 	return &StoreRegistry{
-		dbClient:       dbClient,
-		Consent:        consentStore,
-		AuthResource:   authResourceStore,
-		ConsentPurpose: consentPurposeStore,
+		dbClient:        dbClient,
+		Consent:         consentStore,
+		AuthResource:    authResourceStore,
+		ConsentPurpose:  consentPurposeStore,
+		ExportJob:       exportJobStore,
+		ConsentFile:     consentFileStore,
+		ImportJob:       importJobStore,
+		AttributeSchema: attributeSchemaStore,
+		ResourceSchema:  resourceSchemaStore,
+		Organization:    organizationStore,
+		OperationAudit:  operationAuditStore,
+		EventOutbox:     eventOutboxStore,
 	}
 }
 
-// ExecuteTransaction executes multiple store operations in a single transaction
-func (r *StoreRegistry) ExecuteTransaction(queries []func(tx dbmodel.TxInterface) error) error {
-	logger := log.GetLogger()
+// ExecuteTransaction executes multiple store operations in a single transaction. A transaction the
+// database rolled back over a deadlock or serialization conflict is run again from the first
+// operation, with a randomized backoff, up to the configured number of retries.
+func (r *StoreRegistry) ExecuteTransaction(ctx context.Context,
+	queries []func(tx dbmodel.TxInterface) error) (err error) {
+	_, span := tracing.Start(ctx, "db.transaction", tracing.SpanKindClient)
+	span.SetAttribute("db.system", databaseSystem())
+	span.SetAttribute("db.query_count", len(queries))
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
+	if err = r.beginTracking(); err != nil {
+		return err
+	}
+	defer r.endTracking()
+
+	logger := log.GetLogger().WithContext(ctx)
+	retry := transactionRetryConfig()
+	for attempt := 1; ; attempt++ {
+		err = r.runTransaction(ctx, queries)
+		if err == nil || attempt > retry.GetMaxRetries() || !database.IsRetryable(err) {
+			span.SetAttribute("db.retries", attempt-1)
+			return err
+		}
+
+		wait := transactionBackoff(retry, attempt)
+		logger.Warn("Transaction conflicted with a concurrent transaction, retrying",
+			log.Error(err),
+			log.Int("retry", attempt),
+			log.String("backoff", wait.String()))
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			span.SetAttribute("db.retries", attempt-1)
+			return err
+		}
+	}
+}
+
+// runTransaction runs the store operations in one transaction, rolling it back when one fails
+func (r *StoreRegistry) runTransaction(ctx context.Context, queries []func(tx dbmodel.TxInterface) error) error {
+	logger := log.GetLogger().WithContext(ctx)
 	logger.Debug("Starting transaction", log.Int("query_count", len(queries)))
 
-	tx, err := r.dbClient.BeginTx()
+	tx, err := r.dbClient.BeginTxContext(ctx)
 	if err != nil {
 		logger.Error("Failed to begin transaction", log.Error(err))
 		return err
@@ -62,3 +146,78 @@ func (r *StoreRegistry) ExecuteTransaction(queries []func(tx dbmodel.TxInterface
 	logger.Debug("Transaction committed successfully", log.Int("query_count", len(queries)))
 	return nil
 }
+
+// databaseSystem returns the configured database type, which matches the OpenTelemetry db.system
+// value of the supported databases
+func databaseSystem() string {
+	if cfg := config.Get(); cfg != nil {
+		return cfg.Database.Consent.GetType()
+	}
+	return config.DatabaseTypeMySQL
+}
+
+// transactionRetryConfig returns the configured transaction retries, or the defaults when the
+// configuration is not loaded
+func transactionRetryConfig() *config.TransactionRetryConfig {
+	if cfg := config.Get(); cfg != nil {
+		return &cfg.Database.Consent.TransactionRetry
+	}
+	return &config.TransactionRetryConfig{}
+}
+
+// transactionBackoff returns the wait before retry number attempt, counted from 1: the backoff
+// doubled per retry up to the maximum, randomized between half and all of it
+func transactionBackoff(cfg *config.TransactionRetryConfig, attempt int) time.Duration {
+	wait := cfg.GetBackoff()
+	for i := 1; i < attempt && wait < cfg.GetMaxBackoff(); i++ {
+		wait *= 2
+	}
+	wait = min(wait, cfg.GetMaxBackoff())
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// Drain stops new transactions and waits until the running ones have committed or rolled back,
+// or until the context is done
+func (r *StoreRegistry) Drain(ctx context.Context) error {
+	r.txMu.Lock()
+	if !r.draining {
+		r.draining = true
+		r.txIdle = make(chan struct{})
+		if r.txActive == 0 {
+			close(r.txIdle)
+		}
+	}
+	idle := r.txIdle
+	r.txMu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		r.txMu.Lock()
+		active := r.txActive
+		r.txMu.Unlock()
+		return fmt.Errorf("%d transaction(s) still running: %w", active, ctx.Err())
+	}
+}
+
+// beginTracking counts a new transaction, refusing it once the registry is draining
+func (r *StoreRegistry) beginTracking() error {
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+	if r.draining {
+		return ErrDraining
+	}
+	r.txActive++
+	return nil
+}
+
+// endTracking marks a transaction as finished
+func (r *StoreRegistry) endTracking() {
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+	r.txActive--
+	if r.draining && r.txActive == 0 {
+		close(r.txIdle)
+	}
+}
@@ -0,0 +1,238 @@
+package stores
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	dbmodel "github.com/wso2/consent-management-api/internal/system/database/model"
+)
+
+// fakeTx records whether the transaction was committed or rolled back
+type fakeTx struct {
+	client *fakeDBClient
+}
+
+func (t *fakeTx) Exec(query string, args ...interface{}) (sql.Result, error) { return nil, nil }
+
+func (t *fakeTx) Query(query string, args ...interface{}) (*sql.Rows, error) { return nil, nil }
+
+func (t *fakeTx) Commit() error {
+	t.client.commits++
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.client.rollbacks++
+	return nil
+}
+
+// fakeDBClient hands out fake transactions
+type fakeDBClient struct {
+	commits   int
+	rollbacks int
+}
+
+func (c *fakeDBClient) Query(query dbmodel.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (c *fakeDBClient) QueryContext(ctx context.Context, query dbmodel.DBQuery, args ...interface{}) ([]map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (c *fakeDBClient) Execute(query dbmodel.DBQuery, args ...interface{}) (int64, error) {
+	return 0, nil
+}
+
+func (c *fakeDBClient) ExecuteContext(ctx context.Context, query dbmodel.DBQuery, args ...interface{}) (int64, error) {
+	return c.Execute(query, args...)
+}
+
+func (c *fakeDBClient) BeginTxContext(ctx context.Context) (dbmodel.TxInterface, error) {
+	return c.BeginTx()
+}
+
+func (c *fakeDBClient) BeginTx() (dbmodel.TxInterface, error) {
+	return &fakeTx{client: c}, nil
+}
+
+// newRetryTestRegistry returns a registry that retries conflicting transactions twice, without waiting
+func newRetryTestRegistry(t *testing.T) (*StoreRegistry, *fakeDBClient) {
+	retries := 2
+	config.SetGlobal(&config.Config{Database: config.DatabasesConfig{Consent: config.DatabaseConfig{
+		TransactionRetry: config.TransactionRetryConfig{
+			MaxRetries: &retries,
+			Backoff:    time.Microsecond,
+			MaxBackoff: time.Microsecond,
+		},
+	}}})
+	t.Cleanup(func() { config.SetGlobal(nil) })
+
+	client := &fakeDBClient{}
+	return &StoreRegistry{dbClient: client}, client
+}
+
+// failingQuery fails with err the first failures times it runs
+func failingQuery(failures int, err error, runs *int) func(tx dbmodel.TxInterface) error {
+	return func(tx dbmodel.TxInterface) error {
+		*runs++
+		if *runs <= failures {
+			return err
+		}
+		return nil
+	}
+}
+
+var errDeadlock = fmt.Errorf("failed to update consent: %w",
+	&mysql.MySQLError{Number: 1213, SQLState: [5]byte{'4', '0', '0', '0', '1'}, Message: "Deadlock found"})
+
+// TestExecuteTransaction_RetriesDeadlocks checks that a deadlocked transaction is run again
+func TestExecuteTransaction_RetriesDeadlocks(t *testing.T) {
+	registry, client := newRetryTestRegistry(t)
+
+	runs := 0
+	err := registry.ExecuteTransaction(context.Background(),
+		[]func(tx dbmodel.TxInterface) error{failingQuery(2, errDeadlock, &runs)})
+	if err != nil {
+		t.Fatalf("expected the transaction to succeed on retry, got %v", err)
+	}
+	if runs != 3 || client.rollbacks != 2 || client.commits != 1 {
+		t.Errorf("expected 3 runs, 2 rollbacks and 1 commit, got %d, %d and %d", runs, client.rollbacks, client.commits)
+	}
+}
+
+// TestExecuteTransaction_StopsAfterMaxRetries checks that a transaction that keeps deadlocking fails
+// with the database error once the retries are used up
+func TestExecuteTransaction_StopsAfterMaxRetries(t *testing.T) {
+	registry, client := newRetryTestRegistry(t)
+
+	runs := 0
+	err := registry.ExecuteTransaction(context.Background(),
+		[]func(tx dbmodel.TxInterface) error{failingQuery(10, errDeadlock, &runs)})
+	if !errors.Is(err, errDeadlock) {
+		t.Fatalf("expected the deadlock error, got %v", err)
+	}
+	if runs != 3 || client.commits != 0 {
+		t.Errorf("expected 3 runs and no commit, got %d runs and %d commits", runs, client.commits)
+	}
+}
+
+// TestExecuteTransaction_DoesNotRetryOtherErrors checks that failures other than conflicts are
+// returned at once
+func TestExecuteTransaction_DoesNotRetryOtherErrors(t *testing.T) {
+	registry, _ := newRetryTestRegistry(t)
+
+	runs := 0
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+	err := registry.ExecuteTransaction(context.Background(),
+		[]func(tx dbmodel.TxInterface) error{failingQuery(10, duplicate, &runs)})
+	if !errors.Is(err, duplicate) {
+		t.Fatalf("expected the duplicate entry error, got %v", err)
+	}
+	if runs != 1 {
+		t.Errorf("expected a single run, got %d", runs)
+	}
+}
+
+// TestDrain_WaitsForRunningTransactions checks that draining waits for a running transaction to
+// commit and refuses transactions started afterwards
+func TestDrain_WaitsForRunningTransactions(t *testing.T) {
+	registry, client := newRetryTestRegistry(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	finished := make(chan error, 1)
+	go func() {
+		finished <- registry.ExecuteTransaction(context.Background(), []func(tx dbmodel.TxInterface) error{
+			func(tx dbmodel.TxInterface) error {
+				close(started)
+				<-release
+				return nil
+			},
+		})
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() { drained <- registry.Drain(context.Background()) }()
+
+	for draining := false; !draining; time.Sleep(time.Millisecond) {
+		registry.txMu.Lock()
+		draining = registry.draining
+		registry.txMu.Unlock()
+	}
+	if err := registry.ExecuteTransaction(context.Background(), nil); !errors.Is(err, ErrDraining) {
+		t.Errorf("expected transactions started while draining to be refused, got %v", err)
+	}
+	select {
+	case err := <-drained:
+		t.Fatalf("expected the drain to wait for the running transaction, got %v", err)
+	default:
+	}
+
+	close(release)
+	if err := <-finished; err != nil {
+		t.Fatalf("expected the running transaction to commit, got %v", err)
+	}
+	if err := <-drained; err != nil {
+		t.Fatalf("expected the drain to finish, got %v", err)
+	}
+	if client.commits != 1 {
+		t.Errorf("expected the running transaction to be committed, got %d commits", client.commits)
+	}
+}
+
+// TestDrain_StopsAtTheDeadline checks that draining gives up when the context is done before the
+// running transactions finish
+func TestDrain_StopsAtTheDeadline(t *testing.T) {
+	registry, _ := newRetryTestRegistry(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	finished := make(chan error, 1)
+	go func() {
+		finished <- registry.ExecuteTransaction(context.Background(), []func(tx dbmodel.TxInterface) error{
+			func(tx dbmodel.TxInterface) error {
+				close(started)
+				<-release
+				return nil
+			},
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := registry.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the drain to stop at the deadline, got %v", err)
+	}
+
+	close(release)
+	<-finished
+	if err := registry.Drain(context.Background()); err != nil {
+		t.Errorf("expected a later drain to finish once the transaction committed, got %v", err)
+	}
+}
+
+// TestDatabaseSystem checks that transaction spans name the configured database
+func TestDatabaseSystem(t *testing.T) {
+	t.Cleanup(func() { config.SetGlobal(nil) })
+
+	config.SetGlobal(nil)
+	if system := databaseSystem(); system != config.DatabaseTypeMySQL {
+		t.Errorf("expected mysql without a configuration, got %s", system)
+	}
+	config.SetGlobal(&config.Config{Database: config.DatabasesConfig{Consent: config.DatabaseConfig{
+		Type: config.DatabaseTypeSQLite,
+	}}})
+	if system := databaseSystem(); system != config.DatabaseTypeSQLite {
+		t.Errorf("expected sqlite for a SQLite database, got %s", system)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceparentHeader is the W3C trace context header carrying the trace ID, parent span ID and flags
+const TraceparentHeader = "traceparent"
+
+// propagator reads and writes W3C trace context headers
+var propagator = propagation.TraceContext{}
+
+// ParseTraceparent parses a W3C traceparent value such as
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+func ParseTraceparent(value string) (SpanContext, bool) {
+	ctx := propagator.Extract(context.Background(), propagation.MapCarrier{TraceparentHeader: value})
+	sc := trace.SpanContextFromContext(ctx)
+	return sc, sc.IsValid()
+}
+
+// FormatTraceparent formats a span context as a W3C traceparent value
+func FormatTraceparent(sc SpanContext) string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+	return carrier.Get(TraceparentHeader)
+}
+
+// Extract returns a context that continues the trace of the incoming request headers, if any
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Inject writes the trace context of the current span of ctx to outgoing request headers
+func Inject(ctx context.Context, header http.Header) {
+	if sc := trace.SpanContextFromContext(ctx); !sc.IsValid() || sc.IsRemote() {
+		// No span was started, as tracing is disabled
+		return
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
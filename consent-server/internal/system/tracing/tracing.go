@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package tracing records distributed traces of requests across the handler, service and store
+// layers with the OpenTelemetry SDK. Trace context is propagated in the W3C traceparent format and
+// finished spans are exported to an OpenTelemetry collector over OTLP/HTTP.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+	"github.com/wso2/consent-management-api/internal/system/log"
+)
+
+// instrumentationScope names the instrumentation in exported spans
+const instrumentationScope = "github.com/wso2/consent-management-api"
+
+// SpanKind describes the relationship of a span to its trace
+type SpanKind = trace.SpanKind
+
+// Span kinds
+const (
+	SpanKindInternal = trace.SpanKindInternal
+	SpanKindServer   = trace.SpanKindServer
+	SpanKindClient   = trace.SpanKindClient
+)
+
+// SpanContext identifies a span within a trace
+type SpanContext = trace.SpanContext
+
+// Span is a timed operation within a trace. All methods are safe to call on a nil span, which is
+// what StartSpan returns when tracing is disabled.
+type Span struct {
+	span trace.Span
+}
+
+// SetName replaces the span name, for example once the matched route of a request is known
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.span.SetName(name)
+}
+
+// SetAttribute records an attribute on the span. Values should be strings, integers, floats or
+// booleans; other values are recorded as strings.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.span.SetAttributes(toAttribute(key, value))
+}
+
+// SetError marks the span as failed with the error message. A nil error is ignored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// SetErrorMessage marks the span as failed with the given message
+func (s *Span) SetErrorMessage(message string) {
+	if s == nil {
+		return
+	}
+	s.span.SetStatus(codes.Error, message)
+}
+
+// SpanContext returns the identifiers of the span
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.span.SpanContext()
+}
+
+// End finishes the span and queues it for export. Calls after the first are ignored.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.span.End()
+}
+
+var (
+	tracerMu       sync.RWMutex
+	tracerProvider *sdktrace.TracerProvider
+	tracer         trace.Tracer
+)
+
+// Initialize starts exporting spans to the configured collector. Tracing stays disabled, and
+// StartSpan returns nil spans, when it is not enabled in the configuration.
+func Initialize(cfg config.TracingConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpointURL(strings.TrimSuffix(cfg.Endpoint, "/")+"/v1/traces"),
+		otlptracehttp.WithHeaders(cfg.Headers),
+		otlptracehttp.WithTimeout(cfg.Timeout))
+	if err != nil {
+		return fmt.Errorf("failed to create the trace exporter: %w", err)
+	}
+
+	// Spans are dropped when the queue is full so that a slow collector never blocks requests.
+	// Requests that continue a trace follow the sampling decision of the caller.
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter,
+			sdktrace.WithMaxExportBatchSize(cfg.BatchSize),
+			sdktrace.WithMaxQueueSize(cfg.BatchSize*4),
+			sdktrace.WithBatchTimeout(cfg.ExportInterval),
+			sdktrace.WithExportTimeout(cfg.Timeout)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+	)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.GetLogger().Warn("Failed to export spans", log.Error(err))
+	}))
+
+	tracerMu.Lock()
+	defer tracerMu.Unlock()
+	tracerProvider = provider
+	tracer = provider.Tracer(instrumentationScope)
+
+	log.GetLogger().Info("Tracing enabled",
+		log.String("endpoint", cfg.Endpoint),
+		log.String("service_name", cfg.ServiceName))
+	return nil
+}
+
+// Shutdown flushes the spans that have not been exported yet and stops the exporter
+func Shutdown(ctx context.Context) error {
+	tracerMu.Lock()
+	provider := tracerProvider
+	tracerProvider = nil
+	tracer = nil
+	tracerMu.Unlock()
+
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
+
+// StartSpan starts an internal span as a child of the span in ctx
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	return Start(ctx, name, SpanKindInternal)
+}
+
+// Start starts a span of the given kind. The parent is the span in ctx or, for the first span of a
+// request, the remote span context extracted from the incoming trace context.
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	tracerMu.RLock()
+	t := tracer
+	tracerMu.RUnlock()
+	if t == nil {
+		return ctx, nil
+	}
+
+	ctx, span := t.Start(ctx, name, trace.WithSpanKind(kind))
+	return ctx, &Span{span: span}
+}
+
+// ContextWithRemoteSpanContext returns a context whose next span continues the remote trace
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// toAttribute converts a span attribute to an OpenTelemetry attribute
+func toAttribute(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,230 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wso2/consent-management-api/internal/system/config"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// TestParseTraceparent checks that valid W3C trace contexts are parsed and malformed ones rejected
+func TestParseTraceparent(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		wantOK      bool
+		wantSampled bool
+	}{
+		{"sampled", testTraceparent, true, true},
+		{"not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"future version with extra fields", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true, true},
+		{"version 00 with extra fields", testTraceparent + "-extra", false, false},
+		{"invalid version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"zero span ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+		{"short trace ID", "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", false, false},
+		{"not hex", "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01", false, false},
+		{"empty", "", false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, ok := ParseTraceparent(tc.value)
+			if ok != tc.wantOK {
+				t.Fatalf("expected ok %v, got %v", tc.wantOK, ok)
+			}
+			if ok && sc.IsSampled() != tc.wantSampled {
+				t.Errorf("expected sampled %v, got %v", tc.wantSampled, sc.IsSampled())
+			}
+		})
+	}
+
+	sc, _ := ParseTraceparent(testTraceparent)
+	if formatted := FormatTraceparent(sc); formatted != testTraceparent {
+		t.Errorf("expected %s to round trip, got %s", testTraceparent, formatted)
+	}
+}
+
+// TestStart_ReturnsNilSpansWhenDisabled checks that instrumented code runs untouched without tracing
+func TestStart_ReturnsNilSpansWhenDisabled(t *testing.T) {
+	if err := Initialize(config.TracingConfig{Enabled: false}); err != nil {
+		t.Fatalf("failed to initialize tracing: %v", err)
+	}
+
+	ctx, span := StartSpan(context.Background(), "consent.create")
+	if span != nil {
+		t.Fatalf("expected no span while tracing is disabled, got %+v", span)
+	}
+	span.SetAttribute("consent.id", "c1")
+	span.SetError(errors.New("ignored"))
+	span.End()
+
+	header := http.Header{}
+	Inject(ctx, header)
+	if header.Get(TraceparentHeader) != "" {
+		t.Errorf("expected no trace context to be injected, got %s", header.Get(TraceparentHeader))
+	}
+}
+
+// collector records the OTLP export requests it receives
+type collector struct {
+	mu       sync.Mutex
+	requests []*collectortrace.ExportTraceServiceRequest
+	headers  []http.Header
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	request := &collectortrace.ExportTraceServiceRequest{}
+	_ = proto.Unmarshal(body, request)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, request)
+	c.headers = append(c.headers, r.Header.Clone())
+}
+
+// spans returns the spans of every export request, keyed by name
+func (c *collector) spans() map[string]*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	spans := make(map[string]*tracepb.Span)
+	for _, request := range c.requests {
+		for _, resourceSpans := range request.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				for _, span := range scopeSpans.Spans {
+					spans[span.Name] = span
+				}
+			}
+		}
+	}
+	return spans
+}
+
+// TestTracing_ContinuesRemoteTraceAndExportsSpans checks that spans continue the incoming trace, are
+// parented to each other, and are exported with their status on shutdown
+func TestTracing_ContinuesRemoteTraceAndExportsSpans(t *testing.T) {
+	received := &collector{}
+	server := httptest.NewServer(received)
+	defer server.Close()
+
+	err := Initialize(config.TracingConfig{
+		Enabled:        true,
+		ServiceName:    "consent-server-test",
+		Endpoint:       server.URL,
+		Headers:        map[string]string{"Authorization": "Bearer collector-token"},
+		SampleRatio:    0,
+		BatchSize:      10,
+		ExportInterval: time.Hour,
+		Timeout:        5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize tracing: %v", err)
+	}
+
+	header := http.Header{}
+	header.Set(TraceparentHeader, testTraceparent)
+	ctx := Extract(context.Background(), header)
+
+	ctx, serverSpan := Start(ctx, "GET /api/v1/consents/{consentId}", SpanKindServer)
+	childCtx, childSpan := StartSpan(ctx, "consent.get")
+	childSpan.SetAttribute("consent.id", "c1")
+	childSpan.SetError(errors.New("consent not found"))
+
+	outgoing := http.Header{}
+	Inject(childCtx, outgoing)
+	childSpan.End()
+	childSpan.End()
+	serverSpan.End()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("failed to shut down tracing: %v", err)
+	}
+
+	remote, _ := ParseTraceparent(testTraceparent)
+	if propagated, ok := ParseTraceparent(outgoing.Get(TraceparentHeader)); !ok ||
+		propagated.TraceID() != remote.TraceID() || propagated.SpanID() != childSpan.SpanContext().SpanID() {
+		t.Errorf("expected the child span to be injected into outgoing headers, got %q", outgoing.Get(TraceparentHeader))
+	}
+
+	spans := received.spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected the two spans to be exported once, got %+v", spans)
+	}
+	exportedServer := spans["GET /api/v1/consents/{consentId}"]
+	exportedChild := spans["consent.get"]
+	traceID := remote.TraceID()
+	if !bytes.Equal(exportedServer.TraceId, traceID[:]) || !bytes.Equal(exportedChild.TraceId, traceID[:]) {
+		t.Errorf("expected both spans to continue trace %s, got %x and %x",
+			remote.TraceID(), exportedServer.TraceId, exportedChild.TraceId)
+	}
+	remoteSpanID := remote.SpanID()
+	if !bytes.Equal(exportedServer.ParentSpanId, remoteSpanID[:]) || exportedServer.Kind != tracepb.Span_SPAN_KIND_SERVER {
+		t.Errorf("expected a server span parented to the remote span, got %+v", exportedServer)
+	}
+	if !bytes.Equal(exportedChild.ParentSpanId, exportedServer.SpanId) || exportedChild.Kind != tracepb.Span_SPAN_KIND_INTERNAL {
+		t.Errorf("expected an internal span parented to the server span, got %+v", exportedChild)
+	}
+	if exportedChild.Status.GetCode() != tracepb.Status_STATUS_CODE_ERROR || exportedChild.Status.GetMessage() != "consent not found" {
+		t.Errorf("expected the child span to be marked failed, got %+v", exportedChild.Status)
+	}
+	if len(exportedChild.Attributes) != 1 || exportedChild.Attributes[0].Key != "consent.id" {
+		t.Errorf("expected the consent.id attribute, got %+v", exportedChild.Attributes)
+	}
+
+	received.mu.Lock()
+	defer received.mu.Unlock()
+	if received.headers[0].Get("Authorization") != "Bearer collector-token" {
+		t.Errorf("expected the configured headers to be sent to the collector, got %v", received.headers[0])
+	}
+	resource := received.requests[0].ResourceSpans[0].Resource.Attributes
+	if len(resource) != 1 || resource[0].Value.GetStringValue() != "consent-server-test" {
+		t.Errorf("expected the service name resource attribute, got %+v", resource)
+	}
+}
+
+// TestTracing_UnsampledTracesAreNotExported checks that a caller's decision not to sample is followed
+func TestTracing_UnsampledTracesAreNotExported(t *testing.T) {
+	received := &collector{}
+	server := httptest.NewServer(received)
+	defer server.Close()
+
+	err := Initialize(config.TracingConfig{
+		Enabled:        true,
+		Endpoint:       server.URL,
+		SampleRatio:    1,
+		BatchSize:      10,
+		ExportInterval: time.Hour,
+		Timeout:        5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize tracing: %v", err)
+	}
+
+	header := http.Header{}
+	header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	_, span := Start(Extract(context.Background(), header), "consent.get", SpanKindServer)
+	span.End()
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("failed to shut down tracing: %v", err)
+	}
+	if spans := received.spans(); len(spans) != 0 {
+		t.Errorf("expected no spans of an unsampled trace to be exported, got %+v", spans)
+	}
+}
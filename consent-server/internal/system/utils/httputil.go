@@ -21,6 +21,7 @@ package utils
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/wso2/consent-management-api/internal/system/constants"
 	"github.com/wso2/consent-management-api/internal/system/error/apierror"
@@ -50,8 +51,9 @@ func WriteJSONError(w http.ResponseWriter, code, description string, statusCode
 	})
 }
 
-// SendError writes a ServiceError as an HTTP response with appropriate status code and trace ID.
-// This function extracts the trace ID from the request context, logs the error, and includes it in the error response.
+// SendError writes a ServiceError as an RFC 7807 application/problem+json response with the appropriate
+// status code. This function extracts the trace ID from the request context, logs the error, and includes
+// it in the error response.
 func SendError(w http.ResponseWriter, r *http.Request, err *serviceerror.ServiceError) {
 	// Determine HTTP status code based on error type and code
 	statusCode := mapErrorToStatusCode(err)
@@ -77,36 +79,43 @@ func SendError(w http.ResponseWriter, r *http.Request, err *serviceerror.Service
 		)
 	}
 
-	// Create error response with new format
-	errorResponse := apierror.NewErrorResponse(
-		err.Code,
+	problem := apierror.NewProblemDetails(
+		constants.ErrorCatalogPath+"/"+err.Code,
 		err.Message,
+		statusCode,
 		err.Description,
+		r.URL.Path,
+		err.Code,
 		traceID,
 	)
+	for _, violation := range err.Violations {
+		problem.Violations = append(problem.Violations, apierror.Violation{
+			Path:       violation.Path,
+			Constraint: violation.Constraint,
+			Message:    violation.Message,
+		})
+	}
 
-	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeProblemJSON)
 	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(errorResponse)
+	json.NewEncoder(w).Encode(problem)
 }
 
-// mapErrorToStatusCode maps service error codes to HTTP status codes
+// mapErrorToStatusCode maps service error codes to HTTP status codes using the error code catalog
 func mapErrorToStatusCode(err *serviceerror.ServiceError) int {
+	// Server errors use 500 unless their code has a more specific server error status, such as 504
 	if err.Type == serviceerror.ServerErrorType {
+		if definition, ok := codes.Lookup(err.Code); ok && definition.Status > http.StatusInternalServerError {
+			return definition.Status
+		}
 		return http.StatusInternalServerError
 	}
 
-	// Client error type - map specific codes
-	switch err.Code {
-	case codes.ResourceNotFound, codes.ConsentNotFound, codes.PurposeNotFound, codes.AuthResourceNotFound:
-		return http.StatusNotFound
-	case codes.ConflictError, codes.PurposeInUse:
-		return http.StatusConflict
-	case codes.ValidationError, codes.InvalidRequest:
-		return http.StatusBadRequest
-	default:
-		return http.StatusBadRequest
+	// Client errors use the status of their code, or 400 for codes outside the catalog
+	if definition, ok := codes.Lookup(err.Code); ok && definition.Status < http.StatusInternalServerError {
+		return definition.Status
 	}
+	return http.StatusBadRequest
 }
 
 // extractTraceID extracts the trace ID (correlation ID) from the request context
@@ -123,3 +132,19 @@ func extractTraceID(r *http.Request) string {
 	}
 	return ""
 }
+
+// IfNoneMatch reports whether the If-None-Match header of r matches etag, in which case the
+// client's cached representation is current. Entity tags are compared weakly.
+func IfNoneMatch(r *http.Request, etag string) bool {
+	header := r.Header.Get(constants.HeaderIfNoneMatch)
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
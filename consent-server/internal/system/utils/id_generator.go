@@ -18,7 +18,18 @@
 // Package utils provides common utility functions.
 package utils
 
-import "github.com/google/uuid"
+import (
+	"crypto/rand"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ulidAlphabet is the Crockford base32 alphabet ULIDs are encoded with
+const ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidLength is the length of an encoded ULID
+const ulidLength = 26
 
 // GenerateUUID generates a plain UUID string.
 func GenerateUUID() string {
@@ -30,3 +41,65 @@ func IsValidUUID(id string) bool {
 	_, err := uuid.Parse(id)
 	return err == nil
 }
+
+// GenerateULID generates a ULID: a 48-bit millisecond timestamp followed by 80 random bits,
+// encoded as 26 Crockford base32 characters so that IDs sort by creation time.
+func GenerateULID() string {
+	var id [16]byte
+	ms := uint64(GetCurrentTimeMillis())
+	for i := 5; i >= 0; i-- {
+		id[i] = byte(ms)
+		ms >>= 8
+	}
+	_, _ = rand.Read(id[6:])
+
+	// Encode the 128 bits five at a time, starting with the two padding bits
+	var encoded [ulidLength]byte
+	for i := 0; i < ulidLength; i++ {
+		bit := i*5 - 2
+		var value byte
+		for j := 0; j < 5; j++ {
+			value <<= 1
+			if b := bit + j; b >= 0 && id[b/8]&(0x80>>(b%8)) != 0 {
+				value |= 1
+			}
+		}
+		encoded[i] = ulidAlphabet[value]
+	}
+	return string(encoded[:])
+}
+
+// IsValidULID checks if a string is a valid ULID. Lowercase ULIDs are accepted.
+func IsValidULID(id string) bool {
+	if len(id) != ulidLength || id[0] > '7' {
+		return false
+	}
+	for _, c := range strings.ToUpper(id) {
+		if !strings.ContainsRune(ulidAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidIDPrefix checks that an ID prefix only uses letters, digits, '_', '.' and '-'
+func IsValidIDPrefix(prefix string) bool {
+	for _, c := range prefix {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidGeneratedID checks if a string is a UUID or a ULID, optionally after an ID prefix
+func IsValidGeneratedID(id string) bool {
+	if IsValidUUID(id) {
+		return true
+	}
+	const uuidLength = 36
+	if len(id) >= uuidLength && IsValidIDPrefix(id[:len(id)-uuidLength]) && IsValidUUID(id[len(id)-uuidLength:]) {
+		return true
+	}
+	return len(id) >= ulidLength && IsValidIDPrefix(id[:len(id)-ulidLength]) && IsValidULID(id[len(id)-ulidLength:])
+}
@@ -18,11 +18,16 @@
 // Package utils provides common utility functions.
 package utils
 
-import "time"
+import (
+	"time"
+
+	"github.com/wso2/consent-management-api/internal/system/clock"
+)
 
 // GetCurrentTimeMillis returns current time in milliseconds since epoch.
+// The time is read from the server clock so that it can be controlled in test environments.
 func GetCurrentTimeMillis() int64 {
-	return time.Now().UnixNano() / int64(time.Millisecond)
+	return clock.NowMillis()
 }
 
 // MillisToTime converts milliseconds since epoch to time.Time.
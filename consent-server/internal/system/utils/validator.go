@@ -70,7 +70,8 @@ func ValidateUUID(id string) error {
 	return nil
 }
 
-// ValidateConsentID validates consent ID format
+// ValidateConsentID validates consent ID format: a UUID or ULID, optionally prefixed as configured
+// with consent.id_generation
 func ValidateConsentID(consentID string) error {
 	if err := ValidateRequired("consentID", consentID); err != nil {
 		return err
@@ -78,5 +79,8 @@ func ValidateConsentID(consentID string) error {
 	if len(consentID) > 100 {
 		return fmt.Errorf("consent ID too long (max 100 chars)")
 	}
-	return ValidateUUID(consentID)
+	if !IsValidGeneratedID(consentID) {
+		return fmt.Errorf("invalid consent ID format: %s", consentID)
+	}
+	return nil
 }
@@ -21,6 +21,7 @@
 DEBUG_PORT=${DEBUG_PORT:-2345}
 DEBUG_MODE=${DEBUG_MODE:-false}
 BINARY_NAME="consent-server"
+SERVER_ARGS=()
 
 # Parse command line arguments
 while [[ $# -gt 0 ]]; do
@@ -33,6 +34,10 @@ while [[ $# -gt 0 ]]; do
             DEBUG_PORT="$2"
             shift 2
             ;;
+        --dev)
+            SERVER_ARGS+=(--dev)
+            shift
+            ;;
         --help)
             echo "Consent Management Server Startup Script"
             echo ""
@@ -41,6 +46,7 @@ while [[ $# -gt 0 ]]; do
             echo "Options:"
             echo "  --debug              Enable debug mode with remote debugging"
             echo "  --debug-port PORT    Set debug port (default: 2345)"
+            echo "  --dev                Use an in-memory SQLite database instead of MySQL"
             echo "  --help               Show this help message"
             echo ""
             echo "First-Time Setup:"
@@ -62,6 +68,7 @@ while [[ $# -gt 0 ]]; do
             echo "Examples:"
             echo "  $0                          Start server normally"
             echo "  $0 --debug                  Start in debug mode"
+            echo "  $0 --dev                    Start without a database server"
             echo "  $0 --debug --debug-port 3456  Start with custom debug port"
             echo ""
             echo "Remote Debugging:"
@@ -158,14 +165,14 @@ if [ "$DEBUG_MODE" = "true" ]; then
     export GIN_MODE=debug
     
     # Run with debugger
-    dlv exec --listen=:$DEBUG_PORT --headless=true --api-version=2 --accept-multiclient --continue ./$BINARY_NAME &
+    dlv exec --listen=:$DEBUG_PORT --headless=true --api-version=2 --accept-multiclient --continue ./$BINARY_NAME -- "${SERVER_ARGS[@]}" &
     SERVER_PID=$!
 else
     echo "🔒 Starting Consent Management Server..."
     echo ""
 
     # Run normally (GIN_MODE will default to release mode in main.go)
-    ./$BINARY_NAME &
+    ./$BINARY_NAME "${SERVER_ARGS[@]}" &
     SERVER_PID=$!
 fi
 
@@ -0,0 +1,736 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Check categories
+const (
+	categoryPurposes  = "purposes"
+	categoryConsents  = "consents"
+	categoryStatus    = "status-derivation"
+	categoryExpiry    = "expiry"
+	categoryValidate  = "validate"
+	categoryErrorCode = "error-codes"
+)
+
+// unknownID is a well-formed ID that no server should have issued
+const unknownID = "00000000-0000-0000-0000-000000000000"
+
+type consentResponse struct {
+	ID             string                  `json:"id"`
+	ClientID       string                  `json:"clientId"`
+	Type           string                  `json:"type"`
+	Status         string                  `json:"status"`
+	ValidityTime   *int64                  `json:"validityTime"`
+	Version        int                     `json:"version"`
+	Attributes     map[string]string       `json:"attributes"`
+	ConsentPurpose []map[string]any        `json:"consentPurpose"`
+	Authorizations []authorizationResponse `json:"authorizations"`
+}
+
+type authorizationResponse struct {
+	ID     string  `json:"id"`
+	UserID *string `json:"userId"`
+	Type   string  `json:"type"`
+	Status string  `json:"status"`
+}
+
+type validateResponse struct {
+	IsValid            bool             `json:"isValid"`
+	ErrorCode          int              `json:"errorCode"`
+	ErrorMessage       string           `json:"errorMessage"`
+	ConsentInformation *consentResponse `json:"consentInformation"`
+}
+
+type errorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	TraceID string `json:"traceId"`
+}
+
+// AllChecks returns the conformance checks in the order they run
+func AllChecks() []Check {
+	return []Check{
+		{"PUR-01", categoryPurposes, "Creating a purpose returns 201 with the created purpose", checkCreatePurpose},
+		{"PUR-02", categoryPurposes, "A created purpose can be read by ID", checkGetPurpose},
+		{"PUR-03", categoryPurposes, "Creating a purpose without a name returns 400", checkCreatePurposeWithoutName},
+		{"PUR-04", categoryPurposes, "Reading an unknown purpose returns 404", checkGetUnknownPurpose},
+
+		{"CON-01", categoryConsents, "Creating a consent returns 201 with ID, version 1 and the request fields", checkCreateConsent},
+		{"CON-02", categoryConsents, "A created consent can be read by ID", checkGetConsent},
+		{"CON-03", categoryConsents, "Listing consents filtered by type returns the created consent", checkListConsents},
+		{"CON-04", categoryConsents, "Updating a consent replaces its attributes", checkUpdateConsent},
+		{"CON-05", categoryConsents, "Revoking a consent sets the revoked status", checkRevokeConsent},
+		{"CON-06", categoryConsents, "Revoking a revoked consent returns 409", checkRevokeTwice},
+		{"CON-07", categoryConsents, "A deleted consent can no longer be read", checkDeleteConsent},
+
+		{"STS-01", categoryStatus, "A consent whose authorizations are all approved is active", checkStatusAllApproved},
+		{"STS-02", categoryStatus, "A consent with a created authorization is created", checkStatusCreated},
+		{"STS-03", categoryStatus, "A consent with a rejected authorization is rejected, even when others are approved", checkStatusRejected},
+		{"STS-04", categoryStatus, "A consent without authorizations is created", checkStatusNoAuthorizations},
+		{"STS-05", categoryStatus, "Approving the last pending authorization through an update activates the consent", checkStatusAfterUpdate},
+
+		{"EXP-01", categoryExpiry, "A consent with a future validity time stays active", checkFutureValidity},
+		{"EXP-02", categoryExpiry, "An active consent past its validity time is reported as expired", checkPastValidity},
+		{"EXP-03", categoryExpiry, "Validating an expired consent fails", checkValidateExpired},
+
+		{"VAL-01", categoryValidate, "Validating an active consent succeeds and returns the consent", checkValidateActive},
+		{"VAL-02", categoryValidate, "Validating a consent that is not active fails with error code 401", checkValidateInactive},
+		{"VAL-03", categoryValidate, "Validating an unknown consent fails with error code 404", checkValidateUnknown},
+
+		{"ERR-01", categoryErrorCode, "Requests without the org-id header return 400", checkMissingOrgID},
+		{"ERR-02", categoryErrorCode, "Creating a consent without the client ID header returns 400", checkMissingClientID},
+		{"ERR-03", categoryErrorCode, "A malformed JSON body returns 400", checkMalformedBody},
+		{"ERR-04", categoryErrorCode, "Creating a consent without a type returns 400", checkMissingConsentType},
+		{"ERR-05", categoryErrorCode, "Creating a consent with an unknown purpose returns 400", checkUnknownPurpose},
+		{"ERR-06", categoryErrorCode, "Reading an unknown consent returns 404", checkGetUnknownConsent},
+		{"ERR-07", categoryErrorCode, "A consent is not visible to another organization", checkOrgIsolation},
+	}
+}
+
+// setupPurpose creates the purpose that consents created by the checks refer to
+func setupPurpose(ctx *Context) error {
+	ctx.PurposeName = "conformance_" + ctx.RunID
+	_, err := createPurpose(ctx, ctx.PurposeName)
+	return err
+}
+
+func createPurpose(ctx *Context, name string) (string, error) {
+	payload := []map[string]any{{
+		"name":        name,
+		"description": "Created by the consent API conformance kit",
+		"type":        "string",
+	}}
+	resp, err := ctx.Client.Do(http.MethodPost, "/consent-purposes", payload, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := resp.Expect(http.StatusCreated); err != nil {
+		return "", err
+	}
+	var created struct {
+		Data []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := resp.Decode(&created); err != nil {
+		return "", err
+	}
+	if len(created.Data) != 1 || created.Data[0].ID == "" {
+		return "", fmt.Errorf("expected one created purpose with an ID, got %s", truncate(string(resp.Body)))
+	}
+
+	id := created.Data[0].ID
+	ctx.Cleanup(func() error {
+		resp, err := ctx.Client.Do(http.MethodDelete, "/consent-purposes/"+id, nil, nil)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("failed to delete purpose %s: status %d", id, resp.StatusCode)
+		}
+		return nil
+	})
+	return id, nil
+}
+
+// consentPayload builds a create request with one authorization per status
+func consentPayload(ctx *Context, consentType string, validityTime *int64, authStatuses ...string) map[string]any {
+	authorizations := make([]map[string]any, 0, len(authStatuses))
+	for i, status := range authStatuses {
+		authorizations = append(authorizations, map[string]any{
+			"userId": fmt.Sprintf("conformance-user-%d", i+1),
+			"type":   "authorization",
+			"status": status,
+		})
+	}
+	payload := map[string]any{
+		"type": consentType,
+		"consentPurpose": []map[string]any{
+			{"name": ctx.PurposeName, "value": "conformance", "isUserApproved": true},
+		},
+		"attributes":     map[string]string{"conformanceRun": ctx.RunID},
+		"authorizations": authorizations,
+	}
+	if validityTime != nil {
+		payload["validityTime"] = *validityTime
+	}
+	return payload
+}
+
+// createConsent creates a consent and registers its deletion
+func createConsent(ctx *Context, payload map[string]any) (*consentResponse, error) {
+	resp, err := ctx.Client.Do(http.MethodPost, "/consents", payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Expect(http.StatusCreated); err != nil {
+		return nil, err
+	}
+	var consent consentResponse
+	if err := resp.Decode(&consent); err != nil {
+		return nil, err
+	}
+	if consent.ID == "" {
+		return nil, fmt.Errorf("created consent has no ID")
+	}
+
+	ctx.Cleanup(func() error {
+		resp, err := ctx.Client.Do(http.MethodDelete, "/consents/"+consent.ID, nil, nil)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("failed to delete consent %s: status %d", consent.ID, resp.StatusCode)
+		}
+		return nil
+	})
+	return &consent, nil
+}
+
+func getConsent(ctx *Context, consentID string) (*consentResponse, error) {
+	resp, err := ctx.Client.Do(http.MethodGet, "/consents/"+consentID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Expect(http.StatusOK); err != nil {
+		return nil, err
+	}
+	var consent consentResponse
+	if err := resp.Decode(&consent); err != nil {
+		return nil, err
+	}
+	return &consent, nil
+}
+
+func validateConsent(ctx *Context, consentID string) (*validateResponse, error) {
+	payload := map[string]any{
+		"consentId": consentID,
+		"headers":   map[string]any{},
+		"payload":   map[string]any{},
+	}
+	resp, err := ctx.Client.Do(http.MethodPost, "/consents/validate", payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Expect(http.StatusOK); err != nil {
+		return nil, err
+	}
+	var result validateResponse
+	if err := resp.Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// expectError checks the status code and that the body is a well-formed error response
+func expectError(resp *Response, statusCode int) error {
+	if err := resp.Expect(statusCode); err != nil {
+		return err
+	}
+	var body errorResponse
+	if err := resp.Decode(&body); err != nil {
+		return err
+	}
+	if body.Code == "" || body.Message == "" {
+		return fmt.Errorf("error response must have a code and a message, got %s", truncate(string(resp.Body)))
+	}
+	return nil
+}
+
+func expectStatus(consent *consentResponse, status string) error {
+	if consent.Status != status {
+		return fmt.Errorf("expected consent status %q, got %q", status, consent.Status)
+	}
+	return nil
+}
+
+func consentType(ctx *Context) string {
+	return "conformance-" + ctx.RunID
+}
+
+func checkCreatePurpose(ctx *Context) error {
+	_, err := createPurpose(ctx, "conformance_create_"+ctx.RunID)
+	return err
+}
+
+func checkGetPurpose(ctx *Context) error {
+	name := "conformance_get_" + ctx.RunID
+	id, err := createPurpose(ctx, name)
+	if err != nil {
+		return err
+	}
+	resp, err := ctx.Client.Do(http.MethodGet, "/consent-purposes/"+id, nil, nil)
+	if err != nil {
+		return err
+	}
+	if err := resp.Expect(http.StatusOK); err != nil {
+		return err
+	}
+	var purpose struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if err := resp.Decode(&purpose); err != nil {
+		return err
+	}
+	if purpose.ID != id || purpose.Name != name || purpose.Type != "string" {
+		return fmt.Errorf("expected purpose %s named %q of type string, got %s", id, name, truncate(string(resp.Body)))
+	}
+	return nil
+}
+
+func checkCreatePurposeWithoutName(ctx *Context) error {
+	payload := []map[string]any{{"type": "string"}}
+	resp, err := ctx.Client.Do(http.MethodPost, "/consent-purposes", payload, nil)
+	if err != nil {
+		return err
+	}
+	return expectError(resp, http.StatusBadRequest)
+}
+
+func checkGetUnknownPurpose(ctx *Context) error {
+	resp, err := ctx.Client.Do(http.MethodGet, "/consent-purposes/"+unknownID, nil, nil)
+	if err != nil {
+		return err
+	}
+	return expectError(resp, http.StatusNotFound)
+}
+
+func checkCreateConsent(ctx *Context) error {
+	consent, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	if consent.Type != consentType(ctx) {
+		return fmt.Errorf("expected consent type %q, got %q", consentType(ctx), consent.Type)
+	}
+	if consent.Version != 1 {
+		return fmt.Errorf("expected version 1, got %d", consent.Version)
+	}
+	if consent.Attributes["conformanceRun"] != ctx.RunID {
+		return fmt.Errorf("expected attribute conformanceRun=%s, got %v", ctx.RunID, consent.Attributes)
+	}
+	if len(consent.Authorizations) != 1 || consent.Authorizations[0].ID == "" {
+		return fmt.Errorf("expected one authorization with an ID, got %d", len(consent.Authorizations))
+	}
+	return nil
+}
+
+func checkGetConsent(ctx *Context) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	consent, err := getConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if consent.ID != created.ID || consent.Type != created.Type || consent.Status != created.Status {
+		return fmt.Errorf("read consent %s/%s/%s does not match created consent %s/%s/%s",
+			consent.ID, consent.Type, consent.Status, created.ID, created.Type, created.Status)
+	}
+	if len(consent.ConsentPurpose) != 1 {
+		return fmt.Errorf("expected one consent purpose, got %d", len(consent.ConsentPurpose))
+	}
+	return nil
+}
+
+func checkListConsents(ctx *Context) error {
+	listType := "conformance-list-" + ctx.RunID
+	created, err := createConsent(ctx, consentPayload(ctx, listType, nil, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	resp, err := ctx.Client.Do(http.MethodGet, "/consents?consentTypes="+url.QueryEscape(listType), nil, nil)
+	if err != nil {
+		return err
+	}
+	if err := resp.Expect(http.StatusOK); err != nil {
+		return err
+	}
+	var list struct {
+		Data     []consentResponse `json:"data"`
+		Metadata struct {
+			Count int `json:"count"`
+		} `json:"metadata"`
+	}
+	if err := resp.Decode(&list); err != nil {
+		return err
+	}
+	if len(list.Data) != 1 || list.Data[0].ID != created.ID {
+		return fmt.Errorf("expected only consent %s, got %d consents", created.ID, len(list.Data))
+	}
+	if list.Metadata.Count != 1 {
+		return fmt.Errorf("expected metadata count 1, got %d", list.Metadata.Count)
+	}
+	return nil
+}
+
+func checkUpdateConsent(ctx *Context) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	update := consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved)
+	update["attributes"] = map[string]string{"conformanceRun": ctx.RunID, "updated": "true"}
+	resp, err := ctx.Client.Do(http.MethodPut, "/consents/"+created.ID, update, nil)
+	if err != nil {
+		return err
+	}
+	if err := resp.Expect(http.StatusOK); err != nil {
+		return err
+	}
+
+	consent, err := getConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if consent.Attributes["updated"] != "true" {
+		return fmt.Errorf("expected the updated attributes, got %v", consent.Attributes)
+	}
+	return nil
+}
+
+func revoke(ctx *Context, consentID string) (*Response, error) {
+	payload := map[string]any{"actionBy": "conformance-kit", "revocationReason": "Conformance check"}
+	return ctx.Client.Do(http.MethodPut, "/consents/"+consentID+"/revoke", payload, nil)
+}
+
+func checkRevokeConsent(ctx *Context) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	resp, err := revoke(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if err := resp.Expect(http.StatusOK); err != nil {
+		return err
+	}
+
+	consent, err := getConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	return expectStatus(consent, ctx.Statuses.Revoked)
+}
+
+func checkRevokeTwice(ctx *Context) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	resp, err := revoke(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if err := resp.Expect(http.StatusOK); err != nil {
+		return err
+	}
+	resp, err = revoke(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	return expectError(resp, http.StatusConflict)
+}
+
+func checkDeleteConsent(ctx *Context) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	resp, err := ctx.Client.Do(http.MethodDelete, "/consents/"+created.ID, nil, nil)
+	if err != nil {
+		return err
+	}
+	if err := resp.Expect(http.StatusNoContent); err != nil {
+		return err
+	}
+	resp, err = ctx.Client.Do(http.MethodGet, "/consents/"+created.ID, nil, nil)
+	if err != nil {
+		return err
+	}
+	return expectError(resp, http.StatusNotFound)
+}
+
+// checkDerivedStatus creates a consent with the given authorization statuses and checks the
+// status in both the create response and a subsequent read
+func checkDerivedStatus(ctx *Context, expected string, authStatuses ...string) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, authStatuses...))
+	if err != nil {
+		return err
+	}
+	if err := expectStatus(created, expected); err != nil {
+		return fmt.Errorf("create response: %w", err)
+	}
+	consent, err := getConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	return expectStatus(consent, expected)
+}
+
+func checkStatusAllApproved(ctx *Context) error {
+	return checkDerivedStatus(ctx, ctx.Statuses.Active, ctx.Statuses.AuthApproved, ctx.Statuses.AuthApproved)
+}
+
+func checkStatusCreated(ctx *Context) error {
+	return checkDerivedStatus(ctx, ctx.Statuses.Created, ctx.Statuses.AuthApproved, ctx.Statuses.AuthCreated)
+}
+
+func checkStatusRejected(ctx *Context) error {
+	return checkDerivedStatus(ctx, ctx.Statuses.Rejected,
+		ctx.Statuses.AuthApproved, ctx.Statuses.AuthCreated, ctx.Statuses.AuthRejected)
+}
+
+func checkStatusNoAuthorizations(ctx *Context) error {
+	return checkDerivedStatus(ctx, ctx.Statuses.Created)
+}
+
+func checkStatusAfterUpdate(ctx *Context) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthCreated))
+	if err != nil {
+		return err
+	}
+	if err := expectStatus(created, ctx.Statuses.Created); err != nil {
+		return err
+	}
+	update := consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved)
+	resp, err := ctx.Client.Do(http.MethodPut, "/consents/"+created.ID, update, nil)
+	if err != nil {
+		return err
+	}
+	if err := resp.Expect(http.StatusOK); err != nil {
+		return err
+	}
+	consent, err := getConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	return expectStatus(consent, ctx.Statuses.Active)
+}
+
+// createExpiredConsent creates an approved consent whose validity time has already passed.
+// Servers that reject past validity times on create (strict validation) skip the check.
+func createExpiredConsent(ctx *Context) (*consentResponse, error) {
+	validityTime := time.Now().Add(-time.Hour).Unix()
+	payload := consentPayload(ctx, consentType(ctx), &validityTime, ctx.Statuses.AuthApproved)
+	resp, err := ctx.Client.Do(http.MethodPost, "/consents", payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusBadRequest {
+		return nil, Skip("server rejects consents created with a past validity time")
+	}
+	if err := resp.Expect(http.StatusCreated); err != nil {
+		return nil, err
+	}
+	var created consentResponse
+	if err := resp.Decode(&created); err != nil {
+		return nil, err
+	}
+	ctx.Cleanup(func() error {
+		_, err := ctx.Client.Do(http.MethodDelete, "/consents/"+created.ID, nil, nil)
+		return err
+	})
+	return &created, nil
+}
+
+func checkFutureValidity(ctx *Context) error {
+	validityTime := time.Now().Add(24 * time.Hour).Unix()
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), &validityTime, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	consent, err := getConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if consent.ValidityTime == nil || *consent.ValidityTime != validityTime {
+		return fmt.Errorf("expected validity time %d, got %v", validityTime, consent.ValidityTime)
+	}
+	return expectStatus(consent, ctx.Statuses.Active)
+}
+
+func checkPastValidity(ctx *Context) error {
+	created, err := createExpiredConsent(ctx)
+	if err != nil {
+		return err
+	}
+	consent, err := getConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	return expectStatus(consent, ctx.Statuses.Expired)
+}
+
+func checkValidateExpired(ctx *Context) error {
+	created, err := createExpiredConsent(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := validateConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if result.IsValid {
+		return fmt.Errorf("expected an expired consent to be invalid")
+	}
+	return nil
+}
+
+func checkValidateActive(ctx *Context) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	result, err := validateConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if !result.IsValid {
+		return fmt.Errorf("expected the consent to be valid, got error %d %s", result.ErrorCode, result.ErrorMessage)
+	}
+	if result.ConsentInformation == nil || result.ConsentInformation.ID != created.ID {
+		return fmt.Errorf("expected consent information for %s", created.ID)
+	}
+	return nil
+}
+
+func checkValidateInactive(ctx *Context) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthCreated))
+	if err != nil {
+		return err
+	}
+	result, err := validateConsent(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if result.IsValid || result.ErrorCode != http.StatusUnauthorized {
+		return fmt.Errorf("expected an invalid result with error code 401, got valid=%t code=%d", result.IsValid, result.ErrorCode)
+	}
+	return nil
+}
+
+func checkValidateUnknown(ctx *Context) error {
+	result, err := validateConsent(ctx, unknownID)
+	if err != nil {
+		return err
+	}
+	if result.IsValid || result.ErrorCode != http.StatusNotFound {
+		return fmt.Errorf("expected an invalid result with error code 404, got valid=%t code=%d", result.IsValid, result.ErrorCode)
+	}
+	return nil
+}
+
+func checkMissingOrgID(ctx *Context) error {
+	noOrg := map[string]string{headerOrgID: ""}
+	requests := []struct {
+		method string
+		path   string
+		body   any
+	}{
+		{http.MethodGet, "/consents", nil},
+		{http.MethodGet, "/consents/" + unknownID, nil},
+		{http.MethodPost, "/consents", consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved)},
+		{http.MethodGet, "/consent-purposes", nil},
+	}
+	for _, r := range requests {
+		resp, err := ctx.Client.Do(r.method, r.path, r.body, noOrg)
+		if err != nil {
+			return err
+		}
+		if err := expectError(resp, http.StatusBadRequest); err != nil {
+			return fmt.Errorf("%s %s: %w", r.method, r.path, err)
+		}
+	}
+	return nil
+}
+
+func checkMissingClientID(ctx *Context) error {
+	payload := consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved)
+	resp, err := ctx.Client.Do(http.MethodPost, "/consents", payload, map[string]string{headerClientID: ""})
+	if err != nil {
+		return err
+	}
+	return expectError(resp, http.StatusBadRequest)
+}
+
+func checkMalformedBody(ctx *Context) error {
+	for _, path := range []string{"/consents", "/consent-purposes", "/consents/validate"} {
+		resp, err := ctx.Client.Do(http.MethodPost, path, []byte(`{"type": `), nil)
+		if err != nil {
+			return err
+		}
+		if err := expectError(resp, http.StatusBadRequest); err != nil {
+			return fmt.Errorf("POST %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func checkMissingConsentType(ctx *Context) error {
+	payload := consentPayload(ctx, "", nil, ctx.Statuses.AuthApproved)
+	resp, err := ctx.Client.Do(http.MethodPost, "/consents", payload, nil)
+	if err != nil {
+		return err
+	}
+	return expectError(resp, http.StatusBadRequest)
+}
+
+func checkUnknownPurpose(ctx *Context) error {
+	payload := consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved)
+	payload["consentPurpose"] = []map[string]any{{"name": "conformance_unknown_" + ctx.RunID, "value": "x"}}
+	resp, err := ctx.Client.Do(http.MethodPost, "/consents", payload, nil)
+	if err != nil {
+		return err
+	}
+	return expectError(resp, http.StatusBadRequest)
+}
+
+func checkGetUnknownConsent(ctx *Context) error {
+	resp, err := ctx.Client.Do(http.MethodGet, "/consents/"+unknownID, nil, nil)
+	if err != nil {
+		return err
+	}
+	return expectError(resp, http.StatusNotFound)
+}
+
+func checkOrgIsolation(ctx *Context) error {
+	created, err := createConsent(ctx, consentPayload(ctx, consentType(ctx), nil, ctx.Statuses.AuthApproved))
+	if err != nil {
+		return err
+	}
+	otherOrg := map[string]string{headerOrgID: "conformance-other-org-" + ctx.RunID}
+	resp, err := ctx.Client.Do(http.MethodGet, "/consents/"+created.ID, nil, otherOrg)
+	if err != nil {
+		return err
+	}
+	return expectError(resp, http.StatusNotFound)
+}
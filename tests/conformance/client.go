@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	headerContentType = "Content-Type"
+	headerOrgID       = "org-id"
+	headerClientID    = "TPP-client-id"
+
+	apiBasePath = "/api/v1"
+)
+
+// Client sends requests to the consent server under test
+type Client struct {
+	baseURL    string
+	orgID      string
+	clientID   string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// Response is a buffered HTTP response
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// NewClient creates a client for the server at baseURL. Basic auth is only sent when username is set.
+func NewClient(baseURL, orgID, clientID, username, password string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		orgID:      orgID,
+		clientID:   clientID,
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Do sends a request to path, relative to the API base path, with the configured org and client headers.
+// A header in headers overrides the default; an empty value removes it.
+func (c *Client) Do(method, path string, body interface{}, headers map[string]string) (*Response, error) {
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+	default:
+		payload, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+apiBasePath+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if reader != nil {
+		req.Header.Set(headerContentType, "application/json")
+	}
+	req.Header.Set(headerOrgID, c.orgID)
+	req.Header.Set(headerClientID, c.clientID)
+	for name, value := range headers {
+		if value == "" {
+			req.Header.Del(name)
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody}, nil
+}
+
+// Health checks that the server answers on /health
+func (c *Client) Health() error {
+	resp, err := c.httpClient.Get(c.baseURL + "/health")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Decode unmarshals the response body into v
+func (r *Response) Decode(v interface{}) error {
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		return fmt.Errorf("failed to decode response body %q: %w", truncate(string(r.Body)), err)
+	}
+	return nil
+}
+
+// Expect returns an error unless the response has the given status code
+func (r *Response) Expect(statusCode int) error {
+	if r.StatusCode != statusCode {
+		return fmt.Errorf("expected status %d, got %d: %s", statusCode, r.StatusCode, truncate(string(r.Body)))
+	}
+	return nil
+}
+
+func truncate(s string) string {
+	const max = 300
+	if len(s) > max {
+		return s[:max] + "..."
+	}
+	return s
+}
@@ -0,0 +1,3 @@
+module github.com/wso2/consent-management-api/tests/conformance
+
+go 1.21
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Result outcomes
+const (
+	OutcomePass = "PASS"
+	OutcomeFail = "FAIL"
+	OutcomeSkip = "SKIP"
+)
+
+// Check is a single conformance requirement
+type Check struct {
+	ID          string
+	Category    string
+	Description string
+	Run         func(ctx *Context) error
+}
+
+// StatusNames are the consent and authorization statuses configured on the server under test
+// (consent.status_mappings and consent.auth_status_mappings in deployment.yaml)
+type StatusNames struct {
+	Active       string
+	Created      string
+	Rejected     string
+	Revoked      string
+	Expired      string
+	AuthApproved string
+	AuthCreated  string
+	AuthRejected string
+}
+
+// Context carries the client and the resources shared by checks in a run
+type Context struct {
+	Client   *Client
+	Statuses StatusNames
+	// PurposeName is a consent purpose created for the run, referenced by every consent the checks create
+	PurposeName string
+	RunID       string
+
+	cleanups []func() error
+}
+
+// skipError marks a check that could not be evaluated against the server under test
+type skipError struct {
+	reason string
+}
+
+func (e *skipError) Error() string {
+	return e.reason
+}
+
+// Skip returns an error that reports the check as skipped
+func Skip(format string, args ...interface{}) error {
+	return &skipError{reason: fmt.Sprintf(format, args...)}
+}
+
+// Cleanup registers fn to run after all checks complete, in reverse order of registration
+func (c *Context) Cleanup(fn func() error) {
+	c.cleanups = append(c.cleanups, fn)
+}
+
+// runCleanups runs the registered cleanups and returns the errors they reported
+func (c *Context) runCleanups() []error {
+	var errs []error
+	for i := len(c.cleanups) - 1; i >= 0; i-- {
+		if err := c.cleanups[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.cleanups = nil
+	return errs
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	ID             string `json:"id"`
+	Category       string `json:"category"`
+	Description    string `json:"description"`
+	Outcome        string `json:"outcome"`
+	Message        string `json:"message,omitempty"`
+	DurationMillis int64  `json:"durationMillis"`
+}
+
+// RunChecks runs the checks whose ID or category matches filter (all checks when filter is nil)
+func RunChecks(ctx *Context, checks []Check, filter *regexp.Regexp, onResult func(Result)) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		if filter != nil && !filter.MatchString(check.ID) && !filter.MatchString(check.Category) {
+			continue
+		}
+		result := runCheck(ctx, check)
+		if onResult != nil {
+			onResult(result)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func runCheck(ctx *Context, check Check) (result Result) {
+	result = Result{ID: check.ID, Category: check.Category, Description: check.Description}
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			result.Outcome = OutcomeFail
+			result.Message = fmt.Sprintf("check panicked: %v", r)
+		}
+		result.DurationMillis = time.Since(start).Milliseconds()
+	}()
+
+	err := check.Run(ctx)
+	var skip *skipError
+	switch {
+	case err == nil:
+		result.Outcome = OutcomePass
+	case errors.As(err, &skip):
+		result.Outcome = OutcomeSkip
+		result.Message = skip.reason
+	default:
+		result.Outcome = OutcomeFail
+		result.Message = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Command conformance verifies that a deployed consent server behaves as the Consent Management API
+// specifies. It creates its own purposes and consents under the given organization, checks the
+// responses and removes what it created.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+func main() {
+	target := flag.String("url", "http://localhost:3000", "base URL of the consent server under test")
+	orgID := flag.String("org", "conformance-org", "organization the checks create their data in")
+	clientID := flag.String("client", "conformance-client", "client ID sent in the TPP-client-id header")
+	username := flag.String("username", "", "basic auth username, if the server requires basic auth")
+	password := flag.String("password", "", "basic auth password")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout for each request")
+	run := flag.String("run", "", "only run checks whose ID or category matches this regular expression")
+	jsonReport := flag.String("report", "", "write a JSON report to this file")
+	junitReport := flag.String("junit", "", "write a JUnit XML report to this file")
+	list := flag.Bool("list", false, "list the checks and exit")
+
+	statuses := StatusNames{}
+	flag.StringVar(&statuses.Active, "status-active", "ACTIVE", "configured active consent status")
+	flag.StringVar(&statuses.Created, "status-created", "CREATED", "configured created consent status")
+	flag.StringVar(&statuses.Rejected, "status-rejected", "REJECTED", "configured rejected consent status")
+	flag.StringVar(&statuses.Revoked, "status-revoked", "REVOKED", "configured revoked consent status")
+	flag.StringVar(&statuses.Expired, "status-expired", "EXPIRED", "configured expired consent status")
+	flag.StringVar(&statuses.AuthApproved, "auth-status-approved", "APPROVED", "configured approved authorization status")
+	flag.StringVar(&statuses.AuthCreated, "auth-status-created", "CREATED", "configured created authorization status")
+	flag.StringVar(&statuses.AuthRejected, "auth-status-rejected", "REJECTED", "configured rejected authorization status")
+	flag.Parse()
+
+	checks := AllChecks()
+	if *list {
+		for _, check := range checks {
+			fmt.Printf("%-7s %-18s %s\n", check.ID, check.Category, check.Description)
+		}
+		return
+	}
+
+	var filter *regexp.Regexp
+	if *run != "" {
+		var err error
+		if filter, err = regexp.Compile(*run); err != nil {
+			fmt.Printf("Invalid -run expression: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	client := NewClient(*target, *orgID, *clientID, *username, *password, *timeout)
+	if err := client.Health(); err != nil {
+		fmt.Printf("Consent server at %s is not reachable: %v\n", *target, err)
+		os.Exit(2)
+	}
+
+	ctx := &Context{
+		Client:   client,
+		Statuses: statuses,
+		RunID:    strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+	report := &Report{Target: *target, OrgID: *orgID, StartedAt: time.Now().UTC()}
+
+	fmt.Printf("Running consent API conformance checks against %s (org %s)\n\n", *target, *orgID)
+	if err := setupPurpose(ctx); err != nil {
+		fmt.Printf("Failed to create the conformance purpose: %v\n", err)
+		os.Exit(2)
+	}
+	report.Results = RunChecks(ctx, checks, filter, func(result Result) {
+		printResult(os.Stdout, result)
+	})
+	for _, err := range ctx.runCleanups() {
+		report.CleanupErrors = append(report.CleanupErrors, err.Error())
+	}
+	report.FinishedAt = time.Now().UTC()
+	report.Summary = NewSummary(report.Results)
+	printSummary(os.Stdout, report)
+
+	if *jsonReport != "" {
+		if err := writeJSONReport(*jsonReport, report); err != nil {
+			fmt.Printf("Failed to write JSON report: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	if *junitReport != "" {
+		if err := writeJUnitReport(*junitReport, report); err != nil {
+			fmt.Printf("Failed to write JUnit report: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	if report.Summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Report summarizes a conformance run
+type Report struct {
+	Target     string    `json:"target"`
+	OrgID      string    `json:"orgId"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Summary    Summary   `json:"summary"`
+	Results    []Result  `json:"results"`
+	// CleanupErrors lists resources the kit created but could not remove
+	CleanupErrors []string `json:"cleanupErrors,omitempty"`
+}
+
+// Summary counts results by outcome
+type Summary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// NewSummary counts the outcomes of results
+func NewSummary(results []Result) Summary {
+	summary := Summary{Total: len(results)}
+	for _, result := range results {
+		switch result.Outcome {
+		case OutcomePass:
+			summary.Passed++
+		case OutcomeFail:
+			summary.Failed++
+		case OutcomeSkip:
+			summary.Skipped++
+		}
+	}
+	return summary
+}
+
+// printResult writes a one-line result to w as checks complete
+func printResult(w io.Writer, result Result) {
+	fmt.Fprintf(w, "%-4s %-7s %s (%dms)\n", result.Outcome, result.ID, result.Description, result.DurationMillis)
+	if result.Message != "" {
+		fmt.Fprintf(w, "             %s\n", result.Message)
+	}
+}
+
+// printSummary writes the totals of the report to w
+func printSummary(w io.Writer, report *Report) {
+	s := report.Summary
+	fmt.Fprintf(w, "\n%d checks: %d passed, %d failed, %d skipped\n", s.Total, s.Passed, s.Failed, s.Skipped)
+	for _, msg := range report.CleanupErrors {
+		fmt.Fprintf(w, "cleanup: %s\n", msg)
+	}
+}
+
+// writeJSONReport writes the report as indented JSON
+func writeJSONReport(path string, report *Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeJUnitReport writes the report in the JUnit XML format understood by CI servers
+func writeJUnitReport(path string, report *Report) error {
+	suite := junitTestSuite{
+		Name:     "consent-api-conformance",
+		Tests:    report.Summary.Total,
+		Failures: report.Summary.Failed,
+		Skipped:  report.Summary.Skipped,
+		Time:     seconds(report.FinishedAt.Sub(report.StartedAt).Milliseconds()),
+	}
+	for _, result := range report.Results {
+		testCase := junitTestCase{
+			Name:      result.ID + " " + result.Description,
+			ClassName: result.Category,
+			Time:      seconds(result.DurationMillis),
+		}
+		switch result.Outcome {
+		case OutcomeFail:
+			testCase.Failure = &junitMessage{Message: result.Message}
+		case OutcomeSkip:
+			testCase.Skipped = &junitMessage{Message: result.Message}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0o644)
+}
+
+func seconds(millis int64) string {
+	return fmt.Sprintf("%.3f", float64(millis)/1000)
+}
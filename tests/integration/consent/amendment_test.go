@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// POST /consents/{id}/amendments and GET /consents/{id}/versions Tests
+// ============================
+
+// amendConsent amends a consent and returns response and body
+func (ts *ConsentAPITestSuite) amendConsent(consentID string, payload interface{}) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	url := fmt.Sprintf("%s/api/v1/consents/%s/amendments", testServerURL, consentID)
+	httpReq, _ := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// getConsentVersions retrieves a consent version list, or a single version when version is set
+func (ts *ConsentAPITestSuite) getConsentVersions(consentID, version string) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consents/%s/versions", testServerURL, consentID)
+	if version != "" {
+		url = fmt.Sprintf("%s/%s", url, version)
+	}
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestAmendConsent_KeepsPreviousVersion amends a consent and retrieves the superseded version
+func (ts *ConsentAPITestSuite) TestAmendConsent_KeepsPreviousVersion() {
+	createPayload := ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "marketing-purpose", IsUserApproved: true},
+		},
+		Attributes: map[string]string{"channel": "web"},
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "auth", Status: "APPROVED"},
+		},
+	}
+
+	createResp, createBody := ts.createConsent(createPayload)
+	defer createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+	ts.Equal(1, created.Version)
+
+	amendment := ConsentAmendmentRequest{
+		ConsentUpdateRequest: ConsentUpdateRequest{
+			ConsentPurpose: []ConsentPurposeItem{
+				{Name: "marketing-purpose", IsUserApproved: true},
+				{Name: "analytics-purpose", IsUserApproved: true},
+			},
+			Attributes: map[string]string{"channel": "mobile"},
+		},
+		AmendedBy: "user1",
+		Reason:    "re-authorization",
+	}
+
+	amendResp, amendBody := ts.amendConsent(created.ID, amendment)
+	defer amendResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, amendResp.StatusCode, string(amendBody))
+
+	var amended ConsentResponse
+	ts.Require().NoError(json.Unmarshal(amendBody, &amended))
+	ts.Equal(2, amended.Version)
+	ts.Len(amended.ConsentPurpose, 2)
+	ts.Equal("mobile", amended.Attributes["channel"])
+
+	// The version list contains the superseded version only
+	listResp, listBody := ts.getConsentVersions(created.ID, "")
+	defer listResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, listResp.StatusCode, string(listBody))
+
+	var versions ConsentVersionListResponse
+	ts.Require().NoError(json.Unmarshal(listBody, &versions))
+	ts.Equal(2, versions.CurrentVersion)
+	ts.Require().Len(versions.Data, 1)
+	ts.Equal(1, versions.Data[0].Version)
+	ts.Equal("user1", versions.Data[0].AmendedBy)
+	ts.Equal("re-authorization", versions.Data[0].Reason)
+
+	// Version 1 still shows the original purposes and attributes
+	v1Resp, v1Body := ts.getConsentVersions(created.ID, "1")
+	defer v1Resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, v1Resp.StatusCode, string(v1Body))
+
+	var v1 ConsentVersionResponse
+	ts.Require().NoError(json.Unmarshal(v1Body, &v1))
+	ts.False(v1.Current)
+	ts.NotNil(v1.AmendedTime)
+	ts.Equal(1, v1.Consent.Version)
+	ts.Len(v1.Consent.ConsentPurpose, 1)
+	ts.Equal("web", v1.Consent.Attributes["channel"])
+	ts.Len(v1.Consent.Authorizations, 1)
+
+	// The current version is served from the live consent
+	v2Resp, v2Body := ts.getConsentVersions(created.ID, "2")
+	defer v2Resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, v2Resp.StatusCode, string(v2Body))
+
+	var v2 ConsentVersionResponse
+	ts.Require().NoError(json.Unmarshal(v2Body, &v2))
+	ts.True(v2.Current)
+	ts.Nil(v2.AmendedTime)
+	ts.Len(v2.Consent.ConsentPurpose, 2)
+}
+
+// TestGetConsentVersion_UnknownVersion_ReturnsNotFound returns 404 for a version that never existed
+func (ts *ConsentAPITestSuite) TestGetConsentVersion_UnknownVersion_ReturnsNotFound() {
+	createPayload := ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "auth", Status: "APPROVED"},
+		},
+	}
+
+	createResp, createBody := ts.createConsent(createPayload)
+	defer createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode)
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+
+	resp, body := ts.getConsentVersions(created.ID, "5")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestAmendConsent_RevokedConsent_ReturnsConflict rejects amendments to revoked consents
+func (ts *ConsentAPITestSuite) TestAmendConsent_RevokedConsent_ReturnsConflict() {
+	createPayload := ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "auth", Status: "APPROVED"},
+		},
+	}
+
+	createResp, createBody := ts.createConsent(createPayload)
+	defer createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode)
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+
+	revokeResp, _ := ts.revokeConsent(created.ID, "amendment test")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode)
+
+	amendment := ConsentAmendmentRequest{
+		ConsentUpdateRequest: ConsentUpdateRequest{Type: "payments"},
+		Reason:               "late amendment",
+	}
+	resp, body := ts.amendConsent(created.ID, amendment)
+	defer resp.Body.Close()
+	ts.Equal(http.StatusConflict, resp.StatusCode, string(body))
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// /admin/attribute-schema Tests
+// ============================
+
+// sendAttributeSchemaRequest calls the admin attribute schema API for the test organization
+func (ts *ConsentAPITestSuite) sendAttributeSchemaRequest(method string, payload interface{}) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/admin/attribute-schema", testServerURL)
+	httpReq, _ := http.NewRequest(method, url, reqBody)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// putAttributeSchema defines the attribute schema of the test organization. The caller must
+// delete it again, since it applies to every consent the other tests create.
+func (ts *ConsentAPITestSuite) putAttributeSchema(attributes []map[string]interface{}) {
+	resp, body := ts.sendAttributeSchemaRequest("PUT", map[string]interface{}{"attributes": attributes})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+}
+
+// deleteAttributeSchema removes the attribute schema of the test organization
+func (ts *ConsentAPITestSuite) deleteAttributeSchema() {
+	resp, _ := ts.sendAttributeSchemaRequest("DELETE", nil)
+	resp.Body.Close()
+}
+
+// createConsentWithAttributes creates an active consent of the given type with the given attributes
+func (ts *ConsentAPITestSuite) createConsentWithAttributes(consentType string, attributes map[string]string) (*http.Response, []byte) {
+	return ts.createConsent(ConsentCreateRequest{
+		Type:       consentType,
+		Attributes: attributes,
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "accounts", Status: "APPROVED"},
+		},
+	})
+}
+
+// TestAttributeSchema_PutAndGet stores a schema and reads it back
+func (ts *ConsentAPITestSuite) TestAttributeSchema_PutAndGet() {
+	ts.putAttributeSchema([]map[string]interface{}{
+		{"key": "channel", "pattern": "^(web|mobile)$", "requiredConsentTypes": []string{"accounts"}},
+		{"key": "riskScore", "type": "integer"},
+	})
+	defer ts.deleteAttributeSchema()
+
+	resp, body := ts.sendAttributeSchemaRequest("GET", nil)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var schema AttributeSchemaResponse
+	ts.Require().NoError(json.Unmarshal(body, &schema))
+	ts.Equal(testOrgID, schema.OrgID)
+	ts.Require().Len(schema.Attributes, 2)
+	ts.Equal("channel", schema.Attributes[0].Key)
+	ts.Equal([]string{"accounts"}, schema.Attributes[0].RequiredConsentTypes)
+	ts.Equal("integer", schema.Attributes[1].Type)
+}
+
+// TestAttributeSchema_InvalidDefinition_Rejected checks that malformed schemas are not stored
+func (ts *ConsentAPITestSuite) TestAttributeSchema_InvalidDefinition_Rejected() {
+	testCases := []struct {
+		name       string
+		attributes []map[string]interface{}
+		errorText  string
+	}{
+		{"unsupported type", []map[string]interface{}{{"key": "channel", "type": "date"}}, "unsupported type"},
+		{"invalid pattern", []map[string]interface{}{{"key": "channel", "pattern": "("}}, "invalid pattern"},
+		{"duplicate key", []map[string]interface{}{{"key": "channel"}, {"key": "channel"}}, "more than once"},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.sendAttributeSchemaRequest("PUT", map[string]interface{}{"attributes": tc.attributes})
+			defer resp.Body.Close()
+			ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+			ts.True(strings.Contains(string(body), tc.errorText), string(body))
+		})
+	}
+
+	resp, body := ts.sendAttributeSchemaRequest("GET", nil)
+	defer resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestAttributeSchema_EnforcedOnCreate checks key whitelisting, type and pattern constraints and
+// keys required by consent type
+func (ts *ConsentAPITestSuite) TestAttributeSchema_EnforcedOnCreate() {
+	ts.putAttributeSchema([]map[string]interface{}{
+		{"key": "channel", "pattern": "^(web|mobile)$", "requiredConsentTypes": []string{"accounts"}},
+		{"key": "riskScore", "type": "integer"},
+	})
+	defer ts.deleteAttributeSchema()
+
+	testCases := []struct {
+		name        string
+		consentType string
+		attributes  map[string]string
+		errorText   string
+	}{
+		{"unknown key", "accounts", map[string]string{"channel": "web", "legacyRef": "x"}, "attribute 'legacyRef' is not defined"},
+		{"pattern mismatch", "accounts", map[string]string{"channel": "fax"}, "does not match pattern"},
+		{"type mismatch", "accounts", map[string]string{"channel": "web", "riskScore": "high"}, "must be of type integer"},
+		{"missing required key", "accounts", map[string]string{"riskScore": "3"}, "attribute 'channel' is required for consent type 'accounts'"},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.createConsentWithAttributes(tc.consentType, tc.attributes)
+			defer resp.Body.Close()
+			ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+			ts.True(strings.Contains(string(body), tc.errorText), string(body))
+		})
+	}
+
+	// channel is only required for accounts consents
+	for _, consentType := range []string{"accounts", "payments"} {
+		attributes := map[string]string{"riskScore": "3"}
+		if consentType == "accounts" {
+			attributes["channel"] = "mobile"
+		}
+		resp, body := ts.createConsentWithAttributes(consentType, attributes)
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+		var created ConsentResponse
+		ts.Require().NoError(json.Unmarshal(body, &created))
+		ts.trackConsent(created.ID)
+	}
+}
+
+// TestAttributeSchema_EnforcedOnUpdate checks replaced attributes and consent type changes against
+// the schema, and that deleting the schema lifts the restrictions
+func (ts *ConsentAPITestSuite) TestAttributeSchema_EnforcedOnUpdate() {
+	resp, body := ts.createConsentWithAttributes("payments", map[string]string{"legacyRef": "x"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+
+	ts.putAttributeSchema([]map[string]interface{}{
+		{"key": "channel", "requiredConsentTypes": []string{"accounts"}},
+	})
+	defer ts.deleteAttributeSchema()
+
+	updateResp, updateBody := ts.updateConsent(created.ID, map[string]interface{}{
+		"attributes": map[string]string{"legacyRef": "y"},
+	})
+	updateResp.Body.Close()
+	ts.Equal(http.StatusBadRequest, updateResp.StatusCode, string(updateBody))
+	ts.Contains(string(updateBody), "attribute 'legacyRef' is not defined")
+
+	updateResp, updateBody = ts.updateConsent(created.ID, map[string]interface{}{
+		"attributes": map[string]string{},
+	})
+	updateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, updateResp.StatusCode, string(updateBody))
+
+	// Switching to accounts requires the channel attribute the consent does not have
+	updateResp, updateBody = ts.updateConsent(created.ID, map[string]interface{}{"type": "accounts"})
+	updateResp.Body.Close()
+	ts.Equal(http.StatusBadRequest, updateResp.StatusCode, string(updateBody))
+	ts.Contains(string(updateBody), "attribute 'channel' is required for consent type 'accounts'")
+
+	ts.deleteAttributeSchema()
+	updateResp, updateBody = ts.updateConsent(created.ID, map[string]interface{}{
+		"attributes": map[string]string{"legacyRef": "z"},
+	})
+	updateResp.Body.Close()
+	ts.Equal(http.StatusOK, updateResp.StatusCode, string(updateBody))
+
+	getResp, getBody := ts.sendAttributeSchemaRequest("GET", nil)
+	getResp.Body.Close()
+	ts.Equal(http.StatusNotFound, getResp.StatusCode, string(getBody))
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// GET /consents/attributes - Attribute Search Tests
+// ============================
+
+// searchConsentsByAttributes calls GET /consents/attributes with the given query
+func (ts *ConsentAPITestSuite) searchConsentsByAttributes(query url.Values) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/consents/attributes?%s", testServerURL, query.Encode()), nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// attributeSearchConsentRequest returns the request for a consent with the given attributes
+func attributeSearchConsentRequest(attributes map[string]string) ConsentCreateRequest {
+	return ConsentCreateRequest{
+		Type:           "accounts",
+		Attributes:     attributes,
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "accounts", Status: "APPROVED"}},
+	}
+}
+
+// TestSearchByAttribute_MultipleAttributes_MatchesAll verifies that several attributes are combined
+// with AND semantics, by value and by key only
+func (ts *ConsentAPITestSuite) TestSearchByAttribute_MultipleAttributes_MatchesAll() {
+	both := ts.createConsentOrFail(attributeSearchConsentRequest(map[string]string{"attrSearchBank": "bank-a", "attrSearchChannel": "mobile"}))
+	bankOnly := ts.createConsentOrFail(attributeSearchConsentRequest(map[string]string{"attrSearchBank": "bank-a"}))
+	otherChannel := ts.createConsentOrFail(attributeSearchConsentRequest(map[string]string{"attrSearchBank": "bank-a", "attrSearchChannel": "web"}))
+
+	resp, body := ts.searchConsentsByAttributes(url.Values{
+		"key":       {"attrSearchBank"},
+		"value":     {"bank-a"},
+		"attribute": {"attrSearchChannel:mobile"},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result ConsentAttributeSearchResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Equal([]string{both}, result.ConsentIDs)
+
+	// A key without a value matches any value
+	resp, body = ts.searchConsentsByAttributes(url.Values{
+		"attribute": {"attrSearchBank:bank-a", "attrSearchChannel"},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.ElementsMatch([]string{both, otherChannel}, result.ConsentIDs)
+	ts.NotContains(result.ConsentIDs, bankOnly)
+}
+
+// TestSearchByAttribute_Expand_ReturnsPaginatedConsents verifies that expand=true returns full
+// consents with pagination metadata
+func (ts *ConsentAPITestSuite) TestSearchByAttribute_Expand_ReturnsPaginatedConsents() {
+	for i := 0; i < 3; i++ {
+		ts.createConsentOrFail(attributeSearchConsentRequest(map[string]string{"attrSearchExpand": "yes", "attrSearchIndex": fmt.Sprint(i)}))
+	}
+
+	query := url.Values{"attribute": {"attrSearchExpand:yes"}, "expand": {"true"}, "limit": {"2"}}
+	resp, body := ts.searchConsentsByAttributes(query)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var firstPage ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &firstPage))
+	ts.Require().Len(firstPage.Data, 2)
+	ts.Equal(3, firstPage.Meta.Total)
+	ts.True(firstPage.Meta.HasMore)
+	for _, consent := range firstPage.Data {
+		ts.Equal("yes", consent.Attributes["attrSearchExpand"])
+		ts.Len(consent.Authorizations, 1)
+	}
+
+	query.Set("offset", "2")
+	resp, body = ts.searchConsentsByAttributes(query)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var secondPage ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &secondPage))
+	ts.Require().Len(secondPage.Data, 1)
+	ts.False(secondPage.Meta.HasMore)
+	ts.NotEqual(firstPage.Data[0].ID, secondPage.Data[0].ID)
+	ts.NotEqual(firstPage.Data[1].ID, secondPage.Data[0].ID)
+}
+
+// TestSearchByAttribute_InvalidParameters_ReturnsBadRequest verifies malformed attribute searches
+func (ts *ConsentAPITestSuite) TestSearchByAttribute_InvalidParameters_ReturnsBadRequest() {
+	queries := []url.Values{
+		{},
+		{"value": {"orphan"}},
+		{"attribute": {":no-key"}},
+		{"key": {"attrSearchBank"}, "expand": {"maybe"}},
+	}
+	for _, query := range queries {
+		resp, body := ts.searchConsentsByAttributes(query)
+		resp.Body.Close()
+		ts.Equal(http.StatusBadRequest, resp.StatusCode, "query %q: %s", query.Encode(), string(body))
+	}
+}
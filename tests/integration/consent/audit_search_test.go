@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// GET /audit Tests
+// ============================
+
+// searchStatusAudit calls the status audit search API with the given query parameters
+func (ts *ConsentAPITestSuite) searchStatusAudit(params url.Values, withAdminAuth bool) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/audit?%s", testServerURL, params.Encode()), nil)
+	if withAdminAuth {
+		httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestSearchStatusAudit_ByActorAndTransition finds the consents an actor revoked
+func (ts *ConsentAPITestSuite) TestSearchStatusAudit_ByActorAndTransition() {
+	actor := fmt.Sprintf("audit-agent-%d", time.Now().UnixNano())
+	startTime := time.Now().Add(-time.Minute).UnixMilli()
+
+	revokedID := ts.createConsentOrFail(userConsentRequest("audit-user-1"))
+	expiredID := ts.createConsentOrFail(userConsentRequest("audit-user-2"))
+	for consentID, status := range map[string]string{revokedID: "REVOKED", expiredID: "EXPIRED"} {
+		resp, body := ts.overrideStatus(consentID, map[string]string{
+			"status":   status,
+			"reason":   "audit search test",
+			"actionBy": actor,
+		}, true)
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	}
+
+	resp, body := ts.searchStatusAudit(url.Values{
+		"orgId":      {testOrgID},
+		"actionBy":   {actor},
+		"fromStatus": {"ACTIVE"},
+		"toStatus":   {"REVOKED"},
+		"fromTime":   {fmt.Sprint(startTime)},
+	}, true)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result StatusAuditSearchResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Require().Len(result.Data, 1)
+	ts.Equal(1, result.Metadata.Total)
+	ts.Equal(1, result.Metadata.Count)
+	ts.False(result.Metadata.HasMore)
+
+	entry := result.Data[0]
+	ts.Equal(revokedID, entry.ConsentID)
+	ts.Equal("ACTIVE", entry.PreviousStatus)
+	ts.Equal("REVOKED", entry.CurrentStatus)
+	ts.Equal(actor, entry.ActionBy)
+	ts.Equal("audit search test", entry.Reason)
+	ts.Equal(testOrgID, entry.OrgID)
+	ts.GreaterOrEqual(entry.ActionTime, startTime)
+
+	// Without a transition filter both changes are returned, newest first, one per page
+	resp2, body2 := ts.searchStatusAudit(url.Values{"actionBy": {actor}, "limit": {"1"}}, true)
+	defer resp2.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp2.StatusCode, string(body2))
+
+	var page StatusAuditSearchResponse
+	ts.Require().NoError(json.Unmarshal(body2, &page))
+	ts.Require().Len(page.Data, 1)
+	ts.Equal(2, page.Metadata.Total)
+	ts.Equal(1, page.Metadata.Limit)
+	ts.True(page.Metadata.HasMore)
+}
+
+// TestSearchStatusAudit_ByConsentAndTimeRange returns the history of a consent within a time range
+func (ts *ConsentAPITestSuite) TestSearchStatusAudit_ByConsentAndTimeRange() {
+	consentID := ts.createConsentOrFail(userConsentRequest("audit-user-3"))
+
+	revokeResp, revokeBody := ts.revokeConsent(consentID, "no longer needed")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	resp, body := ts.searchStatusAudit(url.Values{"orgId": {testOrgID}, "consentId": {consentID}}, true)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result StatusAuditSearchResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Require().NotEmpty(result.Data)
+	ts.Equal("REVOKED", result.Data[0].CurrentStatus, "newest change comes first")
+	for _, entry := range result.Data {
+		ts.Equal(consentID, entry.ConsentID)
+	}
+
+	future := time.Now().Add(time.Hour).UnixMilli()
+	resp2, body2 := ts.searchStatusAudit(url.Values{"consentId": {consentID}, "fromTime": {fmt.Sprint(future)}}, true)
+	defer resp2.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp2.StatusCode, string(body2))
+
+	var empty StatusAuditSearchResponse
+	ts.Require().NoError(json.Unmarshal(body2, &empty))
+	ts.Empty(empty.Data)
+	ts.Equal(0, empty.Metadata.Total)
+}
+
+// TestSearchStatusAudit_InvalidRequests_AreRejected checks validation and authentication
+func (ts *ConsentAPITestSuite) TestSearchStatusAudit_InvalidRequests_AreRejected() {
+	testCases := []struct {
+		name       string
+		params     url.Values
+		adminAuth  bool
+		wantStatus int
+	}{
+		{"invalid fromTime", url.Values{"fromTime": {"yesterday"}}, true, http.StatusBadRequest},
+		{"fromTime after toTime", url.Values{"fromTime": {"2000"}, "toTime": {"1000"}}, true, http.StatusBadRequest},
+		{"no admin credentials", url.Values{}, false, http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		resp, body := ts.searchStatusAudit(tc.params, tc.adminAuth)
+		resp.Body.Close()
+		ts.Equal(tc.wantStatus, resp.StatusCode, "%s: %s", tc.name, string(body))
+	}
+}
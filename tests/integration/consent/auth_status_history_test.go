@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// GET /consents/{consentId}/authorizations/{authorizationId}/history Tests
+// ============================
+
+// getAuthorizationHistory retrieves the status history of an authorization of a consent
+func (ts *ConsentAPITestSuite) getAuthorizationHistory(consentID, authID string) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consents/%s/authorizations/%s/history", testServerURL, consentID, authID)
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// authorizationHistory retrieves the status history of an authorization and fails the test if it cannot be read
+func (ts *ConsentAPITestSuite) authorizationHistory(consentID, authID string) []AuthStatusAuditResponse {
+	resp, body := ts.getAuthorizationHistory(consentID, authID)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var history struct {
+		Data []AuthStatusAuditResponse `json:"data"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &history))
+	return history.Data
+}
+
+// TestAuthorizationHistory_RecordsTransitionsAndRevokeCascade verifies direct and cascaded status changes are recorded
+func (ts *ConsentAPITestSuite) TestAuthorizationHistory_RecordsTransitionsAndRevokeCascade() {
+	created := ts.createConsentWithCreatedAuthorization()
+	authID := created.Authorizations[0].ID
+
+	history := ts.authorizationHistory(created.ID, authID)
+	ts.Require().Len(history, 1)
+	ts.Equal("CREATED", history[0].CurrentStatus)
+	ts.Nil(history[0].PreviousStatus)
+	ts.Equal(authID, history[0].AuthorizationID)
+	ts.Equal(created.ID, history[0].ConsentID)
+
+	resp, body := ts.patchAuthorization(created.ID, authID, AuthorizationPatchRequest{Status: "APPROVED"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	// A change that keeps the status is not a transition
+	resp, body = ts.patchAuthorization(created.ID, authID, AuthorizationPatchRequest{Resources: []string{"acc-2"}})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = ts.revokeConsent(created.ID, "history test")
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	history = ts.authorizationHistory(created.ID, authID)
+	ts.Require().Len(history, 3)
+	ts.Equal("APPROVED", history[1].CurrentStatus)
+	ts.Require().NotNil(history[1].PreviousStatus)
+	ts.Equal("CREATED", *history[1].PreviousStatus)
+	ts.Equal("SYS_REVOKED", history[2].CurrentStatus)
+	ts.Require().NotNil(history[2].PreviousStatus)
+	ts.Equal("APPROVED", *history[2].PreviousStatus)
+	ts.LessOrEqual(history[1].ActionTime, history[2].ActionTime)
+}
+
+// TestAuthorizationHistory_UnknownAuthorization_ReturnsNotFound verifies the authorization must belong to the consent
+func (ts *ConsentAPITestSuite) TestAuthorizationHistory_UnknownAuthorization_ReturnsNotFound() {
+	created := ts.createConsentWithCreatedAuthorization()
+
+	resp, body := ts.getAuthorizationHistory(created.ID, "unknown-auth-id")
+	resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+
+	resp, body = ts.getAuthorizationHistory("unknown-consent-id", created.Authorizations[0].ID)
+	resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// PATCH /consents/{consentId}/authorizations/{authorizationId} - Partial Update Tests
+// ============================
+
+// patchAuthorization partially updates an authorization of a consent
+func (ts *ConsentAPITestSuite) patchAuthorization(consentID, authID string, payload interface{}) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	url := fmt.Sprintf("%s/api/v1/consents/%s/authorizations/%s", testServerURL, consentID, authID)
+	httpReq, _ := http.NewRequest("PATCH", url, bytes.NewBuffer(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// createConsentWithCreatedAuthorization creates a consent whose only authorization is in CREATED state
+func (ts *ConsentAPITestSuite) createConsentWithCreatedAuthorization() ConsentResponse {
+	payload := ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "patch-test-user", Type: "authorisation", Status: "CREATED", Resources: []string{"acc-1"}},
+		},
+	}
+
+	resp, body := ts.createConsent(payload)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	ts.Require().Len(created.Authorizations, 1)
+	return created
+}
+
+// TestPatchAuthorization_StatusAndResources_AppendsAndRederivesConsentStatus verifies a partial update
+func (ts *ConsentAPITestSuite) TestPatchAuthorization_StatusAndResources_AppendsAndRederivesConsentStatus() {
+	created := ts.createConsentWithCreatedAuthorization()
+	ts.Equal("CREATED", created.Status)
+	authID := created.Authorizations[0].ID
+
+	resp, body := ts.patchAuthorization(created.ID, authID, AuthorizationPatchRequest{
+		Status:    "APPROVED",
+		Resources: []string{"acc-2"},
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var auth AuthorizationResponse
+	ts.Require().NoError(json.Unmarshal(body, &auth))
+	ts.Equal("APPROVED", auth.Status)
+	ts.Equal([]interface{}{"acc-1", "acc-2"}, auth.Resources)
+	ts.Require().NotNil(auth.UserID)
+	ts.Equal("patch-test-user", *auth.UserID)
+
+	getResp, getBody := ts.getConsent(created.ID)
+	getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &consent))
+	ts.Equal("ACTIVE", consent.Status)
+}
+
+// TestPatchAuthorization_ResourcesOnly_KeepsStatus verifies omitted fields are left unchanged
+func (ts *ConsentAPITestSuite) TestPatchAuthorization_ResourcesOnly_KeepsStatus() {
+	created := ts.createConsentWithCreatedAuthorization()
+	authID := created.Authorizations[0].ID
+
+	resp, body := ts.patchAuthorization(created.ID, authID, AuthorizationPatchRequest{
+		Resources: []string{"acc-3"},
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var auth AuthorizationResponse
+	ts.Require().NoError(json.Unmarshal(body, &auth))
+	ts.Equal("CREATED", auth.Status)
+	ts.Equal([]interface{}{"acc-1", "acc-3"}, auth.Resources)
+}
+
+// TestPatchAuthorization_EmptyBody_ReturnsBadRequest verifies at least one field is required
+func (ts *ConsentAPITestSuite) TestPatchAuthorization_EmptyBody_ReturnsBadRequest() {
+	created := ts.createConsentWithCreatedAuthorization()
+
+	resp, body := ts.patchAuthorization(created.ID, created.Authorizations[0].ID, AuthorizationPatchRequest{})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+// TestPatchAuthorization_MismatchedResourceShape_ReturnsBadRequest verifies resources must match the stored shape
+func (ts *ConsentAPITestSuite) TestPatchAuthorization_MismatchedResourceShape_ReturnsBadRequest() {
+	created := ts.createConsentWithCreatedAuthorization()
+
+	resp, body := ts.patchAuthorization(created.ID, created.Authorizations[0].ID, AuthorizationPatchRequest{
+		Resources: map[string]interface{}{"accountIds": []string{"acc-4"}},
+	})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+// TestPatchAuthorization_OtherConsent_ReturnsNotFound verifies the authorization must belong to the consent
+func (ts *ConsentAPITestSuite) TestPatchAuthorization_OtherConsent_ReturnsNotFound() {
+	first := ts.createConsentWithCreatedAuthorization()
+	second := ts.createConsentWithCreatedAuthorization()
+
+	resp, body := ts.patchAuthorization(second.ID, first.Authorizations[0].ID, AuthorizationPatchRequest{
+		Status: "APPROVED",
+	})
+	resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
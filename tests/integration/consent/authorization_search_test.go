@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// GET /authorizations - Authorization Search Tests
+// ============================
+
+// searchAuthorizations calls the admin GET /authorizations, with admin credentials when admin is set
+func (ts *ConsentAPITestSuite) searchAuthorizations(query url.Values, admin bool) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/authorizations?%s", testServerURL, query.Encode()), nil)
+	if admin {
+		httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestSearchAuthorizations_FiltersAcrossConsents verifies the user, status and type filters
+func (ts *ConsentAPITestSuite) TestSearchAuthorizations_FiltersAcrossConsents() {
+	userID := fmt.Sprintf("auth-search-user-%d", time.Now().UnixNano())
+	var consentIDs []string
+	for _, auth := range []AuthorizationRequest{
+		{UserID: userID, Type: "authorisation", Status: "APPROVED", Resources: []string{"acc-1"}},
+		{UserID: userID, Type: "re-authorisation", Status: "CREATED"},
+	} {
+		resp, body := ts.createConsent(ConsentCreateRequest{Type: "accounts", Authorizations: []AuthorizationRequest{auth}})
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+		var created ConsentResponse
+		ts.Require().NoError(json.Unmarshal(body, &created))
+		ts.trackConsent(created.ID)
+		consentIDs = append(consentIDs, created.ID)
+	}
+
+	testCases := []struct {
+		name       string
+		query      url.Values
+		consentIDs []string
+	}{
+		{"user", url.Values{"userId": {userID}}, consentIDs},
+		{"user and status", url.Values{"userId": {userID}, "status": {"APPROVED"}}, consentIDs[:1]},
+		{"user and statuses", url.Values{"userId": {userID}, "status": {"APPROVED,CREATED"}}, consentIDs},
+		{"user and type", url.Values{"userId": {userID}, "type": {"re-authorisation"}}, consentIDs[1:]},
+		{"user and organization", url.Values{"userId": {userID}, "orgId": {testOrgID}}, consentIDs},
+		{"other organization", url.Values{"userId": {userID}, "orgId": {"auth-search-other-org"}}, nil},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.searchAuthorizations(tc.query, true)
+			resp.Body.Close()
+			ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+			var result AuthorizationSearchResponse
+			ts.Require().NoError(json.Unmarshal(body, &result))
+			ts.Equal(len(tc.consentIDs), result.Metadata.Total, string(body))
+
+			found := []string{}
+			for _, item := range result.Data {
+				ts.Equal(userID, item.UserID)
+				ts.Equal(testOrgID, item.OrgID)
+				found = append(found, item.ConsentID)
+			}
+			ts.ElementsMatch(tc.consentIDs, found)
+		})
+	}
+}
+
+// TestSearchAuthorizations_Pagination verifies pages are reported with the total number of matches
+func (ts *ConsentAPITestSuite) TestSearchAuthorizations_Pagination() {
+	userID := fmt.Sprintf("auth-search-page-user-%d", time.Now().UnixNano())
+	for i := 0; i < 3; i++ {
+		resp, body := ts.createConsent(ConsentCreateRequest{
+			Type:           "accounts",
+			Authorizations: []AuthorizationRequest{{UserID: userID, Type: "authorisation", Status: "APPROVED"}},
+		})
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+		var created ConsentResponse
+		ts.Require().NoError(json.Unmarshal(body, &created))
+		ts.trackConsent(created.ID)
+	}
+
+	resp, body := ts.searchAuthorizations(url.Values{"userId": {userID}, "limit": {"2"}, "offset": {"1"}}, true)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result AuthorizationSearchResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Equal(3, result.Metadata.Total)
+	ts.Equal(2, result.Metadata.Count)
+	ts.False(result.Metadata.HasMore)
+}
+
+// TestSearchAuthorizations_RequiresAdminCredentials verifies the search is an admin endpoint
+func (ts *ConsentAPITestSuite) TestSearchAuthorizations_RequiresAdminCredentials() {
+	resp, body := ts.searchAuthorizations(url.Values{}, false)
+	resp.Body.Close()
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode, string(body))
+}
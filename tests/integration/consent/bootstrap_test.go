@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// applyBootstrapBundle posts a YAML bundle to the admin bootstrap API
+func (ts *ConsentAPITestSuite) applyBootstrapBundle(query, bundle string) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("POST", testServerURL+"/api/v1/admin/bootstrap"+query, strings.NewReader(bundle))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/yaml")
+	httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// listOrgPurposes returns the purposes of an organization by name
+func (ts *ConsentAPITestSuite) listOrgPurposes(orgID string) map[string]map[string]interface{} {
+	httpReq, _ := http.NewRequest("GET", testServerURL+"/api/v1/consent-purposes?limit=100", nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, orgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	purposes := make(map[string]map[string]interface{}, len(list.Data))
+	for _, purpose := range list.Data {
+		purposes[purpose["name"].(string)] = purpose
+	}
+	return purposes
+}
+
+// deleteOrgPurposes removes the purposes of an organization created by a test
+func (ts *ConsentAPITestSuite) deleteOrgPurposes(orgID string) {
+	for _, purpose := range ts.listOrgPurposes(orgID) {
+		httpReq, _ := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v1/consent-purposes/%s", testServerURL, purpose["id"]), nil)
+		httpReq.Header.Set(testutils.HeaderOrgID, orgID)
+		httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+		if resp, err := testutils.GetHTTPClient().Do(httpReq); err == nil {
+			resp.Body.Close()
+		}
+	}
+}
+
+// TestBootstrap_ReconcilesBundle checks that a bundle creates its organization and purposes, that
+// applying it again changes nothing, and that a changed bundle updates only what differs
+func (ts *ConsentAPITestSuite) TestBootstrap_ReconcilesBundle() {
+	orgID := fmt.Sprintf("org-bootstrap-%d", time.Now().UnixNano())
+	defer ts.deleteOrganization(orgID)
+	defer ts.deleteOrgPurposes(orgID)
+
+	bundle := fmt.Sprintf(`
+organizations:
+  - orgId: %s
+    name: Bootstrap Bank
+    retentionDays: 30
+    purposes:
+      - name: marketing
+        description: Marketing emails
+        type: string
+      - name: analytics
+        type: string
+`, orgID)
+
+	resp, body := ts.applyBootstrapBundle("", bundle)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var result BootstrapResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Equal(3, result.Summary.Created, string(body))
+	ts.Equal(0, result.Summary.Failed, string(body))
+
+	resp, body = ts.sendOrganizationRequest("GET", orgID, nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Contains(string(body), "Bootstrap Bank")
+	ts.Len(ts.listOrgPurposes(orgID), 2)
+
+	resp, body = ts.applyBootstrapBundle("", bundle)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	result = BootstrapResponse{}
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Equal(3, result.Summary.Unchanged, string(body))
+	ts.Equal(0, result.Summary.Created+result.Summary.Updated+result.Summary.Deleted, string(body))
+
+	changed := strings.Replace(bundle, "Marketing emails", "Marketing emails and offers", 1)
+	resp, body = ts.applyBootstrapBundle("?dryRun=true", changed)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	result = BootstrapResponse{}
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.True(result.DryRun)
+	ts.Equal(1, result.Summary.Updated, string(body))
+	ts.Equal("Marketing emails", ts.listOrgPurposes(orgID)["marketing"]["description"])
+
+	resp, body = ts.applyBootstrapBundle("", changed)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Equal("Marketing emails and offers", ts.listOrgPurposes(orgID)["marketing"]["description"])
+}
+
+// TestBootstrap_DryRunPrune_ReportsUndeclaredPurposes checks that a pruned dry run lists the
+// purposes the bundle leaves out without deleting them
+func (ts *ConsentAPITestSuite) TestBootstrap_DryRunPrune_ReportsUndeclaredPurposes() {
+	orgID := fmt.Sprintf("org-bootstrap-%d", time.Now().UnixNano())
+	defer ts.deleteOrgPurposes(orgID)
+
+	resp, body := ts.applyBootstrapBundle("", fmt.Sprintf(`
+organizations:
+  - orgId: %s
+    purposes:
+      - {name: kept, type: string}
+      - {name: dropped, type: string}
+`, orgID))
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = ts.applyBootstrapBundle("?dryRun=true&prune=true", fmt.Sprintf(`
+organizations:
+  - orgId: %s
+    purposes:
+      - {name: kept, type: string}
+`, orgID))
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var result BootstrapResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Contains(result.Changes, BootstrapChange{Kind: "purpose", OrgID: orgID, Name: "dropped", Action: "deleted"})
+	ts.Len(ts.listOrgPurposes(orgID), 2)
+}
+
+// TestBootstrap_InvalidBundle_Rejected checks that malformed bundles and options are rejected
+// before anything is applied
+func (ts *ConsentAPITestSuite) TestBootstrap_InvalidBundle_Rejected() {
+	for _, tc := range []struct {
+		name, query, bundle string
+	}{
+		{"unknown field", "", "organizations:\n  - orgId: org-x\n    nmae: typo\n"},
+		{"duplicate organization", "", "organizations:\n  - orgId: org-x\n  - orgId: org-x\n"},
+		{"invalid prune", "?prune=maybe", "organizations: []\n"},
+	} {
+		resp, body := ts.applyBootstrapBundle(tc.query, tc.bundle)
+		ts.Equal(http.StatusBadRequest, resp.StatusCode, "%s: %s", tc.name, body)
+	}
+
+	httpReq, _ := http.NewRequest("POST", testServerURL+"/api/v1/admin/bootstrap", strings.NewReader("organizations: []\n"))
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	resp.Body.Close()
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ============================
+// GET /consents - Composite Search Tests
+// ============================
+
+// compositeSearchConsentRequest returns the request for a consent with the given purpose,
+// attributes and authorizations
+func compositeSearchConsentRequest(purpose string, attributes map[string]string, auths []AuthorizationRequest) ConsentCreateRequest {
+	return ConsentCreateRequest{
+		Type:           "accounts",
+		ConsentPurpose: []ConsentPurposeItem{{Name: purpose, Value: "yes", IsUserApproved: true}},
+		Attributes:     attributes,
+		Authorizations: auths,
+	}
+}
+
+// TestListConsents_CompositeFilters_MatchAllConditions combines purpose, attribute and
+// authorization filters in one search
+func (ts *ConsentAPITestSuite) TestListConsents_CompositeFilters_MatchAllConditions() {
+	attributes := map[string]string{"compositeSearch": "dashboard"}
+	approved := []AuthorizationRequest{{UserID: "composite-user", Type: "authorisation", Status: "APPROVED"}}
+
+	match := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", attributes, approved))
+	ts.createConsentOrFail(compositeSearchConsentRequest("analytics-purpose", attributes, approved))
+	ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", map[string]string{"compositeSearch": "other"}, approved))
+	// The user's authorization is not approved; another user's is
+	ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", attributes, []AuthorizationRequest{
+		{UserID: "composite-user", Type: "authorisation", Status: "CREATED"},
+		{UserID: "composite-other-user", Type: "authorisation", Status: "APPROVED"},
+	}))
+
+	resp, body := ts.listConsents(map[string]string{
+		"consentTypes": "accounts",
+		"purposeNames": "marketing-purpose",
+		"attribute":    "compositeSearch:dashboard",
+		"userIds":      "composite-user",
+		"authTypes":    "authorisation",
+		"authStatuses": "APPROVED",
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Require().Len(list.Data, 1)
+	ts.Equal(match, list.Data[0].ID)
+	ts.Equal(1, list.Meta.Total)
+}
+
+// TestListConsents_PurposeNames_MatchesAnyPurpose verifies that purposeNames matches consents linked
+// to any of the listed purposes
+func (ts *ConsentAPITestSuite) TestListConsents_PurposeNames_MatchesAnyPurpose() {
+	attributes := map[string]string{"compositeSearch": "purposes"}
+	auths := []AuthorizationRequest{{UserID: "composite-purpose-user", Type: "authorisation", Status: "APPROVED"}}
+
+	marketing := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", attributes, auths))
+	analytics := ts.createConsentOrFail(compositeSearchConsentRequest("analytics-purpose", attributes, auths))
+	ts.createConsentOrFail(compositeSearchConsentRequest("terms-purpose", attributes, auths))
+
+	resp, body := ts.listConsents(map[string]string{
+		"purposeNames": "marketing-purpose,analytics-purpose",
+		"attribute":    "compositeSearch:purposes",
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ids := make([]string, 0, len(list.Data))
+	for _, consent := range list.Data {
+		ids = append(ids, consent.ID)
+	}
+	ts.ElementsMatch([]string{marketing, analytics}, ids)
+}
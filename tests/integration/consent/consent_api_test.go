@@ -102,6 +102,19 @@ func (ts *ConsentAPITestSuite) createConsent(payload interface{}) (*http.Respons
 	return resp, body
 }
 
+// createConsentOrFail creates a consent, failing the test unless it is created, tracks it for
+// cleanup and returns its ID
+func (ts *ConsentAPITestSuite) createConsentOrFail(payload ConsentCreateRequest) string {
+	resp, body := ts.createConsent(payload)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	return created.ID
+}
+
 // getConsent retrieves a consent by ID and returns response and body
 func (ts *ConsentAPITestSuite) getConsent(consentID string) (*http.Response, []byte) {
 	url := fmt.Sprintf("%s/api/v1/consents/%s", testServerURL, consentID)
@@ -120,6 +133,17 @@ func (ts *ConsentAPITestSuite) getConsent(consentID string) (*http.Response, []b
 	return resp, body
 }
 
+// getConsentOrFail retrieves a consent by ID, failing the test unless it is found
+func (ts *ConsentAPITestSuite) getConsentOrFail(consentID string) ConsentResponse {
+	resp, body := ts.getConsent(consentID)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &consent))
+	return consent
+}
+
 // getConsentWithHeaders retrieves a consent with custom headers (for testing header validation)
 func (ts *ConsentAPITestSuite) getConsentWithHeaders(consentID, orgID, clientID string) (*http.Response, []byte) {
 	url := fmt.Sprintf("%s/api/v1/consents/%s", testServerURL, consentID)
@@ -376,9 +400,6 @@ func (ts *ConsentAPITestSuite) validateConsentWithHeaders(payload interface{}, o
 
 // deleteConsent deletes a consent by ID (for cleanup)
 func (ts *ConsentAPITestSuite) deleteConsent(consentID string) bool {
-	// Note: DELETE /consents/{id} endpoint may not exist
-	// This is a placeholder - adjust based on actual API
-
 	url := fmt.Sprintf("%s/api/v1/consents/%s", testServerURL, consentID)
 	httpReq, _ := http.NewRequest("DELETE", url, nil)
 	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
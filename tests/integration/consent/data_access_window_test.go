@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// createConsentWithDataAccessDuration creates an active consent for user1 whose data access is
+// valid for the given number of seconds after authorization
+func (ts *ConsentAPITestSuite) createConsentWithDataAccessDuration(seconds int64) string {
+	resp, body := ts.createConsent(map[string]interface{}{
+		"type":                       "accounts",
+		"dataAccessValidityDuration": seconds,
+		"authorizations": []map[string]interface{}{
+			{"userId": "user1", "type": "payment", "status": "APPROVED"},
+		},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	return created.ID
+}
+
+// TestValidateConsent_DataAccessWindowOpen_ReturnsWindow checks that a consent inside its data
+// access window is valid and reports the window
+func (ts *ConsentAPITestSuite) TestValidateConsent_DataAccessWindowOpen_ReturnsWindow() {
+	consentID := ts.createConsentWithDataAccessDuration(3600)
+
+	result := ts.validateAsUser1(consentID)
+	ts.True(result.IsValid, result.ErrorDescription)
+	ts.Require().NotNil(result.DataAccessWindow)
+	ts.Equal(int64(3600*1000), result.DataAccessWindow.End-result.DataAccessWindow.Start)
+}
+
+// TestValidateConsent_DataAccessWindowLapsed_ReturnsError checks that validation fails once the
+// data access window has passed
+func (ts *ConsentAPITestSuite) TestValidateConsent_DataAccessWindowLapsed_ReturnsError() {
+	consentID := ts.createConsentWithDataAccessDuration(1)
+	time.Sleep(1100 * time.Millisecond)
+
+	result := ts.validateAsUser1(consentID)
+	ts.False(result.IsValid)
+	ts.Equal(http.StatusUnauthorized, result.ErrorCode)
+	ts.Equal("data_access_window_lapsed", result.ErrorMessage)
+	ts.Require().NotNil(result.DataAccessWindow)
+	ts.Less(result.DataAccessWindow.End, time.Now().UnixMilli())
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package consent
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// /admin/database/pool Tests
+// ============================
+
+// getDatabasePool calls the admin database pool API
+func (ts *ConsentAPITestSuite) getDatabasePool(withAdminAuth bool) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("GET", testServerURL+"/api/v1/admin/database/pool", nil)
+	if withAdminAuth {
+		httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestDatabasePool_ReportsConfiguredPool checks the pool settings from the test configuration
+func (ts *ConsentAPITestSuite) TestDatabasePool_ReportsConfiguredPool() {
+	resp, body := ts.getDatabasePool(true)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var pool DatabasePoolResponse
+	ts.Require().NoError(json.Unmarshal(body, &pool))
+	ts.Equal(25, pool.Consent.MaxOpenConnections)
+	ts.Equal(5, pool.Consent.MaxIdleConnections)
+	ts.Equal(pool.Consent.OpenConnections, pool.Consent.InUse+pool.Consent.Idle)
+	ts.LessOrEqual(pool.Consent.OpenConnections, pool.Consent.MaxOpenConnections)
+}
+
+// TestDatabasePool_RequiresAdminAuth checks that the pool gauges are only served to administrators
+func (ts *ConsentAPITestSuite) TestDatabasePool_RequiresAdminAuth() {
+	resp, _ := ts.getDatabasePool(false)
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ============================
+// Delegated Authorization Tests
+// ============================
+
+// TestDelegatedAuthorization_ReturnedAndSearchableByEitherParty creates a consent authorized under a
+// power of attorney and finds it by the delegator and by the delegate
+func (ts *ConsentAPITestSuite) TestDelegatedAuthorization_ReturnedAndSearchableByEitherParty() {
+	suffix := time.Now().UnixNano()
+	delegator := fmt.Sprintf("delegator-%d", suffix)
+	delegate := fmt.Sprintf("delegate-%d", suffix)
+
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: delegate, Type: "authorisation", Status: "APPROVED", DelegatorID: delegator, DelegateID: delegate},
+		},
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	ts.Require().Len(created.Authorizations, 1)
+	ts.Require().NotNil(created.Authorizations[0].DelegatorID)
+	ts.Require().NotNil(created.Authorizations[0].DelegateID)
+	ts.Equal(delegator, *created.Authorizations[0].DelegatorID)
+	ts.Equal(delegate, *created.Authorizations[0].DelegateID)
+
+	validateResp, validateBody := ts.validateConsent(ConsentValidateRequest{ConsentID: created.ID})
+	validateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, validateResp.StatusCode, string(validateBody))
+
+	var validation struct {
+		IsValid            bool `json:"isValid"`
+		ConsentInformation struct {
+			Authorizations []AuthorizationResponse `json:"authorizations"`
+		} `json:"consentInformation"`
+	}
+	ts.Require().NoError(json.Unmarshal(validateBody, &validation))
+	ts.True(validation.IsValid, string(validateBody))
+	ts.Require().Len(validation.ConsentInformation.Authorizations, 1)
+	ts.Require().NotNil(validation.ConsentInformation.Authorizations[0].DelegatorID)
+	ts.Equal(delegator, *validation.ConsentInformation.Authorizations[0].DelegatorID)
+
+	ts.Equal([]string{created.ID}, ts.searchConsentIDs(map[string]string{"delegatorIds": delegator}))
+	ts.Equal([]string{created.ID}, ts.searchConsentIDs(map[string]string{"delegateIds": delegate}))
+	ts.Empty(ts.searchConsentIDs(map[string]string{"delegatorIds": delegate}))
+
+	searchResp, searchBody := ts.searchAuthorizations(url.Values{"orgId": {testOrgID}, "delegateId": {delegate}}, true)
+	searchResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, searchResp.StatusCode, string(searchBody))
+
+	var authorizations AuthorizationSearchResponse
+	ts.Require().NoError(json.Unmarshal(searchBody, &authorizations))
+	ts.Require().Len(authorizations.Data, 1)
+	ts.Equal(created.ID, authorizations.Data[0].ConsentID)
+}
+
+// TestDelegatedAuthorization_PatchSetsBothParties delegates an existing authorization
+func (ts *ConsentAPITestSuite) TestDelegatedAuthorization_PatchSetsBothParties() {
+	consent := ts.getConsentOrFail(ts.createConsentOrFail(userConsentRequest("delegation-attorney-1")))
+	authID := consent.Authorizations[0].ID
+
+	resp, body := ts.patchAuthorization(consent.ID, authID, map[string]string{"delegatorId": "delegation-holder-1"})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = ts.patchAuthorization(consent.ID, authID, map[string]string{
+		"delegatorId": "delegation-holder-1",
+		"delegateId":  "delegation-attorney-1",
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var patched AuthorizationResponse
+	ts.Require().NoError(json.Unmarshal(body, &patched))
+	ts.Require().NotNil(patched.DelegatorID)
+	ts.Require().NotNil(patched.DelegateID)
+	ts.Equal("delegation-holder-1", *patched.DelegatorID)
+	ts.Equal("delegation-attorney-1", *patched.DelegateID)
+}
+
+// TestDelegatedAuthorization_IncompleteParties_AreRejected checks that both parties are captured
+func (ts *ConsentAPITestSuite) TestDelegatedAuthorization_IncompleteParties_AreRejected() {
+	testCases := []struct {
+		name          string
+		authorization AuthorizationRequest
+	}{
+		{"missing delegate", AuthorizationRequest{UserID: "u", Type: "authorisation", DelegatorID: "holder"}},
+		{"missing delegator", AuthorizationRequest{UserID: "u", Type: "authorisation", DelegateID: "attorney"}},
+		{"same person", AuthorizationRequest{UserID: "u", Type: "authorisation", DelegatorID: "holder", DelegateID: "holder"}},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.createConsent(ConsentCreateRequest{
+				Type:           "accounts",
+				Authorizations: []AuthorizationRequest{tc.authorization},
+			})
+			resp.Body.Close()
+			ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+		})
+	}
+}
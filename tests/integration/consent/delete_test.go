@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// DELETE /consents/{consentId} - Soft Delete Tests
+// ============================
+
+// sendDeleteConsent deletes a consent with the given headers and returns the raw response
+func (ts *ConsentAPITestSuite) sendDeleteConsent(consentID, orgID, clientID string) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consents/%s", testServerURL, consentID)
+	httpReq, _ := http.NewRequest("DELETE", url, nil)
+	if orgID != "" {
+		httpReq.Header.Set(testutils.HeaderOrgID, orgID)
+	}
+	if clientID != "" {
+		httpReq.Header.Set(testutils.HeaderClientID, clientID)
+	}
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// typedConsentRequest returns the request for an approved consent of the given type
+func typedConsentRequest(consentType string) ConsentCreateRequest {
+	return ConsentCreateRequest{
+		Type: consentType,
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "marketing-purpose", IsUserApproved: true},
+		},
+		Authorizations: []AuthorizationRequest{{UserID: "delete-test-user", Type: "auth", Status: "APPROVED"}},
+	}
+}
+
+// TestDeleteConsent_HidesConsentFromReads verifies a deleted consent is no longer returned
+func (ts *ConsentAPITestSuite) TestDeleteConsent_HidesConsentFromReads() {
+	consentType := fmt.Sprintf("delete-test-%d", time.Now().UnixNano())
+	deletedID := ts.createConsentOrFail(typedConsentRequest(consentType))
+	keptID := ts.createConsentOrFail(typedConsentRequest(consentType))
+
+	resp, body := ts.sendDeleteConsent(deletedID, testOrgID, testClientID)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+
+	getResp, getBody := ts.getConsent(deletedID)
+	getResp.Body.Close()
+	ts.Equal(http.StatusNotFound, getResp.StatusCode, string(getBody))
+
+	listResp, listBody := ts.listConsents(map[string]string{"consentTypes": consentType})
+	listResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, listResp.StatusCode, string(listBody))
+
+	var list ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(listBody, &list))
+	ts.Require().Len(list.Data, 1)
+	ts.Equal(keptID, list.Data[0].ID)
+	ts.Equal(1, list.Meta.Total)
+}
+
+// TestDeleteConsent_AlreadyDeleted_ReturnsNotFound verifies a consent cannot be deleted twice
+func (ts *ConsentAPITestSuite) TestDeleteConsent_AlreadyDeleted_ReturnsNotFound() {
+	consentID := ts.createConsentOrFail(typedConsentRequest("accounts"))
+
+	resp, body := ts.sendDeleteConsent(consentID, testOrgID, testClientID)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+
+	resp, body = ts.sendDeleteConsent(consentID, testOrgID, testClientID)
+	resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestDeleteConsent_NonExistent_ReturnsNotFound verifies deleting an unknown consent fails
+func (ts *ConsentAPITestSuite) TestDeleteConsent_NonExistent_ReturnsNotFound() {
+	resp, body := ts.sendDeleteConsent("7f1c2a4e-0000-4000-8000-000000000000", testOrgID, testClientID)
+	resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestDeleteConsent_MissingHeaders_ReturnsBadRequest verifies org and client headers are required
+func (ts *ConsentAPITestSuite) TestDeleteConsent_MissingHeaders_ReturnsBadRequest() {
+	consentID := ts.createConsentOrFail(typedConsentRequest("accounts"))
+
+	resp, body := ts.sendDeleteConsent(consentID, "", testClientID)
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = ts.sendDeleteConsent(consentID, testOrgID, "")
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// eraseUser calls the right-to-erasure API for a user
+func (ts *ConsentAPITestSuite) eraseUser(userID string, withAdminAuth bool) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("POST", testServerURL+"/api/v1/users/"+url.PathEscape(userID)+"/erase", nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	if withAdminAuth {
+		httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// revokeConsentAs revokes a consent on behalf of actionBy
+func (ts *ConsentAPITestSuite) revokeConsentAs(consentID, actionBy string) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(ConsentRevokeRequest{Reason: "No longer needed", ActionBy: actionBy})
+	ts.Require().NoError(err)
+
+	httpReq, _ := http.NewRequest("PUT", fmt.Sprintf("%s/api/v1/consents/%s/revoke", testServerURL, consentID),
+		bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// ============================
+// POST /users/{userId}/erase - Right-to-Erasure Tests
+// ============================
+
+// TestEraseUser_PseudonymizesUserAndRemovesIdentifyingAttributes erases a user with an amended and
+// revoked consent and checks the authorizations, attributes, history and status audits
+func (ts *ConsentAPITestSuite) TestEraseUser_PseudonymizesUserAndRemovesIdentifyingAttributes() {
+	userID := fmt.Sprintf("erase-user-%d", time.Now().UnixNano())
+	payload := ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: userID, Type: "authorisation", Status: "APPROVED"},
+		},
+		Attributes: map[string]string{"email": "erase@example.com", "channel": "web"},
+	}
+	createResp, createBody := ts.createConsent(payload)
+	defer createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+
+	amendResp, amendBody := ts.amendConsent(created.ID, ConsentAmendmentRequest{
+		ConsentUpdateRequest: ConsentUpdateRequest{
+			Attributes: map[string]string{"email": "erase@example.com", "channel": "mobile"},
+		},
+		AmendedBy: userID,
+		Reason:    "channel change",
+	})
+	defer amendResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, amendResp.StatusCode, string(amendBody))
+
+	revokeResp, revokeBody := ts.revokeConsentAs(created.ID, userID)
+	defer revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	resp, body := ts.eraseUser(userID, true)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var erasure UserErasureResponse
+	ts.Require().NoError(json.Unmarshal(body, &erasure))
+	ts.NotEmpty(erasure.JWS)
+	ts.Equal(testOrgID, erasure.Report.OrgID)
+	ts.NotContains(string(body), userID)
+	ts.Require().True(strings.HasPrefix(erasure.Report.Pseudonym, "erased-"))
+	ts.Require().Len(erasure.Report.Consents, 1)
+	ts.Equal(created.ID, erasure.Report.Consents[0].ConsentID)
+	ts.Len(erasure.Report.Consents[0].AuthorizationIDs, 1)
+	ts.Equal([]string{"email"}, erasure.Report.Consents[0].RemovedAttributes)
+
+	getResp, getBody := ts.getConsent(created.ID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode)
+
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &consent))
+	ts.Require().Len(consent.Authorizations, 1)
+	ts.Require().NotNil(consent.Authorizations[0].UserID)
+	ts.Equal(erasure.Report.Pseudonym, *consent.Authorizations[0].UserID)
+	ts.NotContains(consent.Attributes, "email")
+	ts.Equal("mobile", consent.Attributes["channel"])
+
+	versionResp, versionBody := ts.getConsentVersions(created.ID, "1")
+	defer versionResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, versionResp.StatusCode, string(versionBody))
+	ts.NotContains(string(versionBody), userID)
+	ts.NotContains(string(versionBody), "erase@example.com")
+
+	var version ConsentVersionResponse
+	ts.Require().NoError(json.Unmarshal(versionBody, &version))
+	ts.Equal(erasure.Report.Pseudonym, version.AmendedBy)
+	ts.Equal("web", version.Consent.Attributes["channel"])
+
+	auditResp, auditBody := ts.searchStatusAudit(url.Values{"consentId": {created.ID}}, true)
+	defer auditResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, auditResp.StatusCode, string(auditBody))
+	ts.NotContains(string(auditBody), userID)
+	ts.Contains(string(auditBody), erasure.Report.Pseudonym)
+}
+
+// TestEraseUser_UnknownUser_ReturnsEmptyReport checks that erasing a user without consents succeeds
+func (ts *ConsentAPITestSuite) TestEraseUser_UnknownUser_ReturnsEmptyReport() {
+	resp, body := ts.eraseUser(fmt.Sprintf("erase-unknown-%d", time.Now().UnixNano()), true)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var erasure UserErasureResponse
+	ts.Require().NoError(json.Unmarshal(body, &erasure))
+	ts.Empty(erasure.Report.Consents)
+	ts.NotEmpty(erasure.Report.ErasureID)
+}
+
+// TestEraseUser_WithoutAdminAuth_ReturnsUnauthorized checks that erasure is an admin operation
+func (ts *ConsentAPITestSuite) TestEraseUser_WithoutAdminAuth_ReturnsUnauthorized() {
+	resp, body := ts.eraseUser("erase-no-auth", false)
+	defer resp.Body.Close()
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode, string(body))
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Problem Details and /errors Tests
+// ============================
+
+// getErrorCatalog calls the error code catalog without credentials
+func (ts *ConsentAPITestSuite) getErrorCatalog(path string) (*http.Response, []byte) {
+	resp, err := testutils.GetHTTPClient().Get(fmt.Sprintf("%s/api/v1/errors%s", testServerURL, path))
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestErrorResponse_IsProblemDetails verifies that errors are returned as application/problem+json
+// with the error code, trace ID and a type resolvable through the catalog
+func (ts *ConsentAPITestSuite) TestErrorResponse_IsProblemDetails() {
+	httpReq, _ := http.NewRequest("GET", testServerURL+"/api/v1/consents/7f1c2a4e-0000-4000-8000-000000000001", nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+	httpReq.Header.Set("X-Correlation-ID", "problem-details-trace")
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	ts.Require().Equal(http.StatusNotFound, resp.StatusCode, string(body))
+	ts.Equal("application/problem+json", resp.Header.Get("Content-Type"))
+
+	var problem testutils.ErrorResponse
+	ts.Require().NoError(json.Unmarshal(body, &problem))
+	ts.Equal(http.StatusNotFound, problem.Status)
+	ts.Equal("problem-details-trace", problem.TraceID)
+	ts.Equal("/api/v1/consents/7f1c2a4e-0000-4000-8000-000000000001", problem.Instance)
+	ts.NotEmpty(problem.Title)
+	ts.NotEmpty(problem.Detail)
+	ts.Equal("/api/v1/errors/"+problem.Code, problem.Type)
+
+	// The type URI documents the error code
+	typeResp, typeBody := ts.getErrorCatalog("/" + problem.Code)
+	ts.Require().Equal(http.StatusOK, typeResp.StatusCode, string(typeBody))
+
+	var definition ErrorCodeDefinition
+	ts.Require().NoError(json.Unmarshal(typeBody, &definition))
+	ts.Equal(problem.Code, definition.Code)
+	ts.Equal(http.StatusNotFound, definition.Status)
+}
+
+// TestErrorCatalog_ListsErrorCodes verifies that the catalog lists every code with its HTTP status
+func (ts *ConsentAPITestSuite) TestErrorCatalog_ListsErrorCodes() {
+	resp, body := ts.getErrorCatalog("")
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var catalog struct {
+		Data []ErrorCodeDefinition `json:"data"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &catalog))
+	ts.NotEmpty(catalog.Data)
+
+	statuses := make(map[string]int, len(catalog.Data))
+	for _, definition := range catalog.Data {
+		ts.NotEmpty(definition.Title, definition.Code)
+		ts.NotEmpty(definition.Description, definition.Code)
+		statuses[definition.Code] = definition.Status
+	}
+	ts.Equal(http.StatusBadRequest, statuses["CSE-4000"])
+	ts.Equal(http.StatusConflict, statuses["CSE-4009"])
+	ts.Equal(http.StatusInternalServerError, statuses["CSE-5001"])
+}
+
+// TestErrorCatalog_UnknownCode_ReturnsNotFound verifies that an unknown code is a problem response
+func (ts *ConsentAPITestSuite) TestErrorCatalog_UnknownCode_ReturnsNotFound() {
+	resp, body := ts.getErrorCatalog("/CSE-0000")
+	ts.Require().Equal(http.StatusNotFound, resp.StatusCode, string(body))
+	ts.Equal("application/problem+json", resp.Header.Get("Content-Type"))
+
+	var problem testutils.ErrorResponse
+	ts.Require().NoError(json.Unmarshal(body, &problem))
+	ts.Equal("CSE-4004", problem.Code)
+	ts.Contains(problem.Detail, "CSE-0000")
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Conditional GET Tests
+// ============================
+
+// getConsentConditional retrieves a consent with an If-None-Match header and returns the status, ETag and body
+func (ts *ConsentAPITestSuite) getConsentConditional(consentID, query, ifNoneMatch string) (int, string, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consents/%s", testServerURL, consentID)
+	if query != "" {
+		url += "?" + query
+	}
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+	if ifNoneMatch != "" {
+		httpReq.Header.Set("If-None-Match", ifNoneMatch)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	return resp.StatusCode, resp.Header.Get("ETag"), body
+}
+
+// TestGetConsent_IfNoneMatch_ReturnsNotModifiedUntilChanged verifies the ETag of a consent and conditional GETs
+func (ts *ConsentAPITestSuite) TestGetConsent_IfNoneMatch_ReturnsNotModifiedUntilChanged() {
+	created := ts.createConsentWithCreatedAuthorization()
+
+	status, etag, _ := ts.getConsentConditional(created.ID, "", "")
+	ts.Require().Equal(http.StatusOK, status)
+	ts.Require().NotEmpty(etag)
+	ts.Regexp(`^"[0-9a-f]+"$`, etag)
+
+	status, etagAgain, body := ts.getConsentConditional(created.ID, "", etag)
+	ts.Equal(http.StatusNotModified, status)
+	ts.Equal(etag, etagAgain)
+	ts.Empty(body)
+
+	status, _, _ = ts.getConsentConditional(created.ID, "", `"stale", W/`+etag)
+	ts.Equal(http.StatusNotModified, status)
+
+	// Another representation of the consent has its own ETag
+	status, fieldsETag, _ := ts.getConsentConditional(created.ID, "fields=status", etag)
+	ts.Equal(http.StatusOK, status)
+	ts.NotEqual(etag, fieldsETag)
+
+	// Changing an authorization changes the ETag
+	time.Sleep(5 * time.Millisecond)
+	resp, patchBody := ts.patchAuthorization(created.ID, created.Authorizations[0].ID, AuthorizationPatchRequest{
+		Resources: []string{"acc-2"},
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(patchBody))
+
+	status, newETag, body := ts.getConsentConditional(created.ID, "", etag)
+	ts.Equal(http.StatusOK, status)
+	ts.NotEqual(etag, newETag)
+	ts.Contains(string(body), "acc-2")
+}
+
+// TestGetConsent_IfNoneMatch_UnknownConsentReturnsNotFound verifies conditional GETs of missing consents
+func (ts *ConsentAPITestSuite) TestGetConsent_IfNoneMatch_UnknownConsentReturnsNotFound() {
+	status, etag, _ := ts.getConsentConditional("00000000-0000-0000-0000-000000000000", "", "*")
+	ts.Equal(http.StatusNotFound, status)
+	ts.Empty(etag)
+}
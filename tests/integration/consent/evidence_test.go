@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Consent Evidence Tests
+// ============================
+
+// SHA-256 digest of the consent text shown in the tests
+const evidenceChecksum = "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08"
+
+// listConsentEvidence calls GET /consents/{consentId}/evidence
+func (ts *ConsentAPITestSuite) listConsentEvidence(consentID string) []ConsentEvidence {
+	httpReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/consents/%s/evidence", testServerURL, consentID), nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result struct {
+		Data []ConsentEvidence `json:"data"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	return result.Data
+}
+
+// createConsentWithEvidence creates an approved consent collected with the given evidence
+func (ts *ConsentAPITestSuite) createConsentWithEvidence(evidence ConsentEvidenceRequest) ConsentResponse {
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type:           "accounts",
+		ConsentPurpose: []ConsentPurposeItem{{Name: "marketing-purpose", IsUserApproved: true}},
+		Authorizations: []AuthorizationRequest{{UserID: "evidence-user", Type: "authorisation", Status: "APPROVED"}},
+		Evidence:       &evidence,
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &consent))
+	ts.trackConsent(consent.ID)
+	return consent
+}
+
+// TestConsentEvidence_RecordedPerVersion records the evidence of a create and of an amendment
+// against the versions they produced
+func (ts *ConsentAPITestSuite) TestConsentEvidence_RecordedPerVersion() {
+	consent := ts.createConsentWithEvidence(ConsentEvidenceRequest{
+		Channel:               "mobile-app",
+		IPAddress:             "203.0.113.7",
+		UserAgent:             "BankApp/5.2 (iOS 17.4)",
+		UIVersion:             "consent-screen-v12",
+		PresentedTextChecksum: evidenceChecksum,
+	})
+
+	amendResp, amendBody := ts.amendConsent(consent.ID, ConsentUpdateRequest{
+		Attributes: map[string]string{"branch": "colombo"},
+		Evidence:   &ConsentEvidenceRequest{Channel: "web", IPAddress: "2001:db8::1"},
+	})
+	amendResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, amendResp.StatusCode, string(amendBody))
+
+	evidence := ts.listConsentEvidence(consent.ID)
+	ts.Require().Len(evidence, 2)
+
+	created, amended := evidence[0], evidence[1]
+	ts.NotEmpty(created.ID)
+	ts.Equal(consent.ID, created.ConsentID)
+	ts.Equal(1, created.ConsentVersion)
+	ts.Equal("mobile-app", created.Channel)
+	ts.Equal("203.0.113.7", created.IPAddress)
+	ts.Equal("BankApp/5.2 (iOS 17.4)", created.UserAgent)
+	ts.Equal("consent-screen-v12", created.UIVersion)
+	ts.Equal("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", created.PresentedTextChecksum,
+		"checksums are stored in lowercase")
+	ts.NotZero(created.CapturedTime)
+
+	ts.Equal(2, amended.ConsentVersion)
+	ts.Equal("web", amended.Channel)
+	ts.Equal("2001:db8::1", amended.IPAddress)
+	ts.Empty(amended.UserAgent)
+	ts.LessOrEqual(created.CapturedTime, amended.CapturedTime)
+}
+
+// TestConsentEvidence_InReceiptAndOperationAudit includes the evidence in the consent receipt and
+// in the operation audit of the create
+func (ts *ConsentAPITestSuite) TestConsentEvidence_InReceiptAndOperationAudit() {
+	consent := ts.createConsentWithEvidence(ConsentEvidenceRequest{
+		Channel:               "branch-kiosk",
+		UIVersion:             "kiosk-3.1",
+		PresentedTextChecksum: evidenceChecksum,
+	})
+
+	resp, body := ts.getConsentReceipt(consent.ID)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var receipt struct {
+		Receipt struct {
+			Evidence []ConsentEvidence `json:"evidence"`
+		} `json:"receipt"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &receipt))
+	ts.Require().Len(receipt.Receipt.Evidence, 1)
+	ts.Equal("branch-kiosk", receipt.Receipt.Evidence[0].Channel)
+	ts.Equal("kiosk-3.1", receipt.Receipt.Evidence[0].UIVersion)
+	ts.Equal(1, receipt.Receipt.Evidence[0].ConsentVersion)
+
+	operations := ts.listConsentOperations(consent.ID, url.Values{})
+	ts.Require().Len(operations.Data, 1)
+	var details struct {
+		Evidence ConsentEvidence `json:"evidence"`
+	}
+	ts.Require().NoError(json.Unmarshal(operations.Data[0].Details, &details))
+	ts.Equal("consent.create", operations.Data[0].Action)
+	ts.Equal("branch-kiosk", details.Evidence.Channel)
+	ts.Equal(receipt.Receipt.Evidence[0].ID, details.Evidence.ID)
+}
+
+// TestConsentEvidence_InvalidEvidence_ReturnsBadRequest rejects evidence without a channel or with
+// a malformed IP address or checksum
+func (ts *ConsentAPITestSuite) TestConsentEvidence_InvalidEvidence_ReturnsBadRequest() {
+	invalid := map[string]ConsentEvidenceRequest{
+		"missing channel":    {IPAddress: "203.0.113.7"},
+		"invalid IP address": {Channel: "web", IPAddress: "not-an-ip"},
+		"invalid checksum":   {Channel: "web", PresentedTextChecksum: "abc123"},
+	}
+	for name, evidence := range invalid {
+		resp, body := ts.createConsent(ConsentCreateRequest{
+			Type:           "accounts",
+			Authorizations: []AuthorizationRequest{{UserID: "evidence-user", Type: "authorisation", Status: "APPROVED"}},
+			Evidence:       &evidence,
+		})
+		resp.Body.Close()
+		ts.Equal(http.StatusBadRequest, resp.StatusCode, "%s: %s", name, string(body))
+		ts.Contains(string(body), "evidence", name)
+	}
+
+	consentID := ts.createConsentOrFail(userConsentRequest("evidence-user-2"))
+	resp, body := ts.updateConsent(consentID, ConsentUpdateRequest{
+		Attributes: map[string]string{"branch": "kandy"},
+		Evidence:   &ConsentEvidenceRequest{Channel: "web", IPAddress: "300.1.1.1"},
+	})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Empty(ts.listConsentEvidence(consentID), "rejected updates record no evidence")
+}
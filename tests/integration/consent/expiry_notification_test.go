@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// expiryWebhookEvent is the consent.expiring_soon event posted to organization webhooks
+type expiryWebhookEvent struct {
+	Type         string `json:"type"`
+	OrgID        string `json:"orgId"`
+	ConsentID    string `json:"consentId"`
+	Status       string `json:"status"`
+	ValidityTime int64  `json:"validityTime"`
+	eventType    string
+}
+
+// expiryWebhook records the events posted to it, by consent ID
+type expiryWebhook struct {
+	mu     sync.Mutex
+	events map[string][]expiryWebhookEvent
+}
+
+func (w *expiryWebhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var event expiryWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	event.eventType = r.Header.Get("X-Consent-Event-Type")
+
+	w.mu.Lock()
+	w.events[event.ConsentID] = append(w.events[event.ConsentID], event)
+	w.mu.Unlock()
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (w *expiryWebhook) received(consentID string) []expiryWebhookEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]expiryWebhookEvent(nil), w.events[consentID]...)
+}
+
+// createExpiringConsent creates an active consent of the test organization with a validity time
+func (ts *ConsentAPITestSuite) createExpiringConsent(validityTime int64) string {
+	return ts.createConsentOrFail(ConsentCreateRequest{
+		Type:         "accounts",
+		ValidityTime: validityTime,
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "auth", Status: "APPROVED"},
+		},
+	})
+}
+
+// TestExpiryNotification_NotifiesWebhookOncePerValidityTime checks that a consent entering its
+// organization's notice period is posted to the organization's webhook once, and that consents
+// outside the notice period are not
+func (ts *ConsentAPITestSuite) TestExpiryNotification_NotifiesWebhookOncePerValidityTime() {
+	webhook := &expiryWebhook{events: map[string][]expiryWebhookEvent{}}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+
+	resp, body := ts.sendOrganizationRequest("POST", "", map[string]interface{}{
+		"orgId":            testOrgID,
+		"name":             "Test Organization",
+		"webhookUrls":      []string{server.URL},
+		"expiryNoticeDays": 7,
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	defer ts.deleteOrganization(testOrgID)
+
+	expiringValidity := time.Now().Add(48 * time.Hour).Unix()
+	expiringID := ts.createExpiringConsent(expiringValidity)
+	laterID := ts.createExpiringConsent(time.Now().Add(30 * 24 * time.Hour).Unix())
+
+	ts.Require().Eventually(func() bool {
+		return len(webhook.received(expiringID)) > 0
+	}, 10*time.Second, 200*time.Millisecond, "expiring consent was not notified")
+
+	event := webhook.received(expiringID)[0]
+	ts.Equal("consent.expiring_soon", event.Type)
+	ts.Equal("consent.expiring_soon", event.eventType)
+	ts.Equal(testOrgID, event.OrgID)
+	ts.Equal("ACTIVE", event.Status)
+	ts.Equal(expiringValidity, event.ValidityTime)
+
+	// Further runs neither notify the consent again nor notify the consent outside the notice period
+	time.Sleep(3 * time.Second)
+	ts.Len(webhook.received(expiringID), 1)
+	ts.Empty(webhook.received(laterID))
+
+	// Extending the validity within the notice period notifies the new validity time
+	extendedValidity := time.Now().Add(72 * time.Hour).Unix()
+	updateResp, updateBody := ts.updateConsent(expiringID, ConsentUpdateRequest{ValidityTime: &extendedValidity})
+	updateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, updateResp.StatusCode, string(updateBody))
+
+	ts.Require().Eventually(func() bool {
+		return len(webhook.received(expiringID)) == 2
+	}, 10*time.Second, 200*time.Millisecond, "extended consent was not notified again")
+	ts.Equal(extendedValidity, webhook.received(expiringID)[1].ValidityTime)
+}
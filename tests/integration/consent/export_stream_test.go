@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// GET /consents/export Tests
+// ============================
+
+// exportConsents streams a consent export with the given raw query and returns response and body
+func (ts *ConsentAPITestSuite) exportConsents(query string) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consents/export?%s", testServerURL, query)
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// createExportConsents creates count consents of a fresh consent type and returns the type
+func (ts *ConsentAPITestSuite) createExportConsents(count int) string {
+	consentType := fmt.Sprintf("export-%d", time.Now().UnixNano())
+	for i := 0; i < count; i++ {
+		payload := ConsentCreateRequest{
+			Type: consentType,
+			ConsentPurpose: []ConsentPurposeItem{
+				{Name: "marketing-purpose", IsUserApproved: true},
+				{Name: "analytics-purpose", IsUserApproved: false},
+			},
+			Attributes: map[string]string{"batch": fmt.Sprintf("%d", i)},
+			Authorizations: []AuthorizationRequest{
+				{UserID: "user1", Type: "auth", Status: "APPROVED"},
+			},
+		}
+
+		resp, body := ts.createConsent(payload)
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+		var created ConsentResponse
+		ts.Require().NoError(json.Unmarshal(body, &created))
+		ts.trackConsent(created.ID)
+	}
+	return consentType
+}
+
+// TestExportConsents_NDJSON_StreamsFlattenedRecords exports consents as NDJSON and checks each record
+func (ts *ConsentAPITestSuite) TestExportConsents_NDJSON_StreamsFlattenedRecords() {
+	consentType := ts.createExportConsents(3)
+
+	resp, body := ts.exportConsents("format=ndjson&consentTypes=" + consentType)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Equal("application/x-ndjson", resp.Header.Get(testutils.HeaderContentType))
+	ts.Contains(resp.Header.Get("Content-Disposition"), "consents.ndjson")
+	ts.Equal(int64(-1), resp.ContentLength, "export should be streamed without a Content-Length")
+
+	records := make([]exportRecord, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		var record exportRecord
+		ts.Require().NoError(json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	ts.Require().NoError(scanner.Err())
+	ts.Require().Len(records, 3)
+
+	for _, record := range records {
+		ts.Equal(consentType, record.ConsentType)
+		ts.Equal("ACTIVE", record.CurrentStatus)
+		ts.ElementsMatch([]string{"marketing-purpose", "analytics-purpose"}, record.Purposes)
+		ts.Equal([]string{"marketing-purpose"}, record.ApprovedPurposes)
+		ts.Equal([]string{"user1"}, record.UserIDs)
+		ts.Equal([]string{"user1:APPROVED"}, record.AuthorizationStatuses)
+		ts.Contains(record.Attributes, "batch")
+	}
+}
+
+// TestExportConsents_CSV_WritesHeaderAndRows exports consents as CSV, the default format
+func (ts *ConsentAPITestSuite) TestExportConsents_CSV_WritesHeaderAndRows() {
+	consentType := ts.createExportConsents(2)
+
+	resp, body := ts.exportConsents("consentTypes=" + consentType)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Contains(resp.Header.Get(testutils.HeaderContentType), "text/csv")
+
+	rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	ts.Require().NoError(err)
+	ts.Require().Len(rows, 3, "expected a header row and two consent rows")
+	ts.Equal("consentId", rows[0][0])
+	ts.Contains(rows[0], "authorizationStatuses")
+	for _, row := range rows[1:] {
+		ts.Equal(consentType, row[2])
+	}
+}
+
+// TestExportConsents_NoMatches_ReturnsHeaderOnly exports a filter that matches nothing
+func (ts *ConsentAPITestSuite) TestExportConsents_NoMatches_ReturnsHeaderOnly() {
+	resp, body := ts.exportConsents(fmt.Sprintf("format=csv&consentTypes=export-none-%d", time.Now().UnixNano()))
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	ts.Require().NoError(err)
+	ts.Len(rows, 1)
+}
+
+// TestExportConsents_InvalidFormat_ReturnsBadRequest rejects unsupported export formats
+func (ts *ConsentAPITestSuite) TestExportConsents_InvalidFormat_ReturnsBadRequest() {
+	resp, body := ts.exportConsents("format=xml")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
@@ -0,0 +1,425 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ========================
+// Service extension hooks
+// ========================
+
+// extensionStubAddr is the address the service extension points to in the test deployment.yaml
+const extensionStubAddr = "127.0.0.1:9100"
+
+// extensionStubToken is the access token the stub's token endpoint issues. The test deployment.yaml
+// configures OAuth2 client credentials for the pre_process_consent_revoke hook only.
+const extensionStubToken = "extension-stub-token"
+
+// extensionStubAsyncDelay is how long the stub takes to answer the async revoke enrich hook
+const extensionStubAsyncDelay = time.Second
+
+// extensionStub counts the calls a stub service extension receives
+type extensionStub struct {
+	tokenRequests  atomic.Int32
+	revokeRequests atomic.Int32
+	// updateEnrichRequests counts calls to the update enrich hook, which has a circuit breaker
+	updateEnrichRequests atomic.Int32
+	// failUpdateEnrich makes the update enrich hook answer with a 500
+	failUpdateEnrich atomic.Bool
+	// revokeEnrichRequests counts answered calls to the async revoke enrich hook
+	revokeEnrichRequests atomic.Int32
+}
+
+// extensionStubRequest is the envelope the server sends to the service extension
+type extensionStubRequest struct {
+	RequestID string                 `json:"requestId"`
+	Hook      string                 `json:"hook"`
+	OrgID     string                 `json:"orgId"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// startExtensionStub starts a stub service extension for the duration of a test. Consents whose
+// "extension" attribute is "reject" are rejected, "mutate" adds an attribute before creation, and
+// created consents get an enriched modifiedResponse, which is too large for consents whose
+// "extension" attribute is "oversized" and does not match the schema for "off-schema". Validation policy checks deny consents whose
+// "extension" attribute is "deny". The revoke hook requires the access token issued by the stub's
+// OAuth2 token endpoint.
+func (ts *ConsentAPITestSuite) startExtensionStub() *extensionStub {
+	stub := &extensionStub{}
+	mux := http.NewServeMux()
+	respond := func(w http.ResponseWriter, body interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}
+	reject := func(w http.ResponseWriter, req extensionStubRequest) {
+		respond(w, map[string]interface{}{
+			"responseId":       req.RequestID,
+			"status":           "ERROR",
+			"errorCode":        400,
+			"errorMessage":     "rejected_by_policy",
+			"errorDescription": "Consent rejected by extension policy",
+		})
+	}
+	decode := func(r *http.Request) extensionStubRequest {
+		var req extensionStubRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		return req
+	}
+
+	mux.HandleFunc("/pre-process-consent-creation", func(w http.ResponseWriter, r *http.Request) {
+		req := decode(r)
+		consentRequest, _ := req.Data["consentRequest"].(map[string]interface{})
+		attributes, _ := consentRequest["attributes"].(map[string]interface{})
+		switch attributes["extension"] {
+		case "reject":
+			reject(w, req)
+			return
+		case "mutate":
+			attributes["extensionChecked"] = "true"
+		}
+		respond(w, map[string]interface{}{"responseId": req.RequestID, "status": "SUCCESS", "data": req.Data})
+	})
+	mux.HandleFunc("/enrich-consent-creation-response", func(w http.ResponseWriter, r *http.Request) {
+		req := decode(r)
+		consent, _ := req.Data["consent"].(map[string]interface{})
+		attributes, _ := consent["attributes"].(map[string]interface{})
+		switch attributes["extension"] {
+		case "oversized":
+			req.Data["modifiedResponse"] = map[string]interface{}{"enrichedBy": "extension-stub", "note": strings.Repeat("x", 1024)}
+		case "off-schema":
+			req.Data["modifiedResponse"] = map[string]interface{}{"enrichedBy": 42}
+		default:
+			req.Data["modifiedResponse"] = map[string]interface{}{"enrichedBy": "extension-stub"}
+		}
+		respond(w, map[string]interface{}{"responseId": req.RequestID, "status": "SUCCESS", "data": req.Data})
+	})
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "consent-server" || clientSecret != "extension-secret" ||
+			r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		stub.tokenRequests.Add(1)
+		respond(w, map[string]interface{}{"access_token": extensionStubToken, "token_type": "Bearer", "expires_in": 3600})
+	})
+	mux.HandleFunc("/pre-process-consent-revoke", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+extensionStubToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		stub.revokeRequests.Add(1)
+		req := decode(r)
+		revokeRequest, _ := req.Data["revokeRequest"].(map[string]interface{})
+		if revokeRequest["revocationReason"] == "reject" {
+			reject(w, req)
+			return
+		}
+		respond(w, map[string]interface{}{"responseId": req.RequestID, "status": "SUCCESS"})
+	})
+	mux.HandleFunc("/enrich-consent-update-response", func(w http.ResponseWriter, r *http.Request) {
+		stub.updateEnrichRequests.Add(1)
+		if stub.failUpdateEnrich.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		respond(w, map[string]interface{}{"responseId": decode(r).RequestID, "status": "SUCCESS"})
+	})
+	mux.HandleFunc("/enrich-consent-revoke-response", func(w http.ResponseWriter, r *http.Request) {
+		req := decode(r)
+		time.Sleep(extensionStubAsyncDelay)
+		stub.revokeEnrichRequests.Add(1)
+		respond(w, map[string]interface{}{"responseId": req.RequestID, "status": "SUCCESS"})
+	})
+	mux.HandleFunc("/pre-process-consent-validation", func(w http.ResponseWriter, r *http.Request) {
+		req := decode(r)
+		validateRequest, _ := req.Data["validateRequest"].(map[string]interface{})
+		if validateRequest["electedResource"] == "reject" {
+			reject(w, req)
+			return
+		}
+		respond(w, map[string]interface{}{"responseId": req.RequestID, "status": "SUCCESS"})
+	})
+
+	mux.HandleFunc("/evaluate-validation-policy", func(w http.ResponseWriter, r *http.Request) {
+		req := decode(r)
+		consentInformation, _ := req.Data["consentInformation"].(map[string]interface{})
+		attributes, _ := consentInformation["attributes"].(map[string]interface{})
+		if attributes["extension"] == "deny" {
+			reject(w, req)
+			return
+		}
+		respond(w, map[string]interface{}{"responseId": req.RequestID, "status": "SUCCESS"})
+	})
+
+	listener, err := net.Listen("tcp", extensionStubAddr)
+	ts.Require().NoError(err)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	// Each test runs its own stub, so connections kept alive for a previous stub would be stale
+	server.SetKeepAlivesEnabled(false)
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			ts.T().Logf("extension stub stopped: %v", err)
+		}
+	}()
+	ts.T().Cleanup(func() { _ = server.Close() })
+	return stub
+}
+
+// createConsentWithExtensionAttribute creates a consent carrying the given "extension" attribute
+func (ts *ConsentAPITestSuite) createConsentWithExtensionAttribute(value string) (*http.Response, []byte) {
+	return ts.createConsent(ConsentCreateRequest{
+		Type:           "accounts",
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "APPROVED"}},
+		Attributes:     map[string]string{"extension": value},
+	})
+}
+
+// TestExtensionHooks_PreProcessCreation_Rejects checks that a rejection from the pre-process hook
+// fails the creation with the status the extension returned
+func (ts *ConsentAPITestSuite) TestExtensionHooks_PreProcessCreation_Rejects() {
+	ts.startExtensionStub()
+
+	resp, body := ts.createConsentWithExtensionAttribute("reject")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "Consent rejected by extension policy")
+}
+
+// TestExtensionHooks_PreProcessCreation_MutatesRequest checks that the request returned by the
+// pre-process hook is the one that gets stored, and that the enrich hook fills modifiedResponse
+func (ts *ConsentAPITestSuite) TestExtensionHooks_PreProcessCreation_MutatesRequest() {
+	ts.startExtensionStub()
+
+	resp, body := ts.createConsentWithExtensionAttribute("mutate")
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created struct {
+		ConsentResponse
+		ModifiedResponse map[string]interface{} `json:"modifiedResponse"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+
+	ts.Equal("true", created.Attributes["extensionChecked"])
+	ts.Equal("extension-stub", created.ModifiedResponse["enrichedBy"])
+
+	// Both the mutation and the enrichment are persisted
+	getResp, getBody := ts.getConsent(created.ID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode)
+	var fetched struct {
+		ConsentResponse
+		ModifiedResponse map[string]interface{} `json:"modifiedResponse"`
+	}
+	ts.Require().NoError(json.Unmarshal(getBody, &fetched))
+	ts.Equal("true", fetched.Attributes["extensionChecked"])
+	ts.Equal("extension-stub", fetched.ModifiedResponse["enrichedBy"])
+}
+
+// TestExtensionHooks_PreProcessRevoke_Rejects checks that the pre-process hook can block a
+// revocation and the consent keeps its status
+func (ts *ConsentAPITestSuite) TestExtensionHooks_PreProcessRevoke_Rejects() {
+	ts.startExtensionStub()
+
+	resp, body := ts.createConsentWithExtensionAttribute("none")
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+
+	reqBody, err := json.Marshal(map[string]string{"actionBy": "test-user", "revocationReason": "reject"})
+	ts.Require().NoError(err)
+	url := fmt.Sprintf("%s/api/v1/consents/%s/revoke", testServerURL, created.ID)
+	httpReq, _ := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+	revokeResp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer revokeResp.Body.Close()
+	revokeBody, _ := io.ReadAll(revokeResp.Body)
+	ts.Equal(http.StatusBadRequest, revokeResp.StatusCode, string(revokeBody))
+
+	getResp, getBody := ts.getConsent(created.ID)
+	defer getResp.Body.Close()
+	var fetched ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &fetched))
+	ts.Equal("ACTIVE", fetched.Status)
+}
+
+// TestExtensionHooks_PreProcessValidation_Rejects checks that a validation rejected by the
+// pre-process hook reports the rejection in the validate response
+func (ts *ConsentAPITestSuite) TestExtensionHooks_PreProcessValidation_Rejects() {
+	ts.startExtensionStub()
+
+	resp, body := ts.createConsentWithExtensionAttribute("none")
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+
+	validateResp, validateBody := ts.validateConsent(ConsentValidateRequest{ConsentID: created.ID, ElectedResource: "reject"})
+	defer validateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, validateResp.StatusCode, string(validateBody))
+
+	var result ConsentValidateResponse
+	ts.Require().NoError(json.Unmarshal(validateBody, &result))
+	ts.False(result.IsValid)
+	ts.Equal(http.StatusBadRequest, result.ErrorCode)
+	ts.Equal("extension_rejected", result.ErrorMessage)
+}
+
+// TestExtensionHooks_ExtensionDown_FailsOpen checks that hooks configured to fail open let the
+// operation through when the extension cannot be reached
+func (ts *ConsentAPITestSuite) TestExtensionHooks_ExtensionDown_FailsOpen() {
+	resp, body := ts.createConsentWithExtensionAttribute("reject")
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+}
+
+// TestExtensionHooks_OAuth2_ReusesToken checks that a hook configured with OAuth2 client credentials
+// is called with a bearer token, and that the token is cached across calls
+func (ts *ConsentAPITestSuite) TestExtensionHooks_OAuth2_ReusesToken() {
+	stub := ts.startExtensionStub()
+
+	for i := 0; i < 2; i++ {
+		resp, body := ts.createConsentWithExtensionAttribute("none")
+		defer resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+		var created ConsentResponse
+		ts.Require().NoError(json.Unmarshal(body, &created))
+		ts.trackConsent(created.ID)
+
+		revokeResp, revokeBody := ts.revokeConsent(created.ID, "oauth2 test")
+		defer revokeResp.Body.Close()
+		ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+	}
+
+	// The hook fails open, so only the stub's count shows the calls were authorized
+	ts.Equal(int32(2), stub.revokeRequests.Load())
+	// A token cached by an earlier test is still valid, so at most one is requested here
+	ts.LessOrEqual(stub.tokenRequests.Load(), int32(1))
+}
+
+// getExtensionStats reads the metrics of a hook from the admin extensions API
+func (ts *ConsentAPITestSuite) getExtensionStats(hook string) map[string]interface{} {
+	httpReq, _ := http.NewRequest("GET", testServerURL+"/api/v1/admin/extensions", nil)
+	httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	for _, stats := range list.Data {
+		if stats["hook"] == hook {
+			return stats
+		}
+	}
+	ts.FailNow("hook not listed", hook)
+	return nil
+}
+
+// TestExtensionHooks_CircuitBreaker_OpensAfterFailures checks that a hook stops being called once
+// its consecutive failures reach the threshold, failing open without reaching the extension
+func (ts *ConsentAPITestSuite) TestExtensionHooks_CircuitBreaker_OpensAfterFailures() {
+	stub := ts.startExtensionStub()
+
+	resp, body := ts.createConsentWithExtensionAttribute("none")
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+
+	update := func() {
+		updateResp, updateBody := ts.updateConsent(created.ID, ConsentUpdateRequest{
+			Attributes: map[string]string{"extension": "none"},
+		})
+		defer updateResp.Body.Close()
+		ts.Require().Equal(http.StatusOK, updateResp.StatusCode, string(updateBody))
+	}
+
+	// Earlier tests call the hook without a running stub and may have opened the circuit; a
+	// successful call once it lets a trial through closes it
+	ts.Require().Eventually(func() bool {
+		update()
+		return stub.updateEnrichRequests.Load() > 0
+	}, 5*time.Second, 250*time.Millisecond)
+	ts.Equal("closed", ts.getExtensionStats("enrich_consent_update_response")["circuitState"])
+
+	stub.failUpdateEnrich.Store(true)
+	before := stub.updateEnrichRequests.Load()
+	shortCircuited := ts.getExtensionStats("enrich_consent_update_response")["shortCircuited"].(float64)
+	for i := 0; i < 3; i++ {
+		update()
+	}
+
+	// The test deployment.yaml opens the circuit after two failures
+	ts.Equal(int32(2), stub.updateEnrichRequests.Load()-before)
+	stats := ts.getExtensionStats("enrich_consent_update_response")
+	ts.Equal("open", stats["circuitState"])
+	ts.Equal(shortCircuited+1, stats["shortCircuited"])
+}
+
+// TestExtensionHooks_Async_DoesNotWait checks that an operation does not wait for an async hook,
+// which is still called in the background
+func (ts *ConsentAPITestSuite) TestExtensionHooks_Async_DoesNotWait() {
+	stub := ts.startExtensionStub()
+
+	resp, body := ts.createConsentWithExtensionAttribute("none")
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+
+	start := time.Now()
+	revokeResp, revokeBody := ts.revokeConsent(created.ID, "async test")
+	defer revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+	ts.Less(time.Since(start), extensionStubAsyncDelay)
+
+	ts.Eventually(func() bool { return stub.revokeEnrichRequests.Load() == 1 }, 5*time.Second, 100*time.Millisecond)
+	ts.Equal("async", ts.getExtensionStats("enrich_consent_revoke_response")["mode"])
+}
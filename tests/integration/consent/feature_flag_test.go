@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Feature flag gated behaviour
+// ============================
+
+// TestCreateConsent_StrictValidationDisabled_AllowsBlankAttributeKey checks that blank attribute
+// keys are accepted while strict validation is off
+func (ts *ConsentAPITestSuite) TestCreateConsent_StrictValidationDisabled_AllowsBlankAttributeKey() {
+	payload := ConsentCreateRequest{
+		Type:       "accounts",
+		Attributes: map[string]string{" ": "value"},
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "payment", Status: "APPROVED"},
+		},
+	}
+
+	resp, body := ts.createConsent(payload)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+}
+
+// TestCreateConsent_StrictValidationEnabled_RejectsBlankAttributeKey enables strict validation for
+// the test organization and checks that the same request is rejected
+func (ts *ConsentAPITestSuite) TestCreateConsent_StrictValidationEnabled_RejectsBlankAttributeKey() {
+	state, err := testutils.SetFeatureFlag(testOrgID, "strict_validation", true)
+	ts.Require().NoError(err)
+	defer testutils.ClearFeatureFlag(testOrgID, "strict_validation")
+	ts.True(state.Enabled)
+	ts.Equal("override", state.Source)
+
+	payload := ConsentCreateRequest{
+		Type:       "accounts",
+		Attributes: map[string]string{" ": "value"},
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "payment", Status: "APPROVED"},
+		},
+	}
+
+	resp, body := ts.createConsent(payload)
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode)
+	ts.True(strings.Contains(string(body), "attribute keys cannot be blank"), string(body))
+}
+
+// TestCreateConsent_PurposeEnforcementEnabled_RejectsUnapprovedMandatoryPurpose checks that an
+// active consent cannot be created with a mandatory purpose the user has not approved
+func (ts *ConsentAPITestSuite) TestCreateConsent_PurposeEnforcementEnabled_RejectsUnapprovedMandatoryPurpose() {
+	_, err := testutils.SetFeatureFlag(testOrgID, "purpose_enforcement", true)
+	ts.Require().NoError(err)
+	defer testutils.ClearFeatureFlag(testOrgID, "purpose_enforcement")
+
+	payload := ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "marketing-purpose", Value: "yes", IsUserApproved: false, IsMandatory: true},
+		},
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "payment", Status: "APPROVED"},
+		},
+	}
+
+	resp, body := ts.createConsent(payload)
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode)
+	ts.True(strings.Contains(string(body), "marketing-purpose"), string(body))
+
+	// Approving the purpose makes the request acceptable
+	payload.ConsentPurpose[0].IsUserApproved = true
+	okResp, okBody := ts.createConsent(payload)
+	defer okResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, okResp.StatusCode, string(okBody))
+
+	var created ConsentResponse
+	ts.NoError(json.Unmarshal(okBody, &created))
+	ts.trackConsent(created.ID)
+}
+
+// TestFeatureFlag_UnknownFlag_ReturnsError checks that overriding an undefined flag fails
+func (ts *ConsentAPITestSuite) TestFeatureFlag_UnknownFlag_ReturnsError() {
+	_, err := testutils.SetFeatureFlag(testOrgID, "no_such_flag", true)
+	ts.Error(err)
+}
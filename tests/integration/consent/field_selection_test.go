@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// fields and include query parameter Tests
+// ============================
+
+// getConsentWithQuery retrieves a consent with a raw query string and returns its top-level fields
+func (ts *ConsentAPITestSuite) getConsentWithQuery(consentID, query string) (int, map[string]json.RawMessage) {
+	url := fmt.Sprintf("%s/api/v1/consents/%s?%s", testServerURL, consentID, query)
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	var fields map[string]json.RawMessage
+	if resp.StatusCode == http.StatusOK {
+		ts.Require().NoError(json.Unmarshal(body, &fields))
+	}
+	return resp.StatusCode, fields
+}
+
+// fieldNames returns the sorted keys of a decoded JSON object
+func fieldNames(fields map[string]json.RawMessage) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TestGetConsent_Fields_ReturnsOnlySelectedFields selects fields and relations of a single consent
+func (ts *ConsentAPITestSuite) TestGetConsent_Fields_ReturnsOnlySelectedFields() {
+	consentID := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", map[string]string{"channel": "web"},
+		[]AuthorizationRequest{{UserID: "fields-user-1", Type: "authorisation", Status: "APPROVED"}}))
+
+	status, fields := ts.getConsentWithQuery(consentID, "")
+	ts.Require().Equal(http.StatusOK, status)
+	ts.Contains(fields, "authorizations")
+	ts.Contains(fields, "consentPurpose")
+	ts.Contains(fields, "attributes")
+	ts.NotContains(fields, "history")
+
+	status, fields = ts.getConsentWithQuery(consentID, "fields=status,type")
+	ts.Require().Equal(http.StatusOK, status)
+	ts.Equal([]string{"id", "status", "type"}, fieldNames(fields))
+
+	status, fields = ts.getConsentWithQuery(consentID, "include=authorizations")
+	ts.Require().Equal(http.StatusOK, status)
+	ts.Contains(fields, "authorizations")
+	ts.Contains(fields, "status")
+	ts.NotContains(fields, "consentPurpose")
+	ts.NotContains(fields, "attributes")
+
+	status, fields = ts.getConsentWithQuery(consentID, "fields=status,attributes&include=attributes")
+	ts.Require().Equal(http.StatusOK, status)
+	ts.Equal([]string{"attributes", "id", "status"}, fieldNames(fields))
+	ts.JSONEq(`{"channel": "web"}`, string(fields["attributes"]))
+}
+
+// TestGetConsent_IncludeHistory_ListsSupersededVersions includes the versions replaced by amendments
+func (ts *ConsentAPITestSuite) TestGetConsent_IncludeHistory_ListsSupersededVersions() {
+	userID := fmt.Sprintf("fields-history-user-%d", time.Now().UnixNano())
+	consentID := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", map[string]string{"channel": "web"},
+		[]AuthorizationRequest{{UserID: userID, Type: "authorisation", Status: "APPROVED"}}))
+
+	amendResp, amendBody := ts.amendConsent(consentID, ConsentAmendmentRequest{
+		ConsentUpdateRequest: ConsentUpdateRequest{Attributes: map[string]string{"channel": "mobile"}},
+		AmendedBy:            userID,
+		Reason:               "channel change",
+	})
+	amendResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, amendResp.StatusCode, string(amendBody))
+
+	status, fields := ts.getConsentWithQuery(consentID, "include=history&fields=version,history")
+	ts.Require().Equal(http.StatusOK, status)
+	ts.Equal([]string{"history", "id", "version"}, fieldNames(fields))
+
+	var history []struct {
+		Version int    `json:"version"`
+		Reason  string `json:"reason"`
+	}
+	ts.Require().NoError(json.Unmarshal(fields["history"], &history))
+	ts.Require().Len(history, 1)
+	ts.Equal(1, history[0].Version)
+	ts.Equal("channel change", history[0].Reason)
+
+	resp, body := ts.listConsents(map[string]string{"userIds": userID, "include": "history", "fields": "history"})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list struct {
+		Data []map[string]json.RawMessage `json:"data"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Require().Len(list.Data, 1)
+	ts.Equal([]string{"history", "id"}, fieldNames(list.Data[0]))
+}
+
+// TestListConsents_Fields_ReturnsOnlySelectedFields selects fields of search results
+func (ts *ConsentAPITestSuite) TestListConsents_Fields_ReturnsOnlySelectedFields() {
+	userID := fmt.Sprintf("fields-list-user-%d", time.Now().UnixNano())
+	ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", map[string]string{"channel": "web"},
+		[]AuthorizationRequest{{UserID: userID, Type: "authorisation", Status: "APPROVED"}}))
+
+	resp, body := ts.listConsents(map[string]string{"userIds": userID, "fields": "status,clientId"})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list struct {
+		Data     []map[string]json.RawMessage `json:"data"`
+		Metadata map[string]interface{}       `json:"metadata"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Require().Len(list.Data, 1)
+	ts.Equal([]string{"clientId", "id", "status"}, fieldNames(list.Data[0]))
+	ts.EqualValues(1, list.Metadata["count"])
+
+	resp, body = ts.listConsents(map[string]string{"userIds": userID, "include": "purposes"})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Require().Len(list.Data, 1)
+	ts.Contains(list.Data[0], "consentPurpose")
+	ts.NotContains(list.Data[0], "authorizations")
+	ts.NotContains(list.Data[0], "attributes")
+}
+
+// TestFieldSelection_InvalidParameters_ReturnBadRequest rejects unknown fields and includes
+func (ts *ConsentAPITestSuite) TestFieldSelection_InvalidParameters_ReturnBadRequest() {
+	consentID := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", nil,
+		[]AuthorizationRequest{{UserID: "fields-user-4", Type: "authorisation", Status: "APPROVED"}}))
+
+	status, _ := ts.getConsentWithQuery(consentID, "fields=status,password")
+	ts.Equal(http.StatusBadRequest, status)
+	status, _ = ts.getConsentWithQuery(consentID, "include=receipts")
+	ts.Equal(http.StatusBadRequest, status)
+
+	resp, body := ts.listConsents(map[string]string{"include": "children"})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
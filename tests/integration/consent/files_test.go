@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// =====================================
+// /consents/{consentId}/files - Consent file attachment tests
+// =====================================
+
+// samplePDF is a minimal document carrying the PDF file signature
+var samplePDF = []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\ntrailer << /Root 1 0 R >>\n%%EOF\n")
+
+// uploadConsentFile attaches a file to a consent as the "file" part of a multipart request
+func (ts *ConsentAPITestSuite) uploadConsentFile(consentID, fileName, contentType string, content []byte) (*http.Response, []byte) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, fileName))
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	ts.Require().NoError(err)
+	_, err = part.Write(content)
+	ts.Require().NoError(err)
+	ts.Require().NoError(writer.Close())
+
+	return ts.doFileRequest("POST", fmt.Sprintf("%s/api/v1/consents/%s/files", testServerURL, consentID),
+		writer.FormDataContentType(), &buf)
+}
+
+// doFileRequest sends a request to the consent file API with the test organization headers
+func (ts *ConsentAPITestSuite) doFileRequest(method, url, contentType string, body io.Reader) (*http.Response, []byte) {
+	httpReq, err := http.NewRequest(method, url, body)
+	ts.Require().NoError(err)
+	if contentType != "" {
+		httpReq.Header.Set(testutils.HeaderContentType, contentType)
+	}
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	respBody, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	return resp, respBody
+}
+
+// TestConsentFiles_UploadListDownload_RoundTrips checks that an attached file is listed with its
+// metadata and downloaded unchanged
+func (ts *ConsentAPITestSuite) TestConsentFiles_UploadListDownload_RoundTrips() {
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+
+	resp, body := ts.uploadConsentFile(consentID, "signed consent.pdf", "application/pdf", samplePDF)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var uploaded ConsentFileResponse
+	ts.Require().NoError(json.Unmarshal(body, &uploaded))
+	checksum := sha256.Sum256(samplePDF)
+	ts.NotEmpty(uploaded.ID)
+	ts.Equal(consentID, uploaded.ConsentID)
+	ts.Equal("signed consent.pdf", uploaded.FileName)
+	ts.Equal("application/pdf", uploaded.ContentType)
+	ts.Equal(int64(len(samplePDF)), uploaded.Size)
+	ts.Equal(hex.EncodeToString(checksum[:]), uploaded.Checksum)
+
+	listResp, listBody := ts.doFileRequest("GET", fmt.Sprintf("%s/api/v1/consents/%s/files", testServerURL, consentID), "", nil)
+	defer listResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, listResp.StatusCode, string(listBody))
+	var list struct {
+		Data []ConsentFileResponse `json:"data"`
+	}
+	ts.Require().NoError(json.Unmarshal(listBody, &list))
+	ts.Require().Len(list.Data, 1)
+	ts.Equal(uploaded, list.Data[0])
+
+	getResp, content := ts.doFileRequest("GET",
+		fmt.Sprintf("%s/api/v1/consents/%s/files/%s", testServerURL, consentID, uploaded.ID), "", nil)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(content))
+	ts.Equal(samplePDF, content)
+	ts.Equal("application/pdf", getResp.Header.Get("Content-Type"))
+	ts.Equal(`attachment; filename="signed consent.pdf"`, getResp.Header.Get("Content-Disposition"))
+}
+
+// TestConsentFiles_DisallowedContentType_ReturnsUnsupportedMediaType checks that only the configured
+// content types are accepted
+func (ts *ConsentAPITestSuite) TestConsentFiles_DisallowedContentType_ReturnsUnsupportedMediaType() {
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+
+	resp, body := ts.uploadConsentFile(consentID, "page.html", "text/html", []byte("<html></html>"))
+	defer resp.Body.Close()
+	ts.Equal(http.StatusUnsupportedMediaType, resp.StatusCode, string(body))
+	ts.Contains(string(body), "content type 'text/html' is not allowed")
+}
+
+// TestConsentFiles_NotMultipart_ReturnsUnsupportedMediaType checks that uploads must be multipart
+func (ts *ConsentAPITestSuite) TestConsentFiles_NotMultipart_ReturnsUnsupportedMediaType() {
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+
+	resp, body := ts.doFileRequest("POST", fmt.Sprintf("%s/api/v1/consents/%s/files", testServerURL, consentID),
+		"application/pdf", bytes.NewReader(samplePDF))
+	defer resp.Body.Close()
+	ts.Equal(http.StatusUnsupportedMediaType, resp.StatusCode, string(body))
+}
+
+// TestConsentFiles_ContentMismatch_ReturnsBadRequest checks that a file declared as a PDF must
+// carry the PDF signature
+func (ts *ConsentAPITestSuite) TestConsentFiles_ContentMismatch_ReturnsBadRequest() {
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+
+	resp, body := ts.uploadConsentFile(consentID, "evidence.pdf", "application/pdf", []byte("plain text, not a PDF"))
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "does not match content type")
+}
+
+// TestConsentFiles_TooLarge_ReturnsRequestEntityTooLarge checks the configured size limit of
+// 64 KiB, both just above the limit and far beyond it
+func (ts *ConsentAPITestSuite) TestConsentFiles_TooLarge_ReturnsRequestEntityTooLarge() {
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+
+	for _, size := range []int{64<<10 + 1, 1 << 20} {
+		resp, body := ts.uploadConsentFile(consentID, "large.txt", "text/plain", []byte(strings.Repeat("a", size)))
+		resp.Body.Close()
+		ts.Equal(http.StatusRequestEntityTooLarge, resp.StatusCode, "size %d: %s", size, string(body))
+	}
+
+	resp, body := ts.uploadConsentFile(consentID, "limit.txt", "text/plain", []byte(strings.Repeat("a", 64<<10)))
+	defer resp.Body.Close()
+	ts.Equal(http.StatusCreated, resp.StatusCode, string(body))
+}
+
+// TestConsentFiles_MaxFilesPerConsent_RejectsExtraFile checks the configured limit of three files
+func (ts *ConsentAPITestSuite) TestConsentFiles_MaxFilesPerConsent_RejectsExtraFile() {
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+
+	for i := 0; i < 3; i++ {
+		resp, body := ts.uploadConsentFile(consentID, fmt.Sprintf("note-%d.txt", i), "text/plain", []byte("note"))
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	}
+
+	resp, body := ts.uploadConsentFile(consentID, "note-3.txt", "text/plain", []byte("note"))
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "maximum of 3 files")
+}
+
+// TestConsentFiles_UnknownConsentOrFile_ReturnsNotFound checks lookups of missing consents and files
+func (ts *ConsentAPITestSuite) TestConsentFiles_UnknownConsentOrFile_ReturnsNotFound() {
+	resp, body := ts.uploadConsentFile("00000000-0000-0000-0000-000000000000", "note.txt", "text/plain", []byte("note"))
+	defer resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+	getResp, getBody := ts.doFileRequest("GET",
+		fmt.Sprintf("%s/api/v1/consents/%s/files/00000000-0000-0000-0000-000000000000", testServerURL, consentID), "", nil)
+	defer getResp.Body.Close()
+	ts.Equal(http.StatusNotFound, getResp.StatusCode, string(getBody))
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// ============================
+// GET /consents?filter= - Filter Expression Tests
+// ============================
+
+// filterSearchConsentRequest returns the request for a consent of the given type and attributes
+func filterSearchConsentRequest(consentType string, attributes map[string]string) ConsentCreateRequest {
+	return ConsentCreateRequest{
+		Type:           consentType,
+		ConsentPurpose: []ConsentPurposeItem{{Name: "marketing-purpose", Value: "yes", IsUserApproved: true}},
+		Attributes:     attributes,
+	}
+}
+
+// filterConsentIDs lists the IDs of the consents matching a filter expression
+func (ts *ConsentAPITestSuite) filterConsentIDs(expr string) []string {
+	resp, body := ts.listConsents(map[string]string{"filter": url.QueryEscape(expr), "limit": "100"})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ids := make([]string, 0, len(list.Data))
+	for _, consent := range list.Data {
+		ids = append(ids, consent.ID)
+	}
+	return ids
+}
+
+// TestListConsents_Filter_CombinesConditions checks and, or, ne and parentheses over consent fields
+// and attributes
+func (ts *ConsentAPITestSuite) TestListConsents_Filter_CombinesConditions() {
+	web := ts.createConsentOrFail(filterSearchConsentRequest("accounts", map[string]string{"filterSearch": "run", "channel": "web"}))
+	mobile := ts.createConsentOrFail(filterSearchConsentRequest("accounts", map[string]string{"filterSearch": "run", "channel": "mobile"}))
+	payment := ts.createConsentOrFail(filterSearchConsentRequest("payments", map[string]string{"filterSearch": "run"}))
+
+	ts.ElementsMatch([]string{web, payment}, ts.filterConsentIDs(
+		`attributes.filterSearch eq "run" and (attributes.channel eq "web" or TYPE EQ "payments")`))
+	ts.ElementsMatch([]string{mobile, payment}, ts.filterConsentIDs(
+		`attributes.filterSearch eq "run" and attributes.channel ne "web"`))
+	ts.ElementsMatch([]string{web, mobile}, ts.filterConsentIDs(
+		`attributes.filterSearch eq "run" and type ne "payments" and status eq "created" and createdTime gt 0`))
+}
+
+// TestListConsents_Filter_CombinedWithListFilters checks that the expression narrows the other filters
+func (ts *ConsentAPITestSuite) TestListConsents_Filter_CombinedWithListFilters() {
+	match := ts.createConsentOrFail(filterSearchConsentRequest("accounts", map[string]string{"filterSearch": "combined"}))
+	ts.createConsentOrFail(filterSearchConsentRequest("payments", map[string]string{"filterSearch": "combined"}))
+
+	resp, body := ts.listConsents(map[string]string{
+		"consentTypes": "accounts,payments",
+		"filter":       url.QueryEscape(`attributes.filterSearch eq "combined" and type eq "accounts"`),
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Require().Len(list.Data, 1)
+	ts.Equal(match, list.Data[0].ID)
+	ts.Equal(1, list.Meta.Total)
+}
+
+// TestListConsents_Filter_Malformed_Returns400 checks that a malformed expression is rejected
+func (ts *ConsentAPITestSuite) TestListConsents_Filter_Malformed_Returns400() {
+	for _, expr := range []string{
+		`status eq "ACTIVE" and`,
+		`clientId gt "a"`,
+		`userId eq "u1"`,
+		`status eq "x"; DROP TABLE CONSENT`,
+	} {
+		resp, body := ts.listConsents(map[string]string{"filter": url.QueryEscape(expr)})
+		resp.Body.Close()
+		ts.Equal(http.StatusBadRequest, resp.StatusCode, expr+": "+string(body))
+	}
+}
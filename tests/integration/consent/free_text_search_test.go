@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// ============================
+// GET /consents?q= - Free-text Search Tests
+// ============================
+
+// searchConsentIDs lists consents with the given query parameters and returns their IDs in order
+func (ts *ConsentAPITestSuite) searchConsentIDs(queryParams map[string]string) []string {
+	resp, body := ts.listConsents(queryParams)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ids := make([]string, 0, len(list.Data))
+	for _, consent := range list.Data {
+		ids = append(ids, consent.ID)
+	}
+	return ids
+}
+
+// TestListConsents_FreeText_MatchesUserAndAttributeValues verifies that q matches user IDs and
+// attribute values, and ranks exact matches before partial ones
+func (ts *ConsentAPITestSuite) TestListConsents_FreeText_MatchesUserAndAttributeValues() {
+	// Created first, so without ranking it would be listed last
+	exactUser := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", nil,
+		[]AuthorizationRequest{{UserID: "freetext-alice", Type: "authorisation", Status: "APPROVED"}}))
+	partialUser := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", nil,
+		[]AuthorizationRequest{{UserID: "freetext-alice-2", Type: "authorisation", Status: "APPROVED"}}))
+	attributeValue := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", map[string]string{"note": "ref freetext-alice"},
+		[]AuthorizationRequest{{UserID: "freetext-bob", Type: "authorisation", Status: "APPROVED"}}))
+	ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", nil,
+		[]AuthorizationRequest{{UserID: "freetext-carol", Type: "authorisation", Status: "APPROVED"}}))
+
+	ids := ts.searchConsentIDs(map[string]string{"q": "freetext-alice"})
+	ts.Require().Len(ids, 3)
+	ts.Equal(exactUser, ids[0])
+	ts.ElementsMatch([]string{partialUser, attributeValue}, ids[1:])
+}
+
+// TestListConsents_FreeText_ExactConsentIDRanksFirst verifies that a consent ID finds its consent
+func (ts *ConsentAPITestSuite) TestListConsents_FreeText_ExactConsentIDRanksFirst() {
+	consentID := ts.createConsentOrFail(userConsentRequest("freetext-id-user"))
+
+	ids := ts.searchConsentIDs(map[string]string{"q": consentID})
+	ts.Require().NotEmpty(ids)
+	ts.Equal(consentID, ids[0])
+}
+
+// TestListConsents_FreeText_WildcardsAreLiteral verifies that LIKE wildcards in q match themselves
+func (ts *ConsentAPITestSuite) TestListConsents_FreeText_WildcardsAreLiteral() {
+	literalID := ts.createConsentOrFail(userConsentRequest("freetext_100%"))
+	ts.createConsentOrFail(userConsentRequest("freetextX100Y"))
+
+	ids := ts.searchConsentIDs(map[string]string{"q": url.QueryEscape("freetext_100%")})
+	ts.Equal([]string{literalID}, ids)
+}
+
+// TestListConsents_FreeText_WithCursor_ReturnsBadRequest verifies that ranked results cannot be
+// read with a cursor
+func (ts *ConsentAPITestSuite) TestListConsents_FreeText_WithCursor_ReturnsBadRequest() {
+	resp, body := ts.listConsents(map[string]string{"q": "anything", "cursor": ""})
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
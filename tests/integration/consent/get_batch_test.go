@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// getConsentsBatch calls POST /consents/get-batch with the given consent IDs
+func (ts *ConsentAPITestSuite) getConsentsBatch(consentIDs []string) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(map[string]interface{}{"consentIds": consentIDs})
+	ts.Require().NoError(err)
+
+	httpReq, _ := http.NewRequest("POST", testServerURL+"/api/v1/consents/get-batch", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// ============================
+// POST /consents/get-batch - Batch Retrieval Tests
+// ============================
+
+// TestGetConsentsBatch_ReturnsConsentsInRequestOrder checks that found consents come back in request
+// order with their related data and that unknown IDs are reported as not found
+func (ts *ConsentAPITestSuite) TestGetConsentsBatch_ReturnsConsentsInRequestOrder() {
+	firstID := ts.createConsentOrFail(userConsentRequest("batch-get-user-1"))
+	secondID := ts.createConsentOrFail(userConsentRequest("batch-get-user-2"))
+	missing := "00000000-0000-4000-8000-000000000000"
+
+	resp, body := ts.getConsentsBatch([]string{secondID, missing, firstID, secondID})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var batch ConsentBatchGetResponse
+	ts.Require().NoError(json.Unmarshal(body, &batch))
+	ts.Require().Len(batch.Data, 2)
+	ts.Equal(secondID, batch.Data[0].ID)
+	ts.Equal(firstID, batch.Data[1].ID)
+	ts.Equal([]string{missing}, batch.NotFound)
+
+	ts.Require().Len(batch.Data[0].Authorizations, 1)
+	ts.Require().NotNil(batch.Data[0].Authorizations[0].UserID)
+	ts.Equal("batch-get-user-2", *batch.Data[0].Authorizations[0].UserID)
+}
+
+// TestGetConsentsBatch_MatchesSingleConsentRead checks that a batch entry has the same content as
+// GET /consents/{consentId}
+func (ts *ConsentAPITestSuite) TestGetConsentsBatch_MatchesSingleConsentRead() {
+	consentID := ts.createConsentOrFail(typedConsentRequest("accounts"))
+
+	getResp, getBody := ts.getConsent(consentID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode)
+
+	var single ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &single))
+
+	resp, body := ts.getConsentsBatch([]string{consentID})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var batch ConsentBatchGetResponse
+	ts.Require().NoError(json.Unmarshal(body, &batch))
+	ts.Require().Len(batch.Data, 1)
+	ts.Equal(single, batch.Data[0])
+	ts.Empty(batch.NotFound)
+}
+
+// TestGetConsentsBatch_DeletedConsent_IsNotFound checks that soft-deleted consents are not returned
+func (ts *ConsentAPITestSuite) TestGetConsentsBatch_DeletedConsent_IsNotFound() {
+	consentID := ts.createConsentOrFail(typedConsentRequest("accounts"))
+	ts.Require().True(ts.deleteConsent(consentID))
+
+	resp, body := ts.getConsentsBatch([]string{consentID})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var batch ConsentBatchGetResponse
+	ts.Require().NoError(json.Unmarshal(body, &batch))
+	ts.Empty(batch.Data)
+	ts.Equal([]string{consentID}, batch.NotFound)
+}
+
+// TestGetConsentsBatch_InvalidRequests checks the request validation
+func (ts *ConsentAPITestSuite) TestGetConsentsBatch_InvalidRequests() {
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("00000000-0000-4000-8000-%012d", i)
+	}
+
+	testCases := []struct {
+		name       string
+		consentIDs []string
+	}{
+		{name: "empty list", consentIDs: []string{}},
+		{name: "too many IDs", consentIDs: tooMany},
+		{name: "invalid ID", consentIDs: []string{"not-a-uuid"}},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.getConsentsBatch(tc.consentIDs)
+			defer resp.Body.Close()
+			ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// validateGranular validates a consent for user1 asking for per-purpose and per-resource decisions
+func (ts *ConsentAPITestSuite) validateGranular(consentID string, purposes, resources []string) ConsentValidateResponse {
+	resp, body := ts.validateConsent(ConsentValidateRequest{
+		ConsentID:          consentID,
+		UserID:             "user1",
+		ClientID:           testClientID,
+		RequestedPurposes:  purposes,
+		RequestedResources: resources,
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var validateResp ConsentValidateResponse
+	ts.Require().NoError(json.Unmarshal(body, &validateResp))
+	return validateResp
+}
+
+// TestValidateConsent_GranularDecisions checks the per-purpose and per-resource decisions of an
+// active consent
+func (ts *ConsentAPITestSuite) TestValidateConsent_GranularDecisions() {
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "marketing-purpose", IsUserApproved: false, IsMandatory: false},
+			{Name: "analytics-purpose", IsUserApproved: true, IsMandatory: true, Value: []string{"report-1"}},
+		},
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "payment", Status: "APPROVED", Resources: []string{"acc-1"}},
+			{UserID: "user2", Type: "payment", Status: "APPROVED", Resources: []string{"acc-2"}},
+		},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+
+	validateResp := ts.validateGranular(created.ID,
+		[]string{"marketing-purpose", "analytics-purpose", "unknown-purpose"},
+		[]string{"acc-1", "acc-2", "report-1"})
+	ts.True(validateResp.IsValid)
+
+	ts.Equal(ValidateDecision{Decision: "DENY", Reason: "purpose_not_approved"}, validateResp.PurposeDecisions["marketing-purpose"])
+	ts.Equal(ValidateDecision{Decision: "GRANT"}, validateResp.PurposeDecisions["analytics-purpose"])
+	ts.Equal(ValidateDecision{Decision: "DENY", Reason: "purpose_not_in_consent"}, validateResp.PurposeDecisions["unknown-purpose"])
+
+	ts.Equal(ValidateDecision{Decision: "GRANT"}, validateResp.ResourceDecisions["acc-1"])
+	// acc-2 belongs to another user's authorization
+	ts.Equal(ValidateDecision{Decision: "DENY", Reason: "resource_not_authorized"}, validateResp.ResourceDecisions["acc-2"])
+	ts.Equal(ValidateDecision{Decision: "GRANT"}, validateResp.ResourceDecisions["report-1"])
+}
+
+// TestValidateConsent_GranularDecisions_RevokedConsentDeniesAll checks that nothing is granted
+// once the consent is no longer valid
+func (ts *ConsentAPITestSuite) TestValidateConsent_GranularDecisions_RevokedConsentDeniesAll() {
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+
+	revokeResp, _ := ts.revokeConsent(consentID, "granular validate test")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode)
+
+	validateResp := ts.validateGranular(consentID, []string{"marketing-purpose"}, []string{"acc-1"})
+	ts.False(validateResp.IsValid)
+	ts.Equal(ValidateDecision{Decision: "DENY", Reason: "consent_invalid"}, validateResp.PurposeDecisions["marketing-purpose"])
+	ts.Equal(ValidateDecision{Decision: "DENY", Reason: "consent_invalid"}, validateResp.ResourceDecisions["acc-1"])
+}
+
+// TestValidateConsent_NoRequestedItems_OmitsDecisions keeps the response unchanged for callers
+// that do not ask for granular decisions
+func (ts *ConsentAPITestSuite) TestValidateConsent_NoRequestedItems_OmitsDecisions() {
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+
+	validateResp := ts.validateAsUser1(consentID)
+	ts.True(validateResp.IsValid)
+	ts.Nil(validateResp.PurposeDecisions)
+	ts.Nil(validateResp.ResourceDecisions)
+}
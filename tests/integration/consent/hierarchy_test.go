@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Parent/child consent hierarchy Tests
+// ============================
+
+// createChildConsent creates an approved consent owned by the given parent consent
+func (ts *ConsentAPITestSuite) createChildConsent(parentConsentID, account string) ConsentResponse {
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type:            "accounts",
+		ParentConsentID: parentConsentID,
+		Authorizations: []AuthorizationRequest{
+			{UserID: "hierarchy-user", Type: "authorisation", Status: "APPROVED", Resources: []string{account}},
+		},
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	ts.Require().NotNil(created.ParentConsentID)
+	ts.Equal(parentConsentID, *created.ParentConsentID)
+	return created
+}
+
+// getConsentTree retrieves a consent with include=children
+func (ts *ConsentAPITestSuite) getConsentTree(consentID string) ConsentResponse {
+	url := fmt.Sprintf("%s/api/v1/consents/%s?include=children", testServerURL, consentID)
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var consent ConsentResponse
+	ts.Require().NoError(json.NewDecoder(resp.Body).Decode(&consent))
+	return consent
+}
+
+// TestConsentHierarchy_IncludeChildren_ReturnsTree creates two levels of child consents and reads
+// the tree from the parent
+func (ts *ConsentAPITestSuite) TestConsentHierarchy_IncludeChildren_ReturnsTree() {
+	parentID := ts.createConsentOrFail(userConsentRequest("hierarchy-user"))
+	childA := ts.createChildConsent(parentID, "acc-1")
+	childB := ts.createChildConsent(parentID, "acc-2")
+	grandchild := ts.createChildConsent(childA.ID, "acc-1-sub")
+
+	tree := ts.getConsentTree(parentID)
+	ts.Nil(tree.ParentConsentID)
+	ts.Require().Len(tree.Children, 2)
+	ts.Equal(childA.ID, tree.Children[0].ID)
+	ts.Equal(childB.ID, tree.Children[1].ID)
+	ts.Require().Len(tree.Children[0].Children, 1)
+	ts.Equal(grandchild.ID, tree.Children[0].Children[0].ID)
+	ts.Empty(tree.Children[1].Children)
+
+	// Without include the children are not returned
+	resp, body := ts.getConsent(parentID)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var plain ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &plain))
+	ts.Empty(plain.Children)
+}
+
+// TestConsentHierarchy_RevokeParent_RevokesDescendants revokes a parent and checks its children and
+// grandchildren are revoked with it
+func (ts *ConsentAPITestSuite) TestConsentHierarchy_RevokeParent_RevokesDescendants() {
+	parentID := ts.createConsentOrFail(userConsentRequest("hierarchy-user"))
+	child := ts.createChildConsent(parentID, "acc-1")
+	grandchild := ts.createChildConsent(child.ID, "acc-1-sub")
+	revokedChild := ts.createChildConsent(parentID, "acc-2")
+
+	resp, body := ts.revokeConsent(revokedChild.ID, "account closed")
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = ts.revokeConsent(parentID, "agreement ended")
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var revokeResp struct {
+		RevokedChildConsentIDs []string `json:"revokedChildConsentIds"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &revokeResp))
+	ts.ElementsMatch([]string{child.ID, grandchild.ID}, revokeResp.RevokedChildConsentIDs)
+
+	tree := ts.getConsentTree(parentID)
+	ts.Equal("REVOKED", tree.Status)
+	ts.Require().Len(tree.Children, 2)
+	for _, c := range tree.Children {
+		ts.Equal("REVOKED", c.Status)
+	}
+	ts.Require().Len(tree.Children[0].Children, 1)
+	ts.Equal("REVOKED", tree.Children[0].Children[0].Status)
+	for _, auth := range tree.Children[0].Children[0].Authorizations {
+		ts.Equal("SYS_REVOKED", auth.Status)
+	}
+}
+
+// TestConsentHierarchy_InvalidParent_IsRejected checks the parents a consent cannot be created under
+func (ts *ConsentAPITestSuite) TestConsentHierarchy_InvalidParent_IsRejected() {
+	revokedParentID := ts.createConsentOrFail(userConsentRequest("hierarchy-user"))
+	resp, body := ts.revokeConsent(revokedParentID, "agreement ended")
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	for name, parentID := range map[string]string{
+		"unknown parent": "00000000-0000-0000-0000-000000000000",
+		"revoked parent": revokedParentID,
+	} {
+		ts.Run(name, func() {
+			resp, body := ts.createConsent(ConsentCreateRequest{
+				Type:            "accounts",
+				ParentConsentID: parentID,
+				Authorizations: []AuthorizationRequest{
+					{UserID: "hierarchy-user", Type: "authorisation", Status: "APPROVED"},
+				},
+			})
+			resp.Body.Close()
+			ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+		})
+	}
+
+	getReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/consents/%s?include=versions", testServerURL, revokedParentID), nil)
+	getReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	getReq.Header.Set(testutils.HeaderClientID, testClientID)
+	getResp, err := testutils.GetHTTPClient().Do(getReq)
+	ts.Require().NoError(err)
+	getResp.Body.Close()
+	ts.Equal(http.StatusBadRequest, getResp.StatusCode)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ============================
+// Consent ID Generation Tests
+// ============================
+
+// TestConsentID_UsesOrganizationPrefixAndSortsByCreation checks the prefixed ULIDs configured for
+// the test organization
+func (ts *ConsentAPITestSuite) TestConsentID_UsesOrganizationPrefixAndSortsByCreation() {
+	first := ts.createConsentOrFail(ConsentCreateRequest{Type: "accounts", Authorizations: []AuthorizationRequest{}})
+	time.Sleep(5 * time.Millisecond)
+	second := ts.createConsentOrFail(ConsentCreateRequest{Type: "accounts", Authorizations: []AuthorizationRequest{}})
+
+	for _, id := range []string{first, second} {
+		ts.True(strings.HasPrefix(id, "CONSENT-"), id)
+		ts.Len(strings.TrimPrefix(id, "CONSENT-"), 26, id)
+	}
+	ts.Less(first, second)
+
+	resp, body := ts.getConsent(second)
+	defer resp.Body.Close()
+	ts.Equal(http.StatusOK, resp.StatusCode, string(body))
+}
+
+// TestConsentID_InvalidPrefixedID_ReturnsBadRequest checks that prefixed IDs must end in a UUID or ULID
+func (ts *ConsentAPITestSuite) TestConsentID_InvalidPrefixedID_ReturnsBadRequest() {
+	resp, body := ts.getConsent("CONSENT-not-an-id")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
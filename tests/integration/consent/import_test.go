@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// POST /consents/import and GET /jobs/{jobId} Tests
+// ============================
+
+// submitImport submits an import file and returns response and body
+func (ts *ConsentAPITestSuite) submitImport(contentType string, file []byte) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consents/import", testServerURL)
+	httpReq, _ := http.NewRequest("POST", url, bytes.NewReader(file))
+	httpReq.Header.Set(testutils.HeaderContentType, contentType)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// getJob retrieves a job and returns response and body
+func (ts *ConsentAPITestSuite) getJob(jobID string) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/jobs/%s", testServerURL, jobID)
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// waitForImportJob polls an import job until it is no longer pending or running
+func (ts *ConsentAPITestSuite) waitForImportJob(jobID string) ImportJobResponse {
+	var job ImportJobResponse
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		resp, body := ts.getJob(jobID)
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+		ts.Require().NoError(json.Unmarshal(body, &job))
+
+		if job.Status != "PENDING" && job.Status != "RUNNING" {
+			return job
+		}
+		ts.Require().True(time.Now().Before(deadline), "import job did not finish, last status %s", job.Status)
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// TestImportConsents_CreatesConsentsAndRecordsRowErrors imports a file with valid and invalid lines
+func (ts *ConsentAPITestSuite) TestImportConsents_CreatesConsentsAndRecordsRowErrors() {
+	consentType := fmt.Sprintf("import-%d", time.Now().UnixNano())
+	lines := []string{
+		fmt.Sprintf(`{"type":%q,"consentPurpose":[{"name":"marketing-purpose","isUserApproved":true}],"authorizations":[{"userId":"user1","type":"auth","status":"APPROVED"}]}`, consentType),
+		fmt.Sprintf(`{"type":%q,"consentPurpose":[{"name":"no-such-purpose","isUserApproved":true}],"authorizations":[]}`, consentType),
+		"",
+		`{"type": "accounts",`,
+		fmt.Sprintf(`{"type":%q,"clientId":"legacy-client","attributes":{"source":"legacy"},"authorizations":[]}`, consentType),
+	}
+
+	resp, body := ts.submitImport("application/x-ndjson", []byte(strings.Join(lines, "\n")+"\n"))
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusAccepted, resp.StatusCode, string(body))
+
+	var submitted ImportJobResponse
+	ts.Require().NoError(json.Unmarshal(body, &submitted))
+	ts.Require().NotEmpty(submitted.ID)
+	ts.Equal("CONSENT_IMPORT", submitted.Type)
+	ts.Equal("/api/v1/jobs/"+submitted.ID, resp.Header.Get("Location"))
+
+	job := ts.waitForImportJob(submitted.ID)
+	ts.Equal("COMPLETED", job.Status)
+	ts.Equal(int64(5), job.ProcessedCount)
+	ts.Equal(int64(2), job.SucceededCount)
+	ts.Equal(int64(2), job.FailedCount)
+
+	ts.Require().Len(job.Errors.Data, 2)
+	ts.Equal(2, job.Errors.Metadata.Total)
+	ts.Equal(int64(2), job.Errors.Data[0].Line)
+	ts.Contains(job.Errors.Data[0].Message, "no-such-purpose")
+	ts.Equal(int64(4), job.Errors.Data[1].Line)
+	ts.Contains(job.Errors.Data[1].Message, "invalid JSON")
+
+	searchResp, searchBody := ts.listConsents(map[string]string{"consentTypes": consentType})
+	defer searchResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, searchResp.StatusCode, string(searchBody))
+
+	var search ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(searchBody, &search))
+	ts.Require().Len(search.Data, 2)
+
+	clientIDs := make([]string, 0, len(search.Data))
+	for _, consent := range search.Data {
+		ts.trackConsent(consent.ID)
+		clientIDs = append(clientIDs, consent.ClientID)
+	}
+	ts.ElementsMatch([]string{testClientID, "legacy-client"}, clientIDs)
+}
+
+// TestImportConsents_WrongContentType_ReturnsUnsupportedMediaType rejects files not sent as NDJSON
+func (ts *ConsentAPITestSuite) TestImportConsents_WrongContentType_ReturnsUnsupportedMediaType() {
+	resp, body := ts.submitImport("application/json", []byte(`{"type":"accounts","authorizations":[]}`))
+	defer resp.Body.Close()
+	ts.Equal(http.StatusUnsupportedMediaType, resp.StatusCode, string(body))
+}
+
+// TestImportConsents_EmptyFile_ReturnsBadRequest rejects an empty import file
+func (ts *ConsentAPITestSuite) TestImportConsents_EmptyFile_ReturnsBadRequest() {
+	resp, body := ts.submitImport("application/x-ndjson", nil)
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+// TestImportConsents_FileTooLarge_ReturnsRequestEntityTooLarge rejects files over the configured size
+func (ts *ConsentAPITestSuite) TestImportConsents_FileTooLarge_ReturnsRequestEntityTooLarge() {
+	line := `{"type":"accounts","authorizations":[]}` + "\n"
+	resp, body := ts.submitImport("application/x-ndjson", bytes.Repeat([]byte(line), 1048576/len(line)+1))
+	defer resp.Body.Close()
+	ts.Equal(http.StatusRequestEntityTooLarge, resp.StatusCode, string(body))
+}
+
+// TestGetJob_UnknownJob_ReturnsNotFound checks the response for a job that does not exist
+func (ts *ConsentAPITestSuite) TestGetJob_UnknownJob_ReturnsNotFound() {
+	resp, body := ts.getJob("00000000-0000-0000-0000-000000000000")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
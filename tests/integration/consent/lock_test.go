@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// headerConsentLockToken carries the token of a consent lock held by the caller
+const headerConsentLockToken = "Consent-Lock-Token"
+
+// sendWithLockToken sends a request for the test organization and client, presenting lockToken when set
+func (ts *ConsentAPITestSuite) sendWithLockToken(method, path, lockToken string, payload interface{}) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	httpReq, _ := http.NewRequest(method, testServerURL+path, reqBody)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+	if lockToken != "" {
+		httpReq.Header.Set(headerConsentLockToken, lockToken)
+	}
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// lockConsent locks a consent, presenting lockToken when set, and returns the lock
+func (ts *ConsentAPITestSuite) lockConsent(consentID, lockToken string) ConsentLockResponse {
+	resp, body := ts.sendWithLockToken("POST", fmt.Sprintf("/api/v1/consents/%s/lock", consentID), lockToken, nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var lock ConsentLockResponse
+	ts.Require().NoError(json.Unmarshal(body, &lock))
+	return lock
+}
+
+// unlockConsent releases the lock of a consent held with lockToken
+func (ts *ConsentAPITestSuite) unlockConsent(consentID, lockToken string) {
+	resp, body := ts.sendWithLockToken("POST", fmt.Sprintf("/api/v1/consents/%s/unlock", consentID), lockToken, nil)
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+}
+
+// patchAuthorizationStatus sets the status of an authorization, presenting lockToken when set
+func (ts *ConsentAPITestSuite) patchAuthorizationStatus(consentID, authID, status, lockToken string) (*http.Response, []byte) {
+	return ts.sendWithLockToken("PATCH", fmt.Sprintf("/api/v1/consents/%s/authorizations/%s", consentID, authID),
+		lockToken, AuthorizationPatchRequest{Status: status})
+}
+
+// TestConsentLock_RejectsChangesFromOtherCallers checks that only the lock holder can change a
+// locked consent and its authorizations until the lock is released
+func (ts *ConsentAPITestSuite) TestConsentLock_RejectsChangesFromOtherCallers() {
+	created := ts.createConsentWithCreatedAuthorization()
+	authID := created.Authorizations[0].ID
+
+	lock := ts.lockConsent(created.ID, "")
+	ts.NotEmpty(lock.LockToken)
+	ts.Equal(created.ID, lock.ConsentID)
+	ts.Equal(testClientID, lock.LockedBy)
+	ts.Greater(lock.ExpiryTime, lock.LockedTime)
+
+	resp, body := ts.patchAuthorizationStatus(created.ID, authID, "APPROVED", "")
+	ts.Equal(http.StatusLocked, resp.StatusCode, string(body))
+	ts.Contains(string(body), "CSE-4230")
+
+	resp, body = ts.revokeConsent(created.ID, "revoked while locked")
+	resp.Body.Close()
+	ts.Equal(http.StatusLocked, resp.StatusCode, string(body))
+
+	resp, body = ts.patchAuthorizationStatus(created.ID, authID, "APPROVED", lock.LockToken)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Equal("ACTIVE", ts.consentStatus(created.ID))
+
+	resp, body = ts.sendWithLockToken("POST", fmt.Sprintf("/api/v1/consents/%s/unlock", created.ID), "", nil)
+	ts.Equal(http.StatusLocked, resp.StatusCode, string(body))
+
+	ts.unlockConsent(created.ID, lock.LockToken)
+
+	resp, body = ts.revokeConsent(created.ID, "revoked after unlock")
+	resp.Body.Close()
+	ts.Equal(http.StatusOK, resp.StatusCode, string(body))
+}
+
+// TestConsentLock_OnlyHolderCanRenew checks that a locked consent cannot be locked by another
+// caller, while the holder renews the lock with its token
+func (ts *ConsentAPITestSuite) TestConsentLock_OnlyHolderCanRenew() {
+	created := ts.createConsentWithCreatedAuthorization()
+	lock := ts.lockConsent(created.ID, "")
+
+	resp, body := ts.sendWithLockToken("POST", fmt.Sprintf("/api/v1/consents/%s/lock", created.ID), "", nil)
+	ts.Equal(http.StatusLocked, resp.StatusCode, string(body))
+
+	renewed := ts.lockConsent(created.ID, lock.LockToken)
+	ts.Equal(lock.LockToken, renewed.LockToken)
+	ts.GreaterOrEqual(renewed.ExpiryTime, lock.ExpiryTime)
+
+	ts.unlockConsent(created.ID, renewed.LockToken)
+
+	// Both locks, the rejected lock and the unlock are in the operation audit
+	operations := ts.listConsentOperations(created.ID, url.Values{"action": {"consent.lock,consent.unlock"}})
+	outcomes := map[string][]string{}
+	for _, operation := range operations.Data {
+		outcomes[operation.Action] = append(outcomes[operation.Action], operation.Outcome)
+	}
+	ts.ElementsMatch([]string{"SUCCESS", "FAILURE", "SUCCESS"}, outcomes["consent.lock"])
+	ts.Equal([]string{"SUCCESS"}, outcomes["consent.unlock"])
+}
+
+// TestConsentLock_Expires checks that a lock stops blocking changes once its TTL has passed
+func (ts *ConsentAPITestSuite) TestConsentLock_Expires() {
+	created := ts.createConsentWithCreatedAuthorization()
+	lock := ts.lockConsent(created.ID, "")
+
+	// The integration test configuration holds locks for 2 seconds
+	time.Sleep(time.Until(time.UnixMilli(lock.ExpiryTime)) + 100*time.Millisecond)
+
+	resp, body := ts.patchAuthorizationStatus(created.ID, created.Authorizations[0].ID, "APPROVED", "")
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	newLock := ts.lockConsent(created.ID, "")
+	ts.NotEqual(lock.LockToken, newLock.LockToken)
+
+	ts.unlockConsent(created.ID, newLock.LockToken)
+}
+
+// TestConsentLock_UnknownConsent checks that an unknown consent cannot be locked
+func (ts *ConsentAPITestSuite) TestConsentLock_UnknownConsent() {
+	resp, body := ts.sendWithLockToken("POST", "/api/v1/consents/00000000-0000-0000-0000-000000000000/lock", "", nil)
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
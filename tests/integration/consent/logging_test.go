@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// /admin/logging/level Tests
+// ============================
+
+// logLevelRequest calls the admin logging API for the server level, or for a module when module
+// is not empty. payload is sent as the body when it is not nil.
+func (ts *ConsentAPITestSuite) logLevelRequest(method, module string, payload interface{}, withAdminAuth bool) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	url := testServerURL + "/api/v1/admin/logging/level"
+	if module != "" {
+		url += "/" + module
+	}
+	httpReq, _ := http.NewRequest(method, url, reqBody)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	if withAdminAuth {
+		httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// getLogLevels reads the current log levels
+func (ts *ConsentAPITestSuite) getLogLevels() LogLevelResponse {
+	resp, body := ts.logLevelRequest("GET", "", nil, true)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var levels LogLevelResponse
+	ts.Require().NoError(json.Unmarshal(body, &levels))
+	return levels
+}
+
+// TestLogLevel_Set_ChangesServerLevel changes the server log level and restores it
+func (ts *ConsentAPITestSuite) TestLogLevel_Set_ChangesServerLevel() {
+	original := ts.getLogLevels().Level
+	defer ts.logLevelRequest("PUT", "", map[string]string{"level": original}, true)
+
+	resp, body := ts.logLevelRequest("PUT", "", map[string]string{"level": "warn"}, true)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var updated LogLevelResponse
+	ts.Require().NoError(json.Unmarshal(body, &updated))
+	ts.Equal("warn", updated.Level)
+	ts.Equal("warn", ts.getLogLevels().Level)
+}
+
+// TestLogLevel_Module_SetAndClear sets the level of a module and returns it to the server level
+func (ts *ConsentAPITestSuite) TestLogLevel_Module_SetAndClear() {
+	resp, body := ts.logLevelRequest("PUT", "DBClient", map[string]string{"level": "DEBUG"}, true)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var updated LogLevelResponse
+	ts.Require().NoError(json.Unmarshal(body, &updated))
+	ts.Equal("debug", updated.Modules["DBClient"])
+
+	resp, body = ts.logLevelRequest("DELETE", "DBClient", nil, true)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.NotContains(ts.getLogLevels().Modules, "DBClient")
+}
+
+// TestLogLevel_InvalidRequests_AreRejected checks level validation and admin authentication
+func (ts *ConsentAPITestSuite) TestLogLevel_InvalidRequests_AreRejected() {
+	resp, body := ts.logLevelRequest("PUT", "", map[string]string{"level": "verbose"}, true)
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = ts.logLevelRequest("PUT", "DBClient", map[string]string{}, true)
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = ts.logLevelRequest("PUT", "", map[string]string{"level": "debug"}, false)
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode, string(body))
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package consent
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// /admin/migrate Tests
+// ============================
+
+// migrateRequest calls the admin migrate API
+func (ts *ConsentAPITestSuite) migrateRequest(query string, withAdminAuth bool) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("POST", testServerURL+"/api/v1/admin/migrate"+query, nil)
+	if withAdminAuth {
+		httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestMigrate_DryRun_ReportsUpToDateSchema checks that the schema migrated on start up has nothing pending
+func (ts *ConsentAPITestSuite) TestMigrate_DryRun_ReportsUpToDateSchema() {
+	resp, body := ts.migrateRequest("?dryRun=true", true)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result MigrationResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.True(result.DryRun)
+	ts.Empty(result.Migrations)
+	ts.Positive(result.TargetVersion)
+	ts.Equal(result.TargetVersion, result.CurrentVersion)
+}
+
+// TestMigrate_AppliesNothingOnMigratedSchema checks that migrating an up to date schema is a no-op
+func (ts *ConsentAPITestSuite) TestMigrate_AppliesNothingOnMigratedSchema() {
+	resp, body := ts.migrateRequest("", true)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result MigrationResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.False(result.DryRun)
+	ts.Empty(result.Migrations)
+	ts.Equal(result.TargetVersion, result.CurrentVersion)
+}
+
+// TestMigrate_InvalidDryRun checks that a non-boolean dryRun is rejected
+func (ts *ConsentAPITestSuite) TestMigrate_InvalidDryRun() {
+	resp, body := ts.migrateRequest("?dryRun=maybe", true)
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+// TestMigrate_RequiresAdminAuth checks that the migrate API is only open to administrators
+func (ts *ConsentAPITestSuite) TestMigrate_RequiresAdminAuth() {
+	resp, _ := ts.migrateRequest("?dryRun=true", false)
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode)
+}
@@ -1,11 +1,15 @@
 package consent
 
+import "encoding/json"
+
 // ConsentPurposeItem represents a consent purpose in the request/response
 type ConsentPurposeItem struct {
-	Name           string      `json:"name"`
-	Value          interface{} `json:"value,omitempty"`
-	IsUserApproved bool        `json:"isUserApproved"`
-	IsMandatory    bool        `json:"isMandatory"`
+	Name            string      `json:"name"`
+	Value           interface{} `json:"value,omitempty"`
+	IsUserApproved  bool        `json:"isUserApproved"`
+	IsMandatory     bool        `json:"isMandatory"`
+	ExpiresAt       *int64      `json:"expiresAt,omitempty"`
+	LastConfirmedAt *int64      `json:"lastConfirmedAt,omitempty"`
 }
 
 // AuthorizationRequest represents authorization data in consent creation/update
@@ -16,28 +20,34 @@ type AuthorizationRequest struct {
 	Resources      []string `json:"resources,omitempty"`
 	Permissions    []string `json:"permissions,omitempty"`
 	ExpirationDate string   `json:"expirationDate,omitempty"`
+	DelegatorID    string   `json:"delegatorId,omitempty"`
+	DelegateID     string   `json:"delegateId,omitempty"`
 }
 
 // ConsentCreateRequest represents the payload for creating a consent
 type ConsentCreateRequest struct {
-	Type               string                 `json:"type"`
-	ConsentPurpose     []ConsentPurposeItem   `json:"consentPurpose,omitempty"`
-	Authorizations     []AuthorizationRequest `json:"authorizations"`
-	Attributes         map[string]string      `json:"attributes,omitempty"`
-	ValidityTime       int64                  `json:"validityTime,omitempty"`
-	RecurringIndicator bool                   `json:"recurringIndicator,omitempty"`
-	Frequency          int                    `json:"frequency,omitempty"`
+	Type                string                  `json:"type"`
+	ConsentPurpose      []ConsentPurposeItem    `json:"consentPurpose,omitempty"`
+	Authorizations      []AuthorizationRequest  `json:"authorizations"`
+	Attributes          map[string]string       `json:"attributes,omitempty"`
+	ValidityTime        int64                   `json:"validityTime,omitempty"`
+	RecurringIndicator  bool                    `json:"recurringIndicator,omitempty"`
+	Frequency           int                     `json:"frequency,omitempty"`
+	ParentConsentID     string                  `json:"parentConsentId,omitempty"`
+	RequiredAuthorizers []string                `json:"requiredAuthorizers,omitempty"`
+	Evidence            *ConsentEvidenceRequest `json:"evidence,omitempty"`
 }
 
 // ConsentUpdateRequest represents the payload for updating a consent
 type ConsentUpdateRequest struct {
-	Type               string                 `json:"type,omitempty"`
-	ConsentPurpose     []ConsentPurposeItem   `json:"consentPurpose"` // Remove omitempty to allow empty arrays for removal
-	Authorizations     []AuthorizationRequest `json:"authorizations"` // Remove omitempty to allow empty arrays for removal
-	Attributes         map[string]string      `json:"attributes"`     // Remove omitempty to allow empty maps for removal
-	ValidityTime       *int64                 `json:"validityTime,omitempty"`
-	RecurringIndicator *bool                  `json:"recurringIndicator,omitempty"`
-	Frequency          *int                   `json:"frequency,omitempty"`
+	Type               string                  `json:"type,omitempty"`
+	ConsentPurpose     []ConsentPurposeItem    `json:"consentPurpose"` // Remove omitempty to allow empty arrays for removal
+	Authorizations     []AuthorizationRequest  `json:"authorizations"` // Remove omitempty to allow empty arrays for removal
+	Attributes         map[string]string       `json:"attributes"`     // Remove omitempty to allow empty maps for removal
+	ValidityTime       *int64                  `json:"validityTime,omitempty"`
+	RecurringIndicator *bool                   `json:"recurringIndicator,omitempty"`
+	Frequency          *int                    `json:"frequency,omitempty"`
+	Evidence           *ConsentEvidenceRequest `json:"evidence,omitempty"`
 }
 
 // ConsentRevokeRequest represents the payload for revoking a consent
@@ -54,6 +64,8 @@ type AuthorizationResponse struct {
 	Status      string      `json:"status"`
 	UpdatedTime int64       `json:"updatedTime"`
 	Resources   interface{} `json:"resources,omitempty"`
+	DelegatorID *string     `json:"delegatorId,omitempty"`
+	DelegateID  *string     `json:"delegateId,omitempty"`
 }
 
 // ConsentResponse represents the API response for a consent
@@ -65,40 +77,53 @@ type ConsentResponse struct {
 	ConsentPurpose             []ConsentPurposeItem    `json:"consentPurpose"`
 	Authorizations             []AuthorizationResponse `json:"authorizations"`
 	Attributes                 map[string]string       `json:"attributes"`
+	Tags                       []string                `json:"tags,omitempty"`
 	ValidityTime               *int64                  `json:"validityTime,omitempty"`
 	RecurringIndicator         *bool                   `json:"recurringIndicator,omitempty"`
 	Frequency                  *int                    `json:"frequency,omitempty"`
 	DataAccessValidityDuration *int64                  `json:"dataAccessValidityDuration,omitempty"`
+	ParentConsentID            *string                 `json:"parentConsentId,omitempty"`
+	Version                    int                     `json:"version"`
 	CreatedTime                int64                   `json:"createdTime"`
 	UpdatedTime                int64                   `json:"updatedTime"`
+	Children                   []ConsentResponse       `json:"children,omitempty"`
 }
 
 // ConsentListResponse represents the API response for listing consents
 type ConsentListResponse struct {
 	Data []ConsentResponse `json:"data"`
 	Meta struct {
-		Total  int `json:"total"`
-		Offset int `json:"offset"`
-		Limit  int `json:"limit"`
-	} `json:"meta"`
+		Total      int    `json:"total"`
+		Offset     int    `json:"offset"`
+		Limit      int    `json:"limit"`
+		Count      int    `json:"count"`
+		NextCursor string `json:"nextCursor,omitempty"`
+		HasMore    bool   `json:"hasMore"`
+	} `json:"metadata"`
 }
 
-// ErrorResponse represents error responses from the API
+// ErrorResponse represents RFC 7807 problem responses from the API
 type ErrorResponse struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Details string `json:"details,omitempty"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+	TraceID  string `json:"traceId"`
 }
 
 // ConsentValidateRequest represents the payload for validating a consent
 type ConsentValidateRequest struct {
-	Headers         map[string]interface{} `json:"headers,omitempty"`
-	Payload         map[string]interface{} `json:"payload,omitempty"`
-	ElectedResource string                 `json:"electedResource,omitempty"`
-	ConsentID       string                 `json:"consentId"`
-	UserID          string                 `json:"userId,omitempty"`
-	ClientID        string                 `json:"clientId,omitempty"`
-	ResourceParams  *struct {
+	Headers            map[string]interface{} `json:"headers,omitempty"`
+	Payload            map[string]interface{} `json:"payload,omitempty"`
+	ElectedResource    string                 `json:"electedResource,omitempty"`
+	ConsentID          string                 `json:"consentId"`
+	UserID             string                 `json:"userId,omitempty"`
+	ClientID           string                 `json:"clientId,omitempty"`
+	RequestedPurposes  []string               `json:"requestedPurposes,omitempty"`
+	RequestedResources []string               `json:"requestedResources,omitempty"`
+	ResourceParams     *struct {
 		Resource   string `json:"resource,omitempty"`
 		HTTPMethod string `json:"httpMethod,omitempty"`
 		Context    string `json:"context,omitempty"`
@@ -107,12 +132,28 @@ type ConsentValidateRequest struct {
 
 // ConsentValidateResponse represents the API response for consent validation
 type ConsentValidateResponse struct {
-	IsValid            bool                   `json:"isValid"`
-	ModifiedPayload    interface{}            `json:"modifiedPayload,omitempty"`
-	ErrorCode          int                    `json:"errorCode,omitempty"`
-	ErrorMessage       string                 `json:"errorMessage,omitempty"`
-	ErrorDescription   string                 `json:"errorDescription,omitempty"`
-	ConsentInformation *ConsentValidateDetail `json:"consentInformation,omitempty"`
+	IsValid            bool                        `json:"isValid"`
+	ModifiedPayload    interface{}                 `json:"modifiedPayload,omitempty"`
+	ErrorCode          int                         `json:"errorCode,omitempty"`
+	ErrorMessage       string                      `json:"errorMessage,omitempty"`
+	ErrorDescription   string                      `json:"errorDescription,omitempty"`
+	StalePurposes      []string                    `json:"stalePurposes,omitempty"`
+	PurposeDecisions   map[string]ValidateDecision `json:"purposeDecisions,omitempty"`
+	ResourceDecisions  map[string]ValidateDecision `json:"resourceDecisions,omitempty"`
+	DataAccessWindow   *DataAccessWindow           `json:"dataAccessWindow,omitempty"`
+	ConsentInformation *ConsentValidateDetail      `json:"consentInformation,omitempty"`
+}
+
+// DataAccessWindow represents the data access window of a consent in the validate response
+type DataAccessWindow struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// ValidateDecision represents the decision for a requested purpose or resource
+type ValidateDecision struct {
+	Decision string `json:"decision"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 // ConsentValidateDetail represents consent information in validate response
@@ -131,3 +172,536 @@ type ConsentValidateDetail struct {
 	Frequency                  *int                    `json:"frequency,omitempty"`
 	DataAccessValidityDuration *int64                  `json:"dataAccessValidityDuration,omitempty"`
 }
+
+// RelationshipResponse represents the API response for the user/client relationship summary
+type RelationshipResponse struct {
+	UserID           string            `json:"userId"`
+	ClientID         string            `json:"clientId"`
+	ActiveConsents   []ConsentResponse `json:"activeConsents"`
+	ApprovedPurposes []string          `json:"approvedPurposes"`
+	EarliestExpiry   *int64            `json:"earliestExpiry,omitempty"`
+	LastActivityTime *int64            `json:"lastActivityTime,omitempty"`
+	TotalConsents    int               `json:"totalConsents"`
+}
+
+// ConsentAmendmentRequest represents the payload for amending a consent
+type ConsentAmendmentRequest struct {
+	ConsentUpdateRequest
+	AmendedBy string `json:"amendedBy,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ConsentVersionListResponse represents the API response for listing consent versions
+type ConsentVersionListResponse struct {
+	ConsentID      string `json:"consentId"`
+	CurrentVersion int    `json:"currentVersion"`
+	Data           []struct {
+		Version     int    `json:"version"`
+		AmendedTime int64  `json:"amendedTime"`
+		AmendedBy   string `json:"amendedBy,omitempty"`
+		Reason      string `json:"reason,omitempty"`
+	} `json:"data"`
+}
+
+// ConsentVersionResponse represents the API response for a single consent version
+type ConsentVersionResponse struct {
+	Version     int             `json:"version"`
+	Current     bool            `json:"current"`
+	AmendedTime *int64          `json:"amendedTime,omitempty"`
+	AmendedBy   string          `json:"amendedBy,omitempty"`
+	Reason      string          `json:"reason,omitempty"`
+	Consent     ConsentResponse `json:"consent"`
+}
+
+// AuthorizationPatchRequest represents the payload for partially updating an authorization
+type AuthorizationPatchRequest struct {
+	Status    string      `json:"status,omitempty"`
+	UserID    *string     `json:"userId,omitempty"`
+	Resources interface{} `json:"resources,omitempty"`
+}
+
+// UserConsentResponse represents a consent in the user consent listing
+type UserConsentResponse struct {
+	ConsentResponse
+	UserApprovalStatus string                  `json:"userApprovalStatus"`
+	UserAuthorizations []AuthorizationResponse `json:"userAuthorizations"`
+}
+
+// UserConsentListResponse represents the response of GET /users/{userId}/consents
+type UserConsentListResponse struct {
+	UserID string                `json:"userId"`
+	Data   []UserConsentResponse `json:"data"`
+	Meta   struct {
+		Total   int  `json:"total"`
+		Count   int  `json:"count"`
+		HasMore bool `json:"hasMore"`
+	} `json:"metadata"`
+}
+
+// ConsentEvidenceRequest represents the evidence of how a consent was collected
+type ConsentEvidenceRequest struct {
+	Channel               string `json:"channel"`
+	IPAddress             string `json:"ipAddress,omitempty"`
+	UserAgent             string `json:"userAgent,omitempty"`
+	UIVersion             string `json:"uiVersion,omitempty"`
+	PresentedTextChecksum string `json:"presentedTextChecksum,omitempty"`
+}
+
+// ConsentEvidence represents evidence recorded for a version of a consent
+type ConsentEvidence struct {
+	ID                    string `json:"id"`
+	ConsentID             string `json:"consentId"`
+	ConsentVersion        int    `json:"consentVersion"`
+	Channel               string `json:"channel"`
+	IPAddress             string `json:"ipAddress"`
+	UserAgent             string `json:"userAgent"`
+	UIVersion             string `json:"uiVersion"`
+	PresentedTextChecksum string `json:"presentedTextChecksum"`
+	CapturedTime          int64  `json:"capturedTime"`
+}
+
+// AttributeSchemaResponse represents the API response for an organization's attribute schema
+type AttributeSchemaResponse struct {
+	OrgID      string `json:"orgId"`
+	Attributes []struct {
+		Key                  string   `json:"key"`
+		Type                 string   `json:"type"`
+		Pattern              string   `json:"pattern"`
+		Required             bool     `json:"required"`
+		RequiredConsentTypes []string `json:"requiredConsentTypes"`
+	} `json:"attributes"`
+	CreatedTime int64 `json:"createdTime"`
+	UpdatedTime int64 `json:"updatedTime"`
+}
+
+// ConsentAttributeSearchResponse represents the consent IDs returned by an attribute search
+type ConsentAttributeSearchResponse struct {
+	ConsentIDs []string `json:"consentIds"`
+	Count      int      `json:"count"`
+}
+
+// StatusAuditEntry represents a consent status change in the status audit
+type StatusAuditEntry struct {
+	StatusAuditID  string `json:"statusAuditId"`
+	ConsentID      string `json:"consentId"`
+	CurrentStatus  string `json:"currentStatus"`
+	PreviousStatus string `json:"previousStatus"`
+	ActionTime     int64  `json:"actionTime"`
+	ActionBy       string `json:"actionBy"`
+	Reason         string `json:"reason"`
+	OrgID          string `json:"orgId"`
+}
+
+// StatusAuditSearchResponse represents a page of status audit entries
+type StatusAuditSearchResponse struct {
+	Data     []StatusAuditEntry `json:"data"`
+	Metadata struct {
+		Total   int  `json:"total"`
+		Limit   int  `json:"limit"`
+		Offset  int  `json:"offset"`
+		Count   int  `json:"count"`
+		HasMore bool `json:"hasMore"`
+	} `json:"metadata"`
+}
+
+// AuthStatusAuditResponse represents a status change of an authorization
+type AuthStatusAuditResponse struct {
+	StatusAuditID   string  `json:"statusAuditId"`
+	AuthorizationID string  `json:"authorizationId"`
+	ConsentID       string  `json:"consentId"`
+	CurrentStatus   string  `json:"currentStatus"`
+	PreviousStatus  *string `json:"previousStatus"`
+	ActionTime      int64   `json:"actionTime"`
+}
+
+// AuthorizationSearchItem represents an authorization found by the admin search
+type AuthorizationSearchItem struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	UserID    string `json:"userId"`
+	Status    string `json:"status"`
+	ConsentID string `json:"consentId"`
+	OrgID     string `json:"orgId"`
+}
+
+// AuthorizationSearchResponse represents a page of the admin authorization search
+type AuthorizationSearchResponse struct {
+	Data     []AuthorizationSearchItem `json:"data"`
+	Metadata struct {
+		Total   int  `json:"total"`
+		Count   int  `json:"count"`
+		HasMore bool `json:"hasMore"`
+	} `json:"metadata"`
+}
+
+// BootstrapChange is a change reported by POST /admin/bootstrap
+type BootstrapChange struct {
+	Kind   string `json:"kind"`
+	OrgID  string `json:"orgId"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	Error  string `json:"error"`
+}
+
+// BootstrapResponse is the response of POST /admin/bootstrap
+type BootstrapResponse struct {
+	DryRun  bool              `json:"dryRun"`
+	Changes []BootstrapChange `json:"changes"`
+	Summary struct {
+		Created   int `json:"created"`
+		Updated   int `json:"updated"`
+		Deleted   int `json:"deleted"`
+		Unchanged int `json:"unchanged"`
+		Failed    int `json:"failed"`
+	} `json:"summary"`
+}
+
+// DatabasePoolResponse represents the connection pool gauges returned by the admin API
+type DatabasePoolResponse struct {
+	Consent struct {
+		MaxOpenConnections int   `json:"maxOpenConnections"`
+		MaxIdleConnections int   `json:"maxIdleConnections"`
+		OpenConnections    int   `json:"openConnections"`
+		InUse              int   `json:"inUse"`
+		Idle               int   `json:"idle"`
+		WaitCount          int64 `json:"waitCount"`
+	} `json:"consent"`
+}
+
+// UserErasureResponse represents the API response for a right-to-erasure request
+type UserErasureResponse struct {
+	Report struct {
+		ErasureID  string `json:"erasureId"`
+		OrgID      string `json:"orgId"`
+		UserIDHash string `json:"userIdHash"`
+		Pseudonym  string `json:"pseudonym"`
+		ErasedTime int64  `json:"erasedTime"`
+		Consents   []struct {
+			ConsentID         string   `json:"consentId"`
+			AuthorizationIDs  []string `json:"authorizationIds"`
+			RemovedAttributes []string `json:"removedAttributes"`
+		} `json:"consents"`
+	} `json:"report"`
+	JWS string `json:"jws"`
+}
+
+// ErrorCodeDefinition represents an entry of the error code catalog
+type ErrorCodeDefinition struct {
+	Code        string `json:"code"`
+	Title       string `json:"title"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+// exportRecord mirrors a single NDJSON export line
+type exportRecord struct {
+	ConsentID             string            `json:"consentId"`
+	ConsentType           string            `json:"consentType"`
+	CurrentStatus         string            `json:"currentStatus"`
+	Purposes              []string          `json:"purposes"`
+	ApprovedPurposes      []string          `json:"approvedPurposes"`
+	UserIDs               []string          `json:"userIds"`
+	AuthorizationStatuses []string          `json:"authorizationStatuses"`
+	Attributes            map[string]string `json:"attributes"`
+}
+
+// ConsentFileResponse represents the metadata of a file attached to a consent
+type ConsentFileResponse struct {
+	ID          string `json:"id"`
+	ConsentID   string `json:"consentId"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	CreatedTime int64  `json:"createdTime"`
+}
+
+// ConsentBatchGetResponse represents the API response for a batch consent retrieval
+type ConsentBatchGetResponse struct {
+	Data     []ConsentResponse `json:"data"`
+	NotFound []string          `json:"notFound"`
+}
+
+// ImportJobResponse mirrors an import job with a page of its row errors
+type ImportJobResponse struct {
+	ID             string `json:"id"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	ProcessedCount int64  `json:"processedCount"`
+	SucceededCount int64  `json:"succeededCount"`
+	FailedCount    int64  `json:"failedCount"`
+	Errors         struct {
+		Data []struct {
+			Line    int64  `json:"line"`
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"data"`
+		Metadata struct {
+			Total int `json:"total"`
+		} `json:"metadata"`
+	} `json:"errors"`
+}
+
+// ConsentLockResponse represents a consent lock
+type ConsentLockResponse struct {
+	ConsentID  string `json:"consentId"`
+	LockToken  string `json:"lockToken"`
+	LockedBy   string `json:"lockedBy"`
+	LockedTime int64  `json:"lockedTime"`
+	ExpiryTime int64  `json:"expiryTime"`
+}
+
+// LogLevelResponse represents the log levels returned by the admin logging API
+type LogLevelResponse struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+// MigrationResponse represents the result of the admin migrate API
+type MigrationResponse struct {
+	DryRun         bool `json:"dryRun"`
+	CurrentVersion int  `json:"currentVersion"`
+	TargetVersion  int  `json:"targetVersion"`
+	Migrations     []struct {
+		Version int    `json:"version"`
+		Name    string `json:"name"`
+	} `json:"migrations"`
+}
+
+// consentWithModifiedResponse is a consent response with its modifiedResponse
+type consentWithModifiedResponse struct {
+	ConsentResponse
+	ModifiedResponse map[string]interface{} `json:"modifiedResponse"`
+}
+
+// ConsentNote represents a consent note in API responses
+type ConsentNote struct {
+	ID          string `json:"id"`
+	ConsentID   string `json:"consentId"`
+	Author      string `json:"author"`
+	Text        string `json:"text"`
+	CreatedTime int64  `json:"createdTime"`
+}
+
+// ConsentNoteListResponse represents the API response for listing consent notes
+type ConsentNoteListResponse struct {
+	Data []ConsentNote `json:"data"`
+}
+
+// OperationAuditEntry represents an audited operation on a consent
+type OperationAuditEntry struct {
+	OperationID string          `json:"operationId"`
+	ConsentID   string          `json:"consentId"`
+	Action      string          `json:"action"`
+	Actor       string          `json:"actor"`
+	ClientID    string          `json:"clientId"`
+	Method      string          `json:"method"`
+	Route       string          `json:"route"`
+	StatusCode  int             `json:"statusCode"`
+	Outcome     string          `json:"outcome"`
+	Details     json.RawMessage `json:"details"`
+	ActionTime  int64           `json:"actionTime"`
+	OrgID       string          `json:"orgId"`
+}
+
+// OperationListResponse represents a page of audited operations
+type OperationListResponse struct {
+	Data     []OperationAuditEntry `json:"data"`
+	Metadata struct {
+		Total   int  `json:"total"`
+		Count   int  `json:"count"`
+		HasMore bool `json:"hasMore"`
+	} `json:"metadata"`
+}
+
+// OrganizationResponse represents the API response for an organization
+type OrganizationResponse struct {
+	OrgID          string            `json:"orgId"`
+	Name           string            `json:"name"`
+	StatusMappings map[string]string `json:"statusMappings"`
+	WebhookURLs    []string          `json:"webhookUrls"`
+	RetentionDays  *int              `json:"retentionDays"`
+	CreatedTime    int64             `json:"createdTime"`
+	UpdatedTime    int64             `json:"updatedTime"`
+}
+
+// OwnershipTransferResponse represents the API response for a consent ownership transfer
+type OwnershipTransferResponse struct {
+	FromUserID  string `json:"fromUserId"`
+	ToUserID    string `json:"toUserId"`
+	Transferred []struct {
+		ConsentID        string   `json:"consentId"`
+		Version          int      `json:"version"`
+		AuthorizationIDs []string `json:"authorizationIds"`
+	} `json:"transferred"`
+	Skipped []struct {
+		ConsentID string `json:"consentId"`
+		Status    string `json:"status"`
+	} `json:"skipped"`
+}
+
+// UserPreferencesResponse represents the purpose preferences of a user
+type UserPreferencesResponse struct {
+	UserID      string `json:"userId"`
+	Preferences []struct {
+		PurposeName     string `json:"purposeName"`
+		State           string `json:"state"`
+		IsMandatory     bool   `json:"isMandatory"`
+		LastConfirmedAt *int64 `json:"lastConfirmedAt"`
+		Consents        []struct {
+			ConsentID string `json:"consentId"`
+			OptedIn   bool   `json:"optedIn"`
+		} `json:"consents"`
+	} `json:"preferences"`
+}
+
+// ReauthorizationResponse represents the API response for a consent re-authorization
+type ReauthorizationResponse struct {
+	ConsentID            string `json:"consentId"`
+	PreviousStatus       string `json:"previousStatus"`
+	Status               string `json:"status"`
+	AuthorizationStatus  string `json:"authorizationStatus"`
+	ValidityTime         int64  `json:"validityTime"`
+	PreviousValidityTime *int64 `json:"previousValidityTime"`
+	Reason               string `json:"reason"`
+	ActionBy             string `json:"actionBy"`
+}
+
+// ConsentReceiptResponse represents the API response for a consent receipt
+type ConsentReceiptResponse struct {
+	Receipt struct {
+		Version          string `json:"version"`
+		Jurisdiction     string `json:"jurisdiction"`
+		ConsentTimestamp int64  `json:"consentTimestamp"`
+		CollectionMethod string `json:"collectionMethod"`
+		ConsentReceiptID string `json:"consentReceiptID"`
+		PublicKey        string `json:"publicKey"`
+		PiiPrincipalID   string `json:"piiPrincipalId"`
+		PiiControllers   []struct {
+			PiiController string `json:"piiController"`
+			Email         string `json:"email"`
+		} `json:"piiControllers"`
+		Services []struct {
+			Service  string `json:"service"`
+			Purposes []struct {
+				Purpose        string `json:"purpose"`
+				ConsentType    string `json:"consentType"`
+				PrimaryPurpose bool   `json:"primaryPurpose"`
+				ThirdPartyName string `json:"thirdPartyName"`
+			} `json:"purposes"`
+		} `json:"services"`
+		ConsentID      string `json:"consentId"`
+		ConsentVersion int    `json:"consentVersion"`
+		ConsentStatus  string `json:"consentStatus"`
+	} `json:"receipt"`
+	JWS string `json:"jws"`
+}
+
+// RequiredAuthorizer represents a required authorizer role of a consent
+type RequiredAuthorizer struct {
+	Role             string   `json:"role"`
+	Status           string   `json:"status"`
+	AuthorizationIDs []string `json:"authorizationIds"`
+}
+
+// RequiredAuthorizersResponse represents the required authorizers still to approve a consent
+type RequiredAuthorizersResponse struct {
+	ConsentID     string               `json:"consentId"`
+	ConsentStatus string               `json:"consentStatus"`
+	Authorizers   []RequiredAuthorizer `json:"authorizers"`
+	Pending       []string             `json:"pending"`
+}
+
+// ResourceSchemaResponse represents the API response for a resources schema
+type ResourceSchemaResponse struct {
+	OrgID       string                 `json:"orgId"`
+	AuthType    string                 `json:"authType"`
+	Schema      map[string]interface{} `json:"schema"`
+	CreatedTime int64                  `json:"createdTime"`
+	UpdatedTime int64                  `json:"updatedTime"`
+}
+
+// ConsentRevokedResponse is the response of a revocation
+type ConsentRevokedResponse struct {
+	ConsentStatus           string   `json:"consentStatus"`
+	RevokedPurposes         []string `json:"revokedPurposes"`
+	RevokedAuthorizationIDs []string `json:"revokedAuthorizationIds"`
+}
+
+// ScheduledRevocationResponse is a revocation scheduled for a future time
+type ScheduledRevocationResponse struct {
+	ConsentID        string `json:"consentId"`
+	EffectiveAt      int64  `json:"effectiveAt"`
+	ActionBy         string `json:"actionBy"`
+	RevocationReason string `json:"revocationReason"`
+	ScheduledTime    int64  `json:"scheduledTime"`
+}
+
+// StatusOverrideResponse represents the API response for a consent status override
+type StatusOverrideResponse struct {
+	ConsentID           string `json:"consentId"`
+	PreviousStatus      string `json:"previousStatus"`
+	Status              string `json:"status"`
+	AuthorizationStatus string `json:"authorizationStatus"`
+	Reason              string `json:"reason"`
+	ActionBy            string `json:"actionBy"`
+}
+
+// SuspensionResponse represents the API response for a consent suspend or resume
+type SuspensionResponse struct {
+	ConsentID      string `json:"consentId"`
+	PreviousStatus string `json:"previousStatus"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason"`
+	ActionBy       string `json:"actionBy"`
+}
+
+// ConsentTagsResponse represents the API response for adding tags to a consent
+type ConsentTagsResponse struct {
+	ConsentID string   `json:"consentId"`
+	Tags      []string `json:"tags"`
+}
+
+// ConsentTokenRequest represents the request body for binding a token to a consent
+type ConsentTokenRequest struct {
+	TokenID   string `json:"tokenId"`
+	TokenType string `json:"tokenType"`
+	ExpiresAt *int64 `json:"expiresAt,omitempty"`
+}
+
+// ConsentToken represents a token bound to a consent
+type ConsentToken struct {
+	TokenID     string `json:"tokenId"`
+	ConsentID   string `json:"consentId"`
+	TokenType   string `json:"tokenType"`
+	ExpiresAt   *int64 `json:"expiresAt"`
+	CreatedTime int64  `json:"createdTime"`
+}
+
+// ConsentUsageResponse represents the usage of a consent in its current frequency period
+type ConsentUsageResponse struct {
+	ConsentID          string `json:"consentId"`
+	Frequency          *int   `json:"frequency"`
+	RecurringIndicator bool   `json:"recurringIndicator"`
+	PeriodStart        int64  `json:"periodStart"`
+	PeriodEnd          *int64 `json:"periodEnd"`
+	AccessCount        int    `json:"accessCount"`
+	Remaining          *int   `json:"remaining"`
+	LastAccessTime     *int64 `json:"lastAccessTime"`
+}
+
+// UserConsentExportResponse represents the JSON bundle of a data subject access request export
+type UserConsentExportResponse struct {
+	UserID        string `json:"userId"`
+	OrgID         string `json:"orgId"`
+	GeneratedTime int64  `json:"generatedTime"`
+	Consents      []struct {
+		ConsentResponse
+		UserApprovalStatus string `json:"userApprovalStatus"`
+		History            []struct {
+			Version   int    `json:"version"`
+			AmendedBy string `json:"amendedBy"`
+		} `json:"history"`
+		StatusHistory []StatusAuditEntry `json:"statusHistory"`
+	} `json:"consents"`
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ========================
+// Persisted modifiedResponse
+// ========================
+
+// TestModifiedResponse_ReturnedOnGetAndExcludedFromValidate checks that the modifiedResponse of the
+// enrich hook is stored with the consent, returned when it is read and left out of validation
+func (ts *ConsentAPITestSuite) TestModifiedResponse_ReturnedOnGetAndExcludedFromValidate() {
+	ts.startExtensionStub()
+
+	resp, body := ts.createConsentWithExtensionAttribute("none")
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var created consentWithModifiedResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	ts.Equal("extension-stub", created.ModifiedResponse["enrichedBy"])
+
+	getResp, getBody := ts.getConsent(created.ID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+	var fetched consentWithModifiedResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &fetched))
+	ts.Equal(map[string]interface{}{"enrichedBy": "extension-stub"}, fetched.ModifiedResponse)
+
+	validateResp, validateBody := ts.validateConsent(ConsentValidateRequest{ConsentID: created.ID})
+	defer validateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, validateResp.StatusCode, string(validateBody))
+	var result struct {
+		ConsentInformation map[string]interface{} `json:"consentInformation"`
+	}
+	ts.Require().NoError(json.Unmarshal(validateBody, &result))
+	ts.Require().NotNil(result.ConsentInformation, string(validateBody))
+	ts.NotContains(result.ConsentInformation, "modifiedResponse")
+}
+
+// TestModifiedResponse_InvalidDocumentsAreDropped checks that documents over the size limit or not
+// matching the schema are not returned or stored. The creation enrich hook fails open in the test
+// deployment.yaml, so the consent is still created.
+func (ts *ConsentAPITestSuite) TestModifiedResponse_InvalidDocumentsAreDropped() {
+	ts.startExtensionStub()
+
+	for _, attribute := range []string{"oversized", "off-schema"} {
+		resp, body := ts.createConsentWithExtensionAttribute(attribute)
+		defer resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+		var created consentWithModifiedResponse
+		ts.Require().NoError(json.Unmarshal(body, &created))
+		ts.trackConsent(created.ID)
+		ts.Empty(created.ModifiedResponse, attribute)
+
+		getResp, getBody := ts.getConsent(created.ID)
+		defer getResp.Body.Close()
+		ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+		var fetched consentWithModifiedResponse
+		ts.Require().NoError(json.Unmarshal(getBody, &fetched))
+		ts.Empty(fetched.ModifiedResponse, attribute)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// /consents/{id}/notes Tests
+// ============================
+
+// consentNotesRequest calls the consent notes API. payload is sent as the body when it is not nil.
+func (ts *ConsentAPITestSuite) consentNotesRequest(method, consentID string, payload interface{}, withAdminAuth bool) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/consents/%s/notes", testServerURL, consentID)
+	httpReq, _ := http.NewRequest(method, url, reqBody)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	if withAdminAuth {
+		httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestConsentNotes_AddAndList_ReturnsNotesOldestFirst adds two notes and lists them
+func (ts *ConsentAPITestSuite) TestConsentNotes_AddAndList_ReturnsNotesOldestFirst() {
+	consentID := ts.createConsentOrFail(userConsentRequest("notes-user-1"))
+
+	texts := []string{"customer called to confirm revocation", "confirmation email sent"}
+	var created []ConsentNote
+	for _, text := range texts {
+		resp, body := ts.consentNotesRequest("POST", consentID, map[string]string{
+			"author": "support-agent-1",
+			"text":   text,
+		}, true)
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+		var note ConsentNote
+		ts.Require().NoError(json.Unmarshal(body, &note))
+		ts.NotEmpty(note.ID)
+		ts.Equal(consentID, note.ConsentID)
+		ts.Equal("support-agent-1", note.Author)
+		ts.Equal(text, note.Text)
+		ts.Positive(note.CreatedTime)
+		created = append(created, note)
+
+		// Notes are ordered by creation time in milliseconds
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	resp, body := ts.consentNotesRequest("GET", consentID, nil, true)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list ConsentNoteListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Require().Len(list.Data, 2)
+	ts.Equal(created[0].ID, list.Data[0].ID)
+	ts.Equal(texts[0], list.Data[0].Text)
+	ts.Equal(created[1].ID, list.Data[1].ID)
+
+	// Notes are kept apart from the consent and its attributes
+	getResp, getBody := ts.getConsent(consentID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+	ts.NotContains(string(getBody), texts[0])
+}
+
+// TestConsentNotes_NoNotes_ReturnsEmptyList lists the notes of a consent that has none
+func (ts *ConsentAPITestSuite) TestConsentNotes_NoNotes_ReturnsEmptyList() {
+	consentID := ts.createConsentOrFail(userConsentRequest("notes-user-2"))
+
+	resp, body := ts.consentNotesRequest("GET", consentID, nil, true)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.JSONEq(`{"data":[]}`, string(body))
+}
+
+// TestConsentNotes_InvalidRequests_AreRejected checks validation and admin authentication
+func (ts *ConsentAPITestSuite) TestConsentNotes_InvalidRequests_AreRejected() {
+	consentID := ts.createConsentOrFail(userConsentRequest("notes-user-3"))
+
+	testCases := []struct {
+		name       string
+		method     string
+		consentID  string
+		payload    interface{}
+		adminAuth  bool
+		wantStatus int
+	}{
+		{"missing author", "POST", consentID, map[string]string{"text": "called"}, true, http.StatusBadRequest},
+		{"missing text", "POST", consentID, map[string]string{"author": "agent"}, true, http.StatusBadRequest},
+		{"text too long", "POST", consentID, map[string]string{"author": "agent", "text": strings.Repeat("a", 4001)}, true, http.StatusBadRequest},
+		{"unknown consent", "POST", "00000000-0000-0000-0000-000000000000", map[string]string{"author": "agent", "text": "called"}, true, http.StatusNotFound},
+		{"unknown consent list", "GET", "00000000-0000-0000-0000-000000000000", nil, true, http.StatusNotFound},
+		{"add without admin credentials", "POST", consentID, map[string]string{"author": "agent", "text": "called"}, false, http.StatusUnauthorized},
+		{"list without admin credentials", "GET", consentID, nil, false, http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		resp, body := ts.consentNotesRequest(tc.method, tc.consentID, tc.payload, tc.adminAuth)
+		resp.Body.Close()
+		ts.Equal(tc.wantStatus, resp.StatusCode, "%s: %s", tc.name, string(body))
+	}
+
+	resp, body := ts.consentNotesRequest("GET", consentID, nil, true)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.JSONEq(`{"data":[]}`, string(body))
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// OpenAPI Document Tests
+// ============================
+
+// TestOpenAPI_ServesDocument checks that the OpenAPI document and its Swagger UI are served without
+// credentials
+func (ts *ConsentAPITestSuite) TestOpenAPI_ServesDocument() {
+	resp, err := testutils.GetHTTPClient().Get(testServerURL + "/api/v1/openapi.json")
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var document struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &document))
+	ts.Equal("3.1.0", document.OpenAPI)
+	ts.Contains(document.Paths, "/consents")
+	ts.Contains(document.Paths, "/openapi.json")
+
+	docsResp, err := testutils.GetHTTPClient().Get(testServerURL + "/api/v1/docs")
+	ts.Require().NoError(err)
+	defer docsResp.Body.Close()
+	docsBody, err := io.ReadAll(docsResp.Body)
+	ts.Require().NoError(err)
+	ts.Equal(http.StatusOK, docsResp.StatusCode)
+	ts.True(strings.HasPrefix(docsResp.Header.Get("Content-Type"), "text/html"))
+	ts.Contains(string(docsBody), "openapi.json")
+}
+
+// TestOpenAPI_RequestValidationEnabled_RejectsUndocumentedRequest enables request validation for
+// the test organization and checks that a request the document does not allow is rejected before
+// it is handled, while a documented one is still accepted
+func (ts *ConsentAPITestSuite) TestOpenAPI_RequestValidationEnabled_RejectsUndocumentedRequest() {
+	_, err := testutils.SetFeatureFlag(testOrgID, "request_validation", true)
+	ts.Require().NoError(err)
+	defer testutils.ClearFeatureFlag(testOrgID, "request_validation")
+
+	// type is required by the document
+	resp, body := ts.createConsent(map[string]interface{}{
+		"authorizations": []map[string]string{{"userId": "user1", "type": "payment", "status": "APPROVED"}},
+	})
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "request does not match the API specification: body.type is required")
+
+	// So are values of the wrong type
+	resp, body = ts.createConsent(map[string]interface{}{
+		"type":         "accounts",
+		"validityTime": "tomorrow",
+	})
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "body.validityTime must be of type integer")
+
+	okResp, okBody := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "payment", Status: "APPROVED"},
+		},
+	})
+	defer okResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, okResp.StatusCode, string(okBody))
+
+	var created ConsentResponse
+	ts.NoError(json.Unmarshal(okBody, &created))
+	ts.trackConsent(created.ID)
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Operation Audit Tests
+// ============================
+
+// changes returns a list of changes recorded in the details of the operation, for example the
+// attribute keys it added
+func (e OperationAuditEntry) changes(group, kind string) []string {
+	var details map[string]map[string][]string
+	_ = json.Unmarshal(e.Details, &details)
+	return details[group][kind]
+}
+
+// listConsentOperations calls GET /consents/{consentId}/operations
+func (ts *ConsentAPITestSuite) listConsentOperations(consentID string, query url.Values) OperationListResponse {
+	httpReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/consents/%s/operations?%s", testServerURL, consentID, query.Encode()), nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	return ts.doOperationAuditRequest(httpReq)
+}
+
+// searchOperations calls the admin GET /audit/operations
+func (ts *ConsentAPITestSuite) searchOperations(query url.Values) OperationListResponse {
+	httpReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/audit/operations?%s", testServerURL, query.Encode()), nil)
+	httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+
+	return ts.doOperationAuditRequest(httpReq)
+}
+
+// doOperationAuditRequest sends an operation audit request and decodes the page it returns
+func (ts *ConsentAPITestSuite) doOperationAuditRequest(httpReq *http.Request) OperationListResponse {
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result OperationListResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	return result
+}
+
+// TestOperationAudit_RecordsCreateAndUpdateDeltas records the attribute and purpose changes of a consent
+func (ts *ConsentAPITestSuite) TestOperationAudit_RecordsCreateAndUpdateDeltas() {
+	createResp, createBody := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "marketing-purpose", IsUserApproved: true},
+		},
+		Authorizations: []AuthorizationRequest{{UserID: "audit-op-user", Type: "authorisation", Status: "APPROVED"}},
+		Attributes:     map[string]string{"branch": "colombo", "channel": "web"},
+	})
+	createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &consent))
+	ts.trackConsent(consent.ID)
+
+	updateResp, updateBody := ts.updateConsent(consent.ID, ConsentUpdateRequest{
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "analytics-purpose", IsUserApproved: true},
+		},
+		Attributes: map[string]string{"branch": "kandy", "segment": "retail"},
+	})
+	updateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, updateResp.StatusCode, string(updateBody))
+
+	result := ts.listConsentOperations(consent.ID, url.Values{})
+	ts.Require().Len(result.Data, 2)
+	ts.Equal(2, result.Metadata.Total)
+
+	update, create := result.Data[0], result.Data[1]
+
+	ts.Equal("consent.create", create.Action)
+	ts.Equal(consent.ID, create.ConsentID)
+	ts.Equal(testClientID, create.Actor)
+	ts.Equal("POST /consents", create.Route)
+	ts.Equal(http.StatusCreated, create.StatusCode)
+	ts.Equal("SUCCESS", create.Outcome)
+	ts.Equal([]string{"branch", "channel"}, create.changes("attributes", "added"))
+	ts.Equal([]string{"marketing-purpose"}, create.changes("purposes", "linked"))
+
+	ts.Equal("consent.update", update.Action)
+	ts.Equal("SUCCESS", update.Outcome)
+	ts.Equal([]string{"segment"}, update.changes("attributes", "added"))
+	ts.Equal([]string{"channel"}, update.changes("attributes", "removed"))
+	ts.Equal([]string{"branch"}, update.changes("attributes", "changed"))
+	ts.Equal([]string{"analytics-purpose"}, update.changes("purposes", "linked"))
+	ts.Equal([]string{"marketing-purpose"}, update.changes("purposes", "unlinked"))
+	ts.NotContains(string(update.Details), "kandy", "attribute values are not recorded")
+}
+
+// TestOperationAudit_RecordsFailuresAndFileUploads records failed calls and file uploads, searchable by actor
+func (ts *ConsentAPITestSuite) TestOperationAudit_RecordsFailuresAndFileUploads() {
+	consentID := ts.createConsentOrFail(userConsentRequest("audit-op-user-2"))
+
+	failResp, failBody := ts.updateConsent(consentID, ConsentUpdateRequest{
+		ConsentPurpose: []ConsentPurposeItem{{Name: "no-such-purpose", IsUserApproved: true}},
+	})
+	failResp.Body.Close()
+	ts.Require().Equal(http.StatusBadRequest, failResp.StatusCode, string(failBody))
+
+	uploadResp, uploadBody := ts.uploadConsentFile(consentID, "signed-form.pdf", "application/pdf", []byte("%PDF-1.4 audit"))
+	uploadResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, uploadResp.StatusCode, string(uploadBody))
+
+	failures := ts.listConsentOperations(consentID, url.Values{"outcome": {"failure"}})
+	ts.Require().Len(failures.Data, 1)
+	ts.Equal("consent.update", failures.Data[0].Action)
+	ts.Equal(http.StatusBadRequest, failures.Data[0].StatusCode)
+
+	uploads := ts.searchOperations(url.Values{
+		"actor":     {testClientID},
+		"consentId": {consentID},
+		"action":    {"consent.file_upload"},
+	})
+	ts.Require().Len(uploads.Data, 1)
+	ts.Equal(testOrgID, uploads.Data[0].OrgID)
+	ts.Equal("SUCCESS", uploads.Data[0].Outcome)
+	ts.Contains(string(uploads.Data[0].Details), "signed-form.pdf")
+}
@@ -0,0 +1,282 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// /orgs Tests
+// ============================
+
+// sendOrganizationRequest calls the organizations API with admin credentials. An empty orgID
+// targets the collection.
+func (ts *ConsentAPITestSuite) sendOrganizationRequest(method, orgID string, payload interface{}) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/orgs", testServerURL)
+	if orgID != "" {
+		url += "/" + orgID
+	}
+	httpReq, _ := http.NewRequest(method, url, reqBody)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// deleteOrganization removes an organization created by a test
+func (ts *ConsentAPITestSuite) deleteOrganization(orgID string) {
+	resp, _ := ts.sendOrganizationRequest("DELETE", orgID, nil)
+	resp.Body.Close()
+}
+
+// TestOrganization_CRUD creates, reads, lists, replaces and deletes an organization
+func (ts *ConsentAPITestSuite) TestOrganization_CRUD() {
+	orgID := fmt.Sprintf("org-crud-%d", time.Now().UnixNano())
+	defer ts.deleteOrganization(orgID)
+
+	resp, body := ts.sendOrganizationRequest("POST", "", map[string]interface{}{
+		"orgId":         orgID,
+		"name":          "Acme Bank",
+		"webhookUrls":   []string{"https://hooks.example.com/consents"},
+		"retentionDays": 7,
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	resp, body = ts.sendOrganizationRequest("GET", orgID, nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var organization OrganizationResponse
+	ts.Require().NoError(json.Unmarshal(body, &organization))
+	ts.Equal("Acme Bank", organization.Name)
+	ts.Equal([]string{"https://hooks.example.com/consents"}, organization.WebhookURLs)
+	ts.Require().NotNil(organization.RetentionDays)
+	ts.Equal(7, *organization.RetentionDays)
+
+	resp, body = ts.sendOrganizationRequest("GET", "", nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.True(strings.Contains(string(body), orgID), string(body))
+
+	resp, body = ts.sendOrganizationRequest("PUT", orgID, map[string]interface{}{
+		"name":           "Acme Bank PLC",
+		"statusMappings": map[string]string{"revokedStatus": "WITHDRAWN"},
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var replaced OrganizationResponse
+	ts.Require().NoError(json.Unmarshal(body, &replaced))
+	ts.Equal("Acme Bank PLC", replaced.Name)
+	ts.Equal("WITHDRAWN", replaced.StatusMappings["revokedStatus"])
+	ts.Nil(replaced.RetentionDays)
+	ts.Empty(replaced.WebhookURLs)
+
+	resp, body = ts.sendOrganizationRequest("DELETE", orgID, nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+
+	resp, _ = ts.sendOrganizationRequest("GET", orgID, nil)
+	resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+// TestOrganization_Duplicate_ReturnsConflict checks that an organization cannot be created twice
+func (ts *ConsentAPITestSuite) TestOrganization_Duplicate_ReturnsConflict() {
+	orgID := fmt.Sprintf("org-dup-%d", time.Now().UnixNano())
+	defer ts.deleteOrganization(orgID)
+
+	payload := map[string]interface{}{"orgId": orgID, "name": "Duplicate"}
+	resp, body := ts.sendOrganizationRequest("POST", "", payload)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	resp, body = ts.sendOrganizationRequest("POST", "", payload)
+	resp.Body.Close()
+	ts.Equal(http.StatusConflict, resp.StatusCode, string(body))
+}
+
+// TestOrganization_InvalidRequest_Rejected checks that invalid organizations are not stored
+func (ts *ConsentAPITestSuite) TestOrganization_InvalidRequest_Rejected() {
+	testCases := []struct {
+		name      string
+		payload   map[string]interface{}
+		errorText string
+	}{
+		{"missing name", map[string]interface{}{"orgId": "org-invalid"}, "name is required"},
+		{"negative retention", map[string]interface{}{"orgId": "org-invalid", "name": "x", "retentionDays": -1}, "retentionDays"},
+		{"relative webhook", map[string]interface{}{"orgId": "org-invalid", "name": "x", "webhookUrls": []string{"/hooks"}}, "webhook URL"},
+		{"duplicate status", map[string]interface{}{"orgId": "org-invalid", "name": "x",
+			"statusMappings": map[string]string{"revokedStatus": "ACTIVE"}}, "cannot both be"},
+		{"deleted status", map[string]interface{}{"orgId": "org-invalid", "name": "x",
+			"statusMappings": map[string]string{"expiredStatus": "DELETED"}}, "DELETED"},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.sendOrganizationRequest("POST", "", tc.payload)
+			resp.Body.Close()
+			ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+			ts.True(strings.Contains(string(body), tc.errorText), string(body))
+		})
+	}
+}
+
+// TestOrganization_RequiresAdminAuth checks that the organizations API rejects unauthenticated calls
+func (ts *ConsentAPITestSuite) TestOrganization_RequiresAdminAuth() {
+	httpReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/orgs", testServerURL), nil)
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	resp.Body.Close()
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode)
+}
+
+// TestOrganization_StatusMappings_AppliedToConsents checks that consents of an organization use
+// its status names, and the deployment names again once the organization is deleted
+func (ts *ConsentAPITestSuite) TestOrganization_StatusMappings_AppliedToConsents() {
+	resp, body := ts.sendOrganizationRequest("POST", "", map[string]interface{}{
+		"orgId": testOrgID,
+		"name":  "Test Organization",
+		"statusMappings": map[string]string{
+			"activeStatus":  "AUTHORISED",
+			"revokedStatus": "WITHDRAWN",
+		},
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	defer ts.deleteOrganization(testOrgID)
+
+	createResp, createBody := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "auth", Status: "APPROVED"},
+		},
+	})
+	createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+	ts.Equal("AUTHORISED", created.Status)
+
+	revokeResp, revokeBody := ts.revokeConsent(created.ID, "Customer requested revocation")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	getResp, getBody := ts.getConsent(created.ID)
+	getResp.Body.Close()
+	var revoked ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &revoked))
+	ts.Equal("WITHDRAWN", revoked.Status)
+
+	ts.deleteOrganization(testOrgID)
+
+	defaultResp, defaultBody := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "auth", Status: "APPROVED"},
+		},
+	})
+	defaultResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, defaultResp.StatusCode, string(defaultBody))
+
+	var defaultConsent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(defaultBody, &defaultConsent))
+	ts.trackConsent(defaultConsent.ID)
+	ts.Equal("ACTIVE", defaultConsent.Status)
+}
+
+// TestOrganization_ConsentDefaults_AppliedToConsents checks that an organization's allowed consent
+// types and default validity apply to the consents it creates
+func (ts *ConsentAPITestSuite) TestOrganization_ConsentDefaults_AppliedToConsents() {
+	resp, body := ts.sendOrganizationRequest("POST", "", map[string]interface{}{
+		"orgId":                  testOrgID,
+		"name":                   "Test Organization",
+		"allowedConsentTypes":    []string{"accounts"},
+		"defaultValiditySeconds": 3600,
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	defer ts.deleteOrganization(testOrgID)
+
+	rejectedResp, rejectedBody := ts.createConsent(ConsentCreateRequest{
+		Type: "payments",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "auth", Status: "APPROVED"},
+		},
+	})
+	rejectedResp.Body.Close()
+	ts.Equal(http.StatusBadRequest, rejectedResp.StatusCode, string(rejectedBody))
+	ts.Contains(string(rejectedBody), "not allowed")
+
+	before := time.Now().Unix()
+	createResp, createBody := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "auth", Status: "APPROVED"},
+		},
+	})
+	createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+	ts.Require().NotNil(created.ValidityTime)
+	ts.InDelta(before+3600, *created.ValidityTime, 60)
+
+	explicitValidity := time.Now().Add(2 * time.Hour).Unix()
+	explicitResp, explicitBody := ts.createConsent(ConsentCreateRequest{
+		Type:         "accounts",
+		ValidityTime: explicitValidity,
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "auth", Status: "APPROVED"},
+		},
+	})
+	explicitResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, explicitResp.StatusCode, string(explicitBody))
+
+	var explicit ConsentResponse
+	ts.Require().NoError(json.Unmarshal(explicitBody, &explicit))
+	ts.trackConsent(explicit.ID)
+	ts.Require().NotNil(explicit.ValidityTime)
+	ts.Equal(explicitValidity, *explicit.ValidityTime)
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// transferOwnership calls the admin consent ownership transfer API
+func (ts *ConsentAPITestSuite) transferOwnership(orgID string, payload interface{}) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	httpReq, _ := http.NewRequest("POST", testServerURL+"/api/v1/admin/consent-ownership-transfers",
+		bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, orgID)
+	httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// userConsentRequest returns the request for an active consent bound to userID
+func userConsentRequest(userID string) ConsentCreateRequest {
+	return ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: userID, Type: "authorisation", Status: "APPROVED"},
+		},
+	}
+}
+
+// ============================
+// POST /admin/consent-ownership-transfers - Ownership Transfer Tests
+// ============================
+
+// TestTransferOwnership_MovesAuthorizationsAndKeepsHistory transfers a consent and checks the new
+// user binding, the version bump and the history entry
+func (ts *ConsentAPITestSuite) TestTransferOwnership_MovesAuthorizationsAndKeepsHistory() {
+	created := ts.getConsentOrFail(ts.createConsentOrFail(userConsentRequest("transfer-from-user")))
+
+	resp, body := ts.transferOwnership(testOrgID, map[string]interface{}{
+		"fromUserId": "transfer-from-user",
+		"toUserId":   "transfer-to-user",
+		"reason":     "Guardianship change",
+		"actionBy":   "admin@wso2.com",
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var transfer OwnershipTransferResponse
+	ts.Require().NoError(json.Unmarshal(body, &transfer))
+	ts.Require().Len(transfer.Transferred, 1)
+	ts.Equal(created.ID, transfer.Transferred[0].ConsentID)
+	ts.Equal(created.Version+1, transfer.Transferred[0].Version)
+	ts.Len(transfer.Transferred[0].AuthorizationIDs, 1)
+
+	getResp, getBody := ts.getConsent(created.ID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode)
+
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &consent))
+	ts.Equal(created.Version+1, consent.Version)
+	ts.Require().Len(consent.Authorizations, 1)
+	ts.Require().NotNil(consent.Authorizations[0].UserID)
+	ts.Equal("transfer-to-user", *consent.Authorizations[0].UserID)
+
+	versionsResp, versionsBody := ts.getConsentVersions(created.ID, "")
+	defer versionsResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, versionsResp.StatusCode)
+
+	var versions ConsentVersionListResponse
+	ts.Require().NoError(json.Unmarshal(versionsBody, &versions))
+	ts.Require().Len(versions.Data, 1)
+	ts.Equal(created.Version, versions.Data[0].Version)
+	ts.Equal("admin@wso2.com", versions.Data[0].AmendedBy)
+	ts.Contains(versions.Data[0].Reason, "Guardianship change")
+
+	operations := ts.searchOperations(url.Values{"action": {"user.ownership_transfer"}, "limit": {"100"}})
+	found := false
+	for _, operation := range operations.Data {
+		var details struct {
+			FromUserID string   `json:"fromUserId"`
+			ToUserID   string   `json:"toUserId"`
+			ConsentIDs []string `json:"consentIds"`
+		}
+		_ = json.Unmarshal(operation.Details, &details)
+		if slices.Contains(details.ConsentIDs, created.ID) {
+			found = true
+			ts.Equal("SUCCESS", operation.Outcome)
+			ts.Equal("transfer-to-user", details.ToUserID)
+		}
+	}
+	ts.True(found, "expected the transfer in the operation audit")
+}
+
+// TestTransferOwnership_RevokedConsent_IsSkipped checks that revoked consents stay with the original user
+func (ts *ConsentAPITestSuite) TestTransferOwnership_RevokedConsent_IsSkipped() {
+	consentID := ts.createConsentOrFail(userConsentRequest("transfer-revoked-user"))
+
+	revokeResp, _ := ts.revokeConsent(consentID, "No longer needed")
+	defer revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode)
+
+	resp, body := ts.transferOwnership(testOrgID, map[string]interface{}{
+		"fromUserId": "transfer-revoked-user",
+		"toUserId":   "transfer-heir-user",
+		"reason":     "Account inheritance",
+		"actionBy":   "admin@wso2.com",
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var transfer OwnershipTransferResponse
+	ts.Require().NoError(json.Unmarshal(body, &transfer))
+	ts.Empty(transfer.Transferred)
+	ts.Require().Len(transfer.Skipped, 1)
+	ts.Equal(consentID, transfer.Skipped[0].ConsentID)
+}
+
+// TestTransferOwnership_MissingReason_ReturnsBadRequest checks the organization's policy requires a reason
+func (ts *ConsentAPITestSuite) TestTransferOwnership_MissingReason_ReturnsBadRequest() {
+	resp, _ := ts.transferOwnership(testOrgID, map[string]interface{}{
+		"fromUserId": "transfer-from-user",
+		"toUserId":   "transfer-to-user",
+		"actionBy":   "admin@wso2.com",
+	})
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestTransferOwnership_OrgWithoutPolicy_ReturnsBadRequest checks transfers are refused for
+// organizations that do not allow them
+func (ts *ConsentAPITestSuite) TestTransferOwnership_OrgWithoutPolicy_ReturnsBadRequest() {
+	resp, _ := ts.transferOwnership("org-without-transfer-policy", map[string]interface{}{
+		"fromUserId": "transfer-from-user",
+		"toUserId":   "transfer-to-user",
+		"reason":     "Guardianship change",
+		"actionBy":   "admin@wso2.com",
+	})
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// userPreferences calls GET /users/{userId}/preferences, or PUT when payload is set
+func (ts *ConsentAPITestSuite) userPreferences(userID string, payload interface{}) (*http.Response, []byte) {
+	method := "GET"
+	var reqBody io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		method, reqBody = "PUT", bytes.NewReader(encoded)
+	}
+
+	httpReq, _ := http.NewRequest(method, testServerURL+"/api/v1/users/"+url.PathEscape(userID)+"/preferences", reqBody)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// createPurposeConsentForUser creates an active consent of the user linking the given purposes
+func (ts *ConsentAPITestSuite) createPurposeConsentForUser(userID string, purposes []ConsentPurposeItem) ConsentResponse {
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type:           "accounts",
+		ConsentPurpose: purposes,
+		Authorizations: []AuthorizationRequest{{UserID: userID, Type: "authorisation", Status: "APPROVED"}},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	return created
+}
+
+// ============================
+// /users/{userId}/preferences - Preference Center Tests
+// ============================
+
+// TestUserPreferences_AggregatesAndUpdatesPurposes checks the per-purpose states across a user's
+// consents, and that an opt-out reaches every consent and is audited
+func (ts *ConsentAPITestSuite) TestUserPreferences_AggregatesAndUpdatesPurposes() {
+	userID := fmt.Sprintf("preference-user-%d", time.Now().UnixNano())
+	first := ts.createPurposeConsentForUser(userID, []ConsentPurposeItem{
+		{Name: "marketing-purpose", IsUserApproved: true},
+		{Name: "terms-purpose", IsUserApproved: true, IsMandatory: true},
+	})
+	second := ts.createPurposeConsentForUser(userID, []ConsentPurposeItem{
+		{Name: "marketing-purpose", IsUserApproved: false},
+		{Name: "analytics-purpose", IsUserApproved: false},
+	})
+
+	resp, body := ts.userPreferences(userID, nil)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var preferences UserPreferencesResponse
+	ts.Require().NoError(json.Unmarshal(body, &preferences))
+	ts.Equal(userID, preferences.UserID)
+	states := make(map[string]string)
+	for _, preference := range preferences.Preferences {
+		states[preference.PurposeName] = preference.State
+	}
+	ts.Equal(map[string]string{
+		"analytics-purpose": "OPTED_OUT",
+		"marketing-purpose": "MIXED",
+		"terms-purpose":     "OPTED_IN",
+	}, states)
+
+	updateResp, updateBody := ts.userPreferences(userID, map[string]interface{}{
+		"preferences": []map[string]interface{}{
+			{"purposeName": "marketing-purpose", "optedIn": false},
+			{"purposeName": "analytics-purpose", "optedIn": true},
+		},
+	})
+	defer updateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, updateResp.StatusCode, string(updateBody))
+
+	var updated UserPreferencesResponse
+	ts.Require().NoError(json.Unmarshal(updateBody, &updated))
+	for _, preference := range updated.Preferences {
+		switch preference.PurposeName {
+		case "marketing-purpose":
+			ts.Equal("OPTED_OUT", preference.State)
+			ts.Len(preference.Consents, 2)
+		case "analytics-purpose":
+			ts.Equal("OPTED_IN", preference.State)
+			ts.NotNil(preference.LastConfirmedAt)
+		}
+	}
+
+	// The purposes of the consent itself changed
+	getResp, getBody := ts.getConsent(first.ID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+	var fetched ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &fetched))
+	for _, purpose := range fetched.ConsentPurpose {
+		if purpose.Name == "marketing-purpose" {
+			ts.False(purpose.IsUserApproved)
+		}
+	}
+
+	// The change is a new version of the consent, keeping the previous one in its history
+	ts.Equal(first.Version+1, fetched.Version)
+	ts.Greater(fetched.UpdatedTime, first.UpdatedTime)
+	versionsResp, versionsBody := ts.getConsentVersions(first.ID, "")
+	defer versionsResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, versionsResp.StatusCode, string(versionsBody))
+	var versions ConsentVersionListResponse
+	ts.Require().NoError(json.Unmarshal(versionsBody, &versions))
+	ts.Require().Len(versions.Data, 1)
+	ts.Equal(first.Version, versions.Data[0].Version)
+
+	operations := ts.searchOperations(url.Values{"action": {"user.preference_update"}, "limit": {"100"}})
+	found := false
+	for _, operation := range operations.Data {
+		var details struct {
+			ConsentIDs []string `json:"consentIds"`
+		}
+		_ = json.Unmarshal(operation.Details, &details)
+		if slices.Contains(details.ConsentIDs, first.ID) {
+			found = true
+			ts.ElementsMatch([]string{first.ID, second.ID}, details.ConsentIDs)
+			ts.Equal([]string{"marketing-purpose"}, operation.changes("preferences", "optedOut"))
+			ts.Equal([]string{"analytics-purpose"}, operation.changes("preferences", "optedIn"))
+		}
+	}
+	ts.True(found, "expected the preference update in the operation audit")
+}
+
+// TestUserPreferences_RejectsInvalidUpdates checks that mandatory purposes cannot be opted out of
+// and that purposes must belong to the user's consents
+func (ts *ConsentAPITestSuite) TestUserPreferences_RejectsInvalidUpdates() {
+	userID := fmt.Sprintf("preference-user-%d", time.Now().UnixNano())
+	ts.createPurposeConsentForUser(userID, []ConsentPurposeItem{
+		{Name: "terms-purpose", IsUserApproved: true, IsMandatory: true},
+	})
+
+	tests := []struct {
+		name        string
+		preferences []map[string]interface{}
+	}{
+		{"mandatory purpose", []map[string]interface{}{{"purposeName": "terms-purpose", "optedIn": false}}},
+		{"purpose not linked", []map[string]interface{}{{"purposeName": "analytics-purpose", "optedIn": true}}},
+		{"missing choice", []map[string]interface{}{{"purposeName": "terms-purpose"}}},
+		{"no preferences", []map[string]interface{}{}},
+	}
+	for _, tt := range tests {
+		resp, body := ts.userPreferences(userID, map[string]interface{}{"preferences": tt.preferences})
+		resp.Body.Close()
+		ts.Equal(http.StatusBadRequest, resp.StatusCode, "%s: %s", tt.name, string(body))
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// TestValidateConsent_ReportsStalePurposes checks that purposes past their expiresAt are listed as
+// stale without invalidating the consent, and that the purpose timestamps round-trip on GET
+func (ts *ConsentAPITestSuite) TestValidateConsent_ReportsStalePurposes() {
+	now := time.Now().UnixMilli()
+	confirmed := now - 400*24*time.Hour.Milliseconds()
+	expired := now - 35*24*time.Hour.Milliseconds()
+	future := now + 365*24*time.Hour.Milliseconds()
+
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "marketing-purpose", IsUserApproved: true, LastConfirmedAt: &confirmed, ExpiresAt: &expired},
+			{Name: "analytics-purpose", IsUserApproved: true, ExpiresAt: &future},
+		},
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "APPROVED"}},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+
+	getResp, getBody := ts.getConsent(created.ID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+
+	var fetched ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &fetched))
+	purposes := make(map[string]ConsentPurposeItem, len(fetched.ConsentPurpose))
+	for _, purpose := range fetched.ConsentPurpose {
+		purposes[purpose.Name] = purpose
+	}
+	ts.Require().NotNil(purposes["marketing-purpose"].ExpiresAt)
+	ts.Equal(expired, *purposes["marketing-purpose"].ExpiresAt)
+	ts.Require().NotNil(purposes["marketing-purpose"].LastConfirmedAt)
+	ts.Equal(confirmed, *purposes["marketing-purpose"].LastConfirmedAt)
+	ts.Nil(purposes["analytics-purpose"].LastConfirmedAt)
+
+	validateResp := ts.validateAsUser1(created.ID)
+	ts.True(validateResp.IsValid)
+	ts.Equal([]string{"marketing-purpose"}, validateResp.StalePurposes)
+}
+
+// TestCreateConsent_NegativePurposeExpiry_ReturnsBadRequest rejects a negative purpose expiresAt
+func (ts *ConsentAPITestSuite) TestCreateConsent_NegativePurposeExpiry_ReturnsBadRequest() {
+	negative := int64(-1)
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "marketing-purpose", IsUserApproved: true, ExpiresAt: &negative},
+		},
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "APPROVED"}},
+	})
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
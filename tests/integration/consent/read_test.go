@@ -20,7 +20,10 @@ package consent
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // ============================
@@ -676,3 +679,179 @@ func (ts *ConsentAPITestSuite) TestListConsents_InvalidTimeRange_ReturnsValidati
 	// Adjust based on actual API behavior
 	ts.True(resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusOK)
 }
+
+// createConsentWithValidity creates a consent of the given type and validity time and returns its ID
+func (ts *ConsentAPITestSuite) createConsentWithValidity(consentType string, validityTime int64) string {
+	payload := ConsentCreateRequest{
+		Type:           consentType,
+		ValidityTime:   validityTime,
+		Authorizations: []AuthorizationRequest{{UserID: "range-test-user", Type: "auth", Status: "APPROVED"}},
+	}
+
+	resp, body := ts.createConsent(payload)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	return created.ID
+}
+
+// TestListConsents_ExpiringWithinWindow_ReturnsMatchingConsents verifies the expiry window filters
+func (ts *ConsentAPITestSuite) TestListConsents_ExpiringWithinWindow_ReturnsMatchingConsents() {
+	consentType := fmt.Sprintf("expiry-range-%d", time.Now().UnixNano())
+	now := time.Now()
+	expiringSoonID := ts.createConsentWithValidity(consentType, now.Add(3*24*time.Hour).UnixMilli())
+	ts.createConsentWithValidity(consentType, now.Add(30*24*time.Hour).UnixMilli())
+	ts.createConsentWithValidity(consentType, 0)
+
+	resp, body := ts.listConsents(map[string]string{
+		"consentTypes":  consentType,
+		"expiresAfter":  strconv.FormatInt(now.UnixMilli(), 10),
+		"expiresBefore": strconv.FormatInt(now.Add(7*24*time.Hour).UnixMilli(), 10),
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var listResp ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &listResp))
+	ts.Require().Len(listResp.Data, 1)
+	ts.Equal(expiringSoonID, listResp.Data[0].ID)
+}
+
+// TestListConsents_CreatedAndUpdatedTimeRange_FiltersConsents verifies created and updated time filters
+func (ts *ConsentAPITestSuite) TestListConsents_CreatedAndUpdatedTimeRange_FiltersConsents() {
+	consentType := fmt.Sprintf("created-range-%d", time.Now().UnixNano())
+	before := time.Now().Add(-time.Minute).UnixMilli()
+	consentID := ts.createConsentWithValidity(consentType, 0)
+
+	resp, body := ts.listConsents(map[string]string{
+		"consentTypes":    consentType,
+		"createdTimeFrom": strconv.FormatInt(before, 10),
+		"updatedTimeFrom": strconv.FormatInt(before, 10),
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var listResp ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &listResp))
+	ts.Require().Len(listResp.Data, 1)
+	ts.Equal(consentID, listResp.Data[0].ID)
+
+	resp, body = ts.listConsents(map[string]string{
+		"consentTypes":  consentType,
+		"createdTimeTo": strconv.FormatInt(before, 10),
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.Require().NoError(json.Unmarshal(body, &listResp))
+	ts.Empty(listResp.Data)
+}
+
+// TestListConsents_InvalidRangeFilters_ReturnsBadRequest verifies malformed and inverted range filters return 400
+func (ts *ConsentAPITestSuite) TestListConsents_InvalidRangeFilters_ReturnsBadRequest() {
+	resp, body := ts.listConsents(map[string]string{"expiresBefore": "next-week"})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = ts.listConsents(map[string]string{
+		"updatedTimeFrom": "1734422400000",
+		"updatedTimeTo":   "1702800000000",
+	})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+// TestListConsents_CursorPagination_WalksAllPages verifies keyset pagination returns every consent once
+func (ts *ConsentAPITestSuite) TestListConsents_CursorPagination_WalksAllPages() {
+	consentType := fmt.Sprintf("cursor-page-%d", time.Now().UnixNano())
+	created := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		created[ts.createConsentWithValidity(consentType, 0)] = true
+	}
+
+	seen := map[string]bool{}
+	cursor := ""
+	pages := 0
+	for {
+		resp, body := ts.listConsents(map[string]string{
+			"consentTypes": consentType,
+			"limit":        "2",
+			"cursor":       cursor,
+		})
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+		var page ConsentListResponse
+		ts.Require().NoError(json.Unmarshal(body, &page))
+		ts.Equal(3, page.Meta.Total)
+		for _, consent := range page.Data {
+			ts.False(seen[consent.ID], "consent %s returned twice", consent.ID)
+			seen[consent.ID] = true
+		}
+
+		pages++
+		ts.Require().LessOrEqual(pages, 2, "expected two pages")
+		if page.Meta.NextCursor == "" {
+			ts.Len(page.Data, 1)
+			break
+		}
+		ts.Len(page.Data, 2)
+		cursor = page.Meta.NextCursor
+	}
+
+	ts.Equal(created, seen)
+}
+
+// TestListConsents_InvalidCursor_ReturnsBadRequest verifies a malformed cursor returns 400
+func (ts *ConsentAPITestSuite) TestListConsents_InvalidCursor_ReturnsBadRequest() {
+	resp, body := ts.listConsents(map[string]string{"cursor": "not-a-cursor"})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+// TestListConsents_WithoutTotal_ReportsHasMore verifies includeTotal=false omits the total and reports hasMore
+func (ts *ConsentAPITestSuite) TestListConsents_WithoutTotal_ReportsHasMore() {
+	consentType := fmt.Sprintf("no-total-%d", time.Now().UnixNano())
+	for i := 0; i < 3; i++ {
+		ts.createConsentWithValidity(consentType, 0)
+	}
+
+	for _, tc := range []struct {
+		offset  string
+		count   int
+		hasMore bool
+	}{
+		{offset: "0", count: 2, hasMore: true},
+		{offset: "2", count: 1, hasMore: false},
+	} {
+		resp, body := ts.listConsents(map[string]string{
+			"consentTypes": consentType,
+			"limit":        "2",
+			"offset":       tc.offset,
+			"includeTotal": "false",
+		})
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+		var raw struct {
+			Metadata map[string]interface{} `json:"metadata"`
+		}
+		ts.Require().NoError(json.Unmarshal(body, &raw))
+		ts.NotContains(raw.Metadata, "total")
+
+		var page ConsentListResponse
+		ts.Require().NoError(json.Unmarshal(body, &page))
+		ts.Len(page.Data, tc.count)
+		ts.Equal(tc.hasMore, page.Meta.HasMore)
+	}
+}
+
+// TestListConsents_InvalidIncludeTotal_ReturnsBadRequest verifies a non-boolean includeTotal returns 400
+func (ts *ConsentAPITestSuite) TestListConsents_InvalidIncludeTotal_ReturnsBadRequest() {
+	resp, body := ts.listConsents(map[string]string{"includeTotal": "maybe"})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// POST /consents/{id}/reauthorize Tests
+// ============================
+
+// reauthorizeConsent calls the consent re-authorization API
+func (ts *ConsentAPITestSuite) reauthorizeConsent(consentID string, payload interface{}) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	url := fmt.Sprintf("%s/api/v1/consents/%s/reauthorize", testServerURL, consentID)
+	httpReq, _ := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestReauthorizeConsent_Active_ResetsAuthorizationsAndReactivatesOnApproval sends an active consent
+// for re-authorization and approves it again
+func (ts *ConsentAPITestSuite) TestReauthorizeConsent_Active_ResetsAuthorizationsAndReactivatesOnApproval() {
+	consentID := ts.createConsentOrFail(userConsentRequest("reauth-user-1"))
+	validityTime := time.Now().Add(90 * 24 * time.Hour).Unix()
+
+	resp, body := ts.reauthorizeConsent(consentID, map[string]interface{}{
+		"validityTime": validityTime,
+		"reason":       "90-day re-authentication",
+		"actionBy":     "reauth-user-1",
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result ReauthorizationResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Equal(consentID, result.ConsentID)
+	ts.Equal("ACTIVE", result.PreviousStatus)
+	ts.Equal("AWAITING_REAUTHORIZATION", result.Status)
+	ts.Equal("CREATED", result.AuthorizationStatus)
+	ts.Equal(validityTime, result.ValidityTime)
+	ts.Equal("90-day re-authentication", result.Reason)
+
+	getResp, getBody := ts.getConsent(consentID)
+	getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+
+	var awaiting ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &awaiting))
+	ts.Equal("AWAITING_REAUTHORIZATION", awaiting.Status)
+	ts.Require().NotNil(awaiting.ValidityTime)
+	ts.Equal(validityTime, *awaiting.ValidityTime)
+	ts.Require().NotEmpty(awaiting.Authorizations)
+	for _, auth := range awaiting.Authorizations {
+		ts.Equal("CREATED", auth.Status)
+	}
+
+	patchResp, patchBody := ts.patchAuthorization(consentID, awaiting.Authorizations[0].ID, AuthorizationPatchRequest{
+		Status: "APPROVED",
+	})
+	patchResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, patchResp.StatusCode, string(patchBody))
+
+	getResp, getBody = ts.getConsent(consentID)
+	getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+
+	var reactivated ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &reactivated))
+	ts.Equal("ACTIVE", reactivated.Status)
+}
+
+// TestReauthorizeConsent_Expired_IsAllowed re-authorizes a consent that has expired
+func (ts *ConsentAPITestSuite) TestReauthorizeConsent_Expired_IsAllowed() {
+	consentID := ts.createConsentOrFail(userConsentRequest("reauth-user-2"))
+
+	overrideResp, overrideBody := ts.overrideStatus(consentID, map[string]string{
+		"status":   "EXPIRED",
+		"reason":   "validity time passed",
+		"actionBy": "support-agent-1",
+	}, true)
+	overrideResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, overrideResp.StatusCode, string(overrideBody))
+
+	resp, body := ts.reauthorizeConsent(consentID, map[string]interface{}{
+		"validityTime": time.Now().Add(24 * time.Hour).Unix(),
+		"actionBy":     "reauth-user-2",
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result ReauthorizationResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Equal("EXPIRED", result.PreviousStatus)
+	ts.Equal("AWAITING_REAUTHORIZATION", result.Status)
+}
+
+// TestReauthorizeConsent_InvalidRequests_AreRejected checks the statuses and payloads that are rejected
+func (ts *ConsentAPITestSuite) TestReauthorizeConsent_InvalidRequests_AreRejected() {
+	activeID := ts.createConsentOrFail(userConsentRequest("reauth-user-3"))
+	revokedID := ts.createConsentOrFail(userConsentRequest("reauth-user-4"))
+	revokeResp, revokeBody := ts.revokeConsent(revokedID, "no longer needed")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	future := time.Now().Add(24 * time.Hour).Unix()
+	testCases := []struct {
+		name       string
+		consentID  string
+		payload    map[string]interface{}
+		wantStatus int
+	}{
+		{"revoked consent", revokedID, map[string]interface{}{"validityTime": future, "actionBy": "u"}, http.StatusConflict},
+		{"missing actionBy", activeID, map[string]interface{}{"validityTime": future}, http.StatusBadRequest},
+		{"past validity time", activeID, map[string]interface{}{"validityTime": time.Now().Add(-time.Hour).Unix(), "actionBy": "u"}, http.StatusBadRequest},
+		{"no validity time without default validity", activeID, map[string]interface{}{"actionBy": "u"}, http.StatusBadRequest},
+		{"unknown consent", "00000000-0000-0000-0000-000000000000", map[string]interface{}{"validityTime": future, "actionBy": "u"}, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.reauthorizeConsent(tc.consentID, tc.payload)
+			resp.Body.Close()
+			ts.Equal(tc.wantStatus, resp.StatusCode, string(body))
+		})
+	}
+
+	getResp, getBody := ts.getConsent(activeID)
+	getResp.Body.Close()
+	var unchanged ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &unchanged))
+	ts.Equal("ACTIVE", unchanged.Status)
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// getConsentReceipt retrieves the receipt of a consent
+func (ts *ConsentAPITestSuite) getConsentReceipt(consentID string) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consents/%s/receipt", testServerURL, consentID)
+
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// ============================
+// GET /consents/{consentId}/receipt - Consent Receipt Tests
+// ============================
+
+// TestGetConsentReceipt_ActiveConsent_ReturnsSignedReceipt checks the receipt content and that the
+// JWS verifies with the public key in the receipt
+func (ts *ConsentAPITestSuite) TestGetConsentReceipt_ActiveConsent_ReturnsSignedReceipt() {
+	payload := ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "marketing-purpose", Value: "yes", IsUserApproved: true, IsMandatory: false},
+		},
+		Authorizations: []AuthorizationRequest{
+			{UserID: "receipt-user", Type: "authorisation", Status: "APPROVED"},
+		},
+	}
+
+	createResp, createBody := ts.createConsent(payload)
+	defer createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+
+	resp, body := ts.getConsentReceipt(created.ID)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var receiptResp ConsentReceiptResponse
+	ts.Require().NoError(json.Unmarshal(body, &receiptResp))
+
+	receipt := receiptResp.Receipt
+	ts.Equal("KI-CR-v1.1.0", receipt.Version)
+	ts.Equal("LK", receipt.Jurisdiction)
+	ts.Equal(created.ID, receipt.ConsentID)
+	ts.Equal(created.Version, receipt.ConsentVersion)
+	ts.Equal("ACTIVE", receipt.ConsentStatus)
+	ts.Equal("receipt-user", receipt.PiiPrincipalID)
+	ts.Equal(created.CreatedTime/1000, receipt.ConsentTimestamp)
+	ts.NotEmpty(receipt.ConsentReceiptID)
+	ts.Require().Len(receipt.PiiControllers, 1)
+	ts.Equal("Test Bank", receipt.PiiControllers[0].PiiController)
+	ts.Require().Len(receipt.Services, 1)
+	ts.Equal("accounts", receipt.Services[0].Service)
+	ts.Require().Len(receipt.Services[0].Purposes, 1)
+	ts.Equal("EXPLICIT", receipt.Services[0].Purposes[0].ConsentType)
+	ts.False(receipt.Services[0].Purposes[0].PrimaryPurpose)
+	ts.Equal(testClientID, receipt.Services[0].Purposes[0].ThirdPartyName)
+
+	// The JWS payload is the receipt and verifies with the published key
+	parts := strings.Split(receiptResp.JWS, ".")
+	ts.Require().Len(parts, 3)
+
+	signedPayload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	ts.Require().NoError(err)
+	var signedReceipt map[string]interface{}
+	ts.Require().NoError(json.Unmarshal(signedPayload, &signedReceipt))
+	ts.Equal(receipt.ConsentReceiptID, signedReceipt["consentReceiptID"])
+
+	block, _ := pem.Decode([]byte(receipt.PublicKey))
+	ts.Require().NotNil(block)
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	ts.Require().NoError(err)
+	ecKey, ok := publicKey.(*ecdsa.PublicKey)
+	ts.Require().True(ok, "test signing key is an EC key")
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	ts.Require().NoError(err)
+	ts.Require().Len(signature, 64)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	ts.True(ecdsa.Verify(ecKey, digest[:], r, s), "receipt signature should verify")
+}
+
+// TestGetConsentReceipt_NonExistentConsent_ReturnsNotFound checks receipts are not issued for
+// unknown consents
+func (ts *ConsentAPITestSuite) TestGetConsentReceipt_NonExistentConsent_ReturnsNotFound() {
+	resp, _ := ts.getConsentReceipt("00000000-0000-0000-0000-000000000000")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// GET /relationships - Relationship Summary Tests
+// ============================
+
+// getRelationship retrieves the relationship summary for a user and client
+func (ts *ConsentAPITestSuite) getRelationship(userID, clientID string) (*http.Response, []byte) {
+	query := url.Values{}
+	if userID != "" {
+		query.Set("userId", userID)
+	}
+	if clientID != "" {
+		query.Set("clientId", clientID)
+	}
+	reqURL := fmt.Sprintf("%s/api/v1/relationships?%s", testServerURL, query.Encode())
+
+	httpReq, _ := http.NewRequest("GET", reqURL, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestGetRelationship_ActiveAndRevokedConsents_SummarizesActiveOnly aggregates active consents of a user
+func (ts *ConsentAPITestSuite) TestGetRelationship_ActiveAndRevokedConsents_SummarizesActiveOnly() {
+	userID := fmt.Sprintf("relationship-user-%d", time.Now().UnixNano())
+	earlyExpiry := time.Now().Add(24 * time.Hour).UnixMilli()
+	lateExpiry := time.Now().Add(48 * time.Hour).UnixMilli()
+
+	payloads := []ConsentCreateRequest{
+		{
+			Type:         "accounts",
+			ValidityTime: lateExpiry,
+			ConsentPurpose: []ConsentPurposeItem{
+				{Name: "marketing-purpose", IsUserApproved: true},
+			},
+			Authorizations: []AuthorizationRequest{{UserID: userID, Type: "auth", Status: "APPROVED"}},
+		},
+		{
+			Type:         "accounts",
+			ValidityTime: earlyExpiry,
+			ConsentPurpose: []ConsentPurposeItem{
+				{Name: "analytics-purpose", IsUserApproved: true},
+				{Name: "marketing-purpose", IsUserApproved: false},
+			},
+			Authorizations: []AuthorizationRequest{{UserID: userID, Type: "auth", Status: "APPROVED"}},
+		},
+		{
+			Type: "accounts",
+			ConsentPurpose: []ConsentPurposeItem{
+				{Name: "analytics-purpose", IsUserApproved: true},
+			},
+			Authorizations: []AuthorizationRequest{{UserID: userID, Type: "auth", Status: "APPROVED"}},
+		},
+	}
+
+	consentIDs := make([]string, 0, len(payloads))
+	for _, payload := range payloads {
+		createResp, createBody := ts.createConsent(payload)
+		createResp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+
+		var created ConsentResponse
+		ts.Require().NoError(json.Unmarshal(createBody, &created))
+		ts.trackConsent(created.ID)
+		consentIDs = append(consentIDs, created.ID)
+	}
+
+	// Revoke the consent without expiry so only the first two remain active
+	revokeResp, revokeBody := ts.revokeConsent(consentIDs[2], "relationship test")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	resp, body := ts.getRelationship(userID, testClientID)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var relationship RelationshipResponse
+	ts.Require().NoError(json.Unmarshal(body, &relationship))
+
+	ts.Equal(userID, relationship.UserID)
+	ts.Equal(testClientID, relationship.ClientID)
+	ts.Equal(3, relationship.TotalConsents)
+	ts.Len(relationship.ActiveConsents, 2)
+	ts.Equal([]string{"analytics-purpose", "marketing-purpose"}, relationship.ApprovedPurposes)
+	ts.Require().NotNil(relationship.EarliestExpiry)
+	ts.Equal(earlyExpiry, *relationship.EarliestExpiry)
+	ts.NotNil(relationship.LastActivityTime)
+}
+
+// TestGetRelationship_NoConsents_ReturnsEmptySummary returns an empty summary for an unknown user
+func (ts *ConsentAPITestSuite) TestGetRelationship_NoConsents_ReturnsEmptySummary() {
+	resp, body := ts.getRelationship("relationship-unknown-user", testClientID)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var relationship RelationshipResponse
+	ts.Require().NoError(json.Unmarshal(body, &relationship))
+
+	ts.Equal(0, relationship.TotalConsents)
+	ts.Empty(relationship.ActiveConsents)
+	ts.Empty(relationship.ApprovedPurposes)
+	ts.Nil(relationship.EarliestExpiry)
+	ts.Nil(relationship.LastActivityTime)
+}
+
+// TestGetRelationship_MissingClientID_ReturnsBadRequest requires both query parameters
+func (ts *ConsentAPITestSuite) TestGetRelationship_MissingClientID_ReturnsBadRequest() {
+	resp, body := ts.getRelationship("user1", "")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
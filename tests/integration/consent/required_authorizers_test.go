@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// getRequiredAuthorizers retrieves the required authorizers of a consent
+func (ts *ConsentAPITestSuite) getRequiredAuthorizers(consentID string) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consents/%s/authorizers", testServerURL, consentID)
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// createJointConsent creates a consent requiring two account holders, with the given authorization statuses
+func (ts *ConsentAPITestSuite) createJointConsent(holderStatus, coHolderStatus string) ConsentResponse {
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: "account-holder", Status: holderStatus},
+			{UserID: "user2", Type: "co-account-holder", Status: coHolderStatus},
+		},
+		RequiredAuthorizers: []string{"account-holder", "co-account-holder"},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	return created
+}
+
+// TestRequiredAuthorizers_PartiallyAuthorizedUntilAllApprove checks that a consent stays partially
+// authorized while a required authorizer has not approved it, and activates once all have
+func (ts *ConsentAPITestSuite) TestRequiredAuthorizers_PartiallyAuthorizedUntilAllApprove() {
+	created := ts.createJointConsent("APPROVED", "CREATED")
+	ts.Equal("PARTIALLY_AUTHORIZED", created.Status)
+
+	resp, body := ts.getRequiredAuthorizers(created.ID)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var authorizers RequiredAuthorizersResponse
+	ts.Require().NoError(json.Unmarshal(body, &authorizers))
+	ts.Equal("PARTIALLY_AUTHORIZED", authorizers.ConsentStatus)
+	ts.Equal([]string{"co-account-holder"}, authorizers.Pending)
+	ts.Require().Len(authorizers.Authorizers, 2)
+	ts.Equal("account-holder", authorizers.Authorizers[0].Role)
+	ts.Equal("APPROVED", authorizers.Authorizers[0].Status)
+	ts.Equal("co-account-holder", authorizers.Authorizers[1].Role)
+	ts.Equal("PENDING", authorizers.Authorizers[1].Status)
+	ts.Equal([]string{created.Authorizations[1].ID}, authorizers.Authorizers[1].AuthorizationIDs)
+
+	resp, body = ts.patchAuthorization(created.ID, created.Authorizations[1].ID, AuthorizationPatchRequest{Status: "APPROVED"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Equal("ACTIVE", ts.consentStatus(created.ID))
+
+	resp, body = ts.getRequiredAuthorizers(created.ID)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Require().NoError(json.Unmarshal(body, &authorizers))
+	ts.Empty(authorizers.Pending)
+}
+
+// TestRequiredAuthorizers_RejectionRejectsConsent checks that a rejecting authorizer rejects the consent
+func (ts *ConsentAPITestSuite) TestRequiredAuthorizers_RejectionRejectsConsent() {
+	created := ts.createJointConsent("APPROVED", "CREATED")
+
+	resp, body := ts.patchAuthorization(created.ID, created.Authorizations[1].ID, AuthorizationPatchRequest{Status: "REJECTED"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Equal("REJECTED", ts.consentStatus(created.ID))
+}
+
+// TestRequiredAuthorizers_NoneApprovedStaysCreated checks that a consent no authorizer has approved stays created
+func (ts *ConsentAPITestSuite) TestRequiredAuthorizers_NoneApprovedStaysCreated() {
+	created := ts.createJointConsent("CREATED", "CREATED")
+	ts.Equal("CREATED", created.Status)
+}
+
+// TestRequiredAuthorizers_WithoutRequiredAuthorizers checks that consents without required
+// authorizers report none
+func (ts *ConsentAPITestSuite) TestRequiredAuthorizers_WithoutRequiredAuthorizers() {
+	created := ts.createConsentWithAuthStatuses("accounts", "APPROVED")
+	ts.Equal("ACTIVE", created.Status)
+
+	resp, body := ts.getRequiredAuthorizers(created.ID)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var authorizers RequiredAuthorizersResponse
+	ts.Require().NoError(json.Unmarshal(body, &authorizers))
+	ts.Empty(authorizers.Authorizers)
+	ts.Empty(authorizers.Pending)
+}
+
+// TestRequiredAuthorizers_DuplicateRole checks that a required authorizer role cannot be listed twice
+func (ts *ConsentAPITestSuite) TestRequiredAuthorizers_DuplicateRole() {
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type:                "accounts",
+		Authorizations:      []AuthorizationRequest{{UserID: "user1", Type: "account-holder", Status: "APPROVED"}},
+		RequiredAuthorizers: []string{"account-holder", "account-holder"},
+	})
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+// TestRequiredAuthorizers_UnknownConsent checks that the authorizers of an unknown consent are not found
+func (ts *ConsentAPITestSuite) TestRequiredAuthorizers_UnknownConsent() {
+	resp, body := ts.getRequiredAuthorizers("00000000-0000-0000-0000-000000000000")
+	resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
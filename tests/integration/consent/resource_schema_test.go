@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ==========================================
+// /admin/auth-resource-schemas Tests
+// ==========================================
+
+// resourceSchemaAuthType is only used by these tests, so its schema does not affect other consents
+const resourceSchemaAuthType = "schema-accounts"
+
+// accountIDsSchema requires resources to be a non-empty list of ACC- account IDs
+var accountIDsSchema = map[string]interface{}{
+	"$schema":  "https://json-schema.org/draft/2020-12/schema",
+	"type":     "array",
+	"minItems": 1,
+	"items":    map[string]interface{}{"type": "string", "pattern": "^ACC-[0-9]{6}$"},
+}
+
+// sendResourceSchemaRequest calls the admin resources schema API for the test organization. An
+// empty authType addresses the schema collection.
+func (ts *ConsentAPITestSuite) sendResourceSchemaRequest(method, authType string, payload interface{}) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/admin/auth-resource-schemas", testServerURL)
+	if authType != "" {
+		url += "/" + authType
+	}
+	httpReq, _ := http.NewRequest(method, url, reqBody)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// putResourceSchema defines the account ID schema for resourceSchemaAuthType. The caller must delete it again.
+func (ts *ConsentAPITestSuite) putResourceSchema() {
+	resp, body := ts.sendResourceSchemaRequest("PUT", resourceSchemaAuthType, map[string]interface{}{"schema": accountIDsSchema})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+}
+
+// deleteResourceSchema removes the schema of resourceSchemaAuthType
+func (ts *ConsentAPITestSuite) deleteResourceSchema() {
+	resp, _ := ts.sendResourceSchemaRequest("DELETE", resourceSchemaAuthType, nil)
+	resp.Body.Close()
+}
+
+// createConsentWithResources creates an active consent with one authorization of the given type and resources
+func (ts *ConsentAPITestSuite) createConsentWithResources(authType string, resources []string) (*http.Response, []byte) {
+	return ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: "user1", Type: authType, Status: "APPROVED", Resources: resources},
+		},
+	})
+}
+
+// TestResourceSchema_PutGetListDelete stores a schema, reads it back and removes it
+func (ts *ConsentAPITestSuite) TestResourceSchema_PutGetListDelete() {
+	ts.putResourceSchema()
+	defer ts.deleteResourceSchema()
+
+	resp, body := ts.sendResourceSchemaRequest("GET", resourceSchemaAuthType, nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var schema ResourceSchemaResponse
+	ts.Require().NoError(json.Unmarshal(body, &schema))
+	ts.Equal(testOrgID, schema.OrgID)
+	ts.Equal(resourceSchemaAuthType, schema.AuthType)
+	ts.Equal("array", schema.Schema["type"])
+
+	resp, body = ts.sendResourceSchemaRequest("GET", "", nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list struct {
+		Data []ResourceSchemaResponse `json:"data"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	found := false
+	for _, s := range list.Data {
+		found = found || s.AuthType == resourceSchemaAuthType
+	}
+	ts.True(found, string(body))
+
+	resp, body = ts.sendResourceSchemaRequest("DELETE", resourceSchemaAuthType, nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+
+	resp, body = ts.sendResourceSchemaRequest("GET", resourceSchemaAuthType, nil)
+	resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestResourceSchema_InvalidDefinition_Rejected checks that schemas the server cannot enforce are not stored
+func (ts *ConsentAPITestSuite) TestResourceSchema_InvalidDefinition_Rejected() {
+	testCases := []struct {
+		name      string
+		schema    interface{}
+		errorText string
+	}{
+		{"missing schema", nil, "schema is required"},
+		{"unsupported keyword", map[string]interface{}{"oneOf": []interface{}{}}, "unsupported keyword 'oneOf'"},
+		{"invalid pattern", map[string]interface{}{"type": "string", "pattern": "("}, "pattern at $ is invalid"},
+		{"unknown type", map[string]interface{}{"type": "date"}, "unsupported value 'date'"},
+		{"not an object", "array", "must be an object or a boolean"},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.sendResourceSchemaRequest("PUT", resourceSchemaAuthType, map[string]interface{}{"schema": tc.schema})
+			defer resp.Body.Close()
+			ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+			ts.True(strings.Contains(string(body), tc.errorText), string(body))
+		})
+	}
+
+	resp, body := ts.sendResourceSchemaRequest("GET", resourceSchemaAuthType, nil)
+	defer resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestResourceSchema_EnforcedOnCreate checks that only resources of the schema's authorization type are validated
+func (ts *ConsentAPITestSuite) TestResourceSchema_EnforcedOnCreate() {
+	ts.putResourceSchema()
+	defer ts.deleteResourceSchema()
+
+	testCases := []struct {
+		name      string
+		resources []string
+		errorText string
+	}{
+		{"malformed account ID", []string{"ACC-000001", "12345"}, "$[1] does not match pattern"},
+		{"empty list", []string{}, "must have at least 1 items"},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			payload := fmt.Sprintf(`{"type":"accounts","authorizations":[{"userId":"user1","type":"%s","status":"APPROVED","resources":%s}]}`,
+				resourceSchemaAuthType, mustJSON(tc.resources))
+			resp, body := ts.createConsent(payload)
+			defer resp.Body.Close()
+			ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+			ts.True(strings.Contains(string(body), tc.errorText), string(body))
+		})
+	}
+
+	for _, authType := range []string{resourceSchemaAuthType, "accounts"} {
+		resources := []string{"ACC-000001"}
+		if authType == "accounts" {
+			resources = []string{"free-form"}
+		}
+		resp, body := ts.createConsentWithResources(authType, resources)
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+		var created ConsentResponse
+		ts.Require().NoError(json.Unmarshal(body, &created))
+		ts.trackConsent(created.ID)
+	}
+}
+
+// TestResourceSchema_EnforcedOnAuthorizationUpdate checks resources appended to an authorization
+// against the schema
+func (ts *ConsentAPITestSuite) TestResourceSchema_EnforcedOnAuthorizationUpdate() {
+	ts.putResourceSchema()
+	defer ts.deleteResourceSchema()
+
+	resp, body := ts.createConsentWithResources(resourceSchemaAuthType, []string{"ACC-000001"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	ts.Require().Len(created.Authorizations, 1)
+	authID := created.Authorizations[0].ID
+
+	resp, body = ts.patchAuthorization(created.ID, authID, AuthorizationPatchRequest{Resources: []string{"acc-2"}})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "do not match the schema for authorization type")
+
+	resp, body = ts.patchAuthorization(created.ID, authID, AuthorizationPatchRequest{Resources: []string{"ACC-000002"}})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var auth AuthorizationResponse
+	ts.Require().NoError(json.Unmarshal(body, &auth))
+	ts.Equal([]interface{}{"ACC-000001", "ACC-000002"}, auth.Resources)
+}
+
+// mustJSON encodes v, which must be JSON serializable
+func mustJSON(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
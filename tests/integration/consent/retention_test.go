@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// ============================
+// Consent Retention Tests
+// ============================
+
+// retentionPurgedConsentIDs returns the consent IDs listed by the retention audit entries of the test organization
+func (ts *ConsentAPITestSuite) retentionPurgedConsentIDs() []string {
+	page := ts.searchOperations(url.Values{"orgId": {testOrgID}, "action": {"consent.retention_purge"}, "limit": {"100"}})
+
+	consentIDs := make([]string, 0)
+	for _, entry := range page.Data {
+		var details struct {
+			Action     string   `json:"action"`
+			ConsentIDs []string `json:"consentIds"`
+		}
+		ts.Require().NoError(json.Unmarshal(entry.Details, &details))
+		ts.Equal("anonymize", details.Action)
+		ts.Equal("system", entry.Actor)
+		consentIDs = append(consentIDs, details.ConsentIDs...)
+	}
+	return consentIDs
+}
+
+// TestRetention_AnonymizesRevokedConsentsOfOrganization checks that revoked consents of an
+// organization with a retention period of zero days are anonymized and audited, and that active
+// consents are kept as they are
+func (ts *ConsentAPITestSuite) TestRetention_AnonymizesRevokedConsentsOfOrganization() {
+	resp, body := ts.sendOrganizationRequest("POST", "", map[string]interface{}{
+		"orgId":                 testOrgID,
+		"name":                  "Test Organization",
+		"terminalRetentionDays": 0,
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	defer ts.deleteOrganization(testOrgID)
+
+	auths := []AuthorizationRequest{{UserID: "retention-user", Type: "authorisation", Status: "APPROVED", Resources: []string{"acc-1"}}}
+	revokedID := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", map[string]string{"email": "user@example.com"}, auths))
+	activeID := ts.createConsentOrFail(compositeSearchConsentRequest("marketing-purpose", map[string]string{"email": "user@example.com"}, auths))
+
+	revokeResp, revokeBody := ts.revokeConsent(revokedID, "No longer needed")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	ts.Require().Eventually(func() bool {
+		return slices.Contains(ts.retentionPurgedConsentIDs(), revokedID)
+	}, 10*time.Second, 200*time.Millisecond, "revoked consent was not purged")
+
+	getResp, getBody := ts.getConsent(revokedID)
+	getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+	var anonymized ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &anonymized))
+	ts.Equal("REVOKED", anonymized.Status)
+	ts.Empty(anonymized.Attributes)
+	ts.Require().Len(anonymized.Authorizations, 1)
+	ts.Nil(anonymized.Authorizations[0].UserID)
+	ts.Empty(anonymized.Authorizations[0].Resources)
+
+	// Active consents are kept, and anonymized consents are not purged again
+	time.Sleep(2 * time.Second)
+	ts.NotContains(ts.retentionPurgedConsentIDs(), activeID)
+	count := 0
+	for _, consentID := range ts.retentionPurgedConsentIDs() {
+		if consentID == revokedID {
+			count++
+		}
+	}
+	ts.Equal(1, count)
+
+	getResp, getBody = ts.getConsent(activeID)
+	getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+	var active ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &active))
+	ts.Equal(map[string]string{"email": "user@example.com"}, active.Attributes)
+}
@@ -19,8 +19,15 @@
 package consent
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
 )
 
 // ============================
@@ -240,3 +247,224 @@ func (ts *ConsentAPITestSuite) TestRevokeConsent_InvalidConsentID_ReturnsBadRequ
 
 	ts.Equal(http.StatusBadRequest, resp.StatusCode)
 }
+
+// revokeConsentWith revokes a consent with the given revoke payload
+func (ts *ConsentAPITestSuite) revokeConsentWith(consentID string, payload map[string]interface{}) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	httpReq, _ := http.NewRequest("PUT", fmt.Sprintf("%s/api/v1/consents/%s/revoke", testServerURL, consentID), bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// createPartiallyRevocableConsent creates an ACTIVE consent with a mandatory and an optional
+// purpose and two approved authorizations
+func (ts *ConsentAPITestSuite) createPartiallyRevocableConsent() ConsentResponse {
+	createResp, createBody := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "terms-purpose", Value: "v1", IsUserApproved: true, IsMandatory: true},
+			{Name: "marketing-purpose", Value: "email", IsUserApproved: true, IsMandatory: false},
+		},
+		Authorizations: []AuthorizationRequest{
+			{UserID: "partial-user-1", Type: "auth", Status: "APPROVED"},
+			{UserID: "partial-user-2", Type: "auth", Status: "APPROVED"},
+		},
+	})
+	defer createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+	ts.Require().Equal("ACTIVE", created.Status)
+	return created
+}
+
+// TestRevokeConsent_PartialScope_KeepsConsentActive revokes an optional purpose and one of two
+// authorizations and checks that the consent stays valid with the rest, and that the revoked
+// subset is recorded in the status audit
+func (ts *ConsentAPITestSuite) TestRevokeConsent_PartialScope_KeepsConsentActive() {
+	created := ts.createPartiallyRevocableConsent()
+	revokedAuthID := created.Authorizations[1].ID
+	actor := fmt.Sprintf("partial-revoker-%d", time.Now().UnixNano())
+
+	resp, body := ts.revokeConsentWith(created.ID, map[string]interface{}{
+		"actionBy":         actor,
+		"revocationReason": "stop marketing",
+		"purposeNames":     []string{"marketing-purpose"},
+		"authorizationIds": []string{revokedAuthID},
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var revoked ConsentRevokedResponse
+	ts.Require().NoError(json.Unmarshal(body, &revoked))
+	ts.Equal("ACTIVE", revoked.ConsentStatus)
+	ts.Equal([]string{"marketing-purpose"}, revoked.RevokedPurposes)
+	ts.Equal([]string{revokedAuthID}, revoked.RevokedAuthorizationIDs)
+
+	getResp, getBody := ts.getConsent(created.ID)
+	defer getResp.Body.Close()
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &consent))
+	ts.Equal("ACTIVE", consent.Status)
+	for _, purpose := range consent.ConsentPurpose {
+		ts.Equal(purpose.Name == "terms-purpose", purpose.IsUserApproved, purpose.Name)
+	}
+	for _, authorization := range consent.Authorizations {
+		if authorization.ID == revokedAuthID {
+			ts.Equal("SYS_REVOKED", authorization.Status)
+		} else {
+			ts.Equal("APPROVED", authorization.Status)
+		}
+	}
+
+	validateResp, validateBody := ts.validateConsent(ConsentValidateRequest{
+		ConsentID:         created.ID,
+		RequestedPurposes: []string{"terms-purpose", "marketing-purpose"},
+	})
+	defer validateResp.Body.Close()
+	var validation ConsentValidateResponse
+	ts.Require().NoError(json.Unmarshal(validateBody, &validation))
+	ts.True(validation.IsValid, string(validateBody))
+	ts.Equal("GRANT", validation.PurposeDecisions["terms-purpose"].Decision)
+	ts.Equal("DENY", validation.PurposeDecisions["marketing-purpose"].Decision)
+
+	auditResp, auditBody := ts.searchStatusAudit(url.Values{"orgId": {testOrgID}, "actionBy": {actor}}, true)
+	defer auditResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, auditResp.StatusCode, string(auditBody))
+	var audits StatusAuditSearchResponse
+	ts.Require().NoError(json.Unmarshal(auditBody, &audits))
+	ts.Require().Len(audits.Data, 1)
+	ts.Equal("ACTIVE", audits.Data[0].CurrentStatus)
+	ts.Equal("ACTIVE", audits.Data[0].PreviousStatus)
+	var reason map[string]interface{}
+	ts.Require().NoError(json.Unmarshal([]byte(audits.Data[0].Reason), &reason))
+	ts.Equal("stop marketing", reason["revocationReason"])
+	ts.Equal([]interface{}{"marketing-purpose"}, reason["revokedPurposes"])
+	ts.Equal([]interface{}{revokedAuthID}, reason["revokedAuthorizationIds"])
+}
+
+// TestRevokeConsent_PartialScope_MandatoryPurposeRevokesConsent checks that revoking a mandatory
+// purpose revokes the whole consent
+func (ts *ConsentAPITestSuite) TestRevokeConsent_PartialScope_MandatoryPurposeRevokesConsent() {
+	created := ts.createPartiallyRevocableConsent()
+
+	resp, body := ts.revokeConsentWith(created.ID, map[string]interface{}{
+		"actionBy":     "test-user",
+		"purposeNames": []string{"terms-purpose"},
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var revoked ConsentRevokedResponse
+	ts.Require().NoError(json.Unmarshal(body, &revoked))
+	ts.Equal("REVOKED", revoked.ConsentStatus)
+	ts.Equal([]string{"terms-purpose"}, revoked.RevokedPurposes)
+
+	getResp, getBody := ts.getConsent(created.ID)
+	defer getResp.Body.Close()
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &consent))
+	ts.Equal("REVOKED", consent.Status)
+	for _, authorization := range consent.Authorizations {
+		ts.Equal("SYS_REVOKED", authorization.Status)
+	}
+}
+
+// TestRevokeConsent_PartialScope_LastAuthorizationRevokesConsent checks that revoking every
+// authorization revokes the consent, and that unknown purposes are rejected
+func (ts *ConsentAPITestSuite) TestRevokeConsent_PartialScope_LastAuthorizationRevokesConsent() {
+	created := ts.createPartiallyRevocableConsent()
+
+	resp, body := ts.revokeConsentWith(created.ID, map[string]interface{}{
+		"actionBy":     "test-user",
+		"purposeNames": []string{"unknown-purpose"},
+	})
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = ts.revokeConsentWith(created.ID, map[string]interface{}{
+		"actionBy":         "test-user",
+		"authorizationIds": []string{created.Authorizations[0].ID},
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var revoked ConsentRevokedResponse
+	ts.Require().NoError(json.Unmarshal(body, &revoked))
+	ts.Equal("ACTIVE", revoked.ConsentStatus)
+
+	resp, body = ts.revokeConsentWith(created.ID, map[string]interface{}{
+		"actionBy":         "test-user",
+		"authorizationIds": []string{created.Authorizations[1].ID},
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Require().NoError(json.Unmarshal(body, &revoked))
+	ts.Equal("REVOKED", revoked.ConsentStatus)
+}
+
+// TestRevokeConsent_PartialScope_AlreadyRevokedConflicts checks that purposes and authorizations
+// revoked earlier cannot be revoked again
+func (ts *ConsentAPITestSuite) TestRevokeConsent_PartialScope_AlreadyRevokedConflicts() {
+	created := ts.createPartiallyRevocableConsent()
+	revokedAuthID := created.Authorizations[1].ID
+
+	resp, body := ts.revokeConsentWith(created.ID, map[string]interface{}{
+		"actionBy":         "test-user",
+		"purposeNames":     []string{"marketing-purpose"},
+		"authorizationIds": []string{revokedAuthID},
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = ts.revokeConsentWith(created.ID, map[string]interface{}{
+		"actionBy":     "test-user",
+		"purposeNames": []string{"marketing-purpose"},
+	})
+	ts.Equal(http.StatusConflict, resp.StatusCode, string(body))
+
+	resp, body = ts.revokeConsentWith(created.ID, map[string]interface{}{
+		"actionBy":         "test-user",
+		"authorizationIds": []string{revokedAuthID},
+	})
+	ts.Equal(http.StatusConflict, resp.StatusCode, string(body))
+}
+
+// TestRevokeConsent_PartialScope_StatusMachineRejectsDisallowedRevocation checks that a partial
+// revocation is refused when the state machine does not allow the consent to be revoked, even
+// when the consent would remain
+func (ts *ConsentAPITestSuite) TestRevokeConsent_PartialScope_StatusMachineRejectsDisallowedRevocation() {
+	_, err := testutils.SetFeatureFlag(testOrgID, "status_machine", true)
+	ts.Require().NoError(err)
+	defer testutils.ClearFeatureFlag(testOrgID, "status_machine")
+
+	createResp, createBody := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "terms-purpose", Value: "v1", IsUserApproved: true, IsMandatory: true},
+			{Name: "marketing-purpose", Value: "email", IsUserApproved: true, IsMandatory: false},
+		},
+		Authorizations: []AuthorizationRequest{
+			{UserID: "partial-user-1", Type: "auth", Status: "APPROVED"},
+			{UserID: "partial-user-2", Type: "auth", Status: "REJECTED"},
+		},
+	})
+	defer createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+	ts.Require().Equal("REJECTED", created.Status)
+
+	resp, body := ts.revokeConsentWith(created.ID, map[string]interface{}{
+		"actionBy":     "test-user",
+		"purposeNames": []string{"marketing-purpose"},
+	})
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "cannot change from 'REJECTED' to 'REVOKED'")
+}
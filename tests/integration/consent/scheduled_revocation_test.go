@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Scheduled Revocation Tests
+// ============================
+
+// sendScheduledRevocationRequest gets or cancels the scheduled revocation of a consent
+func (ts *ConsentAPITestSuite) sendScheduledRevocationRequest(method, consentID string) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest(method, fmt.Sprintf("%s/api/v1/consents/%s/scheduled-revocation", testServerURL, consentID), nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	return resp, body
+}
+
+// createScheduledRevocationConsent creates an ACTIVE consent
+func (ts *ConsentAPITestSuite) createScheduledRevocationConsent() string {
+	createResp, createBody := ts.createConsent(ConsentCreateRequest{
+		Type:           "accounts",
+		Authorizations: []AuthorizationRequest{{UserID: "scheduled-user", Type: "auth", Status: "APPROVED"}},
+	})
+	defer createResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, createResp.StatusCode, string(createBody))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(createBody, &created))
+	ts.trackConsent(created.ID)
+	ts.Require().Equal("ACTIVE", created.Status)
+	return created.ID
+}
+
+// TestScheduledRevocation_AppliedWhenEffective checks that a revocation with a future effectiveAt
+// is accepted without changing the consent, and applied by the job once the server time passes it
+func (ts *ConsentAPITestSuite) TestScheduledRevocation_AppliedWhenEffective() {
+	now := time.Now()
+	_, err := testutils.FreezeServerTime(now)
+	ts.Require().NoError(err)
+	defer testutils.ResetServerTime()
+
+	consentID := ts.createScheduledRevocationConsent()
+	effectiveAt := now.Add(time.Hour).Unix()
+
+	resp, body := ts.revokeConsentWith(consentID, map[string]interface{}{
+		"actionBy":         "notice-period",
+		"revocationReason": "contract terminated",
+		"effectiveAt":      effectiveAt,
+	})
+	ts.Require().Equal(http.StatusAccepted, resp.StatusCode, string(body))
+
+	var revoked struct {
+		ConsentStatus       string                       `json:"consentStatus"`
+		ScheduledRevocation *ScheduledRevocationResponse `json:"scheduledRevocation"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &revoked))
+	ts.Equal("ACTIVE", revoked.ConsentStatus)
+	ts.Require().NotNil(revoked.ScheduledRevocation)
+	ts.Equal(effectiveAt, revoked.ScheduledRevocation.EffectiveAt)
+	ts.Equal("ACTIVE", ts.consentStatus(consentID))
+
+	getResp, getBody := ts.sendScheduledRevocationRequest("GET", consentID)
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+	var scheduled ScheduledRevocationResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &scheduled))
+	ts.Equal(consentID, scheduled.ConsentID)
+	ts.Equal("notice-period", scheduled.ActionBy)
+	ts.Equal("contract terminated", scheduled.RevocationReason)
+	ts.Equal(now.Unix(), scheduled.ScheduledTime)
+
+	_, err = testutils.AdvanceServerTime(2 * time.Hour)
+	ts.Require().NoError(err)
+
+	ts.Require().Eventually(func() bool {
+		return ts.consentStatus(consentID) == "REVOKED"
+	}, 10*time.Second, 200*time.Millisecond, "scheduled revocation was not applied")
+
+	getResp, getBody = ts.sendScheduledRevocationRequest("GET", consentID)
+	ts.Equal(http.StatusNotFound, getResp.StatusCode, string(getBody))
+}
+
+// TestScheduledRevocation_Cancelled checks that a cancelled revocation is not applied
+func (ts *ConsentAPITestSuite) TestScheduledRevocation_Cancelled() {
+	now := time.Now()
+	_, err := testutils.FreezeServerTime(now)
+	ts.Require().NoError(err)
+	defer testutils.ResetServerTime()
+
+	consentID := ts.createScheduledRevocationConsent()
+	resp, body := ts.revokeConsentWith(consentID, map[string]interface{}{
+		"actionBy":    "notice-period",
+		"effectiveAt": now.Add(time.Hour).Unix(),
+	})
+	ts.Require().Equal(http.StatusAccepted, resp.StatusCode, string(body))
+
+	deleteResp, deleteBody := ts.sendScheduledRevocationRequest("DELETE", consentID)
+	ts.Require().Equal(http.StatusNoContent, deleteResp.StatusCode, string(deleteBody))
+	deleteResp, _ = ts.sendScheduledRevocationRequest("DELETE", consentID)
+	ts.Equal(http.StatusNotFound, deleteResp.StatusCode)
+
+	_, err = testutils.AdvanceServerTime(2 * time.Hour)
+	ts.Require().NoError(err)
+	time.Sleep(2 * time.Second)
+	ts.Equal("ACTIVE", ts.consentStatus(consentID))
+}
+
+// TestScheduledRevocation_ScheduledWithUpdate checks that a consent update schedules a revocation
+// with a future effectiveAt only, and that revoking the consent drops the scheduled revocation
+func (ts *ConsentAPITestSuite) TestScheduledRevocation_ScheduledWithUpdate() {
+	consentID := ts.createScheduledRevocationConsent()
+
+	updateResp, updateBody := ts.updateConsent(consentID, map[string]interface{}{
+		"scheduledRevocation": map[string]interface{}{"actionBy": "notice-period", "effectiveAt": time.Now().Add(-time.Hour).Unix()},
+	})
+	updateResp.Body.Close()
+	ts.Require().Equal(http.StatusBadRequest, updateResp.StatusCode, string(updateBody))
+
+	effectiveAt := time.Now().Add(24 * time.Hour).Unix()
+	updateResp, updateBody = ts.updateConsent(consentID, map[string]interface{}{
+		"scheduledRevocation": map[string]interface{}{"actionBy": "notice-period", "effectiveAt": effectiveAt},
+	})
+	updateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, updateResp.StatusCode, string(updateBody))
+
+	getResp, getBody := ts.sendScheduledRevocationRequest("GET", consentID)
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+	var scheduled ScheduledRevocationResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &scheduled))
+	ts.Equal(effectiveAt, scheduled.EffectiveAt)
+
+	revokeResp, revokeBody := ts.revokeConsent(consentID, "revoked early")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	getResp, getBody = ts.sendScheduledRevocationRequest("GET", consentID)
+	ts.Equal(http.StatusNotFound, getResp.StatusCode, string(getBody))
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Scheduled Update Tests
+// ============================
+
+// sendScheduledUpdateRequest gets or cancels the scheduled update of a consent
+func (ts *ConsentAPITestSuite) sendScheduledUpdateRequest(method, consentID string) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest(method, fmt.Sprintf("%s/api/v1/consents/%s/scheduled-update", testServerURL, consentID), nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	return resp, body
+}
+
+// consentType returns the type of a consent
+func (ts *ConsentAPITestSuite) consentType(consentID string) string {
+	resp, body := ts.getConsent(consentID)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &consent))
+	return consent.Type
+}
+
+// TestScheduledUpdate_AppliedWhenEffective checks that an update with a future effectiveAt is
+// accepted without changing the consent, and applied by the job once the server time passes it
+func (ts *ConsentAPITestSuite) TestScheduledUpdate_AppliedWhenEffective() {
+	now := time.Now()
+	_, err := testutils.FreezeServerTime(now)
+	ts.Require().NoError(err)
+	defer testutils.ResetServerTime()
+
+	consentID := ts.createScheduledRevocationConsent()
+	effectiveAt := now.Add(time.Hour).Unix()
+
+	resp, body := ts.updateConsent(consentID, map[string]interface{}{
+		"type":        "payments",
+		"effectiveAt": effectiveAt,
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusAccepted, resp.StatusCode, string(body))
+
+	var updated struct {
+		Type            string `json:"type"`
+		ScheduledUpdate *struct {
+			EffectiveAt int64 `json:"effectiveAt"`
+			Update      struct {
+				Type string `json:"type"`
+			} `json:"update"`
+		} `json:"scheduledUpdate"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &updated))
+	ts.Equal("accounts", updated.Type)
+	ts.Require().NotNil(updated.ScheduledUpdate)
+	ts.Equal(effectiveAt, updated.ScheduledUpdate.EffectiveAt)
+	ts.Equal("payments", updated.ScheduledUpdate.Update.Type)
+
+	getResp, getBody := ts.sendScheduledUpdateRequest("GET", consentID)
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+	ts.Equal("accounts", ts.consentType(consentID))
+
+	_, err = testutils.AdvanceServerTime(2 * time.Hour)
+	ts.Require().NoError(err)
+
+	ts.Require().Eventually(func() bool {
+		return ts.consentType(consentID) == "payments"
+	}, 10*time.Second, 200*time.Millisecond, "scheduled update was not applied")
+
+	getResp, getBody = ts.sendScheduledUpdateRequest("GET", consentID)
+	ts.Equal(http.StatusNotFound, getResp.StatusCode, string(getBody))
+}
+
+// TestScheduledUpdate_Cancelled checks that a cancelled update is not applied, and that an update
+// cannot schedule a revocation when it takes effect later
+func (ts *ConsentAPITestSuite) TestScheduledUpdate_Cancelled() {
+	now := time.Now()
+	_, err := testutils.FreezeServerTime(now)
+	ts.Require().NoError(err)
+	defer testutils.ResetServerTime()
+
+	consentID := ts.createScheduledRevocationConsent()
+
+	resp, body := ts.updateConsent(consentID, map[string]interface{}{
+		"type":                "payments",
+		"effectiveAt":         now.Add(time.Hour).Unix(),
+		"scheduledRevocation": map[string]interface{}{"actionBy": "notice-period", "effectiveAt": now.Add(2 * time.Hour).Unix()},
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = ts.updateConsent(consentID, map[string]interface{}{
+		"type":        "payments",
+		"effectiveAt": now.Add(time.Hour).Unix(),
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusAccepted, resp.StatusCode, string(body))
+
+	deleteResp, deleteBody := ts.sendScheduledUpdateRequest("DELETE", consentID)
+	ts.Require().Equal(http.StatusNoContent, deleteResp.StatusCode, string(deleteBody))
+	deleteResp, _ = ts.sendScheduledUpdateRequest("DELETE", consentID)
+	ts.Equal(http.StatusNotFound, deleteResp.StatusCode)
+
+	_, err = testutils.AdvanceServerTime(2 * time.Hour)
+	ts.Require().NoError(err)
+	time.Sleep(2 * time.Second)
+	ts.Equal("accounts", ts.consentType(consentID))
+}
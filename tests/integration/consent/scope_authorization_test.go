@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ===========================
+// Scope-based authorization
+// ===========================
+
+const (
+	reporterUsername = "reporter"
+	reporterPassword = "reporter"
+	// scopeAuthorizationOrgID is the only organization security.authorization applies to in the
+	// test configuration
+	scopeAuthorizationOrgID = "scope-authorization-org"
+)
+
+// callAsUser sends a request to the consent API of the organization authenticated as username. An
+// empty username sends the request without credentials.
+func (ts *ConsentAPITestSuite) callAsUser(orgID, method, path, username, password string, payload interface{}) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	httpReq, _ := http.NewRequest(method, testServerURL+"/api/v1"+path, reqBody)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, orgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+	if username != "" {
+		httpReq.SetBasicAuth(username, password)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// createAuthorizedConsent creates an active consent in the scope authorization organization as the
+// admin, and deletes it when the test ends
+func (ts *ConsentAPITestSuite) createAuthorizedConsent() ConsentResponse {
+	request := ConsentCreateRequest{
+		Type:           "accounts",
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "APPROVED"}},
+	}
+	resp, body := ts.callAsUser(scopeAuthorizationOrgID, "POST", "/consents",
+		testutils.AdminUsername, testutils.AdminPassword, request)
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.T().Cleanup(func() {
+		ts.callAsUser(scopeAuthorizationOrgID, "DELETE", "/consents/"+created.ID,
+			testutils.AdminUsername, testutils.AdminPassword, nil)
+	})
+	return created
+}
+
+// TestScopeAuthorization_OtherOrganizations_AllowAnonymousCalls checks that routes are not
+// authorized for organizations outside security.authorization.org_ids
+func (ts *ConsentAPITestSuite) TestScopeAuthorization_OtherOrganizations_AllowAnonymousCalls() {
+	created := ts.createConsentWithAuthStatuses("accounts", "APPROVED")
+
+	resp, body := ts.callAsUser(testOrgID, "GET", "/consents/"+created.ID, "", "", nil)
+	ts.Equal(http.StatusOK, resp.StatusCode, string(body))
+}
+
+// TestScopeAuthorization_CannotBeDisabledThroughFeatureFlags checks that the admin API cannot turn
+// authorization off
+func (ts *ConsentAPITestSuite) TestScopeAuthorization_CannotBeDisabledThroughFeatureFlags() {
+	created := ts.createAuthorizedConsent()
+
+	_, err := testutils.SetFeatureFlag(scopeAuthorizationOrgID, "scope_authorization", false)
+	ts.Error(err)
+
+	resp, body := ts.callAsUser(scopeAuthorizationOrgID, "GET", "/consents/"+created.ID, "", "", nil)
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode, string(body))
+}
+
+// TestScopeAuthorization_MissingCredentials_Returns401 checks that unauthenticated calls are
+// rejected
+func (ts *ConsentAPITestSuite) TestScopeAuthorization_MissingCredentials_Returns401() {
+	created := ts.createAuthorizedConsent()
+
+	resp, body := ts.callAsUser(scopeAuthorizationOrgID, "GET", "/consents/"+created.ID, "", "", nil)
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode, string(body))
+
+	resp, body = ts.callAsUser(scopeAuthorizationOrgID, "GET", "/consents/"+created.ID, reporterUsername, "wrong", nil)
+	ts.Equal(http.StatusUnauthorized, resp.StatusCode, string(body))
+}
+
+// TestScopeAuthorization_ReadOnlyUser_CannotRevoke checks that a user with only consents:read
+// can read consents but not revoke them
+func (ts *ConsentAPITestSuite) TestScopeAuthorization_ReadOnlyUser_CannotRevoke() {
+	created := ts.createAuthorizedConsent()
+
+	resp, body := ts.callAsUser(scopeAuthorizationOrgID, "GET", "/consents/"+created.ID, reporterUsername, reporterPassword, nil)
+	ts.Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = ts.callAsUser(scopeAuthorizationOrgID, "GET", "/consents", reporterUsername, reporterPassword, nil)
+	ts.Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	revoke := ConsentRevokeRequest{ActionBy: reporterUsername}
+	resp, body = ts.callAsUser(scopeAuthorizationOrgID, "PUT", "/consents/"+created.ID+"/revoke", reporterUsername, reporterPassword, revoke)
+	ts.Equal(http.StatusForbidden, resp.StatusCode, string(body))
+	ts.Contains(string(body), "consents:revoke")
+
+	// A user without configured scopes is granted all of them
+	resp, body = ts.callAsUser(scopeAuthorizationOrgID, "PUT", "/consents/"+created.ID+"/revoke", testutils.AdminUsername, testutils.AdminPassword, revoke)
+	ts.Equal(http.StatusOK, resp.StatusCode, string(body))
+}
+
+// TestScopeAuthorization_ReadOnlyUser_CannotManagePurposes checks that purpose writes require
+// purposes:admin
+func (ts *ConsentAPITestSuite) TestScopeAuthorization_ReadOnlyUser_CannotManagePurposes() {
+	purpose := map[string]interface{}{"name": "scope_authorization_purpose", "type": "string"}
+	resp, body := ts.callAsUser(scopeAuthorizationOrgID, "POST", "/consent-purposes", reporterUsername, reporterPassword, purpose)
+	ts.Equal(http.StatusForbidden, resp.StatusCode, string(body))
+
+	resp, body = ts.callAsUser(scopeAuthorizationOrgID, "GET", "/consent-purposes", reporterUsername, reporterPassword, nil)
+	ts.Equal(http.StatusOK, resp.StatusCode, string(body))
+}
+
+// TestScopeAuthorization_RouteOverride_RequiresConfiguredScope checks that a route mapped under
+// security.authorization.route_scopes requires the configured scope instead of its default
+func (ts *ConsentAPITestSuite) TestScopeAuthorization_RouteOverride_RequiresConfiguredScope() {
+	created := ts.createAuthorizedConsent()
+
+	// The test configuration maps the receipt route to consents:write
+	resp, body := ts.callAsUser(scopeAuthorizationOrgID, "GET", "/consents/"+created.ID+"/receipt", reporterUsername, reporterPassword, nil)
+	ts.Equal(http.StatusForbidden, resp.StatusCode, string(body))
+	ts.Contains(string(body), "consents:write")
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Consent status state machine
+// ============================
+
+// createConsentWithAuthStatuses creates a consent of a type with one authorization per status and returns it
+func (ts *ConsentAPITestSuite) createConsentWithAuthStatuses(consentType string, statuses ...string) ConsentResponse {
+	authorizations := make([]AuthorizationRequest, 0, len(statuses))
+	for _, status := range statuses {
+		authorizations = append(authorizations, AuthorizationRequest{UserID: "user1", Type: "payment", Status: status})
+	}
+
+	resp, body := ts.createConsent(ConsentCreateRequest{Type: consentType, Authorizations: authorizations})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+	return created
+}
+
+// TestCreateConsent_MappedAuthStatus_DerivesConfiguredStatus checks that an authorization status
+// mapped in the state machine derives its configured consent status
+func (ts *ConsentAPITestSuite) TestCreateConsent_MappedAuthStatus_DerivesConfiguredStatus() {
+	created := ts.createConsentWithAuthStatuses("accounts", "PENDING_REVIEW")
+	ts.Equal("AWAITING_REVIEW", created.Status)
+
+	// Additional states take precedence over active
+	created = ts.createConsentWithAuthStatuses("accounts", "APPROVED", "PENDING_REVIEW")
+	ts.Equal("AWAITING_REVIEW", created.Status)
+
+	// Rejected and created take precedence over additional states
+	created = ts.createConsentWithAuthStatuses("accounts", "PENDING_REVIEW", "CREATED")
+	ts.Equal("CREATED", created.Status)
+	created = ts.createConsentWithAuthStatuses("accounts", "PENDING_REVIEW", "REJECTED")
+	ts.Equal("REJECTED", created.Status)
+}
+
+// TestUpdateConsent_StatusMachineDisabled_AllowsAnyTransition checks that transitions are not
+// enforced while the status_machine flag is off
+func (ts *ConsentAPITestSuite) TestUpdateConsent_StatusMachineDisabled_AllowsAnyTransition() {
+	created := ts.createConsentWithAuthStatuses("accounts", "APPROVED")
+	ts.Require().Equal("ACTIVE", created.Status)
+
+	resp, body := ts.updateConsent(created.ID, ConsentUpdateRequest{
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "CREATED"}},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var updated ConsentResponse
+	ts.NoError(json.Unmarshal(body, &updated))
+	ts.Equal("CREATED", updated.Status)
+}
+
+// TestUpdateConsent_StatusMachineEnabled_RejectsDisallowedTransition checks that an update moving
+// an active consent back to created is rejected
+func (ts *ConsentAPITestSuite) TestUpdateConsent_StatusMachineEnabled_RejectsDisallowedTransition() {
+	_, err := testutils.SetFeatureFlag(testOrgID, "status_machine", true)
+	ts.Require().NoError(err)
+	defer testutils.ClearFeatureFlag(testOrgID, "status_machine")
+
+	created := ts.createConsentWithAuthStatuses("accounts", "APPROVED")
+	ts.Require().Equal("ACTIVE", created.Status)
+
+	resp, body := ts.updateConsent(created.ID, ConsentUpdateRequest{
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "CREATED"}},
+	})
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode)
+	ts.True(strings.Contains(string(body), "cannot change from 'ACTIVE' to 'CREATED'"), string(body))
+
+	// The consent keeps its status
+	getResp, getBody := ts.getConsent(created.ID)
+	defer getResp.Body.Close()
+	var current ConsentResponse
+	ts.NoError(json.Unmarshal(getBody, &current))
+	ts.Equal("ACTIVE", current.Status)
+}
+
+// TestUpdateConsent_StatusMachineEnabled_AllowsConfiguredTransition checks that a transition
+// listed in the state machine is accepted
+func (ts *ConsentAPITestSuite) TestUpdateConsent_StatusMachineEnabled_AllowsConfiguredTransition() {
+	_, err := testutils.SetFeatureFlag(testOrgID, "status_machine", true)
+	ts.Require().NoError(err)
+	defer testutils.ClearFeatureFlag(testOrgID, "status_machine")
+
+	created := ts.createConsentWithAuthStatuses("accounts", "PENDING_REVIEW")
+	ts.Require().Equal("AWAITING_REVIEW", created.Status)
+
+	resp, body := ts.updateConsent(created.ID, ConsentUpdateRequest{
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "APPROVED"}},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var updated ConsentResponse
+	ts.NoError(json.Unmarshal(body, &updated))
+	ts.Equal("ACTIVE", updated.Status)
+}
+
+// TestRevokeConsent_StatusMachineEnabled_RejectsRevokingRejectedConsent checks that revocation is
+// subject to the configured transitions
+func (ts *ConsentAPITestSuite) TestRevokeConsent_StatusMachineEnabled_RejectsRevokingRejectedConsent() {
+	_, err := testutils.SetFeatureFlag(testOrgID, "status_machine", true)
+	ts.Require().NoError(err)
+	defer testutils.ClearFeatureFlag(testOrgID, "status_machine")
+
+	created := ts.createConsentWithAuthStatuses("accounts", "REJECTED")
+	ts.Require().Equal("REJECTED", created.Status)
+
+	resp, body := ts.revokeConsent(created.ID, "No longer needed")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode)
+	ts.True(strings.Contains(string(body), "cannot change from 'REJECTED' to 'REVOKED'"), string(body))
+}
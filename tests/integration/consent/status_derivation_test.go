@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"net/http"
+)
+
+// ============================
+// Consent status derivation strategies
+// ============================
+
+// consentStatus returns the current status of a consent
+func (ts *ConsentAPITestSuite) consentStatus(consentID string) string {
+	return ts.getConsentOrFail(consentID).Status
+}
+
+// TestStatusDerivation_Quorum_ActivatesOnceQuorumApproves checks that a quorum consent stays
+// created until the configured number of authorizations approve
+func (ts *ConsentAPITestSuite) TestStatusDerivation_Quorum_ActivatesOnceQuorumApproves() {
+	created := ts.createConsentWithAuthStatuses("quorum-payments", "APPROVED", "CREATED", "CREATED")
+	ts.Equal("CREATED", created.Status)
+
+	resp, body := ts.patchAuthorization(created.ID, created.Authorizations[1].ID, AuthorizationPatchRequest{Status: "APPROVED"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Equal("ACTIVE", ts.consentStatus(created.ID))
+}
+
+// TestStatusDerivation_Quorum_RejectsOnceQuorumIsUnreachable checks that rejections only reject a
+// quorum consent once too few authorizations are left to reach the quorum
+func (ts *ConsentAPITestSuite) TestStatusDerivation_Quorum_RejectsOnceQuorumIsUnreachable() {
+	created := ts.createConsentWithAuthStatuses("quorum-payments", "APPROVED", "REJECTED", "CREATED")
+	ts.Equal("CREATED", created.Status)
+
+	resp, body := ts.patchAuthorization(created.ID, created.Authorizations[2].ID, AuthorizationPatchRequest{Status: "REJECTED"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Equal("REJECTED", ts.consentStatus(created.ID))
+}
+
+// TestStatusDerivation_StrategiesByConsentType checks that each consent type uses its configured strategy
+func (ts *ConsentAPITestSuite) TestStatusDerivation_StrategiesByConsentType() {
+	testCases := []struct {
+		consentType string
+		statuses    []string
+		expected    string
+	}{
+		{"any-approve-accounts", []string{"APPROVED", "REJECTED"}, "ACTIVE"},
+		{"any-approve-accounts", []string{"CREATED", "REJECTED"}, "REJECTED"},
+		{"accounts", []string{"APPROVED", "REJECTED"}, "REJECTED"},
+		{"accounts", []string{"APPROVED", "CREATED"}, "CREATED"},
+		{"quorum-payments", []string{"APPROVED", "APPROVED", "REJECTED"}, "ACTIVE"},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.consentType, func() {
+			created := ts.createConsentWithAuthStatuses(tc.consentType, tc.statuses...)
+			ts.Equal(tc.expected, created.Status, "auth statuses %v", tc.statuses)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// POST /admin/consents/{id}/status Tests
+// ============================
+
+// overrideStatus calls the admin consent status override API
+func (ts *ConsentAPITestSuite) overrideStatus(consentID string, payload interface{}, withAdminAuth bool) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	url := fmt.Sprintf("%s/api/v1/admin/consents/%s/status", testServerURL, consentID)
+	httpReq, _ := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	if withAdminAuth {
+		httpReq.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestOverrideStatus_RevokedToActive_BypassesStateMachine reactivates a revoked consent
+func (ts *ConsentAPITestSuite) TestOverrideStatus_RevokedToActive_BypassesStateMachine() {
+	consentID := ts.createConsentOrFail(userConsentRequest("override-user-1"))
+
+	revokeResp, revokeBody := ts.revokeConsent(consentID, "revoked by mistake")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	resp, body := ts.overrideStatus(consentID, map[string]string{
+		"status":   "ACTIVE",
+		"reason":   "revocation was issued for the wrong consent",
+		"actionBy": "support-agent-1",
+	}, true)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result StatusOverrideResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Equal(consentID, result.ConsentID)
+	ts.Equal("REVOKED", result.PreviousStatus)
+	ts.Equal("ACTIVE", result.Status)
+	ts.Empty(result.AuthorizationStatus, "no cascade is configured for ACTIVE")
+	ts.Equal("support-agent-1", result.ActionBy)
+
+	getResp, getBody := ts.getConsent(consentID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+
+	var updated ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &updated))
+	ts.Equal("ACTIVE", updated.Status)
+}
+
+// TestOverrideStatus_Expired_CascadesToAuthorizations expires a consent and its authorizations
+func (ts *ConsentAPITestSuite) TestOverrideStatus_Expired_CascadesToAuthorizations() {
+	consentID := ts.createConsentOrFail(userConsentRequest("override-user-2"))
+
+	resp, body := ts.overrideStatus(consentID, map[string]string{
+		"status":   "EXPIRED",
+		"reason":   "consent should have expired last month",
+		"actionBy": "support-agent-1",
+	}, true)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result StatusOverrideResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Equal("ACTIVE", result.PreviousStatus)
+	ts.Require().NotEmpty(result.AuthorizationStatus)
+
+	getResp, getBody := ts.getConsent(consentID)
+	defer getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+
+	var updated ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &updated))
+	ts.Equal("EXPIRED", updated.Status)
+	ts.Require().NotEmpty(updated.Authorizations)
+	for _, auth := range updated.Authorizations {
+		ts.Equal(result.AuthorizationStatus, auth.Status)
+	}
+}
+
+// TestOverrideStatus_InvalidRequests_AreRejected checks validation of the override request
+func (ts *ConsentAPITestSuite) TestOverrideStatus_InvalidRequests_AreRejected() {
+	consentID := ts.createConsentOrFail(userConsentRequest("override-user-3"))
+
+	testCases := []struct {
+		name       string
+		consentID  string
+		payload    map[string]string
+		adminAuth  bool
+		wantStatus int
+	}{
+		{"missing reason", consentID, map[string]string{"status": "REVOKED", "actionBy": "agent"}, true, http.StatusBadRequest},
+		{"missing actor", consentID, map[string]string{"status": "REVOKED", "reason": "fix"}, true, http.StatusBadRequest},
+		{"unknown status", consentID, map[string]string{"status": "STUCK", "reason": "fix", "actionBy": "agent"}, true, http.StatusBadRequest},
+		{"unknown consent", "00000000-0000-0000-0000-000000000000", map[string]string{"status": "REVOKED", "reason": "fix", "actionBy": "agent"}, true, http.StatusNotFound},
+		{"no admin credentials", consentID, map[string]string{"status": "REVOKED", "reason": "fix", "actionBy": "agent"}, false, http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		resp, body := ts.overrideStatus(tc.consentID, tc.payload, tc.adminAuth)
+		resp.Body.Close()
+		ts.Equal(tc.wantStatus, resp.StatusCode, "%s: %s", tc.name, string(body))
+	}
+
+	getResp, getBody := ts.getConsent(consentID)
+	defer getResp.Body.Close()
+	var unchanged ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &unchanged))
+	ts.Equal("ACTIVE", unchanged.Status)
+}
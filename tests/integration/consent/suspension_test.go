@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// POST /consents/{id}/suspend and /resume Tests
+// ============================
+
+// changeSuspension calls the consent suspend or resume API
+func (ts *ConsentAPITestSuite) changeSuspension(consentID, operation string, payload interface{}) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	url := fmt.Sprintf("%s/api/v1/consents/%s/%s", testServerURL, consentID, operation)
+	httpReq, _ := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestSuspendConsent_FailsValidationUntilResumed suspends an active consent, checks that validation
+// fails with the suspension error and resumes it back to active
+func (ts *ConsentAPITestSuite) TestSuspendConsent_FailsValidationUntilResumed() {
+	consentID := ts.createConsentOrFail(userConsentRequest("suspend-user-1"))
+
+	resp, body := ts.changeSuspension(consentID, "suspend", map[string]interface{}{
+		"reason":   "fraud investigation",
+		"actionBy": "fraud-team",
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var suspended SuspensionResponse
+	ts.Require().NoError(json.Unmarshal(body, &suspended))
+	ts.Equal(consentID, suspended.ConsentID)
+	ts.Equal("ACTIVE", suspended.PreviousStatus)
+	ts.Equal("SUSPENDED", suspended.Status)
+	ts.Equal("fraud investigation", suspended.Reason)
+	ts.Equal("SUSPENDED", ts.consentStatus(consentID))
+
+	validateResp, validateBody := ts.validateConsent(ConsentValidateRequest{ConsentID: consentID})
+	validateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, validateResp.StatusCode, string(validateBody))
+
+	var validation ConsentValidateResponse
+	ts.Require().NoError(json.Unmarshal(validateBody, &validation))
+	ts.False(validation.IsValid)
+	ts.Equal(http.StatusForbidden, validation.ErrorCode)
+	ts.Equal("consent_suspended", validation.ErrorMessage)
+
+	resp, body = ts.changeSuspension(consentID, "resume", map[string]interface{}{
+		"actionBy": "fraud-team",
+	})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var resumed SuspensionResponse
+	ts.Require().NoError(json.Unmarshal(body, &resumed))
+	ts.Equal("SUSPENDED", resumed.PreviousStatus)
+	ts.Equal("ACTIVE", resumed.Status)
+	ts.Equal("Consent resumed", resumed.Reason)
+	ts.Equal("ACTIVE", ts.consentStatus(consentID))
+
+	validateResp, validateBody = ts.validateConsent(ConsentValidateRequest{ConsentID: consentID})
+	validateResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, validateResp.StatusCode, string(validateBody))
+
+	var revalidation ConsentValidateResponse
+	ts.Require().NoError(json.Unmarshal(validateBody, &revalidation))
+	ts.True(revalidation.IsValid, string(validateBody))
+}
+
+// TestSuspendConsent_SuspendedConsent_CanBeRevoked checks that suspension is not terminal
+func (ts *ConsentAPITestSuite) TestSuspendConsent_SuspendedConsent_CanBeRevoked() {
+	consentID := ts.createConsentOrFail(userConsentRequest("suspend-user-2"))
+
+	resp, body := ts.changeSuspension(consentID, "suspend", map[string]interface{}{"actionBy": "fraud-team"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	revokeResp, revokeBody := ts.revokeConsent(consentID, "fraud confirmed")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+	ts.Equal("REVOKED", ts.consentStatus(consentID))
+}
+
+// TestSuspendConsent_InvalidRequests_AreRejected checks the statuses and payloads that are rejected
+func (ts *ConsentAPITestSuite) TestSuspendConsent_InvalidRequests_AreRejected() {
+	activeID := ts.createConsentOrFail(userConsentRequest("suspend-user-3"))
+	suspendedID := ts.createConsentOrFail(userConsentRequest("suspend-user-4"))
+	resp, body := ts.changeSuspension(suspendedID, "suspend", map[string]interface{}{"actionBy": "u"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	revokedID := ts.createConsentOrFail(userConsentRequest("suspend-user-5"))
+	revokeResp, revokeBody := ts.revokeConsent(revokedID, "no longer needed")
+	revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	testCases := []struct {
+		name       string
+		consentID  string
+		operation  string
+		payload    map[string]interface{}
+		wantStatus int
+	}{
+		{"suspend revoked consent", revokedID, "suspend", map[string]interface{}{"actionBy": "u"}, http.StatusConflict},
+		{"suspend suspended consent", suspendedID, "suspend", map[string]interface{}{"actionBy": "u"}, http.StatusConflict},
+		{"resume active consent", activeID, "resume", map[string]interface{}{"actionBy": "u"}, http.StatusConflict},
+		{"missing actionBy", activeID, "suspend", map[string]interface{}{"reason": "r"}, http.StatusBadRequest},
+		{"unknown consent", "00000000-0000-0000-0000-000000000000", "suspend", map[string]interface{}{"actionBy": "u"}, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.changeSuspension(tc.consentID, tc.operation, tc.payload)
+			resp.Body.Close()
+			ts.Equal(tc.wantStatus, resp.StatusCode, string(body))
+		})
+	}
+
+	ts.Equal("ACTIVE", ts.consentStatus(activeID))
+}
+
+// TestSuspendConsent_StatusMachineEnabled_ChecksTransitions checks that suspend and resume follow
+// the configured transitions while the status_machine flag is on
+func (ts *ConsentAPITestSuite) TestSuspendConsent_StatusMachineEnabled_ChecksTransitions() {
+	_, err := testutils.SetFeatureFlag(testOrgID, "status_machine", true)
+	ts.Require().NoError(err)
+	defer testutils.ClearFeatureFlag(testOrgID, "status_machine")
+
+	awaitingReview := ts.createConsentWithAuthStatuses("accounts", "PENDING_REVIEW")
+	ts.Require().Equal("AWAITING_REVIEW", awaitingReview.Status)
+	resp, body := ts.changeSuspension(awaitingReview.ID, "suspend", map[string]interface{}{"actionBy": "fraud-team"})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "cannot change from 'AWAITING_REVIEW' to 'SUSPENDED'")
+	ts.Equal("AWAITING_REVIEW", ts.consentStatus(awaitingReview.ID))
+
+	active := ts.createConsentWithAuthStatuses("accounts", "APPROVED")
+	for _, operation := range []string{"suspend", "resume"} {
+		resp, body = ts.changeSuspension(active.ID, operation, map[string]interface{}{"actionBy": "fraud-team"})
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	}
+	ts.Equal("ACTIVE", ts.consentStatus(active.ID))
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// /consents/{id}/tags Tests
+// ============================
+
+// sendTagRequest calls the consent tags API. payload is sent as the body when it is not nil.
+func (ts *ConsentAPITestSuite) sendTagRequest(method, path string, payload interface{}) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	httpReq, _ := http.NewRequest(method, testServerURL+"/api/v1/consents/"+path, reqBody)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// addTags adds tags to a consent and returns its tags
+func (ts *ConsentAPITestSuite) addTags(consentID string, tags ...string) []string {
+	resp, body := ts.sendTagRequest("POST", consentID+"/tags", map[string][]string{"tags": tags})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result ConsentTagsResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Equal(consentID, result.ConsentID)
+	return result.Tags
+}
+
+// TestConsentTags_Add_ReturnedWithConsentWithoutNewVersion tags a consent and reads it back
+func (ts *ConsentAPITestSuite) TestConsentTags_Add_ReturnedWithConsentWithoutNewVersion() {
+	created := ts.getConsentOrFail(ts.createConsentOrFail(userConsentRequest("tags-user-1")))
+
+	ts.Equal([]string{"migration-batch-7", "under-investigation"},
+		ts.addTags(created.ID, "under-investigation", "migration-batch-7"))
+	// Adding a tag the consent already has is a no-op
+	ts.Equal([]string{"migration-batch-7", "under-investigation"}, ts.addTags(created.ID, "migration-batch-7"))
+
+	resp, body := ts.getConsent(created.ID)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &consent))
+	ts.Equal([]string{"migration-batch-7", "under-investigation"}, consent.Tags)
+	ts.Empty(consent.Attributes, "tags are not stored as attributes")
+	ts.Equal(created.Version, consent.Version)
+	ts.Equal(created.UpdatedTime, consent.UpdatedTime)
+}
+
+// TestConsentTags_ConsentUpdate_KeepsTags checks that replacing the attributes leaves the tags alone
+func (ts *ConsentAPITestSuite) TestConsentTags_ConsentUpdate_KeepsTags() {
+	consentID := ts.createConsentOrFail(userConsentRequest("tags-user-2"))
+	ts.addTags(consentID, "migration-batch-7")
+
+	resp, body := ts.updateConsent(consentID, ConsentUpdateRequest{
+		Attributes: map[string]string{"accountType": "checking"},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var updated ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &updated))
+	ts.Equal(map[string]string{"accountType": "checking"}, updated.Attributes)
+	ts.Equal([]string{"migration-batch-7"}, updated.Tags)
+}
+
+// TestConsentTags_SearchByTag_ReturnsTaggedConsents lists consents by tag
+func (ts *ConsentAPITestSuite) TestConsentTags_SearchByTag_ReturnsTaggedConsents() {
+	batch := fmt.Sprintf("batch-%d", time.Now().UnixNano())
+	other := fmt.Sprintf("other-%d", time.Now().UnixNano())
+	firstID := ts.createConsentOrFail(userConsentRequest("tags-user-3"))
+	secondID := ts.createConsentOrFail(userConsentRequest("tags-user-4"))
+	untaggedID := ts.createConsentOrFail(userConsentRequest("tags-user-5"))
+	ts.addTags(firstID, batch)
+	ts.addTags(secondID, batch, other)
+
+	ids := ts.searchConsentIDs(map[string]string{"tags": batch})
+	ts.ElementsMatch([]string{firstID, secondID}, ids)
+	ts.NotContains(ids, untaggedID)
+
+	ts.Equal([]string{secondID}, ts.searchConsentIDs(map[string]string{"tags": other}))
+	ts.ElementsMatch([]string{firstID, secondID}, ts.searchConsentIDs(map[string]string{"tags": other + "," + batch}))
+
+	resp, body := ts.listConsents(map[string]string{"tags": other})
+	defer resp.Body.Close()
+	var list ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Require().Len(list.Data, 1)
+	ts.ElementsMatch([]string{batch, other}, list.Data[0].Tags)
+}
+
+// TestConsentTags_Remove_DropsTag removes a tag and checks that removing it again fails
+func (ts *ConsentAPITestSuite) TestConsentTags_Remove_DropsTag() {
+	consentID := ts.createConsentOrFail(userConsentRequest("tags-user-6"))
+	ts.addTags(consentID, "keep", "drop")
+
+	resp, body := ts.sendTagRequest("DELETE", consentID+"/tags/drop", nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+
+	getResp, getBody := ts.getConsent(consentID)
+	defer getResp.Body.Close()
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &consent))
+	ts.Equal([]string{"keep"}, consent.Tags)
+
+	resp, body = ts.sendTagRequest("DELETE", consentID+"/tags/drop", nil)
+	resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestConsentTags_InvalidRequests_AreRejected checks validation of tag requests
+func (ts *ConsentAPITestSuite) TestConsentTags_InvalidRequests_AreRejected() {
+	consentID := ts.createConsentOrFail(userConsentRequest("tags-user-7"))
+
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		payload    interface{}
+		wantStatus int
+	}{
+		{"no tags", "POST", consentID + "/tags", map[string][]string{"tags": {}}, http.StatusBadRequest},
+		{"tag with a space", "POST", consentID + "/tags", map[string][]string{"tags": {"under investigation"}}, http.StatusBadRequest},
+		{"tag too long", "POST", consentID + "/tags", map[string][]string{"tags": {fmt.Sprintf("%065d", 0)}}, http.StatusBadRequest},
+		{"unknown consent", "POST", "00000000-0000-0000-0000-000000000000/tags", map[string][]string{"tags": {"valid"}}, http.StatusNotFound},
+		{"remove from unknown consent", "DELETE", "00000000-0000-0000-0000-000000000000/tags/valid", nil, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		resp, body := ts.sendTagRequest(tc.method, tc.path, tc.payload)
+		resp.Body.Close()
+		ts.Equal(tc.wantStatus, resp.StatusCode, "%s: %s", tc.name, string(body))
+	}
+
+	getResp, getBody := ts.getConsent(consentID)
+	defer getResp.Body.Close()
+	var consent ConsentResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &consent))
+	ts.Empty(consent.Tags)
+}
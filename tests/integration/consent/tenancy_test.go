@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ============================
+// Tenancy Isolation Tests
+// ============================
+
+// otherTenantOrgID is an organization that owns none of the consents created by the suite
+const otherTenantOrgID = "tenancy-other-org"
+
+// TestTenancy_ConsentOfAnotherOrg_IsNotVisible verifies that a consent cannot be read, listed,
+// updated or revoked through another organization
+func (ts *ConsentAPITestSuite) TestTenancy_ConsentOfAnotherOrg_IsNotVisible() {
+	created := ts.getConsentOrFail(ts.createConsentOrFail(userConsentRequest("tenancy-user")))
+
+	getResp, _ := ts.getConsentWithHeaders(created.ID, otherTenantOrgID, testClientID)
+	defer getResp.Body.Close()
+	ts.Equal(http.StatusNotFound, getResp.StatusCode)
+
+	listResp, listBody := ts.listConsentsWithHeaders(map[string]string{"userIds": "tenancy-user"}, otherTenantOrgID, testClientID)
+	defer listResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, listResp.StatusCode, string(listBody))
+	var list ConsentListResponse
+	ts.Require().NoError(json.Unmarshal(listBody, &list))
+	for _, consent := range list.Data {
+		ts.NotEqual(created.ID, consent.ID)
+	}
+
+	updatePayload := ConsentUpdateRequest{Attributes: map[string]string{"tenancy": "crossed"}}
+	updateResp, _ := ts.updateConsentWithHeaders(created.ID, updatePayload, otherTenantOrgID, testClientID)
+	defer updateResp.Body.Close()
+	ts.Equal(http.StatusNotFound, updateResp.StatusCode)
+
+	revokeResp, _ := ts.revokeConsentWithHeaders(created.ID, "cross-org revoke", otherTenantOrgID, testClientID)
+	defer revokeResp.Body.Close()
+	ts.Equal(http.StatusNotFound, revokeResp.StatusCode)
+
+	// The consent is unchanged in its own organization
+	ownResp, ownBody := ts.getConsent(created.ID)
+	defer ownResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, ownResp.StatusCode)
+	var own ConsentResponse
+	ts.Require().NoError(json.Unmarshal(ownBody, &own))
+	ts.Equal(created.Status, own.Status)
+	ts.NotContains(own.Attributes, "tenancy")
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ============================
+// Token Binding Tests
+// ============================
+
+// newTokenID returns a token ID not bound by earlier runs
+func newTokenID(prefix string) string {
+	return fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
+}
+
+// bindToken calls POST /consents/{consentId}/tokens
+func (ts *ConsentAPITestSuite) bindToken(consentID string, payload ConsentTokenRequest) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	httpReq, _ := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/consents/%s/tokens", testServerURL, consentID),
+		bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	return ts.doTokenRequest(httpReq)
+}
+
+// listTokens calls GET /consents/{consentId}/tokens
+func (ts *ConsentAPITestSuite) listTokens(consentID string) []ConsentToken {
+	httpReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/consents/%s/tokens", testServerURL, consentID), nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, body := ts.doTokenRequest(httpReq)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result struct {
+		Data []ConsentToken `json:"data"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	return result.Data
+}
+
+// unbindToken calls DELETE /consents/{consentId}/tokens/{tokenId}
+func (ts *ConsentAPITestSuite) unbindToken(consentID, tokenID string) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v1/consents/%s/tokens/%s", testServerURL, consentID, tokenID), nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	return ts.doTokenRequest(httpReq)
+}
+
+// getTokenConsent calls GET /tokens/{tokenId}/consent
+func (ts *ConsentAPITestSuite) getTokenConsent(tokenID string) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/tokens/%s/consent", testServerURL, tokenID), nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	return ts.doTokenRequest(httpReq)
+}
+
+func (ts *ConsentAPITestSuite) doTokenRequest(httpReq *http.Request) (*http.Response, []byte) {
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	return resp, body
+}
+
+// TestTokenBinding_BindAndResolve binds a token, resolves it to its consent and rejects binding it
+// to another consent
+func (ts *ConsentAPITestSuite) TestTokenBinding_BindAndResolve() {
+	consentID := ts.createConsentOrFail(userConsentRequest("token-user"))
+	tokenID := newTokenID("access")
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	resp, body := ts.bindToken(consentID, ConsentTokenRequest{TokenID: tokenID, TokenType: "access_token", ExpiresAt: &expiresAt})
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var bound ConsentToken
+	ts.Require().NoError(json.Unmarshal(body, &bound))
+	ts.Equal(tokenID, bound.TokenID)
+	ts.Equal(consentID, bound.ConsentID)
+	ts.Equal("access_token", bound.TokenType)
+	ts.Require().NotNil(bound.ExpiresAt)
+	ts.Equal(expiresAt, *bound.ExpiresAt)
+
+	resp, body = ts.bindToken(consentID, ConsentTokenRequest{TokenID: tokenID, TokenType: "access_token", ExpiresAt: &expiresAt})
+	ts.Equal(http.StatusOK, resp.StatusCode, "binding again returns the binding: %s", string(body))
+
+	otherID := ts.createConsentOrFail(userConsentRequest("token-user"))
+	resp, body = ts.bindToken(otherID, ConsentTokenRequest{TokenID: tokenID, TokenType: "access_token"})
+	ts.Equal(http.StatusConflict, resp.StatusCode, string(body))
+
+	resp, body = ts.getTokenConsent(tokenID)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	var resolved struct {
+		Token   ConsentToken    `json:"token"`
+		Consent ConsentResponse `json:"consent"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &resolved))
+	ts.Equal(consentID, resolved.Consent.ID)
+	ts.Equal("ACTIVE", resolved.Consent.Status)
+	ts.Equal(tokenID, resolved.Token.TokenID)
+
+	tokens := ts.listTokens(consentID)
+	ts.Require().Len(tokens, 1)
+	ts.Equal(tokenID, tokens[0].TokenID)
+	ts.Empty(ts.listTokens(otherID))
+}
+
+// TestTokenBinding_Unbind stops a token from resolving once it is unbound
+func (ts *ConsentAPITestSuite) TestTokenBinding_Unbind() {
+	consentID := ts.createConsentOrFail(userConsentRequest("token-user"))
+	tokenID := newTokenID("refresh")
+
+	resp, body := ts.bindToken(consentID, ConsentTokenRequest{TokenID: tokenID, TokenType: "refresh_token"})
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	resp, body = ts.unbindToken(consentID, tokenID)
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+
+	resp, body = ts.getTokenConsent(tokenID)
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+	resp, body = ts.unbindToken(consentID, tokenID)
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestTokenBinding_RevokeRemovesBindings removes the bindings of a consent when it is revoked and
+// rejects binding tokens to it afterwards
+func (ts *ConsentAPITestSuite) TestTokenBinding_RevokeRemovesBindings() {
+	consentID := ts.createConsentOrFail(userConsentRequest("token-user"))
+	accessID, refreshID := newTokenID("access"), newTokenID("refresh")
+	for id, tokenType := range map[string]string{accessID: "access_token", refreshID: "refresh_token"} {
+		resp, body := ts.bindToken(consentID, ConsentTokenRequest{TokenID: id, TokenType: tokenType})
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	}
+	ts.Require().Len(ts.listTokens(consentID), 2)
+
+	resp, body := ts.revokeConsent(consentID, "token binding test")
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.Empty(ts.listTokens(consentID))
+	resp, body = ts.getTokenConsent(accessID)
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+
+	resp, body = ts.bindToken(consentID, ConsentTokenRequest{TokenID: newTokenID("access"), TokenType: "access_token"})
+	ts.Equal(http.StatusConflict, resp.StatusCode, string(body))
+}
+
+// TestTokenBinding_SuspendRemovesBindings removes the bindings of a consent when it is suspended
+func (ts *ConsentAPITestSuite) TestTokenBinding_SuspendRemovesBindings() {
+	consentID := ts.createConsentOrFail(userConsentRequest("token-user"))
+	tokenID := newTokenID("access")
+	resp, body := ts.bindToken(consentID, ConsentTokenRequest{TokenID: tokenID, TokenType: "access_token"})
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	resp, body = ts.changeSuspension(consentID, "suspend", map[string]interface{}{"actionBy": "fraud-team"})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.Empty(ts.listTokens(consentID))
+	resp, body = ts.getTokenConsent(tokenID)
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestTokenBinding_RejectedAuthorizationRemovesBindings removes the bindings of a consent rejected
+// through its authorization
+func (ts *ConsentAPITestSuite) TestTokenBinding_RejectedAuthorizationRemovesBindings() {
+	consent := ts.createConsentWithCreatedAuthorization()
+	tokenID := newTokenID("access")
+	resp, body := ts.bindToken(consent.ID, ConsentTokenRequest{TokenID: tokenID, TokenType: "access_token"})
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	resp, body = ts.patchAuthorization(consent.ID, consent.Authorizations[0].ID, AuthorizationPatchRequest{Status: "REJECTED"})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Require().Equal("REJECTED", ts.consentStatus(consent.ID))
+
+	ts.Empty(ts.listTokens(consent.ID))
+	resp, body = ts.getTokenConsent(tokenID)
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+// TestTokenBinding_InvalidRequest_ReturnsBadRequest rejects unknown token types, malformed token
+// IDs and tokens that already expired
+func (ts *ConsentAPITestSuite) TestTokenBinding_InvalidRequest_ReturnsBadRequest() {
+	consentID := ts.createConsentOrFail(userConsentRequest("token-user"))
+	expired := time.Now().Add(-time.Minute).Unix()
+
+	invalid := map[string]ConsentTokenRequest{
+		"unknown token type": {TokenID: newTokenID("id"), TokenType: "id_token"},
+		"missing token ID":   {TokenType: "access_token"},
+		"malformed token ID": {TokenID: "a/b", TokenType: "access_token"},
+		"expired token":      {TokenID: newTokenID("access"), TokenType: "access_token", ExpiresAt: &expired},
+	}
+	for name, payload := range invalid {
+		resp, body := ts.bindToken(consentID, payload)
+		ts.Equal(http.StatusBadRequest, resp.StatusCode, "%s: %s", name, string(body))
+	}
+	ts.Empty(ts.listTokens(consentID))
+}
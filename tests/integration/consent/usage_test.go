@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// getConsentUsage retrieves the usage of a consent
+func (ts *ConsentAPITestSuite) getConsentUsage(consentID string) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consents/%s/usage", testServerURL, consentID)
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	client := testutils.GetHTTPClient()
+	resp, err := client.Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestValidateConsent_FrequencyLimit_RejectsExtraAccess checks that a recurring consent is valid
+// for as many accesses per period as its frequency allows
+func (ts *ConsentAPITestSuite) TestValidateConsent_FrequencyLimit_RejectsExtraAccess() {
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type:               "accounts",
+		RecurringIndicator: true,
+		Frequency:          2,
+		Authorizations:     []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "APPROVED"}},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var created ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.trackConsent(created.ID)
+
+	ts.True(ts.validateAsUser1(created.ID).IsValid)
+	ts.True(ts.validateAsUser1(created.ID).IsValid)
+
+	third := ts.validateAsUser1(created.ID)
+	ts.False(third.IsValid)
+	ts.Equal(http.StatusTooManyRequests, third.ErrorCode)
+	ts.Equal("frequency_limit_exceeded", third.ErrorMessage)
+
+	usageResp, usageBody := ts.getConsentUsage(created.ID)
+	defer usageResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, usageResp.StatusCode, string(usageBody))
+
+	var usage ConsentUsageResponse
+	ts.Require().NoError(json.Unmarshal(usageBody, &usage))
+	ts.True(usage.RecurringIndicator)
+	ts.Equal(2, usage.AccessCount)
+	ts.Require().NotNil(usage.Remaining)
+	ts.Equal(0, *usage.Remaining)
+	ts.Require().NotNil(usage.PeriodEnd)
+	ts.Greater(*usage.PeriodEnd, usage.PeriodStart)
+	ts.NotNil(usage.LastAccessTime)
+}
+
+// TestGetConsentUsage_NoFrequency_CountsLifetimeAccesses checks that consents without a frequency
+// are counted over their lifetime and never limited
+func (ts *ConsentAPITestSuite) TestGetConsentUsage_NoFrequency_CountsLifetimeAccesses() {
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+
+	usageResp, usageBody := ts.getConsentUsage(consentID)
+	usageResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, usageResp.StatusCode, string(usageBody))
+	var before ConsentUsageResponse
+	ts.Require().NoError(json.Unmarshal(usageBody, &before))
+	ts.Equal(0, before.AccessCount)
+	ts.Nil(before.LastAccessTime)
+
+	for i := 0; i < 3; i++ {
+		ts.True(ts.validateAsUser1(consentID).IsValid)
+	}
+
+	usageResp, usageBody = ts.getConsentUsage(consentID)
+	defer usageResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, usageResp.StatusCode, string(usageBody))
+	var after ConsentUsageResponse
+	ts.Require().NoError(json.Unmarshal(usageBody, &after))
+	ts.Equal(3, after.AccessCount)
+	ts.Equal(int64(0), after.PeriodStart)
+	ts.Nil(after.PeriodEnd)
+	ts.Nil(after.Remaining)
+}
+
+// TestGetConsentUsage_UnknownConsent_ReturnsNotFound returns 404 for a consent that does not exist
+func (ts *ConsentAPITestSuite) TestGetConsentUsage_UnknownConsent_ReturnsNotFound() {
+	resp, body := ts.getConsentUsage("00000000-0000-0000-0000-000000000000")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
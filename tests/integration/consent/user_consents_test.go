@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// listUserConsents calls GET /users/{userId}/consents with the given query parameters
+func (ts *ConsentAPITestSuite) listUserConsents(userID string, query url.Values) (*http.Response, []byte) {
+	endpoint := fmt.Sprintf("%s/api/v1/users/%s/consents", testServerURL, url.PathEscape(userID))
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	httpReq, _ := http.NewRequest("GET", endpoint, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// ============================
+// GET /users/{userId}/consents - User Consent Listing Tests
+// ============================
+
+// TestListUserConsents_ReturnsConsentsWithUserApprovalStatus checks that every consent the user
+// authorizes is listed with the user's own authorizations and approval status
+func (ts *ConsentAPITestSuite) TestListUserConsents_ReturnsConsentsWithUserApprovalStatus() {
+	userID := fmt.Sprintf("dashboard-user-%d", time.Now().UnixNano())
+	otherUserID := userID + "-other"
+
+	approvedID := ts.createConsentOrFail(userConsentRequest(userID))
+
+	// A shared consent the user has not approved yet
+	resp, body := ts.createConsent(ConsentCreateRequest{
+		Type: "accounts",
+		Authorizations: []AuthorizationRequest{
+			{UserID: otherUserID, Type: "authorisation", Status: "APPROVED"},
+			{UserID: userID, Type: "authorisation", Status: "CREATED"},
+		},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var pending ConsentResponse
+	ts.Require().NoError(json.Unmarshal(body, &pending))
+	ts.trackConsent(pending.ID)
+
+	// A consent of another user only
+	ts.createConsentOrFail(userConsentRequest(otherUserID))
+
+	listResp, listBody := ts.listUserConsents(userID, nil)
+	defer listResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, listResp.StatusCode, string(listBody))
+
+	var list UserConsentListResponse
+	ts.Require().NoError(json.Unmarshal(listBody, &list))
+	ts.Equal(userID, list.UserID)
+	ts.Require().Len(list.Data, 2)
+	ts.Equal(2, list.Meta.Total)
+
+	byID := make(map[string]UserConsentResponse)
+	for _, consent := range list.Data {
+		byID[consent.ID] = consent
+	}
+
+	ts.Require().Contains(byID, approvedID)
+	ts.Equal("APPROVED", byID[approvedID].UserApprovalStatus)
+	ts.Len(byID[approvedID].UserAuthorizations, 1)
+
+	ts.Require().Contains(byID, pending.ID)
+	ts.Equal("CREATED", byID[pending.ID].UserApprovalStatus)
+	ts.Require().Len(byID[pending.ID].UserAuthorizations, 1)
+	ts.Equal(userID, *byID[pending.ID].UserAuthorizations[0].UserID)
+	ts.Len(byID[pending.ID].Authorizations, 2)
+}
+
+// TestListUserConsents_FilterByStatus checks that consentStatuses narrows the listing
+func (ts *ConsentAPITestSuite) TestListUserConsents_FilterByStatus() {
+	userID := fmt.Sprintf("dashboard-user-%d", time.Now().UnixNano())
+	activeID := ts.createConsentOrFail(userConsentRequest(userID))
+	revokedID := ts.createConsentOrFail(userConsentRequest(userID))
+
+	revokeResp, revokeBody := ts.revokeConsent(revokedID, "No longer needed")
+	defer revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	resp, body := ts.listUserConsents(userID, url.Values{"consentStatuses": {"ACTIVE"}})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list UserConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Require().Len(list.Data, 1)
+	ts.Equal(activeID, list.Data[0].ID)
+}
+
+// TestListUserConsents_UnknownUser_ReturnsEmptyList checks that a user without consents gets an
+// empty listing
+func (ts *ConsentAPITestSuite) TestListUserConsents_UnknownUser_ReturnsEmptyList() {
+	resp, body := ts.listUserConsents(fmt.Sprintf("unknown-user-%d", time.Now().UnixNano()), nil)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list UserConsentListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Empty(list.Data)
+	ts.NotNil(list.Data)
+}
+
+// TestListUserConsents_MissingOrgID_ReturnsBadRequest checks that the org-id header is required
+func (ts *ConsentAPITestSuite) TestListUserConsents_MissingOrgID_ReturnsBadRequest() {
+	httpReq, _ := http.NewRequest("GET", testServerURL+"/api/v1/users/user1/consents", nil)
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode)
+}
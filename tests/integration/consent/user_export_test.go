@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// exportUserConsents calls GET /users/{userId}/consents/export in the given format
+func (ts *ConsentAPITestSuite) exportUserConsents(userID, format string) (*http.Response, []byte) {
+	endpoint := fmt.Sprintf("%s/api/v1/users/%s/consents/export", testServerURL, url.PathEscape(userID))
+	if format != "" {
+		endpoint += "?format=" + format
+	}
+
+	httpReq, _ := http.NewRequest("GET", endpoint, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// createExportedConsent creates a consent of userID with a purpose, amends and revokes it so that
+// it has superseded versions and status changes
+func (ts *ConsentAPITestSuite) createExportedConsent(userID string) string {
+	consentID := ts.createConsentOrFail(ConsentCreateRequest{
+		Type: "accounts",
+		ConsentPurpose: []ConsentPurposeItem{
+			{Name: "marketing-purpose", IsUserApproved: true},
+		},
+		Authorizations: []AuthorizationRequest{
+			{UserID: userID, Type: "authorisation", Status: "APPROVED"},
+		},
+	})
+
+	amendResp, amendBody := ts.amendConsent(consentID, ConsentAmendmentRequest{
+		ConsentUpdateRequest: ConsentUpdateRequest{
+			ConsentPurpose: []ConsentPurposeItem{{Name: "marketing-purpose", IsUserApproved: true}},
+			Attributes:     map[string]string{"channel": "mobile"},
+		},
+		AmendedBy: userID,
+		Reason:    "channel change",
+	})
+	defer amendResp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, amendResp.StatusCode, string(amendBody))
+
+	revokeResp, revokeBody := ts.revokeConsentAs(consentID, userID)
+	defer revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+
+	return consentID
+}
+
+// ============================
+// GET /users/{userId}/consents/export - Data Subject Access Request Export Tests
+// ============================
+
+// TestExportUserConsents_JSON_IncludesPurposesStatusesAndHistory checks the JSON bundle of a user
+func (ts *ConsentAPITestSuite) TestExportUserConsents_JSON_IncludesPurposesStatusesAndHistory() {
+	userID := fmt.Sprintf("export-user-%d", time.Now().UnixNano())
+	consentID := ts.createExportedConsent(userID)
+
+	resp, body := ts.exportUserConsents(userID, "")
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Contains(resp.Header.Get("Content-Type"), "application/json")
+	ts.Contains(resp.Header.Get("Content-Disposition"), "consents-export.json")
+
+	var export UserConsentExportResponse
+	ts.Require().NoError(json.Unmarshal(body, &export))
+	ts.Equal(userID, export.UserID)
+	ts.Equal(testOrgID, export.OrgID)
+	ts.Require().Len(export.Consents, 1)
+
+	consent := export.Consents[0]
+	ts.Equal(consentID, consent.ID)
+	ts.Equal("REVOKED", consent.Status)
+	ts.Require().Len(consent.ConsentPurpose, 1)
+	ts.Equal("marketing-purpose", consent.ConsentPurpose[0].Name)
+	ts.Require().Len(consent.History, 1)
+	ts.Equal(1, consent.History[0].Version)
+	ts.Equal(userID, consent.History[0].AmendedBy)
+	ts.Require().NotEmpty(consent.StatusHistory)
+	last := consent.StatusHistory[len(consent.StatusHistory)-1]
+	ts.Equal("REVOKED", last.CurrentStatus)
+	ts.Equal(userID, last.ActionBy)
+}
+
+// TestExportUserConsents_HTML_RendersReadableBundle checks the human-readable export
+func (ts *ConsentAPITestSuite) TestExportUserConsents_HTML_RendersReadableBundle() {
+	userID := fmt.Sprintf("export-html-user-%d", time.Now().UnixNano())
+	consentID := ts.createExportedConsent(userID)
+
+	resp, body := ts.exportUserConsents(userID, "html")
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Contains(resp.Header.Get("Content-Type"), "text/html")
+
+	page := string(body)
+	ts.True(strings.HasPrefix(page, "<!DOCTYPE html>"))
+	ts.Contains(page, consentID)
+	ts.Contains(page, "Marketing consent purpose")
+	ts.Contains(page, "REVOKED")
+	ts.Contains(page, "channel change")
+}
+
+// TestExportUserConsents_UnsupportedFormat_ReturnsBadRequest checks format validation
+func (ts *ConsentAPITestSuite) TestExportUserConsents_UnsupportedFormat_ReturnsBadRequest() {
+	resp, body := ts.exportUserConsents("export-format-user", "pdf")
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
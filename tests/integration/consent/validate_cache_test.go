@@ -0,0 +1,258 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==========================
+// Consent validation cache
+// ==========================
+
+// redisStubAddr is the address of the validate cache Redis server in the test deployment.yaml
+const redisStubAddr = "127.0.0.1:6390"
+
+// redisStub is a fake Redis server that keeps values in memory, ignores expiry and records the
+// commands it receives
+type redisStub struct {
+	mu       sync.Mutex
+	values   map[string]string
+	commands []string
+	conns    map[net.Conn]struct{}
+}
+
+// startRedisStub starts a fake Redis server for the duration of a test
+func (ts *ConsentAPITestSuite) startRedisStub() *redisStub {
+	listener, err := net.Listen("tcp", redisStubAddr)
+	ts.Require().NoError(err)
+
+	stub := &redisStub{values: map[string]string{}, conns: map[net.Conn]struct{}{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			stub.mu.Lock()
+			stub.conns[conn] = struct{}{}
+			stub.mu.Unlock()
+			go stub.serve(conn)
+		}
+	}()
+	// Pooled connections of the server are closed too, so the next test starts from an empty cache
+	ts.T().Cleanup(func() {
+		_ = listener.Close()
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		for conn := range stub.conns {
+			_ = conn.Close()
+		}
+	})
+
+	// The server's Redis client backs off after failed dials, so wait until it reaches the stub
+	probeID := ts.createConsentOrFail(validatableConsentRequest())
+	ts.Require().Eventually(func() bool {
+		ts.validateAsUser1(probeID)
+		return stub.count("GET", validateCacheKey(probeID)) > 0
+	}, 5*time.Second, 100*time.Millisecond, "the server did not connect to the Redis stub")
+	return stub
+}
+
+func (s *redisStub) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readRedisCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, s.execute(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *redisStub) execute(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	command := strings.ToUpper(args[0])
+	s.commands = append(s.commands, strings.Join(append([]string{command}, args[1:min(len(args), 2)]...), " "))
+
+	switch {
+	case command == "PING":
+		return "+PONG\r\n"
+	case command == "GET" && len(args) == 2:
+		value, ok := s.values[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case command == "SET" && len(args) >= 3:
+		s.values[args[1]] = args[2]
+		return "+OK\r\n"
+	case command == "DEL" && len(args) >= 2:
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := s.values[key]; ok {
+				delete(s.values, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	default:
+		return "-ERR unsupported command\r\n"
+	}
+}
+
+// count returns how many times a command was received for a key
+func (s *redisStub) count(command, key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, received := range s.commands {
+		if received == command+" "+key {
+			count++
+		}
+	}
+	return count
+}
+
+// has reports whether a key is stored
+func (s *redisStub) has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.values[key]
+	return ok
+}
+
+// readRedisCommand reads a command sent as a RESP array of bulk strings
+func readRedisCommand(reader *bufio.Reader) ([]string, error) {
+	readLine := func(prefix byte) (int, error) {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		if len(line) < 3 || line[0] != prefix {
+			return 0, errors.New("malformed command")
+		}
+		return strconv.Atoi(strings.TrimRight(line[1:], "\r\n"))
+	}
+
+	count, err := readLine('*')
+	if err != nil {
+		return nil, err
+	}
+	if count < 1 {
+		return nil, errors.New("empty command")
+	}
+	args := make([]string, count)
+	for i := range args {
+		size, err := readLine('$')
+		if err != nil {
+			return nil, err
+		}
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		args[i] = string(value[:size])
+	}
+	return args, nil
+}
+
+// validateCacheKey returns the Redis key of a consent's validation data
+func validateCacheKey(consentID string) string {
+	return "consent-mgt:validate:" + testOrgID + ":" + consentID
+}
+
+// validatableConsentRequest returns the request for an active consent of user1
+func validatableConsentRequest() ConsentCreateRequest {
+	return ConsentCreateRequest{
+		Type:           "accounts",
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "APPROVED"}},
+	}
+}
+
+// validateAsUser1 validates a consent for user1 and returns the decoded response
+func (ts *ConsentAPITestSuite) validateAsUser1(consentID string) ConsentValidateResponse {
+	resp, body := ts.validateConsent(ConsentValidateRequest{
+		ConsentID: consentID,
+		UserID:    "user1",
+		ClientID:  testClientID,
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var validateResp ConsentValidateResponse
+	ts.Require().NoError(json.Unmarshal(body, &validateResp))
+	return validateResp
+}
+
+// TestValidateCache_SecondValidate_ServedFromCache checks that the first validation stores the
+// consent data in Redis and the next one reads it back with the same result
+func (ts *ConsentAPITestSuite) TestValidateCache_SecondValidate_ServedFromCache() {
+	stub := ts.startRedisStub()
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+	key := validateCacheKey(consentID)
+
+	first := ts.validateAsUser1(consentID)
+	ts.True(first.IsValid)
+	ts.True(stub.has(key), "validation data should be cached after the first validation")
+	ts.Equal(1, stub.count("SET", key))
+
+	second := ts.validateAsUser1(consentID)
+	ts.True(second.IsValid)
+	ts.Equal(2, stub.count("GET", key))
+	ts.Equal(1, stub.count("SET", key), "a cache hit should not write the entry again")
+	ts.Require().NotNil(second.ConsentInformation)
+	ts.Equal(consentID, second.ConsentInformation.ID)
+	ts.Equal(first.ConsentInformation, second.ConsentInformation)
+}
+
+// TestValidateCache_Revoke_InvalidatesEntry checks that revoking a consent drops its cached
+// validation data, so the next validation sees the revocation
+func (ts *ConsentAPITestSuite) TestValidateCache_Revoke_InvalidatesEntry() {
+	stub := ts.startRedisStub()
+	consentID := ts.createConsentOrFail(validatableConsentRequest())
+	key := validateCacheKey(consentID)
+
+	ts.True(ts.validateAsUser1(consentID).IsValid)
+	ts.Require().True(stub.has(key))
+
+	revokeResp, revokeBody := ts.revokeConsent(consentID, "Testing validate cache")
+	defer revokeResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, revokeResp.StatusCode, string(revokeBody))
+	ts.Equal(1, stub.count("DEL", key))
+
+	revoked := ts.validateAsUser1(consentID)
+	ts.False(revoked.IsValid, "revoked consent should be invalid after the cache entry is dropped")
+	ts.Require().NotNil(revoked.ConsentInformation)
+	ts.Equal("REVOKED", revoked.ConsentInformation.Status)
+}
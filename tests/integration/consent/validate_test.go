@@ -21,6 +21,9 @@ package consent
 import (
 	"encoding/json"
 	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
 )
 
 // ============================
@@ -222,12 +225,18 @@ func (ts *ConsentAPITestSuite) TestValidateConsent_MissingClientID_ReturnsBadReq
 	ts.True(validateResp.IsValid, "Valid consent should pass validation even without client-id header")
 }
 
-// TestValidateConsent_ExpiredConsent_ReturnsInvalid validates expired consent returns invalid
+// TestValidateConsent_ExpiredConsent_ReturnsInvalid moves the server clock past the consent
+// validity time and checks that validation then reports the consent as invalid
 func (ts *ConsentAPITestSuite) TestValidateConsent_ExpiredConsent_ReturnsInvalid() {
-	// Create a consent with very short validity (1 second)
+	now := time.Now()
+	_, err := testutils.FreezeServerTime(now)
+	ts.Require().NoError(err)
+	defer testutils.ResetServerTime()
+
+	// Create a consent that is valid for one hour from the frozen time
 	createPayload := ConsentCreateRequest{
 		Type:         "accounts",
-		ValidityTime: 1,
+		ValidityTime: now.Add(time.Hour).UnixMilli(),
 		Authorizations: []AuthorizationRequest{
 			{UserID: "user1", Type: "payment", Status: "APPROVED"},
 		},
@@ -241,25 +250,32 @@ func (ts *ConsentAPITestSuite) TestValidateConsent_ExpiredConsent_ReturnsInvalid
 	ts.NoError(json.Unmarshal(createBody, &created))
 	ts.trackConsent(created.ID)
 
-	// Wait for consent to expire
-	// Note: This test may be flaky depending on timing
-	// Consider using a negative validityTime if the API supports it
-	// or adjusting the business logic to accept a custom current time for testing
-
-	// Validate the potentially expired consent
 	validatePayload := ConsentValidateRequest{
 		ConsentID: created.ID,
+		UserID:    "user1",
+		ClientID:  testClientID,
 	}
 
+	// Consent is still within its validity period
 	resp, body := ts.validateConsent(validatePayload)
 	defer resp.Body.Close()
-
 	ts.Equal(http.StatusOK, resp.StatusCode)
 
 	var validateResp ConsentValidateResponse
 	ts.NoError(json.Unmarshal(body, &validateResp))
-	// Note: Test may pass or fail depending on timing
-	// This test documents the expected behavior but may need adjustment
+	ts.True(validateResp.IsValid, "Consent should be valid before its validity time")
+
+	// Travel past the validity time
+	_, err = testutils.AdvanceServerTime(2 * time.Hour)
+	ts.Require().NoError(err)
+
+	expiredResp, expiredBody := ts.validateConsent(validatePayload)
+	defer expiredResp.Body.Close()
+	ts.Equal(http.StatusOK, expiredResp.StatusCode)
+
+	var expiredValidateResp ConsentValidateResponse
+	ts.NoError(json.Unmarshal(expiredBody, &expiredValidateResp))
+	ts.False(expiredValidateResp.IsValid, "Consent should be invalid after its validity time")
 }
 
 // TestValidateConsent_RejectedConsent_ReturnsInvalid validates consent with rejected auth returns invalid
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consent
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// validateResourceAccess validates a consent for user1 accessing resource with method
+func (ts *ConsentAPITestSuite) validateResourceAccess(consentID, method, resource string) ConsentValidateResponse {
+	resp, body := ts.validateConsent(map[string]interface{}{
+		"consentId": consentID,
+		"userId":    "user1",
+		"clientId":  testClientID,
+		"resourceParams": map[string]interface{}{
+			"resource":   resource,
+			"httpMethod": method,
+		},
+	})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var validateResp ConsentValidateResponse
+	ts.Require().NoError(json.Unmarshal(body, &validateResp))
+	return validateResp
+}
+
+// TestValidationPolicy_InProcessRules checks the HTTP method and resource path rules of the test
+// policy
+func (ts *ConsentAPITestSuite) TestValidationPolicy_InProcessRules() {
+	consentID := ts.createConsentOrFail(ConsentCreateRequest{
+		Type:           "policy-accounts",
+		Frequency:      2,
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "account", Status: "APPROVED", Resources: []string{"acc-1"}}},
+	})
+
+	allowed := ts.validateResourceAccess(consentID, "GET", "/accounts/acc-1/transactions?limit=10")
+	ts.True(allowed.IsValid, allowed.ErrorDescription)
+
+	wrongMethod := ts.validateResourceAccess(consentID, "DELETE", "/accounts/acc-1")
+	ts.False(wrongMethod.IsValid)
+	ts.Equal(http.StatusForbidden, wrongMethod.ErrorCode)
+	ts.Equal("policy_violation", wrongMethod.ErrorMessage)
+	ts.Contains(wrongMethod.ErrorDescription, "read-only-accounts")
+
+	otherAccount := ts.validateResourceAccess(consentID, "GET", "/accounts/acc-2")
+	ts.False(otherAccount.IsValid)
+	ts.Equal("policy_violation", otherAccount.ErrorMessage)
+	ts.Contains(otherAccount.ErrorDescription, "authorized-accounts")
+}
+
+// TestValidationPolicy_FrequencyRule denies consents whose frequency exceeds the policy maximum
+func (ts *ConsentAPITestSuite) TestValidationPolicy_FrequencyRule() {
+	consentID := ts.createConsentOrFail(ConsentCreateRequest{
+		Type:           "policy-accounts",
+		Frequency:      10,
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "account", Status: "APPROVED", Resources: []string{"acc-1"}}},
+	})
+
+	result := ts.validateResourceAccess(consentID, "GET", "/accounts/acc-1")
+	ts.False(result.IsValid)
+	ts.Equal("policy_violation", result.ErrorMessage)
+	ts.Contains(result.ErrorDescription, "daily-access-limit")
+}
+
+// TestValidationPolicy_ExtensionRule delegates the decision to the service extension
+func (ts *ConsentAPITestSuite) TestValidationPolicy_ExtensionRule() {
+	ts.startExtensionStub()
+
+	denied := ts.createConsentOrFail(ConsentCreateRequest{
+		Type:           "policy-payments",
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "APPROVED"}},
+		Attributes:     map[string]string{"extension": "deny"},
+	})
+	allowed := ts.createConsentOrFail(ConsentCreateRequest{
+		Type:           "policy-payments",
+		Authorizations: []AuthorizationRequest{{UserID: "user1", Type: "payment", Status: "APPROVED"}},
+	})
+
+	deniedResp := ts.validateResourceAccess(denied, "POST", "/payments")
+	ts.False(deniedResp.IsValid)
+	ts.Equal(http.StatusForbidden, deniedResp.ErrorCode)
+	ts.Contains(deniedResp.ErrorDescription, "payment-checks")
+
+	allowedResp := ts.validateResourceAccess(allowed, "POST", "/payments")
+	ts.True(allowedResp.IsValid, allowedResp.ErrorDescription)
+}
@@ -0,0 +1,134 @@
+package consentpurpose
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// PurposeApproval represents a user who approved a purpose, as returned by the approvals API
+type PurposeApproval struct {
+	UserID        string `json:"userId"`
+	ConsentID     string `json:"consentId"`
+	ConsentType   string `json:"consentType"`
+	ConsentStatus string `json:"consentStatus"`
+	UpdatedTime   int64  `json:"updatedTime"`
+}
+
+// PurposeApprovalListResponse represents the response of the approvals API
+type PurposeApprovalListResponse struct {
+	Data     []PurposeApproval `json:"data"`
+	Metadata struct {
+		Total  int `json:"total"`
+		Offset int `json:"offset"`
+		Count  int `json:"count"`
+		Limit  int `json:"limit"`
+	} `json:"metadata"`
+}
+
+// listPurposeApprovals calls GET /consent-purposes/{purposeId}/approvals with the given query string
+func (ts *PurposeAPITestSuite) listPurposeApprovals(purposeID, query string) (*http.Response, []byte) {
+	url := fmt.Sprintf("%s/api/v1/consent-purposes/%s/approvals", testServerURL, purposeID)
+	if query != "" {
+		url += "?" + query
+	}
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	return resp, body
+}
+
+// createConsentForPurpose creates a consent in which the users authorize the named purpose, approved or not
+func (ts *PurposeAPITestSuite) createConsentForPurpose(purposeName string, approved bool, userIDs ...string) string {
+	authorizations := make([]map[string]interface{}, 0, len(userIDs))
+	for _, userID := range userIDs {
+		authorizations = append(authorizations, map[string]interface{}{"userId": userID, "type": "authorisation", "status": "APPROVED"})
+	}
+	payload := map[string]interface{}{
+		"type":           "marketing",
+		"consentPurpose": []map[string]interface{}{{"name": purposeName, "isUserApproved": approved, "isMandatory": false}},
+		"authorizations": authorizations,
+	}
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	httpReq, _ := http.NewRequest("POST", testServerURL+"/api/v1/consents", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	return created.ID
+}
+
+// TestPurposeApprovals_ListsApprovingUsers checks that only approving users of live consents are listed
+func (ts *PurposeAPITestSuite) TestPurposeApprovals_ListsApprovingUsers() {
+	name := fmt.Sprintf("approvals_%d", time.Now().UnixNano())
+	resp, body := ts.createPurpose([]ConsentPurposeCreateRequest{{Name: name, Type: "string"}})
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var createResp PurposeCreateResponse
+	ts.Require().NoError(json.Unmarshal(body, &createResp))
+	purposeID := createResp.Data[0].ID
+	ts.trackPurpose(purposeID)
+
+	jointConsent := ts.createConsentForPurpose(name, true, "approver-b", "approver-a")
+	declinedConsent := ts.createConsentForPurpose(name, false, "decliner")
+	deletedConsent := ts.createConsentForPurpose(name, true, "deleted-user")
+	defer ts.deleteConsent(jointConsent)
+	defer ts.deleteConsent(declinedConsent)
+	ts.deleteConsent(deletedConsent)
+
+	resp, body = ts.listPurposeApprovals(purposeID, "")
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var approvals PurposeApprovalListResponse
+	ts.Require().NoError(json.Unmarshal(body, &approvals))
+	ts.Equal(2, approvals.Metadata.Total)
+	ts.Require().Len(approvals.Data, 2)
+	ts.Equal("approver-a", approvals.Data[0].UserID)
+	ts.Equal("approver-b", approvals.Data[1].UserID)
+	for _, approval := range approvals.Data {
+		ts.Equal(jointConsent, approval.ConsentID)
+		ts.Equal("marketing", approval.ConsentType)
+		ts.Equal("ACTIVE", approval.ConsentStatus)
+	}
+
+	resp, body = ts.listPurposeApprovals(purposeID, "limit=1&offset=1")
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Require().NoError(json.Unmarshal(body, &approvals))
+	ts.Equal(2, approvals.Metadata.Total)
+	ts.Require().Len(approvals.Data, 1)
+	ts.Equal("approver-b", approvals.Data[0].UserID)
+
+	resp, body = ts.listPurposeApprovals(purposeID, "status=REVOKED")
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.Require().NoError(json.Unmarshal(body, &approvals))
+	ts.Equal(0, approvals.Metadata.Total)
+	ts.Empty(approvals.Data)
+}
+
+// TestPurposeApprovals_UnknownPurpose_ReturnsNotFound checks the response for a purpose that does not exist
+func (ts *PurposeAPITestSuite) TestPurposeApprovals_UnknownPurpose_ReturnsNotFound() {
+	resp, body := ts.listPurposeApprovals("00000000-0000-0000-0000-000000000000", "")
+	ts.Equal(http.StatusNotFound, resp.StatusCode, string(body))
+}
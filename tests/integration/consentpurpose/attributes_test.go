@@ -0,0 +1,163 @@
+package consentpurpose
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// PurposeAttribute represents a single purpose attribute returned by the attribute API
+type PurposeAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// PurposeAttributeListResponse represents the response of the attribute list API
+type PurposeAttributeListResponse struct {
+	Data []PurposeAttribute `json:"data"`
+}
+
+// sendPurposeAttributeRequest calls the purpose attribute API. An empty key targets the attribute collection.
+func (ts *PurposeAPITestSuite) sendPurposeAttributeRequest(method, purposeID, key string, payload interface{}) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/consent-purposes/%s/attributes", testServerURL, purposeID)
+	if key != "" {
+		url += "/" + key
+	}
+	httpReq, _ := http.NewRequest(method, url, reqBody)
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// createPurposeForAttributes creates a purpose with the given type and attributes and returns its ID
+func (ts *PurposeAPITestSuite) createPurposeForAttributes(purposeType string, attributes map[string]string) string {
+	resp, body := ts.createPurpose([]ConsentPurposeCreateRequest{{
+		Name:       fmt.Sprintf("test_attributes_%d", time.Now().UnixNano()),
+		Type:       purposeType,
+		Attributes: attributes,
+	}})
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var createResp PurposeCreateResponse
+	ts.Require().NoError(json.Unmarshal(body, &createResp))
+	ts.Require().Len(createResp.Data, 1)
+	ts.trackPurpose(createResp.Data[0].ID)
+	return createResp.Data[0].ID
+}
+
+// TestPurposeAttributes_Lifecycle creates, lists, updates and deletes purpose attributes
+func (ts *PurposeAPITestSuite) TestPurposeAttributes_Lifecycle() {
+	purposeID := ts.createPurposeForAttributes("string", nil)
+
+	for _, attr := range []PurposeAttribute{{"legalBasis", "consent"}, {"category", "marketing"}} {
+		resp, body := ts.sendPurposeAttributeRequest("POST", purposeID, "", attr)
+		resp.Body.Close()
+		ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	}
+
+	resp, body := ts.sendPurposeAttributeRequest("PUT", purposeID, "category", map[string]string{"value": "analytics"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var updated PurposeAttribute
+	ts.Require().NoError(json.Unmarshal(body, &updated))
+	ts.Equal(PurposeAttribute{"category", "analytics"}, updated)
+
+	resp, body = ts.sendPurposeAttributeRequest("DELETE", purposeID, "legalBasis", nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+
+	resp, body = ts.sendPurposeAttributeRequest("POST", purposeID, "", PurposeAttribute{"retention", "P2Y"})
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	resp, body = ts.sendPurposeAttributeRequest("GET", purposeID, "", nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list PurposeAttributeListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Equal([]PurposeAttribute{{"category", "analytics"}, {"retention", "P2Y"}}, list.Data)
+
+	// The purpose itself reflects the changes
+	getResp, getBody := ts.getPurpose(purposeID)
+	getResp.Body.Close()
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+
+	var purpose PurposeResponse
+	ts.Require().NoError(json.Unmarshal(getBody, &purpose))
+	ts.Equal(map[string]string{"category": "analytics", "retention": "P2Y"}, purpose.Attributes)
+}
+
+// TestPurposeAttributes_ErrorCases checks missing purposes and attributes, duplicates and key validation
+func (ts *PurposeAPITestSuite) TestPurposeAttributes_ErrorCases() {
+	purposeID := ts.createPurposeForAttributes("string", map[string]string{"category": "marketing"})
+
+	testCases := []struct {
+		name      string
+		method    string
+		purposeID string
+		key       string
+		payload   interface{}
+		status    int
+	}{
+		{"duplicate key", "POST", purposeID, "", PurposeAttribute{"category", "other"}, http.StatusConflict},
+		{"missing key", "POST", purposeID, "", PurposeAttribute{"", "value"}, http.StatusBadRequest},
+		{"key mismatch", "PUT", purposeID, "category", PurposeAttribute{"other", "value"}, http.StatusBadRequest},
+		{"update unknown attribute", "PUT", purposeID, "unknown", map[string]string{"value": "x"}, http.StatusNotFound},
+		{"delete unknown attribute", "DELETE", purposeID, "unknown", nil, http.StatusNotFound},
+		{"unknown purpose", "GET", "non-existent-purpose-id", "", nil, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			resp, body := ts.sendPurposeAttributeRequest(tc.method, tc.purposeID, tc.key, tc.payload)
+			defer resp.Body.Close()
+			ts.Equal(tc.status, resp.StatusCode, string(body))
+		})
+	}
+}
+
+// TestPurposeAttributes_RequiredByType_CannotBeDeleted checks that attribute changes are validated
+// by the purpose type
+func (ts *PurposeAPITestSuite) TestPurposeAttributes_RequiredByType_CannotBeDeleted() {
+	purposeID := ts.createPurposeForAttributes("json-schema", map[string]string{"validationSchema": "{}"})
+
+	resp, body := ts.sendPurposeAttributeRequest("DELETE", purposeID, "validationSchema", nil)
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "validationSchema")
+
+	resp, body = ts.sendPurposeAttributeRequest("PUT", purposeID, "validationSchema", map[string]string{"value": "not json"})
+	resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = ts.sendPurposeAttributeRequest("GET", purposeID, "", nil)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list PurposeAttributeListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	ts.Equal([]PurposeAttribute{{"validationSchema", "{}"}}, list.Data)
+}
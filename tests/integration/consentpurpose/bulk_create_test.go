@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package consentpurpose
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// ========================================
+// POST /consent-purposes/bulk Tests
+// ========================================
+
+// BulkCreateResponse represents the response of a bulk purpose creation
+type BulkCreateResponse struct {
+	Data []struct {
+		Index   int              `json:"index"`
+		Name    string           `json:"name"`
+		Status  string           `json:"status"`
+		Purpose *PurposeResponse `json:"purpose"`
+		Error   *struct {
+			Code       string           `json:"code"`
+			Message    string           `json:"message"`
+			Violations []FieldViolation `json:"violations"`
+		} `json:"error"`
+	} `json:"data"`
+	Summary struct {
+		Total   int `json:"total"`
+		Created int `json:"created"`
+		Failed  int `json:"failed"`
+	} `json:"summary"`
+}
+
+// bulkCreatePurposes posts purposes to the bulk endpoint
+func (ts *PurposeAPITestSuite) bulkCreatePurposes(payload interface{}) (*http.Response, []byte) {
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	httpReq, _ := http.NewRequest("POST", testServerURL+"/api/v1/consent-purposes/bulk", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// TestBulkCreatePurposes_ReportsEveryItem verifies that valid purposes are created while invalid,
+// duplicate and existing names are reported per item
+func (ts *PurposeAPITestSuite) TestBulkCreatePurposes_ReportsEveryItem() {
+	resp, body := ts.createPurpose([]ConsentPurposeCreateRequest{{Name: "test_bulk_existing", Type: "string"}})
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	var existing PurposeCreateResponse
+	ts.Require().NoError(json.Unmarshal(body, &existing))
+	ts.trackPurpose(existing.Data[0].ID)
+
+	payload := make([]ConsentPurposeCreateRequest, 0, 120)
+	for i := 0; i < 120; i++ {
+		payload = append(payload, ConsentPurposeCreateRequest{
+			Name:       fmt.Sprintf("test_bulk_%d", i),
+			Type:       "attribute",
+			Attributes: map[string]string{"resourcePath": fmt.Sprintf("/accounts/%d", i), "jsonPath": "$.data"},
+		})
+	}
+	payload[10].Attributes = map[string]string{"resourcePath": "/accounts"}
+	payload[20].Name = "test_bulk_existing"
+	payload[30].Name = "test_bulk_5"
+
+	resp, body = ts.bulkCreatePurposes(payload)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var result BulkCreateResponse
+	ts.Require().NoError(json.Unmarshal(body, &result))
+	ts.Require().Len(result.Data, 120)
+	for _, item := range result.Data {
+		if item.Purpose != nil {
+			ts.trackPurpose(item.Purpose.ID)
+		}
+	}
+	ts.Equal(120, result.Summary.Total)
+	ts.Equal(117, result.Summary.Created)
+	ts.Equal(3, result.Summary.Failed)
+
+	ts.Equal("CREATED", result.Data[0].Status)
+	ts.Require().NotNil(result.Data[0].Purpose)
+	ts.Equal("/accounts/0", result.Data[0].Purpose.Attributes["resourcePath"])
+
+	ts.Equal("FAILED", result.Data[10].Status)
+	ts.Require().NotNil(result.Data[10].Error)
+	ts.Equal("CSE-4001", result.Data[10].Error.Code)
+	ts.Equal([]FieldViolation{{Path: "attributes.jsonPath", Constraint: "required", Message: "jsonPath is required for attribute type"}},
+		result.Data[10].Error.Violations)
+
+	ts.Equal("FAILED", result.Data[20].Status)
+	ts.Equal("CSE-4009", result.Data[20].Error.Code)
+
+	ts.Equal(30, result.Data[30].Index)
+	ts.Equal("FAILED", result.Data[30].Status)
+	ts.Contains(result.Data[30].Error.Message, "duplicate purpose name 'test_bulk_5'")
+
+	// Created purposes are stored with their attributes
+	getResp, getBody := ts.getPurpose(result.Data[119].Purpose.ID)
+	ts.Require().Equal(http.StatusOK, getResp.StatusCode, string(getBody))
+	ts.Contains(string(getBody), "/accounts/119")
+}
+
+// TestBulkCreatePurposes_EmptyRequest_ReturnsBadRequest verifies that a bulk request needs items
+func (ts *PurposeAPITestSuite) TestBulkCreatePurposes_EmptyRequest_ReturnsBadRequest() {
+	resp, body := ts.bulkCreatePurposes([]ConsentPurposeCreateRequest{})
+	ts.Require().Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "at least one purpose must be provided")
+}
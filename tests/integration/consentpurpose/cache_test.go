@@ -0,0 +1,181 @@
+package consentpurpose
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// purposeCacheStats holds the counters of the purpose definition cache
+type purposeCacheStats struct {
+	Name   string `json:"name"`
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// getPurposeCacheStats reads the purpose cache statistics from the admin cache API
+func (ts *PurposeAPITestSuite) getPurposeCacheStats() purposeCacheStats {
+	req, err := http.NewRequest("GET", testServerURL+"/api/v1/admin/caches/consent-purpose", nil)
+	ts.Require().NoError(err)
+	req.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+
+	resp, err := testutils.GetHTTPClient().Do(req)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var stats purposeCacheStats
+	ts.Require().NoError(json.Unmarshal(body, &stats))
+	return stats
+}
+
+// invalidatePurposeCache invalidates purpose cache entries through the admin cache API and returns
+// the response
+func (ts *PurposeAPITestSuite) invalidatePurposeCache(query string, withAdminAuth bool) (*http.Response, []byte) {
+	req, err := http.NewRequest("DELETE", testServerURL+"/api/v1/admin/caches/consent-purpose?"+query, nil)
+	ts.Require().NoError(err)
+	if withAdminAuth {
+		req.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	}
+
+	resp, err := testutils.GetHTTPClient().Do(req)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+	return resp, body
+}
+
+// ========================================
+// Purpose definition cache Tests
+// ========================================
+
+// TestPurposeCache_RepeatedReadsHitCache checks that a purpose read twice is served from the cache
+// the second time
+func (ts *PurposeAPITestSuite) TestPurposeCache_RepeatedReadsHitCache() {
+	t := ts.T()
+
+	resp, bodyBytes := ts.createPurpose([]ConsentPurposeCreateRequest{
+		{Name: "test_cache_hits", Description: "Cached purpose", Type: "string"},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(bodyBytes))
+
+	var createResp PurposeCreateResponse
+	require.NoError(t, json.Unmarshal(bodyBytes, &createResp))
+	purposeID := createResp.Data[0].ID
+	ts.trackPurpose(purposeID)
+
+	resp, _ = ts.getPurpose(purposeID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	before := ts.getPurposeCacheStats()
+
+	resp, _ = ts.getPurpose(purposeID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	after := ts.getPurposeCacheStats()
+
+	require.Equal(t, "consent-purpose", after.Name)
+	require.Greater(t, after.Hits, before.Hits)
+}
+
+// TestPurposeCache_UpdateInvalidatesCachedPurpose checks that a cached purpose is not served after
+// it is updated
+func (ts *PurposeAPITestSuite) TestPurposeCache_UpdateInvalidatesCachedPurpose() {
+	t := ts.T()
+
+	resp, bodyBytes := ts.createPurpose([]ConsentPurposeCreateRequest{
+		{Name: "test_cache_update", Description: "Before update", Type: "string"},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(bodyBytes))
+
+	var createResp PurposeCreateResponse
+	require.NoError(t, json.Unmarshal(bodyBytes, &createResp))
+	purposeID := createResp.Data[0].ID
+	ts.trackPurpose(purposeID)
+
+	// Populate the cache
+	resp, _ = ts.getPurpose(purposeID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, bodyBytes = ts.updatePurpose(purposeID, ConsentPurposeUpdateRequest{
+		Name:        "test_cache_update",
+		Description: "After update",
+		Type:        "string",
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(bodyBytes))
+
+	resp, bodyBytes = ts.getPurpose(purposeID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var purpose PurposeResponse
+	require.NoError(t, json.Unmarshal(bodyBytes, &purpose))
+	require.NotNil(t, purpose.Description)
+	require.Equal(t, "After update", *purpose.Description)
+}
+
+// TestPurposeCache_DeleteInvalidatesCachedPurpose checks that a deleted purpose is not served from
+// the cache
+func (ts *PurposeAPITestSuite) TestPurposeCache_DeleteInvalidatesCachedPurpose() {
+	t := ts.T()
+
+	resp, bodyBytes := ts.createPurpose([]ConsentPurposeCreateRequest{
+		{Name: "test_cache_delete", Description: "Deleted purpose", Type: "string"},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(bodyBytes))
+
+	var createResp PurposeCreateResponse
+	require.NoError(t, json.Unmarshal(bodyBytes, &createResp))
+	purposeID := createResp.Data[0].ID
+
+	// Populate the cache
+	resp, _ = ts.getPurpose(purposeID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.True(t, ts.deletePurposeWithCheck(purposeID))
+
+	resp, _ = ts.getPurpose(purposeID)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// TestPurposeCache_AdminInvalidationEvictsPurpose checks that a purpose invalidated through the
+// admin cache API is read from the database again
+func (ts *PurposeAPITestSuite) TestPurposeCache_AdminInvalidationEvictsPurpose() {
+	t := ts.T()
+
+	resp, bodyBytes := ts.createPurpose([]ConsentPurposeCreateRequest{
+		{Name: "test_cache_admin", Description: "Invalidated purpose", Type: "string"},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(bodyBytes))
+
+	var createResp PurposeCreateResponse
+	require.NoError(t, json.Unmarshal(bodyBytes, &createResp))
+	purposeID := createResp.Data[0].ID
+	ts.trackPurpose(purposeID)
+
+	// Populate the cache
+	resp, _ = ts.getPurpose(purposeID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, bodyBytes = ts.invalidatePurposeCache("purposeName=test_cache_admin&orgId="+testOrgID, false)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode, string(bodyBytes))
+
+	resp, bodyBytes = ts.invalidatePurposeCache("purposeName=test_cache_admin&orgId="+testOrgID, true)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(bodyBytes))
+	var invalidation struct {
+		TotalRemoved int `json:"totalRemoved"`
+	}
+	require.NoError(t, json.Unmarshal(bodyBytes, &invalidation))
+	require.Positive(t, invalidation.TotalRemoved)
+
+	before := ts.getPurposeCacheStats()
+	resp, _ = ts.getPurpose(purposeID)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	after := ts.getPurposeCacheStats()
+	require.Greater(t, after.Misses, before.Misses)
+}
@@ -21,6 +21,7 @@ package consentpurpose
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -550,9 +551,35 @@ func (ts *PurposeAPITestSuite) TestCreatePurpose_ErrorCases() {
 			// Verify error code and message
 			ts.Require().Equal(tc.expectedCode, errResp.Code, "Test case: %s", tc.name)
 			if tc.messageContains != "" {
-				ts.Require().Contains(strings.ToLower(errResp.Description), strings.ToLower(tc.messageContains), "Test case: %s - Description: %s", tc.name, errResp.Description)
+				ts.Require().Contains(strings.ToLower(errResp.Detail), strings.ToLower(tc.messageContains), "Test case: %s - Description: %s", tc.name, errResp.Detail)
 			}
 			ts.Require().NotEmpty(errResp.TraceID, "Test case: %s", tc.name)
 		})
 	}
 }
+
+// TestCreatePurposes_InvalidEntries_ReturnsViolationPerField verifies that every invalid field of a
+// batch is reported with its index, so clients can point at the offending entries
+func (ts *PurposeAPITestSuite) TestCreatePurposes_InvalidEntries_ReturnsViolationPerField() {
+	payload := make([]ConsentPurposeCreateRequest, 0, 50)
+	for i := 0; i < 50; i++ {
+		payload = append(payload, ConsentPurposeCreateRequest{Name: fmt.Sprintf("test_violation_%d", i), Type: "string"})
+	}
+	payload[7].Name = ""
+	payload[23].Type = "attribute"
+	payload[41].Name = "test_violation_3"
+
+	resp, body := ts.createPurpose(payload)
+	ts.Require().Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+
+	var errResp ErrorResponse
+	ts.Require().NoError(json.Unmarshal(body, &errResp))
+	ts.Equal("CSE-4001", errResp.Code)
+	ts.Equal([]FieldViolation{
+		{Path: "[7].name", Constraint: "required", Message: "purpose name is required"},
+		{Path: "[23].attributes.resourcePath", Constraint: "required", Message: "resourcePath is required for attribute type"},
+		{Path: "[23].attributes.jsonPath", Constraint: "required", Message: "jsonPath is required for attribute type"},
+		{Path: "[41].name", Constraint: "unique", Message: "duplicate purpose name 'test_violation_3' in request batch"},
+	}, errResp.Violations)
+	ts.Contains(errResp.Detail, "[23].attributes.jsonPath")
+}
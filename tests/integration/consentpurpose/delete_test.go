@@ -1,11 +1,14 @@
 package consentpurpose
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/wso2/consent-management-api/tests/integration/testutils"
@@ -237,8 +240,132 @@ func (ts *PurposeAPITestSuite) TestDeletePurpose_ErrorCases() {
 			var errResp ErrorResponse
 			json.NewDecoder(resp.Body).Decode(&errResp)
 			require.Equal(t, tc.expectedCode, errResp.Code, "Error code mismatch")
-			require.Contains(t, strings.ToLower(errResp.Description), strings.ToLower(tc.messageContains),
-				"Error message should contain '%s', got: %s", tc.messageContains, errResp.Description)
+			require.Contains(t, strings.ToLower(errResp.Detail), strings.ToLower(tc.messageContains),
+				"Error message should contain '%s', got: %s", tc.messageContains, errResp.Detail)
 		})
 	}
 }
+
+// createLinkedConsent creates a consent that uses the named purpose and returns its ID
+func (ts *PurposeAPITestSuite) createLinkedConsent(purposeName string) string {
+	payload := map[string]interface{}{
+		"type": "marketing",
+		"consentPurpose": []map[string]interface{}{
+			{"name": purposeName, "isUserApproved": true},
+		},
+		"authorizations": []map[string]interface{}{
+			{"userId": "linked-user", "type": "authorisation", "status": "APPROVED"},
+		},
+	}
+	reqBody, err := json.Marshal(payload)
+	ts.Require().NoError(err)
+
+	httpReq, _ := http.NewRequest("POST", testServerURL+"/api/v1/consents", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	return created.ID
+}
+
+// deleteConsent soft-deletes a consent, ignoring failures
+func (ts *PurposeAPITestSuite) deleteConsent(consentID string) {
+	httpReq, _ := http.NewRequest("DELETE", testServerURL+"/api/v1/consents/"+consentID, nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+	if resp, err := testutils.GetHTTPClient().Do(httpReq); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// createLinkedPurpose creates a string purpose and links it to a new consent
+func (ts *PurposeAPITestSuite) createLinkedPurpose(name string) (string, string) {
+	resp, body := ts.createPurpose([]ConsentPurposeCreateRequest{{Name: name, Type: "string"}})
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var createResp PurposeCreateResponse
+	ts.Require().NoError(json.Unmarshal(body, &createResp))
+	purposeID := createResp.Data[0].ID
+	ts.trackPurpose(purposeID)
+
+	return purposeID, ts.createLinkedConsent(name)
+}
+
+// TestDeletePurpose_LinkedToConsent_ReturnsConflict checks that purposes in use are not deleted
+func (ts *PurposeAPITestSuite) TestDeletePurpose_LinkedToConsent_ReturnsConflict() {
+	purposeID, consentID := ts.createLinkedPurpose(fmt.Sprintf("linked_%d", time.Now().UnixNano()))
+	defer ts.deleteConsent(consentID)
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v1/consent-purposes/%s", testServerURL, purposeID), nil)
+	req.Header.Set(testutils.HeaderOrgID, testOrgID)
+	req.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+	resp, err := testutils.GetHTTPClient().Do(req)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	ts.Require().Equal(http.StatusConflict, resp.StatusCode)
+	var errResp ErrorResponse
+	ts.Require().NoError(json.NewDecoder(resp.Body).Decode(&errResp))
+	ts.Equal("CSE-4009", errResp.Code)
+	ts.Contains(errResp.Detail, "linked to 1 consent(s)")
+
+	resp, _ = ts.getPurpose(purposeID)
+	ts.Equal(http.StatusOK, resp.StatusCode, "purpose in use must not be deleted")
+}
+
+// TestDeletePurpose_LinkedToDeletedConsent_Succeeds checks that soft-deleted consents do not block deletion
+func (ts *PurposeAPITestSuite) TestDeletePurpose_LinkedToDeletedConsent_Succeeds() {
+	purposeID, consentID := ts.createLinkedPurpose(fmt.Sprintf("linked_deleted_%d", time.Now().UnixNano()))
+	ts.deleteConsent(consentID)
+
+	ts.True(ts.deletePurposeWithCheck(purposeID))
+}
+
+// TestDeletePurpose_Force_DetachesConsents force deletes a purpose in use as an admin
+func (ts *PurposeAPITestSuite) TestDeletePurpose_Force_DetachesConsents() {
+	purposeID, consentID := ts.createLinkedPurpose(fmt.Sprintf("linked_force_%d", time.Now().UnixNano()))
+	defer ts.deleteConsent(consentID)
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("%s/api/v1/consent-purposes/%s?force=true", testServerURL, purposeID), nil)
+	req.Header.Set(testutils.HeaderOrgID, testOrgID)
+	req.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+	req.SetBasicAuth(testutils.AdminUsername, testutils.AdminPassword)
+	resp, err := testutils.GetHTTPClient().Do(req)
+	ts.Require().NoError(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var deleteResp struct {
+		PurposeID        string `json:"purposeId"`
+		DetachedConsents int    `json:"detachedConsents"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &deleteResp))
+	ts.Equal(purposeID, deleteResp.PurposeID)
+	ts.Equal(1, deleteResp.DetachedConsents)
+
+	resp, _ = ts.getPurpose(purposeID)
+	ts.Equal(http.StatusNotFound, resp.StatusCode)
+
+	req, _ = http.NewRequest("GET", testServerURL+"/api/v1/consents/"+consentID, nil)
+	req.Header.Set(testutils.HeaderOrgID, testOrgID)
+	req.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+	resp, err = testutils.GetHTTPClient().Do(req)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode)
+	var consent struct {
+		ConsentPurpose []map[string]interface{} `json:"consentPurpose"`
+	}
+	ts.Require().NoError(json.NewDecoder(resp.Body).Decode(&consent))
+	ts.Empty(consent.ConsentPurpose, "purpose should be detached from the consent")
+}
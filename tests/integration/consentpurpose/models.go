@@ -39,8 +39,10 @@ type PurposeResponse struct {
 	Description *string           `json:"description,omitempty"`
 	Type        string            `json:"type"`
 	Attributes  map[string]string `json:"attributes,omitempty"`
-	CreatedAt   string            `json:"createdAt,omitempty"`
-	UpdatedAt   string            `json:"updatedAt,omitempty"`
+	// ConsentCount is only returned by the list API
+	ConsentCount *int   `json:"consentCount,omitempty"`
+	CreatedAt    string `json:"createdAt,omitempty"`
+	UpdatedAt    string `json:"updatedAt,omitempty"`
 }
 
 type PurposeListResponse struct {
@@ -61,8 +63,20 @@ type PurposeCreateResponse struct {
 }
 
 type ErrorResponse struct {
-	Code        string `json:"code"`
-	Message     string `json:"message"`
-	Description string `json:"description,omitempty"`
-	TraceID     string `json:"traceId,omitempty"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+	TraceID  string `json:"traceId"`
+
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+// FieldViolation represents one invalid request field of a validation error
+type FieldViolation struct {
+	Path       string `json:"path"`
+	Constraint string `json:"constraint"`
+	Message    string `json:"message"`
 }
@@ -154,7 +154,7 @@ func (ts *PurposeAPITestSuite) TestGetPurposeByID_NonExistent_ReturnsNotFound()
 	err := json.Unmarshal([]byte(body), &errResp)
 	require.NoError(t, err)
 	require.Equal(t, "CSE-4004", errResp.Code)
-	require.Contains(t, strings.ToLower(errResp.Description), "not found")
+	require.Contains(t, strings.ToLower(errResp.Detail), "not found")
 }
 
 // TestGetPurposeByID_AfterDelete_ReturnsNotFound tests getting deleted purpose returns 404
@@ -237,8 +237,8 @@ func (ts *PurposeAPITestSuite) TestGetPurposeByID_ErrorCases() {
 			var errResp ErrorResponse
 			json.NewDecoder(resp.Body).Decode(&errResp)
 			require.Equal(t, tc.expectedCode, errResp.Code, "Error code mismatch")
-			require.Contains(t, strings.ToLower(errResp.Description), strings.ToLower(tc.messageContains),
-				"Error message should contain '%s', got: %s", tc.messageContains, errResp.Description)
+			require.Contains(t, strings.ToLower(errResp.Detail), strings.ToLower(tc.messageContains),
+				"Error message should contain '%s', got: %s", tc.messageContains, errResp.Detail)
 		})
 	}
 }
@@ -551,7 +551,7 @@ func (ts *PurposeAPITestSuite) TestListPurposes_ErrorCases() {
 			var errResp ErrorResponse
 			json.NewDecoder(resp.Body).Decode(&errResp)
 			require.Equal(t, tc.expectedCode, errResp.Code)
-			require.Contains(t, strings.ToLower(errResp.Description), strings.ToLower(tc.messageContains))
+			require.Contains(t, strings.ToLower(errResp.Detail), strings.ToLower(tc.messageContains))
 		})
 	}
 }
@@ -0,0 +1,125 @@
+package consentpurpose
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+// listPurposesWithQuery calls the purpose list API with the given query parameters
+func (ts *PurposeAPITestSuite) listPurposesWithQuery(query url.Values) (*http.Response, []byte) {
+	httpReq, _ := http.NewRequest("GET", testServerURL+"/api/v1/consent-purposes?"+query.Encode(), nil)
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testutils.TestClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// searchPurposeNames lists purposes with the given query parameters and returns their names
+func (ts *PurposeAPITestSuite) searchPurposeNames(query url.Values) ([]string, PurposeListResponse) {
+	resp, body := ts.listPurposesWithQuery(query)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	var list PurposeListResponse
+	ts.Require().NoError(json.Unmarshal(body, &list))
+	names := make([]string, 0, len(list.Data))
+	for _, p := range list.Data {
+		names = append(names, p.Name)
+	}
+	return names, list
+}
+
+// createSearchCatalog creates a small purpose catalog whose names share a unique prefix
+func (ts *PurposeAPITestSuite) createSearchCatalog() string {
+	prefix := fmt.Sprintf("catalog%d_", time.Now().UnixNano())
+	purposes := []ConsentPurposeCreateRequest{
+		{Name: prefix + "email", Type: "string", Attributes: map[string]string{"category": "marketing", "legalBasis": "consent"}},
+		{Name: prefix + "sms", Type: "string", Attributes: map[string]string{"category": "marketing"}},
+		{Name: prefix + "profile", Type: "attribute", Attributes: map[string]string{"category": "profile", "resourcePath": "/users/{id}", "jsonPath": "$.name"}},
+		{Name: prefix + "accounts", Type: "json-schema", Attributes: map[string]string{"validationSchema": "{}"}},
+	}
+
+	resp, body := ts.createPurpose(purposes)
+	defer resp.Body.Close()
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var createResp PurposeCreateResponse
+	ts.Require().NoError(json.Unmarshal(body, &createResp))
+	for _, p := range createResp.Data {
+		ts.trackPurpose(p.ID)
+	}
+	return prefix
+}
+
+// TestListPurposes_Filters checks the name prefix, type and attribute filters and pagination
+func (ts *PurposeAPITestSuite) TestListPurposes_Filters() {
+	prefix := ts.createSearchCatalog()
+
+	testCases := []struct {
+		name     string
+		query    url.Values
+		expected []string
+	}{
+		{"name prefix", url.Values{"namePrefix": {prefix}}, []string{"accounts", "email", "profile", "sms"}},
+		{"type", url.Values{"namePrefix": {prefix}, "types": {"attribute,json-schema"}}, []string{"accounts", "profile"}},
+		{"attribute key", url.Values{"namePrefix": {prefix}, "attributeKey": {"category"}}, []string{"email", "profile", "sms"}},
+		{"attribute value", url.Values{"namePrefix": {prefix}, "attributeKey": {"category"}, "attributeValue": {"marketing"}}, []string{"email", "sms"}},
+		{"combined", url.Values{"namePrefix": {prefix}, "types": {"string"}, "attributeKey": {"legalBasis"}}, []string{"email"}},
+		{"wildcards are literal", url.Values{"namePrefix": {prefix + "%"}}, []string{}},
+	}
+
+	for _, tc := range testCases {
+		ts.Run(tc.name, func() {
+			names, list := ts.searchPurposeNames(tc.query)
+			expected := make([]string, 0, len(tc.expected))
+			for _, name := range tc.expected {
+				expected = append(expected, prefix+name)
+			}
+			ts.Equal(expected, names)
+			ts.Equal(len(expected), list.Metadata.Total)
+		})
+	}
+
+	names, list := ts.searchPurposeNames(url.Values{"namePrefix": {prefix}, "limit": {"2"}, "offset": {"2"}})
+	ts.Equal([]string{prefix + "profile", prefix + "sms"}, names)
+	ts.Equal(4, list.Metadata.Total)
+	ts.Equal(2, list.Metadata.Count)
+}
+
+// TestListPurposes_AttributeValueWithoutKey_ReturnsBadRequest checks that a value filter needs a key
+func (ts *PurposeAPITestSuite) TestListPurposes_AttributeValueWithoutKey_ReturnsBadRequest() {
+	resp, body := ts.listPurposesWithQuery(url.Values{"attributeValue": {"marketing"}})
+	defer resp.Body.Close()
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+// TestListPurposes_ConsentCount counts the consents linked to each listed purpose
+func (ts *PurposeAPITestSuite) TestListPurposes_ConsentCount() {
+	prefix := ts.createSearchCatalog()
+
+	for i := 0; i < 2; i++ {
+		consentID := ts.createLinkedConsent(prefix + "email")
+		defer ts.deleteConsent(consentID)
+	}
+
+	_, list := ts.searchPurposeNames(url.Values{"namePrefix": {prefix}, "types": {"string"}})
+	ts.Require().Len(list.Data, 2)
+	counts := make(map[string]int, len(list.Data))
+	for _, p := range list.Data {
+		ts.Require().NotNil(p.ConsentCount, p.Name)
+		counts[p.Name] = *p.ConsentCount
+	}
+	ts.Equal(map[string]int{prefix + "email": 2, prefix + "sms": 0}, counts)
+}
@@ -201,7 +201,7 @@ func (ts *PurposeAPITestSuite) TestUpdatePurpose_NonExistent_ReturnsNotFound() {
 	var errResp ErrorResponse
 	json.Unmarshal(bodyBytes, &errResp)
 	require.Equal(t, "CSE-4004", errResp.Code)
-	require.Contains(t, strings.ToLower(errResp.Description), "not found")
+	require.Contains(t, strings.ToLower(errResp.Detail), "not found")
 }
 
 // TestUpdatePurpose_ErrorCases tests error scenarios for UPDATE
@@ -367,8 +367,8 @@ func (ts *PurposeAPITestSuite) TestUpdatePurpose_ErrorCases() {
 			var errResp ErrorResponse
 			json.Unmarshal(body, &errResp)
 			require.Equal(t, tc.expectedCode, errResp.Code, "Error code mismatch for %s", tc.name)
-			require.Contains(t, strings.ToLower(errResp.Description), strings.ToLower(tc.messageContains),
-				"Error message should contain '%s', got: %s", tc.messageContains, errResp.Description)
+			require.Contains(t, strings.ToLower(errResp.Detail), strings.ToLower(tc.messageContains),
+				"Error message should contain '%s', got: %s", tc.messageContains, errResp.Detail)
 		})
 	}
 }
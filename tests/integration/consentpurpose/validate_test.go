@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"testing"
@@ -113,7 +114,7 @@ func (ts *PurposeAPITestSuite) TestValidatePurposes_NoneValid_ReturnsEmpty() {
 	var errResp ErrorResponse
 	json.NewDecoder(resp.Body).Decode(&errResp)
 	require.Equal(t, "CSE-4001", errResp.Code)
-	require.Contains(t, strings.ToLower(errResp.Description), "no valid purposes found")
+	require.Contains(t, strings.ToLower(errResp.Detail), "no valid purposes found")
 }
 
 // TestValidatePurposes_SingleName_ReturnsOne tests single name validation
@@ -290,12 +291,52 @@ func (ts *PurposeAPITestSuite) TestValidatePurposes_ErrorCases() {
 			var errResp ErrorResponse
 			json.NewDecoder(resp.Body).Decode(&errResp)
 			require.Equal(t, tc.expectedCode, errResp.Code, "Error code mismatch")
-			require.Contains(t, strings.ToLower(errResp.Description), strings.ToLower(tc.messageContains),
-				"Error message should contain '%s', got: %s", tc.messageContains, errResp.Description)
+			require.Contains(t, strings.ToLower(errResp.Detail), strings.ToLower(tc.messageContains),
+				"Error message should contain '%s', got: %s", tc.messageContains, errResp.Detail)
 		})
 	}
 }
 
+// TestValidatePurposes_BodyOverRouteLimit_ReturnsPayloadTooLarge verifies the per-route body limit
+// configured for purpose validation in the integration deployment.yaml
+func (ts *PurposeAPITestSuite) TestValidatePurposes_BodyOverRouteLimit_ReturnsPayloadTooLarge() {
+	post := func(names []string) (*http.Response, []byte) {
+		reqBody, err := json.Marshal(names)
+		ts.Require().NoError(err)
+
+		httpReq, _ := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/consent-purposes/validate", baseURL),
+			bytes.NewBuffer(reqBody))
+		httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+		httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+		httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+		resp, err := testutils.GetHTTPClient().Do(httpReq)
+		ts.Require().NoError(err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		ts.Require().NoError(err)
+		return resp, body
+	}
+
+	names := make([]string, 0, 400)
+	for i := 0; i < 400; i++ {
+		names = append(names, fmt.Sprintf("test_body_limit_%d", i))
+	}
+
+	resp, body := post(names)
+	ts.Require().Equal(http.StatusRequestEntityTooLarge, resp.StatusCode, string(body))
+
+	var errResp ErrorResponse
+	ts.Require().NoError(json.Unmarshal(body, &errResp))
+	ts.Equal("CSE-4132", errResp.Code)
+	ts.Contains(errResp.Detail, "4096 bytes")
+
+	// A request under the limit reaches the handler, which finds none of the names
+	resp, body = post(names[:10])
+	ts.Equal(http.StatusBadRequest, resp.StatusCode, string(body))
+	ts.Contains(string(body), "no valid purposes found")
+}
+
 // Helper method to validate purpose names
 func (ts *PurposeAPITestSuite) validatePurposes(names []string) []string {
 	reqBody, err := json.Marshal(names)
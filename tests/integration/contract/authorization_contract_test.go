@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package contract
+
+import (
+	"net/http"
+)
+
+// authorizationPayload builds an authorization request with every optional field set
+func authorizationPayload(userID, accountID string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":      "authorization",
+		"userId":    userID,
+		"status":    "APPROVED",
+		"resources": map[string]interface{}{"accountIds": []string{accountID}},
+	}
+}
+
+// TestContract_Authorizations pins the authorization resource responses
+func (ts *ContractAPITestSuite) TestContract_Authorizations() {
+	userID := uniqueName("contract-user")
+	created, _ := ts.createContractConsent(ts.consentPayload("accounts", userID))
+	ts.Require().Len(created.Authorizations, 1)
+	basePath := "/consents/" + created.ID + "/authorizations"
+
+	resp, body := ts.doRequest(http.MethodPost, basePath, authorizationPayload(userID, "acc-2"))
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	ts.assertShape("authorization-create", body)
+
+	authPath := basePath + "/" + created.Authorizations[0].ID
+
+	resp, body = ts.doRequest(http.MethodGet, basePath, nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.assertShape("authorization-list", body)
+
+	resp, body = ts.doRequest(http.MethodGet, authPath, nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.assertShape("authorization-get", body)
+
+	resp, body = ts.doRequest(http.MethodPut, authPath, map[string]interface{}{
+		"status":    "APPROVED",
+		"resources": map[string]interface{}{"accountIds": []string{"acc-3"}},
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.assertShape("authorization-update", body)
+
+	resp, body = ts.doRequest(http.MethodPatch, authPath, map[string]interface{}{
+		"resources": map[string]interface{}{"accountIds": []string{"acc-4"}},
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.assertShape("authorization-patch", body)
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// consentPayload builds a consent request with every optional field set, so that fields omitted
+// only when empty are part of the pinned shape
+func (ts *ContractAPITestSuite) consentPayload(consentType, userID string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                       consentType,
+		"validityTime":               time.Now().Add(30 * 24 * time.Hour).UnixMilli(),
+		"recurringIndicator":         true,
+		"frequency":                  4,
+		"dataAccessValidityDuration": 86400,
+		"consentPurpose": []map[string]interface{}{
+			{"name": ts.purposeName, "value": "email", "isUserApproved": true, "isMandatory": true},
+		},
+		"attributes": map[string]string{"channel": "web"},
+		"authorizations": []map[string]interface{}{
+			{
+				"userId":    userID,
+				"type":      "authorization",
+				"status":    "APPROVED",
+				"resources": map[string]interface{}{"accountIds": []string{"acc-1"}},
+			},
+		},
+	}
+}
+
+// createContractConsent creates a consent and registers it for cleanup
+func (ts *ContractAPITestSuite) createContractConsent(payload map[string]interface{}) (consentResponse, []byte) {
+	resp, body := ts.doRequest(http.MethodPost, "/consents", payload)
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created consentResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.createdConsentIDs = append(ts.createdConsentIDs, created.ID)
+	return created, body
+}
+
+// uniqueName returns a name that is unique to the current test run
+func uniqueName(prefix string) string {
+	return fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
+}
+
+// TestContract_CreateConsent pins the consent create response
+func (ts *ContractAPITestSuite) TestContract_CreateConsent() {
+	_, body := ts.createContractConsent(ts.consentPayload("accounts", uniqueName("contract-user")))
+
+	ts.assertShape("consent-create", body)
+}
+
+// TestContract_GetConsent pins the consent read response
+func (ts *ContractAPITestSuite) TestContract_GetConsent() {
+	created, _ := ts.createContractConsent(ts.consentPayload("accounts", uniqueName("contract-user")))
+
+	resp, body := ts.doRequest(http.MethodGet, "/consents/"+created.ID, nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("consent-get", body)
+}
+
+// TestContract_GetMinimalConsent pins empty collections as [] and {} rather than null, and the
+// omission of unset optional fields
+func (ts *ContractAPITestSuite) TestContract_GetMinimalConsent() {
+	created, createBody := ts.createContractConsent(map[string]interface{}{
+		"type":           "accounts",
+		"authorizations": []interface{}{},
+	})
+	ts.assertShape("consent-create-minimal", createBody)
+
+	resp, body := ts.doRequest(http.MethodGet, "/consents/"+created.ID, nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("consent-get-minimal", body)
+}
+
+// TestContract_ListConsents pins the consent search response
+func (ts *ContractAPITestSuite) TestContract_ListConsents() {
+	consentType := uniqueName("contract")
+	ts.createContractConsent(ts.consentPayload(consentType, uniqueName("contract-user")))
+
+	resp, body := ts.doRequest(http.MethodGet, "/consents?consentTypes="+consentType, nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("consent-list", body)
+}
+
+// TestContract_ListConsentsEmpty pins an empty search result
+func (ts *ContractAPITestSuite) TestContract_ListConsentsEmpty() {
+	resp, body := ts.doRequest(http.MethodGet, "/consents?consentTypes="+uniqueName("contract-none"), nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("consent-list-empty", body)
+}
+
+// TestContract_UpdateConsent pins the consent update response
+func (ts *ContractAPITestSuite) TestContract_UpdateConsent() {
+	userID := uniqueName("contract-user")
+	created, _ := ts.createContractConsent(ts.consentPayload("accounts", userID))
+
+	resp, body := ts.doRequest(http.MethodPut, "/consents/"+created.ID, ts.consentPayload("accounts", userID))
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("consent-update", body)
+}
+
+// TestContract_RevokeConsent pins the consent revoke response
+func (ts *ContractAPITestSuite) TestContract_RevokeConsent() {
+	created, _ := ts.createContractConsent(ts.consentPayload("accounts", uniqueName("contract-user")))
+
+	resp, body := ts.doRequest(http.MethodPut, "/consents/"+created.ID+"/revoke", map[string]string{
+		"actionBy":         "contract-user",
+		"revocationReason": "contract test",
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("consent-revoke", body)
+}
+
+// TestContract_DeleteConsent verifies the consent delete response has no body
+func (ts *ContractAPITestSuite) TestContract_DeleteConsent() {
+	created, _ := ts.createContractConsent(ts.consentPayload("accounts", uniqueName("contract-user")))
+
+	resp, body := ts.doRequest(http.MethodDelete, "/consents/"+created.ID, nil)
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+	ts.Empty(body)
+}
+
+// TestContract_AmendConsent pins the amendment and version history responses
+func (ts *ContractAPITestSuite) TestContract_AmendConsent() {
+	userID := uniqueName("contract-user")
+	created, _ := ts.createContractConsent(ts.consentPayload("accounts", userID))
+
+	amendment := ts.consentPayload("accounts", userID)
+	amendment["amendedBy"] = "contract-admin"
+	amendment["reason"] = "contract amendment"
+	resp, body := ts.doRequest(http.MethodPost, "/consents/"+created.ID+"/amendments", amendment)
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+	ts.assertShape("consent-amend", body)
+
+	resp, body = ts.doRequest(http.MethodGet, "/consents/"+created.ID+"/versions", nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.assertShape("consent-versions", body)
+
+	resp, body = ts.doRequest(http.MethodGet, "/consents/"+created.ID+"/versions/1", nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.assertShape("consent-version-previous", body)
+
+	resp, body = ts.doRequest(http.MethodGet, "/consents/"+created.ID+"/versions/2", nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.assertShape("consent-version-current", body)
+}
+
+// TestContract_ValidateConsent pins the validate response, which carries the consent without
+// modifiedResponse
+func (ts *ContractAPITestSuite) TestContract_ValidateConsent() {
+	userID := uniqueName("contract-user")
+	created, _ := ts.createContractConsent(ts.consentPayload("accounts", userID))
+
+	resp, body := ts.doRequest(http.MethodPost, "/consents/validate", map[string]string{
+		"consentId": created.ID,
+		"userId":    userID,
+		"clientId":  testClientID,
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+	ts.assertShape("consent-validate", body)
+
+	var validated struct {
+		ConsentInformation map[string]interface{} `json:"consentInformation"`
+	}
+	ts.Require().NoError(json.Unmarshal(body, &validated))
+	ts.NotContains(validated.ConsentInformation, "modifiedResponse")
+}
+
+// TestContract_ValidateUnknownConsent pins the validate response for a consent that does not exist
+func (ts *ContractAPITestSuite) TestContract_ValidateUnknownConsent() {
+	resp, body := ts.doRequest(http.MethodPost, "/consents/validate", map[string]string{
+		"consentId": "7f1c2a4e-0000-4000-8000-000000000000",
+		"userId":    "contract-user",
+		"clientId":  testClientID,
+	})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("consent-validate-invalid", body)
+}
+
+// TestContract_SearchConsentsByAttribute pins the attribute search response
+func (ts *ContractAPITestSuite) TestContract_SearchConsentsByAttribute() {
+	ts.createContractConsent(ts.consentPayload("accounts", uniqueName("contract-user")))
+
+	resp, body := ts.doRequest(http.MethodGet, "/consents/attributes?key=channel&value=web", nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("consent-attribute-search", body)
+}
+
+// TestContract_GetRelationship pins the user-client relationship response
+func (ts *ContractAPITestSuite) TestContract_GetRelationship() {
+	userID := uniqueName("contract-user")
+	ts.createContractConsent(ts.consentPayload("accounts", userID))
+
+	resp, body := ts.doRequest(http.MethodGet, "/relationships?userId="+userID+"&clientId="+testClientID, nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("relationship", body)
+}
+
+// TestContract_ListUserConsents pins the user consent listing response
+func (ts *ContractAPITestSuite) TestContract_ListUserConsents() {
+	userID := uniqueName("contract-user")
+	ts.createContractConsent(ts.consentPayload("accounts", userID))
+
+	resp, body := ts.doRequest(http.MethodGet, "/users/"+userID+"/consents", nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("user-consents", body)
+}
+
+// TestContract_ErrorResponse pins the RFC 7807 problem response
+func (ts *ContractAPITestSuite) TestContract_ErrorResponse() {
+	resp, body := ts.doRequest(http.MethodGet, "/consents/7f1c2a4e-0000-4000-8000-000000000000", nil)
+	ts.Require().Equal(http.StatusNotFound, resp.StatusCode, string(body))
+	ts.Equal("application/problem+json", resp.Header.Get("Content-Type"))
+
+	ts.assertShape("error", body)
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/wso2/consent-management-api/tests/integration/testutils"
+)
+
+const (
+	testOrgID    = "test-org-contract"
+	testClientID = "test-client-contract"
+)
+
+// update rewrites the golden files from the current responses instead of comparing against them.
+// Run with: go test ./contract -update
+var update = flag.Bool("update", false, "rewrite golden files with the current response shapes")
+
+// ContractAPITestSuite pins the JSON shape of every public endpoint's response against golden files
+// in testdata. Values are reduced to their JSON types, so only field names, types, null values and
+// empty-array vs null differences are compared.
+type ContractAPITestSuite struct {
+	suite.Suite
+	purposeName       string
+	purposeID         string
+	createdConsentIDs []string
+	createdPurposeIDs []string
+}
+
+// SetupSuite creates the consent purpose used by the contract consents
+func (ts *ContractAPITestSuite) SetupSuite() {
+	ts.T().Logf("=== Contract Test Suite Starting ===")
+	ts.purposeName = fmt.Sprintf("contract-purpose-%d", time.Now().UnixNano())
+
+	created := ts.createPurpose(ts.purposeName)
+	ts.purposeID = created.ID
+}
+
+// TearDownSuite deletes the consents and purposes created by the suite
+func (ts *ContractAPITestSuite) TearDownSuite() {
+	for _, consentID := range ts.createdConsentIDs {
+		resp, _ := ts.doRequest(http.MethodDelete, "/consents/"+consentID, nil)
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			ts.T().Logf("Warning: failed to delete consent %s: %d", consentID, resp.StatusCode)
+		}
+	}
+	for _, purposeID := range ts.createdPurposeIDs {
+		resp, _ := ts.doRequest(http.MethodDelete, "/consent-purposes/"+purposeID, nil)
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			ts.T().Logf("Warning: failed to delete purpose %s: %d", purposeID, resp.StatusCode)
+		}
+	}
+	ts.T().Logf("=== Contract Test Suite Complete ===")
+}
+
+// doRequest sends a request to the API with the contract org and client headers
+func (ts *ContractAPITestSuite) doRequest(method, path string, payload interface{}) (*http.Response, []byte) {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		ts.Require().NoError(err)
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	httpReq, err := http.NewRequest(method, testutils.TestServerURL+"/api/v1"+path, reqBody)
+	ts.Require().NoError(err)
+	if payload != nil {
+		httpReq.Header.Set(testutils.HeaderContentType, "application/json")
+	}
+	httpReq.Header.Set(testutils.HeaderOrgID, testOrgID)
+	httpReq.Header.Set(testutils.HeaderClientID, testClientID)
+
+	resp, err := testutils.GetHTTPClient().Do(httpReq)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	ts.Require().NoError(err)
+
+	return resp, body
+}
+
+// assertShape compares the shape of a JSON response body with the golden file testdata/<name>.json
+func (ts *ContractAPITestSuite) assertShape(name string, body []byte) {
+	var decoded interface{}
+	ts.Require().NoError(json.Unmarshal(body, &decoded), string(body))
+
+	actual, err := json.MarshalIndent(shapeOf(decoded), "", "  ")
+	ts.Require().NoError(err)
+	actual = append(actual, '\n')
+
+	goldenPath := filepath.Join("testdata", name+".json")
+	if *update {
+		ts.Require().NoError(os.WriteFile(goldenPath, actual, 0o644))
+		return
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	ts.Require().NoError(err, "missing golden file %s, run with -update to create it", goldenPath)
+	ts.Equal(string(expected), string(actual),
+		"response shape of %s changed; if intended, run with -update and review the golden diff", name)
+}
+
+// shapeOf reduces a decoded JSON value to its shape: scalars become their JSON type name, nulls
+// stay null, objects keep their keys and non-empty arrays are represented by their first element.
+func shapeOf(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		if len(v) == 0 {
+			return []interface{}{}
+		}
+		return []interface{}{shapeOf(v[0])}
+	case map[string]interface{}:
+		shape := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			shape[key] = shapeOf(field)
+		}
+		return shape
+	default:
+		return fmt.Sprintf("unknown:%T", v)
+	}
+}
+
+// TestContractAPITestSuite runs the test suite
+func TestContractAPITestSuite(t *testing.T) {
+	suite.Run(t, new(ContractAPITestSuite))
+}
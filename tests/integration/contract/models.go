@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package contract
+
+// purposeResponse holds the purpose fields the contract tests need from a purpose response
+type purposeResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// purposeCreateResponse represents the API response for creating purposes
+type purposeCreateResponse struct {
+	Data []purposeResponse `json:"data"`
+}
+
+// consentResponse holds the consent fields the contract tests need from a consent response
+type consentResponse struct {
+	ID             string `json:"id"`
+	Version        int    `json:"version"`
+	Authorizations []struct {
+		ID string `json:"id"`
+	} `json:"authorizations"`
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2024, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// purposePayload builds a purpose create or update request with every optional field set
+func (ts *ContractAPITestSuite) purposePayload(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"description": "Contract test purpose",
+		"type":        "string",
+		"attributes":  map[string]string{"resourcePath": "/accounts"},
+	}
+}
+
+// createPurpose creates a purpose and registers it for cleanup
+func (ts *ContractAPITestSuite) createPurpose(name string) purposeResponse {
+	resp, body := ts.doRequest(http.MethodPost, "/consent-purposes", []interface{}{ts.purposePayload(name)})
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created purposeCreateResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	ts.Require().Len(created.Data, 1)
+	ts.createdPurposeIDs = append(ts.createdPurposeIDs, created.Data[0].ID)
+	return created.Data[0]
+}
+
+// TestContract_CreatePurposes pins the purpose batch create response
+func (ts *ContractAPITestSuite) TestContract_CreatePurposes() {
+	name := fmt.Sprintf("contract-create-%d", time.Now().UnixNano())
+	resp, body := ts.doRequest(http.MethodPost, "/consent-purposes", []interface{}{ts.purposePayload(name)})
+	ts.Require().Equal(http.StatusCreated, resp.StatusCode, string(body))
+
+	var created purposeCreateResponse
+	ts.Require().NoError(json.Unmarshal(body, &created))
+	for _, purpose := range created.Data {
+		ts.createdPurposeIDs = append(ts.createdPurposeIDs, purpose.ID)
+	}
+
+	ts.assertShape("purpose-create", body)
+}
+
+// TestContract_GetPurpose pins the purpose read response
+func (ts *ContractAPITestSuite) TestContract_GetPurpose() {
+	resp, body := ts.doRequest(http.MethodGet, "/consent-purposes/"+ts.purposeID, nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("purpose-get", body)
+}
+
+// TestContract_ListPurposes pins the purpose list response
+func (ts *ContractAPITestSuite) TestContract_ListPurposes() {
+	resp, body := ts.doRequest(http.MethodGet, "/consent-purposes?name="+ts.purposeName, nil)
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("purpose-list", body)
+}
+
+// TestContract_UpdatePurpose pins the purpose update response
+func (ts *ContractAPITestSuite) TestContract_UpdatePurpose() {
+	created := ts.createPurpose(fmt.Sprintf("contract-update-%d", time.Now().UnixNano()))
+
+	resp, body := ts.doRequest(http.MethodPut, "/consent-purposes/"+created.ID, ts.purposePayload(created.Name))
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("purpose-update", body)
+}
+
+// TestContract_ValidatePurposes pins the purpose name validation response
+func (ts *ContractAPITestSuite) TestContract_ValidatePurposes() {
+	resp, body := ts.doRequest(http.MethodPost, "/consent-purposes/validate", []string{ts.purposeName})
+	ts.Require().Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	ts.assertShape("purpose-validate", body)
+}
+
+// TestContract_DeletePurpose verifies the purpose delete response has no body
+func (ts *ContractAPITestSuite) TestContract_DeletePurpose() {
+	created := ts.createPurpose(fmt.Sprintf("contract-delete-%d", time.Now().UnixNano()))
+
+	resp, body := ts.doRequest(http.MethodDelete, "/consent-purposes/"+created.ID, nil)
+	ts.Require().Equal(http.StatusNoContent, resp.StatusCode, string(body))
+	ts.Empty(body)
+}
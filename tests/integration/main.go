@@ -17,15 +17,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Step 2: Setup test database
-	err = testutils.SetupDatabase()
-	if err != nil {
-		fmt.Printf("Failed to setup database: %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Println("✓ Database initialized")
-
-	// Step 3: Start server
+	// Step 2: Start server. The server applies the schema migrations to the test database.
 	err = testutils.StartServer()
 	if err != nil {
 		fmt.Printf("Failed to start server: %v\n", err)
@@ -33,7 +25,7 @@ func main() {
 	}
 	defer testutils.StopServer()
 
-	// Step 4: Wait for server to be ready
+	// Step 3: Wait for server to be ready
 	time.Sleep(2 * time.Second) // Give server a moment to start
 	err = testutils.WaitForServer()
 	if err != nil {
@@ -42,7 +34,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Step 5: Run tests
+	// Step 4: Run tests
 	fmt.Println("\nRunning tests...")
 	err = runTests()
 	if err != nil {
@@ -59,6 +51,7 @@ func runTests() error {
 	packages := []string{
 		"./consentpurpose",
 		"./consent",
+		"./contract",
 	}
 
 	for _, pkg := range packages {
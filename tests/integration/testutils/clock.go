@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package testutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	clockEndpoint = TestServerURL + "/api/v1/admin/clock"
+
+	AdminUsername = "admin"
+	AdminPassword = "admin"
+)
+
+// ClockState represents the server clock returned by the admin clock API
+type ClockState struct {
+	Now          int64 `json:"now"`
+	Frozen       bool  `json:"frozen"`
+	OffsetMillis int64 `json:"offsetMillis"`
+}
+
+// FreezeServerTime stops the server clock at the given time
+func FreezeServerTime(t time.Time) (*ClockState, error) {
+	millis := t.UnixMilli()
+	return updateServerClock(map[string]int64{"time": millis})
+}
+
+// ShiftServerTime keeps the server clock running, offset from the real time by d
+func ShiftServerTime(d time.Duration) (*ClockState, error) {
+	return updateServerClock(map[string]int64{"offsetMillis": d.Milliseconds()})
+}
+
+// AdvanceServerTime moves the server clock forward by d
+func AdvanceServerTime(d time.Duration) (*ClockState, error) {
+	return updateServerClock(map[string]int64{"advanceMillis": d.Milliseconds()})
+}
+
+// ResetServerTime returns the server clock to the real system time.
+// Tests that change the clock should defer this call.
+func ResetServerTime() (*ClockState, error) {
+	return doClockRequest(http.MethodDelete, nil)
+}
+
+// GetServerTime returns the current server clock
+func GetServerTime() (*ClockState, error) {
+	return doClockRequest(http.MethodGet, nil)
+}
+
+func updateServerClock(body map[string]int64) (*ClockState, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return doClockRequest(http.MethodPut, payload)
+}
+
+func doClockRequest(method string, payload []byte) (*ClockState, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, clockEndpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(AdminUsername, AdminPassword)
+	if payload != nil {
+		req.Header.Set(HeaderContentType, "application/json")
+	}
+
+	resp, err := GetHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("clock request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var state ClockState
+	if err := json.Unmarshal(respBody, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
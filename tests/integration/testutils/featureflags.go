@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (https://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package testutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const featureFlagEndpoint = TestServerURL + "/api/v1/admin/feature-flags"
+
+// FeatureFlagState represents a feature flag returned by the admin feature flag API
+type FeatureFlagState struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Source  string `json:"source"`
+}
+
+// SetFeatureFlag overrides a feature flag for an organization.
+// Tests that set a flag should defer ClearFeatureFlag.
+func SetFeatureFlag(orgID, flag string, enabled bool) (*FeatureFlagState, error) {
+	payload, err := json.Marshal(map[string]bool{"enabled": enabled})
+	if err != nil {
+		return nil, err
+	}
+	return doFeatureFlagRequest(http.MethodPut, orgID, flag, payload)
+}
+
+// ClearFeatureFlag removes the override of a feature flag for an organization
+func ClearFeatureFlag(orgID, flag string) (*FeatureFlagState, error) {
+	return doFeatureFlagRequest(http.MethodDelete, orgID, flag, nil)
+}
+
+func doFeatureFlagRequest(method, orgID, flag string, payload []byte) (*FeatureFlagState, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	endpoint := featureFlagEndpoint + "/" + url.PathEscape(flag) + "?orgId=" + url.QueryEscape(orgID)
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(AdminUsername, AdminPassword)
+	if payload != nil {
+		req.Header.Set(HeaderContentType, "application/json")
+	}
+
+	resp, err := GetHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feature flag request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var state FeatureFlagState
+	if err := json.Unmarshal(respBody, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
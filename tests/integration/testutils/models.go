@@ -18,10 +18,22 @@
 
 package testutils
 
-// ErrorResponse represents API error responses (shared across all tests)
+// ErrorResponse represents RFC 7807 problem responses of the API (shared across all tests)
 type ErrorResponse struct {
-	Code        string `json:"code"`
-	Message     string `json:"message"`
-	Description string `json:"description,omitempty"`
-	TraceID     string `json:"traceId"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+	TraceID  string `json:"traceId"`
+
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+// FieldViolation represents one invalid request field of a validation error
+type FieldViolation struct {
+	Path       string `json:"path"`
+	Constraint string `json:"constraint"`
+	Message    string `json:"message"`
 }
@@ -30,18 +30,16 @@ func BuildServer() error {
 	return nil
 }
 
-// SetupDatabase runs database migration scripts
-func SetupDatabase() error {
-	fmt.Println("Setting up test database...")
-	// For now, we assume the database is already set up
-	// In production, this would run migration scripts
-	return nil
-}
-
-// StartServer starts the consent-server in background
+// StartServer starts the consent-server in background. With TEST_DB=sqlite the server runs in
+// development mode on an in-memory SQLite database instead of the configured MySQL database.
 func StartServer() error {
 	fmt.Println("Starting consent server...")
-	cmd := exec.Command(ServerBinary)
+	var args []string
+	if os.Getenv("TEST_DB") == "sqlite" {
+		fmt.Println("Using an in-memory SQLite database")
+		args = append(args, "--dev")
+	}
+	cmd := exec.Command(ServerBinary, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 